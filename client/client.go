@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client reads dashboard tabs from a TestGrid API server.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+)
+
+// ErrNotFound means the server has no such dashboard tab.
+var ErrNotFound = errors.New("not found")
+
+// Client reads from a TestGrid API server.
+//
+// Responses are cached by ETag and revalidated on each request. Requests
+// which fail with a connection error, 429 or 5xx are retried with
+// exponential backoff.
+type Client struct {
+	// URL of the server, such as https://testgrid.example.com
+	URL string
+	// HTTPClient sends requests, defaulting to http.DefaultClient.
+	HTTPClient *http.Client
+	// Retries is the number of times to retry a failed request.
+	Retries int
+	// Backoff is the delay before the first retry, doubling after each attempt.
+	Backoff time.Duration
+
+	lock  sync.Mutex
+	cache map[string]cached
+}
+
+type cached struct {
+	etag        string
+	contentType string
+	body        []byte
+}
+
+// New returns a client for the server at the URL.
+func New(url string) *Client {
+	return &Client{
+		URL:     strings.TrimSuffix(url, "/"),
+		Retries: 3,
+		Backoff: time.Second,
+	}
+}
+
+// TabSummary returns the summary of the dashboard tab.
+func (c *Client) TabSummary(ctx context.Context, dashboard, tab string) (*summarypb.DashboardTabSummary, error) {
+	var sum summarypb.DashboardTabSummary
+	if err := c.get(ctx, api.TabPath(dashboard, tab, api.SummaryResource), &sum); err != nil {
+		return nil, err
+	}
+	return &sum, nil
+}
+
+// Grid returns the most recent columns of the dashboard tab.
+//
+// The server chooses the number of columns when columns is zero.
+func (c *Client) Grid(ctx context.Context, dashboard, tab string, columns int) (*statepb.Grid, error) {
+	path := api.TabPath(dashboard, tab, api.GridResource)
+	if columns > 0 {
+		path += "?columns=" + strconv.Itoa(columns)
+	}
+	var grid statepb.Grid
+	if err := c.get(ctx, path, &grid); err != nil {
+		return nil, err
+	}
+	return &grid, nil
+}
+
+// Dashboards lists the dashboards served by the API and their tabs.
+func (c *Client) Dashboards(ctx context.Context) (*responsepb.DashboardList, error) {
+	var list responsepb.DashboardList
+	if err := c.get(ctx, api.DashboardsPrefix, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// MessageHistory returns the distinct failure messages of a row in the dashboard tab.
+func (c *Client) MessageHistory(ctx context.Context, dashboard, tab, row string) (*responsepb.MessageHistory, error) {
+	var history responsepb.MessageHistory
+	if err := c.get(ctx, api.RowPath(dashboard, tab, row, api.MessagesResource), &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// ListFailingTests returns the tests currently failing in the dashboard tab.
+func (c *Client) ListFailingTests(ctx context.Context, dashboard, tab string) ([]*summarypb.FailingTestSummary, error) {
+	sum, err := c.TabSummary(ctx, dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	return sum.FailingTestSummaries, nil
+}
+
+// retryable is an error worth retrying.
+type retryable struct {
+	error
+}
+
+func (c *Client) get(ctx context.Context, path string, msg proto.Message) error {
+	var err error
+	delay := c.Backoff
+	for attempt := 0; ; attempt++ {
+		err = c.fetch(ctx, path, msg)
+		var retry retryable
+		if err == nil || !errors.As(err, &retry) || attempt >= c.Retries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", path, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Client) fetch(ctx context.Context, path string, msg proto.Message) error {
+	req, err := http.NewRequest(http.MethodGet, c.URL+path, nil)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", api.ContentTypeProto)
+
+	c.lock.Lock()
+	prev, ok := c.cache[path]
+	c.lock.Unlock()
+	if ok {
+		req.Header.Set("If-None-Match", prev.etag)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return retryable{err}
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return retryable{fmt.Errorf("read: %w", err)}
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusNotModified && ok:
+		return api.Unmarshal(prev.contentType, prev.body, msg)
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusTooManyRequests, code >= 500:
+		return retryable{fmt.Errorf("server returned %s", resp.Status)}
+	case code != http.StatusOK:
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if err := api.Unmarshal(contentType, body, msg); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.lock.Lock()
+		if c.cache == nil {
+			c.cache = map[string]cached{}
+		}
+		c.cache[path] = cached{etag: etag, contentType: contentType, body: body}
+		c.lock.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+// flakyServer serves tabs, failing the first requests.
+type flakyServer struct {
+	api.Server
+	lock     sync.Mutex
+	failures int
+	codes    []int
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	if s.failures > 0 {
+		s.failures--
+		s.codes = append(s.codes, http.StatusServiceUnavailable)
+		s.lock.Unlock()
+		http.Error(w, "try again", http.StatusServiceUnavailable)
+		return
+	}
+	s.lock.Unlock()
+	rec := httptest.NewRecorder()
+	s.Server.ServeHTTP(rec, r)
+	s.lock.Lock()
+	s.codes = append(s.codes, rec.Code)
+	s.lock.Unlock()
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+func newServer(t *testing.T, failures int) (*flakyServer, *summarypb.DashboardTabSummary) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	tab := &summarypb.DashboardTabSummary{
+		DashboardName:    "dash",
+		DashboardTabName: "some tab",
+		OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+		FailingTestSummaries: []*summarypb.FailingTestSummary{
+			{DisplayName: "foo", FailCount: 3},
+		},
+	}
+	buf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{tab},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return &flakyServer{
+		Server: api.Server{
+			Reader: tabs.Reader{
+				Client: fake.Opener{*summaryPath: {Data: string(buf)}},
+				Config: &configpb.Configuration{
+					Dashboards: []*configpb.Dashboard{
+						{
+							Name: "dash",
+							DashboardTab: []*configpb.DashboardTab{
+								{Name: "some tab", TestGroupName: "group"},
+							},
+						},
+					},
+				},
+				ConfigPath:    *configPath,
+				SummaryPrefix: "summary",
+			},
+		},
+		failures: failures,
+	}, tab
+}
+
+func TestListFailingTests(t *testing.T) {
+	cases := []struct {
+		name      string
+		dashboard string
+		failures  int
+		retries   int
+		err       error
+		codes     []int
+	}{
+		{
+			name:      "basically works",
+			dashboard: "dash",
+			codes:     []int{http.StatusOK, http.StatusNotModified},
+		},
+		{
+			name:      "retry server errors",
+			dashboard: "dash",
+			failures:  2,
+			retries:   2,
+			codes:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK, http.StatusNotModified},
+		},
+		{
+			name:      "give up after retries",
+			dashboard: "dash",
+			failures:  3,
+			retries:   1,
+			codes:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		},
+		{
+			name:      "not found",
+			dashboard: "missing",
+			retries:   2,
+			err:       ErrNotFound,
+			codes:     []int{http.StatusNotFound},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, tab := newServer(t, tc.failures)
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+			c := New(ts.URL + "/")
+			c.Retries = tc.retries
+			c.Backoff = 0
+
+			ctx := context.Background()
+			got, err := c.ListFailingTests(ctx, tc.dashboard, "some tab")
+			switch {
+			case err != nil:
+				if tc.err != nil && !errors.Is(err, tc.err) {
+					t.Errorf("ListFailingTests() got error %v, want %v", err, tc.err)
+				}
+				if tc.err == nil && tc.failures <= tc.retries {
+					t.Errorf("ListFailingTests() got unexpected error: %v", err)
+				}
+			case tc.err != nil || tc.failures > tc.retries:
+				t.Error("ListFailingTests() failed to return an error")
+			default:
+				if diff := cmp.Diff(tab.FailingTestSummaries, got, protocmp.Transform()); diff != "" {
+					t.Errorf("ListFailingTests() got unexpected diff (-want +got):\n%s", diff)
+				}
+				// Read again from the cache.
+				cachedSum, err := c.TabSummary(ctx, tc.dashboard, "some tab")
+				if err != nil {
+					t.Fatalf("TabSummary() got unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(tab, cachedSum, protocmp.Transform()); diff != "" {
+					t.Errorf("TabSummary() got unexpected cached diff (-want +got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.codes, server.codes); diff != "" {
+				t.Errorf("server got unexpected response codes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDashboards(t *testing.T) {
+	server, _ := newServer(t, 0)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	c := New(ts.URL)
+	c.Backoff = 0
+
+	got, err := c.Dashboards(context.Background())
+	if err != nil {
+		t.Fatalf("Dashboards() got unexpected error: %v", err)
+	}
+	want := &responsepb.DashboardList{
+		Dashboards: []*responsepb.DashboardListing{{Name: "dash", Tabs: []string{"some tab"}}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Dashboards() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/ui"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// members holds the --federate flag values.
+type members []api.Member
+
+func (m *members) String() string {
+	var parts []string
+	for _, member := range *m {
+		parts = append(parts, member.Name+"="+member.URL)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m *members) Set(value string) error {
+	member, err := api.ParseMember(value)
+	if err != nil {
+		return err
+	}
+	*m = append(*m, *member)
+	return nil
+}
+
+type options struct {
+	listen            string
+	config            gcs.Path // gcs://path/to/config/proto
+	creds             string
+	gridPathPrefix    string
+	summaryPathPrefix string
+	tabPathPrefix     string
+	concurrency       int
+	issues            bool
+	notes             bool
+	columnEdits       bool
+	alerts            bool
+	pullPrefix        gcs.Path
+	pullMaxBuilds     int
+	olderMaxBuilds    int
+	costReport        gcs.Path
+	federate          members
+	refresh           time.Duration
+	cacheTTL          time.Duration
+	cacheEntries      int
+	ui                bool
+
+	iapAudience    string
+	oidcAudience   string
+	oidcIssuers    string
+	tlsCert        string
+	tlsKey         string
+	clientCA       string
+	readers        string
+	writers        string
+	allowAnonymous bool
+
+	rateLimit      float64
+	rateBurst      int
+	maxInFlight    int
+	maxDecodes     int
+	trustForwarded bool
+
+	grpcListen string
+	grpc       api.GRPCOptions
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if (o.tlsCert == "") != (o.tlsKey == "") {
+		return errors.New("--tls-cert and --tls-key require each other")
+	}
+	if o.clientCA != "" && o.tlsCert == "" {
+		return errors.New("--client-ca requires --tls-cert")
+	}
+	if len(o.federate) > 0 {
+		if o.config.String() != "" {
+			return errors.New("--config and --federate are mutually exclusive")
+		}
+		return nil
+	}
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.listen, "listen", ":8080", "Serve the API on this address")
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
+	flag.StringVar(&o.summaryPathPrefix, "summary-path", "", "Read summaries under this GCS path.")
+	flag.StringVar(&o.tabPathPrefix, "tab-path", "", "Read tab states written by the tabulator under this GCS path if set.")
+	flag.IntVar(&o.concurrency, "concurrency", 4, "Read this many tabs concurrently")
+	flag.BoolVar(&o.issues, "issues", false, "Allow reading and, for authenticated requests, changing the issues associated with rows if set")
+	flag.BoolVar(&o.columnEdits, "column-edits", false, "Allow reading and, for authenticated requests, requesting deletion or reprocessing of columns if set")
+	flag.BoolVar(&o.alerts, "alerts", false, "Allow authenticated requests to snooze and acknowledge the alerts of dashboard summaries if set")
+	flag.BoolVar(&o.notes, "notes", false, "Allow reading and, for authenticated requests, changing the triage notes about rows and cells if set")
+	flag.Var(&o.pullPrefix, "pull-prefix", "Serve the presubmit results of pull requests under this gs://bucket/pr-logs/pull/ path if set")
+	flag.IntVar(&o.pullMaxBuilds, "pull-max-builds", 100, "Read at most this many recent runs of a pull request (unlimited if zero)")
+	flag.IntVar(&o.olderMaxBuilds, "older-max-builds", 0, "Serve at most this many builds older than a tab's columns per request if positive (disabled if zero)")
+	flag.Var(&o.costReport, "cost-report", "Serve the storage cost metrics of the updater's gs://path/to/report at /metrics if set")
+	flag.Var(&o.federate, "federate", "Serve the dashboards of this name=url[,prefix] API server instead of --config (repeatable)")
+	flag.DurationVar(&o.refresh, "federation-refresh", time.Minute, "List the dashboards of federated servers at most this often")
+	flag.DurationVar(&o.cacheTTL, "cache-ttl", 0, "Cache decoded grids and summaries for this long and allow warming the cache if set")
+	flag.IntVar(&o.cacheEntries, "cache-entries", 1000, "Cache at most this many grids and summaries (unlimited if zero)")
+	flag.BoolVar(&o.ui, "ui", false, "Serve a web UI rendering dashboards, tabs and grids at /ui/ if set")
+
+	flag.StringVar(&o.iapAudience, "iap-audience", "", "Authenticate Identity-Aware Proxy requests for this audience if set")
+	flag.StringVar(&o.oidcAudience, "oidc-audience", "", "Authenticate bearer ID tokens for this audience if set")
+	flag.StringVar(&o.oidcIssuers, "oidc-issuers", "https://accounts.google.com", "Comma-separated issuers of trusted ID tokens")
+	flag.StringVar(&o.tlsCert, "tls-cert", "", "Serve TLS with this /path/to/cert.pem if set")
+	flag.StringVar(&o.tlsKey, "tls-key", "", "Serve TLS with this /path/to/key.pem")
+	flag.StringVar(&o.clientCA, "client-ca", "", "Authenticate client certificates signed by this /path/to/ca.pem if set")
+	flag.StringVar(&o.readers, "readers", "", "Comma-separated emails, subjects, domain:example.com or * allowed to read if set")
+	flag.StringVar(&o.writers, "writers", "", "Comma-separated emails, subjects, domain:example.com or * allowed to read and write")
+	flag.BoolVar(&o.allowAnonymous, "allow-anonymous", false, "Serve requests without credentials when authentication is configured")
+
+	flag.Float64Var(&o.rateLimit, "rate-limit", 0, "Allow each client this many requests per second (unlimited if zero)")
+	flag.IntVar(&o.rateBurst, "rate-burst", 10, "Allow each client this many requests at once")
+	flag.IntVar(&o.maxInFlight, "max-in-flight", 0, "Reject requests when serving this many (unlimited if zero)")
+	flag.IntVar(&o.maxDecodes, "max-decodes", 0, "Download and decode at most this many grids and summaries at once across every request (unlimited if zero)")
+	flag.BoolVar(&o.trustForwarded, "trust-forwarded", false, "Identify clients by X-Forwarded-For, only set behind a proxy which overwrites it")
+
+	flag.StringVar(&o.grpcListen, "grpc-listen", "", "Serve gRPC health and reflection on this address if set")
+	flag.DurationVar(&o.grpc.KeepaliveTime, "grpc-keepalive-time", 2*time.Minute, "Ping idle gRPC clients after this long")
+	flag.DurationVar(&o.grpc.KeepaliveTimeout, "grpc-keepalive-timeout", 20*time.Second, "Close gRPC connections which do not answer a ping within this long")
+	flag.DurationVar(&o.grpc.MaxConnectionAge, "grpc-max-connection-age", 0, "Close gRPC connections after this long so clients rebalance (infinite if zero)")
+	flag.DurationVar(&o.grpc.MinPingInterval, "grpc-min-ping-interval", 30*time.Second, "Close gRPC connections which ping more often than this")
+	flag.BoolVar(&o.grpc.PermitIdlePings, "grpc-permit-idle-pings", true, "Allow gRPC clients to ping without active streams")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	var handler http.Handler
+	if len(opt.federate) > 0 {
+		logrus.WithField("members", opt.federate.String()).Info("Federating")
+		handler = &api.Federation{
+			Members: opt.federate,
+			Refresh: opt.refresh,
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+		if err != nil {
+			logrus.Fatalf("Failed to read storage client: %v", err)
+		}
+		client := gcs.NewClient(storageClient)
+		cfg, err := config.ReadGCS(ctx, client, opt.config)
+		if err != nil {
+			logrus.Fatalf("Failed to read config: %v", err)
+		}
+		server := api.Server{
+			Reader: tabs.Reader{
+				Client:        client,
+				Config:        cfg,
+				ConfigPath:    opt.config,
+				GridPrefix:    opt.gridPathPrefix,
+				SummaryPrefix: opt.summaryPathPrefix,
+				TabPrefix:     opt.tabPathPrefix,
+				Concurrency:   opt.concurrency,
+			},
+		}
+		if opt.maxDecodes > 0 {
+			server.Reader.Decodes = semaphore.NewWeighted(int64(opt.maxDecodes))
+		}
+		if opt.cacheTTL > 0 {
+			server.Reader.Cache = &tabs.Cache{TTL: opt.cacheTTL, MaxEntries: opt.cacheEntries}
+		}
+		if opt.issues {
+			server.Issues = client
+		}
+		if opt.notes {
+			server.Notes = client
+		}
+		if opt.columnEdits {
+			server.ColumnEdits = client
+		}
+		if opt.alerts {
+			server.Alerts = client
+		}
+		if opt.costReport.String() != "" {
+			server.CostReport = &opt.costReport
+		}
+		if opt.pullPrefix.String() != "" {
+			server.Pulls = &tabs.PullReader{
+				Client:      client,
+				Prefix:      opt.pullPrefix,
+				MaxBuilds:   opt.pullMaxBuilds,
+				Concurrency: opt.concurrency,
+			}
+		}
+		if opt.olderMaxBuilds > 0 {
+			server.Older = &tabs.OlderReader{
+				Client:      client,
+				MaxBuilds:   opt.olderMaxBuilds,
+				Concurrency: opt.concurrency,
+			}
+		}
+		handler = &server
+	}
+	if opt.ui {
+		handler = ui.Wrap(handler)
+	}
+
+	if opt.rateLimit > 0 || opt.maxInFlight > 0 {
+		limiter := api.Limiter{
+			Rate:           opt.rateLimit,
+			Burst:          opt.rateBurst,
+			MaxInFlight:    opt.maxInFlight,
+			TrustForwarded: opt.trustForwarded,
+		}
+		handler = limiter.Wrap(handler)
+	}
+	if auth := opt.auth(); auth != nil {
+		handler = auth.Wrap(handler)
+	}
+
+	if opt.grpcListen != "" {
+		lis, err := net.Listen("tcp", opt.grpcListen)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to listen for gRPC")
+		}
+		grpcServer, _ := api.NewGRPCServer(opt.grpc)
+		logrus.WithField("listen", opt.grpcListen).Info("Serving gRPC")
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				logrus.WithError(err).Fatal("Failed to serve gRPC")
+			}
+		}()
+	}
+
+	srv := http.Server{Addr: opt.listen, Handler: handler}
+	if opt.clientCA != "" {
+		cfg, err := clientTLS(opt.clientCA)
+		if err != nil {
+			logrus.Fatalf("Failed to read client CA: %v", err)
+		}
+		srv.TLSConfig = cfg
+	}
+	logrus.WithField("listen", opt.listen).Info("Serving")
+	var err error
+	if opt.tlsCert != "" {
+		err = srv.ListenAndServeTLS(opt.tlsCert, opt.tlsKey)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to serve")
+	}
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// auth returns the configured authentication, or nil when requests are not authenticated.
+func (o *options) auth() *api.Auth {
+	var auth api.Auth
+	if o.iapAudience != "" {
+		auth.Authenticators = append(auth.Authenticators, api.IAP{Audience: o.iapAudience})
+	}
+	if o.oidcAudience != "" {
+		auth.Authenticators = append(auth.Authenticators, &api.OIDC{Audience: o.oidcAudience, Issuers: split(o.oidcIssuers)})
+	}
+	if o.clientCA != "" {
+		auth.Authenticators = append(auth.Authenticators, api.ClientCert{})
+	}
+	if o.readers != "" || o.writers != "" {
+		auth.Authorize = api.RBAC{Readers: split(o.readers), Writers: split(o.writers)}.Authorize
+	}
+	if len(auth.Authenticators) == 0 && auth.Authorize == nil {
+		return nil
+	}
+	auth.AllowAnonymous = o.allowAnonymous
+	return &auth
+}
+
+// clientTLS verifies client certificates signed by the CA, when clients send one.
+func clientTLS(caPath string) (*tls.Config, error) {
+	buf, err := ioutil.ReadFile(caPath)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(buf) {
+		return nil, fmt.Errorf("no certificates in %s", caPath)
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestValidate(t *testing.T) {
+	config, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	cases := []struct {
+		name string
+		opt  options
+		err  bool
+	}{
+		{
+			name: "config is required",
+			err:  true,
+		},
+		{
+			name: "basically works",
+			opt:  options{config: *config},
+		},
+		{
+			name: "federate",
+			opt:  options{federate: members{{Name: "other", URL: "https://other.example.com"}}},
+		},
+		{
+			name: "config and federate are exclusive",
+			opt:  options{config: *config, federate: members{{Name: "other", URL: "https://other.example.com"}}},
+			err:  true,
+		},
+		{
+			name: "tls",
+			opt:  options{config: *config, tlsCert: "cert.pem", tlsKey: "key.pem"},
+		},
+		{
+			name: "tls cert requires key",
+			opt:  options{config: *config, tlsCert: "cert.pem"},
+			err:  true,
+		},
+		{
+			name: "tls key requires cert",
+			opt:  options{config: *config, tlsKey: "key.pem"},
+			err:  true,
+		},
+		{
+			name: "client ca",
+			opt:  options{config: *config, tlsCert: "cert.pem", tlsKey: "key.pem", clientCA: "ca.pem"},
+		},
+		{
+			name: "client ca requires tls",
+			opt:  options{config: *config, clientCA: "ca.pem"},
+			err:  true,
+		},
+		{
+			name: "client ca requires tls when federating",
+			opt:  options{federate: members{{Name: "other", URL: "https://other.example.com"}}, clientCA: "ca.pem"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opt.validate()
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("validate() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("validate() failed to return an error")
+			}
+		})
+	}
+}
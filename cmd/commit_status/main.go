@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/commitstatus"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type options struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	creds          string
+	confirm        bool
+	dashboard      string
+	wait           time.Duration
+	gridPathPrefix string
+	tokenPath      string
+	githubURL      string
+	testgridURL    string
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.confirm && o.tokenPath == "" {
+		return errors.New("--confirm requires --github-token-path")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Post commit statuses if set")
+	flag.StringVar(&o.dashboard, "dashboard", "", "Only post the statuses of the named dashboard if set")
+	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "grid", "Read grid states under this GCS path.")
+	flag.StringVar(&o.tokenPath, "github-token-path", "", "/path/to/github/token with permission to create commit statuses")
+	flag.StringVar(&o.githubURL, "github-url", "https://api.github.com", "Base URL of the GitHub API")
+	flag.StringVar(&o.testgridURL, "testgrid-url", "https://testgrid.k8s.io", "Link statuses to the tab on this frontend")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not post statuses")
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	var token string
+	if opt.tokenPath != "" {
+		buf, err := ioutil.ReadFile(opt.tokenPath)
+		if err != nil {
+			logrus.Fatalf("Failed to read --github-token-path: %v", err)
+		}
+		token = strings.TrimSpace(string(buf))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+
+	updater := commitstatus.Updater{
+		Client:      gcs.NewClient(storageClient),
+		ConfigPath:  opt.config,
+		GridPrefix:  opt.gridPathPrefix,
+		TestGridURL: opt.testgridURL,
+		Poster: &commitstatus.Client{
+			HTTP:    &http.Client{Timeout: time.Minute},
+			BaseURL: opt.githubURL,
+			Token:   token,
+		},
+	}
+
+	updateOnce := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+		return updater.Update(ctx, opt.dashboard, time.Now(), opt.confirm)
+	}
+
+	if err := updateOnce(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to post commit statuses")
+	}
+	if opt.wait == 0 {
+		return
+	}
+	timer := time.NewTimer(opt.wait)
+	defer timer.Stop()
+	for range timer.C {
+		timer.Reset(opt.wait)
+		if err := updateOnce(ctx); err != nil {
+			logrus.WithError(err).Error("Failed to post commit statuses")
+		}
+		logrus.WithField("wait", opt.wait).Info("Sleeping")
+	}
+}
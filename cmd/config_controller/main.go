@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/crd"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type options struct {
+	output    gcs.Path
+	creds     string
+	confirm   bool
+	wait      time.Duration
+	namespace string
+	apiServer string
+	tokenFile string
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if o.output.String() == "" {
+		return errors.New("empty --output")
+	}
+	if o.tokenFile != "" && o.apiServer == "" {
+		return errors.New("--token-file requires --api-server")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.output, "output", "Write the assembled configuration to this gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
+	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.StringVar(&o.namespace, "namespace", "", "Only assemble resources in this namespace if set")
+	flag.StringVar(&o.apiServer, "api-server", "", "URL of the Kubernetes API server (use the in-cluster service account if empty)")
+	flag.StringVar(&o.tokenFile, "token-file", "", "/path/to/bearer/token for --api-server")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func apiClient(opt options) (*crd.APIClient, error) {
+	if opt.apiServer == "" {
+		return crd.InClusterClient()
+	}
+	client := crd.APIClient{Host: opt.apiServer}
+	if opt.tokenFile != "" {
+		buf, err := ioutil.ReadFile(opt.tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		client.Token = strings.TrimSpace(string(buf))
+	}
+	return &client, nil
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	lister, err := apiClient(opt)
+	if err != nil {
+		logrus.Fatalf("Failed to create kubernetes client: %v", err)
+	}
+	syncer := crd.Syncer{
+		Lister:    lister,
+		Client:    gcs.NewClient(storageClient),
+		Namespace: opt.namespace,
+		Target:    opt.output,
+	}
+
+	updateOnce := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		defer cancel()
+		return syncer.Sync(ctx, opt.confirm)
+	}
+
+	if err := updateOnce(ctx); err != nil {
+		logrus.WithError(err).Error("Failed update")
+	}
+	if opt.wait == 0 {
+		return
+	}
+	timer := time.NewTimer(opt.wait)
+	defer timer.Stop()
+	for range timer.C {
+		timer.Reset(opt.wait)
+		if err := updateOnce(ctx); err != nil {
+			logrus.WithError(err).Error("Failed update")
+		}
+		logrus.WithField("wait", opt.wait).Info("Sleeping")
+	}
+}
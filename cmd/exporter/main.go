@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/exporter"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type options struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	output         gcs.Path
+	creds          string
+	confirm        bool
+	dashboard      string
+	format         string
+	days           int
+	wait           time.Duration
+	gridPathPrefix string
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.output.String() == "" {
+		return errors.New("empty --output")
+	}
+	if o.format != "parquet" {
+		return fmt.Errorf("unsupported --format=%q", o.format)
+	}
+	if o.days < 1 {
+		return fmt.Errorf("--days must be positive, got %d", o.days)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.Var(&o.output, "output", "Write exports under this GCS path, such as gs://bucket/exports")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
+	flag.StringVar(&o.dashboard, "dashboard", "", "Only export named dashboard if set")
+	flag.StringVar(&o.format, "format", "parquet", "Export format (only parquet is supported)")
+	flag.IntVar(&o.days, "days", 2, "Export this many of the most recent days (UTC)")
+	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+
+	exportOnce := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+		since := time.Now().AddDate(0, 0, 1-opt.days)
+		return exporter.ExportParquet(ctx, client, opt.config, opt.gridPathPrefix, opt.output, opt.dashboard, since, opt.confirm)
+	}
+
+	if err := exportOnce(ctx); err != nil {
+		logrus.WithError(err).Error("Failed export")
+	}
+	if opt.wait == 0 {
+		return
+	}
+	timer := time.NewTimer(opt.wait)
+	defer timer.Stop()
+	for range timer.C {
+		timer.Reset(opt.wait)
+		if err := exportOnce(ctx); err != nil {
+			logrus.WithError(err).Error("Failed export")
+		}
+		logrus.WithField("wait", opt.wait).Info("Sleeping")
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/janitor"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type options struct {
+	config            gcs.Path // gcs://path/to/config/proto
+	creds             string
+	confirm           bool
+	wait              time.Duration
+	grace             time.Duration
+	gridPathPrefix    string
+	summaryPathPrefix string
+	tabPathPrefix     string
+	archivePathPrefix string
+	trashPathPrefix   string
+	retention         time.Duration
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.gridPathPrefix == "" && o.summaryPathPrefix == "" && o.tabPathPrefix == "" {
+		return errors.New("set at least one of --grid-path, --summary-path or --tab-path")
+	}
+	if o.grace < 24*time.Hour {
+		return errors.New("--grace must be at least 24h")
+	}
+	if o.trashPathPrefix != "" && o.retention < 24*time.Hour {
+		return errors.New("--retention must be at least 24h")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Delete orphaned state if set, otherwise only report it")
+	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.DurationVar(&o.grace, "grace", 30*24*time.Hour, "Only collect state which has not been updated for this long")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Collect grid states under this GCS path if set.")
+	flag.StringVar(&o.summaryPathPrefix, "summary-path", "", "Collect summaries under this GCS path if set.")
+	flag.StringVar(&o.tabPathPrefix, "tab-path", "", "Collect tab states under this GCS path if set.")
+	flag.StringVar(&o.archivePathPrefix, "archive-path", "", "Copy orphaned state under this GCS path before deleting it if set.")
+	flag.StringVar(&o.trashPathPrefix, "trash-path", "", "Move orphaned state under this GCS path instead of deleting it if set, from where tgctl restore can return it.")
+	flag.DurationVar(&o.retention, "retention", 30*24*time.Hour, "Delete state under --trash-path once it has been there for this long")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not delete from gcs")
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+
+	janitorOpt := janitor.Options{
+		GridPrefix:    opt.gridPathPrefix,
+		SummaryPrefix: opt.summaryPathPrefix,
+		TabPrefix:     opt.tabPathPrefix,
+		ArchivePrefix: opt.archivePathPrefix,
+		TrashPrefix:   opt.trashPathPrefix,
+		Grace:         opt.grace,
+		Retention:     opt.retention,
+		Confirm:       opt.confirm,
+	}
+	updateOnce := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+		_, err := janitor.Collect(ctx, client, opt.config, janitorOpt, time.Now())
+		return err
+	}
+
+	if err := updateOnce(ctx); err != nil {
+		logrus.WithError(err).Error("Failed collection")
+	}
+	if opt.wait == 0 {
+		return
+	}
+	timer := time.NewTimer(opt.wait)
+	defer timer.Stop()
+	for range timer.C {
+		timer.Reset(opt.wait)
+		if err := updateOnce(ctx); err != nil {
+			logrus.WithError(err).Error("Failed collection")
+		}
+		logrus.WithField("wait", opt.wait).Info("Sleeping")
+	}
+}
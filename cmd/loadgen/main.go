@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/loadgen"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type options struct {
+	output      gcs.Path // gs://path/to/synthesized/bucket/
+	creds       string
+	confirm     bool
+	benchmark   bool
+	concurrency int
+	gen         loadgen.Options
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if !o.benchmark && o.output.String() == "" {
+		return errors.New("empty --output without --benchmark")
+	}
+	if o.benchmark && o.output.String() != "" {
+		return errors.New("--benchmark runs in memory, drop --output")
+	}
+	if o.concurrency == 0 {
+		o.concurrency = 4 * runtime.NumCPU()
+	}
+	if err := o.gen.Validate(); err != nil {
+		return fmt.Errorf("generator: %w", err)
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.output, "output", "gs://path/to/write/config/and/logs/under/")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
+	flag.BoolVar(&o.benchmark, "benchmark", false, "Time an updater, tabulator and summarizer cycle over an in-memory bucket")
+	flag.IntVar(&o.concurrency, "concurrency", 0, "Manually define the number of groups to concurrently process if non-zero")
+	flag.IntVar(&o.gen.Groups, "groups", 10, "Number of test groups to synthesize")
+	flag.IntVar(&o.gen.Builds, "builds", 50, "Number of builds of each test group")
+	flag.IntVar(&o.gen.Tests, "tests", 100, "Number of test cases of each build")
+	flag.IntVar(&o.gen.TabsPerDashboard, "tabs-per-dashboard", 10, "Number of tabs, one per group, of each dashboard")
+	flag.IntVar(&o.gen.Columns, "columns", 0, "Recent columns of each tab if non-zero")
+	flag.Float64Var(&o.gen.FailureRate, "failure-rate", 0.05, "Fraction of test cases which fail")
+	flag.DurationVar(&o.gen.Interval, "interval", time.Hour, "Time between the start of consecutive builds")
+	flag.Int64Var(&o.gen.Seed, "seed", 1, "Seed of the synthesized results")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	ctx := context.Background()
+	log := logrus.WithFields(logrus.Fields{
+		"groups": opt.gen.Groups,
+		"builds": opt.gen.Builds,
+		"tests":  opt.gen.Tests,
+	})
+
+	if opt.benchmark {
+		if !opt.debug {
+			// Per-group lines would dwarf the report.
+			logrus.SetLevel(logrus.WarnLevel)
+		}
+		report, err := loadgen.Benchmark(ctx, loadgen.NewBucket(), opt.gen, opt.concurrency, time.Now())
+		if err != nil {
+			log.WithError(err).Fatal("Failed benchmark")
+		}
+		fmt.Print(report)
+		return
+	}
+
+	layout, err := loadgen.NewLayout(opt.output)
+	if err != nil {
+		log.WithError(err).Fatal("Bad --output")
+	}
+	if !opt.confirm {
+		log.WithField("config", layout.Config).Info("--confirm=false (DRY-RUN): will not write to gcs")
+		return
+	}
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to read storage client")
+	}
+	client := gcs.NewClient(storageClient)
+	if _, err := loadgen.Generate(ctx, client, opt.gen, *layout, time.Now()); err != nil {
+		log.WithError(err).Fatal("Failed to generate")
+	}
+	log.WithFields(logrus.Fields{
+		"config": layout.Config,
+		"logs":   layout.Logs,
+	}).Info("Generated")
+}
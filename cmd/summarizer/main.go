@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"runtime"
 	"time"
 
@@ -27,6 +28,10 @@ import (
 
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 
+	"github.com/GoogleCloudPlatform/testgrid/pkg/commitstatus"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/invalidate"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/notifier"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/plugin"
 	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
 )
 
@@ -39,6 +44,15 @@ type options struct {
 	wait              time.Duration
 	gridPathPrefix    string
 	summaryPathPrefix string
+	incremental       bool
+	fixedTime         string
+	fixed             time.Time
+	invalidate        invalidate.Targets
+	notify            bool
+	templates         string
+	language          string
+	senders           plugin.Paths
+	testgridURL       string
 
 	debug    bool
 	trace    bool
@@ -52,6 +66,16 @@ func (o *options) validate() error {
 	if o.concurrency == 0 {
 		o.concurrency = 4 * runtime.NumCPU()
 	}
+	if o.notify && o.templates == "" {
+		return errors.New("--notify requires --notification-templates")
+	}
+	if o.fixedTime != "" {
+		t, err := time.Parse(time.RFC3339, o.fixedTime)
+		if err != nil {
+			return fmt.Errorf("--fixed-time=%s: %w", o.fixedTime, err)
+		}
+		o.fixed = t
+	}
 	return nil
 }
 
@@ -65,6 +89,14 @@ func gatherOptions() options {
 	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
 	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
 	flag.StringVar(&o.summaryPathPrefix, "summary-path", "", "Write summaries under this GCS path.")
+	flag.BoolVar(&o.incremental, "incremental", true, "Reuse the previous summary of tabs whose config and grid state are unchanged if set")
+	flag.Var(&o.invalidate, "invalidate", "Notify this webhook URL or projects/PROJECT/topics/TOPIC of each written summary (repeatable)")
+	flag.BoolVar(&o.notify, "notify", false, "Send the notifications of dashboards with a notification_schedule if set")
+	flag.StringVar(&o.templates, "notification-templates", "", "Render notifications with the templates under this path, relative to --config")
+	flag.StringVar(&o.language, "notification-language", "en", "Humanize notifications in this language")
+	flag.Var(&o.senders, "sender-plugin", "Launch this channel=/path/to/binary plugin to deliver the channel's notifications (repeatable)")
+	flag.StringVar(&o.testgridURL, "testgrid-url", "", "Link notifications to tabs of the frontend at this URL if set")
+	flag.StringVar(&o.fixedTime, "fixed-time", "", "Pin the current time to this RFC 3339 time for deterministic output, such as when comparing canaries")
 
 	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
 	flag.BoolVar(&o.trace, "trace", false, "Log trace and debug lines if set")
@@ -95,6 +127,10 @@ func main() {
 		logrus.SetFormatter(&logrus.JSONFormatter{})
 	}
 	logrus.SetReportCaller(true)
+	if !opt.fixed.IsZero() {
+		logrus.WithField("time", opt.fixed).Info("Pinning the current time for deterministic output")
+		summarizer.FixTime(opt.fixed)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -104,11 +140,43 @@ func main() {
 	}
 
 	client := gcs.NewClient(storageClient)
+	if len(opt.invalidate) > 0 {
+		notifiers, err := invalidate.NewNotifiers(ctx, opt.invalidate, opt.creds)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create invalidation notifiers")
+		}
+		client = invalidate.NewClient(client, opt.config, invalidate.SummaryKind, opt.summaryPathPrefix, notifiers)
+	}
+
+	if opt.notify {
+		templates, err := notifier.NewTemplates(client, opt.config, opt.templates, opt.language)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to load notification templates")
+		}
+		driver := notifier.Driver{
+			Templates: templates,
+			Calendars: client,
+		}
+		if len(opt.senders) > 0 {
+			plugins, err := plugin.LaunchSenders(ctx, opt.senders, time.Minute)
+			if err != nil {
+				logrus.WithError(err).Fatal("Failed to launch sender plugins")
+			}
+			defer plugins.Close()
+			driver.Senders = plugins.Senders()
+		}
+		if opt.testgridURL != "" {
+			driver.TabURL = func(dashboard, tab string) string {
+				return commitstatus.TabURL(opt.testgridURL, dashboard, tab)
+			}
+		}
+		summarizer.NotifyWith(&driver)
+	}
 
 	updateOnce := func(ctx context.Context) error {
 		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 		defer cancel()
-		return summarizer.Update(ctx, client, opt.config, opt.concurrency, opt.dashboard, opt.gridPathPrefix, opt.summaryPathPrefix, opt.confirm)
+		return summarizer.Update(ctx, client, opt.config, opt.concurrency, opt.dashboard, opt.gridPathPrefix, opt.summaryPathPrefix, opt.confirm, opt.incremental)
 	}
 
 	if err := updateOnce(ctx); err != nil {
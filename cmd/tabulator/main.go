@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/invalidate"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type options struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	creds          string
+	confirm        bool
+	dashboard      string
+	concurrency    int
+	wait           time.Duration
+	gridPathPrefix string
+	tabPathPrefix  string
+	fixedTime      string
+	fixed          time.Time
+	invalidate     invalidate.Targets
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.tabPathPrefix == "" {
+		return errors.New("empty --tab-path")
+	}
+	if o.concurrency == 0 {
+		o.concurrency = 4 * runtime.NumCPU()
+	}
+	if o.fixedTime != "" {
+		t, err := time.Parse(time.RFC3339, o.fixedTime)
+		if err != nil {
+			return fmt.Errorf("--fixed-time=%s: %w", o.fixedTime, err)
+		}
+		o.fixed = t
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.Var(&o.config, "config", "gs://path/to/config.pb")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.BoolVar(&o.confirm, "confirm", false, "Upload data if set")
+	flag.StringVar(&o.dashboard, "dashboard", "", "Only update named dashboard if set")
+	flag.IntVar(&o.concurrency, "concurrency", 0, "Manually define the number of groups to concurrently tabulate if non-zero")
+	flag.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
+	flag.StringVar(&o.gridPathPrefix, "grid-path", "", "Read grid states under this GCS path.")
+	flag.StringVar(&o.tabPathPrefix, "tab-path", "tabs", "Write tab states under this GCS path.")
+	flag.Var(&o.invalidate, "invalidate", "Notify this webhook URL or projects/PROJECT/topics/TOPIC of each written tab state (repeatable)")
+	flag.StringVar(&o.fixedTime, "fixed-time", "", "Pin the current time to this RFC 3339 time for deterministic output, such as when comparing canaries")
+
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if !opt.confirm {
+		logrus.Info("--confirm=false (DRY-RUN): will not write to gcs")
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+	if !opt.fixed.IsZero() {
+		logrus.WithField("time", opt.fixed).Info("Pinning the current time for deterministic output")
+		tabulator.FixTime(opt.fixed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		logrus.Fatalf("Failed to read storage client: %v", err)
+	}
+	client := gcs.NewClient(storageClient)
+	if len(opt.invalidate) > 0 {
+		notifiers, err := invalidate.NewNotifiers(ctx, opt.invalidate, opt.creds)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create invalidation notifiers")
+		}
+		client = invalidate.NewClient(client, opt.config, invalidate.TabKind, opt.tabPathPrefix, notifiers)
+	}
+
+	updateOnce := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+		return tabulator.Update(ctx, client, opt.config, opt.concurrency, opt.dashboard, opt.gridPathPrefix, opt.tabPathPrefix, opt.confirm)
+	}
+
+	if err := updateOnce(ctx); err != nil {
+		logrus.WithError(err).Error("Failed update")
+	}
+	if opt.wait == 0 {
+		return
+	}
+	timer := time.NewTimer(opt.wait)
+	defer timer.Stop()
+	for range timer.C {
+		timer.Reset(opt.wait)
+		if err := updateOnce(ctx); err != nil {
+			logrus.WithError(err).Error("Failed update")
+		}
+		logrus.WithField("wait", opt.wait).Info("Sleeping")
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/lite"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/ui"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/dir"
+)
+
+type options struct {
+	root        string
+	config      gcs.Path
+	creds       string
+	yaml        string
+	listen      string
+	wait        time.Duration
+	concurrency int
+	ui          bool
+
+	debug    bool
+	jsonLogs bool
+}
+
+func (o *options) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.concurrency < 1 {
+		return errors.New("--concurrency must be positive")
+	}
+	if o.wait <= 0 {
+		return errors.New("--wait must be positive")
+	}
+	return nil
+}
+
+func gatherOptions() options {
+	var o options
+	flag.StringVar(&o.root, "root", "", "Store every gs://bucket/object under this /path/to/dir instead of GCS if set")
+	flag.Var(&o.config, "config", "gs://path/to/config.pb, with state written alongside it")
+	flag.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	flag.StringVar(&o.yaml, "yaml", "", "Comma-separated YAML files or directories converted into --config before each cycle if set")
+	flag.StringVar(&o.listen, "listen", ":8080", "Serve the API on this address")
+	flag.DurationVar(&o.wait, "wait", 5*time.Minute, "Ensure at least this much time has passed since the last cycle")
+	flag.IntVar(&o.concurrency, "concurrency", 4, "Process this many groups, tabs and dashboards concurrently")
+	flag.BoolVar(&o.ui, "ui", true, "Serve a web UI rendering dashboards, tabs and grids at /ui/ if set")
+	flag.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
+	flag.BoolVar(&o.jsonLogs, "json-logs", false, "Uses a json logrus formatter when set")
+	flag.Parse()
+	return o
+}
+
+func main() {
+	opt := gatherOptions()
+	if err := opt.validate(); err != nil {
+		logrus.Fatalf("Invalid flags: %v", err)
+	}
+	if opt.debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	if opt.jsonLogs {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var client gcs.ConditionalClient
+	if opt.root != "" {
+		client = dir.NewClient(opt.root)
+	} else {
+		storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+		if err != nil {
+			logrus.Fatalf("Failed to create storage client: %v", err)
+		}
+		defer storageClient.Close()
+		client = gcs.NewClient(storageClient)
+	}
+
+	liteOpt := lite.Options{
+		Config:      opt.config,
+		Concurrency: opt.concurrency,
+	}
+	if opt.yaml != "" {
+		liteOpt.YAML = strings.Split(opt.yaml, ",")
+	}
+	handler := lite.NewHandler(client, liteOpt)
+
+	cycle := func() {
+		start := time.Now()
+		if err := lite.Cycle(ctx, client, liteOpt); err != nil {
+			logrus.WithError(err).Error("Cycle failed")
+		}
+		if err := handler.Refresh(ctx); err != nil {
+			logrus.WithError(err).Error("Failed to refresh the API")
+		}
+		logrus.WithField("duration", time.Since(start)).Info("Cycle completed")
+	}
+	go func() {
+		cycle()
+		timer := time.NewTimer(opt.wait)
+		defer timer.Stop()
+		for range timer.C {
+			timer.Reset(opt.wait)
+			cycle()
+		}
+	}()
+
+	logrus.WithFields(logrus.Fields{
+		"listen": opt.listen,
+		"config": opt.config,
+		"root":   opt.root,
+	}).Info("Serving")
+	var served http.Handler = handler
+	if opt.ui {
+		served = ui.Wrap(handler)
+	}
+	logrus.Fatal(http.ListenAndServe(opt.listen, served))
+}
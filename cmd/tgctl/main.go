@@ -0,0 +1,486 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// tgctl performs administrative tasks on the state of a config.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/exporter"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/importer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/janitor"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const usage = `Usage: tgctl <command> [flags]
+
+Commands:
+  accept     replace the state of a test group with the update its write guard quarantined
+  export     write the cells of dashboard tabs as parquet partitions
+  import     add results exported from another dashboard to the state of a test group
+  override   force the results of a build of a test group, or remove the override
+  restore    move the trashed state of a test group back into place
+`
+
+type acceptOptions struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	creds          string
+	gridPathPrefix string
+	group          string
+}
+
+func (o *acceptOptions) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.group == "" {
+		return errors.New("empty --group")
+	}
+	return nil
+}
+
+func gatherAcceptOptions(args []string) (*acceptOptions, error) {
+	var o acceptOptions
+	fs := flag.NewFlagSet("accept", flag.ContinueOnError)
+	fs.Var(&o.config, "config", "gs://path/to/config.pb")
+	fs.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	fs.StringVar(&o.gridPathPrefix, "grid-path", "grid", "Accept the grid state under this GCS path.")
+	fs.StringVar(&o.group, "group", "", "Accept the quarantined update of this test group.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func accept(ctx context.Context, args []string) error {
+	opt, err := gatherAcceptOptions(args)
+	if err != nil {
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		return fmt.Errorf("storage client: %w", err)
+	}
+	client := gcs.NewClient(storageClient)
+	gridPath, err := updater.TestGroupPath(opt.config, opt.gridPathPrefix, opt.group)
+	if err != nil {
+		return fmt.Errorf("grid path: %w", err)
+	}
+	if err := updater.AcceptQuarantine(ctx, client, *gridPath); err != nil {
+		return fmt.Errorf("accept %s: %w", opt.group, err)
+	}
+	logrus.WithField("group", opt.group).Info("Accepted quarantined update")
+	return nil
+}
+
+type restoreOptions struct {
+	config          gcs.Path // gcs://path/to/config/proto
+	creds           string
+	gridPathPrefix  string
+	trashPathPrefix string
+	group           string
+}
+
+func (o *restoreOptions) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.trashPathPrefix == "" {
+		return errors.New("empty --trash-path")
+	}
+	if o.group == "" {
+		return errors.New("empty --group")
+	}
+	return nil
+}
+
+func gatherRestoreOptions(args []string) (*restoreOptions, error) {
+	var o restoreOptions
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	fs.Var(&o.config, "config", "gs://path/to/config.pb")
+	fs.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	fs.StringVar(&o.gridPathPrefix, "grid-path", "grid", "Restore the grid state under this GCS path.")
+	fs.StringVar(&o.trashPathPrefix, "trash-path", "", "The --trash-path of the janitor which trashed the state.")
+	fs.StringVar(&o.group, "group", "", "Restore the state of this test group.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func restore(ctx context.Context, args []string) error {
+	opt, err := gatherRestoreOptions(args)
+	if err != nil {
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		return fmt.Errorf("storage client: %w", err)
+	}
+	client := gcs.NewClient(storageClient)
+	janitorOpt := janitor.Options{
+		GridPrefix:  opt.gridPathPrefix,
+		TrashPrefix: opt.trashPathPrefix,
+	}
+	n, err := janitor.RestoreGroup(ctx, client, opt.config, janitorOpt, opt.group)
+	if err != nil {
+		return fmt.Errorf("restore %s: %w", opt.group, err)
+	}
+	logrus.WithFields(logrus.Fields{
+		"group":   opt.group,
+		"objects": n,
+	}).Info("Restored group, add it back to the config to keep it")
+	return nil
+}
+
+type exportOptions struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	output         gcs.Path
+	creds          string
+	gridPathPrefix string
+	dashboard      string
+	format         string
+	days           int
+	confirm        bool
+}
+
+func (o *exportOptions) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.output.String() == "" {
+		return errors.New("empty --output")
+	}
+	if o.format != "parquet" {
+		return fmt.Errorf("unsupported --format=%q", o.format)
+	}
+	if o.days < 1 {
+		return fmt.Errorf("--days must be positive, got %d", o.days)
+	}
+	return nil
+}
+
+func gatherExportOptions(args []string) (*exportOptions, error) {
+	var o exportOptions
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.Var(&o.config, "config", "gs://path/to/config.pb")
+	fs.Var(&o.output, "output", "Write exports under this GCS path, such as gs://bucket/exports")
+	fs.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	fs.StringVar(&o.gridPathPrefix, "grid-path", "grid", "Read the grid state under this GCS path.")
+	fs.StringVar(&o.dashboard, "dashboard", "", "Only export this dashboard if set")
+	fs.StringVar(&o.format, "format", "parquet", "Export format (only parquet is supported)")
+	fs.IntVar(&o.days, "days", 1, "Export this many of the most recent days (UTC)")
+	fs.BoolVar(&o.confirm, "confirm", false, "Write the exports if set")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func export(ctx context.Context, args []string) error {
+	opt, err := gatherExportOptions(args)
+	if err != nil {
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		return fmt.Errorf("storage client: %w", err)
+	}
+	client := gcs.NewClient(storageClient)
+	since := time.Now().AddDate(0, 0, 1-opt.days)
+	if err := exporter.ExportParquet(ctx, client, opt.config, opt.gridPathPrefix, opt.output, opt.dashboard, since, opt.confirm); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	logrus.WithFields(logrus.Fields{
+		"dashboard": opt.dashboard,
+		"output":    opt.output.String(),
+		"days":      opt.days,
+	}).Info("Exported cells")
+	return nil
+}
+
+type importOptions struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	creds          string
+	gridPathPrefix string
+	group          string
+	format         string
+	input          string
+	confirm        bool
+}
+
+func (o *importOptions) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.group == "" {
+		return errors.New("empty --group")
+	}
+	if o.input == "" {
+		return errors.New("empty --input")
+	}
+	switch importer.Format(o.format) {
+	case importer.TableFormat, importer.CSVFormat:
+	default:
+		return fmt.Errorf("unsupported --format=%q", o.format)
+	}
+	return nil
+}
+
+func gatherImportOptions(args []string) (*importOptions, error) {
+	var o importOptions
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.Var(&o.config, "config", "gs://path/to/config.pb")
+	fs.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	fs.StringVar(&o.gridPathPrefix, "grid-path", "grid", "Write the grid state under this GCS path.")
+	fs.StringVar(&o.group, "group", "", "Import results into the state of this test group.")
+	fs.StringVar(&o.format, "format", string(importer.TableFormat), "Format of the results, either table (legacy table JSON) or csv")
+	fs.StringVar(&o.input, "input", "", "Read results from this local file or gs://path")
+	fs.BoolVar(&o.confirm, "confirm", false, "Write the grid state if set")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// openInput opens the local file or GCS object at the path.
+func openInput(ctx context.Context, opener gcs.Opener, path string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(path, "gs://") {
+		return os.Open(path)
+	}
+	p, err := gcs.NewPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return opener.Open(ctx, *p)
+}
+
+func importResults(ctx context.Context, args []string) error {
+	opt, err := gatherImportOptions(args)
+	if err != nil {
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		return fmt.Errorf("storage client: %w", err)
+	}
+	client := gcs.NewClient(storageClient)
+	cfg, err := config.ReadGCS(ctx, client, opt.config)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	tg := config.FindTestGroup(opt.group, cfg)
+	if tg == nil {
+		return fmt.Errorf("no test group %s in %s", opt.group, opt.config)
+	}
+	gridPath, err := updater.TestGroupPath(opt.config, opt.gridPathPrefix, opt.group)
+	if err != nil {
+		return fmt.Errorf("grid path: %w", err)
+	}
+	r, err := openInput(ctx, client, opt.input)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", opt.input, err)
+	}
+	defer r.Close()
+	cols, err := importer.Read(r, importer.Format(opt.format))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", opt.input, err)
+	}
+	log := logrus.WithFields(logrus.Fields{
+		"group": opt.group,
+		"path":  gridPath,
+	})
+	n, err := importer.Import(ctx, log, client, tg, *gridPath, cols, opt.confirm)
+	if err != nil {
+		return fmt.Errorf("import %s: %w", opt.group, err)
+	}
+	log.WithFields(logrus.Fields{
+		"columns":  n,
+		"imported": len(cols),
+	}).Info("Imported older columns")
+	return nil
+}
+
+type overrideOptions struct {
+	config         gcs.Path // gcs://path/to/config/proto
+	creds          string
+	gridPathPrefix string
+	group          string
+	build          string
+	result         string
+	annotation     string
+	reason         string
+	author         string
+	remove         bool
+}
+
+func (o *overrideOptions) validate() error {
+	if o.config.String() == "" {
+		return errors.New("empty --config")
+	}
+	if o.group == "" {
+		return errors.New("empty --group")
+	}
+	if o.build == "" {
+		return errors.New("empty --build")
+	}
+	if o.remove {
+		if o.result != "" || o.annotation != "" || o.reason != "" {
+			return errors.New("--remove excludes --result, --annotation and --reason")
+		}
+		return nil
+	}
+	if o.result != "" {
+		if _, ok := statuspb.TestStatus_value[o.result]; !ok {
+			return fmt.Errorf("unknown --result=%q", o.result)
+		}
+	}
+	if o.author == "" {
+		return errors.New("empty --author")
+	}
+	return updater.ValidateBuildOverride(o.override())
+}
+
+// override returns the BuildOverride of the options.
+func (o *overrideOptions) override() *statepb.BuildOverride {
+	return &statepb.BuildOverride{
+		Build:      o.build,
+		Result:     statuspb.TestStatus_value[o.result],
+		Annotation: o.annotation,
+		Reason:     o.reason,
+		Author:     o.author,
+	}
+}
+
+func gatherOverrideOptions(args []string) (*overrideOptions, error) {
+	var o overrideOptions
+	fs := flag.NewFlagSet("override", flag.ContinueOnError)
+	fs.Var(&o.config, "config", "gs://path/to/config.pb")
+	fs.StringVar(&o.creds, "gcp-service-account", "", "/path/to/gcp/creds (use local creds if empty)")
+	fs.StringVar(&o.gridPathPrefix, "grid-path", "grid", "Override the builds of the grid state under this GCS path.")
+	fs.StringVar(&o.group, "group", "", "Override a build of this test group.")
+	fs.StringVar(&o.build, "build", "", "Override the results of this build ID.")
+	fs.StringVar(&o.result, "result", "", "Force every result of the build to this status, such as CANCEL, if set")
+	fs.StringVar(&o.annotation, "annotation", "", "Replace the message of every result of the build with this annotation if set")
+	fs.StringVar(&o.reason, "reason", "", "Explain why the results of the build are wrong")
+	fs.StringVar(&o.author, "author", os.Getenv("USER"), "Record this author of the override")
+	fs.BoolVar(&o.remove, "remove", false, "Remove the override of the build instead")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func override(ctx context.Context, args []string) error {
+	opt, err := gatherOverrideOptions(args)
+	if err != nil {
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+	if err != nil {
+		return fmt.Errorf("storage client: %w", err)
+	}
+	client := gcs.NewClient(storageClient)
+	gridPath, err := updater.TestGroupPath(opt.config, opt.gridPathPrefix, opt.group)
+	if err != nil {
+		return fmt.Errorf("grid path: %w", err)
+	}
+	path, err := updater.BuildOverridesPath(*gridPath)
+	if err != nil {
+		return fmt.Errorf("overrides path: %w", err)
+	}
+	var missing bool
+	_, err = updater.UpdateBuildOverrides(ctx, client, *path, func(overrides *statepb.BuildOverrides) bool {
+		if opt.remove {
+			missing = !updater.RemoveBuildOverride(overrides, opt.build)
+			return !missing
+		}
+		updater.SetBuildOverride(overrides, opt.override(), time.Now())
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("update %s: %w", path, err)
+	}
+	log := logrus.WithFields(logrus.Fields{
+		"group": opt.group,
+		"build": opt.build,
+	})
+	switch {
+	case missing:
+		return fmt.Errorf("build %s of %s has no override", opt.build, opt.group)
+	case opt.remove:
+		log.Info("Removed override, reprocess the build to restore its results")
+	default:
+		log.Info("Overrode build, which applies during the next update")
+	}
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	ctx := context.Background()
+	var err error
+	switch cmd := os.Args[1]; cmd {
+	case "accept":
+		err = accept(ctx, os.Args[2:])
+	case "export":
+		err = export(ctx, os.Args[2:])
+	case "import":
+		err = importResults(ctx, os.Args[2:])
+	case "override":
+		err = override(ctx, os.Args[2:])
+	case "restore":
+		err = restore(ctx, os.Args[2:])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
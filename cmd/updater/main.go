@@ -21,28 +21,63 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/argo"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/circleci"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/costs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/crd"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/exporter"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/invalidate"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/plugin"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/resultdb"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tekton"
 	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/replay"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
 // options configures the updater
 type options struct {
-	config           gcs.Path // gs://path/to/config/proto
-	creds            string
-	confirm          bool
-	group            string
-	groupConcurrency int
-	buildConcurrency int
-	wait             time.Duration
-	groupTimeout     time.Duration
-	buildTimeout     time.Duration
-	gridPrefix       string
+	config              gcs.Path // gs://path/to/config/proto
+	creds               string
+	confirm             bool
+	group               string
+	groupConcurrency    int
+	buildConcurrency    int
+	artifactConcurrency int
+	wait                time.Duration
+	groupTimeout        time.Duration
+	buildTimeout        time.Duration
+	gridPrefix          string
+	fixedTime           string
+	fixed               time.Time
+	record              string
+	replay              string
+	replayOutput        string
+	invalidate          invalidate.Targets
+	costReport          gcs.Path
+	plugins             plugin.Paths
+	resultDBCreds       string
+	buildbucketURL      string
+	resultDBURL         string
+	circleCIToken       string
+	circleCIURL         string
+	tektonServer        string
+	tektonToken         string
+	argoServer          string
+	argoToken           string
 
 	debug    bool
 	trace    bool
@@ -66,6 +101,25 @@ func (o *options) validate() error {
 			o.buildConcurrency = 4
 		}
 	}
+	if o.artifactConcurrency < 0 {
+		return fmt.Errorf("--artifact-concurrency=%d must not be negative", o.artifactConcurrency)
+	}
+	if o.fixedTime != "" {
+		t, err := time.Parse(time.RFC3339, o.fixedTime)
+		if err != nil {
+			return fmt.Errorf("--fixed-time=%s: %w", o.fixedTime, err)
+		}
+		o.fixed = t
+	}
+	if o.record != "" && o.replay != "" {
+		return errors.New("--record and --replay are mutually exclusive")
+	}
+	if (o.record != "" || o.replay != "") && o.wait != 0 {
+		return errors.New("--record and --replay run a single cycle, so --wait must be zero")
+	}
+	if o.replayOutput != "" && o.replay == "" {
+		return errors.New("--replay-output requires --replay")
+	}
 
 	return nil
 }
@@ -79,10 +133,27 @@ func gatherFlagOptions(fs *flag.FlagSet, args ...string) options {
 	fs.StringVar(&o.group, "test-group", "", "Only update named group if set")
 	fs.IntVar(&o.groupConcurrency, "group-concurrency", 0, "Manually define the number of groups to concurrently update if non-zero")
 	fs.IntVar(&o.buildConcurrency, "build-concurrency", 0, "Manually define the number of builds to concurrently read if non-zero")
+	fs.IntVar(&o.artifactConcurrency, "artifact-concurrency", 0, "Manually define the number of junit files each build concurrently reads if non-zero")
 	fs.DurationVar(&o.wait, "wait", 0, "Ensure at least this much time has passed since the last loop (exit if zero).")
 	fs.DurationVar(&o.groupTimeout, "group-timeout", 10*time.Minute, "Maximum time to wait for each group to update")
 	fs.DurationVar(&o.buildTimeout, "build-timeout", 3*time.Minute, "Maximum time to wait to read each build")
 	fs.StringVar(&o.gridPrefix, "grid-prefix", "grid", "Join this with the grid name to create the GCS suffix")
+	fs.StringVar(&o.record, "record", "", "Record every object read during the cycle into this tarball if set")
+	fs.StringVar(&o.replay, "replay", "", "Replay the cycle offline from this tarball written by --record if set")
+	fs.StringVar(&o.replayOutput, "replay-output", "", "Write the objects uploaded during --replay under this local directory if set")
+	fs.Var(&o.invalidate, "invalidate", "Notify this webhook URL or projects/PROJECT/topics/TOPIC of each written grid (repeatable)")
+	fs.Var(&o.costReport, "cost-report", "Write the storage each group used during the cycle to gs://path/to/report if set")
+	fs.Var(&o.plugins, "column-reader-plugin", "Launch this name=/path/to/binary plugin to read the columns of groups setting column_reader: name (repeatable)")
+	fs.StringVar(&o.resultDBCreds, "resultdb-service-account", "", "/path/to/creds for reading LUCI builds of groups setting column_reader: resultdb (anonymous if empty)")
+	fs.StringVar(&o.buildbucketURL, "buildbucket-url", "", "Read LUCI builds from this Buildbucket server ("+resultdb.DefaultBuildbucketURL+" if empty)")
+	fs.StringVar(&o.resultDBURL, "resultdb-url", "", "Read LUCI test results from this ResultDB server ("+resultdb.DefaultResultDBURL+" if empty)")
+	fs.StringVar(&o.circleCIToken, "circleci-token-path", "", "/path/to/token for reading the workflows of groups setting column_reader: circleci (anonymous if empty)")
+	fs.StringVar(&o.circleCIURL, "circleci-url", "", "Read CircleCI workflows from this API ("+circleci.DefaultURL+" if empty)")
+	fs.StringVar(&o.tektonServer, "tekton-api-server", "", "Read the Tekton PipelineRuns of groups setting column_reader: tekton from this Kubernetes API server (in-cluster if empty)")
+	fs.StringVar(&o.tektonToken, "tekton-token-path", "", "/path/to/token for authenticating to --tekton-api-server")
+	fs.StringVar(&o.argoServer, "argo-server", "", "Read the archived workflows of groups setting column_reader: argo from this Argo Server, such as https://argo-server.argo:2746")
+	fs.StringVar(&o.argoToken, "argo-token-path", "", "/path/to/token for authenticating to --argo-server")
+	fs.StringVar(&o.fixedTime, "fixed-time", "", "Pin the current time to this RFC 3339 time for deterministic output, such as when comparing canaries")
 
 	fs.BoolVar(&o.debug, "debug", false, "Log debug lines if set")
 	fs.BoolVar(&o.trace, "trace", false, "Log trace and debug lines if set")
@@ -120,29 +191,125 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
-	if err != nil {
-		logrus.Fatalf("Failed to create storage client: %v", err)
+	var client gcs.ConditionalClient
+	var recorder *replay.Recorder
+	var replayer *replay.Replayer
+	if opt.replay != "" {
+		var err error
+		if replayer, err = loadReplay(opt.replay); err != nil {
+			logrus.WithError(err).Fatal("Failed to load replay")
+		}
+		client = replayer
+		// Replay at the recorded time, so time sensitive results match.
+		if opt.fixed.IsZero() {
+			opt.fixed = replayer.Recorded()
+		}
+	} else {
+		storageClient, err := gcs.ClientWithCreds(ctx, opt.creds)
+		if err != nil {
+			logrus.Fatalf("Failed to create storage client: %v", err)
+		}
+		defer storageClient.Close()
+		client = gcs.NewClient(storageClient)
+		if opt.record != "" {
+			recorder = replay.NewRecorder(client)
+			client = recorder
+		}
+		if len(opt.invalidate) > 0 {
+			notifiers, err := invalidate.NewNotifiers(ctx, opt.invalidate, opt.creds)
+			if err != nil {
+				logrus.WithError(err).Fatal("Failed to create invalidation notifiers")
+			}
+			client = invalidate.NewClient(client, opt.config, invalidate.GridKind, opt.gridPrefix, notifiers)
+		}
+	}
+	if !opt.fixed.IsZero() {
+		logrus.WithField("time", opt.fixed).Info("Pinning the current time for deterministic output")
+		updater.FixTime(opt.fixed)
 	}
-	defer storageClient.Close()
-
-	client := gcs.NewClient(storageClient)
 
 	logrus.WithFields(logrus.Fields{
-		"group": opt.groupConcurrency,
-		"build": opt.buildConcurrency,
+		"group":    opt.groupConcurrency,
+		"build":    opt.buildConcurrency,
+		"artifact": opt.artifactConcurrency,
 	}).Info("Configured concurrency")
 
-	groupUpdater := updater.GCS(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, opt.confirm, updater.SortStarted)
+	export := exporter.TestManagement(&http.Client{Timeout: time.Minute})
+	if replayer != nil {
+		// Replays stay offline.
+		export = nil
+	}
+	groupUpdater := updater.GCS(opt.groupTimeout, opt.buildTimeout, opt.buildConcurrency, opt.artifactConcurrency, opt.confirm, updater.SortBuilds, export)
+	readers := map[string]updater.ColumnReader{}
+	if replayer == nil {
+		// Replays stay offline.
+		luci, err := resultDBClient(ctx, opt)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create ResultDB client")
+		}
+		readers[resultdb.ReaderName] = resultdb.ColumnReader(luci)
+		circle, err := circleCIClient(opt)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create CircleCI client")
+		}
+		readers[circleci.ReaderName] = circleci.ColumnReader(circle)
+		if kube, err := tektonClient(opt); err != nil {
+			logrus.WithError(err).Warning("Not reading Tekton PipelineRuns")
+		} else {
+			readers[tekton.ReaderName] = tekton.ColumnReader(*kube, client)
+		}
+		if opt.argoServer != "" {
+			wf, err := argoClient(opt)
+			if err != nil {
+				logrus.WithError(err).Fatal("Failed to create Argo client")
+			}
+			readers[argo.ReaderName] = argo.ColumnReader(*wf)
+		}
+	}
+	if len(opt.plugins) > 0 {
+		plugins, err := plugin.LaunchColumnReaders(ctx, opt.plugins, time.Minute)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to launch column reader plugins")
+		}
+		defer plugins.Close()
+		for name, reader := range plugins.Readers() {
+			readers[name] = reader
+		}
+	}
+	if len(readers) > 0 {
+		groupUpdater = updater.ColumnReaders(groupUpdater, readers, opt.groupTimeout, opt.confirm, updater.SortBuilds, export)
+	}
 	updateOnce := func() {
 		start := time.Now()
-		if err := updater.Update(ctx, client, opt.config, opt.gridPrefix, opt.groupConcurrency, opt.group, groupUpdater, opt.confirm); err != nil {
+		update := groupUpdater
+		var tracker *costs.Tracker
+		if opt.costReport.String() != "" {
+			tracker = costs.NewTracker(start)
+			update = tracker.Track(update)
+		}
+		if err := updater.Update(ctx, client, opt.config, opt.gridPrefix, opt.groupConcurrency, opt.group, update, opt.confirm); err != nil {
 			logrus.WithError(err).Error("Could not update")
 		}
 		logrus.Infof("Update completed in %s", time.Since(start))
+		if tracker != nil {
+			if err := writeCostReport(ctx, client, opt.config, opt.costReport, tracker, opt.confirm); err != nil {
+				logrus.WithError(err).Warning("Failed to write cost report")
+			}
+		}
 	}
 
 	updateOnce()
+	if recorder != nil {
+		if err := saveRecording(recorder, opt.record); err != nil {
+			logrus.WithError(err).Fatal("Failed to save recording")
+		}
+		logrus.WithField("recording", opt.record).Info("Saved recording")
+	}
+	if replayer != nil {
+		if err := writeReplayOutput(replayer, opt.replayOutput); err != nil {
+			logrus.WithError(err).Fatal("Failed to write replay output")
+		}
+	}
 	if opt.wait == 0 {
 		return
 	}
@@ -158,3 +325,156 @@ func main() {
 		}).Info("Sleeping...")
 	}
 }
+
+// writeCostReport writes the storage each group used during the cycle, when confirmed.
+func writeCostReport(ctx context.Context, client gcs.ConditionalClient, configPath, path gcs.Path, tracker *costs.Tracker, confirm bool) error {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	report := tracker.Report(cfg, time.Now())
+	log := logrus.WithFields(logrus.Fields{
+		"path":   path,
+		"groups": len(report.Groups),
+	})
+	if !confirm {
+		log.Info("Skipping cost report write (dry run)")
+		return nil
+	}
+	if err := costs.Write(ctx, client, path, report); err != nil {
+		return err
+	}
+	log.Debug("Wrote cost report")
+	return nil
+}
+
+// resultDBClient returns a client of the LUCI servers, authenticated with
+// --resultdb-service-account when set.
+func resultDBClient(ctx context.Context, opt options) (*resultdb.Client, error) {
+	client := &http.Client{Timeout: time.Minute}
+	if opt.resultDBCreds != "" {
+		var err error
+		client, _, err = htransport.NewClient(ctx, option.WithCredentialsFile(opt.resultDBCreds), option.WithScopes("https://www.googleapis.com/auth/userinfo.email"))
+		if err != nil {
+			return nil, fmt.Errorf("--resultdb-service-account: %w", err)
+		}
+		client.Timeout = time.Minute
+	}
+	return &resultdb.Client{
+		HTTP:           client,
+		BuildbucketURL: opt.buildbucketURL,
+		ResultDBURL:    opt.resultDBURL,
+	}, nil
+}
+
+// circleCIClient returns a client of the CircleCI API, authenticated with
+// the token at --circleci-token-path when set.
+func circleCIClient(opt options) (*circleci.Client, error) {
+	var token string
+	if opt.circleCIToken != "" {
+		buf, err := ioutil.ReadFile(opt.circleCIToken)
+		if err != nil {
+			return nil, fmt.Errorf("--circleci-token-path: %w", err)
+		}
+		token = strings.TrimSpace(string(buf))
+	}
+	return &circleci.Client{
+		HTTP:    &http.Client{Timeout: time.Minute},
+		BaseURL: opt.circleCIURL,
+		Token:   token,
+	}, nil
+}
+
+// tektonClient returns a client of the Kubernetes API server at
+// --tekton-api-server, or of the cluster running the updater when unset.
+func tektonClient(opt options) (*tekton.Client, error) {
+	if opt.tektonServer == "" {
+		kube, err := crd.InClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		return &tekton.Client{Host: kube.Host, Token: kube.Token, HTTP: kube.Client}, nil
+	}
+	var token string
+	if opt.tektonToken != "" {
+		buf, err := ioutil.ReadFile(opt.tektonToken)
+		if err != nil {
+			return nil, fmt.Errorf("--tekton-token-path: %w", err)
+		}
+		token = strings.TrimSpace(string(buf))
+	}
+	return &tekton.Client{
+		Host:  opt.tektonServer,
+		Token: token,
+		HTTP:  &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// argoClient returns a client of the Argo Server at --argo-server,
+// authenticated with the token at --argo-token-path when set.
+func argoClient(opt options) (*argo.Client, error) {
+	var token string
+	if opt.argoToken != "" {
+		buf, err := ioutil.ReadFile(opt.argoToken)
+		if err != nil {
+			return nil, fmt.Errorf("--argo-token-path: %w", err)
+		}
+		token = strings.TrimSpace(string(buf))
+	}
+	return &argo.Client{
+		Host:  opt.argoServer,
+		Token: token,
+		HTTP:  &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// loadReplay reads the recording at the local path.
+func loadReplay(path string) (*replay.Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return replay.Load(f)
+}
+
+// saveRecording writes the recording to the local path.
+func saveRecording(recorder *replay.Recorder, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := recorder.Save(f, time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeReplayOutput logs each object uploaded during the replay, writing them
+// under dir/BUCKET/OBJECT if dir is set.
+func writeReplayOutput(replayer *replay.Replayer, dir string) error {
+	for path, buf := range replayer.Uploads() {
+		log := logrus.WithFields(logrus.Fields{
+			"path":  path,
+			"bytes": len(buf),
+		})
+		switch {
+		case buf == nil:
+			log.Info("Replay deleted object")
+			continue
+		case dir == "":
+			log.Info("Replay uploaded object")
+			continue
+		}
+		out := filepath.Join(dir, path.Bucket(), filepath.FromSlash(path.Object()))
+		if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(out, buf, 0644); err != nil {
+			return err
+		}
+		log.WithField("output", out).Info("Wrote replay upload")
+	}
+	return nil
+}
@@ -110,6 +110,63 @@ func TestGatherFlagOptions(t *testing.T) {
 				o.confirm = true
 			},
 		},
+		{
+			name: "pin the time",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--fixed-time=2021-02-03T04:05:06Z",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.fixedTime = "2021-02-03T04:05:06Z"
+				o.fixed = time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+			},
+		},
+		{
+			name: "record a cycle",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--record=/tmp/cycle.tgz",
+			},
+			expected: func(o *options) {
+				o.config = *newPathOrDie("gs://bucket/whatever")
+				o.record = "/tmp/cycle.tgz"
+			},
+		},
+		{
+			name: "reject --record with --replay",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--record=/tmp/cycle.tgz",
+				"--replay=/tmp/cycle.tgz",
+			},
+			err: true,
+		},
+		{
+			name: "reject --replay with --wait",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--replay=/tmp/cycle.tgz",
+				"--wait=10m",
+			},
+			err: true,
+		},
+		{
+			name: "reject --replay-output without --replay",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--replay-output=/tmp/out",
+			},
+			err: true,
+		},
+		{
+			name: "reject a bad --fixed-time",
+			args: []string{
+				"--config=gs://bucket/whatever",
+				"--fixed-time=yesterday",
+			},
+			err: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -22,9 +22,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
@@ -83,6 +86,11 @@ func normalize(s string) string {
 	return s
 }
 
+// Normalize returns the form of an entity name compared when checking names are unique.
+func Normalize(s string) string {
+	return normalize(s)
+}
+
 const MIN_NAME_LENGTH = 3
 const MAX_NAME_LENGTH = 2048
 
@@ -252,13 +260,32 @@ func validateEmails(addresses string) error {
 	return nil
 }
 
+// validateColumnMetadata ensures the key names finished.json metadata or a known podinfo.json field.
+func validateColumnMetadata(key string) error {
+	if key == "" {
+		return errors.New("empty column_metadata")
+	}
+	if !strings.HasPrefix(key, "pod.") {
+		return nil
+	}
+	switch field := strings.TrimPrefix(key, "pod."); {
+	case field == "node", field == "phase", field == "image":
+		return nil
+	case strings.HasPrefix(field, "label.") && field != "label.":
+		return nil
+	case strings.HasPrefix(field, "annotation.") && field != "annotation.":
+		return nil
+	}
+	return fmt.Errorf("unknown podinfo column_metadata %q", key)
+}
+
 func validateTestGroup(tg *configpb.TestGroup) error {
 	var mErr error
 	if tg == nil {
 		return multierror.Append(mErr, errors.New("got an empty TestGroup"))
 	}
 	// Check that required fields are a non-zero-value.
-	if tg.GetGcsPrefix() == "" {
+	if tg.GetGcsPrefix() == "" && tg.GetColumnReader() == "" {
 		mErr = multierror.Append(mErr, errors.New("gcs_prefix can't be empty"))
 	}
 	if tg.GetDaysOfResults() <= 0 {
@@ -332,7 +359,254 @@ func validateTestGroup(tg *configpb.TestGroup) error {
 				fmt.Errorf("Column Header %d must only set one value, got configuration_value: %q, property: %q, label: %q", idx, cv, p, l),
 			)
 		}
+		for tidx, t := range header.GetTransforms() {
+			if err := validateHeaderTransform(t); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("Column Header %d transform %d: %v", idx, tidx, err))
+			}
+		}
+	}
+
+	// Cell properties need unique names.
+	cellProperties := map[string]bool{}
+	for idx, prop := range tg.GetCellProperties() {
+		name := prop.GetName()
+		if name == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Cell Property %d requires a name", idx))
+			continue
+		}
+		if cellProperties[name] {
+			mErr = multierror.Append(mErr, fmt.Errorf("Cell Property %q is defined more than once", name))
+		}
+		cellProperties[name] = true
+	}
+
+	if export := tg.GetTestManagementExport(); export != nil {
+		if export.GetSystem() == configpb.TestManagementExport_SYSTEM_UNSPECIFIED {
+			mErr = multierror.Append(mErr, errors.New("test_management_export requires a system"))
+		}
+		if export.GetUrl() == "" {
+			mErr = multierror.Append(mErr, errors.New("test_management_export requires a url"))
+		}
+		if !cellProperties[export.GetTestCaseProperty()] {
+			mErr = multierror.Append(mErr, fmt.Errorf("test_management_export test_case_property %q must be one of the cell_properties", export.GetTestCaseProperty()))
+		}
+	}
+
+	if err := validateMirrorPrefixes(tg.GetMirrorPrefixes()); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
+	for idx, r := range tg.GetRedactions() {
+		if r.GetRegex() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Redaction %d requires a regex", idx))
+		} else if _, err := regexp.Compile(r.GetRegex()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Redaction %d has an invalid regex %s: %v", idx, r.GetRegex(), err))
+		}
+	}
+
+	for idx, r := range tg.GetIssueLinkRules() {
+		if r.GetRegex() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Issue link rule %d requires a regex", idx))
+		} else if _, err := regexp.Compile(r.GetRegex()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Issue link rule %d has an invalid regex %s: %v", idx, r.GetRegex(), err))
+		}
+		if r.GetUrl() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Issue link rule %d requires a url", idx))
+		}
+	}
+
+	if n := tg.GetClearIssuesAfterPasses(); n < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("clear_issues_after_passes must be non-negative, got %d", n))
+	}
+
+	if t := tg.GetBrokenColumnThreshold(); t < 0 || t > 1 {
+		mErr = multierror.Append(mErr, fmt.Errorf("broken_column_threshold must be between 0 and 1, got %v", t))
+	}
+
+	if v := tg.GetStateVersion(); v < 0 || v > 2 {
+		mErr = multierror.Append(mErr, fmt.Errorf("state_version must be 1 or 2, got %d", v))
+	}
+
+	for idx, b := range tg.GetDurationBudgets() {
+		if _, err := regexp.Compile(b.GetNameRegex()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Duration budget %d has an invalid regex %s: %v", idx, b.GetNameRegex(), err))
+		}
+		if b.GetMaxMinutes() <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("Duration budget %d requires a positive max_minutes, got %v", idx, b.GetMaxMinutes()))
+		}
+	}
+
+	sourceNames := map[string]bool{tg.GetSourceName(): true}
+	for idx, src := range tg.GetMergedSources() {
+		if src.GetName() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Merged source %d requires a name", idx))
+		} else if sourceNames[src.GetName()] {
+			mErr = multierror.Append(mErr, fmt.Errorf("Merged source %d has a duplicate name %q", idx, src.GetName()))
+		}
+		sourceNames[src.GetName()] = true
+		if src.GetGcsPrefix() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Merged source %d requires a gcs_prefix", idx))
+		}
+		for key, expr := range src.GetMetadataFilters() {
+			if _, err := regexp.Compile(expr); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("Merged source %d has an invalid %s metadata filter %s: %v", idx, key, expr, err))
+			}
+		}
+	}
+
+	if rdb := tg.GetResultSource().GetResultdbConfig(); rdb != nil {
+		if rdb.GetProject() == "" || rdb.GetBucket() == "" || rdb.GetBuilder() == "" {
+			mErr = multierror.Append(mErr, errors.New("resultdb_config requires a project, bucket and builder"))
+		}
+		if tg.GetColumnReader() == "" {
+			mErr = multierror.Append(mErr, errors.New("resultdb_config requires a column_reader, such as resultdb"))
+		}
+	}
+
+	if cci := tg.GetResultSource().GetCircleciConfig(); cci != nil {
+		if parts := strings.Split(cci.GetProjectSlug(), "/"); len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("circleci_config project_slug must be vcs/org/repo, got %q", cci.GetProjectSlug()))
+		}
+		if cci.GetWorkflow() == "" {
+			mErr = multierror.Append(mErr, errors.New("circleci_config requires a workflow"))
+		}
+		if tg.GetColumnReader() == "" {
+			mErr = multierror.Append(mErr, errors.New("circleci_config requires a column_reader, such as circleci"))
+		}
+	}
+
+	if tk := tg.GetResultSource().GetTektonConfig(); tk != nil {
+		if tk.GetNamespace() == "" || tk.GetPipeline() == "" {
+			mErr = multierror.Append(mErr, errors.New("tekton_config requires a namespace and pipeline"))
+		}
+		if prefix := tk.GetArtifactsPrefix(); prefix != "" {
+			if _, err := gcs.NewPath(prefix); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("invalid tekton_config artifacts_prefix %q: %v", prefix, err))
+			}
+		}
+		if tg.GetColumnReader() == "" {
+			mErr = multierror.Append(mErr, errors.New("tekton_config requires a column_reader, such as tekton"))
+		}
+	}
+
+	if argo := tg.GetResultSource().GetArgoConfig(); argo != nil {
+		if argo.GetNamespace() == "" || argo.GetWorkflowTemplate() == "" {
+			mErr = multierror.Append(mErr, errors.New("argo_config requires a namespace and workflow_template"))
+		}
+		if tg.GetColumnReader() == "" {
+			mErr = multierror.Append(mErr, errors.New("argo_config requires a column_reader, such as argo"))
+		}
+	}
+
+	if overall, pod := tg.GetOverallRow(), tg.GetPodRow(); !overall.GetDisable() && !pod.GetDisable() {
+		overallName, podName := overall.GetName(), pod.GetName()
+		if overallName == "" {
+			overallName = "Overall"
+		}
+		if podName == "" {
+			podName = "Pod"
+		}
+		if overallName == podName {
+			mErr = multierror.Append(mErr, fmt.Errorf("overall_row and pod_row both named %q", overallName))
+		}
+	}
+
+	seenMetadata := map[string]bool{}
+	for _, key := range tg.GetColumnMetadata() {
+		if seenMetadata[key] {
+			mErr = multierror.Append(mErr, fmt.Errorf("duplicate column_metadata %q", key))
+		}
+		seenMetadata[key] = true
+		if err := validateColumnMetadata(key); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
+	switch key := tg.GetBuildOrderMetadata(); {
+	case tg.GetBuildOrder() != configpb.TestGroup_BUILD_ORDER_METADATA:
+		if key != "" {
+			mErr = multierror.Append(mErr, errors.New("build_order_metadata requires build_order METADATA"))
+		}
+	case key == "":
+		mErr = multierror.Append(mErr, errors.New("build_order METADATA requires build_order_metadata"))
+	case !seenMetadata[key]:
+		mErr = multierror.Append(mErr, fmt.Errorf("build_order_metadata %q must be in column_metadata", key))
+	}
+
+	if interval := tg.GetUpdateInterval(); interval != "" {
+		if d, err := time.ParseDuration(interval); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid update_interval %q: %v", interval, err))
+		} else if d < 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("update_interval must be non-negative, got %s", interval))
+		}
+	}
+
+	if days := tg.GetArchiveAfterDays(); days < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("archive_after_days must be non-negative, got %d", days))
+	} else if days > 0 {
+		if prefix := tg.GetArchivePrefix(); prefix == "" {
+			mErr = multierror.Append(mErr, errors.New("archive_after_days requires an archive_prefix"))
+		} else if _, err := gcs.NewPath(prefix); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid archive_prefix %q: %v", prefix, err))
+		}
+	}
+
+	if days := tg.GetArchiveColumnsAfterDays(); days < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("archive_columns_after_days must be non-negative, got %d", days))
+	} else if days > 0 {
+		if prefix := tg.GetArchivePrefix(); prefix == "" {
+			mErr = multierror.Append(mErr, errors.New("archive_columns_after_days requires an archive_prefix"))
+		} else if _, err := gcs.NewPath(prefix); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid archive_prefix %q: %v", prefix, err))
+		}
+		keep := tg.GetDaysOfResults()
+		if keep <= 0 {
+			keep = 7 // The updater's default.
+		}
+		if days >= keep {
+			mErr = multierror.Append(mErr, fmt.Errorf("archive_columns_after_days must be less than days_of_results, got %d >= %d", days, keep))
+		}
+	}
+
+	if days := tg.GetNewTestDays(); days < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("new_test_days must be non-negative, got %d", days))
+	}
+	if cols := tg.GetTombstoneColumns(); cols < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("tombstone_columns must be non-negative, got %d", cols))
+	}
+	if n := tg.GetResultSampling().GetEveryNthBuild(); n < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("result_sampling.every_nth_build must be non-negative, got %d", n))
+	}
+	if n := tg.GetResultSampling().GetMaxBuildsPerHour(); n < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("result_sampling.max_builds_per_hour must be non-negative, got %d", n))
+	}
+
+	for idx, r := range tg.GetRenameRules() {
+		if r.GetRegex() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("Rename rule %d requires a regex", idx))
+		} else if _, err := regexp.Compile(r.GetRegex()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Rename rule %d has an invalid regex %s: %v", idx, r.GetRegex(), err))
+		}
+	}
+	if sim := tg.GetRenameSimilarity(); sim < 0 || sim > 1 {
+		mErr = multierror.Append(mErr, fmt.Errorf("rename_similarity must be between 0 and 1, got %f", sim))
+	}
+	if path := tg.GetRenameMapping(); path != "" {
+		if _, err := gcs.NewPath(path); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid rename_mapping %q: %v", path, err))
+		}
+	}
+
+	if pub := tg.GetPublicGrid(); pub != nil {
+		if pub.GetPrefix() == "" {
+			mErr = multierror.Append(mErr, errors.New("public_grid requires a prefix"))
+		} else if _, err := gcs.NewPath(pub.GetPrefix()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid public_grid prefix %q: %v", pub.GetPrefix(), err))
+		}
+	}
 
+	if tg.GetMaxRows() < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("max_rows must be non-negative, got %d", tg.GetMaxRows()))
 	}
 
 	// test_name_config should have a matching number of format strings and name elements.
@@ -407,6 +681,228 @@ func validateDashboardTab(dt *configpb.DashboardTab) error {
 		}
 	}
 
+	// A failure window must be able to hold enough failures to alert.
+	if window := dt.GetAlertOptions().GetNumResultsInFailureWindow(); window != 0 {
+		if failures := dt.GetAlertOptions().GetNumFailuresToAlert(); window < failures {
+			mErr = multierror.Append(mErr, fmt.Errorf("num_results_in_failure_window (%d) must be at least num_failures_to_alert (%d)", window, failures))
+		}
+	}
+
+	if hours := dt.GetAlertOptions().GetNewTestGraceHours(); hours < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("new_test_grace_hours must be non-negative, got %d", hours))
+	}
+
+	if cw := dt.GetColumnWindow(); cw.GetNumColumns() < 0 || cw.GetDays() < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("column_window must be non-negative, got %d columns and %d days", cw.GetNumColumns(), cw.GetDays()))
+	}
+	if eb := dt.GetErrorBudget(); eb.GetAllowedFailingColumns() < 0 || eb.GetDays() < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("error_budget must be non-negative, got %d columns and %d days", eb.GetAllowedFailingColumns(), eb.GetDays()))
+	}
+
+	for i, tmpl := range dt.GetRowLinkTemplates() {
+		if tmpl.GetUrl() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("row_link_templates[%d] requires a url", i))
+		}
+	}
+
+	return mErr
+}
+
+func validateHeaderTransform(t *configpb.TestGroup_ColumnHeader_Transform) error {
+	switch tt := t.GetTransform().(type) {
+	case *configpb.TestGroup_ColumnHeader_Transform_RegexCapture:
+		if _, err := regexp.Compile(tt.RegexCapture); err != nil {
+			return fmt.Errorf("invalid regex_capture %s: %v", tt.RegexCapture, err)
+		}
+	case *configpb.TestGroup_ColumnHeader_Transform_Strftime:
+		if tt.Strftime == "" {
+			return errors.New("strftime can't be empty")
+		}
+	case *configpb.TestGroup_ColumnHeader_Transform_Truncate:
+		if tt.Truncate <= 0 {
+			return fmt.Errorf("truncate must be positive, got %d", tt.Truncate)
+		}
+	case *configpb.TestGroup_ColumnHeader_Transform_RoundDigits:
+		if tt.RoundDigits < 0 {
+			return fmt.Errorf("round_digits must be non-negative, got %d", tt.RoundDigits)
+		}
+	default:
+		return errors.New("transform is empty")
+	}
+	return nil
+}
+
+func validateNotificationSchedule(s *configpb.NotificationSchedule) error {
+	var mErr error
+	if _, err := time.LoadLocation(s.GetTimeZone()); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid time_zone %q: %v", s.GetTimeZone(), err))
+	}
+	for _, w := range s.GetWindows() {
+		for _, tod := range []string{w.GetStart(), w.GetEnd()} {
+			if tod == "" {
+				continue
+			}
+			if _, err := time.Parse("15:04", tod); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("invalid window time %q, want HH:MM", tod))
+			}
+		}
+	}
+	for _, step := range s.GetEscalationSteps() {
+		if step.GetFailingCycles() < 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("escalation step failing_cycles must be non-negative, got %d", step.GetFailingCycles()))
+		}
+		if step.GetChannel() == "" {
+			mErr = multierror.Append(mErr, errors.New("escalation step channel can't be empty"))
+		}
+		if err := validateChannelTarget(step.GetChannel(), step.GetTarget()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("escalation step: %w", err))
+		}
+	}
+	for idx, silence := range s.GetSilences() {
+		if err := validateSilence(silence); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("silence %d: %w", idx, err))
+		}
+	}
+	for _, cal := range s.GetSilenceCalendars() {
+		if _, err := gcs.NewPath(cal); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid silence calendar %q: %v", cal, err))
+		}
+	}
+	for _, route := range s.GetOwnerRoutes() {
+		if route.GetOwner() == "" {
+			mErr = multierror.Append(mErr, errors.New("owner route owner can't be empty"))
+		}
+		if route.GetTarget() == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("owner route for %q must specify a target", route.GetOwner()))
+		} else if err := validateChannelTarget(route.GetChannel(), route.GetTarget()); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("owner route for %q: %w", route.GetOwner(), err))
+		}
+	}
+	return mErr
+}
+
+func validateSilence(s *configpb.SilenceWindow) error {
+	if r := s.GetRecurring(); r != nil {
+		if s.GetStartTime() != "" || s.GetEndTime() != "" {
+			return errors.New("recurring silences cannot have a start_time or end_time")
+		}
+		for _, tod := range []string{r.GetStart(), r.GetEnd()} {
+			if tod == "" {
+				continue
+			}
+			if _, err := time.Parse("15:04", tod); err != nil {
+				return fmt.Errorf("invalid recurring time %q, want HH:MM", tod)
+			}
+		}
+		return nil
+	}
+	start, err := time.Parse(time.RFC3339, s.GetStartTime())
+	if err != nil {
+		return fmt.Errorf("invalid start_time %q: %v", s.GetStartTime(), err)
+	}
+	end, err := time.Parse(time.RFC3339, s.GetEndTime())
+	if err != nil {
+		return fmt.Errorf("invalid end_time %q: %v", s.GetEndTime(), err)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("end_time %s must be after start_time %s", s.GetEndTime(), s.GetStartTime())
+	}
+	return nil
+}
+
+// validateChannelTarget checks that chat webhook channels target an https URL.
+func validateChannelTarget(channel, target string) error {
+	switch channel {
+	case "teams", "google_chat":
+		if u, err := url.Parse(target); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%s target must be an https webhook URL, got %q", channel, target)
+		}
+	}
+	return nil
+}
+
+func validateDashboard(d *configpb.Dashboard) error {
+	var mErr error
+	if d.GetNotificationSchedule() != nil {
+		if err := validateNotificationSchedule(d.GetNotificationSchedule()); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	if err := validateMirrorPrefixes(d.GetMirrorPrefixes()); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	if _, err := time.LoadLocation(d.GetTimeZone()); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid time_zone %q: %v", d.GetTimeZone(), err))
+	}
+	if err := validateDisplayOptions(d.GetDisplayOptions()); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	if err := validateCommitStatus(d); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	return mErr
+}
+
+func validateCommitStatus(d *configpb.Dashboard) error {
+	opts := d.GetCommitStatus()
+	if opts == nil {
+		return nil
+	}
+	var mErr error
+	if parts := strings.Split(opts.GetRepo(), "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		mErr = multierror.Append(mErr, fmt.Errorf("commit_status.repo must be org/repo, got %q", opts.GetRepo()))
+	}
+	tabs := map[string]bool{}
+	for _, tab := range d.GetDashboardTab() {
+		tabs[tab.GetName()] = true
+	}
+	for _, tab := range opts.GetTabs() {
+		if !tabs[tab] {
+			mErr = multierror.Append(mErr, fmt.Errorf("commit_status.tabs has unknown tab %q", tab))
+		}
+	}
+	if opts.GetMaxAgeHours() < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("commit_status.max_age_hours should not be negative, got %d", opts.GetMaxAgeHours()))
+	}
+	return mErr
+}
+
+// maxDescriptionBytes limits the markdown description of a dashboard.
+const maxDescriptionBytes = 4096
+
+func validateDisplayOptions(opts *configpb.DisplayOptions) error {
+	if opts == nil {
+		return nil
+	}
+	var mErr error
+	if _, ok := configpb.DisplayOptions_SortOrder_name[int32(opts.GetDefaultSort())]; !ok {
+		mErr = multierror.Append(mErr, fmt.Errorf("unknown display_options.default_sort %d", opts.GetDefaultSort()))
+	}
+	if _, ok := configpb.DisplayOptions_Palette_name[int32(opts.GetPalette())]; !ok {
+		mErr = multierror.Append(mErr, fmt.Errorf("unknown display_options.palette %d", opts.GetPalette()))
+	}
+	if opts.GetHiddenColumns() < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("display_options.hidden_columns should not be negative, got %d", opts.GetHiddenColumns()))
+	}
+	if desc := opts.GetDescription(); len(desc) > maxDescriptionBytes {
+		mErr = multierror.Append(mErr, fmt.Errorf("display_options.description is %d bytes, limit is %d", len(desc), maxDescriptionBytes))
+	} else if !utf8.ValidString(desc) {
+		mErr = multierror.Append(mErr, errors.New("display_options.description is not valid UTF-8"))
+	}
+	return mErr
+}
+
+func validateMirrorPrefixes(prefixes []string) error {
+	var mErr error
+	for idx, prefix := range prefixes {
+		if prefix == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("mirror prefix %d is empty", idx))
+			continue
+		}
+		if _, err := gcs.NewPath(prefix); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid mirror prefix %q: %v", prefix, err))
+		}
+	}
 	return mErr
 }
 
@@ -416,7 +912,7 @@ func validateEntityConfigs(c *configpb.Configuration) error {
 		return multierror.Append(mErr, errors.New("got an empty config.Configuration"))
 	}
 
-	// At the moment, don't need to further validate Dashboards or DashboardGroups.
+	// At the moment, don't need to further validate DashboardGroups.
 	for _, tg := range c.GetTestGroups() {
 		if err := validateTestGroup(tg); err != nil {
 			mErr = multierror.Append(mErr, &ConfigError{tg.GetName(), "TestGroup", err.Error()})
@@ -424,6 +920,9 @@ func validateEntityConfigs(c *configpb.Configuration) error {
 	}
 
 	for _, d := range c.GetDashboards() {
+		if err := validateDashboard(d); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{d.GetName(), "Dashboard", err.Error()})
+		}
 		for _, dt := range d.DashboardTab {
 			if err := validateDashboardTab(dt); err != nil {
 				mErr = multierror.Append(mErr, &ConfigError{dt.GetName(), "DashboardTab", err.Error()})
@@ -468,6 +967,11 @@ func Validate(c *configpb.Configuration) error {
 		mErr = multierror.Append(mErr, err)
 	}
 
+	// Test groups must inherit from templates which exist, without cycles.
+	if err := validateTemplates(c); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
 	// Validate individual entities have reasonable, well-formed options set.
 	if err := validateEntityConfigs(c); err != nil {
 		mErr = multierror.Append(mErr, err)
@@ -483,6 +483,16 @@ func TestValidateTestGroup(t *testing.T) {
 				NumColumnsRecent: 1,
 			},
 		},
+		{
+			name: "Column readers do not need gcs_prefix",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				ColumnReader:     "plugin",
+			},
+		},
 		{
 			name: "Must have num_columns_recent",
 			testGroup: &configpb.TestGroup{
@@ -699,6 +709,58 @@ func TestValidateTestGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reject invalid column header transforms",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+						Transforms: []*configpb.TestGroup_ColumnHeader_Transform{
+							{Transform: &configpb.TestGroup_ColumnHeader_Transform_RegexCapture{RegexCapture: "(["}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reject empty column header transforms",
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+						Transforms:         []*configpb.TestGroup_ColumnHeader_Transform{{}},
+					},
+				},
+			},
+		},
+		{
+			name: "accept column header transforms",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "test_group",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+					{
+						ConfigurationValue: "Commit",
+						Transforms: []*configpb.TestGroup_ColumnHeader_Transform{
+							{Transform: &configpb.TestGroup_ColumnHeader_Transform_RegexCapture{RegexCapture: `^(.*\+[0-9a-f]{6})`}},
+							{Transform: &configpb.TestGroup_ColumnHeader_Transform_Truncate{Truncate: 20}},
+							{Transform: &configpb.TestGroup_ColumnHeader_Transform_RoundDigits{RoundDigits: 0}},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reject unformatted name format",
 			testGroup: &configpb.TestGroup{
@@ -749,121 +811,1164 @@ func TestValidateTestGroup(t *testing.T) {
 				},
 			},
 		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			err := validateTestGroup(test.testGroup)
-			pass := err == nil
-			if test.pass != pass {
-				t.Fatalf("test group config got pass = %v, want pass = %v: %v", pass, test.pass, err)
-			}
-		})
-	}
-}
-
-func TestInvalidEmails(t *testing.T) {
-	tests := []struct {
-		name      string
-		addresses string
-		pass      bool
-	}{
 		{
-			name:      "Addresses can't be blank",
-			addresses: "",
+			name: "accept uniquely named cell properties",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "props",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				CellProperties: []*configpb.CellProperty{
+					{Name: "owner"},
+					{Name: "case", Property: "testcase-id"},
+				},
+			},
 		},
 		{
-			name:      "Comma-separated addresses can't be blank",
-			addresses: ",",
+			name: "reject unnamed cell properties",
+			testGroup: &configpb.TestGroup{
+				Name:             "props",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				CellProperties: []*configpb.CellProperty{
+					{Property: "owner"},
+				},
+			},
 		},
 		{
-			name:      "Comma-separated addresses still can't be blank",
-			addresses: ",thing@email.com",
+			name: "accept test management exports of cell properties",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "export",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				CellProperties: []*configpb.CellProperty{
+					{Name: "case", Property: "testcase-id"},
+				},
+				TestManagementExport: &configpb.TestManagementExport{
+					System:           configpb.TestManagementExport_JIRA_XRAY,
+					Url:              "https://jira.example.com",
+					TestCaseProperty: "case",
+				},
+			},
 		},
 		{
-			name:      "no username",
-			addresses: "@email.com",
+			name: "reject test management exports of unknown properties",
+			testGroup: &configpb.TestGroup{
+				Name:             "export",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				TestManagementExport: &configpb.TestManagementExport{
+					System:           configpb.TestManagementExport_POLARION,
+					Url:              "https://polarion.example.com",
+					TestCaseProperty: "testcase-id",
+				},
+			},
 		},
 		{
-			name:      "no domain name",
-			addresses: "username",
+			name: "reject bad mirror prefixes",
+			testGroup: &configpb.TestGroup{
+				Name:             "mirrors",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MirrorPrefixes:   []string{"gs://replica/grid", "s3://elsewhere"},
+			},
 		},
 		{
-			name:      "@ but no domain name",
-			addresses: "username@",
+			name: "reject public grids without a prefix",
+			testGroup: &configpb.TestGroup{
+				Name:             "public",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				PublicGrid:       &configpb.PublicGrid{CacheControl: "public"},
+			},
 		},
 		{
-			name:      "too many @'s",
-			addresses: "hey@hello@greetings.com",
+			name: "reject invalid redactions",
+			testGroup: &configpb.TestGroup{
+				Name:             "redact",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				Redactions: []*configpb.Redaction{
+					{Regex: "token=("},
+				},
+			},
 		},
 		{
-			name:      "Valid Address",
-			addresses: "hey@greetings.com",
-			pass:      true,
+			name: "reject invalid issue link rules",
+			testGroup: &configpb.TestGroup{
+				Name:             "issues",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				IssueLinkRules: []*configpb.IssueLinkRule{
+					{Regex: `#(\d+`, Url: "https://github.com/org/repo/issues/${1}"},
+				},
+			},
 		},
 		{
-			name:      "Multiple Valid Addresses",
-			addresses: "hey@greetings.com,something@mail.com",
-			pass:      true,
+			name: "reject issue link rule without a url",
+			testGroup: &configpb.TestGroup{
+				Name:             "issues",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				IssueLinkRules: []*configpb.IssueLinkRule{
+					{Regex: `#(\d+)`},
+				},
+			},
 		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			err := validateEmails(test.addresses)
-			pass := err == nil
-			if test.pass != pass {
-				t.Fatalf("addresses (%s) got pass = %v, want pass = %v: %v", test.addresses, pass, test.pass, err)
-			}
-		})
-	}
-}
-
-func TestValidateDashboardTab(t *testing.T) {
-	tests := []struct {
-		name string
-		tab  *configpb.DashboardTab
-		pass bool
-	}{
 		{
-			name: "nil DashboardTab fails",
-			tab:  nil,
-			pass: false,
+			name: "accept issue link rules",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "issues",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				IssueLinkRules: []*configpb.IssueLinkRule{
+					{Regex: `#(\d+)`, Url: "https://github.com/org/repo/issues/${1}"},
+				},
+			},
 		},
 		{
-			name: "Dashboard Tabs must specify a Test Group Name",
-			tab: &configpb.DashboardTab{
-				Name: "tabby",
+			name: "reject negative clear_issues_after_passes",
+			testGroup: &configpb.TestGroup{
+				Name:                   "issues",
+				DaysOfResults:          1,
+				GcsPrefix:              "fake path",
+				NumColumnsRecent:       1,
+				ClearIssuesAfterPasses: -1,
 			},
 		},
 		{
-			name: "Dashboard Tabs must specify a Test Group Name",
-			tab: &configpb.DashboardTab{
-				Name:          "Summary",
-				TestGroupName: "test_group_1",
+			name: "reject broken_column_threshold above one",
+			testGroup: &configpb.TestGroup{
+				Name:                  "broken",
+				DaysOfResults:         1,
+				GcsPrefix:             "fake path",
+				NumColumnsRecent:      1,
+				BrokenColumnThreshold: 1.5,
 			},
 		},
 		{
-			name: "Tabular Names Regex must compile",
-			tab: &configpb.DashboardTab{
-				Name:              "tabby",
-				TestGroupName:     "test_group_1",
-				TabularNamesRegex: "([1!]",
+			name: "dictionary state_version",
+			testGroup: &configpb.TestGroup{
+				Name:             "compact",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				StateVersion:     2,
 			},
+			pass: true,
 		},
 		{
-			name: "Tabular Names Regex must have at least one capture group",
-			tab: &configpb.DashboardTab{
-				Name:              "tabby",
-				TestGroupName:     "test_group_1",
-				TabularNamesRegex: ".*",
+			name: "reject unknown state_version",
+			testGroup: &configpb.TestGroup{
+				Name:             "future",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				StateVersion:     3,
 			},
 		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			err := validateDashboardTab(test.tab)
-			pass := err == nil
-			if pass != test.pass {
-				t.Fatalf("Invalid dashboard tab config: %v", err)
+		{
+			name: "duration budgets",
+			testGroup: &configpb.TestGroup{
+				Name:             "budget",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				DurationBudgets: []*configpb.TestGroup_DurationBudget{
+					{MaxMinutes: 10},
+					{NameRegex: "^e2e", MaxMinutes: 60, Suite: true},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "reject duration budgets with a bad regex",
+			testGroup: &configpb.TestGroup{
+				Name:             "budget",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				DurationBudgets: []*configpb.TestGroup_DurationBudget{
+					{NameRegex: "(", MaxMinutes: 10},
+				},
+			},
+		},
+		{
+			name: "reject duration budgets without max_minutes",
+			testGroup: &configpb.TestGroup{
+				Name:             "budget",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				DurationBudgets: []*configpb.TestGroup_DurationBudget{
+					{NameRegex: "slow"},
+				},
+			},
+		},
+		{
+			name: "merged sources",
+			testGroup: &configpb.TestGroup{
+				Name:             "merged",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				SourceName:       "postsubmit",
+				MergedSources: []*configpb.TestGroup_MergedSource{
+					{
+						Name:            "presubmit",
+						GcsPrefix:       "other path",
+						MetadataFilters: map[string]string{"merged": "true"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "reject merged sources named like the group's own source",
+			testGroup: &configpb.TestGroup{
+				Name:             "merged",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				SourceName:       "postsubmit",
+				MergedSources: []*configpb.TestGroup_MergedSource{
+					{Name: "postsubmit", GcsPrefix: "other path"},
+				},
+			},
+		},
+		{
+			name: "reject merged sources without a gcs_prefix",
+			testGroup: &configpb.TestGroup{
+				Name:             "merged",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MergedSources: []*configpb.TestGroup_MergedSource{
+					{Name: "presubmit"},
+				},
+			},
+		},
+		{
+			name: "reject merged sources with a bad metadata filter",
+			testGroup: &configpb.TestGroup{
+				Name:             "merged",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MergedSources: []*configpb.TestGroup_MergedSource{
+					{Name: "presubmit", GcsPrefix: "other path", MetadataFilters: map[string]string{"merged": "("}},
+				},
+			},
+		},
+		{
+			name: "resultdb source",
+			testGroup: &configpb.TestGroup{
+				Name:             "luci",
+				DaysOfResults:    1,
+				ColumnReader:     "resultdb",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultdbConfig{
+						ResultdbConfig: &configpb.ResultDBConfig{Project: "chromium", Bucket: "ci", Builder: "linux-rel"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "reject resultdb sources without a builder",
+			testGroup: &configpb.TestGroup{
+				Name:             "luci",
+				DaysOfResults:    1,
+				ColumnReader:     "resultdb",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultdbConfig{
+						ResultdbConfig: &configpb.ResultDBConfig{Project: "chromium", Bucket: "ci"},
+					},
+				},
+			},
+		},
+		{
+			name: "reject resultdb sources without a column reader",
+			testGroup: &configpb.TestGroup{
+				Name:             "luci",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultdbConfig{
+						ResultdbConfig: &configpb.ResultDBConfig{Project: "chromium", Bucket: "ci", Builder: "linux-rel"},
+					},
+				},
+			},
+		},
+		{
+			name: "circleci source",
+			testGroup: &configpb.TestGroup{
+				Name:             "circle",
+				DaysOfResults:    1,
+				ColumnReader:     "circleci",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_CircleciConfig{
+						CircleciConfig: &configpb.CircleCIConfig{ProjectSlug: "gh/org/repo", Workflow: "test"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "reject circleci sources with a bad project slug",
+			testGroup: &configpb.TestGroup{
+				Name:             "circle",
+				DaysOfResults:    1,
+				ColumnReader:     "circleci",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_CircleciConfig{
+						CircleciConfig: &configpb.CircleCIConfig{ProjectSlug: "org/repo", Workflow: "test"},
+					},
+				},
+			},
+		},
+		{
+			name: "reject circleci sources without a workflow",
+			testGroup: &configpb.TestGroup{
+				Name:             "circle",
+				DaysOfResults:    1,
+				ColumnReader:     "circleci",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_CircleciConfig{
+						CircleciConfig: &configpb.CircleCIConfig{ProjectSlug: "gh/org/repo"},
+					},
+				},
+			},
+		},
+		{
+			name: "tekton source",
+			testGroup: &configpb.TestGroup{
+				Name:             "tekton",
+				DaysOfResults:    1,
+				ColumnReader:     "tekton",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_TektonConfig{
+						TektonConfig: &configpb.TektonConfig{Namespace: "ci", Pipeline: "build", ArtifactsPrefix: "gs://bucket/tekton"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "reject tekton sources without a pipeline",
+			testGroup: &configpb.TestGroup{
+				Name:             "tekton",
+				DaysOfResults:    1,
+				ColumnReader:     "tekton",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_TektonConfig{
+						TektonConfig: &configpb.TektonConfig{Namespace: "ci"},
+					},
+				},
+			},
+		},
+		{
+			name: "reject tekton sources with a bad artifacts prefix",
+			testGroup: &configpb.TestGroup{
+				Name:             "tekton",
+				DaysOfResults:    1,
+				ColumnReader:     "tekton",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_TektonConfig{
+						TektonConfig: &configpb.TektonConfig{Namespace: "ci", Pipeline: "build", ArtifactsPrefix: "http://bucket/tekton"},
+					},
+				},
+			},
+		},
+		{
+			name: "argo source",
+			testGroup: &configpb.TestGroup{
+				Name:             "argo",
+				DaysOfResults:    1,
+				ColumnReader:     "argo",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ArgoConfig{
+						ArgoConfig: &configpb.ArgoConfig{Namespace: "ci", WorkflowTemplate: "build", JunitArtifact: "junit"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "reject argo sources without a workflow template",
+			testGroup: &configpb.TestGroup{
+				Name:             "argo",
+				DaysOfResults:    1,
+				ColumnReader:     "argo",
+				NumColumnsRecent: 1,
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ArgoConfig{
+						ArgoConfig: &configpb.ArgoConfig{Namespace: "ci"},
+					},
+				},
+			},
+		},
+		{
+			name: "reject argo sources without a column reader",
+			testGroup: &configpb.TestGroup{
+				Name:             "argo",
+				DaysOfResults:    1,
+				NumColumnsRecent: 1,
+				GcsPrefix:        "bucket/argo",
+				ResultSource: &configpb.TestGroup_ResultSource{
+					ResultSourceConfig: &configpb.TestGroup_ResultSource_ArgoConfig{
+						ArgoConfig: &configpb.ArgoConfig{Namespace: "ci", WorkflowTemplate: "build"},
+					},
+				},
+			},
+		},
+		{
+			name: "column_metadata",
+			testGroup: &configpb.TestGroup{
+				Name:             "metadata",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnMetadata:   []string{"node_image", "pod.node", "pod.label.zone"},
+			},
+			pass: true,
+		},
+		{
+			name: "reject unknown podinfo column_metadata",
+			testGroup: &configpb.TestGroup{
+				Name:             "metadata",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnMetadata:   []string{"pod.kernel"},
+			},
+		},
+		{
+			name: "reject synthetic rows with the same name",
+			testGroup: &configpb.TestGroup{
+				Name:             "synthetic",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				PodRow:           &configpb.TestGroup_SyntheticRow{Name: "Overall"},
+			},
+		},
+		{
+			name: "disabled synthetic rows may share names",
+			testGroup: &configpb.TestGroup{
+				Name:             "synthetic",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				PodRow:           &configpb.TestGroup_SyntheticRow{Name: "Overall", Disable: true},
+			},
+			pass: true,
+		},
+		{
+			name: "reject duplicate column_metadata",
+			testGroup: &configpb.TestGroup{
+				Name:             "metadata",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ColumnMetadata:   []string{"node_image", "node_image"},
+			},
+		},
+		{
+			name: "order builds by column metadata",
+			testGroup: &configpb.TestGroup{
+				Name:               "ordered",
+				DaysOfResults:      1,
+				GcsPrefix:          "fake path",
+				NumColumnsRecent:   1,
+				ColumnMetadata:     []string{"revision"},
+				BuildOrder:         configpb.TestGroup_BUILD_ORDER_METADATA,
+				BuildOrderMetadata: "revision",
+			},
+			pass: true,
+		},
+		{
+			name: "reject build_order_metadata outside column_metadata",
+			testGroup: &configpb.TestGroup{
+				Name:               "ordered",
+				DaysOfResults:      1,
+				GcsPrefix:          "fake path",
+				NumColumnsRecent:   1,
+				BuildOrder:         configpb.TestGroup_BUILD_ORDER_METADATA,
+				BuildOrderMetadata: "revision",
+			},
+		},
+		{
+			name: "reject metadata build_order without a key",
+			testGroup: &configpb.TestGroup{
+				Name:             "ordered",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				BuildOrder:       configpb.TestGroup_BUILD_ORDER_METADATA,
+			},
+		},
+		{
+			name: "reject build_order_metadata with other orders",
+			testGroup: &configpb.TestGroup{
+				Name:               "ordered",
+				DaysOfResults:      1,
+				GcsPrefix:          "fake path",
+				NumColumnsRecent:   1,
+				ColumnMetadata:     []string{"revision"},
+				BuildOrder:         configpb.TestGroup_BUILD_ORDER_LEXICAL,
+				BuildOrderMetadata: "revision",
+			},
+		},
+		{
+			name: "reject invalid update_interval",
+			testGroup: &configpb.TestGroup{
+				Name:             "interval",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				UpdateInterval:   "hourly",
+			},
+		},
+		{
+			name: "accept update_interval and paused",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "interval",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				UpdateInterval:   "6h",
+				Paused:           true,
+			},
+		},
+		{
+			name: "reject archival without a prefix",
+			testGroup: &configpb.TestGroup{
+				Name:             "archive",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ArchiveAfterDays: 30,
+			},
+		},
+		{
+			name: "accept archival",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "archive",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ArchiveAfterDays: 30,
+				ArchivePrefix:    "gs://cold/archive",
+			},
+		},
+		{
+			name: "reject column archival without a prefix",
+			testGroup: &configpb.TestGroup{
+				Name:                    "archive",
+				DaysOfResults:           365,
+				GcsPrefix:               "fake path",
+				NumColumnsRecent:        1,
+				ArchiveColumnsAfterDays: 30,
+			},
+		},
+		{
+			name: "reject column archival after days_of_results",
+			testGroup: &configpb.TestGroup{
+				Name:                    "archive",
+				DaysOfResults:           30,
+				GcsPrefix:               "fake path",
+				NumColumnsRecent:        1,
+				ArchiveColumnsAfterDays: 30,
+				ArchivePrefix:           "gs://cold/archive",
+			},
+		},
+		{
+			name: "accept column archival",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:                    "archive",
+				DaysOfResults:           365,
+				GcsPrefix:               "fake path",
+				NumColumnsRecent:        1,
+				ArchiveColumnsAfterDays: 30,
+				ArchivePrefix:           "gs://cold/archive",
+			},
+		},
+		{
+			name: "reject negative new_test_days",
+			testGroup: &configpb.TestGroup{
+				Name:             "new",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				NewTestDays:      -1,
+			},
+		},
+		{
+			name: "reject negative tombstone_columns",
+			testGroup: &configpb.TestGroup{
+				Name:             "tombstones",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				TombstoneColumns: -1,
+			},
+		},
+		{
+			name: "accept result sampling",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "sampled",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ResultSampling: &configpb.TestGroup_ResultSampling{
+					EveryNthBuild:    5,
+					MaxBuildsPerHour: 4,
+				},
+			},
+		},
+		{
+			name: "reject negative result sampling",
+			testGroup: &configpb.TestGroup{
+				Name:             "sampled",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				ResultSampling: &configpb.TestGroup_ResultSampling{
+					MaxBuildsPerHour: -1,
+				},
+			},
+		},
+		{
+			name: "accept renames",
+			pass: true,
+			testGroup: &configpb.TestGroup{
+				Name:             "renames",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				RenameRules: []*configpb.RenameRule{
+					{Regex: `^TestOld(\w+)$`, Replacement: "TestNew${1}"},
+				},
+				RenameSimilarity: 0.9,
+				RenameMapping:    "gs://bucket/renames.json",
+			},
+		},
+		{
+			name: "reject invalid rename rules",
+			testGroup: &configpb.TestGroup{
+				Name:             "renames",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				RenameRules: []*configpb.RenameRule{
+					{Regex: `(`},
+				},
+			},
+		},
+		{
+			name: "reject rename_similarity above 1",
+			testGroup: &configpb.TestGroup{
+				Name:             "renames",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				RenameSimilarity: 1.5,
+			},
+		},
+		{
+			name: "reject invalid rename_mapping",
+			testGroup: &configpb.TestGroup{
+				Name:             "renames",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				RenameMapping:    "http://example.com/renames.json",
+			},
+		},
+		{
+			name: "reject negative max_rows",
+			testGroup: &configpb.TestGroup{
+				Name:             "rows",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				MaxRows:          -1,
+			},
+		},
+		{
+			name: "reject duplicate cell properties",
+			testGroup: &configpb.TestGroup{
+				Name:             "props",
+				DaysOfResults:    1,
+				GcsPrefix:        "fake path",
+				NumColumnsRecent: 1,
+				CellProperties: []*configpb.CellProperty{
+					{Name: "owner"},
+					{Name: "owner", Property: "team"},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTestGroup(test.testGroup)
+			pass := err == nil
+			if test.pass != pass {
+				t.Fatalf("test group config got pass = %v, want pass = %v: %v", pass, test.pass, err)
+			}
+		})
+	}
+}
+
+func TestInvalidEmails(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses string
+		pass      bool
+	}{
+		{
+			name:      "Addresses can't be blank",
+			addresses: "",
+		},
+		{
+			name:      "Comma-separated addresses can't be blank",
+			addresses: ",",
+		},
+		{
+			name:      "Comma-separated addresses still can't be blank",
+			addresses: ",thing@email.com",
+		},
+		{
+			name:      "no username",
+			addresses: "@email.com",
+		},
+		{
+			name:      "no domain name",
+			addresses: "username",
+		},
+		{
+			name:      "@ but no domain name",
+			addresses: "username@",
+		},
+		{
+			name:      "too many @'s",
+			addresses: "hey@hello@greetings.com",
+		},
+		{
+			name:      "Valid Address",
+			addresses: "hey@greetings.com",
+			pass:      true,
+		},
+		{
+			name:      "Multiple Valid Addresses",
+			addresses: "hey@greetings.com,something@mail.com",
+			pass:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateEmails(test.addresses)
+			pass := err == nil
+			if test.pass != pass {
+				t.Fatalf("addresses (%s) got pass = %v, want pass = %v: %v", test.addresses, pass, test.pass, err)
+			}
+		})
+	}
+}
+
+func TestValidateDashboardTab(t *testing.T) {
+	tests := []struct {
+		name string
+		tab  *configpb.DashboardTab
+		pass bool
+	}{
+		{
+			name: "nil DashboardTab fails",
+			tab:  nil,
+			pass: false,
+		},
+		{
+			name: "Dashboard Tabs must specify a Test Group Name",
+			tab: &configpb.DashboardTab{
+				Name: "tabby",
+			},
+		},
+		{
+			name: "Dashboard Tabs must specify a Test Group Name",
+			tab: &configpb.DashboardTab{
+				Name:          "Summary",
+				TestGroupName: "test_group_1",
+			},
+		},
+		{
+			name: "Tabular Names Regex must compile",
+			tab: &configpb.DashboardTab{
+				Name:              "tabby",
+				TestGroupName:     "test_group_1",
+				TabularNamesRegex: "([1!]",
+			},
+		},
+		{
+			name: "Tabular Names Regex must have at least one capture group",
+			tab: &configpb.DashboardTab{
+				Name:              "tabby",
+				TestGroupName:     "test_group_1",
+				TabularNamesRegex: ".*",
+			},
+		},
+		{
+			name: "Failure windows must hold enough failures to alert",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				AlertOptions: &configpb.DashboardTabAlertOptions{
+					NumFailuresToAlert:        3,
+					NumResultsInFailureWindow: 2,
+				},
+			},
+		},
+		{
+			name: "Failure windows pass",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				AlertOptions: &configpb.DashboardTabAlertOptions{
+					NumFailuresToAlert:        3,
+					NumResultsInFailureWindow: 10,
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "New test grace must be non-negative",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				AlertOptions:  &configpb.DashboardTabAlertOptions{NewTestGraceHours: -1},
+			},
+		},
+		{
+			name: "Column windows must be non-negative",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				ColumnWindow:  &configpb.DashboardTab_ColumnWindow{Days: -1},
+			},
+		},
+		{
+			name: "Column windows pass",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				ColumnWindow:  &configpb.DashboardTab_ColumnWindow{NumColumns: 100, Days: 7},
+			},
+			pass: true,
+		},
+		{
+			name: "Error budgets must be non-negative",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				ErrorBudget:   &configpb.DashboardTab_ErrorBudget{AllowedFailingColumns: -1},
+			},
+		},
+		{
+			name: "Error budgets pass",
+			tab: &configpb.DashboardTab{
+				Name:          "tabby",
+				TestGroupName: "test_group_1",
+				ErrorBudget:   &configpb.DashboardTab_ErrorBudget{AllowedFailingColumns: 10, Days: 28},
+			},
+			pass: true,
+		},
+		{
+			name: "Row link templates require a url",
+			tab: &configpb.DashboardTab{
+				Name:             "tabby",
+				TestGroupName:    "test_group_1",
+				RowLinkTemplates: []*configpb.LinkTemplate{{Name: "source"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDashboardTab(test.tab)
+			pass := err == nil
+			if pass != test.pass {
+				t.Fatalf("Invalid dashboard tab config: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateDashboard(t *testing.T) {
+	tests := []struct {
+		name string
+		dash *configpb.Dashboard
+		pass bool
+	}{
+		{
+			name: "Dashboards without a notification schedule pass",
+			dash: &configpb.Dashboard{Name: "dash"},
+			pass: true,
+		},
+		{
+			name: "Valid notification schedules pass",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					TimeZone: "Europe/Berlin",
+					Windows: []*configpb.NotificationWindow{
+						{Start: "09:00", End: "17:00"},
+					},
+					EscalationSteps: []*configpb.EscalationStep{
+						{FailingCycles: 3, Channel: "page"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "Time zones must exist",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					TimeZone: "Nowhere/Special",
+				},
+			},
+		},
+		{
+			name: "Dashboard time zones and date formats pass",
+			dash: &configpb.Dashboard{
+				Name:       "dash",
+				TimeZone:   "Europe/Berlin",
+				DateFormat: "%d.%m. %H:%M",
+			},
+			pass: true,
+		},
+		{
+			name: "Dashboard time zones must exist",
+			dash: &configpb.Dashboard{
+				Name:     "dash",
+				TimeZone: "Nowhere/Special",
+			},
+		},
+		{
+			name: "Display options pass",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				DisplayOptions: &configpb.DisplayOptions{
+					DefaultSort:   configpb.DisplayOptions_FAILURES_FIRST,
+					HiddenColumns: 10,
+					Palette:       configpb.DisplayOptions_DEUTERANOPIA,
+					Description:   "Owned by **sig-node**.",
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "Commit statuses pass",
+			dash: &configpb.Dashboard{
+				Name:         "dash",
+				DashboardTab: []*configpb.DashboardTab{{Name: "tab"}},
+				CommitStatus: &configpb.CommitStatusOptions{
+					Repo:        "org/repo",
+					Tabs:        []string{"tab"},
+					MaxAgeHours: 12,
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "Commit statuses require an org/repo",
+			dash: &configpb.Dashboard{
+				Name:         "dash",
+				CommitStatus: &configpb.CommitStatusOptions{Repo: "repo"},
+			},
+		},
+		{
+			name: "Commit status tabs must exist",
+			dash: &configpb.Dashboard{
+				Name:         "dash",
+				DashboardTab: []*configpb.DashboardTab{{Name: "tab"}},
+				CommitStatus: &configpb.CommitStatusOptions{
+					Repo: "org/repo",
+					Tabs: []string{"other"},
+				},
+			},
+		},
+		{
+			name: "Commit status max age must not be negative",
+			dash: &configpb.Dashboard{
+				Name:         "dash",
+				CommitStatus: &configpb.CommitStatusOptions{Repo: "org/repo", MaxAgeHours: -1},
+			},
+		},
+		{
+			name: "Display options must use known enums",
+			dash: &configpb.Dashboard{
+				Name:           "dash",
+				DisplayOptions: &configpb.DisplayOptions{Palette: 42},
+			},
+		},
+		{
+			name: "Hidden columns must not be negative",
+			dash: &configpb.Dashboard{
+				Name:           "dash",
+				DisplayOptions: &configpb.DisplayOptions{HiddenColumns: -1},
+			},
+		},
+		{
+			name: "Descriptions are limited",
+			dash: &configpb.Dashboard{
+				Name:           "dash",
+				DisplayOptions: &configpb.DisplayOptions{Description: strings.Repeat("x", maxDescriptionBytes+1)},
+			},
+		},
+		{
+			name: "Descriptions must be UTF-8",
+			dash: &configpb.Dashboard{
+				Name:           "dash",
+				DisplayOptions: &configpb.DisplayOptions{Description: "\xff"},
+			},
+		},
+		{
+			name: "Window times must be HH:MM",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					Windows: []*configpb.NotificationWindow{
+						{Start: "9am", End: "17:00"},
+					},
+				},
+			},
+		},
+		{
+			name: "Chat webhooks pass",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					EscalationSteps: []*configpb.EscalationStep{
+						{Channel: "teams", Target: "https://example.webhook.office.com/webhookb2/abc"},
+						{Channel: "google_chat", Target: "https://chat.googleapis.com/v1/spaces/abc/messages?key=k"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "Chat webhooks must be https URLs",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					EscalationSteps: []*configpb.EscalationStep{
+						{Channel: "teams", Target: "#team-channel"},
+					},
+				},
+			},
+		},
+		{
+			name: "Owner routes pass",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					OwnerRoutes: []*configpb.OwnerRoute{
+						{Owner: "team-storage", Channel: "email", Target: "storage@example.com"},
+					},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "Owner routes must specify a target",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					OwnerRoutes: []*configpb.OwnerRoute{
+						{Owner: "team-storage"},
+					},
+				},
+			},
+		},
+		{
+			name: "Silences pass",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					Silences: []*configpb.SilenceWindow{
+						{Recurring: &configpb.NotificationWindow{Start: "20:00", End: "22:00"}},
+						{StartTime: "2021-06-01T20:00:00Z", EndTime: "2021-06-02T02:00:00+02:00"},
+					},
+					SilenceCalendars: []string{"gs://bucket/maintenance.ics"},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "One-off silences must end after they start",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					Silences: []*configpb.SilenceWindow{
+						{StartTime: "2021-06-01T20:00:00Z", EndTime: "2021-06-01T19:00:00Z"},
+					},
+				},
+			},
+		},
+		{
+			name: "Silence calendars must be paths",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					SilenceCalendars: []string{"https://calendar.example/maintenance.ics"},
+				},
+			},
+		},
+		{
+			name: "Escalation steps must specify a channel",
+			dash: &configpb.Dashboard{
+				Name: "dash",
+				NotificationSchedule: &configpb.NotificationSchedule{
+					EscalationSteps: []*configpb.EscalationStep{
+						{FailingCycles: 1},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDashboard(test.dash)
+			pass := err == nil
+			if pass != test.pass {
+				t.Fatalf("Invalid dashboard config: %v", err)
 			}
 		})
 	}
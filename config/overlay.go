@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// OverlayWildcard is the entity name an overlay uses to change every entity of its type.
+const OverlayWildcard = "*"
+
+// PrefixRewrite replaces the leading From of each gcs_prefix with To.
+type PrefixRewrite struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Overlay adjusts a base configuration for an environment, such as staging.
+//
+// Each entity in the overlay replaces the fields it sets on the base entity
+// with the same name, or on every base entity of its type when named "*".
+// Dashboard tabs are matched by name within their dashboard the same way.
+// Entities which match nothing are added.
+type Overlay struct {
+	*configpb.Configuration
+
+	// GCSPrefixRewrites apply to the gcs_prefix of each test group and its
+	// merged sources after the entities are overlaid.
+	GCSPrefixRewrites []PrefixRewrite `json:"gcs_prefix_rewrites,omitempty"`
+}
+
+// overlayFields sets each field of dst to the value src sets, other than skipped fields.
+func overlayFields(dst, src proto.Message, skip ...protoreflect.Name) {
+	d := proto.MessageReflect(dst)
+	s := proto.MessageReflect(proto.Clone(src))
+	s.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		for _, name := range skip {
+			if fd.Name() == name {
+				return true
+			}
+		}
+		d.Set(fd, v)
+		return true
+	})
+}
+
+// matching returns the indices of the names matching the overlay name.
+func matching(names []string, name string) []int {
+	var out []int
+	for i, n := range names {
+		if name == OverlayWildcard || n == name {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func overlayTestGroups(base []*configpb.TestGroup, overlays []*configpb.TestGroup) []*configpb.TestGroup {
+	for _, o := range overlays {
+		names := make([]string, len(base))
+		for i, tg := range base {
+			names[i] = tg.Name
+		}
+		idx := matching(names, o.Name)
+		if len(idx) == 0 && o.Name != OverlayWildcard {
+			base = append(base, proto.Clone(o).(*configpb.TestGroup))
+			continue
+		}
+		for _, i := range idx {
+			overlayFields(base[i], o, "name")
+		}
+	}
+	return base
+}
+
+func overlayTabs(base []*configpb.DashboardTab, overlays []*configpb.DashboardTab) []*configpb.DashboardTab {
+	for _, o := range overlays {
+		names := make([]string, len(base))
+		for i, dt := range base {
+			names[i] = dt.Name
+		}
+		idx := matching(names, o.Name)
+		if len(idx) == 0 && o.Name != OverlayWildcard {
+			base = append(base, proto.Clone(o).(*configpb.DashboardTab))
+			continue
+		}
+		for _, i := range idx {
+			overlayFields(base[i], o, "name")
+		}
+	}
+	return base
+}
+
+func overlayDashboards(base []*configpb.Dashboard, overlays []*configpb.Dashboard) []*configpb.Dashboard {
+	for _, o := range overlays {
+		names := make([]string, len(base))
+		for i, d := range base {
+			names[i] = d.Name
+		}
+		idx := matching(names, o.Name)
+		if len(idx) == 0 && o.Name != OverlayWildcard {
+			base = append(base, proto.Clone(o).(*configpb.Dashboard))
+			continue
+		}
+		for _, i := range idx {
+			overlayFields(base[i], o, "name", "dashboard_tab")
+			base[i].DashboardTab = overlayTabs(base[i].DashboardTab, o.DashboardTab)
+		}
+	}
+	return base
+}
+
+func overlayDashboardGroups(base []*configpb.DashboardGroup, overlays []*configpb.DashboardGroup) []*configpb.DashboardGroup {
+	for _, o := range overlays {
+		names := make([]string, len(base))
+		for i, dg := range base {
+			names[i] = dg.Name
+		}
+		idx := matching(names, o.Name)
+		if len(idx) == 0 && o.Name != OverlayWildcard {
+			base = append(base, proto.Clone(o).(*configpb.DashboardGroup))
+			continue
+		}
+		for _, i := range idx {
+			overlayFields(base[i], o, "name")
+		}
+	}
+	return base
+}
+
+// rewritePrefix applies the first matching rewrite to each comma-separated prefix.
+func rewritePrefix(prefixes string, rewrites []PrefixRewrite) string {
+	if prefixes == "" {
+		return prefixes
+	}
+	parts := strings.Split(prefixes, ",")
+	for i, p := range parts {
+		for _, r := range rewrites {
+			if strings.HasPrefix(p, r.From) {
+				parts[i] = r.To + strings.TrimPrefix(p, r.From)
+				break
+			}
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ApplyOverlays returns a copy of the base configuration with each overlay applied in order.
+func ApplyOverlays(base *configpb.Configuration, overlays ...Overlay) (*configpb.Configuration, error) {
+	if base == nil {
+		return nil, errors.New("got an empty config.Configuration")
+	}
+	cfg := proto.Clone(base).(*configpb.Configuration)
+	for i, o := range overlays {
+		for _, r := range o.GCSPrefixRewrites {
+			if r.From == "" {
+				return nil, fmt.Errorf("overlay %d: gcs_prefix rewrite to %q has an empty from", i, r.To)
+			}
+		}
+		if c := o.Configuration; c != nil {
+			cfg.TestGroups = overlayTestGroups(cfg.TestGroups, c.TestGroups)
+			cfg.Dashboards = overlayDashboards(cfg.Dashboards, c.Dashboards)
+			cfg.DashboardGroups = overlayDashboardGroups(cfg.DashboardGroups, c.DashboardGroups)
+			cfg.TestGroupTemplates = overlayTestGroups(cfg.TestGroupTemplates, c.TestGroupTemplates)
+		}
+		if len(o.GCSPrefixRewrites) == 0 {
+			continue
+		}
+		for _, tg := range cfg.TestGroups {
+			tg.GcsPrefix = rewritePrefix(tg.GcsPrefix, o.GCSPrefixRewrites)
+			for _, ms := range tg.MergedSources {
+				ms.GcsPrefix = rewritePrefix(ms.GcsPrefix, o.GCSPrefixRewrites)
+			}
+		}
+	}
+	return cfg, nil
+}
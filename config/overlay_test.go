@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestApplyOverlays(t *testing.T) {
+	base := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{Name: "foo", GcsPrefix: "prod-bucket/logs/foo", DaysOfResults: 7, NumColumnsRecent: 10},
+			{
+				Name:          "bar",
+				GcsPrefix:     "prod-bucket/logs/bar,other-bucket/bar",
+				MergedSources: []*configpb.TestGroup_MergedSource{{Name: "pr", GcsPrefix: "prod-bucket/pr/bar"}},
+			},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{
+						Name:          "foo",
+						TestGroupName: "foo",
+						AlertOptions:  &configpb.DashboardTabAlertOptions{AlertMailToAddresses: "prod@example.com"},
+					},
+					{Name: "bar", TestGroupName: "bar"},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		name     string
+		overlays []Overlay
+		want     *configpb.Configuration
+		wantErr  bool
+	}{
+		{
+			name: "no overlays",
+			want: base,
+		},
+		{
+			name: "overlay named entities",
+			overlays: []Overlay{{
+				Configuration: &configpb.Configuration{
+					TestGroups: []*configpb.TestGroup{{Name: "foo", DaysOfResults: 1}},
+					Dashboards: []*configpb.Dashboard{{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{{
+							Name:         "foo",
+							AlertOptions: &configpb.DashboardTabAlertOptions{AlertMailToAddresses: "staging@example.com"},
+						}},
+					}},
+				},
+			}},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "foo", GcsPrefix: "prod-bucket/logs/foo", DaysOfResults: 1, NumColumnsRecent: 10},
+					base.TestGroups[1],
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:          "foo",
+								TestGroupName: "foo",
+								AlertOptions:  &configpb.DashboardTabAlertOptions{AlertMailToAddresses: "staging@example.com"},
+							},
+							{Name: "bar", TestGroupName: "bar"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "wildcards and additions in order",
+			overlays: []Overlay{
+				{
+					Configuration: &configpb.Configuration{
+						TestGroups: []*configpb.TestGroup{{Name: OverlayWildcard, NumColumnsRecent: 3}},
+					},
+				},
+				{
+					Configuration: &configpb.Configuration{
+						TestGroups: []*configpb.TestGroup{{Name: "bar", NumColumnsRecent: 5}, {Name: "new", GcsPrefix: "staging/new"}},
+						Dashboards: []*configpb.Dashboard{{
+							Name:         OverlayWildcard,
+							DashboardTab: []*configpb.DashboardTab{{Name: OverlayWildcard, NumColumnsRecent: 2}},
+						}},
+					},
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "foo", GcsPrefix: "prod-bucket/logs/foo", DaysOfResults: 7, NumColumnsRecent: 3},
+					{
+						Name:             "bar",
+						GcsPrefix:        "prod-bucket/logs/bar,other-bucket/bar",
+						MergedSources:    []*configpb.TestGroup_MergedSource{{Name: "pr", GcsPrefix: "prod-bucket/pr/bar"}},
+						NumColumnsRecent: 5,
+					},
+					{Name: "new", GcsPrefix: "staging/new"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{
+								Name:             "foo",
+								TestGroupName:    "foo",
+								AlertOptions:     &configpb.DashboardTabAlertOptions{AlertMailToAddresses: "prod@example.com"},
+								NumColumnsRecent: 2,
+							},
+							{Name: "bar", TestGroupName: "bar", NumColumnsRecent: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "rewrite prefixes",
+			overlays: []Overlay{{
+				GCSPrefixRewrites: []PrefixRewrite{
+					{From: "prod-bucket/logs/", To: "staging-bucket/"},
+					{From: "prod-bucket/", To: "staging-bucket/other/"},
+				},
+			}},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "foo", GcsPrefix: "staging-bucket/foo", DaysOfResults: 7, NumColumnsRecent: 10},
+					{
+						Name:          "bar",
+						GcsPrefix:     "staging-bucket/bar,other-bucket/bar",
+						MergedSources: []*configpb.TestGroup_MergedSource{{Name: "pr", GcsPrefix: "staging-bucket/other/pr/bar"}},
+					},
+				},
+				Dashboards: base.Dashboards,
+			},
+		},
+		{
+			name:     "empty rewrite",
+			overlays: []Overlay{{GCSPrefixRewrites: []PrefixRewrite{{To: "oops"}}}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := proto.Clone(base)
+			got, err := ApplyOverlays(base, tc.overlays...)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("ApplyOverlays() got unexpected error: %v", err)
+				}
+			case tc.wantErr:
+				t.Error("ApplyOverlays() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("ApplyOverlays() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(orig, base, protocmp.Transform()); diff != "" {
+				t.Errorf("ApplyOverlays() modified the base (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	multierror "github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// InheritanceCycleError is an error for templates which inherit from themselves.
+type InheritanceCycleError struct {
+	Chain []string
+}
+
+func (e InheritanceCycleError) Error() string {
+	return fmt.Sprintf("test group templates inherit from themselves: %s", strings.Join(e.Chain, " -> "))
+}
+
+// templateChain returns the templates a test group inherits from, nearest first.
+func templateChain(tg *configpb.TestGroup, templates map[string]*configpb.TestGroup) ([]*configpb.TestGroup, error) {
+	var chain []*configpb.TestGroup
+	names := []string{tg.GetName()}
+	seen := map[string]bool{}
+	for name := tg.GetInherits(); name != ""; {
+		names = append(names, name)
+		if seen[name] {
+			return nil, InheritanceCycleError{names}
+		}
+		seen[name] = true
+		t, ok := templates[name]
+		if !ok {
+			return nil, MissingEntityError{name, "TestGroupTemplate"}
+		}
+		chain = append(chain, t)
+		name = t.GetInherits()
+	}
+	return chain, nil
+}
+
+// inherit sets each field of tg which is unset to the value in template.
+//
+// Fields are copied whole, so a group setting any part of a message or list
+// replaces the template's value rather than merging with it.
+func inherit(tg, template *configpb.TestGroup) {
+	dst := proto.MessageReflect(tg)
+	src := proto.MessageReflect(proto.Clone(template))
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch fd.Name() {
+		case "name", "inherits":
+			return true
+		}
+		if !dst.Has(fd) {
+			dst.Set(fd, v)
+		}
+		return true
+	})
+}
+
+// InheritTestGroups fills the unset fields of each test group from the chain
+// of templates it inherits, so every group is fully resolved after loading.
+//
+// Returns an error for groups inheriting from missing templates or from a cycle.
+func InheritTestGroups(c *configpb.Configuration) error {
+	if c == nil {
+		return errors.New("got an empty config.Configuration")
+	}
+	templates := make(map[string]*configpb.TestGroup, len(c.GetTestGroupTemplates()))
+	for _, t := range c.GetTestGroupTemplates() {
+		templates[t.GetName()] = t
+	}
+	var mErr error
+	for _, tg := range c.GetTestGroups() {
+		chain, err := templateChain(tg, templates)
+		if err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{tg.GetName(), "TestGroup", err.Error()})
+			continue
+		}
+		for _, t := range chain {
+			inherit(tg, t)
+		}
+	}
+	return mErr
+}
+
+// validateTemplates checks that test groups and templates inherit from
+// templates which exist without forming a cycle.
+func validateTemplates(c *configpb.Configuration) error {
+	var mErr error
+	templates := map[string]*configpb.TestGroup{}
+	var names []string
+	for _, t := range c.GetTestGroupTemplates() {
+		if t.GetName() == "" {
+			mErr = multierror.Append(mErr, &ConfigError{"", "TestGroupTemplate", "name is empty"})
+		}
+		templates[t.GetName()] = t
+		names = append(names, t.GetName())
+	}
+	if err := validateUnique(names, "TestGroupTemplate"); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	for _, t := range c.GetTestGroupTemplates() {
+		if _, err := templateChain(t, templates); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{t.GetName(), "TestGroupTemplate", err.Error()})
+		}
+	}
+	for _, tg := range c.GetTestGroups() {
+		if _, err := templateChain(tg, templates); err != nil {
+			mErr = multierror.Append(mErr, &ConfigError{tg.GetName(), "TestGroup", err.Error()})
+		}
+	}
+	return mErr
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestInheritTestGroups(t *testing.T) {
+	templates := []*configpb.TestGroup{
+		{
+			Name:             "base",
+			DaysOfResults:    7,
+			NumColumnsRecent: 10,
+			ColumnHeader:     []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: "node_os_image"}},
+			IgnoreSkip:       true,
+		},
+		{
+			Name:         "gke",
+			Inherits:     "base",
+			GcsPrefix:    "bucket/gke",
+			ColumnHeader: []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: "cluster"}},
+		},
+		{Name: "loop", Inherits: "loop"},
+	}
+	cases := []struct {
+		name    string
+		group   *configpb.TestGroup
+		want    *configpb.TestGroup
+		wantErr bool
+	}{
+		{
+			name:  "no template",
+			group: &configpb.TestGroup{Name: "foo", DaysOfResults: 1},
+			want:  &configpb.TestGroup{Name: "foo", DaysOfResults: 1},
+		},
+		{
+			name:  "fill unset fields",
+			group: &configpb.TestGroup{Name: "foo", Inherits: "base", DaysOfResults: 1},
+			want: &configpb.TestGroup{
+				Name:             "foo",
+				Inherits:         "base",
+				DaysOfResults:    1,
+				NumColumnsRecent: 10,
+				ColumnHeader:     []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: "node_os_image"}},
+				IgnoreSkip:       true,
+			},
+		},
+		{
+			name:  "nearest template wins",
+			group: &configpb.TestGroup{Name: "foo", Inherits: "gke"},
+			want: &configpb.TestGroup{
+				Name:             "foo",
+				Inherits:         "gke",
+				GcsPrefix:        "bucket/gke",
+				DaysOfResults:    7,
+				NumColumnsRecent: 10,
+				ColumnHeader:     []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: "cluster"}},
+				IgnoreSkip:       true,
+			},
+		},
+		{
+			name: "lists replace the template's",
+			group: &configpb.TestGroup{
+				Name:         "foo",
+				Inherits:     "base",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{{Label: "commit"}},
+			},
+			want: &configpb.TestGroup{
+				Name:             "foo",
+				Inherits:         "base",
+				DaysOfResults:    7,
+				NumColumnsRecent: 10,
+				ColumnHeader:     []*configpb.TestGroup_ColumnHeader{{Label: "commit"}},
+				IgnoreSkip:       true,
+			},
+		},
+		{
+			name:    "missing template",
+			group:   &configpb.TestGroup{Name: "foo", Inherits: "missing"},
+			wantErr: true,
+		},
+		{
+			name:    "cycle",
+			group:   &configpb.TestGroup{Name: "foo", Inherits: "loop"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &configpb.Configuration{
+				TestGroups:         []*configpb.TestGroup{tc.group},
+				TestGroupTemplates: templates,
+			}
+			err := InheritTestGroups(cfg)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("InheritTestGroups() failed to return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InheritTestGroups() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, cfg.TestGroups[0], protocmp.Transform()); diff != "" {
+				t.Errorf("InheritTestGroups() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if templates[1].DaysOfResults != 0 {
+		t.Errorf("InheritTestGroups() modified a template: %v", templates[1])
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	cases := []struct {
+		name      string
+		groups    []*configpb.TestGroup
+		templates []*configpb.TestGroup
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			groups:    []*configpb.TestGroup{{Name: "foo", Inherits: "a"}},
+			templates: []*configpb.TestGroup{{Name: "a", Inherits: "b"}, {Name: "b"}},
+		},
+		{
+			name:    "missing template",
+			groups:  []*configpb.TestGroup{{Name: "foo", Inherits: "a"}},
+			wantErr: true,
+		},
+		{
+			name:      "unused cycle",
+			templates: []*configpb.TestGroup{{Name: "a", Inherits: "b"}, {Name: "b", Inherits: "a"}},
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate names",
+			templates: []*configpb.TestGroup{{Name: "a"}, {Name: "A"}},
+			wantErr:   true,
+		},
+		{
+			name:      "unnamed template",
+			templates: []*configpb.TestGroup{{DaysOfResults: 1}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &configpb.Configuration{
+				TestGroups:         tc.groups,
+				TestGroupTemplates: tc.templates,
+			}
+			err := validateTemplates(cfg)
+			if got := err != nil; got != tc.wantErr {
+				t.Errorf("validateTemplates() got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
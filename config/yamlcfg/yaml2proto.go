@@ -87,8 +87,17 @@ func pathDefault(path string, defaultFiles map[string]DefaultConfiguration, defa
 //     If this directory has a default(s).yaml file, apply it to all configured entities,
 // 		 after applying defaults from defaultPath.
 // Optionally, defaultPath points to default setting YAML
+// Test groups which inherit from a test_group_templates entry are resolved
+// after every file is read.
 // Returns a configuration proto containing the data from all of those sources
 func ReadConfig(paths []string, defaultpath string, strict bool) (config.Configuration, error) {
+	return ReadConfigWithOverlays(paths, defaultpath, nil, strict)
+}
+
+// ReadConfigWithOverlays reads the configuration like ReadConfig, then applies
+// each overlay file in order before resolving test group templates, such as
+// to point a staging environment at different buckets and targets.
+func ReadConfigWithOverlays(paths []string, defaultpath string, overlayPaths []string, strict bool) (config.Configuration, error) {
 
 	var result config.Configuration
 
@@ -132,7 +141,37 @@ func ReadConfig(paths []string, defaultpath string, strict bool) (config.Configu
 		return result, fmt.Errorf("SeekYAMLFiles(%v), gathering config: %v", paths, err)
 	}
 
-	return result, err
+	if len(overlayPaths) > 0 {
+		var overlays []cfgutil.Overlay
+		for _, path := range overlayPaths {
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return result, fmt.Errorf("failed to read overlay at %s: %v", path, err)
+			}
+			overlay, err := LoadOverlay(b, strict)
+			if err != nil {
+				return result, fmt.Errorf("failed to deserialize overlay at %s: %v", path, err)
+			}
+			overlays = append(overlays, overlay)
+		}
+		cfg, err := cfgutil.ApplyOverlays(&result, overlays...)
+		if err != nil {
+			return result, fmt.Errorf("apply overlays: %v", err)
+		}
+		result = config.Configuration{
+			TestGroups:         cfg.TestGroups,
+			Dashboards:         cfg.Dashboards,
+			DashboardGroups:    cfg.DashboardGroups,
+			TestGroupTemplates: cfg.TestGroupTemplates,
+		}
+	}
+
+	// Templates may be declared in any file, so resolve them once everything is read.
+	if err := cfgutil.InheritTestGroups(&result); err != nil {
+		return result, fmt.Errorf("resolve test group templates: %v", err)
+	}
+
+	return result, nil
 }
 
 // Update reads the config in yamlData and updates the config in c.
@@ -154,15 +193,24 @@ func Update(cfg *config.Configuration, yamlData []byte, reconcile *DefaultConfig
 		cfg = &config.Configuration{}
 	}
 
+	// Groups which inherit from a template take their defaults from the root of
+	// their template chain once the templates are resolved.
 	for _, testgroup := range newConfig.TestGroups {
-		if reconcile != nil {
+		if reconcile != nil && reconcile.DefaultTestGroup != nil && testgroup.Inherits == "" {
 			ReconcileTestGroup(testgroup, reconcile.DefaultTestGroup)
 		}
 		cfg.TestGroups = append(cfg.TestGroups, testgroup)
 	}
 
+	for _, template := range newConfig.TestGroupTemplates {
+		if reconcile != nil && reconcile.DefaultTestGroup != nil && template.Inherits == "" {
+			ReconcileTestGroup(template, reconcile.DefaultTestGroup)
+		}
+		cfg.TestGroupTemplates = append(cfg.TestGroupTemplates, template)
+	}
+
 	for _, dashboard := range newConfig.Dashboards {
-		if reconcile != nil {
+		if reconcile != nil && reconcile.DefaultDashboardTab != nil {
 			for _, dashboardtab := range dashboard.DashboardTab {
 				ReconcileDashboardTab(dashboardtab, reconcile.DefaultDashboardTab)
 			}
@@ -300,6 +348,19 @@ func ReconcileDashboardTab(currentTab *config.DashboardTab, defaultTab *config.D
 	}
 }
 
+// LoadOverlay reads an environment overlay from YAML.
+func LoadOverlay(yamlData []byte, strict bool) (cfgutil.Overlay, error) {
+	var result cfgutil.Overlay
+	unmarshal := yaml.Unmarshal
+	if strict {
+		unmarshal = yaml.UnmarshalStrict
+	}
+	if err := unmarshal(yamlData, &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
 // UpdateDefaults reads and validates default settings from YAML
 // Returns an error if the defaultConfig is partially or completely missing.
 func LoadDefaults(yamlData []byte) (DefaultConfiguration, error) {
@@ -20,11 +20,13 @@ import (
 	"bytes"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/testgrid/pb/config"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestYaml2Proto_IsExternal_And_UseKuberClient_False(t *testing.T) {
@@ -684,6 +686,35 @@ func Test_ReadConfig(t *testing.T) {
 			},
 			expectFailure: true,
 		},
+		{
+			name: "Resolves templates across files",
+			files: map[string]string{
+				"1*.yaml": "test_groups:\n- name: foo\n  inherits: gke\n  days_of_results: 3\n",
+				"2*.yaml": "test_group_templates:\n- name: gke\n  inherits: base\n  gcs_prefix: bucket/gke\n- name: base\n  days_of_results: 7\n  num_columns_recent: 5\n",
+			},
+			expected: config.Configuration{
+				TestGroups: []*config.TestGroup{
+					{
+						Name:             "foo",
+						Inherits:         "gke",
+						DaysOfResults:    3,
+						GcsPrefix:        "bucket/gke",
+						NumColumnsRecent: 5,
+					},
+				},
+				TestGroupTemplates: []*config.TestGroup{
+					{Name: "gke", Inherits: "base", GcsPrefix: "bucket/gke"},
+					{Name: "base", DaysOfResults: 7, NumColumnsRecent: 5},
+				},
+			},
+		},
+		{
+			name: "Template cycle: fails",
+			files: map[string]string{
+				"1*.yaml": "test_groups:\n- name: foo\n  inherits: a\ntest_group_templates:\n- name: a\n  inherits: b\n- name: b\n  inherits: a\n",
+			},
+			expectFailure: true,
+		},
 		{
 			name: "Won't read non-YAML",
 			files: map[string]string{
@@ -857,3 +888,52 @@ func Test_PathDefault(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReadConfigWithOverlays(t *testing.T) {
+	directory, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Error in creating temporary dir: %v", err)
+	}
+	defer os.RemoveAll(directory)
+	write := func(name, contents string) string {
+		path := filepath.Join(directory, name)
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("Error in writing temporary file %s: %v", name, err)
+		}
+		return path
+	}
+	base := write("config.yaml", `test_groups:
+- name: foo
+  inherits: base
+  gcs_prefix: prod-bucket/logs/foo
+test_group_templates:
+- name: base
+  days_of_results: 7
+`)
+	overlay := write("staging.yaml", `gcs_prefix_rewrites:
+- from: prod-bucket/
+  to: staging-bucket/
+test_group_templates:
+- name: base
+  days_of_results: 1
+`)
+
+	got, err := ReadConfigWithOverlays([]string{base}, "", []string{overlay}, true)
+	if err != nil {
+		t.Fatalf("ReadConfigWithOverlays() got unexpected error: %v", err)
+	}
+	want := config.Configuration{
+		TestGroups: []*config.TestGroup{
+			{Name: "foo", Inherits: "base", GcsPrefix: "staging-bucket/logs/foo", DaysOfResults: 1},
+		},
+		TestGroupTemplates: []*config.TestGroup{{Name: "base", DaysOfResults: 1}},
+	}
+	if diff := cmp.Diff(&want, &got, protocmp.Transform()); diff != "" {
+		t.Errorf("ReadConfigWithOverlays() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	bad := write("bad.yaml", "unknown_field: true\n")
+	if _, err := ReadConfigWithOverlays([]string{base}, "", []string{bad}, true); err == nil {
+		t.Error("ReadConfigWithOverlays() with an unknown field failed to return an error")
+	}
+}
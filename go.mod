@@ -3,6 +3,7 @@ module github.com/GoogleCloudPlatform/testgrid
 require (
 	cloud.google.com/go/storage v1.10.1-0.20200805182106-fcd132957b02
 	github.com/client9/misspell v0.3.4
+	github.com/coreos/go-oidc/v3 v3.0.0
 	github.com/fvbommel/sortorder v1.0.1
 	github.com/golang/protobuf v1.4.2
 	github.com/google/go-cmp v0.5.1
@@ -10,12 +11,16 @@ require (
 	github.com/hashicorp/go-multierror v1.0.0
 	github.com/sirupsen/logrus v1.6.0
 	github.com/stretchr/testify v1.5.1
+	github.com/xitongsys/parquet-go v1.6.0
+	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	google.golang.org/api v0.30.0
 	google.golang.org/genproto v0.0.0-20200804151602-45615f50871c
 	google.golang.org/grpc v1.31.0
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/square/go-jose.v2 v2.5.1
 	gopkg.in/yaml.v2 v2.2.8
 	k8s.io/api v0.16.13
+	k8s.io/apimachinery v0.16.13
 	sigs.k8s.io/yaml v1.1.0
 )
 
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alert computes the alerts of the rows of a grid.
+package alert
+
+import (
+	"context"
+	"math"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// Set configures the alert of every row using the thresholds of the test group.
+func Set(group *configpb.TestGroup, cols []*statepb.Column, rows []*statepb.Row) {
+	failsOpen := int(group.NumFailuresToAlert)
+	passesClose := int(group.NumPassesToDisableAlert)
+	if failsOpen > 0 && passesClose == 0 {
+		passesClose = 1
+	}
+	Rows(cols, rows, failsOpen, passesClose)
+}
+
+// Rows configures the alert for every row that has one.
+func Rows(cols []*statepb.Column, rows []*statepb.Row, openFailures, closePasses int) {
+	for _, r := range rows {
+		r.AlertInfo = alertRow(cols, r, openFailures, closePasses)
+	}
+}
+
+// Window replaces the alert of every row with one raised by failures within a window of recent results.
+func Window(cols []*statepb.Column, rows []*statepb.Row, failures, window int) {
+	for _, row := range rows {
+		row.AlertInfo = windowAlert(cols, row, failures, window)
+	}
+}
+
+// windowAlert returns an AlertInfo when at least failures of the row's window most recent results fail.
+//
+// Columns without a result for the row, including running ones, and broken
+// columns do not count toward the window.
+func windowAlert(cols []*statepb.Column, row *statepb.Row, failures, window int) *statepb.AlertInfo {
+	if failures <= 0 || window <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, row.Results)
+	var seen, count int
+	var filledIdx, failIdx, latestFailIdx int
+	var firstFail, latestFail *statepb.Column
+	for _, col := range cols {
+		if seen >= window {
+			break
+		}
+		rawRes := <-ch
+		if rawRes == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		idx := filledIdx
+		filledIdx++
+		res := result.Coalesce(rawRes, result.IgnoreRunning)
+		if res == statuspb.TestStatus_NO_RESULT || col.Broken {
+			continue
+		}
+		seen++
+		if res != statuspb.TestStatus_FAIL {
+			continue
+		}
+		count++
+		if count == 1 {
+			latestFail, latestFailIdx = col, idx
+		}
+		firstFail, failIdx = col, idx
+	}
+	if count < failures {
+		return nil
+	}
+	alert := statepb.AlertInfo{
+		FailCount:         int32(count),
+		FailBuildId:       BuildID(firstFail),
+		LatestFailBuildId: BuildID(latestFail),
+		FailTime:          stamp(firstFail),
+	}
+	if lg := row.LastGreen; lg != nil {
+		alert.PassBuildId = lg.BuildId
+	}
+	if latestFailIdx < len(row.Messages) {
+		alert.FailureMessage = row.Messages[latestFailIdx]
+	}
+	if len(row.CellIds) > 0 { // not all rows have cell ids
+		alert.FailTestId = row.CellIds[failIdx]
+		alert.LatestFailTestId = row.CellIds[latestFailIdx]
+	}
+	return &alert
+}
+
+// alertRow returns an AlertInfo proto if there have been failuresToOpen consecutive failures more recently than passesToClose.
+func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesToClose int) *statepb.AlertInfo {
+	if failuresToOpen == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var failures int
+	var totalFailures int32
+	var passes int
+	var compressedIdx int
+	ch := result.Iter(ctx, row.Results)
+	var firstFail *statepb.Column
+	var latestFail *statepb.Column
+	var latestPass *statepb.Column
+	var failIdx int
+	var latestFailIdx int
+	// find the first number of consecutive passesToClose (no alert)
+	// or else failuresToOpen (alert).
+	for _, col := range cols {
+		// TODO(fejta): ignore old running
+		rawRes := <-ch
+		res := result.Coalesce(rawRes, result.IgnoreRunning)
+		if res == statuspb.TestStatus_NO_RESULT {
+			if rawRes == statuspb.TestStatus_RUNNING {
+				compressedIdx++
+			}
+			continue
+		}
+		if col.Broken {
+			compressedIdx++ // neither opens nor closes the alert
+			continue
+		}
+		if res == statuspb.TestStatus_PASS {
+			passes++
+			if failures >= failuresToOpen {
+				latestPass = col // most recent pass before outage
+				break
+			}
+			if passes >= passesToClose {
+				return nil // there is no outage
+			}
+			failures = 0
+		}
+		if res == statuspb.TestStatus_FAIL {
+			passes = 0
+			failures++
+			totalFailures++
+			if failures == 1 { // note most recent failure for this outage
+				latestFailIdx = compressedIdx
+				latestFail = col
+			}
+			failIdx = compressedIdx
+			firstFail = col
+		}
+		if res == statuspb.TestStatus_FLAKY {
+			passes = 0
+			if failures >= failuresToOpen {
+				break // cannot definitively say which commit is at fault
+			}
+			failures = 0
+		}
+		compressedIdx++
+	}
+	if failures < failuresToOpen {
+		return nil
+	}
+	var id string
+	var latestID string
+	if len(row.CellIds) > 0 { // not all rows have cell ids
+		id = row.CellIds[failIdx]
+		latestID = row.CellIds[latestFailIdx]
+	}
+	msg := row.Messages[latestFailIdx]
+	return alertInfo(totalFailures, msg, id, latestID, firstFail, latestFail, latestPass)
+}
+
+// alertInfo returns an alert proto with the configured fields
+func alertInfo(failures int32, msg, cellID, latestCellID string, fail, latestFail, pass *statepb.Column) *statepb.AlertInfo {
+	return &statepb.AlertInfo{
+		FailCount:         failures,
+		FailBuildId:       BuildID(fail),
+		LatestFailBuildId: BuildID(latestFail),
+		FailTime:          stamp(fail),
+		FailTestId:        cellID,
+		LatestFailTestId:  latestCellID,
+		FailureMessage:    msg,
+		PassTime:          stamp(pass),
+		PassBuildId:       BuildID(pass),
+	}
+}
+
+// BuildID extracts the ID from the first extra row or else the Build field.
+func BuildID(col *statepb.Column) string {
+	if col == nil {
+		return ""
+	}
+	if len(col.Extra) > 0 {
+		return col.Extra[0]
+	}
+	return col.Build
+}
+
+const billion = 1e9
+
+// stamp converts seconds into a timestamp proto
+func stamp(col *statepb.Column) *timestamp.Timestamp {
+	if col == nil {
+		return nil
+	}
+	seconds := col.Started
+	floor := math.Floor(seconds)
+	remain := seconds - floor
+	return &timestamp.Timestamp{
+		Seconds: int64(floor),
+		Nanos:   int32(remain * billion),
+	}
+}
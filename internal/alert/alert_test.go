@@ -0,0 +1,462 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestAlertRow(t *testing.T) {
+	var columns []*statepb.Column
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		columns = append(columns, &statepb.Column{
+			Build:   id,
+			Started: 100 - float64(i),
+		})
+	}
+	var brokenColumns []*statepb.Column
+	for i, col := range columns {
+		brokenColumns = append(brokenColumns, &statepb.Column{
+			Build:   col.Build,
+			Started: col.Started,
+			Broken:  i == 1,
+		})
+	}
+	cases := []struct {
+		name      string
+		cols      []*statepb.Column
+		row       statepb.Row
+		failOpen  int
+		passClose int
+		expected  *statepb.AlertInfo
+	}{
+		{
+			name: "never alert by default",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 6,
+				},
+			},
+		},
+		{
+			name: "passes do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 6,
+				},
+			},
+			failOpen:  1,
+			passClose: 3,
+		},
+		{
+			name: "flakes do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 6,
+				},
+			},
+			failOpen: 1,
+		},
+		{
+			name: "intermittent failures do not alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+			},
+			failOpen: 3,
+		},
+		{
+			name: "new failures alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 3,
+				},
+				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
+				CellIds:  []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
+			},
+			failOpen: 3,
+			expected: alertInfo(3, "no", "very wrong", "no", columns[2], columns[0], columns[3]),
+		},
+		{
+			name: "rows without cell IDs can alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 3,
+					int32(statuspb.TestStatus_PASS), 3,
+				},
+				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
+			},
+			failOpen: 3,
+			expected: alertInfo(3, "no", "", "", columns[2], columns[0], columns[3]),
+		},
+		{
+			name: "too few passes do not close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 2,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
+				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
+			},
+			failOpen:  1,
+			passClose: 3,
+			expected:  alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil),
+		},
+		{
+			name: "flakes do not close",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 2,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
+				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
+			},
+			failOpen: 1,
+			expected: alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil),
+		},
+		{
+			name: "count failures after flaky passes",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_FLAKY), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
+				},
+				Messages: []string{"nope", "no", "buu", "wrong", "this one", "hi"},
+				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job", "hi"},
+			},
+			failOpen:  2,
+			passClose: 2,
+			expected:  alertInfo(4, "this one", "hi", "good job", columns[5], columns[4], nil),
+		},
+		{
+			name: "close alert",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
+				},
+			},
+			failOpen: 1,
+		},
+		{
+			name: "track through empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_FAIL), 4,
+				},
+				Messages: []string{"yay" /*no result */, "no", "buu", "wrong", "nono"},
+				CellIds:  []string{"yay-cell" /*no result */, "no", "buzz", "wrong2", "nada"},
+			},
+			failOpen:  5,
+			passClose: 2,
+			expected:  alertInfo(5, "yay", "nada", "yay-cell", columns[5], columns[0], nil),
+		},
+		{
+			name: "track passes through empty results",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_NO_RESULT), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 3,
+				},
+			},
+			failOpen:  1,
+			passClose: 2,
+		},
+		{
+			name: "running cells advance compressed index",
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_RUNNING), 1,
+					int32(statuspb.TestStatus_FAIL), 5,
+				},
+				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
+				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
+			},
+			failOpen: 1,
+			expected: alertInfo(5, "fail1-expected", "no5", "yep", columns[5], columns[1], nil),
+		},
+		{
+			name: "broken columns do not break failure streaks",
+			cols: brokenColumns,
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 3,
+				},
+				Messages: []string{"a", "b", "c", "d", "e", "f"},
+				CellIds:  []string{"a-cell", "b-cell", "c-cell", "d-cell", "e-cell", "f-cell"},
+			},
+			failOpen:  2,
+			passClose: 1,
+			expected:  alertInfo(2, "a", "c-cell", "a-cell", brokenColumns[2], brokenColumns[0], brokenColumns[3]),
+		},
+		{
+			name: "broken columns do not open alerts",
+			cols: brokenColumns,
+			row: statepb.Row{
+				Results: []int32{
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 1,
+					int32(statuspb.TestStatus_PASS), 4,
+				},
+			},
+			failOpen:  1,
+			passClose: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		cols := tc.cols
+		if cols == nil {
+			cols = columns
+		}
+		actual := alertRow(cols, &tc.row, tc.failOpen, tc.passClose)
+		if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
+			t.Errorf("alertRow() not as expected (-want, +got): %s", diff)
+		}
+	}
+}
+
+func TestBuildID(t *testing.T) {
+	cases := []struct {
+		name     string
+		build    string
+		extra    string
+		expected string
+	}{
+		{
+			name: "return empty by default",
+		},
+		{
+			name:     "favor extra if it exists",
+			build:    "wrong",
+			extra:    "right",
+			expected: "right",
+		},
+		{
+			name:     "build if no extra",
+			build:    "yes",
+			expected: "yes",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			col := statepb.Column{
+				Build: tc.build,
+			}
+			if tc.extra != "" {
+				col.Extra = append(col.Extra, tc.extra)
+			}
+			if actual := BuildID(&col); actual != tc.expected {
+				t.Errorf("%q != expected %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStamp(t *testing.T) {
+	cases := []struct {
+		name     string
+		col      *statepb.Column
+		expected *timestamp.Timestamp
+	}{
+		{
+			name: "0 returns nil",
+		},
+		{
+			name: "no nanos",
+			col: &statepb.Column{
+				Started: 2,
+			},
+			expected: &timestamp.Timestamp{
+				Seconds: 2,
+				Nanos:   0,
+			},
+		},
+		{
+			name: "has nanos",
+			col: &statepb.Column{
+				Started: 1.1,
+			},
+			expected: &timestamp.Timestamp{
+				Seconds: 1,
+				Nanos:   1e8,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := stamp(tc.col); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("stamp %s != expected stamp %s", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWindowAlert(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	flaky := int32(statuspb.TestStatus_FLAKY)
+	running := int32(statuspb.TestStatus_RUNNING)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	cols := []*statepb.Column{
+		{Build: "6", Started: 6},
+		{Build: "5", Started: 5},
+		{Build: "4", Started: 4, Extra: []string{"four"}},
+		{Build: "3", Started: 3},
+		{Build: "2", Started: 2},
+		{Build: "1", Started: 1},
+	}
+	cases := []struct {
+		name     string
+		cols     []*statepb.Column
+		row      *statepb.Row
+		failures int
+		window   int
+		want     *statepb.AlertInfo
+	}{
+		{
+			name:     "disabled",
+			row:      &statepb.Row{Results: []int32{fail, 6}, Messages: []string{"", "", "", "", "", ""}},
+			failures: 0,
+			window:   3,
+		},
+		{
+			name: "alert on interleaved failures",
+			row: &statepb.Row{
+				Results:  []int32{fail, 1, pass, 1, fail, 1, pass, 3},
+				Messages: []string{"newest", "", "oldest", "", "", ""},
+				CellIds:  []string{"c6", "c5", "c4", "c3", "c2", "c1"},
+			},
+			failures: 2,
+			window:   3,
+			want: &statepb.AlertInfo{
+				FailCount:         2,
+				FailBuildId:       "four",
+				LatestFailBuildId: "6",
+				FailTime:          &timestamp.Timestamp{Seconds: 4},
+				FailTestId:        "c4",
+				LatestFailTestId:  "c6",
+				FailureMessage:    "newest",
+			},
+		},
+		{
+			name: "report the last green",
+			row: &statepb.Row{
+				Results:  []int32{fail, 2, pass, 4},
+				Messages: []string{"newest", "", "", "", "", ""},
+				LastGreen: &statepb.LastGreen{
+					BuildId: "four",
+					Time:    &timestamp.Timestamp{Seconds: 4},
+				},
+			},
+			failures: 2,
+			window:   3,
+			want: &statepb.AlertInfo{
+				FailCount:         2,
+				FailBuildId:       "5",
+				LatestFailBuildId: "6",
+				FailTime:          &timestamp.Timestamp{Seconds: 5},
+				FailureMessage:    "newest",
+				PassBuildId:       "four",
+			},
+		},
+		{
+			name: "too few failures in window",
+			row: &statepb.Row{
+				Results:  []int32{fail, 1, pass, 1, flaky, 1, fail, 3},
+				Messages: []string{"", "", "", "", "", ""},
+			},
+			failures: 2,
+			window:   3,
+		},
+		{
+			name: "skip empty and running results",
+			row: &statepb.Row{
+				Results:  []int32{running, 1, fail, 1, empty, 1, pass, 1, fail, 1, pass, 1},
+				Messages: []string{"", "newest", "", "oldest", ""},
+			},
+			failures: 2,
+			window:   3,
+			want: &statepb.AlertInfo{
+				FailCount:         2,
+				FailBuildId:       "2",
+				LatestFailBuildId: "5",
+				FailTime:          &timestamp.Timestamp{Seconds: 2},
+				FailureMessage:    "newest",
+			},
+		},
+		{
+			name: "skip broken columns",
+			cols: []*statepb.Column{
+				{Build: "3", Started: 3},
+				{Build: "2", Started: 2, Broken: true},
+				{Build: "1", Started: 1},
+			},
+			row: &statepb.Row{
+				Results:  []int32{fail, 3},
+				Messages: []string{"newest", "broken", "oldest"},
+			},
+			failures: 2,
+			window:   2,
+			want: &statepb.AlertInfo{
+				FailCount:         2,
+				FailBuildId:       "1",
+				LatestFailBuildId: "3",
+				FailTime:          &timestamp.Timestamp{Seconds: 1},
+				FailureMessage:    "newest",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.cols == nil {
+				tc.cols = cols
+			}
+			got := windowAlert(tc.cols, tc.row, tc.failures, tc.window)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("windowAlert() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+//go:build gofuzz
+// +build gofuzz
+
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"encoding/json"
+)
+
+// FuzzStarted exercises the started.json handling of the updater.
+//
+// Run with go-fuzz -func FuzzStarted.
+func FuzzStarted(data []byte) int {
+	var started Started
+	if err := json.Unmarshal(data, &started); err != nil {
+		return 0
+	}
+	Version(started, Finished{})
+	PullHead(started)
+	RepoCheckout(started, Finished{}, "")
+	return 1
+}
+
+// FuzzFinished exercises the finished.json handling of the updater.
+//
+// Run with go-fuzz -func FuzzFinished.
+func FuzzFinished(data []byte) int {
+	var finished Finished
+	if err := json.Unmarshal(data, &finished); err != nil {
+		return 0
+	}
+	Version(Started{}, finished)
+	RepoCheckout(Started{}, finished, "")
+	finished.Metadata.Strings()
+	finished.Metadata.Keys()
+	return 1
+}
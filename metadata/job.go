@@ -17,6 +17,7 @@ limitations under the License.
 package metadata
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -151,7 +152,7 @@ func Version(started Started, finished Finished) string {
 			return ""
 		}
 		v, ok := finished.Metadata.String(key)
-		if !ok {
+		if !ok || v == nil { // missing or not a string
 			return ""
 		}
 		return *v
@@ -172,6 +173,94 @@ func Version(started Started, finished Finished) string {
 	return val
 }
 
+// PullHead extracts the head commit of the pull request the job tested, or Missing.
+//
+// Refs look like base:sha,123:sha where the last pull is the head. The refs
+// come from Pull, or else the Repos of the job when Pull only holds a number.
+func PullHead(started Started) string {
+	refs := []string{started.Pull}
+	repos := make([]string, 0, len(started.Repos))
+	for repo := range started.Repos {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		refs = append(refs, started.Repos[repo])
+	}
+	for _, ref := range refs {
+		if head := ParseRefs(ref).PullHead; head != "" {
+			return head
+		}
+	}
+	return Missing
+}
+
+// Checkout describes the refs of a repo which a job checked out.
+type Checkout struct {
+	// Branch is the base branch, such as main.
+	Branch string
+	// Commit is the base commit.
+	Commit string
+	// PullHead is the head commit of the last pull request merged into the base, if any.
+	PullHead string
+}
+
+// ParseRefs parses refs such as main:sha,123:sha, shortening commits like Version.
+func ParseRefs(refs string) Checkout {
+	var co Checkout
+	parts := strings.Split(refs, ",")
+	base := strings.SplitN(parts[0], ":", 2)
+	co.Branch = base[0]
+	if len(base) == 2 {
+		co.Commit = shortCommit(base[1])
+	}
+	if len(parts) > 1 {
+		if pull := strings.SplitN(parts[len(parts)-1], ":", 2); len(pull) == 2 {
+			co.PullHead = shortCommit(pull[1])
+		}
+	}
+	return co
+}
+
+// RepoCheckout returns the refs which the job checked out for the repo, such as org/repo.
+//
+// Refs come from the repos of started.json, or else the repos of the
+// finished metadata. An empty repo selects the primary repo of the finished
+// metadata, or the only repo checked out.
+func RepoCheckout(started Started, finished Finished, repo string) (Checkout, bool) {
+	repos := map[string]string{}
+	if meta, ok := finished.Metadata.Meta("repos"); ok && meta != nil {
+		for name, refs := range meta.Strings() {
+			repos[name] = refs
+		}
+	}
+	for name, refs := range started.Repos {
+		repos[name] = refs
+	}
+	if repo == "" {
+		if primary, ok := finished.Metadata.String("repo"); ok && primary != nil {
+			repo = *primary
+		} else if len(repos) == 1 {
+			for name := range repos {
+				repo = name
+			}
+		}
+	}
+	refs, ok := repos[repo]
+	if !ok || refs == "" {
+		return Checkout{}, false
+	}
+	return ParseRefs(refs), true
+}
+
+// shortCommit truncates the commit to 9 characters.
+func shortCommit(sha string) string {
+	if len(sha) > 9 {
+		return sha[:9]
+	}
+	return sha
+}
+
 // SetVersion ensures that the repoCommit and jobVersion are set appropriately.
 func SetVersion(started *Started, finished *Finished, repoCommit, jobVersion string) {
 	if started != nil && repoCommit != "" {
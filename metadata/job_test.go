@@ -136,6 +136,19 @@ func TestVersion(t *testing.T) {
 			name:     "missing by default",
 			expected: Missing,
 		},
+		{
+			name: "ignore non-string metadata",
+			started: Started{
+				RepoCommit: "right",
+			},
+			finished: Finished{
+				Metadata: Metadata{
+					"revision":    5,
+					"repo-commit": map[string]interface{}{"hello": "world"},
+				},
+			},
+			expected: "right",
+		},
 		{
 			name: "DEPRECATED: finished job version over started",
 			started: Started{
@@ -215,6 +228,157 @@ func TestVersion(t *testing.T) {
 	}
 }
 
+func TestPullHead(t *testing.T) {
+	cases := []struct {
+		name    string
+		started Started
+		want    string
+	}{
+		{
+			name: "basically works",
+			want: Missing,
+		},
+		{
+			name:    "pull refs",
+			started: Started{Pull: "master:abc,123:deadbeef"},
+			want:    "deadbeef",
+		},
+		{
+			name:    "last pull is the head",
+			started: Started{Pull: "master:abc,123:deadbeef,456:0123456789abcdef"},
+			want:    "012345678",
+		},
+		{
+			name: "repo refs when pull is a number",
+			started: Started{
+				Pull: "123",
+				Repos: map[string]string{
+					"org/repo":  "main:abc,123:cafe",
+					"org/other": "main",
+				},
+			},
+			want: "cafe",
+		},
+		{
+			name:    "periodic",
+			started: Started{Repos: map[string]string{"org/repo": "main:abc"}},
+			want:    Missing,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PullHead(tc.started); got != tc.want {
+				t.Errorf("PullHead() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRefs(t *testing.T) {
+	cases := []struct {
+		refs string
+		want Checkout
+	}{
+		{},
+		{
+			refs: "main",
+			want: Checkout{Branch: "main"},
+		},
+		{
+			refs: "main:0123456789abcdef",
+			want: Checkout{Branch: "main", Commit: "012345678"},
+		},
+		{
+			refs: "main:abc,1:def,2:fed",
+			want: Checkout{Branch: "main", Commit: "abc", PullHead: "fed"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.refs, func(t *testing.T) {
+			if got := ParseRefs(tc.refs); got != tc.want {
+				t.Errorf("ParseRefs(%q) got %+v, want %+v", tc.refs, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRepoCheckout(t *testing.T) {
+	cases := []struct {
+		name     string
+		started  Started
+		finished Finished
+		repo     string
+		want     Checkout
+		found    bool
+	}{
+		{
+			name: "basically works",
+			repo: "org/repo",
+		},
+		{
+			name: "select the repo",
+			started: Started{
+				Repos: map[string]string{
+					"org/repo":  "main:abc",
+					"org/other": "release-1.0:def",
+				},
+			},
+			repo:  "org/other",
+			want:  Checkout{Branch: "release-1.0", Commit: "def"},
+			found: true,
+		},
+		{
+			name: "finished metadata repos",
+			finished: Finished{
+				Metadata: Metadata{
+					"repos": map[string]interface{}{"org/other": "main:abc,12:fed"},
+				},
+			},
+			repo:  "org/other",
+			want:  Checkout{Branch: "main", Commit: "abc", PullHead: "fed"},
+			found: true,
+		},
+		{
+			name: "primary repo",
+			started: Started{
+				Repos: map[string]string{
+					"org/repo":  "main:abc",
+					"org/other": "release-1.0:def",
+				},
+			},
+			finished: Finished{Metadata: Metadata{"repo": "org/repo"}},
+			want:     Checkout{Branch: "main", Commit: "abc"},
+			found:    true,
+		},
+		{
+			name:    "only repo",
+			started: Started{Repos: map[string]string{"org/repo": "main:abc"}},
+			want:    Checkout{Branch: "main", Commit: "abc"},
+			found:   true,
+		},
+		{
+			name: "ambiguous primary repo",
+			started: Started{
+				Repos: map[string]string{
+					"org/repo":  "main:abc",
+					"org/other": "release-1.0:def",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := RepoCheckout(tc.started, tc.finished, tc.repo)
+			if got != tc.want || found != tc.found {
+				t.Errorf("RepoCheckout() got %+v, %t, want %+v, %t", got, found, tc.want, tc.found)
+			}
+		})
+	}
+}
+
 func TestSetVersion(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// failureText joins a .NET failure message with its stack trace.
+func failureText(message, stack string) *string {
+	msg := strings.TrimSpace(message)
+	if stack = strings.TrimSpace(stack); stack != "" {
+		if msg != "" {
+			msg += "\n"
+		}
+		msg += stack
+	}
+	return &msg
+}
+
+// optional returns a pointer to s, or nil when s is empty.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// xunitAssemblies holds an xUnit.net v2 <assemblies/> result.
+type xunitAssemblies struct {
+	Assemblies []xunitAssembly `xml:"assembly"`
+}
+
+type xunitAssembly struct {
+	Name        string            `xml:"name,attr"`
+	Time        float64           `xml:"time,attr"`
+	Total       int               `xml:"total,attr"`
+	Failed      int               `xml:"failed,attr"`
+	Collections []xunitCollection `xml:"collection"`
+}
+
+type xunitCollection struct {
+	Name   string      `xml:"name,attr"`
+	Time   float64     `xml:"time,attr"`
+	Total  int         `xml:"total,attr"`
+	Failed int         `xml:"failed,attr"`
+	Tests  []xunitTest `xml:"test"`
+}
+
+type xunitTest struct {
+	Name    string  `xml:"name,attr"`
+	Type    string  `xml:"type,attr"`
+	Time    float64 `xml:"time,attr"`
+	Result  string  `xml:"result,attr"`
+	Reason  string  `xml:"reason"`
+	Output  string  `xml:"output"`
+	Failure *struct {
+		Message    string `xml:"message"`
+		StackTrace string `xml:"stack-trace"`
+	} `xml:"failure"`
+	Traits []Property `xml:"traits>trait"`
+}
+
+func (a xunitAssemblies) suites() Suites {
+	suites := Suites{XMLName: xml.Name{Local: "testsuites"}}
+	for _, assembly := range a.Assemblies {
+		suite := Suite{
+			XMLName:  xml.Name{Local: "testsuite"},
+			Name:     assembly.Name,
+			Time:     assembly.Time,
+			Tests:    assembly.Total,
+			Failures: assembly.Failed,
+		}
+		for _, coll := range assembly.Collections {
+			inner := Suite{
+				XMLName:  xml.Name{Local: "testsuite"},
+				Name:     coll.Name,
+				Time:     coll.Time,
+				Tests:    coll.Total,
+				Failures: coll.Failed,
+			}
+			for _, test := range coll.Tests {
+				inner.Results = append(inner.Results, test.result())
+			}
+			suite.Suites = append(suite.Suites, inner)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+	return suites
+}
+
+func (t xunitTest) result() Result {
+	r := Result{
+		Name:      t.Name,
+		ClassName: t.Type,
+		Time:      t.Time,
+		Output:    optional(strings.TrimSpace(t.Output)),
+	}
+	switch t.Result {
+	case "Fail":
+		if t.Failure != nil {
+			r.Failure = failureText(t.Failure.Message, t.Failure.StackTrace)
+		} else {
+			r.Failure = failureText("", "")
+		}
+	case "Skip":
+		r.Skipped = failureText(t.Reason, "")
+	}
+	if len(t.Traits) > 0 {
+		r.Properties = &Properties{PropertyList: t.Traits}
+	}
+	return r
+}
+
+// nunitRun holds an NUnit3 <test-run/> result.
+type nunitRun struct {
+	Suites []nunitSuite `xml:"test-suite"`
+}
+
+type nunitSuite struct {
+	Name     string       `xml:"name,attr"`
+	Duration float64      `xml:"duration,attr"`
+	Total    int          `xml:"total,attr"`
+	Failed   int          `xml:"failed,attr"`
+	Suites   []nunitSuite `xml:"test-suite"`
+	Cases    []nunitCase  `xml:"test-case"`
+}
+
+type nunitCase struct {
+	Name       string     `xml:"name,attr"`
+	ClassName  string     `xml:"classname,attr"`
+	Duration   float64    `xml:"duration,attr"`
+	Result     string     `xml:"result,attr"`
+	Label      string     `xml:"label,attr"`
+	Properties []Property `xml:"properties>property"`
+	Failure    *struct {
+		Message    string `xml:"message"`
+		StackTrace string `xml:"stack-trace"`
+	} `xml:"failure"`
+	Reason      string   `xml:"reason>message"`
+	Output      string   `xml:"output"`
+	Attachments []string `xml:"attachments>attachment>filePath"`
+}
+
+func (r nunitRun) suites() Suites {
+	suites := Suites{XMLName: xml.Name{Local: "testsuites"}}
+	for _, s := range r.Suites {
+		suites.Suites = append(suites.Suites, s.suite())
+	}
+	return suites
+}
+
+func (s nunitSuite) suite() Suite {
+	suite := Suite{
+		XMLName:  xml.Name{Local: "testsuite"},
+		Name:     s.Name,
+		Time:     s.Duration,
+		Tests:    s.Total,
+		Failures: s.Failed,
+	}
+	for _, inner := range s.Suites {
+		suite.Suites = append(suite.Suites, inner.suite())
+	}
+	for _, c := range s.Cases {
+		suite.Results = append(suite.Results, c.result())
+	}
+	return suite
+}
+
+func (c nunitCase) result() Result {
+	r := Result{
+		Name:      c.Name,
+		ClassName: c.ClassName,
+		Time:      c.Duration,
+		Output:    optional(strings.TrimSpace(c.Output)),
+	}
+	var msg *string
+	if c.Failure != nil {
+		msg = failureText(c.Failure.Message, c.Failure.StackTrace)
+	} else {
+		msg = failureText("", "")
+	}
+	switch c.Result {
+	case "Failed":
+		if c.Label == "Error" || c.Label == "Invalid" {
+			r.Errored = msg
+		} else {
+			r.Failure = msg
+		}
+	case "Skipped", "Inconclusive":
+		r.Skipped = failureText(c.Reason, "")
+	}
+	props := append([]Property(nil), c.Properties...)
+	for _, path := range c.Attachments {
+		props = append(props, Property{Name: AttachmentProperty, Value: strings.TrimSpace(path)})
+	}
+	if len(props) > 0 {
+		r.Properties = &Properties{PropertyList: props}
+	}
+	return r
+}
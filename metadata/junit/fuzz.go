@@ -0,0 +1,42 @@
+//go:build gofuzz
+// +build gofuzz
+
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+// Fuzz exercises the junit handling of the updater.
+//
+// Run with go-fuzz -func Fuzz.
+func Fuzz(data []byte) int {
+	suites, err := Parse(data)
+	if err != nil {
+		return 0
+	}
+	var walk func([]Suite)
+	walk = func(ss []Suite) {
+		for _, s := range ss {
+			walk(s.Suites)
+			for _, r := range s.Results {
+				r.Message(140)
+				r.Attachments()
+			}
+		}
+	}
+	walk(suites.Suites)
+	return 1
+}
@@ -23,10 +23,14 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 )
 
+// MaxMessageBytes limits the length of each message of a parsed result.
+const MaxMessageBytes = 64 * 1024
+
 type suiteOrSuites struct {
 	suites Suites
 }
@@ -34,15 +38,31 @@ type suiteOrSuites struct {
 func (s *suiteOrSuites) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	switch start.Name.Local {
 	case "testsuites":
-		d.DecodeElement(&s.suites, &start)
+		if err := d.DecodeElement(&s.suites, &start); err != nil {
+			return err
+		}
 	case "testsuite":
 		var suite Suite
-		d.DecodeElement(&suite, &start)
+		if err := d.DecodeElement(&suite, &start); err != nil {
+			return err
+		}
 		s.suites.Suites = append(s.suites.Suites, suite)
+	case "assemblies": // xUnit.net v2
+		var assemblies xunitAssemblies
+		if err := d.DecodeElement(&assemblies, &start); err != nil {
+			return err
+		}
+		s.suites = assemblies.suites()
+	case "test-run": // NUnit3
+		var run nunitRun
+		if err := d.DecodeElement(&run, &start); err != nil {
+			return err
+		}
+		s.suites = run.suites()
 	default:
 		return fmt.Errorf("bad element name: %q", start.Name)
 	}
-	s.suites.Truncate(10000)
+	s.suites.Truncate(MaxMessageBytes)
 	return nil
 }
 
@@ -59,6 +79,24 @@ func (s *Suites) Truncate(max int) {
 	}
 }
 
+// Clone returns a deep copy of the suites.
+func (s Suites) Clone() Suites {
+	out := s
+	out.Suites = cloneSuites(s.Suites)
+	return out
+}
+
+func cloneSuites(suites []Suite) []Suite {
+	if suites == nil {
+		return nil
+	}
+	out := make([]Suite, len(suites))
+	for i, s := range suites {
+		out[i] = s.Clone()
+	}
+	return out
+}
+
 // Suite holds <testsuite/> results
 type Suite struct {
 	XMLName  xml.Name `xml:"testsuite"`
@@ -83,6 +121,19 @@ func (s *Suite) Truncate(max int) {
 	}
 }
 
+// Clone returns a deep copy of the suite.
+func (s Suite) Clone() Suite {
+	out := s
+	out.Suites = cloneSuites(s.Suites)
+	if s.Results != nil {
+		out.Results = make([]Result, len(s.Results))
+		for i, r := range s.Results {
+			out.Results[i] = r.Clone()
+		}
+	}
+	return out
+}
+
 // Property defines the xml element that stores additional metrics about each benchmark.
 type Property struct {
 	Name  string `xml:"name,attr"`
@@ -107,6 +158,36 @@ type Result struct {
 	Properties *Properties `xml:"properties,omitempty"`
 }
 
+// UnmarshalXML decodes the test case, truncating its messages so that large
+// files do not hold every full message in memory.
+func (r *Result) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type result Result // without this method
+	if err := d.DecodeElement((*result)(r), &start); err != nil {
+		return err
+	}
+	r.Truncate(MaxMessageBytes)
+	return nil
+}
+
+// Clone returns a deep copy of the result.
+func (r Result) Clone() Result {
+	out := r
+	for _, s := range []**string{&out.Failure, &out.Output, &out.Error, &out.Errored, &out.Skipped} {
+		if *s != nil {
+			val := **s
+			*s = &val
+		}
+	}
+	if r.Properties != nil {
+		props := Properties{}
+		if r.Properties.PropertyList != nil {
+			props.PropertyList = append([]Property{}, r.Properties.PropertyList...)
+		}
+		out.Properties = &props
+	}
+	return out
+}
+
 // SetProperty adds the specified property to the Result or replaces the
 // existing value if a property with that name already exists.
 func (r *Result) SetProperty(name, value string) {
@@ -153,6 +234,44 @@ func (r Result) Message(max int) string {
 	return fmt.Sprintf("invalid utf8: %s", strings.ToValidUTF8(msg, "?"))
 }
 
+// AttachmentProperty names testcase properties holding attachment paths.
+const AttachmentProperty = "attachment"
+
+var attachmentRE = regexp.MustCompile(`\[\[ATTACHMENT\|([^\]]+)\]\]`)
+
+// Attachments returns the paths of files attached to the test case.
+//
+// Attachments are marked in <system-out/> and <system-err/> with the
+// [[ATTACHMENT|path]] convention, or listed as attachment properties.
+func (r Result) Attachments() []string {
+	var out []string
+	seen := map[string]bool{}
+	add := func(p string) {
+		p = strings.TrimSpace(p)
+		if p == "" || seen[p] {
+			return
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	for _, s := range []*string{r.Output, r.Error} {
+		if s == nil {
+			continue
+		}
+		for _, match := range attachmentRE.FindAllStringSubmatch(*s, -1) {
+			add(match[1])
+		}
+	}
+	if r.Properties != nil {
+		for _, p := range r.Properties.PropertyList {
+			if p.Name == AttachmentProperty {
+				add(p.Value)
+			}
+		}
+	}
+	return out
+}
+
 func truncate(s string, max int) string {
 	if max <= 0 {
 		return s
@@ -169,15 +288,39 @@ func truncatePointer(str *string, max int) {
 	if str == nil {
 		return
 	}
-	s := truncate(*str, max)
-	str = &s
+	*str = truncate(*str, max)
 }
 
 // Truncate ensures that strings do not exceed the specified length.
-func (r Result) Truncate(max int) {
+//
+// Attachments whose markers it drops are listed as attachment properties.
+func (r *Result) Truncate(max int) {
+	var attachments []string
+	for _, s := range []*string{r.Output, r.Error} {
+		if s != nil && max > 0 && len(*s) >= max {
+			attachments = r.Attachments()
+			break
+		}
+	}
 	for _, s := range []*string{r.Errored, r.Failure, r.Skipped, r.Error, r.Output} {
 		truncatePointer(s, max)
 	}
+	if len(attachments) == 0 {
+		return
+	}
+	kept := map[string]bool{}
+	for _, a := range r.Attachments() {
+		kept[a] = true
+	}
+	for _, a := range attachments {
+		if kept[a] {
+			continue
+		}
+		if r.Properties == nil {
+			r.Properties = &Properties{}
+		}
+		r.Properties.PropertyList = append(r.Properties.PropertyList, Property{Name: AttachmentProperty, Value: a})
+	}
 }
 
 func unmarshalXML(reader io.Reader, i interface{}) error {
@@ -203,11 +346,21 @@ func Parse(buf []byte) (*Suites, error) {
 	return ParseStream(reader)
 }
 
-// ParseStream reads bytes into a Suites object.
-func ParseStream(reader io.Reader) (*Suites, error) {
+// ParseStream decodes a Suites object as it reads the stream.
+//
+// Memory tracks the number of test cases rather than the size of the stream,
+// since only the start and end of long messages are read into memory.
+//
+// Malformed content returns an error rather than panicking.
+func ParseStream(reader io.Reader) (suites *Suites, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			suites, err = nil, fmt.Errorf("malformed junit: %v", r)
+		}
+	}()
 	// Try to parse it as a <testsuites/> object
 	var s suiteOrSuites
-	err := unmarshalXML(reader, &s)
+	err = unmarshalXML(newTextLimitReader(reader, textLimitBytes), &s)
 	if err != nil && err != io.EOF {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ package junit
 import (
 	"bytes"
 	"encoding/xml"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -110,6 +111,117 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	pstr := func(s string) *string {
+		return &s
+	}
+	failure := "boom"
+	orig := Suites{
+		Suites: []Suite{
+			{
+				Name: "outer",
+				Suites: []Suite{
+					{
+						Name:    "inner",
+						Results: []Result{{Name: "nested", Failure: &failure}},
+					},
+				},
+				Results: []Result{
+					{
+						Name:       "foo",
+						Failure:    &failure,
+						Properties: &Properties{PropertyList: []Property{{Name: "key", Value: "value"}}},
+					},
+				},
+			},
+		},
+	}
+	want := Suites{
+		Suites: []Suite{
+			{
+				Name: "outer",
+				Suites: []Suite{
+					{
+						Name:    "inner",
+						Results: []Result{{Name: "nested", Failure: pstr("boom")}},
+					},
+				},
+				Results: []Result{
+					{
+						Name:       "foo",
+						Failure:    pstr("boom"),
+						Properties: &Properties{PropertyList: []Property{{Name: "key", Value: "value"}}},
+					},
+				},
+			},
+		},
+	}
+
+	got := orig.Clone()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Clone() got unexpected diff (-want +got):\n%s", diff)
+	}
+	*got.Suites[0].Results[0].Failure = "modified"
+	got.Suites[0].Results[0].Properties.PropertyList[0].Value = "modified"
+	got.Suites[0].Results[0].SetProperty("other", "value")
+	got.Suites[0].Suites[0].Results[0].Name = "modified"
+	if diff := cmp.Diff(want, orig); diff != "" {
+		t.Errorf("modifying the clone changed the original (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	pstr := func(s string) *string {
+		return &s
+	}
+
+	cases := []struct {
+		name     string
+		jr       Result
+		expected []string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "output without attachments",
+			jr: Result{
+				Output: pstr("hello [[world]]"),
+			},
+		},
+		{
+			name: "attachments in output and error",
+			jr: Result{
+				Output: pstr("see [[ATTACHMENT|screenshots/login.png]]\n[[ATTACHMENT|/tmp/log.txt]]"),
+				Error:  pstr("[[ATTACHMENT|stderr.txt]]"),
+			},
+			expected: []string{"screenshots/login.png", "/tmp/log.txt", "stderr.txt"},
+		},
+		{
+			name: "attachment properties",
+			jr: Result{
+				Output: pstr("[[ATTACHMENT|a.png]]"),
+				Properties: &Properties{
+					PropertyList: []Property{
+						{Name: "attachment", Value: "b.png"},
+						{Name: "other", Value: "c.png"},
+						{Name: "attachment", Value: "a.png"},
+					},
+				},
+			},
+			expected: []string{"a.png", "b.png"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, tc.jr.Attachments()); diff != "" {
+				t.Errorf("Attachments() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestParse(t *testing.T) {
 	pstr := func(s string) *string {
 		return &s
@@ -127,6 +239,53 @@ func TestParse(t *testing.T) {
 			name: "not xml fails",
 			buf:  []byte("<hello"),
 		},
+		{
+			name: "truncated testsuites fails",
+			buf:  []byte(`<testsuites><testsuite name="fun"><testcase name="hi"/>`),
+		},
+		{
+			name: "truncated testsuite fails",
+			buf:  []byte(`<testsuite><testcase name="hi">`),
+		},
+		{
+			name: "truncate long messages",
+			buf:  []byte(`<testsuite><testcase name="hi"><failure>` + strings.Repeat("x", MaxMessageBytes+2) + `</failure></testcase></testsuite>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Results: []Result{
+							{
+								Name:    "hi",
+								Failure: pstr(strings.Repeat("x", MaxMessageBytes/2) + "..." + strings.Repeat("x", MaxMessageBytes/2)),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "keep attachments of truncated output",
+			buf:  []byte(`<testsuite><testcase name="hi"><system-out>[[ATTACHMENT|first.txt]]` + strings.Repeat("x", MaxMessageBytes) + `[[ATTACHMENT|middle.txt]]` + strings.Repeat("x", MaxMessageBytes) + `[[ATTACHMENT|last.txt]]</system-out></testcase></testsuite>`),
+			expected: &Suites{
+				Suites: []Suite{
+					{
+						XMLName: xml.Name{Local: "testsuite"},
+						Results: []Result{
+							{
+								Name:   "hi",
+								Output: pstr("[[ATTACHMENT|first.txt]]" + strings.Repeat("x", MaxMessageBytes/2-len("[[ATTACHMENT|first.txt]]")) + "..." + strings.Repeat("x", MaxMessageBytes/2-len("[[ATTACHMENT|last.txt]]")) + "[[ATTACHMENT|last.txt]]"),
+								Properties: &Properties{
+									PropertyList: []Property{
+										{Name: AttachmentProperty, Value: "middle.txt"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "parse testsuite correctly",
 			buf:  []byte(`<testsuite><testcase name="hi"/></testsuite>`),
@@ -197,6 +356,145 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse xunit.net assemblies",
+			buf: []byte(`
+                        <assemblies>
+                            <assembly name="Foo.Tests.dll" total="3" failed="1" time="1.5">
+                                <collection name="Foo collection" total="3" failed="1" time="1.5">
+                                    <test name="Foo.Tests.Good" type="Foo.Tests" time="0.5" result="Pass">
+                                        <traits><trait name="Category" value="fast"/></traits>
+                                    </test>
+                                    <test name="Foo.Tests.Bad" type="Foo.Tests" time="1" result="Fail">
+                                        <failure><message>boom</message><stack-trace>at Foo.Tests.Bad()</stack-trace></failure>
+                                        <output>hello</output>
+                                    </test>
+                                    <test name="Foo.Tests.Later" type="Foo.Tests" time="0" result="Skip">
+                                        <reason>not yet</reason>
+                                    </test>
+                                </collection>
+                            </assembly>
+                        </assemblies>
+                        `),
+			expected: &Suites{
+				XMLName: xml.Name{Local: "testsuites"},
+				Suites: []Suite{
+					{
+						XMLName:  xml.Name{Local: "testsuite"},
+						Name:     "Foo.Tests.dll",
+						Time:     1.5,
+						Tests:    3,
+						Failures: 1,
+						Suites: []Suite{
+							{
+								XMLName:  xml.Name{Local: "testsuite"},
+								Name:     "Foo collection",
+								Time:     1.5,
+								Tests:    3,
+								Failures: 1,
+								Results: []Result{
+									{
+										Name:      "Foo.Tests.Good",
+										ClassName: "Foo.Tests",
+										Time:      0.5,
+										Properties: &Properties{
+											PropertyList: []Property{{Name: "Category", Value: "fast"}},
+										},
+									},
+									{
+										Name:      "Foo.Tests.Bad",
+										ClassName: "Foo.Tests",
+										Time:      1,
+										Failure:   pstr("boom\nat Foo.Tests.Bad()"),
+										Output:    pstr("hello"),
+									},
+									{
+										Name:      "Foo.Tests.Later",
+										ClassName: "Foo.Tests",
+										Skipped:   pstr("not yet"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "parse nunit3 test-run",
+			buf: []byte(`
+                        <test-run id="2" total="4" failed="2">
+                            <test-suite type="Assembly" name="Bar.Tests.dll" total="4" failed="2" duration="2">
+                                <test-suite type="TestFixture" name="BarTests" total="4" failed="2" duration="2">
+                                    <test-case name="Passes" classname="Bar.BarTests" result="Passed" duration="0.25">
+                                        <properties><property name="Owner" value="bar-team"/></properties>
+                                        <attachments><attachment><filePath>screens/pass.png</filePath></attachment></attachments>
+                                    </test-case>
+                                    <test-case name="Fails" classname="Bar.BarTests" result="Failed" duration="0.5">
+                                        <failure><message>expected 1</message></failure>
+                                    </test-case>
+                                    <test-case name="Throws" classname="Bar.BarTests" result="Failed" label="Error" duration="1">
+                                        <failure><message>NullReferenceException</message><stack-trace>at Throws()</stack-trace></failure>
+                                    </test-case>
+                                    <test-case name="Ignored" classname="Bar.BarTests" result="Skipped" label="Ignored">
+                                        <reason><message>flaky</message></reason>
+                                    </test-case>
+                                </test-suite>
+                            </test-suite>
+                        </test-run>
+                        `),
+			expected: &Suites{
+				XMLName: xml.Name{Local: "testsuites"},
+				Suites: []Suite{
+					{
+						XMLName:  xml.Name{Local: "testsuite"},
+						Name:     "Bar.Tests.dll",
+						Time:     2,
+						Tests:    4,
+						Failures: 2,
+						Suites: []Suite{
+							{
+								XMLName:  xml.Name{Local: "testsuite"},
+								Name:     "BarTests",
+								Time:     2,
+								Tests:    4,
+								Failures: 2,
+								Results: []Result{
+									{
+										Name:      "Passes",
+										ClassName: "Bar.BarTests",
+										Time:      0.25,
+										Properties: &Properties{
+											PropertyList: []Property{
+												{Name: "Owner", Value: "bar-team"},
+												{Name: AttachmentProperty, Value: "screens/pass.png"},
+											},
+										},
+									},
+									{
+										Name:      "Fails",
+										ClassName: "Bar.BarTests",
+										Time:      0.5,
+										Failure:   pstr("expected 1"),
+									},
+									{
+										Name:      "Throws",
+										ClassName: "Bar.BarTests",
+										Time:      1,
+										Errored:   pstr("NullReferenceException\nat Throws()"),
+									},
+									{
+										Name:      "Ignored",
+										ClassName: "Bar.BarTests",
+										Skipped:   pstr("flaky"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
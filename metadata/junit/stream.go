@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// textLimitBytes is the most of the start and of the end of each run of
+// character data passed to the XML decoder.
+//
+// Truncated messages keep their first and last MaxMessageBytes/2 bytes, so
+// this leaves room for entities, which decode into fewer bytes.
+const textLimitBytes = 10 * MaxMessageBytes
+
+// readBufferBytes is the size of each read from the underlying reader.
+const readBufferBytes = 32 * 1024
+
+const (
+	cdataStart   = "<![CDATA["
+	commentStart = "<!--"
+)
+
+type scanState int
+
+const (
+	scanText scanState = iota
+	scanMarkupStart
+	scanTag
+	scanCDATA
+	scanComment
+)
+
+// textLimitReader streams XML, dropping the middle of long runs of character
+// data, CDATA and comments so that the decoder never buffers more than about
+// twice the limit of any of them.
+//
+// Runs are only cut at character boundaries outside of entities, keeping the
+// output well formed.
+type textLimitReader struct {
+	r     io.Reader
+	limit int
+	in    []byte
+	buf   []byte
+	out   []byte
+	err   error
+
+	state  scanState
+	prefix []byte
+	quote  byte
+
+	// The current run of character data.
+	kept     int
+	inEntity bool
+	dropping bool
+	// The last bytes of the run, once it exceeds the limit.
+	ring      []byte
+	ringStart int
+	ringLen   int
+	dropped   int
+	// Whether the dropped bytes end within an entity.
+	droppedEntity bool
+	last          [2]byte
+}
+
+// newTextLimitReader returns a reader which keeps the start and end of each
+// run of character data up to the limit.
+func newTextLimitReader(r io.Reader, limit int) *textLimitReader {
+	return &textLimitReader{
+		r:     r,
+		limit: limit,
+		in:    make([]byte, readBufferBytes),
+		ring:  make([]byte, limit),
+	}
+}
+
+// Read implements io.Reader.
+func (l *textLimitReader) Read(p []byte) (int, error) {
+	for len(l.out) == 0 {
+		if l.err != nil {
+			return 0, l.err
+		}
+		l.buf = l.buf[:0]
+		n, err := l.r.Read(l.in)
+		for _, b := range l.in[:n] {
+			l.scan(b)
+		}
+		if err != nil {
+			l.endRun()
+			l.err = err
+		}
+		l.out = l.buf
+	}
+	n := copy(p, l.out)
+	l.out = l.out[n:]
+	return n, nil
+}
+
+// scan processes the next byte of the stream.
+func (l *textLimitReader) scan(b byte) {
+	switch l.state {
+	case scanText:
+		if b == '<' {
+			l.endRun()
+			l.buf = append(l.buf, b)
+			l.state = scanMarkupStart
+			l.prefix = append(l.prefix[:0], b)
+			return
+		}
+		l.content(b)
+	case scanMarkupStart:
+		l.buf = append(l.buf, b)
+		l.prefix = append(l.prefix, b)
+		switch p := string(l.prefix); {
+		case p == cdataStart:
+			l.state = scanCDATA
+		case p == commentStart:
+			l.state = scanComment
+		case len(p) <= len(cdataStart) && p == cdataStart[:len(p)], len(p) <= len(commentStart) && p == commentStart[:len(p)]:
+		default:
+			l.state = scanTag
+			l.tag(b)
+		}
+	case scanTag:
+		l.buf = append(l.buf, b)
+		l.tag(b)
+	case scanCDATA, scanComment:
+		end := [2]byte{']', ']'}
+		if l.state == scanComment {
+			end = [2]byte{'-', '-'}
+		}
+		if b == '>' && l.last == end {
+			l.endRun()
+			l.buf = append(l.buf, b)
+			l.state = scanText
+			return
+		}
+		l.content(b)
+		l.last = [2]byte{l.last[1], b}
+	}
+}
+
+// tag tracks the quoted attribute values and end of a tag.
+func (l *textLimitReader) tag(b byte) {
+	switch {
+	case l.quote != 0:
+		if b == l.quote {
+			l.quote = 0
+		}
+	case b == '"' || b == '\'':
+		l.quote = b
+	case b == '>':
+		l.state = scanText
+	}
+}
+
+// content keeps the first limit bytes of the run and buffers the rest,
+// dropping all but the last limit bytes.
+func (l *textLimitReader) content(b byte) {
+	text := l.state == scanText
+	if !l.dropping {
+		if l.kept < l.limit || !utf8.RuneStart(b) || text && l.inEntity {
+			l.buf = append(l.buf, b)
+			l.kept++
+			if text {
+				l.inEntity = entity(l.inEntity, b)
+			}
+			return
+		}
+		l.dropping = true
+		l.droppedEntity = l.inEntity
+	}
+	if l.ringLen == len(l.ring) {
+		if text {
+			l.droppedEntity = entity(l.droppedEntity, l.ring[l.ringStart])
+		}
+		l.ringStart = (l.ringStart + 1) % len(l.ring)
+		l.ringLen--
+		l.dropped++
+	}
+	l.ring[(l.ringStart+l.ringLen)%len(l.ring)] = b
+	l.ringLen++
+}
+
+// entity returns whether the byte leaves the character data within an entity.
+func entity(in bool, b byte) bool {
+	switch b {
+	case '&':
+		return true
+	case ';':
+		return false
+	}
+	return in
+}
+
+// endRun writes the buffered end of the current run, starting after any
+// partial character or entity left by the dropped bytes.
+func (l *textLimitReader) endRun() {
+	text := l.state == scanText
+	inEntity := l.droppedEntity
+	skip := l.dropped > 0
+	for i := 0; i < l.ringLen; i++ {
+		b := l.ring[(l.ringStart+i)%len(l.ring)]
+		if skip {
+			if inEntity || !utf8.RuneStart(b) {
+				if text {
+					inEntity = entity(inEntity, b)
+				}
+				continue
+			}
+			skip = false
+		}
+		l.buf = append(l.buf, b)
+	}
+	l.kept, l.inEntity, l.dropping = 0, false, false
+	l.ringStart, l.ringLen, l.dropped = 0, 0, 0
+	l.droppedEntity = false
+	l.last = [2]byte{}
+}
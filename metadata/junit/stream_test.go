@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTextLimitReader(t *testing.T) {
+	cases := []struct {
+		name  string
+		in    string
+		limit int
+		want  string
+	}{
+		{
+			name:  "short text",
+			in:    `<a b="c">hello</a>`,
+			limit: 5,
+			want:  `<a b="c">hello</a>`,
+		},
+		{
+			name:  "text within twice the limit",
+			in:    `<a>0123456789</a>`,
+			limit: 5,
+			want:  `<a>0123456789</a>`,
+		},
+		{
+			name:  "long text",
+			in:    `<a>0123456789abcdef</a>`,
+			limit: 5,
+			want:  `<a>01234bcdef</a>`,
+		},
+		{
+			name:  "keep entities whole",
+			in:    `<a>0123&amp;456789abcdef&lt;</a>`,
+			limit: 5,
+			want:  `<a>0123&amp;f&lt;</a>`,
+		},
+		{
+			name:  "keep characters whole",
+			in:    `<a>0123ééé456789abcdefé</a>`,
+			limit: 5,
+			want:  `<a>0123édefé</a>`,
+		},
+		{
+			name:  "quoted attributes",
+			in:    `<a b="x>0123456789abcdef">0123456789abcdef</a>`,
+			limit: 5,
+			want:  `<a b="x>0123456789abcdef">01234bcdef</a>`,
+		},
+		{
+			name:  "cdata",
+			in:    `<a><![CDATA[<0123456789abcdef>]]></a>`,
+			limit: 5,
+			want:  `<a><![CDATA[<0123ef>]]></a>`,
+		},
+		{
+			name:  "comment",
+			in:    `<a><!-- 0123456789abcdef --></a>`,
+			limit: 5,
+			want:  `<a><!-- 0123ef --></a>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Read a byte at a time to cross every boundary.
+			r := newTextLimitReader(iotest.OneByteReader(strings.NewReader(tc.in)), tc.limit)
+			buf, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() got unexpected error: %v", err)
+			}
+			got := string(buf)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("textLimitReader got unexpected diff (-want +got):\n%s", diff)
+			}
+			if err := xml.Unmarshal(buf, new(interface{})); err != nil {
+				t.Errorf("textLimitReader got malformed xml: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseStreamLongMessages(t *testing.T) {
+	long := strings.Repeat("a", MaxMessageBytes) + strings.Repeat("&lt;", 3*textLimitBytes) + strings.Repeat("z", MaxMessageBytes)
+	in := `<testsuite><testcase name="hi"><failure>` + long + `</failure></testcase></testsuite>`
+	suites, err := ParseStream(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ParseStream() got unexpected error: %v", err)
+	}
+	want := strings.Repeat("a", MaxMessageBytes/2) + "..." + strings.Repeat("z", MaxMessageBytes/2)
+	if got := *suites.Suites[0].Results[0].Failure; got != want {
+		t.Errorf("ParseStream() got failure of %d bytes, want %d bytes", len(got), len(want))
+	}
+}
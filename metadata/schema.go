@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// kind describes the json type a schema field requires.
+type kind int
+
+const (
+	kindTimestamp kind = iota // positive epoch seconds
+	kindString
+	kindBool
+	kindStrings  // object of strings
+	kindMetadata // object of strings or objects of strings
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindTimestamp:
+		return "epoch seconds"
+	case kindString:
+		return "a string"
+	case kindBool:
+		return "a boolean"
+	case kindStrings:
+		return "an object of strings"
+	}
+	return "an object of strings or objects of strings"
+}
+
+// field describes a top-level key of started.json or finished.json.
+type field struct {
+	kind     kind
+	required bool
+	// replacement is set for deprecated fields.
+	replacement string
+}
+
+var startedSchema = map[string]field{
+	"timestamp":    {kind: kindTimestamp, required: true},
+	"node":         {kind: kindString},
+	"pull":         {kind: kindString},
+	"repos":        {kind: kindStrings},
+	"repo-commit":  {kind: kindString},
+	"metadata":     {kind: kindMetadata, replacement: "finished.json metadata"},
+	"job-version":  {kind: kindString, replacement: "repo-commit"},
+	"repo-version": {kind: kindString, replacement: "repo-commit"},
+}
+
+var finishedSchema = map[string]field{
+	"timestamp":    {kind: kindTimestamp, required: true},
+	"passed":       {kind: kindBool, required: true},
+	"metadata":     {kind: kindMetadata},
+	"result":       {kind: kindString, replacement: "passed"},
+	"job-version":  {kind: kindString, replacement: "metadata " + JobVersion},
+	"revision":     {kind: kindString, replacement: "metadata " + JobVersion},
+	"repo-version": {kind: kindString, replacement: "metadata " + JobVersion},
+}
+
+// StartedViolations returns how the started.json content deviates from the
+// metadata schema, such as missing, mistyped, unknown or deprecated fields.
+func StartedViolations(buf []byte) []string {
+	return violations(startedSchema, buf)
+}
+
+// FinishedViolations returns how the finished.json content deviates from the
+// metadata schema, such as missing, mistyped, unknown or deprecated fields.
+func FinishedViolations(buf []byte) []string {
+	return violations(finishedSchema, buf)
+}
+
+func violations(schema map[string]field, buf []byte) []string {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &obj); err != nil || obj == nil {
+		return []string{"not a json object"}
+	}
+	var out []string
+	for name, f := range schema {
+		if _, ok := obj[name]; !ok && f.required {
+			out = append(out, fmt.Sprintf("missing %q", name))
+		}
+	}
+	for name, raw := range obj {
+		f, ok := schema[name]
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("unknown field %q", name))
+		case !f.kind.matches(raw):
+			out = append(out, fmt.Sprintf("%q must be %s", name, f.kind))
+		case f.replacement != "":
+			out = append(out, fmt.Sprintf("%q is deprecated, use %s", name, f.replacement))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (k kind) matches(raw json.RawMessage) bool {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return false
+	}
+	switch k {
+	case kindTimestamp:
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return false
+		}
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		i, err := n.Int64()
+		return err == nil && i > 0
+	case kindString:
+		var s string
+		return json.Unmarshal(raw, &s) == nil
+	case kindBool:
+		var b bool
+		return json.Unmarshal(raw, &b) == nil
+	case kindStrings:
+		var m map[string]string
+		return json.Unmarshal(raw, &m) == nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return false
+	}
+	for _, v := range m {
+		if !kindString.matches(v) && !kindStrings.matches(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStartedViolations(t *testing.T) {
+	cases := []struct {
+		name string
+		buf  string
+		want []string
+	}{
+		{
+			name: "compliant",
+			buf:  `{"timestamp": 1600000000, "node": "n", "pull": "123", "repos": {"org/repo": "main:abc"}, "repo-commit": "abc"}`,
+		},
+		{
+			name: "not an object",
+			buf:  `[1, 2]`,
+			want: []string{"not a json object"},
+		},
+		{
+			name: "missing timestamp",
+			buf:  `{"node": "n"}`,
+			want: []string{`missing "timestamp"`},
+		},
+		{
+			name: "mistyped fields",
+			buf:  `{"timestamp": "1600000000", "node": 5, "repos": {"org/repo": 1}}`,
+			want: []string{
+				`"node" must be a string`,
+				`"repos" must be an object of strings`,
+				`"timestamp" must be epoch seconds`,
+			},
+		},
+		{
+			name: "fractional timestamp",
+			buf:  `{"timestamp": 1600000000.5}`,
+			want: []string{`"timestamp" must be epoch seconds`},
+		},
+		{
+			name: "null fields",
+			buf:  `{"timestamp": 1, "node": null}`,
+			want: []string{`"node" must be a string`},
+		},
+		{
+			name: "unknown and deprecated fields",
+			buf:  `{"timestamp": 1, "hello": "world", "job-version": "v1", "metadata": {"a": "b"}}`,
+			want: []string{
+				`"job-version" is deprecated, use repo-commit`,
+				`"metadata" is deprecated, use finished.json metadata`,
+				`unknown field "hello"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := StartedViolations([]byte(tc.buf))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("StartedViolations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFinishedViolations(t *testing.T) {
+	cases := []struct {
+		name string
+		buf  string
+		want []string
+	}{
+		{
+			name: "compliant",
+			buf:  `{"timestamp": 1600000000, "passed": true, "metadata": {"job-version": "v1", "repos": {"org/repo": "main"}}}`,
+		},
+		{
+			name: "missing required fields",
+			buf:  `{}`,
+			want: []string{
+				`missing "passed"`,
+				`missing "timestamp"`,
+			},
+		},
+		{
+			name: "nested metadata",
+			buf:  `{"timestamp": 1, "passed": false, "metadata": {"deep": {"deeper": {"a": "b"}}}}`,
+			want: []string{`"metadata" must be an object of strings or objects of strings`},
+		},
+		{
+			name: "deprecated result",
+			buf:  `{"timestamp": 1, "passed": false, "result": "FAILURE", "revision": "v1"}`,
+			want: []string{
+				`"result" is deprecated, use passed`,
+				`"revision" is deprecated, use metadata job-version`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FinishedViolations([]byte(tc.buf))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FinishedViolations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
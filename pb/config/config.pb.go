@@ -22,6 +22,7 @@ package config
 import (
 	fmt "fmt"
 	custom_evaluator "github.com/GoogleCloudPlatform/testgrid/pb/custom_evaluator"
+	test_status "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	proto "github.com/golang/protobuf/proto"
 	math "math"
 )
@@ -132,6 +133,132 @@ func (TestGroup_PrimaryGrouping) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 2}
 }
 
+// Order of the builds of the group, both when listing new builds to read
+// and when ordering the columns of the grid.
+type TestGroup_BuildOrder int32
+
+const (
+	// Columns by start time, with builds listed in natural order.
+	TestGroup_BUILD_ORDER_STARTED TestGroup_BuildOrder = 0
+	// Natural order of build names, so 10 is newer than 9. Entirely numeric
+	// names are newer than other names, so mixed formats never interleave.
+	TestGroup_BUILD_ORDER_NATURAL TestGroup_BuildOrder = 1
+	// Byte-wise order of build names, such as timestamps like 20210102-0934.
+	TestGroup_BUILD_ORDER_LEXICAL TestGroup_BuildOrder = 2
+	// Natural order of the build_order_metadata value of each column, which
+	// must be in column_metadata. Columns without the value are oldest.
+	TestGroup_BUILD_ORDER_METADATA TestGroup_BuildOrder = 3
+)
+
+var TestGroup_BuildOrder_name = map[int32]string{
+	0: "BUILD_ORDER_STARTED",
+	1: "BUILD_ORDER_NATURAL",
+	2: "BUILD_ORDER_LEXICAL",
+	3: "BUILD_ORDER_METADATA",
+}
+
+var TestGroup_BuildOrder_value = map[string]int32{
+	"BUILD_ORDER_STARTED":  0,
+	"BUILD_ORDER_NATURAL":  1,
+	"BUILD_ORDER_LEXICAL":  2,
+	"BUILD_ORDER_METADATA": 3,
+}
+
+func (x TestGroup_BuildOrder) String() string {
+	return proto.EnumName(TestGroup_BuildOrder_name, int32(x))
+}
+
+func (TestGroup_BuildOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 3}
+}
+
+type TestGroup_SyntheticRow_Position int32
+
+const (
+	TestGroup_SyntheticRow_SORTED TestGroup_SyntheticRow_Position = 0
+	TestGroup_SyntheticRow_TOP    TestGroup_SyntheticRow_Position = 1
+	TestGroup_SyntheticRow_BOTTOM TestGroup_SyntheticRow_Position = 2
+)
+
+var TestGroup_SyntheticRow_Position_name = map[int32]string{
+	0: "SORTED",
+	1: "TOP",
+	2: "BOTTOM",
+}
+
+var TestGroup_SyntheticRow_Position_value = map[string]int32{
+	"SORTED": 0,
+	"TOP":    1,
+	"BOTTOM": 2,
+}
+
+func (x TestGroup_SyntheticRow_Position) String() string {
+	return proto.EnumName(TestGroup_SyntheticRow_Position_name, int32(x))
+}
+
+func (TestGroup_SyntheticRow_Position) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4, 0}
+}
+
+type CellProperty_Type int32
+
+const (
+	CellProperty_STRING CellProperty_Type = 0
+	CellProperty_INT    CellProperty_Type = 1
+	CellProperty_FLOAT  CellProperty_Type = 2
+	CellProperty_BOOL   CellProperty_Type = 3
+)
+
+var CellProperty_Type_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+	2: "FLOAT",
+	3: "BOOL",
+}
+
+var CellProperty_Type_value = map[string]int32{
+	"STRING": 0,
+	"INT":    1,
+	"FLOAT":  2,
+	"BOOL":   3,
+}
+
+func (x CellProperty_Type) String() string {
+	return proto.EnumName(CellProperty_Type_name, int32(x))
+}
+
+func (CellProperty_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{13, 0}
+}
+
+type TestManagementExport_System int32
+
+const (
+	TestManagementExport_SYSTEM_UNSPECIFIED TestManagementExport_System = 0
+	TestManagementExport_JIRA_XRAY          TestManagementExport_System = 1
+	TestManagementExport_POLARION           TestManagementExport_System = 2
+)
+
+var TestManagementExport_System_name = map[int32]string{
+	0: "SYSTEM_UNSPECIFIED",
+	1: "JIRA_XRAY",
+	2: "POLARION",
+}
+
+var TestManagementExport_System_value = map[string]int32{
+	"SYSTEM_UNSPECIFIED": 0,
+	"JIRA_XRAY":          1,
+	"POLARION":           2,
+}
+
+func (x TestManagementExport_System) String() string {
+	return proto.EnumName(TestManagementExport_System_name, int32(x))
+}
+
+func (TestManagementExport_System) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{14, 0}
+}
+
 // Scale of issue priority, used to indicate importance of issue.
 type AutoBugOptions_Priority int32
 
@@ -169,7 +296,146 @@ func (x AutoBugOptions_Priority) String() string {
 }
 
 func (AutoBugOptions_Priority) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{5, 0}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{16, 0}
+}
+
+// Orders the rows of each tab.
+type DisplayOptions_SortOrder int32
+
+const (
+	DisplayOptions_SORT_UNSPECIFIED DisplayOptions_SortOrder = 0
+	DisplayOptions_NAME             DisplayOptions_SortOrder = 1
+	DisplayOptions_FAILURES_FIRST   DisplayOptions_SortOrder = 2
+	DisplayOptions_FLAKINESS        DisplayOptions_SortOrder = 3
+)
+
+var DisplayOptions_SortOrder_name = map[int32]string{
+	0: "SORT_UNSPECIFIED",
+	1: "NAME",
+	2: "FAILURES_FIRST",
+	3: "FLAKINESS",
+}
+
+var DisplayOptions_SortOrder_value = map[string]int32{
+	"SORT_UNSPECIFIED": 0,
+	"NAME":             1,
+	"FAILURES_FIRST":   2,
+	"FLAKINESS":        3,
+}
+
+func (x DisplayOptions_SortOrder) String() string {
+	return proto.EnumName(DisplayOptions_SortOrder_name, int32(x))
+}
+
+func (DisplayOptions_SortOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{20, 0}
+}
+
+// Palettes of the cell colors.
+type DisplayOptions_Palette int32
+
+const (
+	DisplayOptions_PALETTE_UNSPECIFIED DisplayOptions_Palette = 0
+	DisplayOptions_STANDARD            DisplayOptions_Palette = 1
+	DisplayOptions_DEUTERANOPIA        DisplayOptions_Palette = 2
+	DisplayOptions_TRITANOPIA          DisplayOptions_Palette = 3
+	DisplayOptions_HIGH_CONTRAST       DisplayOptions_Palette = 4
+)
+
+var DisplayOptions_Palette_name = map[int32]string{
+	0: "PALETTE_UNSPECIFIED",
+	1: "STANDARD",
+	2: "DEUTERANOPIA",
+	3: "TRITANOPIA",
+	4: "HIGH_CONTRAST",
+}
+
+var DisplayOptions_Palette_value = map[string]int32{
+	"PALETTE_UNSPECIFIED": 0,
+	"STANDARD":            1,
+	"DEUTERANOPIA":        2,
+	"TRITANOPIA":          3,
+	"HIGH_CONTRAST":       4,
+}
+
+func (x DisplayOptions_Palette) String() string {
+	return proto.EnumName(DisplayOptions_Palette_name, int32(x))
+}
+
+func (DisplayOptions_Palette) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{20, 1}
+}
+
+type NotificationWindow_Day int32
+
+const (
+	NotificationWindow_DAY_UNSPECIFIED NotificationWindow_Day = 0
+	NotificationWindow_MONDAY          NotificationWindow_Day = 1
+	NotificationWindow_TUESDAY         NotificationWindow_Day = 2
+	NotificationWindow_WEDNESDAY       NotificationWindow_Day = 3
+	NotificationWindow_THURSDAY        NotificationWindow_Day = 4
+	NotificationWindow_FRIDAY          NotificationWindow_Day = 5
+	NotificationWindow_SATURDAY        NotificationWindow_Day = 6
+	NotificationWindow_SUNDAY          NotificationWindow_Day = 7
+)
+
+var NotificationWindow_Day_name = map[int32]string{
+	0: "DAY_UNSPECIFIED",
+	1: "MONDAY",
+	2: "TUESDAY",
+	3: "WEDNESDAY",
+	4: "THURSDAY",
+	5: "FRIDAY",
+	6: "SATURDAY",
+	7: "SUNDAY",
+}
+
+var NotificationWindow_Day_value = map[string]int32{
+	"DAY_UNSPECIFIED": 0,
+	"MONDAY":          1,
+	"TUESDAY":         2,
+	"WEDNESDAY":       3,
+	"THURSDAY":        4,
+	"FRIDAY":          5,
+	"SATURDAY":        6,
+	"SUNDAY":          7,
+}
+
+func (x NotificationWindow_Day) String() string {
+	return proto.EnumName(NotificationWindow_Day_name, int32(x))
+}
+
+func (NotificationWindow_Day) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{24, 0}
+}
+
+// Periods of rolled up columns.
+type DashboardTab_Rollup int32
+
+const (
+	DashboardTab_ROLLUP_UNSPECIFIED DashboardTab_Rollup = 0
+	DashboardTab_HOURLY             DashboardTab_Rollup = 1
+	DashboardTab_DAILY              DashboardTab_Rollup = 2
+)
+
+var DashboardTab_Rollup_name = map[int32]string{
+	0: "ROLLUP_UNSPECIFIED",
+	1: "HOURLY",
+	2: "DAILY",
+}
+
+var DashboardTab_Rollup_value = map[string]int32{
+	"ROLLUP_UNSPECIFIED": 0,
+	"HOURLY":             1,
+	"DAILY":              2,
+}
+
+func (x DashboardTab_Rollup) String() string {
+	return proto.EnumName(DashboardTab_Rollup_name, int32(x))
+}
+
+func (DashboardTab_Rollup) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{28, 0}
 }
 
 // Specifies the test name, and its source
@@ -514,10 +780,127 @@ type TestGroup struct {
 	BuildOverrideStrftime string `protobuf:"bytes,55,opt,name=build_override_strftime,json=buildOverrideStrftime,proto3" json:"build_override_strftime,omitempty"`
 	// Specify a property that will be read into state in the user_property field.
 	// These can be substituted into LinkTemplates.
-	UserProperty         string   `protobuf:"bytes,56,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UserProperty string `protobuf:"bytes,56,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// Junit properties to extract into named cell fields, which are stored in
+	// the state and may be used to filter rows.
+	CellProperties []*CellProperty `protobuf:"bytes,63,rep,name=cell_properties,json=cellProperties,proto3" json:"cell_properties,omitempty"`
+	// Push the results of each completed build to a test management system.
+	TestManagementExport *TestManagementExport `protobuf:"bytes,64,opt,name=test_management_export,json=testManagementExport,proto3" json:"test_management_export,omitempty"`
+	// Rules applied to test names before they are matched to rows, so that
+	// the same logical test maps to one row regardless of runner quirks.
+	TestNameNormalization *TestNameNormalization `protobuf:"bytes,65,opt,name=test_name_normalization,json=testNameNormalization,proto3" json:"test_name_normalization,omitempty"`
+	// Fold test cases which differ only by a trailing parameter list, such as
+	// test_foo[1] and test_foo[2], into a single row. Cells show the worst
+	// result and retain the result of each parameter for drill-down.
+	FoldParameterizedTests bool `protobuf:"varint,66,opt,name=fold_parameterized_tests,json=foldParameterizedTests,proto3" json:"fold_parameterized_tests,omitempty"`
+	// Maximum number of test rows in the grid. Tests beyond the limit are
+	// collapsed into a single OVERFLOW row with the worst result.
+	// Zero means no limit.
+	MaxRows int32 `protobuf:"varint,67,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// Additional locations, such as gs://other-bucket/prefix or a local
+	// directory, where the group's state is also written. A failure to write
+	// one destination does not affect the others.
+	MirrorPrefixes []string `protobuf:"bytes,68,rep,name=mirror_prefixes,json=mirrorPrefixes,proto3" json:"mirror_prefixes,omitempty"`
+	// Publishes a stripped copy of the grid, without messages or links,
+	// suitable for public serving through a CDN.
+	PublicGrid *PublicGrid `protobuf:"bytes,69,opt,name=public_grid,json=publicGrid,proto3" json:"public_grid,omitempty"`
+	// Rules applied to cell messages, properties and links before the state
+	// is written, such as to remove credentials or internal hostnames.
+	Redactions []*Redaction `protobuf:"bytes,70,rep,name=redactions,proto3" json:"redactions,omitempty"`
+	// Minimum time between updates of this group, such as 6h, so that dormant
+	// groups consume less quota. Defaults to updating the group every cycle.
+	UpdateInterval string `protobuf:"bytes,71,opt,name=update_interval,json=updateInterval,proto3" json:"update_interval,omitempty"`
+	// If true, the updater skips this group and its tabs display as paused.
+	Paused bool `protobuf:"varint,72,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Archive the group after this many days without new results, moving its
+	// state under archive_prefix. Archived groups only check for new builds
+	// and revive automatically when one appears. Zero disables archival.
+	ArchiveAfterDays int32 `protobuf:"varint,73,opt,name=archive_after_days,json=archiveAfterDays,proto3" json:"archive_after_days,omitempty"`
+	// Location, such as gs://cold-bucket/archive, of archived group state.
+	ArchivePrefix string `protobuf:"bytes,74,opt,name=archive_prefix,json=archivePrefix,proto3" json:"archive_prefix,omitempty"`
+	// Move columns older than this many days out of the grid into daily shards
+	// under archive_prefix, which keeps the grid small while days_of_results
+	// retains a longer history. Full history reads stitch the shards back
+	// into the grid. Zero keeps every column in the grid.
+	ArchiveColumnsAfterDays int32 `protobuf:"varint,99,opt,name=archive_columns_after_days,json=archiveColumnsAfterDays,proto3" json:"archive_columns_after_days,omitempty"`
+	// Rules which turn issue references in cell messages, such as #123 or
+	// b/456, into links on the cell.
+	IssueLinkRules []*IssueLinkRule `protobuf:"bytes,75,rep,name=issue_link_rules,json=issueLinkRules,proto3" json:"issue_link_rules,omitempty"`
+	// Detach associated issues from a row once its most recent results pass
+	// this many times in a row. Zero keeps issues until they are detached.
+	ClearIssuesAfterPasses int32 `protobuf:"varint,76,opt,name=clear_issues_after_passes,json=clearIssuesAfterPasses,proto3" json:"clear_issues_after_passes,omitempty"`
+	// Mark columns where more than this fraction of tests fail as broken
+	// (between 0.0 and 1.0). Broken columns neither open nor close alerts and
+	// are excluded from flakiness. Zero disables detection.
+	BrokenColumnThreshold float32 `protobuf:"fixed32,77,opt,name=broken_column_threshold,json=brokenColumnThreshold,proto3" json:"broken_column_threshold,omitempty"`
+	// Build metadata to store with each column and serve from the column
+	// detail API. Keys name finished.json metadata, or podinfo.json fields
+	// with a pod. prefix: pod.node, pod.phase, pod.image, pod.label.<name>
+	// and pod.annotation.<name>.
+	ColumnMetadata []string `protobuf:"bytes,78,rep,name=column_metadata,json=columnMetadata,proto3" json:"column_metadata,omitempty"`
+	// The row summarizing the result of each build, named Overall by default.
+	OverallRow *TestGroup_SyntheticRow `protobuf:"bytes,79,opt,name=overall_row,json=overallRow,proto3" json:"overall_row,omitempty"`
+	// The row analyzing the prow job's pod, named Pod by default.
+	PodRow *TestGroup_SyntheticRow `protobuf:"bytes,80,opt,name=pod_row,json=podRow,proto3" json:"pod_row,omitempty"`
+	// Version of the encoding used to write the state of this group.
+	// Unset or 1 run-length encodes results and repeats each cell string.
+	// 2 delta encodes results and stores cell strings in a per-grid dictionary,
+	// which greatly shrinks groups with long rows of repeated messages.
+	StateVersion int32 `protobuf:"varint,81,opt,name=state_version,json=stateVersion,proto3" json:"state_version,omitempty"`
+	// Duration budgets of the tests and suites in this group. When several
+	// budgets match, the smallest one applies.
+	DurationBudgets []*TestGroup_DurationBudget `protobuf:"bytes,82,rep,name=duration_budgets,json=durationBudgets,proto3" json:"duration_budgets,omitempty"`
+	// Results to merge with those of gcs_prefix into one grid, labeling the
+	// source of each column. Compares branches or presubmits with postsubmits
+	// in a single tab.
+	MergedSources []*TestGroup_MergedSource `protobuf:"bytes,83,rep,name=merged_sources,json=mergedSources,proto3" json:"merged_sources,omitempty"`
+	// Label of the columns read from gcs_prefix when merging merged_sources.
+	SourceName string `protobuf:"bytes,84,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	// Repo, such as org/repo, whose refs populate the Commit, Branch and Pull
+	// column headers when builds check out several repos (extra_refs). Unset
+	// uses the job version for Commit and the primary repo otherwise.
+	CommitRepo         string               `protobuf:"bytes,85,opt,name=commit_repo,json=commitRepo,proto3" json:"commit_repo,omitempty"`
+	BuildOrder         TestGroup_BuildOrder `protobuf:"varint,86,opt,name=build_order,json=buildOrder,proto3,enum=TestGroup_BuildOrder" json:"build_order,omitempty"`
+	BuildOrderMetadata string               `protobuf:"bytes,87,opt,name=build_order_metadata,json=buildOrderMetadata,proto3" json:"build_order_metadata,omitempty"`
+	// Record which builds merge into each column, such as builds sharing a
+	// build_override_strftime date, and report the cells dropped when their
+	// names collide rather than silently keeping only one of them.
+	StrictColumns bool `protobuf:"varint,88,opt,name=strict_columns,json=strictColumns,proto3" json:"strict_columns,omitempty"`
+	// Name of a test group template whose settings fill every field this group
+	// leaves unset, resolved when the config is loaded. Templates may inherit
+	// from other templates.
+	Inherits string `protobuf:"bytes,89,opt,name=inherits,proto3" json:"inherits,omitempty"`
+	// Validate the started.json and finished.json of each build against the
+	// metadata schema, recording any violations with its column.
+	StrictMetadata bool `protobuf:"varint,90,opt,name=strict_metadata,json=strictMetadata,proto3" json:"strict_metadata,omitempty"`
+	// Refused updates write the candidate state beside the current state with a
+	// .quarantine suffix and leave the current state alone. Accept a candidate
+	// by copying it over the current state, or by raising the limits.
+	WriteGuard *TestGroup_WriteGuard `protobuf:"bytes,91,opt,name=write_guard,json=writeGuard,proto3" json:"write_guard,omitempty"`
+	// Read columns with the updater's column reader plugin of this name instead
+	// of from gcs_prefix, which becomes optional.
+	ColumnReader string `protobuf:"bytes,92,opt,name=column_reader,json=columnReader,proto3" json:"column_reader,omitempty"`
+	// Mark rows whose first result is within this many days as new tests. New
+	// tests are not marked when zero.
+	NewTestDays int32 `protobuf:"varint,93,opt,name=new_test_days,json=newTestDays,proto3" json:"new_test_days,omitempty"`
+	// Mark rows missing from this many consecutive columns which have other
+	// test results with a tombstone, such as when tests are removed or renamed.
+	// Columns without any test results, such as when the harness breaks, do not
+	// count. Rows are never marked when zero.
+	TombstoneColumns int32 `protobuf:"varint,94,opt,name=tombstone_columns,json=tombstoneColumns,proto3" json:"tombstone_columns,omitempty"`
+	// Move the history of tests renamed by these rules, in order, to their new
+	// names, so streaks and flakiness survive refactors.
+	RenameRules []*RenameRule `protobuf:"bytes,95,rep,name=rename_rules,json=renameRules,proto3" json:"rename_rules,omitempty"`
+	// Detect renames when a test stops in the column just before a test of at
+	// least this similar name, between 0 and 1, starts. Not detected when zero.
+	RenameSimilarity float32 `protobuf:"fixed32,96,opt,name=rename_similarity,json=renameSimilarity,proto3" json:"rename_similarity,omitempty"`
+	// GCS path to a JSON object mapping old test names to new ones, such as
+	// gs://bucket/renames.json, which overrides rename_rules and detection.
+	RenameMapping        string                    `protobuf:"bytes,97,opt,name=rename_mapping,json=renameMapping,proto3" json:"rename_mapping,omitempty"`
+	ResultSampling       *TestGroup_ResultSampling `protobuf:"bytes,98,opt,name=result_sampling,json=resultSampling,proto3" json:"result_sampling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *TestGroup) Reset()         { *m = TestGroup{} }
@@ -916,738 +1299,2692 @@ func (m *TestGroup) GetUserProperty() string {
 	return ""
 }
 
-// Custom column headers for defining extra column-heading rows from values in
-// the test result.
-type TestGroup_ColumnHeader struct {
-	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
-	Property             string   `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
-	ConfigurationValue   string   `protobuf:"bytes,3,opt,name=configuration_value,json=configurationValue,proto3" json:"configuration_value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *TestGroup) GetCellProperties() []*CellProperty {
+	if m != nil {
+		return m.CellProperties
+	}
+	return nil
 }
 
-func (m *TestGroup_ColumnHeader) Reset()         { *m = TestGroup_ColumnHeader{} }
-func (m *TestGroup_ColumnHeader) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_ColumnHeader) ProtoMessage()    {}
-func (*TestGroup_ColumnHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 0}
+func (m *TestGroup) GetTestManagementExport() *TestManagementExport {
+	if m != nil {
+		return m.TestManagementExport
+	}
+	return nil
 }
 
-func (m *TestGroup_ColumnHeader) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_ColumnHeader.Unmarshal(m, b)
-}
-func (m *TestGroup_ColumnHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_ColumnHeader.Marshal(b, m, deterministic)
-}
-func (m *TestGroup_ColumnHeader) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_ColumnHeader.Merge(m, src)
+func (m *TestGroup) GetTestNameNormalization() *TestNameNormalization {
+	if m != nil {
+		return m.TestNameNormalization
+	}
+	return nil
 }
-func (m *TestGroup_ColumnHeader) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_ColumnHeader.Size(m)
+
+func (m *TestGroup) GetFoldParameterizedTests() bool {
+	if m != nil {
+		return m.FoldParameterizedTests
+	}
+	return false
 }
-func (m *TestGroup_ColumnHeader) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_ColumnHeader.DiscardUnknown(m)
+
+func (m *TestGroup) GetMaxRows() int32 {
+	if m != nil {
+		return m.MaxRows
+	}
+	return 0
 }
 
-var xxx_messageInfo_TestGroup_ColumnHeader proto.InternalMessageInfo
+func (m *TestGroup) GetMirrorPrefixes() []string {
+	if m != nil {
+		return m.MirrorPrefixes
+	}
+	return nil
+}
 
-func (m *TestGroup_ColumnHeader) GetLabel() string {
+func (m *TestGroup) GetPublicGrid() *PublicGrid {
 	if m != nil {
-		return m.Label
+		return m.PublicGrid
 	}
-	return ""
+	return nil
 }
 
-func (m *TestGroup_ColumnHeader) GetProperty() string {
+func (m *TestGroup) GetRedactions() []*Redaction {
 	if m != nil {
-		return m.Property
+		return m.Redactions
 	}
-	return ""
+	return nil
 }
 
-func (m *TestGroup_ColumnHeader) GetConfigurationValue() string {
+func (m *TestGroup) GetUpdateInterval() string {
 	if m != nil {
-		return m.ConfigurationValue
+		return m.UpdateInterval
 	}
 	return ""
 }
 
-// Associates the presence of a named test property with a custom short text
-// displayed over the results. Short text must be <=5 characters long.
-type TestGroup_TestAnnotation struct {
-	ShortText string `protobuf:"bytes,1,opt,name=short_text,json=shortText,proto3" json:"short_text,omitempty"`
-	// Types that are valid to be assigned to ShortTextMessageSource:
-	//	*TestGroup_TestAnnotation_PropertyName
-	ShortTextMessageSource isTestGroup_TestAnnotation_ShortTextMessageSource `protobuf_oneof:"short_text_message_source"`
-	XXX_NoUnkeyedLiteral   struct{}                                          `json:"-"`
-	XXX_unrecognized       []byte                                            `json:"-"`
-	XXX_sizecache          int32                                             `json:"-"`
+func (m *TestGroup) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
 }
 
-func (m *TestGroup_TestAnnotation) Reset()         { *m = TestGroup_TestAnnotation{} }
-func (m *TestGroup_TestAnnotation) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_TestAnnotation) ProtoMessage()    {}
-func (*TestGroup_TestAnnotation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 1}
+func (m *TestGroup) GetArchiveAfterDays() int32 {
+	if m != nil {
+		return m.ArchiveAfterDays
+	}
+	return 0
 }
 
-func (m *TestGroup_TestAnnotation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_TestAnnotation.Unmarshal(m, b)
+func (m *TestGroup) GetArchivePrefix() string {
+	if m != nil {
+		return m.ArchivePrefix
+	}
+	return ""
 }
-func (m *TestGroup_TestAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_TestAnnotation.Marshal(b, m, deterministic)
+
+func (m *TestGroup) GetArchiveColumnsAfterDays() int32 {
+	if m != nil {
+		return m.ArchiveColumnsAfterDays
+	}
+	return 0
 }
-func (m *TestGroup_TestAnnotation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_TestAnnotation.Merge(m, src)
+
+func (m *TestGroup) GetIssueLinkRules() []*IssueLinkRule {
+	if m != nil {
+		return m.IssueLinkRules
+	}
+	return nil
 }
-func (m *TestGroup_TestAnnotation) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_TestAnnotation.Size(m)
+
+func (m *TestGroup) GetClearIssuesAfterPasses() int32 {
+	if m != nil {
+		return m.ClearIssuesAfterPasses
+	}
+	return 0
 }
-func (m *TestGroup_TestAnnotation) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_TestAnnotation.DiscardUnknown(m)
+
+func (m *TestGroup) GetBrokenColumnThreshold() float32 {
+	if m != nil {
+		return m.BrokenColumnThreshold
+	}
+	return 0
 }
 
-var xxx_messageInfo_TestGroup_TestAnnotation proto.InternalMessageInfo
+func (m *TestGroup) GetColumnMetadata() []string {
+	if m != nil {
+		return m.ColumnMetadata
+	}
+	return nil
+}
 
-func (m *TestGroup_TestAnnotation) GetShortText() string {
+func (m *TestGroup) GetOverallRow() *TestGroup_SyntheticRow {
 	if m != nil {
-		return m.ShortText
+		return m.OverallRow
 	}
-	return ""
+	return nil
 }
 
-type isTestGroup_TestAnnotation_ShortTextMessageSource interface {
-	isTestGroup_TestAnnotation_ShortTextMessageSource()
+func (m *TestGroup) GetPodRow() *TestGroup_SyntheticRow {
+	if m != nil {
+		return m.PodRow
+	}
+	return nil
 }
 
-type TestGroup_TestAnnotation_PropertyName struct {
-	PropertyName string `protobuf:"bytes,2,opt,name=property_name,json=propertyName,proto3,oneof"`
+func (m *TestGroup) GetStateVersion() int32 {
+	if m != nil {
+		return m.StateVersion
+	}
+	return 0
 }
 
-func (*TestGroup_TestAnnotation_PropertyName) isTestGroup_TestAnnotation_ShortTextMessageSource() {}
+func (m *TestGroup) GetDurationBudgets() []*TestGroup_DurationBudget {
+	if m != nil {
+		return m.DurationBudgets
+	}
+	return nil
+}
 
-func (m *TestGroup_TestAnnotation) GetShortTextMessageSource() isTestGroup_TestAnnotation_ShortTextMessageSource {
+func (m *TestGroup) GetMergedSources() []*TestGroup_MergedSource {
 	if m != nil {
-		return m.ShortTextMessageSource
+		return m.MergedSources
 	}
 	return nil
 }
 
-func (m *TestGroup_TestAnnotation) GetPropertyName() string {
-	if x, ok := m.GetShortTextMessageSource().(*TestGroup_TestAnnotation_PropertyName); ok {
-		return x.PropertyName
+func (m *TestGroup) GetSourceName() string {
+	if m != nil {
+		return m.SourceName
 	}
 	return ""
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*TestGroup_TestAnnotation) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*TestGroup_TestAnnotation_PropertyName)(nil),
+func (m *TestGroup) GetCommitRepo() string {
+	if m != nil {
+		return m.CommitRepo
 	}
+	return ""
 }
 
-// A string key value pair message
-type TestGroup_KeyValue struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *TestGroup) GetBuildOrder() TestGroup_BuildOrder {
+	if m != nil {
+		return m.BuildOrder
+	}
+	return TestGroup_BUILD_ORDER_STARTED
 }
 
-func (m *TestGroup_KeyValue) Reset()         { *m = TestGroup_KeyValue{} }
-func (m *TestGroup_KeyValue) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_KeyValue) ProtoMessage()    {}
-func (*TestGroup_KeyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 2}
+func (m *TestGroup) GetBuildOrderMetadata() string {
+	if m != nil {
+		return m.BuildOrderMetadata
+	}
+	return ""
+}
+
+func (m *TestGroup) GetStrictColumns() bool {
+	if m != nil {
+		return m.StrictColumns
+	}
+	return false
+}
+
+func (m *TestGroup) GetInherits() string {
+	if m != nil {
+		return m.Inherits
+	}
+	return ""
+}
+
+func (m *TestGroup) GetStrictMetadata() bool {
+	if m != nil {
+		return m.StrictMetadata
+	}
+	return false
+}
+
+func (m *TestGroup) GetWriteGuard() *TestGroup_WriteGuard {
+	if m != nil {
+		return m.WriteGuard
+	}
+	return nil
+}
+
+func (m *TestGroup) GetColumnReader() string {
+	if m != nil {
+		return m.ColumnReader
+	}
+	return ""
+}
+
+func (m *TestGroup) GetNewTestDays() int32 {
+	if m != nil {
+		return m.NewTestDays
+	}
+	return 0
+}
+
+func (m *TestGroup) GetTombstoneColumns() int32 {
+	if m != nil {
+		return m.TombstoneColumns
+	}
+	return 0
+}
+
+func (m *TestGroup) GetRenameRules() []*RenameRule {
+	if m != nil {
+		return m.RenameRules
+	}
+	return nil
+}
+
+func (m *TestGroup) GetRenameSimilarity() float32 {
+	if m != nil {
+		return m.RenameSimilarity
+	}
+	return 0
+}
+
+func (m *TestGroup) GetRenameMapping() string {
+	if m != nil {
+		return m.RenameMapping
+	}
+	return ""
+}
+
+func (m *TestGroup) GetResultSampling() *TestGroup_ResultSampling {
+	if m != nil {
+		return m.ResultSampling
+	}
+	return nil
+}
+
+// Custom column headers for defining extra column-heading rows from values in
+// the test result.
+type TestGroup_ColumnHeader struct {
+	Label              string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Property           string `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
+	ConfigurationValue string `protobuf:"bytes,3,opt,name=configuration_value,json=configurationValue,proto3" json:"configuration_value,omitempty"`
+	// Transformations applied in order to the value of the header.
+	Transforms           []*TestGroup_ColumnHeader_Transform `protobuf:"bytes,4,rep,name=transforms,proto3" json:"transforms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *TestGroup_ColumnHeader) Reset()         { *m = TestGroup_ColumnHeader{} }
+func (m *TestGroup_ColumnHeader) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ColumnHeader) ProtoMessage()    {}
+func (*TestGroup_ColumnHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 0}
+}
+
+func (m *TestGroup_ColumnHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ColumnHeader.Unmarshal(m, b)
+}
+func (m *TestGroup_ColumnHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ColumnHeader.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ColumnHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ColumnHeader.Merge(m, src)
+}
+func (m *TestGroup_ColumnHeader) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ColumnHeader.Size(m)
+}
+func (m *TestGroup_ColumnHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ColumnHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ColumnHeader proto.InternalMessageInfo
+
+func (m *TestGroup_ColumnHeader) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader) GetProperty() string {
+	if m != nil {
+		return m.Property
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader) GetConfigurationValue() string {
+	if m != nil {
+		return m.ConfigurationValue
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader) GetTransforms() []*TestGroup_ColumnHeader_Transform {
+	if m != nil {
+		return m.Transforms
+	}
+	return nil
+}
+
+// Transforms a column header value for display.
+type TestGroup_ColumnHeader_Transform struct {
+	// Types that are valid to be assigned to Transform:
+	//
+	//	*TestGroup_ColumnHeader_Transform_RegexCapture
+	//	*TestGroup_ColumnHeader_Transform_Strftime
+	//	*TestGroup_ColumnHeader_Transform_Truncate
+	//	*TestGroup_ColumnHeader_Transform_RoundDigits
+	Transform            isTestGroup_ColumnHeader_Transform_Transform `protobuf_oneof:"transform"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
+}
+
+func (m *TestGroup_ColumnHeader_Transform) Reset()         { *m = TestGroup_ColumnHeader_Transform{} }
+func (m *TestGroup_ColumnHeader_Transform) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ColumnHeader_Transform) ProtoMessage()    {}
+func (*TestGroup_ColumnHeader_Transform) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 0, 0}
+}
+
+func (m *TestGroup_ColumnHeader_Transform) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ColumnHeader_Transform.Unmarshal(m, b)
+}
+func (m *TestGroup_ColumnHeader_Transform) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ColumnHeader_Transform.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ColumnHeader_Transform) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ColumnHeader_Transform.Merge(m, src)
+}
+func (m *TestGroup_ColumnHeader_Transform) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ColumnHeader_Transform.Size(m)
+}
+func (m *TestGroup_ColumnHeader_Transform) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ColumnHeader_Transform.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ColumnHeader_Transform proto.InternalMessageInfo
+
+type isTestGroup_ColumnHeader_Transform_Transform interface {
+	isTestGroup_ColumnHeader_Transform_Transform()
+}
+
+type TestGroup_ColumnHeader_Transform_RegexCapture struct {
+	RegexCapture string `protobuf:"bytes,1,opt,name=regex_capture,json=regexCapture,proto3,oneof"`
+}
+
+type TestGroup_ColumnHeader_Transform_Strftime struct {
+	Strftime string `protobuf:"bytes,2,opt,name=strftime,proto3,oneof"`
+}
+
+type TestGroup_ColumnHeader_Transform_Truncate struct {
+	Truncate int32 `protobuf:"varint,3,opt,name=truncate,proto3,oneof"`
+}
+
+type TestGroup_ColumnHeader_Transform_RoundDigits struct {
+	RoundDigits int32 `protobuf:"varint,4,opt,name=round_digits,json=roundDigits,proto3,oneof"`
+}
+
+func (*TestGroup_ColumnHeader_Transform_RegexCapture) isTestGroup_ColumnHeader_Transform_Transform() {
+}
+
+func (*TestGroup_ColumnHeader_Transform_Strftime) isTestGroup_ColumnHeader_Transform_Transform() {}
+
+func (*TestGroup_ColumnHeader_Transform_Truncate) isTestGroup_ColumnHeader_Transform_Transform() {}
+
+func (*TestGroup_ColumnHeader_Transform_RoundDigits) isTestGroup_ColumnHeader_Transform_Transform() {}
+
+func (m *TestGroup_ColumnHeader_Transform) GetTransform() isTestGroup_ColumnHeader_Transform_Transform {
+	if m != nil {
+		return m.Transform
+	}
+	return nil
+}
+
+func (m *TestGroup_ColumnHeader_Transform) GetRegexCapture() string {
+	if x, ok := m.GetTransform().(*TestGroup_ColumnHeader_Transform_RegexCapture); ok {
+		return x.RegexCapture
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader_Transform) GetStrftime() string {
+	if x, ok := m.GetTransform().(*TestGroup_ColumnHeader_Transform_Strftime); ok {
+		return x.Strftime
+	}
+	return ""
+}
+
+func (m *TestGroup_ColumnHeader_Transform) GetTruncate() int32 {
+	if x, ok := m.GetTransform().(*TestGroup_ColumnHeader_Transform_Truncate); ok {
+		return x.Truncate
+	}
+	return 0
+}
+
+func (m *TestGroup_ColumnHeader_Transform) GetRoundDigits() int32 {
+	if x, ok := m.GetTransform().(*TestGroup_ColumnHeader_Transform_RoundDigits); ok {
+		return x.RoundDigits
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestGroup_ColumnHeader_Transform) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestGroup_ColumnHeader_Transform_RegexCapture)(nil),
+		(*TestGroup_ColumnHeader_Transform_Strftime)(nil),
+		(*TestGroup_ColumnHeader_Transform_Truncate)(nil),
+		(*TestGroup_ColumnHeader_Transform_RoundDigits)(nil),
+	}
+}
+
+// Associates the presence of a named test property with a custom short text
+// displayed over the results. Short text must be <=5 characters long.
+type TestGroup_TestAnnotation struct {
+	ShortText string `protobuf:"bytes,1,opt,name=short_text,json=shortText,proto3" json:"short_text,omitempty"`
+	// Types that are valid to be assigned to ShortTextMessageSource:
+	//	*TestGroup_TestAnnotation_PropertyName
+	ShortTextMessageSource isTestGroup_TestAnnotation_ShortTextMessageSource `protobuf_oneof:"short_text_message_source"`
+	XXX_NoUnkeyedLiteral   struct{}                                          `json:"-"`
+	XXX_unrecognized       []byte                                            `json:"-"`
+	XXX_sizecache          int32                                             `json:"-"`
+}
+
+func (m *TestGroup_TestAnnotation) Reset()         { *m = TestGroup_TestAnnotation{} }
+func (m *TestGroup_TestAnnotation) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_TestAnnotation) ProtoMessage()    {}
+func (*TestGroup_TestAnnotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 1}
+}
+
+func (m *TestGroup_TestAnnotation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_TestAnnotation.Unmarshal(m, b)
+}
+func (m *TestGroup_TestAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_TestAnnotation.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_TestAnnotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_TestAnnotation.Merge(m, src)
+}
+func (m *TestGroup_TestAnnotation) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_TestAnnotation.Size(m)
+}
+func (m *TestGroup_TestAnnotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_TestAnnotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_TestAnnotation proto.InternalMessageInfo
+
+func (m *TestGroup_TestAnnotation) GetShortText() string {
+	if m != nil {
+		return m.ShortText
+	}
+	return ""
+}
+
+type isTestGroup_TestAnnotation_ShortTextMessageSource interface {
+	isTestGroup_TestAnnotation_ShortTextMessageSource()
+}
+
+type TestGroup_TestAnnotation_PropertyName struct {
+	PropertyName string `protobuf:"bytes,2,opt,name=property_name,json=propertyName,proto3,oneof"`
+}
+
+func (*TestGroup_TestAnnotation_PropertyName) isTestGroup_TestAnnotation_ShortTextMessageSource() {}
+
+func (m *TestGroup_TestAnnotation) GetShortTextMessageSource() isTestGroup_TestAnnotation_ShortTextMessageSource {
+	if m != nil {
+		return m.ShortTextMessageSource
+	}
+	return nil
+}
+
+func (m *TestGroup_TestAnnotation) GetPropertyName() string {
+	if x, ok := m.GetShortTextMessageSource().(*TestGroup_TestAnnotation_PropertyName); ok {
+		return x.PropertyName
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestGroup_TestAnnotation) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestGroup_TestAnnotation_PropertyName)(nil),
+	}
+}
+
+// A string key value pair message
+type TestGroup_KeyValue struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_KeyValue) Reset()         { *m = TestGroup_KeyValue{} }
+func (m *TestGroup_KeyValue) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_KeyValue) ProtoMessage()    {}
+func (*TestGroup_KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 2}
+}
+
+func (m *TestGroup_KeyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_KeyValue.Unmarshal(m, b)
+}
+func (m *TestGroup_KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_KeyValue.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_KeyValue.Merge(m, src)
+}
+func (m *TestGroup_KeyValue) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_KeyValue.Size(m)
+}
+func (m *TestGroup_KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_KeyValue proto.InternalMessageInfo
+
+func (m *TestGroup_KeyValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *TestGroup_KeyValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type TestGroup_ResultSource struct {
+	// Types that are valid to be assigned to ResultSourceConfig:
+	//	*TestGroup_ResultSource_JunitConfig
+	//	*TestGroup_ResultSource_ResultdbConfig
+	//	*TestGroup_ResultSource_CircleciConfig
+	//	*TestGroup_ResultSource_TektonConfig
+	//	*TestGroup_ResultSource_ArgoConfig
+	ResultSourceConfig   isTestGroup_ResultSource_ResultSourceConfig `protobuf_oneof:"result_source_config"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *TestGroup_ResultSource) Reset()         { *m = TestGroup_ResultSource{} }
+func (m *TestGroup_ResultSource) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ResultSource) ProtoMessage()    {}
+func (*TestGroup_ResultSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 3}
+}
+
+func (m *TestGroup_ResultSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ResultSource.Unmarshal(m, b)
+}
+func (m *TestGroup_ResultSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ResultSource.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ResultSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ResultSource.Merge(m, src)
+}
+func (m *TestGroup_ResultSource) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ResultSource.Size(m)
+}
+func (m *TestGroup_ResultSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ResultSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ResultSource proto.InternalMessageInfo
+
+type isTestGroup_ResultSource_ResultSourceConfig interface {
+	isTestGroup_ResultSource_ResultSourceConfig()
+}
+
+type TestGroup_ResultSource_JunitConfig struct {
+	JunitConfig *JUnitConfig `protobuf:"bytes,2,opt,name=junit_config,json=junitConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_ResultdbConfig struct {
+	ResultdbConfig *ResultDBConfig `protobuf:"bytes,5,opt,name=resultdb_config,json=resultdbConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_CircleciConfig struct {
+	CircleciConfig *CircleCIConfig `protobuf:"bytes,6,opt,name=circleci_config,json=circleciConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_TektonConfig struct {
+	TektonConfig *TektonConfig `protobuf:"bytes,7,opt,name=tekton_config,json=tektonConfig,proto3,oneof"`
+}
+
+type TestGroup_ResultSource_ArgoConfig struct {
+	ArgoConfig *ArgoConfig `protobuf:"bytes,8,opt,name=argo_config,json=argoConfig,proto3,oneof"`
+}
+
+func (*TestGroup_ResultSource_JunitConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_ResultdbConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_CircleciConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_TektonConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (*TestGroup_ResultSource_ArgoConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+
+func (m *TestGroup_ResultSource) GetResultSourceConfig() isTestGroup_ResultSource_ResultSourceConfig {
+	if m != nil {
+		return m.ResultSourceConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetJunitConfig() *JUnitConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_JunitConfig); ok {
+		return x.JunitConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetResultdbConfig() *ResultDBConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_ResultdbConfig); ok {
+		return x.ResultdbConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetCircleciConfig() *CircleCIConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_CircleciConfig); ok {
+		return x.CircleciConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetTektonConfig() *TektonConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_TektonConfig); ok {
+		return x.TektonConfig
+	}
+	return nil
+}
+
+func (m *TestGroup_ResultSource) GetArgoConfig() *ArgoConfig {
+	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_ArgoConfig); ok {
+		return x.ArgoConfig
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestGroup_ResultSource) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestGroup_ResultSource_JunitConfig)(nil),
+		(*TestGroup_ResultSource_ResultdbConfig)(nil),
+		(*TestGroup_ResultSource_CircleciConfig)(nil),
+		(*TestGroup_ResultSource_TektonConfig)(nil),
+		(*TestGroup_ResultSource_ArgoConfig)(nil),
+	}
+}
+
+// Configures a row the updater adds to each column, such as Overall.
+type TestGroup_SyntheticRow struct {
+	// Do not add the row.
+	Disable bool `protobuf:"varint,1,opt,name=disable,proto3" json:"disable,omitempty"`
+	// Display the row with this name instead of its default.
+	Name     string                          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Position TestGroup_SyntheticRow_Position `protobuf:"varint,3,opt,name=position,proto3,enum=TestGroup_SyntheticRow_Position" json:"position,omitempty"`
+	// Exclude the row from tab health, such as the tab status, alerts and
+	// flakiness, so only real tests count.
+	ExcludeFromHealth    bool     `protobuf:"varint,4,opt,name=exclude_from_health,json=excludeFromHealth,proto3" json:"exclude_from_health,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_SyntheticRow) Reset()         { *m = TestGroup_SyntheticRow{} }
+func (m *TestGroup_SyntheticRow) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_SyntheticRow) ProtoMessage()    {}
+func (*TestGroup_SyntheticRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 4}
+}
+
+func (m *TestGroup_SyntheticRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_SyntheticRow.Unmarshal(m, b)
+}
+func (m *TestGroup_SyntheticRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_SyntheticRow.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_SyntheticRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_SyntheticRow.Merge(m, src)
+}
+func (m *TestGroup_SyntheticRow) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_SyntheticRow.Size(m)
+}
+func (m *TestGroup_SyntheticRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_SyntheticRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_SyntheticRow proto.InternalMessageInfo
+
+func (m *TestGroup_SyntheticRow) GetDisable() bool {
+	if m != nil {
+		return m.Disable
+	}
+	return false
+}
+
+func (m *TestGroup_SyntheticRow) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TestGroup_SyntheticRow) GetPosition() TestGroup_SyntheticRow_Position {
+	if m != nil {
+		return m.Position
+	}
+	return TestGroup_SyntheticRow_SORTED
+}
+
+func (m *TestGroup_SyntheticRow) GetExcludeFromHealth() bool {
+	if m != nil {
+		return m.ExcludeFromHealth
+	}
+	return false
+}
+
+// Limits how long a test or suite may take before its passing results
+// are marked over budget.
+type TestGroup_DurationBudget struct {
+	// Applies to tests (or suites) whose name matches, or every one if unset.
+	NameRegex string `protobuf:"bytes,1,opt,name=name_regex,json=nameRegex,proto3" json:"name_regex,omitempty"`
+	// Passing results which take longer than this are over budget.
+	MaxMinutes float64 `protobuf:"fixed64,2,opt,name=max_minutes,json=maxMinutes,proto3" json:"max_minutes,omitempty"`
+	// Applies to the total time of each junit suite instead of each test,
+	// marking every passing test of an over-budget suite.
+	Suite                bool     `protobuf:"varint,3,opt,name=suite,proto3" json:"suite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_DurationBudget) Reset()         { *m = TestGroup_DurationBudget{} }
+func (m *TestGroup_DurationBudget) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_DurationBudget) ProtoMessage()    {}
+func (*TestGroup_DurationBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 5}
+}
+
+func (m *TestGroup_DurationBudget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_DurationBudget.Unmarshal(m, b)
+}
+func (m *TestGroup_DurationBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_DurationBudget.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_DurationBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_DurationBudget.Merge(m, src)
+}
+func (m *TestGroup_DurationBudget) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_DurationBudget.Size(m)
+}
+func (m *TestGroup_DurationBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_DurationBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_DurationBudget proto.InternalMessageInfo
+
+func (m *TestGroup_DurationBudget) GetNameRegex() string {
+	if m != nil {
+		return m.NameRegex
+	}
+	return ""
+}
+
+func (m *TestGroup_DurationBudget) GetMaxMinutes() float64 {
+	if m != nil {
+		return m.MaxMinutes
+	}
+	return 0
+}
+
+func (m *TestGroup_DurationBudget) GetSuite() bool {
+	if m != nil {
+		return m.Suite
+	}
+	return false
+}
+
+// An additional location of results to merge into this group's grid.
+type TestGroup_MergedSource struct {
+	// Label of the columns read from this source, such as presubmit.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Path to the results of this source (some-bucket/some/optional/path).
+	GcsPrefix string `protobuf:"bytes,2,opt,name=gcs_prefix,json=gcsPrefix,proto3" json:"gcs_prefix,omitempty"`
+	// Only merge builds whose finished (or started) metadata values fully
+	// match these regular expressions, such as {"merged": "true"} to merge
+	// only the presubmits of merged pull requests.
+	MetadataFilters      map[string]string `protobuf:"bytes,3,rep,name=metadata_filters,json=metadataFilters,proto3" json:"metadata_filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TestGroup_MergedSource) Reset()         { *m = TestGroup_MergedSource{} }
+func (m *TestGroup_MergedSource) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_MergedSource) ProtoMessage()    {}
+func (*TestGroup_MergedSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 6}
+}
+
+func (m *TestGroup_MergedSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_MergedSource.Unmarshal(m, b)
+}
+func (m *TestGroup_MergedSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_MergedSource.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_MergedSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_MergedSource.Merge(m, src)
+}
+func (m *TestGroup_MergedSource) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_MergedSource.Size(m)
+}
+func (m *TestGroup_MergedSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_MergedSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_MergedSource proto.InternalMessageInfo
+
+func (m *TestGroup_MergedSource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TestGroup_MergedSource) GetGcsPrefix() string {
+	if m != nil {
+		return m.GcsPrefix
+	}
+	return ""
+}
+
+func (m *TestGroup_MergedSource) GetMetadataFilters() map[string]string {
+	if m != nil {
+		return m.MetadataFilters
+	}
+	return nil
+}
+
+// Limits how much of the previous state an update may drop, such as when a
+// bad regex edit hides most of the rows.
+type TestGroup_WriteGuard struct {
+	// Refuse updates which drop more than this percentage of the rows with
+	// results within days_of_results. Unchecked when zero.
+	MaxDroppedRowsPercent float32 `protobuf:"fixed32,1,opt,name=max_dropped_rows_percent,json=maxDroppedRowsPercent,proto3" json:"max_dropped_rows_percent,omitempty"`
+	// Refuse updates which drop more than this percentage of the columns
+	// within days_of_results. Unchecked when zero.
+	MaxDroppedColumnsPercent float32  `protobuf:"fixed32,2,opt,name=max_dropped_columns_percent,json=maxDroppedColumnsPercent,proto3" json:"max_dropped_columns_percent,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
+}
+
+func (m *TestGroup_WriteGuard) Reset()         { *m = TestGroup_WriteGuard{} }
+func (m *TestGroup_WriteGuard) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_WriteGuard) ProtoMessage()    {}
+func (*TestGroup_WriteGuard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 7}
+}
+
+func (m *TestGroup_WriteGuard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_WriteGuard.Unmarshal(m, b)
+}
+func (m *TestGroup_WriteGuard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_WriteGuard.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_WriteGuard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_WriteGuard.Merge(m, src)
+}
+func (m *TestGroup_WriteGuard) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_WriteGuard.Size(m)
+}
+func (m *TestGroup_WriteGuard) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_WriteGuard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_WriteGuard proto.InternalMessageInfo
+
+func (m *TestGroup_WriteGuard) GetMaxDroppedRowsPercent() float32 {
+	if m != nil {
+		return m.MaxDroppedRowsPercent
+	}
+	return 0
+}
+
+func (m *TestGroup_WriteGuard) GetMaxDroppedColumnsPercent() float32 {
+	if m != nil {
+		return m.MaxDroppedColumnsPercent
+	}
+	return 0
+}
+
+// Process only a sample of the builds of very frequent jobs. Each column
+// counts the builds skipped since the previous column.
+type TestGroup_ResultSampling struct {
+	// Process every Nth build, in build order, when greater than one.
+	EveryNthBuild int32 `protobuf:"varint,1,opt,name=every_nth_build,json=everyNthBuild,proto3" json:"every_nth_build,omitempty"`
+	// Process at most this many builds starting within each hour, when
+	// positive.
+	MaxBuildsPerHour     int32    `protobuf:"varint,2,opt,name=max_builds_per_hour,json=maxBuildsPerHour,proto3" json:"max_builds_per_hour,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestGroup_ResultSampling) Reset()         { *m = TestGroup_ResultSampling{} }
+func (m *TestGroup_ResultSampling) String() string { return proto.CompactTextString(m) }
+func (*TestGroup_ResultSampling) ProtoMessage()    {}
+func (*TestGroup_ResultSampling) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 8}
+}
+
+func (m *TestGroup_ResultSampling) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestGroup_ResultSampling.Unmarshal(m, b)
+}
+func (m *TestGroup_ResultSampling) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestGroup_ResultSampling.Marshal(b, m, deterministic)
+}
+func (m *TestGroup_ResultSampling) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestGroup_ResultSampling.Merge(m, src)
+}
+func (m *TestGroup_ResultSampling) XXX_Size() int {
+	return xxx_messageInfo_TestGroup_ResultSampling.Size(m)
+}
+func (m *TestGroup_ResultSampling) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestGroup_ResultSampling.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestGroup_ResultSampling proto.InternalMessageInfo
+
+func (m *TestGroup_ResultSampling) GetEveryNthBuild() int32 {
+	if m != nil {
+		return m.EveryNthBuild
+	}
+	return 0
+}
+
+func (m *TestGroup_ResultSampling) GetMaxBuildsPerHour() int32 {
+	if m != nil {
+		return m.MaxBuildsPerHour
+	}
+	return 0
+}
+
+type JUnitConfig struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JUnitConfig) Reset()         { *m = JUnitConfig{} }
+func (m *JUnitConfig) String() string { return proto.CompactTextString(m) }
+func (*JUnitConfig) ProtoMessage()    {}
+func (*JUnitConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{3}
+}
+
+func (m *JUnitConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JUnitConfig.Unmarshal(m, b)
+}
+func (m *JUnitConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JUnitConfig.Marshal(b, m, deterministic)
+}
+func (m *JUnitConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JUnitConfig.Merge(m, src)
+}
+func (m *JUnitConfig) XXX_Size() int {
+	return xxx_messageInfo_JUnitConfig.Size(m)
+}
+func (m *JUnitConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_JUnitConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JUnitConfig proto.InternalMessageInfo
+
+// Reads the builds of a LUCI builder, such as chromium/ci/linux-rel, as
+// columns and the test results of their ResultDB invocations as cells.
+type ResultDBConfig struct {
+	// LUCI project, such as chromium.
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// Buildbucket bucket, such as ci.
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Builder name, such as linux-rel.
+	Builder string `protobuf:"bytes,3,opt,name=builder,proto3" json:"builder,omitempty"`
+	// Variant keys which distinguish rows, such as test_suite. Rows are named
+	// after the test ID followed by the value of each key, such as
+	// "ninja://foo/Bar.Baz [test_suite=foo_tests]". Results of variants which
+	// differ only in other keys merge into the same row.
+	VariantKeys          []string `protobuf:"bytes,4,rep,name=variant_keys,json=variantKeys,proto3" json:"variant_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResultDBConfig) Reset()         { *m = ResultDBConfig{} }
+func (m *ResultDBConfig) String() string { return proto.CompactTextString(m) }
+func (*ResultDBConfig) ProtoMessage()    {}
+func (*ResultDBConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{4}
+}
+
+func (m *ResultDBConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResultDBConfig.Unmarshal(m, b)
+}
+func (m *ResultDBConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResultDBConfig.Marshal(b, m, deterministic)
+}
+func (m *ResultDBConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResultDBConfig.Merge(m, src)
+}
+func (m *ResultDBConfig) XXX_Size() int {
+	return xxx_messageInfo_ResultDBConfig.Size(m)
+}
+func (m *ResultDBConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResultDBConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResultDBConfig proto.InternalMessageInfo
+
+func (m *ResultDBConfig) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ResultDBConfig) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *ResultDBConfig) GetBuilder() string {
+	if m != nil {
+		return m.Builder
+	}
+	return ""
+}
+
+func (m *ResultDBConfig) GetVariantKeys() []string {
+	if m != nil {
+		return m.VariantKeys
+	}
+	return nil
+}
+
+// Reads the runs of a CircleCI workflow as columns and the test metadata of
+// their jobs as cells.
+type CircleCIConfig struct {
+	// Project slug, such as gh/org/repo.
+	ProjectSlug string `protobuf:"bytes,1,opt,name=project_slug,json=projectSlug,proto3" json:"project_slug,omitempty"`
+	// Workflow name, such as build-and-test.
+	Workflow string `protobuf:"bytes,2,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// Only read the pipelines of this branch when set.
+	Branch               string   `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CircleCIConfig) Reset()         { *m = CircleCIConfig{} }
+func (m *CircleCIConfig) String() string { return proto.CompactTextString(m) }
+func (*CircleCIConfig) ProtoMessage()    {}
+func (*CircleCIConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{5}
+}
+
+func (m *CircleCIConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CircleCIConfig.Unmarshal(m, b)
+}
+func (m *CircleCIConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CircleCIConfig.Marshal(b, m, deterministic)
+}
+func (m *CircleCIConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CircleCIConfig.Merge(m, src)
+}
+func (m *CircleCIConfig) XXX_Size() int {
+	return xxx_messageInfo_CircleCIConfig.Size(m)
+}
+func (m *CircleCIConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_CircleCIConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CircleCIConfig proto.InternalMessageInfo
+
+func (m *CircleCIConfig) GetProjectSlug() string {
+	if m != nil {
+		return m.ProjectSlug
+	}
+	return ""
+}
+
+func (m *CircleCIConfig) GetWorkflow() string {
+	if m != nil {
+		return m.Workflow
+	}
+	return ""
+}
+
+func (m *CircleCIConfig) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+// Reads the PipelineRuns of a Tekton Pipeline from the Kubernetes API as
+// columns, with a row for each of their tasks and the junit tests of each task.
+type TektonConfig struct {
+	// Namespace of the PipelineRuns.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the Pipeline, which labels its PipelineRuns as tekton.dev/pipeline.
+	Pipeline string `protobuf:"bytes,2,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	// Read the junit*.xml files of each task under
+	// gs://PREFIX/PIPELINERUN/TASK/ when set, such as gs://bucket/tekton.
+	ArtifactsPrefix      string   `protobuf:"bytes,3,opt,name=artifacts_prefix,json=artifactsPrefix,proto3" json:"artifacts_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TektonConfig) Reset()         { *m = TektonConfig{} }
+func (m *TektonConfig) String() string { return proto.CompactTextString(m) }
+func (*TektonConfig) ProtoMessage()    {}
+func (*TektonConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{6}
+}
+
+func (m *TektonConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TektonConfig.Unmarshal(m, b)
+}
+func (m *TektonConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TektonConfig.Marshal(b, m, deterministic)
+}
+func (m *TektonConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TektonConfig.Merge(m, src)
+}
+func (m *TektonConfig) XXX_Size() int {
+	return xxx_messageInfo_TektonConfig.Size(m)
+}
+func (m *TektonConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_TektonConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TektonConfig proto.InternalMessageInfo
+
+func (m *TektonConfig) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TektonConfig) GetPipeline() string {
+	if m != nil {
+		return m.Pipeline
+	}
+	return ""
+}
+
+func (m *TektonConfig) GetArtifactsPrefix() string {
+	if m != nil {
+		return m.ArtifactsPrefix
+	}
+	return ""
+}
+
+// Reads the archived Argo Workflows of a WorkflowTemplate from an Argo Server
+// as columns, with a row for each of their pods and the junit tests of each pod.
+type ArgoConfig struct {
+	// Namespace of the workflows.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the WorkflowTemplate, which labels its workflows as
+	// workflows.argoproj.io/workflow-template.
+	WorkflowTemplate string `protobuf:"bytes,2,opt,name=workflow_template,json=workflowTemplate,proto3" json:"workflow_template,omitempty"`
+	// Name of the output artifacts holding junit xml, such as junit.
+	// Plain, gzipped and tarred artifacts are all read.
+	JunitArtifact        string   `protobuf:"bytes,3,opt,name=junit_artifact,json=junitArtifact,proto3" json:"junit_artifact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArgoConfig) Reset()         { *m = ArgoConfig{} }
+func (m *ArgoConfig) String() string { return proto.CompactTextString(m) }
+func (*ArgoConfig) ProtoMessage()    {}
+func (*ArgoConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{7}
+}
+
+func (m *ArgoConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArgoConfig.Unmarshal(m, b)
+}
+func (m *ArgoConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArgoConfig.Marshal(b, m, deterministic)
+}
+func (m *ArgoConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArgoConfig.Merge(m, src)
+}
+func (m *ArgoConfig) XXX_Size() int {
+	return xxx_messageInfo_ArgoConfig.Size(m)
+}
+func (m *ArgoConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArgoConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArgoConfig proto.InternalMessageInfo
+
+func (m *ArgoConfig) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ArgoConfig) GetWorkflowTemplate() string {
+	if m != nil {
+		return m.WorkflowTemplate
+	}
+	return ""
+}
+
+func (m *ArgoConfig) GetJunitArtifact() string {
+	if m != nil {
+		return m.JunitArtifact
+	}
+	return ""
+}
+
+// Replaces sensitive text in test results.
+type Redaction struct {
+	// Regular expression matching the text to replace.
+	Regex string `protobuf:"bytes,1,opt,name=regex,proto3" json:"regex,omitempty"`
+	// Replacement text, which may reference capture groups such as ${1}.
+	// Defaults to [REDACTED].
+	Replacement          string   `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Redaction) Reset()         { *m = Redaction{} }
+func (m *Redaction) String() string { return proto.CompactTextString(m) }
+func (*Redaction) ProtoMessage()    {}
+func (*Redaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{8}
+}
+
+func (m *Redaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Redaction.Unmarshal(m, b)
+}
+func (m *Redaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Redaction.Marshal(b, m, deterministic)
+}
+func (m *Redaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Redaction.Merge(m, src)
+}
+func (m *Redaction) XXX_Size() int {
+	return xxx_messageInfo_Redaction.Size(m)
+}
+func (m *Redaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Redaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Redaction proto.InternalMessageInfo
+
+func (m *Redaction) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *Redaction) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+// Renames a test, moving its history to the new name.
+type RenameRule struct {
+	// Regular expression matching the old test name.
+	Regex string `protobuf:"bytes,1,opt,name=regex,proto3" json:"regex,omitempty"`
+	// New test name, which may reference capture groups such as ${1}.
+	Replacement          string   `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameRule) Reset()         { *m = RenameRule{} }
+func (m *RenameRule) String() string { return proto.CompactTextString(m) }
+func (*RenameRule) ProtoMessage()    {}
+func (*RenameRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{9}
+}
+
+func (m *RenameRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameRule.Unmarshal(m, b)
+}
+func (m *RenameRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameRule.Marshal(b, m, deterministic)
+}
+func (m *RenameRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameRule.Merge(m, src)
+}
+func (m *RenameRule) XXX_Size() int {
+	return xxx_messageInfo_RenameRule.Size(m)
+}
+func (m *RenameRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameRule proto.InternalMessageInfo
+
+func (m *RenameRule) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *RenameRule) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+// Links issue references in cell messages to their tracker.
+type IssueLinkRule struct {
+	// Regular expression matching the reference, such as #(\d+).
+	Regex string `protobuf:"bytes,1,opt,name=regex,proto3" json:"regex,omitempty"`
+	// Link template, which may reference capture groups such as
+	// https://github.com/org/repo/issues/${1}.
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueLinkRule) Reset()         { *m = IssueLinkRule{} }
+func (m *IssueLinkRule) String() string { return proto.CompactTextString(m) }
+func (*IssueLinkRule) ProtoMessage()    {}
+func (*IssueLinkRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{10}
+}
+
+func (m *IssueLinkRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueLinkRule.Unmarshal(m, b)
+}
+func (m *IssueLinkRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueLinkRule.Marshal(b, m, deterministic)
+}
+func (m *IssueLinkRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueLinkRule.Merge(m, src)
+}
+func (m *IssueLinkRule) XXX_Size() int {
+	return xxx_messageInfo_IssueLinkRule.Size(m)
+}
+func (m *IssueLinkRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueLinkRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueLinkRule proto.InternalMessageInfo
+
+func (m *IssueLinkRule) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *IssueLinkRule) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+// Location and caching of a public copy of a grid.
+type PublicGrid struct {
+	// Location, such as gs://public-bucket/grids, of the public copy.
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Cache-Control header of the public copy.
+	// Defaults to "public, max-age=300".
+	CacheControl         string   `protobuf:"bytes,2,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublicGrid) Reset()         { *m = PublicGrid{} }
+func (m *PublicGrid) String() string { return proto.CompactTextString(m) }
+func (*PublicGrid) ProtoMessage()    {}
+func (*PublicGrid) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{11}
+}
+
+func (m *PublicGrid) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublicGrid.Unmarshal(m, b)
+}
+func (m *PublicGrid) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublicGrid.Marshal(b, m, deterministic)
+}
+func (m *PublicGrid) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublicGrid.Merge(m, src)
+}
+func (m *PublicGrid) XXX_Size() int {
+	return xxx_messageInfo_PublicGrid.Size(m)
+}
+func (m *PublicGrid) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublicGrid.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublicGrid proto.InternalMessageInfo
+
+func (m *PublicGrid) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *PublicGrid) GetCacheControl() string {
+	if m != nil {
+		return m.CacheControl
+	}
+	return ""
+}
+
+// Rules to normalize junit test names. Rules apply in field order.
+type TestNameNormalization struct {
+	// Replace pytest-style :: separators with a period.
+	ReplaceDoubleColons bool `protobuf:"varint,1,opt,name=replace_double_colons,json=replaceDoubleColons,proto3" json:"replace_double_colons,omitempty"`
+	// Shorten a leading class name, such as com.example.FooTest.testBar,
+	// to the last component of the junit classname, such as FooTest.testBar.
+	CollapseClassPrefix bool `protobuf:"varint,2,opt,name=collapse_class_prefix,json=collapseClassPrefix,proto3" json:"collapse_class_prefix,omitempty"`
+	// Remove a trailing parameter list, such as test_foo[1-2] or Bar(x: 1).
+	StripParameters      bool     `protobuf:"varint,3,opt,name=strip_parameters,json=stripParameters,proto3" json:"strip_parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestNameNormalization) Reset()         { *m = TestNameNormalization{} }
+func (m *TestNameNormalization) String() string { return proto.CompactTextString(m) }
+func (*TestNameNormalization) ProtoMessage()    {}
+func (*TestNameNormalization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{12}
+}
+
+func (m *TestNameNormalization) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestNameNormalization.Unmarshal(m, b)
+}
+func (m *TestNameNormalization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestNameNormalization.Marshal(b, m, deterministic)
+}
+func (m *TestNameNormalization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestNameNormalization.Merge(m, src)
+}
+func (m *TestNameNormalization) XXX_Size() int {
+	return xxx_messageInfo_TestNameNormalization.Size(m)
+}
+func (m *TestNameNormalization) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestNameNormalization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestNameNormalization proto.InternalMessageInfo
+
+func (m *TestNameNormalization) GetReplaceDoubleColons() bool {
+	if m != nil {
+		return m.ReplaceDoubleColons
+	}
+	return false
+}
+
+func (m *TestNameNormalization) GetCollapseClassPrefix() bool {
+	if m != nil {
+		return m.CollapseClassPrefix
+	}
+	return false
+}
+
+func (m *TestNameNormalization) GetStripParameters() bool {
+	if m != nil {
+		return m.StripParameters
+	}
+	return false
+}
+
+// A junit property extracted into a named, typed cell field.
+type CellProperty struct {
+	// Name of the cell field, such as "owner".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The junit property to read. Defaults to name.
+	Property string `protobuf:"bytes,2,opt,name=property,proto3" json:"property,omitempty"`
+	// The type of the value. Values that cannot be parsed as this type are
+	// dropped, others are stored in a canonical form.
+	Type                 CellProperty_Type `protobuf:"varint,3,opt,name=type,proto3,enum=CellProperty_Type" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CellProperty) Reset()         { *m = CellProperty{} }
+func (m *CellProperty) String() string { return proto.CompactTextString(m) }
+func (*CellProperty) ProtoMessage()    {}
+func (*CellProperty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{13}
+}
+
+func (m *CellProperty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CellProperty.Unmarshal(m, b)
+}
+func (m *CellProperty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CellProperty.Marshal(b, m, deterministic)
+}
+func (m *CellProperty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CellProperty.Merge(m, src)
+}
+func (m *CellProperty) XXX_Size() int {
+	return xxx_messageInfo_CellProperty.Size(m)
+}
+func (m *CellProperty) XXX_DiscardUnknown() {
+	xxx_messageInfo_CellProperty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CellProperty proto.InternalMessageInfo
+
+func (m *CellProperty) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CellProperty) GetProperty() string {
+	if m != nil {
+		return m.Property
+	}
+	return ""
+}
+
+func (m *CellProperty) GetType() CellProperty_Type {
+	if m != nil {
+		return m.Type
+	}
+	return CellProperty_STRING
+}
+
+// Exports results to an external test management system.
+type TestManagementExport struct {
+	// The system to export results to.
+	System TestManagementExport_System `protobuf:"varint,1,opt,name=system,proto3,enum=TestManagementExport_System" json:"system,omitempty"`
+	// Base URL of the server, such as https://jira.example.com
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// The Jira project key, or the Polarion project ID.
+	Project string `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	// The cell property holding the test case ID, from cell_properties.
+	// Results without this property are not exported.
+	TestCaseProperty string `protobuf:"bytes,4,opt,name=test_case_property,json=testCaseProperty,proto3" json:"test_case_property,omitempty"`
+	// Path to a file holding the bearer token used to authenticate.
+	TokenFile            string   `protobuf:"bytes,5,opt,name=token_file,json=tokenFile,proto3" json:"token_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestManagementExport) Reset()         { *m = TestManagementExport{} }
+func (m *TestManagementExport) String() string { return proto.CompactTextString(m) }
+func (*TestManagementExport) ProtoMessage()    {}
+func (*TestManagementExport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{14}
+}
+
+func (m *TestManagementExport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestManagementExport.Unmarshal(m, b)
+}
+func (m *TestManagementExport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestManagementExport.Marshal(b, m, deterministic)
+}
+func (m *TestManagementExport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestManagementExport.Merge(m, src)
+}
+func (m *TestManagementExport) XXX_Size() int {
+	return xxx_messageInfo_TestManagementExport.Size(m)
+}
+func (m *TestManagementExport) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestManagementExport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestManagementExport proto.InternalMessageInfo
+
+func (m *TestManagementExport) GetSystem() TestManagementExport_System {
+	if m != nil {
+		return m.System
+	}
+	return TestManagementExport_SYSTEM_UNSPECIFIED
+}
+
+func (m *TestManagementExport) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *TestManagementExport) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *TestManagementExport) GetTestCaseProperty() string {
+	if m != nil {
+		return m.TestCaseProperty
+	}
+	return ""
+}
+
+func (m *TestManagementExport) GetTokenFile() string {
+	if m != nil {
+		return m.TokenFile
+	}
+	return ""
+}
+
+// Default metadata to apply when opening bugs.
+type TestMetadataOptions struct {
+	// Apply the following metadata if this regex matches a test's name.
+	TestNameRegex string `protobuf:"bytes,1,opt,name=test_name_regex,json=testNameRegex,proto3" json:"test_name_regex,omitempty"`
+	// Default bug component to open a bug in.
+	BugComponent int32 `protobuf:"varint,2,opt,name=bug_component,json=bugComponent,proto3" json:"bug_component,omitempty"`
+	// Default owner to assign a bug to.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// List of default users to CC a bug to.
+	Cc []string `protobuf:"bytes,4,rep,name=cc,proto3" json:"cc,omitempty"`
+	// Apply following metadata if this regex matches a test’s failure message.
+	MessageRegex         string   `protobuf:"bytes,5,opt,name=message_regex,json=messageRegex,proto3" json:"message_regex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestMetadataOptions) Reset()         { *m = TestMetadataOptions{} }
+func (m *TestMetadataOptions) String() string { return proto.CompactTextString(m) }
+func (*TestMetadataOptions) ProtoMessage()    {}
+func (*TestMetadataOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{15}
+}
+
+func (m *TestMetadataOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestMetadataOptions.Unmarshal(m, b)
+}
+func (m *TestMetadataOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestMetadataOptions.Marshal(b, m, deterministic)
+}
+func (m *TestMetadataOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestMetadataOptions.Merge(m, src)
+}
+func (m *TestMetadataOptions) XXX_Size() int {
+	return xxx_messageInfo_TestMetadataOptions.Size(m)
+}
+func (m *TestMetadataOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestMetadataOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestMetadataOptions proto.InternalMessageInfo
+
+func (m *TestMetadataOptions) GetTestNameRegex() string {
+	if m != nil {
+		return m.TestNameRegex
+	}
+	return ""
+}
+
+func (m *TestMetadataOptions) GetBugComponent() int32 {
+	if m != nil {
+		return m.BugComponent
+	}
+	return 0
+}
+
+func (m *TestMetadataOptions) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *TestMetadataOptions) GetCc() []string {
+	if m != nil {
+		return m.Cc
+	}
+	return nil
+}
+
+func (m *TestMetadataOptions) GetMessageRegex() string {
+	if m != nil {
+		return m.MessageRegex
+	}
+	return ""
+}
+
+type AutoBugOptions struct {
+	// [BETA] When specified, file bugs to this component, using the beta AutoBug.
+	// If you do not want to opt into the beta, specify `bug_component` in your
+	// TestGroup instead.
+	// TODO(b/154866134): Rename to autobug_component once we've migrated.
+	BetaAutobugComponent int32 `protobuf:"varint,10,opt,name=beta_autobug_component,json=betaAutobugComponent,proto3" json:"beta_autobug_component,omitempty"`
+	// Whether to auto-close auto-filed bugs.
+	AutoClose bool `protobuf:"varint,1,opt,name=auto_close,json=autoClose,proto3" json:"auto_close,omitempty"`
+	// A list of hotlist ids attached to auto-filed bugs.
+	HotlistIds []int64 `protobuf:"varint,2,rep,packed,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	// The priority of the auto-filed bug. If provided, this will overwrite the
+	// priority in the component default template
+	Priority AutoBugOptions_Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=AutoBugOptions_Priority" json:"priority,omitempty"`
+	// A list of hotlist id sources
+	// Corresponds with the list hotlist_ids (#2)
+	HotlistIdsFromSource []*HotlistIdFromSource `protobuf:"bytes,4,rep,name=hotlist_ids_from_source,json=hotlistIdsFromSource,proto3" json:"hotlist_ids_from_source,omitempty"`
+	// If True, files separate bugs for each failing target, instead of one bug
+	// for each set of targets failing at the same run.
+	FileIndividual bool `protobuf:"varint,5,opt,name=file_individual,json=fileIndividual,proto3" json:"file_individual,omitempty"`
+	// If True; keep only one automantic bug per target, regardless of the number of
+	// separate failures a target gets. This also requires `auto_close` and
+	// `file_individual` to be True.
+	// Consider setting `num_passes_to_disable_alert` instead if you're tracking
+	// flaky tests.
+	SingletonAutobug bool `protobuf:"varint,6,opt,name=singleton_autobug,json=singletonAutobug,proto3" json:"singleton_autobug,omitempty"`
+	// If provided: only raise one bug if the number of failures for a single
+	// query by testgrid for a single failure group exceeds this value. Requires
+	// 'file_individual' to be True.
+	MaxAllowedIndividualBugs int32 `protobuf:"varint,7,opt,name=max_allowed_individual_bugs,json=maxAllowedIndividualBugs,proto3" json:"max_allowed_individual_bugs,omitempty"`
+	// If True; file issues for the 'Overall' target, even if otherwise invalid.
+	FileOverall bool `protobuf:"varint,8,opt,name=file_overall,json=fileOverall,proto3" json:"file_overall,omitempty"`
+	// If provided: supplements `max_allowed_individual_bugs` field to raise a
+	// single bug if the number of failures for a single query by testgrid exceeds
+	// the max_allowed_individual_bugs` value, regardless of `TEST_METADATA`
+	// configurations. This is useful for filing fewer suspected environmental
+	// failure bugs and routing them to a specific location (i.e. an oncall).
+	// Requires 'file_individual' to be true and `max_allowed_individual_bugs` to
+	// not be empty.
+	DefaultTestMetadata *AutoBugOptions_DefaultTestMetadata `protobuf:"bytes,9,opt,name=default_test_metadata,json=defaultTestMetadata,proto3" json:"default_test_metadata,omitempty"`
+	// [BETA] If True, query the test metadata API to get issue-routing metadata.
+	// Enables routing issues using structured test failures.
+	AdvancedTestMetadata bool     `protobuf:"varint,11,opt,name=advanced_test_metadata,json=advancedTestMetadata,proto3" json:"advanced_test_metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AutoBugOptions) Reset()         { *m = AutoBugOptions{} }
+func (m *AutoBugOptions) String() string { return proto.CompactTextString(m) }
+func (*AutoBugOptions) ProtoMessage()    {}
+func (*AutoBugOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{16}
+}
+
+func (m *AutoBugOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AutoBugOptions.Unmarshal(m, b)
+}
+func (m *AutoBugOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AutoBugOptions.Marshal(b, m, deterministic)
+}
+func (m *AutoBugOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AutoBugOptions.Merge(m, src)
+}
+func (m *AutoBugOptions) XXX_Size() int {
+	return xxx_messageInfo_AutoBugOptions.Size(m)
+}
+func (m *AutoBugOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_AutoBugOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AutoBugOptions proto.InternalMessageInfo
+
+func (m *AutoBugOptions) GetBetaAutobugComponent() int32 {
+	if m != nil {
+		return m.BetaAutobugComponent
+	}
+	return 0
+}
+
+func (m *AutoBugOptions) GetAutoClose() bool {
+	if m != nil {
+		return m.AutoClose
+	}
+	return false
+}
+
+func (m *AutoBugOptions) GetHotlistIds() []int64 {
+	if m != nil {
+		return m.HotlistIds
+	}
+	return nil
+}
+
+func (m *AutoBugOptions) GetPriority() AutoBugOptions_Priority {
+	if m != nil {
+		return m.Priority
+	}
+	return AutoBugOptions_PRIORITY_UNSPECIFIED
+}
+
+func (m *AutoBugOptions) GetHotlistIdsFromSource() []*HotlistIdFromSource {
+	if m != nil {
+		return m.HotlistIdsFromSource
+	}
+	return nil
+}
+
+func (m *AutoBugOptions) GetFileIndividual() bool {
+	if m != nil {
+		return m.FileIndividual
+	}
+	return false
+}
+
+func (m *AutoBugOptions) GetSingletonAutobug() bool {
+	if m != nil {
+		return m.SingletonAutobug
+	}
+	return false
+}
+
+func (m *AutoBugOptions) GetMaxAllowedIndividualBugs() int32 {
+	if m != nil {
+		return m.MaxAllowedIndividualBugs
+	}
+	return 0
 }
 
-func (m *TestGroup_KeyValue) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_KeyValue.Unmarshal(m, b)
+func (m *AutoBugOptions) GetFileOverall() bool {
+	if m != nil {
+		return m.FileOverall
+	}
+	return false
 }
-func (m *TestGroup_KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_KeyValue.Marshal(b, m, deterministic)
+
+func (m *AutoBugOptions) GetDefaultTestMetadata() *AutoBugOptions_DefaultTestMetadata {
+	if m != nil {
+		return m.DefaultTestMetadata
+	}
+	return nil
 }
-func (m *TestGroup_KeyValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_KeyValue.Merge(m, src)
+
+func (m *AutoBugOptions) GetAdvancedTestMetadata() bool {
+	if m != nil {
+		return m.AdvancedTestMetadata
+	}
+	return false
 }
-func (m *TestGroup_KeyValue) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_KeyValue.Size(m)
+
+type AutoBugOptions_DefaultTestMetadata struct {
+	BugComponent         int32    `protobuf:"varint,1,opt,name=bug_component,json=bugComponent,proto3" json:"bug_component,omitempty"`
+	Owner                string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Cc                   string   `protobuf:"bytes,3,opt,name=cc,proto3" json:"cc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *TestGroup_KeyValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_KeyValue.DiscardUnknown(m)
+
+func (m *AutoBugOptions_DefaultTestMetadata) Reset()         { *m = AutoBugOptions_DefaultTestMetadata{} }
+func (m *AutoBugOptions_DefaultTestMetadata) String() string { return proto.CompactTextString(m) }
+func (*AutoBugOptions_DefaultTestMetadata) ProtoMessage()    {}
+func (*AutoBugOptions_DefaultTestMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{16, 0}
 }
 
-var xxx_messageInfo_TestGroup_KeyValue proto.InternalMessageInfo
+func (m *AutoBugOptions_DefaultTestMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Unmarshal(m, b)
+}
+func (m *AutoBugOptions_DefaultTestMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Marshal(b, m, deterministic)
+}
+func (m *AutoBugOptions_DefaultTestMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Merge(m, src)
+}
+func (m *AutoBugOptions_DefaultTestMetadata) XXX_Size() int {
+	return xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Size(m)
+}
+func (m *AutoBugOptions_DefaultTestMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.DiscardUnknown(m)
+}
 
-func (m *TestGroup_KeyValue) GetKey() string {
+var xxx_messageInfo_AutoBugOptions_DefaultTestMetadata proto.InternalMessageInfo
+
+func (m *AutoBugOptions_DefaultTestMetadata) GetBugComponent() int32 {
 	if m != nil {
-		return m.Key
+		return m.BugComponent
+	}
+	return 0
+}
+
+func (m *AutoBugOptions_DefaultTestMetadata) GetOwner() string {
+	if m != nil {
+		return m.Owner
 	}
 	return ""
 }
 
-func (m *TestGroup_KeyValue) GetValue() string {
+func (m *AutoBugOptions_DefaultTestMetadata) GetCc() string {
 	if m != nil {
-		return m.Value
+		return m.Cc
 	}
 	return ""
 }
 
-type TestGroup_ResultSource struct {
-	// Types that are valid to be assigned to ResultSourceConfig:
-	//	*TestGroup_ResultSource_JunitConfig
-	ResultSourceConfig   isTestGroup_ResultSource_ResultSourceConfig `protobuf_oneof:"result_source_config"`
-	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
-	XXX_unrecognized     []byte                                      `json:"-"`
-	XXX_sizecache        int32                                       `json:"-"`
+type HotlistIdFromSource struct {
+	// Types that are valid to be assigned to HotlistIdSource:
+	//	*HotlistIdFromSource_Value
+	//	*HotlistIdFromSource_Label
+	HotlistIdSource      isHotlistIdFromSource_HotlistIdSource `protobuf_oneof:"hotlist_id_source"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
 }
 
-func (m *TestGroup_ResultSource) Reset()         { *m = TestGroup_ResultSource{} }
-func (m *TestGroup_ResultSource) String() string { return proto.CompactTextString(m) }
-func (*TestGroup_ResultSource) ProtoMessage()    {}
-func (*TestGroup_ResultSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{2, 3}
+func (m *HotlistIdFromSource) Reset()         { *m = HotlistIdFromSource{} }
+func (m *HotlistIdFromSource) String() string { return proto.CompactTextString(m) }
+func (*HotlistIdFromSource) ProtoMessage()    {}
+func (*HotlistIdFromSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{17}
 }
 
-func (m *TestGroup_ResultSource) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestGroup_ResultSource.Unmarshal(m, b)
+func (m *HotlistIdFromSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HotlistIdFromSource.Unmarshal(m, b)
 }
-func (m *TestGroup_ResultSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestGroup_ResultSource.Marshal(b, m, deterministic)
+func (m *HotlistIdFromSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HotlistIdFromSource.Marshal(b, m, deterministic)
 }
-func (m *TestGroup_ResultSource) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestGroup_ResultSource.Merge(m, src)
+func (m *HotlistIdFromSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HotlistIdFromSource.Merge(m, src)
 }
-func (m *TestGroup_ResultSource) XXX_Size() int {
-	return xxx_messageInfo_TestGroup_ResultSource.Size(m)
+func (m *HotlistIdFromSource) XXX_Size() int {
+	return xxx_messageInfo_HotlistIdFromSource.Size(m)
 }
-func (m *TestGroup_ResultSource) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestGroup_ResultSource.DiscardUnknown(m)
+func (m *HotlistIdFromSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_HotlistIdFromSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HotlistIdFromSource proto.InternalMessageInfo
+
+type isHotlistIdFromSource_HotlistIdSource interface {
+	isHotlistIdFromSource_HotlistIdSource()
+}
+
+type HotlistIdFromSource_Value struct {
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3,oneof"`
+}
+
+type HotlistIdFromSource_Label struct {
+	Label string `protobuf:"bytes,2,opt,name=label,proto3,oneof"`
+}
+
+func (*HotlistIdFromSource_Value) isHotlistIdFromSource_HotlistIdSource() {}
+
+func (*HotlistIdFromSource_Label) isHotlistIdFromSource_HotlistIdSource() {}
+
+func (m *HotlistIdFromSource) GetHotlistIdSource() isHotlistIdFromSource_HotlistIdSource {
+	if m != nil {
+		return m.HotlistIdSource
+	}
+	return nil
+}
+
+func (m *HotlistIdFromSource) GetValue() int64 {
+	if x, ok := m.GetHotlistIdSource().(*HotlistIdFromSource_Value); ok {
+		return x.Value
+	}
+	return 0
+}
+
+func (m *HotlistIdFromSource) GetLabel() string {
+	if x, ok := m.GetHotlistIdSource().(*HotlistIdFromSource_Label); ok {
+		return x.Label
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*HotlistIdFromSource) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*HotlistIdFromSource_Value)(nil),
+		(*HotlistIdFromSource_Label)(nil),
+	}
+}
+
+// Specifies a dashboard.
+type Dashboard struct {
+	// A list of the tabs on the dashboard.
+	DashboardTab []*DashboardTab `protobuf:"bytes,1,rep,name=dashboard_tab,json=dashboardTab,proto3" json:"dashboard_tab,omitempty"`
+	// A name for the Dashboard.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// A list of notifications attached to this dashboard.
+	// This is displayed on any dashboard tab in this dashboard.
+	Notifications []*Notification `protobuf:"bytes,3,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// Control which tab is displayed when first opening a dashboard.
+	// Defaults to Summary
+	DefaultTab string `protobuf:"bytes,5,opt,name=default_tab,json=defaultTab,proto3" json:"default_tab,omitempty"`
+	// Controls whether to suppress highlighting of failing tabs.
+	DownplayFailingTabs bool `protobuf:"varint,8,opt,name=downplay_failing_tabs,json=downplayFailingTabs,proto3" json:"downplay_failing_tabs,omitempty"`
+	// Deprecated: Invert of 'downplay_failing_tabs'
+	HighlightFailingTabs bool `protobuf:"varint,6,opt,name=highlight_failing_tabs,json=highlightFailingTabs,proto3" json:"highlight_failing_tabs,omitempty"` // Deprecated: Do not use.
+	// Controls whether to apply special highlighting to result header columns for
+	// the current day.
+	HighlightToday bool `protobuf:"varint,7,opt,name=highlight_today,json=highlightToday,proto3" json:"highlight_today,omitempty"`
+	// Controls when notifications about this dashboard are sent, and how they
+	// escalate as failures persist.
+	NotificationSchedule *NotificationSchedule `protobuf:"bytes,9,opt,name=notification_schedule,json=notificationSchedule,proto3" json:"notification_schedule,omitempty"`
+	// Additional locations, such as gs://other-bucket/prefix or a local
+	// directory, where the dashboard summary is also written. A failure to
+	// write one destination does not affect the others.
+	MirrorPrefixes []string `protobuf:"bytes,10,rep,name=mirror_prefixes,json=mirrorPrefixes,proto3" json:"mirror_prefixes,omitempty"`
+	// IANA time zone, such as Europe/Berlin, of the dates in the dashboard's
+	// column headers and API responses. Defaults to UTC.
+	TimeZone string `protobuf:"bytes,11,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Strftime format of those dates, such as %d.%m. %H:%M.
+	// Defaults to %Y-%m-%d %H:%M.
+	DateFormat string `protobuf:"bytes,12,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"`
+	// The team which owns the dashboard, grouping its alert metrics.
+	// Defaults to the dashboard name.
+	Team string `protobuf:"bytes,13,opt,name=team,proto3" json:"team,omitempty"`
+	// How frontends initially display the dashboard.
+	DisplayOptions *DisplayOptions `protobuf:"bytes,14,opt,name=display_options,json=displayOptions,proto3" json:"display_options,omitempty"`
+	// Post a GitHub commit status summarizing the results of each tab for the
+	// commit of each of its recent columns.
+	CommitStatus         *CommitStatusOptions `protobuf:"bytes,15,opt,name=commit_status,json=commitStatus,proto3" json:"commit_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Dashboard) Reset()         { *m = Dashboard{} }
+func (m *Dashboard) String() string { return proto.CompactTextString(m) }
+func (*Dashboard) ProtoMessage()    {}
+func (*Dashboard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{18}
+}
+
+func (m *Dashboard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dashboard.Unmarshal(m, b)
+}
+func (m *Dashboard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dashboard.Marshal(b, m, deterministic)
+}
+func (m *Dashboard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dashboard.Merge(m, src)
+}
+func (m *Dashboard) XXX_Size() int {
+	return xxx_messageInfo_Dashboard.Size(m)
+}
+func (m *Dashboard) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dashboard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dashboard proto.InternalMessageInfo
+
+func (m *Dashboard) GetDashboardTab() []*DashboardTab {
+	if m != nil {
+		return m.DashboardTab
+	}
+	return nil
+}
+
+func (m *Dashboard) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Dashboard) GetNotifications() []*Notification {
+	if m != nil {
+		return m.Notifications
+	}
+	return nil
+}
+
+func (m *Dashboard) GetDefaultTab() string {
+	if m != nil {
+		return m.DefaultTab
+	}
+	return ""
+}
+
+func (m *Dashboard) GetDownplayFailingTabs() bool {
+	if m != nil {
+		return m.DownplayFailingTabs
+	}
+	return false
+}
+
+// Deprecated: Do not use.
+func (m *Dashboard) GetHighlightFailingTabs() bool {
+	if m != nil {
+		return m.HighlightFailingTabs
+	}
+	return false
+}
+
+func (m *Dashboard) GetHighlightToday() bool {
+	if m != nil {
+		return m.HighlightToday
+	}
+	return false
 }
 
-var xxx_messageInfo_TestGroup_ResultSource proto.InternalMessageInfo
+func (m *Dashboard) GetNotificationSchedule() *NotificationSchedule {
+	if m != nil {
+		return m.NotificationSchedule
+	}
+	return nil
+}
 
-type isTestGroup_ResultSource_ResultSourceConfig interface {
-	isTestGroup_ResultSource_ResultSourceConfig()
+func (m *Dashboard) GetMirrorPrefixes() []string {
+	if m != nil {
+		return m.MirrorPrefixes
+	}
+	return nil
 }
 
-type TestGroup_ResultSource_JunitConfig struct {
-	JunitConfig *JUnitConfig `protobuf:"bytes,2,opt,name=junit_config,json=junitConfig,proto3,oneof"`
+func (m *Dashboard) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
 }
 
-func (*TestGroup_ResultSource_JunitConfig) isTestGroup_ResultSource_ResultSourceConfig() {}
+func (m *Dashboard) GetDateFormat() string {
+	if m != nil {
+		return m.DateFormat
+	}
+	return ""
+}
 
-func (m *TestGroup_ResultSource) GetResultSourceConfig() isTestGroup_ResultSource_ResultSourceConfig {
+func (m *Dashboard) GetTeam() string {
 	if m != nil {
-		return m.ResultSourceConfig
+		return m.Team
 	}
-	return nil
+	return ""
 }
 
-func (m *TestGroup_ResultSource) GetJunitConfig() *JUnitConfig {
-	if x, ok := m.GetResultSourceConfig().(*TestGroup_ResultSource_JunitConfig); ok {
-		return x.JunitConfig
+func (m *Dashboard) GetDisplayOptions() *DisplayOptions {
+	if m != nil {
+		return m.DisplayOptions
 	}
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*TestGroup_ResultSource) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*TestGroup_ResultSource_JunitConfig)(nil),
+func (m *Dashboard) GetCommitStatus() *CommitStatusOptions {
+	if m != nil {
+		return m.CommitStatus
 	}
+	return nil
 }
 
-type JUnitConfig struct {
+// Posts GitHub commit statuses of dashboard tabs.
+type CommitStatusOptions struct {
+	// Repo, such as org/repo, of the commits. The column header with the
+	// Commit configuration_value identifies the commit of each column.
+	Repo string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// Only post the statuses of these tabs. Posts those of every tab when empty.
+	Tabs []string `protobuf:"bytes,2,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	// Post the statuses of columns which started within this many hours.
+	// Defaults to 24.
+	MaxAgeHours          int32    `protobuf:"varint,3,opt,name=max_age_hours,json=maxAgeHours,proto3" json:"max_age_hours,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *JUnitConfig) Reset()         { *m = JUnitConfig{} }
-func (m *JUnitConfig) String() string { return proto.CompactTextString(m) }
-func (*JUnitConfig) ProtoMessage()    {}
-func (*JUnitConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{3}
+func (m *CommitStatusOptions) Reset()         { *m = CommitStatusOptions{} }
+func (m *CommitStatusOptions) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusOptions) ProtoMessage()    {}
+func (*CommitStatusOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{19}
 }
 
-func (m *JUnitConfig) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JUnitConfig.Unmarshal(m, b)
+func (m *CommitStatusOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitStatusOptions.Unmarshal(m, b)
 }
-func (m *JUnitConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JUnitConfig.Marshal(b, m, deterministic)
+func (m *CommitStatusOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitStatusOptions.Marshal(b, m, deterministic)
 }
-func (m *JUnitConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JUnitConfig.Merge(m, src)
+func (m *CommitStatusOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitStatusOptions.Merge(m, src)
 }
-func (m *JUnitConfig) XXX_Size() int {
-	return xxx_messageInfo_JUnitConfig.Size(m)
+func (m *CommitStatusOptions) XXX_Size() int {
+	return xxx_messageInfo_CommitStatusOptions.Size(m)
 }
-func (m *JUnitConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_JUnitConfig.DiscardUnknown(m)
+func (m *CommitStatusOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitStatusOptions.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JUnitConfig proto.InternalMessageInfo
+var xxx_messageInfo_CommitStatusOptions proto.InternalMessageInfo
 
-// Default metadata to apply when opening bugs.
-type TestMetadataOptions struct {
-	// Apply the following metadata if this regex matches a test's name.
-	TestNameRegex string `protobuf:"bytes,1,opt,name=test_name_regex,json=testNameRegex,proto3" json:"test_name_regex,omitempty"`
-	// Default bug component to open a bug in.
-	BugComponent int32 `protobuf:"varint,2,opt,name=bug_component,json=bugComponent,proto3" json:"bug_component,omitempty"`
-	// Default owner to assign a bug to.
-	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
-	// List of default users to CC a bug to.
-	Cc []string `protobuf:"bytes,4,rep,name=cc,proto3" json:"cc,omitempty"`
-	// Apply following metadata if this regex matches a test’s failure message.
-	MessageRegex         string   `protobuf:"bytes,5,opt,name=message_regex,json=messageRegex,proto3" json:"message_regex,omitempty"`
+func (m *CommitStatusOptions) GetRepo() string {
+	if m != nil {
+		return m.Repo
+	}
+	return ""
+}
+
+func (m *CommitStatusOptions) GetTabs() []string {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+func (m *CommitStatusOptions) GetMaxAgeHours() int32 {
+	if m != nil {
+		return m.MaxAgeHours
+	}
+	return 0
+}
+
+// Options frontends use to render a dashboard consistently.
+type DisplayOptions struct {
+	DefaultSort DisplayOptions_SortOrder `protobuf:"varint,1,opt,name=default_sort,json=defaultSort,proto3,enum=DisplayOptions_SortOrder" json:"default_sort,omitempty"`
+	// Number of the oldest columns frontends collapse until expanded.
+	HiddenColumns int32                  `protobuf:"varint,2,opt,name=hidden_columns,json=hiddenColumns,proto3" json:"hidden_columns,omitempty"`
+	Palette       DisplayOptions_Palette `protobuf:"varint,3,opt,name=palette,proto3,enum=DisplayOptions_Palette" json:"palette,omitempty"`
+	// Markdown describing the dashboard, shown above its tabs.
+	// Limited to 4096 bytes.
+	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TestMetadataOptions) Reset()         { *m = TestMetadataOptions{} }
-func (m *TestMetadataOptions) String() string { return proto.CompactTextString(m) }
-func (*TestMetadataOptions) ProtoMessage()    {}
-func (*TestMetadataOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{4}
+func (m *DisplayOptions) Reset()         { *m = DisplayOptions{} }
+func (m *DisplayOptions) String() string { return proto.CompactTextString(m) }
+func (*DisplayOptions) ProtoMessage()    {}
+func (*DisplayOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{20}
 }
 
-func (m *TestMetadataOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TestMetadataOptions.Unmarshal(m, b)
+func (m *DisplayOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisplayOptions.Unmarshal(m, b)
 }
-func (m *TestMetadataOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TestMetadataOptions.Marshal(b, m, deterministic)
+func (m *DisplayOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisplayOptions.Marshal(b, m, deterministic)
 }
-func (m *TestMetadataOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TestMetadataOptions.Merge(m, src)
+func (m *DisplayOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisplayOptions.Merge(m, src)
 }
-func (m *TestMetadataOptions) XXX_Size() int {
-	return xxx_messageInfo_TestMetadataOptions.Size(m)
+func (m *DisplayOptions) XXX_Size() int {
+	return xxx_messageInfo_DisplayOptions.Size(m)
 }
-func (m *TestMetadataOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_TestMetadataOptions.DiscardUnknown(m)
+func (m *DisplayOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisplayOptions.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TestMetadataOptions proto.InternalMessageInfo
+var xxx_messageInfo_DisplayOptions proto.InternalMessageInfo
 
-func (m *TestMetadataOptions) GetTestNameRegex() string {
+func (m *DisplayOptions) GetDefaultSort() DisplayOptions_SortOrder {
 	if m != nil {
-		return m.TestNameRegex
+		return m.DefaultSort
 	}
-	return ""
+	return DisplayOptions_SORT_UNSPECIFIED
 }
 
-func (m *TestMetadataOptions) GetBugComponent() int32 {
+func (m *DisplayOptions) GetHiddenColumns() int32 {
 	if m != nil {
-		return m.BugComponent
+		return m.HiddenColumns
 	}
 	return 0
 }
 
-func (m *TestMetadataOptions) GetOwner() string {
-	if m != nil {
-		return m.Owner
-	}
-	return ""
-}
-
-func (m *TestMetadataOptions) GetCc() []string {
+func (m *DisplayOptions) GetPalette() DisplayOptions_Palette {
 	if m != nil {
-		return m.Cc
+		return m.Palette
 	}
-	return nil
+	return DisplayOptions_PALETTE_UNSPECIFIED
 }
 
-func (m *TestMetadataOptions) GetMessageRegex() string {
+func (m *DisplayOptions) GetDescription() string {
 	if m != nil {
-		return m.MessageRegex
+		return m.Description
 	}
 	return ""
 }
 
-type AutoBugOptions struct {
-	// [BETA] When specified, file bugs to this component, using the beta AutoBug.
-	// If you do not want to opt into the beta, specify `bug_component` in your
-	// TestGroup instead.
-	// TODO(b/154866134): Rename to autobug_component once we've migrated.
-	BetaAutobugComponent int32 `protobuf:"varint,10,opt,name=beta_autobug_component,json=betaAutobugComponent,proto3" json:"beta_autobug_component,omitempty"`
-	// Whether to auto-close auto-filed bugs.
-	AutoClose bool `protobuf:"varint,1,opt,name=auto_close,json=autoClose,proto3" json:"auto_close,omitempty"`
-	// A list of hotlist ids attached to auto-filed bugs.
-	HotlistIds []int64 `protobuf:"varint,2,rep,packed,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
-	// The priority of the auto-filed bug. If provided, this will overwrite the
-	// priority in the component default template
-	Priority AutoBugOptions_Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=AutoBugOptions_Priority" json:"priority,omitempty"`
-	// A list of hotlist id sources
-	// Corresponds with the list hotlist_ids (#2)
-	HotlistIdsFromSource []*HotlistIdFromSource `protobuf:"bytes,4,rep,name=hotlist_ids_from_source,json=hotlistIdsFromSource,proto3" json:"hotlist_ids_from_source,omitempty"`
-	// If True, files separate bugs for each failing target, instead of one bug
-	// for each set of targets failing at the same run.
-	FileIndividual bool `protobuf:"varint,5,opt,name=file_individual,json=fileIndividual,proto3" json:"file_individual,omitempty"`
-	// If True; keep only one automantic bug per target, regardless of the number of
-	// separate failures a target gets. This also requires `auto_close` and
-	// `file_individual` to be True.
-	// Consider setting `num_passes_to_disable_alert` instead if you're tracking
-	// flaky tests.
-	SingletonAutobug bool `protobuf:"varint,6,opt,name=singleton_autobug,json=singletonAutobug,proto3" json:"singleton_autobug,omitempty"`
-	// If provided: only raise one bug if the number of failures for a single
-	// query by testgrid for a single failure group exceeds this value. Requires
-	// 'file_individual' to be True.
-	MaxAllowedIndividualBugs int32 `protobuf:"varint,7,opt,name=max_allowed_individual_bugs,json=maxAllowedIndividualBugs,proto3" json:"max_allowed_individual_bugs,omitempty"`
-	// If True; file issues for the 'Overall' target, even if otherwise invalid.
-	FileOverall bool `protobuf:"varint,8,opt,name=file_overall,json=fileOverall,proto3" json:"file_overall,omitempty"`
-	// If provided: supplements `max_allowed_individual_bugs` field to raise a
-	// single bug if the number of failures for a single query by testgrid exceeds
-	// the max_allowed_individual_bugs` value, regardless of `TEST_METADATA`
-	// configurations. This is useful for filing fewer suspected environmental
-	// failure bugs and routing them to a specific location (i.e. an oncall).
-	// Requires 'file_individual' to be true and `max_allowed_individual_bugs` to
-	// not be empty.
-	DefaultTestMetadata *AutoBugOptions_DefaultTestMetadata `protobuf:"bytes,9,opt,name=default_test_metadata,json=defaultTestMetadata,proto3" json:"default_test_metadata,omitempty"`
-	// [BETA] If True, query the test metadata API to get issue-routing metadata.
-	// Enables routing issues using structured test failures.
-	AdvancedTestMetadata bool     `protobuf:"varint,11,opt,name=advanced_test_metadata,json=advancedTestMetadata,proto3" json:"advanced_test_metadata,omitempty"`
+// Specifies when notifications may be sent and how they escalate.
+type NotificationSchedule struct {
+	// IANA time zone used to interpret notification windows, such as
+	// "Europe/Berlin". Defaults to UTC.
+	TimeZone string `protobuf:"bytes,1,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Windows during which notifications may be sent.
+	// If empty, notifications may be sent at any time.
+	Windows []*NotificationWindow `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`
+	// Steps to take as a failure persists.
+	EscalationSteps []*EscalationStep `protobuf:"bytes,3,rep,name=escalation_steps,json=escalationSteps,proto3" json:"escalation_steps,omitempty"`
+	// Where to send alerts about the failing tests of each owner, according to
+	// the owner in the test group's test_metadata_options.
+	// Tests without a route are alerted by the escalation step itself.
+	OwnerRoutes []*OwnerRoute `protobuf:"bytes,4,rep,name=owner_routes,json=ownerRoutes,proto3" json:"owner_routes,omitempty"`
+	// Periods, such as planned maintenance, during which notifications are
+	// suppressed, even for steps which ignore windows. Failing cycles are
+	// still counted while silenced.
+	Silences []*SilenceWindow `protobuf:"bytes,5,rep,name=silences,proto3" json:"silences,omitempty"`
+	// ICS calendar feeds, such as gs://bucket/maintenance.ics, whose events
+	// also silence notifications.
+	SilenceCalendars     []string `protobuf:"bytes,6,rep,name=silence_calendars,json=silenceCalendars,proto3" json:"silence_calendars,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AutoBugOptions) Reset()         { *m = AutoBugOptions{} }
-func (m *AutoBugOptions) String() string { return proto.CompactTextString(m) }
-func (*AutoBugOptions) ProtoMessage()    {}
-func (*AutoBugOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{5}
+func (m *NotificationSchedule) Reset()         { *m = NotificationSchedule{} }
+func (m *NotificationSchedule) String() string { return proto.CompactTextString(m) }
+func (*NotificationSchedule) ProtoMessage()    {}
+func (*NotificationSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{21}
 }
 
-func (m *AutoBugOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AutoBugOptions.Unmarshal(m, b)
+func (m *NotificationSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationSchedule.Unmarshal(m, b)
 }
-func (m *AutoBugOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AutoBugOptions.Marshal(b, m, deterministic)
+func (m *NotificationSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationSchedule.Marshal(b, m, deterministic)
 }
-func (m *AutoBugOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AutoBugOptions.Merge(m, src)
+func (m *NotificationSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationSchedule.Merge(m, src)
 }
-func (m *AutoBugOptions) XXX_Size() int {
-	return xxx_messageInfo_AutoBugOptions.Size(m)
+func (m *NotificationSchedule) XXX_Size() int {
+	return xxx_messageInfo_NotificationSchedule.Size(m)
 }
-func (m *AutoBugOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_AutoBugOptions.DiscardUnknown(m)
+func (m *NotificationSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationSchedule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AutoBugOptions proto.InternalMessageInfo
+var xxx_messageInfo_NotificationSchedule proto.InternalMessageInfo
 
-func (m *AutoBugOptions) GetBetaAutobugComponent() int32 {
+func (m *NotificationSchedule) GetTimeZone() string {
 	if m != nil {
-		return m.BetaAutobugComponent
+		return m.TimeZone
 	}
-	return 0
+	return ""
 }
 
-func (m *AutoBugOptions) GetAutoClose() bool {
+func (m *NotificationSchedule) GetWindows() []*NotificationWindow {
 	if m != nil {
-		return m.AutoClose
+		return m.Windows
 	}
-	return false
+	return nil
 }
 
-func (m *AutoBugOptions) GetHotlistIds() []int64 {
+func (m *NotificationSchedule) GetEscalationSteps() []*EscalationStep {
 	if m != nil {
-		return m.HotlistIds
+		return m.EscalationSteps
 	}
 	return nil
 }
 
-func (m *AutoBugOptions) GetPriority() AutoBugOptions_Priority {
+func (m *NotificationSchedule) GetOwnerRoutes() []*OwnerRoute {
 	if m != nil {
-		return m.Priority
+		return m.OwnerRoutes
 	}
-	return AutoBugOptions_PRIORITY_UNSPECIFIED
+	return nil
 }
 
-func (m *AutoBugOptions) GetHotlistIdsFromSource() []*HotlistIdFromSource {
+func (m *NotificationSchedule) GetSilences() []*SilenceWindow {
 	if m != nil {
-		return m.HotlistIdsFromSource
+		return m.Silences
 	}
 	return nil
 }
 
-func (m *AutoBugOptions) GetFileIndividual() bool {
+func (m *NotificationSchedule) GetSilenceCalendars() []string {
 	if m != nil {
-		return m.FileIndividual
+		return m.SilenceCalendars
 	}
-	return false
+	return nil
 }
 
-func (m *AutoBugOptions) GetSingletonAutobug() bool {
-	if m != nil {
-		return m.SingletonAutobug
-	}
-	return false
+// A recurring or one-off period during which notifications are suppressed.
+type SilenceWindow struct {
+	// Silences every occurrence of the window, in the schedule's time zone.
+	Recurring *NotificationWindow `protobuf:"bytes,1,opt,name=recurring,proto3" json:"recurring,omitempty"`
+	// Silences once from the RFC 3339 start time, such as
+	// 2021-06-01T20:00:00Z, until the end time.
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Why notifications are silenced, such as "weekly infra maintenance".
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AutoBugOptions) GetMaxAllowedIndividualBugs() int32 {
+func (m *SilenceWindow) Reset()         { *m = SilenceWindow{} }
+func (m *SilenceWindow) String() string { return proto.CompactTextString(m) }
+func (*SilenceWindow) ProtoMessage()    {}
+func (*SilenceWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{22}
+}
+
+func (m *SilenceWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SilenceWindow.Unmarshal(m, b)
+}
+func (m *SilenceWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SilenceWindow.Marshal(b, m, deterministic)
+}
+func (m *SilenceWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SilenceWindow.Merge(m, src)
+}
+func (m *SilenceWindow) XXX_Size() int {
+	return xxx_messageInfo_SilenceWindow.Size(m)
+}
+func (m *SilenceWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_SilenceWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SilenceWindow proto.InternalMessageInfo
+
+func (m *SilenceWindow) GetRecurring() *NotificationWindow {
 	if m != nil {
-		return m.MaxAllowedIndividualBugs
+		return m.Recurring
 	}
-	return 0
+	return nil
 }
 
-func (m *AutoBugOptions) GetFileOverall() bool {
+func (m *SilenceWindow) GetStartTime() string {
 	if m != nil {
-		return m.FileOverall
+		return m.StartTime
 	}
-	return false
+	return ""
 }
 
-func (m *AutoBugOptions) GetDefaultTestMetadata() *AutoBugOptions_DefaultTestMetadata {
+func (m *SilenceWindow) GetEndTime() string {
 	if m != nil {
-		return m.DefaultTestMetadata
+		return m.EndTime
 	}
-	return nil
+	return ""
 }
 
-func (m *AutoBugOptions) GetAdvancedTestMetadata() bool {
+func (m *SilenceWindow) GetReason() string {
 	if m != nil {
-		return m.AdvancedTestMetadata
+		return m.Reason
 	}
-	return false
+	return ""
 }
 
-type AutoBugOptions_DefaultTestMetadata struct {
-	BugComponent         int32    `protobuf:"varint,1,opt,name=bug_component,json=bugComponent,proto3" json:"bug_component,omitempty"`
-	Owner                string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
-	Cc                   string   `protobuf:"bytes,3,opt,name=cc,proto3" json:"cc,omitempty"`
+// Sends alerts about the failing tests of an owner to the owner's team.
+type OwnerRoute struct {
+	// The owner in test_metadata_options, such as "team-storage".
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The kind of notification to send, as in EscalationStep.
+	// Defaults to the channel of the escalation step.
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Where to send the notification, such as the team's channel or email.
+	Target               string   `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AutoBugOptions_DefaultTestMetadata) Reset()         { *m = AutoBugOptions_DefaultTestMetadata{} }
-func (m *AutoBugOptions_DefaultTestMetadata) String() string { return proto.CompactTextString(m) }
-func (*AutoBugOptions_DefaultTestMetadata) ProtoMessage()    {}
-func (*AutoBugOptions_DefaultTestMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{5, 0}
+func (m *OwnerRoute) Reset()         { *m = OwnerRoute{} }
+func (m *OwnerRoute) String() string { return proto.CompactTextString(m) }
+func (*OwnerRoute) ProtoMessage()    {}
+func (*OwnerRoute) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{23}
 }
 
-func (m *AutoBugOptions_DefaultTestMetadata) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Unmarshal(m, b)
-}
-func (m *AutoBugOptions_DefaultTestMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Marshal(b, m, deterministic)
-}
-func (m *AutoBugOptions_DefaultTestMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Merge(m, src)
+func (m *OwnerRoute) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerRoute.Unmarshal(m, b)
 }
-func (m *AutoBugOptions_DefaultTestMetadata) XXX_Size() int {
-	return xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.Size(m)
+func (m *OwnerRoute) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerRoute.Marshal(b, m, deterministic)
 }
-func (m *AutoBugOptions_DefaultTestMetadata) XXX_DiscardUnknown() {
-	xxx_messageInfo_AutoBugOptions_DefaultTestMetadata.DiscardUnknown(m)
+func (m *OwnerRoute) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerRoute.Merge(m, src)
 }
-
-var xxx_messageInfo_AutoBugOptions_DefaultTestMetadata proto.InternalMessageInfo
-
-func (m *AutoBugOptions_DefaultTestMetadata) GetBugComponent() int32 {
-	if m != nil {
-		return m.BugComponent
-	}
-	return 0
+func (m *OwnerRoute) XXX_Size() int {
+	return xxx_messageInfo_OwnerRoute.Size(m)
+}
+func (m *OwnerRoute) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerRoute.DiscardUnknown(m)
 }
 
-func (m *AutoBugOptions_DefaultTestMetadata) GetOwner() string {
+var xxx_messageInfo_OwnerRoute proto.InternalMessageInfo
+
+func (m *OwnerRoute) GetOwner() string {
 	if m != nil {
 		return m.Owner
 	}
 	return ""
 }
 
-func (m *AutoBugOptions_DefaultTestMetadata) GetCc() string {
+func (m *OwnerRoute) GetChannel() string {
 	if m != nil {
-		return m.Cc
+		return m.Channel
 	}
 	return ""
 }
 
-type HotlistIdFromSource struct {
-	// Types that are valid to be assigned to HotlistIdSource:
-	//	*HotlistIdFromSource_Value
-	//	*HotlistIdFromSource_Label
-	HotlistIdSource      isHotlistIdFromSource_HotlistIdSource `protobuf_oneof:"hotlist_id_source"`
-	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
-	XXX_unrecognized     []byte                                `json:"-"`
-	XXX_sizecache        int32                                 `json:"-"`
+func (m *OwnerRoute) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
 }
 
-func (m *HotlistIdFromSource) Reset()         { *m = HotlistIdFromSource{} }
-func (m *HotlistIdFromSource) String() string { return proto.CompactTextString(m) }
-func (*HotlistIdFromSource) ProtoMessage()    {}
-func (*HotlistIdFromSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{6}
+// A recurring period of time during which notifications may be sent.
+type NotificationWindow struct {
+	// Days on which this window starts. Every day if empty.
+	Days []NotificationWindow_Day `protobuf:"varint,1,rep,packed,name=days,proto3,enum=NotificationWindow_Day" json:"days,omitempty"`
+	// Start of the window as HH:MM in the schedule's time zone, inclusive.
+	Start string `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// End of the window as HH:MM in the schedule's time zone, exclusive.
+	// A window that ends before it starts continues past midnight.
+	// A window that ends when it starts lasts all day.
+	End                  string   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *HotlistIdFromSource) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HotlistIdFromSource.Unmarshal(m, b)
-}
-func (m *HotlistIdFromSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HotlistIdFromSource.Marshal(b, m, deterministic)
-}
-func (m *HotlistIdFromSource) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HotlistIdFromSource.Merge(m, src)
+func (m *NotificationWindow) Reset()         { *m = NotificationWindow{} }
+func (m *NotificationWindow) String() string { return proto.CompactTextString(m) }
+func (*NotificationWindow) ProtoMessage()    {}
+func (*NotificationWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{24}
 }
-func (m *HotlistIdFromSource) XXX_Size() int {
-	return xxx_messageInfo_HotlistIdFromSource.Size(m)
+
+func (m *NotificationWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationWindow.Unmarshal(m, b)
 }
-func (m *HotlistIdFromSource) XXX_DiscardUnknown() {
-	xxx_messageInfo_HotlistIdFromSource.DiscardUnknown(m)
+func (m *NotificationWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationWindow.Marshal(b, m, deterministic)
 }
-
-var xxx_messageInfo_HotlistIdFromSource proto.InternalMessageInfo
-
-type isHotlistIdFromSource_HotlistIdSource interface {
-	isHotlistIdFromSource_HotlistIdSource()
+func (m *NotificationWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationWindow.Merge(m, src)
 }
-
-type HotlistIdFromSource_Value struct {
-	Value int64 `protobuf:"varint,1,opt,name=value,proto3,oneof"`
+func (m *NotificationWindow) XXX_Size() int {
+	return xxx_messageInfo_NotificationWindow.Size(m)
 }
-
-type HotlistIdFromSource_Label struct {
-	Label string `protobuf:"bytes,2,opt,name=label,proto3,oneof"`
+func (m *NotificationWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationWindow.DiscardUnknown(m)
 }
 
-func (*HotlistIdFromSource_Value) isHotlistIdFromSource_HotlistIdSource() {}
-
-func (*HotlistIdFromSource_Label) isHotlistIdFromSource_HotlistIdSource() {}
+var xxx_messageInfo_NotificationWindow proto.InternalMessageInfo
 
-func (m *HotlistIdFromSource) GetHotlistIdSource() isHotlistIdFromSource_HotlistIdSource {
+func (m *NotificationWindow) GetDays() []NotificationWindow_Day {
 	if m != nil {
-		return m.HotlistIdSource
+		return m.Days
 	}
 	return nil
 }
 
-func (m *HotlistIdFromSource) GetValue() int64 {
-	if x, ok := m.GetHotlistIdSource().(*HotlistIdFromSource_Value); ok {
-		return x.Value
-	}
-	return 0
-}
-
-func (m *HotlistIdFromSource) GetLabel() string {
-	if x, ok := m.GetHotlistIdSource().(*HotlistIdFromSource_Label); ok {
-		return x.Label
+func (m *NotificationWindow) GetStart() string {
+	if m != nil {
+		return m.Start
 	}
 	return ""
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*HotlistIdFromSource) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*HotlistIdFromSource_Value)(nil),
-		(*HotlistIdFromSource_Label)(nil),
+func (m *NotificationWindow) GetEnd() string {
+	if m != nil {
+		return m.End
 	}
+	return ""
 }
 
-// Specifies a dashboard.
-type Dashboard struct {
-	// A list of the tabs on the dashboard.
-	DashboardTab []*DashboardTab `protobuf:"bytes,1,rep,name=dashboard_tab,json=dashboardTab,proto3" json:"dashboard_tab,omitempty"`
-	// A name for the Dashboard.
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// A list of notifications attached to this dashboard.
-	// This is displayed on any dashboard tab in this dashboard.
-	Notifications []*Notification `protobuf:"bytes,3,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	// Control which tab is displayed when first opening a dashboard.
-	// Defaults to Summary
-	DefaultTab string `protobuf:"bytes,5,opt,name=default_tab,json=defaultTab,proto3" json:"default_tab,omitempty"`
-	// Controls whether to suppress highlighting of failing tabs.
-	DownplayFailingTabs bool `protobuf:"varint,8,opt,name=downplay_failing_tabs,json=downplayFailingTabs,proto3" json:"downplay_failing_tabs,omitempty"`
-	// Deprecated: Invert of 'downplay_failing_tabs'
-	HighlightFailingTabs bool `protobuf:"varint,6,opt,name=highlight_failing_tabs,json=highlightFailingTabs,proto3" json:"highlight_failing_tabs,omitempty"` // Deprecated: Do not use.
-	// Controls whether to apply special highlighting to result header columns for
-	// the current day.
-	HighlightToday       bool     `protobuf:"varint,7,opt,name=highlight_today,json=highlightToday,proto3" json:"highlight_today,omitempty"`
+// A notification to send once a failure persists long enough.
+type EscalationStep struct {
+	// Number of consecutive failing cycles required before taking this step.
+	FailingCycles int32 `protobuf:"varint,1,opt,name=failing_cycles,json=failingCycles,proto3" json:"failing_cycles,omitempty"`
+	// The kind of notification to send, such as "slack", "email" or "page".
+	//
+	// "teams" posts a card to a Microsoft Teams incoming webhook and
+	// "google_chat" posts a card to a Google Chat space webhook.
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Where to send the notification, such as a channel name or pager service.
+	// The https webhook URL for "teams" and "google_chat".
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// Send this notification even outside of the notification windows.
+	IgnoreWindows bool `protobuf:"varint,4,opt,name=ignore_windows,json=ignoreWindows,proto3" json:"ignore_windows,omitempty"`
+	// Take this step whenever the tab fails after exhausting its error budget,
+	// instead of after failing_cycles.
+	BudgetExhausted      bool     `protobuf:"varint,5,opt,name=budget_exhausted,json=budgetExhausted,proto3" json:"budget_exhausted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Dashboard) Reset()         { *m = Dashboard{} }
-func (m *Dashboard) String() string { return proto.CompactTextString(m) }
-func (*Dashboard) ProtoMessage()    {}
-func (*Dashboard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{7}
+func (m *EscalationStep) Reset()         { *m = EscalationStep{} }
+func (m *EscalationStep) String() string { return proto.CompactTextString(m) }
+func (*EscalationStep) ProtoMessage()    {}
+func (*EscalationStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{25}
 }
 
-func (m *Dashboard) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Dashboard.Unmarshal(m, b)
+func (m *EscalationStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EscalationStep.Unmarshal(m, b)
 }
-func (m *Dashboard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Dashboard.Marshal(b, m, deterministic)
+func (m *EscalationStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EscalationStep.Marshal(b, m, deterministic)
 }
-func (m *Dashboard) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Dashboard.Merge(m, src)
+func (m *EscalationStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EscalationStep.Merge(m, src)
 }
-func (m *Dashboard) XXX_Size() int {
-	return xxx_messageInfo_Dashboard.Size(m)
+func (m *EscalationStep) XXX_Size() int {
+	return xxx_messageInfo_EscalationStep.Size(m)
 }
-func (m *Dashboard) XXX_DiscardUnknown() {
-	xxx_messageInfo_Dashboard.DiscardUnknown(m)
+func (m *EscalationStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_EscalationStep.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Dashboard proto.InternalMessageInfo
+var xxx_messageInfo_EscalationStep proto.InternalMessageInfo
 
-func (m *Dashboard) GetDashboardTab() []*DashboardTab {
+func (m *EscalationStep) GetFailingCycles() int32 {
 	if m != nil {
-		return m.DashboardTab
+		return m.FailingCycles
 	}
-	return nil
+	return 0
 }
 
-func (m *Dashboard) GetName() string {
+func (m *EscalationStep) GetChannel() string {
 	if m != nil {
-		return m.Name
+		return m.Channel
 	}
 	return ""
 }
 
-func (m *Dashboard) GetNotifications() []*Notification {
-	if m != nil {
-		return m.Notifications
-	}
-	return nil
-}
-
-func (m *Dashboard) GetDefaultTab() string {
+func (m *EscalationStep) GetTarget() string {
 	if m != nil {
-		return m.DefaultTab
+		return m.Target
 	}
 	return ""
 }
 
-func (m *Dashboard) GetDownplayFailingTabs() bool {
-	if m != nil {
-		return m.DownplayFailingTabs
-	}
-	return false
-}
-
-// Deprecated: Do not use.
-func (m *Dashboard) GetHighlightFailingTabs() bool {
+func (m *EscalationStep) GetIgnoreWindows() bool {
 	if m != nil {
-		return m.HighlightFailingTabs
+		return m.IgnoreWindows
 	}
 	return false
 }
 
-func (m *Dashboard) GetHighlightToday() bool {
+func (m *EscalationStep) GetBudgetExhausted() bool {
 	if m != nil {
-		return m.HighlightToday
+		return m.BudgetExhausted
 	}
 	return false
 }
@@ -1668,7 +4005,7 @@ func (m *LinkTemplate) Reset()         { *m = LinkTemplate{} }
 func (m *LinkTemplate) String() string { return proto.CompactTextString(m) }
 func (*LinkTemplate) ProtoMessage()    {}
 func (*LinkTemplate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{8}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{26}
 }
 
 func (m *LinkTemplate) XXX_Unmarshal(b []byte) error {
@@ -1725,7 +4062,7 @@ func (m *LinkOptionsTemplate) Reset()         { *m = LinkOptionsTemplate{} }
 func (m *LinkOptionsTemplate) String() string { return proto.CompactTextString(m) }
 func (*LinkOptionsTemplate) ProtoMessage()    {}
 func (*LinkOptionsTemplate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{9}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{27}
 }
 
 func (m *LinkOptionsTemplate) XXX_Unmarshal(b []byte) error {
@@ -1829,16 +4166,47 @@ type DashboardTab struct {
 	BetaAutobugOptions *AutoBugOptions `protobuf:"bytes,22,opt,name=beta_autobug_options,json=betaAutobugOptions,proto3" json:"beta_autobug_options,omitempty"`
 	// Options for the configuration of the flakiness analysis tool, on a per tab basis
 	HealthAnalysisOptions *HealthAnalysisOptions `protobuf:"bytes,23,opt,name=health_analysis_options,json=healthAnalysisOptions,proto3" json:"health_analysis_options,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{}               `json:"-"`
-	XXX_unrecognized      []byte                 `json:"-"`
-	XXX_sizecache         int32                  `json:"-"`
+	// Results with these statuses are treated as if the test did not run when
+	// summarizing the tab and alerting on it, such as CANCEL or CATEGORIZED_FAIL.
+	IgnoredStatuses []test_status.TestStatus `protobuf:"varint,25,rep,packed,name=ignored_statuses,json=ignoredStatuses,proto3,enum=TestStatus" json:"ignored_statuses,omitempty"`
+	// Limits the columns of the tab state, defaulting to every column.
+	ColumnWindow *DashboardTab_ColumnWindow `protobuf:"bytes,26,opt,name=column_window,json=columnWindow,proto3" json:"column_window,omitempty"`
+	// Links added to each row, such as to the test's source or owners. URL and
+	// option values expand <test-name>, <test-id> and <property:NAME>, the
+	// row's most recent value of the NAME cell property. Rows without the
+	// property omit the link.
+	RowLinkTemplates []*LinkTemplate `protobuf:"bytes,27,rep,name=row_link_templates,json=rowLinkTemplates,proto3" json:"row_link_templates,omitempty"`
+	// Tracks how much of the tab's error budget remains, escalating
+	// notifications once it is exhausted.
+	ErrorBudget *DashboardTab_ErrorBudget `protobuf:"bytes,28,opt,name=error_budget,json=errorBudget,proto3" json:"error_budget,omitempty"`
+	// Consolidates the columns of the tab state into one per period in the
+	// dashboard's time zone, with the worst result of each row and its number
+	// of passing runs. The test group state retains the raw columns.
+	Rollup DashboardTab_Rollup `protobuf:"varint,29,opt,name=rollup,proto3,enum=DashboardTab_Rollup" json:"rollup,omitempty"`
+	// Windows, in days, over which the summarizer computes the statistics of
+	// each row, such as its pass percentage, served by the API.
+	RowStatsDays []int32 `protobuf:"varint,30,rep,packed,name=row_stats_days,json=rowStatsDays,proto3" json:"row_stats_days,omitempty"`
+	// Links added to each filled cell, such as to the viewer of its junit
+	// results with the test selected. URL and option values expand
+	// <test-name>, <test-id>, <cell-id>, <build>, the cell's column,
+	// <gcs-prefix>, the test group's first gcs_prefix, and <property:NAME>,
+	// the cell's value of the NAME property. Cells without a value omit the
+	// link, as do tabs which roll up columns.
+	CellLinkTemplates []*LinkTemplate `protobuf:"bytes,31,rep,name=cell_link_templates,json=cellLinkTemplates,proto3" json:"cell_link_templates,omitempty"`
+	// Links each filled cell to the Spyglass view of its build on this Prow
+	// deck, such as https://prow.k8s.io, as
+	// SPYGLASS_URL/view/gs/<gcs-prefix>/<build>.
+	SpyglassUrl          string   `protobuf:"bytes,32,opt,name=spyglass_url,json=spyglassUrl,proto3" json:"spyglass_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DashboardTab) Reset()         { *m = DashboardTab{} }
 func (m *DashboardTab) String() string { return proto.CompactTextString(m) }
 func (*DashboardTab) ProtoMessage()    {}
 func (*DashboardTab) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{10}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{28}
 }
 
 func (m *DashboardTab) XXX_Unmarshal(b []byte) error {
@@ -2027,6 +4395,164 @@ func (m *DashboardTab) GetHealthAnalysisOptions() *HealthAnalysisOptions {
 	return nil
 }
 
+func (m *DashboardTab) GetIgnoredStatuses() []test_status.TestStatus {
+	if m != nil {
+		return m.IgnoredStatuses
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetColumnWindow() *DashboardTab_ColumnWindow {
+	if m != nil {
+		return m.ColumnWindow
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetRowLinkTemplates() []*LinkTemplate {
+	if m != nil {
+		return m.RowLinkTemplates
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetErrorBudget() *DashboardTab_ErrorBudget {
+	if m != nil {
+		return m.ErrorBudget
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetRollup() DashboardTab_Rollup {
+	if m != nil {
+		return m.Rollup
+	}
+	return DashboardTab_ROLLUP_UNSPECIFIED
+}
+
+func (m *DashboardTab) GetRowStatsDays() []int32 {
+	if m != nil {
+		return m.RowStatsDays
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetCellLinkTemplates() []*LinkTemplate {
+	if m != nil {
+		return m.CellLinkTemplates
+	}
+	return nil
+}
+
+func (m *DashboardTab) GetSpyglassUrl() string {
+	if m != nil {
+		return m.SpyglassUrl
+	}
+	return ""
+}
+
+// Limits the columns the tabulator writes to the tab state.
+//
+// The test group state retains the full history.
+type DashboardTab_ColumnWindow struct {
+	// Keep at most this many recent columns, unlimited when zero.
+	NumColumns int32 `protobuf:"varint,1,opt,name=num_columns,json=numColumns,proto3" json:"num_columns,omitempty"`
+	// Keep columns which started within this many days, unlimited when zero.
+	Days                 int32    `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardTab_ColumnWindow) Reset()         { *m = DashboardTab_ColumnWindow{} }
+func (m *DashboardTab_ColumnWindow) String() string { return proto.CompactTextString(m) }
+func (*DashboardTab_ColumnWindow) ProtoMessage()    {}
+func (*DashboardTab_ColumnWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{28, 0}
+}
+
+func (m *DashboardTab_ColumnWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardTab_ColumnWindow.Unmarshal(m, b)
+}
+func (m *DashboardTab_ColumnWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardTab_ColumnWindow.Marshal(b, m, deterministic)
+}
+func (m *DashboardTab_ColumnWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardTab_ColumnWindow.Merge(m, src)
+}
+func (m *DashboardTab_ColumnWindow) XXX_Size() int {
+	return xxx_messageInfo_DashboardTab_ColumnWindow.Size(m)
+}
+func (m *DashboardTab_ColumnWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardTab_ColumnWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardTab_ColumnWindow proto.InternalMessageInfo
+
+func (m *DashboardTab_ColumnWindow) GetNumColumns() int32 {
+	if m != nil {
+		return m.NumColumns
+	}
+	return 0
+}
+
+func (m *DashboardTab_ColumnWindow) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+// A rolling budget of failing columns.
+type DashboardTab_ErrorBudget struct {
+	// Number of columns with a failing result allowed within the window.
+	AllowedFailingColumns int32 `protobuf:"varint,1,opt,name=allowed_failing_columns,json=allowedFailingColumns,proto3" json:"allowed_failing_columns,omitempty"`
+	// Length of the rolling window in days. Defaults to 30.
+	Days                 int32    `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DashboardTab_ErrorBudget) Reset()         { *m = DashboardTab_ErrorBudget{} }
+func (m *DashboardTab_ErrorBudget) String() string { return proto.CompactTextString(m) }
+func (*DashboardTab_ErrorBudget) ProtoMessage()    {}
+func (*DashboardTab_ErrorBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{28, 1}
+}
+
+func (m *DashboardTab_ErrorBudget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardTab_ErrorBudget.Unmarshal(m, b)
+}
+func (m *DashboardTab_ErrorBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardTab_ErrorBudget.Marshal(b, m, deterministic)
+}
+func (m *DashboardTab_ErrorBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardTab_ErrorBudget.Merge(m, src)
+}
+func (m *DashboardTab_ErrorBudget) XXX_Size() int {
+	return xxx_messageInfo_DashboardTab_ErrorBudget.Size(m)
+}
+func (m *DashboardTab_ErrorBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardTab_ErrorBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardTab_ErrorBudget proto.InternalMessageInfo
+
+func (m *DashboardTab_ErrorBudget) GetAllowedFailingColumns() int32 {
+	if m != nil {
+		return m.AllowedFailingColumns
+	}
+	return 0
+}
+
+func (m *DashboardTab_ErrorBudget) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
 // Configuration options for dashboard tab alerts.
 type DashboardTabAlertOptions struct {
 	// Time in hours before an alert will be added to a test results table if the
@@ -2051,17 +4577,25 @@ type DashboardTabAlertOptions struct {
 	// TestGrid does not pester about staleness
 	WaitMinutesBetweenEmails int32 `protobuf:"varint,8,opt,name=wait_minutes_between_emails,json=waitMinutesBetweenEmails,proto3" json:"wait_minutes_between_emails,omitempty"`
 	// A custom message
-	AlertMailFailureMessage string   `protobuf:"bytes,9,opt,name=alert_mail_failure_message,json=alertMailFailureMessage,proto3" json:"alert_mail_failure_message,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
-	XXX_unrecognized        []byte   `json:"-"`
-	XXX_sizecache           int32    `json:"-"`
+	AlertMailFailureMessage string `protobuf:"bytes,9,opt,name=alert_mail_failure_message,json=alertMailFailureMessage,proto3" json:"alert_mail_failure_message,omitempty"`
+	// Alert once num_failures_to_alert of a test's most recent results fail
+	// within a window of this many results, rather than requiring consecutive
+	// failures. Columns without a result for the test do not count. If zero,
+	// failures must be consecutive.
+	NumResultsInFailureWindow int32 `protobuf:"varint,10,opt,name=num_results_in_failure_window,json=numResultsInFailureWindow,proto3" json:"num_results_in_failure_window,omitempty"`
+	// Do not alert on tests whose first result is within this many hours, so
+	// new flaky tests do not page. New tests alert like any other when zero.
+	NewTestGraceHours    int32    `protobuf:"varint,11,opt,name=new_test_grace_hours,json=newTestGraceHours,proto3" json:"new_test_grace_hours,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DashboardTabAlertOptions) Reset()         { *m = DashboardTabAlertOptions{} }
 func (m *DashboardTabAlertOptions) String() string { return proto.CompactTextString(m) }
 func (*DashboardTabAlertOptions) ProtoMessage()    {}
 func (*DashboardTabAlertOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{11}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{29}
 }
 
 func (m *DashboardTabAlertOptions) XXX_Unmarshal(b []byte) error {
@@ -2145,6 +4679,20 @@ func (m *DashboardTabAlertOptions) GetAlertMailFailureMessage() string {
 	return ""
 }
 
+func (m *DashboardTabAlertOptions) GetNumResultsInFailureWindow() int32 {
+	if m != nil {
+		return m.NumResultsInFailureWindow
+	}
+	return 0
+}
+
+func (m *DashboardTabAlertOptions) GetNewTestGraceHours() int32 {
+	if m != nil {
+		return m.NewTestGraceHours
+	}
+	return 0
+}
+
 // Configuration options for dashboard tab flakiness alerts.
 type DashboardTabFlakinessAlertOptions struct {
 	// The minimum amount of flakiness needed to trigger a flakiness alert.
@@ -2169,7 +4717,7 @@ func (m *DashboardTabFlakinessAlertOptions) Reset()         { *m = DashboardTabF
 func (m *DashboardTabFlakinessAlertOptions) String() string { return proto.CompactTextString(m) }
 func (*DashboardTabFlakinessAlertOptions) ProtoMessage()    {}
 func (*DashboardTabFlakinessAlertOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{12}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{30}
 }
 
 func (m *DashboardTabFlakinessAlertOptions) XXX_Unmarshal(b []byte) error {
@@ -2241,7 +4789,7 @@ func (m *DashboardGroup) Reset()         { *m = DashboardGroup{} }
 func (m *DashboardGroup) String() string { return proto.CompactTextString(m) }
 func (*DashboardGroup) ProtoMessage()    {}
 func (*DashboardGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{13}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{31}
 }
 
 func (m *DashboardGroup) XXX_Unmarshal(b []byte) error {
@@ -2283,17 +4831,20 @@ type Configuration struct {
 	// A list of all of the dashboards for a server.
 	Dashboards []*Dashboard `protobuf:"bytes,2,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
 	// A list of all the dashboard groups for a server.
-	DashboardGroups      []*DashboardGroup `protobuf:"bytes,3,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DashboardGroups []*DashboardGroup `protobuf:"bytes,3,rep,name=dashboard_groups,json=dashboardGroups,proto3" json:"dashboard_groups,omitempty"`
+	// Named test group settings shared by the test groups which inherit them.
+	// Templates are never updated themselves.
+	TestGroupTemplates   []*TestGroup `protobuf:"bytes,4,rep,name=test_group_templates,json=testGroupTemplates,proto3" json:"test_group_templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Configuration) Reset()         { *m = Configuration{} }
 func (m *Configuration) String() string { return proto.CompactTextString(m) }
 func (*Configuration) ProtoMessage()    {}
 func (*Configuration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{14}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{32}
 }
 
 func (m *Configuration) XXX_Unmarshal(b []byte) error {
@@ -2335,6 +4886,13 @@ func (m *Configuration) GetDashboardGroups() []*DashboardGroup {
 	return nil
 }
 
+func (m *Configuration) GetTestGroupTemplates() []*TestGroup {
+	if m != nil {
+		return m.TestGroupTemplates
+	}
+	return nil
+}
+
 // A grouping of configuration options for the flakiness analysis tool.
 // Later configuration options could include the ability to choose different kinds of
 // flakiness and choosing if and who to email a copy of the flakiness report.
@@ -2367,7 +4925,7 @@ func (m *HealthAnalysisOptions) Reset()         { *m = HealthAnalysisOptions{} }
 func (m *HealthAnalysisOptions) String() string { return proto.CompactTextString(m) }
 func (*HealthAnalysisOptions) ProtoMessage()    {}
 func (*HealthAnalysisOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{15}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{33}
 }
 
 func (m *HealthAnalysisOptions) XXX_Unmarshal(b []byte) error {
@@ -2439,7 +4997,7 @@ func (m *DefaultConfiguration) Reset()         { *m = DefaultConfiguration{} }
 func (m *DefaultConfiguration) String() string { return proto.CompactTextString(m) }
 func (*DefaultConfiguration) ProtoMessage()    {}
 func (*DefaultConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eaf2c85e69e9ea4, []int{16}
+	return fileDescriptor_3eaf2c85e69e9ea4, []int{34}
 }
 
 func (m *DefaultConfiguration) XXX_Unmarshal(b []byte) error {
@@ -2480,24 +5038,59 @@ func init() {
 	proto.RegisterEnum("TestGroup_TestsName", TestGroup_TestsName_name, TestGroup_TestsName_value)
 	proto.RegisterEnum("TestGroup_FallbackGrouping", TestGroup_FallbackGrouping_name, TestGroup_FallbackGrouping_value)
 	proto.RegisterEnum("TestGroup_PrimaryGrouping", TestGroup_PrimaryGrouping_name, TestGroup_PrimaryGrouping_value)
+	proto.RegisterEnum("TestGroup_BuildOrder", TestGroup_BuildOrder_name, TestGroup_BuildOrder_value)
+	proto.RegisterEnum("TestGroup_SyntheticRow_Position", TestGroup_SyntheticRow_Position_name, TestGroup_SyntheticRow_Position_value)
+	proto.RegisterEnum("CellProperty_Type", CellProperty_Type_name, CellProperty_Type_value)
+	proto.RegisterEnum("TestManagementExport_System", TestManagementExport_System_name, TestManagementExport_System_value)
 	proto.RegisterEnum("AutoBugOptions_Priority", AutoBugOptions_Priority_name, AutoBugOptions_Priority_value)
+	proto.RegisterEnum("DisplayOptions_SortOrder", DisplayOptions_SortOrder_name, DisplayOptions_SortOrder_value)
+	proto.RegisterEnum("DisplayOptions_Palette", DisplayOptions_Palette_name, DisplayOptions_Palette_value)
+	proto.RegisterEnum("NotificationWindow_Day", NotificationWindow_Day_name, NotificationWindow_Day_value)
+	proto.RegisterEnum("DashboardTab_Rollup", DashboardTab_Rollup_name, DashboardTab_Rollup_value)
 	proto.RegisterType((*TestNameConfig)(nil), "TestNameConfig")
 	proto.RegisterType((*TestNameConfig_NameElement)(nil), "TestNameConfig.NameElement")
 	proto.RegisterType((*Notification)(nil), "Notification")
 	proto.RegisterType((*TestGroup)(nil), "TestGroup")
 	proto.RegisterType((*TestGroup_ColumnHeader)(nil), "TestGroup.ColumnHeader")
+	proto.RegisterType((*TestGroup_ColumnHeader_Transform)(nil), "TestGroup.ColumnHeader.Transform")
 	proto.RegisterType((*TestGroup_TestAnnotation)(nil), "TestGroup.TestAnnotation")
 	proto.RegisterType((*TestGroup_KeyValue)(nil), "TestGroup.KeyValue")
 	proto.RegisterType((*TestGroup_ResultSource)(nil), "TestGroup.ResultSource")
+	proto.RegisterType((*TestGroup_SyntheticRow)(nil), "TestGroup.SyntheticRow")
+	proto.RegisterType((*TestGroup_DurationBudget)(nil), "TestGroup.DurationBudget")
+	proto.RegisterType((*TestGroup_MergedSource)(nil), "TestGroup.MergedSource")
+	proto.RegisterMapType((map[string]string)(nil), "TestGroup.MergedSource.MetadataFiltersEntry")
+	proto.RegisterType((*TestGroup_WriteGuard)(nil), "TestGroup.WriteGuard")
+	proto.RegisterType((*TestGroup_ResultSampling)(nil), "TestGroup.ResultSampling")
 	proto.RegisterType((*JUnitConfig)(nil), "JUnitConfig")
+	proto.RegisterType((*ResultDBConfig)(nil), "ResultDBConfig")
+	proto.RegisterType((*CircleCIConfig)(nil), "CircleCIConfig")
+	proto.RegisterType((*TektonConfig)(nil), "TektonConfig")
+	proto.RegisterType((*ArgoConfig)(nil), "ArgoConfig")
+	proto.RegisterType((*Redaction)(nil), "Redaction")
+	proto.RegisterType((*RenameRule)(nil), "RenameRule")
+	proto.RegisterType((*IssueLinkRule)(nil), "IssueLinkRule")
+	proto.RegisterType((*PublicGrid)(nil), "PublicGrid")
+	proto.RegisterType((*TestNameNormalization)(nil), "TestNameNormalization")
+	proto.RegisterType((*CellProperty)(nil), "CellProperty")
+	proto.RegisterType((*TestManagementExport)(nil), "TestManagementExport")
 	proto.RegisterType((*TestMetadataOptions)(nil), "TestMetadataOptions")
 	proto.RegisterType((*AutoBugOptions)(nil), "AutoBugOptions")
 	proto.RegisterType((*AutoBugOptions_DefaultTestMetadata)(nil), "AutoBugOptions.DefaultTestMetadata")
 	proto.RegisterType((*HotlistIdFromSource)(nil), "HotlistIdFromSource")
 	proto.RegisterType((*Dashboard)(nil), "Dashboard")
+	proto.RegisterType((*CommitStatusOptions)(nil), "CommitStatusOptions")
+	proto.RegisterType((*DisplayOptions)(nil), "DisplayOptions")
+	proto.RegisterType((*NotificationSchedule)(nil), "NotificationSchedule")
+	proto.RegisterType((*SilenceWindow)(nil), "SilenceWindow")
+	proto.RegisterType((*OwnerRoute)(nil), "OwnerRoute")
+	proto.RegisterType((*NotificationWindow)(nil), "NotificationWindow")
+	proto.RegisterType((*EscalationStep)(nil), "EscalationStep")
 	proto.RegisterType((*LinkTemplate)(nil), "LinkTemplate")
 	proto.RegisterType((*LinkOptionsTemplate)(nil), "LinkOptionsTemplate")
 	proto.RegisterType((*DashboardTab)(nil), "DashboardTab")
+	proto.RegisterType((*DashboardTab_ColumnWindow)(nil), "DashboardTab.ColumnWindow")
+	proto.RegisterType((*DashboardTab_ErrorBudget)(nil), "DashboardTab.ErrorBudget")
 	proto.RegisterType((*DashboardTabAlertOptions)(nil), "DashboardTabAlertOptions")
 	proto.RegisterType((*DashboardTabFlakinessAlertOptions)(nil), "DashboardTabFlakinessAlertOptions")
 	proto.RegisterType((*DashboardGroup)(nil), "DashboardGroup")
@@ -2509,215 +5102,394 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptor_3eaf2c85e69e9ea4) }
 
 var fileDescriptor_3eaf2c85e69e9ea4 = []byte{
-	// 3358 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3a, 0x5d, 0x57, 0x1b, 0x49,
-	0x76, 0xd6, 0x07, 0xb6, 0xb8, 0x48, 0xa2, 0x29, 0x81, 0x68, 0x60, 0x9c, 0xc1, 0x9a, 0xf5, 0x0e,
-	0x33, 0xb3, 0xcb, 0x8c, 0xf1, 0xcc, 0x66, 0xbc, 0xb6, 0x77, 0x57, 0x80, 0x30, 0x60, 0x3e, 0x94,
-	0x46, 0x6c, 0xce, 0xee, 0x4b, 0xa7, 0xd4, 0x5d, 0x92, 0x7a, 0xe8, 0x0f, 0xa5, 0xab, 0xda, 0x86,
-	0xb7, 0x3c, 0xe6, 0x3f, 0x64, 0x1f, 0x73, 0xf2, 0x36, 0x7f, 0x23, 0x0f, 0x79, 0xcc, 0x39, 0xf9,
-	0x3f, 0x39, 0x75, 0xab, 0xba, 0xd5, 0x42, 0xb2, 0xc7, 0x39, 0x79, 0x42, 0x75, 0xbf, 0xaa, 0xea,
-	0x7e, 0xd5, 0xbd, 0xb7, 0x81, 0xaa, 0x13, 0x85, 0x03, 0x6f, 0xb8, 0x3b, 0x8e, 0x23, 0x11, 0x6d,
-	0x7e, 0x3d, 0xee, 0x7f, 0xeb, 0x24, 0x5c, 0x44, 0x81, 0xcd, 0xde, 0x51, 0x3f, 0xa1, 0x22, 0x8a,
-	0x67, 0x00, 0x8a, 0xb6, 0xf5, 0xb7, 0x22, 0xd4, 0x7b, 0x8c, 0x8b, 0x0b, 0x1a, 0xb0, 0x03, 0x14,
-	0x42, 0xfe, 0x04, 0xb5, 0x90, 0x06, 0xcc, 0x66, 0x3e, 0x0b, 0x58, 0x28, 0xb8, 0x59, 0xd8, 0x2e,
-	0xed, 0x2c, 0xed, 0x6d, 0xed, 0x4e, 0xd3, 0xed, 0xca, 0x9f, 0x1d, 0x45, 0x63, 0x55, 0xc3, 0xc9,
-	0x82, 0x93, 0xcf, 0x61, 0x09, 0x25, 0x0c, 0xa2, 0x38, 0xa0, 0xc2, 0x2c, 0x6e, 0x17, 0x76, 0x16,
-	0x2d, 0x90, 0xa0, 0x23, 0x84, 0x6c, 0xfe, 0x47, 0x01, 0x96, 0x72, 0xec, 0xa4, 0x09, 0x0f, 0x7d,
-	0xda, 0x67, 0xbe, 0xdc, 0x4b, 0xd2, 0xea, 0x15, 0xf9, 0x02, 0x6a, 0x82, 0xc6, 0x43, 0x26, 0x6c,
-	0x75, 0x41, 0x2d, 0xaa, 0xaa, 0x80, 0xfa, 0xbc, 0x4f, 0xa0, 0xda, 0x4f, 0x3c, 0xdf, 0xb5, 0x15,
-	0xd4, 0x2c, 0x6d, 0x17, 0x76, 0x2a, 0xd6, 0x12, 0xc2, 0x7a, 0x08, 0x22, 0x04, 0xca, 0x82, 0x0e,
-	0xb9, 0x59, 0x46, 0x76, 0xfc, 0x8d, 0xb2, 0x19, 0x17, 0xf6, 0x38, 0x8e, 0xc6, 0x2c, 0x16, 0x77,
-	0xe6, 0x82, 0x96, 0xcd, 0xb8, 0xe8, 0x6a, 0x58, 0xeb, 0x2d, 0x54, 0x2f, 0x22, 0xe1, 0x0d, 0x3c,
-	0x87, 0x0a, 0x2f, 0x0a, 0x89, 0x09, 0x8f, 0x78, 0x12, 0x04, 0x34, 0xbe, 0xd3, 0x27, 0x4d, 0x97,
-	0xf2, 0x14, 0x4e, 0x14, 0x0a, 0x76, 0x2b, 0x6c, 0xdf, 0x0b, 0x6f, 0xf4, 0x49, 0x97, 0x34, 0xec,
-	0xcc, 0x0b, 0x6f, 0x5a, 0x7f, 0xfb, 0x0c, 0x16, 0xa5, 0x0e, 0xdf, 0xc4, 0x51, 0x32, 0x96, 0x67,
-	0x92, 0x1a, 0xd1, 0x72, 0xf0, 0x37, 0x79, 0x0c, 0x30, 0x74, 0xb8, 0x3d, 0x8e, 0xd9, 0xc0, 0xbb,
-	0xd5, 0x22, 0x16, 0x87, 0x0e, 0xef, 0x22, 0x80, 0xfc, 0x1a, 0x96, 0x5d, 0x7a, 0xc7, 0xed, 0x68,
-	0x60, 0xc7, 0x8c, 0x27, 0xbe, 0xe0, 0x78, 0xd9, 0x05, 0xab, 0x26, 0xc1, 0x97, 0x03, 0x4b, 0x01,
-	0xc9, 0x53, 0xa8, 0x7b, 0xc3, 0x30, 0x8a, 0x99, 0x3d, 0x66, 0xa1, 0xeb, 0x85, 0x43, 0xbc, 0x78,
-	0xc5, 0xaa, 0x29, 0x68, 0x57, 0x01, 0xe5, 0x91, 0x35, 0x99, 0xd4, 0x95, 0x40, 0x05, 0x54, 0xac,
-	0x25, 0x05, 0xdb, 0x97, 0x20, 0xf2, 0x27, 0x58, 0x91, 0xfa, 0xe0, 0x36, 0xda, 0x73, 0x1c, 0xf9,
-	0x9e, 0x73, 0x67, 0x3e, 0xdc, 0x2e, 0xec, 0xd4, 0xf7, 0x56, 0x77, 0xb3, 0xbb, 0xe0, 0x2f, 0x2e,
-	0x0d, 0x6a, 0x2d, 0x8b, 0xf4, 0x67, 0x17, 0x89, 0xc9, 0x1e, 0xac, 0xe9, 0x4d, 0x50, 0xdb, 0x3c,
-	0xe9, 0x73, 0x11, 0xcb, 0x23, 0x55, 0xb6, 0x4b, 0x3b, 0x8b, 0x56, 0x43, 0x21, 0xa5, 0x80, 0xab,
-	0x14, 0x45, 0x5e, 0x41, 0xcd, 0x89, 0xfc, 0x24, 0x08, 0xed, 0x11, 0xa3, 0x2e, 0x8b, 0xcd, 0x45,
-	0xf4, 0xc0, 0xf5, 0xdc, 0x8e, 0x07, 0x88, 0x3f, 0x46, 0xb4, 0x55, 0x75, 0x72, 0x2b, 0x72, 0x0c,
-	0x2b, 0x03, 0xea, 0xfb, 0x7d, 0xea, 0xdc, 0xd8, 0x43, 0x49, 0x2c, 0x77, 0x03, 0x3c, 0xf3, 0x56,
-	0x4e, 0xc2, 0x91, 0xa6, 0x79, 0xa3, 0x49, 0x2c, 0x63, 0x70, 0x0f, 0x42, 0x5e, 0xc3, 0x06, 0xf5,
-	0x59, 0x2c, 0x6c, 0x2e, 0xa8, 0xcf, 0x52, 0x9d, 0xdb, 0xa3, 0x28, 0x89, 0xb9, 0xb9, 0x24, 0x35,
-	0xbf, 0x5f, 0x34, 0x0b, 0x56, 0x13, 0x89, 0xae, 0x24, 0x8d, 0xb6, 0xc0, 0xb1, 0xa4, 0x20, 0x3f,
-	0xc0, 0x5a, 0x98, 0x04, 0xf6, 0x80, 0x7a, 0x7e, 0x12, 0x33, 0x6e, 0x8b, 0xc8, 0x46, 0x4a, 0xb3,
-	0x9a, 0xb1, 0x92, 0x30, 0x09, 0x8e, 0x34, 0xbe, 0x17, 0xb5, 0x25, 0x56, 0x3a, 0x66, 0x3f, 0x19,
-	0xda, 0x4e, 0x14, 0x8c, 0xa3, 0x90, 0x85, 0xc2, 0xac, 0xa1, 0x8d, 0xab, 0xfd, 0x64, 0x78, 0x90,
-	0xc2, 0xc8, 0x0e, 0x18, 0x4e, 0xe4, 0x32, 0x9b, 0x33, 0x1a, 0x3b, 0x23, 0x7b, 0x4c, 0xc5, 0xc8,
-	0xac, 0xa3, 0xbf, 0xd4, 0x25, 0xfc, 0x0a, 0xc1, 0x5d, 0x2a, 0x46, 0xe4, 0x37, 0x20, 0x37, 0xb1,
-	0x95, 0x8a, 0xb8, 0x1d, 0x33, 0x47, 0xca, 0x5c, 0x46, 0x99, 0x46, 0x98, 0x04, 0x4a, 0x93, 0xdc,
-	0x42, 0x38, 0xf9, 0x1a, 0x56, 0x12, 0xae, 0x6d, 0x15, 0x30, 0x41, 0x5d, 0x2a, 0xa8, 0x69, 0xa0,
-	0x63, 0x2c, 0x27, 0x1c, 0xed, 0x74, 0xae, 0xc1, 0xe4, 0x05, 0xac, 0x2b, 0xf5, 0x04, 0xd4, 0xf3,
-	0xf1, 0x76, 0xae, 0x1b, 0x33, 0xce, 0x19, 0x37, 0x57, 0xe4, 0x51, 0xf0, 0x86, 0xab, 0x48, 0x72,
-	0x4e, 0x3d, 0xbf, 0x17, 0xb5, 0x53, 0x3c, 0xf9, 0x0e, 0x48, 0x8e, 0x95, 0x27, 0xfd, 0x9f, 0x98,
-	0x23, 0x4c, 0x92, 0x71, 0x19, 0x19, 0xd7, 0x95, 0xc2, 0x91, 0x3f, 0xc2, 0x66, 0x8e, 0x43, 0xeb,
-	0xd4, 0x0e, 0x18, 0xe7, 0x74, 0xc8, 0xcc, 0x46, 0xc6, 0xb9, 0x9e, 0x71, 0x6a, 0xbd, 0x9e, 0x2b,
-	0x12, 0xf2, 0x1c, 0x56, 0x73, 0x02, 0x5c, 0x26, 0x75, 0x9c, 0xc4, 0xbe, 0xb9, 0x9a, 0xb1, 0xae,
-	0x64, 0xac, 0x87, 0x12, 0x7b, 0x1d, 0xfb, 0xe4, 0x0c, 0x9e, 0x04, 0x5e, 0x68, 0x33, 0x9f, 0x8e,
-	0x39, 0x73, 0xed, 0xc0, 0x0b, 0x13, 0xc1, 0xb8, 0xdd, 0x67, 0xe2, 0x3d, 0x63, 0x21, 0x8a, 0xe2,
-	0xe6, 0x5a, 0x66, 0xce, 0xc7, 0x81, 0x17, 0x76, 0x14, 0xed, 0xb9, 0x22, 0xdd, 0x57, 0x94, 0x52,
-	0x28, 0x27, 0xbb, 0xd0, 0x60, 0x21, 0xed, 0xfb, 0xcc, 0x1e, 0xf8, 0xf4, 0xe6, 0x4e, 0xba, 0x95,
-	0x48, 0xb8, 0xb9, 0x8e, 0xea, 0x5d, 0x51, 0xa8, 0x23, 0x89, 0xb9, 0x42, 0x84, 0x8c, 0x1d, 0xd7,
-	0xe3, 0xc8, 0x10, 0xb0, 0x78, 0xc8, 0xdc, 0x94, 0xe3, 0x15, 0x72, 0x34, 0x34, 0xf2, 0x1c, 0x71,
-	0x13, 0x1e, 0x69, 0xc0, 0x9b, 0xa4, 0xcf, 0xe2, 0x90, 0xc9, 0xc3, 0x3a, 0xbe, 0x27, 0x2d, 0x6e,
-	0x2a, 0x9e, 0x84, 0xb3, 0xb7, 0x19, 0xee, 0x00, 0x51, 0xe4, 0x47, 0x30, 0xd3, 0x7d, 0xc6, 0x71,
-	0xf4, 0xfe, 0xa7, 0xa8, 0x6f, 0xd3, 0x90, 0xfa, 0x77, 0xdc, 0xe3, 0xe6, 0x1f, 0x90, 0xad, 0xa9,
-	0xf1, 0x5d, 0x85, 0x6e, 0x6b, 0xac, 0xcc, 0xf4, 0x1e, 0xb7, 0xd9, 0xad, 0x60, 0x71, 0x48, 0x7d,
-	0x73, 0x03, 0x89, 0xc1, 0xe3, 0x1d, 0x0d, 0x21, 0x2f, 0xc0, 0x40, 0x5f, 0xc2, 0xfc, 0xa1, 0x93,
-	0xf8, 0xe6, 0x76, 0x61, 0x67, 0x69, 0x6f, 0xf9, 0xde, 0x7b, 0x62, 0xd5, 0xc5, 0xf4, 0x3b, 0xf4,
-	0x1c, 0x6a, 0x61, 0x2e, 0xf7, 0x72, 0x73, 0x0b, 0xb3, 0x40, 0x6d, 0x37, 0x9f, 0x91, 0xad, 0x69,
-	0x1a, 0xd2, 0x01, 0x63, 0x1c, 0x7b, 0x32, 0x23, 0x4f, 0x62, 0xff, 0x31, 0xc6, 0xfe, 0x66, 0x2e,
-	0xf6, 0xbb, 0x8a, 0x24, 0x0b, 0xfd, 0xe5, 0xf1, 0x34, 0x20, 0x67, 0xa9, 0x34, 0x12, 0x46, 0x91,
-	0xcb, 0xcd, 0xbf, 0xcb, 0x5b, 0x4a, 0xc7, 0x82, 0x44, 0x90, 0x43, 0x7d, 0x4d, 0x1a, 0x86, 0x91,
-	0xd0, 0xc7, 0xfd, 0x1c, 0x8f, 0xbb, 0x71, 0x2f, 0x4d, 0xb6, 0x33, 0x0a, 0x95, 0x2b, 0x27, 0x6b,
-	0x4e, 0x7e, 0x84, 0x8d, 0x80, 0xde, 0x4e, 0x6d, 0x69, 0x8f, 0x59, 0x8c, 0x00, 0x73, 0x1b, 0x23,
-	0x76, 0x2d, 0xa0, 0xb7, 0xb9, 0x8d, 0xbb, 0x2c, 0x96, 0x2b, 0x72, 0x0c, 0x6b, 0x53, 0x21, 0x6b,
-	0x47, 0x63, 0x75, 0x88, 0x16, 0x1e, 0x42, 0xe5, 0xea, 0x34, 0x70, 0x2f, 0x15, 0xce, 0x6a, 0x88,
-	0x59, 0xa0, 0x4c, 0x2c, 0x28, 0x49, 0xd0, 0xa1, 0xcc, 0x2a, 0xd2, 0x8c, 0xe6, 0x17, 0x2a, 0xb1,
-	0x48, 0x78, 0x8f, 0x0e, 0xbb, 0x0a, 0x2a, 0x4d, 0x4b, 0x13, 0x11, 0xd9, 0x32, 0x90, 0xd2, 0xed,
-	0x7e, 0xa5, 0x4d, 0xdb, 0x4e, 0x44, 0xb4, 0x9f, 0x0c, 0xd3, 0x9d, 0xea, 0x74, 0x6a, 0x4d, 0x9e,
-	0x43, 0x33, 0xbb, 0x68, 0x9c, 0x84, 0xc2, 0x0b, 0x98, 0xce, 0xaa, 0x4f, 0xf1, 0x96, 0x0d, 0x7d,
-	0x4b, 0x4b, 0xe1, 0x54, 0x3a, 0x7d, 0x05, 0x5b, 0x32, 0x91, 0x8d, 0xa9, 0xcc, 0x20, 0x32, 0xdd,
-	0xa4, 0x3e, 0xab, 0x92, 0xea, 0xaf, 0x91, 0x73, 0x3d, 0x4c, 0x82, 0x2e, 0x52, 0xf4, 0xa2, 0x43,
-	0x85, 0x57, 0x59, 0xf5, 0x1b, 0x20, 0xf2, 0x5d, 0x96, 0xa7, 0xe5, 0x76, 0x5f, 0x7b, 0x87, 0xf9,
-	0xa5, 0xca, 0x6c, 0x12, 0xb3, 0x9f, 0x0c, 0xf9, 0xbe, 0xf2, 0x00, 0x72, 0x02, 0xcd, 0x9c, 0x11,
-	0xd2, 0x12, 0xc1, 0x63, 0xdc, 0xfc, 0x0a, 0xf5, 0xd9, 0xc8, 0x19, 0xf5, 0x2d, 0xbb, 0xfb, 0x33,
-	0xf5, 0x13, 0x66, 0xad, 0x8a, 0xcc, 0x2e, 0xdd, 0x8c, 0x41, 0x46, 0xc8, 0x90, 0x8a, 0x11, 0x8b,
-	0x71, 0x67, 0xf3, 0x6b, 0x15, 0x21, 0x0a, 0x24, 0xb7, 0x94, 0x19, 0x97, 0x8f, 0xa2, 0x58, 0xd8,
-	0x58, 0x3b, 0x04, 0x4c, 0xc4, 0x9e, 0x63, 0x7e, 0x83, 0x1a, 0x5f, 0x46, 0x44, 0x8f, 0xdd, 0x4a,
-	0xb1, 0xb1, 0xe7, 0x48, 0x07, 0x99, 0xba, 0xc4, 0x94, 0x73, 0xfe, 0x16, 0x45, 0xaf, 0x4d, 0xee,
-	0x92, 0x77, 0xd0, 0x1f, 0x60, 0x3d, 0x7f, 0xa3, 0x80, 0x0a, 0x67, 0x64, 0xc7, 0x6c, 0xc8, 0x6e,
-	0xcd, 0x5d, 0xdc, 0x2b, 0x77, 0xfa, 0x73, 0x89, 0xb4, 0x24, 0x8e, 0xbc, 0x80, 0x8d, 0x3c, 0x5b,
-	0x12, 0xe6, 0x19, 0x5f, 0x23, 0x63, 0x73, 0xc2, 0x78, 0xad, 0xd0, 0x8a, 0xf5, 0x99, 0x4a, 0x44,
-	0x83, 0xc4, 0xf7, 0x53, 0x76, 0x99, 0x04, 0xb8, 0xf9, 0x2d, 0x9e, 0x93, 0x24, 0x9c, 0x1d, 0x25,
-	0xbe, 0xaf, 0x38, 0x65, 0xd8, 0x73, 0xf2, 0x0f, 0xf0, 0x74, 0xe6, 0xe5, 0xd6, 0x49, 0x23, 0x89,
-	0x31, 0x46, 0x6c, 0x59, 0xbe, 0x32, 0xf3, 0x19, 0xee, 0xdc, 0xba, 0xff, 0x60, 0x1f, 0xe4, 0x49,
-	0xd1, 0x28, 0xb2, 0x94, 0x50, 0xcf, 0xb6, 0xcd, 0xa3, 0x24, 0x76, 0x98, 0xb9, 0x87, 0x1e, 0x9a,
-	0x2f, 0x25, 0xd4, 0x9b, 0x7d, 0x85, 0x68, 0xab, 0x1a, 0xe7, 0x56, 0xe4, 0x00, 0x36, 0xee, 0xd7,
-	0xcd, 0x76, 0x9c, 0xf8, 0xf2, 0xd9, 0x15, 0xe6, 0x73, 0x94, 0x54, 0xd9, 0xb5, 0x12, 0x9f, 0x5d,
-	0x31, 0x61, 0x35, 0x15, 0x69, 0x27, 0xa5, 0xd4, 0x70, 0xa9, 0xfa, 0x98, 0x51, 0x95, 0xbb, 0x99,
-	0x3d, 0x88, 0xa3, 0xc0, 0xe6, 0x22, 0x8a, 0xe5, 0xb3, 0xf5, 0x3d, 0xaa, 0x62, 0x55, 0xa2, 0x65,
-	0xfa, 0x66, 0x47, 0x71, 0x14, 0x5c, 0x29, 0x9c, 0x7c, 0xb7, 0x75, 0xe1, 0x14, 0xf9, 0x6e, 0x56,
-	0xef, 0xfd, 0x80, 0x1c, 0x86, 0xc2, 0x5c, 0xfa, 0x6e, 0x5a, 0xf2, 0xc9, 0x44, 0xac, 0xa8, 0xf9,
-	0x8d, 0x37, 0x36, 0x7f, 0xa7, 0x13, 0x31, 0x82, 0xae, 0x6e, 0xbc, 0x31, 0xf9, 0x1d, 0xac, 0xab,
-	0x2a, 0x39, 0x7a, 0xc7, 0xe2, 0xd8, 0x93, 0xa5, 0x83, 0x88, 0x07, 0x32, 0xba, 0xcc, 0xbf, 0x47,
-	0x6d, 0xae, 0x21, 0xfa, 0x52, 0x63, 0xaf, 0x34, 0x52, 0x56, 0x23, 0x09, 0x67, 0xf1, 0xa4, 0x4c,
-	0xfe, 0x51, 0x95, 0xc9, 0x12, 0x98, 0x96, 0xc9, 0x9b, 0xff, 0x0c, 0xd5, 0x7c, 0x41, 0x46, 0x56,
-	0x61, 0x01, 0x2b, 0x78, 0x5d, 0xdc, 0xaa, 0x05, 0xd9, 0x84, 0x4a, 0x26, 0x45, 0xd5, 0xb6, 0xd9,
-	0x9a, 0x7c, 0x0b, 0x8d, 0x79, 0x86, 0x2e, 0x21, 0x19, 0x71, 0x66, 0x0c, 0xbb, 0xc9, 0x55, 0xdf,
-	0x32, 0x49, 0x9f, 0xb2, 0x78, 0x9e, 0x04, 0x92, 0xde, 0x79, 0x31, 0x8b, 0x20, 0xf2, 0x14, 0x6a,
-	0xe9, 0x6e, 0xe8, 0x88, 0xea, 0x08, 0xc7, 0x0f, 0xac, 0x6a, 0x0a, 0x96, 0x4e, 0xb8, 0xbf, 0x05,
-	0x1b, 0x53, 0xe1, 0x88, 0xc5, 0x83, 0x76, 0x9e, 0xcd, 0x3d, 0xa8, 0xa4, 0xe1, 0x4e, 0x0c, 0x28,
-	0xdd, 0xb0, 0xb4, 0x0d, 0x90, 0x3f, 0xe5, 0xad, 0xd5, 0xa9, 0xd5, 0xe5, 0xd4, 0x62, 0xf3, 0x06,
-	0xaa, 0x79, 0x0f, 0x23, 0xcf, 0xa0, 0xfa, 0x53, 0x12, 0x7a, 0x53, 0x2d, 0xcd, 0xd2, 0x5e, 0x75,
-	0xf7, 0xf4, 0x3a, 0xf4, 0x74, 0x4b, 0x73, 0xfc, 0xc0, 0x5a, 0x42, 0x1a, 0xb5, 0xdc, 0x6f, 0xc2,
-	0xea, 0x94, 0x13, 0x6b, 0xd6, 0xd3, 0x72, 0xa5, 0x60, 0x14, 0x4f, 0xcb, 0x95, 0x92, 0x51, 0x3e,
-	0x2d, 0x57, 0xca, 0xc6, 0x42, 0x2b, 0x50, 0x1d, 0x06, 0x16, 0xe0, 0x64, 0x13, 0x9a, 0xbd, 0xce,
-	0x55, 0xef, 0xca, 0xbe, 0x68, 0x9f, 0x77, 0xec, 0xeb, 0x8b, 0xab, 0x6e, 0xe7, 0xe0, 0xe4, 0xe8,
-	0xa4, 0x73, 0x68, 0x3c, 0x20, 0x6b, 0xb0, 0x92, 0xc3, 0x9d, 0xbc, 0xb9, 0xb8, 0xb4, 0x3a, 0x46,
-	0x81, 0x34, 0x81, 0xe4, 0xc0, 0x56, 0xa7, 0x7b, 0xd6, 0x3e, 0xe8, 0x18, 0xc5, 0x7b, 0xe4, 0xed,
-	0x6e, 0xb7, 0x73, 0x71, 0x68, 0x94, 0x5a, 0xff, 0x55, 0x00, 0xe3, 0x7e, 0x1d, 0x2d, 0xb7, 0x3d,
-	0x6a, 0x9f, 0x9d, 0xed, 0xb7, 0x0f, 0xde, 0xda, 0x6f, 0xac, 0xcb, 0xeb, 0xee, 0xc9, 0xc5, 0x1b,
-	0xfb, 0xe2, 0xf2, 0xa2, 0x63, 0x3c, 0x98, 0x8f, 0x3b, 0x6c, 0xf7, 0xe4, 0xde, 0x9f, 0x81, 0x39,
-	0x8b, 0x3b, 0x6b, 0xef, 0x77, 0xce, 0xae, 0x8c, 0x22, 0x31, 0x61, 0x75, 0x16, 0x7b, 0x72, 0x68,
-	0x94, 0xc8, 0x16, 0xac, 0xcf, 0x62, 0xf6, 0xaf, 0x4f, 0xce, 0x0e, 0x8d, 0x32, 0xf9, 0x0a, 0x9e,
-	0xce, 0x22, 0x0f, 0x2e, 0x2f, 0x8e, 0x4e, 0xde, 0x5c, 0x5b, 0xed, 0xde, 0xc9, 0xe5, 0x85, 0xfd,
-	0xe7, 0xf6, 0xd9, 0x75, 0xc7, 0x58, 0x68, 0x1d, 0xc3, 0xf2, 0xbd, 0xba, 0x80, 0x6c, 0xc0, 0x5a,
-	0xd7, 0x3a, 0x39, 0x6f, 0x5b, 0x7f, 0x99, 0x77, 0x93, 0x19, 0x94, 0xda, 0xb4, 0x70, 0x5a, 0xae,
-	0x3c, 0x32, 0x2a, 0xa7, 0xe5, 0x4a, 0xd3, 0x58, 0x3f, 0x2d, 0x57, 0x3e, 0x33, 0x1e, 0x9f, 0x96,
-	0x2b, 0x4f, 0x8c, 0xd6, 0x69, 0xb9, 0xb2, 0x63, 0x7c, 0x75, 0x5a, 0xae, 0xfc, 0xc6, 0xf8, 0xed,
-	0x69, 0xb9, 0xf2, 0x9d, 0xf1, 0xec, 0xb4, 0x5c, 0xf9, 0xbd, 0xf1, 0xf2, 0xb4, 0x5c, 0x79, 0x69,
-	0xbc, 0x6a, 0xd5, 0x60, 0x29, 0xe7, 0x03, 0xad, 0x9f, 0x0b, 0xd0, 0x98, 0xf3, 0x6a, 0xcb, 0x26,
-	0x70, 0x52, 0x51, 0xa9, 0x44, 0xac, 0x7c, 0xb0, 0x96, 0xd6, 0x4f, 0x2a, 0xff, 0xce, 0xb4, 0x11,
-	0xc5, 0x39, 0x6d, 0xc4, 0x2a, 0x2c, 0x44, 0xef, 0x43, 0x16, 0xeb, 0x40, 0x53, 0x0b, 0x52, 0x87,
-	0xa2, 0xe3, 0x98, 0x65, 0x6c, 0xd0, 0x8a, 0x8e, 0x23, 0x45, 0xa5, 0x81, 0xa0, 0x36, 0xd4, 0xad,
-	0xb2, 0x06, 0xe2, 0x7e, 0xad, 0x7f, 0x79, 0x08, 0xf5, 0xe9, 0x67, 0x9f, 0x7c, 0x0f, 0xcd, 0x3e,
-	0x13, 0xd4, 0x96, 0xaf, 0xff, 0xf4, 0x59, 0x00, 0xcf, 0xb2, 0x2a, 0xb1, 0x6d, 0x85, 0x9c, 0x9c,
-	0xe9, 0x31, 0x00, 0xd6, 0x15, 0x8e, 0x1f, 0x71, 0xd5, 0x1e, 0x57, 0xac, 0x45, 0x09, 0x39, 0x90,
-	0x00, 0x99, 0xe9, 0x46, 0x91, 0xf0, 0x3d, 0x2e, 0x6c, 0xcf, 0xe5, 0x66, 0x71, 0xbb, 0xb4, 0x53,
-	0xb2, 0x40, 0x83, 0x4e, 0x5c, 0xb9, 0x6b, 0x65, 0x1c, 0x7b, 0x51, 0xec, 0x89, 0x3b, 0xbc, 0x56,
-	0x7d, 0xcf, 0xbc, 0x57, 0x8f, 0xc8, 0xfa, 0x0f, 0xf1, 0x56, 0x46, 0x49, 0xde, 0xc2, 0x7a, 0x4e,
-	0xac, 0x4e, 0xd3, 0xea, 0xc9, 0x28, 0xeb, 0x1a, 0xea, 0x38, 0xdd, 0x03, 0xd3, 0xb4, 0x7a, 0x2f,
-	0x56, 0x27, 0x1b, 0x4f, 0xa0, 0xe4, 0x4b, 0x58, 0x1e, 0x78, 0x3e, 0xb3, 0xbd, 0xd0, 0xf5, 0xde,
-	0x79, 0x6e, 0x42, 0x7d, 0xdd, 0x5c, 0xd7, 0x25, 0xf8, 0x24, 0x83, 0x92, 0x6f, 0x60, 0x85, 0x7b,
-	0xe1, 0xd0, 0x67, 0x22, 0x0a, 0x53, 0x35, 0x61, 0x7f, 0x5d, 0xb1, 0x8c, 0x0c, 0xa1, 0x35, 0x44,
-	0x5e, 0xc3, 0x96, 0xac, 0x9a, 0xa8, 0xef, 0x47, 0xef, 0x99, 0x9b, 0x13, 0xae, 0x4a, 0x8b, 0x47,
-	0xa8, 0x53, 0x33, 0xa0, 0xb7, 0x6d, 0x45, 0x31, 0xd9, 0x07, 0x0b, 0x8d, 0x27, 0x50, 0xc5, 0x43,
-	0xc9, 0x07, 0x80, 0xfa, 0xbe, 0x59, 0x51, 0xed, 0xbe, 0x84, 0x5d, 0x2a, 0x10, 0xf9, 0x47, 0x58,
-	0x73, 0xd9, 0x80, 0xca, 0x4c, 0x33, 0xdd, 0x01, 0x2e, 0x62, 0x92, 0xfa, 0xe2, 0xbe, 0x1e, 0x0f,
-	0x15, 0x71, 0xde, 0x4d, 0xad, 0x86, 0x3b, 0x0b, 0x94, 0x9e, 0x40, 0xdd, 0x77, 0x34, 0x74, 0x98,
-	0x7b, 0x4f, 0xf2, 0x92, 0x7a, 0x02, 0x53, 0x6c, 0x9e, 0x6b, 0xf3, 0x9f, 0xa0, 0x31, 0x67, 0x87,
-	0x59, 0xcf, 0x2e, 0x7c, 0xcc, 0xb3, 0x8b, 0xb3, 0x9e, 0xad, 0x9c, 0xbd, 0xe8, 0x38, 0xad, 0x33,
-	0xa8, 0xa4, 0xbe, 0x20, 0x33, 0x4c, 0xd7, 0x3a, 0xb9, 0xb4, 0x4e, 0x7a, 0x7f, 0xb9, 0x97, 0x2c,
-	0x1f, 0x42, 0xb1, 0xfb, 0x9d, 0x51, 0xc0, 0xbf, 0xcf, 0x8c, 0x22, 0xfe, 0xdd, 0x33, 0x4a, 0xf8,
-	0xf7, 0xb9, 0x51, 0xc6, 0xbf, 0xdf, 0x1b, 0x0b, 0xad, 0xbf, 0x42, 0x63, 0x8e, 0x8f, 0x90, 0x66,
-	0xfa, 0x2e, 0xc8, 0x73, 0x96, 0x8e, 0x1f, 0xe8, 0x97, 0x41, 0xc2, 0xd5, 0x2b, 0x99, 0xbe, 0x44,
-	0x6a, 0xb9, 0xdf, 0x80, 0x95, 0x89, 0x2b, 0x6a, 0x27, 0x6c, 0xfd, 0x67, 0x11, 0x16, 0x0f, 0x29,
-	0x1f, 0xf5, 0x23, 0x1a, 0xbb, 0x64, 0x0f, 0x6a, 0x6e, 0xba, 0xb0, 0x05, 0xed, 0xeb, 0x19, 0x5d,
-	0x6d, 0x37, 0x23, 0xe9, 0xd1, 0xbe, 0x55, 0x75, 0x73, 0xab, 0x6c, 0xe0, 0x54, 0xcc, 0x0d, 0x9c,
-	0x66, 0x7a, 0xac, 0xd2, 0x27, 0xf4, 0x58, 0x9f, 0xc3, 0x52, 0xe6, 0x25, 0xb4, 0xaf, 0x93, 0x01,
-	0xa4, 0x66, 0xa7, 0x7d, 0xec, 0x5b, 0xa3, 0xf7, 0xe1, 0xd8, 0xa7, 0x77, 0xd8, 0xa9, 0xcb, 0x32,
-	0x4e, 0xd0, 0x3e, 0xd7, 0x2e, 0xd7, 0x48, 0x91, 0x47, 0x0a, 0xd7, 0xa3, 0x7d, 0xd9, 0xfb, 0x34,
-	0x47, 0xde, 0x70, 0xe4, 0x7b, 0xc3, 0x91, 0x98, 0x66, 0xc2, 0x70, 0x50, 0xb3, 0x84, 0x8c, 0x22,
-	0xcf, 0xf9, 0x25, 0x2c, 0x4f, 0x38, 0x45, 0xe4, 0xd2, 0x3b, 0x0c, 0x85, 0x8a, 0x55, 0xcf, 0xc0,
-	0x3d, 0x09, 0xd5, 0x4f, 0xa4, 0x0b, 0xd5, 0x33, 0x2f, 0xbc, 0xe9, 0xb1, 0x60, 0xec, 0x53, 0x81,
-	0xef, 0x78, 0x12, 0xa7, 0x95, 0x8a, 0xfc, 0x49, 0x76, 0xe1, 0x51, 0xda, 0xcf, 0x14, 0x75, 0xe8,
-	0x4b, 0x0e, 0xed, 0xf4, 0x29, 0xa3, 0x95, 0x12, 0x65, 0x8a, 0x2d, 0x4d, 0x14, 0xdb, 0x7a, 0x0d,
-	0x8d, 0x39, 0x3c, 0x9f, 0x5a, 0x34, 0xb4, 0xfe, 0x15, 0xa0, 0x7a, 0x38, 0xcf, 0x78, 0xf9, 0x69,
-	0x61, 0xfa, 0x12, 0x60, 0xa9, 0x9c, 0xab, 0x69, 0xd4, 0x4b, 0x80, 0x8f, 0x18, 0xd6, 0x01, 0x33,
-	0xf1, 0x52, 0xfa, 0xc4, 0x81, 0x52, 0xf9, 0xff, 0x30, 0x50, 0x5a, 0xf8, 0xc0, 0x40, 0xe9, 0x09,
-	0x54, 0xfb, 0x94, 0xb3, 0xac, 0x43, 0x7c, 0xa8, 0xe6, 0xa2, 0x12, 0x96, 0x3e, 0x13, 0x2f, 0x81,
-	0x44, 0x63, 0x16, 0xaa, 0xc4, 0x20, 0xb4, 0xaa, 0xd0, 0x86, 0xd2, 0x13, 0xf3, 0xc6, 0xb2, 0x0c,
-	0x49, 0x28, 0x93, 0x41, 0xa6, 0xd1, 0x17, 0xb0, 0x82, 0x59, 0x4d, 0xde, 0x30, 0xe3, 0xad, 0xcc,
-	0xe3, 0xc5, 0x94, 0xbc, 0x9f, 0x0c, 0x33, 0xd6, 0xd7, 0xd0, 0xa0, 0x42, 0x50, 0x67, 0x34, 0xcd,
-	0xbc, 0x38, 0x8f, 0x79, 0x45, 0x51, 0xe6, 0xd9, 0x9f, 0x40, 0x35, 0x9d, 0x08, 0x62, 0xc5, 0x09,
-	0xea, 0x66, 0x1a, 0x86, 0x35, 0xe7, 0x1f, 0xd3, 0xc2, 0x8d, 0xdb, 0x49, 0xec, 0x4f, 0xb6, 0x58,
-	0x9a, 0xb7, 0x05, 0xd1, 0xa4, 0xd7, 0xb1, 0x9f, 0xed, 0x71, 0x04, 0x66, 0xde, 0x2a, 0x53, 0x42,
-	0xaa, 0xf3, 0x84, 0xac, 0x4d, 0x8c, 0x95, 0x97, 0xb3, 0x2d, 0x43, 0x96, 0x3b, 0xb1, 0x87, 0x2a,
-	0xc7, 0x89, 0xe2, 0xa2, 0x95, 0x07, 0x91, 0x5d, 0x68, 0x08, 0xda, 0x4f, 0x7c, 0x1a, 0xab, 0x36,
-	0x4d, 0xbf, 0xf4, 0x6a, 0xa6, 0xb8, 0xa2, 0x51, 0xd8, 0xa6, 0xa9, 0xf2, 0xe2, 0x0f, 0x50, 0x53,
-	0xe3, 0xb4, 0xd4, 0xb0, 0xcb, 0x78, 0x9c, 0x8d, 0xa9, 0x0c, 0x84, 0xad, 0x77, 0x3a, 0x04, 0xa8,
-	0xd2, 0xdc, 0x8a, 0xfc, 0x15, 0xd6, 0x07, 0x3e, 0xbd, 0xf1, 0x42, 0xc6, 0xb9, 0x3d, 0x2d, 0xc9,
-	0x44, 0x49, 0xad, 0x29, 0x49, 0x47, 0x29, 0xed, 0x94, 0xc8, 0xb5, 0xc1, 0x3c, 0xb0, 0xbc, 0x0b,
-	0xed, 0x47, 0x89, 0xb0, 0x27, 0x39, 0x52, 0x86, 0xb8, 0xa1, 0xee, 0x82, 0xa8, 0x4c, 0xf6, 0x75,
-	0xec, 0x4b, 0x1f, 0x42, 0x07, 0x9c, 0x72, 0x83, 0x95, 0xb9, 0x3e, 0x24, 0xe9, 0xf2, 0x4e, 0xf0,
-	0x2b, 0xc0, 0xd9, 0x86, 0x9d, 0xfa, 0x20, 0xc7, 0x21, 0x66, 0xc5, 0xaa, 0x4a, 0xe8, 0x91, 0x72,
-	0x38, 0x2e, 0x43, 0xc6, 0xf5, 0x38, 0xe6, 0x43, 0x3f, 0x72, 0xa8, 0x6f, 0x63, 0xdf, 0xd5, 0x50,
-	0xef, 0xbc, 0xc6, 0x9c, 0x49, 0x44, 0x4f, 0xb6, 0x5c, 0x6d, 0x58, 0x4b, 0x3f, 0x25, 0x04, 0x2c,
-	0x4c, 0x26, 0x47, 0x5a, 0x9d, 0x77, 0xa4, 0x86, 0xa6, 0x3d, 0x67, 0x61, 0x92, 0x1d, 0x4b, 0x76,
-	0x7b, 0x71, 0x74, 0xc3, 0x42, 0x1d, 0xa6, 0xb6, 0x18, 0xc5, 0x8c, 0x8f, 0x22, 0xdf, 0xc5, 0x69,
-	0x65, 0xd1, 0x5a, 0x53, 0x68, 0x15, 0xab, 0xbd, 0x14, 0x49, 0xda, 0xb0, 0x3a, 0x55, 0xb1, 0xa5,
-	0x26, 0x69, 0xce, 0x9f, 0xeb, 0x90, 0x5c, 0x01, 0x97, 0x2a, 0xff, 0x02, 0xd6, 0x47, 0x8c, 0xfa,
-	0x62, 0x94, 0xcd, 0x10, 0x33, 0x29, 0xeb, 0x28, 0xa5, 0xb9, 0x7b, 0x8c, 0xf8, 0x74, 0x88, 0x98,
-	0x19, 0x73, 0x34, 0x0f, 0xdc, 0xfa, 0x9f, 0x12, 0x98, 0x1f, 0xf2, 0x29, 0xf2, 0xe2, 0x63, 0x13,
-	0x7a, 0x55, 0x16, 0x7c, 0x68, 0x3a, 0xff, 0xec, 0x43, 0xd3, 0x79, 0x55, 0x27, 0xcf, 0x9b, 0xcc,
-	0xff, 0xf0, 0xe1, 0x81, 0xb7, 0xca, 0xfd, 0xf3, 0x87, 0xdd, 0xbf, 0x30, 0xb8, 0x2a, 0x7f, 0x7c,
-	0x70, 0x85, 0x9f, 0x9c, 0xd4, 0x7c, 0x7c, 0x21, 0xfd, 0xe4, 0xa4, 0x46, 0xe2, 0x5b, 0xb0, 0x38,
-	0x19, 0x63, 0xab, 0xbc, 0x5a, 0x71, 0xd3, 0xc9, 0xf5, 0x17, 0x50, 0x53, 0xc8, 0x74, 0x44, 0xfe,
-	0x48, 0xd5, 0xec, 0x08, 0x4c, 0x67, 0xe2, 0xaf, 0x61, 0xeb, 0x3d, 0xf5, 0xc4, 0xcc, 0x5c, 0x9b,
-	0xa9, 0xc1, 0x76, 0x45, 0x55, 0x94, 0x92, 0x64, 0x7a, 0x9c, 0xdd, 0x41, 0x3c, 0x79, 0xf9, 0xd1,
-	0x99, 0xfc, 0x22, 0x6e, 0xf8, 0xa1, 0x79, 0x7c, 0xeb, 0xe7, 0x22, 0x3c, 0xf9, 0xc5, 0x08, 0x97,
-	0x5b, 0x04, 0x5e, 0xe8, 0x05, 0xd2, 0x52, 0x59, 0xba, 0xc8, 0x4c, 0x55, 0x40, 0x5f, 0x5e, 0xd7,
-	0x14, 0x99, 0x84, 0x4f, 0xb0, 0x57, 0xf1, 0x23, 0xf6, 0xca, 0x69, 0xbc, 0x34, 0xad, 0xf1, 0x5f,
-	0xd0, 0x57, 0xf9, 0xff, 0xa5, 0xaf, 0x85, 0x8f, 0xeb, 0xeb, 0x1c, 0xea, 0x99, 0xba, 0x3e, 0xfc,
-	0x05, 0xf1, 0x4b, 0x58, 0x9e, 0x24, 0x3d, 0x35, 0x6f, 0x2b, 0x62, 0x1f, 0x57, 0xcf, 0xc0, 0x98,
-	0xc4, 0x5b, 0xff, 0x5e, 0x80, 0xda, 0xd4, 0xbc, 0x8c, 0x7c, 0x03, 0x4b, 0x93, 0x72, 0x22, 0xfd,
-	0xea, 0x0b, 0x93, 0x41, 0x99, 0x05, 0x59, 0x59, 0xc1, 0xc9, 0xd7, 0x00, 0x99, 0xc0, 0xb4, 0x4c,
-	0x82, 0x49, 0xc6, 0xb6, 0x72, 0x58, 0xf2, 0x7b, 0x30, 0x26, 0x67, 0xd2, 0xd2, 0x55, 0x9d, 0xb9,
-	0xbc, 0x3b, 0x7d, 0x25, 0x6b, 0x72, 0x78, 0xb5, 0x4f, 0xeb, 0xbf, 0x0b, 0xb0, 0x36, 0x37, 0x5d,
-	0x90, 0x26, 0x3c, 0x54, 0x73, 0x78, 0xdd, 0x22, 0xea, 0x95, 0x2c, 0x64, 0xd2, 0x8f, 0xa4, 0xd9,
-	0x47, 0x0c, 0x15, 0xd2, 0x75, 0xf5, 0x95, 0x34, 0xfb, 0x78, 0xf1, 0x14, 0xea, 0x4c, 0x7d, 0x7f,
-	0x72, 0x46, 0xcc, 0x4d, 0xfc, 0xb4, 0x82, 0xab, 0x21, 0xf4, 0x4a, 0x03, 0xc9, 0x57, 0x60, 0x28,
-	0xb2, 0x98, 0x39, 0xde, 0xd8, 0xc3, 0x4f, 0xe2, 0xaa, 0x32, 0x5a, 0x46, 0xb8, 0x95, 0x81, 0xa5,
-	0xc4, 0x6c, 0x6e, 0x99, 0xef, 0x94, 0x6b, 0x29, 0x54, 0xb5, 0xca, 0xff, 0x56, 0x80, 0x55, 0xdd,
-	0xd8, 0x4c, 0x9b, 0xe0, 0x15, 0x90, 0xa9, 0xfe, 0x4b, 0x0d, 0xa9, 0x0b, 0x98, 0x36, 0x73, 0x96,
-	0x50, 0x9f, 0xc8, 0x72, 0x7d, 0x96, 0xf2, 0x87, 0xce, 0xa4, 0x7b, 0x9b, 0x6e, 0x0e, 0x8a, 0xfa,
-	0xdd, 0xc8, 0x87, 0x1b, 0xca, 0x48, 0x7b, 0xb5, 0x3c, 0xa2, 0xff, 0x10, 0xff, 0x33, 0xe0, 0xf9,
-	0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x0b, 0x12, 0x09, 0x6b, 0x55, 0x20, 0x00, 0x00,
+	// 6214 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3b, 0x49, 0x77, 0x1b, 0x47,
+	0x7a, 0xc6, 0x42, 0x12, 0xfc, 0xb0, 0xb0, 0x59, 0xdc, 0x5a, 0x94, 0x35, 0x96, 0xe0, 0xb1, 0x2d,
+	0x6f, 0xb0, 0x25, 0x6f, 0xb2, 0x2d, 0x8d, 0x0d, 0x12, 0xa0, 0x08, 0x8a, 0x0b, 0xa6, 0x01, 0x5a,
+	0x23, 0x67, 0xe9, 0x29, 0x74, 0x17, 0x81, 0x1e, 0x35, 0xba, 0x91, 0xae, 0x6e, 0x91, 0x9c, 0x43,
+	0xb6, 0x37, 0xb7, 0x5c, 0x73, 0xc8, 0x7b, 0xc9, 0x21, 0xa7, 0xe4, 0xbd, 0xbc, 0x37, 0xbf, 0x20,
+	0x7f, 0x20, 0xc9, 0x31, 0x97, 0xb9, 0xe5, 0xbd, 0x24, 0x7f, 0x24, 0xaf, 0xbe, 0xaa, 0x6a, 0x34,
+	0x48, 0x48, 0xf6, 0x4c, 0x4e, 0x40, 0x7d, 0x4b, 0xad, 0x5f, 0x7d, 0x5b, 0x7d, 0x0d, 0x15, 0x27,
+	0x0c, 0xce, 0xbc, 0x61, 0x63, 0x12, 0x85, 0x71, 0xb8, 0xfd, 0xde, 0x64, 0xf0, 0x91, 0x93, 0xf0,
+	0x38, 0x1c, 0xdb, 0xec, 0x05, 0xf5, 0x13, 0x1a, 0x87, 0xd1, 0x35, 0x80, 0xa2, 0xbd, 0x3d, 0x19,
+	0x7c, 0x14, 0x33, 0x1e, 0xdb, 0x3c, 0xa6, 0x71, 0xc2, 0xb3, 0xff, 0x25, 0x45, 0xfd, 0x1f, 0xf2,
+	0x50, 0xeb, 0x33, 0x1e, 0x1f, 0xd3, 0x31, 0xdb, 0xc5, 0x61, 0xc8, 0xb7, 0x50, 0x0d, 0xe8, 0x98,
+	0xd9, 0xcc, 0x67, 0x63, 0x16, 0xc4, 0xdc, 0xcc, 0xdd, 0x2e, 0xdc, 0x2d, 0xdf, 0xbf, 0xd9, 0x98,
+	0xa5, 0x6b, 0x88, 0xbf, 0x6d, 0x49, 0x63, 0x55, 0x82, 0x69, 0x83, 0x93, 0x37, 0xa0, 0x8c, 0x3d,
+	0x9c, 0x85, 0xd1, 0x98, 0xc6, 0x66, 0xfe, 0x76, 0xee, 0xee, 0xb2, 0x05, 0x02, 0xb4, 0x87, 0x90,
+	0xed, 0x7f, 0xca, 0x41, 0x39, 0xc3, 0x4e, 0x36, 0x61, 0xd1, 0xa7, 0x03, 0xe6, 0x8b, 0xb1, 0x04,
+	0xad, 0x6a, 0x91, 0x37, 0xa1, 0x1a, 0xd3, 0x68, 0xc8, 0x62, 0x5b, 0x6e, 0x81, 0xea, 0xaa, 0x22,
+	0x81, 0x6a, 0xbe, 0x77, 0xa0, 0x32, 0x48, 0x3c, 0xdf, 0xb5, 0x25, 0xd4, 0x2c, 0xdc, 0xce, 0xdd,
+	0x2d, 0x59, 0x65, 0x84, 0xf5, 0x11, 0x44, 0x08, 0x14, 0x63, 0x3a, 0xe4, 0x66, 0x11, 0xd9, 0xf1,
+	0x3f, 0xf6, 0x2d, 0xb6, 0x63, 0x12, 0x85, 0x13, 0x16, 0xc5, 0x97, 0xe6, 0x82, 0xea, 0x9b, 0xf1,
+	0xb8, 0xab, 0x60, 0xf5, 0x27, 0x50, 0x39, 0x0e, 0x63, 0xef, 0xcc, 0x73, 0x68, 0xec, 0x85, 0x01,
+	0x31, 0x61, 0x89, 0x27, 0xe3, 0x31, 0x8d, 0x2e, 0xd5, 0x4c, 0x75, 0x53, 0xcc, 0xc2, 0x09, 0x83,
+	0x98, 0x5d, 0xc4, 0xb6, 0xef, 0x05, 0xcf, 0xd5, 0x4c, 0xcb, 0x0a, 0x76, 0xe8, 0x05, 0xcf, 0xeb,
+	0xff, 0xfb, 0x29, 0x2c, 0x8b, 0x3d, 0x7c, 0x1c, 0x85, 0xc9, 0x44, 0xcc, 0x49, 0xec, 0x88, 0xea,
+	0x07, 0xff, 0x93, 0x5b, 0x00, 0x43, 0x87, 0xdb, 0x93, 0x88, 0x9d, 0x79, 0x17, 0xaa, 0x8b, 0xe5,
+	0xa1, 0xc3, 0xbb, 0x08, 0x20, 0x6f, 0xc3, 0x8a, 0x4b, 0x2f, 0xb9, 0x1d, 0x9e, 0xd9, 0x11, 0xe3,
+	0x89, 0x1f, 0x73, 0x5c, 0xec, 0x82, 0x55, 0x15, 0xe0, 0x93, 0x33, 0x4b, 0x02, 0xc9, 0x5b, 0x50,
+	0xf3, 0x86, 0x41, 0x18, 0x31, 0x7b, 0xc2, 0x02, 0xd7, 0x0b, 0x86, 0xb8, 0xf0, 0x92, 0x55, 0x95,
+	0xd0, 0xae, 0x04, 0x8a, 0x29, 0x2b, 0x32, 0xb1, 0x57, 0x31, 0x6e, 0x40, 0xc9, 0x2a, 0x4b, 0xd8,
+	0x8e, 0x00, 0x91, 0x6f, 0x61, 0x55, 0xec, 0x07, 0xb7, 0xf1, 0x3c, 0x27, 0xa1, 0xef, 0x39, 0x97,
+	0xe6, 0xe2, 0xed, 0xdc, 0xdd, 0xda, 0xfd, 0xf5, 0x46, 0xba, 0x16, 0xfc, 0xc7, 0xc5, 0x81, 0x5a,
+	0x2b, 0xb1, 0xfe, 0xdb, 0x45, 0x62, 0x72, 0x1f, 0x36, 0xd4, 0x20, 0x52, 0xf8, 0x92, 0x01, 0x8f,
+	0x23, 0x31, 0xa5, 0xd2, 0xed, 0xc2, 0xdd, 0x65, 0x6b, 0x4d, 0x22, 0x45, 0x07, 0x3d, 0x8d, 0x22,
+	0x0f, 0xa1, 0xea, 0x84, 0x7e, 0x32, 0x0e, 0xec, 0x11, 0xa3, 0x2e, 0x8b, 0xcc, 0x65, 0x94, 0xc0,
+	0xad, 0xcc, 0x88, 0xbb, 0x88, 0xdf, 0x47, 0xb4, 0x55, 0x71, 0x32, 0x2d, 0xb2, 0x0f, 0xab, 0x67,
+	0xd4, 0xf7, 0x07, 0xd4, 0x79, 0x6e, 0x0f, 0x05, 0xb1, 0x18, 0x0d, 0x70, 0xce, 0x37, 0x33, 0x3d,
+	0xec, 0x29, 0x9a, 0xc7, 0x8a, 0xc4, 0x32, 0xce, 0xae, 0x40, 0xc8, 0x23, 0xb8, 0x41, 0x7d, 0x16,
+	0xe1, 0x95, 0xf1, 0x99, 0xde, 0x73, 0x7b, 0x14, 0x26, 0x11, 0x37, 0xcb, 0x62, 0xe7, 0x77, 0xf2,
+	0x66, 0xce, 0xda, 0x44, 0xa2, 0x9e, 0xa0, 0x51, 0x27, 0xb0, 0x2f, 0x28, 0xc8, 0x67, 0xb0, 0x11,
+	0x24, 0x63, 0xfb, 0x8c, 0x7a, 0x7e, 0x12, 0x31, 0x6e, 0xc7, 0xa1, 0x8d, 0x94, 0x66, 0x25, 0x65,
+	0x25, 0x41, 0x32, 0xde, 0x53, 0xf8, 0x7e, 0xd8, 0x14, 0x58, 0x21, 0x98, 0x83, 0x64, 0x68, 0x3b,
+	0xe1, 0x78, 0x12, 0x06, 0x2c, 0x88, 0xcd, 0x2a, 0x9e, 0x71, 0x65, 0x90, 0x0c, 0x77, 0x35, 0x8c,
+	0xdc, 0x05, 0xc3, 0x09, 0x5d, 0x66, 0x73, 0x46, 0x23, 0x67, 0x64, 0x4f, 0x68, 0x3c, 0x32, 0x6b,
+	0x28, 0x2f, 0x35, 0x01, 0xef, 0x21, 0xb8, 0x4b, 0xe3, 0x11, 0xf9, 0x00, 0xc4, 0x20, 0xb6, 0xdc,
+	0x22, 0x6e, 0x47, 0xcc, 0x11, 0x7d, 0xae, 0x60, 0x9f, 0x46, 0x90, 0x8c, 0xe5, 0x4e, 0x72, 0x0b,
+	0xe1, 0xe4, 0x3d, 0x58, 0x4d, 0xb8, 0x3a, 0xab, 0x31, 0x8b, 0xa9, 0x4b, 0x63, 0x6a, 0x1a, 0x28,
+	0x18, 0x2b, 0x09, 0xc7, 0x73, 0x3a, 0x52, 0x60, 0xf2, 0x25, 0x6c, 0xc9, 0xed, 0x19, 0x53, 0xcf,
+	0xc7, 0xd5, 0xb9, 0x6e, 0xc4, 0x38, 0x67, 0xdc, 0x5c, 0x15, 0x53, 0xc1, 0x15, 0xae, 0x23, 0xc9,
+	0x11, 0xf5, 0xfc, 0x7e, 0xd8, 0xd4, 0x78, 0xf2, 0x31, 0x90, 0x0c, 0x2b, 0x4f, 0x06, 0xbf, 0x62,
+	0x4e, 0x6c, 0x92, 0x94, 0xcb, 0x48, 0xb9, 0x7a, 0x12, 0x47, 0xbe, 0x81, 0xed, 0x0c, 0x87, 0xda,
+	0x53, 0x7b, 0xcc, 0x38, 0xa7, 0x43, 0x66, 0xae, 0xa5, 0x9c, 0x5b, 0x29, 0xa7, 0xda, 0xd7, 0x23,
+	0x49, 0x42, 0x3e, 0x81, 0xf5, 0x4c, 0x07, 0x2e, 0x13, 0x7b, 0x9c, 0x44, 0xbe, 0xb9, 0x9e, 0xb2,
+	0xae, 0xa6, 0xac, 0x2d, 0x81, 0x3d, 0x8d, 0x7c, 0x72, 0x08, 0x77, 0xc6, 0x5e, 0x60, 0x33, 0x9f,
+	0x4e, 0x38, 0x73, 0xed, 0xb1, 0x17, 0x24, 0x31, 0xe3, 0xf6, 0x80, 0xc5, 0xe7, 0x8c, 0x05, 0xd8,
+	0x15, 0x37, 0x37, 0xd2, 0xe3, 0xbc, 0x35, 0xf6, 0x82, 0xb6, 0xa4, 0x3d, 0x92, 0xa4, 0x3b, 0x92,
+	0x52, 0x74, 0xca, 0x49, 0x03, 0xd6, 0x58, 0x40, 0x07, 0x3e, 0xb3, 0xcf, 0x7c, 0xfa, 0xfc, 0x52,
+	0x69, 0x62, 0x73, 0x0b, 0xb7, 0x77, 0x55, 0xa2, 0xf6, 0x04, 0xa6, 0x87, 0x08, 0x71, 0x77, 0x5c,
+	0x8f, 0x23, 0xc3, 0x98, 0x45, 0x43, 0xe6, 0x6a, 0x8e, 0x87, 0xc8, 0xb1, 0xa6, 0x90, 0x47, 0x88,
+	0x9b, 0xf2, 0x88, 0x03, 0x7c, 0x9e, 0x0c, 0x58, 0x14, 0x30, 0x31, 0x59, 0xc7, 0xf7, 0xc4, 0x89,
+	0x9b, 0x92, 0x27, 0xe1, 0xec, 0x49, 0x8a, 0xdb, 0x45, 0x14, 0x79, 0x00, 0xa6, 0x1e, 0x67, 0x12,
+	0x85, 0xe7, 0xbf, 0x0a, 0x07, 0x36, 0x0d, 0xa8, 0x7f, 0xc9, 0x3d, 0x6e, 0xfe, 0x0c, 0xd9, 0x36,
+	0x15, 0xbe, 0x2b, 0xd1, 0x4d, 0x85, 0x15, 0x9a, 0xde, 0xe3, 0x36, 0xbb, 0x88, 0x59, 0x14, 0x50,
+	0xdf, 0xbc, 0x81, 0xc4, 0xe0, 0xf1, 0xb6, 0x82, 0x90, 0x2f, 0xc1, 0x40, 0x59, 0x42, 0xfd, 0xa1,
+	0x94, 0xf8, 0xf6, 0xed, 0xdc, 0xdd, 0xf2, 0xfd, 0x95, 0x2b, 0xf6, 0xc4, 0xaa, 0xc5, 0xb3, 0x76,
+	0xe8, 0x13, 0xa8, 0x06, 0x19, 0xdd, 0xcb, 0xcd, 0x9b, 0xa8, 0x05, 0xaa, 0x8d, 0xac, 0x46, 0xb6,
+	0x66, 0x69, 0x48, 0x1b, 0x8c, 0x49, 0xe4, 0x09, 0x8d, 0x3c, 0xbd, 0xfb, 0xb7, 0xf0, 0xee, 0x6f,
+	0x67, 0xee, 0x7e, 0x57, 0x92, 0xa4, 0x57, 0x7f, 0x65, 0x32, 0x0b, 0xc8, 0x9c, 0x94, 0xbe, 0x09,
+	0xa3, 0xd0, 0xe5, 0xe6, 0x4f, 0xb2, 0x27, 0xa5, 0xee, 0x82, 0x40, 0x90, 0x96, 0x5a, 0x26, 0x0d,
+	0x82, 0x30, 0x56, 0xd3, 0x7d, 0x03, 0xa7, 0x7b, 0xe3, 0x8a, 0x9a, 0x6c, 0xa6, 0x14, 0x52, 0x57,
+	0x4e, 0xdb, 0x9c, 0x3c, 0x80, 0x1b, 0x63, 0x7a, 0x31, 0x33, 0xa4, 0x3d, 0x61, 0x11, 0x02, 0xcc,
+	0xdb, 0x78, 0x63, 0x37, 0xc6, 0xf4, 0x22, 0x33, 0x70, 0x97, 0x45, 0xa2, 0x45, 0xf6, 0x61, 0x63,
+	0xe6, 0xca, 0xda, 0xe1, 0x44, 0x4e, 0xa2, 0x8e, 0x93, 0x90, 0xba, 0x5a, 0x5f, 0xdc, 0x13, 0x89,
+	0xb3, 0xd6, 0xe2, 0xeb, 0x40, 0xa1, 0x58, 0xb0, 0xa7, 0x98, 0x0e, 0x85, 0x56, 0x11, 0xc7, 0x68,
+	0xbe, 0x29, 0x15, 0x8b, 0x80, 0xf7, 0xe9, 0xb0, 0x2b, 0xa1, 0xe2, 0x68, 0x69, 0x12, 0x87, 0xb6,
+	0xb8, 0x48, 0x7a, 0xb8, 0x9f, 0xaa, 0xa3, 0x6d, 0x26, 0x71, 0xb8, 0x93, 0x0c, 0xf5, 0x48, 0x35,
+	0x3a, 0xd3, 0x26, 0x9f, 0xc0, 0x66, 0xba, 0xd0, 0x28, 0x09, 0x62, 0x6f, 0xcc, 0x94, 0x56, 0x7d,
+	0x0b, 0x57, 0xb9, 0xa6, 0x56, 0x69, 0x49, 0x9c, 0x54, 0xa7, 0x0f, 0xe1, 0xa6, 0x50, 0x64, 0x13,
+	0x2a, 0x34, 0x88, 0x50, 0x37, 0x5a, 0x66, 0xa5, 0x52, 0x7d, 0x1b, 0x39, 0xb7, 0x82, 0x64, 0xdc,
+	0x45, 0x8a, 0x7e, 0xd8, 0x92, 0x78, 0xa9, 0x55, 0xdf, 0x07, 0x22, 0xec, 0xb2, 0x98, 0x2d, 0xb7,
+	0x07, 0x4a, 0x3a, 0xcc, 0x77, 0xa4, 0x66, 0x13, 0x98, 0x9d, 0x64, 0xc8, 0x77, 0xa4, 0x04, 0x90,
+	0x0e, 0x6c, 0x66, 0x0e, 0x41, 0xbb, 0x08, 0x1e, 0xe3, 0xe6, 0xbb, 0xb8, 0x9f, 0x6b, 0x99, 0x43,
+	0x7d, 0xc2, 0x2e, 0xbf, 0xa3, 0x7e, 0xc2, 0xac, 0xf5, 0x38, 0x3d, 0x97, 0x6e, 0xca, 0x20, 0x6e,
+	0xc8, 0x90, 0xc6, 0x23, 0x16, 0xe1, 0xc8, 0xe6, 0x7b, 0xf2, 0x86, 0x48, 0x90, 0x18, 0x52, 0x68,
+	0x5c, 0x3e, 0x0a, 0xa3, 0xd8, 0x46, 0xdf, 0x61, 0xcc, 0xe2, 0xc8, 0x73, 0xcc, 0xf7, 0x71, 0xc7,
+	0x57, 0x10, 0xd1, 0x67, 0x17, 0xa2, 0xdb, 0xc8, 0x73, 0x84, 0x80, 0xcc, 0x2c, 0x62, 0x46, 0x38,
+	0x3f, 0xc4, 0xae, 0x37, 0xa6, 0x6b, 0xc9, 0x0a, 0xe8, 0x67, 0xb0, 0x95, 0x5d, 0xd1, 0x98, 0xc6,
+	0xce, 0xc8, 0x8e, 0xd8, 0x90, 0x5d, 0x98, 0x0d, 0x1c, 0x2b, 0x33, 0xfb, 0x23, 0x81, 0xb4, 0x04,
+	0x8e, 0x7c, 0x09, 0x37, 0xb2, 0x6c, 0x49, 0x90, 0x65, 0x7c, 0x84, 0x8c, 0x9b, 0x53, 0xc6, 0x53,
+	0x89, 0x96, 0xac, 0xf7, 0xa4, 0x22, 0x3a, 0x4b, 0x7c, 0x5f, 0xb3, 0x0b, 0x25, 0xc0, 0xcd, 0x8f,
+	0x70, 0x9e, 0x24, 0xe1, 0x6c, 0x2f, 0xf1, 0x7d, 0xc9, 0x29, 0xae, 0x3d, 0x27, 0x3f, 0x87, 0xb7,
+	0xae, 0x59, 0x6e, 0xa5, 0x34, 0x92, 0x08, 0xef, 0x88, 0x2d, 0x1c, 0x5c, 0x66, 0xde, 0xc3, 0x91,
+	0xeb, 0x57, 0x0d, 0xf6, 0x6e, 0x96, 0x14, 0x0f, 0x45, 0xb8, 0x12, 0xd2, 0x6c, 0xdb, 0x3c, 0x4c,
+	0x22, 0x87, 0x99, 0xf7, 0x51, 0x42, 0xb3, 0xae, 0x84, 0xb4, 0xd9, 0x3d, 0x44, 0x5b, 0x95, 0x28,
+	0xd3, 0x22, 0xbb, 0x70, 0xe3, 0xaa, 0x67, 0x6d, 0x47, 0x89, 0x2f, 0xcc, 0x6e, 0x6c, 0x7e, 0x82,
+	0x3d, 0x95, 0x1a, 0x56, 0xe2, 0xb3, 0x1e, 0x8b, 0xad, 0x4d, 0x49, 0xda, 0xd6, 0x94, 0x0a, 0x2e,
+	0xb6, 0x3e, 0x62, 0x54, 0xea, 0x6e, 0x66, 0x9f, 0x45, 0xe1, 0xd8, 0xe6, 0x71, 0x18, 0x09, 0xb3,
+	0xf5, 0x29, 0x6e, 0xc5, 0xba, 0x40, 0x0b, 0xf5, 0xcd, 0xf6, 0xa2, 0x70, 0xdc, 0x93, 0x38, 0x61,
+	0xb7, 0x95, 0xe3, 0x14, 0xfa, 0x6e, 0xea, 0xef, 0x7d, 0x86, 0x1c, 0x86, 0xc4, 0x9c, 0xf8, 0xae,
+	0x76, 0xf9, 0x84, 0x22, 0x96, 0xd4, 0xfc, 0xb9, 0x37, 0x31, 0x3f, 0x57, 0x8a, 0x18, 0x41, 0xbd,
+	0xe7, 0xde, 0x84, 0x7c, 0x0e, 0x5b, 0xd2, 0x4b, 0x0e, 0x5f, 0xb0, 0x28, 0xf2, 0x84, 0xeb, 0x10,
+	0x47, 0x67, 0xe2, 0x76, 0x99, 0x5f, 0xe0, 0x6e, 0x6e, 0x20, 0xfa, 0x44, 0x61, 0x7b, 0x0a, 0x29,
+	0xbc, 0x91, 0x84, 0xb3, 0x68, 0xea, 0x26, 0x3f, 0x90, 0x6e, 0xb2, 0x00, 0x6a, 0x37, 0x99, 0x7c,
+	0x0e, 0x2b, 0x0e, 0xf3, 0xfd, 0xec, 0x45, 0xf9, 0x46, 0x29, 0xeb, 0x5d, 0xe6, 0xfb, 0x9a, 0xce,
+	0xaa, 0x39, 0xd3, 0x96, 0xb8, 0x1c, 0x4f, 0xf4, 0x3d, 0xa3, 0x01, 0x1d, 0x62, 0x28, 0x60, 0xb3,
+	0x8b, 0x49, 0x18, 0xc5, 0xe6, 0xb7, 0xb8, 0xb9, 0x1b, 0x52, 0x6f, 0xa5, 0xd8, 0x36, 0x22, 0x95,
+	0xac, 0x5e, 0x81, 0x92, 0x63, 0x25, 0xe2, 0x68, 0x6a, 0x02, 0x11, 0x68, 0xf8, 0xde, 0xaf, 0x51,
+	0x14, 0xcc, 0x26, 0xf6, 0xb6, 0x99, 0x5a, 0x9c, 0xe3, 0x2c, 0xd6, 0xda, 0x88, 0xe7, 0x81, 0x85,
+	0x55, 0x3c, 0x13, 0x5b, 0x3f, 0xa1, 0x11, 0x1d, 0xb3, 0x98, 0x45, 0xde, 0xaf, 0x99, 0x8b, 0x57,
+	0x8e, 0x9b, 0x3b, 0xd2, 0x2a, 0x0a, 0x7c, 0x37, 0x8b, 0x46, 0x47, 0x98, 0xdc, 0x80, 0x92, 0x50,
+	0x6f, 0x51, 0x78, 0xce, 0xcd, 0x5d, 0x54, 0x4b, 0x4b, 0x63, 0x7a, 0x61, 0x85, 0xe7, 0x9c, 0xbc,
+	0x03, 0x2b, 0x63, 0x2f, 0x8a, 0xc2, 0x48, 0x39, 0xf9, 0x8c, 0x9b, 0x2d, 0x74, 0x84, 0x6b, 0x12,
+	0xdc, 0x55, 0x50, 0xf2, 0x01, 0x94, 0x27, 0xc9, 0xc0, 0xf7, 0x1c, 0x7b, 0x18, 0x79, 0xae, 0xd9,
+	0xc6, 0x15, 0x94, 0x1b, 0x5d, 0x84, 0x3d, 0x8e, 0x3c, 0xd7, 0x82, 0x49, 0xfa, 0x9f, 0xbc, 0x07,
+	0x10, 0x31, 0x97, 0x3a, 0x52, 0x0b, 0xef, 0xe1, 0xde, 0x43, 0xc3, 0xd2, 0x20, 0x2b, 0x83, 0x15,
+	0x53, 0x48, 0x26, 0xae, 0x90, 0x45, 0x2f, 0x88, 0x59, 0xf4, 0x82, 0xfa, 0xe6, 0x63, 0xa9, 0xe0,
+	0x25, 0xb8, 0xa3, 0xa0, 0x22, 0x2a, 0x9b, 0xd0, 0x84, 0x33, 0xd7, 0xdc, 0xc7, 0xe5, 0xaa, 0x96,
+	0x90, 0x4c, 0xe1, 0x5d, 0x7a, 0x2f, 0x98, 0x4d, 0xcf, 0x62, 0x16, 0xd9, 0x22, 0xfa, 0x30, 0x3b,
+	0xd2, 0xa3, 0x54, 0x98, 0xa6, 0x40, 0xb4, 0xe8, 0x25, 0x06, 0x23, 0x9a, 0x5a, 0xc5, 0x35, 0x07,
+	0x38, 0x5a, 0x55, 0x41, 0x55, 0x6c, 0xf3, 0x35, 0x6c, 0x6b, 0x32, 0xed, 0xaa, 0x66, 0x3a, 0x77,
+	0xa4, 0x72, 0x57, 0x14, 0xca, 0x65, 0x9d, 0x8e, 0xf1, 0x00, 0x0c, 0x8f, 0xf3, 0x84, 0x61, 0xe8,
+	0x85, 0x37, 0x94, 0x9b, 0x4f, 0x70, 0x13, 0x6a, 0x8d, 0x8e, 0x40, 0x88, 0xf8, 0x4b, 0xdc, 0x47,
+	0xab, 0xe6, 0x65, 0x9b, 0x5c, 0x28, 0x38, 0xc7, 0x67, 0x34, 0xb2, 0x11, 0xae, 0xc7, 0x94, 0x36,
+	0xc6, 0x3c, 0xc4, 0x51, 0x37, 0x91, 0x00, 0xbb, 0x91, 0x43, 0x4a, 0xfb, 0x82, 0x37, 0x2a, 0x0a,
+	0x9f, 0xb3, 0x40, 0x4d, 0xd8, 0x8e, 0x47, 0x11, 0xe3, 0xa3, 0xd0, 0x77, 0xcd, 0xa3, 0xdb, 0xb9,
+	0xbb, 0x79, 0x6b, 0x43, 0xa2, 0xe5, 0x6c, 0xfb, 0x1a, 0x29, 0xf6, 0x5f, 0x31, 0xa4, 0x0e, 0xf6,
+	0xb1, 0x14, 0x01, 0x09, 0x4e, 0xfd, 0xeb, 0x07, 0x50, 0x16, 0x97, 0x95, 0xfa, 0xbe, 0x10, 0x25,
+	0xf3, 0xe4, 0x9a, 0xe6, 0xea, 0x5d, 0x06, 0xf1, 0x88, 0xc5, 0x9e, 0x63, 0x85, 0xe7, 0x16, 0x28,
+	0x5a, 0x2b, 0x3c, 0x27, 0x1f, 0xc3, 0xd2, 0x24, 0x74, 0x91, 0xab, 0xfb, 0x6a, 0xae, 0xc5, 0x49,
+	0xe8, 0x0a, 0x8e, 0x37, 0xa1, 0x2a, 0xf5, 0xd3, 0x0b, 0x16, 0x71, 0x71, 0x65, 0x7e, 0x2e, 0x83,
+	0x0e, 0x04, 0x7e, 0x27, 0x61, 0xc2, 0xcb, 0x71, 0xb5, 0x22, 0x1e, 0x24, 0xee, 0x90, 0xc5, 0xdc,
+	0xb4, 0xae, 0x79, 0x39, 0x2d, 0x45, 0xb2, 0x83, 0x14, 0xd6, 0x8a, 0x3b, 0xd3, 0xe6, 0xe4, 0x67,
+	0x50, 0xd3, 0xde, 0x2c, 0x6a, 0x59, 0x6e, 0xf6, 0xae, 0x85, 0x77, 0xca, 0xa5, 0x95, 0x3a, 0xb9,
+	0x3a, 0xce, 0xb4, 0x50, 0xd5, 0x49, 0x46, 0xbc, 0xe9, 0x66, 0x5f, 0x66, 0x17, 0x24, 0x48, 0xdc,
+	0x62, 0x41, 0xe0, 0x84, 0xe3, 0xb1, 0x17, 0xdb, 0x11, 0x9b, 0x84, 0xe6, 0xa9, 0x24, 0x90, 0x20,
+	0x8b, 0x4d, 0x42, 0xf2, 0x39, 0x94, 0x95, 0x2e, 0x8c, 0x44, 0x74, 0xf9, 0x1d, 0xfa, 0x87, 0x1b,
+	0x99, 0xe1, 0x77, 0x50, 0x15, 0x0a, 0xa4, 0x05, 0x83, 0xf4, 0x3f, 0xf9, 0x18, 0xd6, 0x33, 0x7c,
+	0xd3, 0xe3, 0x7b, 0x8a, 0x23, 0x90, 0x29, 0x65, 0x7a, 0x84, 0x6f, 0x41, 0x4d, 0xc4, 0xb4, 0x4e,
+	0xac, 0x85, 0xda, 0xfc, 0x85, 0x8c, 0xc4, 0x25, 0x54, 0x09, 0x32, 0xd9, 0x86, 0x92, 0x17, 0x8c,
+	0x58, 0xe4, 0xc5, 0xdc, 0x7c, 0x86, 0x9d, 0xa5, 0x6d, 0x21, 0x2e, 0xaa, 0x8b, 0x74, 0xbc, 0xef,
+	0xb1, 0x0f, 0xd5, 0x73, 0x3a, 0xd6, 0xe7, 0x50, 0x3e, 0x8f, 0xbc, 0x98, 0xd9, 0xc3, 0x84, 0x46,
+	0xae, 0xf9, 0x47, 0x19, 0x0d, 0x2a, 0x57, 0xf5, 0x54, 0x60, 0x1f, 0x0b, 0xa4, 0x05, 0xe7, 0xe9,
+	0x7f, 0x71, 0xf4, 0x4a, 0x1e, 0x23, 0x19, 0x6d, 0xff, 0xb1, 0xd4, 0xf0, 0x12, 0x68, 0xc9, 0xa0,
+	0xba, 0x0e, 0xd5, 0x80, 0x9d, 0x4b, 0x87, 0x03, 0x6f, 0xe4, 0x9f, 0xa0, 0x7c, 0x94, 0x03, 0x76,
+	0x2e, 0x06, 0xc0, 0x5b, 0xf8, 0x3e, 0xac, 0xc6, 0xe1, 0x78, 0xc0, 0xe3, 0x30, 0x48, 0x2f, 0xb1,
+	0xf9, 0xa7, 0x52, 0x2d, 0xa4, 0x08, 0xbd, 0xe4, 0x06, 0x54, 0x22, 0x86, 0xaa, 0x5a, 0x5e, 0x57,
+	0x1b, 0x65, 0xa0, 0xdc, 0xb0, 0x10, 0x88, 0x77, 0xb5, 0x1c, 0xa5, 0xff, 0xb1, 0x73, 0x45, 0xcf,
+	0xbd, 0xb1, 0xe7, 0xd3, 0xc8, 0x8b, 0x2f, 0xcd, 0x5f, 0xe2, 0x3d, 0x33, 0x24, 0xa2, 0x97, 0xc2,
+	0xc5, 0xb6, 0x2b, 0xe2, 0x31, 0x9d, 0x60, 0x0c, 0x40, 0xa5, 0xce, 0x91, 0xd0, 0x23, 0x09, 0x24,
+	0x3b, 0xb0, 0xa2, 0x9d, 0x03, 0x3a, 0x9e, 0xf8, 0x82, 0x6e, 0x80, 0xbb, 0x76, 0xe3, 0xba, 0x7b,
+	0xa0, 0x08, 0xac, 0x5a, 0x34, 0xd3, 0xde, 0xfe, 0x5d, 0x1e, 0x2a, 0xd9, 0x64, 0x04, 0x59, 0x87,
+	0x05, 0xcc, 0x5e, 0xa9, 0xc4, 0x8e, 0x6c, 0x88, 0x13, 0x4e, 0x2d, 0xa8, 0xcc, 0xeb, 0xa4, 0x6d,
+	0xf2, 0x11, 0xac, 0xcd, 0x73, 0x72, 0x0a, 0x52, 0xaa, 0x9c, 0xeb, 0x4e, 0x4d, 0x13, 0x20, 0x8e,
+	0x68, 0xc0, 0xcf, 0xc2, 0x68, 0xcc, 0xcd, 0x22, 0xee, 0xdc, 0x9d, 0x97, 0x24, 0x47, 0x1a, 0x7d,
+	0x4d, 0x69, 0x65, 0x98, 0xb6, 0xff, 0x31, 0x07, 0xcb, 0x29, 0x86, 0xbc, 0x25, 0xbc, 0xa4, 0x21,
+	0xbb, 0xb0, 0x1d, 0x3a, 0x89, 0x93, 0x48, 0x25, 0xa5, 0xf6, 0x5f, 0x13, 0xee, 0xd0, 0x90, 0x5d,
+	0xec, 0x4a, 0x28, 0x79, 0x1d, 0x4a, 0xa9, 0xd3, 0x90, 0x57, 0x14, 0x29, 0x44, 0x60, 0xe3, 0x28,
+	0x09, 0x1c, 0x1a, 0xcb, 0xb9, 0x2f, 0x08, 0xac, 0x86, 0x90, 0x37, 0xa1, 0x12, 0x85, 0x49, 0xe0,
+	0xda, 0xae, 0x37, 0x14, 0x62, 0x5e, 0x54, 0x14, 0x65, 0x84, 0xb6, 0x10, 0xb8, 0x53, 0x86, 0xe5,
+	0x74, 0x8e, 0xdb, 0x5c, 0x66, 0x26, 0xa7, 0x01, 0x12, 0xb9, 0x05, 0x30, 0x75, 0x95, 0xd5, 0xfe,
+	0x2e, 0xa7, 0x3e, 0xb2, 0x58, 0x85, 0xde, 0x53, 0xa9, 0x1a, 0xf4, 0x1c, 0x2b, 0x1a, 0x2c, 0xd4,
+	0xc3, 0xce, 0x4d, 0xb8, 0x31, 0xe3, 0x70, 0x63, 0x7a, 0x40, 0xe9, 0xa2, 0xed, 0xfb, 0x50, 0xd2,
+	0x0e, 0x3d, 0x31, 0xa0, 0xf0, 0x9c, 0xe9, 0x44, 0x9f, 0xf8, 0x2b, 0xce, 0x56, 0x9e, 0x8d, 0x3c,
+	0x42, 0xd9, 0xd8, 0xfe, 0xf7, 0x3c, 0x54, 0xb2, 0x4e, 0x24, 0xb9, 0x07, 0x95, 0x5f, 0x25, 0x81,
+	0x37, 0x93, 0xb5, 0x2c, 0xdf, 0xaf, 0x34, 0x0e, 0x4e, 0x03, 0x4f, 0x65, 0x2d, 0xc5, 0xca, 0x91,
+	0x46, 0x05, 0xbb, 0x5f, 0x69, 0x51, 0x74, 0x07, 0x9a, 0x6b, 0x41, 0xc5, 0x52, 0xb2, 0xeb, 0xd6,
+	0x4e, 0xca, 0x58, 0xd3, 0x94, 0x53, 0x5e, 0xc7, 0x8b, 0x1c, 0x9f, 0x39, 0x9e, 0xe6, 0x5d, 0x54,
+	0xbc, 0xbb, 0x08, 0xdf, 0xed, 0x4c, 0x79, 0x35, 0xa5, 0xe2, 0xfd, 0x14, 0xaa, 0x31, 0x7b, 0x1e,
+	0x87, 0x81, 0xe6, 0x5c, 0x42, 0xce, 0x6a, 0xa3, 0x8f, 0xd0, 0x94, 0xaf, 0x12, 0x67, 0xda, 0xa4,
+	0x01, 0x65, 0x1a, 0x0d, 0x43, 0xcd, 0x53, 0x52, 0xce, 0x49, 0x33, 0x1a, 0x86, 0x29, 0x07, 0xd0,
+	0xb4, 0xb5, 0xb3, 0x09, 0xeb, 0x33, 0x5e, 0xb8, 0x62, 0x3c, 0x28, 0x96, 0x72, 0x46, 0xfe, 0xa0,
+	0x58, 0x2a, 0x18, 0xc5, 0x83, 0x62, 0xa9, 0x68, 0x2c, 0x6c, 0xff, 0x2e, 0x07, 0x95, 0xac, 0x81,
+	0x22, 0x26, 0x2c, 0xa9, 0x38, 0x0f, 0x0f, 0xa2, 0x64, 0xe9, 0x66, 0x9a, 0x40, 0xcd, 0x67, 0x12,
+	0xa8, 0x0f, 0xa1, 0x34, 0x09, 0xb9, 0x87, 0x4e, 0x5f, 0x01, 0xd5, 0xfa, 0xed, 0x97, 0x58, 0xbe,
+	0x46, 0x57, 0xd1, 0x59, 0x29, 0x07, 0x46, 0xfd, 0x17, 0x8e, 0x9f, 0xb8, 0xca, 0x4d, 0x1f, 0x31,
+	0xea, 0xc7, 0x23, 0x95, 0x3c, 0x5d, 0x55, 0x28, 0xe1, 0xa3, 0xef, 0x23, 0xa2, 0xfe, 0x3e, 0x94,
+	0x74, 0x2f, 0x04, 0x60, 0xb1, 0x77, 0x62, 0xf5, 0xdb, 0x2d, 0xe3, 0x35, 0xb2, 0x04, 0x85, 0xfe,
+	0x49, 0xd7, 0xc8, 0x09, 0xe0, 0xce, 0x49, 0xbf, 0x7f, 0x72, 0x64, 0xe4, 0xb7, 0xcf, 0xa0, 0x36,
+	0x6b, 0x19, 0x85, 0x38, 0x4b, 0x05, 0x88, 0xd1, 0x94, 0x12, 0x67, 0xd4, 0x78, 0x18, 0x40, 0xbd,
+	0x01, 0x65, 0xe1, 0x45, 0xaa, 0x9c, 0x13, 0x2e, 0x33, 0x67, 0xc1, 0x98, 0x5e, 0xa8, 0xd4, 0x92,
+	0x90, 0x46, 0x9e, 0x78, 0xea, 0xb6, 0x95, 0x2c, 0xd9, 0xd8, 0xfe, 0xaf, 0x1c, 0x54, 0xb2, 0xe6,
+	0xf3, 0x0f, 0x49, 0x34, 0x3f, 0x05, 0x23, 0xcd, 0x24, 0x9c, 0x79, 0x7e, 0xcc, 0x22, 0x6e, 0x16,
+	0x50, 0xcd, 0x7c, 0xf0, 0x12, 0x23, 0xdd, 0xd0, 0x66, 0x68, 0x4f, 0x92, 0xb7, 0x83, 0x38, 0xba,
+	0xb4, 0x56, 0xc6, 0xb3, 0xd0, 0xed, 0x1d, 0x58, 0x9f, 0x47, 0xf8, 0x63, 0xaf, 0xda, 0x57, 0xf9,
+	0x07, 0xb9, 0xed, 0xdf, 0xe4, 0x00, 0xa6, 0xa6, 0x8c, 0x7c, 0x01, 0xa6, 0xd8, 0x26, 0x37, 0x0a,
+	0x27, 0x13, 0x86, 0x3e, 0x0f, 0x26, 0x4d, 0x30, 0xcb, 0x99, 0x93, 0x7e, 0xd8, 0x98, 0x5e, 0xb4,
+	0x24, 0x5a, 0xf8, 0xe0, 0x5d, 0x89, 0x24, 0x8f, 0xe0, 0x66, 0x96, 0x51, 0x7b, 0x9d, 0x9a, 0x37,
+	0x8f, 0xbc, 0xe6, 0x94, 0x57, 0x59, 0x2e, 0xc5, 0xbe, 0x3d, 0x84, 0xda, 0xac, 0x69, 0x20, 0x6f,
+	0xc3, 0x0a, 0x7b, 0xc1, 0xa2, 0x4b, 0x3b, 0x88, 0x47, 0x98, 0x52, 0x77, 0x71, 0x02, 0x0b, 0x56,
+	0x15, 0xc1, 0xc7, 0xf1, 0x08, 0xfd, 0x0a, 0xf2, 0x21, 0xac, 0x89, 0x81, 0x91, 0x42, 0x26, 0x78,
+	0x46, 0x61, 0x12, 0xe1, 0x80, 0x0b, 0x96, 0x31, 0xa6, 0x17, 0x48, 0x26, 0x06, 0xda, 0x0f, 0x93,
+	0xa8, 0x3e, 0x96, 0xaf, 0x06, 0x98, 0x54, 0x27, 0xdb, 0xb0, 0xd9, 0x6f, 0xf7, 0xfa, 0x3d, 0xfb,
+	0xb8, 0x79, 0xd4, 0xb6, 0x4f, 0x8f, 0x7b, 0xdd, 0xf6, 0x6e, 0x67, 0xaf, 0x83, 0x62, 0xb7, 0x01,
+	0xab, 0x19, 0x5c, 0xe7, 0xf1, 0xf1, 0x89, 0xd5, 0x36, 0x72, 0x64, 0x13, 0x48, 0x06, 0x6c, 0xb5,
+	0xbb, 0x87, 0xcd, 0xdd, 0xb6, 0x91, 0xbf, 0x42, 0xde, 0xec, 0x76, 0xdb, 0xc7, 0x2d, 0xa3, 0x50,
+	0xff, 0x8f, 0x1c, 0x18, 0x57, 0x73, 0xe3, 0x62, 0xd8, 0xbd, 0xe6, 0xe1, 0xe1, 0x4e, 0x73, 0xf7,
+	0x89, 0xfd, 0xd8, 0x3a, 0x39, 0xed, 0x76, 0x8e, 0x1f, 0xdb, 0xc7, 0x27, 0xc7, 0x6d, 0xe3, 0xb5,
+	0xf9, 0xb8, 0x56, 0xb3, 0x2f, 0xc6, 0x7e, 0x1d, 0xcc, 0xeb, 0xb8, 0xc3, 0xe6, 0x4e, 0xfb, 0xb0,
+	0x67, 0xe4, 0x89, 0x09, 0xeb, 0xd7, 0xb1, 0x9d, 0x96, 0x51, 0x20, 0x37, 0x61, 0xeb, 0x3a, 0x66,
+	0xe7, 0xb4, 0x73, 0xd8, 0x32, 0x8a, 0xe4, 0x5d, 0x78, 0xeb, 0x3a, 0x72, 0xf7, 0xe4, 0x78, 0xaf,
+	0xf3, 0xf8, 0xd4, 0x6a, 0xf6, 0x3b, 0x27, 0xc7, 0xf6, 0x77, 0xcd, 0xc3, 0xd3, 0xb6, 0xb1, 0x50,
+	0xdf, 0x87, 0x95, 0x2b, 0xb9, 0x3e, 0x72, 0x03, 0x36, 0xba, 0x56, 0xe7, 0xa8, 0x69, 0x3d, 0x9b,
+	0xb7, 0x92, 0x6b, 0x28, 0x39, 0x68, 0xae, 0xfe, 0x67, 0x00, 0x53, 0xaf, 0x90, 0x6c, 0xc1, 0x1a,
+	0x22, 0xec, 0x13, 0xab, 0xd5, 0xb6, 0xec, 0x5e, 0xbf, 0xa9, 0xae, 0xfe, 0x15, 0xc4, 0x71, 0xb3,
+	0x7f, 0x6a, 0x35, 0x0f, 0x8d, 0xdc, 0x55, 0xc4, 0x61, 0xfb, 0x17, 0x9d, 0xdd, 0xe6, 0xa1, 0xdc,
+	0x84, 0x2c, 0xe2, 0xa8, 0xdd, 0x6f, 0xb6, 0x9a, 0xfd, 0xa6, 0x51, 0x38, 0x28, 0x96, 0x96, 0x8c,
+	0xd2, 0x41, 0xb1, 0xb4, 0x69, 0x6c, 0x1d, 0x14, 0x4b, 0xaf, 0x1b, 0xb7, 0x0e, 0x8a, 0xa5, 0x3b,
+	0x46, 0xfd, 0xa0, 0x58, 0xba, 0x6b, 0xbc, 0x7b, 0x50, 0x2c, 0x7d, 0x60, 0x7c, 0x78, 0x50, 0x2c,
+	0x7d, 0x6c, 0xdc, 0x3b, 0x28, 0x96, 0xbe, 0x32, 0xbe, 0x3e, 0x28, 0x96, 0xbe, 0x36, 0x1e, 0xd6,
+	0xab, 0x50, 0xce, 0xd8, 0x99, 0xfa, 0x5f, 0x68, 0x31, 0xd5, 0x06, 0x44, 0x68, 0xd4, 0x49, 0x14,
+	0x62, 0xc2, 0x5d, 0xbd, 0x61, 0xa9, 0xa6, 0x08, 0xf8, 0x06, 0x89, 0xf3, 0x9c, 0xe9, 0x27, 0x3b,
+	0xd5, 0x12, 0x1c, 0x28, 0xac, 0x2c, 0x52, 0x4e, 0x89, 0x6e, 0x92, 0x3b, 0x50, 0x79, 0x41, 0x23,
+	0x8f, 0x06, 0xb1, 0xfd, 0x9c, 0x5d, 0x4a, 0x5f, 0x64, 0xd9, 0x2a, 0x2b, 0xd8, 0x13, 0x76, 0xc9,
+	0xeb, 0x43, 0xa8, 0xcd, 0x5a, 0x21, 0xc1, 0xa4, 0x46, 0xb4, 0xb9, 0x9f, 0x0c, 0xd5, 0x2c, 0xca,
+	0x0a, 0xd6, 0xf3, 0x13, 0x21, 0x6f, 0xa5, 0xf3, 0x30, 0x7a, 0x7e, 0xe6, 0x87, 0xe7, 0xda, 0x5d,
+	0xd2, 0x6d, 0x9c, 0x65, 0x44, 0x03, 0x67, 0xa4, 0x26, 0xa3, 0x5a, 0x75, 0x0e, 0x95, 0xac, 0xd1,
+	0x22, 0xaf, 0x03, 0x2a, 0x53, 0x3e, 0xa1, 0x0e, 0xcb, 0x6a, 0x57, 0x04, 0xa0, 0x43, 0xe6, 0x4d,
+	0x98, 0xef, 0x05, 0x2c, 0x75, 0xc8, 0x54, 0x9b, 0xbc, 0x0b, 0x06, 0x8d, 0x62, 0xef, 0x8c, 0x3a,
+	0x71, 0xaa, 0x23, 0xe5, 0x58, 0x2b, 0x29, 0x5c, 0x6a, 0xca, 0xfa, 0x9f, 0x03, 0x4c, 0xad, 0xde,
+	0x0f, 0x0c, 0xf9, 0x3e, 0xac, 0xea, 0x45, 0xd8, 0x31, 0x1b, 0x4f, 0x7c, 0xe1, 0x29, 0xc9, 0xb1,
+	0x0d, 0x8d, 0xe8, 0x2b, 0xb8, 0x70, 0x61, 0xa5, 0x0f, 0xa1, 0x47, 0x54, 0x33, 0xa8, 0x22, 0xb4,
+	0xa9, 0x80, 0xf5, 0x5d, 0x58, 0x4e, 0xa3, 0x7c, 0xa1, 0x33, 0xb3, 0xb6, 0x44, 0x36, 0xc8, 0x6d,
+	0x28, 0x47, 0x6c, 0xe2, 0x53, 0x07, 0x93, 0x25, 0xfa, 0x61, 0x32, 0x03, 0xaa, 0xb7, 0x00, 0xa6,
+	0x6e, 0xf7, 0x1f, 0xdc, 0xcb, 0x17, 0x50, 0x9d, 0x89, 0xb5, 0x5f, 0xd2, 0x91, 0x01, 0x85, 0x24,
+	0xf2, 0x55, 0x07, 0xe2, 0x6f, 0xbd, 0x03, 0x30, 0x4d, 0x6b, 0x60, 0xd6, 0x41, 0x6e, 0xb9, 0x7a,
+	0x0b, 0x96, 0x2d, 0x0c, 0x53, 0xa8, 0x33, 0x42, 0xdf, 0x21, 0x8e, 0x42, 0xdd, 0x43, 0x05, 0x81,
+	0xbb, 0x12, 0x56, 0xff, 0x97, 0x1c, 0x6c, 0xcc, 0x4d, 0xf2, 0x90, 0xfb, 0xb0, 0xa1, 0x26, 0x6b,
+	0xbb, 0x61, 0x32, 0xf0, 0x31, 0x42, 0x09, 0x03, 0xae, 0xbc, 0x8a, 0x35, 0x85, 0x6c, 0x21, 0x6e,
+	0x17, 0x51, 0x82, 0xc7, 0x09, 0x7d, 0x7c, 0xcf, 0xb1, 0x1d, 0x9f, 0xf2, 0x19, 0x83, 0x59, 0xb2,
+	0xd6, 0x34, 0x72, 0x57, 0xe0, 0x94, 0xe9, 0x7c, 0x17, 0x0c, 0x11, 0x97, 0x4d, 0xa6, 0x69, 0x23,
+	0xae, 0xec, 0x33, 0x86, 0x71, 0x93, 0x34, 0x5d, 0xc4, 0xeb, 0x7f, 0x97, 0x83, 0x4a, 0x36, 0x3d,
+	0x36, 0xd7, 0x52, 0xbf, 0x2a, 0x70, 0x78, 0x1b, 0x8a, 0xf1, 0xe5, 0x84, 0x29, 0x4f, 0x87, 0xcc,
+	0xe4, 0xda, 0x1a, 0xfd, 0xcb, 0x09, 0xb3, 0x10, 0x5f, 0xff, 0x18, 0x8a, 0xa2, 0x85, 0x3e, 0x4a,
+	0xdf, 0xea, 0x1c, 0x3f, 0x96, 0x3e, 0x4a, 0xe7, 0xb8, 0x6f, 0xe4, 0xc8, 0x32, 0x2c, 0xec, 0x1d,
+	0x9e, 0x34, 0xfb, 0x46, 0x9e, 0x94, 0xa0, 0xb8, 0x73, 0x72, 0x72, 0x68, 0x14, 0xea, 0xbf, 0xc9,
+	0xc3, 0xfa, 0xbc, 0xd4, 0x1b, 0xf9, 0x14, 0x16, 0xf9, 0x25, 0x8f, 0xd9, 0x18, 0x27, 0x59, 0xbb,
+	0xff, 0xfa, 0xdc, 0x0c, 0x5d, 0xa3, 0x87, 0x34, 0x96, 0xa2, 0xbd, 0x7e, 0xe6, 0x59, 0x25, 0x54,
+	0x98, 0x55, 0x42, 0x1f, 0x00, 0xc1, 0x28, 0xd3, 0xa1, 0x9c, 0x4d, 0xb3, 0x8e, 0xf2, 0xe5, 0x1e,
+	0x9f, 0x26, 0x76, 0x29, 0x67, 0xe9, 0x96, 0xdd, 0x02, 0x88, 0x31, 0x07, 0x73, 0xe6, 0xf9, 0x4c,
+	0x3d, 0xe1, 0x2f, 0x23, 0x64, 0xcf, 0xf3, 0x59, 0xfd, 0x11, 0x2c, 0xca, 0xa9, 0x08, 0x2b, 0xd8,
+	0x7b, 0xd6, 0xeb, 0xb7, 0x8f, 0xae, 0x18, 0xcd, 0x2a, 0x2c, 0x1f, 0x74, 0xac, 0xa6, 0xfd, 0x0b,
+	0xab, 0xf9, 0xcc, 0xc8, 0x91, 0x0a, 0x94, 0xba, 0x27, 0x87, 0x4d, 0xab, 0x73, 0x72, 0x6c, 0xe4,
+	0xeb, 0xbf, 0xcd, 0xc1, 0xda, 0x9c, 0x97, 0x13, 0x61, 0xe9, 0xa7, 0xa9, 0xc6, 0xac, 0x8c, 0x57,
+	0x75, 0x2a, 0x51, 0xba, 0x70, 0xd7, 0x9e, 0x72, 0xf3, 0x73, 0x9e, 0x72, 0xd7, 0x61, 0x21, 0x3c,
+	0x0f, 0x52, 0xdd, 0x2a, 0x1b, 0xa4, 0x06, 0x79, 0xc7, 0x51, 0xfa, 0x34, 0xef, 0x38, 0xa2, 0x2b,
+	0x1d, 0xaa, 0xc8, 0x01, 0x55, 0xb9, 0x82, 0x02, 0xe2, 0x78, 0xf5, 0xbf, 0x5c, 0x84, 0xda, 0xec,
+	0xd3, 0x0b, 0xf9, 0x14, 0x36, 0x07, 0x2c, 0xa6, 0x36, 0x4d, 0xe2, 0x70, 0x76, 0x2e, 0x80, 0x73,
+	0x59, 0x17, 0xd8, 0xa6, 0x44, 0x4e, 0xe7, 0x74, 0x0b, 0x00, 0xdf, 0x76, 0x1c, 0x3f, 0xe4, 0xda,
+	0xf1, 0x5e, 0x16, 0x90, 0x5d, 0x01, 0x10, 0xae, 0xe9, 0x28, 0x8c, 0x7d, 0x8f, 0xc7, 0xb6, 0xe7,
+	0x0a, 0xd7, 0xb4, 0x70, 0xb7, 0x60, 0x81, 0x02, 0x75, 0x5c, 0x31, 0x6a, 0x69, 0x12, 0x79, 0x21,
+	0x06, 0xe9, 0x52, 0x3a, 0xcd, 0x2b, 0x6f, 0x42, 0x8d, 0xae, 0xc2, 0x5b, 0x29, 0x25, 0x79, 0x02,
+	0x5b, 0x99, 0x6e, 0x55, 0xaa, 0x5c, 0xa6, 0xed, 0x8b, 0xea, 0x1d, 0x6b, 0x5f, 0x8f, 0x81, 0xa9,
+	0x72, 0x99, 0x1f, 0x5a, 0x9f, 0x0e, 0x3c, 0x85, 0x92, 0x77, 0x60, 0x45, 0xc8, 0x84, 0xed, 0x05,
+	0xae, 0xf7, 0xc2, 0x73, 0x13, 0xea, 0xab, 0x02, 0x87, 0x9a, 0x00, 0x77, 0x52, 0xa8, 0x50, 0xcb,
+	0xdc, 0x0b, 0x86, 0x3e, 0x13, 0x51, 0x90, 0xda, 0x26, 0x0c, 0xa0, 0x4a, 0x96, 0x91, 0x22, 0xd4,
+	0x0e, 0x69, 0xa7, 0x91, 0xfa, 0x7e, 0x78, 0xce, 0xdc, 0x4c, 0xe7, 0xf2, 0x79, 0x67, 0x09, 0xf7,
+	0x54, 0x38, 0x8d, 0x4d, 0x49, 0x31, 0x1d, 0x07, 0x1f, 0x7b, 0xee, 0x40, 0x05, 0x27, 0xa5, 0x72,
+	0x75, 0x18, 0x39, 0x95, 0xac, 0xb2, 0x80, 0x9d, 0x48, 0x10, 0x79, 0x0a, 0x1b, 0x2e, 0x3b, 0xa3,
+	0x22, 0x58, 0x9a, 0x7d, 0x85, 0x5f, 0xc6, 0x28, 0xeb, 0xcd, 0xab, 0xfb, 0xd8, 0x92, 0xc4, 0x59,
+	0x31, 0xb5, 0xd6, 0xdc, 0xeb, 0x40, 0x21, 0x09, 0xd4, 0x7d, 0x41, 0x03, 0x47, 0x65, 0xb1, 0xa7,
+	0x3d, 0x97, 0xe5, 0x33, 0x84, 0xc6, 0x66, 0xb9, 0xb6, 0x7f, 0x09, 0x6b, 0x73, 0x46, 0xb8, 0x2e,
+	0xd9, 0xb9, 0x57, 0x49, 0x76, 0xfe, 0xba, 0x64, 0x4b, 0x61, 0xcf, 0x3b, 0x4e, 0xfd, 0x10, 0x4a,
+	0x5a, 0x16, 0x84, 0x33, 0xd4, 0xb5, 0x3a, 0x27, 0x56, 0xa7, 0xff, 0xec, 0xca, 0x3d, 0x5d, 0x84,
+	0x7c, 0xf7, 0x63, 0x23, 0x87, 0xbf, 0xf7, 0x8c, 0x3c, 0xfe, 0xde, 0x37, 0x0a, 0xf8, 0xfb, 0x89,
+	0x51, 0xc4, 0xdf, 0x4f, 0x8d, 0x85, 0xfa, 0xf7, 0xb0, 0x36, 0x47, 0x46, 0xc8, 0xa6, 0x0e, 0x27,
+	0xc4, 0x3c, 0x0b, 0xfb, 0xaf, 0xa9, 0x80, 0x42, 0xc0, 0x65, 0xb6, 0x46, 0xe7, 0x0a, 0x64, 0x73,
+	0x67, 0x0d, 0x56, 0xa7, 0xa2, 0xa8, 0x84, 0xb0, 0xfe, 0xd7, 0x0b, 0xb0, 0xdc, 0xa2, 0x7c, 0x34,
+	0x08, 0x45, 0xe0, 0x71, 0x1f, 0xaa, 0xae, 0x6e, 0xd8, 0x31, 0x1d, 0xa8, 0x3a, 0xa9, 0x6a, 0x23,
+	0x25, 0xe9, 0xd3, 0x81, 0x55, 0x71, 0x33, 0xad, 0xb9, 0x31, 0xeb, 0xb5, 0x77, 0xee, 0xc2, 0x8f,
+	0x78, 0xe7, 0x7e, 0x03, 0xca, 0xa9, 0x94, 0xd0, 0x81, 0x52, 0x06, 0xa0, 0x8f, 0x9d, 0x0e, 0xb0,
+	0x76, 0x20, 0x3c, 0x0f, 0x26, 0x3e, 0xbd, 0xc4, 0x6a, 0x09, 0x2f, 0x18, 0x0a, 0x4a, 0xae, 0x44,
+	0x6e, 0x4d, 0x23, 0xf7, 0x24, 0xae, 0x4f, 0x07, 0x9c, 0x3c, 0x80, 0xcd, 0x91, 0x37, 0x1c, 0xf9,
+	0xde, 0x70, 0x14, 0xcf, 0x32, 0xe1, 0x75, 0x90, 0xf5, 0x1c, 0x29, 0x45, 0x96, 0xf3, 0x1d, 0x58,
+	0x99, 0x72, 0xc6, 0xa1, 0x4b, 0x2f, 0xf1, 0x2a, 0x94, 0xac, 0x5a, 0x0a, 0xee, 0x0b, 0x28, 0x39,
+	0x80, 0x8d, 0xec, 0x42, 0x6c, 0xee, 0x8c, 0x98, 0x9b, 0xf8, 0x4c, 0x49, 0xf7, 0xc6, 0xcc, 0xa2,
+	0x7b, 0x0a, 0x69, 0xad, 0x07, 0x73, 0xa0, 0xf3, 0xde, 0x52, 0x60, 0xee, 0x5b, 0xca, 0x4d, 0x58,
+	0xc6, 0x27, 0xe6, 0x5f, 0x87, 0x01, 0x43, 0x61, 0x5f, 0xb6, 0x4a, 0x02, 0xf0, 0x7d, 0x18, 0xa0,
+	0x2e, 0xc3, 0xc7, 0x10, 0x55, 0xac, 0x56, 0x51, 0x3b, 0x49, 0x63, 0x55, 0xac, 0x86, 0xc5, 0x63,
+	0x8c, 0x8e, 0xb1, 0x0c, 0x67, 0xd9, 0xc2, 0xff, 0xe4, 0x01, 0xac, 0xb8, 0x1e, 0xc7, 0xcd, 0xd5,
+	0x4f, 0xdf, 0x35, 0x95, 0x72, 0x69, 0x49, 0x78, 0xfa, 0xf4, 0xed, 0xce, 0xb4, 0xc9, 0x97, 0x50,
+	0x55, 0xc9, 0x69, 0x55, 0xcb, 0xb1, 0x82, 0x7c, 0xeb, 0x8d, 0x5d, 0x84, 0xca, 0x2a, 0x0e, 0xcd,
+	0x5c, 0x71, 0x32, 0x40, 0x99, 0x21, 0xa9, 0x53, 0x58, 0x9b, 0x43, 0x2a, 0x66, 0x89, 0xd9, 0x6e,
+	0xe5, 0x3b, 0x88, 0xff, 0xb2, 0xec, 0x6d, 0x20, 0xf5, 0x33, 0x96, 0xbd, 0x0d, 0x38, 0xa9, 0x43,
+	0x15, 0x15, 0xd8, 0x50, 0xbf, 0xb8, 0xcb, 0x0a, 0xb2, 0xb2, 0x50, 0x59, 0x43, 0xf9, 0xd2, 0x5e,
+	0xff, 0xab, 0x02, 0xd4, 0x66, 0x97, 0x41, 0x1e, 0x42, 0x45, 0xcb, 0x1b, 0x0f, 0xa3, 0x58, 0x59,
+	0xff, 0x1b, 0x57, 0x56, 0xdb, 0xe8, 0x85, 0x51, 0x2c, 0xf3, 0xe6, 0x5a, 0x3c, 0x05, 0x44, 0x38,
+	0xb3, 0x23, 0xcf, 0x75, 0xd3, 0xa7, 0x12, 0xae, 0x0c, 0x61, 0x55, 0x42, 0x75, 0x4e, 0xf8, 0x1e,
+	0x2c, 0x4d, 0xa8, 0xcf, 0xe2, 0x58, 0xbb, 0x34, 0x5b, 0x57, 0xfb, 0xef, 0x4a, 0xb4, 0xa5, 0xe9,
+	0x84, 0x5b, 0xea, 0x32, 0xee, 0x44, 0x1e, 0x12, 0x28, 0x37, 0x21, 0x0b, 0xaa, 0x1f, 0xc3, 0x72,
+	0x3a, 0x2b, 0xb2, 0x0e, 0x46, 0xef, 0xc4, 0xea, 0x5f, 0xd1, 0x2d, 0x25, 0x28, 0x8a, 0x18, 0xd8,
+	0xc8, 0x11, 0x02, 0xb5, 0xbd, 0x66, 0xe7, 0xf0, 0xd4, 0x6a, 0xf7, 0xec, 0xbd, 0x8e, 0xd5, 0x13,
+	0x5e, 0x51, 0x15, 0x96, 0xf7, 0x0e, 0x9b, 0x4f, 0x3a, 0xc7, 0xed, 0x5e, 0xcf, 0x28, 0xd4, 0x19,
+	0x2c, 0xa9, 0x59, 0x88, 0x98, 0xae, 0xdb, 0x3c, 0x6c, 0xf7, 0xfb, 0x57, 0x23, 0xf1, 0x0a, 0x94,
+	0x7a, 0xfd, 0xe6, 0x71, 0xab, 0x69, 0xb5, 0x8c, 0x1c, 0x31, 0xa0, 0xd2, 0x6a, 0x9f, 0xf6, 0xdb,
+	0x56, 0xf3, 0xf8, 0xa4, 0xdb, 0x69, 0x1a, 0x79, 0x52, 0x03, 0xe8, 0x5b, 0x9d, 0xbe, 0x6a, 0x17,
+	0xc8, 0x2a, 0x54, 0xf7, 0x3b, 0x8f, 0xf7, 0x45, 0x10, 0xdb, 0xb7, 0x9a, 0xbd, 0xbe, 0x51, 0xac,
+	0xff, 0x73, 0x1e, 0xd6, 0xe7, 0xdd, 0x85, 0x59, 0x61, 0xce, 0x5d, 0x11, 0xe6, 0x0f, 0x61, 0xe9,
+	0xdc, 0x0b, 0xdc, 0xf0, 0x5c, 0x1e, 0x7a, 0xf9, 0xfe, 0xda, 0xcc, 0x85, 0x7a, 0x8a, 0x38, 0x4b,
+	0xd3, 0x90, 0xaf, 0xc0, 0x60, 0xdc, 0xa1, 0xbe, 0xba, 0x8b, 0x31, 0x9b, 0x68, 0xed, 0xb3, 0xd2,
+	0x68, 0xa7, 0x88, 0x5e, 0xcc, 0x26, 0xd6, 0x0a, 0x9b, 0x69, 0x63, 0x02, 0x1f, 0xf5, 0xb9, 0x1d,
+	0x85, 0x98, 0x9f, 0x2a, 0xaa, 0x04, 0xfe, 0x89, 0x00, 0x5a, 0x02, 0x66, 0x95, 0xc3, 0xf4, 0x3f,
+	0x27, 0xef, 0x41, 0x89, 0x7b, 0x3e, 0x0b, 0x1c, 0xc6, 0xcd, 0x05, 0xf5, 0x36, 0xd7, 0x93, 0x00,
+	0x35, 0xad, 0x14, 0x2f, 0x4d, 0x32, 0xfe, 0xb7, 0x1d, 0xea, 0xb3, 0xc0, 0xa5, 0x91, 0xd0, 0x41,
+	0x42, 0x8a, 0x0d, 0x85, 0xd8, 0xd5, 0xf0, 0xfa, 0xdf, 0xe6, 0xa0, 0x3a, 0xd3, 0x11, 0xb9, 0x07,
+	0xcb, 0x11, 0x73, 0x92, 0x08, 0xeb, 0x0c, 0x73, 0x78, 0xbf, 0xe6, 0xee, 0xc3, 0x94, 0x0a, 0x53,
+	0xcb, 0x31, 0x8d, 0x62, 0x7b, 0x9a, 0xdc, 0xb6, 0x96, 0x11, 0xd2, 0xf7, 0xc6, 0x8c, 0xdc, 0x80,
+	0x12, 0x0b, 0x5c, 0x89, 0x54, 0xfe, 0x2a, 0x0b, 0x5c, 0x44, 0x6d, 0xc2, 0x62, 0xc4, 0x28, 0x4f,
+	0x85, 0x4f, 0xb5, 0xea, 0x7d, 0x80, 0xe9, 0x56, 0x4c, 0x4d, 0x61, 0x2e, 0x6b, 0x0a, 0x4d, 0x58,
+	0x72, 0x46, 0x34, 0x08, 0xb4, 0xfd, 0xb1, 0x74, 0x53, 0xf4, 0x9a, 0x29, 0x67, 0x5d, 0xb6, 0x54,
+	0xab, 0xfe, 0xdf, 0x39, 0x20, 0xd7, 0x57, 0x42, 0xde, 0x87, 0x22, 0xbe, 0xca, 0x08, 0x13, 0x24,
+	0xae, 0xcd, 0x75, 0x92, 0x46, 0x8b, 0x5e, 0x5a, 0x48, 0x84, 0x79, 0x43, 0xb1, 0x32, 0x6d, 0x96,
+	0xb1, 0x21, 0x7c, 0x74, 0x16, 0xb8, 0x6a, 0x38, 0xf1, 0xb7, 0xfe, 0x02, 0x0a, 0x2d, 0x7a, 0x49,
+	0xd6, 0x60, 0xa5, 0xd5, 0xbc, 0x6a, 0x8e, 0x01, 0x16, 0x8f, 0x4e, 0x8e, 0x5b, 0xe8, 0x33, 0x97,
+	0x61, 0xa9, 0x7f, 0xda, 0xee, 0x89, 0x06, 0xde, 0x96, 0xa7, 0xed, 0xd6, 0xb1, 0x6c, 0x16, 0xc4,
+	0x4d, 0xe8, 0xef, 0x9f, 0x5a, 0xd8, 0x2a, 0x0a, 0xae, 0x3d, 0xab, 0x23, 0xfe, 0x2f, 0xe0, 0x1d,
+	0x69, 0xf6, 0x4f, 0x2d, 0xd1, 0x5a, 0xc4, 0xd0, 0xe4, 0x14, 0xfb, 0x5b, 0xaa, 0xff, 0x6b, 0x0e,
+	0x6a, 0xb3, 0xd2, 0x27, 0x14, 0x88, 0xb6, 0x47, 0xce, 0xa5, 0xe3, 0x33, 0xae, 0x33, 0x6b, 0x0a,
+	0xba, 0x8b, 0xc0, 0xdf, 0x7f, 0x3f, 0x33, 0xa5, 0xb2, 0xfa, 0xde, 0xcc, 0x94, 0xca, 0x3e, 0x55,
+	0x17, 0xe5, 0x5d, 0x30, 0xe4, 0x83, 0xa7, 0xcd, 0x2e, 0x46, 0x34, 0xe1, 0x31, 0x73, 0x95, 0x37,
+	0xb9, 0x22, 0xe1, 0x6d, 0x0d, 0xae, 0xbb, 0x50, 0x11, 0x11, 0x70, 0x1a, 0xc8, 0xab, 0xd8, 0x27,
+	0x37, 0x8d, 0x7d, 0x1a, 0xb0, 0xa4, 0x8d, 0x46, 0x5e, 0xb9, 0xb5, 0x82, 0x43, 0xe9, 0x38, 0xcd,
+	0x68, 0x69, 0xa2, 0xd4, 0x69, 0x28, 0x4c, 0x9d, 0x86, 0xfa, 0x23, 0x58, 0x9b, 0xc3, 0xf3, 0x63,
+	0xf3, 0xa8, 0xf5, 0xbf, 0x59, 0x81, 0x4a, 0x6b, 0x9e, 0x63, 0x92, 0x0d, 0x3d, 0x75, 0x94, 0x83,
+	0xa5, 0x38, 0x99, 0x17, 0x15, 0x19, 0xe5, 0x60, 0x42, 0x0d, 0x73, 0x92, 0xd7, 0x7c, 0xc1, 0xc2,
+	0x8f, 0x2c, 0x58, 0x2d, 0xfe, 0x1e, 0x05, 0xab, 0x0b, 0x2f, 0x29, 0x58, 0xbd, 0x03, 0x95, 0x81,
+	0x88, 0x14, 0xf5, 0x8e, 0x2e, 0x4a, 0x0b, 0x20, 0x60, 0xda, 0x76, 0x7d, 0x0d, 0x24, 0x9c, 0xb0,
+	0x40, 0x3a, 0xbd, 0x69, 0xe2, 0x45, 0x3f, 0x74, 0x64, 0x0f, 0xcb, 0x32, 0x04, 0xa1, 0x70, 0x74,
+	0xd3, 0x1d, 0xfd, 0x12, 0x56, 0xd1, 0x63, 0x17, 0x2b, 0x4c, 0x79, 0x4b, 0xf3, 0x78, 0x31, 0xdc,
+	0xd8, 0x49, 0x86, 0x29, 0xeb, 0x23, 0x58, 0xa3, 0x71, 0x4c, 0x9d, 0xd1, 0x2c, 0xf3, 0xf2, 0x3c,
+	0xe6, 0x55, 0x49, 0x99, 0x65, 0xbf, 0x03, 0x15, 0x5d, 0x71, 0x8c, 0xef, 0x5d, 0xa0, 0x53, 0x2e,
+	0x08, 0xc3, 0x17, 0xaf, 0x6f, 0xf4, 0xbb, 0x0a, 0xb7, 0x93, 0xc8, 0x9f, 0x0e, 0x51, 0x9e, 0x37,
+	0x04, 0x51, 0xa4, 0xa7, 0x91, 0x9f, 0x8e, 0xb1, 0x07, 0x66, 0xf6, 0x54, 0x66, 0x3a, 0xa9, 0xcc,
+	0xeb, 0x64, 0x63, 0x7a, 0x58, 0xd9, 0x7e, 0xae, 0x98, 0xe1, 0xea, 0x35, 0x33, 0x4c, 0x1a, 0xb0,
+	0x16, 0xd3, 0x41, 0xe2, 0xd3, 0x48, 0x96, 0x81, 0xa9, 0x28, 0x56, 0xd6, 0x2c, 0xaf, 0x2a, 0x14,
+	0x96, 0x81, 0xc9, 0xd0, 0xf9, 0x67, 0x50, 0x95, 0xe5, 0xba, 0xfa, 0x60, 0x57, 0xd4, 0xcb, 0x6c,
+	0x56, 0x6c, 0xb1, 0xb4, 0x2f, 0x75, 0x96, 0x68, 0xa6, 0x45, 0xbe, 0x87, 0xad, 0x33, 0x9f, 0x3e,
+	0xf7, 0x02, 0xc6, 0xb9, 0x3d, 0xdb, 0x93, 0x89, 0x3d, 0xd5, 0x67, 0x7a, 0xda, 0xd3, 0xb4, 0x33,
+	0x5d, 0x6e, 0x9c, 0xcd, 0x03, 0x8b, 0xb5, 0xd0, 0x41, 0x98, 0xc4, 0xf6, 0xd4, 0xff, 0x17, 0x57,
+	0xdc, 0x90, 0x6b, 0x41, 0x54, 0xda, 0xf7, 0x69, 0xe4, 0x0b, 0x19, 0x42, 0x01, 0x9c, 0x11, 0x83,
+	0xd5, 0xb9, 0x32, 0x24, 0xe8, 0xb2, 0x42, 0xf0, 0x53, 0xc0, 0xda, 0x49, 0x5b, 0xcb, 0x20, 0xc7,
+	0x22, 0xe9, 0x92, 0x55, 0x11, 0xd0, 0x3d, 0x29, 0x70, 0x5c, 0x5c, 0x19, 0xed, 0x8e, 0xfa, 0xa1,
+	0x43, 0x7d, 0x69, 0xa8, 0xd6, 0x64, 0x0c, 0xab, 0x30, 0x87, 0x02, 0x81, 0x16, 0xab, 0x09, 0x1b,
+	0xfa, 0x53, 0x85, 0x31, 0x0b, 0x92, 0xe9, 0x94, 0xd6, 0xe7, 0x4d, 0x69, 0x4d, 0xd1, 0x1e, 0xb1,
+	0x20, 0x49, 0xa7, 0xf5, 0x8a, 0xda, 0x97, 0x8d, 0x57, 0xd5, 0xbe, 0x34, 0x61, 0x7d, 0x26, 0x1b,
+	0xa1, 0x8f, 0x64, 0x73, 0x7e, 0xdd, 0x28, 0xc9, 0x24, 0x27, 0xf4, 0xe6, 0x1f, 0xc3, 0x96, 0x7c,
+	0x97, 0x4b, 0x6b, 0x94, 0xd3, 0x5e, 0xb6, 0x54, 0x99, 0x97, 0x7c, 0x9e, 0xd3, 0x45, 0xca, 0xe9,
+	0x61, 0x8e, 0xe6, 0x81, 0xc9, 0xe7, 0xa0, 0xaa, 0xe9, 0x74, 0x75, 0x35, 0xe3, 0xe6, 0x0d, 0x34,
+	0xa3, 0x65, 0xcc, 0x6d, 0x49, 0x37, 0xdb, 0x5a, 0x51, 0x44, 0x3d, 0x45, 0x43, 0xbe, 0x49, 0xcb,
+	0x26, 0xa4, 0xe5, 0x50, 0x65, 0xcd, 0xdb, 0x33, 0x62, 0xa5, 0x9e, 0xe2, 0x95, 0xbf, 0xa1, 0x4a,
+	0x2a, 0x94, 0xcd, 0xfe, 0x1a, 0x48, 0x14, 0x9e, 0xcb, 0x92, 0x25, 0x7d, 0x04, 0xd3, 0x22, 0xe7,
+	0x59, 0xb5, 0x14, 0x85, 0xe7, 0x59, 0x00, 0xfa, 0xe3, 0x0c, 0x43, 0x1f, 0x69, 0x7e, 0xcc, 0xd7,
+	0xe7, 0xdc, 0x8e, 0x46, 0x5b, 0x50, 0xa8, 0x32, 0x9c, 0x32, 0x9b, 0x36, 0xc8, 0x07, 0xb0, 0x18,
+	0x85, 0xbe, 0x9f, 0x4c, 0x54, 0x6d, 0xf4, 0xfa, 0x2c, 0x9f, 0x85, 0x38, 0x4b, 0xd1, 0x08, 0x19,
+	0x14, 0x13, 0x15, 0xbb, 0xc3, 0x65, 0xf1, 0xc7, 0x4f, 0x6e, 0x17, 0x84, 0x82, 0x8f, 0xc2, 0x73,
+	0xb1, 0x1d, 0x1c, 0xab, 0x3f, 0x1e, 0xc1, 0x1a, 0xd6, 0x00, 0x5e, 0x59, 0xcf, 0x1b, 0xf3, 0xd6,
+	0xb3, 0x2a, 0x28, 0x67, 0x17, 0x74, 0x07, 0x2a, 0x7c, 0x72, 0x39, 0xc4, 0x2c, 0xab, 0xb8, 0x4c,
+	0xb7, 0xa5, 0x0a, 0xd1, 0xb0, 0xd3, 0xc8, 0xdf, 0xde, 0xd5, 0x95, 0x16, 0x6a, 0x03, 0xdf, 0x80,
+	0x72, 0xc6, 0x50, 0x28, 0x8f, 0x00, 0xa6, 0x16, 0x42, 0x18, 0x35, 0x9c, 0xae, 0x0c, 0x36, 0xf0,
+	0xff, 0xf6, 0x33, 0x28, 0x67, 0xb6, 0x45, 0x08, 0xb2, 0xce, 0xe5, 0xa4, 0x0e, 0xc6, 0x4c, 0x7f,
+	0x1b, 0x0a, 0xad, 0xa2, 0xdd, 0x57, 0x74, 0x5d, 0xff, 0x02, 0x16, 0xe5, 0xce, 0x91, 0x4d, 0x20,
+	0xd6, 0xc9, 0xe1, 0xe1, 0x69, 0xf7, 0xba, 0xd7, 0xb4, 0x7f, 0x72, 0x6a, 0x1d, 0x3e, 0x93, 0x79,
+	0xd7, 0x56, 0xb3, 0x73, 0xf8, 0xcc, 0xc8, 0xd7, 0xff, 0xad, 0x08, 0xe6, 0xcb, 0xd4, 0x1a, 0xf9,
+	0xf2, 0x55, 0x1f, 0xa1, 0xc8, 0x39, 0xbe, 0xec, 0x03, 0x94, 0x7b, 0x2f, 0xfb, 0x00, 0x45, 0xce,
+	0x7a, 0xde, 0xc7, 0x27, 0x9f, 0xbd, 0xfc, 0x9b, 0x0e, 0xe9, 0x7e, 0xcc, 0xff, 0x9e, 0xe3, 0x07,
+	0x6a, 0xb3, 0x8b, 0xaf, 0xae, 0xcd, 0xc6, 0xaf, 0xaa, 0xe4, 0x27, 0x20, 0x0b, 0xfa, 0xab, 0x2a,
+	0xf9, 0xd5, 0xc7, 0x4d, 0x58, 0x9e, 0x7e, 0xa9, 0x21, 0x4d, 0x7b, 0xc9, 0xd5, 0x1f, 0x67, 0xbc,
+	0x09, 0x55, 0x89, 0xd4, 0x5f, 0x81, 0x2c, 0xc9, 0x94, 0x28, 0x02, 0xf5, 0x67, 0x1f, 0x8f, 0xe0,
+	0xe6, 0x39, 0xf5, 0xe2, 0x6b, 0x9f, 0x6e, 0x30, 0xf9, 0xed, 0x46, 0x49, 0x26, 0xec, 0x04, 0xc9,
+	0xec, 0x17, 0x1b, 0x6d, 0xc4, 0x63, 0x59, 0xe2, 0xcb, 0x3f, 0x3b, 0x59, 0xc6, 0x01, 0x5f, 0xfa,
+	0xc9, 0xc9, 0xb7, 0x70, 0x4b, 0xec, 0x8a, 0x3e, 0x32, 0x2f, 0x48, 0x3b, 0x50, 0x2a, 0x43, 0xa6,
+	0x60, 0x6f, 0x04, 0xc9, 0x58, 0x9d, 0x5b, 0x27, 0x50, 0x5d, 0x28, 0x11, 0xff, 0x08, 0xd6, 0xd3,
+	0xb2, 0xab, 0x61, 0x44, 0x1d, 0x96, 0xfd, 0xf8, 0xc8, 0x5a, 0x55, 0xd5, 0x57, 0x8f, 0x05, 0x46,
+	0x86, 0xee, 0xbf, 0xcd, 0xc3, 0x9d, 0x1f, 0xb4, 0x6b, 0x62, 0x55, 0x63, 0x2f, 0xf0, 0xc6, 0x42,
+	0x38, 0x52, 0x23, 0x99, 0x4a, 0x87, 0x7c, 0x35, 0xdf, 0x52, 0x14, 0x69, 0x0f, 0x3f, 0x42, 0x44,
+	0xf2, 0xaf, 0x10, 0x91, 0xcc, 0x21, 0x17, 0x66, 0x0f, 0xf9, 0x07, 0x8e, 0xa8, 0xf8, 0xff, 0x3a,
+	0xa2, 0x85, 0x57, 0x1e, 0x51, 0xfd, 0x08, 0x6a, 0xe9, 0x76, 0xbd, 0xfc, 0xbb, 0xbc, 0x77, 0x60,
+	0x65, 0x6a, 0xea, 0x65, 0x15, 0xbb, 0xcc, 0xa9, 0xd4, 0x52, 0x30, 0xba, 0x2e, 0xf5, 0xff, 0xc9,
+	0x41, 0x75, 0xa6, 0x0a, 0x9d, 0xbc, 0x0f, 0xe5, 0xa9, 0x13, 0xad, 0xbf, 0xa5, 0x84, 0x69, 0x15,
+	0x85, 0x05, 0xa9, 0x33, 0x2d, 0x62, 0x64, 0x48, 0x3b, 0xd4, 0xc1, 0x01, 0x4c, 0x75, 0xb3, 0x95,
+	0xc1, 0x8a, 0xd8, 0x7d, 0x3a, 0x27, 0xd5, 0xbb, 0x8e, 0xdd, 0x67, 0x97, 0x64, 0x4d, 0x27, 0xaf,
+	0xc6, 0x79, 0x08, 0xeb, 0x19, 0xcf, 0x7e, 0xaa, 0xac, 0x8b, 0xd7, 0x66, 0x47, 0xd2, 0xd9, 0xa5,
+	0xaa, 0xba, 0xfe, 0x9f, 0x39, 0xd8, 0x98, 0x6b, 0x62, 0x45, 0x94, 0x25, 0xbf, 0x8d, 0x51, 0x4f,
+	0x06, 0xaa, 0x25, 0x9c, 0x7f, 0xfd, 0xe1, 0x62, 0xfa, 0x61, 0x91, 0xd4, 0x41, 0x35, 0xf9, 0xe5,
+	0x62, 0xfa, 0x41, 0xd1, 0x5b, 0x50, 0x63, 0xf2, 0x9b, 0x30, 0x9d, 0x18, 0x54, 0xcf, 0x9e, 0x08,
+	0x4d, 0x93, 0x20, 0xef, 0x82, 0x21, 0xc9, 0x22, 0xe6, 0x78, 0x13, 0x0f, 0x3f, 0x53, 0x95, 0xd1,
+	0xc4, 0x0a, 0xc2, 0xad, 0x14, 0x2c, 0x7a, 0x4c, 0xbf, 0x25, 0xc8, 0xbe, 0x9c, 0x54, 0x35, 0x54,
+	0x3e, 0x9d, 0xfc, 0x7d, 0x0e, 0xd6, 0x55, 0xa2, 0x7b, 0xf6, 0x00, 0x1f, 0x02, 0x99, 0xc9, 0xc7,
+	0xcb, 0x0f, 0x47, 0x64, 0x56, 0x21, 0xb3, 0x53, 0xf2, 0xb3, 0xb5, 0x4c, 0xde, 0x5d, 0x4a, 0x53,
+	0x7b, 0x9a, 0xcd, 0x9f, 0x4d, 0x16, 0xe7, 0x95, 0xaf, 0x95, 0xbd, 0xac, 0xd8, 0x87, 0xce, 0xdd,
+	0x67, 0x11, 0x83, 0x45, 0xfc, 0x5a, 0xf7, 0x93, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x9f, 0xa7,
+	0x80, 0x95, 0x0b, 0x3c, 0x00, 0x00,
 }
@@ -0,0 +1,245 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: costs.proto
+
+package costs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Storage a test group used during an update cycle.
+type GroupCost struct {
+	// Name of the test group.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Dashboards with a tab of this group.
+	Dashboards []string `protobuf:"bytes,2,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
+	// Number of times the group updated during the cycle.
+	Updates      int32 `protobuf:"varint,3,opt,name=updates,proto3" json:"updates,omitempty"`
+	BytesRead    int64 `protobuf:"varint,4,opt,name=bytes_read,json=bytesRead,proto3" json:"bytes_read,omitempty"`
+	BytesWritten int64 `protobuf:"varint,5,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	// Objects opened for reading.
+	ObjectsRead int64 `protobuf:"varint,6,opt,name=objects_read,json=objectsRead,proto3" json:"objects_read,omitempty"`
+	// Objects uploaded or copied.
+	ObjectsWritten int64 `protobuf:"varint,7,opt,name=objects_written,json=objectsWritten,proto3" json:"objects_written,omitempty"`
+	// Lists and stats, which are billed per operation.
+	Lists int64 `protobuf:"varint,8,opt,name=lists,proto3" json:"lists,omitempty"`
+	Stats int64 `protobuf:"varint,9,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Whether the write guard quarantined the latest update of the group.
+	Quarantined          bool     `protobuf:"varint,10,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupCost) Reset()         { *m = GroupCost{} }
+func (m *GroupCost) String() string { return proto.CompactTextString(m) }
+func (*GroupCost) ProtoMessage()    {}
+func (*GroupCost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_824a2dabc4a54e2c, []int{0}
+}
+
+func (m *GroupCost) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupCost.Unmarshal(m, b)
+}
+func (m *GroupCost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupCost.Marshal(b, m, deterministic)
+}
+func (m *GroupCost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupCost.Merge(m, src)
+}
+func (m *GroupCost) XXX_Size() int {
+	return xxx_messageInfo_GroupCost.Size(m)
+}
+func (m *GroupCost) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupCost.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupCost proto.InternalMessageInfo
+
+func (m *GroupCost) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GroupCost) GetDashboards() []string {
+	if m != nil {
+		return m.Dashboards
+	}
+	return nil
+}
+
+func (m *GroupCost) GetUpdates() int32 {
+	if m != nil {
+		return m.Updates
+	}
+	return 0
+}
+
+func (m *GroupCost) GetBytesRead() int64 {
+	if m != nil {
+		return m.BytesRead
+	}
+	return 0
+}
+
+func (m *GroupCost) GetBytesWritten() int64 {
+	if m != nil {
+		return m.BytesWritten
+	}
+	return 0
+}
+
+func (m *GroupCost) GetObjectsRead() int64 {
+	if m != nil {
+		return m.ObjectsRead
+	}
+	return 0
+}
+
+func (m *GroupCost) GetObjectsWritten() int64 {
+	if m != nil {
+		return m.ObjectsWritten
+	}
+	return 0
+}
+
+func (m *GroupCost) GetLists() int64 {
+	if m != nil {
+		return m.Lists
+	}
+	return 0
+}
+
+func (m *GroupCost) GetStats() int64 {
+	if m != nil {
+		return m.Stats
+	}
+	return 0
+}
+
+func (m *GroupCost) GetQuarantined() bool {
+	if m != nil {
+		return m.Quarantined
+	}
+	return false
+}
+
+// Storage used by each test group during an update cycle.
+type CostReport struct {
+	// Start and end of the cycle, in seconds since epoch.
+	Start float64 `protobuf:"fixed64,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   float64 `protobuf:"fixed64,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Groups which updated during the cycle, sorted by name.
+	Groups               []*GroupCost `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CostReport) Reset()         { *m = CostReport{} }
+func (m *CostReport) String() string { return proto.CompactTextString(m) }
+func (*CostReport) ProtoMessage()    {}
+func (*CostReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_824a2dabc4a54e2c, []int{1}
+}
+
+func (m *CostReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CostReport.Unmarshal(m, b)
+}
+func (m *CostReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CostReport.Marshal(b, m, deterministic)
+}
+func (m *CostReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CostReport.Merge(m, src)
+}
+func (m *CostReport) XXX_Size() int {
+	return xxx_messageInfo_CostReport.Size(m)
+}
+func (m *CostReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CostReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CostReport proto.InternalMessageInfo
+
+func (m *CostReport) GetStart() float64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *CostReport) GetEnd() float64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *CostReport) GetGroups() []*GroupCost {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GroupCost)(nil), "GroupCost")
+	proto.RegisterType((*CostReport)(nil), "CostReport")
+}
+
+func init() {
+	proto.RegisterFile("costs.proto", fileDescriptor_824a2dabc4a54e2c)
+}
+
+var fileDescriptor_824a2dabc4a54e2c = []byte{
+	// 277 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xbd, 0x4e, 0xc4, 0x30,
+	0x10, 0x84, 0xe5, 0xcb, 0x25, 0x77, 0xd9, 0x1c, 0x3f, 0xb2, 0x28, 0xdc, 0x80, 0x4c, 0x28, 0x70,
+	0x75, 0x05, 0x3c, 0x02, 0x05, 0xbd, 0x1b, 0xe8, 0x90, 0x73, 0xb6, 0x20, 0x08, 0xe2, 0xe0, 0xdd,
+	0x08, 0xf1, 0x64, 0xbc, 0x1e, 0xca, 0x26, 0x41, 0xd7, 0xed, 0x7c, 0x33, 0x9a, 0x62, 0x16, 0xaa,
+	0x43, 0x44, 0xc2, 0x7d, 0x9f, 0x22, 0xc5, 0xfa, 0x77, 0x05, 0xe5, 0x63, 0x8a, 0x43, 0xff, 0x10,
+	0x91, 0xa4, 0x84, 0x75, 0xe7, 0x3e, 0x83, 0x12, 0x5a, 0x98, 0xd2, 0xf2, 0x2d, 0xaf, 0x00, 0xbc,
+	0xc3, 0xb7, 0x26, 0xba, 0xe4, 0x51, 0xad, 0x74, 0x66, 0x4a, 0x7b, 0x44, 0xa4, 0x82, 0xcd, 0xd0,
+	0x7b, 0x47, 0x01, 0x55, 0xa6, 0x85, 0xc9, 0xed, 0x22, 0xe5, 0x25, 0x40, 0xf3, 0x43, 0x01, 0x5f,
+	0x52, 0x70, 0x5e, 0xad, 0xb5, 0x30, 0x99, 0x2d, 0x99, 0xd8, 0xe0, 0xbc, 0xbc, 0x81, 0x93, 0xc9,
+	0xfe, 0x4e, 0x2d, 0x51, 0xe8, 0x54, 0xce, 0x89, 0x1d, 0xc3, 0xa7, 0x89, 0xc9, 0x6b, 0xd8, 0xc5,
+	0xe6, 0x3d, 0x1c, 0x68, 0x6e, 0x29, 0x38, 0x53, 0xcd, 0x8c, 0x7b, 0x6e, 0xe1, 0x6c, 0x89, 0x2c,
+	0x4d, 0x1b, 0x4e, 0x9d, 0xce, 0x78, 0xe9, 0xba, 0x80, 0xfc, 0xa3, 0x45, 0x42, 0xb5, 0x65, 0x7b,
+	0x12, 0x23, 0x45, 0x72, 0x84, 0xaa, 0x9c, 0x28, 0x0b, 0xa9, 0xa1, 0xfa, 0x1a, 0x5c, 0x72, 0x1d,
+	0xb5, 0x5d, 0xf0, 0x0a, 0xb4, 0x30, 0x5b, 0x7b, 0x8c, 0xea, 0x67, 0x80, 0x71, 0x33, 0x1b, 0xfa,
+	0x98, 0x68, 0x6e, 0x49, 0xc4, 0xd3, 0x09, 0x3b, 0x09, 0x79, 0x0e, 0x59, 0xe8, 0xbc, 0x5a, 0x31,
+	0x1b, 0x4f, 0x59, 0x43, 0xf1, 0x3a, 0xce, 0x3d, 0x8e, 0x95, 0x99, 0xea, 0x0e, 0xf6, 0xff, 0xeb,
+	0xdb, 0xd9, 0x69, 0x0a, 0x7e, 0xcd, 0xfd, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x99, 0xa9, 0xfe,
+	0x62, 0xa9, 0x01, 0x00, 0x00,
+}
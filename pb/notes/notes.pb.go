@@ -0,0 +1,290 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: notes.proto
+
+package notes
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// An earlier version of a note.
+type NoteRevision struct {
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Identity of the author, such as their email.
+	Author string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	// When the revision was written, in seconds since epoch.
+	Modified             float64  `protobuf:"fixed64,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoteRevision) Reset()         { *m = NoteRevision{} }
+func (m *NoteRevision) String() string { return proto.CompactTextString(m) }
+func (*NoteRevision) ProtoMessage()    {}
+func (*NoteRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffefd935cd6c4a4a, []int{0}
+}
+
+func (m *NoteRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoteRevision.Unmarshal(m, b)
+}
+func (m *NoteRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoteRevision.Marshal(b, m, deterministic)
+}
+func (m *NoteRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoteRevision.Merge(m, src)
+}
+func (m *NoteRevision) XXX_Size() int {
+	return xxx_messageInfo_NoteRevision.Size(m)
+}
+func (m *NoteRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoteRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoteRevision proto.InternalMessageInfo
+
+func (m *NoteRevision) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *NoteRevision) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *NoteRevision) GetModified() float64 {
+	if m != nil {
+		return m.Modified
+	}
+	return 0
+}
+
+// A short triage note about a row, or about its cells from the column of
+// first_build through the column of last_build.
+type Note struct {
+	// Identifies the note within the test group.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the row.
+	Row string `protobuf:"bytes,2,opt,name=row,proto3" json:"row,omitempty"`
+	// Build of the oldest column the note covers. The note covers the whole
+	// row when empty.
+	FirstBuild string `protobuf:"bytes,3,opt,name=first_build,json=firstBuild,proto3" json:"first_build,omitempty"`
+	// Build of the newest column the note covers, defaulting to first_build.
+	LastBuild string `protobuf:"bytes,4,opt,name=last_build,json=lastBuild,proto3" json:"last_build,omitempty"`
+	// Current text of the note.
+	Text string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	// Identity of the author of the current text.
+	Author string `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	// When the note was created, in seconds since epoch.
+	Created float64 `protobuf:"fixed64,7,opt,name=created,proto3" json:"created,omitempty"`
+	// When the current text was written, in seconds since epoch.
+	Modified float64 `protobuf:"fixed64,8,opt,name=modified,proto3" json:"modified,omitempty"`
+	// Earlier versions of the note, oldest first.
+	History []*NoteRevision `protobuf:"bytes,9,rep,name=history,proto3" json:"history,omitempty"`
+	// True once the note is deleted, which keeps its history.
+	Deleted              bool     `protobuf:"varint,10,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Note) Reset()         { *m = Note{} }
+func (m *Note) String() string { return proto.CompactTextString(m) }
+func (*Note) ProtoMessage()    {}
+func (*Note) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffefd935cd6c4a4a, []int{1}
+}
+
+func (m *Note) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Note.Unmarshal(m, b)
+}
+func (m *Note) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Note.Marshal(b, m, deterministic)
+}
+func (m *Note) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Note.Merge(m, src)
+}
+func (m *Note) XXX_Size() int {
+	return xxx_messageInfo_Note.Size(m)
+}
+func (m *Note) XXX_DiscardUnknown() {
+	xxx_messageInfo_Note.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Note proto.InternalMessageInfo
+
+func (m *Note) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Note) GetRow() string {
+	if m != nil {
+		return m.Row
+	}
+	return ""
+}
+
+func (m *Note) GetFirstBuild() string {
+	if m != nil {
+		return m.FirstBuild
+	}
+	return ""
+}
+
+func (m *Note) GetLastBuild() string {
+	if m != nil {
+		return m.LastBuild
+	}
+	return ""
+}
+
+func (m *Note) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *Note) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Note) GetCreated() float64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Note) GetModified() float64 {
+	if m != nil {
+		return m.Modified
+	}
+	return 0
+}
+
+func (m *Note) GetHistory() []*NoteRevision {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *Note) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// Backing state for the notes of a test group.
+type NoteState struct {
+	Notes                []*Note  `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoteState) Reset()         { *m = NoteState{} }
+func (m *NoteState) String() string { return proto.CompactTextString(m) }
+func (*NoteState) ProtoMessage()    {}
+func (*NoteState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffefd935cd6c4a4a, []int{2}
+}
+
+func (m *NoteState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoteState.Unmarshal(m, b)
+}
+func (m *NoteState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoteState.Marshal(b, m, deterministic)
+}
+func (m *NoteState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoteState.Merge(m, src)
+}
+func (m *NoteState) XXX_Size() int {
+	return xxx_messageInfo_NoteState.Size(m)
+}
+func (m *NoteState) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoteState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoteState proto.InternalMessageInfo
+
+func (m *NoteState) GetNotes() []*Note {
+	if m != nil {
+		return m.Notes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*NoteRevision)(nil), "NoteRevision")
+	proto.RegisterType((*Note)(nil), "Note")
+	proto.RegisterType((*NoteState)(nil), "NoteState")
+}
+
+func init() {
+	proto.RegisterFile("notes.proto", fileDescriptor_ffefd935cd6c4a4a)
+}
+
+var fileDescriptor_ffefd935cd6c4a4a = []byte{
+	// 262 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcf, 0x4a, 0xc4, 0x30,
+	0x10, 0xc6, 0x49, 0xb7, 0x7f, 0xa7, 0x2a, 0x32, 0x07, 0x09, 0x8a, 0x58, 0x7a, 0x31, 0xa7, 0x3d,
+	0xe8, 0x1b, 0xf8, 0x00, 0x1e, 0x22, 0x78, 0x95, 0xae, 0x99, 0x65, 0x03, 0x75, 0x23, 0xe9, 0xac,
+	0x7f, 0x1e, 0xc2, 0x77, 0x96, 0xa4, 0xed, 0x62, 0xc1, 0xdb, 0x7c, 0xdf, 0x47, 0x7e, 0x7c, 0x93,
+	0x81, 0x7a, 0xef, 0x98, 0x86, 0xf5, 0xbb, 0x77, 0xec, 0xda, 0x67, 0x38, 0x79, 0x74, 0x4c, 0x9a,
+	0x3e, 0xec, 0x60, 0xdd, 0x1e, 0x11, 0x52, 0xa6, 0x2f, 0x96, 0xa2, 0x11, 0xaa, 0xd2, 0x71, 0xc6,
+	0x0b, 0xc8, 0xbb, 0x03, 0xef, 0x9c, 0x97, 0x49, 0x74, 0x27, 0x85, 0x97, 0x50, 0xbe, 0x39, 0x63,
+	0xb7, 0x96, 0x8c, 0x5c, 0x35, 0x42, 0x09, 0x7d, 0xd4, 0xed, 0x4f, 0x02, 0x69, 0x00, 0xe3, 0x19,
+	0x24, 0xd6, 0x4c, 0xb8, 0xc4, 0x1a, 0x3c, 0x87, 0x95, 0x77, 0x9f, 0x13, 0x29, 0x8c, 0x78, 0x03,
+	0xf5, 0xd6, 0xfa, 0x81, 0x5f, 0x36, 0x07, 0xdb, 0x8f, 0xa4, 0x4a, 0x43, 0xb4, 0x1e, 0x82, 0x83,
+	0xd7, 0x00, 0x7d, 0x77, 0xcc, 0xd3, 0x98, 0x57, 0xc1, 0x19, 0xe3, 0xb9, 0x72, 0xf6, 0x6f, 0xe5,
+	0x7c, 0x51, 0x59, 0x42, 0xf1, 0xea, 0xa9, 0x63, 0x32, 0xb2, 0x88, 0x8d, 0x67, 0xb9, 0x58, 0xa6,
+	0x5c, 0x2e, 0x83, 0xb7, 0x50, 0xec, 0xec, 0xc0, 0xce, 0x7f, 0xcb, 0xaa, 0x59, 0xa9, 0xfa, 0xee,
+	0x74, 0xfd, 0xf7, 0xd3, 0xf4, 0x9c, 0x06, 0xbc, 0xa1, 0x9e, 0x02, 0x1e, 0x1a, 0xa1, 0x4a, 0x3d,
+	0xcb, 0x56, 0x41, 0x15, 0x9e, 0x3c, 0x71, 0xc7, 0x84, 0x57, 0x90, 0xc5, 0x1b, 0x48, 0x11, 0x69,
+	0xd9, 0x48, 0x1b, 0xbd, 0x4d, 0x1e, 0x0f, 0x73, 0xff, 0x1b, 0x00, 0x00, 0xff, 0xff, 0xfa, 0xce,
+	0x15, 0xd2, 0xa7, 0x01, 0x00, 0x00,
+}
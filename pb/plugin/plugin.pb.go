@@ -0,0 +1,682 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: plugin.proto
+
+package plugin
+
+import (
+	context "context"
+	fmt "fmt"
+	config "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	state "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summary "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	test_status "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// A row's result in a column.
+type InflatedCell struct {
+	// Color of the cell.
+	Result test_status.TestStatus `protobuf:"varint,1,opt,name=result,proto3,enum=TestStatus" json:"result,omitempty"`
+	// Name of the row before user-customized formatting.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Identifier of the build, which may differ between cells of a column.
+	CellId string `protobuf:"bytes,3,opt,name=cell_id,json=cellId,proto3" json:"cell_id,omitempty"`
+	// Short string displayed on the cell.
+	Icon string `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	// Longer string displayed on mouse-over.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Untruncated message, when message is truncated.
+	FullMessage string `protobuf:"bytes,6,opt,name=full_message,json=fullMessage,proto3" json:"full_message,omitempty"`
+	// Numerical data, such as how long it ran.
+	Metrics map[string]float64 `protobuf:"bytes,7,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Value of the test group's user property.
+	UserProperty string `protobuf:"bytes,8,opt,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// Values of the test group's cell properties.
+	Properties map[string]string `protobuf:"bytes,9,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Artifacts of the cell.
+	Links []*state.Link `protobuf:"bytes,10,rep,name=links,proto3" json:"links,omitempty"`
+	// Result of each parameterization folded into the cell.
+	Parameters           []*state.ParameterResult `protobuf:"bytes,11,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *InflatedCell) Reset()         { *m = InflatedCell{} }
+func (m *InflatedCell) String() string { return proto.CompactTextString(m) }
+func (*InflatedCell) ProtoMessage()    {}
+func (*InflatedCell) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22a625af4bc1cc87, []int{0}
+}
+
+func (m *InflatedCell) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InflatedCell.Unmarshal(m, b)
+}
+func (m *InflatedCell) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InflatedCell.Marshal(b, m, deterministic)
+}
+func (m *InflatedCell) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InflatedCell.Merge(m, src)
+}
+func (m *InflatedCell) XXX_Size() int {
+	return xxx_messageInfo_InflatedCell.Size(m)
+}
+func (m *InflatedCell) XXX_DiscardUnknown() {
+	xxx_messageInfo_InflatedCell.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InflatedCell proto.InternalMessageInfo
+
+func (m *InflatedCell) GetResult() test_status.TestStatus {
+	if m != nil {
+		return m.Result
+	}
+	return test_status.TestStatus_NO_RESULT
+}
+
+func (m *InflatedCell) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *InflatedCell) GetCellId() string {
+	if m != nil {
+		return m.CellId
+	}
+	return ""
+}
+
+func (m *InflatedCell) GetIcon() string {
+	if m != nil {
+		return m.Icon
+	}
+	return ""
+}
+
+func (m *InflatedCell) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *InflatedCell) GetFullMessage() string {
+	if m != nil {
+		return m.FullMessage
+	}
+	return ""
+}
+
+func (m *InflatedCell) GetMetrics() map[string]float64 {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *InflatedCell) GetUserProperty() string {
+	if m != nil {
+		return m.UserProperty
+	}
+	return ""
+}
+
+func (m *InflatedCell) GetProperties() map[string]string {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *InflatedCell) GetLinks() []*state.Link {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
+func (m *InflatedCell) GetParameters() []*state.ParameterResult {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+// A column and the cell of each of its rows.
+type InflatedColumn struct {
+	Column *state.Column `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	// Cells by row name.
+	Cells                map[string]*InflatedCell `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *InflatedColumn) Reset()         { *m = InflatedColumn{} }
+func (m *InflatedColumn) String() string { return proto.CompactTextString(m) }
+func (*InflatedColumn) ProtoMessage()    {}
+func (*InflatedColumn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22a625af4bc1cc87, []int{1}
+}
+
+func (m *InflatedColumn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InflatedColumn.Unmarshal(m, b)
+}
+func (m *InflatedColumn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InflatedColumn.Marshal(b, m, deterministic)
+}
+func (m *InflatedColumn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InflatedColumn.Merge(m, src)
+}
+func (m *InflatedColumn) XXX_Size() int {
+	return xxx_messageInfo_InflatedColumn.Size(m)
+}
+func (m *InflatedColumn) XXX_DiscardUnknown() {
+	xxx_messageInfo_InflatedColumn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InflatedColumn proto.InternalMessageInfo
+
+func (m *InflatedColumn) GetColumn() *state.Column {
+	if m != nil {
+		return m.Column
+	}
+	return nil
+}
+
+func (m *InflatedColumn) GetCells() map[string]*InflatedCell {
+	if m != nil {
+		return m.Cells
+	}
+	return nil
+}
+
+type ReadColumnsRequest struct {
+	// Test group to read.
+	TestGroup *config.TestGroup `protobuf:"bytes,1,opt,name=test_group,json=testGroup,proto3" json:"test_group,omitempty"`
+	// Columns the grid already holds, newest first.
+	OldColumns []*InflatedColumn `protobuf:"bytes,2,rep,name=old_columns,json=oldColumns,proto3" json:"old_columns,omitempty"`
+	// Ignore results before this time, in seconds since epoch.
+	Stop                 float64  `protobuf:"fixed64,3,opt,name=stop,proto3" json:"stop,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadColumnsRequest) Reset()         { *m = ReadColumnsRequest{} }
+func (m *ReadColumnsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadColumnsRequest) ProtoMessage()    {}
+func (*ReadColumnsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22a625af4bc1cc87, []int{2}
+}
+
+func (m *ReadColumnsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadColumnsRequest.Unmarshal(m, b)
+}
+func (m *ReadColumnsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadColumnsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadColumnsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadColumnsRequest.Merge(m, src)
+}
+func (m *ReadColumnsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadColumnsRequest.Size(m)
+}
+func (m *ReadColumnsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadColumnsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadColumnsRequest proto.InternalMessageInfo
+
+func (m *ReadColumnsRequest) GetTestGroup() *config.TestGroup {
+	if m != nil {
+		return m.TestGroup
+	}
+	return nil
+}
+
+func (m *ReadColumnsRequest) GetOldColumns() []*InflatedColumn {
+	if m != nil {
+		return m.OldColumns
+	}
+	return nil
+}
+
+func (m *ReadColumnsRequest) GetStop() float64 {
+	if m != nil {
+		return m.Stop
+	}
+	return 0
+}
+
+type ReadColumnsResponse struct {
+	// Columns to replace the grid's old columns with.
+	Columns              []*InflatedColumn `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReadColumnsResponse) Reset()         { *m = ReadColumnsResponse{} }
+func (m *ReadColumnsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadColumnsResponse) ProtoMessage()    {}
+func (*ReadColumnsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22a625af4bc1cc87, []int{3}
+}
+
+func (m *ReadColumnsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadColumnsResponse.Unmarshal(m, b)
+}
+func (m *ReadColumnsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadColumnsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadColumnsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadColumnsResponse.Merge(m, src)
+}
+func (m *ReadColumnsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadColumnsResponse.Size(m)
+}
+func (m *ReadColumnsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadColumnsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadColumnsResponse proto.InternalMessageInfo
+
+func (m *ReadColumnsResponse) GetColumns() []*InflatedColumn {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+type SendRequest struct {
+	// Channel and target of the escalation step.
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Target  string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Notification rendered with the channel's template.
+	Body      string `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	Dashboard string `protobuf:"bytes,4,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	Tab       string `protobuf:"bytes,5,opt,name=tab,proto3" json:"tab,omitempty"`
+	// Link to the dashboard tab, if known.
+	TabUrl  string                       `protobuf:"bytes,6,opt,name=tab_url,json=tabUrl,proto3" json:"tab_url,omitempty"`
+	Summary *summary.DashboardTabSummary `protobuf:"bytes,7,opt,name=summary,proto3" json:"summary,omitempty"`
+	Step    *config.EscalationStep       `protobuf:"bytes,8,opt,name=step,proto3" json:"step,omitempty"`
+	// When the notification was rendered, in seconds since epoch.
+	Now                  float64  `protobuf:"fixed64,9,opt,name=now,proto3" json:"now,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendRequest) Reset()         { *m = SendRequest{} }
+func (m *SendRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRequest) ProtoMessage()    {}
+func (*SendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22a625af4bc1cc87, []int{4}
+}
+
+func (m *SendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendRequest.Unmarshal(m, b)
+}
+func (m *SendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendRequest.Marshal(b, m, deterministic)
+}
+func (m *SendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRequest.Merge(m, src)
+}
+func (m *SendRequest) XXX_Size() int {
+	return xxx_messageInfo_SendRequest.Size(m)
+}
+func (m *SendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendRequest proto.InternalMessageInfo
+
+func (m *SendRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *SendRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *SendRequest) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *SendRequest) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *SendRequest) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *SendRequest) GetTabUrl() string {
+	if m != nil {
+		return m.TabUrl
+	}
+	return ""
+}
+
+func (m *SendRequest) GetSummary() *summary.DashboardTabSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+func (m *SendRequest) GetStep() *config.EscalationStep {
+	if m != nil {
+		return m.Step
+	}
+	return nil
+}
+
+func (m *SendRequest) GetNow() float64 {
+	if m != nil {
+		return m.Now
+	}
+	return 0
+}
+
+type SendResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendResponse) Reset()         { *m = SendResponse{} }
+func (m *SendResponse) String() string { return proto.CompactTextString(m) }
+func (*SendResponse) ProtoMessage()    {}
+func (*SendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_22a625af4bc1cc87, []int{5}
+}
+
+func (m *SendResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendResponse.Unmarshal(m, b)
+}
+func (m *SendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendResponse.Marshal(b, m, deterministic)
+}
+func (m *SendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendResponse.Merge(m, src)
+}
+func (m *SendResponse) XXX_Size() int {
+	return xxx_messageInfo_SendResponse.Size(m)
+}
+func (m *SendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*InflatedCell)(nil), "InflatedCell")
+	proto.RegisterMapType((map[string]float64)(nil), "InflatedCell.MetricsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "InflatedCell.PropertiesEntry")
+	proto.RegisterType((*InflatedColumn)(nil), "InflatedColumn")
+	proto.RegisterMapType((map[string]*InflatedCell)(nil), "InflatedColumn.CellsEntry")
+	proto.RegisterType((*ReadColumnsRequest)(nil), "ReadColumnsRequest")
+	proto.RegisterType((*ReadColumnsResponse)(nil), "ReadColumnsResponse")
+	proto.RegisterType((*SendRequest)(nil), "SendRequest")
+	proto.RegisterType((*SendResponse)(nil), "SendResponse")
+}
+
+func init() {
+	proto.RegisterFile("plugin.proto", fileDescriptor_22a625af4bc1cc87)
+}
+
+var fileDescriptor_22a625af4bc1cc87 = []byte{
+	// 714 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x51, 0x6b, 0xdb, 0x3a,
+	0x14, 0xae, 0xd3, 0xc4, 0xbe, 0x39, 0x76, 0xd3, 0xa2, 0x86, 0x5e, 0x91, 0x7b, 0x2f, 0x37, 0x4b,
+	0x18, 0xb4, 0x2f, 0x4e, 0xc9, 0xf6, 0x30, 0x0a, 0x85, 0xc1, 0x56, 0x4a, 0xc7, 0x0a, 0x45, 0xe9,
+	0x9e, 0x83, 0x6c, 0xab, 0xa9, 0xa9, 0x62, 0x79, 0x92, 0xbc, 0x91, 0xe7, 0xb1, 0x1f, 0xb0, 0xff,
+	0xb1, 0x1f, 0x39, 0x24, 0xcb, 0xad, 0xdb, 0x15, 0xf6, 0x92, 0x1c, 0x7d, 0xdf, 0x39, 0x47, 0xe7,
+	0x7c, 0x47, 0xc7, 0x10, 0x95, 0xbc, 0x5a, 0xe5, 0x45, 0x5c, 0x4a, 0xa1, 0xc5, 0xe8, 0xa0, 0x4c,
+	0x66, 0xa9, 0x28, 0x6e, 0xf2, 0x95, 0xfb, 0x73, 0xf8, 0xb0, 0x4c, 0x66, 0x4a, 0x53, 0xcd, 0xea,
+	0x5f, 0x87, 0x62, 0x83, 0x56, 0xeb, 0x35, 0x95, 0x9b, 0xe6, 0xdf, 0x31, 0xe3, 0x32, 0x99, 0x69,
+	0xa6, 0xf4, 0xd2, 0xb8, 0x57, 0xaa, 0x6d, 0xd7, 0x1e, 0x93, 0x1f, 0x5d, 0x88, 0x2e, 0x8a, 0x1b,
+	0x4e, 0x35, 0xcb, 0xde, 0x31, 0xce, 0xd1, 0x14, 0x7c, 0xc9, 0x54, 0xc5, 0x35, 0xf6, 0xc6, 0xde,
+	0xe1, 0x60, 0x1e, 0xc6, 0xd7, 0x4c, 0xe9, 0x85, 0x8d, 0x21, 0x8e, 0x42, 0x03, 0xe8, 0xe4, 0x19,
+	0xee, 0x8c, 0xbd, 0xc3, 0x3e, 0xe9, 0xe4, 0x19, 0xfa, 0x1b, 0x82, 0x94, 0x71, 0xbe, 0xcc, 0x33,
+	0xbc, 0x6d, 0x41, 0xdf, 0x1c, 0x2f, 0x32, 0x84, 0xa0, 0x9b, 0xa7, 0xa2, 0xc0, 0x5d, 0x8b, 0x5a,
+	0x1b, 0x61, 0x08, 0xd6, 0x4c, 0x29, 0xba, 0x62, 0xb8, 0x67, 0xe1, 0xe6, 0x88, 0x5e, 0x40, 0x74,
+	0x53, 0x71, 0xbe, 0x6c, 0x68, 0xdf, 0xd2, 0xa1, 0xc1, 0x2e, 0x9d, 0xcb, 0x6b, 0x13, 0xac, 0x65,
+	0x9e, 0x2a, 0x1c, 0x8c, 0xb7, 0x0f, 0xc3, 0xf9, 0x28, 0x6e, 0x97, 0x1f, 0x5f, 0xd6, 0xe4, 0x59,
+	0xa1, 0xe5, 0x86, 0x34, 0xae, 0x68, 0x0a, 0x3b, 0x95, 0x62, 0x72, 0x59, 0x4a, 0x51, 0x32, 0xa9,
+	0x37, 0xf8, 0x2f, 0x9b, 0x39, 0x32, 0xe0, 0x95, 0xc3, 0xd0, 0x29, 0x80, 0xe3, 0x73, 0xa6, 0x70,
+	0xdf, 0x66, 0xff, 0xef, 0x71, 0xf6, 0xab, 0x7b, 0xbe, 0xbe, 0xa0, 0x15, 0x80, 0xfe, 0x81, 0x1e,
+	0xcf, 0x8b, 0x3b, 0x85, 0xc1, 0x46, 0xf6, 0xe2, 0x8f, 0x79, 0x71, 0x47, 0x6a, 0x0c, 0x1d, 0x03,
+	0x94, 0x54, 0xd2, 0x35, 0xd3, 0x4c, 0x2a, 0x1c, 0x5a, 0x8f, 0xbd, 0xf8, 0xaa, 0x81, 0x88, 0x95,
+	0x95, 0xb4, 0x7c, 0x46, 0x27, 0x10, 0xb5, 0x7b, 0x41, 0x7b, 0xb0, 0x7d, 0xc7, 0x36, 0x76, 0x28,
+	0x7d, 0x62, 0x4c, 0x34, 0x84, 0xde, 0x17, 0xca, 0x2b, 0x66, 0xe7, 0xe0, 0x91, 0xfa, 0x70, 0xd2,
+	0x79, 0xe3, 0x8d, 0x4e, 0x61, 0xf7, 0x49, 0xa5, 0x7f, 0x0a, 0xef, 0xb7, 0xc2, 0x27, 0x3f, 0x3d,
+	0x18, 0xdc, 0xb7, 0x2d, 0x78, 0xb5, 0x2e, 0xd0, 0xff, 0xe0, 0xa7, 0xd6, 0xb2, 0x19, 0xc2, 0x79,
+	0x10, 0xd7, 0x04, 0x71, 0x30, 0x3a, 0x86, 0x9e, 0x19, 0xb9, 0xc2, 0x9d, 0xa7, 0x53, 0xb1, 0x7c,
+	0x6c, 0xe4, 0x73, 0xa2, 0xd5, 0x8e, 0xa3, 0x73, 0x80, 0x07, 0xf0, 0x99, 0xfa, 0xa6, 0xed, 0xfa,
+	0xc2, 0xf9, 0xce, 0xa3, 0x49, 0xb4, 0xcb, 0xfd, 0xee, 0x01, 0x22, 0x8c, 0xba, 0x9b, 0x14, 0x61,
+	0x9f, 0x2b, 0xa6, 0x34, 0x3a, 0x02, 0xb0, 0xcf, 0x7d, 0x25, 0x45, 0x55, 0xba, 0xb2, 0xc1, 0x3e,
+	0xe6, 0x73, 0x83, 0x90, 0xbe, 0x6e, 0x4c, 0x74, 0x0c, 0xa1, 0xe0, 0xd9, 0xb2, 0x6e, 0xa5, 0x69,
+	0x61, 0xf7, 0x49, 0x0b, 0x04, 0x04, 0x6f, 0xee, 0x30, 0xef, 0x5a, 0x69, 0x51, 0xda, 0xd7, 0xee,
+	0x11, 0x6b, 0x4f, 0xde, 0xc2, 0xfe, 0xa3, 0x32, 0x54, 0x29, 0x0a, 0xc5, 0xd0, 0x11, 0x04, 0x4d,
+	0x62, 0xef, 0xf9, 0xc4, 0x0d, 0x3f, 0xf9, 0xd6, 0x81, 0x70, 0xc1, 0x8a, 0xac, 0x69, 0x01, 0x43,
+	0x90, 0xde, 0xd2, 0xa2, 0x60, 0xdc, 0x09, 0xd3, 0x1c, 0xd1, 0x01, 0xf8, 0x9a, 0xca, 0x15, 0xd3,
+	0x6e, 0x7a, 0xee, 0x64, 0xea, 0x4a, 0x44, 0xb6, 0x71, 0x5b, 0x68, 0x6d, 0xf4, 0x2f, 0xf4, 0x33,
+	0xaa, 0x6e, 0x13, 0x41, 0x65, 0xe6, 0x16, 0xf1, 0x01, 0x30, 0xc2, 0x6b, 0x9a, 0xb8, 0x4d, 0x34,
+	0xa6, 0x59, 0x66, 0x4d, 0x93, 0x65, 0x25, 0xb9, 0x5b, 0x40, 0x5f, 0xd3, 0xe4, 0x93, 0xe4, 0x28,
+	0x86, 0xc0, 0x7d, 0x5e, 0x70, 0x60, 0xe5, 0x1c, 0xc6, 0xef, 0x9b, 0x3c, 0xd7, 0x34, 0x59, 0xd4,
+	0x1c, 0x69, 0x9c, 0xd0, 0xd4, 0x88, 0xc4, 0x4a, 0xbb, 0x6c, 0xa6, 0xed, 0x33, 0x95, 0x52, 0x4e,
+	0x75, 0x2e, 0x8a, 0x85, 0x66, 0x25, 0xb1, 0xa4, 0xb9, 0xbf, 0x10, 0x5f, 0x71, 0xdf, 0x0a, 0x69,
+	0xcc, 0xc9, 0x00, 0xa2, 0x5a, 0x84, 0x5a, 0xc0, 0xf9, 0x07, 0x88, 0x9c, 0x50, 0x8c, 0x66, 0x4c,
+	0xa2, 0x13, 0x08, 0x5b, 0x3a, 0xa3, 0xfd, 0xf8, 0xf7, 0xe1, 0x8f, 0x86, 0xf1, 0x33, 0xa3, 0x98,
+	0x6c, 0xcd, 0x67, 0xe0, 0x9b, 0xdc, 0x4c, 0xa2, 0x97, 0xd0, 0x35, 0x16, 0x8a, 0xe2, 0x96, 0xe2,
+	0xa3, 0x9d, 0xb8, 0x7d, 0xf5, 0x64, 0x2b, 0xf1, 0xed, 0x67, 0xf2, 0xd5, 0xaf, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x1a, 0x70, 0x34, 0x40, 0xa0, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ColumnReaderClient is the client API for ColumnReader service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ColumnReaderClient interface {
+	// Read the recent columns of a test group.
+	ReadColumns(ctx context.Context, in *ReadColumnsRequest, opts ...grpc.CallOption) (*ReadColumnsResponse, error)
+}
+
+type columnReaderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewColumnReaderClient(cc grpc.ClientConnInterface) ColumnReaderClient {
+	return &columnReaderClient{cc}
+}
+
+func (c *columnReaderClient) ReadColumns(ctx context.Context, in *ReadColumnsRequest, opts ...grpc.CallOption) (*ReadColumnsResponse, error) {
+	out := new(ReadColumnsResponse)
+	err := c.cc.Invoke(ctx, "/ColumnReader/ReadColumns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ColumnReaderServer is the server API for ColumnReader service.
+type ColumnReaderServer interface {
+	// Read the recent columns of a test group.
+	ReadColumns(context.Context, *ReadColumnsRequest) (*ReadColumnsResponse, error)
+}
+
+// UnimplementedColumnReaderServer can be embedded to have forward compatible implementations.
+type UnimplementedColumnReaderServer struct {
+}
+
+func (*UnimplementedColumnReaderServer) ReadColumns(ctx context.Context, req *ReadColumnsRequest) (*ReadColumnsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadColumns not implemented")
+}
+
+func RegisterColumnReaderServer(s *grpc.Server, srv ColumnReaderServer) {
+	s.RegisterService(&_ColumnReader_serviceDesc, srv)
+}
+
+func _ColumnReader_ReadColumns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadColumnsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColumnReaderServer).ReadColumns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ColumnReader/ReadColumns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColumnReaderServer).ReadColumns(ctx, req.(*ReadColumnsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ColumnReader_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ColumnReader",
+	HandlerType: (*ColumnReaderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadColumns",
+			Handler:    _ColumnReader_ReadColumns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
+}
+
+// SenderClient is the client API for Sender service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SenderClient interface {
+	// Deliver a notification to its target.
+	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+}
+
+type senderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSenderClient(cc grpc.ClientConnInterface) SenderClient {
+	return &senderClient{cc}
+}
+
+func (c *senderClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := c.cc.Invoke(ctx, "/Sender/Send", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SenderServer is the server API for Sender service.
+type SenderServer interface {
+	// Deliver a notification to its target.
+	Send(context.Context, *SendRequest) (*SendResponse, error)
+}
+
+// UnimplementedSenderServer can be embedded to have forward compatible implementations.
+type UnimplementedSenderServer struct {
+}
+
+func (*UnimplementedSenderServer) Send(ctx context.Context, req *SendRequest) (*SendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
+}
+
+func RegisterSenderServer(s *grpc.Server, srv SenderServer) {
+	s.RegisterService(&_Sender_serviceDesc, srv)
+}
+
+func _Sender_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SenderServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Sender/Send",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SenderServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Sender_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "Sender",
+	HandlerType: (*SenderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Send",
+			Handler:    _Sender_Send_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
+}
@@ -0,0 +1,377 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: alerts.proto
+
+package response
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Alert lifecycle metrics of a tab over a reporting period.
+type TabAlertMetrics struct {
+	Dashboard string `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	Tab       string `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	Team      string `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	// Number of alerts opened and resolved within the period.
+	Opened   int32 `protobuf:"varint,4,opt,name=opened,proto3" json:"opened,omitempty"`
+	Resolved int32 `protobuf:"varint,5,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	// Whether the tab currently fails.
+	Failing bool `protobuf:"varint,6,opt,name=failing,proto3" json:"failing,omitempty"`
+	// Mean seconds from opening to resolving the alerts resolved within the
+	// period, zero if none were.
+	MeanTimeToGreen      float64  `protobuf:"fixed64,7,opt,name=mean_time_to_green,json=meanTimeToGreen,proto3" json:"mean_time_to_green,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TabAlertMetrics) Reset()         { *m = TabAlertMetrics{} }
+func (m *TabAlertMetrics) String() string { return proto.CompactTextString(m) }
+func (*TabAlertMetrics) ProtoMessage()    {}
+func (*TabAlertMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20493709c38b81dc, []int{0}
+}
+
+func (m *TabAlertMetrics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TabAlertMetrics.Unmarshal(m, b)
+}
+func (m *TabAlertMetrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TabAlertMetrics.Marshal(b, m, deterministic)
+}
+func (m *TabAlertMetrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TabAlertMetrics.Merge(m, src)
+}
+func (m *TabAlertMetrics) XXX_Size() int {
+	return xxx_messageInfo_TabAlertMetrics.Size(m)
+}
+func (m *TabAlertMetrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_TabAlertMetrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TabAlertMetrics proto.InternalMessageInfo
+
+func (m *TabAlertMetrics) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *TabAlertMetrics) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *TabAlertMetrics) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *TabAlertMetrics) GetOpened() int32 {
+	if m != nil {
+		return m.Opened
+	}
+	return 0
+}
+
+func (m *TabAlertMetrics) GetResolved() int32 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+func (m *TabAlertMetrics) GetFailing() bool {
+	if m != nil {
+		return m.Failing
+	}
+	return false
+}
+
+func (m *TabAlertMetrics) GetMeanTimeToGreen() float64 {
+	if m != nil {
+		return m.MeanTimeToGreen
+	}
+	return 0
+}
+
+// Alert lifecycle metrics of a team's dashboards over a reporting period.
+type TeamAlertMetrics struct {
+	Team string `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	// Number of alerts opened and resolved within the period.
+	Opened   int32 `protobuf:"varint,2,opt,name=opened,proto3" json:"opened,omitempty"`
+	Resolved int32 `protobuf:"varint,3,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	// Number of the team's tabs which currently fail.
+	Failing int32 `protobuf:"varint,4,opt,name=failing,proto3" json:"failing,omitempty"`
+	// Mean seconds to resolve the alerts resolved within the period, zero if
+	// none were.
+	MeanTimeToResolve    float64  `protobuf:"fixed64,5,opt,name=mean_time_to_resolve,json=meanTimeToResolve,proto3" json:"mean_time_to_resolve,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamAlertMetrics) Reset()         { *m = TeamAlertMetrics{} }
+func (m *TeamAlertMetrics) String() string { return proto.CompactTextString(m) }
+func (*TeamAlertMetrics) ProtoMessage()    {}
+func (*TeamAlertMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20493709c38b81dc, []int{1}
+}
+
+func (m *TeamAlertMetrics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TeamAlertMetrics.Unmarshal(m, b)
+}
+func (m *TeamAlertMetrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TeamAlertMetrics.Marshal(b, m, deterministic)
+}
+func (m *TeamAlertMetrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamAlertMetrics.Merge(m, src)
+}
+func (m *TeamAlertMetrics) XXX_Size() int {
+	return xxx_messageInfo_TeamAlertMetrics.Size(m)
+}
+func (m *TeamAlertMetrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamAlertMetrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamAlertMetrics proto.InternalMessageInfo
+
+func (m *TeamAlertMetrics) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *TeamAlertMetrics) GetOpened() int32 {
+	if m != nil {
+		return m.Opened
+	}
+	return 0
+}
+
+func (m *TeamAlertMetrics) GetResolved() int32 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+func (m *TeamAlertMetrics) GetFailing() int32 {
+	if m != nil {
+		return m.Failing
+	}
+	return 0
+}
+
+func (m *TeamAlertMetrics) GetMeanTimeToResolve() float64 {
+	if m != nil {
+		return m.MeanTimeToResolve
+	}
+	return 0
+}
+
+// The alerts opened and resolved during a week.
+type WeeklyAlertCounts struct {
+	// Start of the week, in seconds since the epoch.
+	Start                float64  `protobuf:"fixed64,1,opt,name=start,proto3" json:"start,omitempty"`
+	Opened               int32    `protobuf:"varint,2,opt,name=opened,proto3" json:"opened,omitempty"`
+	Resolved             int32    `protobuf:"varint,3,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WeeklyAlertCounts) Reset()         { *m = WeeklyAlertCounts{} }
+func (m *WeeklyAlertCounts) String() string { return proto.CompactTextString(m) }
+func (*WeeklyAlertCounts) ProtoMessage()    {}
+func (*WeeklyAlertCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20493709c38b81dc, []int{2}
+}
+
+func (m *WeeklyAlertCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WeeklyAlertCounts.Unmarshal(m, b)
+}
+func (m *WeeklyAlertCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WeeklyAlertCounts.Marshal(b, m, deterministic)
+}
+func (m *WeeklyAlertCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WeeklyAlertCounts.Merge(m, src)
+}
+func (m *WeeklyAlertCounts) XXX_Size() int {
+	return xxx_messageInfo_WeeklyAlertCounts.Size(m)
+}
+func (m *WeeklyAlertCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_WeeklyAlertCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WeeklyAlertCounts proto.InternalMessageInfo
+
+func (m *WeeklyAlertCounts) GetStart() float64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *WeeklyAlertCounts) GetOpened() int32 {
+	if m != nil {
+		return m.Opened
+	}
+	return 0
+}
+
+func (m *WeeklyAlertCounts) GetResolved() int32 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+// AlertMetrics reports alert lifecycle metrics computed from the alert
+// history of the dashboard summaries.
+type AlertMetrics struct {
+	// The reporting period, in seconds since the epoch.
+	Start float64 `protobuf:"fixed64,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   float64 `protobuf:"fixed64,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Sorted by dashboard and tab.
+	Tabs []*TabAlertMetrics `protobuf:"bytes,3,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	// Sorted by team.
+	Teams []*TeamAlertMetrics `protobuf:"bytes,4,rep,name=teams,proto3" json:"teams,omitempty"`
+	// Each week of the period, oldest first.
+	Weeks                []*WeeklyAlertCounts `protobuf:"bytes,5,rep,name=weeks,proto3" json:"weeks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AlertMetrics) Reset()         { *m = AlertMetrics{} }
+func (m *AlertMetrics) String() string { return proto.CompactTextString(m) }
+func (*AlertMetrics) ProtoMessage()    {}
+func (*AlertMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20493709c38b81dc, []int{3}
+}
+
+func (m *AlertMetrics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertMetrics.Unmarshal(m, b)
+}
+func (m *AlertMetrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertMetrics.Marshal(b, m, deterministic)
+}
+func (m *AlertMetrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertMetrics.Merge(m, src)
+}
+func (m *AlertMetrics) XXX_Size() int {
+	return xxx_messageInfo_AlertMetrics.Size(m)
+}
+func (m *AlertMetrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertMetrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertMetrics proto.InternalMessageInfo
+
+func (m *AlertMetrics) GetStart() float64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *AlertMetrics) GetEnd() float64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *AlertMetrics) GetTabs() []*TabAlertMetrics {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+func (m *AlertMetrics) GetTeams() []*TeamAlertMetrics {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *AlertMetrics) GetWeeks() []*WeeklyAlertCounts {
+	if m != nil {
+		return m.Weeks
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TabAlertMetrics)(nil), "TabAlertMetrics")
+	proto.RegisterType((*TeamAlertMetrics)(nil), "TeamAlertMetrics")
+	proto.RegisterType((*WeeklyAlertCounts)(nil), "WeeklyAlertCounts")
+	proto.RegisterType((*AlertMetrics)(nil), "AlertMetrics")
+}
+
+func init() {
+	proto.RegisterFile("alerts.proto", fileDescriptor_20493709c38b81dc)
+}
+
+var fileDescriptor_20493709c38b81dc = []byte{
+	// 354 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x4d, 0x4b, 0xfb, 0x40,
+	0x10, 0xc6, 0xd9, 0x26, 0xe9, 0xcb, 0xfc, 0x0b, 0x4d, 0x87, 0xf2, 0x67, 0x11, 0x0f, 0x21, 0x08,
+	0x06, 0x84, 0x08, 0xfa, 0x09, 0xd4, 0x83, 0x27, 0x2f, 0x4b, 0x40, 0x10, 0xa4, 0x6c, 0xcc, 0x58,
+	0x43, 0x93, 0x6c, 0xc9, 0xae, 0x8a, 0x1f, 0xc8, 0xb3, 0x5f, 0xc5, 0x8f, 0x24, 0xd9, 0xf4, 0x85,
+	0xd6, 0xf6, 0xe2, 0x6d, 0xe6, 0x79, 0x66, 0x97, 0xdf, 0x33, 0x0c, 0x0c, 0x65, 0x41, 0xb5, 0xd1,
+	0xf1, 0xa2, 0x56, 0x46, 0x85, 0xdf, 0x0c, 0x46, 0x89, 0x4c, 0xaf, 0x1a, 0xed, 0x8e, 0x4c, 0x9d,
+	0x3f, 0x69, 0x3c, 0x86, 0x41, 0x26, 0xf5, 0x4b, 0xaa, 0x64, 0x9d, 0x71, 0x16, 0xb0, 0x68, 0x20,
+	0x36, 0x02, 0xfa, 0xe0, 0x18, 0x99, 0xf2, 0x8e, 0xd5, 0x9b, 0x12, 0x11, 0x5c, 0x43, 0xb2, 0xe4,
+	0x8e, 0x95, 0x6c, 0x8d, 0xff, 0xa1, 0xab, 0x16, 0x54, 0x51, 0xc6, 0xdd, 0x80, 0x45, 0x9e, 0x58,
+	0x76, 0x78, 0x04, 0xfd, 0x9a, 0xb4, 0x2a, 0xde, 0x28, 0xe3, 0x9e, 0x75, 0xd6, 0x3d, 0x72, 0xe8,
+	0x3d, 0xcb, 0xbc, 0xc8, 0xab, 0x19, 0xef, 0x06, 0x2c, 0xea, 0x8b, 0x55, 0x8b, 0x67, 0x80, 0x25,
+	0xc9, 0x6a, 0x6a, 0xf2, 0x92, 0xa6, 0x46, 0x4d, 0x67, 0x35, 0x51, 0xc5, 0x7b, 0x01, 0x8b, 0x98,
+	0x18, 0x35, 0x4e, 0x92, 0x97, 0x94, 0xa8, 0xdb, 0x46, 0x0e, 0x3f, 0x19, 0xf8, 0x09, 0xc9, 0x72,
+	0x2b, 0xd3, 0x8a, 0x91, 0xed, 0x65, 0xec, 0x1c, 0x64, 0x74, 0x0e, 0x33, 0xb6, 0xc1, 0xd6, 0x8c,
+	0xe7, 0x30, 0xd9, 0x62, 0x5c, 0x3e, 0xb1, 0x29, 0x99, 0x18, 0x6f, 0x28, 0x45, 0x6b, 0x84, 0x8f,
+	0x30, 0xbe, 0x27, 0x9a, 0x17, 0x1f, 0x16, 0xf4, 0x46, 0xbd, 0x56, 0x46, 0xe3, 0x04, 0x3c, 0x6d,
+	0x64, 0x6d, 0x2c, 0x28, 0x13, 0x6d, 0xf3, 0x17, 0xd2, 0xf0, 0x8b, 0xc1, 0x70, 0x6b, 0x05, 0xfb,
+	0xbf, 0xf6, 0xc1, 0xa1, 0xaa, 0xfd, 0x97, 0x89, 0xa6, 0xc4, 0x13, 0x70, 0x8d, 0x4c, 0x35, 0x77,
+	0x02, 0x27, 0xfa, 0x77, 0xe1, 0xc7, 0x3b, 0xe7, 0x21, 0xac, 0x8b, 0xa7, 0xe0, 0x35, 0x4b, 0xd4,
+	0xdc, 0xb5, 0x63, 0xe3, 0x78, 0x77, 0xe5, 0xa2, 0xf5, 0x31, 0x02, 0xef, 0x9d, 0x68, 0xae, 0xb9,
+	0x67, 0x07, 0x31, 0xfe, 0x15, 0x5a, 0xb4, 0x03, 0xd7, 0xf0, 0xd0, 0xd0, 0x2f, 0x54, 0xa5, 0x29,
+	0xed, 0xda, 0xf3, 0xbc, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0x74, 0x32, 0x4b, 0xe9, 0xae, 0x02,
+	0x00, 0x00,
+}
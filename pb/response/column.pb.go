@@ -0,0 +1,323 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: column.proto
+
+package response
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ColumnHeaderValue is the value of a configured column header.
+type ColumnHeaderValue struct {
+	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ColumnHeaderValue) Reset()         { *m = ColumnHeaderValue{} }
+func (m *ColumnHeaderValue) String() string { return proto.CompactTextString(m) }
+func (*ColumnHeaderValue) ProtoMessage()    {}
+func (*ColumnHeaderValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed724fae847ba464, []int{0}
+}
+
+func (m *ColumnHeaderValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ColumnHeaderValue.Unmarshal(m, b)
+}
+func (m *ColumnHeaderValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ColumnHeaderValue.Marshal(b, m, deterministic)
+}
+func (m *ColumnHeaderValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnHeaderValue.Merge(m, src)
+}
+func (m *ColumnHeaderValue) XXX_Size() int {
+	return xxx_messageInfo_ColumnHeaderValue.Size(m)
+}
+func (m *ColumnHeaderValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnHeaderValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnHeaderValue proto.InternalMessageInfo
+
+func (m *ColumnHeaderValue) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *ColumnHeaderValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// ColumnDetail describes a single column of a dashboard tab.
+type ColumnDetail struct {
+	Build string `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Milliseconds since the epoch.
+	Started float64              `protobuf:"fixed64,3,opt,name=started,proto3" json:"started,omitempty"`
+	Headers []*ColumnHeaderValue `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty"`
+	// Build metadata selected by the test group's column_metadata.
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// True when most tests in the column failed.
+	Broken bool `protobuf:"varint,6,opt,name=broken,proto3" json:"broken,omitempty"`
+	// Label of the result source of the column, if the group merges several.
+	Source string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	// Start time in the time zone and date format of the dashboard.
+	Date string `protobuf:"bytes,8,opt,name=date,proto3" json:"date,omitempty"`
+	// Builds merged into the column, when its test group sets strict_columns.
+	MergedBuilds []string `protobuf:"bytes,9,rep,name=merged_builds,json=mergedBuilds,proto3" json:"merged_builds,omitempty"`
+	// Cells of the merged builds dropped because their names collided.
+	DroppedCells int32 `protobuf:"varint,10,opt,name=dropped_cells,json=droppedCells,proto3" json:"dropped_cells,omitempty"`
+	// Metadata schema violations, when its test group sets strict_metadata.
+	MetadataViolations []string `protobuf:"bytes,11,rep,name=metadata_violations,json=metadataViolations,proto3" json:"metadata_violations,omitempty"`
+	// Builds skipped since the previous column, when its test group sets
+	// result_sampling.
+	SkippedBuilds int32 `protobuf:"varint,12,opt,name=skipped_builds,json=skippedBuilds,proto3" json:"skipped_builds,omitempty"`
+	// Builds older than this column which the tab omits, see TabPath's "older"
+	// resource to read them.
+	OlderBuilds int32 `protobuf:"varint,13,opt,name=older_builds,json=olderBuilds,proto3" json:"older_builds,omitempty"`
+	// Why an administrator overrode the results of the column's build, if so.
+	OverrideReason       string   `protobuf:"bytes,14,opt,name=override_reason,json=overrideReason,proto3" json:"override_reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ColumnDetail) Reset()         { *m = ColumnDetail{} }
+func (m *ColumnDetail) String() string { return proto.CompactTextString(m) }
+func (*ColumnDetail) ProtoMessage()    {}
+func (*ColumnDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed724fae847ba464, []int{1}
+}
+
+func (m *ColumnDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ColumnDetail.Unmarshal(m, b)
+}
+func (m *ColumnDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ColumnDetail.Marshal(b, m, deterministic)
+}
+func (m *ColumnDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnDetail.Merge(m, src)
+}
+func (m *ColumnDetail) XXX_Size() int {
+	return xxx_messageInfo_ColumnDetail.Size(m)
+}
+func (m *ColumnDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnDetail proto.InternalMessageInfo
+
+func (m *ColumnDetail) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *ColumnDetail) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ColumnDetail) GetStarted() float64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *ColumnDetail) GetHeaders() []*ColumnHeaderValue {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *ColumnDetail) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ColumnDetail) GetBroken() bool {
+	if m != nil {
+		return m.Broken
+	}
+	return false
+}
+
+func (m *ColumnDetail) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ColumnDetail) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *ColumnDetail) GetMergedBuilds() []string {
+	if m != nil {
+		return m.MergedBuilds
+	}
+	return nil
+}
+
+func (m *ColumnDetail) GetDroppedCells() int32 {
+	if m != nil {
+		return m.DroppedCells
+	}
+	return 0
+}
+
+func (m *ColumnDetail) GetMetadataViolations() []string {
+	if m != nil {
+		return m.MetadataViolations
+	}
+	return nil
+}
+
+func (m *ColumnDetail) GetSkippedBuilds() int32 {
+	if m != nil {
+		return m.SkippedBuilds
+	}
+	return 0
+}
+
+func (m *ColumnDetail) GetOlderBuilds() int32 {
+	if m != nil {
+		return m.OlderBuilds
+	}
+	return 0
+}
+
+func (m *ColumnDetail) GetOverrideReason() string {
+	if m != nil {
+		return m.OverrideReason
+	}
+	return ""
+}
+
+// ColumnList lists the columns of a dashboard tab, newest first.
+type ColumnList struct {
+	Columns              []*ColumnDetail `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ColumnList) Reset()         { *m = ColumnList{} }
+func (m *ColumnList) String() string { return proto.CompactTextString(m) }
+func (*ColumnList) ProtoMessage()    {}
+func (*ColumnList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed724fae847ba464, []int{2}
+}
+
+func (m *ColumnList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ColumnList.Unmarshal(m, b)
+}
+func (m *ColumnList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ColumnList.Marshal(b, m, deterministic)
+}
+func (m *ColumnList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnList.Merge(m, src)
+}
+func (m *ColumnList) XXX_Size() int {
+	return xxx_messageInfo_ColumnList.Size(m)
+}
+func (m *ColumnList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnList proto.InternalMessageInfo
+
+func (m *ColumnList) GetColumns() []*ColumnDetail {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ColumnHeaderValue)(nil), "ColumnHeaderValue")
+	proto.RegisterType((*ColumnDetail)(nil), "ColumnDetail")
+	proto.RegisterMapType((map[string]string)(nil), "ColumnDetail.MetadataEntry")
+	proto.RegisterType((*ColumnList)(nil), "ColumnList")
+}
+
+func init() {
+	proto.RegisterFile("column.proto", fileDescriptor_ed724fae847ba464)
+}
+
+var fileDescriptor_ed724fae847ba464 = []byte{
+	// 411 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0x8a, 0x14, 0x31,
+	0x10, 0xc6, 0xc9, 0xce, 0xff, 0x9a, 0xee, 0x51, 0xa3, 0x48, 0xd0, 0x4b, 0x3b, 0x22, 0xdb, 0x07,
+	0x19, 0x41, 0x11, 0x45, 0x0f, 0xc2, 0xae, 0x82, 0x07, 0xbd, 0xe4, 0xb0, 0x07, 0x2f, 0x43, 0x66,
+	0x52, 0x68, 0x33, 0x99, 0x4e, 0x93, 0xa4, 0x07, 0xf6, 0x8d, 0x7c, 0x4c, 0x49, 0x75, 0xb2, 0x38,
+	0xe8, 0xad, 0xbe, 0x5f, 0x7d, 0x5d, 0xa9, 0xea, 0x2a, 0x28, 0xf6, 0xd6, 0xf4, 0xc7, 0x76, 0xd3,
+	0x39, 0x1b, 0xec, 0xfa, 0x13, 0x3c, 0xb8, 0x26, 0xfd, 0x15, 0x95, 0x46, 0x77, 0xa3, 0x4c, 0x8f,
+	0xfc, 0x11, 0x4c, 0x8c, 0xda, 0xa1, 0x11, 0xac, 0x62, 0xf5, 0x42, 0x0e, 0x22, 0xd2, 0x53, 0x4c,
+	0x8b, 0x8b, 0x81, 0x92, 0x58, 0xff, 0x1e, 0x43, 0x31, 0x54, 0xf8, 0x8c, 0x41, 0x35, 0x64, 0xdb,
+	0xf5, 0x8d, 0xd1, 0xf9, 0x63, 0x12, 0x9c, 0xc3, 0xb8, 0x55, 0xc7, 0xfc, 0x2d, 0xc5, 0x5c, 0xc0,
+	0xcc, 0x07, 0xe5, 0x02, 0x6a, 0x31, 0xaa, 0x58, 0xcd, 0x64, 0x96, 0xfc, 0x25, 0xcc, 0x7e, 0x51,
+	0x3f, 0x5e, 0x8c, 0xab, 0x51, 0xbd, 0x7c, 0xcd, 0x37, 0xff, 0x74, 0x29, 0xb3, 0x85, 0xbf, 0x83,
+	0xf9, 0x11, 0x83, 0xd2, 0x2a, 0x28, 0x31, 0x21, 0xfb, 0xd3, 0xcd, 0xdf, 0x2d, 0x6d, 0xbe, 0xa7,
+	0xec, 0x97, 0x36, 0xb8, 0x5b, 0x79, 0x67, 0xe6, 0x8f, 0x61, 0xba, 0x73, 0xf6, 0x80, 0xad, 0x98,
+	0x56, 0xac, 0x9e, 0xcb, 0xa4, 0x22, 0xf7, 0xb6, 0x77, 0x7b, 0x14, 0x33, 0x6a, 0x37, 0xa9, 0x38,
+	0x84, 0x56, 0x01, 0xc5, 0x7c, 0x18, 0x22, 0xc6, 0xfc, 0x39, 0x94, 0x47, 0x74, 0x3f, 0x51, 0x6f,
+	0x69, 0x50, 0x2f, 0x16, 0xd5, 0xa8, 0x5e, 0xc8, 0x62, 0x80, 0x57, 0xc4, 0xa2, 0x49, 0x3b, 0xdb,
+	0x75, 0xa8, 0xb7, 0x7b, 0x34, 0xc6, 0x0b, 0xa8, 0x58, 0x3d, 0x91, 0x45, 0x82, 0xd7, 0x91, 0xf1,
+	0x57, 0xf0, 0x30, 0x77, 0xb6, 0x3d, 0x35, 0xd6, 0xa8, 0xd0, 0xd8, 0xd6, 0x8b, 0x25, 0xd5, 0xe3,
+	0x39, 0x75, 0x73, 0x97, 0xe1, 0x2f, 0x60, 0xe5, 0x0f, 0x0d, 0x55, 0x4d, 0x6f, 0x17, 0x54, 0xb6,
+	0x4c, 0x34, 0x3d, 0xfe, 0x0c, 0x0a, 0x6b, 0x34, 0xba, 0x6c, 0x2a, 0xc9, 0xb4, 0x24, 0x96, 0x2c,
+	0x97, 0x70, 0xcf, 0x9e, 0xd0, 0xb9, 0x46, 0xe3, 0xd6, 0xa1, 0xf2, 0xb6, 0x15, 0x2b, 0x9a, 0x71,
+	0x95, 0xb1, 0x24, 0xfa, 0xe4, 0x23, 0x94, 0x67, 0x3f, 0x93, 0xdf, 0x87, 0xd1, 0x01, 0x6f, 0xd3,
+	0xae, 0x63, 0xf8, 0xff, 0x33, 0xf9, 0x70, 0xf1, 0x9e, 0xad, 0xdf, 0x02, 0x0c, 0x6b, 0xf9, 0xd6,
+	0xf8, 0xc0, 0x2f, 0x61, 0x36, 0x5c, 0xa2, 0x17, 0x8c, 0x96, 0x56, 0x9e, 0x2d, 0x4d, 0xe6, 0xec,
+	0x15, 0xfc, 0x98, 0x3b, 0xf4, 0x9d, 0x6d, 0x3d, 0xee, 0xa6, 0x74, 0xb5, 0x6f, 0xfe, 0x04, 0x00,
+	0x00, 0xff, 0xff, 0x8e, 0xb3, 0xb9, 0xcc, 0xc5, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,170 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dashboards.proto
+
+package response
+
+import (
+	fmt "fmt"
+	config "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// DashboardListing names a dashboard and its tabs.
+type DashboardListing struct {
+	Name string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tabs []string `protobuf:"bytes,2,rep,name=tabs,proto3" json:"tabs,omitempty"`
+	// The federated instance serving the dashboard, empty when served locally.
+	Instance string `protobuf:"bytes,3,opt,name=instance,proto3" json:"instance,omitempty"`
+	// How to display the dashboard, when configured.
+	DisplayOptions       *config.DisplayOptions `protobuf:"bytes,4,opt,name=display_options,json=displayOptions,proto3" json:"display_options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *DashboardListing) Reset()         { *m = DashboardListing{} }
+func (m *DashboardListing) String() string { return proto.CompactTextString(m) }
+func (*DashboardListing) ProtoMessage()    {}
+func (*DashboardListing) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46d505848604c947, []int{0}
+}
+
+func (m *DashboardListing) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardListing.Unmarshal(m, b)
+}
+func (m *DashboardListing) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardListing.Marshal(b, m, deterministic)
+}
+func (m *DashboardListing) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardListing.Merge(m, src)
+}
+func (m *DashboardListing) XXX_Size() int {
+	return xxx_messageInfo_DashboardListing.Size(m)
+}
+func (m *DashboardListing) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardListing.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardListing proto.InternalMessageInfo
+
+func (m *DashboardListing) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DashboardListing) GetTabs() []string {
+	if m != nil {
+		return m.Tabs
+	}
+	return nil
+}
+
+func (m *DashboardListing) GetInstance() string {
+	if m != nil {
+		return m.Instance
+	}
+	return ""
+}
+
+func (m *DashboardListing) GetDisplayOptions() *config.DisplayOptions {
+	if m != nil {
+		return m.DisplayOptions
+	}
+	return nil
+}
+
+// DashboardList lists the dashboards served by the API, sorted by name.
+type DashboardList struct {
+	Dashboards           []*DashboardListing `protobuf:"bytes,1,rep,name=dashboards,proto3" json:"dashboards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DashboardList) Reset()         { *m = DashboardList{} }
+func (m *DashboardList) String() string { return proto.CompactTextString(m) }
+func (*DashboardList) ProtoMessage()    {}
+func (*DashboardList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46d505848604c947, []int{1}
+}
+
+func (m *DashboardList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DashboardList.Unmarshal(m, b)
+}
+func (m *DashboardList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DashboardList.Marshal(b, m, deterministic)
+}
+func (m *DashboardList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DashboardList.Merge(m, src)
+}
+func (m *DashboardList) XXX_Size() int {
+	return xxx_messageInfo_DashboardList.Size(m)
+}
+func (m *DashboardList) XXX_DiscardUnknown() {
+	xxx_messageInfo_DashboardList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DashboardList proto.InternalMessageInfo
+
+func (m *DashboardList) GetDashboards() []*DashboardListing {
+	if m != nil {
+		return m.Dashboards
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DashboardListing)(nil), "DashboardListing")
+	proto.RegisterType((*DashboardList)(nil), "DashboardList")
+}
+
+func init() {
+	proto.RegisterFile("dashboards.proto", fileDescriptor_46d505848604c947)
+}
+
+var fileDescriptor_46d505848604c947 = []byte{
+	// 203 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x48, 0x49, 0x2c, 0xce,
+	0x48, 0xca, 0x4f, 0x2c, 0x4a, 0x29, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x97, 0x12, 0x2b, 0x48,
+	0xd2, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0x87, 0x52, 0x10, 0x71, 0xa5, 0x09, 0x8c, 0x5c, 0x02,
+	0x2e, 0x30, 0xc5, 0x3e, 0x99, 0xc5, 0x25, 0x99, 0x79, 0xe9, 0x42, 0x42, 0x5c, 0x2c, 0x79, 0x89,
+	0xb9, 0xa9, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x60, 0x36, 0x48, 0xac, 0x24, 0x31, 0xa9,
+	0x58, 0x82, 0x49, 0x81, 0x19, 0x24, 0x06, 0x62, 0x0b, 0x49, 0x71, 0x71, 0x64, 0xe6, 0x15, 0x97,
+	0x24, 0xe6, 0x25, 0xa7, 0x4a, 0x30, 0x83, 0xd5, 0xc2, 0xf9, 0x42, 0x16, 0x5c, 0xfc, 0x29, 0x99,
+	0xc5, 0x05, 0x39, 0x89, 0x95, 0xf1, 0xf9, 0x05, 0x25, 0x99, 0xf9, 0x79, 0xc5, 0x12, 0x2c, 0x0a,
+	0x8c, 0x1a, 0xdc, 0x46, 0xfc, 0x7a, 0x2e, 0x10, 0x71, 0x7f, 0x88, 0x70, 0x10, 0x5f, 0x0a, 0x0a,
+	0x5f, 0xc9, 0x89, 0x8b, 0x17, 0xc5, 0x45, 0x42, 0x86, 0x5c, 0x5c, 0x08, 0xff, 0x48, 0x30, 0x2a,
+	0x30, 0x6b, 0x70, 0x1b, 0x09, 0xea, 0xa1, 0xbb, 0x3a, 0x08, 0x49, 0x91, 0x13, 0x57, 0x14, 0x47,
+	0x51, 0x6a, 0x71, 0x41, 0x7e, 0x5e, 0x71, 0x6a, 0x12, 0x1b, 0xd8, 0xa7, 0xc6, 0x80, 0x00, 0x00,
+	0x00, 0xff, 0xff, 0x5e, 0xc5, 0xcf, 0xbd, 0x15, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,234 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: feed.proto
+
+package response
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type HealthEvent_Kind int32
+
+const (
+	HealthEvent_KIND_UNSPECIFIED HealthEvent_Kind = 0
+	// The tab started failing.
+	HealthEvent_TAB_FAILING HealthEvent_Kind = 1
+	// The tab passed again.
+	HealthEvent_TAB_PASSING HealthEvent_Kind = 2
+	// The test started failing, opening an alert.
+	HealthEvent_TEST_FAILING HealthEvent_Kind = 3
+)
+
+var HealthEvent_Kind_name = map[int32]string{
+	0: "KIND_UNSPECIFIED",
+	1: "TAB_FAILING",
+	2: "TAB_PASSING",
+	3: "TEST_FAILING",
+}
+
+var HealthEvent_Kind_value = map[string]int32{
+	"KIND_UNSPECIFIED": 0,
+	"TAB_FAILING":      1,
+	"TAB_PASSING":      2,
+	"TEST_FAILING":     3,
+}
+
+func (x HealthEvent_Kind) String() string {
+	return proto.EnumName(HealthEvent_Kind_name, int32(x))
+}
+
+func (HealthEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d7a672c1337cb5ac, []int{0, 0}
+}
+
+// HealthEvent is a change in the health of a dashboard tab or one of its tests.
+type HealthEvent struct {
+	Kind HealthEvent_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=HealthEvent_Kind" json:"kind,omitempty"`
+	Tab  string           `protobuf:"bytes,2,opt,name=tab,proto3" json:"tab,omitempty"`
+	// Seconds since the epoch.
+	Time float64 `protobuf:"fixed64,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Display name of the test, for TEST_FAILING events.
+	Test string `protobuf:"bytes,4,opt,name=test,proto3" json:"test,omitempty"`
+	// First failing build of the test, for TEST_FAILING events.
+	Build                string   `protobuf:"bytes,5,opt,name=build,proto3" json:"build,omitempty"`
+	Message              string   `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthEvent) Reset()         { *m = HealthEvent{} }
+func (m *HealthEvent) String() string { return proto.CompactTextString(m) }
+func (*HealthEvent) ProtoMessage()    {}
+func (*HealthEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7a672c1337cb5ac, []int{0}
+}
+
+func (m *HealthEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthEvent.Unmarshal(m, b)
+}
+func (m *HealthEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthEvent.Marshal(b, m, deterministic)
+}
+func (m *HealthEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthEvent.Merge(m, src)
+}
+func (m *HealthEvent) XXX_Size() int {
+	return xxx_messageInfo_HealthEvent.Size(m)
+}
+func (m *HealthEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthEvent proto.InternalMessageInfo
+
+func (m *HealthEvent) GetKind() HealthEvent_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return HealthEvent_KIND_UNSPECIFIED
+}
+
+func (m *HealthEvent) GetTab() string {
+	if m != nil {
+		return m.Tab
+	}
+	return ""
+}
+
+func (m *HealthEvent) GetTime() float64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *HealthEvent) GetTest() string {
+	if m != nil {
+		return m.Test
+	}
+	return ""
+}
+
+func (m *HealthEvent) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *HealthEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// HealthFeed lists the health events of a dashboard's tabs, newest first.
+type HealthFeed struct {
+	Dashboard            string         `protobuf:"bytes,1,opt,name=dashboard,proto3" json:"dashboard,omitempty"`
+	Events               []*HealthEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HealthFeed) Reset()         { *m = HealthFeed{} }
+func (m *HealthFeed) String() string { return proto.CompactTextString(m) }
+func (*HealthFeed) ProtoMessage()    {}
+func (*HealthFeed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7a672c1337cb5ac, []int{1}
+}
+
+func (m *HealthFeed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthFeed.Unmarshal(m, b)
+}
+func (m *HealthFeed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthFeed.Marshal(b, m, deterministic)
+}
+func (m *HealthFeed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthFeed.Merge(m, src)
+}
+func (m *HealthFeed) XXX_Size() int {
+	return xxx_messageInfo_HealthFeed.Size(m)
+}
+func (m *HealthFeed) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthFeed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthFeed proto.InternalMessageInfo
+
+func (m *HealthFeed) GetDashboard() string {
+	if m != nil {
+		return m.Dashboard
+	}
+	return ""
+}
+
+func (m *HealthFeed) GetEvents() []*HealthEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("HealthEvent_Kind", HealthEvent_Kind_name, HealthEvent_Kind_value)
+	proto.RegisterType((*HealthEvent)(nil), "HealthEvent")
+	proto.RegisterType((*HealthFeed)(nil), "HealthFeed")
+}
+
+func init() {
+	proto.RegisterFile("feed.proto", fileDescriptor_d7a672c1337cb5ac)
+}
+
+var fileDescriptor_d7a672c1337cb5ac = []byte{
+	// 273 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x41, 0x4f, 0x83, 0x40,
+	0x10, 0x85, 0xdd, 0x42, 0xab, 0x1d, 0x1a, 0xc5, 0x49, 0x0f, 0x7b, 0xf0, 0x40, 0x88, 0x26, 0x9c,
+	0x38, 0xd4, 0x5f, 0x40, 0x2d, 0x28, 0xa9, 0x21, 0x04, 0xf0, 0xe2, 0xa5, 0x81, 0xec, 0x68, 0x89,
+	0x2d, 0x34, 0xec, 0xea, 0xef, 0xf6, 0x27, 0x18, 0x16, 0xab, 0xbd, 0xbd, 0xf9, 0xde, 0x4b, 0x26,
+	0xef, 0x01, 0xbc, 0x11, 0x09, 0xff, 0xd0, 0xb5, 0xaa, 0x75, 0xbf, 0x19, 0x58, 0x4f, 0x54, 0xee,
+	0xd4, 0x36, 0xfc, 0xa2, 0x46, 0xe1, 0x1d, 0x98, 0x1f, 0x75, 0x23, 0x38, 0x73, 0x98, 0x77, 0xb9,
+	0xb8, 0xf6, 0x4f, 0x3c, 0x7f, 0x5d, 0x37, 0x22, 0xd3, 0x36, 0xda, 0x60, 0xa8, 0xb2, 0xe2, 0x23,
+	0x87, 0x79, 0xd3, 0xac, 0x97, 0x88, 0x60, 0xaa, 0x7a, 0x4f, 0xdc, 0x70, 0x98, 0xc7, 0x32, 0xad,
+	0x35, 0x23, 0xa9, 0xb8, 0xa9, 0x63, 0x5a, 0xe3, 0x1c, 0xc6, 0xd5, 0x67, 0xbd, 0x13, 0x7c, 0xac,
+	0xe1, 0x70, 0x20, 0x87, 0xf3, 0x3d, 0x49, 0x59, 0xbe, 0x13, 0x9f, 0x68, 0x7e, 0x3c, 0xdd, 0x14,
+	0xcc, 0xfe, 0x2f, 0xce, 0xc1, 0x5e, 0xc7, 0xc9, 0x6a, 0xf3, 0x92, 0xe4, 0x69, 0xf8, 0x10, 0x47,
+	0x71, 0xb8, 0xb2, 0xcf, 0xf0, 0x0a, 0xac, 0x22, 0x58, 0x6e, 0xa2, 0x20, 0x7e, 0x8e, 0x93, 0x47,
+	0x9b, 0x1d, 0x41, 0x1a, 0xe4, 0x79, 0x0f, 0x46, 0x68, 0xc3, 0xac, 0x08, 0xf3, 0xe2, 0x2f, 0x62,
+	0xb8, 0x29, 0xc0, 0xd0, 0x2a, 0x22, 0x12, 0x78, 0x03, 0x53, 0x51, 0xca, 0x6d, 0xd5, 0x96, 0xdd,
+	0xd0, 0x7a, 0x9a, 0xfd, 0x03, 0xbc, 0x85, 0x09, 0xf5, 0xdd, 0x25, 0x1f, 0x39, 0x86, 0x67, 0x2d,
+	0x66, 0xa7, 0x83, 0x64, 0xbf, 0xde, 0x12, 0x5e, 0x2f, 0x3a, 0x92, 0x87, 0xb6, 0x91, 0x54, 0x4d,
+	0xf4, 0xae, 0xf7, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x45, 0xdb, 0x19, 0xd2, 0x65, 0x01, 0x00,
+	0x00,
+}
@@ -0,0 +1,248 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: history.proto
+
+package response
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// MessageRun summarizes the cells of a row which failed with the same message.
+type MessageRun struct {
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Build and start time of the earliest column with this message.
+	FirstBuild   string  `protobuf:"bytes,2,opt,name=first_build,json=firstBuild,proto3" json:"first_build,omitempty"`
+	FirstStarted float64 `protobuf:"fixed64,3,opt,name=first_started,json=firstStarted,proto3" json:"first_started,omitempty"`
+	// Build and start time of the latest column with this message.
+	LastBuild   string  `protobuf:"bytes,4,opt,name=last_build,json=lastBuild,proto3" json:"last_build,omitempty"`
+	LastStarted float64 `protobuf:"fixed64,5,opt,name=last_started,json=lastStarted,proto3" json:"last_started,omitempty"`
+	// Number of columns which failed with this message.
+	Count                int32    `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MessageRun) Reset()         { *m = MessageRun{} }
+func (m *MessageRun) String() string { return proto.CompactTextString(m) }
+func (*MessageRun) ProtoMessage()    {}
+func (*MessageRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_454388b49b309873, []int{0}
+}
+
+func (m *MessageRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageRun.Unmarshal(m, b)
+}
+func (m *MessageRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageRun.Marshal(b, m, deterministic)
+}
+func (m *MessageRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageRun.Merge(m, src)
+}
+func (m *MessageRun) XXX_Size() int {
+	return xxx_messageInfo_MessageRun.Size(m)
+}
+func (m *MessageRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageRun proto.InternalMessageInfo
+
+func (m *MessageRun) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *MessageRun) GetFirstBuild() string {
+	if m != nil {
+		return m.FirstBuild
+	}
+	return ""
+}
+
+func (m *MessageRun) GetFirstStarted() float64 {
+	if m != nil {
+		return m.FirstStarted
+	}
+	return 0
+}
+
+func (m *MessageRun) GetLastBuild() string {
+	if m != nil {
+		return m.LastBuild
+	}
+	return ""
+}
+
+func (m *MessageRun) GetLastStarted() float64 {
+	if m != nil {
+		return m.LastStarted
+	}
+	return 0
+}
+
+func (m *MessageRun) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// MessageHistory lists the distinct failure messages of a row, in the order
+// they first appeared.
+type MessageHistory struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Messages             []*MessageRun `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *MessageHistory) Reset()         { *m = MessageHistory{} }
+func (m *MessageHistory) String() string { return proto.CompactTextString(m) }
+func (*MessageHistory) ProtoMessage()    {}
+func (*MessageHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_454388b49b309873, []int{1}
+}
+
+func (m *MessageHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageHistory.Unmarshal(m, b)
+}
+func (m *MessageHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageHistory.Marshal(b, m, deterministic)
+}
+func (m *MessageHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageHistory.Merge(m, src)
+}
+func (m *MessageHistory) XXX_Size() int {
+	return xxx_messageInfo_MessageHistory.Size(m)
+}
+func (m *MessageHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageHistory proto.InternalMessageInfo
+
+func (m *MessageHistory) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MessageHistory) GetMessages() []*MessageRun {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// FullMessage is the untruncated message of a cell, stored outside the grid.
+type FullMessage struct {
+	// Hex encoded sha256 of the message.
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FullMessage) Reset()         { *m = FullMessage{} }
+func (m *FullMessage) String() string { return proto.CompactTextString(m) }
+func (*FullMessage) ProtoMessage()    {}
+func (*FullMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_454388b49b309873, []int{2}
+}
+
+func (m *FullMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FullMessage.Unmarshal(m, b)
+}
+func (m *FullMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FullMessage.Marshal(b, m, deterministic)
+}
+func (m *FullMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FullMessage.Merge(m, src)
+}
+func (m *FullMessage) XXX_Size() int {
+	return xxx_messageInfo_FullMessage.Size(m)
+}
+func (m *FullMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_FullMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FullMessage proto.InternalMessageInfo
+
+func (m *FullMessage) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *FullMessage) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*MessageRun)(nil), "MessageRun")
+	proto.RegisterType((*MessageHistory)(nil), "MessageHistory")
+	proto.RegisterType((*FullMessage)(nil), "FullMessage")
+}
+
+func init() {
+	proto.RegisterFile("history.proto", fileDescriptor_454388b49b309873)
+}
+
+var fileDescriptor_454388b49b309873 = []byte{
+	// 246 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x31, 0x4f, 0xc3, 0x30,
+	0x10, 0x85, 0xe5, 0xb4, 0x29, 0xed, 0xb9, 0x65, 0xb0, 0x18, 0xbc, 0x20, 0x42, 0x18, 0xc8, 0xd4,
+	0x01, 0x46, 0xb6, 0x0e, 0x88, 0xa5, 0x8b, 0xd9, 0x58, 0x90, 0x4b, 0x0d, 0x89, 0x94, 0xc6, 0x95,
+	0xcf, 0x1e, 0xf8, 0x7f, 0xfc, 0x30, 0xe4, 0x73, 0x5c, 0xe8, 0xe6, 0xfb, 0x9e, 0xf5, 0x49, 0xef,
+	0xc1, 0xaa, 0xed, 0xd0, 0x5b, 0xf7, 0xbd, 0x3e, 0x3a, 0xeb, 0x6d, 0xfd, 0xc3, 0x00, 0xb6, 0x06,
+	0x51, 0x7f, 0x19, 0x15, 0x06, 0x21, 0xe1, 0xe2, 0x90, 0x2e, 0xc9, 0x2a, 0xd6, 0x2c, 0x54, 0x3e,
+	0xc5, 0x0d, 0xf0, 0xcf, 0xce, 0xa1, 0x7f, 0xdf, 0x85, 0xae, 0xdf, 0xcb, 0x82, 0x52, 0x20, 0xb4,
+	0x89, 0x44, 0xdc, 0xc1, 0x2a, 0x7d, 0x40, 0xaf, 0x9d, 0x37, 0x7b, 0x39, 0xa9, 0x58, 0xc3, 0xd4,
+	0x92, 0xe0, 0x6b, 0x62, 0xe2, 0x1a, 0xa0, 0xd7, 0x27, 0xc9, 0x94, 0x24, 0x8b, 0x48, 0x92, 0xe3,
+	0x16, 0x96, 0x14, 0x67, 0x45, 0x49, 0x0a, 0x1e, 0x59, 0x36, 0x5c, 0x41, 0xf9, 0x61, 0xc3, 0xe0,
+	0xe5, 0xac, 0x62, 0x4d, 0xa9, 0xd2, 0x51, 0x6f, 0xe1, 0x72, 0x6c, 0xf1, 0x92, 0xea, 0x09, 0x01,
+	0xd3, 0x41, 0x1f, 0x72, 0x0d, 0x7a, 0x8b, 0x7b, 0x98, 0x8f, 0x75, 0x50, 0x16, 0xd5, 0xa4, 0xe1,
+	0x0f, 0x7c, 0xfd, 0x57, 0x5e, 0x9d, 0xc2, 0xfa, 0x09, 0xf8, 0x73, 0xe8, 0xfb, 0x31, 0x8b, 0xae,
+	0x56, 0x63, 0x9b, 0x5d, 0xf1, 0xfd, 0x7f, 0xa9, 0xe2, 0x6c, 0xa9, 0x0d, 0xbc, 0xcd, 0x9d, 0xc1,
+	0xa3, 0x1d, 0xd0, 0xec, 0x66, 0xb4, 0xf2, 0xe3, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x47, 0x0d,
+	0x74, 0x00, 0x76, 0x01, 0x00, 0x00,
+}
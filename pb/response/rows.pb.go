@@ -0,0 +1,496 @@
+/*
+Copyright The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rows.proto
+
+package response
+
+import (
+	fmt "fmt"
+	notes "github.com/GoogleCloudPlatform/testgrid/pb/notes"
+	state "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summary "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	test_status "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// RowSummary describes a row of a dashboard tab without its cells.
+type RowSummary struct {
+	// Index of the row in the tab, which selects it in a RowBatch.
+	Index int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Id    string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Cell counts over the recent columns.
+	Passes   int32 `protobuf:"varint,4,opt,name=passes,proto3" json:"passes,omitempty"`
+	Failures int32 `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	Flakes   int32 `protobuf:"varint,6,opt,name=flakes,proto3" json:"flakes,omitempty"`
+	// The most recent result other than NO_RESULT.
+	LatestResult test_status.TestStatus `protobuf:"varint,7,opt,name=latest_result,json=latestResult,proto3,enum=TestStatus" json:"latest_result,omitempty"`
+	// True when the row has an active alert.
+	Alert bool `protobuf:"varint,8,opt,name=alert,proto3" json:"alert,omitempty"`
+	// Build ID and start time, in seconds since epoch, of the row's most recent
+	// pass while it fails.
+	LastGreenBuildId string  `protobuf:"bytes,9,opt,name=last_green_build_id,json=lastGreenBuildId,proto3" json:"last_green_build_id,omitempty"`
+	LastGreenTime    float64 `protobuf:"fixed64,10,opt,name=last_green_time,json=lastGreenTime,proto3" json:"last_green_time,omitempty"`
+	// True when the row is a new test.
+	IsNew bool `protobuf:"varint,11,opt,name=is_new,json=isNew,proto3" json:"is_new,omitempty"`
+	// True when the row stopped appearing, such as after the test was removed.
+	Removed              bool     `protobuf:"varint,12,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RowSummary) Reset()         { *m = RowSummary{} }
+func (m *RowSummary) String() string { return proto.CompactTextString(m) }
+func (*RowSummary) ProtoMessage()    {}
+func (*RowSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f852f8df0ede062c, []int{0}
+}
+
+func (m *RowSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowSummary.Unmarshal(m, b)
+}
+func (m *RowSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowSummary.Marshal(b, m, deterministic)
+}
+func (m *RowSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowSummary.Merge(m, src)
+}
+func (m *RowSummary) XXX_Size() int {
+	return xxx_messageInfo_RowSummary.Size(m)
+}
+func (m *RowSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowSummary proto.InternalMessageInfo
+
+func (m *RowSummary) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RowSummary) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RowSummary) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RowSummary) GetPasses() int32 {
+	if m != nil {
+		return m.Passes
+	}
+	return 0
+}
+
+func (m *RowSummary) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *RowSummary) GetFlakes() int32 {
+	if m != nil {
+		return m.Flakes
+	}
+	return 0
+}
+
+func (m *RowSummary) GetLatestResult() test_status.TestStatus {
+	if m != nil {
+		return m.LatestResult
+	}
+	return test_status.TestStatus_NO_RESULT
+}
+
+func (m *RowSummary) GetAlert() bool {
+	if m != nil {
+		return m.Alert
+	}
+	return false
+}
+
+func (m *RowSummary) GetLastGreenBuildId() string {
+	if m != nil {
+		return m.LastGreenBuildId
+	}
+	return ""
+}
+
+func (m *RowSummary) GetLastGreenTime() float64 {
+	if m != nil {
+		return m.LastGreenTime
+	}
+	return 0
+}
+
+func (m *RowSummary) GetIsNew() bool {
+	if m != nil {
+		return m.IsNew
+	}
+	return false
+}
+
+func (m *RowSummary) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+// RowIndex lists the rows of a dashboard tab, so clients may request the
+// cells of visible rows in batches.
+type RowIndex struct {
+	Rows                 []*RowSummary `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RowIndex) Reset()         { *m = RowIndex{} }
+func (m *RowIndex) String() string { return proto.CompactTextString(m) }
+func (*RowIndex) ProtoMessage()    {}
+func (*RowIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f852f8df0ede062c, []int{1}
+}
+
+func (m *RowIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowIndex.Unmarshal(m, b)
+}
+func (m *RowIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowIndex.Marshal(b, m, deterministic)
+}
+func (m *RowIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowIndex.Merge(m, src)
+}
+func (m *RowIndex) XXX_Size() int {
+	return xxx_messageInfo_RowIndex.Size(m)
+}
+func (m *RowIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowIndex proto.InternalMessageInfo
+
+func (m *RowIndex) GetRows() []*RowSummary {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+// RowCells holds the cells of a row, like the Row of a Grid state.
+type RowCells struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Run-length encoded TestStatus values of each column.
+	Results []int32 `protobuf:"varint,3,rep,packed,name=results,proto3" json:"results,omitempty"`
+	// Messages, icons and cell IDs of each column with a result.
+	Messages []string `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	Icons    []string `protobuf:"bytes,5,rep,name=icons,proto3" json:"icons,omitempty"`
+	CellIds  []string `protobuf:"bytes,6,rep,name=cell_ids,json=cellIds,proto3" json:"cell_ids,omitempty"`
+	// Triage notes about the row or its cells.
+	Notes []*notes.Note `protobuf:"bytes,7,rep,name=notes,proto3" json:"notes,omitempty"`
+	// Configured cell properties, links and folded parameter results of each
+	// column with a result.
+	Properties     []*state.PropertyValues `protobuf:"bytes,8,rep,name=properties,proto3" json:"properties,omitempty"`
+	CellLinks      []*state.CellLinks      `protobuf:"bytes,9,rep,name=cell_links,json=cellLinks,proto3" json:"cell_links,omitempty"`
+	CellParameters []*state.CellParameters `protobuf:"bytes,10,rep,name=cell_parameters,json=cellParameters,proto3" json:"cell_parameters,omitempty"`
+	// Numerical data of the row, such as test durations.
+	Metrics              []*state.Metric `protobuf:"bytes,11,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RowCells) Reset()         { *m = RowCells{} }
+func (m *RowCells) String() string { return proto.CompactTextString(m) }
+func (*RowCells) ProtoMessage()    {}
+func (*RowCells) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f852f8df0ede062c, []int{2}
+}
+
+func (m *RowCells) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowCells.Unmarshal(m, b)
+}
+func (m *RowCells) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowCells.Marshal(b, m, deterministic)
+}
+func (m *RowCells) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowCells.Merge(m, src)
+}
+func (m *RowCells) XXX_Size() int {
+	return xxx_messageInfo_RowCells.Size(m)
+}
+func (m *RowCells) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowCells.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowCells proto.InternalMessageInfo
+
+func (m *RowCells) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RowCells) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RowCells) GetResults() []int32 {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *RowCells) GetMessages() []string {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *RowCells) GetIcons() []string {
+	if m != nil {
+		return m.Icons
+	}
+	return nil
+}
+
+func (m *RowCells) GetCellIds() []string {
+	if m != nil {
+		return m.CellIds
+	}
+	return nil
+}
+
+func (m *RowCells) GetNotes() []*notes.Note {
+	if m != nil {
+		return m.Notes
+	}
+	return nil
+}
+
+func (m *RowCells) GetProperties() []*state.PropertyValues {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *RowCells) GetCellLinks() []*state.CellLinks {
+	if m != nil {
+		return m.CellLinks
+	}
+	return nil
+}
+
+func (m *RowCells) GetCellParameters() []*state.CellParameters {
+	if m != nil {
+		return m.CellParameters
+	}
+	return nil
+}
+
+func (m *RowCells) GetMetrics() []*state.Metric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+// RowBatch holds the cells of a range of rows in a dashboard tab.
+type RowBatch struct {
+	// Index of the first row.
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Number of rows in the tab.
+	TotalRows            int32       `protobuf:"varint,2,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	Rows                 []*RowCells `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RowBatch) Reset()         { *m = RowBatch{} }
+func (m *RowBatch) String() string { return proto.CompactTextString(m) }
+func (*RowBatch) ProtoMessage()    {}
+func (*RowBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f852f8df0ede062c, []int{3}
+}
+
+func (m *RowBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowBatch.Unmarshal(m, b)
+}
+func (m *RowBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowBatch.Marshal(b, m, deterministic)
+}
+func (m *RowBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowBatch.Merge(m, src)
+}
+func (m *RowBatch) XXX_Size() int {
+	return xxx_messageInfo_RowBatch.Size(m)
+}
+func (m *RowBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowBatch proto.InternalMessageInfo
+
+func (m *RowBatch) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *RowBatch) GetTotalRows() int32 {
+	if m != nil {
+		return m.TotalRows
+	}
+	return 0
+}
+
+func (m *RowBatch) GetRows() []*RowCells {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+// RowStatsList holds the statistics of the rows of a dashboard tab, computed
+// when summarizing it.
+type RowStatsList struct {
+	Rows                 []*summary.RowStats `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RowStatsList) Reset()         { *m = RowStatsList{} }
+func (m *RowStatsList) String() string { return proto.CompactTextString(m) }
+func (*RowStatsList) ProtoMessage()    {}
+func (*RowStatsList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f852f8df0ede062c, []int{4}
+}
+
+func (m *RowStatsList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowStatsList.Unmarshal(m, b)
+}
+func (m *RowStatsList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowStatsList.Marshal(b, m, deterministic)
+}
+func (m *RowStatsList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowStatsList.Merge(m, src)
+}
+func (m *RowStatsList) XXX_Size() int {
+	return xxx_messageInfo_RowStatsList.Size(m)
+}
+func (m *RowStatsList) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowStatsList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowStatsList proto.InternalMessageInfo
+
+func (m *RowStatsList) GetRows() []*summary.RowStats {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RowSummary)(nil), "RowSummary")
+	proto.RegisterType((*RowIndex)(nil), "RowIndex")
+	proto.RegisterType((*RowCells)(nil), "RowCells")
+	proto.RegisterType((*RowBatch)(nil), "RowBatch")
+	proto.RegisterType((*RowStatsList)(nil), "RowStatsList")
+}
+
+func init() {
+	proto.RegisterFile("rows.proto", fileDescriptor_f852f8df0ede062c)
+}
+
+var fileDescriptor_f852f8df0ede062c = []byte{
+	// 588 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x53, 0x4b, 0x6f, 0x13, 0x3b,
+	0x14, 0xd6, 0x24, 0xcd, 0xeb, 0xa4, 0x8f, 0x2b, 0xdf, 0xde, 0x2b, 0x53, 0x54, 0x31, 0x64, 0x81,
+	0x06, 0xa1, 0x4e, 0x51, 0xd9, 0xb0, 0x2e, 0x0b, 0x54, 0xa9, 0x54, 0x95, 0x5b, 0xb1, 0x60, 0x41,
+	0xe4, 0x64, 0x4e, 0x8b, 0x55, 0xcf, 0x43, 0x3e, 0x0e, 0xa1, 0x7f, 0x97, 0x2d, 0x7f, 0x02, 0xf9,
+	0x38, 0x33, 0x0d, 0x62, 0x33, 0xf1, 0xf7, 0x38, 0xf2, 0xc9, 0xf7, 0xcd, 0x00, 0xb8, 0x7a, 0x4d,
+	0x79, 0xe3, 0x6a, 0x5f, 0x1f, 0x1d, 0x36, 0x8b, 0xd3, 0xaa, 0xf6, 0x48, 0xf1, 0xb9, 0xc5, 0x92,
+	0xd7, 0x1e, 0xe3, 0x73, 0xc3, 0xca, 0xc0, 0xae, 0xca, 0x52, 0xbb, 0xc7, 0xf6, 0x77, 0xa3, 0xa4,
+	0xcd, 0xe2, 0xd4, 0x23, 0xf9, 0x79, 0xb0, 0xaf, 0x68, 0xfb, 0x1c, 0x1d, 0xb3, 0x9f, 0x3d, 0x00,
+	0x55, 0xaf, 0x6f, 0xe2, 0x98, 0x38, 0x84, 0x81, 0xa9, 0x0a, 0xfc, 0x21, 0x93, 0x34, 0xc9, 0x06,
+	0x2a, 0x02, 0x21, 0x60, 0xa7, 0xd2, 0x25, 0xca, 0x5e, 0x9a, 0x64, 0x13, 0xc5, 0x67, 0xb1, 0x0f,
+	0x3d, 0x53, 0xc8, 0x3e, 0x33, 0x3d, 0x53, 0x88, 0xff, 0x61, 0xd8, 0x68, 0x22, 0x24, 0xb9, 0xc3,
+	0xa3, 0x1b, 0x24, 0x8e, 0x60, 0x7c, 0xa7, 0x8d, 0x5d, 0x39, 0x24, 0x39, 0x60, 0xa5, 0xc3, 0x61,
+	0xe6, 0xce, 0xea, 0x07, 0x24, 0x39, 0x8c, 0x33, 0x11, 0x89, 0xb7, 0xb0, 0x67, 0x35, 0xef, 0xea,
+	0x90, 0x56, 0xd6, 0xcb, 0x51, 0x9a, 0x64, 0xfb, 0x67, 0xd3, 0xfc, 0x16, 0xc9, 0xdf, 0xf0, 0xfa,
+	0x6a, 0x37, 0x3a, 0x14, 0x1b, 0xc2, 0xde, 0xda, 0xa2, 0xf3, 0x72, 0x9c, 0x26, 0xd9, 0x58, 0x45,
+	0x20, 0x4e, 0xe0, 0x5f, 0xab, 0xc9, 0xcf, 0xef, 0x1d, 0x62, 0x35, 0x5f, 0xac, 0x8c, 0x2d, 0xe6,
+	0xa6, 0x90, 0x13, 0x5e, 0xfa, 0x9f, 0x20, 0x7d, 0x0c, 0xca, 0x79, 0x10, 0x2e, 0x0a, 0xf1, 0x0a,
+	0x0e, 0xb6, 0xec, 0xde, 0x94, 0x28, 0x21, 0x4d, 0xb2, 0x44, 0xed, 0x75, 0xd6, 0x5b, 0x53, 0xa2,
+	0xf8, 0x0f, 0x86, 0x86, 0xe6, 0x15, 0xae, 0xe5, 0x34, 0xde, 0x66, 0xe8, 0x0a, 0xd7, 0x42, 0xc2,
+	0xc8, 0x61, 0x59, 0x7f, 0xc7, 0x42, 0xee, 0x32, 0xdf, 0xc2, 0xd9, 0x1b, 0x18, 0xab, 0x7a, 0x7d,
+	0xc1, 0x59, 0xbe, 0x80, 0x9d, 0x50, 0xb3, 0x4c, 0xd2, 0x7e, 0x36, 0x3d, 0x9b, 0xe6, 0x4f, 0xe1,
+	0x2b, 0x16, 0x66, 0xbf, 0x7a, 0xec, 0xfe, 0x80, 0xd6, 0x52, 0x97, 0x7c, 0xf2, 0x57, 0xf2, 0xbd,
+	0x2e, 0x79, 0xbe, 0x37, 0xa4, 0x40, 0xb2, 0x9f, 0xf6, 0xb3, 0x81, 0x6a, 0x61, 0xc8, 0xbe, 0x44,
+	0x22, 0x7d, 0xcf, 0xad, 0xf4, 0xb3, 0x89, 0xea, 0x30, 0x37, 0xbd, 0xac, 0xab, 0x50, 0x4a, 0x10,
+	0x22, 0x10, 0xcf, 0x60, 0xbc, 0x44, 0x6b, 0xe7, 0xa6, 0x08, 0x9d, 0x04, 0x61, 0x14, 0xf0, 0x45,
+	0x41, 0xe2, 0x39, 0x0c, 0xf8, 0x55, 0x94, 0x23, 0xde, 0x7c, 0x90, 0x5f, 0xd5, 0x1e, 0x55, 0xe4,
+	0xc4, 0x29, 0x40, 0xe3, 0xea, 0x06, 0x9d, 0x37, 0x48, 0x72, 0xcc, 0x8e, 0x83, 0xfc, 0x3a, 0x52,
+	0x8f, 0x9f, 0xb5, 0x5d, 0x21, 0xa9, 0x2d, 0x8b, 0x78, 0x0d, 0xc0, 0x17, 0x59, 0x53, 0x3d, 0x90,
+	0x9c, 0xf0, 0x00, 0xe4, 0xe1, 0x4f, 0x5f, 0x06, 0x46, 0x4d, 0x96, 0xed, 0x51, 0xbc, 0x87, 0x03,
+	0xb6, 0x36, 0xda, 0xe9, 0x12, 0x3d, 0x3a, 0x92, 0xb0, 0xb9, 0x20, 0xf8, 0xaf, 0x3b, 0x5a, 0xed,
+	0x2f, 0xff, 0xc0, 0xe2, 0x25, 0x8c, 0x4a, 0xf4, 0xce, 0x2c, 0x49, 0x4e, 0x79, 0x62, 0x94, 0x7f,
+	0x62, 0xac, 0x5a, 0x7e, 0xf6, 0x95, 0xc3, 0x3e, 0xd7, 0x7e, 0xf9, 0x2d, 0x44, 0x42, 0x5e, 0x3b,
+	0xdf, 0xbe, 0xfc, 0x0c, 0xc4, 0x31, 0x80, 0xaf, 0xbd, 0xb6, 0x73, 0xae, 0xad, 0xc7, 0xd2, 0x84,
+	0x19, 0x55, 0xaf, 0x49, 0x1c, 0x6f, 0xfa, 0xec, 0xf3, 0x05, 0x93, 0xbc, 0xad, 0x6e, 0xd3, 0xe6,
+	0x09, 0xec, 0x86, 0x86, 0xbd, 0xf6, 0x74, 0x69, 0xc8, 0x77, 0xf6, 0xe4, 0xc9, 0xce, 0x62, 0xb4,
+	0x9f, 0xc3, 0x97, 0xb1, 0x43, 0x6a, 0xea, 0x8a, 0x70, 0x31, 0xe4, 0x2f, 0xf4, 0xdd, 0xef, 0x00,
+	0x00, 0x00, 0xff, 0xff, 0x8a, 0x15, 0x5a, 0x9c, 0x17, 0x04, 0x00, 0x00,
+}
@@ -38,6 +38,36 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ColumnEdit_Action int32
+
+const (
+	ColumnEdit_ACTION_UNSPECIFIED ColumnEdit_Action = 0
+	// Remove the column and ignore its build from then on.
+	ColumnEdit_DELETE ColumnEdit_Action = 1
+	// Read the build again, along with every newer build.
+	ColumnEdit_REPROCESS ColumnEdit_Action = 2
+)
+
+var ColumnEdit_Action_name = map[int32]string{
+	0: "ACTION_UNSPECIFIED",
+	1: "DELETE",
+	2: "REPROCESS",
+}
+
+var ColumnEdit_Action_value = map[string]int32{
+	"ACTION_UNSPECIFIED": 0,
+	"DELETE":             1,
+	"REPROCESS":          2,
+}
+
+func (x ColumnEdit_Action) String() string {
+	return proto.EnumName(ColumnEdit_Action_name, int32(x))
+}
+
+func (ColumnEdit_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{19, 0}
+}
+
 // A metric and its values for each test cycle.
 type Metric struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -442,10 +472,42 @@ type Column struct {
 	// Custom hotlist ids.
 	HotlistIds string `protobuf:"bytes,5,opt,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
 	// An optional hint for the updater.
-	Hint                 string   `protobuf:"bytes,6,opt,name=hint,proto3" json:"hint,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Hint string `protobuf:"bytes,6,opt,name=hint,proto3" json:"hint,omitempty"`
+	// True when most tests in the column failed, typically due to an
+	// infrastructure problem rather than the tests themselves.
+	//
+	// Broken columns do not count toward alerts or flakiness.
+	Broken bool `protobuf:"varint,7,opt,name=broken,proto3" json:"broken,omitempty"`
+	// Selected build metadata, such as the node image or kernel version, from
+	// the test group's column_metadata allowlist.
+	Metadata map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Label of the result source of the column when the test group merges
+	// results from several sources, such as postsubmit or presubmit.
+	Source string `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	// Start time of the column in the time zone and date format of the
+	// dashboard, set by the tabulator.
+	Date string `protobuf:"bytes,10,opt,name=date,proto3" json:"date,omitempty"`
+	// Builds (hints) merged into this column when the test group sets
+	// strict_columns and several builds share its name and build.
+	MergedBuilds []string `protobuf:"bytes,11,rep,name=merged_builds,json=mergedBuilds,proto3" json:"merged_builds,omitempty"`
+	// Cells of the merged builds dropped because their names collided.
+	DroppedCells int32 `protobuf:"varint,12,opt,name=dropped_cells,json=droppedCells,proto3" json:"dropped_cells,omitempty"`
+	// Ways the started.json and finished.json of this column deviate from the
+	// metadata schema, when the test group sets strict_metadata.
+	MetadataViolations []string `protobuf:"bytes,13,rep,name=metadata_violations,json=metadataViolations,proto3" json:"metadata_violations,omitempty"`
+	// Builds between this column and the next older one which the test group's
+	// result_sampling skipped.
+	SkippedBuilds int32 `protobuf:"varint,14,opt,name=skipped_builds,json=skippedBuilds,proto3" json:"skipped_builds,omitempty"`
+	// Builds older than this column which the grid omits, either because they
+	// started before the test group's days_of_results or were never read.
+	//
+	// Only set on the oldest column.
+	OlderBuilds int32 `protobuf:"varint,15,opt,name=older_builds,json=olderBuilds,proto3" json:"older_builds,omitempty"`
+	// Override of the results of this column's build, if any.
+	Override             *BuildOverride `protobuf:"bytes,16,opt,name=override,proto3" json:"override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *Column) Reset()         { *m = Column{} }
@@ -515,6 +577,76 @@ func (m *Column) GetHint() string {
 	return ""
 }
 
+func (m *Column) GetBroken() bool {
+	if m != nil {
+		return m.Broken
+	}
+	return false
+}
+
+func (m *Column) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Column) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Column) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *Column) GetMergedBuilds() []string {
+	if m != nil {
+		return m.MergedBuilds
+	}
+	return nil
+}
+
+func (m *Column) GetDroppedCells() int32 {
+	if m != nil {
+		return m.DroppedCells
+	}
+	return 0
+}
+
+func (m *Column) GetMetadataViolations() []string {
+	if m != nil {
+		return m.MetadataViolations
+	}
+	return nil
+}
+
+func (m *Column) GetSkippedBuilds() int32 {
+	if m != nil {
+		return m.SkippedBuilds
+	}
+	return 0
+}
+
+func (m *Column) GetOlderBuilds() int32 {
+	if m != nil {
+		return m.OlderBuilds
+	}
+	return 0
+}
+
+func (m *Column) GetOverride() *BuildOverride {
+	if m != nil {
+		return m.Override
+	}
+	return nil
+}
+
 // TestGrid rows (also known as TestRow)
 type Row struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -544,10 +676,38 @@ type Row struct {
 	// An alert for the failure if there's a recent failure for this test case.
 	AlertInfo *AlertInfo `protobuf:"bytes,11,opt,name=alert_info,json=alertInfo,proto3" json:"alert_info,omitempty"`
 	// Values of a user-defined property found in test results for this row.
-	UserProperty         []string `protobuf:"bytes,12,rep,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UserProperty []string `protobuf:"bytes,12,rep,name=user_property,json=userProperty,proto3" json:"user_property,omitempty"`
+	// Values of the configured cell properties for this row.
+	Properties []*PropertyValues `protobuf:"bytes,13,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Links associated with each cell, such as attached screenshots or logs.
+	// Present for any column with a non-empty status (not NO_RESULT).
+	CellLinks []*CellLinks `protobuf:"bytes,14,rep,name=cell_links,json=cellLinks,proto3" json:"cell_links,omitempty"`
+	// Results of each parameter folded into the cell, for drill-down.
+	// Present for any column with a non-empty status (not NO_RESULT).
+	CellParameters []*CellParameters `protobuf:"bytes,15,rep,name=cell_parameters,json=cellParameters,proto3" json:"cell_parameters,omitempty"`
+	// Links for the row, such as to its source or owners, from the dashboard
+	// tab's row_link_templates.
+	Links []*Link `protobuf:"bytes,16,rep,name=links,proto3" json:"links,omitempty"`
+	// Version 2 results, as varint pairs of the zig-zag delta from the previous
+	// result and the run length.
+	PackedResults []byte `protobuf:"bytes,17,opt,name=packed_results,json=packedResults,proto3" json:"packed_results,omitempty"`
+	// Version 2 dictionary indices of the messages, icons and cell_ids.
+	MessageRefs []int32 `protobuf:"varint,18,rep,packed,name=message_refs,json=messageRefs,proto3" json:"message_refs,omitempty"`
+	IconRefs    []int32 `protobuf:"varint,19,rep,packed,name=icon_refs,json=iconRefs,proto3" json:"icon_refs,omitempty"`
+	CellIdRefs  []int32 `protobuf:"varint,20,rep,packed,name=cell_id_refs,json=cellIdRefs,proto3" json:"cell_id_refs,omitempty"`
+	// The most recent pass of a failing row, kept after its column leaves the
+	// grid. Empty while the row's latest result passes.
+	LastGreen *LastGreen `protobuf:"bytes,21,opt,name=last_green,json=lastGreen,proto3" json:"last_green,omitempty"`
+	// The start of the row's first column with a result, kept after the column
+	// leaves the grid.
+	FirstSeen *timestamp.Timestamp `protobuf:"bytes,22,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// True when first_seen is within the test group's new_test_days.
+	IsNew bool `protobuf:"varint,23,opt,name=is_new,json=isNew,proto3" json:"is_new,omitempty"`
+	// Set when the row stopped appearing for the test group's tombstone_columns.
+	Tombstone            *Tombstone `protobuf:"bytes,24,opt,name=tombstone,proto3" json:"tombstone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *Row) Reset()         { *m = Row{} }
@@ -652,6 +812,508 @@ func (m *Row) GetUserProperty() []string {
 	return nil
 }
 
+func (m *Row) GetProperties() []*PropertyValues {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Row) GetCellLinks() []*CellLinks {
+	if m != nil {
+		return m.CellLinks
+	}
+	return nil
+}
+
+func (m *Row) GetCellParameters() []*CellParameters {
+	if m != nil {
+		return m.CellParameters
+	}
+	return nil
+}
+
+func (m *Row) GetLinks() []*Link {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
+func (m *Row) GetPackedResults() []byte {
+	if m != nil {
+		return m.PackedResults
+	}
+	return nil
+}
+
+func (m *Row) GetMessageRefs() []int32 {
+	if m != nil {
+		return m.MessageRefs
+	}
+	return nil
+}
+
+func (m *Row) GetIconRefs() []int32 {
+	if m != nil {
+		return m.IconRefs
+	}
+	return nil
+}
+
+func (m *Row) GetCellIdRefs() []int32 {
+	if m != nil {
+		return m.CellIdRefs
+	}
+	return nil
+}
+
+func (m *Row) GetLastGreen() *LastGreen {
+	if m != nil {
+		return m.LastGreen
+	}
+	return nil
+}
+
+func (m *Row) GetFirstSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstSeen
+	}
+	return nil
+}
+
+func (m *Row) GetIsNew() bool {
+	if m != nil {
+		return m.IsNew
+	}
+	return false
+}
+
+func (m *Row) GetTombstone() *Tombstone {
+	if m != nil {
+		return m.Tombstone
+	}
+	return nil
+}
+
+// The last result of a row which stopped appearing.
+type Tombstone struct {
+	// The build ID of the last column with a result for the row.
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// The time the last column with a result for the row started.
+	Time *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Number of newer columns with other test results but none for the row.
+	MissingColumns       int32    `protobuf:"varint,3,opt,name=missing_columns,json=missingColumns,proto3" json:"missing_columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tombstone) Reset()         { *m = Tombstone{} }
+func (m *Tombstone) String() string { return proto.CompactTextString(m) }
+func (*Tombstone) ProtoMessage()    {}
+func (*Tombstone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{7}
+}
+
+func (m *Tombstone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tombstone.Unmarshal(m, b)
+}
+func (m *Tombstone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tombstone.Marshal(b, m, deterministic)
+}
+func (m *Tombstone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tombstone.Merge(m, src)
+}
+func (m *Tombstone) XXX_Size() int {
+	return xxx_messageInfo_Tombstone.Size(m)
+}
+func (m *Tombstone) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tombstone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tombstone proto.InternalMessageInfo
+
+func (m *Tombstone) GetBuildId() string {
+	if m != nil {
+		return m.BuildId
+	}
+	return ""
+}
+
+func (m *Tombstone) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *Tombstone) GetMissingColumns() int32 {
+	if m != nil {
+		return m.MissingColumns
+	}
+	return 0
+}
+
+// The most recent passing result of a row.
+type LastGreen struct {
+	// The build ID of the passing column, which is its first extra value (such
+	// as the commit) or else its build.
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// The time the passing column started.
+	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LastGreen) Reset()         { *m = LastGreen{} }
+func (m *LastGreen) String() string { return proto.CompactTextString(m) }
+func (*LastGreen) ProtoMessage()    {}
+func (*LastGreen) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{8}
+}
+
+func (m *LastGreen) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LastGreen.Unmarshal(m, b)
+}
+func (m *LastGreen) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LastGreen.Marshal(b, m, deterministic)
+}
+func (m *LastGreen) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastGreen.Merge(m, src)
+}
+func (m *LastGreen) XXX_Size() int {
+	return xxx_messageInfo_LastGreen.Size(m)
+}
+func (m *LastGreen) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastGreen.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastGreen proto.InternalMessageInfo
+
+func (m *LastGreen) GetBuildId() string {
+	if m != nil {
+		return m.BuildId
+	}
+	return ""
+}
+
+func (m *LastGreen) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// A link to a resource associated with a cell.
+type Link struct {
+	// Short name for the link, such as the file name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The URL of the resource.
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Link) Reset()         { *m = Link{} }
+func (m *Link) String() string { return proto.CompactTextString(m) }
+func (*Link) ProtoMessage()    {}
+func (*Link) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{9}
+}
+
+func (m *Link) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Link.Unmarshal(m, b)
+}
+func (m *Link) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Link.Marshal(b, m, deterministic)
+}
+func (m *Link) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Link.Merge(m, src)
+}
+func (m *Link) XXX_Size() int {
+	return xxx_messageInfo_Link.Size(m)
+}
+func (m *Link) XXX_DiscardUnknown() {
+	xxx_messageInfo_Link.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Link proto.InternalMessageInfo
+
+func (m *Link) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Link) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+// The links of a single cell.
+type CellLinks struct {
+	Links                []*Link  `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CellLinks) Reset()         { *m = CellLinks{} }
+func (m *CellLinks) String() string { return proto.CompactTextString(m) }
+func (*CellLinks) ProtoMessage()    {}
+func (*CellLinks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{10}
+}
+
+func (m *CellLinks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CellLinks.Unmarshal(m, b)
+}
+func (m *CellLinks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CellLinks.Marshal(b, m, deterministic)
+}
+func (m *CellLinks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CellLinks.Merge(m, src)
+}
+func (m *CellLinks) XXX_Size() int {
+	return xxx_messageInfo_CellLinks.Size(m)
+}
+func (m *CellLinks) XXX_DiscardUnknown() {
+	xxx_messageInfo_CellLinks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CellLinks proto.InternalMessageInfo
+
+func (m *CellLinks) GetLinks() []*Link {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
+// The result of a single parameterization of a test.
+type ParameterResult struct {
+	// The parameter list, such as [1-2] or (x: 1).
+	Parameters string `protobuf:"bytes,1,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	// The Result enum of this parameterization.
+	Result int32 `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	// Short description of the result.
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParameterResult) Reset()         { *m = ParameterResult{} }
+func (m *ParameterResult) String() string { return proto.CompactTextString(m) }
+func (*ParameterResult) ProtoMessage()    {}
+func (*ParameterResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{11}
+}
+
+func (m *ParameterResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParameterResult.Unmarshal(m, b)
+}
+func (m *ParameterResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParameterResult.Marshal(b, m, deterministic)
+}
+func (m *ParameterResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParameterResult.Merge(m, src)
+}
+func (m *ParameterResult) XXX_Size() int {
+	return xxx_messageInfo_ParameterResult.Size(m)
+}
+func (m *ParameterResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParameterResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParameterResult proto.InternalMessageInfo
+
+func (m *ParameterResult) GetParameters() string {
+	if m != nil {
+		return m.Parameters
+	}
+	return ""
+}
+
+func (m *ParameterResult) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *ParameterResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// The parameter results folded into a single cell.
+type CellParameters struct {
+	Results              []*ParameterResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CellParameters) Reset()         { *m = CellParameters{} }
+func (m *CellParameters) String() string { return proto.CompactTextString(m) }
+func (*CellParameters) ProtoMessage()    {}
+func (*CellParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{12}
+}
+
+func (m *CellParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CellParameters.Unmarshal(m, b)
+}
+func (m *CellParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CellParameters.Marshal(b, m, deterministic)
+}
+func (m *CellParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CellParameters.Merge(m, src)
+}
+func (m *CellParameters) XXX_Size() int {
+	return xxx_messageInfo_CellParameters.Size(m)
+}
+func (m *CellParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_CellParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CellParameters proto.InternalMessageInfo
+
+func (m *CellParameters) GetResults() []*ParameterResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// Values of a named cell property.
+type PropertyValues struct {
+	// Name of the property, from TestGroup.cell_properties.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The value of the property for each cell, empty when missing.
+	// Present for any column with a non-empty status (not NO_RESULT).
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PropertyValues) Reset()         { *m = PropertyValues{} }
+func (m *PropertyValues) String() string { return proto.CompactTextString(m) }
+func (*PropertyValues) ProtoMessage()    {}
+func (*PropertyValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{13}
+}
+
+func (m *PropertyValues) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PropertyValues.Unmarshal(m, b)
+}
+func (m *PropertyValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PropertyValues.Marshal(b, m, deterministic)
+}
+func (m *PropertyValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PropertyValues.Merge(m, src)
+}
+func (m *PropertyValues) XXX_Size() int {
+	return xxx_messageInfo_PropertyValues.Size(m)
+}
+func (m *PropertyValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_PropertyValues.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PropertyValues proto.InternalMessageInfo
+
+func (m *PropertyValues) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PropertyValues) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// A shard of older columns moved out of a grid, which is itself a grid
+// holding those columns.
+type ArchiveShard struct {
+	// Location of the shard's grid.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Start time of the shard's oldest and newest columns, in milliseconds.
+	OldestStarted float64 `protobuf:"fixed64,2,opt,name=oldest_started,json=oldestStarted,proto3" json:"oldest_started,omitempty"`
+	NewestStarted float64 `protobuf:"fixed64,3,opt,name=newest_started,json=newestStarted,proto3" json:"newest_started,omitempty"`
+	// Number of columns in the shard.
+	Columns              int32    `protobuf:"varint,4,opt,name=columns,proto3" json:"columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveShard) Reset()         { *m = ArchiveShard{} }
+func (m *ArchiveShard) String() string { return proto.CompactTextString(m) }
+func (*ArchiveShard) ProtoMessage()    {}
+func (*ArchiveShard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{14}
+}
+
+func (m *ArchiveShard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveShard.Unmarshal(m, b)
+}
+func (m *ArchiveShard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveShard.Marshal(b, m, deterministic)
+}
+func (m *ArchiveShard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveShard.Merge(m, src)
+}
+func (m *ArchiveShard) XXX_Size() int {
+	return xxx_messageInfo_ArchiveShard.Size(m)
+}
+func (m *ArchiveShard) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveShard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveShard proto.InternalMessageInfo
+
+func (m *ArchiveShard) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ArchiveShard) GetOldestStarted() float64 {
+	if m != nil {
+		return m.OldestStarted
+	}
+	return 0
+}
+
+func (m *ArchiveShard) GetNewestStarted() float64 {
+	if m != nil {
+		return m.NewestStarted
+	}
+	return 0
+}
+
+func (m *ArchiveShard) GetColumns() int32 {
+	if m != nil {
+		return m.Columns
+	}
+	return 0
+}
+
 // A single table of test results backing a dashboard tab.
 type Grid struct {
 	// A cycle of test results, not including the results. In the TestGrid client,
@@ -673,17 +1335,29 @@ type Grid struct {
 	// Clusters of failures for a TestResultTable instance.
 	Cluster []*Cluster `protobuf:"bytes,10,rep,name=cluster,proto3" json:"cluster,omitempty"`
 	// Most recent timestamp that clusters have processed.
-	MostRecentClusterTimestamp float64  `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	MostRecentClusterTimestamp float64 `protobuf:"fixed64,11,opt,name=most_recent_cluster_timestamp,json=mostRecentClusterTimestamp,proto3" json:"most_recent_cluster_timestamp,omitempty"`
+	// Set when the group is archived, in which case the full state is stored at
+	// this path and this grid only holds the latest column.
+	ArchivePath string `protobuf:"bytes,12,opt,name=archive_path,json=archivePath,proto3" json:"archive_path,omitempty"`
+	// Version of the row encoding, where unset means 1.
+	// Version 2 rows store packed_results and refs into the dictionary instead
+	// of results, messages, icons and cell_ids.
+	Version int32 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	// Distinct cell strings of version 2 rows, starting with the empty string.
+	Dictionary []string `protobuf:"bytes,14,rep,name=dictionary,proto3" json:"dictionary,omitempty"`
+	// Shards holding the columns which the updater moved out of this grid after
+	// the test group's archive_columns_after_days, newest first.
+	ArchiveShards        []*ArchiveShard `protobuf:"bytes,15,rep,name=archive_shards,json=archiveShards,proto3" json:"archive_shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *Grid) Reset()         { *m = Grid{} }
 func (m *Grid) String() string { return proto.CompactTextString(m) }
 func (*Grid) ProtoMessage()    {}
 func (*Grid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{7}
+	return fileDescriptor_a888679467bb7853, []int{15}
 }
 
 func (m *Grid) XXX_Unmarshal(b []byte) error {
@@ -767,6 +1441,34 @@ func (m *Grid) GetMostRecentClusterTimestamp() float64 {
 	return 0
 }
 
+func (m *Grid) GetArchivePath() string {
+	if m != nil {
+		return m.ArchivePath
+	}
+	return ""
+}
+
+func (m *Grid) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Grid) GetDictionary() []string {
+	if m != nil {
+		return m.Dictionary
+	}
+	return nil
+}
+
+func (m *Grid) GetArchiveShards() []*ArchiveShard {
+	if m != nil {
+		return m.ArchiveShards
+	}
+	return nil
+}
+
 // A cluster of failures grouped by test status and message for a test results
 // table.
 type Cluster struct {
@@ -785,7 +1487,7 @@ func (m *Cluster) Reset()         { *m = Cluster{} }
 func (m *Cluster) String() string { return proto.CompactTextString(m) }
 func (*Cluster) ProtoMessage()    {}
 func (*Cluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{8}
+	return fileDescriptor_a888679467bb7853, []int{16}
 }
 
 func (m *Cluster) XXX_Unmarshal(b []byte) error {
@@ -842,7 +1544,7 @@ func (m *ClusterRow) Reset()         { *m = ClusterRow{} }
 func (m *ClusterRow) String() string { return proto.CompactTextString(m) }
 func (*ClusterRow) ProtoMessage()    {}
 func (*ClusterRow) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a888679467bb7853, []int{9}
+	return fileDescriptor_a888679467bb7853, []int{17}
 }
 
 func (m *ClusterRow) XXX_Unmarshal(b []byte) error {
@@ -877,7 +1579,292 @@ func (m *ClusterRow) GetIndex() []int32 {
 	return nil
 }
 
+// Full cell messages too long to store inline in the grid, keyed by the hex
+// encoded sha256 of each message.
+//
+// Cells link to their full message, whereas the grid stores a truncated copy.
+type MessageStore struct {
+	Messages             map[string]string `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MessageStore) Reset()         { *m = MessageStore{} }
+func (m *MessageStore) String() string { return proto.CompactTextString(m) }
+func (*MessageStore) ProtoMessage()    {}
+func (*MessageStore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{18}
+}
+
+func (m *MessageStore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageStore.Unmarshal(m, b)
+}
+func (m *MessageStore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageStore.Marshal(b, m, deterministic)
+}
+func (m *MessageStore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageStore.Merge(m, src)
+}
+func (m *MessageStore) XXX_Size() int {
+	return xxx_messageInfo_MessageStore.Size(m)
+}
+func (m *MessageStore) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageStore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageStore proto.InternalMessageInfo
+
+func (m *MessageStore) GetMessages() map[string]string {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// An administrator's request to change a column of a test group's state.
+type ColumnEdit struct {
+	// Build ID of the column.
+	Build  string            `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	Action ColumnEdit_Action `protobuf:"varint,2,opt,name=action,proto3,enum=ColumnEdit_Action" json:"action,omitempty"`
+	// When the edit was requested, in seconds since epoch.
+	Requested float64 `protobuf:"fixed64,3,opt,name=requested,proto3" json:"requested,omitempty"`
+	// Who requested the edit.
+	Requester            string   `protobuf:"bytes,4,opt,name=requester,proto3" json:"requester,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ColumnEdit) Reset()         { *m = ColumnEdit{} }
+func (m *ColumnEdit) String() string { return proto.CompactTextString(m) }
+func (*ColumnEdit) ProtoMessage()    {}
+func (*ColumnEdit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{19}
+}
+
+func (m *ColumnEdit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ColumnEdit.Unmarshal(m, b)
+}
+func (m *ColumnEdit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ColumnEdit.Marshal(b, m, deterministic)
+}
+func (m *ColumnEdit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnEdit.Merge(m, src)
+}
+func (m *ColumnEdit) XXX_Size() int {
+	return xxx_messageInfo_ColumnEdit.Size(m)
+}
+func (m *ColumnEdit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnEdit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnEdit proto.InternalMessageInfo
+
+func (m *ColumnEdit) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *ColumnEdit) GetAction() ColumnEdit_Action {
+	if m != nil {
+		return m.Action
+	}
+	return ColumnEdit_ACTION_UNSPECIFIED
+}
+
+func (m *ColumnEdit) GetRequested() float64 {
+	if m != nil {
+		return m.Requested
+	}
+	return 0
+}
+
+func (m *ColumnEdit) GetRequester() string {
+	if m != nil {
+		return m.Requester
+	}
+	return ""
+}
+
+// Column edits awaiting the next update of a test group, stored beside its
+// grid.
+//
+// The updater removes reprocess edits once it applies them, and delete edits
+// once the build is older than days_of_results.
+type ColumnEdits struct {
+	Edits                []*ColumnEdit `protobuf:"bytes,1,rep,name=edits,proto3" json:"edits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ColumnEdits) Reset()         { *m = ColumnEdits{} }
+func (m *ColumnEdits) String() string { return proto.CompactTextString(m) }
+func (*ColumnEdits) ProtoMessage()    {}
+func (*ColumnEdits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{20}
+}
+
+func (m *ColumnEdits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ColumnEdits.Unmarshal(m, b)
+}
+func (m *ColumnEdits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ColumnEdits.Marshal(b, m, deterministic)
+}
+func (m *ColumnEdits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ColumnEdits.Merge(m, src)
+}
+func (m *ColumnEdits) XXX_Size() int {
+	return xxx_messageInfo_ColumnEdits.Size(m)
+}
+func (m *ColumnEdits) XXX_DiscardUnknown() {
+	xxx_messageInfo_ColumnEdits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ColumnEdits proto.InternalMessageInfo
+
+func (m *ColumnEdits) GetEdits() []*ColumnEdit {
+	if m != nil {
+		return m.Edits
+	}
+	return nil
+}
+
+// Forced results for the cells of a build, such as a run known to be invalid,
+// which leaves the build's artifacts untouched.
+type BuildOverride struct {
+	// Build ID of the columns to override.
+	Build string `protobuf:"bytes,1,opt,name=build,proto3" json:"build,omitempty"`
+	// The Result enum of every cell with a result, unless NO_RESULT.
+	Result int32 `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	// Message of every cell with a result, unless empty.
+	Annotation string `protobuf:"bytes,3,opt,name=annotation,proto3" json:"annotation,omitempty"`
+	// Why the build's results are wrong.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Who added the override.
+	Author string `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	// When the override was added, in seconds since epoch.
+	Created              float64  `protobuf:"fixed64,6,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuildOverride) Reset()         { *m = BuildOverride{} }
+func (m *BuildOverride) String() string { return proto.CompactTextString(m) }
+func (*BuildOverride) ProtoMessage()    {}
+func (*BuildOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{21}
+}
+
+func (m *BuildOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuildOverride.Unmarshal(m, b)
+}
+func (m *BuildOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuildOverride.Marshal(b, m, deterministic)
+}
+func (m *BuildOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildOverride.Merge(m, src)
+}
+func (m *BuildOverride) XXX_Size() int {
+	return xxx_messageInfo_BuildOverride.Size(m)
+}
+func (m *BuildOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuildOverride proto.InternalMessageInfo
+
+func (m *BuildOverride) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *BuildOverride) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *BuildOverride) GetAnnotation() string {
+	if m != nil {
+		return m.Annotation
+	}
+	return ""
+}
+
+func (m *BuildOverride) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *BuildOverride) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *BuildOverride) GetCreated() float64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+// Overrides of the results of a test group's builds, stored beside its grid.
+//
+// The updater applies them whenever it merges columns, so removing an
+// override only restores the build's results once its column is reprocessed.
+type BuildOverrides struct {
+	Overrides            []*BuildOverride `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BuildOverrides) Reset()         { *m = BuildOverrides{} }
+func (m *BuildOverrides) String() string { return proto.CompactTextString(m) }
+func (*BuildOverrides) ProtoMessage()    {}
+func (*BuildOverrides) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a888679467bb7853, []int{22}
+}
+
+func (m *BuildOverrides) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuildOverrides.Unmarshal(m, b)
+}
+func (m *BuildOverrides) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuildOverrides.Marshal(b, m, deterministic)
+}
+func (m *BuildOverrides) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildOverrides.Merge(m, src)
+}
+func (m *BuildOverrides) XXX_Size() int {
+	return xxx_messageInfo_BuildOverrides.Size(m)
+}
+func (m *BuildOverrides) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildOverrides.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuildOverrides proto.InternalMessageInfo
+
+func (m *BuildOverrides) GetOverrides() []*BuildOverride {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("ColumnEdit_Action", ColumnEdit_Action_name, ColumnEdit_Action_value)
 	proto.RegisterType((*Metric)(nil), "Metric")
 	proto.RegisterType((*UpdatePhaseData)(nil), "UpdatePhaseData")
 	proto.RegisterType((*UpdateInfo)(nil), "UpdateInfo")
@@ -885,82 +1872,152 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "AlertInfo.PropertiesEntry")
 	proto.RegisterType((*TestMetadata)(nil), "TestMetadata")
 	proto.RegisterType((*Column)(nil), "Column")
+	proto.RegisterMapType((map[string]string)(nil), "Column.MetadataEntry")
 	proto.RegisterType((*Row)(nil), "Row")
+	proto.RegisterType((*Tombstone)(nil), "Tombstone")
+	proto.RegisterType((*LastGreen)(nil), "LastGreen")
+	proto.RegisterType((*Link)(nil), "Link")
+	proto.RegisterType((*CellLinks)(nil), "CellLinks")
+	proto.RegisterType((*ParameterResult)(nil), "ParameterResult")
+	proto.RegisterType((*CellParameters)(nil), "CellParameters")
+	proto.RegisterType((*PropertyValues)(nil), "PropertyValues")
+	proto.RegisterType((*ArchiveShard)(nil), "ArchiveShard")
 	proto.RegisterType((*Grid)(nil), "Grid")
 	proto.RegisterType((*Cluster)(nil), "Cluster")
 	proto.RegisterType((*ClusterRow)(nil), "ClusterRow")
+	proto.RegisterType((*MessageStore)(nil), "MessageStore")
+	proto.RegisterMapType((map[string]string)(nil), "MessageStore.MessagesEntry")
+	proto.RegisterType((*ColumnEdit)(nil), "ColumnEdit")
+	proto.RegisterType((*ColumnEdits)(nil), "ColumnEdits")
+	proto.RegisterType((*BuildOverride)(nil), "BuildOverride")
+	proto.RegisterType((*BuildOverrides)(nil), "BuildOverrides")
 }
 
 func init() { proto.RegisterFile("state.proto", fileDescriptor_a888679467bb7853) }
 
 var fileDescriptor_a888679467bb7853 = []byte{
-	// 1085 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x6f, 0x8f, 0xdb, 0x44,
-	0x13, 0x97, 0xf3, 0xdf, 0xe3, 0xe4, 0x92, 0xee, 0xd3, 0xa7, 0x32, 0x41, 0x55, 0x53, 0x17, 0x41,
-	0x40, 0xe0, 0x93, 0xc2, 0x0b, 0x50, 0x05, 0x2f, 0xca, 0x51, 0xaa, 0x3b, 0x71, 0x55, 0xb5, 0xbd,
-	0xbe, 0xb6, 0x1c, 0x7b, 0x2f, 0xb5, 0xea, 0x78, 0xad, 0xdd, 0x35, 0xb9, 0x7c, 0x10, 0x04, 0x7c,
-	0x13, 0x3e, 0x1e, 0x9a, 0xd9, 0x75, 0x92, 0xab, 0x90, 0x78, 0x75, 0x9e, 0xdf, 0xcc, 0xce, 0x4c,
-	0x66, 0x7e, 0x33, 0x73, 0x10, 0x68, 0x93, 0x1a, 0x11, 0xd7, 0x4a, 0x1a, 0x39, 0x7f, 0xb2, 0x91,
-	0x72, 0x53, 0x8a, 0x73, 0x92, 0xd6, 0xcd, 0xed, 0xb9, 0x29, 0xb6, 0x42, 0x9b, 0x74, 0x5b, 0x3b,
-	0x83, 0x47, 0xf5, 0xfa, 0x3c, 0x93, 0xd5, 0x6d, 0xb1, 0x71, 0x7f, 0x2c, 0x1e, 0xbd, 0x86, 0xc1,
-	0xb5, 0x30, 0xaa, 0xc8, 0x18, 0x83, 0x5e, 0x95, 0x6e, 0x45, 0xe8, 0x2d, 0xbc, 0xa5, 0xcf, 0xe9,
-	0x9b, 0x85, 0x30, 0x2c, 0xaa, 0xbc, 0xc8, 0x84, 0x0e, 0x3b, 0x8b, 0xee, 0xb2, 0xcf, 0x5b, 0x91,
-	0x3d, 0x82, 0xc1, 0x6f, 0x69, 0xd9, 0x08, 0x1d, 0x76, 0x17, 0xdd, 0xa5, 0xc7, 0x9d, 0x14, 0xbd,
-	0x83, 0xe9, 0xbb, 0x3a, 0x4f, 0x8d, 0x78, 0xf3, 0x3e, 0xd5, 0xe2, 0xe7, 0xd4, 0xa4, 0xec, 0x31,
-	0x40, 0x8d, 0x42, 0x72, 0xe2, 0xde, 0x27, 0xe4, 0x35, 0xc6, 0x78, 0x06, 0x13, 0xab, 0xd6, 0x22,
-	0x93, 0x55, 0x8e, 0x91, 0xbc, 0xa5, 0xc7, 0xc7, 0x04, 0xbe, 0xb5, 0x58, 0x74, 0x05, 0x60, 0xdd,
-	0x5e, 0x56, 0xb7, 0x92, 0xfd, 0x00, 0x0f, 0x1a, 0x92, 0x12, 0xfb, 0x32, 0x4f, 0x4d, 0x1a, 0x7a,
-	0x8b, 0xee, 0x32, 0x58, 0xcd, 0xe2, 0x8f, 0xc2, 0xf3, 0x69, 0x73, 0x1f, 0x88, 0xfe, 0xec, 0x83,
-	0xff, 0xa2, 0x14, 0xca, 0x90, 0xaf, 0xc7, 0x00, 0xb7, 0x69, 0x51, 0x26, 0x99, 0x6c, 0x2a, 0x43,
-	0xd9, 0xf5, 0xb9, 0x8f, 0xc8, 0x05, 0x02, 0x2c, 0x82, 0x09, 0xa9, 0xd7, 0x4d, 0x51, 0xe6, 0x49,
-	0x91, 0x53, 0x76, 0x3e, 0x0f, 0x10, 0xfc, 0x09, 0xb1, 0xcb, 0x9c, 0x7d, 0x07, 0xf4, 0x20, 0xc1,
-	0x9a, 0x87, 0xdd, 0x85, 0xb7, 0x0c, 0x56, 0xf3, 0xd8, 0x36, 0x24, 0x6e, 0x1b, 0x12, 0xdf, 0xb4,
-	0x0d, 0xe1, 0x23, 0x34, 0x46, 0x91, 0x2d, 0x60, 0x6c, 0x1f, 0x0a, 0x6d, 0xd0, 0x77, 0x8f, 0x7c,
-	0x53, 0x3e, 0x37, 0x42, 0x9b, 0xcb, 0x1c, 0xc3, 0xd7, 0xa9, 0xd6, 0xc7, 0xf0, 0x7d, 0x1b, 0x1e,
-	0xc1, 0x93, 0xf0, 0x64, 0x43, 0xe1, 0x07, 0xff, 0x1d, 0x1e, 0x8d, 0x29, 0xfc, 0x17, 0x30, 0xc5,
-	0x50, 0x8d, 0x12, 0xc9, 0x56, 0x68, 0x9d, 0x6e, 0x44, 0x38, 0x24, 0xf7, 0x67, 0x0e, 0xbe, 0xb6,
-	0x28, 0xd6, 0xc8, 0x26, 0x50, 0x16, 0xd5, 0x87, 0x70, 0x64, 0x3b, 0x48, 0xc8, 0xaf, 0x45, 0xf5,
-	0x81, 0x7d, 0x0e, 0xd3, 0xa3, 0x3a, 0x31, 0xe2, 0xce, 0x84, 0x3e, 0xd9, 0x4c, 0x0e, 0x36, 0x37,
-	0xe2, 0xce, 0xb0, 0xcf, 0xe0, 0xcc, 0xda, 0x35, 0xaa, 0xb4, 0x66, 0x40, 0x66, 0x63, 0x42, 0xdf,
-	0xa9, 0x92, 0xac, 0xce, 0xe1, 0x61, 0x99, 0x52, 0x45, 0xee, 0x17, 0x3e, 0x20, 0xdb, 0x07, 0x56,
-	0xf7, 0xcb, 0x49, 0xf9, 0xbf, 0x81, 0xff, 0x9d, 0x3e, 0x68, 0x8b, 0x79, 0x46, 0xf6, 0xb3, 0xa3,
-	0xbd, 0x2b, 0xe9, 0x73, 0x80, 0x5a, 0xc9, 0x5a, 0x28, 0x53, 0x08, 0x1d, 0x8e, 0x89, 0x35, 0xf3,
-	0xf8, 0x40, 0x88, 0xf8, 0xcd, 0x41, 0xf9, 0xb2, 0x32, 0x6a, 0xcf, 0x4f, 0xac, 0xd9, 0x13, 0x08,
-	0xde, 0x4b, 0x53, 0x16, 0x14, 0x41, 0x87, 0x93, 0x45, 0x17, 0xfb, 0xe5, 0xa0, 0xcb, 0x5c, 0xcf,
-	0x7f, 0x84, 0xe9, 0x47, 0xef, 0xd9, 0x0c, 0xba, 0x1f, 0xc4, 0xde, 0xf1, 0x1e, 0x3f, 0xd9, 0x43,
-	0xe8, 0xd3, 0xb4, 0x38, 0x2e, 0x59, 0xe1, 0x79, 0xe7, 0x7b, 0x2f, 0xfa, 0xdd, 0x83, 0x31, 0xa6,
-	0x79, 0x2d, 0x4c, 0x8a, 0xa4, 0x66, 0x9f, 0x82, 0x4f, 0xbf, 0xe7, 0x64, 0x74, 0x46, 0x08, 0xb4,
-	0x93, 0xb3, 0x6e, 0x36, 0x49, 0x26, 0xb7, 0xb5, 0xac, 0x44, 0x65, 0xc8, 0x5f, 0x1f, 0xcb, 0xb9,
-	0xb9, 0x68, 0x31, 0x0c, 0x26, 0x77, 0x95, 0x50, 0x44, 0x4c, 0x9f, 0x5b, 0x81, 0x9d, 0x41, 0x27,
-	0xcb, 0xc2, 0x1e, 0xe5, 0xdf, 0xc9, 0x32, 0xec, 0xb0, 0x50, 0x4a, 0xaa, 0xc4, 0xec, 0x6b, 0xe1,
-	0x48, 0xe6, 0x13, 0x72, 0xb3, 0xaf, 0x45, 0xf4, 0x87, 0x07, 0x83, 0x0b, 0x59, 0x36, 0xdb, 0x0a,
-	0xfd, 0x51, 0x4b, 0x5c, 0x36, 0x56, 0x38, 0x2c, 0x8f, 0xce, 0xfd, 0xe5, 0xa1, 0x4d, 0xaa, 0x8c,
-	0xc8, 0x29, 0xb6, 0xc7, 0x5b, 0x11, 0x7d, 0x88, 0x3b, 0xa3, 0x52, 0x97, 0x80, 0x15, 0x3e, 0x2e,
-	0xae, 0x4d, 0xe2, 0xa4, 0xb8, 0x18, 0xe4, 0x7d, 0x51, 0x19, 0xe2, 0xb8, 0xcf, 0xe9, 0x3b, 0xfa,
-	0xbb, 0x03, 0x5d, 0x2e, 0x77, 0xff, 0xba, 0xbd, 0xce, 0xa0, 0x73, 0x18, 0xd8, 0x4e, 0x91, 0x63,
-	0x42, 0x4a, 0xe8, 0xa6, 0x34, 0x76, 0x69, 0xf5, 0x79, 0x2b, 0xb2, 0x4f, 0x60, 0x94, 0x89, 0xb2,
-	0xa4, 0xb8, 0x36, 0xa7, 0x21, 0xca, 0x18, 0x74, 0x0e, 0x23, 0x37, 0x1c, 0x98, 0x12, 0xaa, 0x0e,
-	0x32, 0x2e, 0xc1, 0x2d, 0x2d, 0xcf, 0x70, 0x48, 0x1a, 0x27, 0xb1, 0xa7, 0x30, 0xb4, 0x5f, 0x3a,
-	0x1c, 0x11, 0xbf, 0x86, 0xb1, 0x5d, 0xb2, 0xbc, 0xc5, 0xb1, 0x04, 0x45, 0x26, 0x2b, 0x1d, 0xfa,
-	0xb6, 0x04, 0x24, 0xb0, 0xff, 0xc3, 0x00, 0x3b, 0x5a, 0xe4, 0x21, 0x58, 0x78, 0xdd, 0x6c, 0x2e,
-	0x73, 0xf6, 0x25, 0x40, 0x8a, 0xfc, 0x4c, 0x8a, 0xea, 0x56, 0xd2, 0x20, 0x04, 0x2b, 0x38, 0x52,
-	0x96, 0xfb, 0xe9, 0x61, 0x9d, 0x3d, 0x83, 0x49, 0xa3, 0x85, 0x4a, 0x1c, 0x69, 0xf7, 0x44, 0x70,
-	0x9f, 0x8f, 0x11, 0x74, 0xcc, 0xdc, 0x5f, 0xf5, 0x46, 0x83, 0xd9, 0x30, 0xfa, 0xab, 0x0b, 0xbd,
-	0x57, 0xaa, 0xc8, 0x31, 0xdd, 0x8c, 0x9a, 0xab, 0xdd, 0x12, 0x1d, 0xc6, 0xb6, 0xd9, 0xbc, 0xc5,
-	0x59, 0x08, 0x3d, 0x25, 0x77, 0xf6, 0x0a, 0x04, 0xab, 0x5e, 0xcc, 0xe5, 0x8e, 0x13, 0x62, 0xc7,
-	0x55, 0x9b, 0xc4, 0x26, 0xb8, 0xbd, 0xb7, 0x07, 0x3d, 0x1c, 0x57, 0x6d, 0x28, 0xd1, 0xeb, 0x76,
-	0xe9, 0x45, 0x30, 0xb0, 0x17, 0x88, 0xd6, 0x1d, 0xfe, 0x10, 0x64, 0xfc, 0x2b, 0x25, 0x9b, 0x9a,
-	0x3b, 0x0d, 0xfb, 0x0a, 0xe8, 0x21, 0x79, 0x4a, 0xec, 0xfe, 0xce, 0xa9, 0xed, 0x1e, 0x9f, 0xa2,
-	0x02, 0x1d, 0xd9, 0x3d, 0x9f, 0xb3, 0xaf, 0x21, 0x70, 0xc7, 0x80, 0xaa, 0x63, 0x0b, 0x1e, 0xc4,
-	0xc7, 0x73, 0xc1, 0xa1, 0x39, 0x9e, 0x8e, 0x15, 0x4c, 0x68, 0xa0, 0xb6, 0x6e, 0xc2, 0xa8, 0xfe,
-	0xc1, 0x6a, 0x12, 0x9f, 0x8e, 0x1d, 0x1f, 0x9b, 0xd3, 0x21, 0x8c, 0x60, 0x98, 0x95, 0x8d, 0x36,
-	0x42, 0x51, 0x5b, 0x82, 0xd5, 0x28, 0xbe, 0xb0, 0x32, 0x6f, 0x15, 0xec, 0x05, 0x3c, 0xde, 0x4a,
-	0x6d, 0x12, 0x25, 0x32, 0x51, 0x99, 0xc4, 0xc1, 0xc9, 0xe1, 0x0c, 0x53, 0xd7, 0x3c, 0x3e, 0x47,
-	0x23, 0x4e, 0x36, 0xce, 0xc5, 0x61, 0x31, 0x5f, 0xf5, 0x46, 0xfd, 0xd9, 0xe0, 0xaa, 0x37, 0x1a,
-	0xce, 0x46, 0x91, 0x82, 0xa1, 0xd3, 0xe3, 0x58, 0x50, 0xc6, 0x78, 0xee, 0x1b, 0xed, 0x2e, 0x14,
-	0x20, 0xf4, 0x96, 0x10, 0xa4, 0x75, 0xbb, 0xbe, 0x2d, 0xd7, 0x5b, 0x11, 0x4b, 0xd3, 0x26, 0xa2,
-	0xe4, 0x8e, 0x48, 0x8f, 0xa5, 0x69, 0x93, 0x97, 0x3b, 0x0e, 0xd9, 0xe1, 0x3b, 0x7a, 0x09, 0x70,
-	0xd4, 0xb0, 0xa7, 0x30, 0xce, 0x0b, 0x5d, 0x97, 0xe9, 0xfe, 0x74, 0xf9, 0x04, 0x0e, 0xa3, 0xfd,
-	0x83, 0x1c, 0xae, 0x72, 0x71, 0xe7, 0xfe, 0x37, 0xb0, 0xc2, 0x7a, 0x40, 0x37, 0xe7, 0xdb, 0x7f,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x63, 0xee, 0x52, 0x3c, 0xa0, 0x08, 0x00, 0x00,
+	// 1960 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6e, 0xdc, 0xc8,
+	0x11, 0x0e, 0xe7, 0x9f, 0x35, 0xbf, 0x6e, 0xff, 0x84, 0x91, 0xe3, 0x5d, 0x99, 0x9b, 0x4d, 0x64,
+	0xc3, 0xa1, 0x12, 0x25, 0xc0, 0x6e, 0x76, 0x37, 0x01, 0x14, 0x79, 0x76, 0x21, 0xc3, 0x96, 0x85,
+	0x96, 0xec, 0x2b, 0x41, 0x91, 0x2d, 0x89, 0x10, 0x87, 0x9c, 0x74, 0x37, 0x25, 0xcf, 0x29, 0x97,
+	0x9c, 0x72, 0x0f, 0x10, 0x20, 0x0f, 0x90, 0x4b, 0x1e, 0x21, 0xf7, 0xbc, 0x56, 0x50, 0xd5, 0x4d,
+	0x0e, 0x47, 0xb1, 0x61, 0x18, 0xc8, 0x69, 0x58, 0x5f, 0x55, 0x77, 0xf5, 0x54, 0x7d, 0x5d, 0x55,
+	0x0d, 0x43, 0xa5, 0x23, 0x2d, 0x82, 0xa5, 0x2c, 0x74, 0xb1, 0xf5, 0xf9, 0x45, 0x51, 0x5c, 0x64,
+	0x62, 0x97, 0xa4, 0xb3, 0xf2, 0x7c, 0x57, 0xa7, 0x0b, 0xa1, 0x74, 0xb4, 0x58, 0x5a, 0x83, 0x07,
+	0xcb, 0xb3, 0xdd, 0xb8, 0xc8, 0xcf, 0xd3, 0x0b, 0xfb, 0x63, 0x70, 0xff, 0x08, 0x7a, 0xaf, 0x84,
+	0x96, 0x69, 0xcc, 0x18, 0x74, 0xf2, 0x68, 0x21, 0x3c, 0x67, 0xdb, 0xd9, 0x71, 0x39, 0x7d, 0x33,
+	0x0f, 0xfa, 0x69, 0x9e, 0xa4, 0xb1, 0x50, 0x5e, 0x6b, 0xbb, 0xbd, 0xd3, 0xe5, 0x95, 0xc8, 0x1e,
+	0x40, 0xef, 0x3a, 0xca, 0x4a, 0xa1, 0xbc, 0xf6, 0x76, 0x7b, 0xc7, 0xe1, 0x56, 0xf2, 0xdf, 0xc0,
+	0xf4, 0xcd, 0x32, 0x89, 0xb4, 0x38, 0xbe, 0x8c, 0x94, 0x78, 0x1e, 0xe9, 0x88, 0x3d, 0x02, 0x58,
+	0xa2, 0x10, 0x36, 0xb6, 0x77, 0x09, 0x39, 0x42, 0x1f, 0x5f, 0xc0, 0xd8, 0xa8, 0x95, 0x88, 0x8b,
+	0x3c, 0x41, 0x4f, 0xce, 0x8e, 0xc3, 0x47, 0x04, 0x9e, 0x18, 0xcc, 0x7f, 0x01, 0x60, 0xb6, 0x3d,
+	0xcc, 0xcf, 0x0b, 0xf6, 0x1d, 0xdc, 0x29, 0x49, 0x0a, 0xcd, 0xca, 0x24, 0xd2, 0x91, 0xe7, 0x6c,
+	0xb7, 0x77, 0x86, 0x7b, 0xb3, 0xe0, 0x96, 0x7b, 0x3e, 0x2d, 0x37, 0x01, 0xff, 0xef, 0x5d, 0x70,
+	0xf7, 0x33, 0x21, 0x35, 0xed, 0xf5, 0x08, 0xe0, 0x3c, 0x4a, 0xb3, 0x30, 0x2e, 0xca, 0x5c, 0xd3,
+	0xe9, 0xba, 0xdc, 0x45, 0xe4, 0x00, 0x01, 0xe6, 0xc3, 0x98, 0xd4, 0x67, 0x65, 0x9a, 0x25, 0x61,
+	0x9a, 0xd0, 0xe9, 0x5c, 0x3e, 0x44, 0xf0, 0x8f, 0x88, 0x1d, 0x26, 0xec, 0x2b, 0xa0, 0x05, 0x21,
+	0xc6, 0xdc, 0x6b, 0x6f, 0x3b, 0x3b, 0xc3, 0xbd, 0xad, 0xc0, 0x24, 0x24, 0xa8, 0x12, 0x12, 0x9c,
+	0x56, 0x09, 0xe1, 0x03, 0x34, 0x46, 0x91, 0x6d, 0xc3, 0xc8, 0x2c, 0x14, 0x4a, 0xe3, 0xde, 0x1d,
+	0xda, 0x9b, 0xce, 0x73, 0x2a, 0x94, 0x3e, 0x4c, 0xd0, 0xfd, 0x32, 0x52, 0x6a, 0xed, 0xbe, 0x6b,
+	0xdc, 0x23, 0xd8, 0x70, 0x4f, 0x36, 0xe4, 0xbe, 0xf7, 0x71, 0xf7, 0x68, 0x4c, 0xee, 0x7f, 0x01,
+	0x53, 0x74, 0x55, 0x4a, 0x11, 0x2e, 0x84, 0x52, 0xd1, 0x85, 0xf0, 0xfa, 0xb4, 0xfd, 0xc4, 0xc2,
+	0xaf, 0x0c, 0x8a, 0x31, 0x32, 0x07, 0xc8, 0xd2, 0xfc, 0xca, 0x1b, 0x98, 0x0c, 0x12, 0xf2, 0x32,
+	0xcd, 0xaf, 0xd8, 0xcf, 0x61, 0xba, 0x56, 0x87, 0x5a, 0xbc, 0xd3, 0x9e, 0x4b, 0x36, 0xe3, 0xda,
+	0xe6, 0x54, 0xbc, 0xd3, 0xec, 0x67, 0x30, 0x31, 0x76, 0xa5, 0xcc, 0x8c, 0x19, 0x90, 0xd9, 0x88,
+	0xd0, 0x37, 0x32, 0x23, 0xab, 0x5d, 0xb8, 0x97, 0x45, 0x14, 0x91, 0xcd, 0xc0, 0x0f, 0xc9, 0xf6,
+	0x8e, 0xd1, 0x7d, 0xdf, 0x08, 0xff, 0x2f, 0xe1, 0x6e, 0x73, 0x41, 0x15, 0xcc, 0x09, 0xd9, 0xcf,
+	0xd6, 0xf6, 0x36, 0xa4, 0xdf, 0x00, 0x2c, 0x65, 0xb1, 0x14, 0x52, 0xa7, 0x42, 0x79, 0x23, 0x62,
+	0xcd, 0x56, 0x50, 0x13, 0x22, 0x38, 0xae, 0x95, 0xf3, 0x5c, 0xcb, 0x15, 0x6f, 0x58, 0xb3, 0xcf,
+	0x61, 0x78, 0x59, 0xe8, 0x2c, 0x25, 0x0f, 0xca, 0x1b, 0x6f, 0xb7, 0x31, 0x5f, 0x16, 0x3a, 0x4c,
+	0xd4, 0xd6, 0xef, 0x61, 0x7a, 0x6b, 0x3d, 0x9b, 0x41, 0xfb, 0x4a, 0xac, 0x2c, 0xef, 0xf1, 0x93,
+	0xdd, 0x83, 0x2e, 0xdd, 0x16, 0xcb, 0x25, 0x23, 0x7c, 0xd3, 0xfa, 0xda, 0xf1, 0xff, 0xe6, 0xc0,
+	0x08, 0x8f, 0xf9, 0x4a, 0xe8, 0x08, 0x49, 0xcd, 0x1e, 0x82, 0x4b, 0xff, 0xa7, 0x71, 0x75, 0x06,
+	0x08, 0x54, 0x37, 0xe7, 0xac, 0xbc, 0x08, 0xe3, 0x62, 0xb1, 0x2c, 0x72, 0x91, 0x6b, 0xda, 0xaf,
+	0x8b, 0xe1, 0xbc, 0x38, 0xa8, 0x30, 0x74, 0x56, 0xdc, 0xe4, 0x42, 0x12, 0x31, 0x5d, 0x6e, 0x04,
+	0x36, 0x81, 0x56, 0x1c, 0x7b, 0x1d, 0x3a, 0x7f, 0x2b, 0x8e, 0x31, 0xc3, 0x42, 0xca, 0x42, 0x86,
+	0x7a, 0xb5, 0x14, 0x96, 0x64, 0x2e, 0x21, 0xa7, 0xab, 0xa5, 0xf0, 0xff, 0xdd, 0x81, 0xde, 0x41,
+	0x91, 0x95, 0x8b, 0x1c, 0xf7, 0xa3, 0x94, 0xd8, 0xd3, 0x18, 0xa1, 0x2e, 0x1e, 0xad, 0xcd, 0xe2,
+	0xa1, 0x74, 0x24, 0xb5, 0x48, 0xc8, 0xb7, 0xc3, 0x2b, 0x11, 0xf7, 0x10, 0xef, 0xb4, 0x8c, 0xec,
+	0x01, 0x8c, 0x70, 0x3b, 0xb8, 0xe6, 0x10, 0x8d, 0xe0, 0xa2, 0x93, 0xcb, 0x34, 0xd7, 0xc4, 0x71,
+	0x97, 0xd3, 0x37, 0xd6, 0xa1, 0x33, 0x59, 0x5c, 0x89, 0x9c, 0xa8, 0x3b, 0xe0, 0x56, 0x62, 0xbf,
+	0x86, 0xc1, 0xc2, 0x06, 0xd1, 0x1b, 0x50, 0x8e, 0xef, 0x07, 0xe6, 0x1f, 0x04, 0x55, 0x70, 0x4d,
+	0x7a, 0x6b, 0x33, 0xdc, 0x4a, 0x15, 0xa5, 0x8c, 0x85, 0x65, 0xaf, 0x95, 0xd0, 0x2d, 0x16, 0x10,
+	0x4b, 0x56, 0xfa, 0xc6, 0xd0, 0x2f, 0x84, 0xbc, 0x10, 0x89, 0xe1, 0xa7, 0xf2, 0x86, 0xf4, 0x4f,
+	0x46, 0x06, 0x24, 0x66, 0x2a, 0x34, 0x4a, 0x64, 0xb1, 0x5c, 0x8a, 0x24, 0x8c, 0x45, 0x96, 0x21,
+	0xd9, 0x28, 0x3f, 0x16, 0x3c, 0x40, 0x8c, 0xed, 0xc2, 0xdd, 0xea, 0x04, 0xe1, 0x75, 0x5a, 0x64,
+	0x91, 0x4e, 0x8b, 0xbc, 0xa2, 0x16, 0xab, 0x54, 0x6f, 0x6b, 0x0d, 0xfb, 0x12, 0x26, 0xea, 0x2a,
+	0xa5, 0x5d, 0xad, 0xef, 0x09, 0x6d, 0x3b, 0xb6, 0xa8, 0x75, 0xfe, 0x18, 0x46, 0x45, 0x96, 0x08,
+	0x59, 0x19, 0x4d, 0xc9, 0x68, 0x48, 0x98, 0x35, 0x79, 0x0a, 0x83, 0xe2, 0x5a, 0x48, 0x99, 0x26,
+	0xc2, 0x9b, 0x51, 0xdd, 0x98, 0x04, 0xa4, 0x7a, 0x6d, 0x51, 0x5e, 0xeb, 0xb7, 0xbe, 0x85, 0xf1,
+	0x46, 0xdc, 0x3e, 0x89, 0xd6, 0xff, 0xe8, 0x41, 0x9b, 0x17, 0x37, 0xef, 0x6d, 0x31, 0x13, 0x68,
+	0xd5, 0x55, 0xb5, 0x95, 0x26, 0xc8, 0x1a, 0x29, 0x54, 0x99, 0x69, 0xd3, 0x59, 0xba, 0xbc, 0x12,
+	0xd9, 0x4f, 0x60, 0x80, 0x61, 0x24, 0x72, 0x18, 0xe2, 0xf4, 0x51, 0x46, 0x66, 0x6c, 0x61, 0xb6,
+	0xa9, 0x56, 0x21, 0x6f, 0x50, 0x55, 0xcb, 0x98, 0xd6, 0x05, 0x75, 0x38, 0xaf, 0x4f, 0x1a, 0x2b,
+	0xb1, 0xc7, 0xd0, 0x37, 0x5f, 0xca, 0x12, 0xa4, 0x1f, 0x98, 0x4e, 0xc8, 0x2b, 0x1c, 0xff, 0x51,
+	0x1a, 0x63, 0x36, 0x5c, 0xc3, 0x53, 0x12, 0xd8, 0x7d, 0xe8, 0xe1, 0xb5, 0x4b, 0x13, 0x0f, 0x0c,
+	0x7c, 0x56, 0x5e, 0x1c, 0x26, 0xec, 0x09, 0x40, 0x84, 0x45, 0x24, 0x4c, 0xf3, 0xf3, 0x82, 0xaa,
+	0xd5, 0x70, 0x0f, 0xd6, 0x75, 0x85, 0xbb, 0x51, 0xdd, 0x73, 0xbe, 0x80, 0x71, 0xa9, 0x84, 0x0c,
+	0x6d, 0x65, 0x59, 0x51, 0x15, 0x72, 0xf9, 0x08, 0x41, 0x5b, 0x3e, 0x56, 0x6c, 0x77, 0xa3, 0x4e,
+	0x8d, 0xe9, 0x88, 0xd3, 0xaa, 0x3a, 0xad, 0xde, 0x52, 0xbb, 0xdd, 0x28, 0x4e, 0x4f, 0x00, 0x28,
+	0x3e, 0x58, 0x85, 0x91, 0x14, 0x6d, 0x3a, 0x00, 0xb2, 0x0c, 0x2b, 0xb0, 0xe2, 0x6e, 0x5c, 0x7d,
+	0xb2, 0xaf, 0x61, 0x4a, 0xa6, 0xcb, 0x48, 0x46, 0x0b, 0xa1, 0x85, 0x44, 0x7e, 0x18, 0x07, 0x68,
+	0x7f, 0x5c, 0xc3, 0x7c, 0x12, 0x6f, 0xc8, 0xec, 0x21, 0x74, 0xcd, 0xfe, 0x33, 0xb2, 0xef, 0x06,
+	0xb8, 0x21, 0x37, 0x18, 0x52, 0x73, 0x19, 0xc5, 0x57, 0x22, 0x09, 0xab, 0x14, 0xde, 0xd9, 0x76,
+	0x76, 0x46, 0x7c, 0x6c, 0x50, 0x6e, 0x13, 0xf9, 0x18, 0x46, 0x36, 0x3b, 0xa1, 0x14, 0xe7, 0xca,
+	0x63, 0x94, 0xe7, 0xa1, 0xc5, 0xb8, 0x38, 0x47, 0x37, 0x2e, 0x06, 0xdb, 0xe8, 0xef, 0x92, 0x7e,
+	0x80, 0x00, 0x29, 0xb7, 0x61, 0x64, 0x89, 0x60, 0xf4, 0xf7, 0x48, 0x0f, 0x86, 0x0c, 0x64, 0xf1,
+	0x04, 0x20, 0x8b, 0x94, 0x0e, 0x2f, 0xa4, 0x10, 0xb9, 0x77, 0xdf, 0xe6, 0xe2, 0x65, 0xa4, 0xf4,
+	0x0f, 0x88, 0x70, 0x37, 0xab, 0x3e, 0xd9, 0xef, 0x00, 0xce, 0x53, 0xa9, 0x74, 0xa8, 0xd0, 0xf4,
+	0xc1, 0x47, 0xdb, 0xa7, 0x4b, 0xd6, 0x27, 0xb8, 0xf4, 0x3e, 0xf4, 0x52, 0x15, 0xe6, 0xe2, 0xc6,
+	0xfb, 0x31, 0xd5, 0x9e, 0x6e, 0xaa, 0x8e, 0xc4, 0x0d, 0xdb, 0x01, 0x57, 0x17, 0x8b, 0x33, 0xa5,
+	0x8b, 0x5c, 0x78, 0x9e, 0xf5, 0x7d, 0x5a, 0x21, 0x7c, 0xad, 0x7c, 0xd1, 0x19, 0xf4, 0x66, 0x7d,
+	0xff, 0xcf, 0xe0, 0xd6, 0x5a, 0x24, 0x79, 0xdd, 0xf1, 0xcc, 0x35, 0xe9, 0x9f, 0xd9, 0x3e, 0x17,
+	0x40, 0x87, 0x5a, 0x7c, 0xeb, 0xa3, 0x67, 0x24, 0x3b, 0x6c, 0xef, 0x8b, 0x54, 0xa9, 0x34, 0xc7,
+	0x16, 0x81, 0x95, 0x4f, 0x51, 0x1d, 0xee, 0xf2, 0x89, 0x85, 0x4d, 0x3d, 0x54, 0xfe, 0x5b, 0x70,
+	0xeb, 0xd0, 0xfc, 0x1f, 0x0f, 0xe0, 0x3f, 0x83, 0x0e, 0xcd, 0x07, 0xef, 0xbb, 0xf6, 0x33, 0x68,
+	0x97, 0x32, 0xb3, 0xf7, 0x1e, 0x3f, 0xfd, 0x1d, 0x70, 0x6b, 0xae, 0xae, 0x69, 0xe6, 0xfc, 0x2f,
+	0xcd, 0xfc, 0x18, 0xa6, 0x35, 0x23, 0x0d, 0xa7, 0xd8, 0x67, 0x00, 0x0d, 0x2e, 0x1b, 0x47, 0x0d,
+	0x04, 0x8b, 0x80, 0xa1, 0xa4, 0xed, 0x91, 0x56, 0xc2, 0x6a, 0x53, 0x8d, 0x3e, 0xa6, 0x3f, 0x56,
+	0xa2, 0xff, 0x1d, 0x4c, 0x36, 0xaf, 0x02, 0x7b, 0xba, 0xae, 0x4c, 0xd5, 0xac, 0x79, 0xeb, 0x18,
+	0x75, 0xad, 0xc2, 0xd5, 0x9b, 0x37, 0xf5, 0xbd, 0x41, 0x58, 0x0f, 0xd1, 0x2d, 0x53, 0x9a, 0xec,
+	0x10, 0xfd, 0x57, 0x07, 0x46, 0xfb, 0x32, 0xbe, 0x4c, 0xaf, 0xc5, 0xc9, 0x65, 0x24, 0xa9, 0xbd,
+	0x2e, 0x23, 0x7d, 0x59, 0x2d, 0xc6, 0x6f, 0xbc, 0x6c, 0x58, 0xcc, 0x91, 0xb9, 0xb6, 0xcb, 0x9a,
+	0xc1, 0x79, 0x6c, 0xd0, 0x13, 0xdb, 0x6b, 0xbf, 0x84, 0x49, 0x2e, 0x6e, 0x9a, 0x66, 0xa6, 0x19,
+	0x8f, 0x0d, 0x5a, 0x99, 0x79, 0xd0, 0xaf, 0x48, 0xd2, 0xa1, 0x08, 0x55, 0xa2, 0xff, 0xaf, 0x0e,
+	0x74, 0x7e, 0x90, 0x69, 0x82, 0x05, 0xb3, 0x32, 0x71, 0x6c, 0xc1, 0x34, 0x0c, 0xaa, 0x6d, 0x99,
+	0x07, 0x1d, 0x59, 0xdc, 0x98, 0xbf, 0x33, 0xdc, 0xeb, 0x04, 0xbc, 0xb8, 0xe1, 0x84, 0x98, 0xa9,
+	0x4e, 0xe9, 0xd0, 0x94, 0xc8, 0xc5, 0xc6, 0xb8, 0xec, 0xe0, 0x54, 0xa7, 0x34, 0x95, 0xca, 0x57,
+	0xd5, 0x6c, 0xec, 0x43, 0xcf, 0x3c, 0x54, 0xe8, 0x3c, 0x74, 0x85, 0x04, 0x72, 0xb4, 0x28, 0x97,
+	0xdc, 0x6a, 0xd8, 0x53, 0xa0, 0x85, 0xb4, 0x53, 0x68, 0xc6, 0xfc, 0x84, 0xa6, 0x03, 0x87, 0x4f,
+	0x51, 0x81, 0x1b, 0x99, 0xe7, 0x40, 0xc2, 0x9e, 0xc1, 0xd0, 0xbe, 0x19, 0xa8, 0x3e, 0x9b, 0x92,
+	0x3f, 0x0c, 0xd6, 0xaf, 0x0a, 0x0e, 0xe5, 0xfa, 0x85, 0xb1, 0x07, 0x63, 0x9a, 0xbb, 0xea, 0x19,
+	0xc2, 0x25, 0xfb, 0x71, 0xd0, 0x9c, 0xce, 0xf8, 0x48, 0x37, 0x67, 0x35, 0x1f, 0xfa, 0x71, 0x56,
+	0x2a, 0x2d, 0x24, 0x35, 0x86, 0xe1, 0xde, 0x20, 0x38, 0x30, 0x32, 0xaf, 0x14, 0x6c, 0x1f, 0x1e,
+	0x2d, 0x0a, 0xa5, 0x43, 0x29, 0x62, 0x91, 0xeb, 0xd0, 0xc2, 0x61, 0xfd, 0x5a, 0xa3, 0xbe, 0xe1,
+	0xf0, 0x2d, 0x34, 0xe2, 0x64, 0x63, 0xb7, 0xa8, 0xef, 0x16, 0x56, 0xcf, 0xc8, 0x70, 0x23, 0x24,
+	0x4e, 0x8c, 0xcc, 0x8b, 0xc0, 0x62, 0xc7, 0x48, 0x0d, 0x0f, 0xfa, 0xd7, 0x42, 0xaa, 0xb4, 0xc8,
+	0xbd, 0xb1, 0x49, 0xa6, 0x15, 0xf1, 0x9e, 0x24, 0x69, 0x8c, 0x83, 0x44, 0x24, 0x57, 0xd4, 0x23,
+	0x5c, 0xde, 0x40, 0xd8, 0x6f, 0x61, 0x52, 0x6d, 0xae, 0x90, 0x79, 0x55, 0x5f, 0x18, 0x07, 0x4d,
+	0x3e, 0xf2, 0x71, 0xd4, 0x90, 0xd4, 0x8b, 0xce, 0xa0, 0x3b, 0xeb, 0xbd, 0xe8, 0x0c, 0xfa, 0xb3,
+	0x81, 0x2f, 0xa1, 0x6f, 0x8f, 0x8c, 0x03, 0x9d, 0xb6, 0xc4, 0xd3, 0xa5, 0xb2, 0x6f, 0x2b, 0xd0,
+	0x86, 0x75, 0xba, 0x54, 0xcd, 0xdb, 0xd7, 0xda, 0xb8, 0x7d, 0x98, 0xad, 0x2a, 0x36, 0xb2, 0xb8,
+	0xa1, 0x49, 0x00, 0xb3, 0x55, 0xc5, 0xb3, 0xb8, 0xe1, 0x10, 0xd7, 0xdf, 0xfe, 0x1c, 0x60, 0xad,
+	0xc1, 0x00, 0x25, 0xa9, 0x5a, 0x66, 0xd1, 0xaa, 0x39, 0x36, 0x0f, 0x2d, 0x46, 0x93, 0x33, 0x36,
+	0xf6, 0x3c, 0x11, 0xef, 0xec, 0xab, 0xd6, 0x08, 0xfe, 0x5f, 0x1c, 0x18, 0xd9, 0x27, 0xcf, 0x89,
+	0x2e, 0xa4, 0x60, 0x5f, 0x35, 0xc6, 0x0a, 0x43, 0xf9, 0x87, 0x41, 0xd3, 0xa0, 0x12, 0x54, 0x3d,
+	0x4a, 0x1a, 0xd1, 0x4c, 0x4b, 0x0d, 0xd5, 0x27, 0x4d, 0x4b, 0xff, 0x71, 0x00, 0xcc, 0xc5, 0x9a,
+	0x27, 0xa9, 0xfe, 0xc0, 0xc0, 0xfd, 0x14, 0x7a, 0x11, 0x65, 0x8d, 0xd6, 0x4f, 0xf6, 0x58, 0xb0,
+	0x5e, 0x12, 0xec, 0x93, 0x86, 0x5b, 0x0b, 0xf6, 0x53, 0x70, 0xa5, 0xf8, 0x53, 0x29, 0xd4, 0xfa,
+	0xf6, 0xaf, 0x81, 0xa6, 0x56, 0xda, 0x17, 0xe8, 0x1a, 0xf0, 0xbf, 0x85, 0x9e, 0xd9, 0x8d, 0x3d,
+	0x00, 0xb6, 0x7f, 0x70, 0x7a, 0xf8, 0xfa, 0x28, 0x7c, 0x73, 0x74, 0x72, 0x3c, 0x3f, 0x38, 0xfc,
+	0xfe, 0x70, 0xfe, 0x7c, 0xf6, 0x23, 0x06, 0xd0, 0x7b, 0x3e, 0x7f, 0x39, 0x3f, 0x9d, 0xcf, 0x1c,
+	0x36, 0x06, 0x97, 0xcf, 0x8f, 0xf9, 0xeb, 0x83, 0xf9, 0xc9, 0xc9, 0xac, 0xe5, 0xff, 0x0a, 0x86,
+	0xeb, 0x53, 0x61, 0xdf, 0xef, 0x0a, 0xfc, 0xb0, 0xb1, 0x1c, 0x36, 0x8e, 0xcc, 0x8d, 0xc6, 0xff,
+	0xa7, 0x03, 0xe3, 0x8d, 0x11, 0xf4, 0x03, 0x7f, 0xff, 0x43, 0xf5, 0xfc, 0x33, 0x80, 0x28, 0xcf,
+	0x0b, 0x4d, 0xb3, 0xb2, 0x2d, 0xe9, 0x0d, 0xc4, 0xac, 0x8b, 0x54, 0x91, 0xdb, 0x7f, 0x6a, 0x25,
+	0xc4, 0xa3, 0x52, 0x5f, 0x16, 0xd2, 0x3e, 0x3b, 0xac, 0x44, 0x65, 0x51, 0x8a, 0x46, 0x5d, 0xa9,
+	0x44, 0xff, 0x0f, 0x30, 0xd9, 0x38, 0xa8, 0x62, 0xcf, 0xc0, 0xad, 0xc6, 0xe5, 0xea, 0x2f, 0xde,
+	0x9e, 0xa7, 0xd7, 0x06, 0x67, 0x3d, 0x6a, 0x9b, 0xbf, 0xf9, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff,
+	0x2f, 0x8d, 0x55, 0xe0, 0xc7, 0x11, 0x00, 0x00,
 }
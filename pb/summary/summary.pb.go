@@ -65,19 +65,21 @@ func (x TestInfo_Trend) String() string {
 }
 
 func (TestInfo_Trend) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{1, 0}
+	return fileDescriptor_f7168d0e3f3f5589, []int{2, 0}
 }
 
 type DashboardTabSummary_TabStatus int32
 
 const (
-	DashboardTabSummary_NOT_SET DashboardTabSummary_TabStatus = 0
-	DashboardTabSummary_UNKNOWN DashboardTabSummary_TabStatus = 1
-	DashboardTabSummary_PASS    DashboardTabSummary_TabStatus = 2
-	DashboardTabSummary_FAIL    DashboardTabSummary_TabStatus = 3
-	DashboardTabSummary_FLAKY   DashboardTabSummary_TabStatus = 4
-	DashboardTabSummary_STALE   DashboardTabSummary_TabStatus = 5
-	DashboardTabSummary_BROKEN  DashboardTabSummary_TabStatus = 6
+	DashboardTabSummary_NOT_SET  DashboardTabSummary_TabStatus = 0
+	DashboardTabSummary_UNKNOWN  DashboardTabSummary_TabStatus = 1
+	DashboardTabSummary_PASS     DashboardTabSummary_TabStatus = 2
+	DashboardTabSummary_FAIL     DashboardTabSummary_TabStatus = 3
+	DashboardTabSummary_FLAKY    DashboardTabSummary_TabStatus = 4
+	DashboardTabSummary_STALE    DashboardTabSummary_TabStatus = 5
+	DashboardTabSummary_BROKEN   DashboardTabSummary_TabStatus = 6
+	DashboardTabSummary_PAUSED   DashboardTabSummary_TabStatus = 7
+	DashboardTabSummary_ARCHIVED DashboardTabSummary_TabStatus = 8
 )
 
 var DashboardTabSummary_TabStatus_name = map[int32]string{
@@ -88,16 +90,20 @@ var DashboardTabSummary_TabStatus_name = map[int32]string{
 	4: "FLAKY",
 	5: "STALE",
 	6: "BROKEN",
+	7: "PAUSED",
+	8: "ARCHIVED",
 }
 
 var DashboardTabSummary_TabStatus_value = map[string]int32{
-	"NOT_SET": 0,
-	"UNKNOWN": 1,
-	"PASS":    2,
-	"FAIL":    3,
-	"FLAKY":   4,
-	"STALE":   5,
-	"BROKEN":  6,
+	"NOT_SET":  0,
+	"UNKNOWN":  1,
+	"PASS":     2,
+	"FAIL":     3,
+	"FLAKY":    4,
+	"STALE":    5,
+	"BROKEN":   6,
+	"PAUSED":   7,
+	"ARCHIVED": 8,
 }
 
 func (x DashboardTabSummary_TabStatus) String() string {
@@ -105,7 +111,58 @@ func (x DashboardTabSummary_TabStatus) String() string {
 }
 
 func (DashboardTabSummary_TabStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{4, 0}
+	return fileDescriptor_f7168d0e3f3f5589, []int{5, 0}
+}
+
+type TabAlert_Reason int32
+
+const (
+	TabAlert_REASON_UNSPECIFIED TabAlert_Reason = 0
+	// The test group has no stored results.
+	TabAlert_NO_STORED_RESULTS TabAlert_Reason = 1
+	// The test group has no completed results.
+	TabAlert_NO_COMPLETED_RESULTS TabAlert_Reason = 2
+	// The results have not changed since the since timestamp.
+	TabAlert_UNCHANGED TabAlert_Reason = 3
+	// The latest column started at the since timestamp.
+	TabAlert_OLD_RESULTS TabAlert_Reason = 4
+	// The test group is archived, with its latest column from the since
+	// timestamp when known.
+	TabAlert_ARCHIVED TabAlert_Reason = 5
+	// Updates of the test group are paused.
+	TabAlert_PAUSED TabAlert_Reason = 6
+	// The tab failed to summarize.
+	TabAlert_FAILED TabAlert_Reason = 7
+)
+
+var TabAlert_Reason_name = map[int32]string{
+	0: "REASON_UNSPECIFIED",
+	1: "NO_STORED_RESULTS",
+	2: "NO_COMPLETED_RESULTS",
+	3: "UNCHANGED",
+	4: "OLD_RESULTS",
+	5: "ARCHIVED",
+	6: "PAUSED",
+	7: "FAILED",
+}
+
+var TabAlert_Reason_value = map[string]int32{
+	"REASON_UNSPECIFIED":   0,
+	"NO_STORED_RESULTS":    1,
+	"NO_COMPLETED_RESULTS": 2,
+	"UNCHANGED":            3,
+	"OLD_RESULTS":          4,
+	"ARCHIVED":             5,
+	"PAUSED":               6,
+	"FAILED":               7,
+}
+
+func (x TabAlert_Reason) String() string {
+	return proto.EnumName(TabAlert_Reason_name, int32(x))
+}
+
+func (TabAlert_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{11, 0}
 }
 
 // Summary of a failing test.
@@ -144,10 +201,15 @@ type FailingTestSummary struct {
 	// Maps (property name):(property value) for arbitrary alert properties.
 	Properties map[string]string `protobuf:"bytes,15,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// A list of IDs for issue hotlists related to this failure.
-	HotlistIds           []string `protobuf:"bytes,16,rep,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	HotlistIds []string `protobuf:"bytes,16,rep,name=hotlist_ids,json=hotlistIds,proto3" json:"hotlist_ids,omitempty"`
+	// Stable identifier for this alert, unchanged across summarizer cycles for
+	// as long as the test keeps failing from the same first failing build.
+	AlertId string `protobuf:"bytes,18,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	// Snooze, acknowledgement and notification state for this alert.
+	AlertState           *AlertState `protobuf:"bytes,19,opt,name=alert_state,json=alertState,proto3" json:"alert_state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *FailingTestSummary) Reset()         { *m = FailingTestSummary{} }
@@ -294,6 +356,98 @@ func (m *FailingTestSummary) GetHotlistIds() []string {
 	return nil
 }
 
+func (m *FailingTestSummary) GetAlertId() string {
+	if m != nil {
+		return m.AlertId
+	}
+	return ""
+}
+
+func (m *FailingTestSummary) GetAlertState() *AlertState {
+	if m != nil {
+		return m.AlertState
+	}
+	return nil
+}
+
+// Lifecycle state of a single alert, carried across summarizer cycles.
+type AlertState struct {
+	// Do not notify about this alert until this time.
+	SnoozeUntil *timestamp.Timestamp `protobuf:"bytes,1,opt,name=snooze_until,json=snoozeUntil,proto3" json:"snooze_until,omitempty"`
+	// Who acknowledged this alert, if anyone.
+	// Acknowledged alerts are not notified again until they change.
+	AcknowledgedBy string `protobuf:"bytes,2,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	// When the alert was acknowledged.
+	AcknowledgeTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=acknowledge_time,json=acknowledgeTime,proto3" json:"acknowledge_time,omitempty"`
+	// Fingerprint of the alert when a notification was last sent.
+	NotifiedFingerprint string `protobuf:"bytes,4,opt,name=notified_fingerprint,json=notifiedFingerprint,proto3" json:"notified_fingerprint,omitempty"`
+	// When a notification was last sent.
+	NotifyTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=notify_time,json=notifyTime,proto3" json:"notify_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AlertState) Reset()         { *m = AlertState{} }
+func (m *AlertState) String() string { return proto.CompactTextString(m) }
+func (*AlertState) ProtoMessage()    {}
+func (*AlertState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{1}
+}
+
+func (m *AlertState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertState.Unmarshal(m, b)
+}
+func (m *AlertState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertState.Marshal(b, m, deterministic)
+}
+func (m *AlertState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertState.Merge(m, src)
+}
+func (m *AlertState) XXX_Size() int {
+	return xxx_messageInfo_AlertState.Size(m)
+}
+func (m *AlertState) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertState proto.InternalMessageInfo
+
+func (m *AlertState) GetSnoozeUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.SnoozeUntil
+	}
+	return nil
+}
+
+func (m *AlertState) GetAcknowledgedBy() string {
+	if m != nil {
+		return m.AcknowledgedBy
+	}
+	return ""
+}
+
+func (m *AlertState) GetAcknowledgeTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.AcknowledgeTime
+	}
+	return nil
+}
+
+func (m *AlertState) GetNotifiedFingerprint() string {
+	if m != nil {
+		return m.NotifiedFingerprint
+	}
+	return ""
+}
+
+func (m *AlertState) GetNotifyTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotifyTime
+	}
+	return nil
+}
+
 // Metrics about a specific test, i.e. passes, fails, total runs, etc.
 // Next ID: 12
 type TestInfo struct {
@@ -336,7 +490,7 @@ func (m *TestInfo) Reset()         { *m = TestInfo{} }
 func (m *TestInfo) String() string { return proto.CompactTextString(m) }
 func (*TestInfo) ProtoMessage()    {}
 func (*TestInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{1}
+	return fileDescriptor_f7168d0e3f3f5589, []int{2}
 }
 
 func (m *TestInfo) XXX_Unmarshal(b []byte) error {
@@ -458,7 +612,7 @@ func (m *HealthinessInfo) Reset()         { *m = HealthinessInfo{} }
 func (m *HealthinessInfo) String() string { return proto.CompactTextString(m) }
 func (*HealthinessInfo) ProtoMessage()    {}
 func (*HealthinessInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{2}
+	return fileDescriptor_f7168d0e3f3f5589, []int{3}
 }
 
 func (m *HealthinessInfo) XXX_Unmarshal(b []byte) error {
@@ -527,7 +681,7 @@ func (m *AlertingData) Reset()         { *m = AlertingData{} }
 func (m *AlertingData) String() string { return proto.CompactTextString(m) }
 func (*AlertingData) ProtoMessage()    {}
 func (*AlertingData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{3}
+	return fileDescriptor_f7168d0e3f3f5589, []int{4}
 }
 
 func (m *AlertingData) XXX_Unmarshal(b []byte) error {
@@ -583,17 +737,43 @@ type DashboardTabSummary struct {
 	LinkedIssues []string `protobuf:"bytes,13,rep,name=linked_issues,json=linkedIssues,proto3" json:"linked_issues,omitempty"`
 	// Metrics about alerts sent with respect to this summary
 	// Maintained by alerter; does not need to be populated by summarizer
-	AlertingData         *AlertingData `protobuf:"bytes,14,opt,name=alerting_data,json=alertingData,proto3" json:"alerting_data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	AlertingData *AlertingData `protobuf:"bytes,14,opt,name=alerting_data,json=alertingData,proto3" json:"alerting_data,omitempty"`
+	// Generation of the grid state summarized, zero if it does not exist.
+	GridGeneration int64 `protobuf:"varint,15,opt,name=grid_generation,json=gridGeneration,proto3" json:"grid_generation,omitempty"`
+	// Fingerprint of the tab and test group configuration summarized.
+	//
+	// The summarizer reuses this summary until either the grid state or the
+	// fingerprint changes.
+	ConfigFingerprint string `protobuf:"bytes,16,opt,name=config_fingerprint,json=configFingerprint,proto3" json:"config_fingerprint,omitempty"`
+	// Tests which ran over their duration budget in the recent columns.
+	BudgetViolations []*BudgetViolation `protobuf:"bytes,17,rep,name=budget_violations,json=budgetViolations,proto3" json:"budget_violations,omitempty"`
+	// Structured form of the alert, for clients rendering it themselves.
+	TabAlert *TabAlert `protobuf:"bytes,18,opt,name=tab_alert,json=tabAlert,proto3" json:"tab_alert,omitempty"`
+	// Counts behind the status, for clients rendering it themselves.
+	StatusCounts *StatusCounts `protobuf:"bytes,19,opt,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty"`
+	// How much of the tab's error budget remains, if it has one.
+	ErrorBudget *ErrorBudget `protobuf:"bytes,20,opt,name=error_budget,json=errorBudget,proto3" json:"error_budget,omitempty"`
+	// Periods during which the tab failed, oldest first, kept for about a year.
+	AlertHistory []*AlertEpisode `protobuf:"bytes,21,rep,name=alert_history,json=alertHistory,proto3" json:"alert_history,omitempty"`
+	// Statistics of each row over the tab's row_stats_days windows.
+	RowStats []*RowStats `protobuf:"bytes,22,rep,name=row_stats,json=rowStats,proto3" json:"row_stats,omitempty"`
+	// Tests which stopped appearing, such as after being removed or renamed,
+	// most recently seen first.
+	RemovedTests []*RemovedTest `protobuf:"bytes,23,rep,name=removed_tests,json=removedTests,proto3" json:"removed_tests,omitempty"`
+	// Consecutive summaries, including this one, in which the tab failed or broke.
+	FailingCycles int32 `protobuf:"varint,24,opt,name=failing_cycles,json=failingCycles,proto3" json:"failing_cycles,omitempty"`
+	// Escalation steps notified since the tab started failing.
+	NotifiedSteps        []string `protobuf:"bytes,25,rep,name=notified_steps,json=notifiedSteps,proto3" json:"notified_steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DashboardTabSummary) Reset()         { *m = DashboardTabSummary{} }
 func (m *DashboardTabSummary) String() string { return proto.CompactTextString(m) }
 func (*DashboardTabSummary) ProtoMessage()    {}
 func (*DashboardTabSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{4}
+	return fileDescriptor_f7168d0e3f3f5589, []int{5}
 }
 
 func (m *DashboardTabSummary) XXX_Unmarshal(b []byte) error {
@@ -705,6 +885,587 @@ func (m *DashboardTabSummary) GetAlertingData() *AlertingData {
 	return nil
 }
 
+func (m *DashboardTabSummary) GetGridGeneration() int64 {
+	if m != nil {
+		return m.GridGeneration
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetConfigFingerprint() string {
+	if m != nil {
+		return m.ConfigFingerprint
+	}
+	return ""
+}
+
+func (m *DashboardTabSummary) GetBudgetViolations() []*BudgetViolation {
+	if m != nil {
+		return m.BudgetViolations
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetTabAlert() *TabAlert {
+	if m != nil {
+		return m.TabAlert
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetStatusCounts() *StatusCounts {
+	if m != nil {
+		return m.StatusCounts
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetErrorBudget() *ErrorBudget {
+	if m != nil {
+		return m.ErrorBudget
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetAlertHistory() []*AlertEpisode {
+	if m != nil {
+		return m.AlertHistory
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetRowStats() []*RowStats {
+	if m != nil {
+		return m.RowStats
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetRemovedTests() []*RemovedTest {
+	if m != nil {
+		return m.RemovedTests
+	}
+	return nil
+}
+
+func (m *DashboardTabSummary) GetFailingCycles() int32 {
+	if m != nil {
+		return m.FailingCycles
+	}
+	return 0
+}
+
+func (m *DashboardTabSummary) GetNotifiedSteps() []string {
+	if m != nil {
+		return m.NotifiedSteps
+	}
+	return nil
+}
+
+// A test which stopped appearing in its test group.
+type RemovedTest struct {
+	// Display name of the test.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Name of the test, such as its target.
+	TestName string `protobuf:"bytes,2,opt,name=test_name,json=testName,proto3" json:"test_name,omitempty"`
+	// Build ID and start time, in seconds since epoch, of its last result.
+	LastBuildId          string   `protobuf:"bytes,3,opt,name=last_build_id,json=lastBuildId,proto3" json:"last_build_id,omitempty"`
+	LastTimestamp        float64  `protobuf:"fixed64,4,opt,name=last_timestamp,json=lastTimestamp,proto3" json:"last_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemovedTest) Reset()         { *m = RemovedTest{} }
+func (m *RemovedTest) String() string { return proto.CompactTextString(m) }
+func (*RemovedTest) ProtoMessage()    {}
+func (*RemovedTest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{6}
+}
+
+func (m *RemovedTest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovedTest.Unmarshal(m, b)
+}
+func (m *RemovedTest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovedTest.Marshal(b, m, deterministic)
+}
+func (m *RemovedTest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovedTest.Merge(m, src)
+}
+func (m *RemovedTest) XXX_Size() int {
+	return xxx_messageInfo_RemovedTest.Size(m)
+}
+func (m *RemovedTest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovedTest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovedTest proto.InternalMessageInfo
+
+func (m *RemovedTest) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *RemovedTest) GetTestName() string {
+	if m != nil {
+		return m.TestName
+	}
+	return ""
+}
+
+func (m *RemovedTest) GetLastBuildId() string {
+	if m != nil {
+		return m.LastBuildId
+	}
+	return ""
+}
+
+func (m *RemovedTest) GetLastTimestamp() float64 {
+	if m != nil {
+		return m.LastTimestamp
+	}
+	return 0
+}
+
+// Statistics of a row over the columns which started within a window.
+type RowWindowStats struct {
+	// Length of the window in days.
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// Number of completed results, not counting running ones.
+	Runs     int32 `protobuf:"varint,2,opt,name=runs,proto3" json:"runs,omitempty"`
+	Passes   int32 `protobuf:"varint,3,opt,name=passes,proto3" json:"passes,omitempty"`
+	Failures int32 `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`
+	// Percentage of runs which passed.
+	PassPercent float32 `protobuf:"fixed32,5,opt,name=pass_percent,json=passPercent,proto3" json:"pass_percent,omitempty"`
+	// Mean duration of the runs which report one.
+	AverageDurationSeconds float64 `protobuf:"fixed64,6,opt,name=average_duration_seconds,json=averageDurationSeconds,proto3" json:"average_duration_seconds,omitempty"`
+	// When the newest failing run started, in seconds since the epoch, or zero
+	// without failures.
+	LastFailure          float64  `protobuf:"fixed64,7,opt,name=last_failure,json=lastFailure,proto3" json:"last_failure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RowWindowStats) Reset()         { *m = RowWindowStats{} }
+func (m *RowWindowStats) String() string { return proto.CompactTextString(m) }
+func (*RowWindowStats) ProtoMessage()    {}
+func (*RowWindowStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{7}
+}
+
+func (m *RowWindowStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowWindowStats.Unmarshal(m, b)
+}
+func (m *RowWindowStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowWindowStats.Marshal(b, m, deterministic)
+}
+func (m *RowWindowStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowWindowStats.Merge(m, src)
+}
+func (m *RowWindowStats) XXX_Size() int {
+	return xxx_messageInfo_RowWindowStats.Size(m)
+}
+func (m *RowWindowStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowWindowStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowWindowStats proto.InternalMessageInfo
+
+func (m *RowWindowStats) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *RowWindowStats) GetRuns() int32 {
+	if m != nil {
+		return m.Runs
+	}
+	return 0
+}
+
+func (m *RowWindowStats) GetPasses() int32 {
+	if m != nil {
+		return m.Passes
+	}
+	return 0
+}
+
+func (m *RowWindowStats) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *RowWindowStats) GetPassPercent() float32 {
+	if m != nil {
+		return m.PassPercent
+	}
+	return 0
+}
+
+func (m *RowWindowStats) GetAverageDurationSeconds() float64 {
+	if m != nil {
+		return m.AverageDurationSeconds
+	}
+	return 0
+}
+
+func (m *RowWindowStats) GetLastFailure() float64 {
+	if m != nil {
+		return m.LastFailure
+	}
+	return 0
+}
+
+// Statistics of a row over each window.
+type RowStats struct {
+	DisplayName          string            `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Windows              []*RowWindowStats `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RowStats) Reset()         { *m = RowStats{} }
+func (m *RowStats) String() string { return proto.CompactTextString(m) }
+func (*RowStats) ProtoMessage()    {}
+func (*RowStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{8}
+}
+
+func (m *RowStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowStats.Unmarshal(m, b)
+}
+func (m *RowStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowStats.Marshal(b, m, deterministic)
+}
+func (m *RowStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowStats.Merge(m, src)
+}
+func (m *RowStats) XXX_Size() int {
+	return xxx_messageInfo_RowStats.Size(m)
+}
+func (m *RowStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowStats proto.InternalMessageInfo
+
+func (m *RowStats) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *RowStats) GetWindows() []*RowWindowStats {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+// A period during which a tab was failing or broken.
+type AlertEpisode struct {
+	// When the tab started failing, in seconds since the epoch.
+	Opened float64 `protobuf:"fixed64,1,opt,name=opened,proto3" json:"opened,omitempty"`
+	// When the tab passed again, zero while it still fails.
+	Resolved             float64  `protobuf:"fixed64,2,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlertEpisode) Reset()         { *m = AlertEpisode{} }
+func (m *AlertEpisode) String() string { return proto.CompactTextString(m) }
+func (*AlertEpisode) ProtoMessage()    {}
+func (*AlertEpisode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{9}
+}
+
+func (m *AlertEpisode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlertEpisode.Unmarshal(m, b)
+}
+func (m *AlertEpisode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlertEpisode.Marshal(b, m, deterministic)
+}
+func (m *AlertEpisode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlertEpisode.Merge(m, src)
+}
+func (m *AlertEpisode) XXX_Size() int {
+	return xxx_messageInfo_AlertEpisode.Size(m)
+}
+func (m *AlertEpisode) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlertEpisode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlertEpisode proto.InternalMessageInfo
+
+func (m *AlertEpisode) GetOpened() float64 {
+	if m != nil {
+		return m.Opened
+	}
+	return 0
+}
+
+func (m *AlertEpisode) GetResolved() float64 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+// The failing columns of a tab within its rolling error budget window.
+type ErrorBudget struct {
+	// Number of failing columns allowed within the window.
+	AllowedFailingColumns int32 `protobuf:"varint,1,opt,name=allowed_failing_columns,json=allowedFailingColumns,proto3" json:"allowed_failing_columns,omitempty"`
+	// Number of columns with a failing result within the window.
+	FailingColumns int32 `protobuf:"varint,2,opt,name=failing_columns,json=failingColumns,proto3" json:"failing_columns,omitempty"`
+	// Allowed minus failing columns, negative once overspent.
+	Remaining int32 `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	// Length of the window in days.
+	Days                 int32    `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErrorBudget) Reset()         { *m = ErrorBudget{} }
+func (m *ErrorBudget) String() string { return proto.CompactTextString(m) }
+func (*ErrorBudget) ProtoMessage()    {}
+func (*ErrorBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{10}
+}
+
+func (m *ErrorBudget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorBudget.Unmarshal(m, b)
+}
+func (m *ErrorBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorBudget.Marshal(b, m, deterministic)
+}
+func (m *ErrorBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorBudget.Merge(m, src)
+}
+func (m *ErrorBudget) XXX_Size() int {
+	return xxx_messageInfo_ErrorBudget.Size(m)
+}
+func (m *ErrorBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorBudget proto.InternalMessageInfo
+
+func (m *ErrorBudget) GetAllowedFailingColumns() int32 {
+	if m != nil {
+		return m.AllowedFailingColumns
+	}
+	return 0
+}
+
+func (m *ErrorBudget) GetFailingColumns() int32 {
+	if m != nil {
+		return m.FailingColumns
+	}
+	return 0
+}
+
+func (m *ErrorBudget) GetRemaining() int32 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
+func (m *ErrorBudget) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+// Why a tab alerts, which clients may render in their own language and time
+// zone.
+type TabAlert struct {
+	Reason TabAlert_Reason `protobuf:"varint,1,opt,name=reason,proto3,enum=TabAlert_Reason" json:"reason,omitempty"`
+	// Seconds since the epoch, for reasons which refer to a time.
+	Since                float64  `protobuf:"fixed64,2,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TabAlert) Reset()         { *m = TabAlert{} }
+func (m *TabAlert) String() string { return proto.CompactTextString(m) }
+func (*TabAlert) ProtoMessage()    {}
+func (*TabAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{11}
+}
+
+func (m *TabAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TabAlert.Unmarshal(m, b)
+}
+func (m *TabAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TabAlert.Marshal(b, m, deterministic)
+}
+func (m *TabAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TabAlert.Merge(m, src)
+}
+func (m *TabAlert) XXX_Size() int {
+	return xxx_messageInfo_TabAlert.Size(m)
+}
+func (m *TabAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_TabAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TabAlert proto.InternalMessageInfo
+
+func (m *TabAlert) GetReason() TabAlert_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return TabAlert_REASON_UNSPECIFIED
+}
+
+func (m *TabAlert) GetSince() float64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+// Results of the recent columns of a tab.
+type StatusCounts struct {
+	// Columns where every result passed.
+	PassingColumns int32 `protobuf:"varint,1,opt,name=passing_columns,json=passingColumns,proto3" json:"passing_columns,omitempty"`
+	// Columns with any completed results.
+	CompletedColumns int32 `protobuf:"varint,2,opt,name=completed_columns,json=completedColumns,proto3" json:"completed_columns,omitempty"`
+	// Cells which passed.
+	PassingCells int32 `protobuf:"varint,3,opt,name=passing_cells,json=passingCells,proto3" json:"passing_cells,omitempty"`
+	// Cells with a result.
+	FilledCells          int32    `protobuf:"varint,4,opt,name=filled_cells,json=filledCells,proto3" json:"filled_cells,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusCounts) Reset()         { *m = StatusCounts{} }
+func (m *StatusCounts) String() string { return proto.CompactTextString(m) }
+func (*StatusCounts) ProtoMessage()    {}
+func (*StatusCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{12}
+}
+
+func (m *StatusCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusCounts.Unmarshal(m, b)
+}
+func (m *StatusCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusCounts.Marshal(b, m, deterministic)
+}
+func (m *StatusCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusCounts.Merge(m, src)
+}
+func (m *StatusCounts) XXX_Size() int {
+	return xxx_messageInfo_StatusCounts.Size(m)
+}
+func (m *StatusCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusCounts proto.InternalMessageInfo
+
+func (m *StatusCounts) GetPassingColumns() int32 {
+	if m != nil {
+		return m.PassingColumns
+	}
+	return 0
+}
+
+func (m *StatusCounts) GetCompletedColumns() int32 {
+	if m != nil {
+		return m.CompletedColumns
+	}
+	return 0
+}
+
+func (m *StatusCounts) GetPassingCells() int32 {
+	if m != nil {
+		return m.PassingCells
+	}
+	return 0
+}
+
+func (m *StatusCounts) GetFilledCells() int32 {
+	if m != nil {
+		return m.FilledCells
+	}
+	return 0
+}
+
+// A test whose passing results ran over its duration budget.
+type BudgetViolation struct {
+	// Display name of the test.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Number of recent results over budget.
+	Violations int32 `protobuf:"varint,2,opt,name=violations,proto3" json:"violations,omitempty"`
+	// Minutes the most recent of these results ran over budget.
+	LatestOverMinutes    float64  `protobuf:"fixed64,3,opt,name=latest_over_minutes,json=latestOverMinutes,proto3" json:"latest_over_minutes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BudgetViolation) Reset()         { *m = BudgetViolation{} }
+func (m *BudgetViolation) String() string { return proto.CompactTextString(m) }
+func (*BudgetViolation) ProtoMessage()    {}
+func (*BudgetViolation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7168d0e3f3f5589, []int{13}
+}
+
+func (m *BudgetViolation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BudgetViolation.Unmarshal(m, b)
+}
+func (m *BudgetViolation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BudgetViolation.Marshal(b, m, deterministic)
+}
+func (m *BudgetViolation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BudgetViolation.Merge(m, src)
+}
+func (m *BudgetViolation) XXX_Size() int {
+	return xxx_messageInfo_BudgetViolation.Size(m)
+}
+func (m *BudgetViolation) XXX_DiscardUnknown() {
+	xxx_messageInfo_BudgetViolation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BudgetViolation proto.InternalMessageInfo
+
+func (m *BudgetViolation) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *BudgetViolation) GetViolations() int32 {
+	if m != nil {
+		return m.Violations
+	}
+	return 0
+}
+
+func (m *BudgetViolation) GetLatestOverMinutes() float64 {
+	if m != nil {
+		return m.LatestOverMinutes
+	}
+	return 0
+}
+
 // Summary state of a dashboard.
 type DashboardSummary struct {
 	// Summary of a dashboard tab; see config.proto.
@@ -718,7 +1479,7 @@ func (m *DashboardSummary) Reset()         { *m = DashboardSummary{} }
 func (m *DashboardSummary) String() string { return proto.CompactTextString(m) }
 func (*DashboardSummary) ProtoMessage()    {}
 func (*DashboardSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7168d0e3f3f5589, []int{5}
+	return fileDescriptor_f7168d0e3f3f5589, []int{14}
 }
 
 func (m *DashboardSummary) XXX_Unmarshal(b []byte) error {
@@ -749,93 +1510,159 @@ func (m *DashboardSummary) GetTabSummaries() []*DashboardTabSummary {
 func init() {
 	proto.RegisterEnum("TestInfo_Trend", TestInfo_Trend_name, TestInfo_Trend_value)
 	proto.RegisterEnum("DashboardTabSummary_TabStatus", DashboardTabSummary_TabStatus_name, DashboardTabSummary_TabStatus_value)
+	proto.RegisterEnum("TabAlert_Reason", TabAlert_Reason_name, TabAlert_Reason_value)
 	proto.RegisterType((*FailingTestSummary)(nil), "FailingTestSummary")
 	proto.RegisterMapType((map[string]string)(nil), "FailingTestSummary.PropertiesEntry")
+	proto.RegisterType((*AlertState)(nil), "AlertState")
 	proto.RegisterType((*TestInfo)(nil), "TestInfo")
 	proto.RegisterMapType((map[string]int32)(nil), "TestInfo.InfraFailuresEntry")
 	proto.RegisterType((*HealthinessInfo)(nil), "HealthinessInfo")
 	proto.RegisterType((*AlertingData)(nil), "AlertingData")
 	proto.RegisterType((*DashboardTabSummary)(nil), "DashboardTabSummary")
+	proto.RegisterType((*RemovedTest)(nil), "RemovedTest")
+	proto.RegisterType((*RowWindowStats)(nil), "RowWindowStats")
+	proto.RegisterType((*RowStats)(nil), "RowStats")
+	proto.RegisterType((*AlertEpisode)(nil), "AlertEpisode")
+	proto.RegisterType((*ErrorBudget)(nil), "ErrorBudget")
+	proto.RegisterType((*TabAlert)(nil), "TabAlert")
+	proto.RegisterType((*StatusCounts)(nil), "StatusCounts")
+	proto.RegisterType((*BudgetViolation)(nil), "BudgetViolation")
 	proto.RegisterType((*DashboardSummary)(nil), "DashboardSummary")
 }
 
 func init() { proto.RegisterFile("summary.proto", fileDescriptor_f7168d0e3f3f5589) }
 
 var fileDescriptor_f7168d0e3f3f5589 = []byte{
-	// 1187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xff, 0x6e, 0xdb, 0x54,
-	0x14, 0x5e, 0x9a, 0x38, 0xad, 0x4f, 0xe2, 0xc4, 0xbd, 0x2b, 0xc3, 0x94, 0xc1, 0x4a, 0xc6, 0xa0,
-	0x82, 0xe1, 0x42, 0x10, 0x12, 0x20, 0x21, 0x91, 0x76, 0xc9, 0x96, 0xad, 0x4b, 0x27, 0x37, 0xd5,
-	0x84, 0xf8, 0xc3, 0xba, 0x99, 0x6f, 0x12, 0xab, 0x8e, 0x1d, 0xf9, 0x5e, 0x97, 0xf5, 0x51, 0x78,
-	0x13, 0x9e, 0x0a, 0x5e, 0x01, 0x9d, 0x73, 0x9d, 0xd8, 0xcb, 0x0a, 0xdb, 0x7f, 0xf6, 0x77, 0xbe,
-	0xef, 0xdc, 0x7b, 0xcf, 0x4f, 0xb0, 0x64, 0xb6, 0x58, 0xf0, 0xf4, 0xda, 0x5d, 0xa6, 0x89, 0x4a,
-	0xf6, 0xef, 0xcd, 0x92, 0x64, 0x16, 0x89, 0x23, 0xfa, 0x9b, 0x64, 0xd3, 0x23, 0x15, 0x2e, 0x84,
-	0x54, 0x7c, 0xb1, 0xd4, 0x84, 0xce, 0x3f, 0x06, 0xb0, 0x01, 0x0f, 0xa3, 0x30, 0x9e, 0x8d, 0x85,
-	0x54, 0xe7, 0x5a, 0xcd, 0x3e, 0x83, 0x66, 0x10, 0xca, 0x65, 0xc4, 0xaf, 0xfd, 0x98, 0x2f, 0x84,
-	0x53, 0x39, 0xa8, 0x1c, 0x9a, 0x5e, 0x23, 0xc7, 0x46, 0x7c, 0x21, 0xd8, 0xc7, 0x60, 0x2a, 0x21,
-	0x95, 0xb6, 0x6f, 0x91, 0x7d, 0x07, 0x01, 0x32, 0x76, 0xc0, 0x9a, 0xf2, 0x30, 0xf2, 0x27, 0x59,
-	0x18, 0x05, 0x7e, 0x18, 0x38, 0x55, 0xed, 0x00, 0xc1, 0x63, 0xc4, 0x86, 0x01, 0x7b, 0x00, 0x2d,
-	0xe2, 0xac, 0xaf, 0xe4, 0xd4, 0x0e, 0x2a, 0x87, 0x15, 0x8f, 0x94, 0xe3, 0x15, 0x88, 0xae, 0x96,
-	0x5c, 0xca, 0xc2, 0x95, 0xa1, 0x5d, 0x21, 0x58, 0x72, 0x45, 0x9c, 0xc2, 0x55, 0x5d, 0xbb, 0x42,
-	0xb4, 0x70, 0xf5, 0x09, 0x00, 0x9d, 0xf8, 0x2a, 0xc9, 0x62, 0xe5, 0x6c, 0x1f, 0x54, 0x0e, 0x0d,
-	0xcf, 0x44, 0xe4, 0x04, 0x01, 0x34, 0xeb, 0x43, 0xa2, 0x30, 0xbe, 0x74, 0x76, 0xe8, 0x18, 0x93,
-	0x90, 0xd3, 0x30, 0xbe, 0x64, 0x5f, 0x40, 0xbb, 0x30, 0xfb, 0x4a, 0xbc, 0x56, 0x8e, 0x49, 0x1c,
-	0x6b, 0xcd, 0x19, 0x8b, 0xd7, 0x8a, 0x7d, 0x0e, 0x2d, 0xcd, 0xcb, 0xd2, 0x48, 0xd3, 0x80, 0x68,
-	0x4d, 0x42, 0x2f, 0xd2, 0x88, 0x58, 0x5f, 0x42, 0x1b, 0x4f, 0xce, 0x52, 0xe1, 0x2f, 0x84, 0x94,
-	0x7c, 0x26, 0x9c, 0x06, 0xd1, 0x5a, 0x39, 0xfc, 0x5c, 0xa3, 0xec, 0x1e, 0x34, 0xf0, 0x40, 0x11,
-	0xf8, 0x93, 0x6c, 0x26, 0x9d, 0xe6, 0x41, 0xf5, 0xd0, 0xf4, 0x40, 0x43, 0xc7, 0xd9, 0x4c, 0xe2,
-	0x79, 0x3a, 0x8e, 0x98, 0x0d, 0xba, 0xba, 0xa5, 0xcf, 0xa3, 0x38, 0x0a, 0xa9, 0xe8, 0xf6, 0xdf,
-	0xc1, 0x07, 0x11, 0x27, 0xca, 0x06, 0x79, 0x97, 0xc8, 0x4c, 0x1b, 0x07, 0x65, 0xc9, 0x11, 0xec,
-	0x95, 0x25, 0xeb, 0x04, 0xb4, 0x48, 0xb1, 0x5b, 0x28, 0x56, 0x69, 0x38, 0x01, 0x58, 0xa6, 0xc9,
-	0x52, 0xa4, 0x2a, 0x14, 0xd2, 0x69, 0x1f, 0x54, 0x0f, 0x1b, 0xdd, 0xfb, 0xee, 0xdb, 0xe5, 0xe5,
-	0xbe, 0x58, 0xb3, 0xfa, 0xb1, 0x4a, 0xaf, 0xbd, 0x92, 0x0c, 0xdf, 0x3b, 0x4f, 0x54, 0x14, 0x4a,
-	0xe5, 0x87, 0x81, 0x74, 0x6c, 0xfd, 0xde, 0x1c, 0x1a, 0x06, 0x72, 0xff, 0x17, 0x68, 0x6f, 0xe8,
-	0x99, 0x0d, 0xd5, 0x4b, 0x71, 0x9d, 0x57, 0x29, 0x7e, 0xb2, 0x3d, 0x30, 0xae, 0x78, 0x94, 0xad,
-	0x2a, 0x53, 0xff, 0xfc, 0xbc, 0xf5, 0x63, 0xa5, 0xf3, 0xa7, 0x01, 0x3b, 0x78, 0x97, 0x61, 0x3c,
-	0x4d, 0xde, 0xa7, 0xce, 0x8f, 0x60, 0x4f, 0x25, 0x8a, 0x47, 0x7e, 0x9c, 0xc4, 0x7e, 0x18, 0x4f,
-	0x53, 0xee, 0xa7, 0x59, 0x2c, 0xc9, 0xb1, 0xe1, 0xed, 0x92, 0x6d, 0x94, 0xc4, 0x43, 0xb4, 0x78,
-	0x59, 0x2c, 0x31, 0xd2, 0x58, 0x76, 0x22, 0xd8, 0x54, 0x54, 0x49, 0xc1, 0xb4, 0x71, 0x53, 0x82,
-	0x21, 0x7e, 0x5b, 0x52, 0xd3, 0x12, 0x6d, 0x7c, 0x43, 0xf2, 0x15, 0xec, 0xe6, 0x92, 0x12, 0xdd,
-	0x20, 0x7a, 0x5b, 0x1b, 0xde, 0x70, 0xaf, 0x9f, 0x80, 0x24, 0xff, 0x8f, 0x50, 0xcd, 0xb5, 0x88,
-	0xba, 0xc4, 0xf0, 0x18, 0x19, 0x91, 0xf9, 0x32, 0x54, 0x73, 0x92, 0x61, 0x2f, 0x24, 0x6a, 0x2e,
-	0x52, 0xed, 0x37, 0x6f, 0x15, 0x42, 0xc8, 0xe3, 0x5d, 0x30, 0xa7, 0x11, 0xbf, 0x0c, 0x63, 0x21,
-	0x25, 0x75, 0xca, 0x96, 0x57, 0x00, 0xec, 0x1b, 0x60, 0xcb, 0x54, 0x5c, 0x85, 0x49, 0x26, 0xfd,
-	0x82, 0x06, 0x07, 0xd5, 0xc3, 0x2d, 0x6f, 0x77, 0x65, 0x19, 0xac, 0xe9, 0x4f, 0xe1, 0xa3, 0x57,
-	0x73, 0x1e, 0xcf, 0x84, 0x3f, 0x4d, 0x93, 0x85, 0x1f, 0x71, 0x4c, 0x7d, 0xac, 0x44, 0x7a, 0xc5,
-	0x23, 0x6a, 0xb1, 0x56, 0xb7, 0xed, 0xae, 0x52, 0xe6, 0x8e, 0x53, 0x11, 0x07, 0xde, 0x1d, 0xad,
-	0x18, 0xa4, 0xc9, 0xe2, 0x94, 0xa3, 0x45, 0xd3, 0xd9, 0x09, 0xb4, 0x74, 0x3c, 0xf2, 0x2e, 0x92,
-	0x4e, 0x83, 0xca, 0xf0, 0x6e, 0xe1, 0x80, 0x1e, 0x38, 0xc8, 0xcd, 0xba, 0xfe, 0xac, 0xb0, 0x8c,
-	0xed, 0xff, 0x0a, 0xec, 0x6d, 0xd2, 0xbb, 0x8a, 0xcc, 0x28, 0x17, 0xd9, 0x0f, 0x60, 0xd0, 0x3d,
-	0x59, 0x03, 0xb6, 0x2f, 0x46, 0xcf, 0x46, 0x67, 0x2f, 0x47, 0xf6, 0x2d, 0x66, 0x81, 0x39, 0x3a,
-	0xf3, 0x4f, 0x9e, 0xf4, 0x46, 0x8f, 0xfb, 0x76, 0x85, 0xd5, 0x61, 0xeb, 0xe2, 0x85, 0xbd, 0xc5,
-	0x76, 0xa0, 0xf6, 0x08, 0x09, 0xd5, 0xce, 0xdf, 0x15, 0x68, 0x3f, 0x11, 0x3c, 0x52, 0x73, 0x8a,
-	0x0c, 0x95, 0xe8, 0xb7, 0x60, 0x48, 0xc5, 0x53, 0x45, 0x07, 0x37, 0xba, 0xfb, 0xae, 0x1e, 0xe9,
-	0xee, 0x6a, 0xa4, 0xbb, 0xeb, 0xf9, 0xe6, 0x69, 0x22, 0x7b, 0x08, 0x55, 0x11, 0x07, 0x74, 0xa9,
-	0xff, 0xe7, 0x23, 0x8d, 0xdd, 0x03, 0x03, 0xfb, 0x18, 0xcb, 0x13, 0x03, 0x65, 0xae, 0x03, 0xe5,
-	0x69, 0x9c, 0x7d, 0x0d, 0xbb, 0xfc, 0x4a, 0xa4, 0x1c, 0xf3, 0xb3, 0x4e, 0x66, 0x8d, 0x72, 0x6e,
-	0xe7, 0x86, 0xc1, 0x3b, 0x52, 0x6f, 0xfc, 0x47, 0xea, 0x3b, 0x1e, 0x34, 0x7b, 0x11, 0x76, 0x72,
-	0x3c, 0x7b, 0xc4, 0x15, 0x67, 0xc7, 0xd0, 0xa6, 0xf4, 0x8b, 0xc5, 0x6a, 0x33, 0xbc, 0xc7, 0xb3,
-	0x2d, 0x94, 0xf4, 0x17, 0xf9, 0xd6, 0xe8, 0xfc, 0x65, 0xc0, 0xed, 0x47, 0x5c, 0xce, 0x27, 0x09,
-	0x4f, 0x83, 0x31, 0x9f, 0xac, 0x76, 0xda, 0x03, 0x68, 0x05, 0x2b, 0xb8, 0xdc, 0xed, 0xd6, 0x1a,
-	0xa5, 0x7e, 0x7f, 0x08, 0xac, 0xa0, 0x29, 0x3e, 0x29, 0x2f, 0x38, 0x3b, 0x28, 0xf9, 0x25, 0xf6,
-	0x1e, 0x18, 0x1c, 0x1f, 0x90, 0x2f, 0x38, 0xfd, 0xc3, 0x86, 0x70, 0x67, 0xaa, 0xa7, 0x9e, 0x1e,
-	0xb4, 0x7a, 0x29, 0xe3, 0x50, 0xac, 0x51, 0x90, 0x6f, 0xdf, 0x30, 0x14, 0xbd, 0xbd, 0xe9, 0x26,
-	0x86, 0xe3, 0xb0, 0x8b, 0x73, 0x5b, 0x2a, 0x3f, 0x5b, 0x06, 0x5c, 0x89, 0xd2, 0x86, 0x33, 0x68,
-	0xc3, 0xdd, 0x46, 0xe3, 0x05, 0xd9, 0x8a, 0x3d, 0x77, 0x07, 0xea, 0x52, 0x71, 0x95, 0x49, 0x6a,
-	0x70, 0xd3, 0xcb, 0xff, 0x58, 0x1f, 0x5a, 0x09, 0x26, 0x2c, 0x8a, 0xfc, 0xdc, 0xbe, 0x4d, 0xdd,
-	0xf5, 0xa9, 0x7b, 0x43, 0xbc, 0x5c, 0xfc, 0x24, 0x96, 0x67, 0xe5, 0x2a, 0xfd, 0x8b, 0x43, 0x33,
-	0xdf, 0x0b, 0xb3, 0x54, 0x88, 0x38, 0xdf, 0x94, 0x0d, 0x8d, 0x3d, 0x46, 0x08, 0x83, 0x48, 0xb7,
-	0x4e, 0xb3, 0xb8, 0x74, 0x65, 0x93, 0xae, 0x6c, 0xa3, 0xc5, 0xcb, 0xe2, 0xe2, 0xbe, 0x1f, 0xc2,
-	0xf6, 0x24, 0x9b, 0xe1, 0xbe, 0xcc, 0x57, 0x65, 0x7d, 0x92, 0xcd, 0x2e, 0xd2, 0x88, 0x75, 0xa1,
-	0x31, 0x2f, 0xda, 0xc1, 0x69, 0x52, 0x29, 0xd8, 0xee, 0x46, 0x8b, 0x78, 0x65, 0x12, 0xbb, 0x0f,
-	0x56, 0xbe, 0x2f, 0x43, 0x29, 0x33, 0x21, 0x1d, 0x8b, 0x36, 0x48, 0x53, 0x83, 0x43, 0xc2, 0x58,
-	0x17, 0x2c, 0x9e, 0xd7, 0x9d, 0x1f, 0x70, 0xc5, 0x69, 0xa7, 0x35, 0xba, 0x96, 0x5b, 0xae, 0x46,
-	0xaf, 0xc9, 0x4b, 0x7f, 0x9d, 0xdf, 0xc1, 0x5c, 0x87, 0x04, 0xfb, 0x7a, 0x74, 0x36, 0xf6, 0xcf,
-	0xfb, 0x63, 0xfb, 0x56, 0xb9, 0xc9, 0x2b, 0xd8, 0xcd, 0x2f, 0x7a, 0xe7, 0xe7, 0xba, 0xaf, 0x07,
-	0xbd, 0xe1, 0xa9, 0x5d, 0x65, 0x26, 0x18, 0x83, 0xd3, 0xde, 0xb3, 0xdf, 0xec, 0x1a, 0x7e, 0x9e,
-	0x8f, 0x7b, 0xa7, 0x7d, 0xdb, 0x60, 0x00, 0xf5, 0x63, 0xef, 0xec, 0x59, 0x7f, 0x64, 0xd7, 0x9f,
-	0xd6, 0x76, 0x1a, 0x76, 0xb3, 0xf3, 0x1c, 0xec, 0x75, 0x26, 0x56, 0x65, 0xfb, 0x13, 0x58, 0x58,
-	0x85, 0x45, 0x09, 0x55, 0xa8, 0x84, 0xf6, 0x6e, 0xca, 0x99, 0xd7, 0x54, 0xab, 0xef, 0x50, 0xc8,
-	0x49, 0x9d, 0x9a, 0xe5, 0xfb, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x30, 0x43, 0x83, 0xdd, 0x15,
-	0x0a, 0x00, 0x00,
+	// 2083 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcb, 0x72, 0xdb, 0xc8,
+	0xd5, 0x36, 0x44, 0x51, 0x22, 0x0f, 0x78, 0x81, 0x5a, 0xb2, 0x0c, 0xfb, 0xf7, 0x3f, 0x56, 0xe8,
+	0xcc, 0x8c, 0x92, 0x71, 0xe8, 0x58, 0xa9, 0xa4, 0x26, 0x49, 0xb9, 0x2a, 0xba, 0x50, 0x36, 0xc7,
+	0x32, 0xa5, 0x6a, 0x52, 0xe3, 0x64, 0x85, 0x02, 0x85, 0x26, 0x85, 0x32, 0x08, 0xb0, 0xd0, 0x0d,
+	0x69, 0x98, 0x75, 0xde, 0x21, 0xc9, 0x2e, 0xfb, 0xe4, 0x01, 0xf2, 0x1e, 0x79, 0x85, 0x2c, 0x53,
+	0x79, 0x86, 0xd4, 0x39, 0xdd, 0x00, 0x21, 0xd9, 0x19, 0x6b, 0x91, 0x1d, 0xfa, 0x3b, 0x5f, 0xdf,
+	0xce, 0xbd, 0x01, 0x4d, 0x99, 0xcd, 0x66, 0x7e, 0xba, 0xe8, 0xce, 0xd3, 0x44, 0x25, 0x8f, 0x9e,
+	0x4c, 0x93, 0x64, 0x1a, 0x89, 0xe7, 0x34, 0x1a, 0x67, 0x93, 0xe7, 0x2a, 0x9c, 0x09, 0xa9, 0xfc,
+	0xd9, 0x5c, 0x13, 0x3a, 0x7f, 0x5f, 0x03, 0x76, 0xec, 0x87, 0x51, 0x18, 0x4f, 0x47, 0x42, 0xaa,
+	0xa1, 0x9e, 0xcd, 0x7e, 0x00, 0x8d, 0x20, 0x94, 0xf3, 0xc8, 0x5f, 0x78, 0xb1, 0x3f, 0x13, 0xae,
+	0xb5, 0x63, 0xed, 0xd6, 0xb9, 0x6d, 0xb0, 0x81, 0x3f, 0x13, 0xec, 0xff, 0xa0, 0xae, 0x84, 0x54,
+	0x5a, 0xbe, 0x42, 0xf2, 0x1a, 0x02, 0x24, 0xec, 0x40, 0x73, 0xe2, 0x87, 0x91, 0x37, 0xce, 0xc2,
+	0x28, 0xf0, 0xc2, 0xc0, 0xad, 0xe8, 0x05, 0x10, 0x3c, 0x40, 0xac, 0x1f, 0xb0, 0xcf, 0xa1, 0x45,
+	0x9c, 0xe2, 0x48, 0xee, 0xea, 0x8e, 0xb5, 0x6b, 0x71, 0x9a, 0x39, 0xca, 0x41, 0x5c, 0x6a, 0xee,
+	0x4b, 0xb9, 0x5c, 0xaa, 0xaa, 0x97, 0x42, 0xb0, 0xb4, 0x14, 0x71, 0x96, 0x4b, 0xad, 0xe9, 0xa5,
+	0x10, 0x5d, 0x2e, 0xf5, 0xff, 0x00, 0xb4, 0xe3, 0x45, 0x92, 0xc5, 0xca, 0x5d, 0xdf, 0xb1, 0x76,
+	0xab, 0xbc, 0x8e, 0xc8, 0x21, 0x02, 0x28, 0xd6, 0x9b, 0x44, 0x61, 0xfc, 0xde, 0xad, 0xd1, 0x36,
+	0x75, 0x42, 0x4e, 0xc2, 0xf8, 0x3d, 0xfb, 0x02, 0xda, 0x4b, 0xb1, 0xa7, 0xc4, 0x77, 0xca, 0xad,
+	0x13, 0xa7, 0x59, 0x70, 0x46, 0xe2, 0x3b, 0xc5, 0x7e, 0x08, 0x2d, 0xcd, 0xcb, 0xd2, 0x48, 0xd3,
+	0x80, 0x68, 0x0d, 0x42, 0xcf, 0xd3, 0x88, 0x58, 0x5f, 0x42, 0x1b, 0x77, 0xce, 0x52, 0xe1, 0xcd,
+	0x84, 0x94, 0xfe, 0x54, 0xb8, 0x36, 0xd1, 0x5a, 0x06, 0x7e, 0xab, 0x51, 0xf6, 0x04, 0x6c, 0xdc,
+	0x50, 0x04, 0xde, 0x38, 0x9b, 0x4a, 0xb7, 0xb1, 0x53, 0xd9, 0xad, 0x73, 0xd0, 0xd0, 0x41, 0x36,
+	0x95, 0xb8, 0x9f, 0xd6, 0x23, 0x5a, 0x83, 0x8e, 0xde, 0xd4, 0xfb, 0x91, 0x1e, 0x85, 0x54, 0x74,
+	0xfa, 0x17, 0x70, 0x3f, 0xf2, 0x89, 0x72, 0x8b, 0xbc, 0x41, 0x64, 0xa6, 0x85, 0xc7, 0xe5, 0x29,
+	0xcf, 0x61, 0xab, 0x3c, 0xa5, 0x30, 0x40, 0x8b, 0x66, 0x6c, 0x2c, 0x67, 0xe4, 0x66, 0x38, 0x04,
+	0x98, 0xa7, 0xc9, 0x5c, 0xa4, 0x2a, 0x14, 0xd2, 0x6d, 0xef, 0x54, 0x76, 0xed, 0xbd, 0xa7, 0xdd,
+	0x0f, 0xdd, 0xab, 0x7b, 0x56, 0xb0, 0x7a, 0xb1, 0x4a, 0x17, 0xbc, 0x34, 0x0d, 0xef, 0x7b, 0x99,
+	0xa8, 0x28, 0x94, 0xca, 0x0b, 0x03, 0xe9, 0x3a, 0xfa, 0xbe, 0x06, 0xea, 0x07, 0x92, 0x3d, 0x84,
+	0x9a, 0x1f, 0x89, 0x14, 0xc5, 0x2e, 0xa3, 0xa3, 0xac, 0xd3, 0xb8, 0x1f, 0xb0, 0x67, 0x60, 0x6b,
+	0x91, 0x54, 0xbe, 0x12, 0xee, 0xe6, 0x8e, 0xb5, 0x6b, 0xef, 0xd9, 0xdd, 0x7d, 0xc4, 0x86, 0x08,
+	0x71, 0xf0, 0x8b, 0xef, 0x47, 0x2f, 0xa1, 0x7d, 0xeb, 0x20, 0xcc, 0x81, 0xca, 0x7b, 0xb1, 0x30,
+	0xee, 0x8e, 0x9f, 0x6c, 0x0b, 0xaa, 0x57, 0x7e, 0x94, 0xe5, 0x2e, 0xae, 0x07, 0xbf, 0x5a, 0xf9,
+	0xda, 0xea, 0xfc, 0x75, 0x05, 0x60, 0xb9, 0x32, 0x7b, 0x09, 0x0d, 0x19, 0x27, 0xc9, 0xef, 0x85,
+	0x97, 0xc5, 0x2a, 0x8c, 0x68, 0x0d, 0x7b, 0xef, 0x51, 0x57, 0x47, 0x60, 0x37, 0x8f, 0xc0, 0x6e,
+	0xe1, 0x8e, 0xdc, 0xd6, 0xfc, 0x73, 0xa4, 0xa3, 0x3f, 0xf8, 0x17, 0xef, 0xe3, 0xe4, 0x3a, 0x12,
+	0xc1, 0x14, 0x8d, 0xbd, 0x30, 0x3b, 0xb6, 0xca, 0xf0, 0xc1, 0x82, 0xf5, 0xc0, 0x29, 0x21, 0xe4,
+	0xf2, 0x14, 0x5d, 0xdf, 0xbf, 0x57, 0x79, 0x71, 0x44, 0xd9, 0x0b, 0xd8, 0x8a, 0x13, 0x15, 0x4e,
+	0x42, 0x11, 0x78, 0x93, 0x30, 0x9e, 0x8a, 0x74, 0x9e, 0x86, 0xb1, 0xa2, 0x18, 0xac, 0xf3, 0xcd,
+	0x5c, 0x76, 0xbc, 0x14, 0xb1, 0x5f, 0x83, 0x4d, 0xf0, 0x42, 0x6f, 0x5a, 0xfd, 0xe4, 0xa6, 0xa0,
+	0xe9, 0x08, 0x74, 0xfe, 0x5c, 0x85, 0x1a, 0xba, 0x40, 0x3f, 0x9e, 0x24, 0x77, 0x49, 0x2f, 0xcf,
+	0x61, 0x4b, 0x25, 0xca, 0x8f, 0xbc, 0x38, 0x89, 0xbd, 0x30, 0x9e, 0xa4, 0xbe, 0x97, 0x66, 0xb1,
+	0x24, 0xa5, 0x54, 0xf9, 0x06, 0xc9, 0x06, 0x49, 0xdc, 0x47, 0x09, 0xcf, 0x62, 0x89, 0x0e, 0x8e,
+	0xd1, 0x2e, 0x82, 0xdb, 0x33, 0x2a, 0x34, 0x83, 0x69, 0xe1, 0xed, 0x29, 0xe8, 0xd9, 0x1f, 0x4e,
+	0x59, 0xd5, 0x53, 0xb4, 0xf0, 0xc6, 0x94, 0x1f, 0xc3, 0x86, 0x99, 0x52, 0xa2, 0x57, 0x89, 0xde,
+	0xd6, 0x82, 0x1b, 0xcb, 0xeb, 0x2b, 0x20, 0xc9, 0xbb, 0x0e, 0xd5, 0xa5, 0x9e, 0x44, 0xc9, 0xa9,
+	0xca, 0x19, 0x09, 0x91, 0xf9, 0x2e, 0x54, 0x97, 0x34, 0x0d, 0x53, 0x50, 0xa2, 0x2e, 0x45, 0xaa,
+	0xd7, 0x35, 0x19, 0x8a, 0x10, 0x5a, 0xf1, 0x31, 0xd4, 0x27, 0x91, 0xff, 0x3e, 0x8c, 0x85, 0x94,
+	0x94, 0xa0, 0x56, 0xf8, 0x12, 0x60, 0x3f, 0x01, 0x36, 0x4f, 0xc5, 0x55, 0x98, 0x64, 0xd2, 0x5b,
+	0xd2, 0x60, 0xa7, 0xb2, 0xbb, 0xc2, 0x37, 0x72, 0xc9, 0x71, 0x41, 0xff, 0x06, 0x1e, 0x5e, 0x5c,
+	0xfa, 0xf1, 0x54, 0x78, 0x93, 0x34, 0x99, 0x79, 0x91, 0x8f, 0x11, 0x17, 0x2b, 0x91, 0x5e, 0xf9,
+	0x11, 0x65, 0xb6, 0xd6, 0x5e, 0xbb, 0x9b, 0x9b, 0xac, 0x3b, 0x4a, 0x45, 0x1c, 0xf0, 0x6d, 0x3d,
+	0xe3, 0x38, 0x4d, 0x66, 0x27, 0x3e, 0x4a, 0x34, 0x9d, 0x1d, 0x42, 0x4b, 0xeb, 0xc3, 0x24, 0x2f,
+	0xe9, 0xda, 0x14, 0xfd, 0x8f, 0x97, 0x0b, 0xd0, 0x05, 0x8f, 0x8d, 0x58, 0x87, 0x7d, 0x33, 0x2c,
+	0x63, 0x8f, 0x7e, 0x03, 0xec, 0x43, 0xd2, 0xa7, 0x42, 0xb2, 0x5a, 0x0e, 0xc9, 0x9f, 0x43, 0x95,
+	0xce, 0xc9, 0x6c, 0x58, 0x3f, 0x1f, 0xbc, 0x19, 0x9c, 0xbe, 0x1b, 0x38, 0xf7, 0x58, 0x13, 0xea,
+	0x83, 0x53, 0xef, 0xf0, 0xf5, 0xfe, 0xe0, 0x55, 0xcf, 0xb1, 0xd8, 0x1a, 0xac, 0x9c, 0x9f, 0x39,
+	0x2b, 0xac, 0x06, 0xab, 0x47, 0x48, 0xa8, 0x74, 0xfe, 0x6d, 0x41, 0xfb, 0xb5, 0xf0, 0x23, 0x75,
+	0x49, 0x9a, 0x21, 0x17, 0xfd, 0x29, 0x54, 0xa5, 0xf2, 0x53, 0x75, 0x87, 0x38, 0xd6, 0x44, 0xf6,
+	0x0c, 0x2a, 0x22, 0x0e, 0xe8, 0x50, 0xdf, 0xcf, 0x47, 0x1a, 0x7b, 0x02, 0x55, 0x4c, 0x9f, 0xe8,
+	0x9e, 0xa8, 0xa8, 0x7a, 0xa1, 0x28, 0xae, 0x71, 0xf6, 0x15, 0x6c, 0xf8, 0x57, 0x22, 0xf5, 0xd1,
+	0x3e, 0x85, 0x31, 0x57, 0xc9, 0xe6, 0x8e, 0x11, 0x1c, 0x7f, 0xc2, 0xf4, 0xd5, 0xff, 0x62, 0xfa,
+	0x0e, 0x87, 0x06, 0x65, 0xae, 0x30, 0x9e, 0x1e, 0xf9, 0xca, 0x67, 0x07, 0xd0, 0x26, 0xf3, 0x8b,
+	0x59, 0x5e, 0x90, 0xef, 0x70, 0xed, 0x26, 0x4e, 0xe9, 0xcd, 0x4c, 0xb1, 0xee, 0xfc, 0xa3, 0x0e,
+	0x9b, 0x47, 0xbe, 0xbc, 0x1c, 0x27, 0x7e, 0x1a, 0x8c, 0xfc, 0x71, 0xde, 0x4a, 0x7c, 0x0e, 0xad,
+	0x20, 0x87, 0xcb, 0xd1, 0xde, 0x2c, 0x50, 0x8a, 0xf7, 0x67, 0xc0, 0x96, 0x34, 0xe5, 0x8f, 0xcb,
+	0x7d, 0x85, 0x13, 0x94, 0xd6, 0x25, 0xf6, 0x16, 0x54, 0x29, 0x91, 0x9b, 0xbe, 0x42, 0x0f, 0x58,
+	0x1f, 0xb6, 0x27, 0xba, 0xd8, 0xe8, 0xfa, 0xa6, 0x7b, 0x21, 0xac, 0x45, 0xab, 0xa4, 0xe4, 0xcd,
+	0x8f, 0xd4, 0x22, 0xbe, 0x35, 0xb9, 0x8d, 0x61, 0x15, 0xda, 0xc3, 0x72, 0x29, 0x95, 0x97, 0xcd,
+	0x03, 0x5f, 0x89, 0x52, 0x63, 0x51, 0xa5, 0xc6, 0x62, 0x13, 0x85, 0xe7, 0x24, 0x5b, 0xb6, 0x17,
+	0xdb, 0xb0, 0x86, 0x75, 0x27, 0x93, 0x14, 0xe0, 0x75, 0x6e, 0x46, 0xac, 0x07, 0xad, 0x04, 0x0d,
+	0x16, 0x45, 0x9e, 0x91, 0xaf, 0x53, 0x74, 0x7d, 0xd6, 0xfd, 0x88, 0xbe, 0xba, 0xf8, 0x49, 0x2c,
+	0xde, 0x34, 0xb3, 0xf4, 0x10, 0x93, 0xa6, 0x29, 0xc7, 0xd3, 0x54, 0x88, 0xd8, 0x34, 0x28, 0xb6,
+	0xc6, 0x5e, 0x21, 0x84, 0x4a, 0xa4, 0x53, 0xa7, 0x59, 0x5c, 0x3a, 0x72, 0x9d, 0x8e, 0xec, 0xa0,
+	0x84, 0x67, 0xf1, 0xf2, 0xbc, 0x0f, 0x60, 0x7d, 0x9c, 0x4d, 0xb1, 0x4d, 0x31, 0x1d, 0xca, 0xda,
+	0x38, 0x9b, 0x9e, 0xa7, 0x11, 0xdb, 0x03, 0xfb, 0x72, 0x19, 0x0e, 0x6e, 0x83, 0x5c, 0xc1, 0xe9,
+	0xde, 0x0a, 0x11, 0x5e, 0x26, 0xb1, 0xa7, 0xd0, 0x34, 0x6d, 0x4a, 0x28, 0x65, 0x26, 0xa4, 0xdb,
+	0xa4, 0xc2, 0xdd, 0xd0, 0x60, 0x9f, 0x30, 0xb6, 0x07, 0x4d, 0xdf, 0xf8, 0x9d, 0x17, 0xf8, 0xca,
+	0xa7, 0x56, 0xc2, 0xde, 0x6b, 0x76, 0xcb, 0xde, 0xc8, 0x1b, 0x7e, 0xd9, 0x37, 0xbf, 0x84, 0xf6,
+	0x34, 0x0d, 0x03, 0x6f, 0x2a, 0x62, 0x91, 0xfa, 0x2a, 0x4c, 0x62, 0xb7, 0xbd, 0x63, 0xed, 0x56,
+	0x78, 0x0b, 0xe1, 0x57, 0x05, 0x8a, 0x31, 0x70, 0x91, 0xc4, 0x93, 0x70, 0x7a, 0xa3, 0x9e, 0x39,
+	0xba, 0x59, 0xd1, 0x92, 0x72, 0x35, 0x7b, 0x09, 0x1b, 0xe3, 0x2c, 0x98, 0x0a, 0xe5, 0x5d, 0x85,
+	0x49, 0x44, 0x4b, 0x48, 0x77, 0x83, 0xfc, 0xc4, 0xe9, 0x1e, 0x90, 0xe4, 0xdb, 0x5c, 0xc0, 0x9d,
+	0xf1, 0x4d, 0x40, 0xb2, 0x2f, 0xa0, 0x8e, 0x5e, 0xaa, 0xbd, 0x90, 0xd1, 0x35, 0xea, 0x68, 0x3b,
+	0xba, 0x09, 0xaf, 0x29, 0xf3, 0x85, 0x57, 0xd6, 0x46, 0xd7, 0x5d, 0xa7, 0x34, 0x4d, 0x49, 0xb3,
+	0xab, 0xad, 0x4a, 0x9d, 0xa7, 0xe4, 0x0d, 0x59, 0x1a, 0xb1, 0xe7, 0xd0, 0x10, 0x69, 0x9a, 0xa4,
+	0x9e, 0xde, 0xd5, 0xdd, 0xa2, 0x29, 0x8d, 0x6e, 0x0f, 0x41, 0x7d, 0x34, 0x6e, 0x8b, 0xe5, 0xa0,
+	0xd0, 0xab, 0x77, 0x19, 0x4a, 0x95, 0xa4, 0x0b, 0xf7, 0x3e, 0xdd, 0xc3, 0xe8, 0xb5, 0x37, 0x0f,
+	0x65, 0x12, 0x08, 0xa3, 0xd7, 0xd7, 0x9a, 0x82, 0x17, 0x48, 0x93, 0x6b, 0xf2, 0x48, 0xe9, 0x6e,
+	0x9b, 0x24, 0xc4, 0x93, 0x6b, 0x3c, 0x97, 0xe4, 0xb5, 0xd4, 0x7c, 0xb1, 0x17, 0xd0, 0x4c, 0xc5,
+	0x2c, 0xb9, 0x12, 0x81, 0xa7, 0x13, 0xd6, 0x03, 0xe2, 0x36, 0xba, 0x5c, 0xa3, 0x18, 0x37, 0xbc,
+	0x91, 0x2e, 0x07, 0x32, 0xef, 0xec, 0xd1, 0xca, 0x17, 0x8b, 0x8b, 0x48, 0x48, 0xd7, 0xa5, 0x4c,
+	0xdd, 0x34, 0xe8, 0x21, 0x81, 0x48, 0x2b, 0x5a, 0x10, 0xa9, 0xc4, 0x5c, 0xba, 0x0f, 0xc9, 0x67,
+	0x9a, 0x39, 0x3a, 0x44, 0xb0, 0x93, 0x41, 0xbd, 0x88, 0x09, 0x4c, 0xec, 0x83, 0xd3, 0x91, 0x37,
+	0xec, 0x8d, 0x9c, 0x7b, 0xe5, 0x2c, 0x6f, 0x61, 0x3a, 0x3f, 0xdb, 0x1f, 0x0e, 0x75, 0x62, 0x3f,
+	0xde, 0xef, 0x9f, 0x38, 0x15, 0x56, 0x87, 0xea, 0xf1, 0xc9, 0xfe, 0x9b, 0xdf, 0x39, 0xab, 0xf8,
+	0x39, 0x1c, 0xed, 0x9f, 0xf4, 0x9c, 0x2a, 0x03, 0x58, 0x3b, 0xe0, 0xa7, 0x6f, 0x7a, 0x03, 0x67,
+	0x0d, 0xbf, 0xcf, 0xf6, 0xcf, 0x87, 0xbd, 0x23, 0x67, 0x9d, 0x35, 0xa0, 0xb6, 0xcf, 0x0f, 0x5f,
+	0xf7, 0xbf, 0xed, 0x1d, 0x39, 0xb5, 0x6f, 0x56, 0x6b, 0xb6, 0xd3, 0xe8, 0xfc, 0xc9, 0x02, 0xbb,
+	0x74, 0xd1, 0xff, 0xc5, 0xc3, 0x88, 0x22, 0xf4, 0xf6, 0xc3, 0x08, 0xc1, 0xd2, 0x6b, 0x86, 0x38,
+	0x1f, 0x3c, 0x8c, 0x10, 0x2d, 0xc2, 0xb7, 0xf3, 0x2f, 0x0b, 0x5a, 0x3c, 0xb9, 0x7e, 0x17, 0xc6,
+	0x41, 0x6e, 0x2b, 0x06, 0xab, 0x81, 0xbf, 0x90, 0x74, 0xaa, 0x2a, 0xa7, 0x6f, 0xc4, 0x4a, 0x8d,
+	0x13, 0x7d, 0x63, 0xa6, 0xa2, 0x76, 0x28, 0x6f, 0x8e, 0xcc, 0x88, 0x3d, 0x82, 0x5a, 0x51, 0xc0,
+	0x75, 0x0f, 0x54, 0x8c, 0xf1, 0xe6, 0xf4, 0xc6, 0x9a, 0x8b, 0xf4, 0x42, 0xc4, 0x8a, 0x12, 0xe1,
+	0x8a, 0x7e, 0x86, 0x9d, 0x69, 0x88, 0x7d, 0x0d, 0x6e, 0x5e, 0xb2, 0x82, 0x4c, 0x47, 0xa5, 0x27,
+	0xc5, 0x45, 0x12, 0x07, 0xd2, 0x3c, 0xc8, 0xb6, 0x8d, 0xfc, 0xc8, 0x88, 0x87, 0x5a, 0xaa, 0x73,
+	0x9b, 0x79, 0x68, 0x64, 0xa9, 0xa0, 0x04, 0x69, 0x69, 0xad, 0x98, 0x6e, 0xa0, 0xf3, 0x5b, 0xa8,
+	0xe5, 0xde, 0x79, 0x17, 0x2b, 0xfc, 0x08, 0xd6, 0xaf, 0x49, 0x33, 0x78, 0x73, 0x74, 0xd8, 0x76,
+	0xf7, 0xa6, 0xb2, 0x78, 0x2e, 0xef, 0x1c, 0x98, 0x6a, 0x68, 0xe2, 0x04, 0xb5, 0x93, 0xcc, 0x45,
+	0x2c, 0x02, 0x5a, 0xd7, 0xe2, 0x66, 0x84, 0xda, 0x49, 0x85, 0x4c, 0xa2, 0x2b, 0xa1, 0xab, 0xbc,
+	0xc5, 0x8b, 0x71, 0xe7, 0x2f, 0x16, 0xd8, 0xa5, 0xf0, 0x64, 0xbf, 0x80, 0x07, 0x7e, 0x14, 0x25,
+	0xd7, 0xd8, 0x5d, 0xe7, 0xa1, 0x90, 0x44, 0xd9, 0x2c, 0xce, 0x8d, 0x73, 0xdf, 0x88, 0x4d, 0x45,
+	0x3a, 0xd4, 0xc2, 0xfc, 0x59, 0x58, 0xe6, 0x6b, 0xc3, 0xe5, 0x11, 0x95, 0x13, 0x1f, 0x43, 0x3d,
+	0xc5, 0x72, 0x1d, 0x87, 0xf1, 0xd4, 0x58, 0x71, 0x09, 0x14, 0x8e, 0xb0, 0xba, 0x74, 0x84, 0xce,
+	0x3f, 0x2d, 0xa8, 0xe5, 0x09, 0x8a, 0xed, 0xc2, 0x5a, 0x2a, 0x7c, 0x99, 0xc4, 0x74, 0x9c, 0xd6,
+	0x9e, 0x53, 0xe4, 0xae, 0x2e, 0x27, 0x9c, 0x1b, 0x39, 0x96, 0x5a, 0x19, 0xc6, 0x17, 0xc2, 0x5c,
+	0x59, 0x0f, 0x3a, 0x7f, 0xb4, 0x60, 0x4d, 0x13, 0xd9, 0x36, 0x30, 0xde, 0xdb, 0x1f, 0x9e, 0x0e,
+	0xbc, 0xf3, 0xc1, 0xf0, 0xac, 0x77, 0xd8, 0x3f, 0xee, 0xf7, 0x8e, 0x9c, 0x7b, 0xec, 0x3e, 0x6c,
+	0x0c, 0x4e, 0xbd, 0xe1, 0xe8, 0x94, 0xf7, 0x8e, 0x3c, 0xde, 0x1b, 0x9e, 0x9f, 0x8c, 0x86, 0x8e,
+	0xc5, 0x5c, 0xd8, 0xc2, 0x6e, 0xec, 0xf4, 0xed, 0xd9, 0x49, 0x6f, 0x54, 0x92, 0xac, 0x60, 0x9f,
+	0x76, 0x3e, 0xd0, 0x6d, 0xda, 0x91, 0x53, 0x61, 0x6d, 0xb0, 0x4f, 0x4f, 0x96, 0xf2, 0xd5, 0x1b,
+	0xf1, 0x59, 0x2d, 0x45, 0x2e, 0x45, 0x31, 0x46, 0x3c, 0x46, 0x71, 0xe7, 0x6f, 0x16, 0x34, 0xca,
+	0xb9, 0x15, 0x55, 0x8a, 0x4e, 0xfa, 0xa1, 0x09, 0x5a, 0x06, 0xce, 0x55, 0xfa, 0x15, 0x6c, 0x5c,
+	0x24, 0xb3, 0x79, 0x24, 0x94, 0x08, 0x6e, 0x69, 0xdf, 0x29, 0x04, 0x39, 0xf9, 0xa9, 0xfe, 0x2d,
+	0x41, 0xab, 0x8a, 0x28, 0xca, 0x23, 0xa9, 0x91, 0xaf, 0x89, 0x18, 0xfa, 0xe9, 0x24, 0x8c, 0xf0,
+	0xb5, 0xa0, 0x39, 0xda, 0x1c, 0xb6, 0xc6, 0x88, 0xd2, 0xf9, 0x83, 0x05, 0xed, 0x5b, 0xd5, 0xe6,
+	0x2e, 0xee, 0xfd, 0x19, 0x40, 0xa9, 0x6c, 0xe9, 0x43, 0x96, 0x10, 0xd6, 0x85, 0x4d, 0xd3, 0x2c,
+	0x60, 0x13, 0xe1, 0xcd, 0xc2, 0x38, 0x53, 0x26, 0xdc, 0xad, 0xfc, 0xe9, 0x7e, 0x7a, 0x25, 0xd2,
+	0xb7, 0x5a, 0xd0, 0x79, 0x0b, 0x4e, 0xd1, 0x8c, 0xe4, 0x9d, 0xdb, 0x2f, 0xa1, 0x89, 0x25, 0x6e,
+	0xd9, 0x45, 0x59, 0x14, 0x48, 0x5b, 0x1f, 0x6b, 0x5b, 0x78, 0x43, 0xe5, 0xdf, 0xa1, 0x90, 0xe3,
+	0x35, 0xea, 0x17, 0x7f, 0xf6, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0x09, 0xab, 0xd5, 0x8f,
+	0x12, 0x00, 0x00,
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+)
+
+const defaultSnoozeHours = 24
+
+// serveAlert snoozes or acknowledges an alert in the dashboard's summary for
+// authenticated POST requests.
+//
+// Snoozes last for the hours query parameter, 24 by default.
+func (s *Server) serveAlert(w http.ResponseWriter, r *http.Request, dashboard, alertID string) {
+	if s.Alerts == nil {
+		http.Error(w, "alerts are disabled", http.StatusNotImplemented)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id := IdentityFromContext(r.Context())
+	if id == nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"alert":     alertID,
+		"identity":  id.String(),
+	})
+	path, err := summarizer.SummaryPath(s.Reader.ConfigPath, s.Reader.SummaryPrefix, dashboard)
+	if err != nil {
+		log.WithError(err).Warning("Failed to resolve summary")
+		http.Error(w, "failed to resolve summary", http.StatusInternalServerError)
+		return
+	}
+
+	now := s.clock()
+	action := r.URL.Query().Get("action")
+	switch action {
+	case "snooze":
+		hours := defaultSnoozeHours
+		if v := r.URL.Query().Get("hours"); v != "" {
+			if hours, err = strconv.Atoi(v); err != nil || hours <= 0 {
+				http.Error(w, "hours must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+		until := now.Add(time.Duration(hours) * time.Hour)
+		log = log.WithField("until", until)
+		err = summarizer.SnoozeAlert(r.Context(), s.Alerts, *path, alertID, until)
+	case "acknowledge":
+		err = summarizer.AcknowledgeAlert(r.Context(), s.Alerts, *path, alertID, id.String(), now)
+	default:
+		http.Error(w, "action must be snooze or acknowledge", http.StatusBadRequest)
+		return
+	}
+	switch {
+	case errors.Is(err, summarizer.ErrAlertNotFound), errors.Is(err, storage.ErrObjectNotExist):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to update alert")
+		http.Error(w, "failed to update alert", http.StatusInternalServerError)
+		return
+	}
+	log.WithField("action", action).Info("Updated alert")
+	w.WriteHeader(http.StatusNoContent)
+}
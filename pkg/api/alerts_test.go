@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestServeAlert(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := summarizer.SummaryPath(*configPath, "summary", "dash")
+	if err != nil {
+		t.Fatalf("SummaryPath(): %v", err)
+	}
+	existing := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardName:    "dash",
+				DashboardTabName: "tab",
+				FailingTestSummaries: []*summarypb.FailingTestSummary{
+					{TestName: "test", AlertId: "abc"},
+				},
+			},
+		},
+	}
+	buf, err := proto.Marshal(existing)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	now := time.Unix(1000, 0)
+	carol := &Identity{Email: "carol@example.com", Method: "oidc"}
+
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		id       *Identity
+		disabled bool
+		want     int
+		wantSave *summarypb.AlertState
+	}{
+		{
+			name:   "snooze",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "abc") + "?action=snooze&hours=2",
+			id:     carol,
+			want:   http.StatusNoContent,
+			wantSave: &summarypb.AlertState{
+				SnoozeUntil: &timestamp.Timestamp{Seconds: 1000 + 2*3600},
+			},
+		},
+		{
+			name:   "snooze for a day by default",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "abc") + "?action=snooze",
+			id:     carol,
+			want:   http.StatusNoContent,
+			wantSave: &summarypb.AlertState{
+				SnoozeUntil: &timestamp.Timestamp{Seconds: 1000 + 24*3600},
+			},
+		},
+		{
+			name:   "acknowledge",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "abc") + "?action=acknowledge",
+			id:     carol,
+			want:   http.StatusNoContent,
+			wantSave: &summarypb.AlertState{
+				AcknowledgedBy:      "carol@example.com",
+				AcknowledgeTime:     &timestamp.Timestamp{Seconds: 1000},
+				NotifiedFingerprint: "ignored",
+			},
+		},
+		{
+			name:     "disabled",
+			method:   http.MethodPost,
+			path:     AlertPath("dash", "abc") + "?action=acknowledge",
+			id:       carol,
+			disabled: true,
+			want:     http.StatusNotImplemented,
+		},
+		{
+			name:   "anonymous",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "abc") + "?action=acknowledge",
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "get",
+			method: http.MethodGet,
+			path:   AlertPath("dash", "abc") + "?action=acknowledge",
+			id:     carol,
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "unknown action",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "abc") + "?action=mute",
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "bad hours",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "abc") + "?action=snooze&hours=-1",
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown alert",
+			method: http.MethodPost,
+			path:   AlertPath("dash", "missing") + "?action=acknowledge",
+			id:     carol,
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "unknown dashboard",
+			method: http.MethodPost,
+			path:   AlertPath("other", "abc") + "?action=acknowledge",
+			id:     carol,
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client: fake.Client{Opener: fake.Opener{
+						*summaryPath: {Data: string(buf)},
+					}},
+					Uploader: fake.Uploader{},
+					Stater: fake.Stater{
+						*summaryPath: {Attrs: storage.ObjectAttrs{Generation: 1}},
+					},
+				},
+			}
+			s := Server{
+				Reader: tabs.Reader{ConfigPath: *configPath, SummaryPrefix: "summary"},
+				now:    func() time.Time { return now },
+			}
+			if !tc.disabled {
+				s.Alerts = client
+			}
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.id != nil {
+				r = r.WithContext(context.WithValue(r.Context(), identityKey{}, tc.id))
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP(%s %s) got %d, want %d: %s", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+			}
+			var got *summarypb.AlertState
+			if u, ok := client.Uploader[*summaryPath]; ok {
+				var sum summarypb.DashboardSummary
+				if err := proto.Unmarshal(u.Buf, &sum); err != nil {
+					t.Fatalf("proto.Unmarshal(): %v", err)
+				}
+				got = sum.TabSummaries[0].FailingTestSummaries[0].AlertState
+				if got.NotifiedFingerprint != "" {
+					got.NotifiedFingerprint = "ignored"
+				}
+			}
+			if diff := cmp.Diff(tc.wantSave, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ServeHTTP() saved unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
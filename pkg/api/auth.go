@@ -0,0 +1,375 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/idtoken"
+)
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	// Subject uniquely identifies the caller.
+	Subject string
+	// Email of the caller, if known.
+	Email string
+	// Method is the authentication method, such as iap, oidc or mtls.
+	Method string
+}
+
+func (id *Identity) String() string {
+	if id == nil {
+		return "anonymous"
+	}
+	if id.Email != "" {
+		return id.Email
+	}
+	return id.Subject
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the identity of the request, or nil when it is anonymous.
+func IdentityFromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
+
+// Authenticator identifies the caller of a request.
+//
+// Returns a nil identity and error when the request lacks credentials for the
+// authenticator, and an error when its credentials are invalid.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// TokenValidator validates an ID token for the audience, such as idtoken.Validate.
+type TokenValidator func(ctx context.Context, token, audience string) (*idtoken.Payload, error)
+
+// tokenIdentity validates the token and returns the identity of its subject.
+func tokenIdentity(ctx context.Context, validate TokenValidator, token, audience, method string, issuers ...string) (*Identity, error) {
+	if validate == nil {
+		validate = idtoken.Validate
+	}
+	payload, err := validate(ctx, token, audience)
+	if err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	if len(issuers) > 0 {
+		var found bool
+		for _, iss := range issuers {
+			if payload.Issuer == iss {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("untrusted issuer %q", payload.Issuer)
+		}
+	}
+	id := Identity{Subject: payload.Subject, Method: method}
+	id.Email, _ = payload.Claims["email"].(string)
+	return &id, nil
+}
+
+const (
+	iapHeader = "X-Goog-IAP-JWT-Assertion"
+	iapIssuer = "https://cloud.google.com/iap"
+)
+
+// IAP authenticates requests forwarded by Google Cloud Identity-Aware Proxy.
+type IAP struct {
+	// Audience of the IAP backend, such as /projects/NUMBER/global/backendServices/ID
+	Audience string
+	// Validate defaults to idtoken.Validate.
+	Validate TokenValidator
+}
+
+// Authenticate validates the signed IAP header.
+func (a IAP) Authenticate(r *http.Request) (*Identity, error) {
+	token := r.Header.Get(iapHeader)
+	if token == "" {
+		return nil, nil
+	}
+	return tokenIdentity(r.Context(), a.Validate, token, a.Audience, "iap", iapIssuer)
+}
+
+// OIDC authenticates requests with an OpenID Connect ID token bearer credential.
+//
+// Tokens must come from one of the Issuers, whose signing keys are found with
+// OpenID Connect discovery the first time each issuer signs a token.
+type OIDC struct {
+	Audience string
+	// Issuers trusted to sign tokens, such as https://accounts.google.com
+	Issuers []string
+
+	lock      sync.Mutex
+	verifiers map[string]*oidc.IDTokenVerifier
+}
+
+// Authenticate verifies the bearer token of the Authorization header.
+//
+// The identity only includes the email of the token when the issuer verified it.
+func (a *OIDC) Authenticate(r *http.Request) (*Identity, error) {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return nil, nil
+	}
+	const bearer = "bearer "
+	if len(auth) <= len(bearer) || !strings.EqualFold(auth[:len(bearer)], bearer) {
+		return nil, errors.New("authorization is not a bearer token")
+	}
+	token := auth[len(bearer):]
+	issuer, err := tokenIssuer(token)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	verifier, err := a.verifier(issuer)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := verifier.Verify(r.Context(), token)
+	if err != nil {
+		return nil, fmt.Errorf("verify: %w", err)
+	}
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	id := Identity{Subject: idToken.Subject, Method: "oidc"}
+	if claims.EmailVerified {
+		id.Email = claims.Email
+	}
+	return &id, nil
+}
+
+// verifier returns the verifier of tokens signed by a trusted issuer, discovering its keys if necessary.
+func (a *OIDC) verifier(issuer string) (*oidc.IDTokenVerifier, error) {
+	var trusted bool
+	for _, iss := range a.Issuers {
+		if iss == issuer {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return nil, fmt.Errorf("untrusted issuer %q", issuer)
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if v, ok := a.verifiers[issuer]; ok {
+		return v, nil
+	}
+	// The provider refreshes its keys with this context long after the request.
+	provider, err := oidc.NewProvider(context.Background(), issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s: %w", issuer, err)
+	}
+	if a.verifiers == nil {
+		a.verifiers = map[string]*oidc.IDTokenVerifier{}
+	}
+	v := provider.Verifier(&oidc.Config{ClientID: a.Audience})
+	a.verifiers[issuer] = v
+	return v, nil
+}
+
+// tokenIssuer returns the unverified issuer of the token, which selects the keys that verify it.
+func tokenIssuer(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("decode payload: %w", err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("unmarshal payload: %w", err)
+	}
+	return claims.Issuer, nil
+}
+
+// ClientCert authenticates requests with a verified TLS client certificate.
+//
+// The server must verify client certificates, for example with
+// tls.VerifyClientCertIfGiven and a ClientCAs pool.
+type ClientCert struct{}
+
+// Authenticate identifies the caller from its verified certificate.
+//
+// The subject is the first URI SAN, such as a SPIFFE ID, or else the common name.
+func (ClientCert) Authenticate(r *http.Request) (*Identity, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	id := Identity{Subject: cert.Subject.CommonName, Method: "mtls"}
+	if len(cert.URIs) > 0 {
+		id.Subject = cert.URIs[0].String()
+	}
+	if len(cert.EmailAddresses) > 0 {
+		id.Email = cert.EmailAddresses[0]
+	}
+	if id.Subject == "" {
+		id.Subject = id.Email
+	}
+	return &id, nil
+}
+
+// Authorizer returns true when the identity may make the request.
+//
+// The identity is nil for anonymous requests.
+type Authorizer func(id *Identity, r *http.Request) bool
+
+// RBAC authorizes readers to get resources and writers to get or change them.
+//
+// Members are an email or subject, domain:example.com for every email in a
+// domain, or * for every authenticated identity.
+type RBAC struct {
+	Readers []string
+	Writers []string
+}
+
+func matchMember(id *Identity, members []string) bool {
+	if id == nil {
+		return false
+	}
+	for _, m := range members {
+		switch {
+		case m == "*":
+			return true
+		case strings.HasPrefix(m, "domain:"):
+			if id.Email != "" && strings.HasSuffix(id.Email, "@"+strings.TrimPrefix(m, "domain:")) {
+				return true
+			}
+		case m == id.Subject, id.Email != "" && m == id.Email:
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize the request.
+func (rbac RBAC) Authorize(id *Identity, r *http.Request) bool {
+	if matchMember(id, rbac.Writers) {
+		return true
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	return matchMember(id, rbac.Readers)
+}
+
+// Auth authenticates and authorizes requests before serving them.
+//
+// Every request is recorded in the audit log with the identity of the caller:
+// changes at info level and reads at debug level.
+type Auth struct {
+	// Authenticators identify the caller, the first identity wins.
+	Authenticators []Authenticator
+	// Authorize defaults to allowing every authenticated request.
+	Authorize Authorizer
+	// AllowAnonymous serves requests without credentials, subject to Authorize.
+	AllowAnonymous bool
+	// Log is the audit log.
+	Log logrus.FieldLogger
+}
+
+// Wrap returns a handler which serves authorized requests with next.
+//
+// Handlers read the identity with IdentityFromContext.
+func (a *Auth) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log := a.log().WithFields(logrus.Fields{
+			"method": r.Method,
+			"path":   r.URL.Path,
+		})
+		id, err := a.authenticate(r)
+		if err != nil {
+			log.WithError(err).Warning("Rejected invalid credentials")
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		log = log.WithField("identity", id.String())
+		if id != nil {
+			log = log.WithField("auth", id.Method)
+		}
+		if id == nil && !a.AllowAnonymous {
+			log.Info("Rejected anonymous request")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		if a.Authorize != nil && !a.Authorize(id, r) {
+			log.Warning("Denied unauthorized request")
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		rec := statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next.ServeHTTP(&rec, r.WithContext(context.WithValue(r.Context(), identityKey{}, id)))
+		log = log.WithField("code", rec.code)
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			log.Debug("Served request")
+		} else {
+			log.Info("Served request")
+		}
+	})
+}
+
+func (a *Auth) authenticate(r *http.Request) (*Identity, error) {
+	for _, authn := range a.Authenticators {
+		id, err := authn.Authenticate(r)
+		if err != nil || id != nil {
+			return id, err
+		}
+	}
+	return nil, nil
+}
+
+func (a *Auth) log() logrus.FieldLogger {
+	if a.Log == nil {
+		return logrus.StandardLogger()
+	}
+	return a.Log
+}
+
+// statusRecorder records the status code of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	sr.code = code
+	sr.ResponseWriter.WriteHeader(code)
+}
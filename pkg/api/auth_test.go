@@ -0,0 +1,389 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/idtoken"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// fakeValidator accepts the "good" token for the "aud" audience.
+func fakeValidator(issuer string) TokenValidator {
+	return func(_ context.Context, token, audience string) (*idtoken.Payload, error) {
+		if token != "good" || audience != "aud" {
+			return nil, errors.New("bad token")
+		}
+		return &idtoken.Payload{
+			Issuer:   issuer,
+			Audience: audience,
+			Subject:  "12345",
+			Claims:   map[string]interface{}{"email": "fred@example.com"},
+		}, nil
+	}
+}
+
+// fakeIssuer serves the OpenID Connect discovery document and signing keys of an issuer.
+func fakeIssuer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   srv.URL,
+			"jwks_uri": srv.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "key", Algorithm: string(jose.RS256), Use: "sig"}},
+		})
+	})
+	return srv
+}
+
+// signToken returns an ID token with the claims signed by the key.
+func signToken(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+	opts := (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key")
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, opts)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	sig, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	token, err := sig.CompactSerialize()
+	if err != nil {
+		t.Fatalf("CompactSerialize(): %v", err)
+	}
+	return token
+}
+
+func TestAuthenticators(t *testing.T) {
+	spiffe, err := url.Parse("spiffe://example.com/ns/ci/sa/bot")
+	if err != nil {
+		t.Fatalf("url.Parse(): %v", err)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	issuer := fakeIssuer(t, key)
+	defer issuer.Close()
+	now := time.Now()
+	claims := func(mutate func(map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":            issuer.URL,
+			"aud":            "aud",
+			"sub":            "12345",
+			"email":          "fred@example.com",
+			"email_verified": true,
+			"iat":            now.Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+		}
+		if mutate != nil {
+			mutate(c)
+		}
+		return c
+	}
+	bearer := func(k *rsa.PrivateKey, c map[string]interface{}) map[string]string {
+		return map[string]string{"Authorization": "Bearer " + signToken(t, k, c)}
+	}
+	trusted := &OIDC{Audience: "aud", Issuers: []string{issuer.URL}}
+	cases := []struct {
+		name    string
+		authn   Authenticator
+		headers map[string]string
+		tls     *tls.ConnectionState
+		want    *Identity
+		err     bool
+	}{
+		{
+			name:  "iap without header",
+			authn: IAP{Audience: "aud", Validate: fakeValidator(iapIssuer)},
+		},
+		{
+			name:    "iap",
+			authn:   IAP{Audience: "aud", Validate: fakeValidator(iapIssuer)},
+			headers: map[string]string{iapHeader: "good"},
+			want:    &Identity{Subject: "12345", Email: "fred@example.com", Method: "iap"},
+		},
+		{
+			name:    "iap with bad token",
+			authn:   IAP{Audience: "aud", Validate: fakeValidator(iapIssuer)},
+			headers: map[string]string{iapHeader: "bad"},
+			err:     true,
+		},
+		{
+			name:    "iap from another issuer",
+			authn:   IAP{Audience: "aud", Validate: fakeValidator("https://accounts.google.com")},
+			headers: map[string]string{iapHeader: "good"},
+			err:     true,
+		},
+		{
+			name:    "oidc",
+			authn:   trusted,
+			headers: bearer(key, claims(nil)),
+			want:    &Identity{Subject: "12345", Email: "fred@example.com", Method: "oidc"},
+		},
+		{
+			name:  "oidc with unverified email",
+			authn: trusted,
+			headers: bearer(key, claims(func(c map[string]interface{}) {
+				c["email_verified"] = false
+			})),
+			want: &Identity{Subject: "12345", Method: "oidc"},
+		},
+		{
+			name:    "oidc with untrusted issuer",
+			authn:   &OIDC{Audience: "aud", Issuers: []string{"https://accounts.google.com"}},
+			headers: bearer(key, claims(nil)),
+			err:     true,
+		},
+		{
+			name:    "oidc without trusted issuers",
+			authn:   &OIDC{Audience: "aud"},
+			headers: bearer(key, claims(nil)),
+			err:     true,
+		},
+		{
+			name:  "oidc for another audience",
+			authn: trusted,
+			headers: bearer(key, claims(func(c map[string]interface{}) {
+				c["aud"] = "other"
+			})),
+			err: true,
+		},
+		{
+			name:  "oidc with expired token",
+			authn: trusted,
+			headers: bearer(key, claims(func(c map[string]interface{}) {
+				c["exp"] = now.Add(-time.Hour).Unix()
+			})),
+			err: true,
+		},
+		{
+			name:    "oidc signed by another key",
+			authn:   trusted,
+			headers: bearer(otherKey, claims(nil)),
+			err:     true,
+		},
+		{
+			name:    "oidc with malformed token",
+			authn:   trusted,
+			headers: map[string]string{"Authorization": "Bearer good"},
+			err:     true,
+		},
+		{
+			name:    "oidc without a bearer token",
+			authn:   trusted,
+			headers: map[string]string{"Authorization": "Basic Zm9vOmJhcg=="},
+			err:     true,
+		},
+		{
+			name:  "client cert without tls",
+			authn: ClientCert{},
+		},
+		{
+			name:  "client cert",
+			authn: ClientCert{},
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{Subject: pkix.Name{CommonName: "bot"}, URIs: []*url.URL{spiffe}},
+				}},
+			},
+			want: &Identity{Subject: "spiffe://example.com/ns/ci/sa/bot", Method: "mtls"},
+		},
+		{
+			name:  "client cert common name",
+			authn: ClientCert{},
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{Subject: pkix.Name{CommonName: "bot"}},
+				}},
+			},
+			want: &Identity{Subject: "bot", Method: "mtls"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, DashboardsPrefix, nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			r.TLS = tc.tls
+			got, err := tc.authn.Authenticate(r)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Authenticate() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("Authenticate() failed to return an error, got %v", got)
+			default:
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("Authenticate() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRBAC(t *testing.T) {
+	rbac := RBAC{
+		Readers: []string{"domain:example.com", "12345"},
+		Writers: []string{"admin@example.com"},
+	}
+	cases := []struct {
+		name   string
+		id     *Identity
+		method string
+		want   bool
+	}{
+		{
+			name:   "anonymous",
+			method: http.MethodGet,
+		},
+		{
+			name:   "domain reader",
+			id:     &Identity{Email: "fred@example.com"},
+			method: http.MethodGet,
+			want:   true,
+		},
+		{
+			name:   "readers cannot write",
+			id:     &Identity{Email: "fred@example.com"},
+			method: http.MethodPost,
+		},
+		{
+			name:   "subject reader",
+			id:     &Identity{Subject: "12345"},
+			method: http.MethodHead,
+			want:   true,
+		},
+		{
+			name:   "other domain",
+			id:     &Identity{Email: "fred@example.org"},
+			method: http.MethodGet,
+		},
+		{
+			name:   "writer",
+			id:     &Identity{Email: "admin@example.com"},
+			method: http.MethodDelete,
+			want:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, DashboardsPrefix, nil)
+			if got := rbac.Authorize(tc.id, r); got != tc.want {
+				t.Errorf("Authorize(%v, %s) got %t, want %t", tc.id, tc.method, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthWrap(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(IdentityFromContext(r.Context()).String()))
+	})
+	authn := IAP{Audience: "aud", Validate: fakeValidator(iapIssuer)}
+	cases := []struct {
+		name   string
+		auth   Auth
+		token  string
+		want   int
+		wantID string
+	}{
+		{
+			name:   "authenticated",
+			auth:   Auth{Authenticators: []Authenticator{authn}},
+			token:  "good",
+			want:   http.StatusOK,
+			wantID: "fred@example.com",
+		},
+		{
+			name:  "invalid credentials",
+			auth:  Auth{Authenticators: []Authenticator{authn}, AllowAnonymous: true},
+			token: "bad",
+			want:  http.StatusUnauthorized,
+		},
+		{
+			name: "anonymous",
+			auth: Auth{Authenticators: []Authenticator{authn}},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:   "allow anonymous",
+			auth:   Auth{Authenticators: []Authenticator{authn}, AllowAnonymous: true},
+			want:   http.StatusOK,
+			wantID: "anonymous",
+		},
+		{
+			name: "unauthorized",
+			auth: Auth{
+				Authenticators: []Authenticator{authn},
+				Authorize:      RBAC{Readers: []string{"domain:example.org"}}.Authorize,
+			},
+			token: "good",
+			want:  http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, DashboardsPrefix, nil)
+			if tc.token != "" {
+				r.Header.Set(iapHeader, tc.token)
+			}
+			w := httptest.NewRecorder()
+			tc.auth.Wrap(next).ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP() got %d, want %d", w.Code, tc.want)
+			}
+			if tc.wantID != "" && w.Body.String() != tc.wantID {
+				t.Errorf("ServeHTTP() got identity %q, want %q", w.Body.String(), tc.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+)
+
+const (
+	// TabsPath serves the TabBatch of the tabs of its tab query parameters.
+	TabsPath = "/api/v1/tabs"
+
+	// maxBatchTabs is the most tabs a batch may request.
+	maxBatchTabs = 100
+)
+
+// BatchTab returns the tab query parameter of TabsPath which requests the dashboard tab.
+func BatchTab(dashboard, tab string) string {
+	return url.PathEscape(dashboard) + "/" + url.PathEscape(tab)
+}
+
+// parseBatchTab returns the dashboard and tab of a BatchTab.
+func parseBatchTab(value string) (string, string, bool) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	dashboard, err := url.PathUnescape(parts[0])
+	if err != nil || dashboard == "" {
+		return "", "", false
+	}
+	tab, err := url.PathUnescape(parts[1])
+	if err != nil || tab == "" {
+		return "", "", false
+	}
+	return dashboard, tab, true
+}
+
+// serveBatch serves the summaries of the tabs of the tab query parameters,
+// along with their recent columns up to the columns query parameter, reading
+// the tabs concurrently.
+//
+// Tabs which cannot be read report why in their result rather than failing
+// the batch.
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	values := query["tab"]
+	switch {
+	case len(values) == 0:
+		http.Error(w, "tab is required", http.StatusBadRequest)
+		return
+	case len(values) > maxBatchTabs:
+		http.Error(w, fmt.Sprintf("at most %d tabs may be requested", maxBatchTabs), http.StatusBadRequest)
+		return
+	}
+	var columns int
+	if v := query.Get("columns"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "columns must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		columns = n
+	}
+	var full bool
+	if v := query.Get("full_history"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "full_history must be a boolean", http.StatusBadRequest)
+			return
+		}
+		full = b
+	}
+	reqs := make([]tabs.Request, 0, len(values))
+	for _, v := range values {
+		dashboard, tab, ok := parseBatchTab(v)
+		if !ok {
+			http.Error(w, fmt.Sprintf("tab %q is not dashboard/tab", v), http.StatusBadRequest)
+			return
+		}
+		reqs = append(reqs, tabs.Request{Dashboard: dashboard, Tab: tab, Columns: columns, FullHistory: full})
+	}
+
+	log := s.log().WithField("tabs", len(reqs))
+	var batch responsepb.TabBatch
+	for _, res := range s.Reader.GetTabs(r.Context(), reqs) {
+		result := responsepb.TabResult{
+			Dashboard: res.Dashboard,
+			Tab:       res.Tab,
+			Summary:   res.Summary,
+			Grid:      res.Grid,
+		}
+		switch {
+		case errors.Is(res.Err, tabs.ErrNotFound):
+			result.Error = "not found"
+		case res.Err != nil:
+			log.WithError(res.Err).WithFields(logrus.Fields{
+				"dashboard": res.Dashboard,
+				"tab":       res.Tab,
+			}).Warning("Failed to read tab")
+			result.Error = "failed to read tab"
+		}
+		batch.Tabs = append(batch.Tabs, &result)
+	}
+	if err := Write(w, r, &batch); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestServeBatch(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q): %v", s, err)
+		}
+		return *p
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2", Started: 2000}, {Build: "1", Started: 1000}},
+		Rows:    []*statepb.Row{{Name: "test", Id: "test", Results: []int32{1, 2}, CellIds: []string{"", ""}, Messages: []string{"", ""}, Icons: []string{"", ""}}},
+	}
+	gridBuf, err := gcs.MarshalGrid(grid)
+	if err != nil {
+		t.Fatalf("marshal grid: %v", err)
+	}
+	summary := func(tabs ...string) string {
+		var sum summarypb.DashboardSummary
+		for _, tab := range tabs {
+			sum.TabSummaries = append(sum.TabSummaries, &summarypb.DashboardTabSummary{
+				DashboardTabName: tab,
+				OverallStatus:    summarypb.DashboardTabSummary_PASS,
+			})
+		}
+		buf, err := proto.Marshal(&sum)
+		if err != nil {
+			t.Fatalf("marshal summary: %v", err)
+		}
+		return string(buf)
+	}
+	reader := tabs.Reader{
+		Client: fake.Opener{
+			mustPath("gs://bucket/summary/summary-dash"):   {Data: summary("tab", "other/tab")},
+			mustPath("gs://bucket/summary/summary-broken"): {OpenErr: errors.New("injected")},
+			mustPath("gs://bucket/grid/group"):             {Data: string(gridBuf)},
+		},
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name: "dash",
+					DashboardTab: []*configpb.DashboardTab{
+						{Name: "tab", TestGroupName: "group"},
+						{Name: "other/tab", TestGroupName: "group"},
+					},
+				},
+				{
+					Name:         "broken",
+					DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+				},
+			},
+			TestGroups: []*configpb.TestGroup{{Name: "group"}},
+		},
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+		Concurrency:   2,
+	}
+	passing := func(tab string) *summarypb.DashboardTabSummary {
+		return &summarypb.DashboardTabSummary{
+			DashboardTabName: tab,
+			OverallStatus:    summarypb.DashboardTabSummary_PASS,
+		}
+	}
+	batchPath := func(query string, tabs ...string) string {
+		values := url.Values{"tab": tabs}
+		if query != "" {
+			q, err := url.ParseQuery(query)
+			if err != nil {
+				t.Fatalf("url.ParseQuery(%q): %v", query, err)
+			}
+			for k, v := range q {
+				values[k] = v
+			}
+		}
+		return TabsPath + "?" + values.Encode()
+	}
+	var tooMany []string
+	for i := 0; i <= maxBatchTabs; i++ {
+		tooMany = append(tooMany, BatchTab("dash", "tab"))
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		want   *responsepb.TabBatch
+	}{
+		{
+			name: "summaries in request order",
+			path: batchPath("", BatchTab("dash", "other/tab"), BatchTab("dash", "tab")),
+			code: http.StatusOK,
+			want: &responsepb.TabBatch{
+				Tabs: []*responsepb.TabResult{
+					{Dashboard: "dash", Tab: "other/tab", Summary: passing("other/tab")},
+					{Dashboard: "dash", Tab: "tab", Summary: passing("tab")},
+				},
+			},
+		},
+		{
+			name: "recent columns",
+			path: batchPath("columns=1", BatchTab("dash", "tab")),
+			code: http.StatusOK,
+			want: &responsepb.TabBatch{
+				Tabs: []*responsepb.TabResult{
+					{
+						Dashboard: "dash",
+						Tab:       "tab",
+						Summary:   passing("tab"),
+						Grid: &statepb.Grid{
+							Columns: []*statepb.Column{{Build: "2", Started: 2000, Date: "1970-01-01 00:00"}},
+							Rows:    []*statepb.Row{{Name: "test", Id: "test", Results: []int32{1, 1}, CellIds: []string{""}, Messages: []string{""}, Icons: []string{""}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "report tabs which fail to read",
+			path: batchPath("", BatchTab("dash", "tab"), BatchTab("dash", "missing"), BatchTab("broken", "tab")),
+			code: http.StatusOK,
+			want: &responsepb.TabBatch{
+				Tabs: []*responsepb.TabResult{
+					{Dashboard: "dash", Tab: "tab", Summary: passing("tab")},
+					{Dashboard: "dash", Tab: "missing", Error: "not found"},
+					{Dashboard: "broken", Tab: "tab", Error: "failed to read tab"},
+				},
+			},
+		},
+		{
+			name: "require tabs",
+			path: TabsPath,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "too many tabs",
+			path: batchPath("", tooMany...),
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad tab",
+			path: batchPath("", "dash"),
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "bad columns",
+			path: batchPath("columns=-1", BatchTab("dash", "tab")),
+			code: http.StatusBadRequest,
+		},
+		{
+			name:   "read only",
+			method: http.MethodPost,
+			path:   batchPath("", BatchTab("dash", "tab")),
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.method == "" {
+				tc.method = http.MethodGet
+			}
+			s := Server{Reader: reader}
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			r.Header.Set("Accept", ContentTypeProto)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.code {
+				t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, tc.code, w.Body)
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+			var got responsepb.TabBatch
+			if err := proto.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, &got, protocmp.Transform()); diff != "" {
+				t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
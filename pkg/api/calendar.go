@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+)
+
+const (
+	// ContentTypeCalendar is the media type of iCalendar feeds.
+	ContentTypeCalendar = "text/calendar; charset=utf-8"
+
+	defaultReviewHours = 24
+	// icsLineOctets is the longest line of an iCalendar feed before folding.
+	icsLineOctets = 75
+)
+
+// CalendarPath returns the path to the review calendar of the dashboard.
+func CalendarPath(dashboard string) string {
+	return DashboardsPrefix + url.PathEscape(dashboard) + "/" + CalendarResource
+}
+
+// reviewThreshold returns how long tabs may need triage before a review,
+// selected by the hours query parameter and defaulting to defaultReviewHours.
+func reviewThreshold(query url.Values) (time.Duration, error) {
+	v := query.Get("hours")
+	if v == "" {
+		return defaultReviewHours * time.Hour, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, errors.New("hours must be a positive integer")
+	}
+	return time.Duration(n) * time.Hour, nil
+}
+
+// serveCalendar serves an iCalendar feed with a daily review reminder for
+// each tab of the dashboard which has failed or been stale for too long.
+func (s *Server) serveCalendar(w http.ResponseWriter, r *http.Request, dashboard string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	threshold, err := reviewThreshold(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"resource":  CalendarResource,
+	})
+	reviews, err := s.Reader.Reviews(r.Context(), dashboard, threshold, time.Now())
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to read reviews")
+		http.Error(w, "failed to read reviews", http.StatusInternalServerError)
+		return
+	}
+	buf := marshalCalendar(requestOrigin(r), dashboard, threshold, reviews)
+	if err := writeEncoded(w, r, ContentTypeCalendar, buf); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// marshalCalendar encodes the reviews of the dashboard as an iCalendar feed,
+// linking each to the tab's summary at the origin.
+//
+// Each review is an all-day event which starts once the tab exceeds the
+// threshold and repeats daily, until the tab recovers and the event leaves
+// the feed.
+func marshalCalendar(origin, dashboard string, threshold time.Duration, reviews []tabs.Review) []byte {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeICSLine(&buf, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//TestGrid//Reviews//EN")
+	line("X-WR-CALNAME", escapeICS(dashboard+" reviews"))
+	for _, rev := range reviews {
+		since := rev.Since.UTC()
+		summary := fmt.Sprintf("Review %s/%s: failing for over %s", dashboard, rev.Tab, threshold)
+		if rev.Stale {
+			summary = fmt.Sprintf("Review %s/%s: stale for over %s", dashboard, rev.Tab, threshold)
+		}
+		link := origin + TabPath(dashboard, rev.Tab, SummaryResource)
+		desc := link
+		if rev.Alert != "" {
+			desc = rev.Alert + "\n" + link
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", escapeICS(fmt.Sprintf("%d-%s-%s@testgrid", since.Unix(), url.PathEscape(dashboard), url.PathEscape(rev.Tab))))
+		line("DTSTAMP", since.Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", since.Add(threshold).Format("20060102"))
+		line("RRULE", "FREQ=DAILY")
+		line("SUMMARY", escapeICS(summary))
+		line("DESCRIPTION", escapeICS(desc))
+		line("URL", link)
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeICS escapes an iCalendar text value.
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeICSLine writes the content line, folding it into lines of at most
+// icsLineOctets octets without splitting characters.
+func writeICSLine(buf *bytes.Buffer, s string) {
+	limit := icsLineOctets
+	for len(s) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		buf.WriteString(s[:n])
+		buf.WriteString("\r\n ")
+		s = s[n:]
+		// Continuation lines begin with a space.
+		limit = icsLineOctets - 1
+	}
+	buf.WriteString(s)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestMarshalCalendar(t *testing.T) {
+	since := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	reviews := []tabs.Review{
+		{Tab: "stale", Stale: true, Since: since, Alert: "results have not changed for 3d"},
+		{Tab: "failing, badly", Since: since},
+	}
+	got := string(marshalCalendar("https://example.com", "dash", 24*time.Hour, reviews))
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//TestGrid//Reviews//EN",
+		"X-WR-CALNAME:dash reviews",
+		"BEGIN:VEVENT",
+		"UID:1614834367-dash-stale@testgrid",
+		"DTSTAMP:20210304T050607Z",
+		"DTSTART;VALUE=DATE:20210305",
+		"RRULE:FREQ=DAILY",
+		"SUMMARY:Review dash/stale: stale for over 24h0m0s",
+		"DESCRIPTION:results have not changed for 3d\\nhttps://example.com/api/v1/das",
+		" hboards/dash/tabs/stale/summary",
+		"URL:https://example.com/api/v1/dashboards/dash/tabs/stale/summary",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:1614834367-dash-failing%2C%20badly@testgrid",
+		"DTSTAMP:20210304T050607Z",
+		"DTSTART;VALUE=DATE:20210305",
+		"RRULE:FREQ=DAILY",
+		"SUMMARY:Review dash/failing\\, badly: failing for over 24h0m0s",
+		"DESCRIPTION:https://example.com/api/v1/dashboards/dash/tabs/failing%2C%20ba",
+		" dly/summary",
+		"URL:https://example.com/api/v1/dashboards/dash/tabs/failing%2C%20badly/summ",
+		" ary",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("marshalCalendar() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteICSLine(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "short",
+			line: "SUMMARY:hello",
+			want: "SUMMARY:hello\r\n",
+		},
+		{
+			name: "fold",
+			line: strings.Repeat("a", 150),
+			want: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n",
+		},
+		{
+			name: "keep characters whole",
+			line: strings.Repeat("a", 74) + "é",
+			want: strings.Repeat("a", 74) + "\r\n é\r\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeICSLine(&buf, tc.line)
+			if diff := cmp.Diff(tc.want, buf.String()); diff != "" {
+				t.Errorf("writeICSLine() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServeCalendar(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	opened := float64(time.Now().Add(-36 * time.Hour).Unix())
+	buf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "tab",
+				OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: opened}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{
+				*summaryPath: {Data: string(buf)},
+			},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+					},
+				},
+			},
+			ConfigPath:    *configPath,
+			SummaryPrefix: "summary",
+		},
+	}
+	cases := []struct {
+		name   string
+		path   string
+		code   int
+		events int
+	}{
+		{
+			name:   "review",
+			path:   CalendarPath("dash"),
+			code:   http.StatusOK,
+			events: 1,
+		},
+		{
+			name: "longer threshold",
+			path: CalendarPath("dash") + "?hours=48",
+			code: http.StatusOK,
+		},
+		{
+			name: "bad hours",
+			path: CalendarPath("dash") + "?hours=soon",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "missing dashboard",
+			path: CalendarPath("missing"),
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.code {
+				t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, tc.code, w.Body)
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); got != ContentTypeCalendar {
+				t.Errorf("ServeHTTP() got content type %q, want %q", got, ContentTypeCalendar)
+			}
+			if got := strings.Count(w.Body.String(), "BEGIN:VEVENT"); got != tc.events {
+				t.Errorf("ServeHTTP() got %d events, want %d:\n%s", got, tc.events, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+const maxColumnEditBytes = 4 << 10
+
+// serveColumnEdits lists the column edits awaiting the next update of the
+// tab's test group. Authenticated requests may also delete or reprocess the
+// column of a build (POST) and cancel a pending edit (DELETE).
+//
+// POST requests send a ColumnEdit with the build and its action. DELETE
+// requests identify the edit with the build query parameter.
+func (s *Server) serveColumnEdits(w http.ResponseWriter, r *http.Request, dashboard, tab string) {
+	if s.ColumnEdits == nil {
+		http.Error(w, "column edits are disabled", http.StatusNotImplemented)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+	})
+	gridPath, err := s.Reader.GridPath(dashboard, tab)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to resolve grid")
+		http.Error(w, "failed to resolve grid", http.StatusInternalServerError)
+		return
+	}
+	path, err := updater.ColumnEditsPath(*gridPath)
+	if err != nil {
+		log.WithError(err).Warning("Failed to resolve column edits")
+		http.Error(w, "failed to resolve column edits", http.StatusInternalServerError)
+		return
+	}
+
+	id := IdentityFromContext(r.Context())
+	if r.Method != http.MethodGet && id == nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+	var update func(*statepb.ColumnEdits) bool
+	var missing bool
+	switch r.Method {
+	case http.MethodGet:
+		update = func(*statepb.ColumnEdits) bool { return false }
+	case http.MethodPost:
+		buf, err := ioutil.ReadAll(io.LimitReader(r.Body, maxColumnEditBytes))
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+		var edit statepb.ColumnEdit
+		if err := Unmarshal(r.Header.Get("Content-Type"), buf, &edit); err != nil || edit.Build == "" {
+			http.Error(w, "request must be a ColumnEdit with a build", http.StatusBadRequest)
+			return
+		}
+		if edit.Action != statepb.ColumnEdit_DELETE && edit.Action != statepb.ColumnEdit_REPROCESS {
+			http.Error(w, "action must be DELETE or REPROCESS", http.StatusBadRequest)
+			return
+		}
+		log = log.WithFields(logrus.Fields{
+			"build":  edit.Build,
+			"action": edit.Action,
+		})
+		now := time.Now()
+		update = func(edits *statepb.ColumnEdits) bool {
+			updater.RequestColumnEdit(edits, edit.Build, edit.Action, id.String(), now)
+			return true
+		}
+	case http.MethodDelete:
+		build := r.URL.Query().Get("build")
+		if build == "" {
+			http.Error(w, "missing build parameter", http.StatusBadRequest)
+			return
+		}
+		log = log.WithField("build", build)
+		update = func(edits *statepb.ColumnEdits) bool {
+			missing = !updater.CancelColumnEdit(edits, build)
+			return !missing
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	edits, err := updater.UpdateColumnEdits(r.Context(), s.ColumnEdits, *path, update)
+	if err != nil {
+		log.WithError(err).Warning("Failed to update column edits")
+		http.Error(w, "failed to update column edits", http.StatusInternalServerError)
+		return
+	}
+	if missing {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		log.WithFields(logrus.Fields{
+			"method":   r.Method,
+			"identity": id.String(),
+		}).Info("Updated column edits")
+	}
+	if err := Write(w, r, edits); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
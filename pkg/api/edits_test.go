@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestServeColumnEdits(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	editsPath, err := gcs.NewPath("gs://bucket/grid/group.edits")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	existing := &statepb.ColumnEdits{
+		Edits: []*statepb.ColumnEdit{
+			{Build: "10", Action: statepb.ColumnEdit_REPROCESS, Requester: "alice@example.com"},
+		},
+	}
+	buf, err := proto.Marshal(existing)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	reader := tabs.Reader{
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name: "dash",
+					DashboardTab: []*configpb.DashboardTab{
+						{Name: "tab", TestGroupName: "group"},
+					},
+				},
+			},
+		},
+		ConfigPath: *configPath,
+		GridPrefix: "grid",
+	}
+	carol := &Identity{Email: "carol@example.com", Method: "oidc"}
+	// Times differ between runs.
+	clearTimes := func(edits *statepb.ColumnEdits) {
+		for _, e := range edits.Edits {
+			e.Requested = 0
+		}
+	}
+
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		id       *Identity
+		disabled bool
+		want     int
+		wantResp *statepb.ColumnEdits
+		wantSave *statepb.ColumnEdits
+	}{
+		{
+			name:     "list",
+			method:   http.MethodGet,
+			path:     TabPath("dash", "tab", ColumnEditsResource),
+			want:     http.StatusOK,
+			wantResp: existing,
+		},
+		{
+			name:     "disabled",
+			method:   http.MethodGet,
+			path:     TabPath("dash", "tab", ColumnEditsResource),
+			disabled: true,
+			want:     http.StatusNotImplemented,
+		},
+		{
+			name:   "delete a column",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", ColumnEditsResource),
+			body:   `{"build": "12", "action": "DELETE"}`,
+			id:     carol,
+			want:   http.StatusOK,
+			wantResp: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				existing.Edits[0],
+				{Build: "12", Action: statepb.ColumnEdit_DELETE, Requester: "carol@example.com"},
+			}},
+			wantSave: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				existing.Edits[0],
+				{Build: "12", Action: statepb.ColumnEdit_DELETE, Requester: "carol@example.com"},
+			}},
+		},
+		{
+			name:   "replace a pending edit",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", ColumnEditsResource),
+			body:   `{"build": "10", "action": "DELETE"}`,
+			id:     carol,
+			want:   http.StatusOK,
+			wantResp: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "10", Action: statepb.ColumnEdit_DELETE, Requester: "carol@example.com"},
+			}},
+			wantSave: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "10", Action: statepb.ColumnEdit_DELETE, Requester: "carol@example.com"},
+			}},
+		},
+		{
+			name:   "edit anonymously",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", ColumnEditsResource),
+			body:   `{"build": "12", "action": "DELETE"}`,
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "edit without a build",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", ColumnEditsResource),
+			body:   `{"action": "REPROCESS"}`,
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "edit without an action",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", ColumnEditsResource),
+			body:   `{"build": "12"}`,
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:     "cancel",
+			method:   http.MethodDelete,
+			path:     TabPath("dash", "tab", ColumnEditsResource) + "?build=10",
+			id:       carol,
+			want:     http.StatusOK,
+			wantResp: &statepb.ColumnEdits{},
+			wantSave: &statepb.ColumnEdits{},
+		},
+		{
+			name:   "cancel a missing edit",
+			method: http.MethodDelete,
+			path:   TabPath("dash", "tab", ColumnEditsResource) + "?build=11",
+			id:     carol,
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "cancel without a build",
+			method: http.MethodDelete,
+			path:   TabPath("dash", "tab", ColumnEditsResource),
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "edits of unknown tab",
+			method: http.MethodGet,
+			path:   TabPath("dash", "missing", ColumnEditsResource),
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client: fake.Client{Opener: fake.Opener{
+						*editsPath: {Data: string(buf)},
+					}},
+					Uploader: fake.Uploader{},
+					Stater: fake.Stater{
+						*editsPath: {Attrs: storage.ObjectAttrs{Generation: 1}},
+					},
+				},
+			}
+			s := Server{Reader: reader}
+			if !tc.disabled {
+				s.ColumnEdits = client
+			}
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", ContentTypeJSON)
+			if tc.id != nil {
+				r = r.WithContext(context.WithValue(r.Context(), identityKey{}, tc.id))
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP(%s %s) got %d, want %d: %s", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+			}
+			if tc.wantResp != nil {
+				var got statepb.ColumnEdits
+				if err := Unmarshal(ContentTypeJSON, w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("Unmarshal(): %v", err)
+				}
+				clearTimes(&got)
+				if diff := cmp.Diff(tc.wantResp, &got, protocmp.Transform()); diff != "" {
+					t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			var gotSave *statepb.ColumnEdits
+			if u, ok := client.Uploader[*editsPath]; ok {
+				gotSave = &statepb.ColumnEdits{}
+				if err := proto.Unmarshal(u.Buf, gotSave); err != nil {
+					t.Fatalf("proto.Unmarshal(): %v", err)
+				}
+				clearTimes(gotSave)
+			}
+			if diff := cmp.Diff(tc.wantSave, gotSave, protocmp.Transform()); diff != "" {
+				t.Errorf("ServeHTTP() saved unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+)
+
+// Member is a TestGrid API server whose dashboards a Federation serves.
+type Member struct {
+	// Name identifies the member in dashboard listings.
+	Name string
+	// URL of the member's API server, such as https://testgrid.example.com
+	URL string
+	// Prefix is prepended to the name of the member's dashboards, to avoid collisions.
+	Prefix string
+}
+
+// ParseMember parses a name=url[,prefix] member.
+func ParseMember(s string) (*Member, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%q is not name=url[,prefix]", s)
+	}
+	m := Member{Name: parts[0], URL: parts[1]}
+	if idx := strings.Index(m.URL, ","); idx >= 0 {
+		m.URL, m.Prefix = m.URL[:idx], m.URL[idx+1:]
+	}
+	u, err := url.Parse(m.URL)
+	if err != nil {
+		return nil, fmt.Errorf("bad url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("url must be http or https: %s", m.URL)
+	}
+	return &m, nil
+}
+
+const defaultRefresh = time.Minute
+
+// Federation serves the dashboards of many API servers as if they were one.
+//
+// The dashboard list merges the list of each member, and tab requests are
+// proxied to the member serving the dashboard.
+type Federation struct {
+	Members []Member
+	// Client lists the dashboards of members, defaulting to http.DefaultClient.
+	Client *http.Client
+	// Refresh lists member dashboards at most this often, defaulting to a minute.
+	Refresh time.Duration
+	Log     logrus.FieldLogger
+
+	lock     sync.Mutex
+	listed   time.Time
+	listings map[string][]*responsepb.DashboardListing // member name => listings
+}
+
+// ServeHTTP serves the merged DashboardList at DashboardsPrefix and proxies
+// TabPath and RowPath requests to the member serving the dashboard.
+func (f *Federation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := f.log()
+	if isDashboardsPath(r.URL.EscapedPath()) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		list, _ := f.dashboards(r.Context())
+		if err := Write(w, r, list); err != nil {
+			log.WithError(err).Warning("Failed to write response")
+		}
+		return
+	}
+	dashboard, tab, resource, ok := parseTabPath(r.URL.EscapedPath())
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_, routes := f.dashboards(r.Context())
+	rt, ok := routes[dashboard]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	target, err := url.Parse(rt.member.URL)
+	if err != nil {
+		log.WithError(err).WithField("member", rt.member.Name).Warning("Bad member url")
+		http.Error(w, "bad member", http.StatusInternalServerError)
+		return
+	}
+	escapedPath := strings.TrimSuffix(target.EscapedPath(), "/") + TabPath(rt.name, tab, "") + resource
+	proxy := httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path, _ = url.PathUnescape(escapedPath)
+			req.URL.RawPath = escapedPath
+			req.Host = target.Host
+		},
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			log.WithError(err).WithField("member", rt.member.Name).Warning("Failed to proxy request")
+			http.Error(w, "member unavailable", http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+type route struct {
+	member Member
+	name   string // name of the dashboard on the member
+}
+
+// dashboards returns the merged dashboard list and the route to each dashboard.
+//
+// Member dashboards are listed when the previous listing is older than Refresh.
+// The previous listing of a member is used when listing it fails.
+// The first member listing a dashboard name serves it.
+func (f *Federation) dashboards(ctx context.Context) (*responsepb.DashboardList, map[string]route) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	refresh := f.Refresh
+	if refresh <= 0 {
+		refresh = defaultRefresh
+	}
+	if f.listings == nil || time.Since(f.listed) > refresh {
+		f.refresh(ctx)
+	}
+
+	var list responsepb.DashboardList
+	routes := map[string]route{}
+	for _, m := range f.Members {
+		for _, d := range f.listings[m.Name] {
+			name := m.Prefix + d.Name
+			if prev, ok := routes[name]; ok {
+				f.log().WithFields(logrus.Fields{
+					"dashboard": name,
+					"member":    m.Name,
+					"serving":   prev.member.Name,
+				}).Warning("Duplicate federated dashboard")
+				continue
+			}
+			routes[name] = route{member: m, name: d.Name}
+			list.Dashboards = append(list.Dashboards, &responsepb.DashboardListing{
+				Name:           name,
+				Tabs:           d.Tabs,
+				Instance:       m.Name,
+				DisplayOptions: d.DisplayOptions,
+			})
+		}
+	}
+	sort.SliceStable(list.Dashboards, func(i, j int) bool {
+		return list.Dashboards[i].Name < list.Dashboards[j].Name
+	})
+	return &list, routes
+}
+
+// refresh concurrently lists the dashboards of every member.
+func (f *Federation) refresh(ctx context.Context) {
+	if f.listings == nil {
+		f.listings = map[string][]*responsepb.DashboardListing{}
+	}
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	for _, m := range f.Members {
+		m := m
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			list, err := f.list(ctx, m)
+			if err != nil {
+				f.log().WithError(err).WithField("member", m.Name).Warning("Failed to list dashboards")
+				return
+			}
+			lock.Lock()
+			f.listings[m.Name] = list.Dashboards
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+	f.listed = time.Now()
+}
+
+// list returns the dashboards of the member.
+func (f *Federation) list(ctx context.Context, m Member) (*responsepb.DashboardList, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(m.URL, "/")+DashboardsPrefix, nil)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", ContentTypeProto)
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil, fmt.Errorf("get: %s", resp.Status)
+	}
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var list responsepb.DashboardList
+	if err := Unmarshal(resp.Header.Get("Content-Type"), buf, &list); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &list, nil
+}
+
+func (f *Federation) log() logrus.FieldLogger {
+	if f.Log == nil {
+		return logrus.StandardLogger()
+	}
+	return f.Log
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestParseMember(t *testing.T) {
+	cases := []struct {
+		in   string
+		want *Member
+	}{
+		{
+			in:   "prod=https://testgrid.example.com",
+			want: &Member{Name: "prod", URL: "https://testgrid.example.com"},
+		},
+		{
+			in:   "staging=http://localhost:8080/,staging-",
+			want: &Member{Name: "staging", URL: "http://localhost:8080/", Prefix: "staging-"},
+		},
+		{in: "prod"},
+		{in: "=https://testgrid.example.com"},
+		{in: "prod=gs://bucket"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseMember(tc.in)
+			switch {
+			case err != nil:
+				if tc.want != nil {
+					t.Errorf("ParseMember() got unexpected error: %v", err)
+				}
+			case tc.want == nil:
+				t.Errorf("ParseMember() failed to return an error, got %v", got)
+			default:
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("ParseMember() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+// newMember serves the summary of the named tab in each dashboard.
+func newMember(t *testing.T, dashboards ...string) *httptest.Server {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	opener := fake.Opener{}
+	var cfg configpb.Configuration
+	for _, name := range dashboards {
+		path, err := gcs.NewPath("gs://bucket/summary/summary-" + name)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(): %v", err)
+		}
+		buf, err := proto.Marshal(&summarypb.DashboardSummary{
+			TabSummaries: []*summarypb.DashboardTabSummary{{DashboardName: name, DashboardTabName: "tab"}},
+		})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		opener[*path] = fake.Object{Data: string(buf)}
+		cfg.Dashboards = append(cfg.Dashboards, &configpb.Dashboard{
+			Name:         name,
+			DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+		})
+	}
+	return httptest.NewServer(&Server{
+		Reader: tabs.Reader{
+			Client:        opener,
+			Config:        &cfg,
+			ConfigPath:    *configPath,
+			SummaryPrefix: "summary",
+		},
+	})
+}
+
+func TestFederation(t *testing.T) {
+	prod := newMember(t, "release", "shared")
+	defer prod.Close()
+	staging := newMember(t, "shared", "canary")
+	defer staging.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	f := Federation{
+		Members: []Member{
+			{Name: "prod", URL: prod.URL},
+			{Name: "staging", URL: staging.URL + "/"},
+			{Name: "down", URL: down.URL},
+			{Name: "prefixed", URL: staging.URL, Prefix: "stage-"},
+		},
+	}
+
+	t.Run("merge dashboards", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, DashboardsPrefix, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("ServeHTTP() got %d, want %d", w.Code, http.StatusOK)
+		}
+		var got responsepb.DashboardList
+		if err := Unmarshal(w.Header().Get("Content-Type"), w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("Unmarshal(): %v", err)
+		}
+		want := responsepb.DashboardList{
+			Dashboards: []*responsepb.DashboardListing{
+				{Name: "canary", Tabs: []string{"tab"}, Instance: "staging"},
+				{Name: "release", Tabs: []string{"tab"}, Instance: "prod"},
+				{Name: "shared", Tabs: []string{"tab"}, Instance: "prod"},
+				{Name: "stage-canary", Tabs: []string{"tab"}, Instance: "prefixed"},
+				{Name: "stage-shared", Tabs: []string{"tab"}, Instance: "prefixed"},
+			},
+		}
+		if diff := cmp.Diff(&want, &got, protocmp.Transform()); diff != "" {
+			t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	cases := []struct {
+		name          string
+		path          string
+		want          int
+		wantDashboard string
+	}{
+		{
+			name:          "route to member",
+			path:          TabPath("canary", "tab", SummaryResource),
+			want:          http.StatusOK,
+			wantDashboard: "canary",
+		},
+		{
+			name:          "route to prefixed member",
+			path:          TabPath("stage-shared", "tab", SummaryResource),
+			want:          http.StatusOK,
+			wantDashboard: "shared",
+		},
+		{
+			name: "unknown dashboard",
+			path: TabPath("missing", "tab", SummaryResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "unknown tab",
+			path: TabPath("release", "missing", SummaryResource),
+			want: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP(%s) got %d, want %d", tc.path, w.Code, tc.want)
+			}
+			if tc.wantDashboard == "" {
+				return
+			}
+			var got summarypb.DashboardTabSummary
+			if err := Unmarshal(w.Header().Get("Content-Type"), w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Unmarshal(): %v", err)
+			}
+			if got.DashboardName != tc.wantDashboard {
+				t.Errorf("ServeHTTP(%s) got dashboard %q, want %q", tc.path, got.DashboardName, tc.wantDashboard)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+const (
+	// ContentTypeRSS is the media type of RSS feeds.
+	ContentTypeRSS = "application/rss+xml"
+	// ContentTypeAtom is the media type of Atom feeds.
+	ContentTypeAtom = "application/atom+xml"
+
+	defaultFeedEntries = 50
+)
+
+// feedLimit returns the number of feed entries selected by the max query
+// parameter, defaulting to defaultFeedEntries.
+func feedLimit(query url.Values) (int, error) {
+	v := query.Get("max")
+	if v == "" {
+		return defaultFeedEntries, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, errors.New("max must be a positive integer")
+	}
+	return n, nil
+}
+
+// feedType returns the syndication feed media type the request selects with
+// its format query parameter, rss or atom, or prefers in its Accept header.
+//
+// Returns an empty string when the request wants neither feed.
+func feedType(r *http.Request) string {
+	switch r.URL.Query().Get("format") {
+	case "rss":
+		return ContentTypeRSS
+	case "atom":
+		return ContentTypeAtom
+	}
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ContentTypeRSS && mediaType != ContentTypeAtom {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = mediaType, q
+		}
+	}
+	return best
+}
+
+// feed is a list of entries which can be encoded as RSS or Atom.
+type feed struct {
+	Title       string
+	Description string
+	// Link is the absolute URL of the feed.
+	Link    string
+	Entries []feedEntry
+}
+
+// feedEntry is an item of a feed.
+type feedEntry struct {
+	// ID uniquely identifies the entry within its feed.
+	ID          string
+	Title       string
+	Description string
+	Updated     time.Time
+}
+
+// requestOrigin returns the scheme and host of the request.
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if v := r.Header.Get("X-Forwarded-Proto"); v != "" {
+		scheme = v
+	}
+	return scheme + "://" + r.Host
+}
+
+// requestURL returns the absolute URL of the request.
+func requestURL(r *http.Request) string {
+	return requestOrigin(r) + r.URL.RequestURI()
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary,omitempty"`
+}
+
+// marshalFeed encodes the feed as the RSS 2.0 or Atom media type, linking each
+// entry to the feed.
+func marshalFeed(contentType string, f feed) ([]byte, error) {
+	var v interface{}
+	switch contentType {
+	case ContentTypeRSS:
+		channel := rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+		}
+		for _, e := range f.Entries {
+			channel.Items = append(channel.Items, rssItem{
+				Title:       e.Title,
+				Link:        f.Link,
+				Description: e.Description,
+				GUID:        rssGUID{Value: e.ID},
+				PubDate:     e.Updated.Format(time.RFC1123Z),
+			})
+		}
+		v = rssFeed{Version: "2.0", Channel: channel}
+	case ContentTypeAtom:
+		out := atomFeed{
+			ID:    f.Link,
+			Title: f.Title,
+			Link:  atomLink{Href: f.Link, Rel: "self"},
+		}
+		var updated time.Time
+		for _, e := range f.Entries {
+			if e.Updated.After(updated) {
+				updated = e.Updated
+			}
+			out.Entries = append(out.Entries, atomEntry{
+				ID:      f.Link + "#" + url.PathEscape(e.ID),
+				Title:   e.Title,
+				Updated: e.Updated.Format(time.RFC3339),
+				Link:    atomLink{Href: f.Link},
+				Summary: e.Description,
+			})
+		}
+		out.Updated = updated.UTC().Format(time.RFC3339)
+		v = out
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	buf, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), buf...), nil
+}
+
+// writeFeed responds with the feed encoded as the syndication media type.
+//
+// Like Write, responses include an ETag and honor If-None-Match.
+func writeFeed(w http.ResponseWriter, r *http.Request, contentType string, f feed) error {
+	buf, err := marshalFeed(contentType, f)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return writeEncoded(w, r, contentType, buf)
+}
+
+// serveFeed serves the HealthFeed of the dashboard, or an RSS or Atom feed of
+// it when the request wants one.
+func (s *Server) serveFeed(w http.ResponseWriter, r *http.Request, dashboard string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	max, err := feedLimit(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"resource":  FeedResource,
+	})
+	health, err := s.Reader.HealthFeed(r.Context(), dashboard, max)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to read health feed")
+		http.Error(w, "failed to read health feed", http.StatusInternalServerError)
+		return
+	}
+	if contentType := feedType(r); contentType != "" {
+		err = writeFeed(w, r, contentType, healthFeed(requestURL(r), health))
+	} else {
+		err = Write(w, r, health)
+	}
+	if err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// healthFeed returns the feed of the dashboard's health events.
+func healthFeed(link string, health *responsepb.HealthFeed) feed {
+	f := feed{
+		Title:       health.Dashboard + " health",
+		Description: fmt.Sprintf("Tabs and tests of %s which started failing or passing", health.Dashboard),
+		Link:        link,
+	}
+	for _, e := range health.Events {
+		var title string
+		switch e.Kind {
+		case responsepb.HealthEvent_TAB_FAILING:
+			title = fmt.Sprintf("%s started failing", e.Tab)
+		case responsepb.HealthEvent_TAB_PASSING:
+			title = fmt.Sprintf("%s passed again", e.Tab)
+		case responsepb.HealthEvent_TEST_FAILING:
+			title = fmt.Sprintf("%s started failing in %s at %s", e.Test, e.Tab, e.Build)
+		default:
+			continue
+		}
+		f.Entries = append(f.Entries, feedEntry{
+			ID:          fmt.Sprintf("%s/%s/%s/%s/%.0f", e.Kind, e.Tab, e.Test, e.Build, e.Time),
+			Title:       title,
+			Description: e.Message,
+			Updated:     updater.SecondsTime(e.Time).UTC(),
+		})
+	}
+	return f
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestFeedType(t *testing.T) {
+	cases := []struct {
+		name   string
+		query  string
+		accept string
+		want   string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:  "rss format",
+			query: "?format=rss",
+			want:  ContentTypeRSS,
+		},
+		{
+			name:   "atom format",
+			query:  "?format=atom",
+			accept: ContentTypeRSS,
+			want:   ContentTypeAtom,
+		},
+		{
+			name:   "accept",
+			accept: "text/html, application/rss+xml;q=0.5, application/atom+xml;q=0.9",
+			want:   ContentTypeAtom,
+		},
+		{
+			name:   "refuse",
+			accept: "application/rss+xml;q=0",
+		},
+		{
+			name:   "json",
+			accept: ContentTypeJSON,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/feed"+tc.query, nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			if got := feedType(r); got != tc.want {
+				t.Errorf("feedType() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarshalFeed(t *testing.T) {
+	f := feed{
+		Title:       "title",
+		Description: "description",
+		Link:        "https://example.com/feed",
+		Entries: []feedEntry{
+			{ID: "new one", Title: "new", Description: "boom", Updated: time.Unix(200, 0)},
+			{ID: "old", Title: "old", Updated: time.Unix(100, 0)},
+		},
+	}
+
+	t.Run("rss", func(t *testing.T) {
+		buf, err := marshalFeed(ContentTypeRSS, f)
+		if err != nil {
+			t.Fatalf("marshalFeed() got unexpected error: %v", err)
+		}
+		var got rssFeed
+		if err := xml.Unmarshal(buf, &got); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		want := rssFeed{
+			XMLName: xml.Name{Local: "rss"},
+			Version: "2.0",
+			Channel: rssChannel{
+				Title:       "title",
+				Link:        "https://example.com/feed",
+				Description: "description",
+				Items: []rssItem{
+					{
+						Title:       "new",
+						Link:        "https://example.com/feed",
+						Description: "boom",
+						GUID:        rssGUID{Value: "new one"},
+						PubDate:     time.Unix(200, 0).Format(time.RFC1123Z),
+					},
+					{
+						Title:   "old",
+						Link:    "https://example.com/feed",
+						GUID:    rssGUID{Value: "old"},
+						PubDate: time.Unix(100, 0).Format(time.RFC1123Z),
+					},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("marshalFeed() got unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("atom", func(t *testing.T) {
+		buf, err := marshalFeed(ContentTypeAtom, f)
+		if err != nil {
+			t.Fatalf("marshalFeed() got unexpected error: %v", err)
+		}
+		var got atomFeed
+		if err := xml.Unmarshal(buf, &got); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		want := atomFeed{
+			XMLName: xml.Name{Space: "http://www.w3.org/2005/Atom", Local: "feed"},
+			ID:      "https://example.com/feed",
+			Title:   "title",
+			Updated: "1970-01-01T00:03:20Z",
+			Link:    atomLink{Href: "https://example.com/feed", Rel: "self"},
+			Entries: []atomEntry{
+				{
+					ID:      "https://example.com/feed#new%20one",
+					Title:   "new",
+					Updated: time.Unix(200, 0).Format(time.RFC3339),
+					Link:    atomLink{Href: "https://example.com/feed"},
+					Summary: "boom",
+				},
+				{
+					ID:      "https://example.com/feed#old",
+					Title:   "old",
+					Updated: time.Unix(100, 0).Format(time.RFC3339),
+					Link:    atomLink{Href: "https://example.com/feed"},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("marshalFeed() got unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	if _, err := marshalFeed(ContentTypeJSON, f); err == nil {
+		t.Error("marshalFeed() failed to return an error for JSON")
+	}
+}
+
+func TestServeFeed(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	buf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "tab",
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: 100, Resolved: 200}},
+				FailingTestSummaries: []*summarypb.FailingTestSummary{
+					{DisplayName: "test", FailBuildId: "3", FailTimestamp: 300},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{
+				*summaryPath: {Data: string(buf)},
+			},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+					},
+					{Name: "empty"},
+				},
+			},
+			ConfigPath:    *configPath,
+			SummaryPrefix: "summary",
+		},
+	}
+	cases := []struct {
+		name   string
+		path   string
+		accept string
+		code   int
+		want   *responsepb.HealthFeed
+		titles []string
+	}{
+		{
+			name:   "feed",
+			path:   FeedPath("dash") + "?max=2",
+			accept: ContentTypeProto,
+			code:   http.StatusOK,
+			want: &responsepb.HealthFeed{
+				Dashboard: "dash",
+				Events: []*responsepb.HealthEvent{
+					{Kind: responsepb.HealthEvent_TEST_FAILING, Tab: "tab", Time: 300, Test: "test", Build: "3"},
+					{Kind: responsepb.HealthEvent_TAB_PASSING, Tab: "tab", Time: 200},
+				},
+			},
+		},
+		{
+			name:   "without summary",
+			path:   FeedPath("empty"),
+			accept: ContentTypeProto,
+			code:   http.StatusOK,
+			want:   &responsepb.HealthFeed{Dashboard: "empty"},
+		},
+		{
+			name:   "rss",
+			path:   FeedPath("dash"),
+			accept: ContentTypeRSS,
+			code:   http.StatusOK,
+			titles: []string{"test started failing in tab at 3", "tab passed again", "tab started failing"},
+		},
+		{
+			name: "missing dashboard",
+			path: FeedPath("missing"),
+			code: http.StatusNotFound,
+		},
+		{
+			name: "bad max",
+			path: FeedPath("dash") + "?max=-1",
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.code {
+				t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, tc.code, w.Body)
+			}
+			if tc.want != nil {
+				var got responsepb.HealthFeed
+				if err := proto.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("unmarshal: %v", err)
+				}
+				if diff := cmp.Diff(tc.want, &got, protocmp.Transform()); diff != "" {
+					t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			if tc.titles != nil {
+				var got rssFeed
+				if err := xml.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("unmarshal: %v", err)
+				}
+				var titles []string
+				for _, item := range got.Channel.Items {
+					titles = append(titles, item.Title)
+				}
+				if diff := cmp.Diff(tc.titles, titles); diff != "" {
+					t.Errorf("ServeHTTP() got unexpected titles (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
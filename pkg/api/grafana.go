@@ -0,0 +1,335 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+)
+
+const (
+	// GrafanaPrefix is the path under which the endpoints of a Grafana JSON
+	// datasource are served: the prefix itself checks the connection, search
+	// lists the targets and query returns their values.
+	//
+	// Targets are a dashboard, a slash, a tab, a colon and one of its metrics,
+	// such as my-dash/my-tab:pass_rate.
+	GrafanaPrefix = "/api/v1/grafana/"
+
+	grafanaSearch = "search"
+	grafanaQuery  = "query"
+
+	// grafanaPassRate is the percent of the tab's completed columns which passed.
+	grafanaPassRate = "pass_rate"
+	// grafanaCellPassRate is the percent of the tab's filled cells which passed.
+	grafanaCellPassRate = "cell_pass_rate"
+	// grafanaFailingTests is the number of failing tests of the tab.
+	grafanaFailingTests = "failing_tests"
+	// grafanaFlakiness is the average flakiness of the tab's tests.
+	grafanaFlakiness = "flakiness"
+	// grafanaRowStats is the pass rate of each row over each of the tab's row_stats_days.
+	grafanaRowStats = "row_stats"
+)
+
+var grafanaMetrics = []string{
+	grafanaCellPassRate,
+	grafanaFailingTests,
+	grafanaFlakiness,
+	grafanaPassRate,
+	grafanaRowStats,
+}
+
+type grafanaSearchRequest struct {
+	Target string `json:"target"`
+}
+
+type grafanaTarget struct {
+	Target string `json:"target"`
+	RefID  string `json:"refId"`
+	// Type is either timeserie, the default, or table.
+	Type string `json:"type"`
+}
+
+type grafanaQueryRequest struct {
+	Targets []grafanaTarget `json:"targets"`
+}
+
+// grafanaSeries holds datapoints of a value and the milliseconds since the epoch.
+type grafanaSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+type grafanaColumn struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+type grafanaTable struct {
+	Type    string          `json:"type"`
+	Columns []grafanaColumn `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// parseGrafanaTarget returns the dashboard, tab and metric of a target.
+func parseGrafanaTarget(target string) (string, string, string, bool) {
+	colon := strings.LastIndex(target, ":")
+	if colon < 0 {
+		return "", "", "", false
+	}
+	slash := strings.Index(target[:colon], "/")
+	if slash <= 0 || slash == colon-1 {
+		return "", "", "", false
+	}
+	metric := target[colon+1:]
+	for _, m := range grafanaMetrics {
+		if m == metric {
+			return target[:slash], target[slash+1 : colon], metric, true
+		}
+	}
+	return "", "", "", false
+}
+
+// isGrafanaPath returns true when the path is under GrafanaPrefix, with or without its trailing slash.
+func isGrafanaPath(escapedPath string) bool {
+	return strings.HasPrefix(escapedPath, GrafanaPrefix) || escapedPath == strings.TrimSuffix(GrafanaPrefix, "/")
+}
+
+// serveGrafana serves the endpoints of a Grafana JSON datasource.
+func (s *Server) serveGrafana(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.EscapedPath(), strings.TrimSuffix(GrafanaPrefix, "/"))
+	switch endpoint = strings.TrimPrefix(endpoint, "/"); endpoint {
+	case "":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("OK"))
+	case grafanaSearch, grafanaQuery:
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var resp interface{}
+		var err error
+		if endpoint == grafanaSearch {
+			resp, err = s.grafanaSearch(r)
+		} else {
+			resp, err = s.grafanaQuery(r)
+		}
+		var reqErr *grafanaRequestError
+		switch {
+		case errors.As(err, &reqErr):
+			http.Error(w, err.Error(), reqErr.code)
+			return
+		case err != nil:
+			s.log().WithError(err).Warning("Failed to serve grafana query")
+			http.Error(w, "failed to read tabs", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			s.log().WithError(err).Warning("Failed to write response")
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// grafanaRequestError is an error of the request rather than the server.
+type grafanaRequestError struct {
+	code int
+	msg  string
+}
+
+func (e *grafanaRequestError) Error() string {
+	return e.msg
+}
+
+// grafanaSearch returns every target containing the search target.
+func (s *Server) grafanaSearch(r *http.Request) ([]string, error) {
+	var req grafanaSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, &grafanaRequestError{http.StatusBadRequest, fmt.Sprintf("bad search: %v", err)}
+	}
+	query := strings.ToLower(req.Target)
+	targets := []string{}
+	for _, d := range s.Reader.Config.GetDashboards() {
+		for _, tab := range d.DashboardTab {
+			for _, m := range grafanaMetrics {
+				target := d.Name + "/" + tab.Name + ":" + m
+				if strings.Contains(strings.ToLower(target), query) {
+					targets = append(targets, target)
+				}
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// grafanaQuery returns the series or table of each target from the current
+// summary of its tab.
+func (s *Server) grafanaQuery(r *http.Request) ([]interface{}, error) {
+	var req grafanaQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, &grafanaRequestError{http.StatusBadRequest, fmt.Sprintf("bad query: %v", err)}
+	}
+	reqs := make([]tabs.Request, 0, len(req.Targets))
+	metrics := make([]string, 0, len(req.Targets))
+	for _, t := range req.Targets {
+		dashboard, tab, metric, ok := parseGrafanaTarget(t.Target)
+		if !ok {
+			return nil, &grafanaRequestError{http.StatusBadRequest, fmt.Sprintf("bad target %q", t.Target)}
+		}
+		reqs = append(reqs, tabs.Request{Dashboard: dashboard, Tab: tab})
+		metrics = append(metrics, metric)
+	}
+	resp := []interface{}{}
+	for i, res := range s.Reader.GetTabs(r.Context(), reqs) {
+		target := req.Targets[i]
+		switch {
+		case errors.Is(res.Err, tabs.ErrNotFound):
+			return nil, &grafanaRequestError{http.StatusNotFound, fmt.Sprintf("unknown target %q", target.Target)}
+		case res.Err != nil:
+			return nil, fmt.Errorf("%s: %w", target.Target, res.Err)
+		}
+		if target.Type == "table" {
+			resp = append(resp, grafanaSummaryTable(res.Summary, metrics[i]))
+			continue
+		}
+		for _, series := range grafanaSummarySeries(res.Summary, target.Target, metrics[i]) {
+			resp = append(resp, series)
+		}
+	}
+	return resp, nil
+}
+
+// percent returns the percent of num in denom, and false when denom is zero.
+func percent(num, denom int32) (float64, bool) {
+	if denom == 0 {
+		return 0, false
+	}
+	return 100 * float64(num) / float64(denom), true
+}
+
+// grafanaValue returns the metric of the summary, and false when it has none.
+func grafanaValue(sum *summarypb.DashboardTabSummary, metric string) (float64, bool) {
+	switch metric {
+	case grafanaPassRate:
+		counts := sum.GetStatusCounts()
+		return percent(counts.GetPassingColumns(), counts.GetCompletedColumns())
+	case grafanaCellPassRate:
+		counts := sum.GetStatusCounts()
+		return percent(counts.GetPassingCells(), counts.GetFilledCells())
+	case grafanaFailingTests:
+		return float64(len(sum.GetFailingTestSummaries())), sum != nil
+	case grafanaFlakiness:
+		if sum.GetHealthiness() == nil {
+			return 0, false
+		}
+		return float64(sum.GetHealthiness().GetAverageFlakiness()), true
+	}
+	return 0, false
+}
+
+// grafanaSummarySeries returns the series of the metric, with a datapoint at
+// the last update of the summary, or one series per row and window of the row stats.
+func grafanaSummarySeries(sum *summarypb.DashboardTabSummary, target, metric string) []grafanaSeries {
+	stamp := sum.GetLastUpdateTimestamp() * 1000
+	if metric != grafanaRowStats {
+		series := grafanaSeries{Target: target, Datapoints: [][2]float64{}}
+		if v, ok := grafanaValue(sum, metric); ok {
+			series.Datapoints = append(series.Datapoints, [2]float64{v, stamp})
+		}
+		return []grafanaSeries{series}
+	}
+	var out []grafanaSeries
+	for _, stats := range sum.GetRowStats() {
+		for _, win := range stats.Windows {
+			out = append(out, grafanaSeries{
+				Target:     fmt.Sprintf("%s (%dd)", stats.DisplayName, win.Days),
+				Datapoints: [][2]float64{{float64(win.PassPercent), stamp}},
+			})
+		}
+	}
+	return out
+}
+
+// grafanaSummaryTable returns the table of the metric, with the failing
+// tests or row stats of the summary as rows.
+func grafanaSummaryTable(sum *summarypb.DashboardTabSummary, metric string) grafanaTable {
+	table := grafanaTable{Type: "table", Rows: [][]interface{}{}}
+	switch metric {
+	case grafanaFailingTests:
+		table.Columns = []grafanaColumn{
+			{Text: "Test", Type: "string"},
+			{Text: "Failures", Type: "number"},
+			{Text: "First failure", Type: "string"},
+			{Text: "Last pass", Type: "string"},
+			{Text: "Failed since", Type: "time"},
+		}
+		for _, f := range sum.GetFailingTestSummaries() {
+			table.Rows = append(table.Rows, []interface{}{
+				f.DisplayName,
+				f.FailCount,
+				f.FailBuildId,
+				f.PassBuildId,
+				f.FailTimestamp * 1000,
+			})
+		}
+	case grafanaRowStats:
+		table.Columns = []grafanaColumn{
+			{Text: "Test", Type: "string"},
+			{Text: "Days", Type: "number"},
+			{Text: "Runs", Type: "number"},
+			{Text: "Passes", Type: "number"},
+			{Text: "Failures", Type: "number"},
+			{Text: "Pass rate", Type: "number"},
+			{Text: "Average duration", Type: "number"},
+		}
+		for _, stats := range sum.GetRowStats() {
+			for _, win := range stats.Windows {
+				table.Rows = append(table.Rows, []interface{}{
+					stats.DisplayName,
+					win.Days,
+					win.Runs,
+					win.Passes,
+					win.Failures,
+					win.PassPercent,
+					win.AverageDurationSeconds,
+				})
+			}
+		}
+	default:
+		table.Columns = []grafanaColumn{
+			{Text: "Time", Type: "time"},
+			{Text: metric, Type: "number"},
+		}
+		if v, ok := grafanaValue(sum, metric); ok {
+			table.Rows = append(table.Rows, []interface{}{sum.GetLastUpdateTimestamp() * 1000, v})
+		}
+	}
+	return table
+}
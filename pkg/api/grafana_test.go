@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestParseGrafanaTarget(t *testing.T) {
+	cases := []struct {
+		target    string
+		dashboard string
+		tab       string
+		metric    string
+		ok        bool
+	}{
+		{target: "dash/tab:pass_rate", dashboard: "dash", tab: "tab", metric: "pass_rate", ok: true},
+		{target: "dash/a/tab:with:colons:row_stats", dashboard: "dash", tab: "a/tab:with:colons", metric: "row_stats", ok: true},
+		{target: "dash/tab:unknown"},
+		{target: "dash/tab"},
+		{target: "dash:pass_rate"},
+		{target: "/tab:pass_rate"},
+		{target: "dash/:pass_rate"},
+	}
+
+	for _, tc := range cases {
+		dashboard, tab, metric, ok := parseGrafanaTarget(tc.target)
+		if dashboard != tc.dashboard || tab != tc.tab || metric != tc.metric || ok != tc.ok {
+			t.Errorf("parseGrafanaTarget(%q) got %q, %q, %q, %t, want %q, %q, %q, %t", tc.target, dashboard, tab, metric, ok, tc.dashboard, tc.tab, tc.metric, tc.ok)
+		}
+	}
+}
+
+func TestServeGrafana(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	buf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName:    "tab",
+				LastUpdateTimestamp: 1000,
+				StatusCounts:        &summarypb.StatusCounts{PassingColumns: 3, CompletedColumns: 4, PassingCells: 9, FilledCells: 10},
+				FailingTestSummaries: []*summarypb.FailingTestSummary{
+					{DisplayName: "foo", FailCount: 2, FailBuildId: "5", PassBuildId: "4", FailTimestamp: 900},
+				},
+				RowStats: []*summarypb.RowStats{
+					{DisplayName: "foo", Windows: []*summarypb.RowWindowStats{{Days: 7, Runs: 4, Passes: 3, Failures: 1, PassPercent: 75}}},
+				},
+			},
+			{
+				DashboardTabName:    "empty",
+				LastUpdateTimestamp: 1000,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{*summaryPath: {Data: string(buf)}},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", TestGroupName: "group"},
+							{Name: "empty", TestGroupName: "group"},
+						},
+					},
+				},
+			},
+			ConfigPath:    *configPath,
+			SummaryPrefix: "summary",
+		},
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+		result string
+	}{
+		{
+			name:   "connection",
+			method: http.MethodGet,
+			path:   GrafanaPrefix,
+			want:   http.StatusOK,
+			result: "OK",
+		},
+		{
+			name:   "connection without trailing slash",
+			method: http.MethodGet,
+			path:   strings.TrimSuffix(GrafanaPrefix, "/"),
+			want:   http.StatusOK,
+			result: "OK",
+		},
+		{
+			name:   "search",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "search",
+			body:   `{"target": "TAB:PASS"}`,
+			want:   http.StatusOK,
+			result: `["dash/tab:pass_rate"]`,
+		},
+		{
+			name:   "search everything",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "search",
+			body:   `{}`,
+			want:   http.StatusOK,
+			result: `["dash/empty:cell_pass_rate","dash/empty:failing_tests","dash/empty:flakiness","dash/empty:pass_rate","dash/empty:row_stats","dash/tab:cell_pass_rate","dash/tab:failing_tests","dash/tab:flakiness","dash/tab:pass_rate","dash/tab:row_stats"]`,
+		},
+		{
+			name:   "query series",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "query",
+			body:   `{"targets": [{"target": "dash/tab:pass_rate", "refId": "A"}, {"target": "dash/tab:failing_tests", "type": "timeserie"}, {"target": "dash/empty:pass_rate"}]}`,
+			want:   http.StatusOK,
+			result: `[{"target":"dash/tab:pass_rate","datapoints":[[75,1000000]]},{"target":"dash/tab:failing_tests","datapoints":[[1,1000000]]},{"target":"dash/empty:pass_rate","datapoints":[]}]`,
+		},
+		{
+			name:   "query row stats series",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "query",
+			body:   `{"targets": [{"target": "dash/tab:row_stats"}]}`,
+			want:   http.StatusOK,
+			result: `[{"target":"foo (7d)","datapoints":[[75,1000000]]}]`,
+		},
+		{
+			name:   "query tables",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "query",
+			body:   `{"targets": [{"target": "dash/tab:cell_pass_rate", "type": "table"}, {"target": "dash/tab:failing_tests", "type": "table"}, {"target": "dash/tab:row_stats", "type": "table"}]}`,
+			want:   http.StatusOK,
+			result: `[
+				{"type":"table","columns":[{"text":"Time","type":"time"},{"text":"cell_pass_rate","type":"number"}],"rows":[[1000000,90]]},
+				{"type":"table","columns":[{"text":"Test","type":"string"},{"text":"Failures","type":"number"},{"text":"First failure","type":"string"},{"text":"Last pass","type":"string"},{"text":"Failed since","type":"time"}],"rows":[["foo",2,"5","4",900000]]},
+				{"type":"table","columns":[{"text":"Test","type":"string"},{"text":"Days","type":"number"},{"text":"Runs","type":"number"},{"text":"Passes","type":"number"},{"text":"Failures","type":"number"},{"text":"Pass rate","type":"number"},{"text":"Average duration","type":"number"}],"rows":[["foo",7,4,3,1,75,0]]}
+			]`,
+		},
+		{
+			name:   "bad target",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "query",
+			body:   `{"targets": [{"target": "dash/tab"}]}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown tab",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "query",
+			body:   `{"targets": [{"target": "dash/missing:pass_rate"}]}`,
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "bad query",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "query",
+			body:   `{`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodGet,
+			path:   GrafanaPrefix + "query",
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "unknown endpoint",
+			method: http.MethodPost,
+			path:   GrafanaPrefix + "annotations",
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP(%s %s) got %d, want %d: %s", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+			}
+			if tc.result == "" {
+				return
+			}
+			if !json.Valid([]byte(tc.result)) {
+				if got := w.Body.String(); got != tc.result {
+					t.Errorf("ServeHTTP(%s %s) got %q, want %q", tc.method, tc.path, got, tc.result)
+				}
+				return
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshal response: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tc.result), &want); err != nil {
+				t.Fatalf("unmarshal result: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ServeHTTP(%s %s) got unexpected diff (-want +got):\n%s", tc.method, tc.path, diff)
+			}
+		})
+	}
+}
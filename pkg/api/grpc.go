@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// GRPCOptions configures the keepalive behavior of the gRPC server.
+//
+// Zero values use the gRPC defaults.
+type GRPCOptions struct {
+	// KeepaliveTime pings idle clients after this long.
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout closes connections which do not answer a ping within this long.
+	KeepaliveTimeout time.Duration
+	// MaxConnectionAge closes connections after this long, so clients rebalance.
+	MaxConnectionAge time.Duration
+	// MinPingInterval closes connections which ping more often than this.
+	MinPingInterval time.Duration
+	// PermitIdlePings allows clients to ping without active streams.
+	PermitIdlePings bool
+}
+
+func (o GRPCOptions) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:             o.KeepaliveTime,
+			Timeout:          o.KeepaliveTimeout,
+			MaxConnectionAge: o.MaxConnectionAge,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             o.MinPingInterval,
+			PermitWithoutStream: o.PermitIdlePings,
+		}),
+	}
+}
+
+// NewGRPCServer returns a gRPC server which serves reflection and the
+// standard grpc.health.v1 service, along with its health server.
+//
+// The server initially reports that it is serving. Call Shutdown() on the
+// health server before stopping to drain load balancers.
+func NewGRPCServer(o GRPCOptions, opts ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	srv := grpc.NewServer(append(o.serverOptions(), opts...)...)
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	reflection.Register(srv)
+	return srv, hs
+}
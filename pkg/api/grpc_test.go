@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestNewGRPCServer(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv, hs := NewGRPCServer(GRPCOptions{
+		KeepaliveTime:   time.Minute,
+		MinPingInterval: 30 * time.Second,
+	})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatalf("DialContext() got unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	health := healthpb.NewHealthClient(conn)
+	check := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("Check() got unexpected error: %v", err)
+		}
+		if got := resp.GetStatus(); got != want {
+			t.Errorf("Check() got %s, want %s", got, want)
+		}
+	}
+	check(healthpb.HealthCheckResponse_SERVING)
+	hs.Shutdown()
+	check(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("ServerReflectionInfo() got unexpected error: %v", err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("Send() got unexpected error: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() got unexpected error: %v", err)
+	}
+	var got []string
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		got = append(got, svc.Name)
+	}
+	sort.Strings(got)
+	want := []string{"grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListServices() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	issuepb "github.com/GoogleCloudPlatform/testgrid/pb/issue_state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+const maxIssueBytes = 64 << 10
+
+// rowIssues returns the issues of the state associated with the row.
+func rowIssues(state *issuepb.IssueState, row string) *issuepb.IssueState {
+	var out issuepb.IssueState
+	for _, info := range state.IssueInfo {
+		for _, id := range info.RowIds {
+			if id == row {
+				out.IssueInfo = append(out.IssueInfo, info)
+				break
+			}
+		}
+	}
+	return &out
+}
+
+// serveIssues lists, attaches (POST) and detaches (DELETE) the issues associated with a row.
+//
+// POST requests send an IssueInfo with the issue_id, typically the bug URL.
+// DELETE requests identify the issue with the issue query parameter.
+// Changes require an authenticated identity.
+func (s *Server) serveIssues(w http.ResponseWriter, r *http.Request, dashboard, tab, row string) {
+	if s.Issues == nil {
+		http.Error(w, "issue association is disabled", http.StatusNotImplemented)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+		"row":       row,
+	})
+	gridPath, err := s.Reader.GridPath(dashboard, tab)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to resolve grid")
+		http.Error(w, "failed to resolve grid", http.StatusInternalServerError)
+		return
+	}
+	path, err := updater.IssueStatePath(*gridPath)
+	if err != nil {
+		log.WithError(err).Warning("Failed to resolve issue state")
+		http.Error(w, "failed to resolve issue state", http.StatusInternalServerError)
+		return
+	}
+
+	id := IdentityFromContext(r.Context())
+	if (r.Method == http.MethodPost || r.Method == http.MethodDelete) && id == nil {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var update func(*issuepb.IssueState) bool
+	switch r.Method {
+	case http.MethodGet:
+		update = func(*issuepb.IssueState) bool { return false }
+	case http.MethodPost:
+		buf, err := ioutil.ReadAll(io.LimitReader(r.Body, maxIssueBytes))
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+		var info issuepb.IssueInfo
+		if err := Unmarshal(r.Header.Get("Content-Type"), buf, &info); err != nil || info.IssueId == "" {
+			http.Error(w, "request must be an IssueInfo with an issue_id", http.StatusBadRequest)
+			return
+		}
+		log = log.WithField("issue", info.IssueId)
+		update = func(state *issuepb.IssueState) bool {
+			return updater.AttachIssue(state, info.IssueId, row)
+		}
+	case http.MethodDelete:
+		issue := r.URL.Query().Get("issue")
+		if issue == "" {
+			http.Error(w, "missing issue parameter", http.StatusBadRequest)
+			return
+		}
+		log = log.WithField("issue", issue)
+		update = func(state *issuepb.IssueState) bool {
+			return updater.DetachIssue(state, issue, row)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	state, err := updater.UpdateIssueState(r.Context(), s.Issues, *path, update)
+	if err != nil {
+		log.WithError(err).Warning("Failed to update issues")
+		http.Error(w, "failed to update issues", http.StatusInternalServerError)
+		return
+	}
+	if r.Method != http.MethodGet {
+		log.WithFields(logrus.Fields{
+			"method":   r.Method,
+			"identity": id.String(),
+		}).Info("Updated issues")
+	}
+	if err := Write(w, r, rowIssues(state, row)); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
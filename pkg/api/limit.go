@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const defaultMaxClients = 10000
+
+// Limiter rate limits each client and sheds load when too many requests are in flight.
+//
+// Clients are identified by their verified identity, so the limiter must wrap
+// handlers inside Auth, or else by their IP address. Unverified credentials
+// never identify clients, since callers could mint a new one per request.
+type Limiter struct {
+	// Rate allows each client this many requests per second, unlimited when zero.
+	Rate float64
+	// Burst allows each client this many requests at once, at least one.
+	Burst int
+	// MaxInFlight serves at most this many requests concurrently, unlimited when zero.
+	//
+	// A single request may read many grids, so also bound the reads of every
+	// request together with tabs.Reader.Decodes.
+	MaxInFlight int
+	// MaxClients tracks at most this many clients, defaulting to 10000.
+	//
+	// Clients whose allowance has refilled are forgotten first. Requests from
+	// new clients are rejected when every tracked client is still limited.
+	MaxClients int
+	// TrustForwarded identifies clients by the first X-Forwarded-For address.
+	//
+	// Only set this when a proxy in front of the server overwrites the header.
+	TrustForwarded bool
+	Log            logrus.FieldLogger
+
+	now func() time.Time
+
+	lock     sync.Mutex
+	inFlight int
+	buckets  map[string]*bucket
+}
+
+// bucket holds the number of requests a client may make at the updated time.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Wrap returns a handler which serves requests with next unless the client or
+// server is over its limit, in which case it responds with 429 Too Many Requests.
+func (l *Limiter) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := l.client(r)
+		if wait, ok := l.allow(client); !ok {
+			l.log().WithFields(logrus.Fields{
+				"client": client,
+				"path":   r.URL.Path,
+			}).Debug("Rate limited request")
+			reject(w, wait, "rate limit exceeded")
+			return
+		}
+		if !l.acquire() {
+			l.log().WithFields(logrus.Fields{
+				"client": client,
+				"path":   r.URL.Path,
+			}).Warning("Shed request")
+			reject(w, time.Second, "server overloaded")
+			return
+		}
+		defer l.release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func reject(w http.ResponseWriter, wait time.Duration, msg string) {
+	secs := int(math.Ceil(wait.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	http.Error(w, msg, http.StatusTooManyRequests)
+}
+
+// client returns the key which identifies the client making the request.
+func (l *Limiter) client(r *http.Request) string {
+	if id := IdentityFromContext(r.Context()); id != nil {
+		return "identity:" + id.String()
+	}
+	if l.TrustForwarded {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			return "ip:" + strings.TrimSpace(strings.SplitN(fwd, ",", 2)[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// allow consumes a request from the client's allowance, returning false along
+// with how long to wait when it is exhausted.
+func (l *Limiter) allow(client string) (time.Duration, bool) {
+	if l.Rate <= 0 {
+		return 0, true
+	}
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	now := l.clock()
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.buckets == nil {
+		l.buckets = map[string]*bucket{}
+	}
+	b, ok := l.buckets[client]
+	if !ok {
+		if !l.makeRoom(now, burst) {
+			return time.Second, false
+		}
+		b = &bucket{tokens: burst, updated: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*l.Rate)
+	b.updated = now
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.Rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// makeRoom forgets clients whose allowance has refilled when tracking too
+// many clients, returning false if there is still no room for another.
+func (l *Limiter) makeRoom(now time.Time, burst float64) bool {
+	max := l.MaxClients
+	if max <= 0 {
+		max = defaultMaxClients
+	}
+	if len(l.buckets) < max {
+		return true
+	}
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.Rate >= burst {
+			delete(l.buckets, client)
+		}
+	}
+	return len(l.buckets) < max
+}
+
+func (l *Limiter) acquire() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.MaxInFlight > 0 && l.inFlight >= l.MaxInFlight {
+		return false
+	}
+	l.inFlight++
+	return true
+}
+
+func (l *Limiter) release() {
+	l.lock.Lock()
+	l.inFlight--
+	l.lock.Unlock()
+}
+
+func (l *Limiter) clock() time.Time {
+	if l.now == nil {
+		return time.Now()
+	}
+	return l.now()
+}
+
+func (l *Limiter) log() logrus.FieldLogger {
+	if l.Log == nil {
+		return logrus.StandardLogger()
+	}
+	return l.Log
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLimiterRate(t *testing.T) {
+	type request struct {
+		after  time.Duration
+		remote string
+		header map[string]string
+		id     *Identity
+	}
+	cases := []struct {
+		name       string
+		limiter    *Limiter
+		requests   []request
+		want       []int
+		retryAfter string
+	}{
+		{
+			name:     "unlimited by default",
+			requests: []request{{remote: "1.2.3.4:5"}, {remote: "1.2.3.4:5"}, {remote: "1.2.3.4:5"}},
+			want:     []int{200, 200, 200},
+		},
+		{
+			name:    "limit each ip",
+			limiter: &Limiter{Rate: 1, Burst: 2},
+			requests: []request{
+				{remote: "1.2.3.4:5"},
+				{remote: "1.2.3.4:6"},
+				{remote: "1.2.3.4:7"},
+				{remote: "5.6.7.8:5"},
+				{remote: "1.2.3.4:5", after: time.Second},
+				{remote: "1.2.3.4:5"},
+			},
+			want: []int{200, 200, 429, 200, 200, 429},
+		},
+		{
+			name:    "wait for the allowance to refill",
+			limiter: &Limiter{Rate: 0.1},
+			requests: []request{
+				{remote: "1.2.3.4:5"},
+				{remote: "1.2.3.4:5", after: 2 * time.Second},
+			},
+			want:       []int{200, 429},
+			retryAfter: "8",
+		},
+		{
+			name:    "ignore unverified tokens",
+			limiter: &Limiter{Rate: 1},
+			requests: []request{
+				{remote: "1.2.3.4:5", header: map[string]string{"Authorization": "Bearer a"}},
+				{remote: "1.2.3.4:5", header: map[string]string{"Authorization": "Bearer b"}},
+				{remote: "5.6.7.8:5", header: map[string]string{"Authorization": "Bearer a"}},
+			},
+			want: []int{200, 429, 200},
+		},
+		{
+			name:    "limit each identity",
+			limiter: &Limiter{Rate: 1},
+			requests: []request{
+				{remote: "1.2.3.4:5", id: &Identity{Email: "fred@example.com"}},
+				{remote: "5.6.7.8:5", id: &Identity{Email: "fred@example.com"}},
+				{remote: "5.6.7.8:5"},
+			},
+			want: []int{200, 429, 200},
+		},
+		{
+			name:    "ignore forwarded addresses by default",
+			limiter: &Limiter{Rate: 1},
+			requests: []request{
+				{remote: "1.2.3.4:5", header: map[string]string{"X-Forwarded-For": "5.6.7.8"}},
+				{remote: "1.2.3.4:5", header: map[string]string{"X-Forwarded-For": "9.9.9.9"}},
+			},
+			want: []int{200, 429},
+		},
+		{
+			name:    "trust forwarded addresses",
+			limiter: &Limiter{Rate: 1, TrustForwarded: true},
+			requests: []request{
+				{remote: "1.2.3.4:5", header: map[string]string{"X-Forwarded-For": "5.6.7.8, 1.2.3.4"}},
+				{remote: "1.2.3.4:5", header: map[string]string{"X-Forwarded-For": "9.9.9.9, 1.2.3.4"}},
+				{remote: "1.2.3.4:5", header: map[string]string{"X-Forwarded-For": "5.6.7.8"}},
+			},
+			want: []int{200, 200, 429},
+		},
+		{
+			name:    "forget refilled clients",
+			limiter: &Limiter{Rate: 1, MaxClients: 1},
+			requests: []request{
+				{remote: "1.2.3.4:5"},
+				{remote: "5.6.7.8:5"},
+				{remote: "5.6.7.8:5", after: time.Second},
+			},
+			want: []int{200, 429, 200},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.limiter == nil {
+				tc.limiter = &Limiter{}
+			}
+			now := time.Unix(1000, 0)
+			tc.limiter.now = func() time.Time { return now }
+			handler := tc.limiter.Wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			var got []int
+			var retryAfter string
+			for _, req := range tc.requests {
+				now = now.Add(req.after)
+				r := httptest.NewRequest(http.MethodGet, "/dashboards", nil)
+				r.RemoteAddr = req.remote
+				for k, v := range req.header {
+					r.Header.Set(k, v)
+				}
+				if req.id != nil {
+					r = r.WithContext(context.WithValue(r.Context(), identityKey{}, req.id))
+				}
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+				got = append(got, w.Code)
+				if w.Code == http.StatusTooManyRequests {
+					retryAfter = w.Header().Get("Retry-After")
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ServeHTTP() got unexpected codes (-want +got):\n%s", diff)
+			}
+			if tc.retryAfter != "" && retryAfter != tc.retryAfter {
+				t.Errorf("ServeHTTP() got Retry-After %q, want %q", retryAfter, tc.retryAfter)
+			}
+		})
+	}
+}
+
+func TestLimiterShed(t *testing.T) {
+	started := make(chan struct{})
+	done := make(chan struct{})
+	l := Limiter{MaxInFlight: 1}
+	handler := l.Wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		started <- struct{}{}
+		<-done
+	}))
+
+	serve := func() int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dashboards", nil))
+		return w.Code
+	}
+
+	first := make(chan int)
+	go func() { first <- serve() }()
+	<-started
+	if got := serve(); got != http.StatusTooManyRequests {
+		t.Errorf("ServeHTTP() while busy got %d, want %d", got, http.StatusTooManyRequests)
+	}
+	close(done)
+	if got := <-first; got != http.StatusOK {
+		t.Errorf("ServeHTTP() got %d, want %d", got, http.StatusOK)
+	}
+	go func() { <-started }()
+	if got := serve(); got != http.StatusOK {
+		t.Errorf("ServeHTTP() after finishing got %d, want %d", got, http.StatusOK)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	costspb "github.com/GoogleCloudPlatform/testgrid/pb/costs"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/costs"
+)
+
+const (
+	// AlertMetricsPath serves the AlertMetrics of the past weeks query parameter, 13 by default.
+	AlertMetricsPath = "/api/v1/alert-metrics"
+	// MetricsPath serves the alert metrics of the past week, along with any
+	// storage cost metrics, in the Prometheus text format.
+	MetricsPath = "/metrics"
+
+	defaultMetricsWeeks = 13
+	// maxMetricsWeeks covers the alert history kept by the summarizer.
+	maxMetricsWeeks = 56
+)
+
+// serveAlertMetrics serves the AlertMetrics of the requested number of weeks.
+func (s *Server) serveAlertMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	weeks := defaultMetricsWeeks
+	if v := r.URL.Query().Get("weeks"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxMetricsWeeks {
+			http.Error(w, fmt.Sprintf("weeks must be between 1 and %d", maxMetricsWeeks), http.StatusBadRequest)
+			return
+		}
+		weeks = n
+	}
+	end := time.Now()
+	metrics, err := s.Reader.AlertMetrics(r.Context(), end.Add(-time.Duration(weeks)*7*24*time.Hour), end)
+	if err != nil {
+		s.log().WithError(err).Warning("Failed to compute alert metrics")
+		http.Error(w, "failed to compute alert metrics", http.StatusInternalServerError)
+		return
+	}
+	if err := Write(w, r, metrics); err != nil {
+		s.log().WithError(err).Warning("Failed to write response")
+	}
+}
+
+// serveMetrics serves the alert metrics of the past week, along with the
+// storage cost metrics when configured, for Prometheus to scrape.
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	end := time.Now()
+	metrics, err := s.Reader.AlertMetrics(r.Context(), end.Add(-7*24*time.Hour), end)
+	if err != nil {
+		s.log().WithError(err).Warning("Failed to compute alert metrics")
+		http.Error(w, "failed to compute alert metrics", http.StatusInternalServerError)
+		return
+	}
+	var report *costspb.CostReport
+	if s.CostReport != nil {
+		// Alert metrics remain useful without cost metrics.
+		report, err = costs.Read(r.Context(), s.Reader.Client, *s.CostReport)
+		switch {
+		case errors.Is(err, storage.ErrObjectNotExist):
+			s.log().WithField("path", s.CostReport).Debug("No cost report yet")
+		case err != nil:
+			s.log().WithError(err).WithField("path", s.CostReport).Warning("Failed to read cost report")
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := WritePrometheus(w, metrics); err != nil {
+		s.log().WithError(err).Warning("Failed to write response")
+		return
+	}
+	if report == nil {
+		return
+	}
+	if err := WriteCostPrometheus(w, report); err != nil {
+		s.log().WithError(err).Warning("Failed to write response")
+	}
+}
+
+// promSamples adds each sample of a metric, with its value and pairs of label names and values.
+type promSamples func(add func(value float64, labels ...string))
+
+// writeGauge writes the gauge and its samples in the Prometheus text format.
+func writeGauge(b *strings.Builder, name, help string, samples promSamples) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	samples(func(value float64, labels ...string) {
+		b.WriteString(name)
+		for i := 0; i+1 < len(labels); i += 2 {
+			sep := ","
+			if i == 0 {
+				sep = "{"
+			}
+			fmt.Fprintf(b, "%s%s=\"%s\"", sep, labels[i], promLabel.Replace(labels[i+1]))
+		}
+		if len(labels) > 0 {
+			b.WriteString("}")
+		}
+		fmt.Fprintf(b, " %s\n", strconv.FormatFloat(value, 'g', -1, 64))
+	})
+}
+
+// WritePrometheus writes the alert metrics as gauges in the Prometheus text format.
+func WritePrometheus(w io.Writer, metrics *responsepb.AlertMetrics) error {
+	var b strings.Builder
+	gauge := func(name, help string, samples promSamples) {
+		writeGauge(&b, name, help, samples)
+	}
+	tabs := func(value func(*responsepb.TabAlertMetrics) (float64, bool)) func(func(float64, ...string)) {
+		return func(add func(float64, ...string)) {
+			for _, m := range metrics.GetTabs() {
+				if v, ok := value(m); ok {
+					add(v, "dashboard", m.Dashboard, "tab", m.Tab, "team", m.Team)
+				}
+			}
+		}
+	}
+	teams := func(value func(*responsepb.TeamAlertMetrics) (float64, bool)) func(func(float64, ...string)) {
+		return func(add func(float64, ...string)) {
+			for _, m := range metrics.GetTeams() {
+				if v, ok := value(m); ok {
+					add(v, "team", m.Team)
+				}
+			}
+		}
+	}
+
+	gauge("testgrid_tab_failing", "Whether the dashboard tab currently fails.", tabs(func(m *responsepb.TabAlertMetrics) (float64, bool) {
+		if m.Failing {
+			return 1, true
+		}
+		return 0, true
+	}))
+	gauge("testgrid_tab_alerts_opened", "Alerts the dashboard tab opened during the past week.", tabs(func(m *responsepb.TabAlertMetrics) (float64, bool) {
+		return float64(m.Opened), true
+	}))
+	gauge("testgrid_tab_alerts_resolved", "Alerts the dashboard tab resolved during the past week.", tabs(func(m *responsepb.TabAlertMetrics) (float64, bool) {
+		return float64(m.Resolved), true
+	}))
+	gauge("testgrid_tab_time_to_green_seconds", "Mean time to green of the alerts resolved during the past week.", tabs(func(m *responsepb.TabAlertMetrics) (float64, bool) {
+		return m.MeanTimeToGreen, m.Resolved > 0
+	}))
+	gauge("testgrid_team_failing_tabs", "Dashboard tabs of the team which currently fail.", teams(func(m *responsepb.TeamAlertMetrics) (float64, bool) {
+		return float64(m.Failing), true
+	}))
+	gauge("testgrid_team_alerts_opened", "Alerts the team's tabs opened during the past week.", teams(func(m *responsepb.TeamAlertMetrics) (float64, bool) {
+		return float64(m.Opened), true
+	}))
+	gauge("testgrid_team_alerts_resolved", "Alerts the team's tabs resolved during the past week.", teams(func(m *responsepb.TeamAlertMetrics) (float64, bool) {
+		return float64(m.Resolved), true
+	}))
+	gauge("testgrid_team_time_to_resolve_seconds", "Mean time to resolve the team's alerts resolved during the past week.", teams(func(m *responsepb.TeamAlertMetrics) (float64, bool) {
+		return m.MeanTimeToResolve, m.Resolved > 0
+	}))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var promLabel = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteCostPrometheus writes the storage cost report as gauges in the
+// Prometheus text format.
+//
+// Dashboards split the cost of each group evenly with the other dashboards of the group.
+func WriteCostPrometheus(w io.Writer, report *costspb.CostReport) error {
+	var b strings.Builder
+	ops := []struct {
+		name  string
+		value func(*costspb.GroupCost) int64
+	}{
+		{"read", func(gc *costspb.GroupCost) int64 { return gc.ObjectsRead }},
+		{"write", func(gc *costspb.GroupCost) int64 { return gc.ObjectsWritten }},
+		{"list", func(gc *costspb.GroupCost) int64 { return gc.Lists }},
+		{"stat", func(gc *costspb.GroupCost) int64 { return gc.Stats }},
+	}
+	groups := func(value func(*costspb.GroupCost) int64) promSamples {
+		return func(add func(float64, ...string)) {
+			for _, gc := range report.GetGroups() {
+				add(float64(value(gc)), "group", gc.Name)
+			}
+		}
+	}
+	dashboards := func(value func(*costspb.GroupCost) int64) promSamples {
+		return func(add func(float64, ...string)) {
+			shares := map[string]float64{}
+			for _, gc := range report.GetGroups() {
+				for _, d := range gc.Dashboards {
+					shares[d] += float64(value(gc)) / float64(len(gc.Dashboards))
+				}
+			}
+			names := make([]string, 0, len(shares))
+			for name := range shares {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				add(shares[name], "dashboard", name)
+			}
+		}
+	}
+
+	writeGauge(&b, "testgrid_storage_cycle_seconds", "Duration of the update cycle of the cost report.", func(add func(float64, ...string)) {
+		add(report.GetEnd() - report.GetStart())
+	})
+	writeGauge(&b, "testgrid_group_storage_read_bytes", "Bytes read to update the group during the cycle.", groups(func(gc *costspb.GroupCost) int64 {
+		return gc.BytesRead
+	}))
+	writeGauge(&b, "testgrid_group_storage_written_bytes", "Bytes written to update the group during the cycle.", groups(func(gc *costspb.GroupCost) int64 {
+		return gc.BytesWritten
+	}))
+	writeGauge(&b, "testgrid_group_storage_operations", "Storage operations to update the group during the cycle.", func(add func(float64, ...string)) {
+		for _, op := range ops {
+			groups(op.value)(func(value float64, labels ...string) {
+				add(value, append(labels, "op", op.name)...)
+			})
+		}
+	})
+	writeGauge(&b, "testgrid_group_quarantined", "Whether the write guard quarantined the latest update of the group during the cycle.", groups(func(gc *costspb.GroupCost) int64 {
+		if gc.Quarantined {
+			return 1
+		}
+		return 0
+	}))
+	writeGauge(&b, "testgrid_dashboard_storage_read_bytes", "Share of the bytes read to update the dashboard's groups during the cycle.", dashboards(func(gc *costspb.GroupCost) int64 {
+		return gc.BytesRead
+	}))
+	writeGauge(&b, "testgrid_dashboard_storage_written_bytes", "Share of the bytes written to update the dashboard's groups during the cycle.", dashboards(func(gc *costspb.GroupCost) int64 {
+		return gc.BytesWritten
+	}))
+	writeGauge(&b, "testgrid_dashboard_storage_operations", "Share of the storage operations to update the dashboard's groups during the cycle.", func(add func(float64, ...string)) {
+		for _, op := range ops {
+			dashboards(op.value)(func(value float64, labels ...string) {
+				add(value, append(labels, "op", op.name)...)
+			})
+		}
+	})
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
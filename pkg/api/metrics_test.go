@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	costspb "github.com/GoogleCloudPlatform/testgrid/pb/costs"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	metrics := &responsepb.AlertMetrics{
+		Tabs: []*responsepb.TabAlertMetrics{
+			{Dashboard: `my "dash"`, Tab: "tab", Team: "infra", Opened: 2, Resolved: 1, Failing: true, MeanTimeToGreen: 90},
+			{Dashboard: "other", Tab: "tab", Team: "infra"},
+		},
+		Teams: []*responsepb.TeamAlertMetrics{
+			{Team: "infra", Opened: 2, Resolved: 1, Failing: 1, MeanTimeToResolve: 90},
+		},
+	}
+	const want = `# HELP testgrid_tab_failing Whether the dashboard tab currently fails.
+# TYPE testgrid_tab_failing gauge
+testgrid_tab_failing{dashboard="my \"dash\"",tab="tab",team="infra"} 1
+testgrid_tab_failing{dashboard="other",tab="tab",team="infra"} 0
+# HELP testgrid_tab_alerts_opened Alerts the dashboard tab opened during the past week.
+# TYPE testgrid_tab_alerts_opened gauge
+testgrid_tab_alerts_opened{dashboard="my \"dash\"",tab="tab",team="infra"} 2
+testgrid_tab_alerts_opened{dashboard="other",tab="tab",team="infra"} 0
+# HELP testgrid_tab_alerts_resolved Alerts the dashboard tab resolved during the past week.
+# TYPE testgrid_tab_alerts_resolved gauge
+testgrid_tab_alerts_resolved{dashboard="my \"dash\"",tab="tab",team="infra"} 1
+testgrid_tab_alerts_resolved{dashboard="other",tab="tab",team="infra"} 0
+# HELP testgrid_tab_time_to_green_seconds Mean time to green of the alerts resolved during the past week.
+# TYPE testgrid_tab_time_to_green_seconds gauge
+testgrid_tab_time_to_green_seconds{dashboard="my \"dash\"",tab="tab",team="infra"} 90
+# HELP testgrid_team_failing_tabs Dashboard tabs of the team which currently fail.
+# TYPE testgrid_team_failing_tabs gauge
+testgrid_team_failing_tabs{team="infra"} 1
+# HELP testgrid_team_alerts_opened Alerts the team's tabs opened during the past week.
+# TYPE testgrid_team_alerts_opened gauge
+testgrid_team_alerts_opened{team="infra"} 2
+# HELP testgrid_team_alerts_resolved Alerts the team's tabs resolved during the past week.
+# TYPE testgrid_team_alerts_resolved gauge
+testgrid_team_alerts_resolved{team="infra"} 1
+# HELP testgrid_team_time_to_resolve_seconds Mean time to resolve the team's alerts resolved during the past week.
+# TYPE testgrid_team_time_to_resolve_seconds gauge
+testgrid_team_time_to_resolve_seconds{team="infra"} 90
+`
+	var buf bytes.Buffer
+	if err := WritePrometheus(&buf, metrics); err != nil {
+		t.Fatalf("WritePrometheus() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WritePrometheus() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteCostPrometheus(t *testing.T) {
+	report := &costspb.CostReport{
+		Start: 1000,
+		End:   1060,
+		Groups: []*costspb.GroupCost{
+			{Name: "shared", Dashboards: []string{"a", "b"}, BytesRead: 100, BytesWritten: 10, ObjectsRead: 4, ObjectsWritten: 2, Lists: 2, Stats: 1},
+			{Name: "solo", Dashboards: []string{"a"}, BytesRead: 7, ObjectsRead: 1, Lists: 1, Quarantined: true},
+			{Name: "unused", Stats: 1},
+		},
+	}
+	const want = `# HELP testgrid_storage_cycle_seconds Duration of the update cycle of the cost report.
+# TYPE testgrid_storage_cycle_seconds gauge
+testgrid_storage_cycle_seconds 60
+# HELP testgrid_group_storage_read_bytes Bytes read to update the group during the cycle.
+# TYPE testgrid_group_storage_read_bytes gauge
+testgrid_group_storage_read_bytes{group="shared"} 100
+testgrid_group_storage_read_bytes{group="solo"} 7
+testgrid_group_storage_read_bytes{group="unused"} 0
+# HELP testgrid_group_storage_written_bytes Bytes written to update the group during the cycle.
+# TYPE testgrid_group_storage_written_bytes gauge
+testgrid_group_storage_written_bytes{group="shared"} 10
+testgrid_group_storage_written_bytes{group="solo"} 0
+testgrid_group_storage_written_bytes{group="unused"} 0
+# HELP testgrid_group_storage_operations Storage operations to update the group during the cycle.
+# TYPE testgrid_group_storage_operations gauge
+testgrid_group_storage_operations{group="shared",op="read"} 4
+testgrid_group_storage_operations{group="solo",op="read"} 1
+testgrid_group_storage_operations{group="unused",op="read"} 0
+testgrid_group_storage_operations{group="shared",op="write"} 2
+testgrid_group_storage_operations{group="solo",op="write"} 0
+testgrid_group_storage_operations{group="unused",op="write"} 0
+testgrid_group_storage_operations{group="shared",op="list"} 2
+testgrid_group_storage_operations{group="solo",op="list"} 1
+testgrid_group_storage_operations{group="unused",op="list"} 0
+testgrid_group_storage_operations{group="shared",op="stat"} 1
+testgrid_group_storage_operations{group="solo",op="stat"} 0
+testgrid_group_storage_operations{group="unused",op="stat"} 1
+# HELP testgrid_group_quarantined Whether the write guard quarantined the latest update of the group during the cycle.
+# TYPE testgrid_group_quarantined gauge
+testgrid_group_quarantined{group="shared"} 0
+testgrid_group_quarantined{group="solo"} 1
+testgrid_group_quarantined{group="unused"} 0
+# HELP testgrid_dashboard_storage_read_bytes Share of the bytes read to update the dashboard's groups during the cycle.
+# TYPE testgrid_dashboard_storage_read_bytes gauge
+testgrid_dashboard_storage_read_bytes{dashboard="a"} 57
+testgrid_dashboard_storage_read_bytes{dashboard="b"} 50
+# HELP testgrid_dashboard_storage_written_bytes Share of the bytes written to update the dashboard's groups during the cycle.
+# TYPE testgrid_dashboard_storage_written_bytes gauge
+testgrid_dashboard_storage_written_bytes{dashboard="a"} 5
+testgrid_dashboard_storage_written_bytes{dashboard="b"} 5
+# HELP testgrid_dashboard_storage_operations Share of the storage operations to update the dashboard's groups during the cycle.
+# TYPE testgrid_dashboard_storage_operations gauge
+testgrid_dashboard_storage_operations{dashboard="a",op="read"} 3
+testgrid_dashboard_storage_operations{dashboard="b",op="read"} 2
+testgrid_dashboard_storage_operations{dashboard="a",op="write"} 1
+testgrid_dashboard_storage_operations{dashboard="b",op="write"} 1
+testgrid_dashboard_storage_operations{dashboard="a",op="list"} 2
+testgrid_dashboard_storage_operations{dashboard="b",op="list"} 1
+testgrid_dashboard_storage_operations{dashboard="a",op="stat"} 0.5
+testgrid_dashboard_storage_operations{dashboard="b",op="stat"} 0.5
+`
+	var buf bytes.Buffer
+	if err := WriteCostPrometheus(&buf, report); err != nil {
+		t.Fatalf("WriteCostPrometheus() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteCostPrometheus() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeCostMetrics(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	reportPath, err := gcs.NewPath("gs://bucket/costs")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	buf, err := proto.Marshal(&costspb.CostReport{Groups: []*costspb.GroupCost{{Name: "group", BytesRead: 7}}})
+	if err != nil {
+		t.Fatalf("proto.Marshal(): %v", err)
+	}
+	const metric = `testgrid_group_storage_read_bytes{group="group"} 7`
+	cases := []struct {
+		name   string
+		report *gcs.Path
+		opener fake.Opener
+		want   bool
+	}{
+		{
+			name:   "basically works",
+			report: reportPath,
+			opener: fake.Opener{*reportPath: {Data: string(buf)}},
+			want:   true,
+		},
+		{
+			name:   "missing report",
+			report: reportPath,
+			opener: fake.Opener{},
+		},
+		{
+			name:   "disabled",
+			opener: fake.Opener{*reportPath: {Data: string(buf)}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Server{
+				Reader: tabs.Reader{
+					Client:        tc.opener,
+					Config:        &configpb.Configuration{},
+					ConfigPath:    *configPath,
+					SummaryPrefix: "summary",
+				},
+				CostReport: tc.report,
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("ServeHTTP() got %d, want %d", w.Code, http.StatusOK)
+			}
+			if got := strings.Contains(w.Body.String(), metric); got != tc.want {
+				t.Errorf("ServeHTTP() got %t serving %q, want %t:\n%s", got, metric, tc.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestServeAlertMetrics(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client:        fake.Opener{},
+			Config:        &configpb.Configuration{Dashboards: []*configpb.Dashboard{{Name: "dash"}}},
+			ConfigPath:    *configPath,
+			SummaryPrefix: "summary",
+		},
+	}
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{
+			name:   "report",
+			method: http.MethodGet,
+			path:   AlertMetricsPath + "?weeks=4",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "bad weeks",
+			method: http.MethodGet,
+			path:   AlertMetricsPath + "?weeks=1000",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodPost,
+			path:   AlertMetricsPath,
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "prometheus",
+			method: http.MethodGet,
+			path:   MetricsPath,
+			want:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+			if w.Code != tc.want {
+				t.Errorf("ServeHTTP(%s %s) got %d, want %d", tc.method, tc.path, w.Code, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api serves TestGrid protos to HTTP clients.
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// ContentTypeJSON is the media type of proto JSON responses.
+	ContentTypeJSON = "application/json"
+	// ContentTypeProto is the media type of binary proto responses.
+	ContentTypeProto = "application/x-protobuf"
+)
+
+// Negotiate returns the supported media type the Accept header prefers.
+//
+// JSON is returned when the header is missing or accepts neither type.
+func Negotiate(accept string) string {
+	best, bestQ := ContentTypeJSON, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= 0 || q <= bestQ {
+			continue
+		}
+		switch mediaType {
+		case ContentTypeProto, "application/protobuf":
+			best, bestQ = ContentTypeProto, q
+		case ContentTypeJSON, "application/*", "*/*":
+			best, bestQ = ContentTypeJSON, q
+		}
+	}
+	return best
+}
+
+// Marshal encodes the message as the media type.
+func Marshal(contentType string, msg proto.Message) ([]byte, error) {
+	switch contentType {
+	case ContentTypeProto:
+		return proto.Marshal(msg)
+	case ContentTypeJSON:
+		var buf bytes.Buffer
+		if err := (&jsonpb.Marshaler{}).Marshal(&buf, msg); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unsupported content type %q", contentType)
+}
+
+// Unmarshal decodes the message from the media type.
+func Unmarshal(contentType string, buf []byte, msg proto.Message) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("parse content type: %w", err)
+	}
+	switch mediaType {
+	case ContentTypeProto, "application/protobuf":
+		return proto.Unmarshal(buf, msg)
+	case ContentTypeJSON:
+		return (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(bytes.NewReader(buf), msg)
+	}
+	return fmt.Errorf("unsupported content type %q", contentType)
+}
+
+// ETag returns a strong entity tag for the encoded response.
+func ETag(contentType string, buf []byte) string {
+	h := sha256.New()
+	h.Write([]byte(contentType))
+	h.Write(buf)
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// notModified returns true when the If-None-Match header matches the etag.
+func notModified(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Write responds with the message encoded as the request's preferred media type.
+//
+// Responses include an ETag, and requests with a matching If-None-Match
+// receive an empty 304 Not Modified response.
+func Write(w http.ResponseWriter, r *http.Request, msg proto.Message) error {
+	contentType := Negotiate(r.Header.Get("Accept"))
+	buf, err := Marshal(contentType, msg)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return writeEncoded(w, r, contentType, buf)
+}
+
+// writeEncoded responds with the response encoded as the media type, along
+// with its ETag unless it matches the If-None-Match header.
+func writeEncoded(w http.ResponseWriter, r *http.Request, contentType string, buf []byte) error {
+	etag := ETag(contentType, buf)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("ETag", etag)
+	if notModified(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
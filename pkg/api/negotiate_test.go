@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{
+			name: "default to json",
+			want: ContentTypeJSON,
+		},
+		{
+			name:   "proto",
+			accept: "application/x-protobuf",
+			want:   ContentTypeProto,
+		},
+		{
+			name:   "alternate proto name",
+			accept: "application/protobuf",
+			want:   ContentTypeProto,
+		},
+		{
+			name:   "json",
+			accept: "application/json",
+			want:   ContentTypeJSON,
+		},
+		{
+			name:   "first of equal weights",
+			accept: "application/x-protobuf, application/json",
+			want:   ContentTypeProto,
+		},
+		{
+			name:   "prefer higher weight",
+			accept: "application/x-protobuf;q=0.5, application/json",
+			want:   ContentTypeJSON,
+		},
+		{
+			name:   "wildcard",
+			accept: "text/html, */*;q=0.1",
+			want:   ContentTypeJSON,
+		},
+		{
+			name:   "refused proto",
+			accept: "application/x-protobuf;q=0",
+			want:   ContentTypeJSON,
+		},
+		{
+			name:   "ignore malformed",
+			accept: "application/x-protobuf;q=high, ;;;",
+			want:   ContentTypeJSON,
+		},
+		{
+			name:   "unsupported types",
+			accept: "text/html",
+			want:   ContentTypeJSON,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Negotiate(tc.accept); got != tc.want {
+				t.Errorf("Negotiate(%q) got %q, want %q", tc.accept, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	msg := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardName:    "dash",
+				DashboardTabName: "tab",
+				OverallStatus:    summarypb.DashboardTabSummary_FLAKY,
+			},
+		},
+	}
+	cases := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{
+			name: "json",
+			want: ContentTypeJSON,
+		},
+		{
+			name:   "proto",
+			accept: ContentTypeProto,
+			want:   ContentTypeProto,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/summary", nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+			if err := Write(w, r, msg); err != nil {
+				t.Fatalf("Write() got unexpected error: %v", err)
+			}
+			contentType := w.Header().Get("Content-Type")
+			if contentType != tc.want {
+				t.Errorf("Write() got content type %q, want %q", contentType, tc.want)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept" {
+				t.Errorf("Write() got Vary %q, want Accept", vary)
+			}
+			var got summarypb.DashboardSummary
+			if err := Unmarshal(contentType, w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Unmarshal() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(msg, &got, protocmp.Transform()); diff != "" {
+				t.Errorf("Write() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
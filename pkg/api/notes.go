@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	notepb "github.com/GoogleCloudPlatform/testgrid/pb/notes"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/notes"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const maxNoteRequestBytes = 16 << 10
+
+// notesPath returns the path to the notes of the tab's test group.
+func (s *Server) notesPath(dashboard, tab string) (*gcs.Path, error) {
+	gridPath, err := s.Reader.GridPath(dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	return notes.Path(*gridPath)
+}
+
+// serveNotes lists the triage notes about a row, or about every row when row
+// is empty. Authenticated requests may also add (POST), edit (PUT) and
+// delete (DELETE) the notes of a row.
+//
+// POST requests send a Note with its text and optionally the first_build and
+// last_build of the cells it covers. PUT requests send a Note with the new
+// text. PUT and DELETE requests identify the note with the note query parameter.
+func (s *Server) serveNotes(w http.ResponseWriter, r *http.Request, dashboard, tab, row string) {
+	if s.Notes == nil {
+		http.Error(w, "notes are disabled", http.StatusNotImplemented)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+		"row":       row,
+	})
+	path, err := s.notesPath(dashboard, tab)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to resolve notes")
+		http.Error(w, "failed to resolve notes", http.StatusInternalServerError)
+		return
+	}
+
+	id := IdentityFromContext(r.Context())
+	if r.Method != http.MethodGet {
+		if row == "" {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if id == nil {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+	}
+	now := time.Now()
+	var note notepb.Note
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		buf, err := ioutil.ReadAll(io.LimitReader(r.Body, maxNoteRequestBytes))
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+		if err := Unmarshal(r.Header.Get("Content-Type"), buf, &note); err != nil {
+			http.Error(w, "request must be a Note", http.StatusBadRequest)
+			return
+		}
+		note.Row = row
+		if err := notes.Validate(&note); err != nil {
+			http.Error(w, "invalid note: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	noteID := r.URL.Query().Get("note")
+
+	var update func(*notepb.NoteState) bool
+	var missing bool
+	switch r.Method {
+	case http.MethodGet:
+		update = func(*notepb.NoteState) bool { return false }
+	case http.MethodPost:
+		update = func(state *notepb.NoteState) bool {
+			notes.Add(state, &note, id.String(), now)
+			return true
+		}
+	case http.MethodPut, http.MethodDelete:
+		if noteID == "" {
+			http.Error(w, "missing note parameter", http.StatusBadRequest)
+			return
+		}
+		log = log.WithField("note", noteID)
+		update = func(state *notepb.NoteState) bool {
+			if r.Method == http.MethodPut {
+				missing = notes.Edit(state, row, noteID, note.Text, id.String(), now) == nil
+			} else {
+				missing = !notes.Delete(state, row, noteID, id.String(), now)
+			}
+			return !missing
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	state, err := notes.Update(r.Context(), s.Notes, *path, update)
+	if err != nil {
+		log.WithError(err).Warning("Failed to update notes")
+		http.Error(w, "failed to update notes", http.StatusInternalServerError)
+		return
+	}
+	if missing {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		log.WithFields(logrus.Fields{
+			"method":   r.Method,
+			"identity": id.String(),
+		}).Info("Updated notes")
+	}
+	var out notepb.NoteState
+	for _, n := range state.Notes {
+		if !n.Deleted && (row == "" || n.Row == row) {
+			out.Notes = append(out.Notes, n)
+		}
+	}
+	if err := Write(w, r, &out); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// addNotes adds the notes about each row of the batch, when notes are enabled.
+func (s *Server) addNotes(ctx context.Context, log logrus.FieldLogger, dashboard, tab string, batch *responsepb.RowBatch) {
+	if s.Notes == nil {
+		return
+	}
+	path, err := s.notesPath(dashboard, tab)
+	if err != nil {
+		log.WithError(err).Warning("Failed to resolve notes")
+		return
+	}
+	state, _, err := notes.Read(ctx, s.Notes, *path)
+	if err != nil {
+		log.WithError(err).Warning("Failed to read notes")
+		return
+	}
+	live := notes.Live(state)
+	for _, row := range batch.Rows {
+		row.Notes = live[row.Name]
+	}
+}
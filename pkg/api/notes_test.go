@@ -0,0 +1,348 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	notepb "github.com/GoogleCloudPlatform/testgrid/pb/notes"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestServeNotes(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	notesPath, err := gcs.NewPath("gs://bucket/grid/group.notes")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	existing := &notepb.NoteState{
+		Notes: []*notepb.Note{
+			{Id: "1", Row: "foo", Text: "flake", Author: "alice@example.com"},
+			{Id: "2", Row: "bar", Text: "broken", Author: "alice@example.com"},
+			{Id: "3", Row: "foo", Author: "bob@example.com", Deleted: true},
+		},
+	}
+	buf, err := proto.Marshal(existing)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	reader := tabs.Reader{
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name: "dash",
+					DashboardTab: []*configpb.DashboardTab{
+						{Name: "tab", TestGroupName: "group"},
+					},
+				},
+			},
+		},
+		ConfigPath: *configPath,
+		GridPrefix: "grid",
+	}
+	carol := &Identity{Email: "carol@example.com", Method: "oidc"}
+	// Times differ between runs.
+	clearTimes := func(state *notepb.NoteState) {
+		for _, n := range state.Notes {
+			n.Created, n.Modified = 0, 0
+			for _, rev := range n.History {
+				rev.Modified = 0
+			}
+		}
+	}
+
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		id       *Identity
+		want     int
+		wantResp *notepb.NoteState
+		wantSave *notepb.NoteState
+	}{
+		{
+			name:     "list row",
+			method:   http.MethodGet,
+			path:     RowPath("dash", "tab", "foo", NotesResource),
+			want:     http.StatusOK,
+			wantResp: &notepb.NoteState{Notes: existing.Notes[:1]},
+		},
+		{
+			name:     "list tab",
+			method:   http.MethodGet,
+			path:     TabPath("dash", "tab", NotesResource),
+			want:     http.StatusOK,
+			wantResp: &notepb.NoteState{Notes: existing.Notes[:2]},
+		},
+		{
+			name:   "add",
+			method: http.MethodPost,
+			path:   RowPath("dash", "tab", "foo", NotesResource),
+			body:   `{"text": "infra outage", "first_build": "10", "last_build": "12"}`,
+			id:     carol,
+			want:   http.StatusOK,
+			wantResp: &notepb.NoteState{Notes: []*notepb.Note{
+				existing.Notes[0],
+				{Id: "4", Row: "foo", FirstBuild: "10", LastBuild: "12", Text: "infra outage", Author: "carol@example.com"},
+			}},
+			wantSave: &notepb.NoteState{Notes: append(existing.Notes[:3:3],
+				&notepb.Note{Id: "4", Row: "foo", FirstBuild: "10", LastBuild: "12", Text: "infra outage", Author: "carol@example.com"},
+			)},
+		},
+		{
+			name:   "add anonymously",
+			method: http.MethodPost,
+			path:   RowPath("dash", "tab", "foo", NotesResource),
+			body:   `{"text": "hello"}`,
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "add without text",
+			method: http.MethodPost,
+			path:   RowPath("dash", "tab", "foo", NotesResource),
+			body:   `{}`,
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "add to a tab",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", NotesResource),
+			body:   `{"text": "hello"}`,
+			id:     carol,
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "edit",
+			method: http.MethodPut,
+			path:   RowPath("dash", "tab", "foo", NotesResource) + "?note=1",
+			body:   `{"text": "known flake"}`,
+			id:     carol,
+			want:   http.StatusOK,
+			wantResp: &notepb.NoteState{Notes: []*notepb.Note{
+				{
+					Id:      "1",
+					Row:     "foo",
+					Text:    "known flake",
+					Author:  "carol@example.com",
+					History: []*notepb.NoteRevision{{Text: "flake", Author: "alice@example.com"}},
+				},
+			}},
+			wantSave: &notepb.NoteState{Notes: []*notepb.Note{
+				{
+					Id:      "1",
+					Row:     "foo",
+					Text:    "known flake",
+					Author:  "carol@example.com",
+					History: []*notepb.NoteRevision{{Text: "flake", Author: "alice@example.com"}},
+				},
+				existing.Notes[1],
+				existing.Notes[2],
+			}},
+		},
+		{
+			name:   "edit another row's note",
+			method: http.MethodPut,
+			path:   RowPath("dash", "tab", "foo", NotesResource) + "?note=2",
+			body:   `{"text": "mine now"}`,
+			id:     carol,
+			want:   http.StatusNotFound,
+		},
+		{
+			name:     "delete",
+			method:   http.MethodDelete,
+			path:     RowPath("dash", "tab", "bar", NotesResource) + "?note=2",
+			id:       carol,
+			want:     http.StatusOK,
+			wantResp: &notepb.NoteState{},
+			wantSave: &notepb.NoteState{Notes: []*notepb.Note{
+				existing.Notes[0],
+				{
+					Id:      "2",
+					Row:     "bar",
+					Author:  "carol@example.com",
+					Deleted: true,
+					History: []*notepb.NoteRevision{{Text: "broken", Author: "alice@example.com"}},
+				},
+				existing.Notes[2],
+			}},
+		},
+		{
+			name:   "delete without a note",
+			method: http.MethodDelete,
+			path:   RowPath("dash", "tab", "bar", NotesResource),
+			id:     carol,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "notes of unknown tab",
+			method: http.MethodGet,
+			path:   RowPath("dash", "missing", "foo", NotesResource),
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client: fake.Client{Opener: fake.Opener{
+						*notesPath: {Data: string(buf)},
+					}},
+					Uploader: fake.Uploader{},
+					Stater: fake.Stater{
+						*notesPath: {Attrs: storage.ObjectAttrs{Generation: 1}},
+					},
+				},
+			}
+			s := Server{Reader: reader, Notes: client}
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", ContentTypeJSON)
+			if tc.id != nil {
+				r = r.WithContext(context.WithValue(r.Context(), identityKey{}, tc.id))
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP(%s %s) got %d, want %d: %s", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+			}
+			if tc.wantResp != nil {
+				var got notepb.NoteState
+				if err := Unmarshal(ContentTypeJSON, w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("Unmarshal(): %v", err)
+				}
+				clearTimes(&got)
+				if diff := cmp.Diff(tc.wantResp, &got, protocmp.Transform()); diff != "" {
+					t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			var gotSave *notepb.NoteState
+			if u, ok := client.Uploader[*notesPath]; ok {
+				gotSave = &notepb.NoteState{}
+				if err := proto.Unmarshal(u.Buf, gotSave); err != nil {
+					t.Fatalf("proto.Unmarshal(): %v", err)
+				}
+				clearTimes(gotSave)
+			}
+			if diff := cmp.Diff(tc.wantSave, gotSave, protocmp.Transform()); diff != "" {
+				t.Errorf("ServeHTTP() saved unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServeCellsWithNotes(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	gridPath, err := gcs.NewPath("gs://bucket/grid/group")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	notesPath, err := gcs.NewPath("gs://bucket/grid/group.notes")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	gridBuf, err := gcs.MarshalGrid(&statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "a", Results: []int32{pass, 1}, Messages: []string{""}},
+			{Name: "b", Results: []int32{pass, 1}, Messages: []string{""}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	note := &notepb.Note{Id: "1", Row: "b", FirstBuild: "1", LastBuild: "1", Text: "flake"}
+	notesBuf, err := proto.Marshal(&notepb.NoteState{Notes: []*notepb.Note{note}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{
+				*summaryPath: {},
+				*gridPath:    {Data: string(gridBuf)},
+			},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+					},
+				},
+			},
+			ConfigPath:    *configPath,
+			GridPrefix:    "grid",
+			SummaryPrefix: "summary",
+		},
+		Notes: fake.ConditionalClient{
+			UploadClient: fake.UploadClient{
+				Client:   fake.Client{Opener: fake.Opener{*notesPath: {Data: string(notesBuf)}}},
+				Uploader: fake.Uploader{},
+				Stater:   fake.Stater{*notesPath: {Attrs: storage.ObjectAttrs{Generation: 1}}},
+			},
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, TabPath("dash", "tab", CellsResource), nil)
+	r.Header.Set("Accept", ContentTypeProto)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var got responsepb.RowBatch
+	if err := proto.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := &responsepb.RowBatch{
+		TotalRows: 2,
+		Rows: []*responsepb.RowCells{
+			{Name: "a", Results: []int32{pass, 1}, Messages: []string{""}},
+			{Name: "b", Results: []int32{pass, 1}, Messages: []string{""}, Notes: []*notepb.Note{note}},
+		},
+	}
+	if diff := cmp.Diff(want, &got, protocmp.Transform()); diff != "" {
+		t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
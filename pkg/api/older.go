@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+)
+
+// serveOlder serves an ephemeral grid of the builds of a tab's test group
+// which are older than the before query parameter, read on demand.
+//
+// Before defaults to the oldest column of the tab. The columns query
+// parameter limits the number of builds. The oldest column counts the builds
+// which remain in its older_builds, so clients load the next page by passing
+// its hint as before.
+func (s *Server) serveOlder(w http.ResponseWriter, r *http.Request, dashboard, tab string) {
+	if s.Older == nil {
+		http.Error(w, "older results are disabled", http.StatusNotImplemented)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var columns int
+	if v := r.URL.Query().Get("columns"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "columns must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		columns = n
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+		"resource":  OlderResource,
+	})
+	group, err := s.Reader.TestGroup(dashboard, tab)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to find test group")
+		http.Error(w, "failed to read tab", http.StatusInternalServerError)
+		return
+	}
+
+	before := r.URL.Query().Get("before")
+	if before == "" {
+		req := tabs.Request{Dashboard: dashboard, Tab: tab, Columns: math.MaxInt32, FullHistory: true}
+		res := s.Reader.GetTabs(r.Context(), []tabs.Request{req})[0]
+		switch {
+		case errors.Is(res.Err, tabs.ErrNotFound):
+			http.NotFound(w, r)
+			return
+		case res.Err != nil:
+			log.WithError(res.Err).Warning("Failed to read tab")
+			http.Error(w, "failed to read tab", http.StatusInternalServerError)
+			return
+		}
+		if n := len(res.Grid.GetColumns()); n > 0 {
+			oldest := res.Grid.Columns[n-1]
+			before = oldest.Hint
+			if before == "" {
+				before = oldest.Build
+			}
+		}
+	}
+
+	grid, err := s.Older.Grid(r.Context(), group, before, columns)
+	if err != nil {
+		log.WithError(err).Warning("Failed to read older results")
+		http.Error(w, "failed to read older results", http.StatusInternalServerError)
+		return
+	}
+	if err := Write(w, r, grid); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
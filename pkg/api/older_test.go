@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestServeOlder(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q): %v", s, err)
+		}
+		return *p
+	}
+	gridBuf, err := gcs.MarshalGrid(&statepb.Grid{
+		Columns: []*statepb.Column{{Build: "4", Hint: "4", Started: 4000}, {Build: "3", Hint: "3", Started: 3000, OlderBuilds: 2}},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	now := time.Now().Unix()
+	client := fake.Client{
+		Lister: fake.Lister{},
+		Opener: fake.Opener{},
+	}
+	var builds fake.Iterator
+	for i := 1; i <= 4; i++ {
+		dir := fmt.Sprintf("logs/job/%d/", i)
+		builds.Objects = append(builds.Objects, storage.ObjectAttrs{Prefix: dir})
+		client.Opener[mustPath("gs://bucket/"+dir+"started.json")] = fake.Object{
+			Data: fmt.Sprintf(`{"timestamp": %d}`, now+int64(i)),
+		}
+		client.Opener[mustPath("gs://bucket/"+dir+"finished.json")] = fake.Object{
+			Data: fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+int64(i)+1),
+		}
+	}
+	client.Lister[mustPath("gs://bucket/logs/job/")] = builds
+	reader := tabs.Reader{
+		Client: fake.Opener{
+			mustPath("gs://bucket/summary/summary-dash"): {},
+			mustPath("gs://bucket/grid/group"):           {Data: string(gridBuf)},
+		},
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name:         "dash",
+					DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+				},
+			},
+			TestGroups: []*configpb.TestGroup{{Name: "group", GcsPrefix: "bucket/logs/job/"}},
+		},
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+	}
+	older := &tabs.OlderReader{Client: client}
+
+	cases := []struct {
+		name      string
+		older     *tabs.OlderReader
+		method    string
+		path      string
+		code      int
+		want      []string
+		wantOlder int32
+	}{
+		{
+			name: "disabled",
+			path: TabPath("dash", "tab", OlderResource),
+			code: http.StatusNotImplemented,
+		},
+		{
+			name:  "builds older than the tab",
+			older: older,
+			path:  TabPath("dash", "tab", OlderResource),
+			code:  http.StatusOK,
+			want:  []string{"2", "1"},
+		},
+		{
+			name:      "limit columns",
+			older:     older,
+			path:      TabPath("dash", "tab", OlderResource) + "?columns=1",
+			code:      http.StatusOK,
+			want:      []string{"2"},
+			wantOlder: 1,
+		},
+		{
+			name:  "before a build",
+			older: older,
+			path:  TabPath("dash", "tab", OlderResource) + "?before=2",
+			code:  http.StatusOK,
+			want:  []string{"1"},
+		},
+		{
+			name:  "missing tab",
+			older: older,
+			path:  TabPath("dash", "nope", OlderResource),
+			code:  http.StatusNotFound,
+		},
+		{
+			name:   "read only",
+			older:  older,
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", OlderResource),
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:  "bad columns",
+			older: older,
+			path:  TabPath("dash", "tab", OlderResource) + "?columns=zero",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.method == "" {
+				tc.method = http.MethodGet
+			}
+			s := Server{Reader: reader, Older: tc.older}
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			r.Header.Set("Accept", ContentTypeProto)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.code {
+				t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, tc.code, w.Body)
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+			var grid statepb.Grid
+			if err := proto.Unmarshal(w.Body.Bytes(), &grid); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			var got []string
+			var gotOlder int32
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+				gotOlder += col.OlderBuilds
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("ServeHTTP() got columns %v, want %v", got, tc.want)
+			}
+			if gotOlder != tc.wantOlder {
+				t.Errorf("ServeHTTP() got %d older builds, want %d", gotOlder, tc.wantOlder)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+)
+
+// PullsPrefix is the path under which the presubmit results of pull requests are served.
+const PullsPrefix = "/api/v1/pulls/"
+
+// PullPath returns the path to the grid of a pull request of the repo (such as org_repo).
+func PullPath(repo string, pull int) string {
+	return PullsPrefix + url.PathEscape(repo) + "/" + strconv.Itoa(pull) + "/" + GridResource
+}
+
+// parsePullPath returns the repo and pull request of a PullPath.
+func parsePullPath(escapedPath string) (string, int, bool) {
+	if !strings.HasPrefix(escapedPath, PullsPrefix) {
+		return "", 0, false
+	}
+	parts := strings.Split(strings.TrimPrefix(escapedPath, PullsPrefix), "/")
+	if len(parts) != 3 || parts[2] != GridResource {
+		return "", 0, false
+	}
+	repo, err := url.PathUnescape(parts[0])
+	if err != nil || repo == "" {
+		return "", 0, false
+	}
+	pull, err := strconv.Atoi(parts[1])
+	if err != nil || pull <= 0 {
+		return "", 0, false
+	}
+	return repo, pull, true
+}
+
+// servePull serves an ephemeral grid of the presubmit results of a pull request.
+//
+// Each column aggregates the runs of one commit of the pull request. The
+// columns query parameter limits the number of recent commits.
+func (s *Server) servePull(w http.ResponseWriter, r *http.Request, repo string, pull int) {
+	if s.Pulls == nil {
+		http.Error(w, "pull requests are disabled", http.StatusNotImplemented)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var columns int
+	if v := r.URL.Query().Get("columns"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "columns must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		columns = n
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"repo": repo,
+		"pull": pull,
+	})
+	grid, err := s.Pulls.Grid(r.Context(), repo, pull)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to read pull request")
+		http.Error(w, "failed to read pull request", http.StatusInternalServerError)
+		return
+	}
+	if columns > 0 && columns < len(grid.Columns) {
+		grid = state.RecentColumns(grid, columns)
+	}
+	if err := Write(w, r, grid); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
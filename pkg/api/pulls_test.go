@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestParsePullPath(t *testing.T) {
+	repo, pull, ok := parsePullPath(PullPath("org_repo", 123))
+	if !ok || repo != "org_repo" || pull != 123 {
+		t.Errorf("parsePullPath(PullPath()) got %q, %d, %t, want org_repo, 123, true", repo, pull, ok)
+	}
+	for _, p := range []string{
+		PullsPrefix,
+		PullsPrefix + "org_repo/123",
+		PullsPrefix + "org_repo/abc/grid",
+		PullsPrefix + "org_repo/-1/grid",
+		PullsPrefix + "/123/grid",
+		PullsPrefix + "org_repo/123/summary",
+	} {
+		if _, _, ok := parsePullPath(p); ok {
+			t.Errorf("parsePullPath(%q) unexpectedly succeeded", p)
+		}
+	}
+}
+
+func TestServePull(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q): %v", s, err)
+		}
+		return *p
+	}
+	now := time.Now().Unix()
+	client := fake.Client{
+		Lister: fake.Lister{
+			mustPath("gs://bucket/pr-logs/pull/org_repo/123/"): fake.Iterator{
+				Objects: []storage.ObjectAttrs{{Prefix: "pr-logs/pull/org_repo/123/job/"}},
+			},
+			mustPath("gs://bucket/pr-logs/pull/org_repo/123/job/"): fake.Iterator{
+				Objects: []storage.ObjectAttrs{{Prefix: "pr-logs/pull/org_repo/123/job/1/"}},
+			},
+		},
+		Opener: fake.Opener{
+			mustPath("gs://bucket/pr-logs/pull/org_repo/123/job/1/started.json"): {
+				Data: fmt.Sprintf(`{"timestamp": %d, "pull": "main:abc,123:deadbeef"}`, now),
+			},
+			mustPath("gs://bucket/pr-logs/pull/org_repo/123/job/1/finished.json"): {
+				Data: fmt.Sprintf(`{"timestamp": %d, "passed": true}`, now+1),
+			},
+		},
+	}
+	pulls := &tabs.PullReader{
+		Client: client,
+		Prefix: mustPath("gs://bucket/pr-logs/pull/"),
+	}
+
+	cases := []struct {
+		name   string
+		pulls  *tabs.PullReader
+		method string
+		path   string
+		code   int
+		want   []string
+	}{
+		{
+			name: "disabled",
+			path: PullPath("org_repo", 123),
+			code: http.StatusNotImplemented,
+		},
+		{
+			name:  "serve the grid",
+			pulls: pulls,
+			path:  PullPath("org_repo", 123),
+			code:  http.StatusOK,
+			want:  []string{"deadbeef"},
+		},
+		{
+			name:  "missing pull",
+			pulls: pulls,
+			path:  PullPath("org_repo", 456),
+			code:  http.StatusNotFound,
+		},
+		{
+			name:   "read only",
+			pulls:  pulls,
+			method: http.MethodPost,
+			path:   PullPath("org_repo", 123),
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:  "bad columns",
+			pulls: pulls,
+			path:  PullPath("org_repo", 123) + "?columns=zero",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.method == "" {
+				tc.method = http.MethodGet
+			}
+			s := Server{Pulls: tc.pulls}
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			r.Header.Set("Accept", ContentTypeProto)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.code {
+				t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, tc.code, w.Body)
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+			var grid statepb.Grid
+			if err := proto.Unmarshal(w.Body.Bytes(), &grid); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			var got []string
+			for _, col := range grid.Columns {
+				got = append(got, col.Build)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("ServeHTTP() got columns %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
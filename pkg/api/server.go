@@ -0,0 +1,731 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const (
+	// DashboardsPrefix is the path under which dashboards are served.
+	DashboardsPrefix = "/api/v1/dashboards/"
+
+	// SummaryResource is the tab resource serving a DashboardTabSummary.
+	SummaryResource = "summary"
+	// GridResource is the tab resource serving the recent columns of the tab's Grid.
+	GridResource = "grid"
+	// MessagesResource is the row resource serving its MessageHistory.
+	MessagesResource = "messages"
+	// ParametersResource is the row resource serving the CellParameters of the
+	// cell of the build specified by the build query parameter.
+	ParametersResource = "parameters"
+	// IssuesResource is the row resource serving the issues associated with it.
+	IssuesResource = "issues"
+	// NotesResource is the row resource serving the triage notes about it, and
+	// the tab resource serving the notes about each of its rows.
+	NotesResource = "notes"
+	// ColumnsResource is the tab resource serving its ColumnList, or the ColumnDetail of a build.
+	ColumnsResource = "columns"
+	// WarmResource is the dashboard resource which reads its tabs into the cache.
+	WarmResource = "warm"
+	// FeedResource is the dashboard resource serving the HealthFeed of its
+	// tabs, or an RSS or Atom feed of it.
+	FeedResource = "feed"
+	// CalendarResource is the dashboard resource serving an iCalendar feed of
+	// reminders to review its tabs which have failed or been stale for too long.
+	CalendarResource = "calendar"
+	// RowsResource is the tab resource serving the RowIndex of its recent columns.
+	RowsResource = "rows"
+	// CellsResource is the tab resource serving a RowBatch of its recent columns.
+	CellsResource = "cells"
+	// CollisionsResource is the tab resource serving the ColumnList of columns
+	// which merged several builds, when the test group sets strict_columns.
+	CollisionsResource = "collisions"
+	// FullMessagesResource is the tab resource serving the FullMessage of a hash.
+	FullMessagesResource = "full_messages"
+	// StatsResource is the tab resource serving the RowStatsList computed by the
+	// summarizer over the tab's row_stats_days, and the row resource serving its RowStats.
+	StatsResource = "stats"
+	// CompareResource is the tab resource serving the TabComparison of the tab
+	// against the base tab of its dashboard and tab query parameters, as of the
+	// RFC 3339 time of its before query parameter when set.
+	CompareResource = "compare"
+	// TransitionsResource is the tab resource serving the TransitionFeed of its
+	// recent columns, or an RSS feed of it.
+	TransitionsResource = "transitions"
+	// OlderResource is the tab resource serving a Grid of the builds older
+	// than the tab's columns, read on demand.
+	OlderResource = "older"
+	// AlertsResource is the dashboard resource which snoozes or acknowledges its alerts.
+	AlertsResource = "alerts"
+	// ColumnEditsResource is the tab resource serving the ColumnEdits awaiting
+	// the next update of its test group.
+	ColumnEditsResource = "column_edits"
+
+	defaultColumns = 50
+	defaultRows    = 100
+	maxRows        = 1000
+)
+
+// TabPath returns the path to the resource of the dashboard tab.
+func TabPath(dashboard, tab, resource string) string {
+	return DashboardsPrefix + url.PathEscape(dashboard) + "/tabs/" + url.PathEscape(tab) + "/" + resource
+}
+
+// RowPath returns the path to the resource of a row in the dashboard tab.
+func RowPath(dashboard, tab, row, resource string) string {
+	return TabPath(dashboard, tab, "rows/"+url.PathEscape(row)+"/"+resource)
+}
+
+// WarmPath returns the path which warms the cache with the dashboard's tabs.
+func WarmPath(dashboard string) string {
+	return DashboardsPrefix + url.PathEscape(dashboard) + "/" + WarmResource
+}
+
+// FeedPath returns the path to the HealthFeed of the dashboard.
+func FeedPath(dashboard string) string {
+	return DashboardsPrefix + url.PathEscape(dashboard) + "/" + FeedResource
+}
+
+// AlertPath returns the path which snoozes or acknowledges an alert of the dashboard.
+func AlertPath(dashboard, alertID string) string {
+	return DashboardsPrefix + url.PathEscape(dashboard) + "/" + AlertsResource + "/" + url.PathEscape(alertID)
+}
+
+// parseAlertPath returns the dashboard and alert ID of an AlertPath.
+func parseAlertPath(escapedPath string) (string, string, bool) {
+	if !strings.HasPrefix(escapedPath, DashboardsPrefix) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(escapedPath, DashboardsPrefix), "/")
+	if len(parts) != 3 || parts[1] != AlertsResource {
+		return "", "", false
+	}
+	dashboard, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return "", "", false
+	}
+	alertID, err := url.PathUnescape(parts[2])
+	if err != nil || alertID == "" {
+		return "", "", false
+	}
+	return dashboard, alertID, true
+}
+
+// parseDashboardPath returns the dashboard of the path to its resource.
+func parseDashboardPath(escapedPath, resource string) (string, bool) {
+	if !strings.HasPrefix(escapedPath, DashboardsPrefix) {
+		return "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(escapedPath, DashboardsPrefix), "/")
+	if len(parts) != 2 || parts[1] != resource {
+		return "", false
+	}
+	dashboard, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return "", false
+	}
+	return dashboard, true
+}
+
+// ColumnPath returns the path to the detail of a build's column in the dashboard tab.
+func ColumnPath(dashboard, tab, build string) string {
+	return TabPath(dashboard, tab, ColumnsResource+"/"+url.PathEscape(build))
+}
+
+// FullMessagePath returns the path to the untruncated cell message with the hash in the dashboard tab.
+func FullMessagePath(dashboard, tab, hash string) string {
+	return TabPath(dashboard, tab, FullMessagesResource+"/"+url.PathEscape(hash))
+}
+
+// parseTabPath returns the dashboard, tab and (escaped) resource of a TabPath.
+func parseTabPath(escapedPath string) (string, string, string, bool) {
+	if !strings.HasPrefix(escapedPath, DashboardsPrefix) {
+		return "", "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(escapedPath, DashboardsPrefix), "/")
+	if len(parts) < 4 || parts[1] != "tabs" {
+		return "", "", "", false
+	}
+	dashboard, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return "", "", "", false
+	}
+	tab, err := url.PathUnescape(parts[2])
+	if err != nil {
+		return "", "", "", false
+	}
+	return dashboard, tab, strings.Join(parts[3:], "/"), true
+}
+
+// parseRowResource returns the row and resource of a RowPath's tab resource.
+func parseRowResource(resource string) (string, string, bool) {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 3 || parts[0] != "rows" {
+		return "", "", false
+	}
+	row, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return "", "", false
+	}
+	return row, parts[2], true
+}
+
+// parseColumnResource returns the build of a ColumnPath's tab resource.
+func parseColumnResource(resource string) (string, bool) {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 2 || parts[0] != ColumnsResource || parts[1] == "" {
+		return "", false
+	}
+	build, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return "", false
+	}
+	return build, true
+}
+
+// parseFullMessageResource returns the hash of a FullMessagePath's tab resource.
+func parseFullMessageResource(resource string) (string, bool) {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 2 || parts[0] != FullMessagesResource || parts[1] == "" {
+		return "", false
+	}
+	hash, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return "", false
+	}
+	return hash, true
+}
+
+// Server serves dashboard tab summaries and grids.
+type Server struct {
+	Reader tabs.Reader
+	// Issues reads and writes issue associations, which are disabled when nil.
+	Issues gcs.ConditionalClient
+	// Notes reads and writes triage notes, which are disabled when nil.
+	Notes gcs.ConditionalClient
+	// Pulls reads the presubmit results of pull requests, which are disabled when nil.
+	Pulls *tabs.PullReader
+	// Older reads the builds older than a tab's columns, which are disabled when nil.
+	Older *tabs.OlderReader
+	// Alerts snoozes and acknowledges the alerts of dashboard summaries, which
+	// are disabled when nil.
+	Alerts gcs.ConditionalClient
+	// ColumnEdits reads and writes requests to delete or reprocess columns,
+	// which are disabled when nil.
+	ColumnEdits gcs.ConditionalClient
+	// CostReport adds the storage cost metrics of the report at this path to
+	// MetricsPath if set.
+	CostReport *gcs.Path
+	Log        logrus.FieldLogger
+
+	now func() time.Time
+}
+
+// listDashboards returns the dashboards of the configuration, their tabs and display options.
+func listDashboards(cfg *configpb.Configuration) *responsepb.DashboardList {
+	var list responsepb.DashboardList
+	for _, d := range cfg.GetDashboards() {
+		listing := responsepb.DashboardListing{Name: d.Name, DisplayOptions: d.DisplayOptions}
+		for _, tab := range d.DashboardTab {
+			listing.Tabs = append(listing.Tabs, tab.Name)
+		}
+		list.Dashboards = append(list.Dashboards, &listing)
+	}
+	sort.SliceStable(list.Dashboards, func(i, j int) bool {
+		return list.Dashboards[i].Name < list.Dashboards[j].Name
+	})
+	return &list
+}
+
+// isDashboardsPath returns true when the path lists dashboards.
+func isDashboardsPath(escapedPath string) bool {
+	return escapedPath == DashboardsPrefix || escapedPath == strings.TrimSuffix(DashboardsPrefix, "/")
+}
+
+// ServeHTTP serves the DashboardList at DashboardsPrefix, WarmPath, FeedPath, CalendarPath, AlertPath, PullPath, TabsPath as well as TabPath, RowPath, ColumnPath and FullMessagePath resources.
+// It also serves alert metrics at AlertMetricsPath and, for Prometheus, at
+// MetricsPath, along with a Grafana JSON datasource under GrafanaPrefix.
+//
+// Grid, column list, row, cell, message and transition requests accept a
+// columns query parameter limiting the number of recent columns. These are
+// limited to the tab's column window unless the full_history query parameter is
+// true. Column requests accept a name query parameter to select between columns
+// of the same build. Collision requests list every column which merged several builds.
+//
+// Transition and feed requests list the latest rows to start failing or
+// passing and the latest health events of the dashboard, respectively, up to
+// the max query parameter. They are served as RSS or Atom when the format query
+// parameter is rss or atom, or the Accept header prefers either.
+//
+// Older requests read a page of the builds older than the tab's columns, such
+// as those which started before its test group's days_of_results, up to the
+// columns query parameter and before the build of the before query parameter.
+// The oldest column of each grid counts the builds older than itself.
+//
+// Column edit requests delete or reprocess the column of a build, such as
+// one whose artifacts changed after the updater read them, during the next
+// update of the tab's test group.
+//
+// Batch requests at TabsPath read the summary of each tab query parameter,
+// along with the recent columns of its grid when the columns query parameter
+// is positive, so landing pages and bots read many tabs in one request.
+//
+// Alert requests snooze an alert of the dashboard for the hours query
+// parameter when the action query parameter is snooze, or acknowledge it until
+// it changes when the action is acknowledge.
+//
+// Calendar requests remind teams to review tabs which have failed or been stale
+// for at least the hours query parameter, 24 by default.
+//
+// Large tabs may be loaded lazily: first read the columns resource and the
+// rows resource, which summarizes each row, then read the cells resource for
+// the rows from its start query parameter up to its end parameter. Cells
+// include the triage notes about each row when notes are enabled.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.EscapedPath() {
+	case AlertMetricsPath:
+		s.serveAlertMetrics(w, r)
+		return
+	case MetricsPath:
+		s.serveMetrics(w, r)
+		return
+	case TabsPath:
+		s.serveBatch(w, r)
+		return
+	}
+	if isGrafanaPath(r.URL.EscapedPath()) {
+		s.serveGrafana(w, r)
+		return
+	}
+	if isDashboardsPath(r.URL.EscapedPath()) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := Write(w, r, listDashboards(s.Reader.Config)); err != nil {
+			s.log().WithError(err).Warning("Failed to write response")
+		}
+		return
+	}
+	if dashboard, ok := parseDashboardPath(r.URL.EscapedPath(), WarmResource); ok {
+		s.serveWarm(w, r, dashboard)
+		return
+	}
+	if dashboard, ok := parseDashboardPath(r.URL.EscapedPath(), FeedResource); ok {
+		s.serveFeed(w, r, dashboard)
+		return
+	}
+	if dashboard, ok := parseDashboardPath(r.URL.EscapedPath(), CalendarResource); ok {
+		s.serveCalendar(w, r, dashboard)
+		return
+	}
+	if dashboard, alertID, ok := parseAlertPath(r.URL.EscapedPath()); ok {
+		s.serveAlert(w, r, dashboard, alertID)
+		return
+	}
+	if repo, pull, ok := parsePullPath(r.URL.EscapedPath()); ok {
+		s.servePull(w, r, repo, pull)
+		return
+	}
+	dashboard, tab, resource, ok := parseTabPath(r.URL.EscapedPath())
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if build, ok := parseColumnResource(resource); ok {
+		s.serveColumn(w, r, dashboard, tab, build)
+		return
+	}
+	if resource == OlderResource {
+		s.serveOlder(w, r, dashboard, tab)
+		return
+	}
+	if resource == ColumnEditsResource {
+		s.serveColumnEdits(w, r, dashboard, tab)
+		return
+	}
+	if hash, ok := parseFullMessageResource(resource); ok {
+		s.serveFullMessage(w, r, dashboard, tab, hash)
+		return
+	}
+	if resource == CompareResource {
+		s.serveCompare(w, r, dashboard, tab)
+		return
+	}
+	if resource == NotesResource {
+		s.serveNotes(w, r, dashboard, tab, "")
+		return
+	}
+	var row string
+	if name, rowResource, ok := parseRowResource(resource); ok {
+		switch rowResource {
+		case IssuesResource:
+			s.serveIssues(w, r, dashboard, tab, name)
+			return
+		case NotesResource:
+			s.serveNotes(w, r, dashboard, tab, name)
+			return
+		case MessagesResource, StatsResource, ParametersResource:
+			row, resource = name, rowResource
+		}
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req := tabs.Request{Dashboard: dashboard, Tab: tab}
+	var limit int
+	var build string
+	switch resource {
+	case SummaryResource, StatsResource:
+	case GridResource, MessagesResource, ParametersResource, ColumnsResource, RowsResource, CellsResource, CollisionsResource, TransitionsResource:
+		req.Columns = defaultColumns
+		if resource == MessagesResource || resource == ParametersResource || resource == CollisionsResource {
+			req.Columns = math.MaxInt32
+			req.FullHistory = true
+		}
+		if v := r.URL.Query().Get("full_history"); v != "" {
+			full, err := strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, "full_history must be a boolean", http.StatusBadRequest)
+				return
+			}
+			req.FullHistory = req.FullHistory || full
+		}
+		if v := r.URL.Query().Get("columns"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				http.Error(w, "columns must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			req.Columns = n
+		}
+		if resource == CellsResource {
+			start, end, err := rowRange(r.URL.Query())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			req.RowStart, req.RowEnd = start, end
+		}
+		if resource == ParametersResource {
+			if build = r.URL.Query().Get("build"); build == "" {
+				http.Error(w, "build is required", http.StatusBadRequest)
+				return
+			}
+		}
+		if resource == TransitionsResource {
+			n, err := feedLimit(r.URL.Query())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+		"resource":  resource,
+	})
+	res := s.Reader.GetTabs(r.Context(), []tabs.Request{req})[0]
+	switch {
+	case errors.Is(res.Err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case res.Err != nil:
+		log.WithError(res.Err).Warning("Failed to read tab")
+		http.Error(w, "failed to read tab", http.StatusInternalServerError)
+		return
+	}
+
+	var err error
+	switch resource {
+	case SummaryResource:
+		if res.Summary == nil {
+			http.NotFound(w, r)
+			return
+		}
+		err = Write(w, r, res.Summary)
+	case StatsResource:
+		list := rowStats(res.Summary, row)
+		if list == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if row != "" {
+			err = Write(w, r, list.Rows[0])
+			break
+		}
+		err = Write(w, r, list)
+	case GridResource:
+		err = Write(w, r, res.Grid)
+	case ColumnsResource:
+		err = Write(w, r, s.Reader.Columns(res))
+	case CollisionsResource:
+		err = Write(w, r, s.Reader.Collisions(res))
+	case RowsResource:
+		err = Write(w, r, tabs.RowIndex(res.Grid))
+	case CellsResource:
+		batch := tabs.RowBatch(res.Grid, req.RowStart, res.TotalRows)
+		s.addNotes(r.Context(), log, dashboard, tab, batch)
+		err = Write(w, r, batch)
+	case MessagesResource:
+		history := tabs.MessageHistory(res.Grid, row)
+		if history == nil {
+			http.NotFound(w, r)
+			return
+		}
+		err = Write(w, r, history)
+	case ParametersResource:
+		params := tabs.CellParameters(res.Grid, row, build)
+		if params == nil {
+			http.NotFound(w, r)
+			return
+		}
+		err = Write(w, r, params)
+	case TransitionsResource:
+		err = serveTransitions(w, r, dashboard, tab, res.Grid, limit)
+	}
+	if err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// rowStats returns the statistics of the summary's rows, or only of the named
+// row unless it is empty, or nil when the summary or row has none.
+func rowStats(sum *summarypb.DashboardTabSummary, row string) *responsepb.RowStatsList {
+	if sum == nil {
+		return nil
+	}
+	if row == "" {
+		if len(sum.RowStats) == 0 {
+			return nil
+		}
+		return &responsepb.RowStatsList{Rows: sum.RowStats}
+	}
+	for _, stats := range sum.RowStats {
+		if stats.DisplayName == row {
+			return &responsepb.RowStatsList{Rows: []*summarypb.RowStats{stats}}
+		}
+	}
+	return nil
+}
+
+// rowRange returns the rows selected by the start and end query parameters.
+//
+// Ranges default to the first defaultRows rows and may not exceed maxRows.
+func rowRange(query url.Values) (int, int, error) {
+	var start int
+	if v := query.Get("start"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("start must be a non-negative integer")
+		}
+		start = n
+	}
+	end := start + defaultRows
+	if v := query.Get("end"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= start {
+			return 0, 0, errors.New("end must be an integer greater than start")
+		}
+		end = n
+	}
+	if end-start > maxRows {
+		return 0, 0, fmt.Errorf("at most %d rows may be requested", maxRows)
+	}
+	return start, end, nil
+}
+
+// serveWarm reads the tabs of the dashboard into the cache and serves a CacheWarm report.
+//
+// Readers may warm the cache, so GET is allowed along with POST. The
+// full_history query parameter also warms the test group state of tabulated tabs.
+func (s *Server) serveWarm(w http.ResponseWriter, r *http.Request, dashboard string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Reader.Cache == nil {
+		http.Error(w, "cache disabled", http.StatusNotImplemented)
+		return
+	}
+	var full bool
+	if v := r.URL.Query().Get("full_history"); v != "" {
+		var err error
+		if full, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "full_history must be a boolean", http.StatusBadRequest)
+			return
+		}
+	}
+	log := s.log().WithField("dashboard", dashboard)
+	warm, err := s.Reader.Warm(r.Context(), dashboard, full)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to warm cache")
+		http.Error(w, "failed to warm cache", http.StatusInternalServerError)
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"tabs":   warm.Tabs,
+		"failed": len(warm.FailedTabs),
+	}).Debug("Warmed cache")
+	if err := Write(w, r, warm); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// serveColumn serves the ColumnDetail of the build in the dashboard tab.
+func (s *Server) serveColumn(w http.ResponseWriter, r *http.Request, dashboard, tab, build string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+		"build":     build,
+	})
+	detail, err := s.Reader.Column(r.Context(), dashboard, tab, build, r.URL.Query().Get("name"))
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to read column")
+		http.Error(w, "failed to read column", http.StatusInternalServerError)
+		return
+	}
+	if err := Write(w, r, detail); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// serveCompare serves the TabComparison of the dashboard tab against its base.
+//
+// The base defaults to the same dashboard and tab, which requires a before time.
+func (s *Server) serveCompare(w http.ResponseWriter, r *http.Request, dashboard, tab string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	baseDashboard, baseTab := query.Get("dashboard"), query.Get("tab")
+	if baseDashboard == "" {
+		baseDashboard = dashboard
+	}
+	if baseTab == "" {
+		baseTab = tab
+	}
+	var before time.Time
+	if v := query.Get("before"); v != "" {
+		var err error
+		if before, err = time.Parse(time.RFC3339, v); err != nil {
+			http.Error(w, "before must be an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+	}
+	if before.IsZero() && baseDashboard == dashboard && baseTab == tab {
+		http.Error(w, "compare requires a different base tab or a before time", http.StatusBadRequest)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard":      dashboard,
+		"tab":            tab,
+		"base-dashboard": baseDashboard,
+		"base-tab":       baseTab,
+		"before":         before,
+	})
+	comparison, err := s.Reader.Compare(r.Context(), baseDashboard, baseTab, before, dashboard, tab)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to compare tabs")
+		http.Error(w, "failed to compare tabs", http.StatusInternalServerError)
+		return
+	}
+	if err := Write(w, r, comparison); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+// serveFullMessage serves the FullMessage with the hash in the dashboard tab.
+func (s *Server) serveFullMessage(w http.ResponseWriter, r *http.Request, dashboard, tab, hash string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log := s.log().WithFields(logrus.Fields{
+		"dashboard": dashboard,
+		"tab":       tab,
+		"hash":      hash,
+	})
+	msg, err := s.Reader.FullMessage(r.Context(), dashboard, tab, hash)
+	switch {
+	case errors.Is(err, tabs.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		log.WithError(err).Warning("Failed to read full message")
+		http.Error(w, "failed to read full message", http.StatusInternalServerError)
+		return
+	}
+	if err := Write(w, r, msg); err != nil {
+		log.WithError(err).Warning("Failed to write response")
+	}
+}
+
+func (s *Server) clock() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+func (s *Server) log() logrus.FieldLogger {
+	if s.Log == nil {
+		return logrus.StandardLogger()
+	}
+	return s.Log
+}
@@ -0,0 +1,805 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	issuepb "github.com/GoogleCloudPlatform/testgrid/pb/issue_state"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestListDashboards(t *testing.T) {
+	display := &configpb.DisplayOptions{
+		DefaultSort: configpb.DisplayOptions_NAME,
+		Palette:     configpb.DisplayOptions_HIGH_CONTRAST,
+		Description: "Release *blocking* jobs",
+	}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{Name: "zeta", DashboardTab: []*configpb.DashboardTab{{Name: "tab"}}},
+			{Name: "alpha", DisplayOptions: display, DashboardTab: []*configpb.DashboardTab{{Name: "a"}, {Name: "b"}}},
+		},
+	}
+	want := &responsepb.DashboardList{
+		Dashboards: []*responsepb.DashboardListing{
+			{Name: "alpha", Tabs: []string{"a", "b"}, DisplayOptions: display},
+			{Name: "zeta", Tabs: []string{"tab"}},
+		},
+	}
+	if diff := cmp.Diff(want, listDashboards(cfg), protocmp.Transform()); diff != "" {
+		t.Errorf("listDashboards() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseTabPath(t *testing.T) {
+	path := TabPath("my/dash", "a tab", GridResource)
+	dashboard, tab, resource, ok := parseTabPath(path)
+	if !ok || dashboard != "my/dash" || tab != "a tab" || resource != GridResource {
+		t.Errorf("parseTabPath(%q) got %q, %q, %q, %t", path, dashboard, tab, resource, ok)
+	}
+	for _, bad := range []string{"/", "/api/v1/dashboards/dash", "/api/v1/dashboards/dash/foo/tab/grid"} {
+		if _, _, _, ok := parseTabPath(bad); ok {
+			t.Errorf("parseTabPath(%q) unexpectedly succeeded", bad)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	buf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "tab",
+				RowStats: []*summarypb.RowStats{
+					{DisplayName: "foo/bar", Windows: []*summarypb.RowWindowStats{{Days: 7, Runs: 1}}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	gridPath, err := gcs.NewPath("gs://bucket/grid/graded")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	gridBuf, err := proto.Marshal(&statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name:     "foo/bar",
+				Results:  []int32{int32(statuspb.TestStatus_FAIL), 1},
+				Messages: []string{"boom"},
+				CellParameters: []*statepb.CellParameters{
+					{Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: int32(statuspb.TestStatus_FAIL)}}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	zw.Write(gridBuf)
+	zw.Close()
+	storePath, err := gcs.NewPath("gs://bucket/grid/graded.messages")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	storeBuf, err := proto.Marshal(&statepb.MessageStore{
+		Messages: map[string]string{"abc": "boom and a very long stack trace"},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{
+				*summaryPath: {Data: string(buf)},
+				*gridPath:    {Data: zbuf.String()},
+				*storePath:   {Data: string(storeBuf)},
+			},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "dash",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "tab", TestGroupName: "group"},
+							{Name: "unsummarized", TestGroupName: "group"},
+							{Name: "graded", TestGroupName: "graded"},
+						},
+					},
+				},
+			},
+			ConfigPath:    *configPath,
+			GridPrefix:    "grid",
+			SummaryPrefix: "summary",
+		},
+	}
+
+	cases := []struct {
+		name        string
+		method      string
+		path        string
+		ifNoneMatch bool
+		want        int
+	}{
+		{
+			name: "dashboards",
+			path: DashboardsPrefix,
+			want: http.StatusOK,
+		},
+		{
+			name:   "bad dashboards method",
+			method: http.MethodPost,
+			path:   DashboardsPrefix,
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name: "summary",
+			path: TabPath("dash", "tab", SummaryResource),
+			want: http.StatusOK,
+		},
+		{
+			name:        "unchanged summary",
+			path:        TabPath("dash", "tab", SummaryResource),
+			ifNoneMatch: true,
+			want:        http.StatusNotModified,
+		},
+		{
+			name: "missing grid",
+			path: TabPath("dash", "tab", GridResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "messages",
+			path: RowPath("dash", "graded", "foo/bar", MessagesResource),
+			want: http.StatusOK,
+		},
+		{
+			name: "messages of missing row",
+			path: RowPath("dash", "graded", "missing", MessagesResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "parameters",
+			path: RowPath("dash", "graded", "foo/bar", ParametersResource) + "?build=1",
+			want: http.StatusOK,
+		},
+		{
+			name: "parameters of missing build",
+			path: RowPath("dash", "graded", "foo/bar", ParametersResource) + "?build=2",
+			want: http.StatusNotFound,
+		},
+		{
+			name: "parameters without a build",
+			path: RowPath("dash", "graded", "foo/bar", ParametersResource),
+			want: http.StatusBadRequest,
+		},
+		{
+			name:   "issues disabled",
+			method: http.MethodPost,
+			path:   RowPath("dash", "graded", "foo/bar", IssuesResource),
+			want:   http.StatusNotImplemented,
+		},
+		{
+			name: "unknown row resource",
+			path: RowPath("dash", "graded", "foo/bar", "foo"),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "column",
+			path: ColumnPath("dash", "graded", "1"),
+			want: http.StatusOK,
+		},
+		{
+			name: "missing column",
+			path: ColumnPath("dash", "graded", "2"),
+			want: http.StatusNotFound,
+		},
+		{
+			name:   "bad column method",
+			method: http.MethodPost,
+			path:   ColumnPath("dash", "graded", "1"),
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name: "full message",
+			path: FullMessagePath("dash", "graded", "abc"),
+			want: http.StatusOK,
+		},
+		{
+			name: "missing full message",
+			path: FullMessagePath("dash", "graded", "def"),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "full message without a store",
+			path: FullMessagePath("dash", "tab", "abc"),
+			want: http.StatusNotFound,
+		},
+		{
+			name:   "bad full message method",
+			method: http.MethodPost,
+			path:   FullMessagePath("dash", "graded", "abc"),
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name: "column list",
+			path: TabPath("dash", "graded", ColumnsResource),
+			want: http.StatusOK,
+		},
+		{
+			name: "collisions",
+			path: TabPath("dash", "graded", CollisionsResource),
+			want: http.StatusOK,
+		},
+		{
+			name: "row index",
+			path: TabPath("dash", "graded", RowsResource),
+			want: http.StatusOK,
+		},
+		{
+			name: "cells",
+			path: TabPath("dash", "graded", CellsResource) + "?start=0&end=10",
+			want: http.StatusOK,
+		},
+		{
+			name: "bad cell range",
+			path: TabPath("dash", "graded", CellsResource) + "?start=10&end=5",
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "bad columns",
+			path: TabPath("dash", "tab", GridResource) + "?columns=-1",
+			want: http.StatusBadRequest,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodPost,
+			path:   TabPath("dash", "tab", SummaryResource),
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name: "unknown resource",
+			path: TabPath("dash", "tab", "foo"),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "unknown tab",
+			path: TabPath("dash", "missing", SummaryResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "missing summary",
+			path: TabPath("dash", "unsummarized", SummaryResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "stats",
+			path: TabPath("dash", "tab", StatsResource),
+			want: http.StatusOK,
+		},
+		{
+			name: "row stats",
+			path: RowPath("dash", "tab", "foo/bar", StatsResource),
+			want: http.StatusOK,
+		},
+		{
+			name: "missing row stats",
+			path: RowPath("dash", "tab", "missing", StatsResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "missing stats",
+			path: TabPath("dash", "unsummarized", StatsResource),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "compare with an earlier time",
+			path: TabPath("dash", "graded", CompareResource) + "?before=2021-01-01T00:00:00Z",
+			want: http.StatusOK,
+		},
+		{
+			name: "compare without a base",
+			path: TabPath("dash", "graded", CompareResource),
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "compare with a bad time",
+			path: TabPath("dash", "graded", CompareResource) + "?before=yesterday",
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "compare with a missing tab",
+			path: TabPath("dash", "graded", CompareResource) + "?tab=missing",
+			want: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, tc.path, nil)
+			if tc.ifNoneMatch {
+				w := httptest.NewRecorder()
+				s.ServeHTTP(w, httptest.NewRequest(method, tc.path, nil))
+				r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Errorf("ServeHTTP(%s %s) got %d, want %d", method, tc.path, w.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestRowStats(t *testing.T) {
+	foo := &summarypb.RowStats{DisplayName: "foo", Windows: []*summarypb.RowWindowStats{{Days: 7, Runs: 2}}}
+	bar := &summarypb.RowStats{DisplayName: "bar", Windows: []*summarypb.RowWindowStats{{Days: 7}}}
+	sum := &summarypb.DashboardTabSummary{RowStats: []*summarypb.RowStats{foo, bar}}
+	cases := []struct {
+		name string
+		sum  *summarypb.DashboardTabSummary
+		row  string
+		want *responsepb.RowStatsList
+	}{
+		{
+			name: "missing summary",
+		},
+		{
+			name: "summary without stats",
+			sum:  &summarypb.DashboardTabSummary{},
+		},
+		{
+			name: "every row",
+			sum:  sum,
+			want: &responsepb.RowStatsList{Rows: []*summarypb.RowStats{foo, bar}},
+		},
+		{
+			name: "one row",
+			sum:  sum,
+			row:  "bar",
+			want: &responsepb.RowStatsList{Rows: []*summarypb.RowStats{bar}},
+		},
+		{
+			name: "missing row",
+			sum:  sum,
+			row:  "missing",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := rowStats(tc.sum, tc.row)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("rowStats() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRowRange(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		wantStart int
+		wantEnd   int
+		err       bool
+	}{
+		{
+			name:    "default to the first rows",
+			wantEnd: defaultRows,
+		},
+		{
+			name:      "default end",
+			query:     "start=20",
+			wantStart: 20,
+			wantEnd:   20 + defaultRows,
+		},
+		{
+			name:      "explicit range",
+			query:     "start=5&end=7",
+			wantStart: 5,
+			wantEnd:   7,
+		},
+		{
+			name:  "negative start",
+			query: "start=-1",
+			err:   true,
+		},
+		{
+			name:  "empty range",
+			query: "start=5&end=5",
+			err:   true,
+		},
+		{
+			name:  "too many rows",
+			query: fmt.Sprintf("end=%d", maxRows+1),
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tc.query, err)
+			}
+			start, end, err := rowRange(query)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("rowRange() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("rowRange() failed to return an error")
+			case start != tc.wantStart || end != tc.wantEnd:
+				t.Errorf("rowRange() got [%d, %d), want [%d, %d)", start, end, tc.wantStart, tc.wantEnd)
+			}
+		})
+	}
+}
+
+func TestServeCells(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	gridPath, err := gcs.NewPath("gs://bucket/grid/group")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	var rows []*statepb.Row
+	for _, name := range []string{"a", "b", "c"} {
+		rows = append(rows, &statepb.Row{Name: name, Results: []int32{pass, 2}, Messages: []string{"", ""}})
+	}
+	gridBuf, err := gcs.MarshalGrid(&statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows:    rows,
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{
+				*summaryPath: {},
+				*gridPath:    {Data: string(gridBuf)},
+			},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+					},
+				},
+			},
+			ConfigPath:    *configPath,
+			GridPrefix:    "grid",
+			SummaryPrefix: "summary",
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, TabPath("dash", "tab", CellsResource)+"?start=1&end=2&columns=1", nil)
+	r.Header.Set("Accept", ContentTypeProto)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var got responsepb.RowBatch
+	if err := proto.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := &responsepb.RowBatch{
+		Start:     1,
+		TotalRows: 3,
+		Rows:      []*responsepb.RowCells{{Name: "b", Results: []int32{pass, 1}, Messages: []string{""}}},
+	}
+	if diff := cmp.Diff(want, &got, protocmp.Transform()); diff != "" {
+		t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeIssues(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	issuePath, err := gcs.NewPath("gs://bucket/grid/group.issues")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	existing, err := proto.Marshal(&issuepb.IssueState{
+		IssueInfo: []*issuepb.IssueInfo{
+			{IssueId: "https://bugs/1", RowIds: []string{"foo", "bar"}},
+			{IssueId: "https://bugs/2", RowIds: []string{"bar"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	reader := tabs.Reader{
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name: "dash",
+					DashboardTab: []*configpb.DashboardTab{
+						{Name: "tab", TestGroupName: "group"},
+					},
+				},
+			},
+		},
+		ConfigPath: *configPath,
+		GridPrefix: "grid",
+	}
+
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		id       *Identity
+		state    string
+		want     int
+		wantRow  *issuepb.IssueState
+		wantSave *issuepb.IssueState
+	}{
+		{
+			name:    "list",
+			method:  http.MethodGet,
+			path:    RowPath("dash", "tab", "foo", IssuesResource),
+			state:   string(existing),
+			want:    http.StatusOK,
+			wantRow: &issuepb.IssueState{IssueInfo: []*issuepb.IssueInfo{{IssueId: "https://bugs/1", RowIds: []string{"foo", "bar"}}}},
+		},
+		{
+			name:    "attach",
+			method:  http.MethodPost,
+			path:    RowPath("dash", "tab", "foo", IssuesResource),
+			body:    `{"issue_id": "https://bugs/3"}`,
+			id:      &Identity{Email: "me@example.com"},
+			want:    http.StatusOK,
+			wantRow: &issuepb.IssueState{IssueInfo: []*issuepb.IssueInfo{{IssueId: "https://bugs/3", RowIds: []string{"foo"}}}},
+			wantSave: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "https://bugs/3", RowIds: []string{"foo"}}},
+			},
+		},
+		{
+			name:   "attach without an issue",
+			method: http.MethodPost,
+			path:   RowPath("dash", "tab", "foo", IssuesResource),
+			body:   `{}`,
+			id:     &Identity{Email: "me@example.com"},
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:    "detach",
+			method:  http.MethodDelete,
+			path:    RowPath("dash", "tab", "foo", IssuesResource) + "?issue=https://bugs/1",
+			id:      &Identity{Email: "me@example.com"},
+			state:   string(existing),
+			want:    http.StatusOK,
+			wantRow: &issuepb.IssueState{},
+			wantSave: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{
+					{IssueId: "https://bugs/1", RowIds: []string{"bar"}},
+					{IssueId: "https://bugs/2", RowIds: []string{"bar"}},
+				},
+			},
+		},
+		{
+			name:   "detach without an issue",
+			method: http.MethodDelete,
+			path:   RowPath("dash", "tab", "foo", IssuesResource),
+			id:     &Identity{Email: "me@example.com"},
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "anonymous attach",
+			method: http.MethodPost,
+			path:   RowPath("dash", "tab", "foo", IssuesResource),
+			body:   `{"issue_id": "https://bugs/3"}`,
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "anonymous detach",
+			method: http.MethodDelete,
+			path:   RowPath("dash", "tab", "foo", IssuesResource) + "?issue=https://bugs/1",
+			state:  string(existing),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "issues of unknown tab",
+			method: http.MethodGet,
+			path:   RowPath("dash", "missing", "foo", IssuesResource),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodPut,
+			path:   RowPath("dash", "tab", "foo", IssuesResource),
+			want:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client:   fake.Client{Opener: fake.Opener{}},
+					Uploader: fake.Uploader{},
+					Stater:   fake.Stater{},
+				},
+			}
+			if tc.state != "" {
+				client.Opener[*issuePath] = fake.Object{Data: tc.state}
+				client.Stater[*issuePath] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 1}}
+			}
+			s := Server{Reader: reader, Issues: client}
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", ContentTypeJSON)
+			if tc.id != nil {
+				r = r.WithContext(context.WithValue(r.Context(), identityKey{}, tc.id))
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tc.want {
+				t.Fatalf("ServeHTTP(%s %s) got %d, want %d: %s", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+			}
+			if tc.wantRow != nil {
+				var got issuepb.IssueState
+				if err := Unmarshal(ContentTypeJSON, w.Body.Bytes(), &got); err != nil {
+					t.Fatalf("Unmarshal(): %v", err)
+				}
+				if diff := cmp.Diff(tc.wantRow, &got, protocmp.Transform()); diff != "" {
+					t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+			var gotSave *issuepb.IssueState
+			if u, ok := client.Uploader[*issuePath]; ok {
+				gotSave = &issuepb.IssueState{}
+				if err := proto.Unmarshal(u.Buf, gotSave); err != nil {
+					t.Fatalf("proto.Unmarshal(): %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantSave, gotSave, protocmp.Transform()); diff != "" {
+				t.Errorf("ServeHTTP() saved unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServeWarm(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	reader := tabs.Reader{
+		Client: fake.Opener{},
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name:         "my dash",
+					DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+				},
+			},
+		},
+		ConfigPath: *configPath,
+		GridPrefix: "grid",
+	}
+
+	cases := []struct {
+		name    string
+		method  string
+		path    string
+		noCache bool
+		want    int
+	}{
+		{
+			name:   "warm",
+			method: http.MethodPost,
+			path:   WarmPath("my dash"),
+			want:   http.StatusOK,
+		},
+		{
+			name:   "readers may warm",
+			method: http.MethodGet,
+			path:   WarmPath("my dash") + "?full_history=true",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "bad full_history",
+			method: http.MethodGet,
+			path:   WarmPath("my dash") + "?full_history=maybe",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "missing dashboard",
+			method: http.MethodPost,
+			path:   WarmPath("missing"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodDelete,
+			path:   WarmPath("my dash"),
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:    "cache disabled",
+			method:  http.MethodPost,
+			path:    WarmPath("my dash"),
+			noCache: true,
+			want:    http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Server{Reader: reader}
+			if !tc.noCache {
+				s.Reader.Cache = &tabs.Cache{TTL: time.Minute}
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+			if w.Code != tc.want {
+				t.Errorf("ServeHTTP(%s %s) got %d, want %d", tc.method, tc.path, w.Code, tc.want)
+			}
+		})
+	}
+}
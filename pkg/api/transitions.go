@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// serveTransitions serves the TransitionFeed of the latest max transitions of
+// the grid's rows, or an RSS or Atom feed of it when the request wants one.
+func serveTransitions(w http.ResponseWriter, r *http.Request, dashboard, tab string, grid *statepb.Grid, max int) error {
+	transitions := tabs.Transitions(grid, max)
+	contentType := feedType(r)
+	if contentType == "" {
+		return Write(w, r, transitions)
+	}
+	return writeFeed(w, r, contentType, transitionsFeed(requestURL(r), dashboard, tab, transitions))
+}
+
+// transitionsFeed returns the feed of the dashboard tab's transitions.
+func transitionsFeed(link, dashboard, tab string, transitions *responsepb.TransitionFeed) feed {
+	f := feed{
+		Title:       fmt.Sprintf("%s/%s transitions", dashboard, tab),
+		Description: fmt.Sprintf("Tests which started failing or passing in %s/%s", dashboard, tab),
+		Link:        link,
+	}
+	for _, t := range transitions.Transitions {
+		verb := "passing"
+		if result.Failing(t.To) {
+			verb = "failing"
+		}
+		f.Entries = append(f.Entries, feedEntry{
+			ID:          strings.Join([]string{dashboard, tab, t.Name, t.Build, t.To.String()}, "/"),
+			Title:       fmt.Sprintf("%s started %s in %s", t.Name, verb, t.Build),
+			Description: t.Message,
+			Updated:     updater.SecondsTime(t.Started / 1000).UTC(),
+		})
+	}
+	return f
+}
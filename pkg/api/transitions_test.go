@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestServeTransitions(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	summaryPath, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	gridPath, err := gcs.NewPath("gs://bucket/grid/group")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	gridBuf, err := gcs.MarshalGrid(&statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3", Started: 3000}, {Build: "2", Started: 2000}, {Build: "1", Started: 1000}},
+		Rows: []*statepb.Row{
+			{Name: "broke", Results: []int32{fail, 1, pass, 2}, Messages: []string{"boom", "", ""}},
+			{Name: "fixed", Results: []int32{pass, 1, fail, 2}, Messages: []string{"", "old", "older"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := Server{
+		Reader: tabs.Reader{
+			Client: fake.Opener{
+				*summaryPath: {},
+				*gridPath:    {Data: string(gridBuf)},
+			},
+			Config: &configpb.Configuration{
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name:         "dash",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+					},
+				},
+			},
+			ConfigPath:    *configPath,
+			GridPrefix:    "grid",
+			SummaryPrefix: "summary",
+		},
+	}
+
+	t.Run("feed", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, TabPath("dash", "tab", TransitionsResource)+"?max=1", nil)
+		r.Header.Set("Accept", ContentTypeProto)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		var got responsepb.TransitionFeed
+		if err := proto.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		want := &responsepb.TransitionFeed{
+			Transitions: []*responsepb.Transition{
+				{
+					Name:    "broke",
+					From:    statuspb.TestStatus_PASS,
+					To:      statuspb.TestStatus_FAIL,
+					Build:   "3",
+					Started: 3000,
+					Message: "boom",
+				},
+			},
+		}
+		if diff := cmp.Diff(want, &got, protocmp.Transform()); diff != "" {
+			t.Errorf("ServeHTTP() got unexpected diff (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("rss", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, TabPath("dash", "tab", TransitionsResource)+"?format=rss", nil)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		if got := w.Header().Get("Content-Type"); got != ContentTypeRSS {
+			t.Errorf("ServeHTTP() got content type %q, want %q", got, ContentTypeRSS)
+		}
+		var got rssFeed
+		if err := xml.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		var titles []string
+		for _, item := range got.Channel.Items {
+			titles = append(titles, item.Title)
+		}
+		want := []string{"broke started failing in 3", "fixed started passing in 3"}
+		if diff := cmp.Diff(want, titles); diff != "" {
+			t.Errorf("ServeHTTP() got unexpected item diff (-want +got):\n%s", diff)
+		}
+		if got.Channel.Items[0].PubDate != "Thu, 01 Jan 1970 00:00:03 +0000" {
+			t.Errorf("ServeHTTP() got pubDate %q", got.Channel.Items[0].PubDate)
+		}
+	})
+
+	t.Run("bad max", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, TabPath("dash", "tab", TransitionsResource)+"?max=0", nil)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("ServeHTTP() got %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package argo reads archived Argo Workflows, along with the junit output
+// artifacts of their pods, from an Argo Server.
+package argo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkflowTemplateLabel labels workflows with their WorkflowTemplate.
+	WorkflowTemplateLabel = "workflows.argoproj.io/workflow-template"
+
+	// NodePod is the type of nodes which run a pod.
+	NodePod = "Pod"
+
+	pageSize = "500"
+)
+
+// Phases of workflows and their nodes.
+const (
+	PhasePending   = "Pending"
+	PhaseRunning   = "Running"
+	PhaseSucceeded = "Succeeded"
+	PhaseSkipped   = "Skipped"
+	PhaseFailed    = "Failed"
+	PhaseError     = "Error"
+	PhaseOmitted   = "Omitted"
+)
+
+// Client reads from the API of an Argo Server.
+type Client struct {
+	// Host is the URL of the Argo Server, such as https://argo-server.argo:2746
+	Host string
+	// Token authenticates requests when set.
+	Token string
+	HTTP  *http.Client
+}
+
+// Parameter is an argument of a workflow.
+type Parameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// Artifact is an output artifact of a node.
+type Artifact struct {
+	Name string `json:"name"`
+}
+
+// Node is a step, task or pod of a workflow.
+type Node struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	DisplayName  string      `json:"displayName"`
+	Type         string      `json:"type"`
+	TemplateName string      `json:"templateName,omitempty"`
+	Phase        string      `json:"phase,omitempty"`
+	Message      string      `json:"message,omitempty"`
+	StartedAt    metav1.Time `json:"startedAt,omitempty"`
+	FinishedAt   metav1.Time `json:"finishedAt,omitempty"`
+	Outputs      struct {
+		Artifacts []Artifact `json:"artifacts,omitempty"`
+	} `json:"outputs,omitempty"`
+}
+
+// WorkflowStatus is the status of a workflow.
+type WorkflowStatus struct {
+	Phase      string          `json:"phase,omitempty"`
+	Message    string          `json:"message,omitempty"`
+	StartedAt  metav1.Time     `json:"startedAt,omitempty"`
+	FinishedAt metav1.Time     `json:"finishedAt,omitempty"`
+	Nodes      map[string]Node `json:"nodes,omitempty"`
+}
+
+// Workflow is a run of a WorkflowTemplate.
+type Workflow struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Arguments struct {
+			Parameters []Parameter `json:"parameters,omitempty"`
+		} `json:"arguments,omitempty"`
+	} `json:"spec"`
+	Status WorkflowStatus `json:"status"`
+}
+
+// Parameter returns the value of the named argument.
+func (wf Workflow) Parameter(name string) (string, bool) {
+	for _, p := range wf.Spec.Arguments.Parameters {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+type list struct {
+	Metadata metav1.ListMeta `json:"metadata"`
+	Items    []Workflow      `json:"items"`
+}
+
+// ArchivedWorkflows returns the archived workflows of the WorkflowTemplate in the namespace.
+//
+// The archive omits the nodes of each workflow, which ArchivedWorkflow returns.
+func (c Client) ArchivedWorkflows(ctx context.Context, namespace, template string) ([]Workflow, error) {
+	var out []Workflow
+	var cont string
+	for {
+		q := url.Values{
+			"namespace":                 {namespace},
+			"listOptions.labelSelector": {WorkflowTemplateLabel + "=" + template},
+			"listOptions.limit":         {pageSize},
+		}
+		if cont != "" {
+			q.Set("listOptions.continue", cont)
+		}
+		var page list
+		if err := c.getJSON(ctx, "/api/v1/archived-workflows", q, &page); err != nil {
+			return nil, err
+		}
+		out = append(out, page.Items...)
+		if cont = page.Metadata.Continue; cont == "" {
+			return out, nil
+		}
+	}
+}
+
+// ArchivedWorkflow returns the archived workflow with the uid, including its nodes.
+func (c Client) ArchivedWorkflow(ctx context.Context, uid string) (*Workflow, error) {
+	var wf Workflow
+	if err := c.getJSON(ctx, "/api/v1/archived-workflows/"+url.PathEscape(uid), nil, &wf); err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
+// Artifact returns the named output artifact of the node of the workflow with the uid.
+//
+// The Argo Server reads the artifact from its repository, such as S3.
+func (c Client) Artifact(ctx context.Context, uid, node, name string) ([]byte, error) {
+	return c.get(ctx, "/artifacts-by-uid/"+url.PathEscape(uid)+"/"+url.PathEscape(node)+"/"+url.PathEscape(name), nil)
+}
+
+// getJSON decodes the response to the path.
+func (c Client) getJSON(ctx context.Context, path string, q url.Values, out interface{}) error {
+	buf, err := c.get(ctx, path, q)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(buf, out); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// get returns the body of the response to the path.
+func (c Client) get(ctx context.Context, path string, q url.Values) ([]byte, error) {
+	u, err := url.Parse(strings.TrimSuffix(c.Host, "/") + path)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+	u.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: %s: %s", path, resp.Status, buf)
+	}
+	return buf, nil
+}
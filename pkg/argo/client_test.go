@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newServer serves each path, along with the label selector and continue
+// token of archive listings, such as
+// "/api/v1/archived-workflows?workflows.argoproj.io/workflow-template=build&next".
+func newServer(t *testing.T, namespace string, pages map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		key := r.URL.Path
+		if key == "/api/v1/archived-workflows" {
+			q := r.URL.Query()
+			if q.Get("listOptions.limit") != pageSize {
+				t.Errorf("%s sent limit %q", r.URL, q.Get("listOptions.limit"))
+			}
+			if q.Get("namespace") != namespace {
+				http.NotFound(w, r)
+				return
+			}
+			key += "?" + q.Get("listOptions.labelSelector")
+			if cont := q.Get("listOptions.continue"); cont != "" {
+				key += "&" + cont
+			}
+		}
+		body, ok := pages[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestClient(t *testing.T) {
+	server := newServer(t, "ci", map[string]string{
+		"/api/v1/archived-workflows?workflows.argoproj.io/workflow-template=build": `{"metadata":{"continue":"next"},"items":[
+			{"metadata":{"name":"build-x2","uid":"uid-2"},"spec":{"arguments":{"parameters":[{"name":"revision","value":"abc"}]}}}
+		]}`,
+		"/api/v1/archived-workflows?workflows.argoproj.io/workflow-template=build&next": `{"metadata":{},"items":[{"metadata":{"name":"build-x1","uid":"uid-1"}}]}`,
+		"/api/v1/archived-workflows/uid-2": `{"metadata":{"name":"build-x2","uid":"uid-2"},"status":{"phase":"Succeeded","nodes":{
+			"build-x2-123":{"id":"build-x2-123","displayName":"unit","type":"Pod","phase":"Succeeded","outputs":{"artifacts":[{"name":"junit"}]}}
+		}}}`,
+		"/artifacts-by-uid/uid-2/build-x2-123/junit": `<testsuite/>`,
+	})
+	defer server.Close()
+	ctx := context.Background()
+	client := Client{Host: server.URL, Token: "secret", HTTP: server.Client()}
+
+	workflows, err := client.ArchivedWorkflows(ctx, "ci", "build")
+	if err != nil {
+		t.Fatalf("ArchivedWorkflows() got unexpected error: %v", err)
+	}
+	var names []string
+	for _, wf := range workflows {
+		names = append(names, wf.Name)
+	}
+	if diff := cmp.Diff([]string{"build-x2", "build-x1"}, names); diff != "" {
+		t.Errorf("ArchivedWorkflows() got unexpected names (-want +got):\n%s", diff)
+	}
+	if got, ok := workflows[0].Parameter("revision"); !ok || got != "abc" {
+		t.Errorf("Parameter(revision) got %q, %t, want abc", got, ok)
+	}
+
+	wf, err := client.ArchivedWorkflow(ctx, "uid-2")
+	if err != nil {
+		t.Fatalf("ArchivedWorkflow() got unexpected error: %v", err)
+	}
+	if node := wf.Status.Nodes["build-x2-123"]; node.Type != NodePod || !hasArtifact(node, "junit") {
+		t.Errorf("ArchivedWorkflow() got unexpected nodes: %+v", wf.Status.Nodes)
+	}
+
+	buf, err := client.Artifact(ctx, "uid-2", "build-x2-123", "junit")
+	if err != nil {
+		t.Fatalf("Artifact() got unexpected error: %v", err)
+	}
+	if got := string(buf); got != "<testsuite/>" {
+		t.Errorf("Artifact() got %q, want <testsuite/>", got)
+	}
+
+	if _, err := client.ArchivedWorkflows(ctx, "other", "build"); err == nil {
+		t.Error("ArchivedWorkflows() of a missing namespace failed to return an error")
+	}
+	client.Token = "wrong"
+	if _, err := client.ArchivedWorkflows(ctx, "ci", "build"); err == nil {
+		t.Error("ArchivedWorkflows() without authorization failed to return an error")
+	}
+}
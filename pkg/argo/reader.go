@@ -0,0 +1,260 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// ReaderName is the column_reader of groups which read Argo Workflows.
+const ReaderName = "argo"
+
+const (
+	// maxWorkflows limits the workflows read each cycle, matching the GCS reader.
+	maxWorkflows  = 50
+	missingHeader = "missing"
+)
+
+// ColumnReader returns a reader of the archived workflows of a group's
+// argo_config WorkflowTemplate, converting each workflow into a column.
+//
+// Workflows already in the old columns are skipped.
+func ColumnReader(client Client) updater.ColumnReader {
+	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []updater.InflatedColumn, stop time.Time) ([]updater.InflatedColumn, error) {
+		cfg := tg.GetResultSource().GetArgoConfig()
+		if cfg == nil {
+			return nil, errors.New("missing argo_config")
+		}
+		seen := make(map[string]bool, len(oldCols))
+		for _, col := range oldCols {
+			seen[col.Column.Hint] = true
+			if started := updater.ColumnStarted(col.Column); started.After(stop) {
+				stop = started
+			}
+		}
+
+		all, err := client.ArchivedWorkflows(ctx, cfg.Namespace, cfg.WorkflowTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("list archived workflows: %w", err)
+		}
+		var workflows []Workflow
+		for _, wf := range all {
+			if seen[string(wf.UID)] || wf.Status.StartedAt.IsZero() || wf.Status.StartedAt.Time.Before(stop) {
+				continue
+			}
+			workflows = append(workflows, wf)
+		}
+		sort.SliceStable(workflows, func(i, j int) bool {
+			return workflows[i].Status.StartedAt.After(workflows[j].Status.StartedAt.Time)
+		})
+		if len(workflows) > maxWorkflows {
+			workflows = workflows[:maxWorkflows]
+		}
+		log.WithFields(logrus.Fields{"total": len(all), "new": len(workflows)}).Debug("Listed archived workflows")
+
+		var cols []updater.InflatedColumn
+		for _, listed := range workflows {
+			wf, err := client.ArchivedWorkflow(ctx, string(listed.UID))
+			if err != nil {
+				return nil, fmt.Errorf("%s: get workflow: %w", listed.Name, err)
+			}
+			suites := map[string][]junit.Suite{}
+			if cfg.JunitArtifact != "" {
+				for id, node := range wf.Status.Nodes {
+					if !hasArtifact(node, cfg.JunitArtifact) {
+						continue
+					}
+					buf, err := client.Artifact(ctx, string(wf.UID), id, cfg.JunitArtifact)
+					if err != nil {
+						return nil, fmt.Errorf("%s: %s: %w", wf.Name, node.DisplayName, err)
+					}
+					if suites[id], err = parseArtifact(buf); err != nil {
+						return nil, fmt.Errorf("%s: %s: parse %s: %w", wf.Name, node.DisplayName, cfg.JunitArtifact, err)
+					}
+				}
+			}
+			cols = append(cols, convertWorkflow(tg, *wf, suites))
+		}
+		return cols, nil
+	}
+}
+
+// hasArtifact returns true when the node outputs the named artifact.
+func hasArtifact(node Node, name string) bool {
+	for _, a := range node.Outputs.Artifacts {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseArtifact parses the junit xml of an artifact, which Argo gzips and
+// tars unless the artifact disables archiving.
+func parseArtifact(buf []byte) ([]junit.Suite, error) {
+	if bytes.HasPrefix(buf, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("gunzip: %w", err)
+		}
+		if buf, err = ioutil.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("gunzip: %w", err)
+		}
+	}
+	if len(buf) < 262 || string(buf[257:262]) != "ustar" {
+		suites, err := junit.Parse(buf)
+		if err != nil {
+			return nil, err
+		}
+		return suites.Suites, nil
+	}
+	var out []junit.Suite
+	tr := tar.NewReader(bytes.NewReader(buf))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("untar: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(hdr.Name, ".xml") {
+			continue
+		}
+		suites, err := junit.ParseStream(tr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+		out = append(out, suites.Suites...)
+	}
+}
+
+// retrySuffix matches the attempt of a retried node, such as build(1).
+var retrySuffix = regexp.MustCompile(`\(\d+\)$`)
+
+// nodeName returns the row name of the pod node, merging the attempts of retried nodes.
+func nodeName(node Node) string {
+	name := node.DisplayName
+	if name == "" {
+		name = node.Name
+	}
+	return retrySuffix.ReplaceAllString(name, "")
+}
+
+// convertWorkflow returns the column of the workflow, with a row for each pod,
+// a row for each junit test of each pod and the overall row.
+//
+// Column headers hold the argument, or else the label, of that name.
+func convertWorkflow(tg *configpb.TestGroup, wf Workflow, suites map[string][]junit.Suite) updater.InflatedColumn {
+	overall, _ := phaseCell(wf.Status.Phase, wf.Status.Message, wf.Status.StartedAt.Time, wf.Status.FinishedAt.Time)
+	col := updater.InflatedColumn{
+		Column: &statepb.Column{
+			Build:   wf.Name,
+			Hint:    string(wf.UID),
+			Started: float64(wf.Status.StartedAt.UnixNano()) / float64(time.Millisecond),
+		},
+		Cells: map[string]updater.Cell{},
+	}
+	for _, h := range tg.GetColumnHeader() {
+		val, ok := wf.Parameter(h.ConfigurationValue)
+		if !ok {
+			val = wf.Labels[h.ConfigurationValue]
+		}
+		if h.ConfigurationValue == "" {
+			val = ""
+		}
+		if val == "" && overall.Result != statuspb.TestStatus_RUNNING {
+			val = missingHeader
+		}
+		col.Column.Extra = append(col.Column.Extra, val)
+	}
+
+	cells := map[string][]updater.Cell{}
+	for id, node := range wf.Status.Nodes {
+		if node.Type != NodePod {
+			continue
+		}
+		c, ok := phaseCell(node.Phase, node.Message, node.StartedAt.Time, node.FinishedAt.Time)
+		if !ok {
+			continue
+		}
+		name := nodeName(node)
+		c.ID = name
+		cells[name] = append(cells[name], c)
+		for _, suite := range suites[id] {
+			for _, r := range updater.FlattenResults(suite) {
+				if r.Skipped != nil && *r.Skipped == "" {
+					continue
+				}
+				name := name + "/" + r.Name
+				c := updater.ResultCell(r)
+				c.ID = name
+				cells[name] = append(cells[name], c)
+			}
+		}
+	}
+	for name, cs := range cells {
+		col.Cells[name] = updater.MergeCells(true, cs...)
+	}
+	if name := updater.OverallRowName(tg); name != "" {
+		col.Cells[name] = overall
+	}
+	return col
+}
+
+// phaseCell converts the phase of a workflow or node into a cell, returning
+// false for skipped and omitted nodes, which never ran.
+func phaseCell(phase, message string, started, finished time.Time) (updater.Cell, bool) {
+	var c updater.Cell
+	switch phase {
+	case PhaseSkipped, PhaseOmitted:
+		return c, false
+	case PhaseSucceeded:
+		c.Result = statuspb.TestStatus_PASS
+	case PhaseFailed, PhaseError:
+		c.Result = statuspb.TestStatus_FAIL
+		c.Message = message
+	default:
+		c.Result = statuspb.TestStatus_RUNNING
+		c.Icon = "R"
+		c.Message = "Still running..."
+		return c, true
+	}
+	if !started.IsZero() && !finished.IsZero() {
+		c.Metrics = map[string]float64{updater.ElapsedKey: finished.Sub(started).Minutes()}
+	}
+	return c, true
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+func TestParseArtifact(t *testing.T) {
+	const suite = `<testsuite name="unit"><testcase name="TestFoo"/></testsuite>`
+	gzipped := func(buf []byte) []byte {
+		var out bytes.Buffer
+		zw := gzip.NewWriter(&out)
+		zw.Write(buf)
+		zw.Close()
+		return out.Bytes()
+	}
+	tarred := func(files map[string]string) []byte {
+		var out bytes.Buffer
+		tw := tar.NewWriter(&out)
+		for name, body := range files {
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+			tw.Write([]byte(body))
+		}
+		tw.Close()
+		return out.Bytes()
+	}
+	want := []junit.Suite{{XMLName: xml.Name{Local: "testsuite"}, Name: "unit", Results: []junit.Result{{Name: "TestFoo"}}}}
+	cases := []struct {
+		name string
+		buf  []byte
+		want []junit.Suite
+		err  bool
+	}{
+		{
+			name: "plain",
+			buf:  []byte(suite),
+			want: want,
+		},
+		{
+			name: "gzipped",
+			buf:  gzipped([]byte(suite)),
+			want: want,
+		},
+		{
+			name: "tarred",
+			buf:  gzipped(tarred(map[string]string{"junit.xml": suite, "build-log.txt": "hello"})),
+			want: want,
+		},
+		{
+			name: "invalid",
+			buf:  []byte("<testsuite"),
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseArtifact(tc.buf)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("parseArtifact() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("parseArtifact() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("parseArtifact() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPhaseCell(t *testing.T) {
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		phase    string
+		finished time.Time
+		want     updater.Cell
+		wantOK   bool
+	}{
+		{
+			name:   "running",
+			phase:  PhaseRunning,
+			want:   updater.Cell{Result: statuspb.TestStatus_RUNNING, Icon: "R", Message: "Still running..."},
+			wantOK: true,
+		},
+		{
+			name:     "succeeded",
+			phase:    PhaseSucceeded,
+			finished: start.Add(2 * time.Minute),
+			want: updater.Cell{
+				Result:  statuspb.TestStatus_PASS,
+				Metrics: map[string]float64{updater.ElapsedKey: 2},
+			},
+			wantOK: true,
+		},
+		{
+			name:   "errored",
+			phase:  PhaseError,
+			want:   updater.Cell{Result: statuspb.TestStatus_FAIL, Message: "boom"},
+			wantOK: true,
+		},
+		{
+			name:  "skipped",
+			phase: PhaseSkipped,
+		},
+		{
+			name:  "omitted",
+			phase: PhaseOmitted,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := phaseCell(tc.phase, "boom", start, tc.finished)
+			if ok != tc.wantOK {
+				t.Errorf("phaseCell() got ok %t, want %t", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("phaseCell() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnReader(t *testing.T) {
+	millis := func(t time.Time) float64 {
+		return float64(t.UnixNano()) / float64(time.Millisecond)
+	}
+	tg := &configpb.TestGroup{
+		Name:         "argo",
+		ColumnReader: ReaderName,
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ConfigurationValue: "revision"},
+		},
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_ArgoConfig{
+				ArgoConfig: &configpb.ArgoConfig{
+					Namespace:        "ci",
+					WorkflowTemplate: "build",
+					JunitArtifact:    "junit",
+				},
+			},
+		},
+	}
+	server := newServer(t, "ci", map[string]string{
+		"/api/v1/archived-workflows?workflows.argoproj.io/workflow-template=build": `{"metadata":{},"items":[
+			{"metadata":{"name":"build-a","uid":"uid-a"},"status":{"startedAt":"2021-06-01T09:00:00Z"}},
+			{"metadata":{"name":"build-b","uid":"uid-b"},"status":{"startedAt":"2021-06-01T10:00:00Z"}},
+			{"metadata":{"name":"build-old","uid":"uid-old"},"status":{"startedAt":"2021-05-01T10:00:00Z"}}
+		]}`,
+		"/api/v1/archived-workflows/uid-b": `{
+			"metadata":{"name":"build-b","uid":"uid-b"},
+			"spec":{"arguments":{"parameters":[{"name":"revision","value":"abc"}]}},
+			"status":{"phase":"Failed","message":"child failed","startedAt":"2021-06-01T10:00:00Z","finishedAt":"2021-06-01T10:30:00Z","nodes":{
+				"build-b":{"id":"build-b","displayName":"build-b","type":"Steps","phase":"Failed"},
+				"build-b-1":{"id":"build-b-1","displayName":"unit(0)","type":"Pod","phase":"Failed","message":"exit code 1"},
+				"build-b-2":{"id":"build-b-2","displayName":"unit(1)","type":"Pod","phase":"Succeeded",
+				 "outputs":{"artifacts":[{"name":"junit"},{"name":"logs"}]}},
+				"build-b-3":{"id":"build-b-3","displayName":"deploy","type":"Pod","phase":"Skipped"}
+			}}
+		}`,
+		"/artifacts-by-uid/uid-b/build-b-2/junit": `<testsuite>
+			<testcase name="TestFoo" time="60"/>
+			<testcase name="TestSkip"><skipped/></testcase>
+		</testsuite>`,
+	})
+	defer server.Close()
+	oldCols := []updater.InflatedColumn{
+		{Column: &statepb.Column{Build: "build-a", Hint: "uid-a", Started: millis(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))}},
+	}
+	client := Client{Host: server.URL, Token: "secret", HTTP: server.Client()}
+
+	got, err := ColumnReader(client)(context.Background(), logrus.New(), tg, oldCols, time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ColumnReader() got unexpected error: %v", err)
+	}
+	want := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{
+				Build:   "build-b",
+				Hint:    "uid-b",
+				Started: millis(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)),
+				Extra:   []string{"abc"},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": {
+					Result:  statuspb.TestStatus_FAIL,
+					Message: "child failed",
+					Metrics: map[string]float64{updater.ElapsedKey: 30},
+				},
+				"unit": {
+					Result:  statuspb.TestStatus_FLAKY,
+					ID:      "unit",
+					Icon:    "1/2",
+					Message: "1/2 runs passed: exit code 1",
+				},
+				"unit/TestFoo": {
+					Result:  statuspb.TestStatus_PASS,
+					ID:      "unit/TestFoo",
+					Metrics: map[string]float64{updater.ElapsedKey: 1},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ColumnReader() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package circleci lists the pipelines and workflows of CircleCI projects and
+// reads the test metadata of their jobs.
+package circleci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultURL is the base URL of the CircleCI v2 API.
+const DefaultURL = "https://circleci.com/api/v2"
+
+// Client reads from the CircleCI v2 API.
+type Client struct {
+	HTTP *http.Client
+	// BaseURL defaults to DefaultURL.
+	BaseURL string
+	// Token authenticates the client when set.
+	Token string
+}
+
+// Pipeline is a CircleCI pipeline.
+type Pipeline struct {
+	ID        string    `json:"id"`
+	Number    int       `json:"number"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	VCS       struct {
+		Revision string `json:"revision"`
+		Branch   string `json:"branch,omitempty"`
+	} `json:"vcs"`
+}
+
+// Workflow statuses.
+const (
+	StatusSuccess      = "success"
+	StatusRunning      = "running"
+	StatusNotRun       = "not_run"
+	StatusFailed       = "failed"
+	StatusError        = "error"
+	StatusFailing      = "failing"
+	StatusOnHold       = "on_hold"
+	StatusCanceled     = "canceled"
+	StatusUnauthorized = "unauthorized"
+	StatusInfraFail    = "infrastructure_fail"
+)
+
+// Workflow is a run of a workflow of a pipeline.
+type Workflow struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Status         string    `json:"status"`
+	PipelineNumber int       `json:"pipeline_number"`
+	CreatedAt      time.Time `json:"created_at"`
+	StoppedAt      time.Time `json:"stopped_at,omitempty"`
+}
+
+// JobApproval is the type of jobs which wait for approval instead of running.
+const JobApproval = "approval"
+
+// Job is a job of a workflow.
+type Job struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	JobNumber int       `json:"job_number,omitempty"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+	StoppedAt time.Time `json:"stopped_at,omitempty"`
+}
+
+// Test results.
+const (
+	TestSuccess = "success"
+	TestFailure = "failure"
+	TestError   = "error"
+	TestSkipped = "skipped"
+)
+
+// Test is the metadata of a test a job ran.
+type Test struct {
+	Name      string `json:"name"`
+	Classname string `json:"classname,omitempty"`
+	File      string `json:"file,omitempty"`
+	Result    string `json:"result"`
+	Message   string `json:"message,omitempty"`
+	// RunTime is in seconds.
+	RunTime float64 `json:"run_time"`
+}
+
+type page struct {
+	Items         json.RawMessage `json:"items"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+// Pipelines lists the pipelines of the project, newest first, until more
+// returns false for a page or the pipelines run out.
+func (c *Client) Pipelines(ctx context.Context, slug, branch string, more func([]Pipeline) bool) error {
+	query := url.Values{}
+	if branch != "" {
+		query.Set("branch", branch)
+	}
+	return c.list(ctx, "/project/"+slug+"/pipeline", query, func(items json.RawMessage) (bool, error) {
+		var pipelines []Pipeline
+		if err := json.Unmarshal(items, &pipelines); err != nil {
+			return false, err
+		}
+		return more(pipelines), nil
+	})
+}
+
+// Workflows returns the workflows of the pipeline.
+func (c *Client) Workflows(ctx context.Context, pipelineID string) ([]Workflow, error) {
+	var out []Workflow
+	err := c.list(ctx, "/pipeline/"+url.PathEscape(pipelineID)+"/workflow", nil, func(items json.RawMessage) (bool, error) {
+		var workflows []Workflow
+		if err := json.Unmarshal(items, &workflows); err != nil {
+			return false, err
+		}
+		out = append(out, workflows...)
+		return true, nil
+	})
+	return out, err
+}
+
+// Jobs returns the jobs of the workflow.
+func (c *Client) Jobs(ctx context.Context, workflowID string) ([]Job, error) {
+	var out []Job
+	err := c.list(ctx, "/workflow/"+url.PathEscape(workflowID)+"/job", nil, func(items json.RawMessage) (bool, error) {
+		var jobs []Job
+		if err := json.Unmarshal(items, &jobs); err != nil {
+			return false, err
+		}
+		out = append(out, jobs...)
+		return true, nil
+	})
+	return out, err
+}
+
+// Tests returns the test metadata of the job of the project.
+func (c *Client) Tests(ctx context.Context, slug string, jobNumber int) ([]Test, error) {
+	var out []Test
+	err := c.list(ctx, "/project/"+slug+"/"+strconv.Itoa(jobNumber)+"/tests", nil, func(items json.RawMessage) (bool, error) {
+		var tests []Test
+		if err := json.Unmarshal(items, &tests); err != nil {
+			return false, err
+		}
+		out = append(out, tests...)
+		return true, nil
+	})
+	return out, err
+}
+
+// list gets each page of the path, until the page function returns false.
+func (c *Client) list(ctx context.Context, path string, query url.Values, pageFunc func(json.RawMessage) (bool, error)) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	for {
+		var p page
+		if err := c.get(ctx, path, query, &p); err != nil {
+			return err
+		}
+		more, err := pageFunc(p.Items)
+		if err != nil {
+			return fmt.Errorf("unmarshal %s: %w", path, err)
+		}
+		if !more || p.NextPageToken == "" {
+			return nil
+		}
+		query.Set("page-token", p.NextPageToken)
+	}
+}
+
+// get decodes the JSON response to the path.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultURL
+	}
+	u := strings.TrimSuffix(base, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Circle-Token", c.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("get %s: %s: %s", path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
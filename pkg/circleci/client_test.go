@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circleci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newServer serves the JSON of each path and page token, such as
+// "/pipeline/p1/workflow?page-token=next".
+func newServer(t *testing.T, pages map[string]string) (*[]string, *httptest.Server) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tok := r.Header.Get("Circle-Token"); tok != "secret" {
+			t.Errorf("%s sent token %q", r.URL, tok)
+		}
+		key := r.URL.Path
+		if q := r.URL.RawQuery; q != "" {
+			key += "?" + q
+		}
+		requested = append(requested, key)
+		body, ok := pages[key]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	return &requested, server
+}
+
+func TestPipelines(t *testing.T) {
+	pages := map[string]string{
+		"/project/gh/org/repo/pipeline?branch=main":                 `{"items":[{"id":"p3","number":3},{"id":"p2","number":2}],"next_page_token":"next"}`,
+		"/project/gh/org/repo/pipeline?branch=main&page-token=next": `{"items":[{"id":"p1","number":1}],"next_page_token":"last"}`,
+		"/project/gh/org/repo/pipeline?branch=main&page-token=last": `{"items":[{"id":"p0","number":0}]}`,
+	}
+	cases := []struct {
+		name    string
+		branch  string
+		max     int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "list every page",
+			branch: "main",
+			max:    10,
+			want:   []string{"p3", "p2", "p1", "p0"},
+		},
+		{
+			name:   "stop early",
+			branch: "main",
+			max:    2,
+			want:   []string{"p3", "p2"},
+		},
+		{
+			name:    "error",
+			branch:  "missing",
+			max:     10,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, server := newServer(t, pages)
+			defer server.Close()
+			client := Client{HTTP: server.Client(), BaseURL: server.URL, Token: "secret"}
+			var got []string
+			err := client.Pipelines(context.Background(), "gh/org/repo", tc.branch, func(page []Pipeline) bool {
+				for _, p := range page {
+					got = append(got, p.ID)
+				}
+				return len(got) < tc.max
+			})
+			switch {
+			case err != nil && !tc.wantErr:
+				t.Errorf("Pipelines() got unexpected error: %v", err)
+			case err == nil && tc.wantErr:
+				t.Error("Pipelines() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Pipelines() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTests(t *testing.T) {
+	requested, server := newServer(t, map[string]string{
+		"/project/gh/org/repo/7/tests":                 `{"items":[{"name":"TestFoo","result":"success","run_time":1.5}],"next_page_token":"next"}`,
+		"/project/gh/org/repo/7/tests?page-token=next": `{"items":[{"name":"TestBar","classname":"pkg","result":"failure","message":"boom"}]}`,
+	})
+	defer server.Close()
+	client := Client{HTTP: server.Client(), BaseURL: server.URL, Token: "secret"}
+	got, err := client.Tests(context.Background(), "gh/org/repo", 7)
+	if err != nil {
+		t.Fatalf("Tests() got unexpected error: %v", err)
+	}
+	want := []Test{
+		{Name: "TestFoo", Result: TestSuccess, RunTime: 1.5},
+		{Name: "TestBar", Classname: "pkg", Result: TestFailure, Message: "boom"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Tests() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if n := len(*requested); n != 2 {
+		t.Errorf("Tests() sent %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circleci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// ReaderName is the column_reader of groups which read CircleCI workflows.
+const ReaderName = "circleci"
+
+const (
+	// maxPipelines limits the pipelines read each cycle, matching the GCS reader.
+	maxPipelines = 50
+
+	commitHeader  = "Commit"
+	branchHeader  = "Branch"
+	missingHeader = "missing"
+)
+
+// ColumnReader returns a reader of the runs of a group's circleci_config
+// workflow, converting each run into a column.
+//
+// Runs already in the old columns, along with those which did not run, are skipped.
+func ColumnReader(client *Client) updater.ColumnReader {
+	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []updater.InflatedColumn, stop time.Time) ([]updater.InflatedColumn, error) {
+		cfg := tg.GetResultSource().GetCircleciConfig()
+		if cfg == nil {
+			return nil, errors.New("missing circleci_config")
+		}
+		seen := make(map[string]bool, len(oldCols))
+		for _, col := range oldCols {
+			seen[col.Column.Hint] = true
+			if started := updater.ColumnStarted(col.Column); started.After(stop) {
+				stop = started
+			}
+		}
+
+		var pipelines []Pipeline
+		err := client.Pipelines(ctx, cfg.ProjectSlug, cfg.Branch, func(page []Pipeline) bool {
+			for _, p := range page {
+				if p.CreatedAt.Before(stop) || len(pipelines) == maxPipelines {
+					return false
+				}
+				pipelines = append(pipelines, p)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list pipelines: %w", err)
+		}
+		log.WithField("pipelines", len(pipelines)).Debug("Listed pipelines")
+
+		var cols []updater.InflatedColumn
+		for _, p := range pipelines {
+			workflows, err := client.Workflows(ctx, p.ID)
+			if err != nil {
+				return nil, fmt.Errorf("pipeline %d: list workflows: %w", p.Number, err)
+			}
+			for _, w := range workflows {
+				if w.Name != cfg.Workflow || seen[w.ID] || w.Status == StatusNotRun {
+					continue
+				}
+				jobs, err := client.Jobs(ctx, w.ID)
+				if err != nil {
+					return nil, fmt.Errorf("pipeline %d: list jobs: %w", p.Number, err)
+				}
+				tests := map[string][]Test{}
+				for _, j := range jobs {
+					if j.JobNumber == 0 {
+						continue
+					}
+					if tests[j.Name], err = client.Tests(ctx, cfg.ProjectSlug, j.JobNumber); err != nil {
+						return nil, fmt.Errorf("pipeline %d: job %d: list tests: %w", p.Number, j.JobNumber, err)
+					}
+				}
+				cols = append(cols, convertWorkflow(tg, p, w, jobs, tests))
+			}
+		}
+		return cols, nil
+	}
+}
+
+// convertWorkflow returns the column of the workflow run, with a row for each
+// job other than approvals, a row for each test of each job and the overall row.
+func convertWorkflow(tg *configpb.TestGroup, p Pipeline, w Workflow, jobs []Job, tests map[string][]Test) updater.InflatedColumn {
+	overall := statusCell(w.Status, w.CreatedAt, w.StoppedAt)
+	col := updater.InflatedColumn{
+		Column: &statepb.Column{
+			Build:   strconv.Itoa(p.Number),
+			Hint:    w.ID,
+			Started: float64(w.CreatedAt.UnixNano()) / float64(time.Millisecond),
+		},
+		Cells: map[string]updater.Cell{},
+	}
+	for _, h := range tg.GetColumnHeader() {
+		var val string
+		switch h.ConfigurationValue {
+		case commitHeader:
+			val = p.VCS.Revision
+		case branchHeader:
+			val = p.VCS.Branch
+		}
+		if val == "" && overall.Result != statuspb.TestStatus_RUNNING {
+			val = missingHeader
+		}
+		col.Column.Extra = append(col.Column.Extra, val)
+	}
+
+	cells := map[string][]updater.Cell{}
+	for _, j := range jobs {
+		if j.Type == JobApproval {
+			continue
+		}
+		if c := statusCell(j.Status, j.StartedAt, j.StoppedAt); c.Result != statuspb.TestStatus_NO_RESULT {
+			c.ID = j.Name
+			cells[j.Name] = append(cells[j.Name], c)
+		}
+		for _, t := range tests[j.Name] {
+			name := j.Name + "/" + testName(t)
+			c := testCell(t)
+			c.ID = name
+			cells[name] = append(cells[name], c)
+		}
+	}
+	for name, cs := range cells {
+		col.Cells[name] = updater.MergeCells(true, cs...)
+	}
+	if name := updater.OverallRowName(tg); name != "" {
+		col.Cells[name] = overall
+	}
+	return col
+}
+
+// testName qualifies the name of the test with its class.
+func testName(t Test) string {
+	if t.Classname == "" || strings.HasPrefix(t.Name, t.Classname) {
+		return t.Name
+	}
+	return t.Classname + "." + t.Name
+}
+
+// testCell converts the test metadata into a cell.
+func testCell(t Test) updater.Cell {
+	var c updater.Cell
+	if t.RunTime > 0 {
+		c.Metrics = map[string]float64{updater.ElapsedKey: t.RunTime / 60}
+	}
+	c.SetMessage(updater.TextMessage(t.Message))
+	switch t.Result {
+	case TestSuccess:
+		c.Result = statuspb.TestStatus_PASS
+	case TestSkipped:
+		c.Result = statuspb.TestStatus_PASS_WITH_SKIPS
+		c.Icon = "S"
+	case TestFailure, TestError:
+		c.Result = statuspb.TestStatus_FAIL
+		if c.Message != "" {
+			c.Icon = "F"
+		}
+	default:
+		c.Result = statuspb.TestStatus_UNKNOWN
+	}
+	return c
+}
+
+// statusCell converts the status of a workflow or job into a cell, which has
+// no result when the status has yet to start or did not run.
+func statusCell(status string, started, stopped time.Time) updater.Cell {
+	var c updater.Cell
+	switch status {
+	case StatusSuccess:
+		c.Result = statuspb.TestStatus_PASS
+	case StatusFailed:
+		c.Result = statuspb.TestStatus_FAIL
+	case StatusError, StatusInfraFail, StatusUnauthorized:
+		c.Result = statuspb.TestStatus_FAIL
+		c.Icon = "E"
+		c.Message = "Status: " + status
+	case StatusCanceled:
+		c.Result = statuspb.TestStatus_CANCEL
+		c.Icon = "C"
+	case StatusRunning, StatusFailing, StatusOnHold:
+		c.Result = statuspb.TestStatus_RUNNING
+		c.Icon = "R"
+		c.Message = "Still running..."
+		return c
+	default:
+		return c
+	}
+	if !started.IsZero() && !stopped.IsZero() {
+		c.Metrics = map[string]float64{updater.ElapsedKey: stopped.Sub(started).Minutes()}
+	}
+	return c
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circleci
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+func TestTestName(t *testing.T) {
+	cases := []struct {
+		name string
+		test Test
+		want string
+	}{
+		{
+			name: "name",
+			test: Test{Name: "TestFoo"},
+			want: "TestFoo",
+		},
+		{
+			name: "qualify with the class",
+			test: Test{Name: "TestFoo", Classname: "pkg"},
+			want: "pkg.TestFoo",
+		},
+		{
+			name: "already qualified",
+			test: Test{Name: "pkg.TestFoo", Classname: "pkg"},
+			want: "pkg.TestFoo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := testName(tc.test); got != tc.want {
+				t.Errorf("testName() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTestCell(t *testing.T) {
+	long := strings.Repeat("x", 141)
+	cases := []struct {
+		name string
+		test Test
+		want updater.Cell
+	}{
+		{
+			name: "pass",
+			test: Test{Result: TestSuccess, RunTime: 30},
+			want: updater.Cell{
+				Result:  statuspb.TestStatus_PASS,
+				Metrics: map[string]float64{updater.ElapsedKey: 0.5},
+			},
+		},
+		{
+			name: "skip",
+			test: Test{Result: TestSkipped},
+			want: updater.Cell{Result: statuspb.TestStatus_PASS_WITH_SKIPS, Icon: "S"},
+		},
+		{
+			name: "fail",
+			test: Test{Result: TestFailure, Message: "boom"},
+			want: updater.Cell{Result: statuspb.TestStatus_FAIL, Icon: "F", Message: "boom"},
+		},
+		{
+			name: "truncate long messages",
+			test: Test{Result: TestError, Message: long},
+			want: updater.Cell{
+				Result:      statuspb.TestStatus_FAIL,
+				Icon:        "F",
+				Message:     long[:140],
+				FullMessage: long,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, testCell(tc.test), protocmp.Transform()); diff != "" {
+				t.Errorf("testCell() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnReader(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) float64 {
+		return float64(t.UnixNano()) / float64(time.Millisecond)
+	}
+	tg := &configpb.TestGroup{
+		Name:         "circle",
+		ColumnReader: ReaderName,
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ConfigurationValue: "Commit"},
+			{ConfigurationValue: "Branch"},
+		},
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_CircleciConfig{
+				CircleciConfig: &configpb.CircleCIConfig{
+					ProjectSlug: "gh/org/repo",
+					Workflow:    "test",
+				},
+			},
+		},
+	}
+	_, server := newServer(t, map[string]string{
+		"/project/gh/org/repo/pipeline": `{"items":[
+			{"id":"p3","number":3,"created_at":"2021-06-01T11:00:00Z","vcs":{"revision":"ccc"}},
+			{"id":"p2","number":2,"created_at":"2021-06-01T10:00:00Z","vcs":{"revision":"bbb","branch":"main"}},
+			{"id":"p1","number":1,"created_at":"2021-06-01T09:00:00Z"}
+		],"next_page_token":"more"}`,
+		"/pipeline/p3/workflow": `{"items":[
+			{"id":"w3","name":"test","status":"running","created_at":"2021-06-01T11:00:00Z"},
+			{"id":"w3-lint","name":"lint","status":"success","created_at":"2021-06-01T11:00:00Z"}
+		]}`,
+		"/pipeline/p2/workflow": `{"items":[
+			{"id":"w2","name":"test","status":"failed","created_at":"2021-06-01T10:00:00Z","stopped_at":"2021-06-01T10:30:00Z"},
+			{"id":"w2-skipped","name":"test","status":"not_run","created_at":"2021-06-01T10:00:00Z"}
+		]}`,
+		"/workflow/w3/job": `{"items":[{"id":"j3","name":"unit","type":"build","status":"running","job_number":30}]}`,
+		"/workflow/w2/job": `{"items":[
+			{"id":"j2","name":"unit","type":"build","status":"failed","job_number":20,
+			 "started_at":"2021-06-01T10:00:00Z","stopped_at":"2021-06-01T10:06:00Z"},
+			{"id":"hold","name":"approve","type":"approval","status":"on_hold"}
+		]}`,
+		"/project/gh/org/repo/30/tests": `{"items":[]}`,
+		"/project/gh/org/repo/20/tests": `{"items":[
+			{"name":"TestFoo","classname":"pkg","result":"success"},
+			{"name":"TestBar","classname":"pkg","result":"failure","message":"boom"}
+		]}`,
+	})
+	defer server.Close()
+	client := &Client{HTTP: server.Client(), BaseURL: server.URL, Token: "secret"}
+	oldCols := []updater.InflatedColumn{
+		{Column: &statepb.Column{Build: "1", Hint: "w1", Started: millis(time.Date(2021, 6, 1, 9, 30, 0, 0, time.UTC))}},
+	}
+
+	got, err := ColumnReader(client)(context.Background(), logrus.New(), tg, oldCols, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("ColumnReader() got unexpected error: %v", err)
+	}
+	running := updater.Cell{Result: statuspb.TestStatus_RUNNING, Icon: "R", Message: "Still running..."}
+	unit := running
+	unit.ID = "unit"
+	want := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{
+				Build:   "3",
+				Hint:    "w3",
+				Started: millis(time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC)),
+				Extra:   []string{"ccc", ""},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": running,
+				"unit":    unit,
+			},
+		},
+		{
+			Column: &statepb.Column{
+				Build:   "2",
+				Hint:    "w2",
+				Started: millis(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)),
+				Extra:   []string{"bbb", "main"},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": {
+					Result:  statuspb.TestStatus_FAIL,
+					Metrics: map[string]float64{updater.ElapsedKey: 30},
+				},
+				"unit": {
+					Result:  statuspb.TestStatus_FAIL,
+					ID:      "unit",
+					Metrics: map[string]float64{updater.ElapsedKey: 6},
+				},
+				"unit/pkg.TestFoo": {Result: statuspb.TestStatus_PASS, ID: "unit/pkg.TestFoo"},
+				"unit/pkg.TestBar": {
+					Result:  statuspb.TestStatus_FAIL,
+					ID:      "unit/pkg.TestBar",
+					Icon:    "F",
+					Message: "boom",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ColumnReader() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package commitstatus posts GitHub commit statuses summarizing the results
+// of dashboard tabs for the commit of each of their recent columns.
+package commitstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// States of a commit status.
+const (
+	Pending = "pending"
+	Success = "success"
+	Failure = "failure"
+)
+
+const (
+	// commitHeader is the configuration_value of the column header holding the commit.
+	commitHeader = "Commit"
+	// missingHeader is the header value of columns without the header's value.
+	missingHeader = "missing"
+
+	defaultMaxAge = 24 * time.Hour
+)
+
+// Status is a GitHub commit status.
+type Status struct {
+	SHA         string `json:"-"`
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+// StatusContext returns the context of the statuses of the dashboard tab.
+func StatusContext(dashboard, tab string) string {
+	return "testgrid/" + dashboard + "/" + tab
+}
+
+// TabURL returns the link to the dashboard tab of the frontend at the base URL.
+func TabURL(base, dashboard, tab string) string {
+	return strings.TrimSuffix(base, "/") + "/" + url.PathEscape(dashboard) + "#" + url.PathEscape(tab)
+}
+
+// commitIndex returns the index of the commit header, or -1 without one.
+func commitIndex(headers []*configpb.TestGroup_ColumnHeader) int {
+	for i, h := range headers {
+		if h.ConfigurationValue == commitHeader {
+			return i
+		}
+	}
+	return -1
+}
+
+// ColumnStatuses returns the status of the newest column of each commit which
+// started since then, summarizing the results of its rows.
+//
+// Columns without a commit or any results have no status.
+func ColumnStatuses(grid *statepb.Grid, headers []*configpb.TestGroup_ColumnHeader, context string, since time.Time) []Status {
+	idx := commitIndex(headers)
+	if idx < 0 {
+		return nil
+	}
+	results := make([][]statuspb.TestStatus, len(grid.GetRows()))
+	for i, row := range grid.GetRows() {
+		results[i] = state.Expand(row.Results)
+	}
+	seen := map[string]bool{}
+	var out []Status
+	for c, col := range grid.GetColumns() {
+		if idx >= len(col.Extra) || updater.ColumnStarted(col).Before(since) {
+			continue
+		}
+		sha := col.Extra[idx]
+		if sha == "" || sha == missingHeader || seen[sha] {
+			continue
+		}
+		var passed, failed, running int
+		for _, res := range results {
+			if c >= len(res) {
+				continue
+			}
+			if res[c] == statuspb.TestStatus_RUNNING {
+				running++
+				continue
+			}
+			switch result.Coalesce(res[c], false) {
+			case statuspb.TestStatus_PASS, statuspb.TestStatus_FLAKY:
+				passed++
+			case statuspb.TestStatus_FAIL:
+				failed++
+			}
+		}
+		total := passed + failed + running
+		if total == 0 {
+			continue
+		}
+		seen[sha] = true
+		status := Status{SHA: sha, Context: context}
+		switch {
+		case failed > 0:
+			status.State = Failure
+			status.Description = fmt.Sprintf("%d of %d tests failed", failed, total)
+		case running > 0:
+			status.State = Pending
+			status.Description = fmt.Sprintf("%d of %d tests running", running, total)
+		default:
+			status.State = Success
+			status.Description = fmt.Sprintf("%d of %d tests passed", passed, total)
+		}
+		out = append(out, status)
+	}
+	return out
+}
+
+// A Poster posts the status of a commit of the repo, such as org/repo.
+type Poster interface {
+	Post(ctx context.Context, repo string, status Status) error
+}
+
+// Client posts commit statuses with the GitHub REST API.
+type Client struct {
+	HTTP *http.Client
+	// BaseURL of the API, defaults to https://api.github.com.
+	BaseURL string
+	// Token authenticates the client when set.
+	Token string
+}
+
+// Post creates the status of the commit of the repo.
+func (c *Client) Post(ctx context.Context, repo string, status Status) error {
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	base := c.BaseURL
+	if base == "" {
+		base = "https://api.github.com"
+	}
+	buf, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	u := strings.TrimSuffix(base, "/") + "/repos/" + repo + "/statuses/" + url.PathEscape(status.SHA)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("post: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// Updater posts the commit statuses of the tabs of each dashboard which sets
+// commit_status, skipping those it already posted.
+type Updater struct {
+	Client     gcs.Opener
+	ConfigPath gcs.Path
+	GridPrefix string
+	Poster     Poster
+	// TestGridURL is the base URL of the frontend which statuses link to when set.
+	TestGridURL string
+
+	posted map[string]string
+}
+
+// Update posts the changed statuses of every dashboard, or only of the
+// dashboard when set, when confirm is set.
+func (u *Updater) Update(ctx context.Context, dashboard string, now time.Time, confirm bool) error {
+	cfg, err := config.ReadGCS(ctx, u.Client, u.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	if u.posted == nil {
+		u.posted = map[string]string{}
+	}
+	var failures int
+	for _, d := range cfg.Dashboards {
+		opts := d.GetCommitStatus()
+		if opts == nil || (dashboard != "" && d.Name != dashboard) {
+			continue
+		}
+		maxAge := defaultMaxAge
+		if opts.MaxAgeHours > 0 {
+			maxAge = time.Duration(opts.MaxAgeHours) * time.Hour
+		}
+		selected := map[string]bool{}
+		for _, tab := range opts.Tabs {
+			selected[tab] = true
+		}
+		for _, tab := range d.DashboardTab {
+			if len(selected) > 0 && !selected[tab.Name] {
+				continue
+			}
+			log := logrus.WithFields(logrus.Fields{
+				"dashboard": d.Name,
+				"tab":       tab.Name,
+				"repo":      opts.Repo,
+			})
+			if err := u.updateTab(ctx, log, cfg, d.Name, tab, opts.Repo, now.Add(-maxAge), confirm); err != nil {
+				log.WithError(err).Error("Failed to post commit statuses")
+				failures++
+			}
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to post the statuses of %d tabs", failures)
+	}
+	return nil
+}
+
+func (u *Updater) updateTab(ctx context.Context, log logrus.FieldLogger, cfg *configpb.Configuration, dashboard string, tab *configpb.DashboardTab, repo string, since time.Time, confirm bool) error {
+	group := config.FindTestGroup(tab.TestGroupName, cfg)
+	if group == nil {
+		return fmt.Errorf("no test group %s", tab.TestGroupName)
+	}
+	gridPath, err := updater.TestGroupPath(u.ConfigPath, u.GridPrefix, tab.TestGroupName)
+	if err != nil {
+		return fmt.Errorf("grid path: %w", err)
+	}
+	grid, err := gcs.DownloadGrid(ctx, u.Client, *gridPath)
+	if err != nil {
+		return fmt.Errorf("read grid: %w", err)
+	}
+	if grid.Rows, err = state.Filter(grid.Rows, tab.BaseOptions); err != nil {
+		return fmt.Errorf("filter rows: %w", err)
+	}
+	statuses := ColumnStatuses(grid, group.ColumnHeader, StatusContext(dashboard, tab.Name), since)
+	var failures int
+	for _, status := range statuses {
+		if u.TestGridURL != "" {
+			status.TargetURL = TabURL(u.TestGridURL, dashboard, tab.Name)
+		}
+		key := repo + "/" + status.SHA + "/" + status.Context
+		value := status.State + ": " + status.Description
+		if u.posted[key] == value {
+			continue
+		}
+		log := log.WithFields(logrus.Fields{
+			"sha":   status.SHA,
+			"state": status.State,
+		})
+		if !confirm {
+			log.Info("Skipping commit status (dry run)")
+			continue
+		}
+		if err := u.Poster.Post(ctx, repo, status); err != nil {
+			log.WithError(err).Warning("Failed to post commit status")
+			failures++
+			continue
+		}
+		u.posted[key] = value
+		log.Debug("Posted commit status")
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to post %d of %d statuses", failures, len(statuses))
+	}
+	return nil
+}
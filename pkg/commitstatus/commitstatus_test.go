@@ -0,0 +1,296 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitstatus
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func results(statuses ...statuspb.TestStatus) []int32 {
+	var out []int32
+	for _, s := range statuses {
+		out = append(out, int32(s), 1)
+	}
+	return out
+}
+
+func millis(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Millisecond)
+}
+
+func TestColumnStatuses(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	headers := []*configpb.TestGroup_ColumnHeader{
+		{ConfigurationValue: "Repo"},
+		{ConfigurationValue: "Commit"},
+	}
+	col := func(sha string, age time.Duration) *statepb.Column {
+		return &statepb.Column{
+			Started: millis(now.Add(-age)),
+			Extra:   []string{"repo", sha},
+		}
+	}
+	const (
+		pass    = statuspb.TestStatus_PASS
+		fail    = statuspb.TestStatus_FAIL
+		flaky   = statuspb.TestStatus_FLAKY
+		running = statuspb.TestStatus_RUNNING
+		none    = statuspb.TestStatus_NO_RESULT
+	)
+	cases := []struct {
+		name    string
+		headers []*configpb.TestGroup_ColumnHeader
+		grid    *statepb.Grid
+		want    []Status
+	}{
+		{
+			name: "no commit header",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{col("abc", time.Hour)},
+				Rows:    []*statepb.Row{{Name: "test", Results: results(pass)}},
+			},
+		},
+		{
+			name:    "summarize each commit",
+			headers: headers,
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					col("ccc", time.Hour),
+					col("bbb", 2*time.Hour),
+					col("aaa", 3*time.Hour),
+				},
+				Rows: []*statepb.Row{
+					{Name: "first", Results: results(pass, running, fail)},
+					{Name: "second", Results: results(flaky, pass, pass)},
+					{Name: "third", Results: results(none, pass, pass)},
+				},
+			},
+			want: []Status{
+				{SHA: "ccc", State: Success, Description: "2 of 2 tests passed", Context: "ctx"},
+				{SHA: "bbb", State: Pending, Description: "1 of 3 tests running", Context: "ctx"},
+				{SHA: "aaa", State: Failure, Description: "1 of 3 tests failed", Context: "ctx"},
+			},
+		},
+		{
+			name:    "only the newest column of a commit",
+			headers: headers,
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					col("abc", time.Hour),
+					col("abc", 2*time.Hour),
+				},
+				Rows: []*statepb.Row{{Name: "test", Results: results(pass, fail)}},
+			},
+			want: []Status{
+				{SHA: "abc", State: Success, Description: "1 of 1 tests passed", Context: "ctx"},
+			},
+		},
+		{
+			name:    "skip old, empty and commitless columns",
+			headers: headers,
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					col("missing", time.Hour),
+					col("", time.Hour),
+					col("empty", time.Hour),
+					{Started: millis(now)},
+					col("old", 48*time.Hour),
+				},
+				Rows: []*statepb.Row{{Name: "test", Results: results(fail, fail, none, fail, fail)}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ColumnStatuses(tc.grid, tc.headers, "ctx", now.Add(-defaultMaxAge))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ColumnStatuses() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientPost(t *testing.T) {
+	status := Status{
+		SHA:         "abc",
+		State:       Failure,
+		TargetURL:   "https://testgrid.example/dash#tab",
+		Description: "1 of 2 tests failed",
+		Context:     "testgrid/dash/tab",
+	}
+	cases := []struct {
+		name    string
+		code    int
+		wantErr bool
+	}{
+		{
+			name: "post",
+			code: http.StatusCreated,
+		},
+		{
+			name:    "rejected",
+			code:    http.StatusUnprocessableEntity,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Status
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/repos/org/repo/statuses/abc" {
+					t.Errorf("Post() sent %s %s", r.Method, r.URL.Path)
+				}
+				if auth := r.Header.Get("Authorization"); auth != "token secret" {
+					t.Errorf("Post() sent authorization %q", auth)
+				}
+				buf, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("read body: %v", err)
+				}
+				if err := json.Unmarshal(buf, &got); err != nil {
+					t.Errorf("unmarshal body: %v", err)
+				}
+				w.WriteHeader(tc.code)
+			}))
+			defer server.Close()
+			client := Client{HTTP: server.Client(), BaseURL: server.URL, Token: "secret"}
+			err := client.Post(context.Background(), "org/repo", status)
+			switch {
+			case err != nil && !tc.wantErr:
+				t.Errorf("Post() got unexpected error: %v", err)
+			case err == nil && tc.wantErr:
+				t.Error("Post() failed to return an error")
+			}
+			want := status
+			want.SHA = ""
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Post() sent unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// recordingPoster records the statuses it posts.
+type recordingPoster struct {
+	posted []string
+}
+
+func (p *recordingPoster) Post(_ context.Context, repo string, s Status) error {
+	p.posted = append(p.posted, repo+"@"+s.SHA+" "+s.Context+": "+s.State+" "+s.TargetURL)
+	return nil
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{
+			{
+				Name:         "group",
+				ColumnHeader: []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: "Commit"}},
+			},
+		},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "all", TestGroupName: "group"},
+					{Name: "bar", TestGroupName: "group", BaseOptions: "include-filter-by-regex=bar"},
+					{Name: "unselected", TestGroupName: "group"},
+				},
+				CommitStatus: &configpb.CommitStatusOptions{
+					Repo: "org/repo",
+					Tabs: []string{"all", "bar"},
+				},
+			},
+			{
+				Name:         "other",
+				DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+			},
+		},
+	}
+	cfgBuf, err := proto.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	gridPath, err := updater.TestGroupPath(*configPath, "grid", "group")
+	if err != nil {
+		t.Fatalf("TestGroupPath(): %v", err)
+	}
+	gridBuf, err := gcs.MarshalGrid(&statepb.Grid{
+		Columns: []*statepb.Column{{Started: millis(now.Add(-time.Hour)), Extra: []string{"abc"}}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: results(statuspb.TestStatus_FAIL)},
+			{Name: "bar", Results: results(statuspb.TestStatus_PASS)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal grid: %v", err)
+	}
+	opener := fake.Opener{
+		*configPath: {Data: string(cfgBuf)},
+		*gridPath:   {Data: string(gridBuf)},
+	}
+
+	var poster recordingPoster
+	u := Updater{
+		Client:      opener,
+		ConfigPath:  *configPath,
+		GridPrefix:  "grid",
+		Poster:      &poster,
+		TestGridURL: "https://testgrid.example",
+	}
+	if err := u.Update(context.Background(), "", now, false); err != nil {
+		t.Fatalf("Update() got unexpected error: %v", err)
+	}
+	if len(poster.posted) > 0 {
+		t.Errorf("Update() posted without confirm: %v", poster.posted)
+	}
+	for i := 0; i < 2; i++ {
+		if err := u.Update(context.Background(), "", now, true); err != nil {
+			t.Fatalf("Update() got unexpected error: %v", err)
+		}
+	}
+	want := []string{
+		"org/repo@abc testgrid/dash/all: failure https://testgrid.example/dash#all",
+		"org/repo@abc testgrid/dash/bar: success https://testgrid.example/dash#bar",
+	}
+	if diff := cmp.Diff(want, poster.posted); diff != "" {
+		t.Errorf("Update() posted unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package costs attributes the storage used by the updater to each test group,
+// so the storage bill can be split among dashboards.
+package costs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	costspb "github.com/GoogleCloudPlatform/testgrid/pb/costs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Usage counts the storage operations of a client.
+type Usage struct {
+	BytesRead      int64
+	BytesWritten   int64
+	ObjectsRead    int64
+	ObjectsWritten int64
+	Lists          int64
+	Stats          int64
+}
+
+// Client counts the storage used by the wrapped client.
+type Client struct {
+	gcs.Client
+	usage *Usage
+}
+
+// NewClient returns a client which counts its storage operations into the usage.
+func NewClient(client gcs.Client, usage *Usage) *Client {
+	return &Client{Client: client, usage: usage}
+}
+
+// Open counts the object along with the bytes read from it.
+func (c *Client) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, error) {
+	r, err := c.Client.Open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&c.usage.ObjectsRead, 1)
+	return &countingReader{ReadCloser: r, n: &c.usage.BytesRead}, nil
+}
+
+// Upload counts the object along with its bytes.
+func (c *Client) Upload(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl string) error {
+	atomic.AddInt64(&c.usage.ObjectsWritten, 1)
+	atomic.AddInt64(&c.usage.BytesWritten, int64(len(buf)))
+	return c.Client.Upload(ctx, path, buf, worldReadable, cacheControl)
+}
+
+// Copy counts the copied object, whose bytes never leave storage.
+func (c *Client) Copy(ctx context.Context, from, to gcs.Path) error {
+	atomic.AddInt64(&c.usage.ObjectsWritten, 1)
+	return c.Client.Copy(ctx, from, to)
+}
+
+// Objects counts the list.
+func (c *Client) Objects(ctx context.Context, prefix gcs.Path, delimiter, start string) gcs.Iterator {
+	atomic.AddInt64(&c.usage.Lists, 1)
+	return c.Client.Objects(ctx, prefix, delimiter, start)
+}
+
+// Stat counts the stat.
+func (c *Client) Stat(ctx context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	atomic.AddInt64(&c.usage.Stats, 1)
+	return c.Client.Stat(ctx, path)
+}
+
+// countingReader adds the bytes it reads to n.
+type countingReader struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	atomic.AddInt64(r.n, int64(n))
+	return n, err
+}
+
+// Tracker accumulates the storage used by each group during a cycle.
+type Tracker struct {
+	start  time.Time
+	lock   sync.Mutex
+	groups map[string]*costspb.GroupCost
+}
+
+// NewTracker returns a tracker for a cycle which starts now.
+func NewTracker(now time.Time) *Tracker {
+	return &Tracker{
+		start:  now,
+		groups: map[string]*costspb.GroupCost{},
+	}
+}
+
+// Track returns a group updater which records the storage each update uses,
+// and whether the write guard quarantined it.
+func (t *Tracker) Track(update updater.GroupUpdater) updater.GroupUpdater {
+	return func(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		var usage Usage
+		err := update(ctx, log, NewClient(client, &usage), tg, gridPath)
+		t.add(tg.Name, &usage, errors.Is(err, updater.ErrQuarantined))
+		return err
+	}
+}
+
+func (t *Tracker) add(name string, usage *Usage, quarantined bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	gc, ok := t.groups[name]
+	if !ok {
+		gc = &costspb.GroupCost{Name: name}
+		t.groups[name] = gc
+	}
+	gc.Updates++
+	gc.Quarantined = quarantined
+	gc.BytesRead += atomic.LoadInt64(&usage.BytesRead)
+	gc.BytesWritten += atomic.LoadInt64(&usage.BytesWritten)
+	gc.ObjectsRead += atomic.LoadInt64(&usage.ObjectsRead)
+	gc.ObjectsWritten += atomic.LoadInt64(&usage.ObjectsWritten)
+	gc.Lists += atomic.LoadInt64(&usage.Lists)
+	gc.Stats += atomic.LoadInt64(&usage.Stats)
+}
+
+// Report returns the storage each group used since the tracker started, along
+// with the dashboards of each group in the config.
+func (t *Tracker) Report(cfg *configpb.Configuration, end time.Time) *costspb.CostReport {
+	dashboards := map[string][]string{}
+	for _, d := range cfg.GetDashboards() {
+		seen := map[string]bool{}
+		for _, tab := range d.DashboardTab {
+			if seen[tab.TestGroupName] {
+				continue
+			}
+			seen[tab.TestGroupName] = true
+			dashboards[tab.TestGroupName] = append(dashboards[tab.TestGroupName], d.Name)
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	report := costspb.CostReport{
+		Start: float64(t.start.Unix()),
+		End:   float64(end.Unix()),
+	}
+	for name, gc := range t.groups {
+		gc := proto.Clone(gc).(*costspb.GroupCost)
+		gc.Dashboards = dashboards[name]
+		sort.Strings(gc.Dashboards)
+		report.Groups = append(report.Groups, gc)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Name < report.Groups[j].Name
+	})
+	return &report
+}
+
+// Write uploads the report to the path.
+func Write(ctx context.Context, client gcs.Uploader, path gcs.Path, report *costspb.CostReport) error {
+	buf, err := proto.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if err := client.Upload(ctx, path, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
+
+// Read returns the report at the path.
+func Read(ctx context.Context, opener gcs.Opener, path gcs.Path) (*costspb.CostReport, error) {
+	r, err := opener.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var report costspb.CostReport
+	if err := proto.Unmarshal(buf, &report); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	costspb "github.com/GoogleCloudPlatform/testgrid/pb/costs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+	}
+	return *p
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	hello := mustPath(t, "gs://bucket/hello")
+	missing := mustPath(t, "gs://bucket/missing")
+	client := fake.UploadClient{
+		Client: fake.Client{
+			Opener: fake.Opener{hello: {Data: "hello world"}},
+			Lister: fake.Lister{},
+		},
+		Uploader: fake.Uploader{},
+		Stater:   fake.Stater{},
+	}
+	var usage Usage
+	c := NewClient(client, &usage)
+
+	r, err := c.Open(ctx, hello)
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatalf("ReadAll() got unexpected error: %v", err)
+	}
+	r.Close()
+	if _, err := c.Open(ctx, missing); err == nil {
+		t.Error("Open() of a missing object failed to return an error")
+	}
+	if err := c.Upload(ctx, mustPath(t, "gs://bucket/out"), []byte("12345"), gcs.DefaultACL, "no-cache"); err != nil {
+		t.Errorf("Upload() got unexpected error: %v", err)
+	}
+	if err := c.Copy(ctx, mustPath(t, "gs://bucket/out"), mustPath(t, "gs://bucket/copy")); err != nil {
+		t.Errorf("Copy() got unexpected error: %v", err)
+	}
+	c.Objects(ctx, mustPath(t, "gs://bucket/"), "/", "")
+	c.Stat(ctx, hello)
+	c.Stat(ctx, missing)
+
+	want := Usage{
+		BytesRead:      11,
+		BytesWritten:   5,
+		ObjectsRead:    1,
+		ObjectsWritten: 2,
+		Lists:          1,
+		Stats:          2,
+	}
+	if diff := cmp.Diff(want, usage); diff != "" {
+		t.Errorf("NewClient() counted unexpected usage (-want +got):\n%s", diff)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := start.Add(time.Minute)
+	gridPath := mustPath(t, "gs://bucket/grid")
+	update := func(ctx context.Context, _ logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, _ gcs.Path) error {
+		if err := client.Upload(ctx, gridPath, []byte(tg.Name), gcs.DefaultACL, "no-cache"); err != nil {
+			return err
+		}
+		switch tg.Name {
+		case "broken":
+			return errors.New("boom")
+		case "guarded":
+			return fmt.Errorf("%w grid: dropped too much", updater.ErrQuarantined)
+		}
+		return nil
+	}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "b-dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "one", TestGroupName: "group"},
+					{Name: "two", TestGroupName: "group"},
+				},
+			},
+			{
+				Name:         "a-dash",
+				DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+			},
+		},
+	}
+
+	tracker := NewTracker(start)
+	tracked := tracker.Track(update)
+	client := fake.UploadClient{Uploader: fake.Uploader{}}
+	for _, name := range []string{"group", "group", "broken", "guarded"} {
+		err := tracked(context.Background(), logrus.New(), client, &configpb.TestGroup{Name: name}, gridPath)
+		if (err != nil) != (name == "broken" || name == "guarded") {
+			t.Errorf("Track() of %s got unexpected error: %v", name, err)
+		}
+	}
+
+	want := &costspb.CostReport{
+		Start: 1000,
+		End:   1060,
+		Groups: []*costspb.GroupCost{
+			{
+				Name:           "broken",
+				Updates:        1,
+				BytesWritten:   6,
+				ObjectsWritten: 1,
+			},
+			{
+				Name:           "group",
+				Dashboards:     []string{"a-dash", "b-dash"},
+				Updates:        2,
+				BytesWritten:   10,
+				ObjectsWritten: 2,
+			},
+			{
+				Name:           "guarded",
+				Updates:        1,
+				BytesWritten:   7,
+				ObjectsWritten: 1,
+				Quarantined:    true,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, tracker.Report(cfg, end), protocmp.Transform()); diff != "" {
+		t.Errorf("Report() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	path := mustPath(t, "gs://bucket/costs")
+	report := &costspb.CostReport{
+		Start:  1000,
+		End:    1060,
+		Groups: []*costspb.GroupCost{{Name: "group", BytesRead: 7}},
+	}
+	uploader := fake.Uploader{}
+	if err := Write(context.Background(), uploader, path, report); err != nil {
+		t.Fatalf("Write() got unexpected error: %v", err)
+	}
+	buf, err := proto.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal() got unexpected error: %v", err)
+	}
+	if got := uploader[path].Buf; string(got) != string(buf) {
+		t.Errorf("Write() uploaded %q, want %q", got, buf)
+	}
+
+	got, err := Read(context.Background(), fake.Opener{path: {Data: string(uploader[path].Buf)}}, path)
+	if err != nil {
+		t.Fatalf("Read() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(report, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Read() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// ResourceError describes why a resource was left out of the configuration.
+type ResourceError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+func (e ResourceError) Error() string {
+	return fmt.Sprintf("%s %s/%s: %s", e.Kind, e.Namespace, e.Name, e.Reason)
+}
+
+// sortTestGroups orders the oldest resources first, so they keep contested names.
+func sortTestGroups(groups []TestGroup) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].ObjectMeta, groups[j].ObjectMeta
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// sortDashboards orders the oldest resources first, so they keep contested names.
+func sortDashboards(dashboards []Dashboard) {
+	sort.SliceStable(dashboards, func(i, j int) bool {
+		a, b := dashboards[i].ObjectMeta, dashboards[j].ObjectMeta
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// Assemble returns the configuration described by the resources.
+//
+// Resources which cannot be included, such as those reusing the name of an
+// older resource or dashboards referring to missing test groups, are left
+// out and described by the returned errors, so one team's mistake does not
+// block everyone else. Test groups which no dashboard displays are left out
+// as well.
+func Assemble(groups []TestGroup, dashboards []Dashboard) (*configpb.Configuration, []error) {
+	groups = append([]TestGroup(nil), groups...)
+	dashboards = append([]Dashboard(nil), dashboards...)
+	sortTestGroups(groups)
+	sortDashboards(dashboards)
+
+	var errs []error
+	type namespaced struct {
+		namespace string
+		name      string
+	}
+	// Test groups by resource, and which resource owns each normalized name.
+	resources := map[namespaced]*configpb.TestGroup{}
+	owners := map[string]*configpb.TestGroup{}
+	type accepted struct {
+		resource TestGroup
+		group    *configpb.TestGroup
+	}
+	var tgs []accepted
+	for _, r := range groups {
+		fail := func(reason string) {
+			errs = append(errs, ResourceError{TestGroupKind, r.Namespace, r.ObjectMeta.Name, reason})
+		}
+		if r.Spec == nil {
+			fail("missing spec")
+			continue
+		}
+		name := r.ConfigName()
+		key := config.Normalize(name)
+		if _, ok := owners[key]; ok {
+			fail(fmt.Sprintf("test group %q belongs to an older resource", name))
+			continue
+		}
+		tg := proto.Clone(r.Spec).(*configpb.TestGroup)
+		tg.Name = name
+		owners[key] = tg
+		resources[namespaced{r.Namespace, r.ObjectMeta.Name}] = tg
+		tgs = append(tgs, accepted{r, tg})
+	}
+
+	var cfg configpb.Configuration
+	displayed := map[string]bool{}
+	dashOwners := map[string]bool{}
+	for _, r := range dashboards {
+		fail := func(reason string) {
+			errs = append(errs, ResourceError{DashboardKind, r.Namespace, r.ObjectMeta.Name, reason})
+		}
+		if r.Spec == nil {
+			fail("missing spec")
+			continue
+		}
+		name := r.ConfigName()
+		key := config.Normalize(name)
+		if dashOwners[key] {
+			fail(fmt.Sprintf("dashboard %q belongs to an older resource", name))
+			continue
+		}
+		if len(r.Spec.DashboardTab) == 0 {
+			fail("contains no tabs")
+			continue
+		}
+		d := proto.Clone(r.Spec).(*configpb.Dashboard)
+		d.Name = name
+		var missing []string
+		for _, tab := range d.DashboardTab {
+			if tg, ok := resources[namespaced{r.Namespace, tab.TestGroupName}]; ok {
+				tab.TestGroupName = tg.Name
+				continue
+			}
+			if tg, ok := owners[config.Normalize(tab.TestGroupName)]; ok {
+				tab.TestGroupName = tg.Name
+				continue
+			}
+			missing = append(missing, tab.TestGroupName)
+		}
+		if len(missing) > 0 {
+			fail(fmt.Sprintf("tabs refer to missing test groups: %v", missing))
+			continue
+		}
+		dashOwners[key] = true
+		for _, tab := range d.DashboardTab {
+			displayed[tab.TestGroupName] = true
+		}
+		cfg.Dashboards = append(cfg.Dashboards, d)
+	}
+
+	for _, a := range tgs {
+		if !displayed[a.group.Name] {
+			r := a.resource
+			errs = append(errs, ResourceError{TestGroupKind, r.Namespace, r.ObjectMeta.Name, "no dashboard tab displays this test group"})
+			continue
+		}
+		cfg.TestGroups = append(cfg.TestGroups, a.group)
+	}
+	return &cfg, errs
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func meta(namespace, name string, age int) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		CreationTimestamp: metav1.NewTime(time.Unix(1000-int64(age), 0)),
+	}
+}
+
+func TestAssemble(t *testing.T) {
+	cases := []struct {
+		name       string
+		groups     []TestGroup
+		dashboards []Dashboard
+		want       *configpb.Configuration
+		wantErrs   []string
+	}{
+		{
+			name: "empty",
+			want: &configpb.Configuration{},
+		},
+		{
+			name: "resolve tabs within the namespace",
+			groups: []TestGroup{
+				{ObjectMeta: meta("team-a", "e2e", 0), Spec: &configpb.TestGroup{GcsPrefix: "bucket/a", DaysOfResults: 3}},
+				{ObjectMeta: meta("team-b", "e2e", 0), Spec: &configpb.TestGroup{Name: "team-b-e2e", GcsPrefix: "bucket/b"}},
+			},
+			dashboards: []Dashboard{
+				{
+					ObjectMeta: meta("team-b", "dash", 0),
+					Spec: &configpb.Dashboard{
+						Name:         "Team B",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e"}},
+					},
+				},
+				{
+					ObjectMeta: meta("team-a", "team-a", 0),
+					Spec: &configpb.Dashboard{
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "mine", TestGroupName: "e2e"},
+							{Name: "theirs", TestGroupName: "Team-B-E2E"},
+						},
+					},
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{
+					{Name: "e2e", GcsPrefix: "bucket/a", DaysOfResults: 3},
+					{Name: "team-b-e2e", GcsPrefix: "bucket/b"},
+				},
+				Dashboards: []*configpb.Dashboard{
+					{
+						Name: "team-a",
+						DashboardTab: []*configpb.DashboardTab{
+							{Name: "mine", TestGroupName: "e2e"},
+							{Name: "theirs", TestGroupName: "team-b-e2e"},
+						},
+					},
+					{
+						Name:         "Team B",
+						DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "team-b-e2e"}},
+					},
+				},
+			},
+		},
+		{
+			name: "older resources keep contested names",
+			groups: []TestGroup{
+				{ObjectMeta: meta("new", "e2e", 1), Spec: &configpb.TestGroup{GcsPrefix: "bucket/new"}},
+				{ObjectMeta: meta("old", "e2e", 2), Spec: &configpb.TestGroup{GcsPrefix: "bucket/old"}},
+			},
+			dashboards: []Dashboard{
+				{
+					ObjectMeta: meta("new", "dash", 1),
+					Spec:       &configpb.Dashboard{DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e"}}},
+				},
+				{
+					ObjectMeta: meta("old", "Dash", 2),
+					Spec:       &configpb.Dashboard{DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e"}}},
+				},
+			},
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "e2e", GcsPrefix: "bucket/old"}},
+				Dashboards: []*configpb.Dashboard{
+					{Name: "Dash", DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e"}}},
+				},
+			},
+			wantErrs: []string{
+				`TestGroup new/e2e: test group "e2e" belongs to an older resource`,
+				`Dashboard new/dash: dashboard "dash" belongs to an older resource`,
+			},
+		},
+		{
+			name: "skip broken resources",
+			groups: []TestGroup{
+				{ObjectMeta: meta("ns", "nospec", 0)},
+				{ObjectMeta: meta("ns", "hidden", 0), Spec: &configpb.TestGroup{}},
+			},
+			dashboards: []Dashboard{
+				{ObjectMeta: meta("ns", "notabs", 0), Spec: &configpb.Dashboard{}},
+				{
+					ObjectMeta: meta("ns", "missing", 0),
+					Spec:       &configpb.Dashboard{DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "nope"}}},
+				},
+			},
+			want: &configpb.Configuration{},
+			wantErrs: []string{
+				"TestGroup ns/nospec: missing spec",
+				"Dashboard ns/missing: tabs refer to missing test groups: [nope]",
+				"Dashboard ns/notabs: contains no tabs",
+				"TestGroup ns/hidden: no dashboard tab displays this test group",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, errs := Assemble(tc.groups, tc.dashboards)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Assemble() got unexpected diff (-want +got):\n%s", diff)
+			}
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if diff := cmp.Diff(tc.wantErrs, gotErrs); diff != "" {
+				t.Errorf("Assemble() got unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	pageSize          = "500"
+)
+
+// APIClient lists the custom resources from a Kubernetes API server.
+type APIClient struct {
+	// Host is the URL of the API server, such as https://kubernetes.default.svc
+	Host string
+	// Token authenticates requests when set.
+	Token  string
+	Client *http.Client
+}
+
+// InClusterClient returns a client for the API server using the pod's service account.
+func InClusterClient() (*APIClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a cluster")
+	}
+	token, err := ioutil.ReadFile(path.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("read token: %w", err)
+	}
+	ca, err := ioutil.ReadFile(path.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("read ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("no certificates in ca.crt")
+	}
+	return &APIClient{
+		Host:  "https://" + net.JoinHostPort(host, port),
+		Token: strings.TrimSpace(string(token)),
+		Client: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+	}, nil
+}
+
+// resourcePath returns the API path listing the resource in the namespace, or every namespace if empty.
+func resourcePath(namespace, resource string) string {
+	if namespace == "" {
+		return path.Join("/apis", Group, Version, resource)
+	}
+	return path.Join("/apis", Group, Version, "namespaces", namespace, resource)
+}
+
+// list pages through the resource, decoding each page with decode.
+func (c APIClient) list(ctx context.Context, namespace, resource string, decode func([]byte) (string, error)) error {
+	base, err := url.Parse(c.Host)
+	if err != nil {
+		return fmt.Errorf("parse host: %w", err)
+	}
+	base.Path = resourcePath(namespace, resource)
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var cont string
+	for {
+		q := url.Values{"limit": {pageSize}}
+		if cont != "" {
+			q.Set("continue", cont)
+		}
+		base.RawQuery = q.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.String(), nil)
+		if err != nil {
+			return fmt.Errorf("request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		if c.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("get %s: %w", base.Path, err)
+		}
+		buf, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("read %s: %w", base.Path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("get %s: %s: %s", base.Path, resp.Status, buf)
+		}
+		if cont, err = decode(buf); err != nil {
+			return fmt.Errorf("decode %s: %w", base.Path, err)
+		}
+		if cont == "" {
+			return nil
+		}
+	}
+}
+
+// TestGroups returns the TestGroup resources in the namespace, or every namespace if empty.
+func (c APIClient) TestGroups(ctx context.Context, namespace string) ([]TestGroup, error) {
+	var out []TestGroup
+	err := c.list(ctx, namespace, testGroupResource, func(buf []byte) (string, error) {
+		var list TestGroupList
+		if err := json.Unmarshal(buf, &list); err != nil {
+			return "", err
+		}
+		out = append(out, list.Items...)
+		return list.Continue, nil
+	})
+	return out, err
+}
+
+// Dashboards returns the Dashboard resources in the namespace, or every namespace if empty.
+func (c APIClient) Dashboards(ctx context.Context, namespace string) ([]Dashboard, error) {
+	var out []Dashboard
+	err := c.list(ctx, namespace, dashboardResource, func(buf []byte) (string, error) {
+		var list DashboardList
+		if err := json.Unmarshal(buf, &list); err != nil {
+			return "", err
+		}
+		out = append(out, list.Items...)
+		return list.Continue, nil
+	})
+	return out, err
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestResourcePath(t *testing.T) {
+	if got, want := resourcePath("", testGroupResource), "/apis/testgrid.k8s.io/v1alpha1/testgroups"; got != want {
+		t.Errorf("resourcePath() got %q, want %q", got, want)
+	}
+	if got, want := resourcePath("team", dashboardResource), "/apis/testgrid.k8s.io/v1alpha1/namespaces/team/dashboards"; got != want {
+		t.Errorf("resourcePath() got %q, want %q", got, want)
+	}
+}
+
+func TestAPIClient(t *testing.T) {
+	pages := map[string]TestGroupList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page2"},
+			Items:    []TestGroup{{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "first"}, Spec: &configpb.TestGroup{DaysOfResults: 1}}},
+		},
+		"page2": {
+			Items: []TestGroup{{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "second"}}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case resourcePath("ns", testGroupResource):
+			page, ok := pages[r.URL.Query().Get("continue")]
+			if !ok {
+				http.Error(w, "bad continue", http.StatusGone)
+				return
+			}
+			json.NewEncoder(w).Encode(page)
+		case resourcePath("ns", dashboardResource):
+			json.NewEncoder(w).Encode(DashboardList{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := APIClient{Host: server.URL, Token: "secret"}
+	groups, err := client.TestGroups(ctx, "ns")
+	if err != nil {
+		t.Fatalf("TestGroups() got unexpected error: %v", err)
+	}
+	var names []string
+	var specs []*configpb.TestGroup
+	for _, tg := range groups {
+		names = append(names, tg.ObjectMeta.Name)
+		specs = append(specs, tg.Spec)
+	}
+	if diff := cmp.Diff([]string{"first", "second"}, names); diff != "" {
+		t.Errorf("TestGroups() got unexpected names (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*configpb.TestGroup{{DaysOfResults: 1}, nil}, specs, protocmp.Transform()); diff != "" {
+		t.Errorf("TestGroups() got unexpected specs (-want +got):\n%s", diff)
+	}
+	if dashboards, err := client.Dashboards(ctx, "ns"); err != nil || len(dashboards) != 0 {
+		t.Errorf("Dashboards() got %v, %v, want no dashboards", dashboards, err)
+	}
+	if _, err := client.TestGroups(ctx, ""); err == nil {
+		t.Error("TestGroups() of a missing path failed to return an error")
+	}
+	client.Token = "wrong"
+	if _, err := client.TestGroups(ctx, "ns"); err == nil {
+		t.Error("TestGroups() without authorization failed to return an error")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Lister returns the custom resources in a namespace, or every namespace if empty.
+type Lister interface {
+	TestGroups(ctx context.Context, namespace string) ([]TestGroup, error)
+	Dashboards(ctx context.Context, namespace string) ([]Dashboard, error)
+}
+
+var _ Lister = APIClient{}
+
+// Syncer writes the configuration assembled from the resources to a path,
+// which the config merger can then converge with the other configurations.
+type Syncer struct {
+	Lister    Lister
+	Client    gcs.Uploader
+	Namespace string
+	Target    gcs.Path
+
+	last []byte
+}
+
+// Sync assembles the current resources and writes the configuration when it
+// changed and confirm is set.
+//
+// Resources left out of the configuration are logged. An invalid configuration
+// is never written, leaving the previous one in place.
+func (s *Syncer) Sync(ctx context.Context, confirm bool) error {
+	groups, err := s.Lister.TestGroups(ctx, s.Namespace)
+	if err != nil {
+		return fmt.Errorf("list test groups: %w", err)
+	}
+	dashboards, err := s.Lister.Dashboards(ctx, s.Namespace)
+	if err != nil {
+		return fmt.Errorf("list dashboards: %w", err)
+	}
+	cfg, errs := Assemble(groups, dashboards)
+	log := logrus.WithField("target", s.Target)
+	for _, err := range errs {
+		log.WithError(err).Warning("Skipped resource")
+	}
+	// Without any resources the empty configuration is written, which removes
+	// everything previously assembled once merged.
+	if len(cfg.TestGroups) > 0 || len(cfg.Dashboards) > 0 {
+		if err := config.Validate(cfg); err != nil {
+			return fmt.Errorf("validate: %w", err)
+		}
+	}
+	buf, err := proto.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	log = log.WithFields(logrus.Fields{
+		"groups":     len(cfg.TestGroups),
+		"dashboards": len(cfg.Dashboards),
+		"skipped":    len(errs),
+	})
+	if s.last != nil && bytes.Equal(buf, s.last) {
+		log.Debug("Configuration unchanged")
+		return nil
+	}
+	if !confirm {
+		log.Info("Assembled configuration (dry run)")
+		return nil
+	}
+	if err := s.Client.Upload(ctx, s.Target, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload %s: %w", s.Target, err)
+	}
+	s.last = buf
+	log.Info("Wrote configuration")
+	return nil
+}
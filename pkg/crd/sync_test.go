@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+type fakeLister struct {
+	groups     []TestGroup
+	dashboards []Dashboard
+	err        error
+}
+
+func (f fakeLister) TestGroups(context.Context, string) ([]TestGroup, error) {
+	return f.groups, f.err
+}
+
+func (f fakeLister) Dashboards(context.Context, string) ([]Dashboard, error) {
+	return f.dashboards, f.err
+}
+
+func TestSync(t *testing.T) {
+	target, err := gcs.NewPath("gs://bucket/shards/crd")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	valid := fakeLister{
+		groups: []TestGroup{{ObjectMeta: meta("ns", "e2e-tests", 0), Spec: &configpb.TestGroup{GcsPrefix: "bucket/logs/e2e", DaysOfResults: 1, NumColumnsRecent: 1}}},
+		dashboards: []Dashboard{{
+			ObjectMeta: meta("ns", "team-dash", 0),
+			Spec:       &configpb.Dashboard{DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e-tests"}}},
+		}},
+	}
+	cases := []struct {
+		name    string
+		lister  fakeLister
+		confirm bool
+		want    *configpb.Configuration
+		wantErr bool
+	}{
+		{
+			name:   "dry run",
+			lister: valid,
+		},
+		{
+			name:    "write assembled config",
+			lister:  valid,
+			confirm: true,
+			want: &configpb.Configuration{
+				TestGroups: []*configpb.TestGroup{{Name: "e2e-tests", GcsPrefix: "bucket/logs/e2e", DaysOfResults: 1, NumColumnsRecent: 1}},
+				Dashboards: []*configpb.Dashboard{{
+					Name:         "team-dash",
+					DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e-tests"}},
+				}},
+			},
+		},
+		{
+			name:    "write empty config without resources",
+			confirm: true,
+			want:    &configpb.Configuration{},
+		},
+		{
+			name: "reject invalid config",
+			lister: fakeLister{
+				groups: []TestGroup{{ObjectMeta: meta("ns", "e2e", 0), Spec: &configpb.TestGroup{}}},
+				dashboards: []Dashboard{{
+					ObjectMeta: meta("ns", "dashboard-name", 0),
+					Spec:       &configpb.Dashboard{DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "e2e"}}},
+				}},
+			},
+			confirm: true,
+			wantErr: true,
+		},
+		{
+			name:    "list error",
+			lister:  fakeLister{err: errors.New("boom")},
+			confirm: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{}
+			s := Syncer{Lister: tc.lister, Client: uploader, Target: *target}
+			err := s.Sync(context.Background(), tc.confirm)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("Sync() got unexpected error: %v", err)
+				}
+			case tc.wantErr:
+				t.Error("Sync() failed to return an error")
+			}
+			upload, ok := uploader[*target]
+			if tc.want == nil {
+				if ok {
+					t.Errorf("Sync() unexpectedly wrote %v", upload)
+				}
+				return
+			}
+			var got configpb.Configuration
+			if err := proto.Unmarshal(upload.Buf, &got); err != nil {
+				t.Fatalf("Failed to unmarshal the written config: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, &got, protocmp.Transform()); diff != "" {
+				t.Errorf("Sync() wrote unexpected config (-want +got):\n%s", diff)
+			}
+			delete(uploader, *target)
+			if err := s.Sync(context.Background(), tc.confirm); err != nil {
+				t.Fatalf("Sync() again got unexpected error: %v", err)
+			}
+			if _, ok := uploader[*target]; ok {
+				t.Error("Sync() rewrote an unchanged config")
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crd assembles TestGroup and Dashboard custom resources into a
+// configuration, so teams can manage their dashboards from their own namespaces.
+package crd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// API group and version of the custom resources.
+const (
+	Group   = "testgrid.k8s.io"
+	Version = "v1alpha1"
+
+	TestGroupKind = "TestGroup"
+	DashboardKind = "Dashboard"
+
+	testGroupResource = "testgroups"
+	dashboardResource = "dashboards"
+)
+
+// TestGroup is a custom resource describing a test group.
+//
+// The test group is named after the resource unless the spec sets a name.
+type TestGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec *configpb.TestGroup `json:"spec,omitempty"`
+}
+
+// TestGroupList is a list of TestGroup resources.
+type TestGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TestGroup `json:"items"`
+}
+
+// Dashboard is a custom resource describing a dashboard.
+//
+// The dashboard is named after the resource unless the spec sets a name.
+// Each tab's test_group_name refers to a TestGroup resource in the same
+// namespace, or otherwise to a test group by its name in the configuration.
+type Dashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec *configpb.Dashboard `json:"spec,omitempty"`
+}
+
+// DashboardList is a list of Dashboard resources.
+type DashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Dashboard `json:"items"`
+}
+
+// ConfigName returns the name of the test group in the configuration.
+func (tg TestGroup) ConfigName() string {
+	if n := tg.Spec.GetName(); n != "" {
+		return n
+	}
+	return tg.ObjectMeta.Name
+}
+
+// ConfigName returns the name of the dashboard in the configuration.
+func (d Dashboard) ConfigName() string {
+	if n := d.Spec.GetName(); n != "" {
+		return n
+	}
+	return d.ObjectMeta.Name
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// CellRecord is a single test result of a tab.
+type CellRecord struct {
+	Dashboard string
+	Tab       string
+	TestGroup string
+	Build     string
+	Started   int64 // milliseconds since the epoch
+	Test      string
+	Status    statuspb.TestStatus
+	Message   string
+}
+
+const dayFormat = "2006-01-02"
+
+// CellRecords returns the filled cells of the grid, grouped by the UTC day each column started.
+//
+// Records are sorted by start time, then build and test name.
+func CellRecords(dashboard, tab, group string, grid *statepb.Grid) map[string][]CellRecord {
+	days := map[string][]CellRecord{}
+	for _, col := range updater.InflateGrid(grid) {
+		started := int64(col.Column.Started)
+		day := time.Unix(0, started*int64(time.Millisecond)).UTC().Format(dayFormat)
+		for name, cell := range col.Cells {
+			if cell.Result == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			days[day] = append(days[day], CellRecord{
+				Dashboard: dashboard,
+				Tab:       tab,
+				TestGroup: group,
+				Build:     col.Column.Build,
+				Started:   started,
+				Test:      name,
+				Status:    cell.Result,
+				Message:   cell.Message,
+			})
+		}
+	}
+	for _, records := range days {
+		sort.Slice(records, func(i, j int) bool {
+			a, b := records[i], records[j]
+			if a.Started != b.Started {
+				return a.Started < b.Started
+			}
+			if a.Build != b.Build {
+				return a.Build < b.Build
+			}
+			return a.Test < b.Test
+		})
+	}
+	return days
+}
+
+// ParquetPath returns the hive-style partition path of a tab's cells on the specified day.
+//
+// Such as prefix/dashboard=foo/tab=bar/date=2021-03-04/cells.parquet
+func ParquetPath(prefix gcs.Path, dashboard, tab, day string) (*gcs.Path, error) {
+	name := path.Join(
+		"dashboard="+url.PathEscape(dashboard),
+		"tab="+url.PathEscape(tab),
+		"date="+day,
+		"cells.parquet",
+	)
+	u := url.URL{Path: "/" + path.Join(prefix.Object(), name)}
+	return prefix.ResolveReference(&u)
+}
+
+// ExportParquet writes parquet partitions of each dashboard tab's cells for days on or after since.
+//
+// Only the named dashboard is exported when dashboard is set.
+func ExportParquet(ctx context.Context, client gcs.Client, configPath gcs.Path, gridPrefix string, output gcs.Path, dashboard string, since time.Time, write bool) error {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	sinceDay := since.UTC().Format(dayFormat)
+	var failures int
+	for _, dash := range cfg.Dashboards {
+		if dashboard != "" && dash.Name != dashboard {
+			continue
+		}
+		for _, tab := range dash.DashboardTab {
+			log := logrus.WithFields(logrus.Fields{
+				"dashboard": dash.Name,
+				"tab":       tab.Name,
+			})
+			gridPath, err := updater.TestGroupPath(configPath, gridPrefix, tab.TestGroupName)
+			if err != nil {
+				log.WithError(err).Error("Bad grid path")
+				failures++
+				continue
+			}
+			grid, err := gcs.DownloadGrid(ctx, client, *gridPath)
+			if err != nil {
+				log.WithError(err).Error("Failed to read grid")
+				failures++
+				continue
+			}
+			for day, records := range CellRecords(dash.Name, tab.Name, tab.TestGroupName, grid) {
+				if day < sinceDay {
+					continue
+				}
+				log := log.WithField("day", day)
+				outPath, err := ParquetPath(output, dash.Name, tab.Name, day)
+				if err != nil {
+					log.WithError(err).Error("Bad output path")
+					failures++
+					continue
+				}
+				var buf bytes.Buffer
+				if err := WriteParquet(&buf, records); err != nil {
+					log.WithError(err).Error("Failed to encode parquet")
+					failures++
+					continue
+				}
+				if !write {
+					log.WithField("path", outPath).WithField("records", len(records)).Info("Skipping write")
+					continue
+				}
+				if err := client.Upload(ctx, *outPath, buf.Bytes(), gcs.DefaultACL, "no-cache"); err != nil {
+					log.WithError(err).Error("Failed to write parquet")
+					failures++
+					continue
+				}
+				log.WithField("path", outPath).Debug("Wrote parquet")
+			}
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d exports failed", failures)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestCellRecords(t *testing.T) {
+	const (
+		day1 = 1614816000000 // 2021-03-04T00:00:00Z
+		day2 = day1 + 24*60*60*1000
+	)
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+		want map[string][]CellRecord
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+			want: map[string][]CellRecord{},
+		},
+		{
+			name: "group by day and skip empty cells",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Build: "3", Started: day2 + 5},
+					{Build: "2", Started: day1 + 10},
+					{Build: "1", Started: day1},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name:     "foo",
+						Results:  []int32{fail, 1, pass, 2},
+						Messages: []string{"boom", "", ""},
+						Icons:    []string{"", "", ""},
+						CellIds:  []string{"", "", ""},
+					},
+					{
+						Name:     "bar",
+						Results:  []int32{empty, 2, pass, 1},
+						Messages: []string{""},
+						Icons:    []string{""},
+						CellIds:  []string{""},
+					},
+				},
+			},
+			want: map[string][]CellRecord{
+				"2021-03-04": {
+					{Dashboard: "dash", Tab: "tab", TestGroup: "group", Build: "1", Started: day1, Test: "bar", Status: statuspb.TestStatus_PASS},
+					{Dashboard: "dash", Tab: "tab", TestGroup: "group", Build: "1", Started: day1, Test: "foo", Status: statuspb.TestStatus_PASS},
+					{Dashboard: "dash", Tab: "tab", TestGroup: "group", Build: "2", Started: day1 + 10, Test: "foo", Status: statuspb.TestStatus_PASS},
+				},
+				"2021-03-05": {
+					{Dashboard: "dash", Tab: "tab", TestGroup: "group", Build: "3", Started: day2 + 5, Test: "foo", Status: statuspb.TestStatus_FAIL, Message: "boom"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CellRecords("dash", "tab", "group", tc.grid)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CellRecords() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParquetPath(t *testing.T) {
+	prefix, err := gcs.NewPath("gs://bucket/some/prefix")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	got, err := ParquetPath(*prefix, "my dash", "a/tab", "2021-03-04")
+	if err != nil {
+		t.Fatalf("ParquetPath() got unexpected error: %v", err)
+	}
+	const want = "some/prefix/dashboard=my%20dash/tab=a%2Ftab/date=2021-03-04/cells.parquet"
+	if got.Bucket() != "bucket" || got.Object() != want {
+		t.Errorf("ParquetPath() got gs://%s/%s, want gs://bucket/%s", got.Bucket(), got.Object(), want)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetCell is the parquet schema of a CellRecord.
+type parquetCell struct {
+	Dashboard string `parquet:"name=dashboard, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Tab       string `parquet:"name=tab, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TestGroup string `parquet:"name=test_group, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Build     string `parquet:"name=build, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Started   int64  `parquet:"name=started, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	Test      string `parquet:"name=test, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Status    string `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Message   string `parquet:"name=message, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// WriteParquet writes the records as a parquet table.
+func WriteParquet(w io.Writer, records []CellRecord) error {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(parquetCell), 1)
+	if err != nil {
+		return fmt.Errorf("create writer: %w", err)
+	}
+	for _, r := range records {
+		row := parquetCell{
+			Dashboard: r.Dashboard,
+			Tab:       r.Tab,
+			TestGroup: r.TestGroup,
+			Build:     r.Build,
+			Started:   r.Started,
+			Test:      r.Test,
+			Status:    r.Status.String(),
+			Message:   r.Message,
+		}
+		if err := pw.Write(row); err != nil {
+			return fmt.Errorf("write %s/%s: %w", r.Build, r.Test, err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		return fmt.Errorf("finish: %w", err)
+	}
+	return nil
+}
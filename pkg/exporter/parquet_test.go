@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// bufferFile reads a parquet file from memory.
+type bufferFile struct {
+	*bytes.Reader
+}
+
+func (f bufferFile) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("read only")
+}
+
+func (f bufferFile) Close() error {
+	return nil
+}
+
+func (f bufferFile) Open(string) (source.ParquetFile, error) {
+	return f, nil
+}
+
+func (f bufferFile) Create(string) (source.ParquetFile, error) {
+	return nil, fmt.Errorf("read only")
+}
+
+// parquetSchema describes a leaf of a parquet schema.
+type parquetSchema struct {
+	Name      string
+	Type      parquet.Type
+	Converted parquet.ConvertedType
+	Required  bool
+}
+
+func TestWriteParquet(t *testing.T) {
+	str := func(name string) parquetSchema {
+		return parquetSchema{Name: name, Type: parquet.Type_BYTE_ARRAY, Converted: parquet.ConvertedType_UTF8, Required: true}
+	}
+	schema := []parquetSchema{
+		str("dashboard"),
+		str("tab"),
+		str("test_group"),
+		str("build"),
+		{Name: "started", Type: parquet.Type_INT64, Converted: parquet.ConvertedType_TIMESTAMP_MILLIS, Required: true},
+		str("test"),
+		str("status"),
+		str("message"),
+	}
+	cases := []struct {
+		name       string
+		records    []CellRecord
+		wantValues [][]interface{}
+	}{
+		{
+			name: "records",
+			records: []CellRecord{
+				{
+					Dashboard: "dash",
+					Tab:       "tab",
+					TestGroup: "group",
+					Build:     "1",
+					Started:   1000,
+					Test:      "foo",
+					Status:    statuspb.TestStatus_PASS,
+				},
+				{
+					Dashboard: "dash",
+					Tab:       "tab",
+					TestGroup: "group",
+					Build:     "2",
+					Started:   1614816000000,
+					Test:      "hello \u4e16\u754c",
+					Status:    statuspb.TestStatus_FAIL,
+					Message:   "boom",
+				},
+			},
+			wantValues: [][]interface{}{
+				{"dash", "dash"},
+				{"tab", "tab"},
+				{"group", "group"},
+				{"1", "2"},
+				{int64(1000), int64(1614816000000)},
+				{"foo", "hello \u4e16\u754c"},
+				{"PASS", "FAIL"},
+				{"", "boom"},
+			},
+		},
+		{
+			name:       "no records",
+			wantValues: make([][]interface{}, len(schema)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteParquet(&buf, tc.records); err != nil {
+				t.Fatalf("WriteParquet() got unexpected error: %v", err)
+			}
+
+			pr, err := reader.NewParquetColumnReader(bufferFile{bytes.NewReader(buf.Bytes())}, 1)
+			if err != nil {
+				t.Fatalf("NewParquetColumnReader() got unexpected error: %v", err)
+			}
+			defer pr.ReadStop()
+
+			var gotSchema []parquetSchema
+			for i, el := range pr.Footer.Schema[1:] {
+				gotSchema = append(gotSchema, parquetSchema{
+					Name:      pr.SchemaHandler.GetExName(i + 1),
+					Type:      el.GetType(),
+					Converted: el.GetConvertedType(),
+					Required:  el.GetRepetitionType() == parquet.FieldRepetitionType_REQUIRED,
+				})
+			}
+			if diff := cmp.Diff(schema, gotSchema); diff != "" {
+				t.Errorf("WriteParquet() got unexpected schema (-want +got):\n%s", diff)
+			}
+
+			rows := pr.GetNumRows()
+			var gotValues [][]interface{}
+			for i := range schema {
+				var values []interface{}
+				if rows > 0 {
+					if values, _, _, err = pr.ReadColumnByIndex(int64(i), rows); err != nil {
+						t.Fatalf("ReadColumnByIndex(%d) got unexpected error: %v", i, err)
+					}
+				}
+				gotValues = append(gotValues, values)
+			}
+			if diff := cmp.Diff(tc.wantValues, gotValues); diff != "" {
+				t.Errorf("WriteParquet() got unexpected values (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
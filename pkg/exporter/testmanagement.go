@@ -0,0 +1,321 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package exporter sends test results to systems outside of TestGrid.
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// CaseResult is the outcome of a test case in a build.
+type CaseResult struct {
+	TestCase string
+	Status   statuspb.TestStatus
+	Message  string
+}
+
+// caseResults returns the results of each test case in the column, sorted by test case.
+//
+// Test cases reported by multiple rows use the most severe result.
+func caseResults(property string, col updater.InflatedColumn) []CaseResult {
+	cases := map[string]CaseResult{}
+	for _, cell := range col.Cells {
+		id := cell.Properties[property]
+		if id == "" || cell.Result == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		if prev, ok := cases[id]; ok && result.GTE(prev.Status, cell.Result) {
+			continue
+		}
+		cases[id] = CaseResult{
+			TestCase: id,
+			Status:   cell.Result,
+			Message:  cell.Message,
+		}
+	}
+	out := make([]CaseResult, 0, len(cases))
+	for _, c := range cases {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].TestCase < out[j].TestCase
+	})
+	return out
+}
+
+// TestManagement returns an exporter which pushes results to the test
+// management system configured for each group, if any.
+func TestManagement(client *http.Client) updater.ColumnExporter {
+	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, cols []updater.InflatedColumn) error {
+		cfg := tg.GetTestManagementExport()
+		if cfg == nil {
+			return nil
+		}
+		token, err := readToken(cfg.TokenFile)
+		if err != nil {
+			return fmt.Errorf("token: %w", err)
+		}
+		var failures int
+		for _, col := range cols {
+			results := caseResults(cfg.TestCaseProperty, col)
+			if len(results) == 0 {
+				continue
+			}
+			log := log.WithField("build", col.Column.Build)
+			var reqs []*http.Request
+			switch cfg.System {
+			case configpb.TestManagementExport_JIRA_XRAY:
+				reqs, err = xrayRequests(ctx, cfg, tg.Name, col, results)
+			case configpb.TestManagementExport_POLARION:
+				reqs, err = polarionRequests(ctx, cfg, tg.Name, col, results)
+			default:
+				return fmt.Errorf("unsupported system: %s", cfg.System)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", col.Column.Build, err)
+			}
+			if err := sendAll(client, reqs, token); err != nil {
+				log.WithError(err).Warning("Failed to export results")
+				failures++
+				continue
+			}
+			log.WithField("cases", len(results)).Debug("Exported results")
+		}
+		if failures > 0 {
+			return fmt.Errorf("failed to export %d builds", failures)
+		}
+		return nil
+	}
+}
+
+func readToken(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(buf)), nil
+}
+
+func sendAll(client *http.Client, reqs []*http.Request, token string) error {
+	for _, req := range reqs {
+		if err := send(client, req, token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func send(client *http.Client, req *http.Request, token string) error {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL, resp.Status, body)
+	}
+	return nil
+}
+
+func jsonRequest(ctx context.Context, base string, elem []string, body interface{}) (*http.Request, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+	u.Path = path.Join(append([]string{u.Path}, elem...)...)
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+func runTitle(group string, col updater.InflatedColumn) string {
+	return fmt.Sprintf("TestGrid %s build %s", group, col.Column.Build)
+}
+
+func started(col updater.InflatedColumn) time.Time {
+	ms := int64(col.Column.Started)
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC()
+}
+
+type xrayTest struct {
+	TestKey string `json:"testKey"`
+	Status  string `json:"status"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type xrayInfo struct {
+	Project   string `json:"project"`
+	Summary   string `json:"summary"`
+	StartDate string `json:"startDate,omitempty"`
+}
+
+type xrayExecution struct {
+	Info  xrayInfo   `json:"info"`
+	Tests []xrayTest `json:"tests"`
+}
+
+func xrayStatus(s statuspb.TestStatus) string {
+	switch result.Coalesce(s, result.ShowRunning) {
+	case statuspb.TestStatus_PASS:
+		return "PASSED"
+	case statuspb.TestStatus_FAIL, statuspb.TestStatus_FLAKY:
+		return "FAILED"
+	}
+	return "TODO"
+}
+
+// xrayRequests imports the results as a new Xray test execution.
+func xrayRequests(ctx context.Context, cfg *configpb.TestManagementExport, group string, col updater.InflatedColumn, results []CaseResult) ([]*http.Request, error) {
+	exec := xrayExecution{
+		Info: xrayInfo{
+			Project: cfg.Project,
+			Summary: runTitle(group, col),
+		},
+	}
+	if col.Column.Started > 0 {
+		exec.Info.StartDate = started(col).Format(time.RFC3339)
+	}
+	for _, r := range results {
+		exec.Tests = append(exec.Tests, xrayTest{
+			TestKey: r.TestCase,
+			Status:  xrayStatus(r.Status),
+			Comment: r.Message,
+		})
+	}
+	req, err := jsonRequest(ctx, cfg.Url, []string{"rest", "raven", "2.0", "import", "execution"}, exec)
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{req}, nil
+}
+
+type polarionResource struct {
+	Type          string                 `json:"type"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Relationships map[string]interface{} `json:"relationships,omitempty"`
+}
+
+type polarionDocument struct {
+	Data []polarionResource `json:"data"`
+}
+
+func polarionStatus(s statuspb.TestStatus) string {
+	switch result.Coalesce(s, result.ShowRunning) {
+	case statuspb.TestStatus_PASS:
+		return "passed"
+	case statuspb.TestStatus_FAIL, statuspb.TestStatus_FLAKY:
+		return "failed"
+	}
+	return "blocked"
+}
+
+// polarionRunID returns a Polarion-compatible test run ID for the column.
+func polarionRunID(group string, col updater.InflatedColumn) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, group+"-"+col.Column.Build)
+}
+
+// polarionRequests creates a test run for the column and records each result in it.
+func polarionRequests(ctx context.Context, cfg *configpb.TestManagementExport, group string, col updater.InflatedColumn, results []CaseResult) ([]*http.Request, error) {
+	runID := polarionRunID(group, col)
+	projectPath := []string{"polarion", "rest", "v1", "projects", cfg.Project, "testruns"}
+	run := polarionDocument{
+		Data: []polarionResource{
+			{
+				Type: "testruns",
+				Attributes: map[string]interface{}{
+					"id":    runID,
+					"title": runTitle(group, col),
+				},
+			},
+		},
+	}
+	createRun, err := jsonRequest(ctx, cfg.Url, projectPath, run)
+	if err != nil {
+		return nil, err
+	}
+
+	var records polarionDocument
+	for _, r := range results {
+		attrs := map[string]interface{}{
+			"result": polarionStatus(r.Status),
+		}
+		if r.Message != "" {
+			attrs["comment"] = map[string]string{
+				"type":  "text/plain",
+				"value": r.Message,
+			}
+		}
+		if col.Column.Started > 0 {
+			attrs["executed"] = started(col).Format(time.RFC3339)
+		}
+		records.Data = append(records.Data, polarionResource{
+			Type:       "testrecords",
+			Attributes: attrs,
+			Relationships: map[string]interface{}{
+				"testCase": map[string]interface{}{
+					"data": map[string]string{
+						"type": "workitems",
+						"id":   cfg.Project + "/" + r.TestCase,
+					},
+				},
+			},
+		})
+	}
+	addRecords, err := jsonRequest(ctx, cfg.Url, append(projectPath, runID, "testrecords"), records)
+	if err != nil {
+		return nil, err
+	}
+	return []*http.Request{createRun, addRecords}, nil
+}
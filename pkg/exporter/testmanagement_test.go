@@ -0,0 +1,283 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+func TestCaseResults(t *testing.T) {
+	cases := []struct {
+		name  string
+		cells map[string]updater.Cell
+		want  []CaseResult
+	}{
+		{
+			name: "basically works",
+			want: []CaseResult{},
+		},
+		{
+			name: "skip cells without a test case",
+			cells: map[string]updater.Cell{
+				"foo": {Result: statuspb.TestStatus_PASS},
+				"bar": {
+					Result:     statuspb.TestStatus_FAIL,
+					Message:    "boom",
+					Properties: map[string]string{"case": "TC-2"},
+				},
+				"empty": {
+					Properties: map[string]string{"case": "TC-3"},
+				},
+			},
+			want: []CaseResult{
+				{TestCase: "TC-2", Status: statuspb.TestStatus_FAIL, Message: "boom"},
+			},
+		},
+		{
+			name: "use the most severe result",
+			cells: map[string]updater.Cell{
+				"a": {Result: statuspb.TestStatus_PASS, Properties: map[string]string{"case": "TC-1"}},
+				"b": {Result: statuspb.TestStatus_FAIL, Properties: map[string]string{"case": "TC-1"}},
+				"c": {Result: statuspb.TestStatus_PASS, Properties: map[string]string{"case": "TC-1"}},
+				"d": {Result: statuspb.TestStatus_PASS, Properties: map[string]string{"case": "TC-0"}},
+			},
+			want: []CaseResult{
+				{TestCase: "TC-0", Status: statuspb.TestStatus_PASS},
+				{TestCase: "TC-1", Status: statuspb.TestStatus_FAIL},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := caseResults("case", updater.InflatedColumn{Cells: tc.cells})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("caseResults() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type request struct {
+	Path string
+	Auth string
+	Body interface{}
+}
+
+func TestTestManagement(t *testing.T) {
+	cols := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "100", Started: 1000},
+			Cells: map[string]updater.Cell{
+				"pass": {Result: statuspb.TestStatus_PASS, Properties: map[string]string{"case": "TC-1"}},
+				"fail": {Result: statuspb.TestStatus_FAIL, Message: "boom", Properties: map[string]string{"case": "TC-2"}},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "99"},
+			Cells: map[string]updater.Cell{
+				"untracked": {Result: statuspb.TestStatus_PASS},
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "exporter")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	tokenPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenPath, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("write token: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		export *configpb.TestManagementExport
+		status int
+		want   []request
+		err    bool
+	}{
+		{
+			name: "no export configured",
+		},
+		{
+			name: "xray",
+			export: &configpb.TestManagementExport{
+				System:           configpb.TestManagementExport_JIRA_XRAY,
+				Project:          "PROJ",
+				TestCaseProperty: "case",
+				TokenFile:        tokenPath,
+			},
+			want: []request{
+				{
+					Path: "/jira/rest/raven/2.0/import/execution",
+					Auth: "Bearer secret",
+					Body: map[string]interface{}{
+						"info": map[string]interface{}{
+							"project":   "PROJ",
+							"summary":   "TestGrid group build 100",
+							"startDate": "1970-01-01T00:00:01Z",
+						},
+						"tests": []interface{}{
+							map[string]interface{}{"testKey": "TC-1", "status": "PASSED"},
+							map[string]interface{}{"testKey": "TC-2", "status": "FAILED", "comment": "boom"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "polarion",
+			export: &configpb.TestManagementExport{
+				System:           configpb.TestManagementExport_POLARION,
+				Project:          "proj",
+				TestCaseProperty: "case",
+			},
+			want: []request{
+				{
+					Path: "/jira/polarion/rest/v1/projects/proj/testruns",
+					Body: map[string]interface{}{
+						"data": []interface{}{
+							map[string]interface{}{
+								"type": "testruns",
+								"attributes": map[string]interface{}{
+									"id":    "group-100",
+									"title": "TestGrid group build 100",
+								},
+							},
+						},
+					},
+				},
+				{
+					Path: "/jira/polarion/rest/v1/projects/proj/testruns/group-100/testrecords",
+					Body: map[string]interface{}{
+						"data": []interface{}{
+							map[string]interface{}{
+								"type": "testrecords",
+								"attributes": map[string]interface{}{
+									"result":   "passed",
+									"executed": "1970-01-01T00:00:01Z",
+								},
+								"relationships": map[string]interface{}{
+									"testCase": map[string]interface{}{
+										"data": map[string]interface{}{"type": "workitems", "id": "proj/TC-1"},
+									},
+								},
+							},
+							map[string]interface{}{
+								"type": "testrecords",
+								"attributes": map[string]interface{}{
+									"result":   "failed",
+									"executed": "1970-01-01T00:00:01Z",
+									"comment":  map[string]interface{}{"type": "text/plain", "value": "boom"},
+								},
+								"relationships": map[string]interface{}{
+									"testCase": map[string]interface{}{
+										"data": map[string]interface{}{"type": "workitems", "id": "proj/TC-2"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "server errors",
+			export: &configpb.TestManagementExport{
+				System:           configpb.TestManagementExport_JIRA_XRAY,
+				TestCaseProperty: "case",
+			},
+			status: http.StatusInternalServerError,
+			want: []request{
+				{
+					Path: "/jira/rest/raven/2.0/import/execution",
+					Body: map[string]interface{}{
+						"info": map[string]interface{}{
+							"project":   "",
+							"summary":   "TestGrid group build 100",
+							"startDate": "1970-01-01T00:00:01Z",
+						},
+						"tests": []interface{}{
+							map[string]interface{}{"testKey": "TC-1", "status": "PASSED"},
+							map[string]interface{}{"testKey": "TC-2", "status": "FAILED", "comment": "boom"},
+						},
+					},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var got []request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decode: %v", err)
+				}
+				lock.Lock()
+				got = append(got, request{Path: r.URL.Path, Auth: r.Header.Get("Authorization"), Body: body})
+				lock.Unlock()
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+			}))
+			defer server.Close()
+
+			if tc.export != nil {
+				tc.export.Url = server.URL + "/jira"
+			}
+			tg := &configpb.TestGroup{
+				Name:                 "group",
+				TestManagementExport: tc.export,
+			}
+			export := TestManagement(server.Client())
+			err := export(context.Background(), logrus.New(), tg, cols)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("export() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("export() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("export() got unexpected requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
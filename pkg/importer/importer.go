@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer converts results exported from other dashboards, such as
+// the legacy table JSON of TestGrid, into the state of a test group.
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Format names the format of the imported results.
+type Format string
+
+const (
+	// TableFormat is the legacy table JSON served by TestGrid.
+	TableFormat Format = "table"
+	// CSVFormat is a CSV of test names and the status of each build.
+	//
+	// The first row holds the build of each column, the second row the
+	// milliseconds since the epoch each build started, and each remaining row
+	// a test name followed by status names such as PASS or FAIL.
+	CSVFormat Format = "csv"
+)
+
+// Read returns the columns of the results in the format.
+func Read(r io.Reader, format Format) ([]updater.InflatedColumn, error) {
+	switch format {
+	case TableFormat:
+		var table responsepb.Response
+		if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(r, &table); err != nil {
+			return nil, fmt.Errorf("unmarshal: %w", err)
+		}
+		return TableColumns(&table)
+	case CSVFormat:
+		return CSVColumns(r)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// TableColumns returns the columns of a legacy table.
+func TableColumns(table *responsepb.Response) ([]updater.InflatedColumn, error) {
+	builds := table.BuildIds
+	if len(builds) == 0 {
+		builds = table.ColumnIds
+	}
+	n := len(table.Timestamps)
+	if len(builds) != n {
+		return nil, fmt.Errorf("%d builds for %d timestamps", len(builds), n)
+	}
+	if c := len(table.CustomColumns); c > 0 && c != n {
+		return nil, fmt.Errorf("%d custom columns for %d timestamps", c, n)
+	}
+	cols := make([]updater.InflatedColumn, n)
+	for i := range cols {
+		col := statepb.Column{
+			Build:   builds[i],
+			Hint:    builds[i],
+			Started: float64(table.Timestamps[i]),
+		}
+		if len(table.CustomColumns) > 0 {
+			col.Extra = table.CustomColumns[i].GetCustomColumns()
+		}
+		cols[i] = updater.InflatedColumn{Column: &col, Cells: map[string]updater.Cell{}}
+	}
+	for _, test := range table.Tests {
+		results, err := expand(test.Statuses, n)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", test.Name, err)
+		}
+		id := test.OriginalName
+		if id == "" {
+			id = test.Name
+		}
+		for i, res := range results {
+			if res == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			cell := updater.Cell{Result: res, ID: id}
+			if i < len(test.Messages) {
+				cell.Message = test.Messages[i]
+			}
+			if i < len(test.ShortTexts) {
+				cell.Icon = test.ShortTexts[i]
+			}
+			cols[i].Cells[test.Name] = cell
+		}
+	}
+	return cols, nil
+}
+
+// expand returns the n results of the run-length encoded statuses.
+func expand(statuses []*responsepb.RleStatus, n int) ([]statuspb.TestStatus, error) {
+	results := make([]statuspb.TestStatus, 0, n)
+	for _, s := range statuses {
+		if s.Count < 0 {
+			return nil, fmt.Errorf("negative count %d", s.Count)
+		}
+		if len(results)+int(s.Count) > n {
+			return nil, fmt.Errorf("more than %d statuses", n)
+		}
+		if _, ok := statuspb.TestStatus_name[s.Value]; !ok {
+			return nil, fmt.Errorf("unknown status %d", s.Value)
+		}
+		for i := int32(0); i < s.Count; i++ {
+			results = append(results, statuspb.TestStatus(s.Value))
+		}
+	}
+	if len(results) != n {
+		return nil, fmt.Errorf("%d statuses for %d columns", len(results), n)
+	}
+	return results, nil
+}
+
+// CSVColumns returns the columns of a CSV in the CSVFormat.
+func CSVColumns(r io.Reader) ([]updater.InflatedColumn, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("missing build and started rows")
+	}
+	builds, starts := records[0][1:], records[1][1:]
+	if len(starts) != len(builds) {
+		return nil, fmt.Errorf("%d start times for %d builds", len(starts), len(builds))
+	}
+	cols := make([]updater.InflatedColumn, len(builds))
+	for i, build := range builds {
+		started, err := strconv.ParseFloat(starts[i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("build %s started: %w", build, err)
+		}
+		cols[i] = updater.InflatedColumn{
+			Column: &statepb.Column{
+				Build:   build,
+				Hint:    build,
+				Started: started,
+			},
+			Cells: map[string]updater.Cell{},
+		}
+	}
+	for _, record := range records[2:] {
+		name := record[0]
+		if len(record) != len(builds)+1 {
+			return nil, fmt.Errorf("%s: %d results for %d builds", name, len(record)-1, len(builds))
+		}
+		for i, status := range record[1:] {
+			if status == "" {
+				continue
+			}
+			res, ok := statuspb.TestStatus_value[strings.ToUpper(status)]
+			if !ok {
+				return nil, fmt.Errorf("%s: build %s: unknown status %q", name, builds[i], status)
+			}
+			if statuspb.TestStatus(res) == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			cols[i].Cells[name] = updater.Cell{Result: statuspb.TestStatus(res), ID: name}
+		}
+	}
+	return cols, nil
+}
+
+// Merge returns the existing columns along with the imported columns which
+// started before all of them, newest first.
+//
+// Imported builds which the existing columns already have are skipped.
+func Merge(existing, imported []updater.InflatedColumn) []updater.InflatedColumn {
+	builds := map[string]bool{}
+	oldest := -1.0
+	for _, col := range existing {
+		builds[col.Column.Build] = true
+		if oldest < 0 || col.Column.Started < oldest {
+			oldest = col.Column.Started
+		}
+	}
+	out := make([]updater.InflatedColumn, 0, len(existing)+len(imported))
+	out = append(out, existing...)
+	for _, col := range imported {
+		if builds[col.Column.Build] || (oldest >= 0 && col.Column.Started >= oldest) {
+			continue
+		}
+		builds[col.Column.Build] = true
+		out = append(out, col)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Column.Started > out[j].Column.Started
+	})
+	return out
+}
+
+// Import adds the imported columns older than any in the state of the test
+// group, returning the number of columns it adds.
+//
+// Writes the state when confirm is set, refusing to replace any state written
+// in the meantime.
+func Import(ctx context.Context, log logrus.FieldLogger, client gcs.ConditionalClient, tg *configpb.TestGroup, gridPath gcs.Path, imported []updater.InflatedColumn, confirm bool) (int, error) {
+	cond := storage.Conditions{DoesNotExist: true}
+	var existing []updater.InflatedColumn
+	attrs, err := client.Stat(ctx, gridPath)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+	case err != nil:
+		return 0, fmt.Errorf("stat: %w", err)
+	default:
+		cond = storage.Conditions{GenerationMatch: attrs.Generation}
+		grid, err := gcs.DownloadGrid(ctx, client, gridPath)
+		if err != nil {
+			return 0, fmt.Errorf("download: %w", err)
+		}
+		existing = updater.InflateGrid(grid)
+	}
+	cols := Merge(existing, imported)
+	added := len(cols) - len(existing)
+	if added == 0 {
+		return 0, nil
+	}
+	grid := updater.ConstructGrid(log, tg, cols)
+	encoded, err := state.Encode(grid, tg.GetStateVersion())
+	if err != nil {
+		return 0, fmt.Errorf("encode: %w", err)
+	}
+	buf, err := gcs.MarshalGrid(encoded)
+	if err != nil {
+		return 0, fmt.Errorf("marshal: %w", err)
+	}
+	if !confirm {
+		log.WithField("bytes", len(buf)).Info("Skipping write (dry run)")
+		return added, nil
+	}
+	if err := client.If(nil, &cond).Upload(ctx, gridPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return 0, fmt.Errorf("upload: %w", err)
+	}
+	return added, nil
+}
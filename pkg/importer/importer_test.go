@@ -0,0 +1,329 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestRead(t *testing.T) {
+	cases := []struct {
+		name   string
+		format Format
+		input  string
+		want   []updater.InflatedColumn
+		err    bool
+	}{
+		{
+			name:   "basically works",
+			format: TableFormat,
+			input:  `{}`,
+			want:   []updater.InflatedColumn{},
+		},
+		{
+			name:   "legacy table",
+			format: TableFormat,
+			input: `{
+				"test-group-name": "group",
+				"build-ids": ["2", "1"],
+				"custom-columns": [{"custom_columns": ["v2"]}, {"custom_columns": ["v1"]}],
+				"timestamps": [2000, 1000],
+				"tests": [
+					{
+						"name": "foo",
+						"original-name": "foo-id",
+						"statuses": [{"count": 1, "value": 12}, {"count": 1, "value": 1}],
+						"messages": ["boom", ""],
+						"short_texts": ["F", ""]
+					},
+					{
+						"name": "bar",
+						"statuses": [{"count": 1, "value": 0}, {"count": 1, "value": 1}]
+					}
+				],
+				"overall-status": "FAIL",
+				"unknown-field": true
+			}`,
+			want: []updater.InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2", Hint: "2", Started: 2000, Extra: []string{"v2"}},
+					Cells: map[string]updater.Cell{
+						"foo": {Result: statuspb.TestStatus_FAIL, ID: "foo-id", Message: "boom", Icon: "F"},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1", Hint: "1", Started: 1000, Extra: []string{"v1"}},
+					Cells: map[string]updater.Cell{
+						"foo": {Result: statuspb.TestStatus_PASS, ID: "foo-id"},
+						"bar": {Result: statuspb.TestStatus_PASS, ID: "bar"},
+					},
+				},
+			},
+		},
+		{
+			name:   "legacy table with column ids",
+			format: TableFormat,
+			input:  `{"column_ids": ["7"], "timestamps": [7000]}`,
+			want: []updater.InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "7", Hint: "7", Started: 7000},
+					Cells:  map[string]updater.Cell{},
+				},
+			},
+		},
+		{
+			name:   "legacy table missing builds",
+			format: TableFormat,
+			input:  `{"timestamps": [7000]}`,
+			err:    true,
+		},
+		{
+			name:   "legacy table with too few statuses",
+			format: TableFormat,
+			input:  `{"build-ids": ["2", "1"], "timestamps": [2000, 1000], "tests": [{"name": "foo", "statuses": [{"count": 1, "value": 1}]}]}`,
+			err:    true,
+		},
+		{
+			name:   "legacy table with unknown status",
+			format: TableFormat,
+			input:  `{"build-ids": ["1"], "timestamps": [1000], "tests": [{"name": "foo", "statuses": [{"count": 1, "value": 99}]}]}`,
+			err:    true,
+		},
+		{
+			name:   "invalid json",
+			format: TableFormat,
+			input:  `{`,
+			err:    true,
+		},
+		{
+			name:   "csv",
+			format: CSVFormat,
+			input: strings.Join([]string{
+				"build,2,1",
+				"started,2000,1000",
+				"foo,fail,PASS",
+				"bar,,pass",
+			}, "\n"),
+			want: []updater.InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2", Hint: "2", Started: 2000},
+					Cells: map[string]updater.Cell{
+						"foo": {Result: statuspb.TestStatus_FAIL, ID: "foo"},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "1", Hint: "1", Started: 1000},
+					Cells: map[string]updater.Cell{
+						"foo": {Result: statuspb.TestStatus_PASS, ID: "foo"},
+						"bar": {Result: statuspb.TestStatus_PASS, ID: "bar"},
+					},
+				},
+			},
+		},
+		{
+			name:   "csv missing started row",
+			format: CSVFormat,
+			input:  "build,2,1\n",
+			err:    true,
+		},
+		{
+			name:   "csv with bad start time",
+			format: CSVFormat,
+			input:  "build,1\nstarted,yesterday\n",
+			err:    true,
+		},
+		{
+			name:   "csv with unknown status",
+			format: CSVFormat,
+			input:  "build,1\nstarted,1000\nfoo,GREEN\n",
+			err:    true,
+		},
+		{
+			name:   "unknown format",
+			format: "xml",
+			input:  "<grid/>",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tc.input), tc.format)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Read() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Read() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("Read() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func importColumn(build string, started float64) updater.InflatedColumn {
+	return updater.InflatedColumn{
+		Column: &statepb.Column{Build: build, Started: started},
+		Cells: map[string]updater.Cell{
+			"foo": {Result: statuspb.TestStatus_PASS},
+		},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing []updater.InflatedColumn
+		imported []updater.InflatedColumn
+		want     []updater.InflatedColumn
+	}{
+		{
+			name: "basically works",
+			want: []updater.InflatedColumn{},
+		},
+		{
+			name:     "import everything without existing columns",
+			imported: []updater.InflatedColumn{importColumn("1", 1000), importColumn("2", 2000)},
+			want:     []updater.InflatedColumn{importColumn("2", 2000), importColumn("1", 1000)},
+		},
+		{
+			name:     "only import older columns",
+			existing: []updater.InflatedColumn{importColumn("4", 4000), importColumn("3", 3000)},
+			imported: []updater.InflatedColumn{importColumn("5", 5000), importColumn("3.5", 3500), importColumn("2", 2000)},
+			want:     []updater.InflatedColumn{importColumn("4", 4000), importColumn("3", 3000), importColumn("2", 2000)},
+		},
+		{
+			name:     "skip existing builds",
+			existing: []updater.InflatedColumn{importColumn("3", 3000)},
+			imported: []updater.InflatedColumn{importColumn("3", 1000), importColumn("2", 2000), importColumn("2", 1500)},
+			want:     []updater.InflatedColumn{importColumn("3", 3000), importColumn("2", 2000)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Merge(tc.existing, tc.imported)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Merge() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	path, err := gcs.NewPath("gs://bucket/grid/group")
+	if err != nil {
+		t.Fatalf("bad path: %v", err)
+	}
+	log := logrus.WithField("test", "TestImport")
+	existing := updater.ConstructGrid(log, &configpb.TestGroup{}, []updater.InflatedColumn{importColumn("3", 3000)})
+	existingBuf, err := gcs.MarshalGrid(existing)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	imported := []updater.InflatedColumn{importColumn("3", 3000), importColumn("2", 2000)}
+
+	cases := []struct {
+		name       string
+		existing   bool
+		confirm    bool
+		want       int
+		wantBuilds []string
+	}{
+		{
+			name:       "create state",
+			confirm:    true,
+			want:       2,
+			wantBuilds: []string{"3", "2"},
+		},
+		{
+			name:       "add to state",
+			existing:   true,
+			confirm:    true,
+			want:       1,
+			wantBuilds: []string{"3", "2"},
+		},
+		{
+			name:     "dry run",
+			existing: true,
+			want:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{}
+			stater := fake.Stater{}
+			opener := fake.Opener{}
+			if tc.existing {
+				opener[*path] = fake.Object{Data: string(existingBuf)}
+				uploader[*path] = fake.Upload{Buf: existingBuf, Generation: 7}
+				stater[*path] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 7}}
+			}
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client:   fake.Client{Opener: opener},
+					Uploader: uploader,
+					Stater:   stater,
+				},
+			}
+			got, err := Import(context.Background(), log, client, &configpb.TestGroup{}, *path, imported, tc.confirm)
+			if err != nil {
+				t.Fatalf("Import() got unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Import() got %d columns, want %d", got, tc.want)
+			}
+			if tc.wantBuilds == nil {
+				if uploader[*path].Generation != 7 {
+					t.Error("Import() wrote state during a dry run")
+				}
+				return
+			}
+			grid, err := state.Unmarshal(uploader[*path].Buf)
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			var builds []string
+			for _, col := range grid.Columns {
+				builds = append(builds, col.Build)
+			}
+			if diff := cmp.Diff(tc.wantBuilds, builds); diff != "" {
+				t.Errorf("Import() got unexpected builds (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
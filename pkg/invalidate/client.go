@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invalidate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const (
+	// configTTL is how long the client resolves paths with the config it read.
+	configTTL = time.Minute
+	// notifyTimeout limits the delivery of each event.
+	notifyTimeout = 30 * time.Second
+)
+
+// Client notifies downstream of each successful upload of the kind of state
+// it writes, which is under the prefix relative to the config.
+//
+// Failures to notify are logged rather than failing the upload.
+type Client struct {
+	gcs.ConditionalClient
+	resolver *resolver
+	notifier Notifier
+	log      logrus.FieldLogger
+}
+
+// NewClient wraps the client to notify of uploads of the kind of state.
+func NewClient(client gcs.ConditionalClient, configPath gcs.Path, kind Kind, prefix string, notifier Notifier) *Client {
+	return &Client{
+		ConditionalClient: client,
+		resolver: &resolver{
+			configPath: configPath,
+			kind:       kind,
+			prefix:     prefix,
+		},
+		notifier: notifier,
+		log:      logrus.WithField("invalidate", kind),
+	}
+}
+
+// If returns a client with conditions which also notifies.
+func (c *Client) If(read, write *storage.Conditions) gcs.ConditionalClient {
+	return &Client{
+		ConditionalClient: c.ConditionalClient.If(read, write),
+		resolver:          c.resolver,
+		notifier:          c.notifier,
+		log:               c.log,
+	}
+}
+
+// Upload writes the object and then notifies of its event, if any.
+func (c *Client) Upload(ctx context.Context, path gcs.Path, buf []byte, worldRead bool, cacheControl string) error {
+	if err := c.ConditionalClient.Upload(ctx, path, buf, worldRead, cacheControl); err != nil {
+		return err
+	}
+	log := c.log.WithField("path", path)
+	ev, err := c.resolver.resolve(ctx, c.ConditionalClient, path)
+	if err != nil {
+		log.WithError(err).Warning("Failed to resolve invalidation")
+		return nil
+	}
+	if ev == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	if err := c.notifier.Notify(ctx, *ev); err != nil {
+		log.WithError(err).Warning("Failed to notify invalidation")
+		return nil
+	}
+	log.WithField("tabs", len(ev.Tabs)).Debug("Notified invalidation")
+	return nil
+}
+
+// resolver maps the paths of a kind of state to their events, according to
+// the config it periodically reads.
+type resolver struct {
+	configPath gcs.Path
+	kind       Kind
+	prefix     string
+
+	lock   sync.Mutex
+	read   time.Time
+	events map[string]Event
+}
+
+// resolve returns the event of the path, or nil if it is not state of the kind.
+func (r *resolver) resolve(ctx context.Context, opener gcs.Opener, path gcs.Path) (*Event, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.events == nil || time.Since(r.read) > configTTL {
+		cfg, err := config.ReadGCS(ctx, opener, r.configPath)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		events, err := Events(cfg, r.configPath, r.kind, r.prefix)
+		if err != nil {
+			return nil, err
+		}
+		r.events, r.read = events, time.Now()
+	}
+	ev, ok := r.events[path.String()]
+	if !ok {
+		return nil, nil
+	}
+	return &ev, nil
+}
+
+// Events returns the event of each gs:// path where the config stores state of
+// the kind under the prefix.
+func Events(cfg *configpb.Configuration, configPath gcs.Path, kind Kind, prefix string) (map[string]Event, error) {
+	events := map[string]Event{}
+	switch kind {
+	case GridKind:
+		groupTabs := map[string][]Tab{}
+		for _, d := range cfg.Dashboards {
+			for _, dt := range d.DashboardTab {
+				groupTabs[dt.TestGroupName] = append(groupTabs[dt.TestGroupName], Tab{Dashboard: d.Name, Tab: dt.Name})
+			}
+		}
+		for _, tg := range cfg.TestGroups {
+			path, err := updater.TestGroupPath(configPath, prefix, tg.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s path: %w", tg.Name, err)
+			}
+			events[path.String()] = Event{Kind: kind, Path: path.String(), Group: tg.Name, Tabs: groupTabs[tg.Name]}
+		}
+	case TabKind:
+		for _, d := range cfg.Dashboards {
+			for _, dt := range d.DashboardTab {
+				path, err := tabs.TabStatePath(configPath, prefix, d.Name, dt.Name)
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s path: %w", d.Name, dt.Name, err)
+				}
+				events[path.String()] = Event{Kind: kind, Path: path.String(), Dashboard: d.Name, Tabs: []Tab{{Dashboard: d.Name, Tab: dt.Name}}}
+			}
+		}
+	case SummaryKind:
+		for _, d := range cfg.Dashboards {
+			path, err := summarizer.SummaryPath(configPath, prefix, d.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s path: %w", d.Name, err)
+			}
+			ev := Event{Kind: kind, Path: path.String(), Dashboard: d.Name}
+			for _, dt := range d.DashboardTab {
+				ev.Tabs = append(ev.Tabs, Tab{Dashboard: d.Name, Tab: dt.Name})
+			}
+			events[path.String()] = ev
+		}
+	default:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+	return events, nil
+}
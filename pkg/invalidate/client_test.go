@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+	}
+	return *p
+}
+
+var testConfig = &configpb.Configuration{
+	TestGroups: []*configpb.TestGroup{{Name: "group"}, {Name: "lonely"}},
+	Dashboards: []*configpb.Dashboard{
+		{
+			Name: "dash",
+			DashboardTab: []*configpb.DashboardTab{
+				{Name: "tab", TestGroupName: "group"},
+				{Name: "other", TestGroupName: "group"},
+			},
+		},
+	},
+}
+
+func TestEvents(t *testing.T) {
+	configPath := mustPath(t, "gs://bucket/config")
+	cases := []struct {
+		name   string
+		kind   Kind
+		prefix string
+		want   map[string]Event
+		err    bool
+	}{
+		{
+			name:   "grids",
+			kind:   GridKind,
+			prefix: "grid",
+			want: map[string]Event{
+				"gs://bucket/grid/group": {
+					Kind:  GridKind,
+					Path:  "gs://bucket/grid/group",
+					Group: "group",
+					Tabs:  []Tab{{Dashboard: "dash", Tab: "tab"}, {Dashboard: "dash", Tab: "other"}},
+				},
+				"gs://bucket/grid/lonely": {
+					Kind:  GridKind,
+					Path:  "gs://bucket/grid/lonely",
+					Group: "lonely",
+				},
+			},
+		},
+		{
+			name:   "tabs",
+			kind:   TabKind,
+			prefix: "tabs",
+			want: map[string]Event{
+				"gs://bucket/tabs/dash/tab": {
+					Kind:      TabKind,
+					Path:      "gs://bucket/tabs/dash/tab",
+					Dashboard: "dash",
+					Tabs:      []Tab{{Dashboard: "dash", Tab: "tab"}},
+				},
+				"gs://bucket/tabs/dash/other": {
+					Kind:      TabKind,
+					Path:      "gs://bucket/tabs/dash/other",
+					Dashboard: "dash",
+					Tabs:      []Tab{{Dashboard: "dash", Tab: "other"}},
+				},
+			},
+		},
+		{
+			name:   "summaries",
+			kind:   SummaryKind,
+			prefix: "summary",
+			want: map[string]Event{
+				"gs://bucket/summary/summary-dash": {
+					Kind:      SummaryKind,
+					Path:      "gs://bucket/summary/summary-dash",
+					Dashboard: "dash",
+					Tabs:      []Tab{{Dashboard: "dash", Tab: "tab"}, {Dashboard: "dash", Tab: "other"}},
+				},
+			},
+		},
+		{
+			name: "unknown kind",
+			kind: "foo",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Events(testConfig, configPath, tc.kind, tc.prefix)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Events() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Events() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("Events() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestClientUpload(t *testing.T) {
+	configPath := mustPath(t, "gs://bucket/config")
+	buf, err := proto.Marshal(testConfig)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	cases := []struct {
+		name      string
+		path      string
+		uploadErr error
+		notifyErr error
+		want      []Event
+		err       bool
+	}{
+		{
+			name: "notify of state",
+			path: "gs://bucket/grid/lonely",
+			want: []Event{{Kind: GridKind, Path: "gs://bucket/grid/lonely", Group: "lonely"}},
+		},
+		{
+			name: "ignore other objects",
+			path: "gs://bucket/grid/lonely.messages",
+		},
+		{
+			name:      "failed uploads do not notify",
+			path:      "gs://bucket/grid/lonely",
+			uploadErr: errors.New("boom"),
+			err:       true,
+		},
+		{
+			name:      "failed notifications do not fail uploads",
+			path:      "gs://bucket/grid/lonely",
+			notifyErr: errors.New("boom"),
+			want:      []Event{{Kind: GridKind, Path: "gs://bucket/grid/lonely", Group: "lonely"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{}
+			if tc.uploadErr != nil {
+				uploader[mustPath(t, tc.path)] = fake.Upload{Err: tc.uploadErr}
+			}
+			client := fake.UploadClient{
+				Client:   fake.Client{Opener: fake.Opener{configPath: {Data: string(buf)}}},
+				Uploader: uploader,
+			}
+			notifier := &fakeNotifier{err: tc.notifyErr}
+			c := NewClient(client, configPath, GridKind, "grid", notifier).If(nil, nil)
+			err := c.Upload(context.Background(), mustPath(t, tc.path), []byte("hi"), false, "")
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("Upload() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatal("Upload() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, notifier.events); diff != "" {
+				t.Errorf("Upload() notified unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
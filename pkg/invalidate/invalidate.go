@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package invalidate tells downstream caches, such as CDNs and caching
+// frontends, which dashboards and tabs changed after each write of their state.
+package invalidate
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/option"
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+// Kind identifies the state written to storage.
+type Kind string
+
+// Kinds of state.
+const (
+	// GridKind is the state of a test group written by the updater.
+	GridKind Kind = "grid"
+	// TabKind is the state of a dashboard tab written by the tabulator.
+	TabKind Kind = "tab"
+	// SummaryKind is the summary of a dashboard written by the summarizer.
+	SummaryKind Kind = "summary"
+)
+
+// Tab identifies a dashboard tab.
+type Tab struct {
+	Dashboard string `json:"dashboard"`
+	Tab       string `json:"tab"`
+}
+
+// Event describes a write of state and the dashboard tabs it affects.
+type Event struct {
+	Kind Kind `json:"kind"`
+	// Path is the gs:// path of the written object.
+	Path string `json:"path"`
+	// Group is the test group of grids.
+	Group string `json:"group,omitempty"`
+	// Dashboard is the dashboard of tab states and summaries.
+	Dashboard string `json:"dashboard,omitempty"`
+	// Tabs lists every dashboard tab which displays the state.
+	Tabs []Tab `json:"tabs,omitempty"`
+}
+
+// A Notifier delivers events downstream.
+type Notifier interface {
+	Notify(ctx context.Context, ev Event) error
+}
+
+// Notifiers delivers events to each notifier.
+type Notifiers []Notifier
+
+// Notify delivers the event to each notifier, returning the first error.
+func (ns Notifiers) Notify(ctx context.Context, ev Event) error {
+	var first error
+	for _, n := range ns {
+		if err := n.Notify(ctx, ev); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Webhook posts events to a URL as JSON.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// Notify posts the event, failing on non-2xx responses.
+func (w Webhook) Notify(ctx context.Context, ev Event) error {
+	buf, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("post: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// Topic publishes events to a Pub/Sub topic as JSON, with the kind,
+// group and dashboard as attributes for subscription filters.
+type Topic struct {
+	// Name is the projects/PROJECT/topics/TOPIC name of the topic.
+	Name    string
+	Service *pubsub.Service
+}
+
+// Notify publishes the event.
+func (t Topic) Notify(ctx context.Context, ev Event) error {
+	buf, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	attrs := map[string]string{"kind": string(ev.Kind)}
+	if ev.Group != "" {
+		attrs["group"] = ev.Group
+	}
+	if ev.Dashboard != "" {
+		attrs["dashboard"] = ev.Dashboard
+	}
+	req := &pubsub.PublishRequest{
+		Messages: []*pubsub.PubsubMessage{{
+			Data:       base64.StdEncoding.EncodeToString(buf),
+			Attributes: attrs,
+		}},
+	}
+	if _, err := t.Service.Projects.Topics.Publish(t.Name, req).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	return nil
+}
+
+// IsTopic returns true when the target names a Pub/Sub topic rather than a webhook URL.
+func IsTopic(target string) bool {
+	parts := strings.Split(target, "/")
+	return len(parts) == 4 && parts[0] == "projects" && parts[1] != "" && parts[2] == "topics" && parts[3] != ""
+}
+
+// NewNotifier returns the notifier of the target, which is either an http(s)
+// webhook URL posted to with the client or a projects/PROJECT/topics/TOPIC
+// published to with the service.
+func NewNotifier(target string, client *http.Client, service *pubsub.Service) (Notifier, error) {
+	switch {
+	case IsTopic(target):
+		if service == nil {
+			return nil, errors.New("nil pubsub service")
+		}
+		return Topic{Name: target, Service: service}, nil
+	case strings.HasPrefix(target, "https://"), strings.HasPrefix(target, "http://"):
+		return Webhook{URL: target, Client: client}, nil
+	}
+	return nil, fmt.Errorf("%q is neither an http(s) URL nor a projects/PROJECT/topics/TOPIC", target)
+}
+
+// Targets is a flag of repeatable notifier targets.
+type Targets []string
+
+func (t *Targets) String() string {
+	return strings.Join(*t, ",")
+}
+
+// Set appends a target.
+func (t *Targets) Set(value string) error {
+	if _, err := NewNotifier(value, http.DefaultClient, &pubsub.Service{}); err != nil {
+		return err
+	}
+	*t = append(*t, value)
+	return nil
+}
+
+// NewNotifiers returns the notifier of each target, publishing to topics with
+// the credentials file unless it is empty.
+func NewNotifiers(ctx context.Context, targets []string, creds string) (Notifiers, error) {
+	client := &http.Client{Timeout: time.Minute}
+	var service *pubsub.Service
+	var notifiers Notifiers
+	for _, target := range targets {
+		if IsTopic(target) && service == nil {
+			var opts []option.ClientOption
+			if creds != "" {
+				opts = append(opts, option.WithCredentialsFile(creds))
+			}
+			var err error
+			if service, err = pubsub.NewService(ctx, opts...); err != nil {
+				return nil, fmt.Errorf("pubsub: %w", err)
+			}
+		}
+		n, err := NewNotifier(target, client, service)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+	return notifiers, nil
+}
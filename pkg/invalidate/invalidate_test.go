@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+func TestWebhook(t *testing.T) {
+	ev := Event{
+		Kind:      TabKind,
+		Path:      "gs://bucket/tabs/dash/tab",
+		Dashboard: "dash",
+		Tabs:      []Tab{{Dashboard: "dash", Tab: "tab"}},
+	}
+	cases := []struct {
+		name   string
+		status int
+		err    bool
+	}{
+		{
+			name:   "post",
+			status: http.StatusOK,
+		},
+		{
+			name:   "reject",
+			status: http.StatusBadGateway,
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Event
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode: %v", err)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+			err := Webhook{URL: server.URL}.Notify(context.Background(), ev)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("Notify() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Fatal("Notify() failed to return an error")
+			}
+			if diff := cmp.Diff(ev, got); diff != "" {
+				t.Errorf("Notify() posted unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeNotifier struct {
+	events []Event
+	err    error
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, ev Event) error {
+	f.events = append(f.events, ev)
+	return f.err
+}
+
+func TestNotifiers(t *testing.T) {
+	ev := Event{Kind: GridKind, Group: "group"}
+	failing := &fakeNotifier{err: errors.New("boom")}
+	working := &fakeNotifier{}
+	if err := (Notifiers{failing, working}).Notify(context.Background(), ev); err == nil {
+		t.Error("Notify() failed to return an error")
+	}
+	if len(working.events) != 1 {
+		t.Errorf("Notify() stopped at the first failure, got %d events", len(working.events))
+	}
+}
+
+func TestNewNotifier(t *testing.T) {
+	cases := []struct {
+		target string
+		want   Notifier
+		err    bool
+	}{
+		{
+			target: "https://example.com/purge",
+			want:   Webhook{URL: "https://example.com/purge", Client: http.DefaultClient},
+		},
+		{
+			target: "projects/proj/topics/updates",
+			want:   Topic{Name: "projects/proj/topics/updates", Service: &pubsub.Service{}},
+		},
+		{
+			target: "projects/proj/subscriptions/updates",
+			err:    true,
+		},
+		{
+			target: "ftp://example.com",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.target, func(t *testing.T) {
+			got, err := NewNotifier(tc.target, http.DefaultClient, &pubsub.Service{})
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("NewNotifier() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("NewNotifier() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(x, y *pubsub.Service) bool { return (x == nil) == (y == nil) }), cmp.Comparer(func(x, y *http.Client) bool { return x == y })); diff != "" {
+					t.Errorf("NewNotifier() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
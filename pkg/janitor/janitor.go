@@ -0,0 +1,470 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package janitor removes the state of test groups and dashboards which are
+// no longer in the config.
+package janitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/notes"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Kinds of state the janitor collects.
+const (
+	GridKind    = "grid"
+	SummaryKind = "summary"
+	TabKind     = "tab"
+	// TrashKind is state moved aside by an earlier collection.
+	TrashKind = "trash"
+)
+
+// Options configures where state lives and what happens to orphaned state.
+//
+// Each prefix is relative to the config path. Kinds with an empty prefix are
+// skipped, since their state shares a directory with the config.
+type Options struct {
+	GridPrefix    string
+	SummaryPrefix string
+	TabPrefix     string
+
+	// ArchivePrefix copies orphans under this prefix before deleting them, if set.
+	ArchivePrefix string
+
+	// TrashPrefix moves orphans under this prefix instead of deleting them, if
+	// set, from where Restore can return them.
+	TrashPrefix string
+
+	// Grace is how long state must go without updates before it is collected.
+	Grace time.Duration
+
+	// Retention is how long trashed state is kept before it is deleted.
+	Retention time.Duration
+
+	// Confirm deletes expired orphans, otherwise only reports them.
+	Confirm bool
+}
+
+// Orphan is a state object which no longer belongs to the config.
+type Orphan struct {
+	Kind       string
+	Path       gcs.Path
+	Updated    time.Time
+	Generation int64
+	Size       int64
+}
+
+// Report lists the orphaned state found by a collection.
+type Report struct {
+	// Expired orphans have outlived the grace period.
+	Expired []Orphan
+	// Pending orphans are still within the grace period.
+	Pending []Orphan
+	// Collected counts the expired orphans which were deleted.
+	Collected int
+}
+
+// kind is a directory of state along with the objects the config expects in it.
+type kind struct {
+	name   string
+	prefix gcs.Path
+	keep   map[string]bool
+}
+
+// resolvePrefix returns the directory of the prefix relative to the config.
+func resolvePrefix(configPath gcs.Path, prefix string) (*gcs.Path, error) {
+	u, err := url.Parse(strings.TrimSuffix(prefix, "/") + "/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix %s: %w", prefix, err)
+	}
+	np, err := configPath.ResolveReference(u)
+	if err != nil {
+		return nil, fmt.Errorf("resolve reference: %w", err)
+	}
+	if np.Bucket() != configPath.Bucket() {
+		return nil, fmt.Errorf("prefix %s should not change bucket", prefix)
+	}
+	return np, nil
+}
+
+// kinds returns each kind of state with a prefix and the objects the config expects.
+func kinds(cfg *configpb.Configuration, configPath gcs.Path, opt Options) ([]kind, error) {
+	var out []kind
+	add := func(name, prefix string, paths func() ([]*gcs.Path, error)) error {
+		if prefix == "" {
+			return nil
+		}
+		dir, err := resolvePrefix(configPath, prefix)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		keep, err := paths()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		k := kind{name: name, prefix: *dir, keep: make(map[string]bool, len(keep))}
+		for _, p := range keep {
+			k.keep[p.Object()] = true
+		}
+		out = append(out, k)
+		return nil
+	}
+
+	err := add(GridKind, opt.GridPrefix, func() ([]*gcs.Path, error) {
+		var out []*gcs.Path
+		for _, tg := range cfg.TestGroups {
+			paths, err := GroupPaths(configPath, opt.GridPrefix, tg.Name)
+			if err != nil {
+				return nil, fmt.Errorf("group %s: %w", tg.Name, err)
+			}
+			out = append(out, paths...)
+		}
+		return out, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(SummaryKind, opt.SummaryPrefix, func() ([]*gcs.Path, error) {
+		var out []*gcs.Path
+		for _, d := range cfg.Dashboards {
+			p, err := summarizer.SummaryPath(configPath, opt.SummaryPrefix, d.Name)
+			if err != nil {
+				return nil, fmt.Errorf("dashboard %s: %w", d.Name, err)
+			}
+			out = append(out, p)
+		}
+		return out, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(TabKind, opt.TabPrefix, func() ([]*gcs.Path, error) {
+		var out []*gcs.Path
+		for _, d := range cfg.Dashboards {
+			for _, dt := range d.DashboardTab {
+				p, err := tabs.TabStatePath(configPath, opt.TabPrefix, d.Name, dt.Name)
+				if err != nil {
+					return nil, fmt.Errorf("dashboard %s tab %s: %w", d.Name, dt.Name, err)
+				}
+				out = append(out, p)
+			}
+		}
+		return out, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing belongs in the trash, so everything there expires.
+	err = add(TrashKind, opt.TrashPrefix, func() ([]*gcs.Path, error) {
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Collecting a directory nested in another would delete the other's state.
+	dirs := make([]string, 0, len(out)+1)
+	for _, k := range out {
+		dirs = append(dirs, k.prefix.Object())
+	}
+	if opt.ArchivePrefix != "" {
+		archive, err := resolvePrefix(configPath, opt.ArchivePrefix)
+		if err != nil {
+			return nil, fmt.Errorf("archive: %w", err)
+		}
+		dirs = append(dirs, archive.Object())
+	}
+	for i, a := range dirs {
+		for _, b := range dirs[i+1:] {
+			if strings.HasPrefix(a, b) || strings.HasPrefix(b, a) {
+				return nil, fmt.Errorf("prefixes %s and %s overlap", a, b)
+			}
+		}
+	}
+	return out, nil
+}
+
+// GroupPaths returns the paths to the state of the test group.
+func GroupPaths(configPath gcs.Path, gridPrefix, group string) ([]*gcs.Path, error) {
+	p, err := updater.TestGroupPath(configPath, gridPrefix, group)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := updater.IssueStatePath(*p)
+	if err != nil {
+		return nil, fmt.Errorf("issues: %w", err)
+	}
+	messages, err := updater.MessageStorePath(*p)
+	if err != nil {
+		return nil, fmt.Errorf("messages: %w", err)
+	}
+	quarantine, err := updater.QuarantinePath(*p)
+	if err != nil {
+		return nil, fmt.Errorf("quarantine: %w", err)
+	}
+	notesPath, err := notes.Path(*p)
+	if err != nil {
+		return nil, fmt.Errorf("notes: %w", err)
+	}
+	edits, err := updater.ColumnEditsPath(*p)
+	if err != nil {
+		return nil, fmt.Errorf("edits: %w", err)
+	}
+	overrides, err := updater.BuildOverridesPath(*p)
+	if err != nil {
+		return nil, fmt.Errorf("overrides: %w", err)
+	}
+	return []*gcs.Path{p, issues, messages, quarantine, notesPath, edits, overrides}, nil
+}
+
+// objectPath returns the path to the named object in the bucket.
+func objectPath(bucket gcs.Path, name string) (*gcs.Path, error) {
+	u := bucket.URL()
+	var p gcs.Path
+	if err := p.SetURL(&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/" + name}); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// orphans lists the objects in the kind's directory which the config does not expect.
+func orphans(ctx context.Context, lister gcs.Lister, k kind) ([]Orphan, error) {
+	var out []Orphan
+	it := lister.Objects(ctx, k.prefix, "", "")
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", k.prefix, err)
+		}
+		if attrs.Name == "" || k.keep[attrs.Name] {
+			continue
+		}
+		p, err := objectPath(k.prefix, attrs.Name)
+		if err != nil {
+			return nil, fmt.Errorf("object %s: %w", attrs.Name, err)
+		}
+		out = append(out, Orphan{
+			Kind:       k.name,
+			Path:       *p,
+			Updated:    attrs.Updated,
+			Generation: attrs.Generation,
+			Size:       attrs.Size,
+		})
+	}
+	return out, nil
+}
+
+// Find returns the orphaned state under each prefix of the options, split by
+// whether the orphan has outlived the grace period.
+func Find(ctx context.Context, lister gcs.Lister, cfg *configpb.Configuration, configPath gcs.Path, opt Options, now time.Time) (*Report, error) {
+	ks, err := kinds(cfg, configPath, opt)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	for _, k := range ks {
+		found, err := orphans(ctx, lister, k)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k.name, err)
+		}
+		grace := opt.Grace
+		if k.name == TrashKind {
+			grace = opt.Retention
+		}
+		for _, o := range found {
+			if now.Sub(o.Updated) < grace {
+				report.Pending = append(report.Pending, o)
+				continue
+			}
+			report.Expired = append(report.Expired, o)
+		}
+	}
+	for _, list := range [][]Orphan{report.Expired, report.Pending} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].Path.String() < list[j].Path.String()
+		})
+	}
+	return &report, nil
+}
+
+// movedPath returns where the object moves under the prefix, which mirrors
+// its path relative to the bucket.
+func movedPath(configPath gcs.Path, prefix string, p gcs.Path) (*gcs.Path, error) {
+	dir, err := resolvePrefix(configPath, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return objectPath(*dir, path.Join(dir.Object(), p.Object()))
+}
+
+// collect archives and trashes the orphan if requested and then deletes it,
+// provided nothing wrote to it since it was listed.
+//
+// Trash is only ever deleted.
+func collect(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, opt Options, o Orphan) error {
+	cond := &storage.Conditions{GenerationMatch: o.Generation}
+	for _, dest := range []struct {
+		name   string
+		prefix string
+	}{
+		{"archive", opt.ArchivePrefix},
+		{"trash", opt.TrashPrefix},
+	} {
+		if dest.prefix == "" || o.Kind == TrashKind {
+			continue
+		}
+		to, err := movedPath(configPath, dest.prefix, o.Path)
+		if err != nil {
+			return fmt.Errorf("%s path: %w", dest.name, err)
+		}
+		if err := client.If(cond, nil).Copy(ctx, o.Path, *to); err != nil {
+			return fmt.Errorf("%s to %s: %w", dest.name, to, err)
+		}
+	}
+	if err := client.If(nil, cond).Delete(ctx, o.Path); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	return nil
+}
+
+// Collect reads the config and reports the state which no longer belongs to
+// it, deleting orphans past the grace period when confirmed.
+func Collect(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, opt Options, now time.Time) (*Report, error) {
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	report, err := Find(ctx, client, cfg, configPath, opt, now)
+	if err != nil {
+		return nil, err
+	}
+	log := logrus.WithField("config", configPath)
+	for _, o := range report.Pending {
+		log.WithFields(logrus.Fields{
+			"kind":    o.Kind,
+			"path":    o.Path,
+			"updated": o.Updated,
+		}).Debug("Orphan within grace period")
+	}
+	var failures int
+	for _, o := range report.Expired {
+		log := log.WithFields(logrus.Fields{
+			"kind":    o.Kind,
+			"path":    o.Path,
+			"updated": o.Updated,
+			"bytes":   o.Size,
+		})
+		if !opt.Confirm {
+			log.Info("Would collect orphan (dry run)")
+			continue
+		}
+		if err := collect(ctx, client, configPath, opt, o); err != nil {
+			log.WithError(err).Error("Failed to collect orphan")
+			failures++
+			continue
+		}
+		report.Collected++
+		log.Info("Collected orphan")
+	}
+	log.WithFields(logrus.Fields{
+		"expired":   len(report.Expired),
+		"pending":   len(report.Pending),
+		"collected": report.Collected,
+	}).Info("Finished collecting orphaned state")
+	if failures > 0 {
+		return report, fmt.Errorf("failed to collect %d of %d orphans", failures, len(report.Expired))
+	}
+	return report, nil
+}
+
+// Restore moves trashed state back to its original path, refusing to replace
+// any state written there since.
+//
+// Add the state back to the config within the grace period, or a later
+// collection trashes it again.
+func Restore(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, trashPrefix string, p gcs.Path) error {
+	from, err := movedPath(configPath, trashPrefix, p)
+	if err != nil {
+		return fmt.Errorf("trash path: %w", err)
+	}
+	attrs, err := client.Stat(ctx, *from)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", from, err)
+	}
+	_, err = client.Stat(ctx, p)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%s already exists", p)
+	case !errors.Is(err, storage.ErrObjectNotExist):
+		return fmt.Errorf("stat %s: %w", p, err)
+	}
+	cond := &storage.Conditions{GenerationMatch: attrs.Generation}
+	if err := client.If(cond, &storage.Conditions{DoesNotExist: true}).Copy(ctx, *from, p); err != nil {
+		return fmt.Errorf("copy from %s: %w", from, err)
+	}
+	if err := client.If(nil, cond).Delete(ctx, *from); err != nil {
+		return fmt.Errorf("delete %s: %w", from, err)
+	}
+	return nil
+}
+
+// RestoreGroup moves the trashed state of the test group back to its
+// original paths, returning the number of objects it restores.
+func RestoreGroup(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, opt Options, group string) (int, error) {
+	paths, err := GroupPaths(configPath, opt.GridPrefix, group)
+	if err != nil {
+		return 0, fmt.Errorf("group paths: %w", err)
+	}
+	var restored int
+	for _, p := range paths {
+		err := Restore(ctx, client, configPath, opt.TrashPrefix, *p)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			continue // Groups need not have issues or messages.
+		}
+		if err != nil {
+			return restored, fmt.Errorf("%s: %w", p, err)
+		}
+		restored++
+	}
+	if restored == 0 {
+		return 0, fmt.Errorf("no trashed state for %s: %w", group, storage.ErrObjectNotExist)
+	}
+	return restored, nil
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+	}
+	return *p
+}
+
+var testConfig = &configpb.Configuration{
+	TestGroups: []*configpb.TestGroup{{Name: "group"}},
+	Dashboards: []*configpb.Dashboard{
+		{
+			Name:         "Dash",
+			DashboardTab: []*configpb.DashboardTab{{Name: "my tab", TestGroupName: "group"}},
+		},
+	},
+}
+
+func TestKinds(t *testing.T) {
+	configPath := mustPath(t, "gs://bucket/config")
+	cases := []struct {
+		name    string
+		opt     Options
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "skip kinds without a prefix",
+			want: map[string][]string{},
+		},
+		{
+			name: "expect configured state",
+			opt:  Options{GridPrefix: "grid", SummaryPrefix: "summary", TabPrefix: "tabs"},
+			want: map[string][]string{
+				GridKind:    {"grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine"},
+				SummaryKind: {"summary/summary-dash"},
+				TabKind:     {"tabs/Dash/my%20tab"},
+			},
+		},
+		{
+			name:    "nested prefixes",
+			opt:     Options{GridPrefix: "state", TabPrefix: "state/tabs"},
+			wantErr: true,
+		},
+		{
+			name:    "archive inside a prefix",
+			opt:     Options{GridPrefix: "grid", ArchivePrefix: "grid/archive"},
+			wantErr: true,
+		},
+		{
+			name:    "trash inside a prefix",
+			opt:     Options{TabPrefix: "tabs", TrashPrefix: "tabs/trash"},
+			wantErr: true,
+		},
+		{
+			name: "nothing belongs in the trash",
+			opt:  Options{GridPrefix: "grid", TrashPrefix: "trash"},
+			want: map[string][]string{
+				GridKind:  {"grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine"},
+				TrashKind: nil,
+			},
+		},
+		{
+			name: "similar prefixes",
+			opt:  Options{GridPrefix: "grid", ArchivePrefix: "grid-archive"},
+			want: map[string][]string{
+				GridKind: {"grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ks, err := kinds(testConfig, configPath, tc.opt)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("kinds() got unexpected error: %v", err)
+				}
+				return
+			case tc.wantErr:
+				t.Fatal("kinds() failed to return an error")
+			}
+			got := map[string][]string{}
+			for _, k := range ks {
+				var names []string
+				for name := range k.keep {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				got[k.name] = names
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("kinds() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-60 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+	configPath := mustPath(t, "gs://bucket/config")
+	cfgBuf, err := proto.Marshal(testConfig)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	objects := map[string]time.Time{
+		"grid/group":            old,
+		"grid/group.edits":      old,
+		"grid/group.issues":     old,
+		"grid/group.messages":   old,
+		"grid/group.notes":      old,
+		"grid/group.overrides":  old,
+		"grid/group.quarantine": old,
+		"grid/removed":          old,
+		"grid/removed.issues":   old,
+		"grid/renamed":          recent,
+		"tabs/Dash/my%20tab":    old,
+		"tabs/Gone/tab":         old,
+	}
+	opt := Options{
+		GridPrefix: "grid",
+		TabPrefix:  "tabs",
+		Grace:      30 * 24 * time.Hour,
+		Retention:  7 * 24 * time.Hour,
+	}
+
+	cases := []struct {
+		name        string
+		confirm     bool
+		archive     string
+		trash       string
+		trashed     map[string]time.Time
+		uploadErr   map[string]bool
+		wantExpired []string
+		wantPending []string
+		wantRemain  []string
+		wantErr     bool
+	}{
+		{
+			name:        "dry run",
+			wantExpired: []string{"grid/removed", "grid/removed.issues", "tabs/Gone/tab"},
+			wantPending: []string{"grid/renamed"},
+			wantRemain:  []string{"config", "grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine", "grid/removed", "grid/removed.issues", "grid/renamed", "tabs/Dash/my%20tab", "tabs/Gone/tab"},
+		},
+		{
+			name:        "delete expired orphans",
+			confirm:     true,
+			wantExpired: []string{"grid/removed", "grid/removed.issues", "tabs/Gone/tab"},
+			wantPending: []string{"grid/renamed"},
+			wantRemain:  []string{"config", "grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine", "grid/renamed", "tabs/Dash/my%20tab"},
+		},
+		{
+			name:        "archive expired orphans",
+			confirm:     true,
+			archive:     "archive",
+			wantExpired: []string{"grid/removed", "grid/removed.issues", "tabs/Gone/tab"},
+			wantPending: []string{"grid/renamed"},
+			wantRemain: []string{
+				"archive/grid/removed", "archive/grid/removed.issues", "archive/tabs/Gone/tab",
+				"config", "grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine", "grid/renamed", "tabs/Dash/my%20tab",
+			},
+		},
+		{
+			name:        "trash expired orphans",
+			confirm:     true,
+			trash:       "trash",
+			wantExpired: []string{"grid/removed", "grid/removed.issues", "tabs/Gone/tab"},
+			wantPending: []string{"grid/renamed"},
+			wantRemain: []string{
+				"config", "grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine", "grid/renamed", "tabs/Dash/my%20tab",
+				"trash/grid/removed", "trash/grid/removed.issues", "trash/tabs/Gone/tab",
+			},
+		},
+		{
+			name:    "delete expired trash",
+			confirm: true,
+			trash:   "trash",
+			trashed: map[string]time.Time{
+				"trash/grid/deleted": old,
+				"trash/grid/fresh":   recent,
+			},
+			wantExpired: []string{"grid/removed", "grid/removed.issues", "tabs/Gone/tab", "trash/grid/deleted"},
+			wantPending: []string{"grid/renamed", "trash/grid/fresh"},
+			wantRemain: []string{
+				"config", "grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine", "grid/renamed", "tabs/Dash/my%20tab",
+				"trash/grid/fresh", "trash/grid/removed", "trash/grid/removed.issues", "trash/tabs/Gone/tab",
+			},
+		},
+		{
+			name:        "report failures",
+			confirm:     true,
+			uploadErr:   map[string]bool{"tabs/Gone/tab": true},
+			wantExpired: []string{"grid/removed", "grid/removed.issues", "tabs/Gone/tab"},
+			wantPending: []string{"grid/renamed"},
+			wantRemain:  []string{"config", "grid/group", "grid/group.edits", "grid/group.issues", "grid/group.messages", "grid/group.notes", "grid/group.overrides", "grid/group.quarantine", "grid/renamed", "tabs/Dash/my%20tab", "tabs/Gone/tab"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{configPath: {Buf: cfgBuf}}
+			stater := fake.Stater{}
+			lister := fake.Lister{}
+			all := map[string]time.Time{}
+			for name, updated := range objects {
+				all[name] = updated
+			}
+			for name, updated := range tc.trashed {
+				all[name] = updated
+			}
+			for name, updated := range all {
+				p, err := objectPath(configPath, name)
+				if err != nil {
+					t.Fatalf("objectPath(%q) got unexpected error: %v", name, err)
+				}
+				u := fake.Upload{Generation: 1}
+				if tc.uploadErr[name] {
+					u.Err = errors.New("injected")
+				}
+				uploader[*p] = u
+				stater[*p] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 1}}
+				dir := mustPath(t, "gs://bucket/"+strings.SplitN(name, "/", 2)[0]+"/")
+				it := lister[dir]
+				it.Objects = append(it.Objects, storage.ObjectAttrs{Name: name, Updated: updated, Generation: 1})
+				lister[dir] = it
+			}
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client: fake.Client{
+						Lister: lister,
+						Opener: fake.Opener{configPath: {Data: string(cfgBuf)}},
+					},
+					Uploader: uploader,
+					Stater:   stater,
+				},
+			}
+			opt := opt
+			opt.Confirm = tc.confirm
+			opt.ArchivePrefix = tc.archive
+			opt.TrashPrefix = tc.trash
+			report, err := Collect(context.Background(), client, configPath, opt, now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Collect() got error %v, want error %t", err, tc.wantErr)
+			}
+			names := func(orphans []Orphan) []string {
+				var out []string
+				for _, o := range orphans {
+					out = append(out, o.Path.Object())
+				}
+				return out
+			}
+			if diff := cmp.Diff(tc.wantExpired, names(report.Expired)); diff != "" {
+				t.Errorf("Collect() got unexpected expired orphans (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPending, names(report.Pending)); diff != "" {
+				t.Errorf("Collect() got unexpected pending orphans (-want +got):\n%s", diff)
+			}
+			var remain []string
+			for p := range uploader {
+				remain = append(remain, p.Object())
+			}
+			sort.Strings(remain)
+			if diff := cmp.Diff(tc.wantRemain, remain); diff != "" {
+				t.Errorf("Collect() left unexpected objects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestoreGroup(t *testing.T) {
+	configPath := mustPath(t, "gs://bucket/config")
+	opt := Options{GridPrefix: "grid", TrashPrefix: "trash"}
+	cases := []struct {
+		name         string
+		objects      []string
+		want         int
+		wantRemain   []string
+		wantNotExist bool
+		wantErr      bool
+	}{
+		{
+			name:       "basically works",
+			objects:    []string{"trash/grid/group", "trash/grid/group.issues", "trash/grid/group.notes", "trash/grid/group.edits", "trash/grid/group.overrides", "trash/grid/other"},
+			want:       5,
+			wantRemain: []string{"grid/group", "grid/group.edits", "grid/group.issues", "grid/group.notes", "grid/group.overrides", "trash/grid/other"},
+		},
+		{
+			name:         "nothing to restore",
+			objects:      []string{"trash/grid/other"},
+			wantRemain:   []string{"trash/grid/other"},
+			wantNotExist: true,
+			wantErr:      true,
+		},
+		{
+			name:       "refuse to replace newer state",
+			objects:    []string{"grid/group", "trash/grid/group"},
+			wantRemain: []string{"grid/group", "trash/grid/group"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{}
+			stater := fake.Stater{}
+			for _, name := range tc.objects {
+				p, err := objectPath(configPath, name)
+				if err != nil {
+					t.Fatalf("objectPath(%q) got unexpected error: %v", name, err)
+				}
+				uploader[*p] = fake.Upload{Generation: 1}
+				stater[*p] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 1}}
+			}
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Uploader: uploader,
+					Stater:   stater,
+				},
+			}
+			got, err := RestoreGroup(context.Background(), client, configPath, opt, "group")
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("RestoreGroup() got unexpected error: %v", err)
+				}
+				if tc.wantNotExist && !errors.Is(err, storage.ErrObjectNotExist) {
+					t.Errorf("RestoreGroup() got error %v, want %v", err, storage.ErrObjectNotExist)
+				}
+			case tc.wantErr:
+				t.Error("RestoreGroup() failed to return an error")
+			case got != tc.want:
+				t.Errorf("RestoreGroup() got %d, want %d", got, tc.want)
+			}
+			var remain []string
+			for p := range uploader {
+				remain = append(remain, p.Object())
+			}
+			sort.Strings(remain)
+			if diff := cmp.Diff(tc.wantRemain, remain); diff != "" {
+				t.Errorf("RestoreGroup() left unexpected objects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
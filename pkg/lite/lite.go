@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lite runs the updater, tabulator, summarizer and API server in a
+// single process, for small projects and local demos.
+package lite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// State is written under these prefixes, relative to the config.
+const (
+	GridPrefix    = "grid"
+	TabPrefix     = "tabs"
+	SummaryPrefix = "summary"
+)
+
+// Options configures a lite deployment.
+type Options struct {
+	// Config is where the configuration proto lives.
+	Config gcs.Path
+	// YAML converts these YAML files or directories into Config before each cycle if set.
+	YAML []string
+	// Concurrency limits the groups, tabs and dashboards processed at once.
+	Concurrency int
+}
+
+// WriteConfig converts the YAML config into the configuration proto.
+func WriteConfig(ctx context.Context, client gcs.Uploader, opt Options) error {
+	cfg, err := yamlcfg.ReadConfig(opt.YAML, "", false)
+	if err != nil {
+		return fmt.Errorf("read yaml: %w", err)
+	}
+	if err := config.Validate(&cfg); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+	buf, err := config.MarshalBytes(&cfg)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if err := client.Upload(ctx, opt.Config, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
+
+// Cycle converts any YAML config and then updates every test group, tab and
+// dashboard once.
+func Cycle(ctx context.Context, client gcs.ConditionalClient, opt Options) error {
+	if opt.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive, got: %d", opt.Concurrency)
+	}
+	if len(opt.YAML) > 0 {
+		if err := WriteConfig(ctx, client, opt); err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+	}
+	groupUpdater := updater.GCS(10*time.Minute, 3*time.Minute, opt.Concurrency, 0, true, updater.SortBuilds, nil)
+	if err := updater.Update(ctx, client, opt.Config, GridPrefix, opt.Concurrency, "", groupUpdater, true); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if err := tabulator.Update(ctx, client, opt.Config, opt.Concurrency, "", GridPrefix, TabPrefix, true); err != nil {
+		return fmt.Errorf("tabulate: %w", err)
+	}
+	if err := summarizer.Update(ctx, client, opt.Config, opt.Concurrency, "", GridPrefix, SummaryPrefix, true, false); err != nil {
+		return fmt.Errorf("summarize: %w", err)
+	}
+	return nil
+}
+
+// Handler serves the API over the state written by Cycle.
+//
+// Call Refresh after each cycle to serve changes to the config.
+type Handler struct {
+	client gcs.ConditionalClient
+	opt    Options
+
+	lock   sync.RWMutex
+	server *api.Server
+}
+
+// NewHandler returns a handler which serves the API once refreshed.
+func NewHandler(client gcs.ConditionalClient, opt Options) *Handler {
+	return &Handler{client: client, opt: opt}
+}
+
+// Refresh reads the config, which the API serves until the next refresh.
+func (h *Handler) Refresh(ctx context.Context) error {
+	cfg, err := config.ReadGCS(ctx, h.client, h.opt.Config)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	server := &api.Server{
+		Reader: tabs.Reader{
+			Client:        h.client,
+			Config:        cfg,
+			ConfigPath:    h.opt.Config,
+			GridPrefix:    GridPrefix,
+			SummaryPrefix: SummaryPrefix,
+			TabPrefix:     TabPrefix,
+			Concurrency:   h.opt.Concurrency,
+		},
+		Issues: h.client,
+		Log:    logrus.WithField("config", h.opt.Config),
+	}
+	h.lock.Lock()
+	h.server = server
+	h.lock.Unlock()
+	return nil
+}
+
+// ServeHTTP serves the API, or unavailable until the first refresh.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.lock.RLock()
+	server := h.server
+	h.lock.RUnlock()
+	if server == nil {
+		http.Error(w, "waiting for the first update cycle", http.StatusServiceUnavailable)
+		return
+	}
+	server.ServeHTTP(w, r)
+}
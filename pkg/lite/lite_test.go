@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lite
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/loadgen"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/dir"
+)
+
+const yamlConfig = `test_groups:
+- name: group-0
+  gcs_prefix: lite/logs/group-0
+  days_of_results: 7
+  num_columns_recent: 10
+  use_kubernetes_client: true
+  is_external: true
+dashboards:
+- name: demo
+  dashboard_tab:
+  - name: tab
+    test_group_name: group-0
+`
+
+func TestCycle(t *testing.T) {
+	ctx := context.Background()
+	root, err := ioutil.TempDir("", "lite")
+	if err != nil {
+		t.Fatalf("TempDir() got unexpected error: %v", err)
+	}
+	defer os.RemoveAll(root)
+	yamlPath := filepath.Join(root, "config.yaml")
+	if err := ioutil.WriteFile(yamlPath, []byte(yamlConfig), 0644); err != nil {
+		t.Fatalf("WriteFile() got unexpected error: %v", err)
+	}
+	client := dir.NewClient(filepath.Join(root, "buckets"))
+
+	prefix, err := gcs.NewPath("gs://lite/")
+	if err != nil {
+		t.Fatalf("gcs.NewPath() got unexpected error: %v", err)
+	}
+	layout, err := loadgen.NewLayout(*prefix)
+	if err != nil {
+		t.Fatalf("NewLayout() got unexpected error: %v", err)
+	}
+	gen := loadgen.Options{
+		Groups:           1,
+		Builds:           3,
+		Tests:            5,
+		TabsPerDashboard: 1,
+		FailureRate:      0.5,
+		Interval:         time.Hour,
+		Seed:             1,
+	}
+	if _, err := loadgen.Generate(ctx, client, gen, *layout, time.Now()); err != nil {
+		t.Fatalf("Generate() got unexpected error: %v", err)
+	}
+
+	opt := Options{
+		Config:      layout.Config,
+		YAML:        []string{yamlPath},
+		Concurrency: 2,
+	}
+	handler := NewHandler(client, opt)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, api.DashboardsPrefix, nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("ServeHTTP() before the first refresh got %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	if err := Cycle(ctx, client, opt); err != nil {
+		t.Fatalf("Cycle() got unexpected error: %v", err)
+	}
+	gridPath, err := updater.TestGroupPath(layout.Config, GridPrefix, "group-0")
+	if err != nil {
+		t.Fatalf("TestGroupPath() got unexpected error: %v", err)
+	}
+	grid, err := gcs.DownloadGrid(ctx, client, *gridPath)
+	if err != nil {
+		t.Fatalf("DownloadGrid() got unexpected error: %v", err)
+	}
+	if len(grid.Columns) == 0 {
+		t.Error("Cycle() wrote a grid without columns")
+	}
+	tabPath, err := tabs.TabStatePath(layout.Config, TabPrefix, "demo", "tab")
+	if err != nil {
+		t.Fatalf("TabStatePath() got unexpected error: %v", err)
+	}
+	summaryPath, err := summarizer.SummaryPath(layout.Config, SummaryPrefix, "demo")
+	if err != nil {
+		t.Fatalf("SummaryPath() got unexpected error: %v", err)
+	}
+	for _, p := range []*gcs.Path{tabPath, summaryPath} {
+		if _, err := client.Stat(ctx, *p); err != nil {
+			t.Errorf("Cycle() failed to write %s: %v", p, err)
+		}
+	}
+
+	if err := handler.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() got unexpected error: %v", err)
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, api.DashboardsPrefix, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() got %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "demo") {
+		t.Errorf("ServeHTTP() failed to list the demo dashboard: %s", w.Body)
+	}
+}
+
+func TestCycleBadConcurrency(t *testing.T) {
+	if err := Cycle(context.Background(), dir.NewClient(os.TempDir()), Options{}); err == nil {
+		t.Error("Cycle() failed to return an error")
+	}
+}
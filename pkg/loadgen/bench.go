@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const (
+	// GridPrefix is where the benchmark writes test group state.
+	GridPrefix = "grid"
+	// TabPrefix is where the benchmark writes tab state.
+	TabPrefix = "tabs"
+	// SummaryPrefix is where the benchmark writes dashboard summaries.
+	SummaryPrefix = "summary"
+)
+
+// Stage records how long one component took to process the bucket.
+type Stage struct {
+	Name    string
+	Elapsed time.Duration
+	// Cycles is the number of updates needed to process every build.
+	Cycles int
+}
+
+// Report describes the throughput of a benchmark.
+type Report struct {
+	Options Options
+	// Cells is the number of synthesized results.
+	Cells  int
+	Stages []Stage
+}
+
+// Total returns the combined duration of every stage.
+func (r Report) Total() time.Duration {
+	var total time.Duration
+	for _, s := range r.Stages {
+		total += s.Elapsed
+	}
+	return total
+}
+
+// Throughput returns the groups, builds and cells per second of the stage.
+func (r Report) Throughput(s Stage) (groups, builds, cells float64) {
+	secs := s.Elapsed.Seconds()
+	if secs <= 0 {
+		return 0, 0, 0
+	}
+	g := float64(r.Options.Groups)
+	return g / secs, g * float64(r.Options.Builds) / secs, float64(r.Cells) / secs
+}
+
+// String summarizes the report, one stage per line.
+func (r Report) String() string {
+	s := fmt.Sprintf("%d groups x %d builds x %d tests (%d cells)\n", r.Options.Groups, r.Options.Builds, r.Options.Tests, r.Cells)
+	for _, stage := range r.Stages {
+		g, b, c := r.Throughput(stage)
+		s += fmt.Sprintf("%-10s %12s %3d cycles %10.1f groups/s %12.1f builds/s %14.1f cells/s\n", stage.Name, stage.Elapsed, stage.Cycles, g, b, c)
+	}
+	return s
+}
+
+// Benchmark synthesizes results into bucket and times an update cycle of
+// the updater, tabulator and summarizer over them.
+//
+// Pass a fresh NewBucket() to measure processing in isolation.
+func Benchmark(ctx context.Context, bucket gcs.ConditionalClient, opt Options, concurrency int, now time.Time) (*Report, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be positive, got: %d", concurrency)
+	}
+	prefix, err := gcs.NewPath("gs://loadgen/")
+	if err != nil {
+		return nil, fmt.Errorf("prefix: %w", err)
+	}
+	layout, err := NewLayout(*prefix)
+	if err != nil {
+		return nil, fmt.Errorf("layout: %w", err)
+	}
+	if _, err := Generate(ctx, bucket, opt, *layout, now); err != nil {
+		return nil, fmt.Errorf("generate: %w", err)
+	}
+
+	report := Report{
+		Options: opt,
+		Cells:   opt.Groups * opt.Builds * opt.Tests,
+	}
+	stage := func(name string, f func() error) error {
+		start := time.Now()
+		if err := f(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		report.Stages = append(report.Stages, Stage{Name: name, Elapsed: time.Since(start), Cycles: 1})
+		return nil
+	}
+	cfg := layout.Config
+	updaterStage, err := updateAll(ctx, bucket, cfg, opt, concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("updater: %w", err)
+	}
+	report.Stages = append(report.Stages, *updaterStage)
+	if err := stage("tabulator", func() error {
+		return tabulator.Update(ctx, bucket, cfg, concurrency, "", GridPrefix, TabPrefix, true)
+	}); err != nil {
+		return nil, err
+	}
+	if err := stage("summarizer", func() error {
+		return summarizer.Update(ctx, bucket, cfg, concurrency, "", GridPrefix, SummaryPrefix, true, false)
+	}); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// updateAll times update cycles until every group contains every build.
+//
+// The updater only reads a few columns of a new group and then limits how
+// many cells it reads per cycle, so the first cycle alone is not enough.
+func updateAll(ctx context.Context, client gcs.ConditionalClient, cfg gcs.Path, opt Options, concurrency int) (*Stage, error) {
+	groupUpdater := updater.GCS(10*time.Minute, time.Minute, concurrency, 0, true, updater.SortBuilds, nil)
+	stage := Stage{Name: "updater"}
+	for stage.Cycles < opt.Builds {
+		start := time.Now()
+		if err := updater.Update(ctx, client, cfg, GridPrefix, concurrency, "", groupUpdater, true); err != nil {
+			return nil, err
+		}
+		stage.Elapsed += time.Since(start)
+		stage.Cycles++
+		done, err := complete(ctx, client, cfg, opt)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return &stage, nil
+		}
+	}
+	return nil, fmt.Errorf("groups still incomplete after %d cycles", stage.Cycles)
+}
+
+// complete returns true when the grid of each group has a column per build.
+func complete(ctx context.Context, client gcs.Opener, cfg gcs.Path, opt Options) (bool, error) {
+	for g := 0; g < opt.Groups; g++ {
+		path, err := updater.TestGroupPath(cfg, GridPrefix, GroupName(g))
+		if err != nil {
+			return false, fmt.Errorf("grid path: %w", err)
+		}
+		grid, err := gcs.DownloadGrid(ctx, client, *path)
+		if err != nil {
+			return false, fmt.Errorf("read %s: %w", path, err)
+		}
+		if len(grid.Columns) < opt.Builds {
+			return false, nil
+		}
+	}
+	return true, nil
+}
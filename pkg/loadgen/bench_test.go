@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+var benchOptions = Options{
+	Groups:           4,
+	Builds:           10,
+	Tests:            20,
+	TabsPerDashboard: 2,
+	FailureRate:      0.1,
+	Interval:         time.Hour,
+	Seed:             1,
+}
+
+func TestBenchmark(t *testing.T) {
+	ctx := context.Background()
+	bucket := NewBucket()
+	report, err := Benchmark(ctx, bucket, benchOptions, 2, time.Now())
+	if err != nil {
+		t.Fatalf("Benchmark(): %v", err)
+	}
+	if got, want := len(report.Stages), 3; got != want {
+		t.Fatalf("Benchmark() got %d stages, want %d", got, want)
+	}
+	if got, want := report.Cells, 4*10*20; got != want {
+		t.Errorf("Benchmark() got %d cells, want %d", got, want)
+	}
+
+	cfg := mustPath(t, "gs://loadgen/config")
+	for g := 0; g < benchOptions.Groups; g++ {
+		path, err := updater.TestGroupPath(cfg, GridPrefix, GroupName(g))
+		if err != nil {
+			t.Fatalf("TestGroupPath(): %v", err)
+		}
+		grid, err := gcs.DownloadGrid(ctx, bucket, *path)
+		if err != nil {
+			t.Fatalf("DownloadGrid(%s): %v", path, err)
+		}
+		if got, want := len(grid.Columns), benchOptions.Builds; got != want {
+			t.Errorf("%s got %d columns, want %d", GroupName(g), got, want)
+		}
+	}
+	path, err := summarizer.SummaryPath(cfg, SummaryPrefix, DashboardName(0))
+	if err != nil {
+		t.Fatalf("SummaryPath(): %v", err)
+	}
+	if _, err := bucket.Stat(ctx, *path); err != nil {
+		t.Errorf("Benchmark() failed to write %s: %v", path, err)
+	}
+}
+
+func BenchmarkCycle(b *testing.B) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		if _, err := Benchmark(ctx, NewBucket(), benchOptions, 4, time.Now()); err != nil {
+			b.Fatalf("Benchmark(): %v", err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadgen synthesizes buckets of results and measures how quickly the
+// updater, tabulator and summarizer process them.
+package loadgen
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Options describes the synthesized results.
+type Options struct {
+	// Groups is the number of test groups.
+	Groups int
+	// Builds is the number of builds, and thus columns, of each group.
+	Builds int
+	// Tests is the number of test cases, and thus rows, of each build.
+	Tests int
+	// TabsPerDashboard limits the tabs of each dashboard, one per group.
+	TabsPerDashboard int
+	// Columns sets the recent columns of each tab if positive.
+	Columns int
+	// FailureRate is the fraction of test cases which fail.
+	FailureRate float64
+	// Interval separates the start of consecutive builds.
+	Interval time.Duration
+	// Seed makes the results reproducible.
+	Seed int64
+}
+
+// Validate returns an error for options which cannot synthesize results.
+func (o Options) Validate() error {
+	switch {
+	case o.Groups < 1:
+		return fmt.Errorf("groups must be positive, got %d", o.Groups)
+	case o.Builds < 1:
+		return fmt.Errorf("builds must be positive, got %d", o.Builds)
+	case o.Tests < 1:
+		return fmt.Errorf("tests must be positive, got %d", o.Tests)
+	case o.TabsPerDashboard < 1:
+		return fmt.Errorf("tabs per dashboard must be positive, got %d", o.TabsPerDashboard)
+	case o.Columns < 0:
+		return fmt.Errorf("columns must not be negative, got %d", o.Columns)
+	case o.FailureRate < 0 || o.FailureRate > 1:
+		return fmt.Errorf("failure rate must be between 0 and 1, got %f", o.FailureRate)
+	case o.Interval <= 0:
+		return errors.New("interval must be positive")
+	}
+	return nil
+}
+
+// Layout locates the synthesized objects under a prefix.
+type Layout struct {
+	// Config is where the configuration proto is written.
+	Config gcs.Path
+	// Logs is the prefix of the builds of each group.
+	Logs gcs.Path
+}
+
+// NewLayout returns the layout of objects under the prefix.
+func NewLayout(prefix gcs.Path) (*Layout, error) {
+	config, err := prefix.ResolveReference(mustURL("config"))
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	logs, err := prefix.ResolveReference(mustURL("logs/"))
+	if err != nil {
+		return nil, fmt.Errorf("logs: %w", err)
+	}
+	return &Layout{Config: *config, Logs: *logs}, nil
+}
+
+// GroupName returns the name of the nth test group.
+func GroupName(n int) string {
+	return fmt.Sprintf("group-%d", n)
+}
+
+// DashboardName returns the name of the nth dashboard.
+func DashboardName(n int) string {
+	return fmt.Sprintf("loadgen-%d", n)
+}
+
+// Config returns the configuration of the synthesized groups and dashboards.
+func Config(opt Options, layout Layout) *configpb.Configuration {
+	days := int32(math.Ceil(float64(opt.Builds)*opt.Interval.Hours()/24)) + 1
+	var cfg configpb.Configuration
+	var dash *configpb.Dashboard
+	for g := 0; g < opt.Groups; g++ {
+		name := GroupName(g)
+		cfg.TestGroups = append(cfg.TestGroups, &configpb.TestGroup{
+			Name:                name,
+			GcsPrefix:           layout.Logs.Bucket() + "/" + layout.Logs.Object() + name,
+			DaysOfResults:       days,
+			NumColumnsRecent:    10,
+			UseKubernetesClient: true,
+			IsExternal:          true,
+		})
+		if g%opt.TabsPerDashboard == 0 {
+			dash = &configpb.Dashboard{Name: DashboardName(g / opt.TabsPerDashboard)}
+			cfg.Dashboards = append(cfg.Dashboards, dash)
+		}
+		dash.DashboardTab = append(dash.DashboardTab, &configpb.DashboardTab{
+			Name:             name,
+			TestGroupName:    name,
+			NumColumnsRecent: int32(opt.Columns),
+		})
+	}
+	return &cfg
+}
+
+// Generate writes the config along with every build of every group, with
+// the newest build starting at now.
+//
+// Results only depend on the options and now.
+func Generate(ctx context.Context, client gcs.Uploader, opt Options, layout Layout, now time.Time) (*configpb.Configuration, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	cfg := Config(opt, layout)
+	buf, err := proto.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	if err := client.Upload(ctx, layout.Config, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return nil, fmt.Errorf("upload config: %w", err)
+	}
+	rng := rand.New(rand.NewSource(opt.Seed))
+	for g := 0; g < opt.Groups; g++ {
+		for b := 0; b < opt.Builds; b++ {
+			started := now.Add(-time.Duration(opt.Builds-1-b) * opt.Interval)
+			if err := writeBuild(ctx, client, rng, opt, layout, GroupName(g), b+1, started); err != nil {
+				return nil, fmt.Errorf("%s build %d: %w", GroupName(g), b+1, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// mustURL returns a relative reference to the path.
+func mustURL(path string) *url.URL {
+	return &url.URL{Path: path}
+}
+
+// writeBuild writes the started.json, finished.json and junit.xml of a build.
+func writeBuild(ctx context.Context, client gcs.Uploader, rng *rand.Rand, opt Options, layout Layout, group string, build int, started time.Time) error {
+	dir, err := layout.Logs.ResolveReference(mustURL(fmt.Sprintf("%s/%d/", group, build)))
+	if err != nil {
+		return fmt.Errorf("build path: %w", err)
+	}
+	suite := junit.Suite{Name: "loadgen"}
+	passed := true
+	var elapsed float64
+	for t := 0; t < opt.Tests; t++ {
+		r := junit.Result{
+			Name: fmt.Sprintf("test-%d", t),
+			Time: 0.5 + rng.Float64(),
+		}
+		elapsed += r.Time
+		if rng.Float64() < opt.FailureRate {
+			msg := fmt.Sprintf("synthetic failure %d of test-%d", rng.Intn(5), t)
+			r.Failure = &msg
+			passed = false
+		}
+		suite.Results = append(suite.Results, r)
+	}
+	finished := started.Add(time.Duration(elapsed * float64(time.Second))).Unix()
+
+	upload := func(name string, buf []byte) error {
+		p, err := dir.ResolveReference(mustURL(name))
+		if err != nil {
+			return fmt.Errorf("%s path: %w", name, err)
+		}
+		if err := client.Upload(ctx, *p, buf, gcs.DefaultACL, ""); err != nil {
+			return fmt.Errorf("upload %s: %w", name, err)
+		}
+		return nil
+	}
+	buf, err := json.Marshal(metadata.Started{Timestamp: started.Unix()})
+	if err != nil {
+		return fmt.Errorf("marshal started: %w", err)
+	}
+	if err := upload("started.json", buf); err != nil {
+		return err
+	}
+	buf, err = xml.Marshal(junit.Suites{Suites: []junit.Suite{suite}})
+	if err != nil {
+		return fmt.Errorf("marshal junit: %w", err)
+	}
+	if err := upload("artifacts/junit_01.xml", buf); err != nil {
+		return err
+	}
+	result := "SUCCESS"
+	if !passed {
+		result = "FAILURE"
+	}
+	buf, err = json.Marshal(metadata.Finished{Timestamp: &finished, Passed: &passed, Result: result})
+	if err != nil {
+		return fmt.Errorf("marshal finished: %w", err)
+	}
+	return upload("finished.json", buf)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("NewPath(%q): %v", s, err)
+	}
+	return *p
+}
+
+func TestOptionsValidate(t *testing.T) {
+	good := Options{Groups: 1, Builds: 1, Tests: 1, TabsPerDashboard: 1, Interval: time.Hour}
+	cases := []struct {
+		name   string
+		mutate func(*Options)
+		err    bool
+	}{
+		{
+			name:   "basically works",
+			mutate: func(*Options) {},
+		},
+		{
+			name:   "groups",
+			mutate: func(o *Options) { o.Groups = 0 },
+			err:    true,
+		},
+		{
+			name:   "builds",
+			mutate: func(o *Options) { o.Builds = 0 },
+			err:    true,
+		},
+		{
+			name:   "tests",
+			mutate: func(o *Options) { o.Tests = 0 },
+			err:    true,
+		},
+		{
+			name:   "tabs per dashboard",
+			mutate: func(o *Options) { o.TabsPerDashboard = 0 },
+			err:    true,
+		},
+		{
+			name:   "negative columns",
+			mutate: func(o *Options) { o.Columns = -1 },
+			err:    true,
+		},
+		{
+			name:   "failure rate",
+			mutate: func(o *Options) { o.FailureRate = 1.5 },
+			err:    true,
+		},
+		{
+			name:   "interval",
+			mutate: func(o *Options) { o.Interval = 0 },
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := good
+			tc.mutate(&opt)
+			err := opt.Validate()
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("Validate() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("Validate() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	opt := Options{
+		Groups:           5,
+		Builds:           3,
+		Tests:            4,
+		TabsPerDashboard: 2,
+		FailureRate:      0.5,
+		Interval:         time.Hour,
+		Seed:             1,
+	}
+	layout, err := NewLayout(mustPath(t, "gs://bucket/prefix/"))
+	if err != nil {
+		t.Fatalf("NewLayout(): %v", err)
+	}
+	bucket := NewBucket()
+	now := time.Unix(1600000000, 0)
+	cfg, err := Generate(ctx, bucket, opt, *layout, now)
+	if err != nil {
+		t.Fatalf("Generate(): %v", err)
+	}
+	if err := config.Validate(cfg); err != nil {
+		t.Errorf("Generate() wrote an invalid config: %v", err)
+	}
+	if got, want := len(cfg.Dashboards), 3; got != want {
+		t.Errorf("Generate() got %d dashboards, want %d", got, want)
+	}
+	read, err := config.ReadGCS(ctx, bucket, layout.Config)
+	if err != nil {
+		t.Fatalf("ReadGCS(): %v", err)
+	}
+	if got, want := len(read.TestGroups), opt.Groups; got != want {
+		t.Errorf("Generate() wrote %d groups, want %d", got, want)
+	}
+	// config plus started, finished and junit per build
+	if got, want := bucket.Len(), 1+3*opt.Groups*opt.Builds; got != want {
+		t.Errorf("Generate() wrote %d objects, want %d", got, want)
+	}
+
+	again := NewBucket()
+	if _, err := Generate(ctx, again, opt, *layout, now); err != nil {
+		t.Fatalf("Generate() again: %v", err)
+	}
+	for path, obj := range bucket.objects {
+		if diff := cmp.Diff(obj.buf, again.objects[path].buf); diff != "" {
+			t.Errorf("Generate() is not reproducible for %s (-first +second):\n%s", path, diff)
+		}
+	}
+}
+
+func TestBucketObjects(t *testing.T) {
+	ctx := context.Background()
+	bucket := NewBucket()
+	for _, p := range []string{
+		"gs://bucket/logs/a/1/started.json",
+		"gs://bucket/logs/a/2/started.json",
+		"gs://bucket/logs/a/top",
+		"gs://bucket/logs/b/1/started.json",
+		"gs://other/logs/a/1/started.json",
+	} {
+		if err := bucket.Upload(ctx, mustPath(t, p), []byte(p), false, ""); err != nil {
+			t.Fatalf("Upload(%s): %v", p, err)
+		}
+	}
+
+	cases := []struct {
+		name      string
+		prefix    string
+		delimiter string
+		start     string
+		want      []string
+	}{
+		{
+			name:   "recursive",
+			prefix: "gs://bucket/logs/a",
+			want: []string{
+				"logs/a/1/started.json",
+				"logs/a/2/started.json",
+				"logs/a/top",
+			},
+		},
+		{
+			name:      "delimiter",
+			prefix:    "gs://bucket/logs/a/",
+			delimiter: "/",
+			want: []string{
+				"prefix:logs/a/1/",
+				"prefix:logs/a/2/",
+				"logs/a/top",
+			},
+		},
+		{
+			name:      "start offset",
+			prefix:    "gs://bucket/logs/a/",
+			delimiter: "/",
+			start:     "logs/a/2",
+			want: []string{
+				"prefix:logs/a/2/",
+				"logs/a/top",
+			},
+		},
+		{
+			name:   "other bucket",
+			prefix: "gs://other/logs",
+			want:   []string{"logs/a/1/started.json"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			it := bucket.Objects(ctx, mustPath(t, tc.prefix), tc.delimiter, tc.start)
+			var got []string
+			for {
+				attrs, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next(): %v", err)
+				}
+				if attrs.Prefix != "" {
+					got = append(got, "prefix:"+attrs.Prefix)
+					continue
+				}
+				got = append(got, attrs.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Objects() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Bucket is an in-memory client, so benchmarks measure processing rather
+// than the network.
+//
+// Listing follows GCS semantics for prefixes, delimiters and start offsets.
+// Conditions are ignored.
+type Bucket struct {
+	lock       sync.RWMutex
+	objects    map[gcs.Path]*memObject
+	generation int64
+}
+
+type memObject struct {
+	buf   []byte
+	attrs storage.ObjectAttrs
+}
+
+// NewBucket returns an empty in-memory client.
+func NewBucket() *Bucket {
+	return &Bucket{objects: map[gcs.Path]*memObject{}}
+}
+
+// Len returns the number of objects stored.
+func (b *Bucket) Len() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return len(b.objects)
+}
+
+// If returns the bucket, ignoring the conditions.
+func (b *Bucket) If(_, _ *storage.Conditions) gcs.ConditionalClient {
+	return b
+}
+
+// Upload stores a copy of the content.
+func (b *Bucket) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, cacheControl string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.generation++
+	b.objects[path] = &memObject{
+		buf: append([]byte{}, buf...),
+		attrs: storage.ObjectAttrs{
+			Bucket:       path.Bucket(),
+			Name:         path.Object(),
+			Size:         int64(len(buf)),
+			CacheControl: cacheControl,
+			Generation:   b.generation,
+			Updated:      time.Now(),
+		},
+	}
+	return nil
+}
+
+func (b *Bucket) get(path gcs.Path) (*memObject, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	obj, ok := b.objects[path]
+	if !ok {
+		// Callers compare against the sentinel rather than unwrapping it.
+		return nil, storage.ErrObjectNotExist
+	}
+	return obj, nil
+}
+
+// Open returns the content of the path.
+func (b *Bucket) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	obj, err := b.get(path)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(obj.buf)), nil
+}
+
+// Stat returns the attributes of the path.
+func (b *Bucket) Stat(_ context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	obj, err := b.get(path)
+	if err != nil {
+		return nil, err
+	}
+	attrs := obj.attrs
+	return &attrs, nil
+}
+
+// Copy stores the content of from at to.
+func (b *Bucket) Copy(ctx context.Context, from, to gcs.Path) error {
+	obj, err := b.get(from)
+	if err != nil {
+		return err
+	}
+	return b.Upload(ctx, to, obj.buf, false, obj.attrs.CacheControl)
+}
+
+// Delete removes the path.
+func (b *Bucket) Delete(_ context.Context, path gcs.Path) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.objects[path]; !ok {
+		return storage.ErrObjectNotExist
+	}
+	delete(b.objects, path)
+	return nil
+}
+
+// Objects lists the objects under the prefix, in name order.
+//
+// With a delimiter, names which contain it after the prefix are collapsed
+// into a single Prefix entry, like GCS does.
+func (b *Bucket) Objects(_ context.Context, prefix gcs.Path, delimiter, start string) gcs.Iterator {
+	p := prefix.Object()
+	if p != "" && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	b.lock.RLock()
+	var names []string
+	attrs := map[string]storage.ObjectAttrs{}
+	for path, obj := range b.objects {
+		if path.Bucket() != prefix.Bucket() {
+			continue
+		}
+		name := path.Object()
+		if !strings.HasPrefix(name, p) || name < start {
+			continue
+		}
+		names = append(names, name)
+		attrs[name] = obj.attrs
+	}
+	b.lock.RUnlock()
+	sort.Strings(names)
+
+	var out []*storage.ObjectAttrs
+	seen := map[string]bool{}
+	for _, name := range names {
+		if delimiter != "" {
+			if idx := strings.Index(name[len(p):], delimiter); idx >= 0 {
+				dir := name[:len(p)+idx+len(delimiter)]
+				if !seen[dir] {
+					seen[dir] = true
+					out = append(out, &storage.ObjectAttrs{Prefix: dir})
+				}
+				continue
+			}
+		}
+		a := attrs[name]
+		out = append(out, &a)
+	}
+	return &memIterator{attrs: out}
+}
+
+type memIterator struct {
+	attrs []*storage.ObjectAttrs
+}
+
+func (mi *memIterator) Next() (*storage.ObjectAttrs, error) {
+	if len(mi.attrs) == 0 {
+		return nil, iterator.Done
+	}
+	attrs := mi.attrs[0]
+	mi.attrs = mi.attrs[1:]
+	return attrs, nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notes stores triage notes about the rows and cells of test groups
+// alongside their grids.
+package notes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/api/googleapi"
+
+	notepb "github.com/GoogleCloudPlatform/testgrid/pb/notes"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const (
+	stateSuffix = ".notes"
+
+	// MaxTextBytes limits the text of a note.
+	MaxTextBytes = 2048
+)
+
+// Path returns the path to the notes about the rows of a grid.
+func Path(gridPath gcs.Path) (*gcs.Path, error) {
+	return gcs.NewPath(gridPath.String() + stateSuffix)
+}
+
+// Read returns the notes at the path along with their generation.
+//
+// Returns an empty state and zero generation when the path does not exist.
+func Read(ctx context.Context, client gcs.Client, path gcs.Path) (*notepb.NoteState, int64, error) {
+	var state notepb.NoteState
+	attrs, err := client.Stat(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return &state, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("stat: %w", err)
+	}
+	r, err := client.Open(ctx, path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read: %w", err)
+	}
+	if err := proto.Unmarshal(buf, &state); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &state, attrs.Generation, nil
+}
+
+// Update writes the notes when update changes them.
+//
+// Retries when the notes change concurrently.
+func Update(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, update func(*notepb.NoteState) bool) (*notepb.NoteState, error) {
+	const attempts = 3
+	for i := 0; ; i++ {
+		state, generation, err := Read(ctx, client, path)
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		if !update(state) {
+			return state, nil
+		}
+		buf, err := proto.Marshal(state)
+		if err != nil {
+			return nil, fmt.Errorf("marshal: %w", err)
+		}
+		cond := storage.Conditions{GenerationMatch: generation}
+		if generation == 0 {
+			cond = storage.Conditions{DoesNotExist: true}
+		}
+		err = client.If(nil, &cond).Upload(ctx, path, buf, gcs.DefaultACL, "no-cache")
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed && i+1 < attempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("upload: %w", err)
+		}
+		return state, nil
+	}
+}
+
+// Validate returns an error when the note lacks a row or text, or its text is too long.
+func Validate(note *notepb.Note) error {
+	switch {
+	case note.GetRow() == "":
+		return errors.New("missing row")
+	case note.GetText() == "":
+		return errors.New("missing text")
+	case len(note.GetText()) > MaxTextBytes:
+		return fmt.Errorf("text exceeds %d bytes", MaxTextBytes)
+	case note.GetFirstBuild() == "" && note.GetLastBuild() != "":
+		return errors.New("last_build requires a first_build")
+	}
+	return nil
+}
+
+// seconds returns the time in seconds since epoch.
+func seconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// Add adds a copy of the note's row, builds and text by the author to the
+// state, returning the added note.
+func Add(state *notepb.NoteState, note *notepb.Note, author string, now time.Time) *notepb.Note {
+	added := &notepb.Note{
+		Id:         strconv.Itoa(len(state.Notes) + 1),
+		Row:        note.Row,
+		FirstBuild: note.FirstBuild,
+		LastBuild:  note.LastBuild,
+		Text:       note.Text,
+		Author:     author,
+		Created:    seconds(now),
+		Modified:   seconds(now),
+	}
+	if added.LastBuild == "" {
+		added.LastBuild = added.FirstBuild
+	}
+	state.Notes = append(state.Notes, added)
+	return added
+}
+
+// find returns the undeleted note of the row with the ID, or nil.
+func find(state *notepb.NoteState, row, id string) *notepb.Note {
+	for _, n := range state.Notes {
+		if n.Id == id && n.Row == row && !n.Deleted {
+			return n
+		}
+	}
+	return nil
+}
+
+// Edit replaces the text of the row's note with the ID, moving the current
+// text into its history, returning the note or nil if it does not exist.
+func Edit(state *notepb.NoteState, row, id, text, author string, now time.Time) *notepb.Note {
+	n := find(state, row, id)
+	if n == nil {
+		return nil
+	}
+	n.History = append(n.History, &notepb.NoteRevision{Text: n.Text, Author: n.Author, Modified: n.Modified})
+	n.Text, n.Author, n.Modified = text, author, seconds(now)
+	return n
+}
+
+// Delete marks the row's note with the ID as deleted by the author,
+// returning false if it does not exist.
+func Delete(state *notepb.NoteState, row, id, author string, now time.Time) bool {
+	n := find(state, row, id)
+	if n == nil {
+		return false
+	}
+	n.History = append(n.History, &notepb.NoteRevision{Text: n.Text, Author: n.Author, Modified: n.Modified})
+	n.Text, n.Author, n.Modified, n.Deleted = "", author, seconds(now), true
+	return true
+}
+
+// Live returns the undeleted notes of the state, grouped by row.
+func Live(state *notepb.NoteState) map[string][]*notepb.Note {
+	out := map[string][]*notepb.Note{}
+	for _, n := range state.GetNotes() {
+		if !n.Deleted {
+			out[n.Row] = append(out[n.Row], n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	notepb "github.com/GoogleCloudPlatform/testgrid/pb/notes"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestValidate(t *testing.T) {
+	long := make([]byte, MaxTextBytes+1)
+	cases := []struct {
+		name string
+		note *notepb.Note
+		err  bool
+	}{
+		{
+			name: "row note",
+			note: &notepb.Note{Row: "foo", Text: "known flake"},
+		},
+		{
+			name: "cell range",
+			note: &notepb.Note{Row: "foo", Text: "infra outage", FirstBuild: "10", LastBuild: "12"},
+		},
+		{
+			name: "missing row",
+			note: &notepb.Note{Text: "hello"},
+			err:  true,
+		},
+		{
+			name: "missing text",
+			note: &notepb.Note{Row: "foo"},
+			err:  true,
+		},
+		{
+			name: "long text",
+			note: &notepb.Note{Row: "foo", Text: string(long)},
+			err:  true,
+		},
+		{
+			name: "last build without first",
+			note: &notepb.Note{Row: "foo", Text: "hello", LastBuild: "12"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.note)
+			if (err != nil) != tc.err {
+				t.Errorf("Validate() got error %v, want error %t", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAddEditDelete(t *testing.T) {
+	created := time.Unix(100, 0)
+	edited := time.Unix(200, 0)
+	deleted := time.Unix(300, 0)
+	var state notepb.NoteState
+
+	got := Add(&state, &notepb.Note{Row: "foo", FirstBuild: "10", Text: "flake"}, "alice@example.com", created)
+	want := &notepb.Note{
+		Id:         "1",
+		Row:        "foo",
+		FirstBuild: "10",
+		LastBuild:  "10",
+		Text:       "flake",
+		Author:     "alice@example.com",
+		Created:    100,
+		Modified:   100,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Add() got unexpected diff (-want +got):\n%s", diff)
+	}
+	Add(&state, &notepb.Note{Row: "bar", Text: "broken"}, "bob@example.com", created)
+
+	if got := Edit(&state, "bar", "1", "wrong row", "bob@example.com", edited); got != nil {
+		t.Errorf("Edit() of another row's note got %v, want nil", got)
+	}
+	got = Edit(&state, "foo", "1", "infra flake", "bob@example.com", edited)
+	want.Text, want.Author, want.Modified = "infra flake", "bob@example.com", 200
+	want.History = []*notepb.NoteRevision{{Text: "flake", Author: "alice@example.com", Modified: 100}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Edit() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if !Delete(&state, "foo", "1", "carol@example.com", deleted) {
+		t.Error("Delete() failed to delete the note")
+	}
+	if Delete(&state, "foo", "1", "carol@example.com", deleted) {
+		t.Error("Delete() deleted a deleted note")
+	}
+	if got := Edit(&state, "foo", "1", "revived", "carol@example.com", deleted); got != nil {
+		t.Errorf("Edit() of a deleted note got %v, want nil", got)
+	}
+	want.Text, want.Author, want.Modified, want.Deleted = "", "carol@example.com", 300, true
+	want.History = append(want.History, &notepb.NoteRevision{Text: "infra flake", Author: "bob@example.com", Modified: 200})
+	if diff := cmp.Diff(want, state.Notes[0], protocmp.Transform()); diff != "" {
+		t.Errorf("Delete() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	live := Live(&state)
+	if len(live["foo"]) != 0 || len(live["bar"]) != 1 || live["bar"][0].Id != "2" {
+		t.Errorf("Live() got unexpected notes: %v", live)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	path, err := gcs.NewPath("gs://bucket/grid/group.notes")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	existing := &notepb.NoteState{Notes: []*notepb.Note{{Id: "1", Row: "foo", Text: "flake"}}}
+	buf, err := proto.Marshal(existing)
+	if err != nil {
+		t.Fatalf("proto.Marshal(): %v", err)
+	}
+	cases := []struct {
+		name     string
+		state    []byte
+		change   bool
+		want     *notepb.NoteState
+		wantSave bool
+	}{
+		{
+			name: "empty",
+			want: &notepb.NoteState{},
+		},
+		{
+			name:  "unchanged",
+			state: buf,
+			want:  existing,
+		},
+		{
+			name:     "changed",
+			state:    buf,
+			change:   true,
+			want:     &notepb.NoteState{Notes: []*notepb.Note{{Id: "1", Row: "foo", Text: "flake"}, {Id: "2", Row: "bar"}}},
+			wantSave: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client:   fake.Client{Opener: fake.Opener{}},
+					Uploader: fake.Uploader{},
+					Stater:   fake.Stater{},
+				},
+			}
+			if tc.state != nil {
+				client.Opener[*path] = fake.Object{Data: string(tc.state)}
+				client.Stater[*path] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 1}}
+			}
+			got, err := Update(context.Background(), client, *path, func(state *notepb.NoteState) bool {
+				if tc.change {
+					state.Notes = append(state.Notes, &notepb.Note{Id: "2", Row: "bar"})
+				}
+				return tc.change
+			})
+			if err != nil {
+				t.Fatalf("Update() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Update() got unexpected diff (-want +got):\n%s", diff)
+			}
+			u, saved := client.Uploader[*path]
+			if saved != tc.wantSave {
+				t.Fatalf("Update() saved %t, want %t", saved, tc.wantSave)
+			}
+			if !saved {
+				return
+			}
+			var gotSave notepb.NoteState
+			if err := proto.Unmarshal(u.Buf, &gotSave); err != nil {
+				t.Fatalf("proto.Unmarshal(): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, &gotSave, protocmp.Transform()); diff != "" {
+				t.Errorf("Update() saved unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
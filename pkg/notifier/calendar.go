@@ -0,0 +1,381 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// maxOccurrences limits how many occurrences of a recurring event are expanded.
+const maxOccurrences = 10000
+
+// LoadCalendars returns a copy of the schedule which is also silenced by the
+// events of its calendars between now and the horizon.
+func (s *Schedule) LoadCalendars(ctx context.Context, opener gcs.Opener, now time.Time, horizon time.Duration) (*Schedule, error) {
+	var silences []Silence
+	for _, p := range s.calendars {
+		r, err := opener.Open(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", p, err)
+		}
+		sils, err := ParseCalendar(r, s.loc, now, now.Add(horizon))
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", p, err)
+		}
+		silences = append(silences, sils...)
+	}
+	return s.WithSilences(silences), nil
+}
+
+// ParseCalendar returns the silences of the ICS calendar's events which overlap [from, to).
+//
+// Floating times are interpreted in loc. Recurring events may repeat daily or
+// weekly, optionally on specific days, with an interval, count or end.
+func ParseCalendar(r io.Reader, loc *time.Location, from, to time.Time) ([]Silence, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var silences []Silence
+	var ev *event
+	var nested int // depth of components, such as alarms, within the event
+	for _, line := range lines {
+		name, params, value := splitProperty(line)
+		switch {
+		case ev == nil:
+			if name == "BEGIN" && value == "VEVENT" {
+				ev = &event{exdates: map[int64]bool{}}
+			}
+			continue
+		case name == "BEGIN":
+			nested++
+			continue
+		case name == "END" && nested > 0:
+			nested--
+			continue
+		case nested > 0:
+			continue
+		case name == "END" && value == "VEVENT":
+			occ, err := ev.occurrences(from, to)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: %w", ev.summary, err)
+			}
+			silences = append(silences, occ...)
+			ev = nil
+			continue
+		}
+		var err error
+		switch name {
+		case "DTSTART":
+			ev.start, ev.allDay, err = parseICSTime(value, params, loc)
+		case "DTEND":
+			ev.end, _, err = parseICSTime(value, params, loc)
+		case "DURATION":
+			ev.duration, err = parseICSDuration(value)
+		case "SUMMARY":
+			ev.summary = icsText.Replace(value)
+		case "RRULE":
+			ev.rule = value
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				var t time.Time
+				if t, _, err = parseICSTime(v, params, loc); err != nil {
+					break
+				}
+				ev.exdates[t.Unix()] = true
+			}
+		case "STATUS":
+			ev.cancelled = value == "CANCELLED"
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	sort.SliceStable(silences, func(i, j int) bool {
+		return silences[i].Start.Before(silences[j].Start)
+	})
+	return silences, nil
+}
+
+type event struct {
+	start     time.Time
+	end       time.Time
+	allDay    bool
+	duration  time.Duration
+	summary   string
+	rule      string
+	exdates   map[int64]bool
+	cancelled bool
+}
+
+// occurrences returns the silences of the event which overlap [from, to).
+func (ev *event) occurrences(from, to time.Time) ([]Silence, error) {
+	if ev.cancelled {
+		return nil, nil
+	}
+	if ev.start.IsZero() {
+		return nil, errors.New("missing DTSTART")
+	}
+	length := ev.duration
+	switch {
+	case !ev.end.IsZero():
+		length = ev.end.Sub(ev.start)
+	case length == 0 && ev.allDay:
+		length = 24 * time.Hour
+	}
+	if length <= 0 {
+		return nil, nil
+	}
+	starts, err := ev.starts(to)
+	if err != nil {
+		return nil, err
+	}
+	var silences []Silence
+	for _, s := range starts {
+		if ev.exdates[s.Unix()] {
+			continue
+		}
+		end := s.Add(length)
+		if end.After(from) && s.Before(to) {
+			silences = append(silences, Silence{Start: s, End: end, Reason: ev.summary})
+		}
+	}
+	return silences, nil
+}
+
+// starts returns when each occurrence of the event starts, until the specified time.
+func (ev *event) starts(until time.Time) ([]time.Time, error) {
+	if ev.rule == "" {
+		return []time.Time{ev.start}, nil
+	}
+	r, err := parseRule(ev.rule, ev.start.Location())
+	if err != nil {
+		return nil, fmt.Errorf("RRULE: %w", err)
+	}
+	var out []time.Time
+	add := func(t time.Time) bool {
+		switch {
+		case t.Before(ev.start):
+			return true
+		case !t.Before(until), !r.until.IsZero() && t.After(r.until), r.count > 0 && len(out) >= r.count:
+			return false
+		}
+		out = append(out, t)
+		return true
+	}
+	// Monday of the first week, for weekly rules on specific days.
+	monday := ev.start.AddDate(0, 0, -((int(ev.start.Weekday()) + 6) % 7))
+	for i := 0; i < maxOccurrences; i++ {
+		switch {
+		case r.freq == "DAILY":
+			if !add(ev.start.AddDate(0, 0, i*r.interval)) {
+				return out, nil
+			}
+		case len(r.days) == 0:
+			if !add(ev.start.AddDate(0, 0, 7*i*r.interval)) {
+				return out, nil
+			}
+		default:
+			week := monday.AddDate(0, 0, 7*i*r.interval)
+			for _, d := range r.days {
+				if !add(week.AddDate(0, 0, (int(d)+6)%7)) {
+					return out, nil
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+type rule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	days     []time.Weekday // ordered from Monday
+}
+
+var icsDays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+func parseRule(s string, loc *time.Location) (*rule, error) {
+	r := rule{interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("bad part %q", part)
+		}
+		var err error
+		switch key, val := kv[0], kv[1]; key {
+		case "FREQ":
+			if val != "DAILY" && val != "WEEKLY" {
+				return nil, fmt.Errorf("unsupported FREQ %q", val)
+			}
+			r.freq = val
+		case "INTERVAL":
+			if r.interval, err = strconv.Atoi(val); err == nil && r.interval < 1 {
+				err = fmt.Errorf("must be positive, got %d", r.interval)
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(val)
+		case "UNTIL":
+			r.until, _, err = parseICSTime(val, nil, loc)
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, ok := icsDays[d]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY %q", d)
+				}
+				r.days = append(r.days, wd)
+			}
+			sort.Slice(r.days, func(i, j int) bool {
+				return (r.days[i]+6)%7 < (r.days[j]+6)%7
+			})
+		case "WKST":
+			if val != "MO" {
+				return nil, fmt.Errorf("unsupported WKST %q", val)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", kv[0], err)
+		}
+	}
+	if r.freq == "" {
+		return nil, errors.New("missing FREQ")
+	}
+	if r.freq != "WEEKLY" && len(r.days) > 0 {
+		return nil, fmt.Errorf("BYDAY requires FREQ=WEEKLY, got %s", r.freq)
+	}
+	return &r, nil
+}
+
+// parseICSTime parses a DATE or DATE-TIME value, returning true for dates.
+func parseICSTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("TZID: %w", err)
+		}
+		loc = l
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+var icsDurationRE = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICSDuration parses a positive duration, such as P1D or PT2H30M.
+func parseICSDuration(s string) (time.Duration, error) {
+	m := icsDurationRE.FindStringSubmatch(strings.TrimPrefix(s, "+"))
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("bad duration %q", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, u := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * u
+	}
+	return d, nil
+}
+
+var icsText = strings.NewReplacer(`\\`, `\`, `\,`, `,`, `\;`, `;`, `\n`, "\n", `\N`, "\n")
+
+// unfoldLines returns the content lines of the calendar, joining folded lines.
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if n := len(lines); n > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[n-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	return lines, nil
+}
+
+// splitProperty splits a content line, such as DTSTART;TZID=Europe/Berlin:20210601T200000,
+// into its name, parameters and value.
+func splitProperty(line string) (string, map[string]string, string) {
+	var quoted bool
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+	parts := strings.Split(line[:colon], ";")
+	var params map[string]string
+	for _, p := range parts[1:] {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if params == nil {
+			params = map[string]string{}
+		}
+		params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:]
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestParseCalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	utc := func(day, hour int) time.Time {
+		return time.Date(2021, 6, day, hour, 0, 0, 0, time.UTC)
+	}
+	from, to := utc(1, 0), utc(15, 0)
+	calendar := func(events ...string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(events, "") + "END:VCALENDAR\r\n"
+	}
+
+	cases := []struct {
+		name    string
+		ics     string
+		want    []Silence
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			ics:  calendar(),
+		},
+		{
+			name: "one-off event",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210602T200000Z\r\n" +
+				"DTEND:20210602T220000Z\r\n" +
+				"SUMMARY:DB failover\\, phase 1\r\n" +
+				"END:VEVENT\r\n"),
+			want: []Silence{{Start: utc(2, 20), End: utc(2, 22), Reason: "DB failover, phase 1"}},
+		},
+		{
+			name: "folded lines, time zones and durations",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART;TZID=\"Europe/Berlin\":20210602T220000\r\n" +
+				"DURATION:PT2H\r\n" +
+				"SUMMARY:network\r\n" +
+				"  upgrade\r\n" +
+				"BEGIN:VALARM\r\n" +
+				"DURATION:PT15M\r\n" +
+				"END:VALARM\r\n" +
+				"END:VEVENT\r\n"),
+			want: []Silence{{Start: utc(2, 20), End: utc(2, 22), Reason: "network upgrade"}},
+		},
+		{
+			name: "floating all-day events",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20210605\r\n" +
+				"SUMMARY:datacenter move\r\n" +
+				"END:VEVENT\r\n"),
+			want: []Silence{{
+				Start:  time.Date(2021, 6, 5, 0, 0, 0, 0, berlin),
+				End:    time.Date(2021, 6, 6, 0, 0, 0, 0, berlin),
+				Reason: "datacenter move",
+			}},
+		},
+		{
+			name: "events outside the range",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210520T200000Z\r\n" +
+				"DTEND:20210520T220000Z\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART:20210620T200000Z\r\n" +
+				"DTEND:20210620T220000Z\r\n" +
+				"END:VEVENT\r\n"),
+		},
+		{
+			name: "cancelled events",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210602T200000Z\r\n" +
+				"DTEND:20210602T220000Z\r\n" +
+				"STATUS:CANCELLED\r\n" +
+				"END:VEVENT\r\n"),
+		},
+		{
+			name: "weekly events on specific days",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210525T200000Z\r\n" + // Tuesday
+				"DTEND:20210525T210000Z\r\n" +
+				"RRULE:FREQ=WEEKLY;BYDAY=TH,TU\r\n" +
+				"EXDATE:20210603T200000Z\r\n" +
+				"SUMMARY:patching\r\n" +
+				"END:VEVENT\r\n"),
+			want: []Silence{
+				{Start: utc(1, 20), End: utc(1, 21), Reason: "patching"},
+				{Start: utc(8, 20), End: utc(8, 21), Reason: "patching"},
+				{Start: utc(10, 20), End: utc(10, 21), Reason: "patching"},
+			},
+		},
+		{
+			name: "daily events with an interval and count",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210601T060000Z\r\n" +
+				"DTEND:20210601T063000Z\r\n" +
+				"RRULE:FREQ=DAILY;INTERVAL=3;COUNT=3\r\n" +
+				"END:VEVENT\r\n"),
+			want: []Silence{
+				{Start: utc(1, 6), End: utc(1, 6).Add(30 * time.Minute)},
+				{Start: utc(4, 6), End: utc(4, 6).Add(30 * time.Minute)},
+				{Start: utc(7, 6), End: utc(7, 6).Add(30 * time.Minute)},
+			},
+		},
+		{
+			name: "daily events until",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210601T060000Z\r\n" +
+				"DTEND:20210601T063000Z\r\n" +
+				"RRULE:FREQ=DAILY;UNTIL=20210602T060000Z\r\n" +
+				"END:VEVENT\r\n"),
+			want: []Silence{
+				{Start: utc(1, 6), End: utc(1, 6).Add(30 * time.Minute)},
+				{Start: utc(2, 6), End: utc(2, 6).Add(30 * time.Minute)},
+			},
+		},
+		{
+			name: "unsupported rules",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:20210601T060000Z\r\n" +
+				"DTEND:20210601T063000Z\r\n" +
+				"RRULE:FREQ=MONTHLY\r\n" +
+				"END:VEVENT\r\n"),
+			wantErr: true,
+		},
+		{
+			name: "bad time",
+			ics: calendar("BEGIN:VEVENT\r\n" +
+				"DTSTART:tomorrow\r\n" +
+				"END:VEVENT\r\n"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseCalendar(strings.NewReader(tc.ics), berlin, from, to)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("ParseCalendar() got unexpected error: %v", err)
+				}
+			case tc.wantErr:
+				t.Error("ParseCalendar() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got, cmp.Comparer(time.Time.Equal)); diff != "" {
+					t.Errorf("ParseCalendar() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadCalendars(t *testing.T) {
+	path, err := gcs.NewPath("gs://bucket/maintenance.ics")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	s, err := NewSchedule(&configpb.NotificationSchedule{SilenceCalendars: []string{path.String()}})
+	if err != nil {
+		t.Fatalf("NewSchedule() got unexpected error: %v", err)
+	}
+	opener := fake.Opener{
+		*path: {Data: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20210602T200000Z\nDTEND:20210602T220000Z\nSUMMARY:upgrade\nEND:VEVENT\nEND:VCALENDAR\n"},
+	}
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	loaded, err := s.LoadCalendars(context.Background(), opener, now, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("LoadCalendars() got unexpected error: %v", err)
+	}
+	when := time.Date(2021, 6, 2, 21, 0, 0, 0, time.UTC)
+	if reason, silenced := loaded.Silenced(when); !silenced || reason != "upgrade" {
+		t.Errorf("LoadCalendars() got %q, %t, want upgrade, true", reason, silenced)
+	}
+	if _, silenced := s.Silenced(when); silenced {
+		t.Error("LoadCalendars() modified the original schedule")
+	}
+	if _, err := s.LoadCalendars(context.Background(), fake.Opener{}, now, time.Hour); err == nil {
+		t.Error("LoadCalendars() failed to return an error for a missing calendar")
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// calendarHorizon is how far ahead silence calendars are expanded each cycle.
+const calendarHorizon = 24 * time.Hour
+
+// Driver sends the notifications of the dashboards' schedules as the
+// summarizer writes their summaries, see summarizer.NotifyWith.
+type Driver struct {
+	// Templates renders the body of each notification.
+	Templates *Templates
+	// Client posts to webhooks, http.DefaultClient when nil.
+	Client *http.Client
+	// Senders delivers the channels of plugins.
+	Senders Senders
+	// Calendars reads the silence calendars of schedules.
+	Calendars gcs.Opener
+	// TabURL links notifications to the dashboard tab if set.
+	TabURL func(dashboard, tab string) string
+}
+
+// NotifyDashboard takes the escalation steps of each failing tab of the
+// dashboard's summary.
+//
+// Each step notifies a failing tab once, unless its failing tests change. The
+// notifications leave out snoozed tests, and skip tabs whose failing tests
+// are all snoozed. The summary records the steps and alerts it notified.
+func (d *Driver) NotifyDashboard(ctx context.Context, cfg *configpb.Configuration, dash *configpb.Dashboard, sum *summarypb.DashboardSummary, now time.Time) error {
+	if dash.GetNotificationSchedule() == nil {
+		return nil
+	}
+	sched, err := NewSchedule(dash.NotificationSchedule)
+	if err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+	if len(sched.Calendars()) > 0 {
+		if sched, err = sched.LoadCalendars(ctx, d.Calendars, now, calendarHorizon); err != nil {
+			return fmt.Errorf("calendars: %w", err)
+		}
+	}
+	groups := map[string]string{}
+	for _, tab := range dash.DashboardTab {
+		groups[tab.Name] = tab.TestGroupName
+	}
+	var failures int
+	var last error
+	for _, tab := range sum.TabSummaries {
+		var owners *Owners
+		if tg := config.FindTestGroup(groups[tab.DashboardTabName], cfg); tg != nil {
+			if owners, err = NewOwners(tg); err != nil {
+				failures++
+				last = fmt.Errorf("%s: owners: %w", tab.DashboardTabName, err)
+				continue
+			}
+		}
+		if err := d.notifyTab(ctx, sched, owners, dash, tab, now); err != nil {
+			failures++
+			last = fmt.Errorf("%s: %w", tab.DashboardTabName, err)
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to notify %d tabs, last: %w", failures, last)
+	}
+	return nil
+}
+
+// notifyTab takes the escalation steps of the tab which it has not taken yet,
+// or every step when a failing test changed.
+func (d *Driver) notifyTab(ctx context.Context, sched *Schedule, owners *Owners, dash *configpb.Dashboard, tab *summarypb.DashboardTabSummary, now time.Time) error {
+	if tab.FailingCycles == 0 {
+		return nil
+	}
+	steps := sched.BudgetEscalations(int(tab.FailingCycles), tab.ErrorBudget, now)
+	if len(steps) == 0 {
+		return nil
+	}
+	var active []*summarypb.FailingTestSummary
+	var changed bool
+	for _, fts := range tab.FailingTestSummaries {
+		if summarizer.Snoozed(fts, now) {
+			continue
+		}
+		active = append(active, fts)
+		if summarizer.ShouldNotify(fts, now) {
+			changed = true
+		}
+	}
+	if len(tab.FailingTestSummaries) > 0 && len(active) == 0 {
+		return nil
+	}
+	notified := map[string]bool{}
+	for _, key := range tab.NotifiedSteps {
+		notified[key] = true
+	}
+	msg := Message{
+		Dashboard: dash.Name,
+		Tab:       tab.DashboardTabName,
+		Summary:   proto.Clone(tab).(*summarypb.DashboardTabSummary),
+		Now:       now,
+	}
+	msg.Summary.FailingTestSummaries = active
+	if d.TabURL != nil {
+		msg.TabURL = d.TabURL(dash.Name, tab.DashboardTabName)
+	}
+	routes := dash.NotificationSchedule.GetOwnerRoutes()
+	var sent bool
+	var last error
+	for _, step := range steps {
+		key := stepKey(step)
+		if notified[key] && !changed {
+			continue
+		}
+		msg.Step = step
+		if err := d.Senders.Dispatch(ctx, d.Templates, d.Client, owners, routes, msg); err != nil {
+			last = err
+			continue
+		}
+		sent = true
+		if !notified[key] {
+			notified[key] = true
+			tab.NotifiedSteps = append(tab.NotifiedSteps, key)
+		}
+	}
+	if sent {
+		for _, fts := range active {
+			summarizer.MarkNotified(fts, now)
+		}
+	}
+	return last
+}
+
+// stepKey identifies the escalation step in the steps notified about a tab.
+func stepKey(step *configpb.EscalationStep) string {
+	if step.BudgetExhausted {
+		return fmt.Sprintf("budget/%s/%s", step.Channel, step.Target)
+	}
+	return fmt.Sprintf("%d/%s/%s", step.FailingCycles, step.Channel, step.Target)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestDriverNotifyDashboard(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	templates, err := NewTemplates(fakeStore{Opener: fake.Opener{}, Stater: fake.Stater{}}, *configPath, "notifications", "en")
+	if err != nil {
+		t.Fatalf("NewTemplates(): %v", err)
+	}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}},
+	}
+	dash := &configpb.Dashboard{
+		Name:         "dash",
+		DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+		NotificationSchedule: &configpb.NotificationSchedule{
+			EscalationSteps: []*configpb.EscalationStep{
+				{FailingCycles: 1, Channel: "pager", Target: "oncall"},
+				{FailingCycles: 3, Channel: "pager", Target: "manager"},
+			},
+		},
+	}
+	now := time.Unix(1000, 0)
+	fts := &summarypb.FailingTestSummary{TestName: "test", AlertId: "a", FailureMessage: "boom"}
+	tab := &summarypb.DashboardTabSummary{
+		DashboardTabName:     "tab",
+		OverallStatus:        summarypb.DashboardTabSummary_FAIL,
+		FailingTestSummaries: []*summarypb.FailingTestSummary{fts},
+	}
+	sum := &summarypb.DashboardSummary{TabSummaries: []*summarypb.DashboardTabSummary{tab}}
+	pager := &recordingSender{}
+	d := Driver{
+		Templates: templates,
+		Senders:   Senders{"pager": pager},
+		TabURL: func(dashboard, tab string) string {
+			return "https://testgrid.example/" + dashboard + "#" + tab
+		},
+	}
+
+	// Each cycle counts another failing summary before notifying.
+	cycles := []struct {
+		name   string
+		modify func()
+		want   []string
+	}{
+		{
+			name: "first failure notifies the first step",
+			want: []string{"oncall"},
+		},
+		{
+			name: "unchanged failures notify nothing",
+		},
+		{
+			name: "escalate to the next step",
+			want: []string{"manager"},
+		},
+		{
+			name: "changed failures notify every step",
+			modify: func() {
+				fts.FailureMessage = "bang"
+			},
+			want: []string{"oncall", "manager"},
+		},
+		{
+			name: "snoozed failures notify nothing",
+			modify: func() {
+				fts.FailureMessage = "crash"
+				fts.AlertState.SnoozeUntil = &timestamp.Timestamp{Seconds: now.Add(time.Hour).Unix()}
+			},
+		},
+	}
+	for _, c := range cycles {
+		t.Run(c.name, func(t *testing.T) {
+			tab.FailingCycles++
+			if c.modify != nil {
+				c.modify()
+			}
+			pager.sent = nil
+			if err := d.NotifyDashboard(context.Background(), cfg, dash, sum, now); err != nil {
+				t.Fatalf("NotifyDashboard() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, s := range pager.sent {
+				got = append(got, strings.SplitN(s, ":", 2)[0])
+			}
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Errorf("NotifyDashboard() sent unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	pager.sent = nil
+	tab.FailingCycles = 0
+	tab.NotifiedSteps = nil
+	if err := d.NotifyDashboard(context.Background(), cfg, dash, sum, now); err != nil {
+		t.Fatalf("NotifyDashboard() got unexpected error: %v", err)
+	}
+	if len(pager.sent) > 0 {
+		t.Errorf("NotifyDashboard() sent %v about a passing tab", pager.sent)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// Owners finds the owner of failing tests from a test group's test_metadata_options.
+type Owners struct {
+	options []ownerOption
+}
+
+type ownerOption struct {
+	owner   string
+	name    *regexp.Regexp
+	message *regexp.Regexp
+}
+
+// NewOwners returns the owners of the test group's tests.
+func NewOwners(tg *configpb.TestGroup) (*Owners, error) {
+	var o Owners
+	for _, opt := range tg.GetTestMetadataOptions() {
+		if opt.GetOwner() == "" {
+			continue
+		}
+		oo := ownerOption{owner: opt.GetOwner()}
+		var err error
+		if re := opt.GetTestNameRegex(); re != "" {
+			if oo.name, err = regexp.Compile(re); err != nil {
+				return nil, fmt.Errorf("test_name_regex %q: %w", re, err)
+			}
+		}
+		if re := opt.GetMessageRegex(); re != "" {
+			if oo.message, err = regexp.Compile(re); err != nil {
+				return nil, fmt.Errorf("message_regex %q: %w", re, err)
+			}
+		}
+		o.options = append(o.options, oo)
+	}
+	return &o, nil
+}
+
+// Owner returns the owner of the first option matching the failing test, if any.
+func (o *Owners) Owner(f *summarypb.FailingTestSummary) string {
+	if o == nil {
+		return ""
+	}
+	name := f.GetTestName()
+	if name == "" {
+		name = f.GetDisplayName()
+	}
+	for _, opt := range o.options {
+		if opt.name != nil && !opt.name.MatchString(name) {
+			continue
+		}
+		if opt.message != nil && !opt.message.MatchString(f.GetFailureMessage()) {
+			continue
+		}
+		return opt.owner
+	}
+	return ""
+}
+
+// Route splits the alert of the message's escalation step by owner.
+//
+// The failures of tests whose owner has a route go to each of the owner's routes.
+// The step's own target receives the remaining failures, or the tab-wide alert
+// when the tab has no failing tests.
+func Route(msg Message, owners *Owners, routes []*configpb.OwnerRoute) []Message {
+	byOwner := map[string][]*configpb.OwnerRoute{}
+	for _, r := range routes {
+		byOwner[r.GetOwner()] = append(byOwner[r.GetOwner()], r)
+	}
+
+	failures := msg.Failures()
+	routed := map[*configpb.OwnerRoute][]*summarypb.FailingTestSummary{}
+	var unrouted []*summarypb.FailingTestSummary
+	for _, f := range failures {
+		owned := byOwner[owners.Owner(f)]
+		if len(owned) == 0 {
+			unrouted = append(unrouted, f)
+			continue
+		}
+		for _, r := range owned {
+			routed[r] = append(routed[r], f)
+		}
+	}
+
+	var msgs []Message
+	if len(failures) == 0 || len(unrouted) > 0 {
+		msgs = append(msgs, withFailures(msg, msg.Step, unrouted))
+	}
+	for _, r := range routes {
+		fs, ok := routed[r]
+		if !ok {
+			continue
+		}
+		step := &configpb.EscalationStep{}
+		if msg.Step != nil {
+			step = proto.Clone(msg.Step).(*configpb.EscalationStep)
+		}
+		if r.GetChannel() != "" {
+			step.Channel = r.GetChannel()
+		}
+		step.Target = r.GetTarget()
+		msgs = append(msgs, withFailures(msg, step, fs))
+	}
+	return msgs
+}
+
+// withFailures returns a copy of the message to the step about only the failures.
+func withFailures(msg Message, step *configpb.EscalationStep, failures []*summarypb.FailingTestSummary) Message {
+	msg.Step = step
+	if msg.Summary != nil {
+		sum := proto.Clone(msg.Summary).(*summarypb.DashboardTabSummary)
+		sum.FailingTestSummaries = failures
+		msg.Summary = sum
+	}
+	return msg
+}
+
+// Dispatch sends the alert of the message's escalation step, routing the failures
+// of each owner's tests to the owner's team.
+func Dispatch(ctx context.Context, templates *Templates, client *http.Client, owners *Owners, routes []*configpb.OwnerRoute, msg Message) error {
+	return Senders(nil).Dispatch(ctx, templates, client, owners, routes, msg)
+}
+
+// Dispatch sends the alert of the message's escalation step like Dispatch,
+// with the sender of each channel.
+func (s Senders) Dispatch(ctx context.Context, templates *Templates, client *http.Client, owners *Owners, routes []*configpb.OwnerRoute, msg Message) error {
+	msgs := Route(msg, owners, routes)
+	var failures int
+	var last error
+	for _, m := range msgs {
+		if err := s.Notify(ctx, templates, client, m); err != nil {
+			failures++
+			last = fmt.Errorf("%s %s: %w", m.Step.GetChannel(), m.Step.GetTarget(), err)
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to send %d of %d notifications, last: %w", failures, len(msgs), last)
+	}
+	return nil
+}
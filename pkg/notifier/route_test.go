@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestOwner(t *testing.T) {
+	owners, err := NewOwners(&configpb.TestGroup{
+		TestMetadataOptions: []*configpb.TestMetadataOptions{
+			{TestNameRegex: "^//storage/", MessageRegex: "timeout", Owner: "storage-infra"},
+			{TestNameRegex: "^//storage/", Owner: "storage"},
+			{TestNameRegex: "^//net/", BugComponent: 3},
+			{MessageRegex: "OOM", Owner: "memory"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewOwners() got unexpected error: %v", err)
+	}
+	cases := []struct {
+		name string
+		test *summarypb.FailingTestSummary
+		want string
+	}{
+		{
+			name: "first match wins",
+			test: &summarypb.FailingTestSummary{TestName: "//storage/disk", FailureMessage: "timeout after 5m"},
+			want: "storage-infra",
+		},
+		{
+			name: "match name",
+			test: &summarypb.FailingTestSummary{TestName: "//storage/disk", FailureMessage: "bad checksum"},
+			want: "storage",
+		},
+		{
+			name: "match display name without a test name",
+			test: &summarypb.FailingTestSummary{DisplayName: "//storage/disk"},
+			want: "storage",
+		},
+		{
+			name: "options without an owner are skipped",
+			test: &summarypb.FailingTestSummary{TestName: "//net/dns", FailureMessage: "OOM"},
+			want: "memory",
+		},
+		{
+			name: "unowned",
+			test: &summarypb.FailingTestSummary{TestName: "//net/dns"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := owners.Owner(tc.test); got != tc.want {
+				t.Errorf("Owner() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	owners, err := NewOwners(&configpb.TestGroup{
+		TestMetadataOptions: []*configpb.TestMetadataOptions{
+			{TestNameRegex: "^storage", Owner: "storage"},
+			{TestNameRegex: "^net", Owner: "net"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewOwners() got unexpected error: %v", err)
+	}
+	routes := []*configpb.OwnerRoute{
+		{Owner: "storage", Target: "#storage"},
+		{Owner: "storage", Channel: "email", Target: "storage@example.com"},
+	}
+	step := &configpb.EscalationStep{FailingCycles: 2, Channel: "slack", Target: "#tab"}
+	storage := &summarypb.FailingTestSummary{TestName: "storage-disk"}
+	net := &summarypb.FailingTestSummary{TestName: "net-dns"}
+	other := &summarypb.FailingTestSummary{TestName: "other"}
+
+	type sent struct {
+		Step     *configpb.EscalationStep
+		Alert    string
+		Failures []*summarypb.FailingTestSummary
+	}
+	cases := []struct {
+		name     string
+		failures []*summarypb.FailingTestSummary
+		want     []sent
+	}{
+		{
+			name: "tab-wide alerts go to the step",
+			want: []sent{{Step: step, Alert: "stale"}},
+		},
+		{
+			name:     "owned tests go to each route of the owner",
+			failures: []*summarypb.FailingTestSummary{storage},
+			want: []sent{
+				{
+					Step:     &configpb.EscalationStep{FailingCycles: 2, Channel: "slack", Target: "#storage"},
+					Alert:    "stale",
+					Failures: []*summarypb.FailingTestSummary{storage},
+				},
+				{
+					Step:     &configpb.EscalationStep{FailingCycles: 2, Channel: "email", Target: "storage@example.com"},
+					Alert:    "stale",
+					Failures: []*summarypb.FailingTestSummary{storage},
+				},
+			},
+		},
+		{
+			name:     "tests without a route go to the step",
+			failures: []*summarypb.FailingTestSummary{net, storage, other},
+			want: []sent{
+				{
+					Step:     step,
+					Alert:    "stale",
+					Failures: []*summarypb.FailingTestSummary{net, other},
+				},
+				{
+					Step:     &configpb.EscalationStep{FailingCycles: 2, Channel: "slack", Target: "#storage"},
+					Alert:    "stale",
+					Failures: []*summarypb.FailingTestSummary{storage},
+				},
+				{
+					Step:     &configpb.EscalationStep{FailingCycles: 2, Channel: "email", Target: "storage@example.com"},
+					Alert:    "stale",
+					Failures: []*summarypb.FailingTestSummary{storage},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := Message{
+				Dashboard: "dash",
+				Tab:       "tab",
+				Summary:   &summarypb.DashboardTabSummary{Alert: "stale", FailingTestSummaries: tc.failures},
+				Step:      step,
+			}
+			var got []sent
+			for _, m := range Route(msg, owners, routes) {
+				got = append(got, sent{Step: m.Step, Alert: m.Summary.Alert, Failures: m.Failures()})
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Route() got unexpected messages (-want +got):\n%s", diff)
+			}
+			if n := len(msg.Summary.FailingTestSummaries); n != len(tc.failures) {
+				t.Errorf("Route() modified the summary, got %d failures", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifier decides when, where and how to send alert notifications.
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// Schedule interprets a dashboard's notification windows and escalation steps.
+type Schedule struct {
+	loc       *time.Location
+	windows   []window
+	steps     []*configpb.EscalationStep
+	recurring []recurringSilence
+	silences  []Silence
+	calendars []gcs.Path
+}
+
+// A Silence is a period during which notifications are suppressed.
+type Silence struct {
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// contains returns true if the time is within [Start, End).
+func (s Silence) contains(t time.Time) bool {
+	return !t.Before(s.Start) && t.Before(s.End)
+}
+
+type recurringSilence struct {
+	window
+	reason string
+}
+
+type window struct {
+	days       map[time.Weekday]bool // nil means every day
+	start, end time.Duration         // offset from midnight
+}
+
+// NewSchedule compiles the configured notification schedule.
+//
+// A nil schedule allows notifications at any time with no escalation steps.
+func NewSchedule(cfg *configpb.NotificationSchedule) (*Schedule, error) {
+	loc, err := time.LoadLocation(cfg.GetTimeZone())
+	if err != nil {
+		return nil, fmt.Errorf("time zone: %w", err)
+	}
+	s := Schedule{loc: loc}
+	for i, w := range cfg.GetWindows() {
+		win, err := newWindow(w)
+		if err != nil {
+			return nil, fmt.Errorf("window %d: %w", i, err)
+		}
+		s.windows = append(s.windows, *win)
+	}
+	for i, sw := range cfg.GetSilences() {
+		if err := s.addSilence(sw); err != nil {
+			return nil, fmt.Errorf("silence %d: %w", i, err)
+		}
+	}
+	for _, c := range cfg.GetSilenceCalendars() {
+		p, err := gcs.NewPath(c)
+		if err != nil {
+			return nil, fmt.Errorf("silence calendar %q: %w", c, err)
+		}
+		s.calendars = append(s.calendars, *p)
+	}
+	s.steps = append(s.steps, cfg.GetEscalationSteps()...)
+	sort.SliceStable(s.steps, func(i, j int) bool {
+		return s.steps[i].FailingCycles < s.steps[j].FailingCycles
+	})
+	return &s, nil
+}
+
+func (s *Schedule) addSilence(sw *configpb.SilenceWindow) error {
+	if r := sw.GetRecurring(); r != nil {
+		if sw.GetStartTime() != "" || sw.GetEndTime() != "" {
+			return errors.New("recurring silences cannot have a start_time or end_time")
+		}
+		win, err := newWindow(r)
+		if err != nil {
+			return err
+		}
+		s.recurring = append(s.recurring, recurringSilence{window: *win, reason: sw.GetReason()})
+		return nil
+	}
+	start, err := time.Parse(time.RFC3339, sw.GetStartTime())
+	if err != nil {
+		return fmt.Errorf("start_time: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, sw.GetEndTime())
+	if err != nil {
+		return fmt.Errorf("end_time: %w", err)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("end_time %s must be after start_time %s", sw.GetEndTime(), sw.GetStartTime())
+	}
+	s.silences = append(s.silences, Silence{Start: start, End: end, Reason: sw.GetReason()})
+	return nil
+}
+
+func newWindow(w *configpb.NotificationWindow) (*window, error) {
+	start, err := ParseTimeOfDay(w.Start)
+	if err != nil {
+		return nil, fmt.Errorf("start: %w", err)
+	}
+	end, err := ParseTimeOfDay(w.End)
+	if err != nil {
+		return nil, fmt.Errorf("end: %w", err)
+	}
+	win := window{start: start, end: end}
+	for _, d := range w.Days {
+		if d == configpb.NotificationWindow_DAY_UNSPECIFIED {
+			continue
+		}
+		if win.days == nil {
+			win.days = map[time.Weekday]bool{}
+		}
+		win.days[time.Weekday(d%7)] = true // SUNDAY=7 -> time.Sunday=0
+	}
+	return &win, nil
+}
+
+// ParseTimeOfDay converts an HH:MM string into an offset from midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w window) startsOn(day time.Weekday) bool {
+	return w.days == nil || w.days[day]
+}
+
+func (w window) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	tod := t.Sub(midnight)
+	switch {
+	case w.start == w.end:
+		return w.startsOn(t.Weekday())
+	case w.start < w.end:
+		return w.startsOn(t.Weekday()) && tod >= w.start && tod < w.end
+	}
+	// The window wraps past midnight, so it may have started yesterday.
+	if tod >= w.start {
+		return w.startsOn(t.Weekday())
+	}
+	return tod < w.end && w.startsOn(midnight.AddDate(0, 0, -1).Weekday())
+}
+
+// Open returns true if notifications may be sent at the specified time.
+func (s *Schedule) Open(t time.Time) bool {
+	if len(s.windows) == 0 {
+		return true
+	}
+	t = t.In(s.loc)
+	for _, w := range s.windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Silenced returns the reason notifications are silenced at the specified time, if they are.
+func (s *Schedule) Silenced(t time.Time) (string, bool) {
+	for _, sil := range s.silences {
+		if sil.contains(t) {
+			return sil.Reason, true
+		}
+	}
+	lt := t.In(s.loc)
+	for _, r := range s.recurring {
+		if r.contains(lt) {
+			return r.reason, true
+		}
+	}
+	return "", false
+}
+
+// Calendars returns the paths of the ICS calendars whose events silence notifications.
+func (s *Schedule) Calendars() []gcs.Path {
+	return s.calendars
+}
+
+// WithSilences returns a copy of the schedule which is also silenced during the silences.
+func (s *Schedule) WithSilences(silences []Silence) *Schedule {
+	ns := *s
+	ns.silences = make([]Silence, 0, len(s.silences)+len(silences))
+	ns.silences = append(ns.silences, s.silences...)
+	ns.silences = append(ns.silences, silences...)
+	return &ns
+}
+
+// Escalations returns the steps to take after the specified number of
+// consecutive failing cycles, at the specified time.
+//
+// Steps are returned in order of increasing failing cycles. Steps outside
+// the notification windows are dropped unless they ignore windows. Every step
+// is dropped while silenced, so callers still count failing cycles and record
+// state transitions but send nothing.
+//
+// Steps taken on an exhausted error budget are skipped, see BudgetEscalations.
+func (s *Schedule) Escalations(failingCycles int, t time.Time) []*configpb.EscalationStep {
+	return s.BudgetEscalations(failingCycles, nil, t)
+}
+
+// BudgetEscalations returns the Escalations of a tab with the error budget,
+// which also include the budget_exhausted steps while the tab is failing and
+// no budget remains.
+func (s *Schedule) BudgetEscalations(failingCycles int, budget *summarypb.ErrorBudget, t time.Time) []*configpb.EscalationStep {
+	if _, silenced := s.Silenced(t); silenced {
+		return nil
+	}
+	exhausted := failingCycles > 0 && budget != nil && budget.Remaining <= 0
+	open := s.Open(t)
+	var steps []*configpb.EscalationStep
+	for _, step := range s.steps {
+		switch {
+		case step.BudgetExhausted:
+			if !exhausted {
+				continue
+			}
+		case int(step.FailingCycles) > failingCycles:
+			continue
+		}
+		if !open && !step.IgnoreWindows {
+			continue
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
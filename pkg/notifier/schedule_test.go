@@ -0,0 +1,434 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestNewSchedule(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  *configpb.NotificationSchedule
+		err  bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "basically works",
+			cfg: &configpb.NotificationSchedule{
+				TimeZone: "Europe/Berlin",
+				Windows: []*configpb.NotificationWindow{
+					{Start: "09:00", End: "17:30"},
+				},
+			},
+		},
+		{
+			name: "bad time zone",
+			cfg: &configpb.NotificationSchedule{
+				TimeZone: "Mars/Olympus_Mons",
+			},
+			err: true,
+		},
+		{
+			name: "bad start",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{
+					{Start: "9am", End: "17:00"},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "bad end",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{
+					{Start: "09:00", End: "25:00"},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "silences",
+			cfg: &configpb.NotificationSchedule{
+				Silences: []*configpb.SilenceWindow{
+					{Recurring: &configpb.NotificationWindow{Start: "20:00", End: "22:00"}},
+					{StartTime: "2021-01-08T20:00:00Z", EndTime: "2021-01-09T02:00:00Z"},
+				},
+				SilenceCalendars: []string{"gs://bucket/maintenance.ics"},
+			},
+		},
+		{
+			name: "silences end after they start",
+			cfg: &configpb.NotificationSchedule{
+				Silences: []*configpb.SilenceWindow{
+					{StartTime: "2021-01-08T20:00:00Z", EndTime: "2021-01-08T20:00:00Z"},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "recurring silences have no start time",
+			cfg: &configpb.NotificationSchedule{
+				Silences: []*configpb.SilenceWindow{
+					{
+						Recurring: &configpb.NotificationWindow{Start: "20:00", End: "22:00"},
+						StartTime: "2021-01-08T20:00:00Z",
+					},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "bad calendar",
+			cfg: &configpb.NotificationSchedule{
+				SilenceCalendars: []string{"https://calendar.example/maintenance.ics"},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewSchedule(tc.cfg)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("NewSchedule() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("NewSchedule() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	// Friday, January 8th 2021
+	friday := func(hour, min int) time.Time {
+		return time.Date(2021, 1, 8, hour, min, 0, 0, time.UTC)
+	}
+	saturday := func(hour, min int) time.Time {
+		return time.Date(2021, 1, 9, hour, min, 0, 0, time.UTC)
+	}
+	weekdays := []configpb.NotificationWindow_Day{
+		configpb.NotificationWindow_MONDAY,
+		configpb.NotificationWindow_TUESDAY,
+		configpb.NotificationWindow_WEDNESDAY,
+		configpb.NotificationWindow_THURSDAY,
+		configpb.NotificationWindow_FRIDAY,
+	}
+
+	cases := []struct {
+		name string
+		cfg  *configpb.NotificationSchedule
+		when time.Time
+		want bool
+	}{
+		{
+			name: "always open without windows",
+			when: friday(3, 0),
+			want: true,
+		},
+		{
+			name: "inside window",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Start: "09:00", End: "17:00"}},
+			},
+			when: friday(9, 0),
+			want: true,
+		},
+		{
+			name: "end is exclusive",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Start: "09:00", End: "17:00"}},
+			},
+			when: friday(17, 0),
+		},
+		{
+			name: "time zone applies",
+			cfg: &configpb.NotificationSchedule{
+				TimeZone: berlin.String(),
+				Windows:  []*configpb.NotificationWindow{{Start: "09:00", End: "17:00"}},
+			},
+			when: friday(16, 30), // 17:30 in Berlin
+		},
+		{
+			name: "weekday window on a weekday",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Days: weekdays, Start: "09:00", End: "17:00"}},
+			},
+			when: friday(12, 0),
+			want: true,
+		},
+		{
+			name: "weekday window on a weekend",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Days: weekdays, Start: "09:00", End: "17:00"}},
+			},
+			when: saturday(12, 0),
+		},
+		{
+			name: "overnight window continues the next morning",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Days: weekdays, Start: "22:00", End: "06:00"}},
+			},
+			when: saturday(5, 0),
+			want: true,
+		},
+		{
+			name: "overnight window ends",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Days: weekdays, Start: "22:00", End: "06:00"}},
+			},
+			when: saturday(6, 0),
+		},
+		{
+			name: "all day window",
+			cfg: &configpb.NotificationSchedule{
+				Windows: []*configpb.NotificationWindow{{Days: []configpb.NotificationWindow_Day{configpb.NotificationWindow_SATURDAY}}},
+			},
+			when: saturday(23, 59),
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewSchedule(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewSchedule() got unexpected error: %v", err)
+			}
+			if got := s.Open(tc.when); got != tc.want {
+				t.Errorf("Open(%s) got %t, want %t", tc.when, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEscalations(t *testing.T) {
+	slack := &configpb.EscalationStep{FailingCycles: 1, Channel: "slack", Target: "#ci"}
+	page := &configpb.EscalationStep{FailingCycles: 3, Channel: "page", Target: "oncall"}
+	urgent := &configpb.EscalationStep{FailingCycles: 5, Channel: "page", Target: "oncall", IgnoreWindows: true}
+	cfg := &configpb.NotificationSchedule{
+		Windows:         []*configpb.NotificationWindow{{Start: "09:00", End: "17:00"}},
+		EscalationSteps: []*configpb.EscalationStep{urgent, page, slack},
+		Silences: []*configpb.SilenceWindow{
+			{StartTime: "2021-01-15T11:00:00Z", EndTime: "2021-01-15T13:00:00Z"},
+		},
+	}
+	day := time.Date(2021, 1, 8, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2021, 1, 8, 2, 0, 0, 0, time.UTC)
+	maintenance := time.Date(2021, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		failing int
+		when    time.Time
+		want    []*configpb.EscalationStep
+	}{
+		{
+			name: "passing",
+			when: day,
+		},
+		{
+			name:    "first step",
+			failing: 1,
+			when:    day,
+			want:    []*configpb.EscalationStep{slack},
+		},
+		{
+			name:    "later steps include earlier ones",
+			failing: 4,
+			when:    day,
+			want:    []*configpb.EscalationStep{slack, page},
+		},
+		{
+			name:    "quiet at night",
+			failing: 4,
+			when:    night,
+		},
+		{
+			name:    "urgent steps ignore windows",
+			failing: 5,
+			when:    night,
+			want:    []*configpb.EscalationStep{urgent},
+		},
+		{
+			name:    "silences suppress every step",
+			failing: 5,
+			when:    maintenance,
+		},
+	}
+
+	s, err := NewSchedule(cfg)
+	if err != nil {
+		t.Fatalf("NewSchedule() got unexpected error: %v", err)
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := s.Escalations(tc.failing, tc.when)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Escalations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSilenced(t *testing.T) {
+	cfg := &configpb.NotificationSchedule{
+		TimeZone: "Europe/Berlin",
+		Silences: []*configpb.SilenceWindow{
+			{
+				Recurring: &configpb.NotificationWindow{
+					Days:  []configpb.NotificationWindow_Day{configpb.NotificationWindow_TUESDAY},
+					Start: "22:00",
+					End:   "02:00",
+				},
+				Reason: "weekly maintenance",
+			},
+			{StartTime: "2021-01-08T10:00:00Z", EndTime: "2021-01-08T11:00:00Z", Reason: "migration"},
+		},
+	}
+	s, err := NewSchedule(cfg)
+	if err != nil {
+		t.Fatalf("NewSchedule() got unexpected error: %v", err)
+	}
+	s = s.WithSilences([]Silence{
+		{
+			Start:  time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2021, 1, 9, 6, 0, 0, 0, time.UTC),
+			Reason: "calendar",
+		},
+	})
+
+	cases := []struct {
+		name       string
+		when       time.Time
+		wantReason string
+		want       bool
+	}{
+		{
+			name: "not silenced",
+			when: time.Date(2021, 1, 8, 9, 59, 0, 0, time.UTC),
+		},
+		{
+			name:       "one-off silence",
+			when:       time.Date(2021, 1, 8, 10, 0, 0, 0, time.UTC),
+			wantReason: "migration",
+			want:       true,
+		},
+		{
+			name: "one-off silences end",
+			when: time.Date(2021, 1, 8, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "recurring silence in the schedule's time zone",
+			when:       time.Date(2021, 1, 12, 21, 30, 0, 0, time.UTC), // 22:30 in Berlin
+			wantReason: "weekly maintenance",
+			want:       true,
+		},
+		{
+			name:       "recurring silence past midnight",
+			when:       time.Date(2021, 1, 13, 0, 30, 0, 0, time.UTC), // Wednesday 01:30 in Berlin
+			wantReason: "weekly maintenance",
+			want:       true,
+		},
+		{
+			name: "recurring silence on other days",
+			when: time.Date(2021, 1, 13, 21, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "added silence",
+			when:       time.Date(2021, 1, 9, 3, 0, 0, 0, time.UTC),
+			wantReason: "calendar",
+			want:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, got := s.Silenced(tc.when)
+			if got != tc.want || reason != tc.wantReason {
+				t.Errorf("Silenced() got %q, %t, want %q, %t", reason, got, tc.wantReason, tc.want)
+			}
+		})
+	}
+}
+
+func TestBudgetEscalations(t *testing.T) {
+	slack := &configpb.EscalationStep{FailingCycles: 1, Channel: "slack", Target: "#ci"}
+	page := &configpb.EscalationStep{FailingCycles: 3, Channel: "page", Target: "oncall"}
+	sre := &configpb.EscalationStep{Channel: "page", Target: "sre", BudgetExhausted: true}
+	s, err := NewSchedule(&configpb.NotificationSchedule{
+		EscalationSteps: []*configpb.EscalationStep{page, sre, slack},
+	})
+	if err != nil {
+		t.Fatalf("NewSchedule() got unexpected error: %v", err)
+	}
+	now := time.Date(2021, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		failing int
+		budget  *summarypb.ErrorBudget
+		want    []*configpb.EscalationStep
+	}{
+		{
+			name:    "no budget",
+			failing: 3,
+			want:    []*configpb.EscalationStep{slack, page},
+		},
+		{
+			name:    "budget remains",
+			failing: 1,
+			budget:  &summarypb.ErrorBudget{AllowedFailingColumns: 3, FailingColumns: 2, Remaining: 1},
+			want:    []*configpb.EscalationStep{slack},
+		},
+		{
+			name:    "exhausted budgets escalate",
+			failing: 1,
+			budget:  &summarypb.ErrorBudget{AllowedFailingColumns: 3, FailingColumns: 3},
+			want:    []*configpb.EscalationStep{sre, slack},
+		},
+		{
+			name:   "exhausted budgets of passing tabs do not",
+			budget: &summarypb.ErrorBudget{AllowedFailingColumns: 3, FailingColumns: 4, Remaining: -1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := s.BudgetEscalations(tc.failing, tc.budget, now)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("BudgetEscalations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
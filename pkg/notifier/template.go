@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// DefaultTemplate renders the body of notifications to channels without a template.
+const DefaultTemplate = `{{.Dashboard}}/{{.Tab}}: {{.Alert}}{{if .TabURL}} {{.TabURL}}{{end}}
+{{.Status}}
+{{range .Failures}}- {{.DisplayName}}, {{$.Failing .}}{{if .FailTestLink}} {{.FailTestLink}}{{end}}
+{{end}}`
+
+var (
+	templateFuncs = template.FuncMap{
+		"join": strings.Join,
+	}
+	defaultTemplate = template.Must(template.New("default").Funcs(templateFuncs).Parse(DefaultTemplate))
+)
+
+// Message is what notification templates render.
+type Message struct {
+	Dashboard string
+	Tab       string
+	// TabURL links to the dashboard tab, if known.
+	TabURL  string
+	Summary *summarypb.DashboardTabSummary
+	// Step is the escalation step sending the notification, if any.
+	Step *configpb.EscalationStep
+	// Now is when the notification is rendered.
+	Now time.Time
+
+	locale summarizer.Locale
+}
+
+// Alert describes why the tab alerts in the templates' language.
+func (m Message) Alert() string {
+	if a := m.Summary.GetTabAlert(); a != nil {
+		return m.locale.Alert(a, m.Now)
+	}
+	return m.Summary.GetAlert()
+}
+
+// Status describes how many recent columns and cells passed in the templates' language.
+func (m Message) Status() string {
+	if c := m.Summary.GetStatusCounts(); c != nil {
+		return m.locale.Status(c)
+	}
+	return m.Summary.GetStatus()
+}
+
+// Failures returns the failing tests of the summary.
+func (m Message) Failures() []*summarypb.FailingTestSummary {
+	return m.Summary.GetFailingTestSummaries()
+}
+
+// Failing describes how long and for how many builds the test has failed.
+func (m Message) Failing(f *summarypb.FailingTestSummary) string {
+	return m.locale.Failing(f, m.Now)
+}
+
+// A TemplateStore reads notification templates, such as a gcs.Client.
+type TemplateStore interface {
+	gcs.Opener
+	gcs.Stater
+}
+
+// Templates renders notification bodies with a Go text/template per channel
+// type, such as slack or email.
+//
+// The template of each channel is read from <channel>.tmpl under the prefix,
+// and reloads whenever its generation changes. Channels without a template
+// use DefaultTemplate.
+type Templates struct {
+	client TemplateStore
+	prefix gcs.Path
+	locale summarizer.Locale
+
+	lock   sync.Mutex
+	loaded map[string]*loadedTemplate
+}
+
+type loadedTemplate struct {
+	generation int64
+	tmpl       *template.Template
+}
+
+// NewTemplates returns templates under the prefix, relative to the configuration,
+// which humanize summaries in the language.
+func NewTemplates(client TemplateStore, configPath gcs.Path, prefix, lang string) (*Templates, error) {
+	p, err := configPath.ResolveReference(&url.URL{Path: strings.TrimSuffix(prefix, "/") + "/"})
+	if err != nil {
+		return nil, fmt.Errorf("resolve reference: %w", err)
+	}
+	if p.Bucket() != configPath.Bucket() {
+		return nil, fmt.Errorf("templates %s should not change bucket", prefix)
+	}
+	return &Templates{
+		client: client,
+		prefix: *p,
+		locale: summarizer.NewLocale(lang),
+		loaded: map[string]*loadedTemplate{},
+	}, nil
+}
+
+// TemplatePath returns the path of the channel's template.
+func (t *Templates) TemplatePath(channel string) (*gcs.Path, error) {
+	if channel == "" || strings.ContainsAny(channel, "/\\") || strings.HasPrefix(channel, ".") {
+		return nil, fmt.Errorf("invalid channel %q", channel)
+	}
+	p, err := t.prefix.ResolveReference(&url.URL{Path: channel + ".tmpl"})
+	if err != nil {
+		return nil, fmt.Errorf("resolve reference: %w", err)
+	}
+	return p, nil
+}
+
+// Render returns the body of a notification to the channel.
+func (t *Templates) Render(ctx context.Context, channel string, msg Message) (string, error) {
+	tmpl, err := t.template(ctx, channel)
+	if err != nil {
+		return "", err
+	}
+	msg.locale = t.locale
+	if msg.Now.IsZero() {
+		msg.Now = time.Now()
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, msg); err != nil {
+		return "", fmt.Errorf("execute %s template: %w", channel, err)
+	}
+	return buf.String(), nil
+}
+
+// template returns the current template of the channel, reading it when its generation changes.
+func (t *Templates) template(ctx context.Context, channel string) (*template.Template, error) {
+	p, err := t.TemplatePath(channel)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := t.client.Stat(ctx, *p)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return defaultTemplate, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", p, err)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if lt, ok := t.loaded[channel]; ok && lt.generation == attrs.Generation {
+		return lt.tmpl, nil
+	}
+	r, err := t.client.Open(ctx, *p)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", p, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p, err)
+	}
+	tmpl, err := template.New(channel).Funcs(templateFuncs).Parse(string(buf))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", p, err)
+	}
+	t.loaded[channel] = &loadedTemplate{generation: attrs.Generation, tmpl: tmpl}
+	return tmpl, nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+type fakeStore struct {
+	fake.Opener
+	fake.Stater
+}
+
+func TestRender(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	tmplPath := func(channel string) gcs.Path {
+		p, err := gcs.NewPath("gs://bucket/notifications/" + channel + ".tmpl")
+		if err != nil {
+			t.Fatalf("gcs.NewPath(): %v", err)
+		}
+		return *p
+	}
+	now := time.Unix(1000000, 0)
+	msg := Message{
+		Dashboard: "dash",
+		Tab:       "tab",
+		TabURL:    "https://testgrid.example/dash#tab",
+		Summary: &summarypb.DashboardTabSummary{
+			Alert:    "data has not changed since a while",
+			TabAlert: &summarypb.TabAlert{Reason: summarypb.TabAlert_UNCHANGED, Since: float64(now.Add(-3 * time.Hour).Unix())},
+			Status:   "1 of 2 (50.0%) recent columns passed",
+			FailingTestSummaries: []*summarypb.FailingTestSummary{
+				{
+					DisplayName:   "//pkg:test",
+					FailTimestamp: float64(now.Add(-50 * time.Hour).Unix()),
+					FailCount:     4,
+					FailTestLink:  "https://prow.example/4",
+				},
+			},
+		},
+		Now: now,
+	}
+
+	cases := []struct {
+		name    string
+		store   fakeStore
+		lang    string
+		channel string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "default template",
+			channel: "slack",
+			want: `dash/tab: results have not changed for 3 hours https://testgrid.example/dash#tab
+1 of 2 (50.0%) recent columns passed
+- //pkg:test, failing for 2 days, 4 builds https://prow.example/4
+`,
+		},
+		{
+			name: "channel template",
+			store: fakeStore{
+				Opener: fake.Opener{
+					tmplPath("chat"): {Data: `{{.Alert}} in {{.Tab}} ({{len .Failures}} failing)`},
+				},
+				Stater: fake.Stater{
+					tmplPath("chat"): {Attrs: storage.ObjectAttrs{Generation: 1}},
+				},
+			},
+			lang:    "de",
+			channel: "chat",
+			want:    "Ergebnisse 3 Stunden lang unverändert in tab (1 failing)",
+		},
+		{
+			name: "invalid template",
+			store: fakeStore{
+				Opener: fake.Opener{
+					tmplPath("chat"): {Data: `{{.Alert`},
+				},
+				Stater: fake.Stater{
+					tmplPath("chat"): {Attrs: storage.ObjectAttrs{Generation: 1}},
+				},
+			},
+			channel: "chat",
+			wantErr: true,
+		},
+		{
+			name:    "invalid channel",
+			channel: "../config",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			templates, err := NewTemplates(tc.store, *configPath, "notifications", tc.lang)
+			if err != nil {
+				t.Fatalf("NewTemplates() got unexpected error: %v", err)
+			}
+			got, err := templates.Render(context.Background(), tc.channel, msg)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Errorf("Render() got unexpected error: %v", err)
+				}
+			case tc.wantErr:
+				t.Errorf("Render() failed to return an error")
+			case got != tc.want:
+				t.Errorf("Render() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderReloads(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	p, err := gcs.NewPath("gs://bucket/notifications/email.tmpl")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	store := fakeStore{
+		Opener: fake.Opener{*p: {Data: "first {{.Tab}}"}},
+		Stater: fake.Stater{*p: {Attrs: storage.ObjectAttrs{Generation: 1}}},
+	}
+	templates, err := NewTemplates(store, *configPath, "notifications/", "")
+	if err != nil {
+		t.Fatalf("NewTemplates() got unexpected error: %v", err)
+	}
+	render := func() string {
+		got, err := templates.Render(context.Background(), "email", Message{Tab: "tab"})
+		if err != nil {
+			t.Fatalf("Render() got unexpected error: %v", err)
+		}
+		return got
+	}
+	if got := render(); got != "first tab" {
+		t.Fatalf("Render() got %q, want first tab", got)
+	}
+	store.Opener[*p] = fake.Object{Data: "second {{.Tab}}"}
+	if got := render(); got != "first tab" {
+		t.Errorf("Render() of an unchanged generation got %q, want first tab", got)
+	}
+	store.Stater[*p] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 2}}
+	if got := render(); got != "second tab" {
+		t.Errorf("Render() of a new generation got %q, want second tab", got)
+	}
+	delete(store.Stater, *p)
+	if got := render(); !strings.HasPrefix(got, "/tab: ") {
+		t.Errorf("Render() without a template got %q, want the default template", got)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Channels which post cards to chat webhooks.
+const (
+	TeamsChannel      = "teams"
+	GoogleChatChannel = "google_chat"
+)
+
+// A Sender delivers the rendered body of a notification to the target of an escalation step.
+type Sender interface {
+	Send(ctx context.Context, target string, msg Message, body string) error
+}
+
+// NewSender returns the sender of the channel, which posts with the client.
+func NewSender(channel string, client *http.Client) (Sender, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	switch channel {
+	case TeamsChannel:
+		return &TeamsWebhook{Client: client}, nil
+	case GoogleChatChannel:
+		return &GoogleChatWebhook{Client: client}, nil
+	}
+	return nil, fmt.Errorf("unsupported channel %q", channel)
+}
+
+// Senders holds additional senders by channel, such as plugins, which take
+// precedence over the built-in webhooks.
+type Senders map[string]Sender
+
+// Sender returns the sender of the channel, which posts with the client.
+func (s Senders) Sender(channel string, client *http.Client) (Sender, error) {
+	if sender, ok := s[channel]; ok {
+		return sender, nil
+	}
+	return NewSender(channel, client)
+}
+
+// Notify renders the message with the template of its escalation step's channel
+// and sends it to the step's target.
+func Notify(ctx context.Context, templates *Templates, client *http.Client, msg Message) error {
+	return Senders(nil).Notify(ctx, templates, client, msg)
+}
+
+// Notify renders the message with the template of its escalation step's channel
+// and sends it to the step's target with the channel's sender.
+func (s Senders) Notify(ctx context.Context, templates *Templates, client *http.Client, msg Message) error {
+	channel := msg.Step.GetChannel()
+	sender, err := s.Sender(channel, client)
+	if err != nil {
+		return err
+	}
+	body, err := templates.Render(ctx, channel, msg)
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+	if err := sender.Send(ctx, msg.Step.GetTarget(), msg, body); err != nil {
+		return fmt.Errorf("send to %s: %w", channel, err)
+	}
+	return nil
+}
+
+// cardTitle names the dashboard tab of the notification.
+func cardTitle(msg Message) string {
+	return msg.Dashboard + "/" + msg.Tab
+}
+
+// openLabel labels the button linking to the dashboard tab.
+const openLabel = "Open in TestGrid"
+
+// TeamsWebhook posts adaptive cards to Microsoft Teams incoming webhooks.
+type TeamsWebhook struct {
+	Client *http.Client
+}
+
+// Send posts a card with the body to the webhook URL.
+func (w *TeamsWebhook) Send(ctx context.Context, target string, msg Message, body string) error {
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body": []interface{}{
+			map[string]interface{}{"type": "TextBlock", "text": cardTitle(msg), "weight": "Bolder", "size": "Medium", "wrap": true},
+			map[string]interface{}{"type": "TextBlock", "text": body, "wrap": true},
+		},
+	}
+	if msg.TabURL != "" {
+		card["actions"] = []interface{}{
+			map[string]interface{}{"type": "Action.OpenUrl", "title": openLabel, "url": msg.TabURL},
+		}
+	}
+	payload := map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content":     card,
+			},
+		},
+	}
+	return postJSON(ctx, w.Client, target, payload)
+}
+
+// GoogleChatWebhook posts cards to Google Chat space webhooks.
+type GoogleChatWebhook struct {
+	Client *http.Client
+}
+
+// Send posts a card with the body to the webhook URL.
+func (w *GoogleChatWebhook) Send(ctx context.Context, target string, msg Message, body string) error {
+	widgets := []interface{}{
+		map[string]interface{}{"textParagraph": map[string]interface{}{"text": html.EscapeString(body)}},
+	}
+	if msg.TabURL != "" {
+		widgets = append(widgets, map[string]interface{}{
+			"buttonList": map[string]interface{}{
+				"buttons": []interface{}{
+					map[string]interface{}{
+						"text":    openLabel,
+						"onClick": map[string]interface{}{"openLink": map[string]interface{}{"url": msg.TabURL}},
+					},
+				},
+			},
+		})
+	}
+	payload := map[string]interface{}{
+		// Shown in notifications and clients without card support.
+		"text": cardTitle(msg),
+		"cardsV2": []interface{}{
+			map[string]interface{}{
+				"cardId": "testgrid",
+				"card": map[string]interface{}{
+					"header":   map[string]interface{}{"title": cardTitle(msg)},
+					"sections": []interface{}{map[string]interface{}{"widgets": widgets}},
+				},
+			},
+		},
+	}
+	return postJSON(ctx, w.Client, target, payload)
+}
+
+// postJSON posts the payload to the URL as JSON, failing on non-2xx responses.
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("post: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
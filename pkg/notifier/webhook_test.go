@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestSend(t *testing.T) {
+	msg := Message{Dashboard: "dash", Tab: "tab", TabURL: "https://testgrid.example/dash#tab"}
+	const body = "1 test <failing>"
+	cases := []struct {
+		name    string
+		channel string
+		want    string
+	}{
+		{
+			name:    "teams",
+			channel: TeamsChannel,
+			want: `{"attachments":[{"content":{"$schema":"http://adaptivecards.io/schemas/adaptive-card.json",` +
+				`"actions":[{"title":"Open in TestGrid","type":"Action.OpenUrl","url":"https://testgrid.example/dash#tab"}],` +
+				`"body":[{"size":"Medium","text":"dash/tab","type":"TextBlock","weight":"Bolder","wrap":true},` +
+				`{"text":"1 test <failing>","type":"TextBlock","wrap":true}],"type":"AdaptiveCard","version":"1.4"},` +
+				`"contentType":"application/vnd.microsoft.card.adaptive"}],"type":"message"}`,
+		},
+		{
+			name:    "google chat",
+			channel: GoogleChatChannel,
+			want: `{"cardsV2":[{"card":{"header":{"title":"dash/tab"},"sections":[{"widgets":[` +
+				`{"textParagraph":{"text":"1 test &lt;failing&gt;"}},` +
+				`{"buttonList":{"buttons":[{"onClick":{"openLink":{"url":"https://testgrid.example/dash#tab"}},"text":"Open in TestGrid"}]}}]}]},` +
+				`"cardId":"testgrid"}],"text":"dash/tab"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if ct := r.Header.Get("Content-Type"); ct != "application/json; charset=UTF-8" {
+					t.Errorf("Send() posted content type %q", ct)
+				}
+				buf, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("read body: %v", err)
+				}
+				got = string(buf)
+			}))
+			defer server.Close()
+			sender, err := NewSender(tc.channel, server.Client())
+			if err != nil {
+				t.Fatalf("NewSender() got unexpected error: %v", err)
+			}
+			if err := sender.Send(context.Background(), server.URL, msg, body); err != nil {
+				t.Fatalf("Send() got unexpected error: %v", err)
+			}
+			var want, gotJSON interface{}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatalf("unmarshal want: %v", err)
+			}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Fatalf("Send() posted invalid JSON %s: %v", got, err)
+			}
+			if diff := cmp.Diff(want, gotJSON); diff != "" {
+				t.Errorf("Send() posted unexpected payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	templates, err := NewTemplates(fakeStore{Opener: fake.Opener{}, Stater: fake.Stater{}}, *configPath, "notifications", "en")
+	if err != nil {
+		t.Fatalf("NewTemplates(): %v", err)
+	}
+	cases := []struct {
+		name    string
+		channel string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:    "send",
+			channel: TeamsChannel,
+			status:  http.StatusOK,
+		},
+		{
+			name:    "unsupported channel",
+			channel: "carrier_pigeon",
+			wantErr: true,
+		},
+		{
+			name:    "webhook rejects",
+			channel: GoogleChatChannel,
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var posts int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				posts++
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+			msg := Message{
+				Dashboard: "dash",
+				Tab:       "tab",
+				Step:      &configpb.EscalationStep{Channel: tc.channel, Target: server.URL},
+			}
+			err := Notify(context.Background(), templates, server.Client(), msg)
+			switch {
+			case err != nil && !tc.wantErr:
+				t.Errorf("Notify() got unexpected error: %v", err)
+			case err == nil && tc.wantErr:
+				t.Error("Notify() failed to return an error")
+			}
+			if tc.status == 0 && posts > 0 {
+				t.Errorf("Notify() posted %d times to an unsupported channel", posts)
+			}
+		})
+	}
+}
+
+// recordingSender records the targets and bodies it sends.
+type recordingSender struct {
+	sent []string
+}
+
+func (s *recordingSender) Send(_ context.Context, target string, _ Message, body string) error {
+	s.sent = append(s.sent, target+": "+body)
+	return nil
+}
+
+func TestSendersNotify(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	templates, err := NewTemplates(fakeStore{Opener: fake.Opener{}, Stater: fake.Stater{}}, *configPath, "notifications", "en")
+	if err != nil {
+		t.Fatalf("NewTemplates(): %v", err)
+	}
+	pager := &recordingSender{}
+	teams := &recordingSender{}
+	senders := Senders{"pager": pager, TeamsChannel: teams}
+	msg := Message{
+		Dashboard: "dash",
+		Tab:       "tab",
+		Summary:   &summarypb.DashboardTabSummary{Alert: "boom"},
+	}
+
+	for _, channel := range []string{"pager", TeamsChannel} {
+		msg.Step = &configpb.EscalationStep{Channel: channel, Target: "oncall"}
+		if err := senders.Notify(context.Background(), templates, nil, msg); err != nil {
+			t.Errorf("Notify(%s) got unexpected error: %v", channel, err)
+		}
+	}
+	want := []string{"oncall: dash/tab: boom\n\n"}
+	for name, s := range map[string]*recordingSender{"pager": pager, "teams": teams} {
+		if diff := cmp.Diff(want, s.sent); diff != "" {
+			t.Errorf("Notify() sent unexpected diff to %s (-want +got):\n%s", name, diff)
+		}
+	}
+
+	msg.Step = &configpb.EscalationStep{Channel: "carrier_pigeon"}
+	if err := senders.Notify(context.Background(), templates, nil, msg); err == nil {
+		t.Error("Notify() to an unsupported channel failed to return an error")
+	}
+}
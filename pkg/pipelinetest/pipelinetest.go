@@ -0,0 +1,321 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pipelinetest runs the updater, tabulator and summarizer in memory
+// over a config and fixture builds, so tests can assert on the resulting tab
+// summaries and row statuses of the config.
+package pipelinetest
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/config/yamlcfg"
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/loadgen"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabulator"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const (
+	configPath    = "gs://pipelinetest/config"
+	gridPrefix    = "grid"
+	tabPrefix     = "tabs"
+	summaryPrefix = "summary"
+	concurrency   = 4
+)
+
+// succeededPod is the podinfo.json of a finished build, so that the Pod row
+// only reflects the tests.
+const succeededPod = `{"pod":{"status":{"phase":"Succeeded"}}}`
+
+// Test is a junit test case of a fixture build.
+type Test struct {
+	Name string
+	// Failure fails the test with this message if set.
+	Failure string
+	// Skipped skips the test.
+	Skipped bool
+}
+
+// Build is a fixture build of a test group, which passes unless a test fails.
+type Build struct {
+	// ID names the build, defaulting to its 1-based position among the group's builds.
+	ID string
+	// Started is when the build started.
+	Started time.Time
+	// Duration is how long the build ran, defaulting to a minute.
+	Duration time.Duration
+	// Running builds have not finished yet.
+	Running bool
+	Tests   []Test
+}
+
+// ReadYAML converts and validates the YAML config files or directories.
+func ReadYAML(paths ...string) (*configpb.Configuration, error) {
+	cfg, err := yamlcfg.ReadConfig(paths, "", true)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	if err := config.Validate(&cfg); err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Run writes the config and the builds of each test group under the group's
+// gcs_prefix, then updates every group until its grid holds every build before
+// tabulating and summarizing every dashboard.
+func Run(ctx context.Context, cfg *configpb.Configuration, builds map[string][]Build) (*Snapshot, error) {
+	if err := config.Validate(cfg); err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	cfgPath, err := gcs.NewPath(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config path: %w", err)
+	}
+	bucket := loadgen.NewBucket()
+	buf, err := config.MarshalBytes(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	if err := bucket.Upload(ctx, *cfgPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return nil, fmt.Errorf("upload config: %w", err)
+	}
+	var maxBuilds int
+	for name, groupBuilds := range builds {
+		group := config.FindTestGroup(name, cfg)
+		if group == nil {
+			return nil, fmt.Errorf("builds of unknown test group %q", name)
+		}
+		for i, b := range groupBuilds {
+			if err := writeBuild(ctx, bucket, group.GcsPrefix, i, b); err != nil {
+				return nil, fmt.Errorf("%s build %d: %w", name, i+1, err)
+			}
+		}
+		if len(groupBuilds) > maxBuilds {
+			maxBuilds = len(groupBuilds)
+		}
+	}
+
+	if err := updateAll(ctx, bucket, *cfgPath, cfg, maxBuilds); err != nil {
+		return nil, fmt.Errorf("update: %w", err)
+	}
+	if err := tabulator.Update(ctx, bucket, *cfgPath, concurrency, "", gridPrefix, tabPrefix, true); err != nil {
+		return nil, fmt.Errorf("tabulate: %w", err)
+	}
+	if err := summarizer.Update(ctx, bucket, *cfgPath, concurrency, "", gridPrefix, summaryPrefix, true, false); err != nil {
+		return nil, fmt.Errorf("summarize: %w", err)
+	}
+	return &Snapshot{
+		reader: tabs.Reader{
+			Client:        bucket,
+			Config:        cfg,
+			ConfigPath:    *cfgPath,
+			GridPrefix:    gridPrefix,
+			SummaryPrefix: summaryPrefix,
+			TabPrefix:     tabPrefix,
+			Concurrency:   concurrency,
+		},
+	}, nil
+}
+
+// updateAll repeats update cycles until they stop adding columns.
+//
+// The updater only reads a few columns of a new group per cycle, so the first
+// cycle alone is not enough.
+func updateAll(ctx context.Context, client gcs.ConditionalClient, cfgPath gcs.Path, cfg *configpb.Configuration, maxBuilds int) error {
+	groupUpdater := updater.GCS(10*time.Minute, time.Minute, concurrency, 0, true, updater.SortBuilds, nil)
+	prev := map[string]int{}
+	for cycle := 0; cycle <= maxBuilds; cycle++ {
+		if err := updater.Update(ctx, client, cfgPath, gridPrefix, concurrency, "", groupUpdater, true); err != nil {
+			return err
+		}
+		changed := false
+		for _, group := range cfg.TestGroups {
+			path, err := updater.TestGroupPath(cfgPath, gridPrefix, group.Name)
+			if err != nil {
+				return fmt.Errorf("grid path: %w", err)
+			}
+			grid, err := gcs.DownloadGrid(ctx, client, *path)
+			if err != nil {
+				return fmt.Errorf("read %s: %w", path, err)
+			}
+			if n := len(grid.Columns); n != prev[group.Name] {
+				prev[group.Name] = n
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+	return fmt.Errorf("grids still changing after %d cycles", maxBuilds+1)
+}
+
+// writeBuild writes the started.json, finished.json and junit.xml of the nth build.
+func writeBuild(ctx context.Context, client gcs.Uploader, prefix string, n int, b Build) error {
+	id := b.ID
+	if id == "" {
+		id = strconv.Itoa(n + 1)
+	}
+	dir, err := gcs.NewPath("gs://" + strings.TrimSuffix(prefix, "/") + "/" + id + "/")
+	if err != nil {
+		return fmt.Errorf("build path: %w", err)
+	}
+	upload := func(name string, buf []byte) error {
+		p, err := gcs.NewPath(dir.String() + name)
+		if err != nil {
+			return fmt.Errorf("%s path: %w", name, err)
+		}
+		if err := client.Upload(ctx, *p, buf, gcs.DefaultACL, ""); err != nil {
+			return fmt.Errorf("upload %s: %w", name, err)
+		}
+		return nil
+	}
+
+	buf, err := json.Marshal(metadata.Started{Timestamp: b.Started.Unix()})
+	if err != nil {
+		return fmt.Errorf("marshal started: %w", err)
+	}
+	if err := upload("started.json", buf); err != nil {
+		return err
+	}
+	var suite junit.Suite
+	passed := true
+	for _, t := range b.Tests {
+		r := junit.Result{Name: t.Name}
+		if t.Failure != "" {
+			msg := t.Failure
+			r.Failure = &msg
+			passed = false
+		}
+		if t.Skipped {
+			msg := ""
+			r.Skipped = &msg
+		}
+		suite.Results = append(suite.Results, r)
+	}
+	buf, err = xml.Marshal(junit.Suites{Suites: []junit.Suite{suite}})
+	if err != nil {
+		return fmt.Errorf("marshal junit: %w", err)
+	}
+	if err := upload("artifacts/junit_01.xml", buf); err != nil {
+		return err
+	}
+	if b.Running {
+		return nil
+	}
+	if err := upload("podinfo.json", []byte(succeededPod)); err != nil {
+		return err
+	}
+	duration := b.Duration
+	if duration == 0 {
+		duration = time.Minute
+	}
+	finished := b.Started.Add(duration).Unix()
+	res := "SUCCESS"
+	if !passed {
+		res = "FAILURE"
+	}
+	buf, err = json.Marshal(metadata.Finished{Timestamp: &finished, Passed: &passed, Result: res})
+	if err != nil {
+		return fmt.Errorf("marshal finished: %w", err)
+	}
+	return upload("finished.json", buf)
+}
+
+// Snapshot reads the tabs written by Run, as the API serves them.
+type Snapshot struct {
+	reader tabs.Reader
+}
+
+// ErrNotFound means the dashboard, tab or row does not exist.
+var ErrNotFound = tabs.ErrNotFound
+
+// tab reads every column of the tab.
+func (s *Snapshot) tab(ctx context.Context, dashboard, tab string) (*tabs.Result, error) {
+	req := tabs.Request{
+		Dashboard:   dashboard,
+		Tab:         tab,
+		Columns:     math.MaxInt32,
+		FullHistory: true,
+	}
+	res := s.reader.GetTabs(ctx, []tabs.Request{req})[0]
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	return &res, nil
+}
+
+// Summary returns the summary of the dashboard tab.
+func (s *Snapshot) Summary(ctx context.Context, dashboard, tab string) (*summarypb.DashboardTabSummary, error) {
+	res, err := s.tab(ctx, dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	if res.Summary == nil {
+		return nil, fmt.Errorf("summary: %w", ErrNotFound)
+	}
+	return res.Summary, nil
+}
+
+// Grid returns the state of the dashboard tab, with its newest column first.
+func (s *Snapshot) Grid(ctx context.Context, dashboard, tab string) (*statepb.Grid, error) {
+	res, err := s.tab(ctx, dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	return res.Grid, nil
+}
+
+// RowStatuses returns the status of the named row in each column of the
+// dashboard tab, newest first.
+func (s *Snapshot) RowStatuses(ctx context.Context, dashboard, tab, row string) ([]statuspb.TestStatus, error) {
+	grid, err := s.Grid(ctx, dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range grid.Rows {
+		if r.Name != row {
+			continue
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var statuses []statuspb.TestStatus
+		for status := range result.Iter(ctx, r.Results) {
+			statuses = append(statuses, status)
+		}
+		return statuses, nil
+	}
+	return nil, fmt.Errorf("row %q: %w", row, ErrNotFound)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinetest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+const yamlConfig = `test_groups:
+- name: unit
+  gcs_prefix: fixtures/logs/unit
+  days_of_results: 7
+  num_columns_recent: 10
+  num_failures_to_alert: 2
+  use_kubernetes_client: true
+  is_external: true
+dashboards:
+- name: team
+  dashboard_tab:
+  - name: unit
+    test_group_name: unit
+`
+
+// readConfig returns the converted yamlConfig.
+func readConfig(t *testing.T) *configpb.Configuration {
+	dir, err := ioutil.TempDir("", "pipelinetest")
+	if err != nil {
+		t.Fatalf("TempDir() got unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(yamlConfig), 0644); err != nil {
+		t.Fatalf("WriteFile() got unexpected error: %v", err)
+	}
+	cfg, err := ReadYAML(path)
+	if err != nil {
+		t.Fatalf("ReadYAML() got unexpected error: %v", err)
+	}
+	return cfg
+}
+
+func TestRun(t *testing.T) {
+	cfg := readConfig(t)
+
+	now := time.Now().Truncate(time.Second)
+	builds := map[string][]Build{
+		"unit": {
+			{
+				Started: now.Add(-2 * time.Hour),
+				Tests:   []Test{{Name: "stable"}, {Name: "flaky"}},
+			},
+			{
+				Started: now.Add(-time.Hour),
+				Tests:   []Test{{Name: "stable"}, {Name: "flaky", Failure: "timeout"}},
+			},
+			{
+				Started: now.Add(-time.Minute),
+				Tests:   []Test{{Name: "stable"}, {Name: "flaky", Failure: "timeout"}},
+			},
+		},
+	}
+	ctx := context.Background()
+	snap, err := Run(ctx, cfg, builds)
+	if err != nil {
+		t.Fatalf("Run() got unexpected error: %v", err)
+	}
+
+	sum, err := snap.Summary(ctx, "team", "unit")
+	if err != nil {
+		t.Fatalf("Summary() got unexpected error: %v", err)
+	}
+	if sum.OverallStatus != summarypb.DashboardTabSummary_FAIL {
+		t.Errorf("Summary() got status %s, want %s", sum.OverallStatus, summarypb.DashboardTabSummary_FAIL)
+	}
+
+	rows := []struct {
+		name string
+		want []statuspb.TestStatus
+	}{
+		{
+			name: "stable",
+			want: []statuspb.TestStatus{statuspb.TestStatus_PASS, statuspb.TestStatus_PASS, statuspb.TestStatus_PASS},
+		},
+		{
+			name: "flaky",
+			want: []statuspb.TestStatus{statuspb.TestStatus_FAIL, statuspb.TestStatus_FAIL, statuspb.TestStatus_PASS},
+		},
+	}
+	for _, row := range rows {
+		got, err := snap.RowStatuses(ctx, "team", "unit", row.name)
+		if err != nil {
+			t.Errorf("RowStatuses(%q) got unexpected error: %v", row.name, err)
+			continue
+		}
+		if diff := cmp.Diff(row.want, got); diff != "" {
+			t.Errorf("RowStatuses(%q) got unexpected diff (-want +got):\n%s", row.name, diff)
+		}
+	}
+
+	if _, err := snap.RowStatuses(ctx, "team", "unit", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RowStatuses() of a missing row got %v, want %v", err, ErrNotFound)
+	}
+	if _, err := snap.Summary(ctx, "team", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Summary() of a missing tab got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestRunUnknownGroup(t *testing.T) {
+	if _, err := Run(context.Background(), readConfig(t), map[string][]Build{"missing": {{}}}); err == nil {
+		t.Error("Run() failed to return an error")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	pluginpb "github.com/GoogleCloudPlatform/testgrid/pb/plugin"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// ServeColumnReader serves the column reader until the updater closes stdin.
+//
+// Call it from the main function of a column reader plugin binary, which the
+// updater launches. Logs go to stderr, since the handshake uses stdout.
+func ServeColumnReader(reader updater.ColumnReader) error {
+	return serveKind(ColumnReaderKind, func(s *grpc.Server) {
+		pluginpb.RegisterColumnReaderServer(s, &columnReaderServer{reader: reader})
+	})
+}
+
+// columnReaderServer serves a ColumnReader over gRPC.
+type columnReaderServer struct {
+	reader updater.ColumnReader
+}
+
+// ReadColumns reads the columns of the requested group.
+func (s *columnReaderServer) ReadColumns(ctx context.Context, req *pluginpb.ReadColumnsRequest) (*pluginpb.ReadColumnsResponse, error) {
+	log := logrus.WithField("group", req.GetTestGroup().GetName())
+	cols, err := s.reader(ctx, log, req.GetTestGroup(), fromProto(req.GetOldColumns()), fromSeconds(req.GetStop()))
+	if err != nil {
+		return nil, err
+	}
+	return &pluginpb.ReadColumnsResponse{Columns: toProto(cols)}, nil
+}
+
+// ColumnReaders holds launched column reader plugins by name.
+type ColumnReaders map[string]*Process
+
+// LaunchColumnReaders launches each column reader plugin, stopping those
+// already launched if one fails.
+//
+// Call Close to stop the plugins.
+func LaunchColumnReaders(ctx context.Context, paths Paths, timeout time.Duration) (ColumnReaders, error) {
+	procs, err := launchAll(ctx, ColumnReaderKind, paths, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return ColumnReaders(procs), nil
+}
+
+// Readers returns the column reader of each plugin by name.
+func (rs ColumnReaders) Readers() map[string]updater.ColumnReader {
+	readers := make(map[string]updater.ColumnReader, len(rs))
+	for name, p := range rs {
+		readers[name] = columnReader(name, pluginpb.NewColumnReaderClient(p.conn))
+	}
+	return readers
+}
+
+// Close stops every plugin.
+func (rs ColumnReaders) Close() {
+	closeAll(rs)
+}
+
+// columnReader reads columns with the plugin's client.
+func columnReader(name string, client pluginpb.ColumnReaderClient) updater.ColumnReader {
+	return func(ctx context.Context, _ logrus.FieldLogger, tg *configpb.TestGroup, oldCols []updater.InflatedColumn, stop time.Time) ([]updater.InflatedColumn, error) {
+		resp, err := client.ReadColumns(ctx, &pluginpb.ReadColumnsRequest{
+			TestGroup:  tg,
+			OldColumns: toProto(oldCols),
+			Stop:       seconds(stop),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s plugin: %w", name, err)
+		}
+		return fromProto(resp.Columns), nil
+	}
+}
+
+// toProto converts the columns for a request or response.
+func toProto(cols []updater.InflatedColumn) []*pluginpb.InflatedColumn {
+	out := make([]*pluginpb.InflatedColumn, 0, len(cols))
+	for _, col := range cols {
+		pc := pluginpb.InflatedColumn{
+			Column: col.Column,
+			Cells:  make(map[string]*pluginpb.InflatedCell, len(col.Cells)),
+		}
+		for name, cell := range col.Cells {
+			pc.Cells[name] = &pluginpb.InflatedCell{
+				Result:       cell.Result,
+				Id:           cell.ID,
+				CellId:       cell.CellID,
+				Icon:         cell.Icon,
+				Message:      cell.Message,
+				FullMessage:  cell.FullMessage,
+				Metrics:      cell.Metrics,
+				UserProperty: cell.UserProperty,
+				Properties:   cell.Properties,
+				Links:        cell.Links,
+				Parameters:   cell.Parameters,
+			}
+		}
+		out = append(out, &pc)
+	}
+	return out
+}
+
+// fromProto converts the columns of a request or response.
+func fromProto(cols []*pluginpb.InflatedColumn) []updater.InflatedColumn {
+	out := make([]updater.InflatedColumn, 0, len(cols))
+	for _, pc := range cols {
+		col := updater.InflatedColumn{
+			Column: pc.Column,
+			Cells:  make(map[string]updater.Cell, len(pc.Cells)),
+		}
+		for name, cell := range pc.Cells {
+			col.Cells[name] = updater.Cell{
+				Result:       cell.GetResult(),
+				ID:           cell.GetId(),
+				CellID:       cell.GetCellId(),
+				Icon:         cell.GetIcon(),
+				Message:      cell.GetMessage(),
+				FullMessage:  cell.GetFullMessage(),
+				Metrics:      cell.GetMetrics(),
+				UserProperty: cell.GetUserProperty(),
+				Properties:   cell.GetProperties(),
+				Links:        cell.GetLinks(),
+				Parameters:   cell.GetParameters(),
+			}
+		}
+		out = append(out, col)
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// echoReader prepends a column named after the group and stop time to the old columns.
+func echoReader(_ context.Context, _ logrus.FieldLogger, tg *configpb.TestGroup, oldCols []updater.InflatedColumn, stop time.Time) ([]updater.InflatedColumn, error) {
+	col := updater.InflatedColumn{
+		Column: &statepb.Column{Build: tg.Name, Started: float64(stop.Unix() * 1000)},
+		Cells: map[string]updater.Cell{
+			"test": {Result: statuspb.TestStatus_PASS},
+		},
+	}
+	return append([]updater.InflatedColumn{col}, oldCols...), nil
+}
+
+// TestHelperColumnReader serves echoReader when launched as a plugin by TestLaunchColumnReaders.
+func TestHelperColumnReader(t *testing.T) {
+	if Kind(os.Getenv(MagicCookieKey)) != ColumnReaderKind {
+		return
+	}
+	if err := ServeColumnReader(echoReader); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestLaunchColumnReaders(t *testing.T) {
+	ctx := context.Background()
+	p, err := launch(ctx, ColumnReaderKind, "echo", os.Args[0], time.Minute, "-test.run=^TestHelperColumnReader$")
+	if err != nil {
+		t.Fatalf("launch() got unexpected error: %v", err)
+	}
+	plugins := ColumnReaders{"echo": p}
+	defer plugins.Close()
+
+	stop := time.Unix(1600000000, 0)
+	old := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "1", Started: 1000},
+			Cells: map[string]updater.Cell{
+				"test": {Result: statuspb.TestStatus_FAIL, Message: "boom"},
+			},
+		},
+	}
+	got, err := plugins.Readers()["echo"](ctx, logrus.New(), &configpb.TestGroup{Name: "group"}, old, stop)
+	if err != nil {
+		t.Fatalf("ColumnReader() got unexpected error: %v", err)
+	}
+	want := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "group", Started: 1600000000000},
+			Cells: map[string]updater.Cell{
+				"test": {Result: statuspb.TestStatus_PASS},
+			},
+		},
+		old[0],
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ColumnReader() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	plugins.Close()
+	select {
+	case <-p.exited:
+	default:
+		t.Error("Close() failed to stop the plugin")
+	}
+}
+
+func TestLaunchBadHandshake(t *testing.T) {
+	// Without a matching test, the test binary prints PASS instead of a handshake.
+	if _, err := launch(context.Background(), ColumnReaderKind, "bad", os.Args[0], time.Minute, "-test.run=^$"); err == nil {
+		t.Error("launch() failed to return an error")
+	}
+}
+
+func TestServeColumnReaderWithoutCookie(t *testing.T) {
+	if os.Getenv(MagicCookieKey) != "" {
+		return
+	}
+	if err := ServeColumnReader(echoReader); err == nil {
+		t.Error("ServeColumnReader() failed to return an error")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	cols := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "2", Started: 2000},
+			Cells: map[string]updater.Cell{
+				"test": {
+					Result:       statuspb.TestStatus_FAIL,
+					ID:           "test-id",
+					CellID:       "cell",
+					Icon:         "F",
+					Message:      "boom",
+					FullMessage:  "boom boom",
+					Metrics:      map[string]float64{"elapsed": 3},
+					UserProperty: "prop",
+					Properties:   map[string]string{"key": "value"},
+					Links:        []*statepb.Link{{Name: "log", Url: "https://log"}},
+					Parameters:   []*statepb.ParameterResult{{Parameters: "[1]", Result: int32(statuspb.TestStatus_FAIL)}},
+				},
+			},
+		},
+		{
+			Column: &statepb.Column{Build: "1", Started: 1000},
+			Cells: map[string]updater.Cell{
+				"test": {Result: statuspb.TestStatus_PASS},
+			},
+		},
+	}
+	got := fromProto(toProto(cols))
+	if diff := cmp.Diff(cols, got, protocmp.Transform()); diff != "" {
+		t.Errorf("fromProto(toProto()) got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin runs column readers and notification senders out of process,
+// so organizations can read proprietary results or deliver notifications to
+// custom sinks from a separate binary over gRPC, without linking their code
+// into TestGrid.
+//
+// TestGrid launches each plugin with MagicCookieKey set to the Kind of plugin
+// it expects. The plugin serves the gRPC service of its kind on a local
+// address, prints a handshake line to stdout and serves until its stdin closes:
+//
+//	CORE-PROTOCOL-VERSION|APP-PROTOCOL-VERSION|NETWORK|ADDRESS|grpc
+//
+// Anything the plugin writes to stderr is logged by TestGrid.
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind identifies the service a plugin serves.
+type Kind string
+
+const (
+	// ColumnReaderKind plugins serve the ColumnReader service for the updater.
+	ColumnReaderKind Kind = "column-reader"
+	// SenderKind plugins serve the Sender service for notifications.
+	SenderKind Kind = "sender"
+)
+
+const (
+	// MagicCookieKey names the environment variable holding the Kind of
+	// plugin TestGrid launched, which plugins check before serving.
+	MagicCookieKey = "TESTGRID_PLUGIN"
+
+	// CoreProtocolVersion is the version of the handshake.
+	CoreProtocolVersion = 1
+	// ProtocolVersion is the version of the plugin services.
+	ProtocolVersion = 1
+
+	// maxMessageBytes limits the size of requests and responses, which may
+	// hold every recent column of a group.
+	maxMessageBytes = 256 << 20
+)
+
+// handshake returns the line a plugin prints once serving at the address.
+func handshake(network, addr string) string {
+	return fmt.Sprintf("%d|%d|%s|%s|grpc", CoreProtocolVersion, ProtocolVersion, network, addr)
+}
+
+// parseHandshake returns the network and address of the plugin's handshake line.
+func parseHandshake(line string) (string, string, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 5 {
+		return "", "", fmt.Errorf("malformed handshake %q", line)
+	}
+	if v, err := strconv.Atoi(parts[0]); err != nil || v != CoreProtocolVersion {
+		return "", "", fmt.Errorf("unsupported core protocol version %q, want %d", parts[0], CoreProtocolVersion)
+	}
+	if v, err := strconv.Atoi(parts[1]); err != nil || v != ProtocolVersion {
+		return "", "", fmt.Errorf("unsupported protocol version %q, want %d", parts[1], ProtocolVersion)
+	}
+	if parts[4] != "grpc" {
+		return "", "", fmt.Errorf("unsupported protocol %q, want grpc", parts[4])
+	}
+	return parts[2], parts[3], nil
+}
+
+// seconds returns the time in seconds since epoch, or zero for the zero time.
+func seconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// fromSeconds returns the time of seconds since epoch, or the zero time for zero.
+func fromSeconds(s float64) time.Time {
+	if s == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(s*float64(time.Second)))
+}
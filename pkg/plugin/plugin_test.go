@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseHandshake(t *testing.T) {
+	cases := []struct {
+		name    string
+		line    string
+		network string
+		addr    string
+		err     bool
+	}{
+		{
+			name:    "basic",
+			line:    handshake("tcp", "127.0.0.1:1234"),
+			network: "tcp",
+			addr:    "127.0.0.1:1234",
+		},
+		{
+			name:    "trailing newline",
+			line:    handshake("tcp", "127.0.0.1:1234") + "\n",
+			network: "tcp",
+			addr:    "127.0.0.1:1234",
+		},
+		{
+			name: "malformed",
+			line: "PASS",
+			err:  true,
+		},
+		{
+			name: "core protocol mismatch",
+			line: "2|1|tcp|127.0.0.1:1234|grpc",
+			err:  true,
+		},
+		{
+			name: "protocol mismatch",
+			line: "1|2|tcp|127.0.0.1:1234|grpc",
+			err:  true,
+		},
+		{
+			name: "not grpc",
+			line: "1|1|tcp|127.0.0.1:1234|netrpc",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			network, addr, err := parseHandshake(tc.line)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("parseHandshake() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("parseHandshake() failed to return an error")
+			case network != tc.network || addr != tc.addr:
+				t.Errorf("parseHandshake() got %s %s, want %s %s", network, addr, tc.network, tc.addr)
+			}
+		})
+	}
+}
+
+func TestPathsSet(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		want   Paths
+		err    bool
+	}{
+		{
+			name:   "basic",
+			values: []string{"foo=/bin/foo", "bar=/bin/bar=baz"},
+			want:   Paths{"foo": "/bin/foo", "bar": "/bin/bar=baz"},
+		},
+		{
+			name:   "missing path",
+			values: []string{"foo"},
+			err:    true,
+		},
+		{
+			name:   "empty name",
+			values: []string{"=/bin/foo"},
+			err:    true,
+		},
+		{
+			name:   "duplicate",
+			values: []string{"foo=/bin/foo", "foo=/bin/bar"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Paths
+			var err error
+			for _, v := range tc.values {
+				if err = got.Set(v); err != nil {
+					break
+				}
+			}
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("Set() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("Set() failed to return an error")
+			default:
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("Set() got unexpected diff (-want +got):\n%s", diff)
+				}
+				if s := got.String(); s != "bar=/bin/bar=baz,foo=/bin/foo" {
+					t.Errorf("String() got %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestSeconds(t *testing.T) {
+	if got := fromSeconds(seconds(time.Time{})); !got.IsZero() {
+		t.Errorf("fromSeconds(seconds(zero)) got %v, want zero", got)
+	}
+	now := time.Unix(1600000000, 500000000)
+	if got := fromSeconds(seconds(now)); !got.Equal(now) {
+		t.Errorf("fromSeconds(seconds(%v)) got %v", now, got)
+	}
+}
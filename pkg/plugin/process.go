@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Process is a plugin running in a separate process.
+type Process struct {
+	name   string
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	conn   *grpc.ClientConn
+	exited chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// launch starts the plugin binary at path as the kind of plugin and connects
+// to it once it completes the handshake, or fails after timeout.
+func launch(ctx context.Context, kind Kind, name, path string, timeout time.Duration, args ...string) (*Process, error) {
+	log := logrus.WithFields(logrus.Fields{"plugin": name, "kind": kind, "path": path})
+	cmd := exec.Command(path, args...)
+	cmd.Env = append(os.Environ(), MagicCookieKey+"="+string(kind))
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stdout: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stderr: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start: %w", err)
+	}
+	p := &Process{
+		name:   name,
+		cmd:    cmd,
+		stdin:  stdin,
+		exited: make(chan struct{}),
+	}
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func() {
+		defer readers.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			log.Info(scanner.Text())
+		}
+	}()
+
+	lines := make(chan string, 1)
+	go func() {
+		defer readers.Done()
+		scanner := bufio.NewScanner(stdout)
+		if scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+		// Keep draining stdout, so the plugin never blocks writing to it.
+		for scanner.Scan() {
+			log.Info(scanner.Text())
+		}
+	}()
+	go func() {
+		readers.Wait() // Wait closes the pipes, so finish reading them first.
+		cmd.Wait()
+		close(p.exited)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var line string
+	select {
+	case l, ok := <-lines:
+		if !ok {
+			p.Close()
+			return nil, errors.New("plugin exited before the handshake")
+		}
+		line = l
+	case <-timer.C:
+		p.Close()
+		return nil, fmt.Errorf("no handshake within %s", timeout)
+	case <-ctx.Done():
+		p.Close()
+		return nil, ctx.Err()
+	}
+	network, addr, err := parseHandshake(line)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	if network != "tcp" {
+		p.Close()
+		return nil, fmt.Errorf("unsupported network %q", network)
+	}
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(maxMessageBytes),
+		grpc.MaxCallSendMsgSize(maxMessageBytes),
+	))
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	p.conn = conn
+	log.WithField("addr", addr).Info("Launched plugin")
+	return p, nil
+}
+
+// Close asks the plugin to exit by closing its stdin, killing it if it is
+// still running after a few seconds.
+func (p *Process) Close() error {
+	p.closeOnce.Do(func() {
+		if p.conn != nil {
+			p.closeErr = p.conn.Close()
+		}
+		p.stdin.Close()
+		select {
+		case <-p.exited:
+		case <-time.After(5 * time.Second):
+			p.cmd.Process.Kill()
+			<-p.exited
+		}
+	})
+	return p.closeErr
+}
+
+// Paths is a flag of repeatable name=/path/to/plugin binaries.
+type Paths map[string]string
+
+func (p *Paths) String() string {
+	var parts []string
+	for name, path := range *p {
+		parts = append(parts, name+"="+path)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// Set adds a name=/path/to/plugin binary.
+func (p *Paths) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("%q is not name=/path/to/plugin", value)
+	}
+	if *p == nil {
+		*p = Paths{}
+	}
+	if _, ok := (*p)[parts[0]]; ok {
+		return fmt.Errorf("duplicate plugin %q", parts[0])
+	}
+	(*p)[parts[0]] = parts[1]
+	return nil
+}
+
+// launchAll launches each plugin as the kind of plugin, stopping those
+// already launched if one fails.
+func launchAll(ctx context.Context, kind Kind, paths Paths, timeout time.Duration) (map[string]*Process, error) {
+	procs := map[string]*Process{}
+	for name, path := range paths {
+		p, err := launch(ctx, kind, name, path, timeout)
+		if err != nil {
+			closeAll(procs)
+			return nil, fmt.Errorf("launch %s: %w", name, err)
+		}
+		procs[name] = p
+	}
+	return procs, nil
+}
+
+// closeAll stops every plugin.
+func closeAll(procs map[string]*Process) {
+	for name, p := range procs {
+		if err := p.Close(); err != nil {
+			logrus.WithError(err).WithField("plugin", name).Warning("Failed to close plugin")
+		}
+	}
+}
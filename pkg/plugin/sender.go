@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pluginpb "github.com/GoogleCloudPlatform/testgrid/pb/plugin"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/notifier"
+)
+
+// ServeSender serves the notification sender until TestGrid closes stdin.
+//
+// Call it from the main function of a sender plugin binary, which TestGrid
+// launches. Logs go to stderr, since the handshake uses stdout.
+func ServeSender(sender notifier.Sender) error {
+	return serveKind(SenderKind, func(s *grpc.Server) {
+		pluginpb.RegisterSenderServer(s, &senderServer{sender: sender})
+	})
+}
+
+// senderServer serves a notifier.Sender over gRPC.
+type senderServer struct {
+	sender notifier.Sender
+}
+
+// Send delivers the notification with the sender.
+func (s *senderServer) Send(ctx context.Context, req *pluginpb.SendRequest) (*pluginpb.SendResponse, error) {
+	msg := notifier.Message{
+		Dashboard: req.GetDashboard(),
+		Tab:       req.GetTab(),
+		TabURL:    req.GetTabUrl(),
+		Summary:   req.GetSummary(),
+		Step:      req.GetStep(),
+		Now:       fromSeconds(req.GetNow()),
+	}
+	if err := s.sender.Send(ctx, req.GetTarget(), msg, req.GetBody()); err != nil {
+		return nil, err
+	}
+	return &pluginpb.SendResponse{}, nil
+}
+
+// Senders holds launched sender plugins by the channel they deliver.
+type Senders map[string]*Process
+
+// LaunchSenders launches a sender plugin for each channel, stopping those
+// already launched if one fails.
+//
+// Call Close to stop the plugins.
+func LaunchSenders(ctx context.Context, paths Paths, timeout time.Duration) (Senders, error) {
+	procs, err := launchAll(ctx, SenderKind, paths, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return Senders(procs), nil
+}
+
+// Senders returns the sender of each channel.
+func (ss Senders) Senders() notifier.Senders {
+	senders := make(notifier.Senders, len(ss))
+	for channel, p := range ss {
+		senders[channel] = &sender{channel: channel, client: pluginpb.NewSenderClient(p.conn)}
+	}
+	return senders
+}
+
+// Close stops every plugin.
+func (ss Senders) Close() {
+	closeAll(ss)
+}
+
+// sender delivers the notifications of a channel with its plugin.
+type sender struct {
+	channel string
+	client  pluginpb.SenderClient
+}
+
+// Send asks the plugin to deliver the notification.
+func (s *sender) Send(ctx context.Context, target string, msg notifier.Message, body string) error {
+	_, err := s.client.Send(ctx, &pluginpb.SendRequest{
+		Channel:   s.channel,
+		Target:    target,
+		Body:      body,
+		Dashboard: msg.Dashboard,
+		Tab:       msg.Tab,
+		TabUrl:    msg.TabURL,
+		Summary:   msg.Summary,
+		Step:      msg.Step,
+		Now:       seconds(msg.Now),
+	})
+	if err != nil {
+		return fmt.Errorf("%s plugin: %w", s.channel, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/notifier"
+)
+
+// sentMessage is the notification TestSenders expects the plugin to receive.
+var sentMessage = notifier.Message{
+	Dashboard: "dash",
+	Tab:       "tab",
+	TabURL:    "https://testgrid/dash#tab",
+	Summary: &summarypb.DashboardTabSummary{
+		DashboardName:    "dash",
+		DashboardTabName: "tab",
+		OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+	},
+	Step: &configpb.EscalationStep{Channel: "chat", Target: "room"},
+	Now:  time.Unix(1600000000, 0),
+}
+
+// checkSender fails to deliver to the fail target or anything other than sentMessage.
+//
+// Plugins receive the rendered body, so the message omits its locale.
+type checkSender struct{}
+
+func (checkSender) Send(_ context.Context, target string, msg notifier.Message, body string) error {
+	if target == "fail" {
+		return errors.New("delivery failed")
+	}
+	if diff := cmp.Diff(sentMessage, msg, protocmp.Transform(), cmpopts.IgnoreUnexported(notifier.Message{})); diff != "" {
+		return fmt.Errorf("unexpected message (-want +got):\n%s", diff)
+	}
+	if body != "hello" {
+		return fmt.Errorf("unexpected body %q", body)
+	}
+	return nil
+}
+
+// TestHelperSender serves checkSender when launched as a plugin by TestSenders.
+func TestHelperSender(t *testing.T) {
+	if Kind(os.Getenv(MagicCookieKey)) != SenderKind {
+		return
+	}
+	if err := ServeSender(checkSender{}); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestSenders(t *testing.T) {
+	ctx := context.Background()
+	p, err := launch(ctx, SenderKind, "chat", os.Args[0], time.Minute, "-test.run=^TestHelperSender$")
+	if err != nil {
+		t.Fatalf("launch() got unexpected error: %v", err)
+	}
+	plugins := Senders{"chat": p}
+	defer plugins.Close()
+
+	sender := plugins.Senders()["chat"]
+	if sender == nil {
+		t.Fatal("Senders() is missing the chat plugin")
+	}
+	if err := sender.Send(ctx, "room", sentMessage, "hello"); err != nil {
+		t.Errorf("Send() got unexpected error: %v", err)
+	}
+	if err := sender.Send(ctx, "fail", sentMessage, "hello"); err == nil {
+		t.Error("Send() failed to return the plugin's error")
+	}
+}
+
+func TestLaunchWrongKind(t *testing.T) {
+	// The column reader helper refuses to serve as a sender, so never completes the handshake.
+	if _, err := launch(context.Background(), SenderKind, "reader", os.Args[0], time.Minute, "-test.run=^TestHelperColumnReader$"); err == nil {
+		t.Error("launch() failed to return an error")
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// serveKind serves the plugin's gRPC services until TestGrid closes stdin,
+// after checking that TestGrid launched this kind of plugin.
+func serveKind(kind Kind, register func(*grpc.Server)) error {
+	switch launched := Kind(os.Getenv(MagicCookieKey)); launched {
+	case kind:
+	case "":
+		return fmt.Errorf("this binary is a %s plugin, which only runs when TestGrid launches it", kind)
+	default:
+		return fmt.Errorf("launched as a %s plugin, but this binary is a %s plugin", launched, kind)
+	}
+	logrus.SetOutput(os.Stderr)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+		select {
+		case <-ch:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(ch)
+	}()
+	return serve(ctx, os.Stdin, os.Stdout, register)
+}
+
+// serve prints the handshake to stdout once serving the registered services,
+// and serves until stdin closes or the context ends.
+func serve(ctx context.Context, stdin io.Reader, stdout io.Writer, register func(*grpc.Server)) error {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageBytes), grpc.MaxSendMsgSize(maxMessageBytes))
+	register(server)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(lis)
+	}()
+	if _, err := fmt.Fprintln(stdout, handshake(lis.Addr().Network(), lis.Addr().String())); err != nil {
+		server.Stop()
+		return fmt.Errorf("handshake: %w", err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		io.Copy(ioutil.Discard, stdin)
+		close(closed)
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-closed:
+	case <-ctx.Done():
+	}
+	server.GracefulStop()
+	return nil
+}
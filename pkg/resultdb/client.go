@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resultdb reads the builds of LUCI builders from Buildbucket and the
+// test results of their invocations from ResultDB.
+package resultdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBuildbucketURL is the Buildbucket server of public LUCI projects.
+	DefaultBuildbucketURL = "https://cr-buildbucket.appspot.com"
+	// DefaultResultDBURL is the ResultDB server of public LUCI projects.
+	DefaultResultDBURL = "https://results.api.cr.dev"
+
+	// prpcPrefix protects pRPC JSON responses from XSSI.
+	prpcPrefix = ")]}'"
+
+	buildFields  = "builds.*.id,builds.*.number,builds.*.status,builds.*.startTime,builds.*.endTime,builds.*.summaryMarkdown,builds.*.tags,builds.*.input.gitilesCommit,builds.*.infra.resultdb"
+	resultFields = "name,testId,resultId,variant,expected,status,duration,failureReason"
+)
+
+// Client calls the pRPC APIs of Buildbucket and ResultDB.
+type Client struct {
+	HTTP *http.Client
+	// BuildbucketURL defaults to DefaultBuildbucketURL.
+	BuildbucketURL string
+	// ResultDBURL defaults to DefaultResultDBURL.
+	ResultDBURL string
+}
+
+// BuilderID identifies a LUCI builder.
+type BuilderID struct {
+	Project string `json:"project"`
+	Bucket  string `json:"bucket"`
+	Builder string `json:"builder"`
+}
+
+// Build statuses.
+const (
+	StatusScheduled    = "SCHEDULED"
+	StatusStarted      = "STARTED"
+	StatusSuccess      = "SUCCESS"
+	StatusFailure      = "FAILURE"
+	StatusInfraFailure = "INFRA_FAILURE"
+	StatusCanceled     = "CANCELED"
+)
+
+// Build is a Buildbucket build.
+type Build struct {
+	ID              string       `json:"id"`
+	Number          int          `json:"number,omitempty"`
+	Status          string       `json:"status"`
+	StartTime       time.Time    `json:"startTime,omitempty"`
+	EndTime         time.Time    `json:"endTime,omitempty"`
+	SummaryMarkdown string       `json:"summaryMarkdown,omitempty"`
+	Tags            []StringPair `json:"tags,omitempty"`
+	Input           BuildInput   `json:"input"`
+	Infra           BuildInfra   `json:"infra"`
+}
+
+// StringPair is a key-value pair, such as a build tag.
+type StringPair struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// BuildInput holds the input of a build.
+type BuildInput struct {
+	GitilesCommit *GitilesCommit `json:"gitilesCommit,omitempty"`
+}
+
+// GitilesCommit identifies the commit a build tested.
+type GitilesCommit struct {
+	Host    string `json:"host"`
+	Project string `json:"project"`
+	Ref     string `json:"ref,omitempty"`
+	ID      string `json:"id"`
+}
+
+// BuildInfra holds the infrastructure of a build.
+type BuildInfra struct {
+	ResultDB struct {
+		Hostname   string `json:"hostname,omitempty"`
+		Invocation string `json:"invocation,omitempty"`
+	} `json:"resultdb"`
+}
+
+// Tag returns the value of the first tag with the key.
+func (b Build) Tag(key string) (string, bool) {
+	for _, t := range b.Tags {
+		if t.Key == key {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// Test result statuses.
+const (
+	TestPass  = "PASS"
+	TestFail  = "FAIL"
+	TestCrash = "CRASH"
+	TestAbort = "ABORT"
+	TestSkip  = "SKIP"
+)
+
+// TestResult is a ResultDB test result.
+type TestResult struct {
+	Name     string  `json:"name"`
+	TestID   string  `json:"testId"`
+	ResultID string  `json:"resultId"`
+	Variant  Variant `json:"variant"`
+	Expected bool    `json:"expected,omitempty"`
+	Status   string  `json:"status"`
+	// Duration is formatted as a JSON protobuf duration, such as 1.500s.
+	Duration      string         `json:"duration,omitempty"`
+	FailureReason *FailureReason `json:"failureReason,omitempty"`
+}
+
+// FailureReason explains why a test failed.
+type FailureReason struct {
+	PrimaryErrorMessage string `json:"primaryErrorMessage,omitempty"`
+}
+
+// Variant describes how a test ran, such as on which device.
+type Variant struct {
+	Def map[string]string `json:"def,omitempty"`
+}
+
+type timeRange struct {
+	StartTime time.Time `json:"startTime"`
+}
+
+type buildPredicate struct {
+	Builder    BuilderID `json:"builder"`
+	CreateTime timeRange `json:"createTime"`
+}
+
+type searchBuildsRequest struct {
+	Predicate buildPredicate `json:"predicate"`
+	Fields    string         `json:"fields"`
+	PageSize  int            `json:"pageSize"`
+	PageToken string         `json:"pageToken,omitempty"`
+}
+
+type searchBuildsResponse struct {
+	Builds        []Build `json:"builds"`
+	NextPageToken string  `json:"nextPageToken"`
+}
+
+// SearchBuilds returns at most max builds of the builder created since then,
+// newest first.
+func (c *Client) SearchBuilds(ctx context.Context, builder BuilderID, since time.Time, max int) ([]Build, error) {
+	req := searchBuildsRequest{
+		Predicate: buildPredicate{Builder: builder, CreateTime: timeRange{StartTime: since}},
+		Fields:    buildFields,
+		PageSize:  max,
+	}
+	var builds []Build
+	for len(builds) < max {
+		var resp searchBuildsResponse
+		if err := c.call(ctx, c.BuildbucketURL, DefaultBuildbucketURL, "buildbucket.v2.Builds", "SearchBuilds", req, &resp); err != nil {
+			return nil, err
+		}
+		builds = append(builds, resp.Builds...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if len(builds) > max {
+		builds = builds[:max]
+	}
+	return builds, nil
+}
+
+type queryTestResultsRequest struct {
+	Invocations []string `json:"invocations"`
+	ReadMask    string   `json:"readMask"`
+	PageSize    int      `json:"pageSize"`
+	PageToken   string   `json:"pageToken,omitempty"`
+}
+
+type queryTestResultsResponse struct {
+	TestResults   []TestResult `json:"testResults"`
+	NextPageToken string       `json:"nextPageToken"`
+}
+
+// QueryTestResults returns every test result of the invocation, such as
+// invocations/build-123.
+func (c *Client) QueryTestResults(ctx context.Context, invocation string) ([]TestResult, error) {
+	req := queryTestResultsRequest{
+		Invocations: []string{invocation},
+		ReadMask:    resultFields,
+		PageSize:    1000,
+	}
+	var results []TestResult
+	for {
+		var resp queryTestResultsResponse
+		if err := c.call(ctx, c.ResultDBURL, DefaultResultDBURL, "luci.resultdb.v1.ResultDB", "QueryTestResults", req, &resp); err != nil {
+			return nil, err
+		}
+		results = append(results, resp.TestResults...)
+		if resp.NextPageToken == "" {
+			return results, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// call sends a pRPC request, decoding the JSON response.
+func (c *Client) call(ctx context.Context, base, defaultBase, service, method string, in, out interface{}) error {
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if base == "" {
+		base = defaultBase
+	}
+	buf, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", method, err)
+	}
+	u := strings.TrimSuffix(base, "/") + "/prpc/" + service + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("%s request: %w", method, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s: %s", method, resp.Status, bytes.TrimSpace(msg))
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", method, err)
+	}
+	body = bytes.TrimPrefix(body, []byte(prpcPrefix))
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultdb
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// prpcServer serves each method's responses in order, recording the requests.
+type prpcServer struct {
+	t         *testing.T
+	responses map[string][]string
+	requests  map[string][]map[string]interface{}
+}
+
+func (s *prpcServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.t.Errorf("%s %s: want POST", r.Method, r.URL.Path)
+	}
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.t.Errorf("read body: %v", err)
+	}
+	var req map[string]interface{}
+	if err := json.Unmarshal(buf, &req); err != nil {
+		s.t.Errorf("unmarshal %s: %v", r.URL.Path, err)
+	}
+	s.requests[r.URL.Path] = append(s.requests[r.URL.Path], req)
+	resps := s.responses[r.URL.Path]
+	if len(resps) == 0 {
+		http.Error(w, "no more responses", http.StatusNotFound)
+		return
+	}
+	s.responses[r.URL.Path] = resps[1:]
+	w.Write([]byte(prpcPrefix + "\n" + resps[0]))
+}
+
+func newServer(t *testing.T, responses map[string][]string) (*prpcServer, *httptest.Server) {
+	s := &prpcServer{t: t, responses: responses, requests: map[string][]map[string]interface{}{}}
+	return s, httptest.NewServer(s)
+}
+
+const (
+	searchPath = "/prpc/buildbucket.v2.Builds/SearchBuilds"
+	queryPath  = "/prpc/luci.resultdb.v1.ResultDB/QueryTestResults"
+)
+
+func TestSearchBuilds(t *testing.T) {
+	since := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		responses []string
+		max       int
+		want      []Build
+		wantPages int
+		wantErr   bool
+	}{
+		{
+			name: "page through builds",
+			responses: []string{
+				`{"builds":[{"id":"3","number":3,"status":"STARTED"}],"nextPageToken":"next"}`,
+				`{"builds":[{"id":"2","number":2,"status":"SUCCESS","tags":[{"key":"os","value":"linux"}]}]}`,
+			},
+			max: 5,
+			want: []Build{
+				{ID: "3", Number: 3, Status: StatusStarted},
+				{ID: "2", Number: 2, Status: StatusSuccess, Tags: []StringPair{{Key: "os", Value: "linux"}}},
+			},
+			wantPages: 2,
+		},
+		{
+			name: "stop at max",
+			responses: []string{
+				`{"builds":[{"id":"3"},{"id":"2"}],"nextPageToken":"next"}`,
+				`{"builds":[{"id":"1"}]}`,
+			},
+			max:       1,
+			want:      []Build{{ID: "3"}},
+			wantPages: 1,
+		},
+		{
+			name:      "error",
+			max:       5,
+			wantErr:   true,
+			wantPages: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, server := newServer(t, map[string][]string{searchPath: tc.responses})
+			defer server.Close()
+			client := Client{HTTP: server.Client(), BuildbucketURL: server.URL}
+			builder := BuilderID{Project: "chromium", Bucket: "ci", Builder: "linux-rel"}
+			got, err := client.SearchBuilds(context.Background(), builder, since, tc.max)
+			switch {
+			case err != nil && !tc.wantErr:
+				t.Errorf("SearchBuilds() got unexpected error: %v", err)
+			case err == nil && tc.wantErr:
+				t.Error("SearchBuilds() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SearchBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+			reqs := s.requests[searchPath]
+			if len(reqs) != tc.wantPages {
+				t.Fatalf("SearchBuilds() sent %d requests, want %d", len(reqs), tc.wantPages)
+			}
+			wantPredicate := map[string]interface{}{
+				"builder":    map[string]interface{}{"project": "chromium", "bucket": "ci", "builder": "linux-rel"},
+				"createTime": map[string]interface{}{"startTime": "2021-06-01T00:00:00Z"},
+			}
+			if diff := cmp.Diff(wantPredicate, reqs[0]["predicate"]); diff != "" {
+				t.Errorf("SearchBuilds() sent unexpected predicate (-want +got):\n%s", diff)
+			}
+			if len(reqs) > 1 && reqs[1]["pageToken"] != "next" {
+				t.Errorf("SearchBuilds() sent page token %v, want next", reqs[1]["pageToken"])
+			}
+		})
+	}
+}
+
+func TestQueryTestResults(t *testing.T) {
+	s, server := newServer(t, map[string][]string{
+		queryPath: {
+			`{"testResults":[{"testId":"foo","status":"PASS","variant":{"def":{"os":"linux"}}}],"nextPageToken":"next"}`,
+			`{"testResults":[{"testId":"bar","status":"FAIL","failureReason":{"primaryErrorMessage":"boom"}}]}`,
+		},
+	})
+	defer server.Close()
+	client := Client{HTTP: server.Client(), ResultDBURL: server.URL}
+	got, err := client.QueryTestResults(context.Background(), "invocations/build-1")
+	if err != nil {
+		t.Fatalf("QueryTestResults() got unexpected error: %v", err)
+	}
+	want := []TestResult{
+		{TestID: "foo", Status: TestPass, Variant: Variant{Def: map[string]string{"os": "linux"}}},
+		{TestID: "bar", Status: TestFail, FailureReason: &FailureReason{PrimaryErrorMessage: "boom"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QueryTestResults() got unexpected diff (-want +got):\n%s", diff)
+	}
+	reqs := s.requests[queryPath]
+	if len(reqs) != 2 {
+		t.Fatalf("QueryTestResults() sent %d requests, want 2", len(reqs))
+	}
+	if diff := cmp.Diff([]interface{}{"invocations/build-1"}, reqs[0]["invocations"]); diff != "" {
+		t.Errorf("QueryTestResults() sent unexpected invocations (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// ReaderName is the column_reader of groups which read LUCI builds.
+const ReaderName = "resultdb"
+
+const (
+	// maxBuilds limits the builds read each cycle, matching the GCS reader.
+	maxBuilds = 50
+	// commitHeader is the column header holding the tested commit.
+	commitHeader = "Commit"
+	// missingHeader is the value of headers without a value.
+	missingHeader = "missing"
+)
+
+// ColumnReader returns a reader of the builds of a group's resultdb_config
+// builder, converting each build into a column.
+//
+// Builds already in the old columns, along with scheduled builds which have
+// yet to start, are skipped.
+func ColumnReader(client *Client) updater.ColumnReader {
+	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []updater.InflatedColumn, stop time.Time) ([]updater.InflatedColumn, error) {
+		cfg := tg.GetResultSource().GetResultdbConfig()
+		if cfg == nil {
+			return nil, errors.New("missing resultdb_config")
+		}
+		seen := make(map[string]bool, len(oldCols))
+		for _, col := range oldCols {
+			seen[col.Column.Hint] = true
+			if started := updater.ColumnStarted(col.Column); started.After(stop) {
+				stop = started
+			}
+		}
+		builder := BuilderID{Project: cfg.Project, Bucket: cfg.Bucket, Builder: cfg.Builder}
+		builds, err := client.SearchBuilds(ctx, builder, stop, maxBuilds)
+		if err != nil {
+			return nil, fmt.Errorf("search builds: %w", err)
+		}
+		log.WithField("builds", len(builds)).Debug("Searched builds")
+		var cols []updater.InflatedColumn
+		for _, b := range builds {
+			if seen[b.ID] || b.StartTime.IsZero() {
+				continue
+			}
+			var results []TestResult
+			if inv := b.Infra.ResultDB.Invocation; inv != "" {
+				if results, err = client.QueryTestResults(ctx, inv); err != nil {
+					return nil, fmt.Errorf("build %s: query test results: %w", b.ID, err)
+				}
+			}
+			cols = append(cols, convertBuild(tg, cfg, b, results))
+		}
+		return cols, nil
+	}
+}
+
+// convertBuild returns the column of the build, with a row for each test and
+// variant along with the overall row.
+func convertBuild(tg *configpb.TestGroup, cfg *configpb.ResultDBConfig, b Build, results []TestResult) updater.InflatedColumn {
+	build := b.ID
+	if b.Number > 0 {
+		build = strconv.Itoa(b.Number)
+	}
+	overall := overallCell(b)
+	col := updater.InflatedColumn{
+		Column: &statepb.Column{
+			Build:   build,
+			Hint:    b.ID,
+			Started: float64(b.StartTime.UnixNano()) / float64(time.Millisecond),
+		},
+		Cells: map[string]updater.Cell{},
+	}
+	for _, h := range tg.GetColumnHeader() {
+		val := headerValue(b, h.ConfigurationValue)
+		if val == "" && overall.Result != statuspb.TestStatus_RUNNING {
+			val = missingHeader
+		}
+		col.Column.Extra = append(col.Column.Extra, val)
+	}
+
+	cells := map[string][]updater.Cell{}
+	for _, r := range results {
+		name := rowName(r.TestID, r.Variant, cfg.VariantKeys)
+		cell := resultCell(r)
+		cell.ID = name
+		cells[name] = append(cells[name], cell)
+	}
+	for name, cs := range cells {
+		col.Cells[name] = updater.MergeCells(true, cs...)
+	}
+	if name := updater.OverallRowName(tg); name != "" {
+		col.Cells[name] = overall
+	}
+	return col
+}
+
+// headerValue returns the commit for the Commit header, or else the value of the build tag.
+func headerValue(b Build, header string) string {
+	if header == "" {
+		return ""
+	}
+	if header == commitHeader && b.Input.GitilesCommit != nil {
+		return b.Input.GitilesCommit.ID
+	}
+	val, _ := b.Tag(header)
+	return val
+}
+
+// rowName returns the test ID along with the value of each variant key, such
+// as "foo [os=linux, gpu=none]".
+func rowName(testID string, variant Variant, keys []string) string {
+	var parts []string
+	for _, k := range keys {
+		if v, ok := variant.Def[k]; ok {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	if len(parts) == 0 {
+		return testID
+	}
+	return testID + " [" + strings.Join(parts, ", ") + "]"
+}
+
+// resultCell converts the test result into a cell.
+//
+// Expected failures, such as of tests known to be broken on a platform, pass with errors.
+func resultCell(r TestResult) updater.Cell {
+	var c updater.Cell
+	if r.FailureReason != nil {
+		c.Message = r.FailureReason.PrimaryErrorMessage
+	}
+	if d, err := time.ParseDuration(r.Duration); err == nil && d > 0 {
+		c.Metrics = map[string]float64{updater.ElapsedKey: d.Minutes()}
+	}
+	switch r.Status {
+	case TestPass:
+		c.Result = statuspb.TestStatus_PASS
+	case TestSkip:
+		c.Result = statuspb.TestStatus_PASS_WITH_SKIPS
+		c.Icon = "S"
+	case TestFail, TestCrash, TestAbort:
+		if r.Expected {
+			c.Result = statuspb.TestStatus_PASS_WITH_ERRORS
+			if c.Message == "" {
+				c.Message = "Expected " + r.Status
+			}
+			break
+		}
+		c.Result = statuspb.TestStatus_FAIL
+		c.Icon = r.Status[:1]
+	default:
+		c.Result = statuspb.TestStatus_UNKNOWN
+	}
+	return c
+}
+
+// overallCell summarizes the build's status.
+func overallCell(b Build) updater.Cell {
+	c := updater.Cell{Message: b.SummaryMarkdown}
+	switch b.Status {
+	case StatusSuccess:
+		c.Result = statuspb.TestStatus_PASS
+	case StatusFailure:
+		c.Result = statuspb.TestStatus_FAIL
+	case StatusInfraFailure:
+		c.Result = statuspb.TestStatus_FAIL
+		c.Icon = "E"
+	case StatusCanceled:
+		c.Result = statuspb.TestStatus_CANCEL
+		c.Icon = "C"
+	default:
+		c.Result = statuspb.TestStatus_RUNNING
+		c.Icon = "R"
+		if c.Message == "" {
+			c.Message = "Build still running..."
+		}
+		return c
+	}
+	if !b.EndTime.IsZero() {
+		c.Metrics = map[string]float64{updater.ElapsedKey: b.EndTime.Sub(b.StartTime).Minutes()}
+	}
+	return c
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resultdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+func TestRowName(t *testing.T) {
+	variant := Variant{Def: map[string]string{"os": "linux", "gpu": "none", "builder": "linux-rel"}}
+	cases := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{
+			name: "no keys",
+			want: "foo",
+		},
+		{
+			name: "keys in configured order",
+			keys: []string{"os", "gpu"},
+			want: "foo [os=linux, gpu=none]",
+		},
+		{
+			name: "ignore absent keys",
+			keys: []string{"test_suite", "os"},
+			want: "foo [os=linux]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rowName("foo", variant, tc.keys); got != tc.want {
+				t.Errorf("rowName() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResultCell(t *testing.T) {
+	cases := []struct {
+		name   string
+		result TestResult
+		want   updater.Cell
+	}{
+		{
+			name:   "pass",
+			result: TestResult{Status: TestPass, Duration: "90s"},
+			want: updater.Cell{
+				Result:  statuspb.TestStatus_PASS,
+				Metrics: map[string]float64{updater.ElapsedKey: 1.5},
+			},
+		},
+		{
+			name:   "skip",
+			result: TestResult{Status: TestSkip},
+			want:   updater.Cell{Result: statuspb.TestStatus_PASS_WITH_SKIPS, Icon: "S"},
+		},
+		{
+			name:   "crash",
+			result: TestResult{Status: TestCrash, FailureReason: &FailureReason{PrimaryErrorMessage: "segfault"}},
+			want:   updater.Cell{Result: statuspb.TestStatus_FAIL, Icon: "C", Message: "segfault"},
+		},
+		{
+			name:   "expected failure",
+			result: TestResult{Status: TestFail, Expected: true},
+			want:   updater.Cell{Result: statuspb.TestStatus_PASS_WITH_ERRORS, Message: "Expected FAIL"},
+		},
+		{
+			name:   "unknown",
+			result: TestResult{Status: "STATUS_UNSPECIFIED"},
+			want:   updater.Cell{Result: statuspb.TestStatus_UNKNOWN},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, resultCell(tc.result), protocmp.Transform()); diff != "" {
+				t.Errorf("resultCell() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnReader(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) float64 {
+		return float64(t.UnixNano()) / float64(time.Millisecond)
+	}
+	tg := &configpb.TestGroup{
+		Name:         "luci",
+		ColumnReader: ReaderName,
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ConfigurationValue: "Commit"},
+			{ConfigurationValue: "os"},
+		},
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_ResultdbConfig{
+				ResultdbConfig: &configpb.ResultDBConfig{
+					Project:     "chromium",
+					Bucket:      "ci",
+					Builder:     "linux-rel",
+					VariantKeys: []string{"test_suite"},
+				},
+			},
+		},
+	}
+	s, server := newServer(t, map[string][]string{
+		searchPath: {`{"builds":[
+			{"id":"30","status":"SCHEDULED"},
+			{"id":"20","number":2,"status":"STARTED","startTime":"2021-06-01T11:00:00Z","tags":[{"key":"os","value":"linux"}]},
+			{"id":"10","number":1,"status":"FAILURE","startTime":"2021-06-01T10:00:00Z","endTime":"2021-06-01T10:30:00Z",
+			 "input":{"gitilesCommit":{"host":"chromium.googlesource.com","project":"chromium/src","id":"abc"}},
+			 "infra":{"resultdb":{"invocation":"invocations/build-10"}}},
+			{"id":"5","number":0,"status":"SUCCESS","startTime":"2021-06-01T09:00:00Z"}
+		]}`},
+		queryPath: {`{"testResults":[
+			{"testId":"foo","status":"PASS","variant":{"def":{"test_suite":"unit","os":"linux"}}},
+			{"testId":"foo","status":"PASS","variant":{"def":{"test_suite":"browser","os":"linux"}}},
+			{"testId":"foo","status":"FAIL","variant":{"def":{"test_suite":"browser","os":"mac"}}}
+		]}`},
+	})
+	defer server.Close()
+	client := &Client{HTTP: server.Client(), BuildbucketURL: server.URL, ResultDBURL: server.URL}
+	oldCols := []updater.InflatedColumn{
+		{Column: &statepb.Column{Build: "5", Hint: "5", Started: millis(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))}},
+	}
+
+	got, err := ColumnReader(client)(context.Background(), logrus.New(), tg, oldCols, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("ColumnReader() got unexpected error: %v", err)
+	}
+	want := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{
+				Build:   "2",
+				Hint:    "20",
+				Started: millis(time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC)),
+				Extra:   []string{"", "linux"},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": {Result: statuspb.TestStatus_RUNNING, Icon: "R", Message: "Build still running..."},
+			},
+		},
+		{
+			Column: &statepb.Column{
+				Build:   "1",
+				Hint:    "10",
+				Started: millis(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)),
+				Extra:   []string{"abc", "missing"},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": {
+					Result:  statuspb.TestStatus_FAIL,
+					Metrics: map[string]float64{updater.ElapsedKey: 30},
+				},
+				"foo [test_suite=unit]": {Result: statuspb.TestStatus_PASS, ID: "foo [test_suite=unit]"},
+				"foo [test_suite=browser]": {
+					Result:  statuspb.TestStatus_FLAKY,
+					ID:      "foo [test_suite=browser]",
+					Icon:    "1/2",
+					Message: "1/2 runs passed",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ColumnReader() got unexpected diff (-want +got):\n%s", diff)
+	}
+	reqs := s.requests[searchPath]
+	if len(reqs) != 1 {
+		t.Fatalf("ColumnReader() searched %d times, want 1", len(reqs))
+	}
+	since := reqs[0]["predicate"].(map[string]interface{})["createTime"].(map[string]interface{})["startTime"]
+	if since != "2021-06-01T09:00:00Z" {
+		t.Errorf("ColumnReader() searched builds since %v, want the newest old column", since)
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// Versions of the row encoding.
+const (
+	// RunLengthVersion run-length encodes results and repeats each cell string.
+	RunLengthVersion int32 = 1
+	// DictionaryVersion delta encodes results and stores each distinct cell
+	// string once per grid.
+	DictionaryVersion int32 = 2
+)
+
+// Encode returns the grid with its rows encoded at the version.
+//
+// Unset and run-length versions return the grid as is. The dictionary version
+// returns a copy that shares everything but the rows with the grid.
+func Encode(grid *statepb.Grid, version int32) (*statepb.Grid, error) {
+	switch version {
+	case 0, RunLengthVersion:
+		return grid, nil
+	case DictionaryVersion:
+	default:
+		return nil, fmt.Errorf("unknown version %d", version)
+	}
+	if grid.Version > RunLengthVersion {
+		return nil, fmt.Errorf("grid already encoded at version %d", grid.Version)
+	}
+	out := statepb.Grid{
+		Columns:                    grid.Columns,
+		LastAlertMailTime:          grid.LastAlertMailTime,
+		Config:                     grid.Config,
+		LastTimeUpdated:            grid.LastTimeUpdated,
+		UpdateInfo:                 grid.UpdateInfo,
+		TestMetadata:               grid.TestMetadata,
+		Cluster:                    grid.Cluster,
+		MostRecentClusterTimestamp: grid.MostRecentClusterTimestamp,
+		ArchivePath:                grid.ArchivePath,
+		ArchiveShards:              grid.ArchiveShards,
+		Version:                    version,
+	}
+	dict := newDictionary()
+	for _, row := range grid.Rows {
+		out.Rows = append(out.Rows, packRow(row, dict))
+	}
+	out.Dictionary = dict.words
+	return &out, nil
+}
+
+// Decode expands the rows of a dictionary encoded grid in place.
+//
+// Grids written at earlier versions are left unchanged.
+func Decode(grid *statepb.Grid) error {
+	switch grid.Version {
+	case 0, RunLengthVersion:
+		return nil
+	case DictionaryVersion:
+	default:
+		return fmt.Errorf("unknown version %d", grid.Version)
+	}
+	for _, row := range grid.Rows {
+		if err := unpackRow(row, grid.Dictionary); err != nil {
+			return fmt.Errorf("row %q: %w", row.Name, err)
+		}
+	}
+	grid.Version = 0
+	grid.Dictionary = nil
+	return nil
+}
+
+// dictionary assigns each distinct string an index, starting with the empty string.
+type dictionary struct {
+	index map[string]int32
+	words []string
+}
+
+func newDictionary() *dictionary {
+	return &dictionary{
+		index: map[string]int32{"": 0},
+		words: []string{""},
+	}
+}
+
+// refs returns the index of each value, adding new values to the dictionary.
+func (d *dictionary) refs(vals []string) []int32 {
+	if len(vals) == 0 {
+		return nil
+	}
+	out := make([]int32, len(vals))
+	for i, v := range vals {
+		idx, ok := d.index[v]
+		if !ok {
+			idx = int32(len(d.words))
+			d.index[v] = idx
+			d.words = append(d.words, v)
+		}
+		out[i] = idx
+	}
+	return out
+}
+
+// lookup returns the value of each index.
+func lookup(refs []int32, words []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	out := make([]string, len(refs))
+	for i, ref := range refs {
+		if ref < 0 || int(ref) >= len(words) {
+			return nil, fmt.Errorf("ref %d beyond dictionary of %d", ref, len(words))
+		}
+		out[i] = words[ref]
+	}
+	return out, nil
+}
+
+// packRow returns a copy of the row with dictionary refs and packed results.
+func packRow(row *statepb.Row, dict *dictionary) *statepb.Row {
+	return &statepb.Row{
+		Name:           row.Name,
+		Id:             row.Id,
+		Metric:         row.Metric,
+		Metrics:        row.Metrics,
+		BugId:          row.BugId,
+		AlertInfo:      row.AlertInfo,
+		LastGreen:      row.LastGreen,
+		FirstSeen:      row.FirstSeen,
+		IsNew:          row.IsNew,
+		Tombstone:      row.Tombstone,
+		UserProperty:   row.UserProperty,
+		Properties:     row.Properties,
+		CellLinks:      row.CellLinks,
+		CellParameters: row.CellParameters,
+		Links:          row.Links,
+		PackedResults:  packResults(row.Results),
+		MessageRefs:    dict.refs(row.Messages),
+		IconRefs:       dict.refs(row.Icons),
+		CellIdRefs:     dict.refs(row.CellIds),
+	}
+}
+
+// unpackRow replaces the dictionary refs and packed results of the row.
+func unpackRow(row *statepb.Row, words []string) error {
+	results, err := unpackResults(row.PackedResults)
+	if err != nil {
+		return fmt.Errorf("results: %w", err)
+	}
+	messages, err := lookup(row.MessageRefs, words)
+	if err != nil {
+		return fmt.Errorf("messages: %w", err)
+	}
+	icons, err := lookup(row.IconRefs, words)
+	if err != nil {
+		return fmt.Errorf("icons: %w", err)
+	}
+	cellIDs, err := lookup(row.CellIdRefs, words)
+	if err != nil {
+		return fmt.Errorf("cell ids: %w", err)
+	}
+	row.Results, row.PackedResults = results, nil
+	row.Messages, row.MessageRefs = messages, nil
+	row.Icons, row.IconRefs = icons, nil
+	row.CellIds, row.CellIdRefs = cellIDs, nil
+	return nil
+}
+
+// packResults encodes each run-length pair as the varint delta from the
+// previous result followed by the uvarint run length.
+//
+// Rows rarely change result, so most pairs fit in two bytes.
+func packResults(results []int32) []byte {
+	if len(results) < 2 {
+		return nil
+	}
+	out := make([]byte, 0, len(results))
+	var buf [binary.MaxVarintLen64]byte
+	var prev int32
+	for i := 0; i+1 < len(results); i += 2 {
+		n := binary.PutVarint(buf[:], int64(results[i]-prev))
+		out = append(out, buf[:n]...)
+		n = binary.PutUvarint(buf[:], uint64(results[i+1]))
+		out = append(out, buf[:n]...)
+		prev = results[i]
+	}
+	return out
+}
+
+var errTruncated = errors.New("truncated")
+
+// unpackResults decodes the run-length pairs written by packResults.
+func unpackResults(buf []byte) ([]int32, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	var out []int32
+	var prev int32
+	for len(buf) > 0 {
+		delta, n := binary.Varint(buf)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+		buf = buf[n:]
+		count, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+		buf = buf[n:]
+		prev += int32(delta)
+		out = append(out, prev, int32(count))
+	}
+	return out, nil
+}
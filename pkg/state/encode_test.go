@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestEncode(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := func() *statepb.Grid {
+		return &statepb.Grid{
+			Columns: []*statepb.Column{{Build: "4"}, {Build: "3"}, {Build: "2"}, {Build: "1"}},
+			Rows: []*statepb.Row{
+				{
+					Name:     "foo",
+					Results:  []int32{fail, 1, empty, 1, pass, 2},
+					Messages: []string{"boom", "", ""},
+					Icons:    []string{"F", "", ""},
+					CellIds:  []string{"4", "2", "1"},
+				},
+				{
+					Name:      "bar",
+					Results:   []int32{fail, 4},
+					Messages:  []string{"boom", "boom", "boom", "boom"},
+					LastGreen: &statepb.LastGreen{BuildId: "0"},
+				},
+			},
+			ArchivePath: "gs://bucket/archive",
+		}
+	}
+	cases := []struct {
+		name    string
+		version int32
+		want    *statepb.Grid
+		err     bool
+	}{
+		{
+			name: "unset version is unchanged",
+			want: grid(),
+		},
+		{
+			name:    "run-length version is unchanged",
+			version: RunLengthVersion,
+			want:    grid(),
+		},
+		{
+			name:    "dictionary version",
+			version: DictionaryVersion,
+			want: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "4"}, {Build: "3"}, {Build: "2"}, {Build: "1"}},
+				Rows: []*statepb.Row{
+					{
+						Name:          "foo",
+						PackedResults: []byte{24, 1, 23, 1, 2, 2},
+						MessageRefs:   []int32{1, 0, 0},
+						IconRefs:      []int32{2, 0, 0},
+						CellIdRefs:    []int32{3, 4, 5},
+					},
+					{
+						Name:          "bar",
+						PackedResults: []byte{24, 4},
+						MessageRefs:   []int32{1, 1, 1, 1},
+						LastGreen:     &statepb.LastGreen{BuildId: "0"},
+					},
+				},
+				ArchivePath: "gs://bucket/archive",
+				Version:     DictionaryVersion,
+				Dictionary:  []string{"", "boom", "F", "4", "2", "1"},
+			},
+		},
+		{
+			name:    "unknown version",
+			version: 3,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := grid()
+			got, err := Encode(orig, tc.version)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Fatalf("Encode() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("Encode() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Encode() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(grid(), orig, protocmp.Transform()); diff != "" {
+				t.Errorf("Encode() modified the grid (-want +got):\n%s", diff)
+			}
+			if _, err := Encode(got, tc.version); tc.version == DictionaryVersion && err == nil {
+				t.Error("Encode() failed to reject an encoded grid")
+			}
+			if err := Decode(got); err != nil {
+				t.Fatalf("Decode() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(grid(), got, protocmp.Transform()); diff != "" {
+				t.Errorf("Decode(Encode()) got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+	}{
+		{
+			name: "unknown version",
+			grid: &statepb.Grid{Version: 3},
+		},
+		{
+			name: "truncated results",
+			grid: &statepb.Grid{
+				Version: DictionaryVersion,
+				Rows:    []*statepb.Row{{Name: "foo", PackedResults: []byte{4}}},
+			},
+		},
+		{
+			name: "ref beyond dictionary",
+			grid: &statepb.Grid{
+				Version:    DictionaryVersion,
+				Dictionary: []string{""},
+				Rows:       []*statepb.Row{{Name: "foo", MessageRefs: []int32{1}}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Decode(tc.grid); err == nil {
+				t.Error("Decode() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestReadEncoded(t *testing.T) {
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{
+				Name:     "foo",
+				Results:  []int32{int32(statuspb.TestStatus_PASS), 2},
+				Messages: []string{"ok", "ok"},
+			},
+		},
+	}
+	encoded, err := Encode(grid, DictionaryVersion)
+	if err != nil {
+		t.Fatalf("Encode() got unexpected error: %v", err)
+	}
+	buf, err := Marshal(encoded)
+	if err != nil {
+		t.Fatalf("Marshal() got unexpected error: %v", err)
+	}
+	got, err := Unmarshal(buf)
+	if err != nil {
+		t.Fatalf("Unmarshal() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(grid, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Unmarshal() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+// longGrid returns a grid of long rows that mostly repeat the same few results and messages.
+func longGrid(rows, cols int) *statepb.Grid {
+	var grid statepb.Grid
+	for c := 0; c < cols; c++ {
+		grid.Columns = append(grid.Columns, &statepb.Column{Build: fmt.Sprint(cols - c)})
+	}
+	for r := 0; r < rows; r++ {
+		row := statepb.Row{Name: fmt.Sprintf("//pkg/test:case_%d", r)}
+		for c := 0; c < cols; c++ {
+			result := statuspb.TestStatus_PASS
+			msg := "passed in 1.2s"
+			if (r+c)%17 == 0 {
+				result = statuspb.TestStatus_FAIL
+				msg = fmt.Sprintf("timed out after %dm: context deadline exceeded", 10+r%3)
+			}
+			row.Results = append(row.Results, int32(result), 1)
+			row.Messages = append(row.Messages, msg)
+			row.CellIds = append(row.CellIds, fmt.Sprint(cols-c))
+		}
+		grid.Rows = append(grid.Rows, &row)
+	}
+	return &grid
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	grid := longGrid(200, 500)
+	for _, version := range []int32{RunLengthVersion, DictionaryVersion} {
+		b.Run(fmt.Sprintf("version %d", version), func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				encoded, err := Encode(grid, version)
+				if err != nil {
+					b.Fatalf("Encode() got unexpected error: %v", err)
+				}
+				buf, err := Marshal(encoded)
+				if err != nil {
+					b.Fatalf("Marshal() got unexpected error: %v", err)
+				}
+				size = len(buf)
+			}
+			b.ReportMetric(float64(size), "bytes")
+		})
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	grid := longGrid(200, 500)
+	for _, version := range []int32{RunLengthVersion, DictionaryVersion} {
+		b.Run(fmt.Sprintf("version %d", version), func(b *testing.B) {
+			encoded, err := Encode(grid, version)
+			if err != nil {
+				b.Fatalf("Encode() got unexpected error: %v", err)
+			}
+			buf, err := Marshal(encoded)
+			if err != nil {
+				b.Fatalf("Marshal() got unexpected error: %v", err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := Unmarshal(buf); err != nil {
+					b.Fatalf("Unmarshal() got unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
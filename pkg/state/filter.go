@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// Base option keys which filter the rows of a tab.
+const (
+	IncludeFilter         = "include-filter-by-regex"
+	ExcludeFilter         = "exclude-filter-by-regex"
+	IncludePropertyFilter = "include-filter-by-property"
+	ExcludePropertyFilter = "exclude-filter-by-property"
+	// TODO(fejta): others, which are not used by testgrid.k8s.io
+)
+
+// Filter returns the subset of rows matching the filters in the tab's base options.
+func Filter(rows []*statepb.Row, baseOptions string) ([]*statepb.Row, error) {
+	vals, err := url.ParseQuery(baseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %v", baseOptions, err)
+	}
+
+	for _, include := range vals[IncludeFilter] {
+		if rows, err = IncludeRows(rows, include); err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", IncludeFilter, include, err)
+		}
+	}
+
+	for _, exclude := range vals[ExcludeFilter] {
+		if rows, err = ExcludeRows(rows, exclude); err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", ExcludeFilter, exclude, err)
+		}
+	}
+
+	for _, include := range vals[IncludePropertyFilter] {
+		if rows, err = FilterRowsByProperty(rows, include, true); err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", IncludePropertyFilter, include, err)
+		}
+	}
+
+	for _, exclude := range vals[ExcludePropertyFilter] {
+		if rows, err = FilterRowsByProperty(rows, exclude, false); err != nil {
+			return nil, fmt.Errorf("bad %s=%s: %v", ExcludePropertyFilter, exclude, err)
+		}
+	}
+	return rows, nil
+}
+
+// IncludeRows returns the subset of rows that match the regex
+func IncludeRows(in []*statepb.Row, include string) ([]*statepb.Row, error) {
+	re, err := regexp.Compile(include)
+	if err != nil {
+		return nil, err
+	}
+	var rows []*statepb.Row
+	for _, r := range in {
+		if !re.MatchString(r.Name) {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// ExcludeRows returns the subset of rows that do not match the regex
+func ExcludeRows(in []*statepb.Row, exclude string) ([]*statepb.Row, error) {
+	re, err := regexp.Compile(exclude)
+	if err != nil {
+		return nil, err
+	}
+	var rows []*statepb.Row
+	for _, r := range in {
+		if re.MatchString(r.Name) {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// FilterRowsByProperty returns the subset of rows whose latest value of a
+// cell property matches (or does not match) a regex.
+//
+// The filter has the form <property>:<regex>.
+func FilterRowsByProperty(in []*statepb.Row, filter string, include bool) ([]*statepb.Row, error) {
+	parts := strings.SplitN(filter, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("want <property>:<regex>")
+	}
+	name := parts[0]
+	re, err := regexp.Compile(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	var rows []*statepb.Row
+	for _, r := range in {
+		if re.MatchString(LatestProperty(r, name)) != include {
+			continue
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// LatestProperty returns the most recent non-empty value of the named property.
+func LatestProperty(row *statepb.Row, name string) string {
+	for _, prop := range row.Properties {
+		if prop.Name != name {
+			continue
+		}
+		for _, v := range prop.Values {
+			if v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
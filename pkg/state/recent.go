@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// RecentColumns returns a copy of the grid with only the first n columns.
+func RecentColumns(grid *statepb.Grid, n int) *statepb.Grid {
+	return RecentRows(grid, n, 0, len(grid.Rows))
+}
+
+// RecentRows returns a copy of the grid with only the first n columns of the rows
+// from start up to (but not including) end.
+//
+// Only the selected rows are copied, so callers may page through large grids.
+func RecentRows(grid *statepb.Grid, n, start, end int) *statepb.Grid {
+	if n > len(grid.Columns) {
+		n = len(grid.Columns)
+	}
+	if end > len(grid.Rows) {
+		end = len(grid.Rows)
+	}
+	if start < 0 {
+		start = 0
+	}
+	out := statepb.Grid{
+		Columns:         grid.Columns[:n],
+		LastTimeUpdated: grid.LastTimeUpdated,
+		ArchivePath:     grid.ArchivePath,
+		ArchiveShards:   grid.ArchiveShards,
+	}
+	for i := start; i < end; i++ {
+		out.Rows = append(out.Rows, recentRow(grid.Rows[i], n))
+	}
+	return &out
+}
+
+// recentRow returns a copy of the row with only the first n columns.
+func recentRow(row *statepb.Row, n int) *statepb.Row {
+	out := statepb.Row{
+		Name:      row.Name,
+		Id:        row.Id,
+		Metric:    row.Metric,
+		BugId:     row.BugId,
+		AlertInfo: row.AlertInfo,
+		LastGreen: row.LastGreen,
+		FirstSeen: row.FirstSeen,
+		IsNew:     row.IsNew,
+		Tombstone: row.Tombstone,
+		Links:     row.Links,
+	}
+
+	var col, filled int
+	for i := 0; i+1 < len(row.Results) && col < n; i += 2 {
+		val, count := row.Results[i], int(row.Results[i+1])
+		if col+count > n {
+			count = n - col
+		}
+		out.Results = append(out.Results, val, int32(count))
+		col += count
+		if statuspb.TestStatus(val) != statuspb.TestStatus_NO_RESULT {
+			filled += count
+		}
+	}
+
+	first := func(vals []string) []string {
+		if len(vals) > filled {
+			return vals[:filled]
+		}
+		return vals
+	}
+	out.Messages = first(row.Messages)
+	out.Icons = first(row.Icons)
+	out.CellIds = first(row.CellIds)
+	out.UserProperty = first(row.UserProperty)
+	if len(row.CellLinks) > filled {
+		out.CellLinks = row.CellLinks[:filled]
+	} else {
+		out.CellLinks = row.CellLinks
+	}
+	if len(row.CellParameters) > filled {
+		out.CellParameters = row.CellParameters[:filled]
+	} else {
+		out.CellParameters = row.CellParameters
+	}
+	for _, prop := range row.Properties {
+		out.Properties = append(out.Properties, &statepb.PropertyValues{
+			Name:   prop.Name,
+			Values: first(prop.Values),
+		})
+	}
+	for _, m := range row.Metrics {
+		out.Metrics = append(out.Metrics, recentMetric(m, n))
+	}
+	return &out
+}
+
+// recentMetric returns a copy of the sparse metric with only the first n columns.
+func recentMetric(m *statepb.Metric, n int) *statepb.Metric {
+	out := statepb.Metric{Name: m.Name}
+	var valueIdx int
+	for i := 0; i+1 < len(m.Indices); i += 2 {
+		start, count := m.Indices[i], m.Indices[i+1]
+		if int(start) >= n {
+			break
+		}
+		if int(start+count) > n {
+			count = int32(n) - start
+		}
+		out.Indices = append(out.Indices, start, count)
+		out.Values = append(out.Values, m.Values[valueIdx:valueIdx+int(count)]...)
+		valueIdx += int(m.Indices[i+1])
+	}
+	return &out
+}
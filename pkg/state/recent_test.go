@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestRecentColumns(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "4"}, {Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name:     "foo",
+				Results:  []int32{fail, 1, empty, 1, pass, 2},
+				Messages: []string{"boom", "", ""},
+				Icons:    []string{"F", "", ""},
+				Properties: []*statepb.PropertyValues{
+					{Name: "owner", Values: []string{"a", "b", "c"}},
+				},
+				Metric: []string{"elapsed"},
+				Metrics: []*statepb.Metric{
+					{Name: "elapsed", Indices: []int32{0, 1, 2, 2}, Values: []float64{1, 3, 4}},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		n    int
+		want *statepb.Grid
+	}{
+		{
+			name: "zero columns",
+			want: &statepb.Grid{
+				Columns: []*statepb.Column{},
+				Rows: []*statepb.Row{
+					{
+						Name:       "foo",
+						Messages:   []string{},
+						Icons:      []string{},
+						Properties: []*statepb.PropertyValues{{Name: "owner", Values: []string{}}},
+						Metric:     []string{"elapsed"},
+						Metrics:    []*statepb.Metric{{Name: "elapsed"}},
+					},
+				},
+			},
+		},
+		{
+			name: "split a run",
+			n:    3,
+			want: &statepb.Grid{
+				Columns: []*statepb.Column{{Build: "4"}, {Build: "3"}, {Build: "2"}},
+				Rows: []*statepb.Row{
+					{
+						Name:     "foo",
+						Results:  []int32{fail, 1, empty, 1, pass, 1},
+						Messages: []string{"boom", ""},
+						Icons:    []string{"F", ""},
+						Properties: []*statepb.PropertyValues{
+							{Name: "owner", Values: []string{"a", "b"}},
+						},
+						Metric: []string{"elapsed"},
+						Metrics: []*statepb.Metric{
+							{Name: "elapsed", Indices: []int32{0, 1, 2, 1}, Values: []float64{1, 3}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "more than available",
+			n:    10,
+			want: grid,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RecentColumns(grid, tc.n)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("RecentColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecentRows(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	row := func(name string) *statepb.Row {
+		return &statepb.Row{Name: name, Results: []int32{pass, 3}, Messages: []string{"", "", ""}}
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows:    []*statepb.Row{row("a"), row("b"), row("c"), row("d")},
+	}
+	cases := []struct {
+		name       string
+		start, end int
+		want       []string
+	}{
+		{
+			name: "empty range",
+		},
+		{
+			name:  "middle rows",
+			start: 1,
+			end:   3,
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "clamp to the rows",
+			start: -1,
+			end:   10,
+			want:  []string{"a", "b", "c", "d"},
+		},
+		{
+			name:  "start beyond the rows",
+			start: 5,
+			end:   10,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RecentRows(grid, 2, tc.start, tc.end)
+			var names []string
+			for _, r := range got.Rows {
+				names = append(names, r.Name)
+				if want := []int32{pass, 2}; !cmp.Equal(want, r.Results) {
+					t.Errorf("RecentRows() row %s got results %v, want %v", r.Name, r.Results, want)
+				}
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Errorf("RecentRows() got unexpected rows (-want +got):\n%s", diff)
+			}
+			if len(got.Columns) != 2 {
+				t.Errorf("RecentRows() got %d columns, want 2", len(got.Columns))
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// Expand decodes the run-length-encoded results into one result per column.
+func Expand(results []int32) []statuspb.TestStatus {
+	var n int
+	for i := 1; i < len(results); i += 2 {
+		if results[i] > 0 {
+			n += int(results[i])
+		}
+	}
+	out := make([]statuspb.TestStatus, 0, n)
+	for i := 0; i+1 < len(results); i += 2 {
+		result := statuspb.TestStatus(results[i])
+		for count := results[i+1]; count > 0; count-- {
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// Cell is the expanded result of a row in a column.
+type Cell struct {
+	Result  statuspb.TestStatus
+	Message string
+	Icon    string
+	CellID  string
+}
+
+// Cells expands the row into one cell per column.
+//
+// Messages, icons and cell IDs are only stored for filled cells,
+// so these are empty for NO_RESULT cells.
+func Cells(row *statepb.Row) []Cell {
+	results := Expand(row.Results)
+	out := make([]Cell, len(results))
+	var filled int
+	for i, result := range results {
+		out[i].Result = result
+		if result == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		if filled < len(row.Messages) {
+			out[i].Message = row.Messages[filled]
+		}
+		if filled < len(row.Icons) {
+			out[i].Icon = row.Icons[filled]
+		}
+		if filled < len(row.CellIds) {
+			out[i].CellID = row.CellIds[filled]
+		}
+		filled++
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestExpand(t *testing.T) {
+	pass := statuspb.TestStatus_PASS
+	fail := statuspb.TestStatus_FAIL
+	cases := []struct {
+		name    string
+		results []int32
+		want    []statuspb.TestStatus
+	}{
+		{
+			name: "basically works",
+			want: []statuspb.TestStatus{},
+		},
+		{
+			name:    "expand runs",
+			results: []int32{int32(pass), 2, int32(fail), 1},
+			want:    []statuspb.TestStatus{pass, pass, fail},
+		},
+		{
+			name:    "ignore a trailing value",
+			results: []int32{int32(fail), 1, int32(pass)},
+			want:    []statuspb.TestStatus{fail},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Expand(tc.results)); diff != "" {
+				t.Errorf("Expand() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCells(t *testing.T) {
+	row := &statepb.Row{
+		Results: []int32{
+			int32(statuspb.TestStatus_FAIL), 1,
+			int32(statuspb.TestStatus_NO_RESULT), 2,
+			int32(statuspb.TestStatus_PASS), 1,
+		},
+		Messages: []string{"boom", "ok"},
+		Icons:    []string{"F"},
+		CellIds:  []string{"3", "1"},
+	}
+	want := []Cell{
+		{Result: statuspb.TestStatus_FAIL, Message: "boom", Icon: "F", CellID: "3"},
+		{Result: statuspb.TestStatus_NO_RESULT},
+		{Result: statuspb.TestStatus_NO_RESULT},
+		{Result: statuspb.TestStatus_PASS, Message: "ok", CellID: "1"},
+	}
+	if diff := cmp.Diff(want, Cells(row)); diff != "" {
+		t.Errorf("Cells() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package state decodes, expands and filters test group and tab states.
+//
+// It only depends on the state protos and the standard library so that it
+// also compiles under GOOS=js GOARCH=wasm, which lets browser frontends
+// decode grids client side. Keep storage and RPC dependencies out of it.
+package state
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// Read decompresses and deserializes the grid written by Marshal,
+// decoding rows written at later versions.
+func Read(r io.Reader) (*statepb.Grid, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open zlib: %w", err)
+	}
+	defer zr.Close()
+	buf, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	var grid statepb.Grid
+	if err := proto.Unmarshal(buf, &grid); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	if err := Decode(&grid); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return &grid, nil
+}
+
+// Unmarshal decompresses and deserializes the grid in buf.
+func Unmarshal(buf []byte) (*statepb.Grid, error) {
+	return Read(bytes.NewReader(buf))
+}
+
+// MarshalDeterministic serializes the message with map entries in key order,
+// so that equal messages always serialize to the same bytes.
+func MarshalDeterministic(m proto.Message) ([]byte, error) {
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(m); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Marshal serializes and compresses a grid for Read to decode.
+//
+// Equal grids always marshal to the same bytes.
+func Marshal(grid *statepb.Grid) ([]byte, error) {
+	buf, err := MarshalDeterministic(grid)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	if _, err = zw.Write(buf); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+	if err = zw.Close(); err != nil {
+		return nil, fmt.Errorf("close: %w", err)
+	}
+	return zbuf.Bytes(), nil
+}
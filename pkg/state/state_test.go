@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestMarshal(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name:     "foo",
+				Results:  []int32{int32(statuspb.TestStatus_PASS), 2},
+				Messages: []string{"", ""},
+			},
+		},
+	}
+	buf, err := Marshal(grid)
+	if err != nil {
+		t.Fatalf("Marshal() got unexpected error: %v", err)
+	}
+	got, err := Unmarshal(buf)
+	if err != nil {
+		t.Fatalf("Unmarshal() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(grid, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Unmarshal(Marshal()) got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarshalIsDeterministic(t *testing.T) {
+	metadata := map[string]string{}
+	for i := 0; i < 20; i++ {
+		metadata[fmt.Sprintf("key-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1", Metadata: metadata}},
+		Rows: []*statepb.Row{
+			{
+				Name:      "foo",
+				Results:   []int32{int32(statuspb.TestStatus_PASS), 1},
+				Messages:  []string{""},
+				AlertInfo: &statepb.AlertInfo{Properties: map[string]string{"a": "1", "b": "2", "c": "3"}},
+			},
+		},
+	}
+	want, err := Marshal(grid)
+	if err != nil {
+		t.Fatalf("Marshal() got unexpected error: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := Marshal(grid)
+		if err != nil {
+			t.Fatalf("Marshal() got unexpected error: %v", err)
+		}
+		if !bytes.Equal(want, got) {
+			t.Fatalf("Marshal() got different bytes for the same grid")
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	cases := []struct {
+		name string
+		buf  []byte
+	}{
+		{
+			name: "not compressed",
+			buf:  []byte("hello"),
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Read(bytes.NewReader(tc.buf)); err == nil {
+				t.Error("Read() failed to return an error")
+			}
+		})
+	}
+}
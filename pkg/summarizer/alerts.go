@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/api/googleapi"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// alertID returns a stable identifier for a failing test.
+//
+// The ID remains the same across cycles until the test passes and fails again,
+// at which point the first failing build (and thus the ID) changes.
+func alertID(dashboard, tab string, fts *summarypb.FailingTestSummary) string {
+	return digest(dashboard, tab, fts.TestName, fts.FailBuildId)[:16]
+}
+
+// alertFingerprint identifies the observable state of an alert.
+//
+// Notifications are suppressed while the fingerprint remains unchanged.
+func alertFingerprint(fts *summarypb.FailingTestSummary) string {
+	return digest(fts.AlertId, fts.FailureMessage)
+}
+
+func digest(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// assignAlertIDs sets the alert ID of every failing test in the tab summary.
+func assignAlertIDs(sum *summarypb.DashboardTabSummary) {
+	for _, fts := range sum.FailingTestSummaries {
+		fts.AlertId = alertID(sum.DashboardName, sum.DashboardTabName, fts)
+	}
+}
+
+// carryAlertStates copies the lifecycle state of alerts in the previous summary
+// into matching alerts of the current one.
+//
+// Expired snoozes are dropped, as are acknowledgements of alerts whose
+// fingerprint changed since they were acknowledged.
+func carryAlertStates(prev, cur *summarypb.DashboardSummary, now time.Time) {
+	if prev == nil || cur == nil {
+		return
+	}
+	states := map[string]*summarypb.AlertState{}
+	for _, tab := range prev.TabSummaries {
+		for _, fts := range tab.FailingTestSummaries {
+			if fts.AlertId == "" || fts.AlertState == nil {
+				continue
+			}
+			states[fts.AlertId] = fts.AlertState
+		}
+	}
+	for _, tab := range cur.TabSummaries {
+		for _, fts := range tab.FailingTestSummaries {
+			state, ok := states[fts.AlertId]
+			if !ok {
+				continue
+			}
+			if state.SnoozeUntil != nil && !now.Before(fromTimestamp(state.SnoozeUntil)) {
+				state.SnoozeUntil = nil
+			}
+			if state.AcknowledgedBy != "" && state.NotifiedFingerprint != alertFingerprint(fts) {
+				state.AcknowledgedBy = ""
+				state.AcknowledgeTime = nil
+			}
+			fts.AlertState = state
+		}
+	}
+}
+
+// alertHistoryDays is how long resolved alert episodes are kept.
+const alertHistoryDays = 400
+
+// recordAlertHistory carries the alert history of each tab from the previous
+// summary into the current one.
+//
+// An episode opens when a tab starts failing or breaks and resolves once it
+// passes again. Other statuses, such as stale or unknown, leave the history unchanged.
+func recordAlertHistory(prev, cur *summarypb.DashboardSummary, now time.Time) {
+	if cur == nil {
+		return
+	}
+	history := map[string][]*summarypb.AlertEpisode{}
+	for _, tab := range prev.GetTabSummaries() {
+		history[tab.DashboardTabName] = tab.AlertHistory
+	}
+	seconds := float64(now.Unix())
+	cutoff := float64(now.AddDate(0, 0, -alertHistoryDays).Unix())
+	for _, tab := range cur.TabSummaries {
+		var episodes []*summarypb.AlertEpisode
+		for _, e := range history[tab.DashboardTabName] {
+			if e.Resolved != 0 && e.Resolved < cutoff {
+				continue
+			}
+			episodes = append(episodes, &summarypb.AlertEpisode{Opened: e.Opened, Resolved: e.Resolved})
+		}
+		var open *summarypb.AlertEpisode
+		if n := len(episodes); n > 0 && episodes[n-1].Resolved == 0 {
+			open = episodes[n-1]
+		}
+		switch tab.OverallStatus {
+		case summarypb.DashboardTabSummary_FAIL, summarypb.DashboardTabSummary_BROKEN:
+			if open == nil {
+				episodes = append(episodes, &summarypb.AlertEpisode{Opened: seconds})
+			}
+		case summarypb.DashboardTabSummary_PASS, summarypb.DashboardTabSummary_FLAKY:
+			if open != nil {
+				open.Resolved = seconds
+			}
+		}
+		tab.AlertHistory = episodes
+	}
+}
+
+// countFailingCycles counts the consecutive summaries in which each tab failed
+// or broke, and forgets the escalation steps notified about tabs which stopped.
+func countFailingCycles(prev, cur *summarypb.DashboardSummary) {
+	prevTabs := map[string]*summarypb.DashboardTabSummary{}
+	for _, tab := range prev.GetTabSummaries() {
+		prevTabs[tab.DashboardTabName] = tab
+	}
+	for _, tab := range cur.GetTabSummaries() {
+		switch tab.OverallStatus {
+		case summarypb.DashboardTabSummary_FAIL, summarypb.DashboardTabSummary_BROKEN:
+			p := prevTabs[tab.DashboardTabName]
+			tab.FailingCycles = p.GetFailingCycles() + 1
+			tab.NotifiedSteps = p.GetNotifiedSteps()
+		default:
+			tab.FailingCycles = 0
+			tab.NotifiedSteps = nil
+		}
+	}
+}
+
+// Snoozed returns true while notifications about the alert are snoozed.
+func Snoozed(fts *summarypb.FailingTestSummary, now time.Time) bool {
+	until := fts.GetAlertState().GetSnoozeUntil()
+	return until != nil && now.Before(fromTimestamp(until))
+}
+
+// ShouldNotify returns true when a notification should be sent for the alert.
+//
+// Snoozed alerts are never notified. Otherwise the alert is notified unless
+// it has already been notified (or acknowledged) in its current state.
+func ShouldNotify(fts *summarypb.FailingTestSummary, now time.Time) bool {
+	if Snoozed(fts, now) {
+		return false
+	}
+	return fts.GetAlertState().GetNotifiedFingerprint() != alertFingerprint(fts)
+}
+
+// MarkNotified records that a notification was sent for the alert in its current state.
+func MarkNotified(fts *summarypb.FailingTestSummary, now time.Time) {
+	if fts.AlertState == nil {
+		fts.AlertState = &summarypb.AlertState{}
+	}
+	fts.AlertState.NotifiedFingerprint = alertFingerprint(fts)
+	fts.AlertState.NotifyTime = toTimestamp(now)
+}
+
+// ErrAlertNotFound means the summary has no alert with the ID.
+var ErrAlertNotFound = errors.New("alert not found")
+
+// SnoozeAlert suppresses notifications for the identified alert until the specified time.
+func SnoozeAlert(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, alertID string, until time.Time) error {
+	return updateAlertState(ctx, client, path, alertID, func(fts *summarypb.FailingTestSummary) {
+		fts.AlertState.SnoozeUntil = toTimestamp(until)
+	})
+}
+
+// AcknowledgeAlert suppresses notifications for the identified alert until it changes.
+func AcknowledgeAlert(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, alertID, who string, when time.Time) error {
+	return updateAlertState(ctx, client, path, alertID, func(fts *summarypb.FailingTestSummary) {
+		fts.AlertState.AcknowledgedBy = who
+		fts.AlertState.AcknowledgeTime = toTimestamp(when)
+		fts.AlertState.NotifiedFingerprint = alertFingerprint(fts)
+	})
+}
+
+// updateAlertState atomically modifies the state of an alert in the summary at path.
+func updateAlertState(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, alertID string, modify func(*summarypb.FailingTestSummary)) error {
+	attrs, err := client.Stat(ctx, path)
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+	sum, err := ReadSummary(ctx, client, path)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	var found bool
+	for _, tab := range sum.TabSummaries {
+		for _, fts := range tab.FailingTestSummaries {
+			if fts.AlertId != alertID {
+				continue
+			}
+			if fts.AlertState == nil {
+				fts.AlertState = &summarypb.AlertState{}
+			}
+			modify(fts)
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %q in %s", ErrAlertNotFound, alertID, path)
+	}
+	cond := storage.Conditions{GenerationMatch: attrs.Generation}
+	if err := writeSummary(ctx, client.If(&cond, &cond), path, sum); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
+
+// readSummaryGeneration reads the summary at the path along with its generation.
+func readSummaryGeneration(ctx context.Context, client gcs.ConditionalClient, path gcs.Path) (*summarypb.DashboardSummary, int64, error) {
+	attrs, err := client.Stat(ctx, path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("stat: %w", err)
+	}
+	sum, err := ReadSummary(ctx, client, path)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sum, attrs.Generation, nil
+}
+
+// writeAlertedSummary writes the summary unless the generation of the
+// previous summary changed since it was read.
+//
+// Snoozes and acknowledgements may change the alert states of the summary in
+// the meantime. The summary then carries the new states forward and retries.
+func writeAlertedSummary(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, sum *summarypb.DashboardSummary, generation int64, now time.Time) error {
+	const attempts = 3
+	for i := 0; ; i++ {
+		cond := storage.Conditions{GenerationMatch: generation}
+		if generation == 0 {
+			cond = storage.Conditions{DoesNotExist: true}
+		}
+		err := writeSummary(ctx, client.If(&cond, &cond), path, sum)
+		var apiErr *googleapi.Error
+		if err == nil || !errors.As(err, &apiErr) || apiErr.Code != http.StatusPreconditionFailed || i+1 == attempts {
+			return err
+		}
+		prev, gen, err := readSummaryGeneration(ctx, client, path)
+		if err != nil {
+			return fmt.Errorf("read changed summary: %w", err)
+		}
+		carryAlertStates(prev, sum, now)
+		generation = gen
+	}
+}
+
+func toTimestamp(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{
+		Seconds: t.Unix(),
+		Nanos:   int32(t.Nanosecond()),
+	}
+}
+
+func fromTimestamp(ts *timestamp.Timestamp) time.Time {
+	return time.Unix(ts.Seconds, int64(ts.Nanos))
+}
+
+// graceNewTests drops the alerts of rows first seen after since.
+func graceNewTests(rows []*statepb.Row, since time.Time) {
+	for _, row := range rows {
+		if first := row.FirstSeen; first != nil && first.AsTime().After(since) {
+			row.AlertInfo = nil
+		}
+	}
+}
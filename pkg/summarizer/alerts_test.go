@@ -0,0 +1,406 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestAssignAlertIDs(t *testing.T) {
+	first := &summarypb.FailingTestSummary{TestName: "foo", FailBuildId: "1"}
+	same := &summarypb.FailingTestSummary{TestName: "foo", FailBuildId: "1", FailCount: 7}
+	refailed := &summarypb.FailingTestSummary{TestName: "foo", FailBuildId: "9"}
+	other := &summarypb.FailingTestSummary{TestName: "bar", FailBuildId: "1"}
+
+	assignAlertIDs(&summarypb.DashboardTabSummary{
+		DashboardName:        "dash",
+		DashboardTabName:     "tab",
+		FailingTestSummaries: []*summarypb.FailingTestSummary{first, same, refailed, other},
+	})
+
+	if first.AlertId == "" {
+		t.Fatalf("assignAlertIDs() did not assign an ID")
+	}
+	if first.AlertId != same.AlertId {
+		t.Errorf("same failure got different IDs: %q != %q", first.AlertId, same.AlertId)
+	}
+	if first.AlertId == refailed.AlertId {
+		t.Errorf("new failure reused ID %q", first.AlertId)
+	}
+	if first.AlertId == other.AlertId {
+		t.Errorf("different test reused ID %q", first.AlertId)
+	}
+}
+
+func TestCarryAlertStates(t *testing.T) {
+	now := time.Unix(1000, 0)
+	failing := func(id, msg string, state *summarypb.AlertState) *summarypb.DashboardSummary {
+		return &summarypb.DashboardSummary{
+			TabSummaries: []*summarypb.DashboardTabSummary{
+				{
+					FailingTestSummaries: []*summarypb.FailingTestSummary{
+						{
+							AlertId:        id,
+							FailureMessage: msg,
+							AlertState:     state,
+						},
+					},
+				},
+			},
+		}
+	}
+	fingerprint := alertFingerprint(&summarypb.FailingTestSummary{AlertId: "a", FailureMessage: "boom"})
+
+	cases := []struct {
+		name string
+		prev *summarypb.DashboardSummary
+		cur  *summarypb.DashboardSummary
+		want *summarypb.DashboardSummary
+	}{
+		{
+			name: "no previous summary",
+			cur:  failing("a", "boom", nil),
+			want: failing("a", "boom", nil),
+		},
+		{
+			name: "carry snooze",
+			prev: failing("a", "boom", &summarypb.AlertState{SnoozeUntil: toTimestamp(now.Add(time.Hour))}),
+			cur:  failing("a", "boom", nil),
+			want: failing("a", "boom", &summarypb.AlertState{SnoozeUntil: toTimestamp(now.Add(time.Hour))}),
+		},
+		{
+			name: "drop expired snooze",
+			prev: failing("a", "boom", &summarypb.AlertState{SnoozeUntil: toTimestamp(now)}),
+			cur:  failing("a", "boom", nil),
+			want: failing("a", "boom", &summarypb.AlertState{}),
+		},
+		{
+			name: "ignore other alerts",
+			prev: failing("b", "boom", &summarypb.AlertState{AcknowledgedBy: "fejta"}),
+			cur:  failing("a", "boom", nil),
+			want: failing("a", "boom", nil),
+		},
+		{
+			name: "keep acknowledgement of unchanged alert",
+			prev: failing("a", "boom", &summarypb.AlertState{
+				AcknowledgedBy:      "fejta",
+				NotifiedFingerprint: fingerprint,
+			}),
+			cur: failing("a", "boom", nil),
+			want: failing("a", "boom", &summarypb.AlertState{
+				AcknowledgedBy:      "fejta",
+				NotifiedFingerprint: fingerprint,
+			}),
+		},
+		{
+			name: "drop acknowledgement of changed alert",
+			prev: failing("a", "boom", &summarypb.AlertState{
+				AcknowledgedBy:      "fejta",
+				AcknowledgeTime:     toTimestamp(now),
+				NotifiedFingerprint: fingerprint,
+			}),
+			cur: failing("a", "bang", nil),
+			want: failing("a", "bang", &summarypb.AlertState{
+				NotifiedFingerprint: fingerprint,
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			carryAlertStates(tc.prev, tc.cur, now)
+			if diff := cmp.Diff(tc.want, tc.cur, protocmp.Transform()); diff != "" {
+				t.Errorf("carryAlertStates() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordAlertHistory(t *testing.T) {
+	now := time.Unix(100000000, 0)
+	seconds := float64(now.Unix())
+	ancient := float64(now.AddDate(-2, 0, 0).Unix())
+	tab := func(status summarypb.DashboardTabSummary_TabStatus, history ...*summarypb.AlertEpisode) *summarypb.DashboardSummary {
+		return &summarypb.DashboardSummary{
+			TabSummaries: []*summarypb.DashboardTabSummary{
+				{DashboardTabName: "tab", OverallStatus: status, AlertHistory: history},
+			},
+		}
+	}
+	cases := []struct {
+		name string
+		prev *summarypb.DashboardSummary
+		cur  *summarypb.DashboardSummary
+		want *summarypb.DashboardSummary
+	}{
+		{
+			name: "passing tabs have no history",
+			cur:  tab(summarypb.DashboardTabSummary_PASS),
+			want: tab(summarypb.DashboardTabSummary_PASS),
+		},
+		{
+			name: "open an alert",
+			prev: tab(summarypb.DashboardTabSummary_PASS, &summarypb.AlertEpisode{Opened: seconds - 100, Resolved: seconds - 50}),
+			cur:  tab(summarypb.DashboardTabSummary_FAIL),
+			want: tab(summarypb.DashboardTabSummary_FAIL,
+				&summarypb.AlertEpisode{Opened: seconds - 100, Resolved: seconds - 50},
+				&summarypb.AlertEpisode{Opened: seconds},
+			),
+		},
+		{
+			name: "keep failing",
+			prev: tab(summarypb.DashboardTabSummary_FAIL, &summarypb.AlertEpisode{Opened: seconds - 100}),
+			cur:  tab(summarypb.DashboardTabSummary_BROKEN),
+			want: tab(summarypb.DashboardTabSummary_BROKEN, &summarypb.AlertEpisode{Opened: seconds - 100}),
+		},
+		{
+			name: "stale tabs leave alerts open",
+			prev: tab(summarypb.DashboardTabSummary_FAIL, &summarypb.AlertEpisode{Opened: seconds - 100}),
+			cur:  tab(summarypb.DashboardTabSummary_STALE),
+			want: tab(summarypb.DashboardTabSummary_STALE, &summarypb.AlertEpisode{Opened: seconds - 100}),
+		},
+		{
+			name: "resolve an alert",
+			prev: tab(summarypb.DashboardTabSummary_FAIL, &summarypb.AlertEpisode{Opened: seconds - 100}),
+			cur:  tab(summarypb.DashboardTabSummary_FLAKY),
+			want: tab(summarypb.DashboardTabSummary_FLAKY, &summarypb.AlertEpisode{Opened: seconds - 100, Resolved: seconds}),
+		},
+		{
+			name: "drop old alerts",
+			prev: tab(summarypb.DashboardTabSummary_PASS,
+				&summarypb.AlertEpisode{Opened: ancient, Resolved: ancient + 60},
+				&summarypb.AlertEpisode{Opened: seconds - 60, Resolved: seconds - 30},
+			),
+			cur:  tab(summarypb.DashboardTabSummary_PASS),
+			want: tab(summarypb.DashboardTabSummary_PASS, &summarypb.AlertEpisode{Opened: seconds - 60, Resolved: seconds - 30}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recordAlertHistory(tc.prev, tc.cur, now)
+			if diff := cmp.Diff(tc.want, tc.cur, protocmp.Transform()); diff != "" {
+				t.Errorf("recordAlertHistory() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCountFailingCycles(t *testing.T) {
+	prev := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{DashboardTabName: "failing", FailingCycles: 2, NotifiedSteps: []string{"1/pager/oncall"}},
+			{DashboardTabName: "fixed", FailingCycles: 4, NotifiedSteps: []string{"1/pager/oncall"}},
+		},
+	}
+	cur := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{DashboardTabName: "failing", OverallStatus: summarypb.DashboardTabSummary_FAIL},
+			{DashboardTabName: "fixed", OverallStatus: summarypb.DashboardTabSummary_PASS},
+			{DashboardTabName: "broken", OverallStatus: summarypb.DashboardTabSummary_BROKEN},
+		},
+	}
+	countFailingCycles(prev, cur)
+	want := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "failing",
+				OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+				FailingCycles:    3,
+				NotifiedSteps:    []string{"1/pager/oncall"},
+			},
+			{DashboardTabName: "fixed", OverallStatus: summarypb.DashboardTabSummary_PASS},
+			{DashboardTabName: "broken", OverallStatus: summarypb.DashboardTabSummary_BROKEN, FailingCycles: 1},
+		},
+	}
+	if diff := cmp.Diff(want, cur, protocmp.Transform()); diff != "" {
+		t.Errorf("countFailingCycles() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestShouldNotify(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cases := []struct {
+		name   string
+		fts    *summarypb.FailingTestSummary
+		notify func(*summarypb.FailingTestSummary)
+		want   bool
+	}{
+		{
+			name: "new alert",
+			fts:  &summarypb.FailingTestSummary{AlertId: "a"},
+			want: true,
+		},
+		{
+			name: "already notified",
+			fts:  &summarypb.FailingTestSummary{AlertId: "a"},
+			notify: func(fts *summarypb.FailingTestSummary) {
+				MarkNotified(fts, now)
+			},
+		},
+		{
+			name: "changed since notified",
+			fts:  &summarypb.FailingTestSummary{AlertId: "a"},
+			notify: func(fts *summarypb.FailingTestSummary) {
+				MarkNotified(fts, now)
+				fts.FailureMessage = "something new"
+			},
+			want: true,
+		},
+		{
+			name: "snoozed",
+			fts: &summarypb.FailingTestSummary{
+				AlertId: "a",
+				AlertState: &summarypb.AlertState{
+					SnoozeUntil: toTimestamp(now.Add(time.Minute)),
+				},
+			},
+		},
+		{
+			name: "snooze expired",
+			fts: &summarypb.FailingTestSummary{
+				AlertId: "a",
+				AlertState: &summarypb.AlertState{
+					SnoozeUntil: toTimestamp(now.Add(-time.Minute)),
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.notify != nil {
+				tc.notify(tc.fts)
+			}
+			if got := ShouldNotify(tc.fts, now); got != tc.want {
+				t.Errorf("ShouldNotify() got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteAlertedSummary(t *testing.T) {
+	path, err := gcs.NewPath("gs://bucket/summary/summary-dash")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	now := time.Unix(1000, 0)
+	snoozed := &summarypb.AlertState{SnoozeUntil: toTimestamp(now.Add(time.Hour))}
+	newSummary := func(state *summarypb.AlertState) *summarypb.DashboardSummary {
+		return &summarypb.DashboardSummary{
+			TabSummaries: []*summarypb.DashboardTabSummary{
+				{
+					DashboardTabName: "tab",
+					FailingTestSummaries: []*summarypb.FailingTestSummary{
+						{AlertId: "a", AlertState: state},
+					},
+				},
+			},
+		}
+	}
+	cases := []struct {
+		name       string
+		generation int64
+		current    int64
+		stored     *summarypb.DashboardSummary
+		want       *summarypb.AlertState
+	}{
+		{
+			name:       "unchanged",
+			generation: 1,
+			current:    1,
+			stored:     newSummary(nil),
+		},
+		{
+			name:       "keep a snooze written since reading",
+			generation: 1,
+			current:    2,
+			stored:     newSummary(snoozed),
+			want:       snoozed,
+		},
+		{
+			name: "first summary",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := fake.Opener{}
+			stater := fake.Stater{}
+			uploader := fake.Uploader{}
+			if tc.stored != nil {
+				buf, err := proto.Marshal(tc.stored)
+				if err != nil {
+					t.Fatalf("marshal: %v", err)
+				}
+				opener[*path] = fake.Object{Data: string(buf)}
+				stater[*path] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: tc.current}}
+				uploader[*path] = fake.Upload{Buf: buf, Generation: tc.current}
+			}
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client:   fake.Client{Opener: opener},
+					Uploader: uploader,
+					Stater:   stater,
+				},
+			}
+			if err := writeAlertedSummary(context.Background(), client, *path, newSummary(nil), tc.generation, now); err != nil {
+				t.Fatalf("writeAlertedSummary() got unexpected error: %v", err)
+			}
+			var got summarypb.DashboardSummary
+			if err := proto.Unmarshal(uploader[*path].Buf, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.TabSummaries[0].FailingTestSummaries[0].AlertState, protocmp.Transform()); diff != "" {
+				t.Errorf("writeAlertedSummary() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGraceNewTests(t *testing.T) {
+	since := time.Unix(1000, 0)
+	alert := &statepb.AlertInfo{FailCount: 3}
+	rows := []*statepb.Row{
+		{Name: "new", FirstSeen: &timestamp.Timestamp{Seconds: 1001}, AlertInfo: alert},
+		{Name: "old", FirstSeen: &timestamp.Timestamp{Seconds: 1000}, AlertInfo: alert},
+		{Name: "unknown", AlertInfo: alert},
+	}
+	graceNewTests(rows, since)
+	want := []*statepb.Row{
+		{Name: "new", FirstSeen: &timestamp.Timestamp{Seconds: 1001}},
+		{Name: "old", FirstSeen: &timestamp.Timestamp{Seconds: 1000}, AlertInfo: alert},
+		{Name: "unknown", AlertInfo: alert},
+	}
+	if diff := cmp.Diff(want, rows, protocmp.Transform()); diff != "" {
+		t.Errorf("graceNewTests() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
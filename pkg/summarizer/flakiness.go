@@ -19,6 +19,7 @@ package summarizer
 import (
 	"context"
 	"regexp"
+	"sort"
 
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
@@ -134,8 +135,8 @@ func parseGrid(grid *statepb.Grid, startTime int, endTime int) ([]*common.GridMe
 			rowResult := result.Coalesce(nextRowResult, result.ShowRunning)
 
 			// We still need to increment rowToMessageIndex even if we want to skip counting
-			// this column.
-			if !isWithinTimeFrame(grid.Columns[i], startTime, endTime) {
+			// this column, such as when most of its tests failed.
+			if grid.Columns[i].Broken || !isWithinTimeFrame(grid.Columns[i], startTime, endTime) {
 				switch rowResult {
 				case statuspb.TestStatus_NO_RESULT:
 					// Ignore NO_RESULT (e.g. blank cell)
@@ -174,6 +175,10 @@ func parseGrid(grid *statepb.Grid, startTime int, endTime int) ([]*common.GridMe
 			gridMetrics = append(gridMetrics, metric)
 		}
 	}
+	// Map iteration is random, so sort for a deterministic summary.
+	sort.Slice(gridMetrics, func(i, j int) bool {
+		return gridMetrics[i].Name < gridMetrics[j].Name
+	})
 	return gridMetrics, rowStatuses
 }
 
@@ -481,6 +481,45 @@ func TestParseGrid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "grid with broken columns skips them",
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Started: 0},
+					{Started: 1000, Broken: true},
+					{Started: 2000},
+				},
+				Rows: []*statepb.Row{
+					{
+						Name: "test_1",
+						Results: []int32{
+							statuspb.TestStatus_value["PASS"], 1,
+							statuspb.TestStatus_value["FAIL"], 2,
+						},
+						Messages: []string{
+							"",
+							"infra_fail_1",
+							"",
+						},
+					},
+				},
+			},
+			startTime: 0,
+			endTime:   2,
+			expectedMetrics: []*common.GridMetrics{
+				{
+					Name:          "test_1",
+					Passed:        1,
+					Failed:        1,
+					InfraFailures: map[string]int{},
+				},
+			},
+			expectedFilteredStatus: map[string][]analyzers.StatusCategory{
+				"test_1": {
+					analyzers.StatusPass, analyzers.StatusFail,
+				},
+			},
+		},
 	}
 
 	metricsSort := func(x *common.GridMetrics, y *common.GridMetrics) bool {
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+type unit int
+
+const (
+	minuteUnit unit = iota
+	hourUnit
+	dayUnit
+	buildUnit
+)
+
+// A Locale humanizes the structured fields of summaries in a language.
+//
+// The zero value is not usable, see NewLocale.
+type Locale struct {
+	// units holds the singular and plural name of each unit.
+	units   map[unit][2]string
+	failing string // duration, builds
+	status  string // passing columns, completed columns, cell percentage
+	alerts  map[summarypb.TabAlert_Reason]string
+	decimal string
+}
+
+var locales = map[string]Locale{
+	"en": {
+		units: map[unit][2]string{
+			minuteUnit: {"minute", "minutes"},
+			hourUnit:   {"hour", "hours"},
+			dayUnit:    {"day", "days"},
+			buildUnit:  {"build", "builds"},
+		},
+		failing: "failing for %s, %s",
+		status:  "%d of %d recent columns passed (%s%% of cells)",
+		alerts: map[summarypb.TabAlert_Reason]string{
+			summarypb.TabAlert_NO_STORED_RESULTS:    "no stored results",
+			summarypb.TabAlert_NO_COMPLETED_RESULTS: "no completed results",
+			summarypb.TabAlert_UNCHANGED:            "results have not changed for %s",
+			summarypb.TabAlert_OLD_RESULTS:          "latest results are %s old",
+			summarypb.TabAlert_ARCHIVED:             "archived until new results appear",
+			summarypb.TabAlert_PAUSED:               "updates are paused",
+			summarypb.TabAlert_FAILED:               "failed to summarize tab",
+		},
+		decimal: ".",
+	},
+	"de": {
+		units: map[unit][2]string{
+			minuteUnit: {"Minute", "Minuten"},
+			hourUnit:   {"Stunde", "Stunden"},
+			dayUnit:    {"Tag", "Tage"},
+			buildUnit:  {"Build", "Builds"},
+		},
+		failing: "%s lang fehlgeschlagen, %s",
+		status:  "%d von %d aktuellen Spalten bestanden (%s %% der Zellen)",
+		alerts: map[summarypb.TabAlert_Reason]string{
+			summarypb.TabAlert_NO_STORED_RESULTS:    "keine gespeicherten Ergebnisse",
+			summarypb.TabAlert_NO_COMPLETED_RESULTS: "keine abgeschlossenen Ergebnisse",
+			summarypb.TabAlert_UNCHANGED:            "Ergebnisse %s lang unverändert",
+			summarypb.TabAlert_OLD_RESULTS:          "neueste Ergebnisse sind %s alt",
+			summarypb.TabAlert_ARCHIVED:             "archiviert bis neue Ergebnisse vorliegen",
+			summarypb.TabAlert_PAUSED:               "Aktualisierungen sind pausiert",
+			summarypb.TabAlert_FAILED:               "Tab konnte nicht zusammengefasst werden",
+		},
+		decimal: ",",
+	},
+	"fr": {
+		units: map[unit][2]string{
+			minuteUnit: {"minute", "minutes"},
+			hourUnit:   {"heure", "heures"},
+			dayUnit:    {"jour", "jours"},
+			buildUnit:  {"build", "builds"},
+		},
+		failing: "en échec depuis %s, %s",
+		status:  "%d sur %d colonnes récentes réussies (%s %% des cellules)",
+		alerts: map[summarypb.TabAlert_Reason]string{
+			summarypb.TabAlert_NO_STORED_RESULTS:    "aucun résultat enregistré",
+			summarypb.TabAlert_NO_COMPLETED_RESULTS: "aucun résultat terminé",
+			summarypb.TabAlert_UNCHANGED:            "résultats inchangés depuis %s",
+			summarypb.TabAlert_OLD_RESULTS:          "les derniers résultats datent de %s",
+			summarypb.TabAlert_ARCHIVED:             "archivé jusqu'à de nouveaux résultats",
+			summarypb.TabAlert_PAUSED:               "les mises à jour sont suspendues",
+			summarypb.TabAlert_FAILED:               "impossible de résumer l'onglet",
+		},
+		decimal: ",",
+	},
+}
+
+// NewLocale returns the locale of a language tag such as de or de-CH.
+//
+// Unknown languages use English.
+func NewLocale(tag string) Locale {
+	lang := strings.ToLower(tag)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if l, ok := locales[lang]; ok {
+		return l
+	}
+	return locales["en"]
+}
+
+// count returns the number with the singular or plural name of the unit.
+func (l Locale) count(n int, u unit) string {
+	names := l.units[u]
+	if n == 1 {
+		return "1 " + names[0]
+	}
+	return strconv.Itoa(n) + " " + names[1]
+}
+
+// Duration returns the duration in its largest whole unit, such as 3 days.
+func (l Locale) Duration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return l.count(int(d/(24*time.Hour)), dayUnit)
+	case d >= time.Hour:
+		return l.count(int(d/time.Hour), hourUnit)
+	case d < 0:
+		d = 0
+	}
+	return l.count(int(d/time.Minute), minuteUnit)
+}
+
+// Builds returns the number of builds, such as 14 builds.
+func (l Locale) Builds(n int) string {
+	return l.count(n, buildUnit)
+}
+
+// Percent returns the percentage with one decimal and the locale's decimal separator.
+func (l Locale) Percent(p float64) string {
+	return strings.Replace(strconv.FormatFloat(p, 'f', 1, 64), ".", l.decimal, 1)
+}
+
+// Failing describes how long and for how many builds the test has failed,
+// such as failing for 3 days, 14 builds.
+func (l Locale) Failing(f *summarypb.FailingTestSummary, now time.Time) string {
+	var d time.Duration
+	if f.FailTimestamp > 0 {
+		d = now.Sub(updater.SecondsTime(f.FailTimestamp))
+	}
+	return fmt.Sprintf(l.failing, l.Duration(d), l.Builds(int(f.FailCount)))
+}
+
+// Alert describes why the tab alerts, or returns an empty string.
+func (l Locale) Alert(a *summarypb.TabAlert, now time.Time) string {
+	msg, ok := l.alerts[a.GetReason()]
+	if !ok {
+		return ""
+	}
+	switch a.GetReason() {
+	case summarypb.TabAlert_UNCHANGED, summarypb.TabAlert_OLD_RESULTS:
+		return fmt.Sprintf(msg, l.Duration(now.Sub(updater.SecondsTime(a.Since))))
+	}
+	return msg
+}
+
+// Status describes how many recent columns and cells passed.
+func (l Locale) Status(c *summarypb.StatusCounts) string {
+	if c.GetFilledCells() == 0 {
+		return l.alerts[summarypb.TabAlert_NO_COMPLETED_RESULTS]
+	}
+	cells := 100 * float64(c.PassingCells) / float64(c.FilledCells)
+	return fmt.Sprintf(l.status, c.PassingColumns, c.CompletedColumns, l.Percent(cells))
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+	"time"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestNewLocale(t *testing.T) {
+	cases := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "", want: "3 days"},
+		{tag: "en-US", want: "3 days"},
+		{tag: "de-CH", want: "3 Tage"},
+		{tag: "fr_FR", want: "3 jours"},
+		{tag: "xx", want: "3 days"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.tag, func(t *testing.T) {
+			if got := NewLocale(tc.tag).Duration(3 * 24 * time.Hour); got != tc.want {
+				t.Errorf("NewLocale(%q).Duration() got %q, want %q", tc.tag, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocaleDuration(t *testing.T) {
+	cases := []struct {
+		dur  time.Duration
+		want string
+	}{
+		{dur: -time.Hour, want: "0 minutes"},
+		{dur: time.Minute, want: "1 minute"},
+		{dur: 59 * time.Minute, want: "59 minutes"},
+		{dur: 90 * time.Minute, want: "1 hour"},
+		{dur: 47 * time.Hour, want: "1 day"},
+		{dur: 80 * time.Hour, want: "3 days"},
+	}
+	en := NewLocale("en")
+	for _, tc := range cases {
+		t.Run(tc.dur.String(), func(t *testing.T) {
+			if got := en.Duration(tc.dur); got != tc.want {
+				t.Errorf("Duration(%s) got %q, want %q", tc.dur, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFailing(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	f := &summarypb.FailingTestSummary{
+		FailTimestamp: float64(now.Add(-75 * time.Hour).Unix()),
+		FailCount:     14,
+	}
+	cases := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "en", want: "failing for 3 days, 14 builds"},
+		{tag: "de", want: "3 Tage lang fehlgeschlagen, 14 Builds"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.tag, func(t *testing.T) {
+			if got := NewLocale(tc.tag).Failing(f, now); got != tc.want {
+				t.Errorf("Failing() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocaleAlert(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	cases := []struct {
+		name  string
+		alert *summarypb.TabAlert
+		want  string
+	}{
+		{
+			name: "no alert",
+		},
+		{
+			name:  "unchanged",
+			alert: &summarypb.TabAlert{Reason: summarypb.TabAlert_UNCHANGED, Since: float64(now.Add(-5 * time.Hour).Unix())},
+			want:  "results have not changed for 5 hours",
+		},
+		{
+			name:  "old results",
+			alert: &summarypb.TabAlert{Reason: summarypb.TabAlert_OLD_RESULTS, Since: float64(now.Add(-49 * time.Hour).Unix())},
+			want:  "latest results are 2 days old",
+		},
+		{
+			name:  "paused",
+			alert: &summarypb.TabAlert{Reason: summarypb.TabAlert_PAUSED},
+			want:  "updates are paused",
+		},
+	}
+	en := NewLocale("en")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := en.Alert(tc.alert, now); got != tc.want {
+				t.Errorf("Alert() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocaleStatus(t *testing.T) {
+	counts := &summarypb.StatusCounts{
+		PassingColumns:   3,
+		CompletedColumns: 4,
+		PassingCells:     2,
+		FilledCells:      3,
+	}
+	cases := []struct {
+		tag    string
+		counts *summarypb.StatusCounts
+		want   string
+	}{
+		{tag: "en", counts: counts, want: "3 of 4 recent columns passed (66.7% of cells)"},
+		{tag: "fr", counts: counts, want: "3 sur 4 colonnes récentes réussies (66,7 % des cellules)"},
+		{tag: "en", want: "no completed results"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.tag, func(t *testing.T) {
+			if got := NewLocale(tc.tag).Status(tc.counts); got != tc.want {
+				t.Errorf("Status() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
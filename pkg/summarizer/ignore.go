@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// ignoreStatuses replaces results with an ignored status with NO_RESULT, returning the rows this changes.
+func ignoreStatuses(rows []*statepb.Row, statuses []statuspb.TestStatus) []*statepb.Row {
+	if len(statuses) == 0 {
+		return nil
+	}
+	ignored := make(map[statuspb.TestStatus]bool, len(statuses))
+	for _, s := range statuses {
+		ignored[s] = true
+	}
+	var changed []*statepb.Row
+	for _, row := range rows {
+		if ignoreRow(row, ignored) {
+			changed = append(changed, row)
+		}
+	}
+	return changed
+}
+
+// ignoreRow clears the ignored cells of the row, returning true if there were any.
+//
+// Cell details only exist for filled cells, so drop them along with the result.
+func ignoreRow(row *statepb.Row, ignored map[statuspb.TestStatus]bool) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var results []int32
+	var keep []bool
+	var dropped bool
+	for res := range result.Iter(ctx, row.Results) {
+		if res != statuspb.TestStatus_NO_RESULT {
+			keep = append(keep, !ignored[res])
+			if ignored[res] {
+				dropped = true
+				res = statuspb.TestStatus_NO_RESULT
+			}
+		}
+		n := len(results)
+		if n > 0 && results[n-2] == int32(res) {
+			results[n-1]++
+			continue
+		}
+		results = append(results, int32(res), 1)
+	}
+	if !dropped {
+		return false
+	}
+	row.Results = results
+	row.CellIds = keepStrings(row.CellIds, keep)
+	row.Messages = keepStrings(row.Messages, keep)
+	row.Icons = keepStrings(row.Icons, keep)
+	row.UserProperty = keepStrings(row.UserProperty, keep)
+	for _, p := range row.Properties {
+		p.Values = keepStrings(p.Values, keep)
+	}
+	if len(row.CellLinks) == len(keep) {
+		links := row.CellLinks[:0]
+		for i, cl := range row.CellLinks {
+			if keep[i] {
+				links = append(links, cl)
+			}
+		}
+		row.CellLinks = links
+	}
+	if len(row.CellParameters) == len(keep) {
+		params := row.CellParameters[:0]
+		for i, cp := range row.CellParameters {
+			if keep[i] {
+				params = append(params, cp)
+			}
+		}
+		row.CellParameters = params
+	}
+	return true
+}
+
+// keepStrings returns the values to keep, provided there is one value per cell.
+func keepStrings(values []string, keep []bool) []string {
+	if len(values) != len(keep) {
+		return values
+	}
+	out := values[:0]
+	for i, v := range values {
+		if keep[i] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestIgnoreStatuses(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	cancel := int32(statuspb.TestStatus_CANCEL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	cases := []struct {
+		name        string
+		rows        []*statepb.Row
+		statuses    []statuspb.TestStatus
+		want        []*statepb.Row
+		wantChanged []string
+	}{
+		{
+			name: "basically works",
+			rows: []*statepb.Row{{Name: "foo", Results: []int32{cancel, 1}, Messages: []string{"canceled"}}},
+			want: []*statepb.Row{{Name: "foo", Results: []int32{cancel, 1}, Messages: []string{"canceled"}}},
+		},
+		{
+			name: "drop ignored cells",
+			rows: []*statepb.Row{
+				{
+					Name:     "foo",
+					Results:  []int32{cancel, 1, empty, 1, pass, 1, cancel, 1, fail, 1},
+					CellIds:  []string{"c5", "c3", "c2", "c1"},
+					Messages: []string{"canceled", "", "canceled", "boom"},
+					Icons:    []string{"C", "", "C", "F"},
+					Properties: []*statepb.PropertyValues{
+						{Name: "p", Values: []string{"5", "3", "2", "1"}},
+					},
+					CellParameters: []*statepb.CellParameters{
+						{}, {}, {Results: []*statepb.ParameterResult{{Parameters: "x"}}}, {},
+					},
+				},
+				{
+					Name:     "bar",
+					Results:  []int32{pass, 5},
+					Messages: []string{"", "", "", "", ""},
+				},
+			},
+			statuses: []statuspb.TestStatus{statuspb.TestStatus_CANCEL},
+			want: []*statepb.Row{
+				{
+					Name:     "foo",
+					Results:  []int32{empty, 2, pass, 1, empty, 1, fail, 1},
+					CellIds:  []string{"c3", "c1"},
+					Messages: []string{"", "boom"},
+					Icons:    []string{"", "F"},
+					Properties: []*statepb.PropertyValues{
+						{Name: "p", Values: []string{"3", "1"}},
+					},
+					CellParameters: []*statepb.CellParameters{{}, {}},
+				},
+				{
+					Name:     "bar",
+					Results:  []int32{pass, 5},
+					Messages: []string{"", "", "", "", ""},
+				},
+			},
+			wantChanged: []string{"foo"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := ignoreStatuses(tc.rows, tc.statuses)
+			var gotChanged []string
+			for _, row := range changed {
+				gotChanged = append(gotChanged, row.Name)
+			}
+			if diff := cmp.Diff(tc.wantChanged, gotChanged); diff != "" {
+				t.Errorf("ignoreStatuses() got unexpected changed rows (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.rows, protocmp.Transform()); diff != "" {
+				t.Errorf("ignoreStatuses() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// gridGeneration returns the generation of the named group's grid state without reading it.
+type gridGeneration func(ctx context.Context, name string) (int64, error)
+
+// tabReuser returns the previous summary of the tab when it is still current, otherwise nil.
+type tabReuser func(ctx context.Context, tab *configpb.DashboardTab) *summarypb.DashboardTabSummary
+
+// tabFingerprint identifies the configuration which determines the tab's summary.
+func tabFingerprint(tab *configpb.DashboardTab, group *configpb.TestGroup) string {
+	h := sha256.New()
+	for _, msg := range []proto.Message{tab, group} {
+		buf := proto.NewBuffer(nil)
+		buf.SetDeterministic(true)
+		if err := buf.Marshal(msg); err != nil {
+			return ""
+		}
+		h.Write(buf.Bytes())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fingerprintTabs records the configuration of each summarized tab.
+func fingerprintTabs(dash *configpb.Dashboard, sum *summarypb.DashboardSummary, finder groupFinder) {
+	tabs := map[string]*configpb.DashboardTab{}
+	for _, tab := range dash.DashboardTab {
+		tabs[tab.Name] = tab
+	}
+	for _, s := range sum.TabSummaries {
+		tab, ok := tabs[s.DashboardTabName]
+		if !ok || s.GridGeneration == 0 {
+			continue
+		}
+		group, _, err := finder(tab.TestGroupName)
+		if err != nil || group == nil {
+			continue
+		}
+		s.ConfigFingerprint = tabFingerprint(tab, group)
+	}
+}
+
+// timeSensitive returns true when the summary of the tab changes over time,
+// even without new results.
+func timeSensitive(tab *configpb.DashboardTab) bool {
+	return shouldRunHealthiness(tab) || tab.GetErrorBudget() != nil || len(tab.GetRowStatsDays()) > 0 || tab.GetAlertOptions().GetNewTestGraceHours() > 0
+}
+
+// newTabReuser returns a tabReuser for the tabs of the previous dashboard summary.
+func newTabReuser(prev *summarypb.DashboardSummary, finder groupFinder, generation gridGeneration) tabReuser {
+	tabs := map[string]*summarypb.DashboardTabSummary{}
+	for _, s := range prev.TabSummaries {
+		tabs[s.DashboardTabName] = s
+	}
+	return func(ctx context.Context, tab *configpb.DashboardTab) *summarypb.DashboardTabSummary {
+		s, ok := tabs[tab.Name]
+		if !ok || s.GridGeneration == 0 || s.ConfigFingerprint == "" || timeSensitive(tab) {
+			return nil
+		}
+		group, _, err := finder(tab.TestGroupName)
+		if err != nil || group == nil || tabFingerprint(tab, group) != s.ConfigFingerprint {
+			return nil
+		}
+		gen, err := generation(ctx, tab.TestGroupName)
+		if err != nil || gen != s.GridGeneration {
+			return nil
+		}
+		return reuseTab(s, tab)
+	}
+}
+
+// reuseTab returns a copy of the previous summary of an unchanged tab.
+//
+// Unchanged tabs still go stale, so refresh the stale alert.
+func reuseTab(prev *summarypb.DashboardTabSummary, tab *configpb.DashboardTab) *summarypb.DashboardTabSummary {
+	s := proto.Clone(prev).(*summarypb.DashboardTabSummary)
+	switch s.OverallStatus {
+	case summarypb.DashboardTabSummary_PAUSED, summarypb.DashboardTabSummary_ARCHIVED:
+		return s
+	}
+	alert, reason := staleAlert(updater.SecondsTime(s.LastUpdateTimestamp), updater.SecondsTime(s.LastRunTimestamp), staleHours(tab))
+	if alert == "" {
+		return s
+	}
+	s.Alert = alert
+	s.TabAlert = reason
+	if s.OverallStatus != summarypb.DashboardTabSummary_BROKEN {
+		s.OverallStatus = summarypb.DashboardTabSummary_STALE
+	}
+	return s
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestTabFingerprint(t *testing.T) {
+	tab := &configpb.DashboardTab{Name: "tab", TestGroupName: "group"}
+	group := &configpb.TestGroup{Name: "group"}
+	base := tabFingerprint(tab, group)
+	if base == "" {
+		t.Fatal("tabFingerprint() returned an empty fingerprint")
+	}
+	if got := tabFingerprint(&configpb.DashboardTab{Name: "tab", TestGroupName: "group"}, &configpb.TestGroup{Name: "group"}); got != base {
+		t.Errorf("tabFingerprint() of equal config got %s, want %s", got, base)
+	}
+	if got := tabFingerprint(&configpb.DashboardTab{Name: "tab", TestGroupName: "group", NumColumnsRecent: 3}, group); got == base {
+		t.Error("tabFingerprint() failed to change with the tab")
+	}
+	if got := tabFingerprint(tab, &configpb.TestGroup{Name: "group", Paused: true}); got == base {
+		t.Error("tabFingerprint() failed to change with the group")
+	}
+}
+
+func TestNewTabReuser(t *testing.T) {
+	tab := &configpb.DashboardTab{Name: "tab", TestGroupName: "group"}
+	group := &configpb.TestGroup{Name: "group"}
+	current := &summarypb.DashboardTabSummary{
+		DashboardName:       "dash",
+		DashboardTabName:    "tab",
+		LastUpdateTimestamp: float64(time.Now().Unix()),
+		OverallStatus:       summarypb.DashboardTabSummary_PASS,
+		GridGeneration:      7,
+		ConfigFingerprint:   tabFingerprint(tab, group),
+	}
+	cases := []struct {
+		name string
+		tab  *configpb.DashboardTab
+		prev *summarypb.DashboardTabSummary
+		gen  int64
+		err  error
+		want *summarypb.DashboardTabSummary
+	}{
+		{
+			name: "reuse unchanged tabs",
+			prev: current,
+			gen:  7,
+			want: current,
+		},
+		{
+			name: "grid changed",
+			prev: current,
+			gen:  8,
+		},
+		{
+			name: "stat error",
+			prev: current,
+			err:  errors.New("boom"),
+		},
+		{
+			name: "config changed",
+			tab:  &configpb.DashboardTab{Name: "tab", TestGroupName: "group", NumColumnsRecent: 3},
+			prev: current,
+			gen:  7,
+		},
+		{
+			name: "healthiness is time sensitive",
+			tab: &configpb.DashboardTab{
+				Name:                  "tab",
+				TestGroupName:         "group",
+				HealthAnalysisOptions: &configpb.HealthAnalysisOptions{Enable: true},
+			},
+			prev: current,
+			gen:  7,
+		},
+		{
+			name: "error budgets are time sensitive",
+			tab: &configpb.DashboardTab{
+				Name:          "tab",
+				TestGroupName: "group",
+				ErrorBudget:   &configpb.DashboardTab_ErrorBudget{AllowedFailingColumns: 3},
+			},
+			prev: current,
+			gen:  7,
+		},
+		{
+			name: "row stats are time sensitive",
+			tab: &configpb.DashboardTab{
+				Name:          "tab",
+				TestGroupName: "group",
+				RowStatsDays:  []int32{7},
+			},
+			prev: current,
+			gen:  7,
+		},
+		{
+			name: "new test grace is time sensitive",
+			tab: &configpb.DashboardTab{
+				Name:          "tab",
+				TestGroupName: "group",
+				AlertOptions:  &configpb.DashboardTabAlertOptions{NewTestGraceHours: 24},
+			},
+			prev: current,
+			gen:  7,
+		},
+		{
+			name: "missing fingerprint",
+			prev: &summarypb.DashboardTabSummary{DashboardTabName: "tab", GridGeneration: 7},
+			gen:  7,
+		},
+		{
+			name: "new tab",
+			prev: &summarypb.DashboardTabSummary{DashboardTabName: "other"},
+			gen:  7,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.tab == nil {
+				tc.tab = tab
+			}
+			finder := func(name string) (*configpb.TestGroup, gridReader, error) {
+				if name != group.Name {
+					return nil, nil, nil
+				}
+				return group, nil, nil
+			}
+			generation := func(_ context.Context, name string) (int64, error) {
+				return tc.gen, tc.err
+			}
+			prev := &summarypb.DashboardSummary{TabSummaries: []*summarypb.DashboardTabSummary{tc.prev}}
+			got := newTabReuser(prev, finder, generation)(context.Background(), tc.tab)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("newTabReuser() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReuseTab(t *testing.T) {
+	now := time.Now()
+	old := float64(now.Add(-3 * time.Hour).Unix())
+	recent := float64(now.Add(-time.Minute).Unix())
+	stale := &configpb.DashboardTab{
+		AlertOptions: &configpb.DashboardTabAlertOptions{AlertStaleResultsHours: 2},
+	}
+	cases := []struct {
+		name       string
+		tab        *configpb.DashboardTab
+		prev       *summarypb.DashboardTabSummary
+		wantStatus summarypb.DashboardTabSummary_TabStatus
+		wantAlert  string
+	}{
+		{
+			name: "fresh tabs are unchanged",
+			tab:  stale,
+			prev: &summarypb.DashboardTabSummary{
+				LastUpdateTimestamp: recent,
+				LastRunTimestamp:    recent,
+				OverallStatus:       summarypb.DashboardTabSummary_FAIL,
+			},
+			wantStatus: summarypb.DashboardTabSummary_FAIL,
+		},
+		{
+			name: "tabs without stale alerts never go stale",
+			tab:  &configpb.DashboardTab{},
+			prev: &summarypb.DashboardTabSummary{
+				LastUpdateTimestamp: old,
+				LastRunTimestamp:    old,
+				OverallStatus:       summarypb.DashboardTabSummary_PASS,
+			},
+			wantStatus: summarypb.DashboardTabSummary_PASS,
+		},
+		{
+			name: "tabs go stale",
+			tab:  stale,
+			prev: &summarypb.DashboardTabSummary{
+				LastUpdateTimestamp: old,
+				LastRunTimestamp:    old,
+				OverallStatus:       summarypb.DashboardTabSummary_PASS,
+			},
+			wantStatus: summarypb.DashboardTabSummary_STALE,
+			wantAlert:  "data has not changed since",
+		},
+		{
+			name: "broken tabs stay broken",
+			tab:  stale,
+			prev: &summarypb.DashboardTabSummary{
+				LastUpdateTimestamp: recent,
+				LastRunTimestamp:    old,
+				OverallStatus:       summarypb.DashboardTabSummary_BROKEN,
+			},
+			wantStatus: summarypb.DashboardTabSummary_BROKEN,
+			wantAlert:  "latest column from",
+		},
+		{
+			name: "paused tabs stay paused",
+			tab:  stale,
+			prev: &summarypb.DashboardTabSummary{
+				LastUpdateTimestamp: old,
+				LastRunTimestamp:    old,
+				Alert:               pausedAlert,
+				OverallStatus:       summarypb.DashboardTabSummary_PAUSED,
+			},
+			wantStatus: summarypb.DashboardTabSummary_PAUSED,
+			wantAlert:  pausedAlert,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := tc.prev.String()
+			got := reuseTab(tc.prev, tc.tab)
+			if got.OverallStatus != tc.wantStatus {
+				t.Errorf("reuseTab() got status %s, want %s", got.OverallStatus, tc.wantStatus)
+			}
+			if !strings.HasPrefix(got.Alert, tc.wantAlert) || (tc.wantAlert == "") != (got.Alert == "") {
+				t.Errorf("reuseTab() got alert %q, want prefix %q", got.Alert, tc.wantAlert)
+			}
+			if tc.prev.String() != orig {
+				t.Errorf("reuseTab() modified the previous summary: %s", tc.prev)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// rowStats returns the statistics of each row over the columns which started
+// within each window of days.
+func rowStats(days []int32, cols []*statepb.Column, rows []*statepb.Row, now time.Time) []*summarypb.RowStats {
+	if len(days) == 0 {
+		return nil
+	}
+	cutoffs := make([]float64, len(days))
+	for i, d := range days {
+		cutoffs[i] = float64(now.AddDate(0, 0, -int(d)).UnixNano() / int64(time.Millisecond))
+	}
+	out := make([]*summarypb.RowStats, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, rowWindowStats(days, cutoffs, cols, row))
+	}
+	return out
+}
+
+// rowWindowStats returns the statistics of the row over each window, which
+// starts at its cutoff.
+func rowWindowStats(days []int32, cutoffs []float64, cols []*statepb.Column, row *statepb.Row) *summarypb.RowStats {
+	windows := make([]*summarypb.RowWindowStats, len(days))
+	for i, d := range days {
+		windows[i] = &summarypb.RowWindowStats{Days: d}
+	}
+	durations := elapsedMinutes(row)
+	totals := make([]float64, len(days))
+	counts := make([]int, len(days))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var col int
+	// Columns are ordered newest first.
+	for res := range result.Iter(ctx, row.Results) {
+		if col >= len(cols) {
+			break
+		}
+		started := cols[col].Started
+		minutes, timed := durations[col]
+		col++
+		res = coalesceResult(res, result.IgnoreRunning)
+		if res == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		for i, w := range windows {
+			if started < cutoffs[i] {
+				continue
+			}
+			w.Runs++
+			switch {
+			case result.Passing(res):
+				w.Passes++
+			case result.Failing(res):
+				w.Failures++
+				if w.LastFailure == 0 {
+					w.LastFailure = started / 1000
+				}
+			}
+			if timed {
+				totals[i] += minutes * 60
+				counts[i]++
+			}
+		}
+	}
+	for i, w := range windows {
+		if w.Runs > 0 {
+			w.PassPercent = 100 * float32(w.Passes) / float32(w.Runs)
+		}
+		if counts[i] > 0 {
+			w.AverageDurationSeconds = totals[i] / float64(counts[i])
+		}
+	}
+	return &summarypb.RowStats{DisplayName: row.Name, Windows: windows}
+}
+
+// elapsedMinutes returns the elapsed metric of the row by column index.
+func elapsedMinutes(row *statepb.Row) map[int]float64 {
+	out := map[int]float64{}
+	for i, metric := range row.Metrics {
+		name := metric.Name
+		if name == "" && i < len(row.Metric) {
+			name = row.Metric[i]
+		}
+		if name != updater.ElapsedKey {
+			continue
+		}
+		var value int
+		for i := 0; i+1 < len(metric.Indices); i += 2 {
+			start, count := int(metric.Indices[i]), int(metric.Indices[i+1])
+			for col := start; col < start+count && value < len(metric.Values); col++ {
+				out[col] = metric.Values[value]
+				value++
+			}
+		}
+		break
+	}
+	return out
+}
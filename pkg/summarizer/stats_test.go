@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summarizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+func TestRowStats(t *testing.T) {
+	now := time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) float64 {
+		return float64(now.AddDate(0, 0, -d).UnixNano() / int64(time.Millisecond))
+	}
+	cols := []*statepb.Column{
+		{Build: "5", Started: daysAgo(1)},
+		{Build: "4", Started: daysAgo(2)},
+		{Build: "3", Started: daysAgo(10)},
+		{Build: "2", Started: daysAgo(20)},
+		{Build: "1", Started: daysAgo(40)},
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	running := int32(statuspb.TestStatus_RUNNING)
+	rows := []*statepb.Row{
+		{
+			Name:    "a",
+			Results: []int32{running, 1, pass, 1, fail, 1, pass, 1, fail, 1},
+			Metric:  []string{updater.ElapsedKey},
+			Metrics: []*statepb.Metric{{Indices: []int32{1, 2}, Values: []float64{1, 3}}},
+		},
+		{Name: "b", Results: []int32{fail, 1, pass, 2, fail, 2}},
+	}
+	cases := []struct {
+		name string
+		days []int32
+		want []*summarypb.RowStats
+	}{
+		{
+			name: "no windows",
+		},
+		{
+			name: "windows",
+			days: []int32{7, 30},
+			want: []*summarypb.RowStats{
+				{
+					DisplayName: "a",
+					Windows: []*summarypb.RowWindowStats{
+						{
+							Days:                   7,
+							Runs:                   1,
+							Passes:                 1,
+							PassPercent:            100,
+							AverageDurationSeconds: 60,
+						},
+						{
+							Days:                   30,
+							Runs:                   3,
+							Passes:                 2,
+							Failures:               1,
+							PassPercent:            100 * float32(2) / float32(3),
+							AverageDurationSeconds: 120,
+							LastFailure:            daysAgo(10) / 1000,
+						},
+					},
+				},
+				{
+					DisplayName: "b",
+					Windows: []*summarypb.RowWindowStats{
+						{
+							Days:        7,
+							Runs:        2,
+							Passes:      1,
+							Failures:    1,
+							PassPercent: 50,
+							LastFailure: daysAgo(1) / 1000,
+						},
+						{
+							Days:        30,
+							Runs:        4,
+							Passes:      2,
+							Failures:    2,
+							PassPercent: 50,
+							LastFailure: daysAgo(1) / 1000,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := rowStats(tc.days, cols, rows, now)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("rowStats() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
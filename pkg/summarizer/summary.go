@@ -18,7 +18,6 @@ limitations under the License.
 package summarizer
 
 import (
-	"compress/zlib"
 	"context"
 	"errors"
 	"fmt"
@@ -28,6 +27,7 @@ import (
 	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -38,14 +38,44 @@ import (
 	"google.golang.org/api/googleapi"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/alert"
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 )
 
+// clock returns the current time, unless FixTime pins it.
+var clock = time.Now
+
+// FixTime pins the time used to compute dashboard summaries, so that identical inputs
+// always produce identical output, such as when comparing a canary.
+//
+// Call it before any update.
+func FixTime(t time.Time) {
+	clock = func() time.Time { return t }
+}
+
+// A Notifier sends the notifications about a dashboard's summary before the
+// summarizer writes it, recording what it sent in the summary.
+type Notifier interface {
+	NotifyDashboard(ctx context.Context, cfg *configpb.Configuration, dash *configpb.Dashboard, sum *summarypb.DashboardSummary, now time.Time) error
+}
+
+// notifier sends notifications about written summaries, unless NotifyWith was not called.
+var notifier Notifier
+
+// NotifyWith sends notifications about each summary the summarizer writes with n.
+//
+// Call it before any update.
+func NotifyWith(n Notifier) {
+	notifier = n
+}
+
 // gridReader returns the grid content and metadata (last updated time, generation id)
 type gridReader func(ctx context.Context) (io.ReadCloser, time.Time, int64, error)
 
@@ -57,7 +87,8 @@ type groupFinder func(string) (*configpb.TestGroup, gridReader, error)
 // Will use concurrency go routines to update dashboards in parallel.
 // Setting dashboard will limit update to this dashboard.
 // Will write summary proto when confirm is set.
-func Update(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, concurrency int, dashboard, gridPathPrefix, summaryPathPrefix string, confirm bool) error {
+// Reuses the previous summary of tabs whose config and grid state are unchanged when incremental is set.
+func Update(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Path, concurrency int, dashboard, gridPathPrefix, summaryPathPrefix string, confirm, incremental bool) error {
 	if concurrency < 1 {
 		return fmt.Errorf("concurrency must be positive, got: %d", concurrency)
 	}
@@ -88,6 +119,18 @@ func Update(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Pa
 		return group, reader, nil
 	}
 
+	generation := func(ctx context.Context, name string) (int64, error) {
+		groupPath, err := configPath.ResolveReference(&url.URL{Path: path.Join(gridPathPrefix, name)})
+		if err != nil {
+			return 0, err
+		}
+		attrs, err := client.Stat(ctx, *groupPath)
+		if err != nil {
+			return 0, err
+		}
+		return attrs.Generation, nil
+	}
+
 	errCh := make(chan error)
 
 	for i := 0; i < concurrency; i++ {
@@ -97,7 +140,7 @@ func Update(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Pa
 			for dash := range dashboards {
 				log := log.WithField("dashboard", dash.Name)
 				log.Debug("Summarizing dashboard")
-				summaryPath, err := summaryPath(configPath, summaryPathPrefix, dash.Name)
+				summaryPath, err := SummaryPath(configPath, summaryPathPrefix, dash.Name)
 				if err != nil {
 					log.WithError(err).Error("Cannot resolve summary path")
 					errCh <- errors.New(dash.Name)
@@ -120,18 +163,41 @@ func Update(ctx context.Context, client gcs.ConditionalClient, configPath gcs.Pa
 					}
 					log.Debug("Acquired update lock")
 				}
-				sum, err := updateDashboard(ctx, dash, groupFinder)
+				log = log.WithField("path", summaryPath)
+				prev, summaryGeneration, err := readSummaryGeneration(ctx, client, *summaryPath)
+				switch {
+				case errors.Is(err, storage.ErrObjectNotExist):
+				case err != nil:
+					log.WithError(err).Warning("Cannot read previous summary, alert states will be reset")
+				}
+				var reuse tabReuser
+				if incremental && prev != nil {
+					reuse = newTabReuser(prev, groupFinder, generation)
+				}
+				sum, err := updateDashboard(ctx, dash, groupFinder, reuse)
 				if err != nil {
 					log.WithError(err).Error("Cannot summarize dashboard")
 					errCh <- errors.New(dash.Name)
 					continue
 				}
-				log = log.WithField("path", summaryPath)
+				fingerprintTabs(dash, sum, groupFinder)
+				if prev != nil {
+					carryAlertStates(prev, sum, clock())
+				}
+				recordAlertHistory(prev, sum, clock())
+				countFailingCycles(prev, sum)
 				if !confirm {
 					log.WithField("summary", sum).Info("Summarized")
 					continue
 				}
-				if err := writeSummary(ctx, client, *summaryPath, sum); err != nil {
+				if notifier != nil {
+					if err := notifier.NotifyDashboard(ctx, cfg, dash, sum, clock()); err != nil {
+						log.WithError(err).Warning("Failed to send notifications")
+					}
+				}
+				err = writeAlertedSummary(ctx, client, *summaryPath, sum, summaryGeneration, clock())
+				writeMirrors(ctx, log, client, dash.MirrorPrefixes, summaryName(dash.Name), sum)
+				if err != nil {
 					log.WithError(err).Error("Cannot write summary")
 					errCh <- errors.New(dash.Name)
 					continue
@@ -196,7 +262,7 @@ func sortDashboards(ctx context.Context, log logrus.FieldLogger, client gcs.Stat
 	pathedDashboards := make(map[gcs.Path]*configpb.Dashboard, len(dashboards))
 	paths := make([]gcs.Path, 0, len(dashboards))
 	for _, d := range dashboards {
-		path, err := summaryPath(configPath, summaryPathPrefix, d.Name)
+		path, err := SummaryPath(configPath, summaryPathPrefix, d.Name)
 		if err != nil {
 			return nil, fmt.Errorf("bad dashboard path: %s: %w", d.Name, err)
 		}
@@ -219,10 +285,15 @@ var (
 	normalizer = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
-func summaryPath(g gcs.Path, prefix, dashboard string) (*gcs.Path, error) {
+// summaryName returns the name of the dashboard's summary object.
+func summaryName(dashboard string) string {
 	// ''.join(c for c in n.lower() if c is alphanumeric
-	name := "summary-" + normalizer.ReplaceAllString(strings.ToLower(dashboard), "")
-	fullName := path.Join(prefix, name)
+	return "summary-" + normalizer.ReplaceAllString(strings.ToLower(dashboard), "")
+}
+
+// SummaryPath returns the path to the summary proto of the dashboard.
+func SummaryPath(g gcs.Path, prefix, dashboard string) (*gcs.Path, error) {
+	fullName := path.Join(prefix, summaryName(dashboard))
 	u, err := url.Parse(fullName)
 	if err != nil {
 		return nil, fmt.Errorf("parse url: %w", err)
@@ -238,13 +309,50 @@ func summaryPath(g gcs.Path, prefix, dashboard string) (*gcs.Path, error) {
 }
 
 func writeSummary(ctx context.Context, client gcs.Client, path gcs.Path, sum *summarypb.DashboardSummary) error {
-	buf, err := proto.Marshal(sum)
+	buf, err := state.MarshalDeterministic(sum)
 	if err != nil {
 		return fmt.Errorf("marshal: %v", err)
 	}
 	return client.Upload(ctx, path, buf, gcs.DefaultACL, "no-cache") // TODO(fejta): configurable cache value
 }
 
+// writeMirrors writes the summary to each mirror, logging any failures.
+//
+// Mirrors are best effort and do not affect the primary write.
+func writeMirrors(ctx context.Context, log logrus.FieldLogger, client gcs.Client, prefixes []string, name string, sum *summarypb.DashboardSummary) {
+	for _, prefix := range prefixes {
+		log := log.WithField("mirror", prefix)
+		mirrorPath, err := gcs.MirrorPath(prefix, name)
+		if err != nil {
+			log.WithError(err).Warning("Bad mirror prefix")
+			continue
+		}
+		if err := writeSummary(ctx, client, *mirrorPath, sum); err != nil {
+			log.WithError(err).Warning("Failed to write mirror")
+			continue
+		}
+		log.Debug("Wrote mirror")
+	}
+}
+
+// ReadSummary reads the summary proto at the specified path.
+func ReadSummary(ctx context.Context, client gcs.Opener, path gcs.Path) (*summarypb.DashboardSummary, error) {
+	r, err := client.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var sum summarypb.DashboardSummary
+	if err := proto.Unmarshal(buf, &sum); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &sum, nil
+}
+
 // pathReader returns a reader for the specified path and last modified, generation metadata.
 func pathReader(ctx context.Context, client gcs.Client, path gcs.Path) (io.ReadCloser, time.Time, int64, error) {
 	r, err := client.Open(ctx, path)
@@ -259,12 +367,23 @@ func pathReader(ctx context.Context, client gcs.Client, path gcs.Path) (io.ReadC
 }
 
 // updateDashboard will summarize all the tabs (through errors), returning an error if any fail to summarize.
-func updateDashboard(ctx context.Context, dash *configpb.Dashboard, finder groupFinder) (*summarypb.DashboardSummary, error) {
+//
+// Tabs which reuse returns a summary for are not summarized again.
+func updateDashboard(ctx context.Context, dash *configpb.Dashboard, finder groupFinder, reuse tabReuser) (*summarypb.DashboardSummary, error) {
 	log := logrus.WithField("dashboard", dash.Name)
 	var badTabs []string
 	var sum summarypb.DashboardSummary
+	var reused int
 	for _, tab := range dash.DashboardTab {
 		log := log.WithField("tab", tab.Name)
+		if reuse != nil {
+			if s := reuse(ctx, tab); s != nil {
+				log.Trace("Reusing unchanged tab summary")
+				reused++
+				sum.TabSummaries = append(sum.TabSummaries, s)
+				continue
+			}
+		}
 		log.Debug("Summarizing tab")
 		s, err := updateTab(ctx, tab, finder)
 		if err != nil {
@@ -274,8 +393,12 @@ func updateDashboard(ctx context.Context, dash *configpb.Dashboard, finder group
 			continue
 		}
 		s.DashboardName = dash.Name
+		assignAlertIDs(s)
 		sum.TabSummaries = append(sum.TabSummaries, s)
 	}
+	if reused > 0 {
+		log.WithField("reused", reused).Debug("Reused unchanged tab summaries")
+	}
 	var err error
 	if d := len(badTabs); d > 0 {
 		err = fmt.Errorf("Failed %d tabs: %s", d, strings.Join(badTabs, ", "))
@@ -289,6 +412,7 @@ func problemTab(dashboardName, tabName string) *summarypb.DashboardTabSummary {
 		DashboardName:    dashboardName,
 		DashboardTabName: tabName,
 		Alert:            "failed to summarize tab",
+		TabAlert:         &summarypb.TabAlert{Reason: summarypb.TabAlert_FAILED},
 	}
 }
 
@@ -310,11 +434,12 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 	if group == nil {
 		return nil, fmt.Errorf("not found: %q", groupName)
 	}
-	grid, mod, _, err := readGrid(ctx, groupReader) // TODO(fejta): track gen
+	grid, mod, gen, err := readGrid(ctx, groupReader)
 	if err != nil && errors.Is(err, storage.ErrObjectNotExist) {
 		return &summarypb.DashboardTabSummary{
 			DashboardTabName: tab.Name,
 			Alert:            noRuns,
+			TabAlert:         &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS},
 			OverallStatus:    overallStatus(nil, 0, noRuns, false, nil),
 			Status:           noRuns,
 			LatestGreen:      noGreens,
@@ -323,6 +448,26 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 	if err != nil {
 		return nil, fmt.Errorf("load %s: %v", groupName, err)
 	}
+	if grid.ArchivePath != "" {
+		_, latestSeconds := latestRun(grid.Columns)
+		return &summarypb.DashboardTabSummary{
+			DashboardTabName:    tab.Name,
+			LastUpdateTimestamp: float64(mod.Unix()),
+			LastRunTimestamp:    float64(latestSeconds),
+			GridGeneration:      gen,
+			Alert:               archivedAlert(grid.Columns),
+			TabAlert:            archivedReason(grid.Columns),
+			OverallStatus:       summarypb.DashboardTabSummary_ARCHIVED,
+			Status:              noRuns,
+			LatestGreen:         noGreens,
+		}, nil
+	}
+
+	grid.Rows = updater.HealthRows(group, grid.Rows)
+	if changed := ignoreStatuses(grid.Rows, tab.GetIgnoredStatuses()); len(changed) > 0 {
+		// Alert as if the ignored results never happened.
+		alert.Set(group, grid.Columns, changed)
+	}
 
 	var healthiness *summarypb.HealthinessInfo
 	if shouldRunHealthiness(tab) {
@@ -331,7 +476,31 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 		if interval <= 0 {
 			interval = DefaultInterval
 		}
-		healthiness = getHealthinessForInterval(grid, tab.Name, time.Now(), interval)
+		healthiness = getHealthinessForInterval(grid, tab.Name, clock(), interval)
+	}
+
+	var budget *summarypb.ErrorBudget
+	var stats []*summarypb.RowStats
+	if eb, days := tab.GetErrorBudget(), tab.GetRowStatsDays(); eb != nil || len(days) > 0 {
+		// These windows usually span more than the recent columns.
+		rows, err := state.Filter(filterMethods(grid.Rows), tab.BaseOptions)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %v", err)
+		}
+		if eb != nil {
+			budget = errorBudget(eb, grid.Columns, rows, clock())
+		}
+		stats = rowStats(days, grid.Columns, rows, clock())
+	}
+
+	var removed []*summarypb.RemovedTest
+	if group.GetTombstoneColumns() > 0 {
+		// Removed tests have no recent results, so check them before filtering.
+		rows, err := state.Filter(filterMethods(grid.Rows), tab.BaseOptions)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %v", err)
+		}
+		removed = removedTests(rows)
 	}
 
 	recent := recentColumns(tab, group)
@@ -340,23 +509,49 @@ func updateTab(ctx context.Context, tab *configpb.DashboardTab, findGroup groupF
 		return nil, fmt.Errorf("filter: %v", err)
 	}
 
+	if opts := tab.GetAlertOptions(); opts.GetNumResultsInFailureWindow() > 0 && opts.GetNumFailuresToAlert() > 0 {
+		alert.Window(grid.Columns, grid.Rows, int(opts.NumFailuresToAlert), int(opts.NumResultsInFailureWindow))
+	}
+	if hours := tab.GetAlertOptions().GetNewTestGraceHours(); hours > 0 {
+		graceNewTests(grid.Rows, clock().Add(-time.Duration(hours)*time.Hour))
+	}
+
 	latest, latestSeconds := latestRun(grid.Columns)
-	alert := staleAlert(mod, latest, staleHours(tab))
+	alert, reason := staleAlert(mod, latest, staleHours(tab))
 	failures := failingTestSummaries(grid.Rows)
 	passingCols, completedCols, passingCells, filledCells, brokenState := gridMetrics(len(grid.Columns), grid.Rows, recent, tab.BrokenColumnThreshold)
-	return &summarypb.DashboardTabSummary{
+	sum := &summarypb.DashboardTabSummary{
 		DashboardTabName:     tab.Name,
 		LastUpdateTimestamp:  float64(mod.Unix()),
 		LastRunTimestamp:     float64(latestSeconds),
+		GridGeneration:       gen,
 		Alert:                alert,
+		TabAlert:             reason,
 		FailingTestSummaries: failures,
 		OverallStatus:        overallStatus(grid, recent, alert, brokenState, failures),
 		Status:               statusMessage(passingCols, completedCols, passingCells, filledCells),
+		StatusCounts: &summarypb.StatusCounts{
+			PassingColumns:   int32(passingCols),
+			CompletedColumns: int32(completedCols),
+			PassingCells:     int32(passingCells),
+			FilledCells:      int32(filledCells),
+		},
 		LatestGreen:          latestGreen(grid, group.UseKubernetesClient),
 		// TODO(fejta): BugUrl
-		Healthiness:  healthiness,
-		LinkedIssues: allLinkedIssues(grid.Rows),
-	}, nil
+		Healthiness:      healthiness,
+		LinkedIssues:     allLinkedIssues(grid.Rows),
+		BudgetViolations: budgetViolations(grid.Rows, recent),
+		ErrorBudget:      budget,
+		RowStats:         stats,
+		RemovedTests:     removed,
+	}
+	if group.Paused {
+		// Paused groups are expected to go stale.
+		sum.Alert = pausedAlert
+		sum.TabAlert = &summarypb.TabAlert{Reason: summarypb.TabAlert_PAUSED}
+		sum.OverallStatus = summarypb.DashboardTabSummary_PAUSED
+	}
+	return sum, nil
 }
 
 // readGrid downloads and deserializes the current test group state.
@@ -367,19 +562,11 @@ func readGrid(ctx context.Context, reader gridReader) (*statepb.Grid, time.Time,
 		return nil, t, 0, fmt.Errorf("open: %w", err)
 	}
 	defer r.Close()
-	zlibReader, err := zlib.NewReader(r)
-	if err != nil {
-		return nil, t, 0, fmt.Errorf("decompress: %v", err)
-	}
-	buf, err := ioutil.ReadAll(zlibReader)
+	g, err := state.Read(r)
 	if err != nil {
-		return nil, t, 0, fmt.Errorf("read: %v", err)
+		return nil, t, 0, err
 	}
-	var g statepb.Grid
-	if err = proto.Unmarshal(buf, &g); err != nil {
-		return nil, t, 0, fmt.Errorf("parse: %v", err)
-	}
-	return &g, mod, gen, nil
+	return g, mod, gen, nil
 }
 
 // recentColumns returns the configured number of recent columns to summarize, or 5.
@@ -397,34 +584,15 @@ func firstFilled(values ...int32) int {
 	return 0
 }
 
-const (
-	includeFilter = "include-filter-by-regex"
-	excludeFilter = "exclude-filter-by-regex"
-	// TODO(fejta): others, which are not used by testgrid.k8s.io
-)
-
 // filterGrid truncates the grid to rows with recent results and matching the white/blacklist.
 func filterGrid(baseOptions string, rows []*statepb.Row, recent int) ([]*statepb.Row, error) {
-
-	vals, err := url.ParseQuery(baseOptions)
-	if err != nil {
-		return nil, fmt.Errorf("parse %q: %v", baseOptions, err)
-	}
-
 	rows = recentRows(rows, recent)
 
 	rows = filterMethods(rows)
 
-	for _, include := range vals[includeFilter] {
-		if rows, err = includeRows(rows, include); err != nil {
-			return nil, fmt.Errorf("bad %s=%s: %v", includeFilter, include, err)
-		}
-	}
-
-	for _, exclude := range vals[excludeFilter] {
-		if rows, err = excludeRows(rows, exclude); err != nil {
-			return nil, fmt.Errorf("bad %s=%s: %v", excludeFilter, exclude, err)
-		}
+	rows, err := state.Filter(rows, baseOptions)
+	if err != nil {
+		return nil, err
 	}
 
 	// TODO(fejta): grouping, which is not used by testgrid.k8s.io
@@ -461,38 +629,6 @@ func filterMethods(rows []*statepb.Row) []*statepb.Row {
 	return filtered
 }
 
-// includeRows returns the subset of rows that match the regex
-func includeRows(in []*statepb.Row, include string) ([]*statepb.Row, error) {
-	re, err := regexp.Compile(include)
-	if err != nil {
-		return nil, err
-	}
-	var rows []*statepb.Row
-	for _, r := range in {
-		if !re.MatchString(r.Name) {
-			continue
-		}
-		rows = append(rows, r)
-	}
-	return rows, nil
-}
-
-// excludeRows returns the subset of rows that do not match the regex
-func excludeRows(in []*statepb.Row, exclude string) ([]*statepb.Row, error) {
-	re, err := regexp.Compile(exclude)
-	if err != nil {
-		return nil, err
-	}
-	var rows []*statepb.Row
-	for _, r := range in {
-		if re.MatchString(r.Name) {
-			continue
-		}
-		rows = append(rows, r)
-	}
-	return rows, nil
-}
-
 // latestRun returns the Time (and seconds-since-epoch) of the most recent run.
 func latestRun(columns []*statepb.Column) (time.Time, int64) {
 	if len(columns) > 0 {
@@ -505,25 +641,48 @@ func latestRun(columns []*statepb.Column) (time.Time, int64) {
 
 const noRuns = "no completed results"
 
-// staleAlert returns an explanatory message if the latest results are stale.
-func staleAlert(mod, ran time.Time, stale time.Duration) string {
+const pausedAlert = "updates are paused for this test group"
+
+// archivedAlert explains that the group is archived until new results appear.
+func archivedAlert(columns []*statepb.Column) string {
+	if len(columns) == 0 || columns[0].Started <= 0 {
+		return "archived until new results appear"
+	}
+	ms := int64(columns[0].Started)
+	last := time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC()
+	return fmt.Sprintf("archived until new results appear, last results at %s", last.Format(time.RFC3339))
+}
+
+// archivedReason returns the structured form of archivedAlert.
+func archivedReason(columns []*statepb.Column) *summarypb.TabAlert {
+	reason := summarypb.TabAlert{Reason: summarypb.TabAlert_ARCHIVED}
+	if len(columns) > 0 && columns[0].Started > 0 {
+		reason.Since = columns[0].Started / 1000
+	}
+	return &reason
+}
+
+// staleAlert returns an explanatory message, and its structured form, if the latest results are stale.
+func staleAlert(mod, ran time.Time, stale time.Duration) (string, *summarypb.TabAlert) {
 	if mod.IsZero() {
-		return "no stored results"
+		return "no stored results", &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_STORED_RESULTS}
 	}
 	if stale == 0 {
-		return ""
+		return "", nil
 	}
 	if ran.IsZero() {
-		return noRuns
+		return noRuns, &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS}
 	}
-	now := time.Now()
+	now := clock()
 	if dur := now.Sub(mod); dur > stale {
-		return fmt.Sprintf("data has not changed since %s (%s old)", mod, dur.Truncate(15*time.Minute))
+		msg := fmt.Sprintf("data has not changed since %s (%s old)", mod, dur.Truncate(15*time.Minute))
+		return msg, &summarypb.TabAlert{Reason: summarypb.TabAlert_UNCHANGED, Since: float64(mod.Unix())}
 	}
 	if dur := now.Sub(ran); dur > stale {
-		return fmt.Sprintf("latest column from %s (%s old)", ran, dur.Truncate(15*time.Minute))
+		msg := fmt.Sprintf("latest column from %s (%s old)", ran, dur.Truncate(15*time.Minute))
+		return msg, &summarypb.TabAlert{Reason: summarypb.TabAlert_OLD_RESULTS, Since: float64(ran.Unix())}
 	}
-	return ""
+	return "", nil
 }
 
 // failingTestSummaries returns details for every row with an active alert.
@@ -564,6 +723,100 @@ func failingTestSummaries(rows []*statepb.Row) []*summarypb.FailingTestSummary {
 	return failures
 }
 
+// removedTests returns every row with a tombstone, most recently seen first.
+func removedTests(rows []*statepb.Row) []*summarypb.RemovedTest {
+	var removed []*summarypb.RemovedTest
+	for _, row := range rows {
+		ts := row.Tombstone
+		if ts == nil {
+			continue
+		}
+		rt := summarypb.RemovedTest{
+			DisplayName: row.Name,
+			TestName:    row.Id,
+			LastBuildId: ts.BuildId,
+		}
+		if t := ts.Time; t != nil {
+			rt.LastTimestamp = float64(t.Seconds) + float64(t.Nanos)/1e9
+		}
+		removed = append(removed, &rt)
+	}
+	sort.SliceStable(removed, func(i, j int) bool {
+		return removed[i].LastTimestamp > removed[j].LastTimestamp
+	})
+	return removed
+}
+
+// budgetViolations returns every row with a result over its duration budget
+// in the recent columns.
+func budgetViolations(rows []*statepb.Row, recent int) []*summarypb.BudgetViolation {
+	var violations []*summarypb.BudgetViolation
+	for _, row := range rows {
+		for _, metric := range row.Metrics {
+			if metric.Name != updater.OverBudgetKey {
+				continue
+			}
+			var v summarypb.BudgetViolation
+			var value int
+			for i := 0; i+1 < len(metric.Indices); i += 2 {
+				start, count := int(metric.Indices[i]), int(metric.Indices[i+1])
+				for col := start; col < start+count; col++ {
+					if col < recent {
+						if v.Violations == 0 {
+							// Columns are ordered newest first.
+							v.LatestOverMinutes = metric.Values[value]
+						}
+						v.Violations++
+					}
+					value++
+				}
+			}
+			if v.Violations > 0 {
+				v.DisplayName = row.Name
+				violations = append(violations, &v)
+			}
+			break
+		}
+	}
+	return violations
+}
+
+// DefaultErrorBudgetDays is the length of error budget windows which do not specify one.
+const DefaultErrorBudgetDays = 30
+
+// errorBudget counts the columns with a failing result which started within the budget's window.
+func errorBudget(budget *configpb.DashboardTab_ErrorBudget, cols []*statepb.Column, rows []*statepb.Row, now time.Time) *summarypb.ErrorBudget {
+	days := firstFilled(budget.GetDays(), DefaultErrorBudgetDays)
+	cutoff := float64(now.AddDate(0, 0, -days).UnixNano() / int64(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := results(ctx, rows)
+	var failing int
+	// Columns are ordered newest first.
+	for _, col := range cols {
+		if col.Started < cutoff {
+			break
+		}
+		var failed bool
+		for _, ch := range results {
+			if result.Failing(coalesceResult(<-ch, result.IgnoreRunning)) {
+				failed = true
+			}
+		}
+		if failed {
+			failing++
+		}
+	}
+	allowed := budget.GetAllowedFailingColumns()
+	return &summarypb.ErrorBudget{
+		AllowedFailingColumns: allowed,
+		FailingColumns:        int32(failing),
+		Remaining:             allowed - int32(failing),
+		Days:                  int32(days),
+	}
+}
+
 // buildFailLink creates a search link
 // TODO(#134): Build proper url for both internal and external jobs
 func buildFailLink(testID, target string) string {
@@ -653,6 +906,7 @@ func allLinkedIssues(rows []*statepb.Row) []string {
 	for issueID := range issueSet {
 		linkedIssues = append(linkedIssues, issueID)
 	}
+	sort.Strings(linkedIssues)
 	return linkedIssues
 }
 
@@ -714,7 +968,7 @@ func fmtStatus(passCols, cols, passCells, cells int) string {
 	return fmt.Sprintf("%d of %d (%.1f%%) recent columns passed (%d of %d or %.1f%% cells)", passCols, cols, colCent, passCells, cells, cellCent)
 }
 
-//  2483 of 115784 tests (2.1%) and 163 of 164 runs (99.4%) failed in the past 7 days
+// 2483 of 115784 tests (2.1%) and 163 of 164 runs (99.4%) failed in the past 7 days
 func statusMessage(passingCols, completedCols, passingCells, filledCells int) string {
 	if filledCells == 0 {
 		return noRuns
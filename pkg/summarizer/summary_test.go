@@ -26,7 +26,6 @@ import (
 	"io/ioutil"
 	"net/url"
 	"reflect"
-	"sort"
 	"testing"
 	"time"
 
@@ -34,7 +33,6 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/testing/protocmp"
 
@@ -43,6 +41,8 @@ import (
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 )
 
@@ -102,6 +102,8 @@ func TestUpdateDashboard(t *testing.T) {
 						DashboardTabName:    "stale-tab",
 						LastUpdateTimestamp: 1000,
 						Alert:               noRuns,
+						TabAlert:            &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS},
+						StatusCounts:        &summarypb.StatusCounts{},
 						OverallStatus:       summarypb.DashboardTabSummary_STALE,
 						Status:              noRuns,
 						LatestGreen:         noGreens,
@@ -159,6 +161,8 @@ func TestUpdateDashboard(t *testing.T) {
 						DashboardTabName:    "working",
 						LastUpdateTimestamp: 1000,
 						Alert:               noRuns,
+						TabAlert:            &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS},
+						StatusCounts:        &summarypb.StatusCounts{},
 						Status:              noRuns,
 						OverallStatus:       summarypb.DashboardTabSummary_STALE,
 						LatestGreen:         noGreens,
@@ -170,6 +174,8 @@ func TestUpdateDashboard(t *testing.T) {
 						DashboardTabName:    "still-working",
 						LastUpdateTimestamp: 1000,
 						Alert:               noRuns,
+						TabAlert:            &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS},
+						StatusCounts:        &summarypb.StatusCounts{},
 						Status:              noRuns,
 						OverallStatus:       summarypb.DashboardTabSummary_STALE,
 						LatestGreen:         noGreens,
@@ -195,7 +201,7 @@ func TestUpdateDashboard(t *testing.T) {
 				}
 				return &fake.group, reader, nil
 			}
-			actual, err := updateDashboard(context.Background(), tc.dash, finder)
+			actual, err := updateDashboard(context.Background(), tc.dash, finder, nil)
 			if err != nil && !tc.err {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -311,12 +317,66 @@ func TestUpdateTab(t *testing.T) {
 			expected: &summarypb.DashboardTabSummary{
 				DashboardTabName:    "foo-tab",
 				LastUpdateTimestamp: float64(now.Unix()),
+				GridGeneration:      43,
 				Alert:               noRuns,
+				TabAlert:            &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS},
+				StatusCounts:        &summarypb.StatusCounts{},
 				LatestGreen:         noGreens,
 				OverallStatus:       summarypb.DashboardTabSummary_STALE,
 				Status:              noRuns,
 			},
 		},
+		{
+			name: "paused groups are paused rather than stale",
+			tab: &configpb.DashboardTab{
+				Name:          "foo-tab",
+				TestGroupName: "foo-group",
+				AlertOptions: &configpb.DashboardTabAlertOptions{
+					AlertStaleResultsHours: 1,
+				},
+			},
+			group: &configpb.TestGroup{Paused: true},
+			mod:   now,
+			gen:   43,
+			expected: &summarypb.DashboardTabSummary{
+				DashboardTabName:    "foo-tab",
+				LastUpdateTimestamp: float64(now.Unix()),
+				GridGeneration:      43,
+				Alert:               pausedAlert,
+				TabAlert:            &summarypb.TabAlert{Reason: summarypb.TabAlert_PAUSED},
+				StatusCounts:        &summarypb.StatusCounts{},
+				LatestGreen:         noGreens,
+				OverallStatus:       summarypb.DashboardTabSummary_PAUSED,
+				Status:              noRuns,
+			},
+		},
+		{
+			name: "archived groups are archived",
+			tab: &configpb.DashboardTab{
+				Name:          "foo-tab",
+				TestGroupName: "foo-group",
+			},
+			group: &configpb.TestGroup{},
+			grid: statepb.Grid{
+				ArchivePath: "gs://cold/archive/foo-group",
+				Columns: []*statepb.Column{
+					{Build: "1", Started: 1000},
+				},
+			},
+			mod: now,
+			gen: 44,
+			expected: &summarypb.DashboardTabSummary{
+				DashboardTabName:    "foo-tab",
+				LastUpdateTimestamp: float64(now.Unix()),
+				LastRunTimestamp:    1000,
+				GridGeneration:      44,
+				Alert:               "archived until new results appear, last results at 1970-01-01T00:00:01Z",
+				TabAlert:            &summarypb.TabAlert{Reason: summarypb.TabAlert_ARCHIVED, Since: 1},
+				LatestGreen:         noGreens,
+				OverallStatus:       summarypb.DashboardTabSummary_ARCHIVED,
+				Status:              noRuns,
+			},
+		},
 		{
 			name: "missing grid returns a blank summary",
 			tab: &configpb.DashboardTab{
@@ -327,6 +387,7 @@ func TestUpdateTab(t *testing.T) {
 			expected: &summarypb.DashboardTabSummary{
 				DashboardTabName: "you know",
 				Alert:            noRuns,
+				TabAlert:         &summarypb.TabAlert{Reason: summarypb.TabAlert_NO_COMPLETED_RESULTS},
 				OverallStatus:    summarypb.DashboardTabSummary_STALE,
 				Status:           noRuns,
 				LatestGreen:      noGreens,
@@ -538,7 +599,7 @@ func TestAllLinkedIssues(t *testing.T) {
 					BugId:   []string{"10", "7"},
 				},
 			},
-			want: []string{"1", "2", "5", "7", "10"},
+			want: []string{"1", "10", "2", "5", "7"},
 		},
 		{
 			name: "multiple linked issues with duplicates",
@@ -561,15 +622,162 @@ func TestAllLinkedIssues(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			got := allLinkedIssues(tc.rows)
-			sort.Strings(got)
-			strSort := cmpopts.SortSlices(func(a, b string) bool { return a < b })
-			if diff := cmp.Diff(tc.want, got, strSort); diff != "" {
+			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("allLinkedIssues() unexpected diff (-want +got): %s", diff)
 			}
 		})
 	}
 }
 
+func TestRemovedTests(t *testing.T) {
+	rows := []*statepb.Row{
+		{Name: "present", Id: "//present"},
+		{
+			Name:      "older",
+			Id:        "//older",
+			Tombstone: &statepb.Tombstone{BuildId: "5", Time: &timestamp.Timestamp{Seconds: 500}, MissingColumns: 7},
+		},
+		{
+			Name:      "newer",
+			Id:        "//newer",
+			Tombstone: &statepb.Tombstone{BuildId: "9", Time: &timestamp.Timestamp{Seconds: 900, Nanos: 500000000}, MissingColumns: 3},
+		},
+	}
+	want := []*summarypb.RemovedTest{
+		{DisplayName: "newer", TestName: "//newer", LastBuildId: "9", LastTimestamp: 900.5},
+		{DisplayName: "older", TestName: "//older", LastBuildId: "5", LastTimestamp: 500},
+	}
+	if diff := cmp.Diff(want, removedTests(rows), protocmp.Transform()); diff != "" {
+		t.Errorf("removedTests() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := removedTests(rows[:1]); got != nil {
+		t.Errorf("removedTests() got %v, want nil", got)
+	}
+}
+
+func TestBudgetViolations(t *testing.T) {
+	cases := []struct {
+		name   string
+		rows   []*statepb.Row
+		recent int
+		want   []*summarypb.BudgetViolation
+	}{
+		{
+			name:   "basically works",
+			recent: 5,
+		},
+		{
+			name:   "ignore other metrics",
+			recent: 5,
+			rows: []*statepb.Row{
+				{
+					Name: "fine",
+					Metrics: []*statepb.Metric{
+						{Name: updater.ElapsedKey, Indices: []int32{0, 2}, Values: []float64{1, 2}},
+					},
+				},
+			},
+		},
+		{
+			name:   "count recent violations",
+			recent: 5,
+			rows: []*statepb.Row{
+				{
+					Name: "slow",
+					Metrics: []*statepb.Metric{
+						{Name: updater.ElapsedKey, Indices: []int32{0, 8}, Values: []float64{1, 2, 3, 4, 5, 6, 7, 8}},
+						{Name: updater.OverBudgetKey, Indices: []int32{1, 2, 4, 3}, Values: []float64{0.5, 1, 2, 3, 4}},
+					},
+				},
+				{
+					Name: "old",
+					Metrics: []*statepb.Metric{
+						{Name: updater.OverBudgetKey, Indices: []int32{6, 1}, Values: []float64{10}},
+					},
+				},
+			},
+			want: []*summarypb.BudgetViolation{
+				{DisplayName: "slow", Violations: 3, LatestOverMinutes: 0.5},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := budgetViolations(tc.rows, tc.recent)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("budgetViolations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestErrorBudget(t *testing.T) {
+	now := time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) float64 {
+		return float64(now.AddDate(0, 0, -d).UnixNano() / int64(time.Millisecond))
+	}
+	cols := []*statepb.Column{
+		{Build: "5", Started: daysAgo(1)},
+		{Build: "4", Started: daysAgo(2)},
+		{Build: "3", Started: daysAgo(10)},
+		{Build: "2", Started: daysAgo(20)},
+		{Build: "1", Started: daysAgo(40)},
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	running := int32(statuspb.TestStatus_RUNNING)
+	rows := []*statepb.Row{
+		{Name: "a", Results: []int32{running, 1, pass, 1, fail, 1, pass, 1, fail, 1}},
+		{Name: "b", Results: []int32{fail, 1, pass, 2, fail, 2}},
+	}
+	cases := []struct {
+		name   string
+		budget *configpb.DashboardTab_ErrorBudget
+		want   *summarypb.ErrorBudget
+	}{
+		{
+			name:   "default window",
+			budget: &configpb.DashboardTab_ErrorBudget{AllowedFailingColumns: 5},
+			want: &summarypb.ErrorBudget{
+				AllowedFailingColumns: 5,
+				FailingColumns:        3,
+				Remaining:             2,
+				Days:                  30,
+			},
+		},
+		{
+			name:   "shorter window",
+			budget: &configpb.DashboardTab_ErrorBudget{AllowedFailingColumns: 1, Days: 7},
+			want: &summarypb.ErrorBudget{
+				AllowedFailingColumns: 1,
+				FailingColumns:        1,
+				Remaining:             0,
+				Days:                  7,
+			},
+		},
+		{
+			name:   "overspent",
+			budget: &configpb.DashboardTab_ErrorBudget{AllowedFailingColumns: 1, Days: 60},
+			want: &summarypb.ErrorBudget{
+				AllowedFailingColumns: 1,
+				FailingColumns:        4,
+				Remaining:             -3,
+				Days:                  60,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := errorBudget(tc.budget, cols, rows, now)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("errorBudget() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestFirstFilled(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -615,8 +823,8 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "everything works",
 			baseOptions: url.Values{
-				includeFilter: []string{"foo"},
-				excludeFilter: []string{"bar"},
+				state.IncludeFilter: []string{"foo"},
+				state.ExcludeFilter: []string{"bar"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -646,7 +854,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "must match all includes",
 			baseOptions: url.Values{
-				includeFilter: []string{"foo", "spam"},
+				state.IncludeFilter: []string{"foo", "spam"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -674,7 +882,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "exclude any exclusions",
 			baseOptions: url.Values{
-				excludeFilter: []string{"not", "nope"},
+				state.ExcludeFilter: []string{"not", "nope"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -702,7 +910,7 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "exclude all test methods",
 			baseOptions: url.Values{
-				includeFilter: []string{"test"},
+				state.IncludeFilter: []string{"test"},
 			}.Encode(),
 			rows: []*statepb.Row{
 				{
@@ -740,14 +948,14 @@ func TestFilterGrid(t *testing.T) {
 		{
 			name: "bad inclusion regexp errors",
 			baseOptions: url.Values{
-				includeFilter: []string{"this.("},
+				state.IncludeFilter: []string{"this.("},
 			}.Encode(),
 			err: true,
 		},
 		{
 			name: "bad exclude regexp errors",
 			baseOptions: url.Values{
-				excludeFilter: []string{"this.("},
+				state.ExcludeFilter: []string{"this.("},
 			}.Encode(),
 			err: true,
 		},
@@ -867,116 +1075,6 @@ func TestRecentRows(t *testing.T) {
 	}
 }
 
-func TestIncludeRows(t *testing.T) {
-	cases := []struct {
-		name     string
-		names    []string
-		include  string
-		expected []string
-		err      bool
-	}{
-		{
-			name: "basically works",
-		},
-		{
-			name:    "bad regex errors",
-			include: "^[a-z",
-			err:     true,
-		},
-		{
-			name:    "return nothing rows when nothing matches",
-			names:   []string{"hello", "world"},
-			include: "dog",
-		},
-		{
-			name:     "include only matching rows",
-			include:  "fun",
-			names:    []string{"apply", "function", "to", "funny", "bone"},
-			expected: []string{"function", "funny"},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			var rows []*statepb.Row
-			for _, n := range tc.names {
-				rows = append(rows, &statepb.Row{Name: n})
-			}
-			actualRows, err := includeRows(rows, tc.include)
-			var actual []string
-			for _, r := range actualRows {
-				actual = append(actual, r.Name)
-			}
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("failed to return expected error")
-			case !reflect.DeepEqual(actual, tc.expected):
-				t.Errorf("actual %s != expected %s", actual, tc.expected)
-			}
-		})
-	}
-}
-
-func TestExcludeRows(t *testing.T) {
-	cases := []struct {
-		name     string
-		names    []string
-		exclude  string
-		expected []string
-		err      bool
-	}{
-		{
-			name: "basically works",
-		},
-		{
-			name:    "bad regex errors",
-			exclude: "^[a-z",
-			err:     true,
-		},
-		{
-			name:     "return all rows when nothing matches",
-			names:    []string{"hello", "world"},
-			exclude:  "dog",
-			expected: []string{"hello", "world"},
-		},
-		{
-			name:     "drop matching rows",
-			exclude:  "fun",
-			names:    []string{"apply", "function", "to", "funny", "bone"},
-			expected: []string{"apply", "to", "bone"},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			var rows []*statepb.Row
-			for _, n := range tc.names {
-				rows = append(rows, &statepb.Row{Name: n})
-			}
-			actualRows, err := excludeRows(rows, tc.exclude)
-			var actual []string
-			for _, r := range actualRows {
-				actual = append(actual, r.Name)
-			}
-			switch {
-			case err != nil:
-				if !tc.err {
-					t.Errorf("unexpected error: %v", err)
-				}
-			case tc.err:
-				t.Error("failed to return expected error")
-			case !reflect.DeepEqual(actual, tc.expected):
-				t.Errorf("actual %s != expected %s", actual, tc.expected)
-			}
-		})
-	}
-
-}
-
 func TestLatestRun(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -1067,13 +1165,16 @@ func TestStaleAlert(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := staleAlert(tc.mod, tc.ran, tc.dur)
+			actual, reason := staleAlert(tc.mod, tc.ran, tc.dur)
 			if actual != "" && !tc.alert {
 				t.Errorf("unexpected stale alert: %s", actual)
 			}
 			if actual == "" && tc.alert {
 				t.Errorf("failed to create a stale alert")
 			}
+			if (reason == nil) != (actual == "") {
+				t.Errorf("staleAlert() got reason %v for alert %q", reason, actual)
+			}
 		})
 	}
 }
@@ -2429,17 +2530,17 @@ func TestSummaryPath(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := summaryPath(tc.path, tc.prefix, tc.dash)
+			got, err := SummaryPath(tc.path, tc.prefix, tc.dash)
 			switch {
 			case err != nil:
 				if !tc.err {
-					t.Errorf("summaryPath(%q, %q, %q) got unexpected error: %v", tc.path, tc.prefix, tc.dash, err)
+					t.Errorf("SummaryPath(%q, %q, %q) got unexpected error: %v", tc.path, tc.prefix, tc.dash, err)
 				}
 			case tc.err:
-				t.Errorf("summaryPath(%q, %q, %q) failed to get an error", tc.path, tc.prefix, tc.name)
+				t.Errorf("SummaryPath(%q, %q, %q) failed to get an error", tc.path, tc.prefix, tc.name)
 			default:
 				if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(gcs.Path{})); diff != "" {
-					t.Errorf("summaryPath(%q, %q, %q) got unexpected diff (-want +got):\n%s", tc.path, tc.prefix, tc.dash, diff)
+					t.Errorf("SummaryPath(%q, %q, %q) got unexpected diff (-want +got):\n%s", tc.path, tc.prefix, tc.dash, diff)
 				}
 			}
 		})
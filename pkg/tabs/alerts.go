@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+const week = 7 * 24 * time.Hour
+
+// AlertMetrics computes the alert lifecycle metrics of the period from the
+// alert history in each dashboard's summary.
+//
+// Dashboards without a summary are skipped.
+func (r Reader) AlertMetrics(ctx context.Context, start, end time.Time) (*responsepb.AlertMetrics, error) {
+	summaries := map[string]*summarypb.DashboardSummary{}
+	for _, d := range r.Config.GetDashboards() {
+		sum, err := r.readSummary(ctx, d.Name)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s summary: %w", d.Name, err)
+		}
+		summaries[d.Name] = sum
+	}
+	return AlertMetrics(r.Config, summaries, start, end), nil
+}
+
+// AlertMetrics computes the alert lifecycle metrics of the period from the
+// alert history of the summaries, keyed by dashboard name.
+//
+// Alerts count as opened or resolved in the period when they opened or
+// resolved in it. Teams default to the dashboard name.
+func AlertMetrics(cfg *configpb.Configuration, summaries map[string]*summarypb.DashboardSummary, start, end time.Time) *responsepb.AlertMetrics {
+	from, to := float64(start.Unix()), float64(end.Unix())
+	metrics := responsepb.AlertMetrics{Start: from, End: to}
+	for t := start; t.Before(end); t = t.Add(week) {
+		metrics.Weeks = append(metrics.Weeks, &responsepb.WeeklyAlertCounts{Start: float64(t.Unix())})
+	}
+	weekOf := func(seconds float64) *responsepb.WeeklyAlertCounts {
+		return metrics.Weeks[int((seconds-from)/week.Seconds())]
+	}
+	within := func(seconds float64) bool {
+		return seconds >= from && seconds < to
+	}
+
+	teams := map[string]*responsepb.TeamAlertMetrics{}
+	for _, d := range cfg.GetDashboards() {
+		sum, ok := summaries[d.Name]
+		if !ok {
+			continue
+		}
+		team := d.GetTeam()
+		if team == "" {
+			team = d.Name
+		}
+		tm, ok := teams[team]
+		if !ok {
+			tm = &responsepb.TeamAlertMetrics{Team: team}
+			teams[team] = tm
+		}
+		for _, tab := range sum.TabSummaries {
+			m := responsepb.TabAlertMetrics{
+				Dashboard: d.Name,
+				Tab:       tab.DashboardTabName,
+				Team:      team,
+			}
+			var toGreen float64
+			for _, e := range tab.AlertHistory {
+				if within(e.Opened) {
+					m.Opened++
+					weekOf(e.Opened).Opened++
+				}
+				if e.Resolved != 0 && within(e.Resolved) {
+					m.Resolved++
+					weekOf(e.Resolved).Resolved++
+					toGreen += e.Resolved - e.Opened
+				}
+			}
+			if n := len(tab.AlertHistory); n > 0 {
+				last := tab.AlertHistory[n-1]
+				m.Failing = last.Resolved == 0 && last.Opened < to
+			}
+			if m.Resolved > 0 {
+				m.MeanTimeToGreen = toGreen / float64(m.Resolved)
+			}
+			metrics.Tabs = append(metrics.Tabs, &m)
+
+			// Weight each tab's mean by its resolved alerts.
+			resolved := tm.Resolved + m.Resolved
+			if resolved > 0 {
+				tm.MeanTimeToResolve = (tm.MeanTimeToResolve*float64(tm.Resolved) + toGreen) / float64(resolved)
+			}
+			tm.Opened += m.Opened
+			tm.Resolved = resolved
+			if m.Failing {
+				tm.Failing++
+			}
+		}
+	}
+
+	sort.SliceStable(metrics.Tabs, func(i, j int) bool {
+		a, b := metrics.Tabs[i], metrics.Tabs[j]
+		if a.Dashboard != b.Dashboard {
+			return a.Dashboard < b.Dashboard
+		}
+		return a.Tab < b.Tab
+	})
+	for _, tm := range teams {
+		metrics.Teams = append(metrics.Teams, tm)
+	}
+	sort.Slice(metrics.Teams, func(i, j int) bool {
+		return metrics.Teams[i].Team < metrics.Teams[j].Team
+	})
+	return &metrics
+}
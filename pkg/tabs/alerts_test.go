@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestAlertMetrics(t *testing.T) {
+	start := time.Unix(1000000, 0)
+	end := start.Add(2 * week)
+	at := func(d time.Duration) float64 {
+		return float64(start.Add(d).Unix())
+	}
+	hour := time.Hour
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{Name: "storage", Team: "infra"},
+			{Name: "network", Team: "infra"},
+			{Name: "ui"},
+			{Name: "unsummarized"},
+		},
+	}
+	summaries := map[string]*summarypb.DashboardSummary{
+		"storage": {
+			TabSummaries: []*summarypb.DashboardTabSummary{
+				{
+					DashboardTabName: "disk",
+					AlertHistory: []*summarypb.AlertEpisode{
+						{Opened: at(-2 * hour), Resolved: at(2 * hour)}, // opened before the period
+						{Opened: at(10 * hour), Resolved: at(14 * hour)},
+						{Opened: at(week + hour)},
+					},
+				},
+			},
+		},
+		"network": {
+			TabSummaries: []*summarypb.DashboardTabSummary{
+				{
+					DashboardTabName: "dns",
+					AlertHistory: []*summarypb.AlertEpisode{
+						{Opened: at(week), Resolved: at(week + 10*hour)},
+					},
+				},
+			},
+		},
+		"ui": {
+			TabSummaries: []*summarypb.DashboardTabSummary{{DashboardTabName: "e2e"}},
+		},
+	}
+
+	got := AlertMetrics(cfg, summaries, start, end)
+	want := &responsepb.AlertMetrics{
+		Start: at(0),
+		End:   at(2 * week),
+		Tabs: []*responsepb.TabAlertMetrics{
+			{
+				Dashboard:       "network",
+				Tab:             "dns",
+				Team:            "infra",
+				Opened:          1,
+				Resolved:        1,
+				MeanTimeToGreen: (10 * hour).Seconds(),
+			},
+			{
+				Dashboard:       "storage",
+				Tab:             "disk",
+				Team:            "infra",
+				Opened:          2,
+				Resolved:        2,
+				Failing:         true,
+				MeanTimeToGreen: (4 * hour).Seconds(),
+			},
+			{Dashboard: "ui", Tab: "e2e", Team: "ui"},
+		},
+		Teams: []*responsepb.TeamAlertMetrics{
+			{
+				Team:              "infra",
+				Opened:            3,
+				Resolved:          3,
+				Failing:           1,
+				MeanTimeToResolve: (6 * hour).Seconds(),
+			},
+			{Team: "ui"},
+		},
+		Weeks: []*responsepb.WeeklyAlertCounts{
+			{Start: at(0), Opened: 1, Resolved: 2},
+			{Start: at(week), Opened: 2, Resolved: 1},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("AlertMetrics() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestReaderAlertMetrics(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	now := time.Now()
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "tab",
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: float64(now.Add(-time.Hour).Unix())}},
+			},
+		},
+	}
+	buf, err := proto.Marshal(sum)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	reader := Reader{
+		Client: fake.Opener{
+			mustPath("gs://bucket/summary/summary-dash"): {Data: string(buf)},
+		},
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{{Name: "dash"}, {Name: "missing"}},
+		},
+		ConfigPath:    mustPath("gs://bucket/config"),
+		SummaryPrefix: "summary",
+	}
+	got, err := reader.AlertMetrics(context.Background(), now.Add(-week), now)
+	if err != nil {
+		t.Fatalf("AlertMetrics() got unexpected error: %v", err)
+	}
+	want := []*responsepb.TabAlertMetrics{{Dashboard: "dash", Tab: "tab", Team: "dash", Opened: 1, Failing: true}}
+	if diff := cmp.Diff(want, got.Tabs, protocmp.Transform()); diff != "" {
+		t.Errorf("AlertMetrics() got unexpected tabs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+)
+
+// Cache holds recently decoded grids and summaries so requests skip downloading them.
+//
+// Cached values are shared between requests and must not be modified.
+type Cache struct {
+	// TTL expires entries after this long.
+	TTL time.Duration
+	// MaxEntries evicts the least recently used entries beyond this many, unlimited when zero.
+	MaxEntries int
+
+	now     func() time.Time
+	lock    sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	val    interface{}
+	loaded time.Time
+	used   time.Time
+}
+
+func (c *Cache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// get returns the cached value of the key, or stores the loaded value unless it fails.
+//
+// Refresh ignores any cached value.
+func (c *Cache) get(key string, refresh bool, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+	now := c.clock()
+	c.lock.Lock()
+	if e, ok := c.entries[key]; ok && !refresh && now.Sub(e.loaded) < c.TTL {
+		e.used = now
+		c.lock.Unlock()
+		return e.val, nil
+	}
+	c.lock.Unlock()
+
+	val, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]*cacheEntry{}
+	}
+	c.entries[key] = &cacheEntry{val: val, loaded: now, used: now}
+	c.evict()
+	return val, nil
+}
+
+// evict drops expired entries and then the least recently used ones beyond MaxEntries.
+func (c *Cache) evict() {
+	now := c.clock()
+	for key, e := range c.entries {
+		if now.Sub(e.loaded) >= c.TTL {
+			delete(c.entries, key)
+		}
+	}
+	if c.MaxEntries <= 0 || len(c.entries) <= c.MaxEntries {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].used.Before(c.entries[keys[j]].used)
+	})
+	for _, key := range keys[:len(keys)-c.MaxEntries] {
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.entries)
+}
+
+// Warm reads the summary and tab states of each tab in the dashboard into the cache,
+// replacing any cached copies, or returns an ErrNotFound error.
+//
+// Warming the full history also reads the test group state of tabs with a tab state.
+func (r Reader) Warm(ctx context.Context, dashboard string, fullHistory bool) (*responsepb.CacheWarm, error) {
+	dash := config.FindDashboard(dashboard, r.Config)
+	if dash == nil {
+		return nil, fmt.Errorf("dashboard %q: %w", dashboard, ErrNotFound)
+	}
+	r.refresh = true
+	var reqs []Request
+	for _, tab := range dash.DashboardTab {
+		reqs = append(reqs, Request{Dashboard: dash.Name, Tab: tab.Name, Columns: math.MaxInt32})
+		if fullHistory && r.TabPrefix != "" {
+			reqs = append(reqs, Request{Dashboard: dash.Name, Tab: tab.Name, Columns: math.MaxInt32, FullHistory: true})
+		}
+	}
+	out := responsepb.CacheWarm{Dashboard: dash.Name}
+	failed := map[string]bool{}
+	for _, res := range r.GetTabs(ctx, reqs) {
+		if res.Err != nil && !failed[res.Tab] {
+			failed[res.Tab] = true
+			out.FailedTabs = append(out.FailedTabs, res.Tab)
+		}
+	}
+	out.Tabs = int32(len(dash.DashboardTab) - len(out.FailedTabs))
+	return &out, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestCacheGet(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cache := Cache{TTL: time.Minute, MaxEntries: 2, now: func() time.Time { return now }}
+	var loads int
+	load := func(val string) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			loads++
+			return val, nil
+		}
+	}
+	get := func(key string, refresh bool, val string) string {
+		got, err := cache.get(key, refresh, load(val))
+		if err != nil {
+			t.Fatalf("get(%q) got unexpected error: %v", key, err)
+		}
+		return got.(string)
+	}
+
+	if got := get("a", false, "first"); got != "first" || loads != 1 {
+		t.Errorf("get() got %q after %d loads, want first after 1", got, loads)
+	}
+	if got := get("a", false, "second"); got != "first" || loads != 1 {
+		t.Errorf("get() of a cached key got %q after %d loads, want first after 1", got, loads)
+	}
+	if got := get("a", true, "refreshed"); got != "refreshed" || loads != 2 {
+		t.Errorf("get() with refresh got %q after %d loads, want refreshed after 2", got, loads)
+	}
+	now = now.Add(time.Minute)
+	if got := get("a", false, "expired"); got != "expired" || loads != 3 {
+		t.Errorf("get() of an expired key got %q after %d loads, want expired after 3", got, loads)
+	}
+
+	if _, err := cache.get("b", false, func() (interface{}, error) { return nil, errors.New("boom") }); err == nil {
+		t.Error("get() failed to return an error")
+	}
+	if n := cache.Len(); n != 1 {
+		t.Errorf("get() cached a failure, got %d entries, want 1", n)
+	}
+
+	now = now.Add(time.Second)
+	get("b", false, "b")
+	now = now.Add(time.Second)
+	get("c", false, "c")
+	if n := cache.Len(); n != 2 {
+		t.Errorf("get() got %d entries, want at most 2", n)
+	}
+	if got := get("a", false, "evicted"); got != "evicted" {
+		t.Errorf("get() of the least recently used key got %q, want it evicted", got)
+	}
+}
+
+func TestWarm(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tab", TestGroupName: "group"},
+					{Name: "missing", TestGroupName: "missing"},
+				},
+			},
+		},
+	}
+	grid := &statepb.Grid{Columns: []*statepb.Column{{Build: "1"}}}
+	sum, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{{DashboardTabName: "tab"}},
+	})
+	if err != nil {
+		t.Fatalf("marshal summary: %v", err)
+	}
+	opener := fake.Opener{
+		mustPath("gs://bucket/summary/summary-dash"): {Data: string(sum)},
+		mustPath("gs://bucket/grid/group"):           {Data: compressGrid(t, grid)},
+	}
+	reader := Reader{
+		Client:        opener,
+		Config:        cfg,
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+		Cache:         &Cache{TTL: time.Hour},
+	}
+
+	if _, err := reader.Warm(context.Background(), "nope", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Warm() of a missing dashboard got %v, want ErrNotFound", err)
+	}
+
+	got, err := reader.Warm(context.Background(), "dash", false)
+	if err != nil {
+		t.Fatalf("Warm() got unexpected error: %v", err)
+	}
+	want := &responsepb.CacheWarm{Dashboard: "dash", Tabs: 1, FailedTabs: []string{"missing"}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Warm() got unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Serve the warmed tab after its state disappears.
+	delete(opener, mustPath("gs://bucket/grid/group"))
+	res := reader.GetTabs(context.Background(), []Request{{Dashboard: "dash", Tab: "tab", Columns: 10}})[0]
+	if res.Err != nil {
+		t.Fatalf("GetTabs() got unexpected error: %v", res.Err)
+	}
+	if diff := cmp.Diff(grid, res.Grid, protocmp.Transform()); diff != "" {
+		t.Errorf("GetTabs() got unexpected grid (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// ColumnDetail returns the detail of the newest column with the build, or nil if the grid has no such column.
+//
+// The column must also have the name when it is set.
+func ColumnDetail(grid *statepb.Grid, headers []*configpb.TestGroup_ColumnHeader, build, name string) *responsepb.ColumnDetail {
+	for _, col := range grid.GetColumns() {
+		if col.Build != build || (name != "" && col.Name != name) {
+			continue
+		}
+		return columnDetail(col, headers)
+	}
+	return nil
+}
+
+// ColumnList returns the detail of every column in the grid.
+func ColumnList(grid *statepb.Grid, headers []*configpb.TestGroup_ColumnHeader) *responsepb.ColumnList {
+	var list responsepb.ColumnList
+	for _, col := range grid.GetColumns() {
+		list.Columns = append(list.Columns, columnDetail(col, headers))
+	}
+	return &list
+}
+
+// columnDetail labels the header values of the column.
+func columnDetail(col *statepb.Column, headers []*configpb.TestGroup_ColumnHeader) *responsepb.ColumnDetail {
+	detail := responsepb.ColumnDetail{
+		Build:              col.Build,
+		Name:               col.Name,
+		Started:            col.Started,
+		Metadata:           col.Metadata,
+		Broken:             col.Broken,
+		Source:             col.Source,
+		Date:               col.Date,
+		MergedBuilds:       col.MergedBuilds,
+		DroppedCells:       col.DroppedCells,
+		MetadataViolations: col.MetadataViolations,
+		SkippedBuilds:      col.SkippedBuilds,
+		OlderBuilds:        col.OlderBuilds,
+		OverrideReason:     col.GetOverride().GetReason(),
+	}
+	for i, val := range col.Extra {
+		var label string
+		if i < len(headers) {
+			label = headerLabel(headers[i])
+		}
+		detail.Headers = append(detail.Headers, &responsepb.ColumnHeaderValue{Label: label, Value: val})
+	}
+	return &detail
+}
+
+// headerLabel returns the display label of the header.
+func headerLabel(h *configpb.TestGroup_ColumnHeader) string {
+	switch {
+	case h.Label != "":
+		return h.Label
+	case h.ConfigurationValue != "":
+		return h.ConfigurationValue
+	}
+	return h.Property
+}
+
+// Column returns the detail of a column in the dashboard tab, or an ErrNotFound error.
+//
+// Columns outside the tab's column window are included.
+func (r Reader) Column(ctx context.Context, dashboard, tab, build, name string) (*responsepb.ColumnDetail, error) {
+	_, dt, err := r.findTab(dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	req := Request{Dashboard: dashboard, Tab: tab, Columns: math.MaxInt32, FullHistory: true}
+	res := r.GetTabs(ctx, []Request{req})[0]
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	group := config.FindTestGroup(dt.TestGroupName, r.Config)
+	detail := ColumnDetail(res.Grid, group.GetColumnHeader(), build, name)
+	if detail == nil {
+		return nil, fmt.Errorf("column %q: %w", build, ErrNotFound)
+	}
+	return detail, nil
+}
+
+// Columns returns the detail of each column in the result's grid.
+func (r Reader) Columns(res Result) *responsepb.ColumnList {
+	var headers []*configpb.TestGroup_ColumnHeader
+	if _, dt, err := r.findTab(res.Dashboard, res.Tab); err == nil {
+		headers = config.FindTestGroup(dt.TestGroupName, r.Config).GetColumnHeader()
+	}
+	return ColumnList(res.Grid, headers)
+}
+
+// Collisions returns the detail of each column in the result's grid which
+// merged several builds or dropped colliding cells.
+func (r Reader) Collisions(res Result) *responsepb.ColumnList {
+	var list responsepb.ColumnList
+	for _, col := range r.Columns(res).Columns {
+		if len(col.MergedBuilds) > 1 || col.DroppedCells > 0 {
+			list.Columns = append(list.Columns, col)
+		}
+	}
+	return &list
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestColumnDetail(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: 300, Extra: []string{"def"}},
+			{Build: "2", Name: "retry", Started: 200, Extra: []string{"abc"}},
+			{
+				Build:    "1",
+				Started:  100,
+				Extra:    []string{"123", "extra"},
+				Metadata: map[string]string{"node_image": "cos-85", "pod.node": "node-1"},
+				Broken:   true,
+				Source:   "presubmit",
+				Date:     "1970-01-01 00:00",
+
+				MetadataViolations: []string{`finished.json: missing "passed"`},
+			},
+		},
+	}
+	headers := []*configpb.TestGroup_ColumnHeader{
+		{ConfigurationValue: "Commit"},
+	}
+	cases := []struct {
+		name  string
+		build string
+		col   string
+		want  *responsepb.ColumnDetail
+	}{
+		{
+			name:  "column with metadata",
+			build: "1",
+			want: &responsepb.ColumnDetail{
+				Build:   "1",
+				Started: 100,
+				Headers: []*responsepb.ColumnHeaderValue{
+					{Label: "Commit", Value: "123"},
+					{Value: "extra"},
+				},
+				Metadata: map[string]string{"node_image": "cos-85", "pod.node": "node-1"},
+				Broken:   true,
+				Source:   "presubmit",
+				Date:     "1970-01-01 00:00",
+
+				MetadataViolations: []string{`finished.json: missing "passed"`},
+			},
+		},
+		{
+			name:  "newest column of the build",
+			build: "2",
+			want: &responsepb.ColumnDetail{
+				Build:   "2",
+				Started: 300,
+				Headers: []*responsepb.ColumnHeaderValue{{Label: "Commit", Value: "def"}},
+			},
+		},
+		{
+			name:  "named column",
+			build: "2",
+			col:   "retry",
+			want: &responsepb.ColumnDetail{
+				Build:   "2",
+				Name:    "retry",
+				Started: 200,
+				Headers: []*responsepb.ColumnHeaderValue{{Label: "Commit", Value: "abc"}},
+			},
+		},
+		{
+			name:  "missing name",
+			build: "2",
+			col:   "missing",
+		},
+		{
+			name:  "missing build",
+			build: "3",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ColumnDetail(grid, headers, tc.build, tc.col)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ColumnDetail() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnList(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2", Started: 200, Extra: []string{"def"}, SkippedBuilds: 3, Override: &statepb.BuildOverride{Build: "2", Reason: "bad node"}},
+			{Build: "1", Started: 100, Extra: []string{"abc"}, Broken: true, OlderBuilds: 7},
+		},
+	}
+	headers := []*configpb.TestGroup_ColumnHeader{{Label: "Commit", Property: "commit"}}
+	want := &responsepb.ColumnList{
+		Columns: []*responsepb.ColumnDetail{
+			{
+				Build:          "2",
+				Started:        200,
+				Headers:        []*responsepb.ColumnHeaderValue{{Label: "Commit", Value: "def"}},
+				SkippedBuilds:  3,
+				OverrideReason: "bad node",
+			},
+			{
+				Build:       "1",
+				Started:     100,
+				Headers:     []*responsepb.ColumnHeaderValue{{Label: "Commit", Value: "abc"}},
+				Broken:      true,
+				OlderBuilds: 7,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, ColumnList(grid, headers), protocmp.Transform()); diff != "" {
+		t.Errorf("ColumnList() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "2021-03-02", MergedBuilds: []string{"4", "3"}},
+			{Build: "2021-03-01", MergedBuilds: []string{"2"}},
+			{Build: "2021-02-28", MergedBuilds: []string{"1"}, DroppedCells: 2},
+		},
+	}
+	want := &responsepb.ColumnList{
+		Columns: []*responsepb.ColumnDetail{
+			{Build: "2021-03-02", MergedBuilds: []string{"4", "3"}},
+			{Build: "2021-02-28", MergedBuilds: []string{"1"}, DroppedCells: 2},
+		},
+	}
+	got := Reader{}.Collisions(Result{Grid: grid})
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Collisions() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
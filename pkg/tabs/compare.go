@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+)
+
+// latestResults returns the latest result of each row with a completed
+// result in the columns which started before the millisecond timestamp, or in
+// every column when it is zero.
+func latestResults(grid *statepb.Grid, before float64) map[string]statuspb.TestStatus {
+	var skip int
+	if before > 0 {
+		// Columns are ordered newest first.
+		for skip < len(grid.Columns) && grid.Columns[skip].Started >= before {
+			skip++
+		}
+	}
+	out := make(map[string]statuspb.TestStatus, len(grid.Rows))
+	for _, row := range grid.Rows {
+		results := state.Expand(row.Results)
+		if skip < len(results) {
+			results = results[skip:]
+		} else {
+			results = nil
+		}
+		for _, res := range results {
+			if res = result.Coalesce(res, result.IgnoreRunning); res != statuspb.TestStatus_NO_RESULT {
+				out[row.Name] = res
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Compare returns the rows whose latest result changed from the base grid,
+// considering columns which started before the millisecond timestamp unless
+// it is zero, to the target grid.
+func Compare(base *statepb.Grid, before float64, target *statepb.Grid) *responsepb.TabComparison {
+	baseResults := latestResults(base, before)
+	targetResults := latestResults(target, 0)
+	names := make([]string, 0, len(baseResults)+len(targetResults))
+	for name := range baseResults {
+		names = append(names, name)
+	}
+	for name := range targetResults {
+		if _, ok := baseResults[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var out responsepb.TabComparison
+	for _, name := range names {
+		b, inBase := baseResults[name]
+		t, inTarget := targetResults[name]
+		change := &responsepb.RowChange{Name: name, Base: b, Target: t}
+		switch {
+		case !inBase:
+			out.Appeared = append(out.Appeared, change)
+		case !inTarget:
+			out.Disappeared = append(out.Disappeared, change)
+		case result.Passing(b) && result.Failing(t):
+			out.Regressed = append(out.Regressed, change)
+		case result.Failing(b) && result.Passing(t):
+			out.Improved = append(out.Improved, change)
+		}
+	}
+	return &out
+}
+
+// Compare returns the rows whose latest result changed from the base tab,
+// before the time unless it is zero, to the target tab.
+func (r Reader) Compare(ctx context.Context, baseDashboard, baseTab string, before time.Time, dashboard, tab string) (*responsepb.TabComparison, error) {
+	reqs := []Request{
+		{Dashboard: baseDashboard, Tab: baseTab, Columns: math.MaxInt32, FullHistory: true},
+		{Dashboard: dashboard, Tab: tab, Columns: math.MaxInt32, FullHistory: true},
+	}
+	results := r.GetTabs(ctx, reqs)
+	for _, res := range results {
+		if res.Err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", res.Dashboard, res.Tab, res.Err)
+		}
+	}
+	var millis float64
+	if !before.IsZero() {
+		millis = float64(before.UnixNano() / int64(time.Millisecond))
+	}
+	return Compare(results[0].Grid, millis, results[1].Grid), nil
+}
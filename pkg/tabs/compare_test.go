@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestCompare(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	running := int32(statuspb.TestStatus_RUNNING)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	base := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2", Started: 2000}, {Build: "1", Started: 1000}},
+		Rows: []*statepb.Row{
+			{Name: "fixed", Results: []int32{fail, 2}},
+			{Name: "broke", Results: []int32{pass, 2}},
+			{Name: "gone", Results: []int32{pass, 2}},
+			{Name: "same", Results: []int32{fail, 2}},
+			{Name: "new", Results: []int32{pass, 1, empty, 1}},
+		},
+	}
+	target := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3", Started: 3000}, {Build: "2", Started: 2000}},
+		Rows: []*statepb.Row{
+			{Name: "fixed", Results: []int32{running, 1, pass, 1}},
+			{Name: "broke", Results: []int32{fail, 2}},
+			{Name: "gone", Results: []int32{empty, 2}},
+			{Name: "same", Results: []int32{fail, 2}},
+			{Name: "new", Results: []int32{pass, 2}},
+		},
+	}
+	cases := []struct {
+		name   string
+		base   *statepb.Grid
+		before float64
+		target *statepb.Grid
+		want   *responsepb.TabComparison
+	}{
+		{
+			name:   "identical tabs",
+			base:   base,
+			target: base,
+			want:   &responsepb.TabComparison{},
+		},
+		{
+			name:   "compare tabs",
+			base:   base,
+			target: target,
+			want: &responsepb.TabComparison{
+				Regressed: []*responsepb.RowChange{
+					{Name: "broke", Base: statuspb.TestStatus_PASS, Target: statuspb.TestStatus_FAIL},
+				},
+				Improved: []*responsepb.RowChange{
+					{Name: "fixed", Base: statuspb.TestStatus_FAIL, Target: statuspb.TestStatus_PASS},
+				},
+				Disappeared: []*responsepb.RowChange{
+					{Name: "gone", Base: statuspb.TestStatus_PASS},
+				},
+			},
+		},
+		{
+			name:   "compare with an earlier time",
+			base:   base,
+			before: 2000,
+			target: base,
+			want: &responsepb.TabComparison{
+				Appeared: []*responsepb.RowChange{
+					{Name: "new", Target: statuspb.TestStatus_PASS},
+				},
+			},
+		},
+		{
+			name:   "before every column",
+			base:   base,
+			before: 500,
+			target: target,
+			want: &responsepb.TabComparison{
+				Appeared: []*responsepb.RowChange{
+					{Name: "broke", Target: statuspb.TestStatus_FAIL},
+					{Name: "fixed", Target: statuspb.TestStatus_PASS},
+					{Name: "new", Target: statuspb.TestStatus_PASS},
+					{Name: "same", Target: statuspb.TestStatus_FAIL},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Compare(tc.base, tc.before, tc.target)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Compare() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
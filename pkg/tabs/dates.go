@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// DefaultDateFormat formats dates of dashboards without a date_format.
+const DefaultDateFormat = "%Y-%m-%d %H:%M"
+
+// Dates formats timestamps in the time zone and date format of a dashboard.
+//
+// The zero value formats in UTC with the default date format.
+type Dates struct {
+	loc    *time.Location
+	layout string
+}
+
+// NewDates returns the dates of the dashboard, or an error if its time zone does not exist.
+func NewDates(dash *configpb.Dashboard) (Dates, error) {
+	loc, err := time.LoadLocation(dash.GetTimeZone())
+	if err != nil {
+		return Dates{}, fmt.Errorf("time zone: %w", err)
+	}
+	var layout string
+	if f := dash.GetDateFormat(); f != "" {
+		layout = updater.FormatStrftime(f)
+	}
+	return Dates{loc: loc, layout: layout}, nil
+}
+
+// Time returns the millisecond timestamp in the time zone.
+func (d Dates) Time(millis float64) time.Time {
+	ms := int64(millis)
+	when := time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+	if d.loc == nil {
+		return when.UTC()
+	}
+	return when.In(d.loc)
+}
+
+// Format returns the millisecond timestamp in the time zone and date format.
+func (d Dates) Format(millis float64) string {
+	return d.Strftime(millis, "")
+}
+
+// Strftime returns the millisecond timestamp in the time zone and strftime format,
+// or the date format when the strftime format is empty.
+func (d Dates) Strftime(millis float64, format string) string {
+	layout := d.layout
+	switch {
+	case format != "":
+		layout = updater.FormatStrftime(format)
+	case layout == "":
+		layout = updater.FormatStrftime(DefaultDateFormat)
+	}
+	return d.Time(millis).Format(layout)
+}
+
+// SetColumnDates sets the date of each column of the grid.
+//
+// Builds named after their start time by the group's build_override_strftime
+// are also renamed in the time zone. Columns are copied rather than modified,
+// as windowed grids share them with the group state.
+func SetColumnDates(grid *statepb.Grid, group *configpb.TestGroup, dates Dates) {
+	cols := make([]*statepb.Column, 0, len(grid.Columns))
+	for _, col := range grid.Columns {
+		if col.Started == 0 {
+			cols = append(cols, col)
+			continue
+		}
+		col = proto.Clone(col).(*statepb.Column)
+		col.Date = dates.Format(col.Started)
+		if f := group.GetBuildOverrideStrftime(); f != "" {
+			col.Build = dates.Strftime(col.Started, f)
+		}
+		cols = append(cols, col)
+	}
+	grid.Columns = cols
+}
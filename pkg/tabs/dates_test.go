@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestDatesFormat(t *testing.T) {
+	// 2021-03-28 00:30 UTC, the night Europe/Berlin switches to summer time.
+	when := float64(time.Date(2021, 3, 28, 0, 30, 0, 0, time.UTC).Unix() * 1000)
+	cases := []struct {
+		name    string
+		dash    *configpb.Dashboard
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "utc by default",
+			dash: &configpb.Dashboard{},
+			want: "2021-03-28 00:30",
+		},
+		{
+			name: "time zone",
+			dash: &configpb.Dashboard{TimeZone: "Europe/Berlin"},
+			want: "2021-03-28 01:30",
+		},
+		{
+			name: "date format",
+			dash: &configpb.Dashboard{TimeZone: "America/Los_Angeles", DateFormat: "%d.%m. %H:%M"},
+			want: "27.03. 17:30",
+		},
+		{
+			name:   "strftime overrides the date format",
+			dash:   &configpb.Dashboard{TimeZone: "Asia/Kolkata", DateFormat: "%d.%m. %H:%M"},
+			format: "%Y%m%d-%H%M%S",
+			want:   "20210328-060000",
+		},
+		{
+			name:    "invalid time zone",
+			dash:    &configpb.Dashboard{TimeZone: "Nowhere/Special"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dates, err := NewDates(tc.dash)
+			switch {
+			case err != nil:
+				if !tc.wantErr {
+					t.Fatalf("NewDates() got unexpected error: %v", err)
+				}
+				return
+			case tc.wantErr:
+				t.Fatal("NewDates() failed to return an error")
+			}
+			if got := dates.Strftime(when, tc.format); got != tc.want {
+				t.Errorf("Strftime() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetColumnDates(t *testing.T) {
+	dates, err := NewDates(&configpb.Dashboard{TimeZone: "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("NewDates() got unexpected error: %v", err)
+	}
+	started := float64(time.Date(2021, 6, 1, 22, 15, 0, 0, time.UTC).Unix() * 1000)
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		want  []*statepb.Column
+	}{
+		{
+			name: "set dates",
+			want: []*statepb.Column{
+				{Build: "2", Started: started, Date: "2021-06-02 00:15"},
+				{Build: "1"},
+			},
+		},
+		{
+			name:  "rename builds named after their start time",
+			group: &configpb.TestGroup{BuildOverrideStrftime: "%H:%M"},
+			want: []*statepb.Column{
+				{Build: "00:15", Started: started, Date: "2021-06-02 00:15"},
+				{Build: "1"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := []*statepb.Column{
+				{Build: "2", Started: started},
+				{Build: "1"},
+			}
+			grid := &statepb.Grid{Columns: append([]*statepb.Column(nil), orig...)}
+			SetColumnDates(grid, tc.group, dates)
+			if diff := cmp.Diff(tc.want, grid.Columns, protocmp.Transform()); diff != "" {
+				t.Errorf("SetColumnDates() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if orig[0].Date != "" || orig[0].Build != "2" {
+				t.Errorf("SetColumnDates() modified the original column: %v", orig[0])
+			}
+		})
+	}
+}
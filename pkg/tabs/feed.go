@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+// HealthFeed returns the latest max health events of the dashboard's tabs,
+// or every event unless max is positive.
+//
+// Dashboards without a summary have no events.
+func (r Reader) HealthFeed(ctx context.Context, dashboard string, max int) (*responsepb.HealthFeed, error) {
+	dash := config.FindDashboard(dashboard, r.Config)
+	if dash == nil {
+		return nil, fmt.Errorf("dashboard %q: %w", dashboard, ErrNotFound)
+	}
+	sum, err := r.readSummary(ctx, dash.Name)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return &responsepb.HealthFeed{Dashboard: dash.Name}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read summary: %w", err)
+	}
+	return HealthFeed(dash.Name, sum, max), nil
+}
+
+// HealthFeed returns the health events of the summary's tabs, newest first
+// and limited to the latest max events when positive.
+//
+// Each alert episode of a tab fails the tab when it opens and passes it when
+// it resolves, and each failing test fails when it started failing.
+func HealthFeed(dashboard string, sum *summarypb.DashboardSummary, max int) *responsepb.HealthFeed {
+	feed := responsepb.HealthFeed{Dashboard: dashboard}
+	for _, tab := range sum.GetTabSummaries() {
+		for i, e := range tab.AlertHistory {
+			failing := responsepb.HealthEvent{
+				Kind: responsepb.HealthEvent_TAB_FAILING,
+				Tab:  tab.DashboardTabName,
+				Time: e.Opened,
+			}
+			if e.Resolved != 0 {
+				feed.Events = append(feed.Events, &responsepb.HealthEvent{
+					Kind: responsepb.HealthEvent_TAB_PASSING,
+					Tab:  tab.DashboardTabName,
+					Time: e.Resolved,
+				})
+			} else if i == len(tab.AlertHistory)-1 {
+				// The alert describes the open episode.
+				failing.Message = tab.Alert
+			}
+			feed.Events = append(feed.Events, &failing)
+		}
+		for _, f := range tab.FailingTestSummaries {
+			if f.FailTimestamp == 0 {
+				continue
+			}
+			feed.Events = append(feed.Events, &responsepb.HealthEvent{
+				Kind:    responsepb.HealthEvent_TEST_FAILING,
+				Tab:     tab.DashboardTabName,
+				Time:    f.FailTimestamp,
+				Test:    f.DisplayName,
+				Build:   f.FailBuildId,
+				Message: f.FailureMessage,
+			})
+		}
+	}
+	sort.SliceStable(feed.Events, func(i, j int) bool {
+		a, b := feed.Events[i], feed.Events[j]
+		if a.Time != b.Time {
+			return a.Time > b.Time
+		}
+		if a.Tab != b.Tab {
+			return a.Tab < b.Tab
+		}
+		return a.Test < b.Test
+	})
+	if max > 0 && len(feed.Events) > max {
+		feed.Events = feed.Events[:max]
+	}
+	return &feed
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestHealthFeed(t *testing.T) {
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "flaky",
+				Alert:            "2 tests failed",
+				AlertHistory: []*summarypb.AlertEpisode{
+					{Opened: 100, Resolved: 200},
+					{Opened: 300},
+				},
+				FailingTestSummaries: []*summarypb.FailingTestSummary{
+					{DisplayName: "b", FailBuildId: "7", FailTimestamp: 300, FailureMessage: "boom"},
+					{DisplayName: "a", FailBuildId: "7", FailTimestamp: 300},
+					{DisplayName: "unknown"},
+				},
+			},
+			{
+				DashboardTabName: "fixed",
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: 150, Resolved: 250}},
+			},
+			{
+				DashboardTabName: "green",
+			},
+		},
+	}
+	cases := []struct {
+		name string
+		sum  *summarypb.DashboardSummary
+		max  int
+		want *responsepb.HealthFeed
+	}{
+		{
+			name: "missing summary",
+			want: &responsepb.HealthFeed{Dashboard: "dash"},
+		},
+		{
+			name: "events",
+			sum:  sum,
+			want: &responsepb.HealthFeed{
+				Dashboard: "dash",
+				Events: []*responsepb.HealthEvent{
+					{Kind: responsepb.HealthEvent_TAB_FAILING, Tab: "flaky", Time: 300, Message: "2 tests failed"},
+					{Kind: responsepb.HealthEvent_TEST_FAILING, Tab: "flaky", Time: 300, Test: "a", Build: "7"},
+					{Kind: responsepb.HealthEvent_TEST_FAILING, Tab: "flaky", Time: 300, Test: "b", Build: "7", Message: "boom"},
+					{Kind: responsepb.HealthEvent_TAB_PASSING, Tab: "fixed", Time: 250},
+					{Kind: responsepb.HealthEvent_TAB_PASSING, Tab: "flaky", Time: 200},
+					{Kind: responsepb.HealthEvent_TAB_FAILING, Tab: "fixed", Time: 150},
+					{Kind: responsepb.HealthEvent_TAB_FAILING, Tab: "flaky", Time: 100},
+				},
+			},
+		},
+		{
+			name: "limit events",
+			sum:  sum,
+			max:  2,
+			want: &responsepb.HealthFeed{
+				Dashboard: "dash",
+				Events: []*responsepb.HealthEvent{
+					{Kind: responsepb.HealthEvent_TAB_FAILING, Tab: "flaky", Time: 300, Message: "2 tests failed"},
+					{Kind: responsepb.HealthEvent_TEST_FAILING, Tab: "flaky", Time: 300, Test: "a", Build: "7"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := HealthFeed("dash", tc.sum, tc.max)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("HealthFeed() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// MessageHistory returns the distinct failure messages of the named row, or nil if the grid has no such row.
+//
+// Messages are ordered by the column in which they first appeared, oldest first.
+// Failing cells without a message are ignored.
+func MessageHistory(grid *statepb.Grid, name string) *responsepb.MessageHistory {
+	var row *statepb.Row
+	for _, r := range grid.Rows {
+		if r.Name == name {
+			row = r
+			break
+		}
+	}
+	if row == nil {
+		return nil
+	}
+
+	// Columns are ordered newest first, so record each failure and walk them backwards.
+	type failure struct {
+		col     int
+		message string
+	}
+	var failures []failure
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var col, filled int
+	for res := range result.Iter(ctx, row.Results) {
+		if res != statuspb.TestStatus_NO_RESULT {
+			if result.Failing(res) && filled < len(row.Messages) && row.Messages[filled] != "" {
+				failures = append(failures, failure{col: col, message: row.Messages[filled]})
+			}
+			filled++
+		}
+		col++
+	}
+
+	history := responsepb.MessageHistory{Name: name}
+	runs := map[string]*responsepb.MessageRun{}
+	for i := len(failures) - 1; i >= 0; i-- {
+		f := failures[i]
+		if f.col >= len(grid.Columns) {
+			continue
+		}
+		column := grid.Columns[f.col]
+		run, ok := runs[f.message]
+		if !ok {
+			run = &responsepb.MessageRun{
+				Message:      f.message,
+				FirstBuild:   column.Build,
+				FirstStarted: column.Started,
+			}
+			runs[f.message] = run
+			history.Messages = append(history.Messages, run)
+		}
+		run.LastBuild = column.Build
+		run.LastStarted = column.Started
+		run.Count++
+	}
+	return &history
+}
+
+// FullMessage returns the untruncated cell message with the hash, which the
+// updater stores alongside the grid of the tab's test group.
+func (r Reader) FullMessage(ctx context.Context, dashboard, tab, hash string) (*responsepb.FullMessage, error) {
+	gridPath, err := r.GridPath(dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	path, err := updater.MessageStorePath(*gridPath)
+	if err != nil {
+		return nil, fmt.Errorf("message store path: %w", err)
+	}
+	store, err := updater.ReadMessageStore(ctx, r.Client, *path)
+	if err != nil {
+		return nil, fmt.Errorf("read message store: %w", err)
+	}
+	msg, ok := store.Messages[hash]
+	if !ok {
+		return nil, fmt.Errorf("message %q: %w", hash, ErrNotFound)
+	}
+	return &responsepb.FullMessage{Hash: hash, Message: msg}, nil
+}
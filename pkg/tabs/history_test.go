@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestMessageHistory(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "6", Started: 6},
+			{Build: "5", Started: 5},
+			{Build: "4", Started: 4},
+			{Build: "3", Started: 3},
+			{Build: "2", Started: 2},
+			{Build: "1", Started: 1},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:     "foo",
+				Results:  []int32{fail, 2, pass, 1, empty, 1, fail, 2},
+				Messages: []string{"new", "old", "passed", "old", "old"},
+			},
+			{
+				Name:     "bar",
+				Results:  []int32{fail, 1, pass, 5},
+				Messages: []string{"", "", "", "", "", ""},
+			},
+		},
+	}
+	cases := []struct {
+		name string
+		row  string
+		want *responsepb.MessageHistory
+	}{
+		{
+			name: "missing row",
+			row:  "missing",
+		},
+		{
+			name: "ignore empty messages",
+			row:  "bar",
+			want: &responsepb.MessageHistory{Name: "bar"},
+		},
+		{
+			name: "oldest first",
+			row:  "foo",
+			want: &responsepb.MessageHistory{
+				Name: "foo",
+				Messages: []*responsepb.MessageRun{
+					{
+						Message:      "old",
+						FirstBuild:   "1",
+						FirstStarted: 1,
+						LastBuild:    "5",
+						LastStarted:  5,
+						Count:        3,
+					},
+					{
+						Message:      "new",
+						FirstBuild:   "6",
+						FirstStarted: 6,
+						LastBuild:    "6",
+						LastStarted:  6,
+						Count:        1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MessageHistory(grid, tc.row)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("MessageHistory() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+)
+
+var (
+	rowToken  = regexp.MustCompile(`<(test-name|test-id|property:[^>]+)>`)
+	cellToken = regexp.MustCompile(`<(test-name|test-id|cell-id|build|gcs-prefix|property:[^>]+)>`)
+)
+
+// SpyglassLink names the link to the Spyglass view of a cell's build.
+const SpyglassLink = "spyglass"
+
+// SetRowLinks replaces the links of each row of the grid with the expanded templates.
+//
+// Links whose templates reference a property the row lacks, or which do not
+// expand to a valid URL, are omitted.
+func SetRowLinks(grid *statepb.Grid, templates []*configpb.LinkTemplate) {
+	for _, row := range grid.Rows {
+		row.Links = nil
+		for _, tmpl := range templates {
+			if link := expandLink(tmpl, rowToken, func(name string) string { return rowValue(row, name) }); link != nil {
+				row.Links = append(row.Links, link)
+			}
+		}
+	}
+}
+
+// CellLinkTemplates returns the cell link templates of the tab, along with a
+// template linking to Spyglass when the tab sets a spyglass_url.
+//
+// Tabs which roll up columns have none, as each cell spans several builds.
+func CellLinkTemplates(tab *configpb.DashboardTab) []*configpb.LinkTemplate {
+	if tab.GetRollup() != configpb.DashboardTab_ROLLUP_UNSPECIFIED {
+		return nil
+	}
+	templates := tab.GetCellLinkTemplates()
+	if base := tab.GetSpyglassUrl(); base != "" {
+		templates = append(templates[:len(templates):len(templates)], &configpb.LinkTemplate{
+			Name: SpyglassLink,
+			Url:  strings.TrimSuffix(base, "/") + "/view/gs/<gcs-prefix>/<build>",
+		})
+	}
+	return templates
+}
+
+// SetCellLinks adds the expanded templates to the links of each filled cell
+// of the grid, such as to view the junit results of the cell's build.
+//
+// Links whose templates reference a value the cell lacks, or which do not
+// expand to a valid URL, are omitted. The grid's existing cell links are
+// copied rather than modified.
+func SetCellLinks(grid *statepb.Grid, group *configpb.TestGroup, templates []*configpb.LinkTemplate) {
+	if len(templates) == 0 {
+		return
+	}
+	prefix := strings.Split(group.GetGcsPrefix(), ",")[0]
+	for _, row := range grid.Rows {
+		var links []*statepb.CellLinks
+		var filled int
+		for col, result := range state.Expand(row.Results) {
+			if result == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			cl := &statepb.CellLinks{}
+			if filled < len(row.CellLinks) {
+				cl.Links = append(cl.Links, row.CellLinks[filled].GetLinks()...)
+			}
+			value := func(name string) string {
+				switch name {
+				case "build":
+					if col < len(grid.Columns) {
+						return grid.Columns[col].Build
+					}
+					return ""
+				case "gcs-prefix":
+					return prefix
+				case "cell-id":
+					if filled < len(row.CellIds) {
+						return row.CellIds[filled]
+					}
+					return ""
+				}
+				return cellValue(row, filled, name)
+			}
+			for _, tmpl := range templates {
+				if link := expandLink(tmpl, cellToken, value); link != nil {
+					cl.Links = append(cl.Links, link)
+				}
+			}
+			links = append(links, cl)
+			filled++
+		}
+		row.CellLinks = links
+	}
+}
+
+// expandLink returns the link of the template, or nil if it does not expand.
+func expandLink(tmpl *configpb.LinkTemplate, token *regexp.Regexp, value func(string) string) *statepb.Link {
+	pathEscape := func(s string) string {
+		return strings.ReplaceAll(url.PathEscape(s), "%2F", "/")
+	}
+	raw, ok := expand(tmpl.Url, token, value, pathEscape)
+	if !ok {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	if len(tmpl.Options) == 0 {
+		return &statepb.Link{Name: tmpl.Name, Url: raw}
+	}
+	query := u.Query()
+	for _, opt := range tmpl.Options {
+		val, ok := expand(opt.Value, token, value, func(s string) string { return s })
+		if !ok {
+			return nil
+		}
+		query.Add(opt.Key, val)
+	}
+	u.RawQuery = query.Encode()
+	return &statepb.Link{Name: tmpl.Name, Url: u.String()}
+}
+
+// expand replaces the tokens of the template with their escaped values.
+func expand(template string, token *regexp.Regexp, value func(string) string, escape func(string) string) (string, bool) {
+	ok := true
+	out := token.ReplaceAllStringFunc(template, func(tok string) string {
+		val := value(tok[1 : len(tok)-1])
+		if val == "" {
+			ok = false
+		}
+		return escape(val)
+	})
+	return out, ok
+}
+
+// rowValue returns the value of the row token.
+func rowValue(row *statepb.Row, name string) string {
+	switch name {
+	case "test-name":
+		return row.Name
+	case "test-id":
+		return row.Id
+	}
+	return rowProperty(row, strings.TrimPrefix(name, "property:"))
+}
+
+// cellValue returns the value of the row token for the nth filled cell of the
+// row, using the cell's own property values.
+func cellValue(row *statepb.Row, filled int, name string) string {
+	if !strings.HasPrefix(name, "property:") {
+		return rowValue(row, name)
+	}
+	name = strings.TrimPrefix(name, "property:")
+	for _, prop := range row.Properties {
+		if prop.Name == name && filled < len(prop.Values) {
+			return prop.Values[filled]
+		}
+	}
+	return ""
+}
+
+// rowProperty returns the most recent value of the named cell property of the row.
+func rowProperty(row *statepb.Row, name string) string {
+	for _, prop := range row.Properties {
+		if prop.Name != name {
+			continue
+		}
+		for _, val := range prop.Values { // Newest first
+			if val != "" {
+				return val
+			}
+		}
+	}
+	return ""
+}
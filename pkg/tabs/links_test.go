@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestSetRowLinks(t *testing.T) {
+	row := func() *statepb.Row {
+		return &statepb.Row{
+			Name: "//pkg/foo:go_default_test",
+			Id:   "pkg/foo TestBar",
+			Properties: []*statepb.PropertyValues{
+				{Name: "team", Values: []string{"", "sig-node", "sig-old"}},
+			},
+			Links: []*statepb.Link{{Name: "stale"}},
+		}
+	}
+	cases := []struct {
+		name      string
+		templates []*configpb.LinkTemplate
+		want      []*statepb.Link
+	}{
+		{
+			name: "no templates clear links",
+		},
+		{
+			name: "expand url tokens",
+			templates: []*configpb.LinkTemplate{
+				{Name: "source", Url: "https://example.com/src/<test-name>"},
+				{Name: "owners", Url: "https://example.com/teams/<property:team>"},
+			},
+			want: []*statepb.Link{
+				{Name: "source", Url: "https://example.com/src///pkg/foo:go_default_test"},
+				{Name: "owners", Url: "https://example.com/teams/sig-node"},
+			},
+		},
+		{
+			name: "expand options",
+			templates: []*configpb.LinkTemplate{
+				{
+					Name: "search",
+					Url:  "https://example.com/search",
+					Options: []*configpb.LinkOptionsTemplate{
+						{Key: "q", Value: "<test-id>"},
+					},
+				},
+			},
+			want: []*statepb.Link{
+				{Name: "search", Url: "https://example.com/search?q=pkg%2Ffoo+TestBar"},
+			},
+		},
+		{
+			name: "omit links with missing properties",
+			templates: []*configpb.LinkTemplate{
+				{Name: "missing", Url: "https://example.com/<property:missing>"},
+				{Name: "source", Url: "https://example.com/<test-id>"},
+			},
+			want: []*statepb.Link{
+				{Name: "source", Url: "https://example.com/pkg/foo%20TestBar"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := &statepb.Grid{Rows: []*statepb.Row{row()}}
+			SetRowLinks(grid, tc.templates)
+			if diff := cmp.Diff(tc.want, grid.Rows[0].Links, protocmp.Transform()); diff != "" {
+				t.Errorf("SetRowLinks() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCellLinkTemplates(t *testing.T) {
+	custom := &configpb.LinkTemplate{Name: "junit", Url: "https://example.com/<build>"}
+	cases := []struct {
+		name string
+		tab  *configpb.DashboardTab
+		want []*configpb.LinkTemplate
+	}{
+		{
+			name: "empty",
+			tab:  &configpb.DashboardTab{},
+		},
+		{
+			name: "templates",
+			tab:  &configpb.DashboardTab{CellLinkTemplates: []*configpb.LinkTemplate{custom}},
+			want: []*configpb.LinkTemplate{custom},
+		},
+		{
+			name: "spyglass",
+			tab: &configpb.DashboardTab{
+				CellLinkTemplates: []*configpb.LinkTemplate{custom},
+				SpyglassUrl:       "https://prow.example.com/",
+			},
+			want: []*configpb.LinkTemplate{
+				custom,
+				{Name: SpyglassLink, Url: "https://prow.example.com/view/gs/<gcs-prefix>/<build>"},
+			},
+		},
+		{
+			name: "rollups have none",
+			tab: &configpb.DashboardTab{
+				CellLinkTemplates: []*configpb.LinkTemplate{custom},
+				SpyglassUrl:       "https://prow.example.com",
+				Rollup:            configpb.DashboardTab_DAILY,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CellLinkTemplates(tc.tab)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("CellLinkTemplates() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCellLinks(t *testing.T) {
+	artifact := &statepb.Link{Name: "artifact", Url: "https://example.com/artifact"}
+	grid := func() *statepb.Grid {
+		return &statepb.Grid{
+			Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+			Rows: []*statepb.Row{
+				{
+					Name: "TestFoo",
+					Id:   "TestFoo",
+					Results: []int32{
+						int32(statuspb.TestStatus_FAIL), 1,
+						int32(statuspb.TestStatus_NO_RESULT), 1,
+						int32(statuspb.TestStatus_PASS), 1,
+					},
+					CellIds:  []string{"foo-3", "foo-1"},
+					Messages: []string{"boom", ""},
+					Properties: []*statepb.PropertyValues{
+						{Name: "shard", Values: []string{"", "2"}},
+					},
+					CellLinks: []*statepb.CellLinks{{Links: []*statepb.Link{artifact}}, {}},
+				},
+			},
+		}
+	}
+	group := &configpb.TestGroup{GcsPrefix: "bucket/logs/job,other/logs/job"}
+	cases := []struct {
+		name      string
+		templates []*configpb.LinkTemplate
+		want      []*statepb.CellLinks
+	}{
+		{
+			name: "no templates keep links",
+			want: []*statepb.CellLinks{{Links: []*statepb.Link{artifact}}, {}},
+		},
+		{
+			name: "expand cell tokens",
+			templates: []*configpb.LinkTemplate{
+				{
+					Name: "junit",
+					Url:  "https://prow.example.com/view/gs/<gcs-prefix>/<build>",
+					Options: []*configpb.LinkOptionsTemplate{
+						{Key: "test", Value: "<test-name>"},
+						{Key: "cell", Value: "<cell-id>"},
+					},
+				},
+			},
+			want: []*statepb.CellLinks{
+				{Links: []*statepb.Link{
+					artifact,
+					{Name: "junit", Url: "https://prow.example.com/view/gs/bucket/logs/job/3?cell=foo-3&test=TestFoo"},
+				}},
+				{Links: []*statepb.Link{
+					{Name: "junit", Url: "https://prow.example.com/view/gs/bucket/logs/job/1?cell=foo-1&test=TestFoo"},
+				}},
+			},
+		},
+		{
+			name: "omit links with missing cell properties",
+			templates: []*configpb.LinkTemplate{
+				{Name: "shard", Url: "https://example.com/<build>/shard-<property:shard>"},
+			},
+			want: []*statepb.CellLinks{
+				{Links: []*statepb.Link{artifact}},
+				{Links: []*statepb.Link{{Name: "shard", Url: "https://example.com/1/shard-2"}}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := grid()
+			orig := g.Rows[0].CellLinks[0]
+			SetCellLinks(g, group, tc.templates)
+			if diff := cmp.Diff(tc.want, g.Rows[0].CellLinks, protocmp.Transform()); diff != "" {
+				t.Errorf("SetCellLinks() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if len(orig.Links) != 1 {
+				t.Errorf("SetCellLinks() modified the original links: %v", orig.Links)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// OlderReader reads ephemeral grids of the builds of a test group which are
+// older than its state, such as those which started before its days_of_results.
+//
+// Grids are built on demand and never written.
+type OlderReader struct {
+	Client gcs.Downloader
+	// MaxBuilds limits the number of builds read for each page, DefaultOlderBuilds if unset.
+	MaxBuilds    int
+	BuildTimeout time.Duration
+	Concurrency  int
+}
+
+// DefaultOlderBuilds is the number of builds in each page of older results by default.
+const DefaultOlderBuilds = 20
+
+const defaultOlderBuildTimeout = time.Minute
+
+// Grid returns up to max of the group's builds which are older than the
+// before build, limited to MaxBuilds.
+//
+// The oldest column counts the builds which remain in its OlderBuilds, so
+// callers read the next page by passing its hint as before.
+func (o OlderReader) Grid(ctx context.Context, group *configpb.TestGroup, before string, max int) (*statepb.Grid, error) {
+	limit := o.MaxBuilds
+	if limit <= 0 {
+		limit = DefaultOlderBuilds
+	}
+	if max <= 0 || max > limit {
+		max = limit
+	}
+	timeout := o.BuildTimeout
+	if timeout <= 0 {
+		timeout = defaultOlderBuildTimeout
+	}
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	log := logrus.WithFields(logrus.Fields{
+		"group":  group.Name,
+		"before": before,
+	})
+	return updater.OlderGrid(ctx, log, o.Client, group, before, max, timeout, concurrency)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// PullReader reads ephemeral grids of the presubmit results of pull requests.
+//
+// Grids are built on demand and never written.
+type PullReader struct {
+	Client gcs.Downloader
+	// Prefix holds the results of pull requests in the pr-logs layout, such as
+	// gs://bucket/pr-logs/pull/ holding <repo>/<pull>/<job>/<build>/.
+	Prefix gcs.Path
+	// MaxBuilds limits the number of recent runs read, unlimited if unset.
+	MaxBuilds    int
+	BuildTimeout time.Duration
+	Concurrency  int
+}
+
+const defaultPullBuildTimeout = time.Minute
+
+// PullPath returns the results of the pull request of the repo.
+//
+// The repo names the directory of the repo in the pr-logs layout, such as org_repo.
+func (p PullReader) PullPath(repo string, pull int) (*gcs.Path, error) {
+	if repo == "" || repo == "." || repo == ".." || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("%w: bad repo %q", ErrNotFound, repo)
+	}
+	if pull <= 0 {
+		return nil, fmt.Errorf("%w: bad pull %d", ErrNotFound, pull)
+	}
+	prefix := p.Prefix
+	if u := prefix.URL(); !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if err := prefix.SetURL(&u); err != nil {
+			return nil, fmt.Errorf("prefix: %w", err)
+		}
+	}
+	rel := url.URL{Path: repo + "/" + strconv.Itoa(pull) + "/"}
+	np, err := prefix.ResolveReference(&rel)
+	if err != nil {
+		return nil, fmt.Errorf("resolve reference: %w", err)
+	}
+	return np, nil
+}
+
+// Grid returns the grid of the presubmit results of the pull request of the repo.
+//
+// Each column aggregates the runs of one commit of the pull request, see updater.PullGrid.
+func (p PullReader) Grid(ctx context.Context, repo string, pull int) (*statepb.Grid, error) {
+	pullPath, err := p.PullPath(repo, pull)
+	if err != nil {
+		return nil, err
+	}
+	timeout := p.BuildTimeout
+	if timeout <= 0 {
+		timeout = defaultPullBuildTimeout
+	}
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	log := logrus.WithField("pull", pullPath.String())
+	grid, err := updater.PullGrid(ctx, log, p.Client, *pullPath, p.MaxBuilds, timeout, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	if len(grid.Columns) == 0 {
+		return nil, fmt.Errorf("%w: no results for %s", ErrNotFound, pullPath)
+	}
+	return grid, nil
+}
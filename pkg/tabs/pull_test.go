@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestPullPath(t *testing.T) {
+	cases := []struct {
+		name   string
+		prefix string
+		repo   string
+		pull   int
+		want   string
+	}{
+		{
+			name:   "basically works",
+			prefix: "gs://bucket/pr-logs/pull/",
+			repo:   "org_repo",
+			pull:   123,
+			want:   "gs://bucket/pr-logs/pull/org_repo/123/",
+		},
+		{
+			name:   "prefix without a trailing slash",
+			prefix: "gs://bucket/pr-logs/pull",
+			repo:   "org_repo",
+			pull:   123,
+			want:   "gs://bucket/pr-logs/pull/org_repo/123/",
+		},
+		{
+			name:   "reject nested repos",
+			prefix: "gs://bucket/pr-logs/pull/",
+			repo:   "../other",
+			pull:   123,
+		},
+		{
+			name:   "reject parent repos",
+			prefix: "gs://bucket/pr-logs/pull/",
+			repo:   "..",
+			pull:   123,
+		},
+		{
+			name:   "reject bad pulls",
+			prefix: "gs://bucket/pr-logs/pull/",
+			repo:   "org_repo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, err := gcs.NewPath(tc.prefix)
+			if err != nil {
+				t.Fatalf("gcs.NewPath(%q): %v", tc.prefix, err)
+			}
+			got, err := PullReader{Prefix: *prefix}.PullPath(tc.repo, tc.pull)
+			switch {
+			case tc.want == "":
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("PullPath() got %v, %v, want ErrNotFound", got, err)
+				}
+			case err != nil:
+				t.Errorf("PullPath() got unexpected error: %v", err)
+			case got.String() != tc.want:
+				t.Errorf("PullPath() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// Review is a dashboard tab which has needed triage for too long.
+type Review struct {
+	Tab string
+	// Stale is true when the tab's results are stale rather than failing.
+	Stale bool
+	// Since is when the tab started failing or its results went stale.
+	Since time.Time
+	// Alert describes why the tab needs triage.
+	Alert string
+}
+
+// Reviews returns the tabs of the dashboard which have needed triage for at
+// least the threshold.
+//
+// Dashboards without a summary have no reviews.
+func (r Reader) Reviews(ctx context.Context, dashboard string, threshold time.Duration, now time.Time) ([]Review, error) {
+	dash := config.FindDashboard(dashboard, r.Config)
+	if dash == nil {
+		return nil, fmt.Errorf("dashboard %q: %w", dashboard, ErrNotFound)
+	}
+	sum, err := r.readSummary(ctx, dash.Name)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read summary: %w", err)
+	}
+	return Reviews(sum, threshold, now), nil
+}
+
+// Reviews returns the tabs of the summary which have failed, or whose results
+// have been stale, for at least the threshold, the longest first.
+//
+// Tabs fail from when their open alert episode began, and go stale when their
+// results stopped changing or their latest column started.
+func Reviews(sum *summarypb.DashboardSummary, threshold time.Duration, now time.Time) []Review {
+	cutoff := now.Add(-threshold)
+	var out []Review
+	for _, tab := range sum.GetTabSummaries() {
+		review := Review{Tab: tab.DashboardTabName, Alert: tab.Alert}
+		switch tab.OverallStatus {
+		case summarypb.DashboardTabSummary_STALE:
+			switch tab.GetTabAlert().GetReason() {
+			case summarypb.TabAlert_UNCHANGED, summarypb.TabAlert_OLD_RESULTS:
+			default:
+				continue
+			}
+			review.Stale = true
+			review.Since = updater.SecondsTime(tab.TabAlert.Since)
+		case summarypb.DashboardTabSummary_FAIL, summarypb.DashboardTabSummary_BROKEN:
+			n := len(tab.AlertHistory)
+			if n == 0 || tab.AlertHistory[n-1].Resolved != 0 {
+				continue
+			}
+			review.Since = updater.SecondsTime(tab.AlertHistory[n-1].Opened)
+		default:
+			continue
+		}
+		if review.Since.After(cutoff) {
+			continue
+		}
+		out = append(out, review)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Since.Equal(out[j].Since) {
+			return out[i].Since.Before(out[j].Since)
+		}
+		return out[i].Tab < out[j].Tab
+	})
+	return out
+}
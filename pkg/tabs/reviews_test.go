@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+)
+
+func TestReviews(t *testing.T) {
+	now := time.Unix(100000, 0)
+	seconds := func(d time.Duration) float64 {
+		return float64(now.Add(-d).Unix())
+	}
+	sum := &summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{
+			{
+				DashboardTabName: "failing",
+				Alert:            "3 tests failed",
+				OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+				AlertHistory: []*summarypb.AlertEpisode{
+					{Opened: seconds(48 * time.Hour), Resolved: seconds(40 * time.Hour)},
+					{Opened: seconds(30 * time.Hour)},
+				},
+			},
+			{
+				DashboardTabName: "recent",
+				OverallStatus:    summarypb.DashboardTabSummary_FAIL,
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: seconds(time.Hour)}},
+			},
+			{
+				DashboardTabName: "broken",
+				OverallStatus:    summarypb.DashboardTabSummary_BROKEN,
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: seconds(30 * time.Hour)}},
+			},
+			{
+				DashboardTabName: "stale",
+				Alert:            "results have not changed for 3d",
+				OverallStatus:    summarypb.DashboardTabSummary_STALE,
+				TabAlert:         &summarypb.TabAlert{Reason: summarypb.TabAlert_UNCHANGED, Since: seconds(72 * time.Hour)},
+			},
+			{
+				DashboardTabName: "paused",
+				OverallStatus:    summarypb.DashboardTabSummary_STALE,
+				TabAlert:         &summarypb.TabAlert{Reason: summarypb.TabAlert_PAUSED},
+			},
+			{
+				DashboardTabName: "passing",
+				OverallStatus:    summarypb.DashboardTabSummary_PASS,
+				AlertHistory:     []*summarypb.AlertEpisode{{Opened: seconds(48 * time.Hour), Resolved: seconds(40 * time.Hour)}},
+			},
+		},
+	}
+	cases := []struct {
+		name      string
+		sum       *summarypb.DashboardSummary
+		threshold time.Duration
+		want      []Review
+	}{
+		{
+			name: "missing summary",
+		},
+		{
+			name:      "reviews",
+			sum:       sum,
+			threshold: 24 * time.Hour,
+			want: []Review{
+				{Tab: "stale", Stale: true, Since: now.Add(-72 * time.Hour), Alert: "results have not changed for 3d"},
+				{Tab: "broken", Since: now.Add(-30 * time.Hour)},
+				{Tab: "failing", Since: now.Add(-30 * time.Hour), Alert: "3 tests failed"},
+			},
+		},
+		{
+			name:      "longer threshold",
+			sum:       sum,
+			threshold: 48 * time.Hour,
+			want: []Review{
+				{Tab: "stale", Stale: true, Since: now.Add(-72 * time.Hour), Alert: "results have not changed for 3d"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Reviews(tc.sum, tc.threshold, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Reviews() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// periodStart returns the start of the period containing the time, and the
+// name of the period.
+func periodStart(when time.Time, rollup configpb.DashboardTab_Rollup) (time.Time, string) {
+	y, m, d := when.Date()
+	if rollup == configpb.DashboardTab_HOURLY {
+		start := time.Date(y, m, d, when.Hour(), 0, 0, 0, when.Location())
+		return start, start.Format("2006-01-02 15:00")
+	}
+	start := time.Date(y, m, d, 0, 0, 0, 0, when.Location())
+	return start, start.Format("2006-01-02")
+}
+
+// Rollup returns a copy of the grid with one column per period in the time
+// zone, or the grid itself when the tab does not roll up columns.
+//
+// Each cell holds the worst result of the row's runs during the period, with
+// the number of passing runs as its icon. Each column lists the builds it
+// rolls up. Rows keep their alerts and bugs from the grid, as alerting on
+// periods rather than runs would count failures differently.
+func Rollup(log logrus.FieldLogger, grid *statepb.Grid, group *configpb.TestGroup, rollup configpb.DashboardTab_Rollup, dates Dates) *statepb.Grid {
+	if rollup == configpb.DashboardTab_ROLLUP_UNSPECIFIED {
+		return grid
+	}
+
+	var cols []updater.InflatedColumn
+	var current *updater.InflatedColumn
+	var runs map[string][]updater.Cell
+	flush := func() {
+		if current == nil {
+			return
+		}
+		for name, cells := range runs {
+			current.Cells[name] = updater.MergeCells(false, cells...)
+		}
+		cols = append(cols, *current)
+	}
+	// Inflating modifies the grid, which other tabs share.
+	inflated := updater.InflateGrid(proto.Clone(grid).(*statepb.Grid))
+	// Columns are ordered newest first.
+	for _, col := range inflated {
+		start, name := periodStart(dates.Time(col.Column.Started), rollup)
+		started := float64(start.UnixNano() / int64(time.Millisecond))
+		if current == nil || current.Column.Started != started {
+			flush()
+			current = &updater.InflatedColumn{
+				Column: &statepb.Column{
+					Build:   name,
+					Started: started,
+					Hint:    col.Column.Hint,
+					Extra:   col.Column.Extra,
+				},
+				Cells: map[string]updater.Cell{},
+			}
+			runs = map[string][]updater.Cell{}
+		}
+		current.Column.MergedBuilds = append(current.Column.MergedBuilds, col.Column.Build)
+		for name, cell := range col.Cells {
+			if cell.Result == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			runs[name] = append(runs[name], cell)
+		}
+	}
+	flush()
+
+	out := updater.ConstructGrid(log, group, cols)
+	out.LastTimeUpdated = grid.LastTimeUpdated
+	out.ArchivePath = grid.ArchivePath
+	rows := make(map[string]*statepb.Row, len(grid.Rows))
+	for _, row := range grid.Rows {
+		rows[row.Name] = row
+	}
+	for _, row := range out.Rows {
+		orig, ok := rows[row.Name]
+		if !ok {
+			continue
+		}
+		row.AlertInfo = orig.AlertInfo
+		row.LastGreen = orig.LastGreen
+		row.FirstSeen = orig.FirstSeen
+		row.IsNew = orig.IsNew
+		row.Tombstone = orig.Tombstone
+		row.BugId = orig.BugId
+	}
+	return out
+}
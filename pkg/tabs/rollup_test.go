@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+func TestRollup(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("LoadLocation() got unexpected error: %v", err)
+	}
+	millis := func(when time.Time) float64 {
+		return float64(when.Unix() * 1000)
+	}
+	at := func(hour, min int) float64 {
+		return millis(time.Date(2021, 1, 2, hour, min, 0, 0, time.UTC))
+	}
+	pass := updater.Cell{Result: statuspb.TestStatus_PASS, Icon: "P"}
+	fail := updater.Cell{Result: statuspb.TestStatus_FAIL, Icon: "F", Message: "boom"}
+	empty := updater.Cell{}
+	cols := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "4", Hint: "4", Started: at(10, 50)},
+			Cells:  map[string]updater.Cell{"a": pass, "b": pass},
+		},
+		{
+			Column: &statepb.Column{Build: "3", Hint: "3", Started: at(10, 10)},
+			Cells:  map[string]updater.Cell{"a": fail},
+		},
+		{
+			Column: &statepb.Column{Build: "2", Hint: "2", Started: at(9, 30)},
+			Cells:  map[string]updater.Cell{"a": pass, "b": pass},
+		},
+		{
+			Column: &statepb.Column{Build: "1", Hint: "1", Started: at(1, 0)},
+			Cells:  map[string]updater.Cell{"a": fail, "b": pass},
+		},
+	}
+	log := logrus.WithField("test", "TestRollup")
+	grid := updater.ConstructGrid(log, &configpb.TestGroup{}, cols)
+	grid.LastTimeUpdated = 7
+
+	cases := []struct {
+		name   string
+		rollup configpb.DashboardTab_Rollup
+		dates  Dates
+		want   []updater.InflatedColumn
+	}{
+		{
+			name: "keep every column",
+			want: cols,
+		},
+		{
+			name:   "hourly",
+			rollup: configpb.DashboardTab_HOURLY,
+			want: []updater.InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:        "2021-01-02 10:00",
+						Hint:         "4",
+						Started:      at(10, 0),
+						MergedBuilds: []string{"4", "3"},
+					},
+					Cells: map[string]updater.Cell{
+						"a": {Result: statuspb.TestStatus_FAIL, Icon: "1/2", Message: "1/2 runs passed: boom"},
+						"b": pass,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:        "2021-01-02 09:00",
+						Hint:         "2",
+						Started:      at(9, 0),
+						MergedBuilds: []string{"2"},
+					},
+					Cells: map[string]updater.Cell{"a": pass, "b": pass},
+				},
+				{
+					Column: &statepb.Column{
+						Build:        "2021-01-02 01:00",
+						Hint:         "1",
+						Started:      at(1, 0),
+						MergedBuilds: []string{"1"},
+					},
+					Cells: map[string]updater.Cell{"a": fail, "b": pass},
+				},
+			},
+		},
+		{
+			name:   "daily in the time zone",
+			rollup: configpb.DashboardTab_DAILY,
+			dates:  Dates{loc: la},
+			want: []updater.InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:        "2021-01-02",
+						Hint:         "4",
+						Started:      millis(time.Date(2021, 1, 2, 0, 0, 0, 0, la)),
+						MergedBuilds: []string{"4", "3", "2"},
+					},
+					Cells: map[string]updater.Cell{
+						"a": {Result: statuspb.TestStatus_FAIL, Icon: "2/3", Message: "2/3 runs passed: boom"},
+						"b": {Result: statuspb.TestStatus_PASS, Icon: "2/2", Message: "2/2 runs passed"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:        "2021-01-01",
+						Hint:         "1",
+						Started:      millis(time.Date(2021, 1, 1, 0, 0, 0, 0, la)),
+						MergedBuilds: []string{"1"},
+					},
+					Cells: map[string]updater.Cell{"a": fail, "b": pass},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := grid.String()
+			got := Rollup(log, grid, &configpb.TestGroup{}, tc.rollup, tc.dates)
+			if grid.String() != orig {
+				t.Errorf("Rollup() modified the grid")
+			}
+			if got.LastTimeUpdated != grid.LastTimeUpdated {
+				t.Errorf("Rollup() got last time updated %f, want %f", got.LastTimeUpdated, grid.LastTimeUpdated)
+			}
+			for _, col := range tc.want {
+				for _, row := range grid.Rows {
+					if _, ok := col.Cells[row.Name]; !ok {
+						col.Cells[row.Name] = empty
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.want, updater.InflateGrid(got), protocmp.Transform()); diff != "" {
+				t.Errorf("Rollup() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+)
+
+// RowIndex returns a summary of each row in the grid, without any cells.
+func RowIndex(grid *statepb.Grid) *responsepb.RowIndex {
+	var index responsepb.RowIndex
+	for i, row := range grid.Rows {
+		index.Rows = append(index.Rows, rowSummary(i, row))
+	}
+	return &index
+}
+
+// rowSummary counts the results of the row.
+func rowSummary(idx int, row *statepb.Row) *responsepb.RowSummary {
+	sum := responsepb.RowSummary{
+		Index:   int32(idx),
+		Name:    row.Name,
+		Id:      row.Id,
+		Alert:   row.AlertInfo != nil,
+		IsNew:   row.IsNew,
+		Removed: row.Tombstone != nil,
+	}
+	if lg := row.LastGreen; lg != nil {
+		sum.LastGreenBuildId = lg.BuildId
+		sum.LastGreenTime = float64(lg.Time.GetSeconds()) + float64(lg.Time.GetNanos())/1e9
+	}
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		res, count := statuspb.TestStatus(row.Results[i]), row.Results[i+1]
+		switch {
+		case res == statuspb.TestStatus_NO_RESULT:
+			continue
+		case res == statuspb.TestStatus_FLAKY:
+			sum.Flakes += count
+		case result.Passing(res):
+			sum.Passes += count
+		case result.Failing(res):
+			sum.Failures += count
+		}
+		if sum.LatestResult == statuspb.TestStatus_NO_RESULT {
+			sum.LatestResult = res
+		}
+	}
+	return &sum
+}
+
+// RowBatch returns the cells of the grid's rows, which start at the index
+// of a tab with the total number of rows.
+func RowBatch(grid *statepb.Grid, start, total int) *responsepb.RowBatch {
+	batch := responsepb.RowBatch{
+		Start:     int32(start),
+		TotalRows: int32(total),
+	}
+	for _, row := range grid.Rows {
+		batch.Rows = append(batch.Rows, &responsepb.RowCells{
+			Name:     row.Name,
+			Id:       row.Id,
+			Results:  row.Results,
+			Messages: row.Messages,
+			Icons:    row.Icons,
+			CellIds:  row.CellIds,
+
+			Properties:     row.Properties,
+			CellLinks:      row.CellLinks,
+			CellParameters: row.CellParameters,
+			Metrics:        row.Metrics,
+		})
+	}
+	return &batch
+}
+
+// CellParameters returns the parameter results folded into the cell of the named row and build,
+// or nil if the grid has no such row or column.
+func CellParameters(grid *statepb.Grid, name, build string) *statepb.CellParameters {
+	col := -1
+	for i, c := range grid.Columns {
+		if c.Build == build {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return nil
+	}
+	for _, row := range grid.Rows {
+		if row.Name == name {
+			return &statepb.CellParameters{Results: updater.ParameterResults(row, col)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestRowIndex(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	flaky := int32(statuspb.TestStatus_FLAKY)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	cols := []*statepb.Column{{Build: "4"}, {Build: "3"}, {Build: "2"}, {Build: "1"}}
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+		want *responsepb.RowIndex
+	}{
+		{
+			name: "basically works",
+			grid: &statepb.Grid{},
+			want: &responsepb.RowIndex{},
+		},
+		{
+			name: "summarize rows",
+			grid: &statepb.Grid{
+				Columns: cols,
+				Rows: []*statepb.Row{
+					{
+						Name:      "foo",
+						Id:        "//foo",
+						Results:   []int32{empty, 1, fail, 2, pass, 1},
+						AlertInfo: &statepb.AlertInfo{FailCount: 2},
+						LastGreen: &statepb.LastGreen{
+							BuildId: "1",
+							Time:    &timestamp.Timestamp{Seconds: 100, Nanos: 500000000},
+						},
+					},
+					{
+						Name:    "bar",
+						Results: []int32{flaky, 1, pass, 3},
+						IsNew:   true,
+					},
+					{
+						Name:      "never ran",
+						Results:   []int32{empty, 4},
+						Tombstone: &statepb.Tombstone{MissingColumns: 4},
+					},
+				},
+			},
+			want: &responsepb.RowIndex{
+				Rows: []*responsepb.RowSummary{
+					{
+						Name:             "foo",
+						Id:               "//foo",
+						Passes:           1,
+						Failures:         2,
+						LatestResult:     statuspb.TestStatus_FAIL,
+						Alert:            true,
+						LastGreenBuildId: "1",
+						LastGreenTime:    100.5,
+					},
+					{
+						Index:        1,
+						Name:         "bar",
+						Passes:       3,
+						Flakes:       1,
+						LatestResult: statuspb.TestStatus_FLAKY,
+						IsNew:        true,
+					},
+					{
+						Index:   2,
+						Name:    "never ran",
+						Removed: true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, RowIndex(tc.grid), protocmp.Transform()); diff != "" {
+				t.Errorf("RowIndex() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRowBatch(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name:      "foo",
+				Id:        "//foo",
+				Results:   []int32{pass, 2},
+				Messages:  []string{"", "ok"},
+				CellIds:   []string{"2", "1"},
+				AlertInfo: &statepb.AlertInfo{FailCount: 1},
+				Properties: []*statepb.PropertyValues{
+					{Name: "shard", Values: []string{"", "3"}},
+				},
+				CellLinks: []*statepb.CellLinks{
+					{},
+					{Links: []*statepb.Link{{Name: "log", Url: "https://example.com/log"}}},
+				},
+				CellParameters: []*statepb.CellParameters{
+					{},
+					{Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: pass}}},
+				},
+				Metrics: []*statepb.Metric{
+					{Name: "elapsed", Indices: []int32{1, 1}, Values: []float64{3}},
+				},
+			},
+		},
+	}
+	want := &responsepb.RowBatch{
+		Start:     3,
+		TotalRows: 10,
+		Rows: []*responsepb.RowCells{
+			{
+				Name:     "foo",
+				Id:       "//foo",
+				Results:  []int32{pass, 2},
+				Messages: []string{"", "ok"},
+				CellIds:  []string{"2", "1"},
+
+				Properties: []*statepb.PropertyValues{
+					{Name: "shard", Values: []string{"", "3"}},
+				},
+				CellLinks: []*statepb.CellLinks{
+					{},
+					{Links: []*statepb.Link{{Name: "log", Url: "https://example.com/log"}}},
+				},
+				CellParameters: []*statepb.CellParameters{
+					{},
+					{Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: pass}}},
+				},
+				Metrics: []*statepb.Metric{
+					{Name: "elapsed", Indices: []int32{1, 1}, Values: []float64{3}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, RowBatch(grid, 3, 10), protocmp.Transform()); diff != "" {
+		t.Errorf("RowBatch() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCellParameters(t *testing.T) {
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{
+				Name:    "foo",
+				Results: []int32{empty, 1, fail, 2},
+				CellParameters: []*statepb.CellParameters{
+					{Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: fail}}},
+					{},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		name  string
+		row   string
+		build string
+		want  *statepb.CellParameters
+	}{
+		{
+			name:  "folded parameters",
+			row:   "foo",
+			build: "2",
+			want: &statepb.CellParameters{
+				Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: fail}},
+			},
+		},
+		{
+			name:  "no parameters",
+			row:   "foo",
+			build: "1",
+			want:  &statepb.CellParameters{},
+		},
+		{
+			name:  "empty cell",
+			row:   "foo",
+			build: "3",
+			want:  &statepb.CellParameters{},
+		},
+		{
+			name:  "missing build",
+			row:   "foo",
+			build: "4",
+		},
+		{
+			name:  "missing row",
+			row:   "bar",
+			build: "2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CellParameters(grid, tc.row, tc.build)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("CellParameters() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tabs reads the summaries and recent results of many dashboard tabs at once.
+package tabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/summarizer"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// ErrNotFound means the requested dashboard or tab is not configured or has not been written.
+var ErrNotFound = errors.New("not found")
+
+// Request identifies a dashboard tab and the number of recent columns to return.
+//
+// Columns are limited to the tab's column window unless FullHistory is set.
+// Rows are limited to those from RowStart up to RowEnd when RowEnd is positive.
+type Request struct {
+	Dashboard   string
+	Tab         string
+	Columns     int
+	FullHistory bool
+	RowStart    int
+	RowEnd      int
+}
+
+// Result holds the summary and recent columns of a requested tab, or why they are missing.
+type Result struct {
+	Request
+	Summary *summarypb.DashboardTabSummary
+	Grid    *statepb.Grid
+	// TotalRows is the number of rows in the tab, regardless of the requested rows.
+	TotalRows int
+	Err       error
+}
+
+// Reader reads tabs from the storage written by the updater and summarizer.
+type Reader struct {
+	Client        gcs.Opener
+	Config        *configpb.Configuration
+	ConfigPath    gcs.Path
+	GridPrefix    string
+	SummaryPrefix string
+	// TabPrefix reads tab states written by the tabulator under this prefix if set.
+	//
+	// Tabs which have not been tabulated yet fall back to the test group state.
+	TabPrefix   string
+	Concurrency int
+	// Cache holds decoded grids and summaries if set.
+	Cache *Cache
+	// Decodes limits how many grids and summaries every request together reads at once if set.
+	Decodes *semaphore.Weighted
+
+	refresh bool // replace cached values
+}
+
+type summaryEntry struct {
+	once sync.Once
+	sum  *summarypb.DashboardSummary
+	err  error
+}
+
+// GetTabs returns a result for each request, in request order.
+//
+// Tabs are read concurrently, and each dashboard summary is read at most once.
+func (r Reader) GetTabs(ctx context.Context, reqs []Request) []Result {
+	results := make([]Result, len(reqs))
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var lock sync.Mutex
+	summaries := map[string]*summaryEntry{}
+	summary := func(dashboard string) (*summarypb.DashboardSummary, error) {
+		lock.Lock()
+		entry, ok := summaries[dashboard]
+		if !ok {
+			entry = &summaryEntry{}
+			summaries[dashboard] = entry
+		}
+		lock.Unlock()
+		entry.once.Do(func() {
+			entry.sum, entry.err = r.readSummary(ctx, dashboard)
+		})
+		return entry.sum, entry.err
+	}
+
+	idxs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxs {
+				results[idx] = r.getTab(ctx, reqs[idx], summary)
+			}
+		}()
+	}
+	for i := range reqs {
+		idxs <- i
+	}
+	close(idxs)
+	wg.Wait()
+	return results
+}
+
+func (r Reader) readSummary(ctx context.Context, dashboard string) (*summarypb.DashboardSummary, error) {
+	path, err := summarizer.SummaryPath(r.ConfigPath, r.SummaryPrefix, dashboard)
+	if err != nil {
+		return nil, fmt.Errorf("summary path: %w", err)
+	}
+	val, err := r.Cache.get(path.String(), r.refresh, func() (interface{}, error) {
+		return r.decode(ctx, func() (interface{}, error) {
+			return summarizer.ReadSummary(ctx, r.Client, *path)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*summarypb.DashboardSummary), nil
+}
+
+// cachedGrid returns the cached grid at the path, or reads it.
+func (r Reader) cachedGrid(ctx context.Context, path gcs.Path, read func() (*statepb.Grid, error)) (*statepb.Grid, error) {
+	val, err := r.Cache.get(path.String(), r.refresh, func() (interface{}, error) {
+		return r.decode(ctx, func() (interface{}, error) {
+			return read()
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*statepb.Grid), nil
+}
+
+// decode reads once fewer than the Decodes limit are in flight.
+func (r Reader) decode(ctx context.Context, read func() (interface{}, error)) (interface{}, error) {
+	if r.Decodes == nil {
+		return read()
+	}
+	if err := r.Decodes.Acquire(ctx, 1); err != nil {
+		return nil, fmt.Errorf("wait to decode: %w", err)
+	}
+	defer r.Decodes.Release(1)
+	return read()
+}
+
+// findTab returns the configured dashboard and tab, or an ErrNotFound error.
+func (r Reader) findTab(dashboard, tab string) (*configpb.Dashboard, *configpb.DashboardTab, error) {
+	dash := config.FindDashboard(dashboard, r.Config)
+	if dash == nil {
+		return nil, nil, fmt.Errorf("dashboard %q: %w", dashboard, ErrNotFound)
+	}
+	for _, dt := range dash.DashboardTab {
+		if dt.Name == tab {
+			return dash, dt, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("tab %q: %w", tab, ErrNotFound)
+}
+
+// GridPath returns the path to the grid of the dashboard tab's test group.
+func (r Reader) GridPath(dashboard, tab string) (*gcs.Path, error) {
+	_, dt, err := r.findTab(dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	gridPath, err := updater.TestGroupPath(r.ConfigPath, r.GridPrefix, dt.TestGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("grid path: %w", err)
+	}
+	return gridPath, nil
+}
+
+// TestGroup returns the configuration of the dashboard tab's test group.
+func (r Reader) TestGroup(dashboard, tab string) (*configpb.TestGroup, error) {
+	_, dt, err := r.findTab(dashboard, tab)
+	if err != nil {
+		return nil, err
+	}
+	group := config.FindTestGroup(dt.TestGroupName, r.Config)
+	if group == nil {
+		return nil, fmt.Errorf("test group %q: %w", dt.TestGroupName, ErrNotFound)
+	}
+	return group, nil
+}
+
+func (r Reader) getTab(ctx context.Context, req Request, summary func(string) (*summarypb.DashboardSummary, error)) Result {
+	res := Result{Request: req}
+	dash, tab, err := r.findTab(req.Dashboard, req.Tab)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	sum, err := summary(dash.Name)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		err = fmt.Errorf("%v: %w", err, ErrNotFound)
+	}
+	if err != nil {
+		res.Err = fmt.Errorf("read summary: %w", err)
+		return res
+	}
+	for _, ts := range sum.TabSummaries {
+		if ts.DashboardTabName == tab.Name {
+			res.Summary = ts
+			break
+		}
+	}
+
+	if req.Columns <= 0 {
+		return res
+	}
+	if r.TabPrefix != "" && !req.FullHistory {
+		tabPath, err := TabStatePath(r.ConfigPath, r.TabPrefix, dash.Name, tab.Name)
+		if err != nil {
+			res.Err = fmt.Errorf("tab path: %w", err)
+			return res
+		}
+		grid, err := r.cachedGrid(ctx, *tabPath, func() (*statepb.Grid, error) {
+			return readGrid(ctx, r.Client, *tabPath)
+		})
+		switch {
+		case err == nil:
+			res.Grid, res.TotalRows = requestedRows(grid, req, req.Columns), len(grid.Rows)
+			return res
+		case !errors.Is(err, storage.ErrObjectNotExist):
+			res.Err = fmt.Errorf("read tab state: %w", err)
+			return res
+		}
+	}
+	gridPath, err := updater.TestGroupPath(r.ConfigPath, r.GridPrefix, tab.TestGroupName)
+	if err != nil {
+		res.Err = fmt.Errorf("grid path: %w", err)
+		return res
+	}
+	grid, err := r.cachedGrid(ctx, *gridPath, func() (*statepb.Grid, error) {
+		return gcs.DownloadGrid(ctx, r.Client, *gridPath)
+	})
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		err = fmt.Errorf("%v: %w", err, ErrNotFound)
+	}
+	if err != nil {
+		res.Err = fmt.Errorf("read grid: %w", err)
+		return res
+	}
+	group := config.FindTestGroup(tab.TestGroupName, r.Config)
+	if req.FullHistory && req.Columns > len(grid.Columns) && len(grid.ArchiveShards) > 0 {
+		if grid, err = r.stitchArchive(ctx, group, grid, req.Columns); err != nil {
+			res.Err = fmt.Errorf("read archive: %w", err)
+			return res
+		}
+	}
+	cols := req.Columns
+	if !req.FullHistory {
+		if n := windowColumns(grid.Columns, tab.ColumnWindow, time.Now()); n < cols {
+			cols = n
+		}
+	}
+	res.Grid, res.TotalRows = requestedRows(grid, req, cols), len(grid.Rows)
+	SetRowLinks(res.Grid, tab.RowLinkTemplates)
+	SetCellLinks(res.Grid, group, CellLinkTemplates(tab))
+	if dates, err := NewDates(dash); err == nil {
+		SetColumnDates(res.Grid, group, dates)
+	}
+	return res
+}
+
+// stitchArchive returns the grid along with the columns of its archive shards,
+// reading shards newest first until there are at least n columns.
+func (r Reader) stitchArchive(ctx context.Context, group *configpb.TestGroup, grid *statepb.Grid, n int) (*statepb.Grid, error) {
+	if group == nil {
+		group = &configpb.TestGroup{}
+	}
+	cols := len(grid.Columns)
+	var shards []*statepb.Grid
+	for _, shard := range grid.ArchiveShards {
+		if cols >= n {
+			break
+		}
+		path, err := gcs.NewPath(shard.Path)
+		if err != nil {
+			return nil, fmt.Errorf("shard path: %w", err)
+		}
+		sg, err := r.cachedGrid(ctx, *path, func() (*statepb.Grid, error) {
+			return gcs.DownloadGrid(ctx, r.Client, *path)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+		shards = append(shards, sg)
+		cols += len(sg.Columns)
+	}
+	return updater.StitchGrids(logrus.WithField("group", group.Name), group, grid, shards...), nil
+}
+
+// requestedRows returns a copy of the first n columns of the requested rows.
+func requestedRows(grid *statepb.Grid, req Request, n int) *statepb.Grid {
+	end := len(grid.Rows)
+	if req.RowEnd > 0 {
+		end = req.RowEnd
+	}
+	return state.RecentRows(grid, n, req.RowStart, end)
+}
+
+// readGrid reads the grid at the path, returning an error wrapping
+// storage.ErrObjectNotExist if it does not exist.
+func readGrid(ctx context.Context, client gcs.Opener, path gcs.Path) (*statepb.Grid, error) {
+	r, err := client.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	return state.Read(r)
+}
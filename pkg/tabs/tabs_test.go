@@ -0,0 +1,331 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	summarypb "github.com/GoogleCloudPlatform/testgrid/pb/summary"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func compressGrid(t *testing.T, grid *statepb.Grid) string {
+	buf, err := proto.Marshal(grid)
+	if err != nil {
+		t.Fatalf("marshal grid: %v", err)
+	}
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	if _, err := zw.Write(buf); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return zbuf.String()
+}
+
+func TestGetTabs(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "first", TestGroupName: "group-one"},
+					{Name: "second", TestGroupName: "group-two"},
+				},
+			},
+			{
+				Name: "missing-summary",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tab", TestGroupName: "group-one"},
+				},
+			},
+		},
+	}
+	first := &summarypb.DashboardTabSummary{DashboardTabName: "first", OverallStatus: summarypb.DashboardTabSummary_PASS}
+	second := &summarypb.DashboardTabSummary{DashboardTabName: "second", OverallStatus: summarypb.DashboardTabSummary_FAIL}
+	sumBuf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{first, second},
+	})
+	if err != nil {
+		t.Fatalf("marshal summary: %v", err)
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{int32(statuspb.TestStatus_PASS), 2}, Messages: []string{"", ""}, Icons: []string{"", ""}},
+		},
+	}
+	client := fake.Opener{
+		mustPath("gs://bucket/summary/summary-dash"): {Data: string(sumBuf)},
+		mustPath("gs://bucket/grid/group-one"):       {Data: compressGrid(t, grid)},
+	}
+	reader := Reader{
+		Client:        client,
+		Config:        cfg,
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+		Concurrency:   3,
+	}
+
+	reqs := []Request{
+		{Dashboard: "dash", Tab: "first", Columns: 1},
+		{Dashboard: "dash", Tab: "second"},
+		{Dashboard: "dash", Tab: "third"},
+		{Dashboard: "nope", Tab: "first"},
+		{Dashboard: "missing-summary", Tab: "tab"},
+	}
+	got := reader.GetTabs(context.Background(), reqs)
+	if len(got) != len(reqs) {
+		t.Fatalf("GetTabs() got %d results, want %d", len(got), len(reqs))
+	}
+
+	wantGrid := state.RecentColumns(grid, 1)
+	if diff := cmp.Diff(first, got[0].Summary, protocmp.Transform()); diff != "" || got[0].Err != nil {
+		t.Errorf("GetTabs()[0] got unexpected summary (err: %v, -want +got):\n%s", got[0].Err, diff)
+	}
+	if diff := cmp.Diff(wantGrid, got[0].Grid, protocmp.Transform()); diff != "" {
+		t.Errorf("GetTabs()[0] got unexpected grid (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(second, got[1].Summary, protocmp.Transform()); diff != "" || got[1].Err != nil {
+		t.Errorf("GetTabs()[1] got unexpected summary (err: %v, -want +got):\n%s", got[1].Err, diff)
+	}
+	if got[1].Grid != nil {
+		t.Errorf("GetTabs()[1] got unrequested grid: %v", got[1].Grid)
+	}
+	for i := 2; i < len(reqs); i++ {
+		if got[i].Err == nil {
+			t.Errorf("GetTabs()[%d] failed to return an error for %v", i, reqs[i])
+		}
+		if got[i].Request != reqs[i] {
+			t.Errorf("GetTabs()[%d] got request %v, want %v", i, got[i].Request, reqs[i])
+		}
+	}
+}
+
+func TestGetTabsStitchesArchive(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name:         "dash",
+				DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+			},
+		},
+	}
+	sumBuf, err := proto.Marshal(&summarypb.DashboardSummary{})
+	if err != nil {
+		t.Fatalf("marshal summary: %v", err)
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3", Started: 3000}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{pass, 1}, Messages: []string{""}, Icons: []string{""}, CellIds: []string{""}, BugId: []string{"bug"}},
+		},
+		ArchiveShards: []*statepb.ArchiveShard{
+			{Path: "gs://cold/group.columns/newer", OldestStarted: 2000, NewestStarted: 2000, Columns: 1},
+			{Path: "gs://cold/group.columns/older", OldestStarted: 1000, NewestStarted: 1000, Columns: 1},
+		},
+	}
+	newer := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "2", Started: 2000}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{pass, 1}, Messages: []string{""}, Icons: []string{""}, CellIds: []string{""}},
+			{Name: "bar", Results: []int32{pass, 1}, Messages: []string{""}, Icons: []string{""}, CellIds: []string{""}},
+		},
+	}
+	older := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1", Started: 1000}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{pass, 1}, Messages: []string{""}, Icons: []string{""}, CellIds: []string{""}},
+		},
+	}
+	client := fake.Opener{
+		mustPath("gs://bucket/summary/summary-dash"): {Data: string(sumBuf)},
+		mustPath("gs://bucket/grid/group"):           {Data: compressGrid(t, grid)},
+		mustPath("gs://cold/group.columns/newer"):    {Data: compressGrid(t, newer)},
+		mustPath("gs://cold/group.columns/older"):    {Data: compressGrid(t, older)},
+	}
+	reader := Reader{
+		Client:        client,
+		Config:        cfg,
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+		Concurrency:   1,
+	}
+
+	cases := []struct {
+		name     string
+		req      Request
+		want     []string
+		wantRows []string
+	}{
+		{
+			name:     "recent columns ignore the archive",
+			req:      Request{Dashboard: "dash", Tab: "tab", Columns: 5},
+			want:     []string{"3"},
+			wantRows: []string{"foo"},
+		},
+		{
+			name:     "full history without more columns ignores the archive",
+			req:      Request{Dashboard: "dash", Tab: "tab", Columns: 1, FullHistory: true},
+			want:     []string{"3"},
+			wantRows: []string{"foo"},
+		},
+		{
+			name:     "read shards until there are enough columns",
+			req:      Request{Dashboard: "dash", Tab: "tab", Columns: 2, FullHistory: true},
+			want:     []string{"3", "2"},
+			wantRows: []string{"bar", "foo"},
+		},
+		{
+			name:     "read every shard",
+			req:      Request{Dashboard: "dash", Tab: "tab", Columns: 10, FullHistory: true},
+			want:     []string{"3", "2", "1"},
+			wantRows: []string{"bar", "foo"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := reader.GetTabs(context.Background(), []Request{tc.req})[0]
+			if got.Err != nil {
+				t.Fatalf("GetTabs() got unexpected error: %v", got.Err)
+			}
+			var builds, rows []string
+			for _, col := range got.Grid.Columns {
+				builds = append(builds, col.Build)
+			}
+			for _, row := range got.Grid.Rows {
+				rows = append(rows, row.Name)
+				if row.Name == "foo" && !cmp.Equal(row.BugId, []string{"bug"}) {
+					t.Errorf("GetTabs() got foo bugs %v, want the grid's", row.BugId)
+				}
+			}
+			if diff := cmp.Diff(tc.want, builds); diff != "" {
+				t.Errorf("GetTabs() got unexpected columns (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRows, rows); diff != "" {
+				t.Errorf("GetTabs() got unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTabsLimitsDecodes(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	sumBuf, err := proto.Marshal(&summarypb.DashboardSummary{
+		TabSummaries: []*summarypb.DashboardTabSummary{{DashboardTabName: "tab"}},
+	})
+	if err != nil {
+		t.Fatalf("marshal summary: %v", err)
+	}
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1"}},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{int32(statuspb.TestStatus_PASS), 1}, Messages: []string{""}, Icons: []string{""}},
+		},
+	}
+	decodes := semaphore.NewWeighted(1)
+	reader := Reader{
+		Client: fake.Opener{
+			mustPath("gs://bucket/summary/summary-dash"): {Data: string(sumBuf)},
+			mustPath("gs://bucket/grid/group"):           {Data: compressGrid(t, grid)},
+		},
+		Config: &configpb.Configuration{
+			Dashboards: []*configpb.Dashboard{
+				{
+					Name:         "dash",
+					DashboardTab: []*configpb.DashboardTab{{Name: "tab", TestGroupName: "group"}},
+				},
+			},
+		},
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+		Concurrency:   2,
+		Decodes:       decodes,
+	}
+	reqs := []Request{
+		{Dashboard: "dash", Tab: "tab", Columns: 1},
+		{Dashboard: "dash", Tab: "tab", Columns: 1},
+	}
+
+	// Another request holds every decode.
+	if !decodes.TryAcquire(1) {
+		t.Fatal("TryAcquire() failed")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	for i, res := range reader.GetTabs(ctx, reqs) {
+		if !errors.Is(res.Err, context.DeadlineExceeded) {
+			t.Errorf("GetTabs()[%d] got error %v, want %v", i, res.Err, context.DeadlineExceeded)
+		}
+	}
+
+	decodes.Release(1)
+	for i, res := range reader.GetTabs(context.Background(), reqs) {
+		if res.Err != nil {
+			t.Errorf("GetTabs()[%d] got unexpected error: %v", i, res.Err)
+		}
+		if diff := cmp.Diff(grid, res.Grid, protocmp.Transform()); diff != "" {
+			t.Errorf("GetTabs()[%d] got unexpected grid (-want +got):\n%s", i, diff)
+		}
+	}
+	if !decodes.TryAcquire(1) {
+		t.Error("GetTabs() failed to release its decodes")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"sort"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+)
+
+// Transitions returns the columns where each row of the grid started failing
+// after passing, or passing after failing, newest first and limited to the
+// latest max transitions when positive.
+//
+// Running, flaky and other results which neither pass nor fail are ignored, so
+// a row which passes, flakes and then fails transitions once.
+func Transitions(grid *statepb.Grid, max int) *responsepb.TransitionFeed {
+	var feed responsepb.TransitionFeed
+	for _, row := range grid.Rows {
+		feed.Transitions = append(feed.Transitions, rowTransitions(grid.Columns, row)...)
+	}
+	sort.SliceStable(feed.Transitions, func(i, j int) bool {
+		a, b := feed.Transitions[i], feed.Transitions[j]
+		if a.Started != b.Started {
+			return a.Started > b.Started
+		}
+		return a.Name < b.Name
+	})
+	if max > 0 && len(feed.Transitions) > max {
+		feed.Transitions = feed.Transitions[:max]
+	}
+	return &feed
+}
+
+// rowTransitions returns the transitions of the row, oldest first.
+func rowTransitions(cols []*statepb.Column, row *statepb.Row) []*responsepb.Transition {
+	results := state.Expand(row.Results)
+	if len(results) > len(cols) {
+		results = results[:len(cols)]
+	}
+	// Messages only include filled cells, so index them by column.
+	messages := make([]string, len(results))
+	var filled int
+	for i, res := range results {
+		if statuspb.TestStatus(res) == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		if filled < len(row.Messages) {
+			messages[i] = row.Messages[filled]
+		}
+		filled++
+	}
+
+	var out []*responsepb.Transition
+	prev := statuspb.TestStatus_NO_RESULT
+	// Columns are ordered newest first.
+	for i := len(results) - 1; i >= 0; i-- {
+		res := statuspb.TestStatus(results[i])
+		switch result.Coalesce(res, result.IgnoreRunning) {
+		case statuspb.TestStatus_PASS, statuspb.TestStatus_FAIL:
+		default:
+			continue
+		}
+		if prev != statuspb.TestStatus_NO_RESULT && result.Failing(prev) != result.Failing(res) {
+			t := &responsepb.Transition{
+				Name:    row.Name,
+				From:    prev,
+				To:      res,
+				Build:   cols[i].Build,
+				Started: cols[i].Started,
+			}
+			if result.Failing(res) {
+				t.Message = messages[i]
+			}
+			out = append(out, t)
+		}
+		prev = res
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	responsepb "github.com/GoogleCloudPlatform/testgrid/pb/response"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestTransitions(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	flaky := int32(statuspb.TestStatus_FLAKY)
+	running := int32(statuspb.TestStatus_RUNNING)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "5", Started: 5000},
+			{Build: "4", Started: 4000},
+			{Build: "3", Started: 3000},
+			{Build: "2", Started: 2000},
+			{Build: "1", Started: 1000},
+		},
+		Rows: []*statepb.Row{
+			{Name: "stable", Results: []int32{pass, 5}},
+			{Name: "broke", Results: []int32{running, 1, fail, 2, pass, 2}, Messages: []string{"", "boom", "bang", "", ""}},
+			{Name: "fixed", Results: []int32{pass, 1, empty, 1, fail, 3}, Messages: []string{"", "old", "older", "oldest"}},
+			{Name: "flaky", Results: []int32{fail, 1, flaky, 1, pass, 3}, Messages: []string{"nope", "", "", "", ""}},
+			{Name: "new", Results: []int32{fail, 1, empty, 4}, Messages: []string{"first"}},
+		},
+	}
+	cases := []struct {
+		name string
+		grid *statepb.Grid
+		max  int
+		want *responsepb.TransitionFeed
+	}{
+		{
+			name: "empty grid",
+			grid: &statepb.Grid{},
+			want: &responsepb.TransitionFeed{},
+		},
+		{
+			name: "transitions",
+			grid: grid,
+			want: &responsepb.TransitionFeed{
+				Transitions: []*responsepb.Transition{
+					{
+						Name:    "fixed",
+						From:    statuspb.TestStatus_FAIL,
+						To:      statuspb.TestStatus_PASS,
+						Build:   "5",
+						Started: 5000,
+					},
+					{
+						Name:    "flaky",
+						From:    statuspb.TestStatus_PASS,
+						To:      statuspb.TestStatus_FAIL,
+						Build:   "5",
+						Started: 5000,
+						Message: "nope",
+					},
+					{
+						Name:    "broke",
+						From:    statuspb.TestStatus_PASS,
+						To:      statuspb.TestStatus_FAIL,
+						Build:   "3",
+						Started: 3000,
+						Message: "bang",
+					},
+				},
+			},
+		},
+		{
+			name: "limit transitions",
+			grid: grid,
+			max:  1,
+			want: &responsepb.TransitionFeed{
+				Transitions: []*responsepb.Transition{
+					{
+						Name:    "fixed",
+						From:    statuspb.TestStatus_FAIL,
+						To:      statuspb.TestStatus_PASS,
+						Build:   "5",
+						Started: 5000,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Transitions(tc.grid, tc.max)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Transitions() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// TabStatePath returns the path to the state of the dashboard tab written by the tabulator.
+func TabStatePath(configPath gcs.Path, tabPrefix, dashboard, tab string) (*gcs.Path, error) {
+	name := path.Join(tabPrefix, url.PathEscape(dashboard), url.PathEscape(tab))
+	u := url.URL{Path: name}
+	np, err := configPath.ResolveReference(&u)
+	if err != nil {
+		return nil, fmt.Errorf("resolve reference: %w", err)
+	}
+	if np.Bucket() != configPath.Bucket() {
+		return nil, fmt.Errorf("tab %s should not change bucket", name)
+	}
+	return np, nil
+}
+
+// windowColumns returns the number of recent columns within the window.
+func windowColumns(cols []*statepb.Column, window *configpb.DashboardTab_ColumnWindow, now time.Time) int {
+	n := len(cols)
+	if max := int(window.GetNumColumns()); max > 0 && max < n {
+		n = max
+	}
+	if days := window.GetDays(); days > 0 {
+		cutoff := float64(now.Add(-time.Duration(days)*24*time.Hour).UnixNano() / int64(time.Millisecond))
+		// Columns are ordered newest first.
+		for i, col := range cols[:n] {
+			if col.Started < cutoff {
+				n = i
+				break
+			}
+		}
+	}
+	return n
+}
+
+// Window returns a copy of the grid with only the columns within the window.
+func Window(grid *statepb.Grid, window *configpb.DashboardTab_ColumnWindow, now time.Time) *statepb.Grid {
+	return state.RecentColumns(grid, windowColumns(grid.Columns, window, now))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestTabStatePath(t *testing.T) {
+	configPath, err := gcs.NewPath("gs://bucket/config")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	got, err := TabStatePath(*configPath, "tabs", "my dash", "a/tab")
+	if err != nil {
+		t.Fatalf("TabStatePath() got unexpected error: %v", err)
+	}
+	const want = "tabs/my%20dash/a%2Ftab"
+	if got.Bucket() != "bucket" || got.Object() != want {
+		t.Errorf("TabStatePath() got gs://%s/%s, want gs://bucket/%s", got.Bucket(), got.Object(), want)
+	}
+}
+
+func TestWindowColumns(t *testing.T) {
+	now := time.Unix(10*24*60*60, 0)
+	day := func(d int) float64 {
+		return float64(now.Add(-time.Duration(d)*24*time.Hour).Unix() * 1000)
+	}
+	cols := []*statepb.Column{
+		{Build: "4", Started: day(0)},
+		{Build: "3", Started: day(1)},
+		{Build: "2", Started: day(3) + 1},
+		{Build: "1", Started: day(5)},
+	}
+	cases := []struct {
+		name   string
+		window *configpb.DashboardTab_ColumnWindow
+		want   int
+	}{
+		{
+			name: "every column by default",
+			want: 4,
+		},
+		{
+			name:   "limit columns",
+			window: &configpb.DashboardTab_ColumnWindow{NumColumns: 2},
+			want:   2,
+		},
+		{
+			name:   "limit days",
+			window: &configpb.DashboardTab_ColumnWindow{Days: 3},
+			want:   3,
+		},
+		{
+			name:   "smallest limit wins",
+			window: &configpb.DashboardTab_ColumnWindow{NumColumns: 2, Days: 3},
+			want:   2,
+		},
+		{
+			name:   "more columns than available",
+			window: &configpb.DashboardTab_ColumnWindow{NumColumns: 10},
+			want:   4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := windowColumns(cols, tc.window, now); got != tc.want {
+				t.Errorf("windowColumns() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetTabsWindow(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	cfg := &configpb.Configuration{
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "tabulated", TestGroupName: "group"},
+					{
+						Name:          "windowed",
+						TestGroupName: "group",
+						ColumnWindow:  &configpb.DashboardTab_ColumnWindow{NumColumns: 1},
+					},
+				},
+			},
+		},
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows:    []*statepb.Row{{Name: "foo", Results: []int32{pass, 3}, Messages: []string{"", "", ""}}},
+	}
+	tabState := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}},
+		Rows:    []*statepb.Row{{Name: "foo", Results: []int32{pass, 1}, Messages: []string{""}}},
+	}
+	reader := Reader{
+		Client: fake.Opener{
+			mustPath("gs://bucket/summary/summary-dash"): {},
+			mustPath("gs://bucket/grid/group"):           {Data: compressGrid(t, grid)},
+			mustPath("gs://bucket/tabs/dash/tabulated"):  {Data: compressGrid(t, tabState)},
+		},
+		Config:        cfg,
+		ConfigPath:    mustPath("gs://bucket/config"),
+		GridPrefix:    "grid",
+		SummaryPrefix: "summary",
+		TabPrefix:     "tabs",
+	}
+
+	cases := []struct {
+		name string
+		req  Request
+		want *statepb.Grid
+	}{
+		{
+			name: "read the tab state",
+			req:  Request{Dashboard: "dash", Tab: "tabulated", Columns: 10},
+			want: tabState,
+		},
+		{
+			name: "read the full history",
+			req:  Request{Dashboard: "dash", Tab: "tabulated", Columns: 10, FullHistory: true},
+			want: grid,
+		},
+		{
+			name: "window tabs which are not tabulated yet",
+			req:  Request{Dashboard: "dash", Tab: "windowed", Columns: 10},
+			want: state.RecentColumns(grid, 1),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := reader.GetTabs(context.Background(), []Request{tc.req})[0]
+			if got.Err != nil {
+				t.Fatalf("GetTabs() got unexpected error: %v", got.Err)
+			}
+			if diff := cmp.Diff(tc.want, got.Grid, protocmp.Transform()); diff != "" {
+				t.Errorf("GetTabs() got unexpected grid (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
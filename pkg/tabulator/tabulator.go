@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tabulator writes the state of each dashboard tab from its test group state.
+package tabulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/config"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// clock returns the current time, unless FixTime pins it.
+var clock = time.Now
+
+// FixTime pins the time used to compute tab states, so that identical inputs
+// always produce identical output, such as when comparing a canary.
+//
+// Call it before any update.
+func FixTime(t time.Time) {
+	clock = func() time.Time { return t }
+}
+
+// tab identifies a dashboard tab and its configuration.
+type tab struct {
+	dashboard string
+	tab       *configpb.DashboardTab
+	group     *configpb.TestGroup
+	dates     tabs.Dates
+}
+
+// Update writes the state of each dashboard tab, rolled up into periods when the
+// tab sets a rollup and limited to the tab's column window, with the links of
+// the tab's row and cell link templates and with column dates in the
+// dashboard's time zone.
+//
+// Each test group state is read once, so concurrency go routines tabulate groups in parallel.
+// Setting dashboard will limit update to this dashboard.
+// Will write tab states when confirm is set.
+func Update(ctx context.Context, client gcs.Client, configPath gcs.Path, concurrency int, dashboard, gridPathPrefix, tabPathPrefix string, confirm bool) error {
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive, got: %d", concurrency)
+	}
+	cfg, err := config.ReadGCS(ctx, client, configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	log := logrus.WithField("config", configPath)
+
+	groups := map[string][]tab{}
+	for _, d := range cfg.Dashboards {
+		if dashboard != "" && dashboard != d.Name {
+			continue
+		}
+		dates, err := tabs.NewDates(d)
+		if err != nil {
+			log.WithError(err).WithField("dashboard", d.Name).Warning("Formatting dates in UTC")
+		}
+		for _, dt := range d.DashboardTab {
+			groups[dt.TestGroupName] = append(groups[dt.TestGroupName], tab{
+				dashboard: d.Name,
+				tab:       dt,
+				group:     config.FindTestGroup(dt.TestGroupName, cfg),
+				dates:     dates,
+			})
+		}
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log.WithField("groups", len(names)).Info("Tabulating test groups")
+
+	ch := make(chan string)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var failures []string
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range ch {
+				log := log.WithField("group", name)
+				if err := tabulate(ctx, log, client, configPath, gridPathPrefix, tabPathPrefix, name, groups[name], clock(), confirm); err != nil {
+					log.WithError(err).Error("Failed to tabulate group")
+					lock.Lock()
+					failures = append(failures, name)
+					lock.Unlock()
+				}
+			}
+		}()
+	}
+	for _, name := range names {
+		ch <- name
+	}
+	close(ch)
+	wg.Wait()
+
+	if n := len(failures); n > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("failed to tabulate %d groups: %s", n, strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// tabulate writes the state of each tab of the group.
+func tabulate(ctx context.Context, log logrus.FieldLogger, client gcs.Client, configPath gcs.Path, gridPathPrefix, tabPathPrefix, group string, groupTabs []tab, now time.Time, confirm bool) error {
+	gridPath, err := updater.TestGroupPath(configPath, gridPathPrefix, group)
+	if err != nil {
+		return fmt.Errorf("grid path: %w", err)
+	}
+	grid, err := gcs.DownloadGrid(ctx, client, *gridPath)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		log.Debug("Skipping group without state")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read grid: %w", err)
+	}
+
+	var failures int
+	for _, t := range groupTabs {
+		log := log.WithFields(logrus.Fields{
+			"dashboard": t.dashboard,
+			"tab":       t.tab.Name,
+		})
+		tabPath, err := tabs.TabStatePath(configPath, tabPathPrefix, t.dashboard, t.tab.Name)
+		if err != nil {
+			log.WithError(err).Error("Bad tab path")
+			failures++
+			continue
+		}
+		tabGrid := tabs.Rollup(log, grid, t.group, t.tab.Rollup, t.dates)
+		tabGrid = tabs.Window(tabGrid, t.tab.ColumnWindow, now)
+		tabs.SetRowLinks(tabGrid, t.tab.RowLinkTemplates)
+		tabs.SetCellLinks(tabGrid, t.group, tabs.CellLinkTemplates(t.tab))
+		tabs.SetColumnDates(tabGrid, t.group, t.dates)
+		buf, err := gcs.MarshalGrid(tabGrid)
+		if err != nil {
+			log.WithError(err).Error("Failed to marshal tab state")
+			failures++
+			continue
+		}
+		log = log.WithFields(logrus.Fields{
+			"path":  tabPath,
+			"cols":  len(tabGrid.Columns),
+			"bytes": len(buf),
+		})
+		if !confirm {
+			log.Info("Tabulated (dry run)")
+			continue
+		}
+		if err := client.Upload(ctx, *tabPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+			log.WithError(err).Error("Failed to write tab state")
+			failures++
+			continue
+		}
+		log.Debug("Wrote tab state")
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to write %d of %d tabs", failures, len(groupTabs))
+	}
+	return nil
+}
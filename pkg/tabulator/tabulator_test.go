@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/tabs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestUpdate(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	cfg := &configpb.Configuration{
+		TestGroups: []*configpb.TestGroup{{Name: "group"}, {Name: "missing"}},
+		Dashboards: []*configpb.Dashboard{
+			{
+				Name: "dash",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "full", TestGroupName: "group"},
+					{
+						Name:          "recent",
+						TestGroupName: "group",
+						ColumnWindow:  &configpb.DashboardTab_ColumnWindow{NumColumns: 2},
+					},
+					{Name: "daily", TestGroupName: "group", Rollup: configpb.DashboardTab_DAILY},
+					{Name: "missing", TestGroupName: "missing"},
+				},
+			},
+			{
+				Name: "other",
+				DashboardTab: []*configpb.DashboardTab{
+					{Name: "skipped", TestGroupName: "group"},
+				},
+			},
+		},
+	}
+	cfgBuf, err := proto.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	pass := int32(statuspb.TestStatus_PASS)
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "3"}, {Build: "2"}, {Build: "1"}},
+		Rows: []*statepb.Row{{
+			Name:     "foo",
+			Results:  []int32{pass, 3},
+			Messages: []string{"", "", ""},
+			Icons:    []string{"", "", ""},
+			CellIds:  []string{"", "", ""},
+		}},
+	}
+	gridBuf, err := gcs.MarshalGrid(grid)
+	if err != nil {
+		t.Fatalf("marshal grid: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		confirm bool
+		want    map[string]*statepb.Grid
+	}{
+		{
+			name: "dry run",
+			want: map[string]*statepb.Grid{},
+		},
+		{
+			name:    "write windowed and rolled up tab states",
+			confirm: true,
+			want: map[string]*statepb.Grid{
+				"gs://bucket/tabs/dash/full":   state.RecentColumns(grid, 3),
+				"gs://bucket/tabs/dash/recent": state.RecentColumns(grid, 2),
+				"gs://bucket/tabs/dash/daily":  tabs.Rollup(logrus.New(), grid, cfg.TestGroups[0], configpb.DashboardTab_DAILY, tabs.Dates{}),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{}
+			client := fake.UploadClient{
+				Client: fake.Client{
+					Opener: fake.Opener{
+						mustPath("gs://bucket/config"):     {Data: string(cfgBuf)},
+						mustPath("gs://bucket/grid/group"): {Data: string(gridBuf)},
+					},
+				},
+				Uploader: uploader,
+			}
+			if err := Update(context.Background(), client, mustPath("gs://bucket/config"), 2, "dash", "grid", "tabs", tc.confirm); err != nil {
+				t.Fatalf("Update() got unexpected error: %v", err)
+			}
+			got := map[string]*statepb.Grid{}
+			for path, upload := range uploader {
+				opener := fake.Opener{path: {Data: string(upload.Buf)}}
+				g, err := gcs.DownloadGrid(context.Background(), opener, path)
+				if err != nil {
+					t.Fatalf("DownloadGrid(%s) got unexpected error: %v", path, err)
+				}
+				got[path.String()] = g
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Update() wrote unexpected tab states (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
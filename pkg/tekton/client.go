@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tekton reads the PipelineRuns of Tekton Pipelines, along with the
+// junit artifacts of their tasks.
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Group of the Tekton Pipelines API.
+	Group = "tekton.dev"
+	// Version of the Tekton Pipelines API.
+	Version = "v1beta1"
+
+	// PipelineLabel labels PipelineRuns and TaskRuns with their Pipeline.
+	PipelineLabel = "tekton.dev/pipeline"
+	// PipelineRunLabel labels TaskRuns with their PipelineRun.
+	PipelineRunLabel = "tekton.dev/pipelineRun"
+	// PipelineTaskLabel labels TaskRuns with the name of their task in the Pipeline.
+	PipelineTaskLabel = "tekton.dev/pipelineTask"
+
+	pageSize = "500"
+)
+
+// Client lists Tekton resources from a Kubernetes API server.
+type Client struct {
+	// Host is the URL of the API server, such as https://kubernetes.default.svc
+	Host string
+	// Token authenticates requests when set.
+	Token string
+	HTTP  *http.Client
+}
+
+// Condition describes the state of a run.
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RunStatus is the status of a PipelineRun or TaskRun.
+type RunStatus struct {
+	Conditions     []Condition  `json:"conditions,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// Succeeded returns the Succeeded condition, if any.
+func (s RunStatus) Succeeded() *Condition {
+	for i, c := range s.Conditions {
+		if c.Type == "Succeeded" {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// Param is a parameter of a PipelineRun.
+//
+// Value is empty unless the parameter is a string.
+type Param struct {
+	Name  string      `json:"name"`
+	Value ParamString `json:"value"`
+}
+
+// ParamString decodes string parameter values, ignoring arrays and objects.
+type ParamString string
+
+// UnmarshalJSON decodes a string value, or else leaves the value empty.
+func (p *ParamString) UnmarshalJSON(buf []byte) error {
+	var s string
+	if err := json.Unmarshal(buf, &s); err == nil {
+		*p = ParamString(s)
+	}
+	return nil
+}
+
+// PipelineRun is a run of a Pipeline.
+type PipelineRun struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Params []Param `json:"params,omitempty"`
+	} `json:"spec"`
+	Status RunStatus `json:"status"`
+}
+
+// Param returns the string value of the named parameter.
+func (pr PipelineRun) Param(name string) (string, bool) {
+	for _, p := range pr.Spec.Params {
+		if p.Name == name {
+			return string(p.Value), true
+		}
+	}
+	return "", false
+}
+
+// TaskRun is a run of a task of a PipelineRun.
+type TaskRun struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            RunStatus `json:"status"`
+}
+
+type list struct {
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           json.RawMessage `json:"items"`
+}
+
+// PipelineRuns returns the PipelineRuns of the Pipeline in the namespace.
+func (c Client) PipelineRuns(ctx context.Context, namespace, pipeline string) ([]PipelineRun, error) {
+	var out []PipelineRun
+	err := c.list(ctx, namespace, "pipelineruns", PipelineLabel+"="+pipeline, func(items json.RawMessage) error {
+		var runs []PipelineRun
+		if err := json.Unmarshal(items, &runs); err != nil {
+			return err
+		}
+		out = append(out, runs...)
+		return nil
+	})
+	return out, err
+}
+
+// TaskRuns returns the TaskRuns of the PipelineRun in the namespace.
+func (c Client) TaskRuns(ctx context.Context, namespace, pipelineRun string) ([]TaskRun, error) {
+	var out []TaskRun
+	err := c.list(ctx, namespace, "taskruns", PipelineRunLabel+"="+pipelineRun, func(items json.RawMessage) error {
+		var runs []TaskRun
+		if err := json.Unmarshal(items, &runs); err != nil {
+			return err
+		}
+		out = append(out, runs...)
+		return nil
+	})
+	return out, err
+}
+
+// list pages through the resources matching the label selector, decoding the items of each page.
+func (c Client) list(ctx context.Context, namespace, resource, selector string, decode func(json.RawMessage) error) error {
+	base, err := url.Parse(c.Host)
+	if err != nil {
+		return fmt.Errorf("parse host: %w", err)
+	}
+	base.Path = path.Join("/apis", Group, Version, "namespaces", namespace, resource)
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var cont string
+	for {
+		q := url.Values{"limit": {pageSize}, "labelSelector": {selector}}
+		if cont != "" {
+			q.Set("continue", cont)
+		}
+		base.RawQuery = q.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.String(), nil)
+		if err != nil {
+			return fmt.Errorf("request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		if c.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("get %s: %w", base.Path, err)
+		}
+		buf, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("read %s: %w", base.Path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("get %s: %s: %s", base.Path, resp.Status, buf)
+		}
+		var page list
+		if err := json.Unmarshal(buf, &page); err != nil {
+			return fmt.Errorf("decode %s: %w", base.Path, err)
+		}
+		if err := decode(page.Items); err != nil {
+			return fmt.Errorf("decode %s items: %w", base.Path, err)
+		}
+		if cont = page.Continue; cont == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newServer serves the JSON of each resource, label selector and continue
+// token, such as "pipelineruns?tekton.dev/pipeline=build&next".
+func newServer(t *testing.T, namespace string, pages map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("limit") != pageSize {
+			t.Errorf("%s sent limit %q", r.URL, q.Get("limit"))
+		}
+		prefix := "/apis/tekton.dev/v1beta1/namespaces/" + namespace + "/"
+		if len(r.URL.Path) <= len(prefix) || r.URL.Path[:len(prefix)] != prefix {
+			http.NotFound(w, r)
+			return
+		}
+		key := r.URL.Path[len(prefix):] + "?" + q.Get("labelSelector")
+		if cont := q.Get("continue"); cont != "" {
+			key += "&" + cont
+		}
+		body, ok := pages[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestClient(t *testing.T) {
+	server := newServer(t, "ci", map[string]string{
+		"pipelineruns?tekton.dev/pipeline=build": `{"metadata":{"continue":"next"},"items":[
+			{"metadata":{"name":"build-2"},"spec":{"params":[{"name":"revision","value":"abc"},{"name":"targets","value":["a","b"]}]}}
+		]}`,
+		"pipelineruns?tekton.dev/pipeline=build&next": `{"items":[{"metadata":{"name":"build-1"}}]}`,
+		"taskruns?tekton.dev/pipelineRun=build-2": `{"items":[
+			{"metadata":{"name":"build-2-unit","labels":{"tekton.dev/pipelineTask":"unit"}},
+			 "status":{"conditions":[{"type":"Succeeded","status":"True"}]}}
+		]}`,
+	})
+	defer server.Close()
+	ctx := context.Background()
+	client := Client{Host: server.URL, Token: "secret", HTTP: server.Client()}
+
+	runs, err := client.PipelineRuns(ctx, "ci", "build")
+	if err != nil {
+		t.Fatalf("PipelineRuns() got unexpected error: %v", err)
+	}
+	var names []string
+	for _, pr := range runs {
+		names = append(names, pr.Name)
+	}
+	if diff := cmp.Diff([]string{"build-2", "build-1"}, names); diff != "" {
+		t.Errorf("PipelineRuns() got unexpected names (-want +got):\n%s", diff)
+	}
+	if got, ok := runs[0].Param("revision"); !ok || got != "abc" {
+		t.Errorf("Param(revision) got %q, %t, want abc", got, ok)
+	}
+	if got, ok := runs[0].Param("targets"); !ok || got != "" {
+		t.Errorf("Param(targets) of an array got %q, %t, want an empty string", got, ok)
+	}
+
+	taskRuns, err := client.TaskRuns(ctx, "ci", "build-2")
+	if err != nil {
+		t.Fatalf("TaskRuns() got unexpected error: %v", err)
+	}
+	if len(taskRuns) != 1 || taskName(taskRuns[0]) != "unit" || taskRuns[0].Status.Succeeded().Status != "True" {
+		t.Errorf("TaskRuns() got unexpected runs: %+v", taskRuns)
+	}
+
+	if _, err := client.PipelineRuns(ctx, "other", "build"); err == nil {
+		t.Error("PipelineRuns() of a missing namespace failed to return an error")
+	}
+	client.Token = "wrong"
+	if _, err := client.PipelineRuns(ctx, "ci", "build"); err == nil {
+		t.Error("PipelineRuns() without authorization failed to return an error")
+	}
+}
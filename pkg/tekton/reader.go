@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// ReaderName is the column_reader of groups which read Tekton PipelineRuns.
+const ReaderName = "tekton"
+
+const (
+	// maxRuns limits the PipelineRuns read each cycle, matching the GCS reader.
+	maxRuns       = 50
+	missingHeader = "missing"
+)
+
+// ColumnReader returns a reader of the PipelineRuns of a group's tekton_config
+// pipeline, converting each run into a column.
+//
+// Runs already in the old columns, along with pending runs which have yet to
+// start, are skipped.
+func ColumnReader(client Client, storage gcs.Downloader) updater.ColumnReader {
+	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []updater.InflatedColumn, stop time.Time) ([]updater.InflatedColumn, error) {
+		cfg := tg.GetResultSource().GetTektonConfig()
+		if cfg == nil {
+			return nil, errors.New("missing tekton_config")
+		}
+		seen := make(map[string]bool, len(oldCols))
+		for _, col := range oldCols {
+			seen[col.Column.Hint] = true
+			if started := updater.ColumnStarted(col.Column); started.After(stop) {
+				stop = started
+			}
+		}
+		var prefix *gcs.Path
+		if cfg.ArtifactsPrefix != "" {
+			p, err := gcs.NewPath(strings.TrimSuffix(cfg.ArtifactsPrefix, "/") + "/")
+			if err != nil {
+				return nil, fmt.Errorf("artifacts prefix: %w", err)
+			}
+			prefix = p
+		}
+
+		all, err := client.PipelineRuns(ctx, cfg.Namespace, cfg.Pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("list pipeline runs: %w", err)
+		}
+		var runs []PipelineRun
+		for _, pr := range all {
+			if seen[pr.Name] || pr.Status.StartTime == nil || pr.Status.StartTime.Time.Before(stop) {
+				continue
+			}
+			runs = append(runs, pr)
+		}
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].Status.StartTime.After(runs[j].Status.StartTime.Time)
+		})
+		if len(runs) > maxRuns {
+			runs = runs[:maxRuns]
+		}
+		log.WithFields(logrus.Fields{"total": len(all), "new": len(runs)}).Debug("Listed pipeline runs")
+
+		var cols []updater.InflatedColumn
+		for _, pr := range runs {
+			taskRuns, err := client.TaskRuns(ctx, cfg.Namespace, pr.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: list task runs: %w", pr.Name, err)
+			}
+			suites := map[string][]junit.Suite{}
+			if prefix != nil {
+				for _, tr := range taskRuns {
+					task := taskName(tr)
+					if suites[task], err = readJUnit(ctx, storage, *prefix, pr.Name, task); err != nil {
+						return nil, fmt.Errorf("%s: %s: %w", pr.Name, task, err)
+					}
+				}
+			}
+			cols = append(cols, convertRun(tg, pr, taskRuns, suites))
+		}
+		return cols, nil
+	}
+}
+
+// taskName returns the name of the TaskRun's task in its Pipeline.
+func taskName(tr TaskRun) string {
+	if name := tr.Labels[PipelineTaskLabel]; name != "" {
+		return name
+	}
+	return tr.Name
+}
+
+// readJUnit reads the junit*.xml files of the task of the PipelineRun under the prefix.
+func readJUnit(ctx context.Context, storage gcs.Downloader, prefix gcs.Path, run, task string) ([]junit.Suite, error) {
+	dir, err := gcs.NewPath(prefix.String() + run + "/" + task + "/")
+	if err != nil {
+		return nil, fmt.Errorf("artifacts path: %w", err)
+	}
+	it := storage.Objects(ctx, *dir, "", "")
+	var out []junit.Suite
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", dir, err)
+		}
+		if base := path.Base(attrs.Name); !strings.HasPrefix(base, "junit") || !strings.HasSuffix(base, ".xml") {
+			continue
+		}
+		p, err := gcs.NewPath("gs://" + attrs.Bucket + "/" + attrs.Name)
+		if err != nil {
+			return nil, fmt.Errorf("bad path %s: %w", attrs.Name, err)
+		}
+		r, err := storage.Open(ctx, *p)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", p, err)
+		}
+		buf, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", p, err)
+		}
+		suites, err := junit.Parse(buf)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", p, err)
+		}
+		out = append(out, suites.Suites...)
+	}
+}
+
+// convertRun returns the column of the PipelineRun, with a row for each task,
+// a row for each junit test of each task and the overall row.
+//
+// Column headers hold the string parameter, or else the label, of that name.
+func convertRun(tg *configpb.TestGroup, pr PipelineRun, taskRuns []TaskRun, suites map[string][]junit.Suite) updater.InflatedColumn {
+	overall := statusCell(pr.Status)
+	col := updater.InflatedColumn{
+		Column: &statepb.Column{
+			Build:   pr.Name,
+			Hint:    pr.Name,
+			Started: float64(pr.Status.StartTime.UnixNano()) / float64(time.Millisecond),
+		},
+		Cells: map[string]updater.Cell{},
+	}
+	for _, h := range tg.GetColumnHeader() {
+		val, ok := pr.Param(h.ConfigurationValue)
+		if !ok {
+			val = pr.Labels[h.ConfigurationValue]
+		}
+		if h.ConfigurationValue == "" {
+			val = ""
+		}
+		if val == "" && overall.Result != statuspb.TestStatus_RUNNING {
+			val = missingHeader
+		}
+		col.Column.Extra = append(col.Column.Extra, val)
+	}
+
+	cells := map[string][]updater.Cell{}
+	for _, tr := range taskRuns {
+		task := taskName(tr)
+		c := statusCell(tr.Status)
+		c.ID = task
+		cells[task] = append(cells[task], c)
+		for _, suite := range suites[task] {
+			for _, r := range updater.FlattenResults(suite) {
+				if r.Skipped != nil && *r.Skipped == "" {
+					continue
+				}
+				name := task + "/" + r.Name
+				c := updater.ResultCell(r)
+				c.ID = name
+				cells[name] = append(cells[name], c)
+			}
+		}
+	}
+	for name, cs := range cells {
+		col.Cells[name] = updater.MergeCells(true, cs...)
+	}
+	if name := updater.OverallRowName(tg); name != "" {
+		col.Cells[name] = overall
+	}
+	return col
+}
+
+// statusCell converts the Succeeded condition of a run into a cell.
+func statusCell(status RunStatus) updater.Cell {
+	var c updater.Cell
+	cond := status.Succeeded()
+	switch {
+	case cond == nil || cond.Status == "Unknown":
+		c.Result = statuspb.TestStatus_RUNNING
+		c.Icon = "R"
+		c.Message = "Still running..."
+		return c
+	case cond.Status == "True":
+		c.Result = statuspb.TestStatus_PASS
+	case strings.Contains(cond.Reason, "Cancelled"):
+		c.Result = statuspb.TestStatus_CANCEL
+		c.Icon = "C"
+		c.Message = cond.Message
+	case strings.Contains(cond.Reason, "Timeout"):
+		c.Result = statuspb.TestStatus_TIMED_OUT
+		c.Icon = "T"
+		c.Message = cond.Message
+	default:
+		c.Result = statuspb.TestStatus_FAIL
+		c.Message = cond.Message
+	}
+	if status.StartTime != nil && status.CompletionTime != nil {
+		c.Metrics = map[string]float64{updater.ElapsedKey: status.CompletionTime.Sub(status.StartTime.Time).Minutes()}
+	}
+	return c
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/updater"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestStatusCell(t *testing.T) {
+	start := metav1.NewTime(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(3 * time.Minute))
+	cases := []struct {
+		name   string
+		status RunStatus
+		want   updater.Cell
+	}{
+		{
+			name:   "pending",
+			status: RunStatus{},
+			want:   updater.Cell{Result: statuspb.TestStatus_RUNNING, Icon: "R", Message: "Still running..."},
+		},
+		{
+			name: "running",
+			status: RunStatus{
+				StartTime:  &start,
+				Conditions: []Condition{{Type: "Succeeded", Status: "Unknown", Reason: "Running"}},
+			},
+			want: updater.Cell{Result: statuspb.TestStatus_RUNNING, Icon: "R", Message: "Still running..."},
+		},
+		{
+			name: "passed",
+			status: RunStatus{
+				StartTime:      &start,
+				CompletionTime: &end,
+				Conditions:     []Condition{{Type: "Succeeded", Status: "True"}},
+			},
+			want: updater.Cell{
+				Result:  statuspb.TestStatus_PASS,
+				Metrics: map[string]float64{updater.ElapsedKey: 3},
+			},
+		},
+		{
+			name:   "failed",
+			status: RunStatus{Conditions: []Condition{{Type: "Succeeded", Status: "False", Reason: "Failed", Message: "step failed"}}},
+			want:   updater.Cell{Result: statuspb.TestStatus_FAIL, Message: "step failed"},
+		},
+		{
+			name:   "cancelled",
+			status: RunStatus{Conditions: []Condition{{Type: "Succeeded", Status: "False", Reason: "PipelineRunCancelled"}}},
+			want:   updater.Cell{Result: statuspb.TestStatus_CANCEL, Icon: "C"},
+		},
+		{
+			name:   "timed out",
+			status: RunStatus{Conditions: []Condition{{Type: "Succeeded", Status: "False", Reason: "TaskRunTimeout"}}},
+			want:   updater.Cell{Result: statuspb.TestStatus_TIMED_OUT, Icon: "T"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, statusCell(tc.status), protocmp.Transform()); diff != "" {
+				t.Errorf("statusCell() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("gcs.NewPath(%q): %v", s, err)
+	}
+	return *p
+}
+
+func TestColumnReader(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) float64 {
+		return float64(t.UnixNano()) / float64(time.Millisecond)
+	}
+	tg := &configpb.TestGroup{
+		Name:         "tekton",
+		ColumnReader: ReaderName,
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{
+			{ConfigurationValue: "revision"},
+			{ConfigurationValue: "team"},
+		},
+		ResultSource: &configpb.TestGroup_ResultSource{
+			ResultSourceConfig: &configpb.TestGroup_ResultSource_TektonConfig{
+				TektonConfig: &configpb.TektonConfig{
+					Namespace:       "ci",
+					Pipeline:        "build",
+					ArtifactsPrefix: "gs://bucket/tekton",
+				},
+			},
+		},
+	}
+	server := newServer(t, "ci", map[string]string{
+		"pipelineruns?tekton.dev/pipeline=build": `{"items":[
+			{"metadata":{"name":"build-1"},"status":{"startTime":"2021-06-01T09:00:00Z"}},
+			{"metadata":{"name":"build-3","labels":{"team":"infra"}},"status":{"startTime":"2021-06-01T11:00:00Z"}},
+			{"metadata":{"name":"build-pending"}},
+			{"metadata":{"name":"build-2"},"spec":{"params":[{"name":"revision","value":"abc"}]},
+			 "status":{"startTime":"2021-06-01T10:00:00Z","completionTime":"2021-06-01T10:30:00Z",
+			  "conditions":[{"type":"Succeeded","status":"False","reason":"Failed"}]}},
+			{"metadata":{"name":"build-0"},"status":{"startTime":"2021-05-01T09:00:00Z"}}
+		]}`,
+		"taskruns?tekton.dev/pipelineRun=build-3": `{"items":[]}`,
+		"taskruns?tekton.dev/pipelineRun=build-2": `{"items":[
+			{"metadata":{"name":"build-2-unit-x1y2","labels":{"tekton.dev/pipelineTask":"unit"}},
+			 "status":{"conditions":[{"type":"Succeeded","status":"False","reason":"Failed"}]}}
+		]}`,
+	})
+	defer server.Close()
+	storage := fake.Client{
+		Lister: fake.Lister{
+			mustPath(t, "gs://bucket/tekton/build-2/unit/"): fake.Iterator{
+				Objects: []storage.ObjectAttrs{
+					{Bucket: "bucket", Name: "tekton/build-2/unit/junit_01.xml"},
+					{Bucket: "bucket", Name: "tekton/build-2/unit/build-log.txt"},
+				},
+			},
+		},
+		Opener: fake.Opener{
+			mustPath(t, "gs://bucket/tekton/build-2/unit/junit_01.xml"): {Data: `<testsuite>
+				<testcase name="TestFoo" time="60"/>
+				<testcase name="TestBar"><failure>boom</failure></testcase>
+				<testcase name="TestSkip"><skipped/></testcase>
+			</testsuite>`},
+		},
+	}
+	oldCols := []updater.InflatedColumn{
+		{Column: &statepb.Column{Build: "build-1", Hint: "build-1", Started: millis(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))}},
+	}
+	client := Client{Host: server.URL, Token: "secret", HTTP: server.Client()}
+
+	got, err := ColumnReader(client, storage)(context.Background(), logrus.New(), tg, oldCols, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("ColumnReader() got unexpected error: %v", err)
+	}
+	want := []updater.InflatedColumn{
+		{
+			Column: &statepb.Column{
+				Build:   "build-3",
+				Hint:    "build-3",
+				Started: millis(time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC)),
+				Extra:   []string{"", "infra"},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": {Result: statuspb.TestStatus_RUNNING, Icon: "R", Message: "Still running..."},
+			},
+		},
+		{
+			Column: &statepb.Column{
+				Build:   "build-2",
+				Hint:    "build-2",
+				Started: millis(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)),
+				Extra:   []string{"abc", "missing"},
+			},
+			Cells: map[string]updater.Cell{
+				"Overall": {
+					Result:  statuspb.TestStatus_FAIL,
+					Metrics: map[string]float64{updater.ElapsedKey: 30},
+				},
+				"unit": {Result: statuspb.TestStatus_FAIL, ID: "unit"},
+				"unit/TestFoo": {
+					Result:  statuspb.TestStatus_PASS,
+					ID:      "unit/TestFoo",
+					Metrics: map[string]float64{updater.ElapsedKey: 1},
+				},
+				"unit/TestBar": {
+					Result:  statuspb.TestStatus_FAIL,
+					ID:      "unit/TestBar",
+					Icon:    "F",
+					Message: "boom",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ColumnReader() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+// indexHTML is the page which loads the UI.
+const indexHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>TestGrid</title>
+<link rel="stylesheet" href="app.css">
+</head>
+<body>
+<header>
+<a href="#/">TestGrid</a>
+<span id="crumbs"></span>
+</header>
+<main id="main">Loading...</main>
+<script src="app.js"></script>
+</body>
+</html>
+`
+
+// appCSS styles the UI, coloring cells by their test status.
+const appCSS = `body { font-family: sans-serif; margin: 0; }
+header { background: #37474f; color: #fff; padding: 8px 16px; }
+header a { color: #fff; font-weight: bold; text-decoration: none; }
+main { padding: 16px; }
+.error { color: #c62828; }
+.filters { margin-bottom: 8px; }
+.filters input[type=text] { width: 16em; }
+ul.tabs li { margin: 4px 0; }
+.status { display: inline-block; min-width: 6em; padding: 0 4px; margin-left: 8px; font-size: small; }
+table.grid { border-collapse: collapse; font-size: small; }
+table.grid th { font-weight: normal; padding: 2px 4px; white-space: nowrap; }
+table.grid th.col { writing-mode: vertical-rl; transform: rotate(180deg); text-align: left; }
+table.grid th.row { text-align: left; max-width: 40em; overflow: hidden; text-overflow: ellipsis; }
+table.grid th.row .new { font-size: smaller; color: #1565c0; }
+table.grid th.row .removed { font-size: smaller; color: #757575; }
+table.grid td { min-width: 12px; height: 16px; border: 1px solid #fff; }
+.PASS, .PASS_WITH_SKIPS, .BUILD_PASSED { background: #4caf50; }
+.PASS_WITH_ERRORS { background: #8bc34a; }
+.FAIL, .BUILD_FAIL, .CATEGORIZED_FAIL, .TIMED_OUT, .FAILING { background: #e53935; }
+.FLAKY { background: #7e57c2; }
+.RUNNING, .PENDING { background: #90caf9; }
+.TOOL_FAIL, .CATEGORIZED_ABORT, .UNKNOWN, .BROKEN { background: #ff9800; }
+.CANCEL, .BLOCKED, .STALE, .PAUSED { background: #bdbdbd; }
+`
+
+// appJS renders the dashboards, tabs and grids served by the API.
+//
+// Grid rows run-length encode their results as value, count pairs and only
+// list messages for cells with a result.
+const appJS = `'use strict';
+
+var API = '/api/v1/dashboards/';
+var STATUSES = ['NO_RESULT', 'PASS', 'PASS_WITH_ERRORS', 'PASS_WITH_SKIPS', 'RUNNING',
+  'CATEGORIZED_ABORT', 'UNKNOWN', 'CANCEL', 'BLOCKED', 'TIMED_OUT', 'CATEGORIZED_FAIL',
+  'BUILD_FAIL', 'FAIL', 'FLAKY', 'TOOL_FAIL', 'BUILD_PASSED'];
+var FAILURES = {BUILD_FAIL: true, CATEGORIZED_FAIL: true, FAIL: true, FLAKY: true, TIMED_OUT: true};
+
+function el(tag, attrs, children) {
+  var e = document.createElement(tag);
+  Object.keys(attrs || {}).forEach(function(k) { e.setAttribute(k, attrs[k]); });
+  (children || []).forEach(function(c) {
+    e.appendChild(typeof c === 'string' ? document.createTextNode(c) : c);
+  });
+  return e;
+}
+
+function getJSON(path) {
+  return fetch(path, {headers: {Accept: 'application/json'}}).then(function(resp) {
+    if (!resp.ok) {
+      throw new Error(path + ': ' + resp.status + ' ' + resp.statusText);
+    }
+    return resp.json();
+  });
+}
+
+function tabPath(dashboard, tab, resource) {
+  return API + encodeURIComponent(dashboard) + '/tabs/' + encodeURIComponent(tab) + '/' + resource;
+}
+
+function link(parts, text) {
+  return el('a', {href: '#/' + parts.map(encodeURIComponent).join('/')}, [text]);
+}
+
+function show(node) {
+  var main = document.getElementById('main');
+  main.textContent = '';
+  main.appendChild(node);
+}
+
+function crumbs(parts) {
+  var c = document.getElementById('crumbs');
+  c.textContent = '';
+  parts.forEach(function(p, i) {
+    c.appendChild(document.createTextNode(' / '));
+    c.appendChild(link(parts.slice(0, i + 1), p));
+  });
+}
+
+function showDashboards() {
+  crumbs([]);
+  return getJSON(API).then(function(list) {
+    var items = (list.dashboards || []).map(function(d) {
+      return el('li', {}, [link([d.name], d.name)]);
+    });
+    show(el('ul', {}, items));
+  });
+}
+
+function showDashboard(dashboard) {
+  crumbs([dashboard]);
+  return getJSON(API).then(function(list) {
+    var d = (list.dashboards || []).filter(function(d) { return d.name === dashboard; })[0];
+    if (!d) {
+      throw new Error('no dashboard named ' + dashboard);
+    }
+    var items = (d.tabs || []).map(function(tab) {
+      var status = el('span', {'class': 'status'}, ['']);
+      getJSON(tabPath(dashboard, tab, 'summary')).then(function(sum) {
+        var s = sum.overallStatus || 'UNKNOWN';
+        status.textContent = s;
+        status.className = 'status ' + s;
+      }).catch(function() {});
+      return el('li', {}, [link([dashboard, tab], tab), status]);
+    });
+    show(el('ul', {'class': 'tabs'}, items));
+  });
+}
+
+// decodeRow returns the status and message of each column of the row.
+function decodeRow(row, columns) {
+  var cells = [];
+  var results = row.results || [];
+  var messages = row.messages || [];
+  var m = 0;
+  for (var i = 0; i + 1 < results.length; i += 2) {
+    for (var n = 0; n < results[i + 1] && cells.length < columns; n++) {
+      var cell = {status: STATUSES[results[i]] || 'UNKNOWN', message: ''};
+      if (results[i] !== 0) {
+        cell.message = messages[m++] || '';
+      }
+      cells.push(cell);
+    }
+  }
+  while (cells.length < columns) {
+    cells.push({status: 'NO_RESULT', message: ''});
+  }
+  return cells;
+}
+
+function renderGrid(grid, filters) {
+  var columns = grid.columns || [];
+  var include = filters.include ? new RegExp(filters.include) : null;
+  var exclude = filters.exclude ? new RegExp(filters.exclude) : null;
+  var head = el('tr', {}, [el('th', {}, [])].concat(columns.map(function(c) {
+    var started = c.started ? new Date(c.started).toLocaleString() : '';
+    return el('th', {'class': 'col', title: started}, [c.build || c.name || '']);
+  })));
+  var rows = [head];
+  (grid.rows || []).forEach(function(row) {
+    if (include && !include.test(row.name)) {
+      return;
+    }
+    if (exclude && exclude.test(row.name)) {
+      return;
+    }
+    var cells = decodeRow(row, columns.length);
+    if (filters.failing && !cells.some(function(c) { return FAILURES[c.status]; })) {
+      return;
+    }
+    var name = [row.name];
+    if (row.isNew) {
+      name.push(' ', el('span', {'class': 'new'}, ['NEW']));
+    }
+    if (row.tombstone) {
+      name.push(' ', el('span', {'class': 'removed', title: 'last seen in ' + (row.tombstone.buildId || '')}, ['REMOVED']));
+    }
+    rows.push(el('tr', {}, [el('th', {'class': 'row', title: row.name}, name)].concat(cells.map(function(c) {
+      var title = c.status === 'NO_RESULT' ? '' : c.status + (c.message ? ': ' + c.message : '');
+      return el('td', {'class': c.status, title: title}, []);
+    }))));
+  });
+  return el('table', {'class': 'grid'}, rows);
+}
+
+function showTab(dashboard, tab, query) {
+  crumbs([dashboard, tab]);
+  return getJSON(tabPath(dashboard, tab, 'grid')).then(function(grid) {
+    var include = el('input', {type: 'text', placeholder: 'include regex', value: query.get('include') || ''});
+    var exclude = el('input', {type: 'text', placeholder: 'exclude regex', value: query.get('exclude') || ''});
+    var failing = el('input', {type: 'checkbox'});
+    failing.checked = query.get('failing') === 'true';
+    var holder = el('div', {}, []);
+    var render = function() {
+      holder.textContent = '';
+      try {
+        holder.appendChild(renderGrid(grid, {include: include.value, exclude: exclude.value, failing: failing.checked}));
+      } catch (err) {
+        holder.appendChild(el('p', {'class': 'error'}, [err.message]));
+      }
+    };
+    [include, exclude, failing].forEach(function(input) { input.addEventListener('input', render); });
+    failing.addEventListener('change', render);
+    var filters = el('div', {'class': 'filters'}, [include, ' ', exclude, ' ', el('label', {}, [failing, ' failing rows only'])]);
+    show(el('div', {}, [filters, holder]));
+    render();
+  });
+}
+
+function route() {
+  var hash = location.hash.replace(/^#\/?/, '');
+  var q = hash.indexOf('?');
+  var query = new URLSearchParams(q < 0 ? '' : hash.slice(q + 1));
+  var parts = (q < 0 ? hash : hash.slice(0, q)).split('/').filter(Boolean).map(decodeURIComponent);
+  var page;
+  if (parts.length === 0) {
+    page = showDashboards();
+  } else if (parts.length === 1) {
+    page = showDashboard(parts[0]);
+  } else {
+    page = showTab(parts[0], parts[1], query);
+  }
+  page.catch(function(err) {
+    show(el('p', {'class': 'error'}, [err.message]));
+  });
+}
+
+window.addEventListener('hashchange', route);
+route();
+`
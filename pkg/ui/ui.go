@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ui serves a minimal web UI which renders the dashboards, tabs and
+// grids of the API from assets compiled into the binary.
+package ui
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/pkg/api"
+)
+
+// Prefix is the path under which the UI is served.
+const Prefix = "/ui/"
+
+// asset is a file of the UI.
+type asset struct {
+	contentType string
+	body        string
+}
+
+// assets holds each file of the UI by its path under Prefix.
+var assets = map[string]asset{
+	"":           {"text/html; charset=utf-8", indexHTML},
+	"index.html": {"text/html; charset=utf-8", indexHTML},
+	"app.js":     {"application/javascript; charset=utf-8", appJS},
+	"app.css":    {"text/css; charset=utf-8", appCSS},
+}
+
+// Wrap serves the UI under Prefix, redirects the root to it and passes every
+// other request to the API handler.
+func Wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/" || r.URL.Path+"/" == Prefix:
+			http.Redirect(w, r, Prefix, http.StatusFound)
+		case strings.HasPrefix(r.URL.Path, Prefix):
+			serveAsset(w, r)
+		default:
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
+
+// serveAsset responds with the requested asset.
+func serveAsset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, Prefix)
+	a, ok := assets[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", a.contentType)
+	w.Header().Set("ETag", api.ETag(a.contentType, []byte(a.body)))
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader([]byte(a.body)))
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("api"))
+	})
+	handler := Wrap(api)
+	cases := []struct {
+		name        string
+		method      string
+		path        string
+		etag        bool
+		code        int
+		contentType string
+		body        string
+		location    string
+	}{
+		{
+			name:     "redirect root",
+			path:     "/",
+			code:     http.StatusFound,
+			location: Prefix,
+		},
+		{
+			name:        "index",
+			path:        Prefix,
+			code:        http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			body:        "<title>TestGrid</title>",
+		},
+		{
+			name:     "redirect without slash",
+			path:     "/ui",
+			code:     http.StatusFound,
+			location: Prefix,
+		},
+		{
+			name:        "script",
+			path:        Prefix + "app.js",
+			code:        http.StatusOK,
+			contentType: "application/javascript; charset=utf-8",
+			body:        "function decodeRow",
+		},
+		{
+			name:        "style",
+			path:        Prefix + "app.css",
+			code:        http.StatusOK,
+			contentType: "text/css; charset=utf-8",
+			body:        "table.grid",
+		},
+		{
+			name: "unchanged",
+			path: Prefix + "app.js",
+			etag: true,
+			code: http.StatusNotModified,
+		},
+		{
+			name: "missing asset",
+			path: Prefix + "missing.js",
+			code: http.StatusNotFound,
+		},
+		{
+			name:   "reject writes",
+			method: http.MethodPost,
+			path:   Prefix,
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name: "api",
+			path: "/api/v1/dashboards/",
+			code: http.StatusOK,
+			body: "api",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.method == "" {
+				tc.method = http.MethodGet
+			}
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.etag {
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+				r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tc.code {
+				t.Fatalf("ServeHTTP() got code %d, want %d", w.Code, tc.code)
+			}
+			if tc.contentType != "" {
+				if got := w.Header().Get("Content-Type"); got != tc.contentType {
+					t.Errorf("ServeHTTP() got content type %q, want %q", got, tc.contentType)
+				}
+			}
+			if !strings.Contains(w.Body.String(), tc.body) {
+				t.Errorf("ServeHTTP() got body %q, want it to contain %q", w.Body, tc.body)
+			}
+			if got := w.Header().Get("Location"); got != tc.location {
+				t.Errorf("ServeHTTP() got location %q, want %q", got, tc.location)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// latestColumn returns the most recently started column of the grid, if any.
+func latestColumn(grid *statepb.Grid) *statepb.Column {
+	var latest *statepb.Column
+	for _, col := range grid.GetColumns() {
+		if latest == nil || col.Started > latest.Started {
+			latest = col
+		}
+	}
+	return latest
+}
+
+// shouldArchive returns true when the group is configured for archival and
+// the grid has no results newer than archive_after_days.
+func shouldArchive(tg *configpb.TestGroup, grid *statepb.Grid, now time.Time) bool {
+	if tg.ArchiveAfterDays <= 0 || tg.ArchivePrefix == "" || grid == nil || grid.ArchivePath != "" {
+		return false
+	}
+	latest := latestColumn(grid)
+	if latest == nil {
+		return false // Nothing worth archiving.
+	}
+	started := time.Unix(0, int64(latest.Started)*int64(time.Millisecond))
+	return now.Sub(started) > days(float64(tg.ArchiveAfterDays))
+}
+
+// archivedGrid returns a stub of the grid which points at its archived copy.
+//
+// The stub retains the latest column so the next update only reads newer builds.
+func archivedGrid(grid *statepb.Grid, archivePath gcs.Path) *statepb.Grid {
+	stub := statepb.Grid{
+		ArchivePath:     archivePath.String(),
+		LastTimeUpdated: grid.LastTimeUpdated,
+	}
+	if latest := latestColumn(grid); latest != nil {
+		stub.Columns = []*statepb.Column{
+			{
+				Build:   latest.Build,
+				Name:    latest.Name,
+				Started: latest.Started,
+				Hint:    latest.Hint,
+			},
+		}
+	}
+	return &stub
+}
+
+// archiveGrid copies the grid under the archive prefix and replaces it with a stub.
+func archiveGrid(ctx context.Context, client gcs.Uploader, tg *configpb.TestGroup, gridPath gcs.Path, grid *statepb.Grid) error {
+	archivePath, err := gcs.MirrorPath(tg.ArchivePrefix, tg.Name)
+	if err != nil {
+		return fmt.Errorf("archive path: %w", err)
+	}
+	encoded, err := state.Encode(grid, tg.StateVersion)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+	buf, err := marshalGrid(encoded)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if err := client.Upload(ctx, *archivePath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload archive: %w", err)
+	}
+	if buf, err = marshalGrid(archivedGrid(grid, *archivePath)); err != nil {
+		return fmt.Errorf("marshal stub: %w", err)
+	}
+	if err := client.Upload(ctx, gridPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload stub: %w", err)
+	}
+	return nil
+}
+
+// reviveGrid downloads the archived copy of the stub's grid.
+//
+// Returns storage.ErrObjectNotExist when the archive is missing.
+func reviveGrid(ctx context.Context, client gcs.Opener, stub *statepb.Grid) (*statepb.Grid, error) {
+	archivePath, err := gcs.NewPath(stub.ArchivePath)
+	if err != nil {
+		return nil, fmt.Errorf("archive path: %w", err)
+	}
+	r, err := client.Open(ctx, *archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	return state.Read(r)
+}
+
+// ArchiveShardPath returns the path to the shard holding the group's columns
+// which started on the UTC day.
+func ArchiveShardPath(tg *configpb.TestGroup, day time.Time) (*gcs.Path, error) {
+	return gcs.MirrorPath(tg.ArchivePrefix, tg.Name+".columns/"+day.UTC().Format("2006-01-02"))
+}
+
+// millis returns the time in milliseconds, as columns record when they started.
+func millis(t time.Time) float64 {
+	return float64(t.UnixNano() / int64(time.Millisecond))
+}
+
+// shardUpload holds a shard which the updater must write before the grid lists it.
+type shardUpload struct {
+	path gcs.Path
+	buf  []byte
+}
+
+// archiveColumns moves the columns which started on a UTC day before
+// archive_columns_after_days out of cols and into a shard per day.
+//
+// Returns the remaining columns along with the shards, newest first, which
+// the grid should list and the shards to upload. Shards the old grid already
+// lists gain any columns which arrive late for their day. The grid stops
+// listing shards with no columns after stop.
+//
+// The newest column always remains, so the next update only reads newer builds.
+func archiveColumns(ctx context.Context, log logrus.FieldLogger, client gcs.Opener, tg *configpb.TestGroup, old *statepb.Grid, cols []InflatedColumn, stop, now time.Time) ([]InflatedColumn, []*statepb.ArchiveShard, []shardUpload, error) {
+	listed := map[string]*statepb.ArchiveShard{}
+	for _, shard := range old.GetArchiveShards() {
+		if shard.NewestStarted < millis(stop) {
+			continue
+		}
+		listed[shard.Path] = shard
+	}
+
+	hot := cols
+	var uploads []shardUpload
+	if tg.ArchiveColumnsAfterDays > 0 && tg.ArchivePrefix != "" && len(cols) > 1 {
+		cutoff := now.UTC().AddDate(0, 0, -int(tg.ArchiveColumnsAfterDays)).Truncate(24 * time.Hour)
+		newest := 0
+		for i, col := range cols {
+			if col.Column.Started > cols[newest].Column.Started {
+				newest = i
+			}
+		}
+		hot = make([]InflatedColumn, 0, len(cols))
+		cold := map[string][]InflatedColumn{}
+		for i, col := range cols {
+			if i == newest || col.Column.Started >= millis(cutoff) || col.Column.Started < millis(stop) {
+				hot = append(hot, col)
+				continue
+			}
+			path, err := ArchiveShardPath(tg, time.Unix(0, int64(col.Column.Started)*int64(time.Millisecond)))
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("shard path: %w", err)
+			}
+			cold[path.String()] = append(cold[path.String()], col)
+		}
+
+		paths := make([]string, 0, len(cold))
+		for p := range cold {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			path, err := gcs.NewPath(p)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("shard path: %w", err)
+			}
+			shardCols := cold[p]
+			if _, ok := listed[p]; ok {
+				prev, err := gcs.DownloadGrid(ctx, client, *path)
+				switch {
+				case errors.Is(err, storage.ErrObjectNotExist):
+					log.WithField("shard", p).Warning("Listed archive shard does not exist, replacing it")
+				case err != nil:
+					return nil, nil, nil, fmt.Errorf("download %s: %w", p, err)
+				default:
+					builds := make(map[string]bool, len(shardCols))
+					for _, col := range shardCols {
+						builds[col.Column.Build] = true
+					}
+					for _, col := range InflateGrid(prev) {
+						if !builds[col.Column.Build] {
+							shardCols = append(shardCols, col)
+						}
+					}
+				}
+			}
+			SortStarted(tg, shardCols)
+			grid := constructGrid(log, tg, shardCols)
+			encoded, err := state.Encode(grid, tg.StateVersion)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("encode %s: %w", p, err)
+			}
+			buf, err := marshalGrid(encoded)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("marshal %s: %w", p, err)
+			}
+			uploads = append(uploads, shardUpload{path: *path, buf: buf})
+			listed[p] = &statepb.ArchiveShard{
+				Path:          p,
+				NewestStarted: shardCols[0].Column.Started,
+				OldestStarted: shardCols[len(shardCols)-1].Column.Started,
+				Columns:       int32(len(shardCols)),
+			}
+		}
+	}
+
+	shards := make([]*statepb.ArchiveShard, 0, len(listed))
+	for _, shard := range listed {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].NewestStarted != shards[j].NewestStarted {
+			return shards[i].NewestStarted > shards[j].NewestStarted
+		}
+		return shards[i].Path > shards[j].Path
+	})
+	return hot, shards, uploads, nil
+}
+
+// archivedColumn returns true when one of the shards holds columns started at the time.
+func archivedColumn(shards []*statepb.ArchiveShard, started float64) bool {
+	for _, shard := range shards {
+		if started >= shard.OldestStarted && started <= shard.NewestStarted {
+			return true
+		}
+	}
+	return false
+}
+
+// StitchGrids returns the grid with the columns of its archive shards, newest first.
+//
+// Rows keep the alerts and other attributes of the grid, which shards do not track.
+func StitchGrids(log logrus.FieldLogger, group *configpb.TestGroup, grid *statepb.Grid, shards ...*statepb.Grid) *statepb.Grid {
+	if len(shards) == 0 {
+		return grid
+	}
+	// Inflating modifies the grids, which callers may cache.
+	cols := InflateGrid(proto.Clone(grid).(*statepb.Grid))
+	for _, shard := range shards {
+		cols = append(cols, InflateGrid(proto.Clone(shard).(*statepb.Grid))...)
+	}
+	SortStarted(group, cols)
+
+	out := constructGrid(log, group, cols)
+	out.LastTimeUpdated = grid.LastTimeUpdated
+	out.ArchivePath = grid.ArchivePath
+	out.ArchiveShards = grid.ArchiveShards
+	rows := make(map[string]*statepb.Row, len(grid.Rows))
+	for _, row := range grid.Rows {
+		rows[row.Name] = row
+	}
+	for _, row := range out.Rows {
+		orig, ok := rows[row.Name]
+		if !ok {
+			continue
+		}
+		row.AlertInfo = orig.AlertInfo
+		row.LastGreen = orig.LastGreen
+		row.FirstSeen = orig.FirstSeen
+		row.IsNew = orig.IsNew
+		row.Tombstone = orig.Tombstone
+		row.BugId = orig.BugId
+	}
+	return out
+}
@@ -0,0 +1,480 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestShouldArchive(t *testing.T) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	ms := func(t time.Time) float64 {
+		return float64(t.UnixNano() / int64(time.Millisecond))
+	}
+	archival := &configpb.TestGroup{
+		ArchiveAfterDays: 30,
+		ArchivePrefix:    "gs://cold/archive",
+	}
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		grid  *statepb.Grid
+		want  bool
+	}{
+		{
+			name:  "basically works",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name:  "archival disabled",
+			group: &configpb.TestGroup{},
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{{Started: ms(now.AddDate(-1, 0, 0))}},
+			},
+		},
+		{
+			name:  "recent results",
+			group: archival,
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{
+					{Started: ms(now.AddDate(0, 0, -40))},
+					{Started: ms(now.AddDate(0, 0, -1))},
+				},
+			},
+		},
+		{
+			name:  "idle group",
+			group: archival,
+			grid: &statepb.Grid{
+				Columns: []*statepb.Column{{Started: ms(now.AddDate(0, 0, -31))}},
+			},
+			want: true,
+		},
+		{
+			name:  "empty grid",
+			group: archival,
+			grid:  &statepb.Grid{},
+		},
+		{
+			name:  "already archived",
+			group: archival,
+			grid: &statepb.Grid{
+				ArchivePath: "gs://cold/archive/foo",
+				Columns:     []*statepb.Column{{Started: ms(now.AddDate(0, 0, -31))}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := shouldArchive(tc.group, tc.grid, now); got != tc.want {
+				t.Errorf("shouldArchive() got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArchiveGrid(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	gridPath := mustPath("gs://bucket/grid/foo")
+	archivePath := mustPath("gs://cold/archive/foo")
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "1", Started: 1000, Hint: "1"},
+			{Build: "2", Started: 2000, Hint: "2", Extra: []string{"commit"}},
+		},
+		Rows: []*statepb.Row{
+			{Name: "foo", Results: []int32{1, 2}},
+		},
+		LastTimeUpdated: 3,
+	}
+	tg := &configpb.TestGroup{
+		Name:             "foo",
+		ArchiveAfterDays: 30,
+		ArchivePrefix:    "gs://cold/archive",
+	}
+
+	client := fakeUploader{}
+	if err := archiveGrid(context.Background(), client, tg, gridPath, grid); err != nil {
+		t.Fatalf("archiveGrid() got unexpected error: %v", err)
+	}
+
+	opener := fakeOpener{}
+	for path, upload := range client {
+		opener[path] = fakeObject{Data: string(upload.Buf)}
+	}
+	gotArchive, err := gcs.DownloadGrid(context.Background(), opener, archivePath)
+	if err != nil {
+		t.Fatalf("DownloadGrid(archive) got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(grid, gotArchive, protocmp.Transform()); diff != "" {
+		t.Errorf("archiveGrid() got unexpected archive (-want +got):\n%s", diff)
+	}
+
+	wantStub := &statepb.Grid{
+		ArchivePath: archivePath.String(),
+		Columns: []*statepb.Column{
+			{Build: "2", Started: 2000, Hint: "2"},
+		},
+		LastTimeUpdated: 3,
+	}
+	gotStub, err := gcs.DownloadGrid(context.Background(), opener, gridPath)
+	if err != nil {
+		t.Fatalf("DownloadGrid(stub) got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wantStub, gotStub, protocmp.Transform()); diff != "" {
+		t.Errorf("archiveGrid() got unexpected stub (-want +got):\n%s", diff)
+	}
+
+	revived, err := reviveGrid(context.Background(), opener, gotStub)
+	if err != nil {
+		t.Fatalf("reviveGrid() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(grid, revived, protocmp.Transform()); diff != "" {
+		t.Errorf("reviveGrid() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestReviveGrid(t *testing.T) {
+	archivePath := newPathOrDie("gs://cold/archive/foo")
+	grid := &statepb.Grid{
+		Columns: []*statepb.Column{{Build: "1", Started: 1000}},
+	}
+	buf, err := marshalGrid(grid)
+	if err != nil {
+		t.Fatalf("marshalGrid() got unexpected error: %v", err)
+	}
+	injected := errors.New("injected")
+	cases := []struct {
+		name    string
+		stub    *statepb.Grid
+		opener  fakeOpener
+		want    *statepb.Grid
+		wantErr error
+		err     bool
+	}{
+		{
+			name:   "revive archived grid",
+			stub:   &statepb.Grid{ArchivePath: archivePath.String()},
+			opener: fakeOpener{archivePath: {Data: string(buf)}},
+			want:   grid,
+		},
+		{
+			name:    "missing archive",
+			stub:    &statepb.Grid{ArchivePath: archivePath.String()},
+			opener:  fakeOpener{},
+			wantErr: storage.ErrObjectNotExist,
+		},
+		{
+			name:    "open error",
+			stub:    &statepb.Grid{ArchivePath: archivePath.String()},
+			opener:  fakeOpener{archivePath: {OpenErr: injected}},
+			wantErr: injected,
+		},
+		{
+			name:   "corrupt archive",
+			stub:   &statepb.Grid{ArchivePath: archivePath.String()},
+			opener: fakeOpener{archivePath: {Data: "garbage"}},
+			err:    true,
+		},
+		{
+			name: "bad archive path",
+			stub: &statepb.Grid{ArchivePath: "http://cold/archive/foo"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := reviveGrid(context.Background(), tc.opener, tc.stub)
+			switch {
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("reviveGrid() got error %v, want %v", err, tc.wantErr)
+				}
+			case tc.err:
+				if err == nil {
+					t.Error("reviveGrid() failed to return an error")
+				}
+			case err != nil:
+				t.Errorf("reviveGrid() got unexpected error: %v", err)
+			default:
+				if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+					t.Errorf("reviveGrid() got unexpected diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveColumns(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	now := time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+	stop := now.AddDate(0, 0, -7)
+	at := func(day, hour int) float64 {
+		return millis(time.Date(2021, 3, day, hour, 0, 0, 0, time.UTC))
+	}
+	column := func(build string, started float64) InflatedColumn {
+		return InflatedColumn{
+			Column: &statepb.Column{Build: build, Started: started},
+			Cells:  map[string]Cell{"foo": {Result: statuspb.TestStatus_PASS}},
+		}
+	}
+	group := &configpb.TestGroup{
+		Name:                    "foo",
+		ArchivePrefix:           "gs://cold/archive",
+		ArchiveColumnsAfterDays: 2,
+	}
+	cols := func() []InflatedColumn {
+		return []InflatedColumn{
+			column("5", at(10, 10)),
+			column("4", at(8, 1)),
+			column("3", at(7, 20)),
+			column("2", at(7, 8)),
+			column("1", at(6, 8)),
+			column("0", at(1, 8)),
+		}
+	}
+	sixth := &statepb.ArchiveShard{
+		Path:          "gs://cold/archive/foo.columns/2021-03-06",
+		OldestStarted: at(6, 2),
+		NewestStarted: at(6, 2),
+		Columns:       1,
+	}
+	fifth := &statepb.ArchiveShard{
+		Path:          "gs://cold/archive/foo.columns/2021-03-05",
+		OldestStarted: at(5, 2),
+		NewestStarted: at(5, 2),
+		Columns:       1,
+	}
+	first := &statepb.ArchiveShard{
+		Path:          "gs://cold/archive/foo.columns/2021-03-01",
+		OldestStarted: at(1, 2),
+		NewestStarted: at(1, 2),
+		Columns:       1,
+	}
+	old := &statepb.Grid{ArchiveShards: []*statepb.ArchiveShard{sixth, fifth, first}}
+	existing := fakeOpener{
+		mustPath(sixth.Path): {Data: string(mustGrid(constructGrid(logrus.New(), group, []InflatedColumn{column("x", at(6, 2))})))},
+	}
+
+	cases := []struct {
+		name        string
+		group       *configpb.TestGroup
+		old         *statepb.Grid
+		cols        []InflatedColumn
+		client      fakeOpener
+		wantHot     []string
+		wantShards  []*statepb.ArchiveShard
+		wantUploads map[string][]string
+	}{
+		{
+			name:    "basically works",
+			group:   &configpb.TestGroup{},
+			cols:    cols(),
+			wantHot: []string{"5", "4", "3", "2", "1", "0"},
+		},
+		{
+			name:       "disabled groups keep listing unexpired shards",
+			group:      &configpb.TestGroup{Name: "foo"},
+			old:        old,
+			cols:       cols(),
+			wantHot:    []string{"5", "4", "3", "2", "1", "0"},
+			wantShards: []*statepb.ArchiveShard{sixth, fifth},
+		},
+		{
+			name:    "move old days into shards",
+			group:   group,
+			old:     old,
+			cols:    cols(),
+			client:  existing,
+			wantHot: []string{"5", "4", "0"},
+			wantShards: []*statepb.ArchiveShard{
+				{
+					Path:          "gs://cold/archive/foo.columns/2021-03-07",
+					OldestStarted: at(7, 8),
+					NewestStarted: at(7, 20),
+					Columns:       2,
+				},
+				{
+					Path:          sixth.Path,
+					OldestStarted: at(6, 2),
+					NewestStarted: at(6, 8),
+					Columns:       2,
+				},
+				fifth,
+			},
+			wantUploads: map[string][]string{
+				"gs://cold/archive/foo.columns/2021-03-07": {"3", "2"},
+				sixth.Path: {"1", "x"},
+			},
+		},
+		{
+			name:    "replace missing shards",
+			group:   group,
+			old:     old,
+			cols:    cols(),
+			client:  fakeOpener{},
+			wantHot: []string{"5", "4", "0"},
+			wantShards: []*statepb.ArchiveShard{
+				{
+					Path:          "gs://cold/archive/foo.columns/2021-03-07",
+					OldestStarted: at(7, 8),
+					NewestStarted: at(7, 20),
+					Columns:       2,
+				},
+				{
+					Path:          sixth.Path,
+					OldestStarted: at(6, 8),
+					NewestStarted: at(6, 8),
+					Columns:       1,
+				},
+				fifth,
+			},
+			wantUploads: map[string][]string{
+				"gs://cold/archive/foo.columns/2021-03-07": {"3", "2"},
+				sixth.Path: {"1"},
+			},
+		},
+		{
+			name:  "keep the newest column",
+			group: group,
+			cols: []InflatedColumn{
+				column("3", at(7, 20)),
+				column("2", at(7, 8)),
+			},
+			wantHot: []string{"3"},
+			wantShards: []*statepb.ArchiveShard{
+				{
+					Path:          "gs://cold/archive/foo.columns/2021-03-07",
+					OldestStarted: at(7, 8),
+					NewestStarted: at(7, 8),
+					Columns:       1,
+				},
+			},
+			wantUploads: map[string][]string{
+				"gs://cold/archive/foo.columns/2021-03-07": {"2"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hot, shards, uploads, err := archiveColumns(context.Background(), logrus.New(), tc.client, tc.group, tc.old, tc.cols, stop, now)
+			if err != nil {
+				t.Fatalf("archiveColumns() got unexpected error: %v", err)
+			}
+			var gotHot []string
+			for _, col := range hot {
+				gotHot = append(gotHot, col.Column.Build)
+			}
+			if diff := cmp.Diff(tc.wantHot, gotHot); diff != "" {
+				t.Errorf("archiveColumns() got unexpected columns (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantShards, shards, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("archiveColumns() got unexpected shards (-want +got):\n%s", diff)
+			}
+			gotUploads := map[string][]string{}
+			for _, upload := range uploads {
+				grid, err := gcs.DownloadGrid(context.Background(), fakeOpener{upload.path: {Data: string(upload.buf)}}, upload.path)
+				if err != nil {
+					t.Fatalf("DownloadGrid(%s) got unexpected error: %v", upload.path, err)
+				}
+				for _, col := range grid.Columns {
+					gotUploads[upload.path.String()] = append(gotUploads[upload.path.String()], col.Build)
+				}
+			}
+			if diff := cmp.Diff(tc.wantUploads, gotUploads, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("archiveColumns() got unexpected uploads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStitchGrids(t *testing.T) {
+	group := &configpb.TestGroup{}
+	column := func(build string, started float64, names ...string) InflatedColumn {
+		col := InflatedColumn{
+			Column: &statepb.Column{Build: build, Hint: build, Started: started},
+			Cells:  map[string]Cell{},
+		}
+		for _, name := range names {
+			col.Cells[name] = Cell{Result: statuspb.TestStatus_PASS}
+		}
+		return col
+	}
+	grid := constructGrid(logrus.New(), group, []InflatedColumn{column("3", 3000, "foo")})
+	grid.Rows[0].BugId = []string{"bug"}
+	grid.LastTimeUpdated = 7
+	grid.ArchiveShards = []*statepb.ArchiveShard{{Path: "gs://cold/shard", OldestStarted: 1000, NewestStarted: 2000, Columns: 2}}
+	shard := constructGrid(logrus.New(), group, []InflatedColumn{
+		column("2", 2000, "foo", "bar"),
+		column("1", 1000, "foo"),
+	})
+	orig := proto.Clone(shard)
+
+	want := constructGrid(logrus.New(), group, []InflatedColumn{
+		column("3", 3000, "foo"),
+		column("2", 2000, "foo", "bar"),
+		column("1", 1000, "foo"),
+	})
+	want.LastTimeUpdated = 7
+	want.ArchiveShards = grid.ArchiveShards
+	for _, row := range want.Rows {
+		if row.Name == "foo" {
+			row.BugId = []string{"bug"}
+		}
+	}
+
+	got := StitchGrids(logrus.New(), group, grid, shard)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("StitchGrids() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(orig, shard, protocmp.Transform()); diff != "" {
+		t.Errorf("StitchGrids() modified the shard (-want +got):\n%s", diff)
+	}
+	if got := StitchGrids(logrus.New(), group, grid); got != grid {
+		t.Errorf("StitchGrids() without shards got %v, want the grid", got)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// OverBudgetKey is the metric of how many minutes a passing test, or its
+// suite, ran over its duration budget.
+const OverBudgetKey = "over-budget-minutes"
+
+type durationBudget struct {
+	re      *regexp.Regexp
+	minutes float64
+	suite   bool
+}
+
+type durationBudgets []durationBudget
+
+// newDurationBudgets compiles the configured duration budgets.
+//
+// Invalid budgets, which config validation rejects, are ignored.
+func newDurationBudgets(budgets []*configpb.TestGroup_DurationBudget) durationBudgets {
+	var out durationBudgets
+	for _, b := range budgets {
+		re, err := regexp.Compile(b.NameRegex)
+		if err != nil || b.MaxMinutes <= 0 {
+			continue
+		}
+		out = append(out, durationBudget{re: re, minutes: b.MaxMinutes, suite: b.Suite})
+	}
+	return out
+}
+
+// limit returns the smallest budget matching the test or suite name, or zero.
+func (b durationBudgets) limit(name string, suite bool) float64 {
+	var min float64
+	for _, budget := range b {
+		if budget.suite != suite || !budget.re.MatchString(name) {
+			continue
+		}
+		if min == 0 || budget.minutes < min {
+			min = budget.minutes
+		}
+	}
+	return min
+}
+
+// suiteMinutes returns the duration of the suite, or the sum of its results
+// when the suite does not report one.
+func suiteMinutes(suite junit.Suite) float64 {
+	seconds := suite.Time
+	if seconds <= 0 {
+		for _, r := range FlattenResults(suite) {
+			seconds += r.Time
+		}
+	}
+	return seconds / 60
+}
+
+// overBudget marks a passing cell which took longer than its budget.
+//
+// The cell passes with errors, noting the overage in its message (unless it
+// already has one) and in the OverBudgetKey metric.
+func overBudget(c *Cell, what string, minutes, budget float64) {
+	if budget <= 0 || minutes <= budget {
+		return
+	}
+	switch c.Result {
+	case statuspb.TestStatus_PASS, statuspb.TestStatus_PASS_WITH_SKIPS:
+	default:
+		return
+	}
+	c.Result = statuspb.TestStatus_PASS_WITH_ERRORS
+	if c.Icon == "" {
+		c.Icon = "B"
+	}
+	if c.Message == "" {
+		c.Message = fmt.Sprintf("%s took %.1f minutes, over its %g minute budget", what, minutes, budget)
+	}
+	if c.Metrics == nil {
+		c.Metrics = map[string]float64{}
+	}
+	c.Metrics[OverBudgetKey] = minutes - budget
+}
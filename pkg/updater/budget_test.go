@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestDurationBudgetLimit(t *testing.T) {
+	budgets := newDurationBudgets([]*configpb.TestGroup_DurationBudget{
+		{MaxMinutes: 10},
+		{NameRegex: "slow", MaxMinutes: 30},
+		{NameRegex: "quick", MaxMinutes: 1},
+		{NameRegex: "^e2e", MaxMinutes: 60, Suite: true},
+		{NameRegex: "bad(", MaxMinutes: 1},
+		{NameRegex: "unlimited"},
+	})
+	cases := []struct {
+		name  string
+		test  string
+		suite bool
+		want  float64
+	}{
+		{
+			name: "default budget",
+			test: "foo",
+			want: 10,
+		},
+		{
+			name: "smallest matching budget wins",
+			test: "slow but quick",
+			want: 1,
+		},
+		{
+			name: "larger budgets do not loosen smaller ones",
+			test: "slow",
+			want: 10,
+		},
+		{
+			name:  "suite budget",
+			test:  "e2e suite",
+			suite: true,
+			want:  60,
+		},
+		{
+			name:  "suite without a budget",
+			test:  "unit",
+			suite: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := budgets.limit(tc.test, tc.suite); got != tc.want {
+				t.Errorf("limit(%q, %t) got %v, want %v", tc.test, tc.suite, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSuiteMinutes(t *testing.T) {
+	suite := junit.Suite{
+		Results: []junit.Result{{Time: 60}, {Time: 30}},
+		Suites:  []junit.Suite{{Results: []junit.Result{{Time: 90}}}},
+	}
+	if got := suiteMinutes(suite); got != 3 {
+		t.Errorf("suiteMinutes() summing results got %v, want 3", got)
+	}
+	suite.Time = 600
+	if got := suiteMinutes(suite); got != 10 {
+		t.Errorf("suiteMinutes() got %v, want 10", got)
+	}
+}
+
+func TestOverBudget(t *testing.T) {
+	cases := []struct {
+		name    string
+		cell    Cell
+		minutes float64
+		budget  float64
+		want    Cell
+	}{
+		{
+			name:    "within budget",
+			cell:    Cell{Result: statuspb.TestStatus_PASS},
+			minutes: 3,
+			budget:  5,
+			want:    Cell{Result: statuspb.TestStatus_PASS},
+		},
+		{
+			name:    "no budget",
+			cell:    Cell{Result: statuspb.TestStatus_PASS},
+			minutes: 3,
+			want:    Cell{Result: statuspb.TestStatus_PASS},
+		},
+		{
+			name:    "over budget",
+			cell:    Cell{Result: statuspb.TestStatus_PASS},
+			minutes: 7.5,
+			budget:  5,
+			want: Cell{
+				Result:  statuspb.TestStatus_PASS_WITH_ERRORS,
+				Icon:    "B",
+				Message: "Test took 7.5 minutes, over its 5 minute budget",
+				Metrics: map[string]float64{OverBudgetKey: 2.5},
+			},
+		},
+		{
+			name:    "keep existing icons and messages",
+			cell:    Cell{Result: statuspb.TestStatus_PASS_WITH_SKIPS, Icon: "S", Message: "skipped some"},
+			minutes: 6,
+			budget:  5,
+			want: Cell{
+				Result:  statuspb.TestStatus_PASS_WITH_ERRORS,
+				Icon:    "S",
+				Message: "skipped some",
+				Metrics: map[string]float64{OverBudgetKey: 1},
+			},
+		},
+		{
+			name:    "failures are not over budget",
+			cell:    Cell{Result: statuspb.TestStatus_FAIL},
+			minutes: 7,
+			budget:  5,
+			want:    Cell{Result: statuspb.TestStatus_FAIL},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.cell
+			overBudget(&got, "Test", tc.minutes, tc.budget)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("overBudget() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
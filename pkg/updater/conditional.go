@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// readGeneration reads the message at the path along with its generation.
+//
+// Leaves the message empty and returns zero generation when the path does not exist.
+func readGeneration(ctx context.Context, client gcs.Client, path gcs.Path, msg proto.Message) (int64, error) {
+	msg.Reset()
+	attrs, err := client.Stat(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("stat: %w", err)
+	}
+	r, err := client.Open(ctx, path)
+	if err != nil {
+		return 0, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("read: %w", err)
+	}
+	if err := proto.Unmarshal(buf, msg); err != nil {
+		return 0, fmt.Errorf("unmarshal: %w", err)
+	}
+	return attrs.Generation, nil
+}
+
+// updateGeneration reads the message at the path and writes it back when update changes it.
+//
+// Retries when the message changes concurrently.
+func updateGeneration(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, msg proto.Message, update func() bool) error {
+	const attempts = 3
+	for i := 0; ; i++ {
+		generation, err := readGeneration(ctx, client, path, msg)
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+		if !update() {
+			return nil
+		}
+		buf, err := proto.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+		cond := storage.Conditions{GenerationMatch: generation}
+		if generation == 0 {
+			cond = storage.Conditions{DoesNotExist: true}
+		}
+		err = client.If(nil, &cond).Upload(ctx, path, buf, gcs.DefaultACL, "no-cache")
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed && i+1 < attempts {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("upload: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const columnEditsSuffix = ".edits"
+
+// ColumnEditsPath returns the path to the column edits awaiting the next update of a grid.
+func ColumnEditsPath(gridPath gcs.Path) (*gcs.Path, error) {
+	return gcs.NewPath(gridPath.String() + columnEditsSuffix)
+}
+
+// ReadColumnEdits returns the column edits at the path along with its generation.
+//
+// Returns no edits and zero generation when the path does not exist.
+func ReadColumnEdits(ctx context.Context, client gcs.Client, path gcs.Path) (*statepb.ColumnEdits, int64, error) {
+	var edits statepb.ColumnEdits
+	generation, err := readGeneration(ctx, client, path, &edits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &edits, generation, nil
+}
+
+// UpdateColumnEdits writes the column edits when update changes them.
+//
+// Retries when the edits change concurrently.
+func UpdateColumnEdits(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, update func(*statepb.ColumnEdits) bool) (*statepb.ColumnEdits, error) {
+	var edits statepb.ColumnEdits
+	if err := updateGeneration(ctx, client, path, &edits, func() bool { return update(&edits) }); err != nil {
+		return nil, err
+	}
+	return &edits, nil
+}
+
+// RequestColumnEdit records the action on the build's column, replacing any
+// earlier edit of the build.
+func RequestColumnEdit(edits *statepb.ColumnEdits, build string, action statepb.ColumnEdit_Action, requester string, now time.Time) *statepb.ColumnEdit {
+	edit := &statepb.ColumnEdit{
+		Build:     build,
+		Action:    action,
+		Requested: float64(now.Unix()),
+		Requester: requester,
+	}
+	for i, e := range edits.Edits {
+		if e.Build == build {
+			edits.Edits[i] = edit
+			return edit
+		}
+	}
+	edits.Edits = append(edits.Edits, edit)
+	return edit
+}
+
+// CancelColumnEdit removes the pending edit of the build, returning false when there is none.
+func CancelColumnEdit(edits *statepb.ColumnEdits, build string) bool {
+	for i, e := range edits.Edits {
+		if e.Build == build {
+			edits.Edits = append(edits.Edits[:i], edits.Edits[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// deletedBuilds returns the builds which the edits delete.
+func deletedBuilds(edits *statepb.ColumnEdits) map[string]bool {
+	deleted := map[string]bool{}
+	for _, e := range edits.GetEdits() {
+		if e.Action == statepb.ColumnEdit_DELETE {
+			deleted[e.Build] = true
+		}
+	}
+	return deleted
+}
+
+// applyColumnEdits returns the columns, newest first, without those of deleted
+// builds nor those of reprocessed builds and any newer columns, so the next
+// read includes them again.
+func applyColumnEdits(edits *statepb.ColumnEdits, cols []InflatedColumn) []InflatedColumn {
+	if len(edits.GetEdits()) == 0 {
+		return cols
+	}
+	deleted := deletedBuilds(edits)
+	reprocess := map[string]bool{}
+	for _, e := range edits.Edits {
+		if e.Action == statepb.ColumnEdit_REPROCESS {
+			reprocess[e.Build] = true
+		}
+	}
+	for i := len(cols) - 1; i >= 0; i-- {
+		if reprocess[cols[i].Column.Build] {
+			cols = cols[i+1:]
+			break
+		}
+	}
+	return dropBuilds(cols, deleted)
+}
+
+// dropBuilds returns the columns without those of the builds.
+func dropBuilds(cols []InflatedColumn, builds map[string]bool) []InflatedColumn {
+	if len(builds) == 0 {
+		return cols
+	}
+	out := make([]InflatedColumn, 0, len(cols))
+	for _, col := range cols {
+		if !builds[col.Column.Build] {
+			out = append(out, col)
+		}
+	}
+	return out
+}
+
+// clearColumnEdits removes the applied edits, returning true when this changes the edits.
+//
+// Reprocess edits apply once, unless requested again since. Delete edits last
+// until stop, after which the updater never reads the build again.
+func clearColumnEdits(edits, applied *statepb.ColumnEdits, stop time.Time) bool {
+	done := map[string]float64{}
+	for _, e := range applied.GetEdits() {
+		if e.Action == statepb.ColumnEdit_REPROCESS {
+			done[e.Build] = e.Requested
+		}
+	}
+	kept := edits.Edits[:0]
+	for _, e := range edits.Edits {
+		if e.Action == statepb.ColumnEdit_REPROCESS {
+			if requested, ok := done[e.Build]; ok && e.Requested <= requested {
+				continue
+			}
+		}
+		if e.Action == statepb.ColumnEdit_DELETE && e.Requested < float64(stop.Unix()) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	changed := len(kept) != len(edits.Edits)
+	edits.Edits = kept
+	return changed
+}
+
+// clearEdits removes the applied edits from the path, which requires a client supporting conditional writes.
+func clearEdits(ctx context.Context, client gcs.Client, path gcs.Path, applied *statepb.ColumnEdits, stop time.Time) error {
+	if len(applied.GetEdits()) == 0 {
+		return nil
+	}
+	cc, ok := client.(gcs.ConditionalClient)
+	if !ok {
+		return errors.New("client does not support conditional writes")
+	}
+	_, err := UpdateColumnEdits(ctx, cc, path, func(edits *statepb.ColumnEdits) bool {
+		return clearColumnEdits(edits, applied, stop)
+	})
+	return err
+}
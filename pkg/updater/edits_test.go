@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestApplyColumnEdits(t *testing.T) {
+	cols := func() []InflatedColumn {
+		var out []InflatedColumn
+		for _, b := range []string{"5", "4", "3", "2", "1"} {
+			out = append(out, InflatedColumn{Column: &statepb.Column{Build: b}})
+		}
+		return out
+	}
+	cases := []struct {
+		name  string
+		edits *statepb.ColumnEdits
+		want  []string
+	}{
+		{
+			name: "basically works",
+			want: []string{"5", "4", "3", "2", "1"},
+		},
+		{
+			name: "delete columns",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "4", Action: statepb.ColumnEdit_DELETE},
+				{Build: "2", Action: statepb.ColumnEdit_DELETE},
+			}},
+			want: []string{"5", "3", "1"},
+		},
+		{
+			name: "reprocess a column and every newer one",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "3", Action: statepb.ColumnEdit_REPROCESS},
+			}},
+			want: []string{"2", "1"},
+		},
+		{
+			name: "reprocess from the oldest column",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "4", Action: statepb.ColumnEdit_REPROCESS},
+				{Build: "2", Action: statepb.ColumnEdit_REPROCESS},
+				{Build: "1", Action: statepb.ColumnEdit_DELETE},
+			}},
+			want: []string{},
+		},
+		{
+			name: "ignore missing builds",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "9", Action: statepb.ColumnEdit_REPROCESS},
+				{Build: "8", Action: statepb.ColumnEdit_DELETE},
+			}},
+			want: []string{"5", "4", "3", "2", "1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for _, col := range applyColumnEdits(tc.edits, cols()) {
+				got = append(got, col.Column.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("applyColumnEdits() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClearColumnEdits(t *testing.T) {
+	now := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	stop := now.AddDate(0, 0, -7)
+	recent := float64(now.Add(-time.Hour).Unix())
+	later := float64(now.Unix())
+	old := float64(stop.Add(-time.Hour).Unix())
+	cases := []struct {
+		name        string
+		edits       *statepb.ColumnEdits
+		applied     *statepb.ColumnEdits
+		want        *statepb.ColumnEdits
+		wantChanged bool
+	}{
+		{
+			name:    "basically works",
+			edits:   &statepb.ColumnEdits{},
+			applied: &statepb.ColumnEdits{},
+			want:    &statepb.ColumnEdits{},
+		},
+		{
+			name: "clear applied reprocess edits",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_REPROCESS, Requested: recent},
+				{Build: "2", Action: statepb.ColumnEdit_REPROCESS, Requested: recent},
+			}},
+			applied: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_REPROCESS, Requested: recent},
+			}},
+			want: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "2", Action: statepb.ColumnEdit_REPROCESS, Requested: recent},
+			}},
+			wantChanged: true,
+		},
+		{
+			name: "keep edits requested again",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_REPROCESS, Requested: later},
+			}},
+			applied: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_REPROCESS, Requested: recent},
+			}},
+			want: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_REPROCESS, Requested: later},
+			}},
+		},
+		{
+			name: "keep delete edits until stop",
+			edits: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_DELETE, Requested: old},
+				{Build: "2", Action: statepb.ColumnEdit_DELETE, Requested: recent},
+			}},
+			applied: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "1", Action: statepb.ColumnEdit_DELETE, Requested: old},
+				{Build: "2", Action: statepb.ColumnEdit_DELETE, Requested: recent},
+			}},
+			want: &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+				{Build: "2", Action: statepb.ColumnEdit_DELETE, Requested: recent},
+			}},
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := clearColumnEdits(tc.edits, tc.applied, stop)
+			if changed != tc.wantChanged {
+				t.Errorf("clearColumnEdits() got changed %t, want %t", changed, tc.wantChanged)
+			}
+			if diff := cmp.Diff(tc.want, tc.edits, protocmp.Transform()); diff != "" {
+				t.Errorf("clearColumnEdits() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequestColumnEdit(t *testing.T) {
+	now := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	edits := &statepb.ColumnEdits{}
+	RequestColumnEdit(edits, "1", statepb.ColumnEdit_REPROCESS, "alice", now)
+	RequestColumnEdit(edits, "2", statepb.ColumnEdit_DELETE, "bob", now)
+	RequestColumnEdit(edits, "1", statepb.ColumnEdit_DELETE, "carol", now.Add(time.Minute))
+	want := &statepb.ColumnEdits{Edits: []*statepb.ColumnEdit{
+		{Build: "1", Action: statepb.ColumnEdit_DELETE, Requester: "carol", Requested: float64(now.Add(time.Minute).Unix())},
+		{Build: "2", Action: statepb.ColumnEdit_DELETE, Requester: "bob", Requested: float64(now.Unix())},
+	}}
+	if diff := cmp.Diff(want, edits, protocmp.Transform()); diff != "" {
+		t.Errorf("RequestColumnEdit() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if !CancelColumnEdit(edits, "1") {
+		t.Error("CancelColumnEdit(1) got false, want true")
+	}
+	if CancelColumnEdit(edits, "1") {
+		t.Error("CancelColumnEdit(1) again got true, want false")
+	}
+	if diff := cmp.Diff(want.Edits[1:], edits.Edits, protocmp.Transform()); diff != "" {
+		t.Errorf("CancelColumnEdit() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
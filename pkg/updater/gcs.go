@@ -18,15 +18,20 @@ package updater
 
 import (
 	"fmt"
+	"net/url"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
 
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
@@ -43,6 +48,8 @@ type gcsResult struct {
 	job       string
 	build     string
 	malformed []string
+	// violations of the metadata schema, when strict.
+	violations []string
 }
 
 const maxDuplicates = 20
@@ -101,6 +108,127 @@ const (
 	podInfoRow = "Pod"
 )
 
+// cellProperties extracts the configured properties from the junit properties.
+//
+// Values that do not parse as the configured type are dropped.
+func cellProperties(props map[string][]string, cfgs []*configpb.CellProperty) map[string]string {
+	var out map[string]string
+	for _, cfg := range cfgs {
+		key := cfg.Property
+		if key == "" {
+			key = cfg.Name
+		}
+		values, ok := props[key]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		val, ok := typedProperty(values[0], cfg.Type)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = map[string]string{}
+		}
+		out[cfg.Name] = val
+	}
+	return out
+}
+
+// typedProperty returns the canonical form of the value for the type, if valid.
+func typedProperty(val string, typ configpb.CellProperty_Type) (string, bool) {
+	val = strings.TrimSpace(val)
+	switch typ {
+	case configpb.CellProperty_INT:
+		i, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatInt(i, 10), true
+	case configpb.CellProperty_FLOAT:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	case configpb.CellProperty_BOOL:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatBool(b), true
+	}
+	return val, val != ""
+}
+
+var parametersRE = regexp.MustCompile(`\s*(\[[^\]]*\]|\([^)]*\))$`)
+
+// splitParameters separates a trailing parameter list from the test name.
+func splitParameters(name string) (string, string) {
+	loc := parametersRE.FindStringIndex(name)
+	if loc == nil || loc[0] == 0 {
+		return name, ""
+	}
+	return name[:loc[0]], strings.TrimSpace(name[loc[0]:])
+}
+
+// normalizeName applies the configured normalization rules to a test name.
+func normalizeName(cfg *configpb.TestNameNormalization, name, className string) string {
+	if cfg == nil {
+		return name
+	}
+	if cfg.ReplaceDoubleColons {
+		name = strings.ReplaceAll(name, "::", ".")
+		className = strings.ReplaceAll(className, "::", ".")
+	}
+	if cfg.CollapseClassPrefix && className != "" {
+		// The class may follow the suite names which prefix the test.
+		if i := strings.Index(name, className+"."); i == 0 || i > 0 && name[i-1] == '.' {
+			short := className[strings.LastIndex(className, ".")+1:]
+			name = name[:i] + short + name[i+len(className):]
+		}
+	}
+	if cfg.StripParameters {
+		name, _ = splitParameters(name)
+	}
+	return name
+}
+
+// folded returns true when the cells hold parameterized results of the same test.
+func folded(cells []Cell) bool {
+	for _, c := range cells {
+		if len(c.Parameters) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentLinks resolves attachment paths relative to the junit file which lists them.
+func attachmentLinks(junitPath string, attachments []string) []*statepb.Link {
+	if len(attachments) == 0 {
+		return nil
+	}
+	base, err := url.Parse(junitPath)
+	if err != nil {
+		base = nil
+	}
+	var links []*statepb.Link
+	for _, a := range attachments {
+		ref, err := url.Parse(a)
+		if err != nil {
+			continue
+		}
+		if base != nil {
+			ref = base.ResolveReference(ref)
+		}
+		links = append(links, &statepb.Link{
+			Name: path.Base(ref.Path),
+			Url:  ref.String(),
+		})
+	}
+	return links
+}
+
 // MergeCells will combine the cells into a single result.
 //
 // The flaky argument determines whether returned result
@@ -130,6 +258,8 @@ func MergeCells(flaky bool, cells ...Cell) Cell {
 	// determine the status and potential messages
 	// gather all metrics
 	means := map[string][]float64{}
+	var links []*statepb.Link
+	var params []*statepb.ParameterResult
 
 	current := out.Result
 	passMessageResult := current
@@ -157,7 +287,11 @@ func MergeCells(flaky bool, cells ...Cell) Cell {
 		for metric, mean := range c.Metrics {
 			means[metric] = append(means[metric], mean)
 		}
+		links = append(links, c.Links...)
+		params = append(params, c.Parameters...)
 	}
+	out.Links = links
+	out.Parameters = params
 
 	if flaky && pass > 0 && fail > 0 {
 		out.Result = statuspb.TestStatus_FLAKY
@@ -233,6 +367,13 @@ func SplitCells(originalName string, cells ...Cell) map[string]Cell {
 	return out
 }
 
+const (
+	// pullHeader is the column header holding the head commit of the pull request tested.
+	pullHeader = "Pull"
+	// branchHeader is the column header holding the base branch tested.
+	branchHeader = "Branch"
+)
+
 // convertResult returns an InflatedColumn representation of the GCS result.
 func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, headers []string, result gcsResult, opt groupOptions) (*InflatedColumn, error) {
 	cells := map[string][]Cell{}
@@ -245,59 +386,83 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 
 	meta := result.finished.Metadata.Strings()
 	version := metadata.Version(result.started.Started, result.finished.Finished)
+	pullHead := metadata.PullHead(result.started.Started)
+	branch := metadata.Missing
+	if opt.commitRepo != "" {
+		// Never describe a different repo than the configured one.
+		version, pullHead = metadata.Missing, metadata.Missing
+	}
+	if co, ok := metadata.RepoCheckout(result.started.Started, result.finished.Finished, opt.commitRepo); ok {
+		if co.Branch != "" {
+			branch = co.Branch
+		}
+		if opt.commitRepo != "" {
+			if co.PullHead != "" {
+				version, pullHead = co.PullHead, co.PullHead
+			} else if co.Commit != "" {
+				version = co.Commit
+			}
+		}
+	}
 
 	// Append each result into the column
 	for _, suite := range result.suites {
-		for _, r := range flattenResults(suite.Suites.Suites...) {
-			if r.Skipped != nil && *r.Skipped == "" {
-				continue
+		for _, top := range suite.Suites.Suites {
+			var topMinutes float64
+			topBudget := opt.budgets.limit(top.Name, true)
+			if topBudget > 0 {
+				topMinutes = suiteMinutes(top)
 			}
-			c := Cell{CellID: cellID}
-			if elapsed := r.Time; elapsed > 0 {
-				c.Metrics = setElapsed(c.Metrics, elapsed)
-			}
-
-			props := propertyMap(&r)
-			for metric, mean := range Means(props) {
-				if c.Metrics == nil {
-					c.Metrics = map[string]float64{}
+			for _, r := range FlattenResults(top) {
+				if r.Skipped != nil && *r.Skipped == "" {
+					continue
+				}
+				c := ResultCell(r)
+				c.CellID = cellID
+
+				props := propertyMap(&r)
+				for metric, mean := range Means(props) {
+					if c.Metrics == nil {
+						c.Metrics = map[string]float64{}
+					}
+					c.Metrics[metric] = mean
 				}
-				c.Metrics[metric] = mean
-			}
-
-			const max = 140
-			if msg := r.Message(max); msg != "" {
-				c.Message = msg
-			}
 
-			switch {
-			case r.Errored != nil:
-				c.Result = statuspb.TestStatus_FAIL
-				if c.Message != "" {
-					c.Icon = "F"
+				if f, ok := c.Metrics[opt.metricKey]; ok {
+					c.Icon = strconv.FormatFloat(f, 'g', 4, 64)
 				}
-			case r.Failure != nil:
-				c.Result = statuspb.TestStatus_FAIL
-				if c.Message != "" {
-					c.Icon = "F"
+
+				if values, ok := props[opt.userKey]; ok && len(values) > 0 {
+					c.UserProperty = values[0]
 				}
-			case r.Skipped != nil:
-				c.Result = statuspb.TestStatus_PASS_WITH_SKIPS
-				c.Icon = "S"
-			default:
-				c.Result = statuspb.TestStatus_PASS
-			}
 
-			if f, ok := c.Metrics[opt.metricKey]; ok {
-				c.Icon = strconv.FormatFloat(f, 'g', 4, 64)
-			}
+				c.Properties = cellProperties(props, opt.cellProperties)
+				c.Links = attachmentLinks(suite.Path, r.Attachments())
+				if len(opt.issueLinks) > 0 {
+					// Search the entire message, which may be truncated in the cell.
+					c.Links = append(c.Links, opt.issueLinks.links(r.Message(0))...)
+				}
 
-			if values, ok := props[opt.userKey]; ok && len(values) > 0 {
-				c.UserProperty = values[0]
+				testName := normalizeName(opt.normalize, r.Name, r.ClassName)
+				if len(opt.budgets) > 0 {
+					overBudget(&c, "Test", c.Metrics[ElapsedKey], opt.budgets.limit(testName, false))
+					overBudget(&c, strings.TrimSpace("Suite "+top.Name), topMinutes, topBudget)
+				}
+				if opt.foldParameters {
+					if base, params := splitParameters(testName); params != "" {
+						testName = base
+						c.Parameters = []*statepb.ParameterResult{
+							{
+								Parameters: params,
+								Result:     int32(c.Result),
+								Message:    c.Message,
+							},
+						}
+					}
+				}
+				name := nameCfg.render(result.job, testName, first(props), suite.Metadata, meta)
+				cells[name] = append(cells[name], c)
 			}
-
-			name := nameCfg.render(result.job, r.Name, first(props), suite.Metadata, meta)
-			cells[name] = append(cells[name], c)
 		}
 	}
 
@@ -321,13 +486,14 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 		}
 	}
 
-	injectedCells := map[string]Cell{
-		overallRow: overall,
+	injectedCells := map[string]Cell{}
+	if name := syntheticRowName(opt.overallRow, overallRow); name != "" {
+		injectedCells[name] = overall
 	}
 
-	if opt.analyzeProwJob {
-		if pic := podInfoCell(result.podInfo); pic.Message != gcs.MissingPodInfo || overall.Result != statuspb.TestStatus_RUNNING {
-			injectedCells[podInfoRow] = pic
+	if name := syntheticRowName(opt.podRow, podInfoRow); opt.analyzeProwJob && name != "" {
+		if pic := podInfoCell(result.podInfo, PodRules); pic.Message != gcs.MissingPodInfo || overall.Result != statuspb.TestStatus_RUNNING {
+			injectedCells[name] = pic
 		}
 	}
 
@@ -342,15 +508,20 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 
 	out := InflatedColumn{
 		Column: &statepb.Column{
-			Build:   id,
-			Started: float64(result.started.Timestamp * 1000),
-			Hint:    id,
+			Build:    id,
+			Started:  float64(result.started.Timestamp * 1000),
+			Hint:     id,
+			Metadata: columnMetadata(opt.columnMetadata, meta, result.podInfo),
+
+			MetadataViolations: result.violations,
 		},
 		Cells: map[string]Cell{},
 	}
 
 	for name, cells := range cells {
 		switch {
+		case folded(cells):
+			out.Cells[name] = MergeCells(false, cells...)
 		case opt.merge:
 			out.Cells[name] = MergeCells(true, cells...)
 		default:
@@ -360,20 +531,39 @@ func convertResult(log logrus.FieldLogger, nameCfg nameConfig, id string, header
 		}
 	}
 
-	for _, h := range headers {
+	for i, h := range headers {
 		val, ok := meta[h]
 		if !ok && h == "Commit" && version != metadata.Missing {
-			val = version
+			val, ok = version, true
+		} else if !ok && h == pullHeader && pullHead != metadata.Missing {
+			val, ok = pullHead, true
+		} else if !ok && h == branchHeader && branch != metadata.Missing {
+			val, ok = branch, true
 		} else if !ok && overall.Result != statuspb.TestStatus_RUNNING {
 			val = "missing"
 		}
+		if ok {
+			val = opt.headers.apply(i, val)
+		}
 		out.Column.Extra = append(out.Column.Extra, val)
 	}
 
 	return &out, nil
 }
 
-func podInfoCell(podInfo gcs.PodInfo) Cell {
+// podInfoCell returns the pod row cell, classified by the first matching rule of a pod which did not succeed.
+func podInfoCell(podInfo gcs.PodInfo, rules []PodRule) Cell {
+	if pod := podInfo.Pod; pod != nil && pod.Status.Phase != core.PodSucceeded {
+		for _, rule := range rules {
+			if msg, ok := rule.Match(pod); ok {
+				return Cell{
+					Message: msg,
+					Icon:    rule.Icon,
+					Result:  rule.Result,
+				}
+			}
+		}
+	}
 	pass, msg := podInfo.Summarize()
 	var status statuspb.TestStatus
 	var icon string
@@ -431,7 +621,7 @@ func overallCell(result gcsResult) Cell {
 			c.Result = statuspb.TestStatus_FAIL
 		}
 		c.Metrics = setElapsed(nil, float64(finished-result.started.Timestamp))
-	case time.Now().Add(-24*time.Hour).Unix() > result.started.Timestamp:
+	case clock().Add(-24*time.Hour).Unix() > result.started.Timestamp:
 		c.Result = statuspb.TestStatus_FAIL
 		c.Message = "Build did not complete within 24 hours"
 		c.Icon = "T"
@@ -454,13 +644,35 @@ func setElapsed(metrics map[string]float64, seconds float64) map[string]float64
 	return metrics
 }
 
-// flattenResults returns the DFS of all junit results in all suites.
-func flattenResults(suites ...junit.Suite) []junit.Result {
+// ResultCell converts the junit result into a cell with its elapsed time, message and status.
+func ResultCell(r junit.Result) Cell {
+	var c Cell
+	if elapsed := r.Time; elapsed > 0 {
+		c.Metrics = setElapsed(c.Metrics, elapsed)
+	}
+	c.SetMessage(r.Message)
+	switch {
+	case r.Errored != nil, r.Failure != nil:
+		c.Result = statuspb.TestStatus_FAIL
+		if c.Message != "" {
+			c.Icon = "F"
+		}
+	case r.Skipped != nil:
+		c.Result = statuspb.TestStatus_PASS_WITH_SKIPS
+		c.Icon = "S"
+	default:
+		c.Result = statuspb.TestStatus_PASS
+	}
+	return c
+}
+
+// FlattenResults returns the DFS of all junit results in all suites.
+func FlattenResults(suites ...junit.Suite) []junit.Result {
 	var results []junit.Result
 	for _, suite := range suites {
 		for _, innerSuite := range suite.Suites {
 			innerSuite.Name = dotName(suite.Name, innerSuite.Name)
-			results = append(results, flattenResults(innerSuite)...)
+			results = append(results, FlattenResults(innerSuite)...)
 		}
 		for _, r := range suite.Results {
 			r.Name = dotName(suite.Name, r.Name)
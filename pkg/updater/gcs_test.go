@@ -19,6 +19,7 @@ package updater
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
@@ -347,6 +349,124 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "transform column headers",
+			headers: []string{"tag", "hello", "do not have this one"},
+			id:      "hello",
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: 300,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Metadata: metadata.Metadata{
+							"hello": "world",
+							"tag":   "v1.30.0-alpha.2+abc123def4567890",
+						},
+					},
+				},
+			},
+			opt: groupOptions{
+				headers: headerTransforms{
+					{func(val string) string { return truncate(22, val) }},
+					{strings.ToUpper},
+					{strings.ToUpper},
+				},
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Build:   "hello",
+					Hint:    "hello",
+					Started: 300 * 1000,
+					Extra: []string{
+						"v1.30.0-alpha.2+abc123",
+						"WORLD",
+						"missing",
+					},
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "T",
+						Message: "Build did not complete within 24 hours",
+					},
+				},
+			},
+		},
+		{
+			name:    "select the repo of commit headers",
+			headers: []string{"Commit", "Branch", "Pull"},
+			id:      "hello",
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp:  300,
+						RepoCommit: "wrong-commit",
+						Repos: map[string]string{
+							"org/repo":  "main:wrong,1:wrong",
+							"org/other": "release:base,7:head",
+						},
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Metadata: metadata.Metadata{"repo": "org/repo"},
+					},
+				},
+			},
+			opt: groupOptions{
+				commitRepo: "org/other",
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Build:   "hello",
+					Hint:    "hello",
+					Started: 300 * 1000,
+					Extra:   []string{"head", "release", "head"},
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "T",
+						Message: "Build did not complete within 24 hours",
+					},
+				},
+			},
+		},
+		{
+			name:    "missing commit repo",
+			headers: []string{"Commit", "Branch"},
+			id:      "hello",
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp:  300,
+						RepoCommit: "wrong-commit",
+						Repos:      map[string]string{"org/repo": "main:wrong"},
+					},
+				},
+			},
+			opt: groupOptions{
+				commitRepo: "org/other",
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Build:   "hello",
+					Hint:    "hello",
+					Started: 300 * 1000,
+					Extra:   []string{"missing", "missing"},
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "T",
+						Message: "Build did not complete within 24 hours",
+					},
+				},
+			},
+		},
 		{
 			name:    "running results do not have missing column headers",
 			headers: []string{"Commit", "hello", "spam", "do not have this one"},
@@ -505,6 +625,138 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "mark passing results over their duration budget",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Name: "slow",
+									Time: 600,
+									Results: []junit.Result{
+										{Name: "fast", Time: 30},
+										{Name: "slower", Time: 300},
+										{Name: "broken", Time: 400, Failure: pstr("boom")},
+									},
+								},
+								{
+									Name: "quick",
+									Results: []junit.Result{
+										{Name: "fast", Time: 30},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			opt: groupOptions{
+				budgets: newDurationBudgets([]*configpb.TestGroup_DurationBudget{
+					{MaxMinutes: 4},
+					{NameRegex: "^slow", MaxMinutes: 8, Suite: true},
+				}),
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"slow.fast": {
+						Result:  statuspb.TestStatus_PASS_WITH_ERRORS,
+						Icon:    "B",
+						Message: "Suite slow took 10.0 minutes, over its 8 minute budget",
+						Metrics: map[string]float64{ElapsedKey: 0.5, OverBudgetKey: 2},
+					},
+					"slow.slower": {
+						Result:  statuspb.TestStatus_PASS_WITH_ERRORS,
+						Icon:    "B",
+						Message: "Test took 5.0 minutes, over its 4 minute budget",
+						Metrics: map[string]float64{ElapsedKey: 5, OverBudgetKey: 1},
+					},
+					"slow.broken": {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "F",
+						Message: "boom",
+						Metrics: setElapsed(nil, 400),
+					},
+					"quick.fast": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 30),
+					},
+				},
+			},
+		},
+		{
+			name: "truncated messages keep their full message",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{Name: "long", Failure: pstr(strings.Repeat("a", 100) + strings.Repeat("b", 100))},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"long": {
+						Result:      statuspb.TestStatus_FAIL,
+						Icon:        "F",
+						Message:     strings.Repeat("a", 70) + "..." + strings.Repeat("b", 70),
+						FullMessage: strings.Repeat("a", 100) + strings.Repeat("b", 100),
+					},
+				},
+			},
+		},
 		{
 			name: "failing job with only passing results has a failing overall message",
 			nameCfg: nameConfig{
@@ -1299,6 +1551,38 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "rename and disable synthetic rows",
+			opt: groupOptions{
+				analyzeProwJob: true,
+				overallRow:     &configpb.TestGroup_SyntheticRow{Name: "Build"},
+				podRow:         &configpb.TestGroup_SyntheticRow{Disable: true},
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					"Build": {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+				},
+			},
+		},
 		{
 			name: "can add interesting pod info",
 			opt: groupOptions{
@@ -1449,6 +1733,81 @@ func TestConvertResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fold parameterized tests",
+			nameCfg: nameConfig{
+				format: "%s",
+				parts:  []string{testsName},
+			},
+			opt: groupOptions{
+				foldParameters: true,
+			},
+			result: gcsResult{
+				started: gcs.Started{
+					Started: metadata.Started{
+						Timestamp: now,
+					},
+				},
+				finished: gcs.Finished{
+					Finished: metadata.Finished{
+						Timestamp: pint(now + 1),
+						Passed:    &yes,
+					},
+				},
+				suites: []gcs.SuitesMeta{
+					{
+						Suites: junit.Suites{
+							Suites: []junit.Suite{
+								{
+									Results: []junit.Result{
+										{
+											Name: "test_foo[a]",
+										},
+										{
+											Name:    "test_foo[b]",
+											Failure: pstr("boom"),
+										},
+										{
+											Name: "test_bar",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &InflatedColumn{
+				Column: &statepb.Column{
+					Started: float64(now * 1000),
+				},
+				Cells: map[string]Cell{
+					overallRow: {
+						Result:  statuspb.TestStatus_PASS,
+						Metrics: setElapsed(nil, 1),
+					},
+					"test_foo": {
+						Result:  statuspb.TestStatus_FAIL,
+						Icon:    "1/2",
+						Message: "1/2 runs passed: boom",
+						Parameters: []*statepb.ParameterResult{
+							{
+								Parameters: "[a]",
+								Result:     int32(statuspb.TestStatus_PASS),
+							},
+							{
+								Parameters: "[b]",
+								Result:     int32(statuspb.TestStatus_FAIL),
+								Message:    "boom",
+							},
+						},
+					},
+					"test_bar": {
+						Result: statuspb.TestStatus_PASS,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1471,6 +1830,204 @@ func TestConvertResult(t *testing.T) {
 	}
 }
 
+func TestCellProperties(t *testing.T) {
+	cases := []struct {
+		name  string
+		props map[string][]string
+		cfgs  []*configpb.CellProperty
+		want  map[string]string
+	}{
+		{
+			name:  "basically works",
+			props: map[string][]string{"owner": {"sig-node"}},
+		},
+		{
+			name:  "extract strings",
+			props: map[string][]string{"owner": {"sig-node", "sig-network"}, "feature": {" alpha "}},
+			cfgs: []*configpb.CellProperty{
+				{Name: "owner"},
+				{Name: "feature"},
+				{Name: "missing"},
+			},
+			want: map[string]string{"owner": "sig-node", "feature": "alpha"},
+		},
+		{
+			name:  "rename property",
+			props: map[string][]string{"testcase-id": {"TC-123"}},
+			cfgs: []*configpb.CellProperty{
+				{Name: "case", Property: "testcase-id"},
+			},
+			want: map[string]string{"case": "TC-123"},
+		},
+		{
+			name: "canonicalize types",
+			props: map[string][]string{
+				"int":   {"007"},
+				"float": {"1.50"},
+				"bool":  {"TRUE"},
+			},
+			cfgs: []*configpb.CellProperty{
+				{Name: "int", Type: configpb.CellProperty_INT},
+				{Name: "float", Type: configpb.CellProperty_FLOAT},
+				{Name: "bool", Type: configpb.CellProperty_BOOL},
+			},
+			want: map[string]string{"int": "7", "float": "1.5", "bool": "true"},
+		},
+		{
+			name: "drop invalid values",
+			props: map[string][]string{
+				"int":   {"seven"},
+				"float": {"1.5x"},
+				"bool":  {"maybe"},
+				"empty": {""},
+			},
+			cfgs: []*configpb.CellProperty{
+				{Name: "int", Type: configpb.CellProperty_INT},
+				{Name: "float", Type: configpb.CellProperty_FLOAT},
+				{Name: "bool", Type: configpb.CellProperty_BOOL},
+				{Name: "empty"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := cellProperties(tc.props, tc.cfgs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("cellProperties() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	all := &configpb.TestNameNormalization{
+		ReplaceDoubleColons: true,
+		CollapseClassPrefix: true,
+		StripParameters:     true,
+	}
+	cases := []struct {
+		name      string
+		cfg       *configpb.TestNameNormalization
+		testName  string
+		className string
+		want      string
+	}{
+		{
+			name:     "basically works",
+			testName: "tests/test_foo.py::TestFoo::test_bar[1-2]",
+			want:     "tests/test_foo.py::TestFoo::test_bar[1-2]",
+		},
+		{
+			name:     "replace double colons",
+			cfg:      &configpb.TestNameNormalization{ReplaceDoubleColons: true},
+			testName: "tests/test_foo.py::TestFoo::test_bar",
+			want:     "tests/test_foo.py.TestFoo.test_bar",
+		},
+		{
+			name:      "collapse class prefix",
+			cfg:       &configpb.TestNameNormalization{CollapseClassPrefix: true},
+			testName:  "com.example.FooTest.testBar",
+			className: "com.example.FooTest",
+			want:      "FooTest.testBar",
+		},
+		{
+			name:      "collapse class prefix after suite name",
+			cfg:       &configpb.TestNameNormalization{CollapseClassPrefix: true},
+			testName:  "suite.com.example.FooTest.testBar",
+			className: "com.example.FooTest",
+			want:      "suite.FooTest.testBar",
+		},
+		{
+			name:      "ignore partial class matches",
+			cfg:       &configpb.TestNameNormalization{CollapseClassPrefix: true},
+			testName:  "xcom.example.FooTest.testBar",
+			className: "com.example.FooTest",
+			want:      "xcom.example.FooTest.testBar",
+		},
+		{
+			name:     "strip brackets",
+			cfg:      &configpb.TestNameNormalization{StripParameters: true},
+			testName: "test_bar[1-2]",
+			want:     "test_bar",
+		},
+		{
+			name:     "strip parentheses",
+			cfg:      &configpb.TestNameNormalization{StripParameters: true},
+			testName: "Foo.Tests.Bar(x: 1, y: \"two\")",
+			want:     "Foo.Tests.Bar",
+		},
+		{
+			name:     "keep names which are only parameters",
+			cfg:      &configpb.TestNameNormalization{StripParameters: true},
+			testName: "[sig-node] works",
+			want:     "[sig-node] works",
+		},
+		{
+			name:      "all rules",
+			cfg:       all,
+			testName:  "TestFoo::TestNested::test_bar[a]",
+			className: "tests.test_foo.TestFoo.TestNested",
+			want:      "TestFoo.TestNested.test_bar",
+		},
+		{
+			name:      "all rules with module prefix",
+			cfg:       all,
+			testName:  "tests.test_foo.TestFoo::test_bar[a]",
+			className: "tests.test_foo.TestFoo",
+			want:      "TestFoo.test_bar",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := normalizeName(tc.cfg, tc.testName, tc.className); got != tc.want {
+				t.Errorf("normalizeName(%q, %q) got %q, want %q", tc.testName, tc.className, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentLinks(t *testing.T) {
+	cases := []struct {
+		name        string
+		junitPath   string
+		attachments []string
+		want        []*statepb.Link
+	}{
+		{
+			name:      "basically works",
+			junitPath: "gs://bucket/logs/job/1/artifacts/junit.xml",
+		},
+		{
+			name:        "relative to the junit file",
+			junitPath:   "gs://bucket/logs/job/1/artifacts/junit.xml",
+			attachments: []string{"screenshots/login.png", "../build-log.txt"},
+			want: []*statepb.Link{
+				{Name: "login.png", Url: "gs://bucket/logs/job/1/artifacts/screenshots/login.png"},
+				{Name: "build-log.txt", Url: "gs://bucket/logs/job/1/build-log.txt"},
+			},
+		},
+		{
+			name:        "keep absolute urls",
+			junitPath:   "gs://bucket/logs/job/1/artifacts/junit.xml",
+			attachments: []string{"https://example.com/trace.zip"},
+			want: []*statepb.Link{
+				{Name: "trace.zip", Url: "https://example.com/trace.zip"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := attachmentLinks(tc.junitPath, tc.attachments)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("attachmentLinks() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestPodInfoCell(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -1516,11 +2073,42 @@ func TestPodInfoCell(t *testing.T) {
 				Result:  statuspb.TestStatus_FAIL,
 			},
 		},
+		{
+			name: "classify problems",
+			podInfo: gcs.PodInfo{
+				Pod: &core.Pod{
+					Status: core.PodStatus{
+						Phase:   core.PodFailed,
+						Reason:  "Evicted",
+						Message: "low on memory",
+					},
+				},
+			},
+			expected: Cell{
+				Message: "pod evicted: low on memory",
+				Icon:    "V",
+				Result:  statuspb.TestStatus_CATEGORIZED_ABORT,
+			},
+		},
+		{
+			name: "successful pods are not classified",
+			podInfo: gcs.PodInfo{
+				Pod: &core.Pod{
+					Status: core.PodStatus{
+						Phase:  core.PodSucceeded,
+						Reason: "Evicted",
+					},
+				},
+			},
+			expected: Cell{
+				Result: statuspb.TestStatus_PASS,
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := podInfoCell(tc.podInfo)
+			actual := podInfoCell(tc.podInfo, PodRules)
 			if diff := cmp.Diff(actual, tc.expected); diff != "" {
 				t.Errorf("podInfoCell(%s) got unexpected diff (-have, +want):\n%s", tc.podInfo, diff)
 			}
@@ -1740,6 +2328,68 @@ func TestSetElapsed(t *testing.T) {
 	}
 }
 
+func TestResultCell(t *testing.T) {
+	pstr := func(s string) *string {
+		return &s
+	}
+	cases := []struct {
+		name     string
+		result   junit.Result
+		expected Cell
+	}{
+		{
+			name: "pass",
+			result: junit.Result{
+				Time: 30,
+			},
+			expected: Cell{
+				Result:  statuspb.TestStatus_PASS,
+				Metrics: setElapsed(nil, 30),
+			},
+		},
+		{
+			name: "failure",
+			result: junit.Result{
+				Failure: pstr("boom"),
+			},
+			expected: Cell{
+				Result:  statuspb.TestStatus_FAIL,
+				Icon:    "F",
+				Message: "boom",
+			},
+		},
+		{
+			name: "error without message",
+			result: junit.Result{
+				Errored: pstr(""),
+			},
+			expected: Cell{
+				Result: statuspb.TestStatus_FAIL,
+			},
+		},
+		{
+			name: "skipped",
+			result: junit.Result{
+				Skipped: pstr("later"),
+			},
+			expected: Cell{
+				Result:  statuspb.TestStatus_PASS_WITH_SKIPS,
+				Icon:    "S",
+				Message: "later",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := ResultCell(tc.result)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("ResultCell(%v) got %#v, want %#v", tc.result, actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestFlattenResults(t *testing.T) {
 	pstr := func(s string) *string {
 		return &s
@@ -1845,9 +2495,9 @@ func TestFlattenResults(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := flattenResults(tc.suites...)
+			actual := FlattenResults(tc.suites...)
 			if !reflect.DeepEqual(actual, tc.expected) {
-				t.Errorf("flattenResults(%v) got %v, want %v", tc.suites, actual, tc.expected)
+				t.Errorf("FlattenResults(%v) got %v, want %v", tc.suites, actual, tc.expected)
 			}
 		})
 	}
@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const quarantineSuffix = ".quarantine"
+
+// ErrQuarantined means the write guard refused an update and quarantined its candidate state.
+var ErrQuarantined = errors.New("quarantined")
+
+// QuarantinePath returns the path to the candidate state of a grid which its
+// write guard refused.
+func QuarantinePath(gridPath gcs.Path) (*gcs.Path, error) {
+	return gcs.NewPath(gridPath.String() + quarantineSuffix)
+}
+
+// droppedPercents returns the percentage of the old grid's rows with results
+// and of its columns since stop which the new grid drops, ignoring any columns
+// which moved into the new grid's archive shards.
+func droppedPercents(old, grid *statepb.Grid, stop time.Time) (rows, cols float64) {
+	if old == nil {
+		return 0, 0
+	}
+	var oldCols []InflatedColumn
+	for _, col := range inflateGrid(old, stop, time.Unix(math.MaxInt64/int64(time.Second), 0)) {
+		if !archivedColumn(grid.ArchiveShards, col.Column.Started) {
+			oldCols = append(oldCols, col)
+		}
+	}
+
+	builds := make(map[string]bool, len(grid.Columns))
+	for _, col := range grid.Columns {
+		builds[col.Build] = true
+	}
+	var droppedCols int
+	for _, col := range oldCols {
+		if !builds[col.Column.Build] {
+			droppedCols++
+		}
+	}
+
+	names := make(map[string]bool, len(grid.Rows))
+	for _, row := range grid.Rows {
+		names[row.Name] = true
+	}
+	oldRows := map[string]bool{}
+	for _, col := range oldCols {
+		for name, cell := range col.Cells {
+			if cell.Result != statuspb.TestStatus_NO_RESULT {
+				oldRows[name] = true
+			}
+		}
+	}
+	var droppedRows int
+	for name := range oldRows {
+		if !names[name] {
+			droppedRows++
+		}
+	}
+
+	if n := len(oldRows); n > 0 {
+		rows = 100 * float64(droppedRows) / float64(n)
+	}
+	if n := len(oldCols); n > 0 {
+		cols = 100 * float64(droppedCols) / float64(n)
+	}
+	return rows, cols
+}
+
+// checkGuard returns an error when the new grid drops more of the old grid
+// than the guard allows.
+func checkGuard(guard *configpb.TestGroup_WriteGuard, old, grid *statepb.Grid, stop time.Time) error {
+	maxRows, maxCols := float64(guard.GetMaxDroppedRowsPercent()), float64(guard.GetMaxDroppedColumnsPercent())
+	if maxRows <= 0 && maxCols <= 0 {
+		return nil
+	}
+	rows, cols := droppedPercents(old, grid, stop)
+	if maxRows > 0 && rows > maxRows {
+		return fmt.Errorf("drops %.1f%% of rows, more than %.1f%%", rows, maxRows)
+	}
+	if maxCols > 0 && cols > maxCols {
+		return fmt.Errorf("drops %.1f%% of columns, more than %.1f%%", cols, maxCols)
+	}
+	return nil
+}
+
+// quarantineGrid writes the refused candidate state beside the grid.
+func quarantineGrid(ctx context.Context, client gcs.Uploader, gridPath gcs.Path, buf []byte) error {
+	path, err := QuarantinePath(gridPath)
+	if err != nil {
+		return fmt.Errorf("quarantine path: %w", err)
+	}
+	if err := client.Upload(ctx, *path, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
+
+// AcceptQuarantine replaces the grid with the candidate state its write guard
+// refused, then removes the candidate.
+//
+// Returns an error wrapping storage.ErrObjectNotExist when nothing is
+// quarantined. Refuses candidates which do not decode, or which are older than
+// the grid, since an update passed the guard after quarantining them.
+func AcceptQuarantine(ctx context.Context, client gcs.ConditionalClient, gridPath gcs.Path) error {
+	path, err := QuarantinePath(gridPath)
+	if err != nil {
+		return fmt.Errorf("quarantine path: %w", err)
+	}
+	attrs, err := client.Stat(ctx, *path)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+	cond := &storage.Conditions{GenerationMatch: attrs.Generation}
+	r, err := client.If(cond, nil).Open(ctx, *path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	_, err = state.Read(r)
+	r.Close()
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	gridCond := &storage.Conditions{DoesNotExist: true}
+	gridAttrs, err := client.Stat(ctx, gridPath)
+	switch {
+	case err == nil:
+		if gridAttrs.Updated.After(attrs.Updated) {
+			return fmt.Errorf("%s changed after quarantining %s", gridPath, path)
+		}
+		gridCond = &storage.Conditions{GenerationMatch: gridAttrs.Generation}
+	case !errors.Is(err, storage.ErrObjectNotExist):
+		return fmt.Errorf("stat %s: %w", gridPath, err)
+	}
+	if err := client.If(cond, gridCond).Copy(ctx, *path, gridPath); err != nil {
+		return fmt.Errorf("copy %s: %w", path, err)
+	}
+	if err := client.If(nil, cond).Delete(ctx, *path); err != nil {
+		return fmt.Errorf("delete %s: %w", path, err)
+	}
+	return nil
+}
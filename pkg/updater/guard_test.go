@@ -0,0 +1,347 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+type guardColumn struct {
+	build   string
+	started time.Time
+	passed  []string
+}
+
+func guardGrid(cols ...guardColumn) *statepb.Grid {
+	var inflated []InflatedColumn
+	for _, c := range cols {
+		col := InflatedColumn{
+			Column: &statepb.Column{Build: c.build, Hint: c.build, Started: float64(c.started.Unix() * 1000)},
+			Cells:  map[string]Cell{},
+		}
+		for _, name := range c.passed {
+			col.Cells[name] = Cell{Result: statuspb.TestStatus_PASS}
+		}
+		inflated = append(inflated, col)
+	}
+	return constructGrid(logrus.New(), &configpb.TestGroup{}, inflated)
+}
+
+func TestDroppedPercents(t *testing.T) {
+	now := time.Now()
+	stop := now.Add(-24 * time.Hour)
+	cases := []struct {
+		name     string
+		old      *statepb.Grid
+		grid     *statepb.Grid
+		wantRows float64
+		wantCols float64
+	}{
+		{
+			name: "new groups drop nothing",
+			grid: guardGrid(guardColumn{build: "1", started: now, passed: []string{"a"}}),
+		},
+		{
+			name: "appending drops nothing",
+			old:  guardGrid(guardColumn{build: "1", started: now, passed: []string{"a", "b"}}),
+			grid: guardGrid(
+				guardColumn{build: "2", started: now, passed: []string{"a", "b", "c"}},
+				guardColumn{build: "1", started: now, passed: []string{"a", "b"}},
+			),
+		},
+		{
+			name: "count dropped rows and columns",
+			old: guardGrid(
+				guardColumn{build: "4", started: now, passed: []string{"a", "b"}},
+				guardColumn{build: "3", started: now, passed: []string{"c", "d"}},
+			),
+			grid:     guardGrid(guardColumn{build: "4", started: now, passed: []string{"a"}}),
+			wantRows: 75,
+			wantCols: 50,
+		},
+		{
+			name: "ignore columns which age out",
+			old: guardGrid(
+				guardColumn{build: "2", started: now, passed: []string{"a"}},
+				guardColumn{build: "1", started: stop.Add(-time.Hour), passed: []string{"old"}},
+			),
+			grid: guardGrid(guardColumn{build: "2", started: now, passed: []string{"a"}}),
+		},
+		{
+			name: "ignore columns which move into archive shards",
+			old: guardGrid(
+				guardColumn{build: "2", started: now, passed: []string{"a"}},
+				guardColumn{build: "1", started: now.Add(-time.Hour), passed: []string{"cold"}},
+			),
+			grid: func() *statepb.Grid {
+				grid := guardGrid(guardColumn{build: "2", started: now, passed: []string{"a"}})
+				started := float64(now.Add(-time.Hour).Unix() * 1000)
+				grid.ArchiveShards = []*statepb.ArchiveShard{{OldestStarted: started, NewestStarted: started, Columns: 1}}
+				return grid
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, cols := droppedPercents(tc.old, tc.grid, stop)
+			if rows != tc.wantRows || cols != tc.wantCols {
+				t.Errorf("droppedPercents() got rows %f, cols %f, want rows %f, cols %f", rows, cols, tc.wantRows, tc.wantCols)
+			}
+		})
+	}
+}
+
+func TestCheckGuard(t *testing.T) {
+	now := time.Now()
+	stop := now.Add(-24 * time.Hour)
+	old := guardGrid(
+		guardColumn{build: "4", started: now, passed: []string{"a", "b"}},
+		guardColumn{build: "3", started: now, passed: []string{"c", "d"}},
+	)
+	grid := guardGrid(
+		guardColumn{build: "5", started: now, passed: []string{"a"}},
+		guardColumn{build: "4", started: now, passed: []string{"a"}},
+	)
+	cases := []struct {
+		name  string
+		guard *configpb.TestGroup_WriteGuard
+		err   bool
+	}{
+		{
+			name: "unguarded",
+		},
+		{
+			name:  "allow drops within limits",
+			guard: &configpb.TestGroup_WriteGuard{MaxDroppedRowsPercent: 80, MaxDroppedColumnsPercent: 50},
+		},
+		{
+			name:  "refuse dropping too many rows",
+			guard: &configpb.TestGroup_WriteGuard{MaxDroppedRowsPercent: 50},
+			err:   true,
+		},
+		{
+			name:  "refuse dropping too many columns",
+			guard: &configpb.TestGroup_WriteGuard{MaxDroppedColumnsPercent: 25},
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkGuard(tc.guard, old, grid, stop)
+			switch {
+			case err != nil && !tc.err:
+				t.Errorf("checkGuard() got unexpected error: %v", err)
+			case err == nil && tc.err:
+				t.Error("checkGuard() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestQuarantineGrid(t *testing.T) {
+	gridPath := newPathOrDie("gs://bucket/grid")
+	quarantinePath := newPathOrDie("gs://bucket/grid.quarantine")
+	cases := []struct {
+		name     string
+		uploader fake.Uploader
+		want     fake.Uploader
+		err      bool
+	}{
+		{
+			name:     "basically works",
+			uploader: fake.Uploader{},
+			want: fake.Uploader{
+				quarantinePath: {
+					Buf:          []byte("candidate"),
+					CacheControl: "no-cache",
+				},
+			},
+		},
+		{
+			name: "replace older candidates",
+			uploader: fake.Uploader{
+				quarantinePath: {Buf: []byte("older")},
+			},
+			want: fake.Uploader{
+				quarantinePath: {
+					Buf:          []byte("candidate"),
+					CacheControl: "no-cache",
+				},
+			},
+		},
+		{
+			name: "upload error",
+			uploader: fake.Uploader{
+				quarantinePath: {Err: errors.New("boom")},
+			},
+			want: fake.Uploader{
+				quarantinePath: {Err: errors.New("boom")},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := quarantineGrid(context.Background(), tc.uploader, gridPath, []byte("candidate"))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("quarantineGrid() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Error("quarantineGrid() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, tc.uploader, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+				t.Errorf("quarantineGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAcceptQuarantine(t *testing.T) {
+	gridPath := newPathOrDie("gs://bucket/grid")
+	quarantinePath := newPathOrDie("gs://bucket/grid.quarantine")
+	candidate, err := state.Marshal(guardGrid(guardColumn{build: "2", passed: []string{"hello"}}))
+	if err != nil {
+		t.Fatalf("Marshal() got unexpected error: %v", err)
+	}
+	now := time.Now()
+	cases := []struct {
+		name     string
+		opener   fake.Opener
+		uploader fake.Uploader
+		stater   fake.Stater
+		want     fake.Uploader
+		err      bool
+	}{
+		{
+			name: "basically works",
+			opener: fake.Opener{
+				quarantinePath: {Data: string(candidate)},
+			},
+			uploader: fake.Uploader{
+				gridPath:       {Buf: []byte("old"), Generation: 1},
+				quarantinePath: {Buf: candidate, Generation: 3},
+			},
+			stater: fake.Stater{
+				gridPath:       {Attrs: storage.ObjectAttrs{Generation: 1, Updated: now.Add(-time.Minute)}},
+				quarantinePath: {Attrs: storage.ObjectAttrs{Generation: 3, Updated: now}},
+			},
+			want: fake.Uploader{
+				gridPath: {Buf: candidate, Generation: 2},
+			},
+		},
+		{
+			name: "accept when the grid is missing",
+			opener: fake.Opener{
+				quarantinePath: {Data: string(candidate)},
+			},
+			uploader: fake.Uploader{
+				quarantinePath: {Buf: candidate, Generation: 3},
+			},
+			stater: fake.Stater{
+				quarantinePath: {Attrs: storage.ObjectAttrs{Generation: 3, Updated: now}},
+			},
+			want: fake.Uploader{
+				gridPath: {Buf: candidate, Generation: 1},
+			},
+		},
+		{
+			name: "nothing quarantined",
+			uploader: fake.Uploader{
+				gridPath: {Buf: []byte("old"), Generation: 1},
+			},
+			stater: fake.Stater{
+				gridPath: {Attrs: storage.ObjectAttrs{Generation: 1, Updated: now}},
+			},
+			err: true,
+		},
+		{
+			name: "refuse stale candidates",
+			opener: fake.Opener{
+				quarantinePath: {Data: string(candidate)},
+			},
+			uploader: fake.Uploader{
+				gridPath:       {Buf: []byte("newer"), Generation: 1},
+				quarantinePath: {Buf: candidate, Generation: 3},
+			},
+			stater: fake.Stater{
+				gridPath:       {Attrs: storage.ObjectAttrs{Generation: 1, Updated: now}},
+				quarantinePath: {Attrs: storage.ObjectAttrs{Generation: 3, Updated: now.Add(-time.Minute)}},
+			},
+			err: true,
+		},
+		{
+			name: "refuse corrupt candidates",
+			opener: fake.Opener{
+				quarantinePath: {Data: "garbage"},
+			},
+			uploader: fake.Uploader{
+				gridPath:       {Buf: []byte("old"), Generation: 1},
+				quarantinePath: {Buf: []byte("garbage"), Generation: 3},
+			},
+			stater: fake.Stater{
+				gridPath:       {Attrs: storage.ObjectAttrs{Generation: 1, Updated: now.Add(-time.Minute)}},
+				quarantinePath: {Attrs: storage.ObjectAttrs{Generation: 3, Updated: now}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client:   fake.Client{Opener: tc.opener},
+					Uploader: tc.uploader,
+					Stater:   tc.stater,
+				},
+			}
+			err := AcceptQuarantine(context.Background(), client, gridPath)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("AcceptQuarantine() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Error("AcceptQuarantine() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, tc.uploader, cmp.AllowUnexported(gcs.Path{})); diff != "" {
+				t.Errorf("AcceptQuarantine() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
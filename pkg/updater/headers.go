@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+type headerTransform func(string) string
+
+// headerTransforms holds the transforms of each column header.
+type headerTransforms [][]headerTransform
+
+// newHeaderTransforms compiles the transforms of each column header.
+//
+// Invalid transforms, which config validation rejects, are ignored.
+func newHeaderTransforms(headers []*configpb.TestGroup_ColumnHeader) headerTransforms {
+	var out headerTransforms
+	var found bool
+	for _, h := range headers {
+		var transforms []headerTransform
+		for _, t := range h.Transforms {
+			if fn := newHeaderTransform(t); fn != nil {
+				transforms = append(transforms, fn)
+			}
+		}
+		if len(transforms) > 0 {
+			found = true
+		}
+		out = append(out, transforms)
+	}
+	if !found {
+		return nil
+	}
+	return out
+}
+
+func newHeaderTransform(t *configpb.TestGroup_ColumnHeader_Transform) headerTransform {
+	switch tt := t.Transform.(type) {
+	case *configpb.TestGroup_ColumnHeader_Transform_RegexCapture:
+		re, err := regexp.Compile(tt.RegexCapture)
+		if err != nil {
+			return nil
+		}
+		return func(val string) string { return regexCapture(re, val) }
+	case *configpb.TestGroup_ColumnHeader_Transform_Strftime:
+		if tt.Strftime == "" {
+			return nil
+		}
+		layout := FormatStrftime(tt.Strftime)
+		return func(val string) string { return reformatTime(layout, val) }
+	case *configpb.TestGroup_ColumnHeader_Transform_Truncate:
+		if tt.Truncate <= 0 {
+			return nil
+		}
+		n := int(tt.Truncate)
+		return func(val string) string { return truncate(n, val) }
+	case *configpb.TestGroup_ColumnHeader_Transform_RoundDigits:
+		if tt.RoundDigits < 0 {
+			return nil
+		}
+		digits := int(tt.RoundDigits)
+		return func(val string) string { return round(digits, val) }
+	}
+	return nil
+}
+
+// apply transforms the value of the idx-th header.
+func (ht headerTransforms) apply(idx int, val string) string {
+	if idx >= len(ht) {
+		return val
+	}
+	for _, fn := range ht[idx] {
+		val = fn(val)
+	}
+	return val
+}
+
+// regexCapture returns the first capture group of the match, or the entire match.
+func regexCapture(re *regexp.Regexp, val string) string {
+	match := re.FindStringSubmatch(val)
+	switch {
+	case match == nil:
+		return val
+	case len(match) > 1:
+		return match[1]
+	default:
+		return match[0]
+	}
+}
+
+// reformatTime formats a unix seconds or RFC 3339 value with the layout.
+func reformatTime(layout, val string) string {
+	var when time.Time
+	if secs, err := strconv.ParseFloat(val, 64); err == nil {
+		whole, frac := math.Modf(secs)
+		when = time.Unix(int64(whole), int64(frac*1e9)).UTC()
+	} else if t, err := time.Parse(time.RFC3339, val); err == nil {
+		when = t
+	} else {
+		return val
+	}
+	return when.Format(layout)
+}
+
+// truncate returns at most the first n characters of the value.
+func truncate(n int, val string) string {
+	runes := []rune(val)
+	if len(runes) <= n {
+		return val
+	}
+	return string(runes[:n])
+}
+
+// round rounds a numeric value to the number of decimal places.
+func round(digits int, val string) string {
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return val
+	}
+	return strconv.FormatFloat(f, 'f', digits, 64)
+}
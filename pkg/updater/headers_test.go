@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+func TestHeaderTransforms(t *testing.T) {
+	regex := func(re string) *configpb.TestGroup_ColumnHeader_Transform {
+		return &configpb.TestGroup_ColumnHeader_Transform{
+			Transform: &configpb.TestGroup_ColumnHeader_Transform_RegexCapture{RegexCapture: re},
+		}
+	}
+	strftime := func(f string) *configpb.TestGroup_ColumnHeader_Transform {
+		return &configpb.TestGroup_ColumnHeader_Transform{
+			Transform: &configpb.TestGroup_ColumnHeader_Transform_Strftime{Strftime: f},
+		}
+	}
+	trunc := func(n int32) *configpb.TestGroup_ColumnHeader_Transform {
+		return &configpb.TestGroup_ColumnHeader_Transform{
+			Transform: &configpb.TestGroup_ColumnHeader_Transform_Truncate{Truncate: n},
+		}
+	}
+	round := func(n int32) *configpb.TestGroup_ColumnHeader_Transform {
+		return &configpb.TestGroup_ColumnHeader_Transform{
+			Transform: &configpb.TestGroup_ColumnHeader_Transform_RoundDigits{RoundDigits: n},
+		}
+	}
+	cases := []struct {
+		name       string
+		transforms []*configpb.TestGroup_ColumnHeader_Transform
+		value      string
+		want       string
+	}{
+		{
+			name:  "basically works",
+			value: "hello",
+			want:  "hello",
+		},
+		{
+			name:       "capture group",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{regex(`^(.*\+[0-9a-f]{6})`)},
+			value:      "v1.30.0-alpha.2+abc123def4567890",
+			want:       "v1.30.0-alpha.2+abc123",
+		},
+		{
+			name:       "entire match",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{regex(`[0-9a-f]{7}`)},
+			value:      "commit 0123456789abcdef",
+			want:       "0123456",
+		},
+		{
+			name:       "no match",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{regex(`^v\d`)},
+			value:      "master",
+			want:       "master",
+		},
+		{
+			name:       "reformat unix seconds",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{strftime("%Y-%m-%d %H:%M")},
+			value:      "1614816000",
+			want:       "2021-03-04 00:00",
+		},
+		{
+			name:       "reformat RFC 3339",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{strftime("%m/%d")},
+			value:      "2021-03-04T05:06:07Z",
+			want:       "03/04",
+		},
+		{
+			name:       "leave non-timestamps",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{strftime("%m/%d")},
+			value:      "yesterday",
+			want:       "yesterday",
+		},
+		{
+			name:       "truncate",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{trunc(7)},
+			value:      "0123456789abcdef",
+			want:       "0123456",
+		},
+		{
+			name:       "round",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{round(1)},
+			value:      "3.14159",
+			want:       "3.1",
+		},
+		{
+			name:       "leave non-numbers",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{round(1)},
+			value:      "pi",
+			want:       "pi",
+		},
+		{
+			name:       "apply in order",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{regex(`\+(.*)`), trunc(3)},
+			value:      "v1.2.3+abcdef",
+			want:       "abc",
+		},
+		{
+			name:       "ignore invalid transforms",
+			transforms: []*configpb.TestGroup_ColumnHeader_Transform{regex(`(`), trunc(0), {}},
+			value:      "hello",
+			want:       "hello",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := []*configpb.TestGroup_ColumnHeader{
+				{ConfigurationValue: "other"},
+				{ConfigurationValue: "value", Transforms: tc.transforms},
+			}
+			ht := newHeaderTransforms(headers)
+			if got := ht.apply(1, tc.value); got != tc.want {
+				t.Errorf("apply(%q) got %q, want %q", tc.value, got, tc.want)
+			}
+			if got := ht.apply(0, tc.value); got != tc.value {
+				t.Errorf("apply(%q) to other header got %q, want unchanged", tc.value, got)
+			}
+		})
+	}
+}
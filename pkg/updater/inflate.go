@@ -18,6 +18,7 @@ package updater
 
 import (
 	"context"
+	"math"
 	"time"
 
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
@@ -36,6 +37,20 @@ type InflatedColumn struct {
 	Cells map[string]Cell
 }
 
+// ColumnStarted returns when the column started, which the grid stores in
+// milliseconds since the epoch.
+func ColumnStarted(col *statepb.Column) time.Time {
+	return time.Unix(0, int64(col.Started*float64(time.Millisecond)))
+}
+
+// SecondsTime returns the time of seconds since the epoch, or the zero time for zero.
+func SecondsTime(seconds float64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
 // Cell holds a row's values for a given column
 type Cell struct {
 	// Result determines the color of the cell, defaulting to NO_RESULT (clear)
@@ -52,6 +67,10 @@ type Cell struct {
 	Icon string
 	// Message is a longer string that appears on mouse-over
 	Message string
+	// FullMessage is the untruncated message, when Message is truncated.
+	//
+	// The updater moves it into the message store and links the cell to it.
+	FullMessage string
 
 	// Metrics holds numerical data, such as how long it ran, coverage, etc.
 	Metrics map[string]float64
@@ -59,6 +78,15 @@ type Cell struct {
 	// UserProperty holds the value of a user-defined property, which allows
 	// runtime flexibility in generating links to click on.
 	UserProperty string
+
+	// Properties holds the values of the configured cell properties.
+	Properties map[string]string
+
+	// Links holds artifacts associated with the cell, such as attachments.
+	Links []*statepb.Link
+
+	// Parameters holds the result of each parameterization folded into the cell.
+	Parameters []*statepb.ParameterResult
 }
 
 // inflateGrid inflates the grid's rows into an InflatedColumn channel.
@@ -100,6 +128,27 @@ func inflateGrid(grid *statepb.Grid, earliest, latest time.Time) []InflatedColum
 	return cols
 }
 
+// carryOlderBuilds adds the columns of the grid which started before stop and
+// were dropped from the sorted cols, along with the older builds they counted,
+// to the older builds of the oldest remaining column.
+func carryOlderBuilds(grid *statepb.Grid, cols []InflatedColumn, stop time.Time) {
+	if len(cols) == 0 {
+		return
+	}
+	kept := make(map[*statepb.Column]bool, len(cols))
+	for _, col := range cols {
+		kept[col.Column] = true
+	}
+	var older int32
+	for _, col := range grid.Columns {
+		if kept[col] || int64(col.Started/1000) >= stop.Unix() {
+			continue
+		}
+		older += 1 + col.OlderBuilds
+	}
+	cols[len(cols)-1].Column.OlderBuilds += older
+}
+
 // inflateRow inflates the values for each column into a Cell channel.
 func inflateRow(parent context.Context, row *statepb.Row) <-chan Cell {
 	out := make(chan Cell)
@@ -143,6 +192,21 @@ func inflateRow(parent context.Context, row *statepb.Row) <-chan Cell {
 				if n := len(row.UserProperty); n > filledIdx {
 					c.UserProperty = row.UserProperty[filledIdx]
 				}
+				if n := len(row.CellLinks); n > filledIdx {
+					c.Links = row.CellLinks[filledIdx].GetLinks()
+				}
+				if n := len(row.CellParameters); n > filledIdx {
+					c.Parameters = row.CellParameters[filledIdx].GetResults()
+				}
+				for _, prop := range row.Properties {
+					if len(prop.Values) <= filledIdx || prop.Values[filledIdx] == "" {
+						continue
+					}
+					if c.Properties == nil {
+						c.Properties = map[string]string{}
+					}
+					c.Properties[prop.Name] = prop.Values[filledIdx]
+				}
 				filledIdx++
 			}
 			select {
@@ -209,3 +273,32 @@ func inflateResults(ctx context.Context, results []int32) <-chan statuspb.TestSt
 	}()
 	return out
 }
+
+// ParameterResults returns the parameter results folded into the cell at the
+// specified column of the row, if any.
+func ParameterResults(row *statepb.Row, col int) []*statepb.ParameterResult {
+	var idx, filledIdx int
+	for i := 0; i+1 < len(row.Results); i += 2 {
+		val, n := statuspb.TestStatus(row.Results[i]), int(row.Results[i+1])
+		if col < idx+n {
+			if val == statuspb.TestStatus_NO_RESULT {
+				return nil
+			}
+			filledIdx += col - idx
+			if filledIdx >= len(row.CellParameters) {
+				return nil
+			}
+			return row.CellParameters[filledIdx].GetResults()
+		}
+		idx += n
+		if val != statuspb.TestStatus_NO_RESULT {
+			filledIdx += n
+		}
+	}
+	return nil
+}
+
+// InflateGrid returns every column of the grid along with the cells of each row.
+func InflateGrid(grid *statepb.Grid) []InflatedColumn {
+	return inflateGrid(grid, time.Time{}, time.Unix(math.MaxInt64/int64(time.Second), 0))
+}
@@ -43,6 +43,44 @@ func blank(n int) []string {
 	return out
 }
 
+func TestColumnStarted(t *testing.T) {
+	when := time.Unix(1600000000, 250*int64(time.Millisecond))
+	col := &statepb.Column{Started: 1600000000250}
+	if got := ColumnStarted(col).Round(time.Millisecond); !got.Equal(when) {
+		t.Errorf("ColumnStarted() got %v, want %v", got, when)
+	}
+}
+
+func TestSecondsTime(t *testing.T) {
+	cases := []struct {
+		name    string
+		seconds float64
+		want    time.Time
+	}{
+		{
+			name: "zero",
+		},
+		{
+			name:    "whole seconds",
+			seconds: 1600000000,
+			want:    time.Unix(1600000000, 0),
+		},
+		{
+			name:    "fractional seconds",
+			seconds: 1600000000.5,
+			want:    time.Unix(1600000000, 500*int64(time.Millisecond)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SecondsTime(tc.seconds); !got.Equal(tc.want) {
+				t.Errorf("SecondsTime(%v) got %v, want %v", tc.seconds, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestInflateGrid(t *testing.T) {
 	var hours []time.Time
 	when := time.Now().Round(time.Hour)
@@ -541,6 +579,60 @@ func TestInflateGrid(t *testing.T) {
 	}
 }
 
+func TestCarryOlderBuilds(t *testing.T) {
+	stop := time.Unix(100, 0)
+	cases := []struct {
+		name    string
+		grid    []*statepb.Column
+		dropped map[string]bool
+		want    int32
+	}{
+		{
+			name: "nothing dropped",
+			grid: []*statepb.Column{
+				{Build: "new", Started: 200000},
+				{Build: "old", Started: 50000, OlderBuilds: 3},
+			},
+			want: 3,
+		},
+		{
+			name: "count dropped columns",
+			grid: []*statepb.Column{
+				{Build: "new", Started: 200000},
+				{Build: "old", Started: 90000},
+				{Build: "older", Started: 80000},
+				{Build: "oldest", Started: 70000, OlderBuilds: 5},
+			},
+			dropped: map[string]bool{"older": true, "oldest": true},
+			want:    7,
+		},
+		{
+			name: "ignore recent dropped columns",
+			grid: []*statepb.Column{
+				{Build: "running", Started: 300000},
+				{Build: "new", Started: 200000},
+			},
+			dropped: map[string]bool{"running": true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid := statepb.Grid{Columns: tc.grid}
+			var cols []InflatedColumn
+			for _, col := range tc.grid {
+				if !tc.dropped[col.Build] {
+					cols = append(cols, InflatedColumn{Column: col})
+				}
+			}
+			carryOlderBuilds(&grid, cols, stop)
+			if got := cols[len(cols)-1].Column.OlderBuilds; got != tc.want {
+				t.Errorf("carryOlderBuilds() got %d older builds, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestInflateRow(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -806,3 +898,62 @@ func TestInflateResults(t *testing.T) {
 		})
 	}
 }
+
+func TestParameterResults(t *testing.T) {
+	a := &statepb.ParameterResult{Parameters: "[a]", Result: int32(statuspb.TestStatus_PASS)}
+	b := &statepb.ParameterResult{Parameters: "[b]", Result: int32(statuspb.TestStatus_FAIL)}
+	row := &statepb.Row{
+		Results: []int32{
+			int32(statuspb.TestStatus_FAIL), 1,
+			int32(statuspb.TestStatus_NO_RESULT), 2,
+			int32(statuspb.TestStatus_PASS), 2,
+		},
+		CellParameters: []*statepb.CellParameters{
+			{Results: []*statepb.ParameterResult{a, b}},
+			{},
+			{Results: []*statepb.ParameterResult{a}},
+		},
+	}
+
+	cases := []struct {
+		name string
+		row  *statepb.Row
+		col  int
+		want []*statepb.ParameterResult
+	}{
+		{
+			name: "basically works",
+			row:  &statepb.Row{},
+		},
+		{
+			name: "first column",
+			row:  row,
+			want: []*statepb.ParameterResult{a, b},
+		},
+		{
+			name: "empty column",
+			row:  row,
+			col:  2,
+		},
+		{
+			name: "skip empty columns",
+			row:  row,
+			col:  4,
+			want: []*statepb.ParameterResult{a},
+		},
+		{
+			name: "past the end",
+			row:  row,
+			col:  5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ParameterResults(tc.row, tc.col)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ParameterResults() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
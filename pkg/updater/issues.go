@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"regexp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+type issueRule struct {
+	re  *regexp.Regexp
+	url string
+}
+
+type issueLinker []issueRule
+
+// newIssueLinker compiles the configured issue link rules.
+//
+// Invalid rules, which config validation rejects, are ignored.
+func newIssueLinker(rules []*configpb.IssueLinkRule) issueLinker {
+	var l issueLinker
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil || rule.Url == "" {
+			continue
+		}
+		l = append(l, issueRule{re: re, url: rule.Url})
+	}
+	return l
+}
+
+// links returns a link for each distinct issue referenced by the message.
+func (l issueLinker) links(msg string) []*statepb.Link {
+	if msg == "" {
+		return nil
+	}
+	var out []*statepb.Link
+	seen := map[string]bool{}
+	for _, rule := range l {
+		for _, match := range rule.re.FindAllStringSubmatchIndex(msg, -1) {
+			url := string(rule.re.ExpandString(nil, rule.url, msg, match))
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+			out = append(out, &statepb.Link{
+				Name: msg[match[0]:match[1]],
+				Url:  url,
+			})
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestIssueLinks(t *testing.T) {
+	rules := []*configpb.IssueLinkRule{
+		{Regex: `#(\d+)`, Url: "https://github.com/org/repo/issues/${1}"},
+		{Regex: `\bb/(\d+)`, Url: "https://tracker.example.com/${1}"},
+		{Regex: `bad(`, Url: "https://ignored"},
+		{Regex: `ignored`},
+	}
+	cases := []struct {
+		name  string
+		rules []*configpb.IssueLinkRule
+		msg   string
+		want  []*statepb.Link
+	}{
+		{
+			name: "basically works",
+			msg:  "see #123",
+		},
+		{
+			name:  "no references",
+			rules: rules,
+			msg:   "this is ignored, not bad(",
+		},
+		{
+			name:  "link each distinct reference",
+			rules: rules,
+			msg:   "flake tracked in #123 and b/456, also #123 and #7",
+			want: []*statepb.Link{
+				{Name: "#123", Url: "https://github.com/org/repo/issues/123"},
+				{Name: "#7", Url: "https://github.com/org/repo/issues/7"},
+				{Name: "b/456", Url: "https://tracker.example.com/456"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newIssueLinker(tc.rules).links(tc.msg)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("links() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
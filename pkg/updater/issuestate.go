@@ -0,0 +1,257 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/testgrid/internal/result"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	issuepb "github.com/GoogleCloudPlatform/testgrid/pb/issue_state"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const issueStateSuffix = ".issues"
+
+// IssueStatePath returns the path to the issues associated with the rows of a grid.
+func IssueStatePath(gridPath gcs.Path) (*gcs.Path, error) {
+	return gcs.NewPath(gridPath.String() + issueStateSuffix)
+}
+
+// ReadIssueState returns the issue state at the path along with its generation.
+//
+// Returns an empty state and zero generation when the path does not exist.
+func ReadIssueState(ctx context.Context, client gcs.Client, path gcs.Path) (*issuepb.IssueState, int64, error) {
+	var state issuepb.IssueState
+	attrs, err := client.Stat(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return &state, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("stat: %w", err)
+	}
+	r, err := client.Open(ctx, path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read: %w", err)
+	}
+	if err := proto.Unmarshal(buf, &state); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &state, attrs.Generation, nil
+}
+
+// UpdateIssueState writes the issue state when update changes it.
+//
+// Retries when the state changes concurrently.
+func UpdateIssueState(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, update func(*issuepb.IssueState) bool) (*issuepb.IssueState, error) {
+	const attempts = 3
+	for i := 0; ; i++ {
+		state, generation, err := ReadIssueState(ctx, client, path)
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		if !update(state) {
+			return state, nil
+		}
+		buf, err := proto.Marshal(state)
+		if err != nil {
+			return nil, fmt.Errorf("marshal: %w", err)
+		}
+		cond := storage.Conditions{GenerationMatch: generation}
+		if generation == 0 {
+			cond = storage.Conditions{DoesNotExist: true}
+		}
+		err = client.If(nil, &cond).Upload(ctx, path, buf, gcs.DefaultACL, "no-cache")
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed && i+1 < attempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("upload: %w", err)
+		}
+		return state, nil
+	}
+}
+
+// AttachIssue associates the issue with the rows, returning true if this changes the state.
+func AttachIssue(state *issuepb.IssueState, issueID string, rows ...string) bool {
+	var info *issuepb.IssueInfo
+	for _, ii := range state.IssueInfo {
+		if ii.IssueId == issueID {
+			info = ii
+			break
+		}
+	}
+	if info == nil {
+		info = &issuepb.IssueInfo{IssueId: issueID}
+		state.IssueInfo = append(state.IssueInfo, info)
+	}
+	var changed bool
+	for _, row := range rows {
+		var found bool
+		for _, id := range info.RowIds {
+			if id == row {
+				found = true
+				break
+			}
+		}
+		if !found {
+			info.RowIds = append(info.RowIds, row)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// DetachIssue removes the association between the issue and the rows,
+// returning true if this changes the state.
+//
+// Issues no longer associated with any row are removed.
+func DetachIssue(state *issuepb.IssueState, issueID string, rows ...string) bool {
+	detach := map[string]bool{}
+	for _, row := range rows {
+		detach[row] = true
+	}
+	var changed bool
+	infos := state.IssueInfo[:0]
+	for _, info := range state.IssueInfo {
+		if info.IssueId == issueID {
+			ids := info.RowIds[:0]
+			for _, id := range info.RowIds {
+				if detach[id] {
+					changed = true
+					continue
+				}
+				ids = append(ids, id)
+			}
+			info.RowIds = ids
+		}
+		if len(info.RowIds) == 0 {
+			changed = true
+			continue
+		}
+		infos = append(infos, info)
+	}
+	state.IssueInfo = infos
+	return changed
+}
+
+// recentlyPassing returns true when the row's n most recent results pass.
+func recentlyPassing(row *statepb.Row, n int) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var passes int
+	for res := range result.Iter(ctx, row.Results) {
+		if res == statuspb.TestStatus_NO_RESULT {
+			continue
+		}
+		if !result.Passing(res) {
+			return false
+		}
+		passes++
+		if passes >= n {
+			return true
+		}
+	}
+	return false
+}
+
+// clearPassingIssues detaches issues from rows whose n most recent results
+// pass, returning true if this changes the state.
+func clearPassingIssues(state *issuepb.IssueState, grid *statepb.Grid, n int) bool {
+	if n <= 0 {
+		return false
+	}
+	passing := map[string]bool{}
+	for _, row := range grid.Rows {
+		if recentlyPassing(row, n) {
+			passing[row.Name] = true
+		}
+	}
+	var changed bool
+	for _, info := range append([]*issuepb.IssueInfo(nil), state.IssueInfo...) {
+		var rows []string
+		for _, id := range info.RowIds {
+			if passing[id] {
+				rows = append(rows, id)
+			}
+		}
+		if len(rows) > 0 && DetachIssue(state, info.IssueId, rows...) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// applyIssues sets the bug IDs of each row to the issues associated with it.
+func applyIssues(state *issuepb.IssueState, grid *statepb.Grid) {
+	bugs := map[string][]string{}
+	for _, info := range state.IssueInfo {
+		for _, id := range info.RowIds {
+			bugs[id] = append(bugs[id], info.IssueId)
+		}
+	}
+	for _, row := range grid.Rows {
+		row.BugId = bugs[row.Name]
+		sort.Strings(row.BugId)
+	}
+}
+
+// associateIssues sets the bug IDs of the grid's rows from the group's issue state.
+//
+// Detaches issues from rows which pass clear_issues_after_passes times in a
+// row, provided the client supports conditional writes.
+func associateIssues(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, grid *statepb.Grid, write bool) error {
+	path, err := IssueStatePath(gridPath)
+	if err != nil {
+		return fmt.Errorf("issue state path: %w", err)
+	}
+	var state *issuepb.IssueState
+	cc, conditional := client.(gcs.ConditionalClient)
+	if n := int(tg.ClearIssuesAfterPasses); write && conditional && n > 0 {
+		state, err = UpdateIssueState(ctx, cc, *path, func(state *issuepb.IssueState) bool {
+			return clearPassingIssues(state, grid, n)
+		})
+	} else {
+		state, _, err = ReadIssueState(ctx, client, *path)
+	}
+	if err != nil {
+		return err
+	}
+	if len(state.IssueInfo) > 0 {
+		log.WithField("issues", len(state.IssueInfo)).Debug("Associating issues")
+	}
+	applyIssues(state, grid)
+	return nil
+}
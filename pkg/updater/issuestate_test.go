@@ -0,0 +1,321 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	issuepb "github.com/GoogleCloudPlatform/testgrid/pb/issue_state"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestAttachIssue(t *testing.T) {
+	cases := []struct {
+		name        string
+		state       *issuepb.IssueState
+		issue       string
+		rows        []string
+		want        *issuepb.IssueState
+		wantChanged bool
+	}{
+		{
+			name:        "basically works",
+			state:       &issuepb.IssueState{},
+			issue:       "bug",
+			rows:        []string{"foo"},
+			want:        &issuepb.IssueState{IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo"}}}},
+			wantChanged: true,
+		},
+		{
+			name:  "add rows to existing issue",
+			state: &issuepb.IssueState{IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo"}}}},
+			issue: "bug",
+			rows:  []string{"foo", "bar"},
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo", "bar"}}},
+			},
+			wantChanged: true,
+		},
+		{
+			name:  "already attached",
+			state: &issuepb.IssueState{IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo"}}}},
+			issue: "bug",
+			rows:  []string{"foo"},
+			want:  &issuepb.IssueState{IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo"}}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if changed := AttachIssue(tc.state, tc.issue, tc.rows...); changed != tc.wantChanged {
+				t.Errorf("AttachIssue() got changed %t, want %t", changed, tc.wantChanged)
+			}
+			if diff := cmp.Diff(tc.want, tc.state, protocmp.Transform()); diff != "" {
+				t.Errorf("AttachIssue() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDetachIssue(t *testing.T) {
+	cases := []struct {
+		name        string
+		state       *issuepb.IssueState
+		issue       string
+		rows        []string
+		want        *issuepb.IssueState
+		wantChanged bool
+	}{
+		{
+			name:  "basically works",
+			state: &issuepb.IssueState{},
+			issue: "bug",
+			rows:  []string{"foo"},
+			want:  &issuepb.IssueState{},
+		},
+		{
+			name: "detach some rows",
+			state: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{
+					{IssueId: "bug", RowIds: []string{"foo", "bar"}},
+					{IssueId: "other", RowIds: []string{"foo"}},
+				},
+			},
+			issue: "bug",
+			rows:  []string{"foo"},
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{
+					{IssueId: "bug", RowIds: []string{"bar"}},
+					{IssueId: "other", RowIds: []string{"foo"}},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "remove issues without rows",
+			state: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{
+					{IssueId: "bug", RowIds: []string{"foo"}},
+					{IssueId: "other", RowIds: []string{"foo"}},
+				},
+			},
+			issue: "bug",
+			rows:  []string{"foo"},
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "other", RowIds: []string{"foo"}}},
+			},
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if changed := DetachIssue(tc.state, tc.issue, tc.rows...); changed != tc.wantChanged {
+				t.Errorf("DetachIssue() got changed %t, want %t", changed, tc.wantChanged)
+			}
+			if diff := cmp.Diff(tc.want, tc.state, protocmp.Transform(), protocmp.IgnoreEmptyMessages()); diff != "" {
+				t.Errorf("DetachIssue() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClearPassingIssues(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "fixed", Results: []int32{pass, 2, empty, 1, fail, 1}},
+			{Name: "flaky", Results: []int32{pass, 1, fail, 1, pass, 1}},
+			{Name: "broken", Results: []int32{fail, 3}},
+		},
+	}
+	state := func() *issuepb.IssueState {
+		return &issuepb.IssueState{
+			IssueInfo: []*issuepb.IssueInfo{
+				{IssueId: "fixed-bug", RowIds: []string{"fixed"}},
+				{IssueId: "shared-bug", RowIds: []string{"fixed", "flaky", "broken"}},
+			},
+		}
+	}
+	cases := []struct {
+		name        string
+		n           int
+		want        *issuepb.IssueState
+		wantChanged bool
+	}{
+		{
+			name: "disabled",
+			want: state(),
+		},
+		{
+			name: "clear recently passing rows",
+			n:    2,
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{
+					{IssueId: "shared-bug", RowIds: []string{"flaky", "broken"}},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "clear more rows",
+			n:    1,
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{
+					{IssueId: "shared-bug", RowIds: []string{"broken"}},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "not enough passes",
+			n:    3,
+			want: state(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := state()
+			if changed := clearPassingIssues(got, grid, tc.n); changed != tc.wantChanged {
+				t.Errorf("clearPassingIssues() got changed %t, want %t", changed, tc.wantChanged)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("clearPassingIssues() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyIssues(t *testing.T) {
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "foo", BugId: []string{"stale"}},
+			{Name: "bar"},
+		},
+	}
+	state := &issuepb.IssueState{
+		IssueInfo: []*issuepb.IssueInfo{
+			{IssueId: "b", RowIds: []string{"foo"}},
+			{IssueId: "a", RowIds: []string{"foo", "bar"}},
+		},
+	}
+	applyIssues(state, grid)
+	want := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{Name: "foo", BugId: []string{"a", "b"}},
+			{Name: "bar", BugId: []string{"a"}},
+		},
+	}
+	if diff := cmp.Diff(want, grid, protocmp.Transform()); diff != "" {
+		t.Errorf("applyIssues() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateIssueState(t *testing.T) {
+	path, err := gcs.NewPath("gs://bucket/grid/group.issues")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	cases := []struct {
+		name     string
+		state    *issuepb.IssueState
+		want     *issuepb.IssueState
+		wantSave bool
+	}{
+		{
+			name: "create state",
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"bar"}}},
+			},
+			wantSave: true,
+		},
+		{
+			name: "update existing state",
+			state: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo"}}},
+			},
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"foo", "bar"}}},
+			},
+			wantSave: true,
+		},
+		{
+			name: "unchanged state",
+			state: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"bar"}}},
+			},
+			want: &issuepb.IssueState{
+				IssueInfo: []*issuepb.IssueInfo{{IssueId: "bug", RowIds: []string{"bar"}}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.ConditionalClient{
+				UploadClient: fake.UploadClient{
+					Client:   fake.Client{Opener: fake.Opener{}},
+					Uploader: fake.Uploader{},
+					Stater:   fake.Stater{},
+				},
+			}
+			if tc.state != nil {
+				buf, err := proto.Marshal(tc.state)
+				if err != nil {
+					t.Fatalf("proto.Marshal(): %v", err)
+				}
+				client.Opener[*path] = fake.Object{Data: string(buf)}
+				client.Stater[*path] = fake.Stat{Attrs: storage.ObjectAttrs{Generation: 7}}
+			}
+			got, err := UpdateIssueState(context.Background(), client, *path, func(state *issuepb.IssueState) bool {
+				return AttachIssue(state, "bug", "bar")
+			})
+			if err != nil {
+				t.Fatalf("UpdateIssueState() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("UpdateIssueState() got unexpected diff (-want +got):\n%s", diff)
+			}
+			u, saved := client.Uploader[*path]
+			if saved != tc.wantSave {
+				t.Fatalf("UpdateIssueState() saved %t, want %t", saved, tc.wantSave)
+			}
+			if !saved {
+				return
+			}
+			var gotSave issuepb.IssueState
+			if err := proto.Unmarshal(u.Buf, &gotSave); err != nil {
+				t.Fatalf("proto.Unmarshal(): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, &gotSave, protocmp.Transform()); diff != "" {
+				t.Errorf("UpdateIssueState() saved unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const (
+	messageStoreSuffix = ".messages"
+
+	// inlineMessageBytes is the most of a message stored inline in the grid.
+	inlineMessageBytes = 140
+	// maxFullMessageBytes is the most of a message stored in the message store.
+	maxFullMessageBytes = junit.MaxMessageBytes
+
+	// FullMessageLink names the cell link to its full message.
+	FullMessageLink = "full message"
+	// FullMessageScheme prefixes the hash in the URL of a FullMessageLink.
+	FullMessageScheme = "sha256:"
+)
+
+// MessageStorePath returns the path to the full messages of the cells of a grid.
+func MessageStorePath(gridPath gcs.Path) (*gcs.Path, error) {
+	return gcs.NewPath(gridPath.String() + messageStoreSuffix)
+}
+
+// MessageHash returns the key of the message in a message store.
+func MessageHash(msg string) string {
+	sum := sha256.Sum256([]byte(msg))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetMessage stores as much of the message inline as the grid allows, keeping
+// the untruncated message in FullMessage when it does not fit.
+//
+// The message function returns the message truncated to max bytes, or all of
+// it for zero, such as junit.Result.Message or TextMessage.
+func (c *Cell) SetMessage(message func(max int) string) {
+	c.Message = message(inlineMessageBytes)
+	if full := message(0); full != c.Message {
+		c.FullMessage = full
+	}
+}
+
+// TextMessage returns the message function of text, which truncates it to its
+// first max bytes.
+func TextMessage(text string) func(max int) string {
+	return func(max int) string {
+		if max <= 0 || len(text) <= max {
+			return text
+		}
+		return strings.ToValidUTF8(text[:max], "")
+	}
+}
+
+// FullMessageHash returns the hash of the cell's full message from its links.
+func FullMessageHash(links []*statepb.Link) (string, bool) {
+	for _, l := range links {
+		if l.Name == FullMessageLink && strings.HasPrefix(l.Url, FullMessageScheme) {
+			return strings.TrimPrefix(l.Url, FullMessageScheme), true
+		}
+	}
+	return "", false
+}
+
+// ReadMessageStore returns the message store at the path.
+//
+// Returns an empty store when the path does not exist.
+func ReadMessageStore(ctx context.Context, opener gcs.Opener, path gcs.Path) (*statepb.MessageStore, error) {
+	var store statepb.MessageStore
+	r, err := opener.Open(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return &store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	if err := proto.Unmarshal(buf, &store); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &store, nil
+}
+
+// spillMessages moves the full message of each cell into the returned
+// messages, linking the cell to it by hash.
+//
+// Full messages beyond maxFullMessageBytes are truncated.
+func spillMessages(cols []InflatedColumn) map[string]string {
+	messages := map[string]string{}
+	for _, col := range cols {
+		for name, cell := range col.Cells {
+			if cell.FullMessage == "" {
+				continue
+			}
+			msg := cell.FullMessage
+			if len(msg) > maxFullMessageBytes {
+				msg = strings.ToValidUTF8(msg[:maxFullMessageBytes], "")
+			}
+			hash := MessageHash(msg)
+			messages[hash] = msg
+			cell.FullMessage = ""
+			if _, ok := FullMessageHash(cell.Links); !ok {
+				cell.Links = append(cell.Links, &statepb.Link{Name: FullMessageLink, Url: FullMessageScheme + hash})
+			}
+			col.Cells[name] = cell
+		}
+	}
+	return messages
+}
+
+// gridMessageHashes returns the hashes of the full messages linked by the grid's cells.
+func gridMessageHashes(grid *statepb.Grid) map[string]bool {
+	hashes := map[string]bool{}
+	for _, row := range grid.Rows {
+		for _, cl := range row.CellLinks {
+			if hash, ok := FullMessageHash(cl.GetLinks()); ok {
+				hashes[hash] = true
+			}
+		}
+	}
+	return hashes
+}
+
+// updateMessageStore adds the new messages to the store and drops any which
+// the grid no longer links to, returning true if this changes the store.
+func updateMessageStore(store *statepb.MessageStore, grid *statepb.Grid, messages map[string]string) bool {
+	hashes := gridMessageHashes(grid)
+	var changed bool
+	for hash := range store.Messages {
+		if !hashes[hash] {
+			delete(store.Messages, hash)
+			changed = true
+		}
+	}
+	for hash, msg := range messages {
+		if !hashes[hash] {
+			continue
+		}
+		if _, ok := store.Messages[hash]; ok {
+			continue
+		}
+		if store.Messages == nil {
+			store.Messages = map[string]string{}
+		}
+		store.Messages[hash] = msg
+		changed = true
+	}
+	return changed
+}
+
+// writeMessageStore writes the full messages linked by the grid's cells
+// alongside it, when this changes the store.
+func writeMessageStore(ctx context.Context, client gcs.Client, gridPath gcs.Path, grid *statepb.Grid, messages map[string]string) error {
+	path, err := MessageStorePath(gridPath)
+	if err != nil {
+		return fmt.Errorf("message store path: %w", err)
+	}
+	store, err := ReadMessageStore(ctx, client, *path)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	if !updateMessageStore(store, grid, messages) {
+		return nil
+	}
+	buf, err := state.MarshalDeterministic(store)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if err := client.Upload(ctx, *path, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		return fmt.Errorf("upload: %w", err)
+	}
+	return nil
+}
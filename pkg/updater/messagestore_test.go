@@ -0,0 +1,329 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func fullMessageLink(msg string) *statepb.Link {
+	return &statepb.Link{Name: FullMessageLink, Url: FullMessageScheme + MessageHash(msg)}
+}
+
+func TestSetMessage(t *testing.T) {
+	long := strings.Repeat("x", inlineMessageBytes) + "\u00e9"
+	cases := []struct {
+		name    string
+		message func(max int) string
+		want    Cell
+	}{
+		{
+			name:    "empty",
+			message: TextMessage(""),
+		},
+		{
+			name:    "fits inline",
+			message: TextMessage("hello"),
+			want:    Cell{Message: "hello"},
+		},
+		{
+			name:    "truncate to valid utf8",
+			message: TextMessage(long[:inlineMessageBytes-1] + "\u00e9"),
+			want: Cell{
+				Message:     long[:inlineMessageBytes-1],
+				FullMessage: long[:inlineMessageBytes-1] + "\u00e9",
+			},
+		},
+		{
+			name:    "keep the full message",
+			message: TextMessage(long),
+			want:    Cell{Message: long[:inlineMessageBytes], FullMessage: long},
+		},
+		{
+			name: "custom truncation",
+			message: func(max int) string {
+				if max > 0 {
+					return "short"
+				}
+				return "long"
+			},
+			want: Cell{Message: "short", FullMessage: "long"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Cell
+			got.SetMessage(tc.message)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SetMessage() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSpillMessages(t *testing.T) {
+	long := strings.Repeat("x", maxFullMessageBytes+10)
+	capped := long[:maxFullMessageBytes]
+	cases := []struct {
+		name         string
+		cols         []InflatedColumn
+		want         []InflatedColumn
+		wantMessages map[string]string
+	}{
+		{
+			name:         "basically works",
+			wantMessages: map[string]string{},
+		},
+		{
+			name: "keep short messages inline",
+			cols: []InflatedColumn{
+				{Cells: map[string]Cell{"foo": {Message: "boom"}}},
+			},
+			want: []InflatedColumn{
+				{Cells: map[string]Cell{"foo": {Message: "boom"}}},
+			},
+			wantMessages: map[string]string{},
+		},
+		{
+			name: "spill full messages",
+			cols: []InflatedColumn{
+				{
+					Cells: map[string]Cell{
+						"foo": {Message: "boom...", FullMessage: "boom with a stack trace"},
+						"bar": {
+							Message:     "bang...",
+							FullMessage: "bang with a stack trace",
+							Links:       []*statepb.Link{{Name: "log", Url: "https://log"}},
+						},
+					},
+				},
+				{Cells: map[string]Cell{"foo": {Message: "boom...", FullMessage: "boom with a stack trace"}}},
+			},
+			want: []InflatedColumn{
+				{
+					Cells: map[string]Cell{
+						"foo": {
+							Message: "boom...",
+							Links:   []*statepb.Link{fullMessageLink("boom with a stack trace")},
+						},
+						"bar": {
+							Message: "bang...",
+							Links: []*statepb.Link{
+								{Name: "log", Url: "https://log"},
+								fullMessageLink("bang with a stack trace"),
+							},
+						},
+					},
+				},
+				{
+					Cells: map[string]Cell{
+						"foo": {
+							Message: "boom...",
+							Links:   []*statepb.Link{fullMessageLink("boom with a stack trace")},
+						},
+					},
+				},
+			},
+			wantMessages: map[string]string{
+				MessageHash("boom with a stack trace"): "boom with a stack trace",
+				MessageHash("bang with a stack trace"): "bang with a stack trace",
+			},
+		},
+		{
+			name: "cap huge messages",
+			cols: []InflatedColumn{
+				{Cells: map[string]Cell{"foo": {Message: "xxx...", FullMessage: long}}},
+			},
+			want: []InflatedColumn{
+				{
+					Cells: map[string]Cell{
+						"foo": {
+							Message: "xxx...",
+							Links:   []*statepb.Link{fullMessageLink(capped)},
+						},
+					},
+				},
+			},
+			wantMessages: map[string]string{MessageHash(capped): capped},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := spillMessages(tc.cols)
+			if diff := cmp.Diff(tc.wantMessages, got); diff != "" {
+				t.Errorf("spillMessages() got unexpected messages (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("spillMessages() got unexpected columns (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateMessageStore(t *testing.T) {
+	linked := func(hashes ...string) *statepb.Grid {
+		var row statepb.Row
+		for _, h := range hashes {
+			row.CellLinks = append(row.CellLinks, &statepb.CellLinks{
+				Links: []*statepb.Link{{Name: FullMessageLink, Url: FullMessageScheme + h}},
+			})
+		}
+		row.CellLinks = append(row.CellLinks, &statepb.CellLinks{})
+		return &statepb.Grid{Rows: []*statepb.Row{&row}}
+	}
+	cases := []struct {
+		name        string
+		store       *statepb.MessageStore
+		grid        *statepb.Grid
+		messages    map[string]string
+		want        *statepb.MessageStore
+		wantChanged bool
+	}{
+		{
+			name:  "basically works",
+			store: &statepb.MessageStore{},
+			grid:  &statepb.Grid{},
+			want:  &statepb.MessageStore{},
+		},
+		{
+			name:     "add linked messages",
+			store:    &statepb.MessageStore{},
+			grid:     linked("a"),
+			messages: map[string]string{"a": "hello"},
+			want: &statepb.MessageStore{
+				Messages: map[string]string{"a": "hello"},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "keep linked messages",
+			store: &statepb.MessageStore{
+				Messages: map[string]string{"a": "hello"},
+			},
+			grid:     linked("a"),
+			messages: map[string]string{"a": "hello"},
+			want: &statepb.MessageStore{
+				Messages: map[string]string{"a": "hello"},
+			},
+		},
+		{
+			name: "drop unlinked messages",
+			store: &statepb.MessageStore{
+				Messages: map[string]string{"a": "hello", "b": "world"},
+			},
+			grid:     linked("b", "c"),
+			messages: map[string]string{"c": "there", "d": "ignored"},
+			want: &statepb.MessageStore{
+				Messages: map[string]string{"b": "world", "c": "there"},
+			},
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := updateMessageStore(tc.store, tc.grid, tc.messages)
+			if changed != tc.wantChanged {
+				t.Errorf("updateMessageStore() got changed %t, want %t", changed, tc.wantChanged)
+			}
+			if diff := cmp.Diff(tc.want, tc.store, protocmp.Transform()); diff != "" {
+				t.Errorf("updateMessageStore() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteMessageStore(t *testing.T) {
+	gridPath, err := gcs.NewPath("gs://bucket/grid/group")
+	if err != nil {
+		t.Fatalf("gcs.NewPath(): %v", err)
+	}
+	storePath, err := MessageStorePath(*gridPath)
+	if err != nil {
+		t.Fatalf("MessageStorePath(): %v", err)
+	}
+	const msg = "boom with a stack trace"
+	grid := &statepb.Grid{
+		Rows: []*statepb.Row{
+			{
+				Name:      "foo",
+				CellLinks: []*statepb.CellLinks{{Links: []*statepb.Link{fullMessageLink(msg)}}},
+			},
+		},
+	}
+	messages := map[string]string{MessageHash(msg): msg}
+	want := &statepb.MessageStore{Messages: messages}
+	wantBuf, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	cases := []struct {
+		name      string
+		existing  fake.Opener
+		wantWrite bool
+	}{
+		{
+			name:      "write new stores",
+			existing:  fake.Opener{},
+			wantWrite: true,
+		},
+		{
+			name:     "skip unchanged stores",
+			existing: fake.Opener{*storePath: {Data: string(wantBuf)}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uploader := fake.Uploader{}
+			client := fake.UploadClient{
+				Client:   fake.Client{Opener: tc.existing},
+				Uploader: uploader,
+			}
+			if err := writeMessageStore(context.Background(), client, *gridPath, grid, messages); err != nil {
+				t.Fatalf("writeMessageStore() got unexpected error: %v", err)
+			}
+			upload, wrote := uploader[*storePath]
+			if wrote != tc.wantWrite {
+				t.Fatalf("writeMessageStore() wrote %t, want %t", wrote, tc.wantWrite)
+			}
+			if !wrote {
+				return
+			}
+			got, err := ReadMessageStore(context.Background(), fake.Opener{*storePath: {Data: string(upload.Buf)}}, *storePath)
+			if err != nil {
+				t.Fatalf("ReadMessageStore() got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("writeMessageStore() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// podPrefix selects podinfo.json fields rather than finished.json metadata.
+const podPrefix = "pod."
+
+// columnMetadata returns the allowed keys of the build's metadata.
+//
+// Missing keys are omitted.
+func columnMetadata(keys []string, meta map[string]string, podInfo gcs.PodInfo) map[string]string {
+	var out map[string]string
+	for _, key := range keys {
+		var val string
+		var ok bool
+		if strings.HasPrefix(key, podPrefix) {
+			val, ok = podMetadata(podInfo.Pod, strings.TrimPrefix(key, podPrefix))
+		} else {
+			val, ok = meta[key]
+		}
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string, len(keys))
+		}
+		out[key] = val
+	}
+	return out
+}
+
+// podMetadata returns the named field of the pod.
+func podMetadata(pod *core.Pod, field string) (string, bool) {
+	if pod == nil {
+		return "", false
+	}
+	switch {
+	case field == "node":
+		return pod.Spec.NodeName, pod.Spec.NodeName != ""
+	case field == "phase":
+		return string(pod.Status.Phase), pod.Status.Phase != ""
+	case field == "image":
+		if len(pod.Spec.Containers) == 0 {
+			return "", false
+		}
+		return pod.Spec.Containers[0].Image, true
+	case strings.HasPrefix(field, "label."):
+		val, ok := pod.Labels[strings.TrimPrefix(field, "label.")]
+		return val, ok
+	case strings.HasPrefix(field, "annotation."):
+		val, ok := pod.Annotations[strings.TrimPrefix(field, "annotation.")]
+		return val, ok
+	}
+	return "", false
+}
+
+// metadataViolations returns how the build's started.json and finished.json
+// deviate from the metadata schema, prefixed by the file.
+//
+// Files which are missing or fail to download are skipped, as reading the
+// result already handles them.
+func metadataViolations(ctx context.Context, opener gcs.Opener, build gcs.Build) []string {
+	var out []string
+	for _, f := range []struct {
+		name       string
+		violations func([]byte) []string
+	}{
+		{"started.json", metadata.StartedViolations},
+		{"finished.json", metadata.FinishedViolations},
+	} {
+		path, err := build.Path.ResolveReference(&url.URL{Path: f.name})
+		if err != nil {
+			continue
+		}
+		r, err := opener.Open(ctx, *path)
+		if err != nil {
+			continue
+		}
+		buf, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			continue
+		}
+		for _, v := range f.violations(buf) {
+			out = append(out, f.name+": "+v)
+		}
+	}
+	return out
+}
+
+// reportCompliance logs the fraction of columns whose metadata follows the
+// schema, and warns about each column which does not.
+func reportCompliance(log logrus.FieldLogger, cols []InflatedColumn) {
+	if len(cols) == 0 {
+		return
+	}
+	var compliant int
+	for _, col := range cols {
+		if len(col.Column.MetadataViolations) == 0 {
+			compliant++
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"build":      col.Column.Build,
+			"violations": col.Column.MetadataViolations,
+		}).Warning("Build metadata violates the schema")
+	}
+	log.WithFields(logrus.Fields{
+		"compliant":  compliant,
+		"columns":    len(cols),
+		"compliance": float64(compliant) / float64(len(cols)),
+	}).Info("Metadata compliance")
+}
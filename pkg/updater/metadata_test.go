@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestColumnMetadata(t *testing.T) {
+	pod := gcs.PodInfo{
+		Pod: &core.Pod{
+			ObjectMeta: meta.ObjectMeta{
+				Labels:      map[string]string{"zone": "us-central1-f"},
+				Annotations: map[string]string{"kernel": "5.4"},
+			},
+			Spec: core.PodSpec{
+				NodeName:   "node-1",
+				Containers: []core.Container{{Image: "gcr.io/test:v1"}, {Image: "sidecar"}},
+			},
+			Status: core.PodStatus{Phase: core.PodSucceeded},
+		},
+	}
+	finished := map[string]string{"node_image": "cos-85", "infra-commit": "abc123"}
+	cases := []struct {
+		name    string
+		keys    []string
+		podInfo gcs.PodInfo
+		want    map[string]string
+	}{
+		{
+			name:    "nothing by default",
+			podInfo: pod,
+		},
+		{
+			name:    "finished metadata",
+			keys:    []string{"node_image", "infra-commit"},
+			podInfo: pod,
+			want:    map[string]string{"node_image": "cos-85", "infra-commit": "abc123"},
+		},
+		{
+			name:    "podinfo fields",
+			keys:    []string{"pod.node", "pod.phase", "pod.image", "pod.label.zone", "pod.annotation.kernel"},
+			podInfo: pod,
+			want: map[string]string{
+				"pod.node":              "node-1",
+				"pod.phase":             "Succeeded",
+				"pod.image":             "gcr.io/test:v1",
+				"pod.label.zone":        "us-central1-f",
+				"pod.annotation.kernel": "5.4",
+			},
+		},
+		{
+			name:    "omit missing keys",
+			keys:    []string{"node_image", "missing", "pod.label.missing"},
+			podInfo: pod,
+			want:    map[string]string{"node_image": "cos-85"},
+		},
+		{
+			name: "missing podinfo",
+			keys: []string{"pod.node"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := columnMetadata(tc.keys, finished, tc.podInfo)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("columnMetadata() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataViolations(t *testing.T) {
+	build := gcs.Build{Path: newPathOrDie("gs://bucket/logs/job/1/")}
+	cases := []struct {
+		name string
+		data map[string]fakeObject
+		want []string
+	}{
+		{
+			name: "missing files are skipped",
+		},
+		{
+			name: "compliant",
+			data: map[string]fakeObject{
+				"started.json":  {Data: `{"timestamp": 1}`},
+				"finished.json": {Data: `{"timestamp": 2, "passed": true}`},
+			},
+		},
+		{
+			name: "violations of each file",
+			data: map[string]fakeObject{
+				"started.json":  {Data: `{"timestamp": 1, "hello": "world"}`},
+				"finished.json": {Data: `{"timestamp": 2, "result": "SUCCESS"}`},
+			},
+			want: []string{
+				`started.json: unknown field "hello"`,
+				`finished.json: "result" is deprecated, use passed`,
+				`finished.json: missing "passed"`,
+			},
+		},
+		{
+			name: "read errors are skipped",
+			data: map[string]fakeObject{
+				"started.json":  {Data: `{}`, ReadErr: errors.New("injected read error")},
+				"finished.json": {Data: `{}`, OpenErr: errors.New("injected open error")},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opener := fakeOpener{}
+			for name, obj := range tc.data {
+				opener[newPathOrDie("gs://bucket/logs/job/1/"+name)] = obj
+			}
+			got := metadataViolations(context.Background(), opener, build)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("metadataViolations() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
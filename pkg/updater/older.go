@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// OlderGrid returns an ephemeral grid of up to max of the group's builds
+// which are older than the before build, or its newest builds when before is
+// empty.
+//
+// Builds are read on demand and never written. The oldest column counts the
+// builds which remain in its OlderBuilds, so callers page through older
+// results by passing its hint as the next before.
+func OlderGrid(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, group *configpb.TestGroup, before string, max int, buildTimeout time.Duration, concurrency int) (*statepb.Grid, error) {
+	paths, err := groupPaths(group)
+	if err != nil {
+		return nil, fmt.Errorf("group path: %w", err)
+	}
+	paths = append(paths, newMergedSources(group.MergedSources).paths()...)
+	less := buildLess(group)
+	builds, err := listBuilds(ctx, client, "", less, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("list builds: %w", err)
+	}
+	older := builds[:0]
+	for _, b := range builds {
+		if before == "" || less(b.Build(), before) {
+			older = append(older, b)
+		}
+	}
+	var remaining int
+	if max > 0 && len(older) > max {
+		remaining = len(older) - max
+		older = older[:max]
+	}
+	log.WithFields(logrus.Fields{
+		"before":    before,
+		"builds":    len(older),
+		"remaining": remaining,
+	}).Debug("Listed older builds")
+	if len(older) == 0 {
+		return &statepb.Grid{}, nil
+	}
+	cols, err := readColumns(ctx, client, group, older, time.Time{}, len(older), buildTimeout, concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("read columns: %w", err)
+	}
+	SortStarted(group, cols)
+	if n := len(cols); n > 0 {
+		cols[n-1].Column.OlderBuilds += int32(remaining)
+	}
+	return constructGrid(log, group, cols), nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestOlderGrid(t *testing.T) {
+	now := time.Now().Unix()
+	yes := true
+	run := func(id string, started int64) fakeBuild {
+		return fakeBuild{
+			id: id,
+			started: &fakeObject{
+				Data: jsonData(metadata.Started{Timestamp: started}),
+			},
+			finished: &fakeObject{
+				Data: jsonData(metadata.Finished{Timestamp: pint64(started + 1), Passed: &yes}),
+			},
+			passed: []string{"test"},
+		}
+	}
+
+	groupPath := newPathOrDie("gs://bucket/logs/job/")
+	client := fakeClient{
+		Lister: fake.Lister{},
+		Opener: fake.Opener{},
+	}
+	var buildDirs fake.Iterator
+	for _, b := range addBuilds(&client, groupPath, run("13", now+13), run("12", now+12), run("11", now+11), run("10", now+10)) {
+		buildDirs.Objects = append(buildDirs.Objects, storage.ObjectAttrs{Prefix: b.Path.Object()})
+	}
+	client.Lister[groupPath] = buildDirs
+	group := &configpb.TestGroup{
+		Name:      "group",
+		GcsPrefix: "bucket/logs/job/",
+	}
+
+	cases := []struct {
+		name       string
+		before     string
+		max        int
+		wantBuilds []string
+		wantOlder  int32
+	}{
+		{
+			name:       "newest builds",
+			max:        2,
+			wantBuilds: []string{"13", "12"},
+			wantOlder:  2,
+		},
+		{
+			name:       "builds before",
+			before:     "12",
+			max:        1,
+			wantBuilds: []string{"11"},
+			wantOlder:  1,
+		},
+		{
+			name:       "last page",
+			before:     "12",
+			max:        5,
+			wantBuilds: []string{"11", "10"},
+		},
+		{
+			name:   "nothing older",
+			before: "10",
+			max:    5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			grid, err := OlderGrid(context.Background(), logrus.New(), client, group, tc.before, tc.max, time.Minute, 2)
+			if err != nil {
+				t.Fatalf("OlderGrid() got unexpected error: %v", err)
+			}
+			var builds []string
+			var older int32
+			for _, col := range grid.Columns {
+				builds = append(builds, col.Build)
+				older += col.OlderBuilds
+			}
+			if diff := cmp.Diff(tc.wantBuilds, builds); diff != "" {
+				t.Errorf("OlderGrid() got unexpected columns (-want +got):\n%s", diff)
+			}
+			if older != tc.wantOlder {
+				t.Errorf("OlderGrid() got %d older builds, want %d", older, tc.wantOlder)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"sort"
+
+	"github.com/fvbommel/sortorder"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+)
+
+// buildLess returns how the group orders build names, oldest first.
+func buildLess(tg *configpb.TestGroup) func(a, b string) bool {
+	switch tg.GetBuildOrder() {
+	case configpb.TestGroup_BUILD_ORDER_NATURAL:
+		return numericLess
+	case configpb.TestGroup_BUILD_ORDER_LEXICAL:
+		return func(a, b string) bool { return a < b }
+	}
+	return sortorder.NaturalLess
+}
+
+// numericLess orders names naturally, with entirely numeric names after all others.
+func numericLess(a, b string) bool {
+	if an, bn := numeric(a), numeric(b); an != bn {
+		return bn
+	}
+	return sortorder.NaturalLess(a, b)
+}
+
+func numeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// SortBuilds sorts InflatedColumns newest first in the group's build order.
+//
+// Ties, and groups ordered by start time, sort by column start time.
+func SortBuilds(tg *configpb.TestGroup, cols []InflatedColumn) {
+	var key func(InflatedColumn) (string, bool)
+	switch tg.GetBuildOrder() {
+	case configpb.TestGroup_BUILD_ORDER_NATURAL, configpb.TestGroup_BUILD_ORDER_LEXICAL:
+		key = func(col InflatedColumn) (string, bool) {
+			return col.Column.Build, true
+		}
+	case configpb.TestGroup_BUILD_ORDER_METADATA:
+		name := tg.GetBuildOrderMetadata()
+		key = func(col InflatedColumn) (string, bool) {
+			val, ok := col.Column.Metadata[name]
+			return val, ok
+		}
+	default:
+		SortStarted(tg, cols)
+		return
+	}
+	less := buildLess(tg)
+	sort.SliceStable(cols, func(i, j int) bool {
+		a, aok := key(cols[i])
+		b, bok := key(cols[j])
+		switch {
+		case aok != bok:
+			return aok // columns without a value are oldest
+		case less(b, a):
+			return true
+		case less(a, b):
+			return false
+		}
+		return cols[i].Column.Started > cols[j].Column.Started
+	})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestBuildLess(t *testing.T) {
+	cases := []struct {
+		name  string
+		order configpb.TestGroup_BuildOrder
+		a     string
+		b     string
+		want  bool
+	}{
+		{
+			name: "started order lists builds naturally",
+			a:    "9",
+			b:    "10",
+			want: true,
+		},
+		{
+			name:  "natural",
+			order: configpb.TestGroup_BUILD_ORDER_NATURAL,
+			a:     "9",
+			b:     "10",
+			want:  true,
+		},
+		{
+			name:  "numeric names are newer than other names",
+			order: configpb.TestGroup_BUILD_ORDER_NATURAL,
+			a:     "nightly-20",
+			b:     "3",
+			want:  true,
+		},
+		{
+			name:  "other names are older than numeric names",
+			order: configpb.TestGroup_BUILD_ORDER_NATURAL,
+			a:     "3",
+			b:     "nightly-20",
+		},
+		{
+			name:  "lexical",
+			order: configpb.TestGroup_BUILD_ORDER_LEXICAL,
+			a:     "20210102-1234",
+			b:     "20210102-934",
+			want:  true,
+		},
+		{
+			name:  "lexical is not natural",
+			order: configpb.TestGroup_BUILD_ORDER_LEXICAL,
+			a:     "9",
+			b:     "10",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			less := buildLess(&configpb.TestGroup{BuildOrder: tc.order})
+			if got := less(tc.a, tc.b); got != tc.want {
+				t.Errorf("less(%q, %q) got %t, want %t", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSortBuilds(t *testing.T) {
+	col := func(build string, started float64, metadata map[string]string) InflatedColumn {
+		return InflatedColumn{
+			Column: &statepb.Column{Build: build, Started: started, Metadata: metadata},
+		}
+	}
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		cols  []InflatedColumn
+		want  []string
+	}{
+		{
+			name:  "started",
+			group: &configpb.TestGroup{},
+			cols: []InflatedColumn{
+				col("10", 1, nil),
+				col("9", 2, nil),
+			},
+			want: []string{"9", "10"},
+		},
+		{
+			name:  "natural",
+			group: &configpb.TestGroup{BuildOrder: configpb.TestGroup_BUILD_ORDER_NATURAL},
+			cols: []InflatedColumn{
+				col("9", 2, nil),
+				col("manual", 3, nil),
+				col("10", 1, nil),
+			},
+			want: []string{"10", "9", "manual"},
+		},
+		{
+			name:  "lexical",
+			group: &configpb.TestGroup{BuildOrder: configpb.TestGroup_BUILD_ORDER_LEXICAL},
+			cols: []InflatedColumn{
+				col("20210102-1234", 2, nil),
+				col("20210102-0934", 3, nil),
+				col("20210103-0001", 1, nil),
+			},
+			want: []string{"20210103-0001", "20210102-1234", "20210102-0934"},
+		},
+		{
+			name: "metadata",
+			group: &configpb.TestGroup{
+				BuildOrder:         configpb.TestGroup_BUILD_ORDER_METADATA,
+				BuildOrderMetadata: "revision",
+			},
+			cols: []InflatedColumn{
+				col("a", 4, nil),
+				col("b", 1, map[string]string{"revision": "r10"}),
+				col("c", 3, map[string]string{"revision": "r9"}),
+				col("d", 2, map[string]string{"revision": "r10"}),
+			},
+			want: []string{"d", "b", "c", "a"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SortBuilds(tc.group, tc.cols)
+			var got []string
+			for _, col := range tc.cols {
+				got = append(got, col.Column.Build)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SortBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const buildOverridesSuffix = ".overrides"
+
+// BuildOverridesPath returns the path to the overrides of the builds of a grid.
+func BuildOverridesPath(gridPath gcs.Path) (*gcs.Path, error) {
+	return gcs.NewPath(gridPath.String() + buildOverridesSuffix)
+}
+
+// ReadBuildOverrides returns the build overrides at the path along with its generation.
+//
+// Returns no overrides and zero generation when the path does not exist.
+func ReadBuildOverrides(ctx context.Context, client gcs.Client, path gcs.Path) (*statepb.BuildOverrides, int64, error) {
+	var overrides statepb.BuildOverrides
+	generation, err := readGeneration(ctx, client, path, &overrides)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &overrides, generation, nil
+}
+
+// UpdateBuildOverrides writes the build overrides when update changes them.
+//
+// Retries when the overrides change concurrently.
+func UpdateBuildOverrides(ctx context.Context, client gcs.ConditionalClient, path gcs.Path, update func(*statepb.BuildOverrides) bool) (*statepb.BuildOverrides, error) {
+	var overrides statepb.BuildOverrides
+	if err := updateGeneration(ctx, client, path, &overrides, func() bool { return update(&overrides) }); err != nil {
+		return nil, err
+	}
+	return &overrides, nil
+}
+
+// ValidateBuildOverride returns an error for overrides which change nothing or lack a reason.
+func ValidateBuildOverride(o *statepb.BuildOverride) error {
+	switch {
+	case o.Build == "":
+		return errors.New("missing build")
+	case o.Reason == "":
+		return errors.New("missing reason")
+	case o.Result == int32(statuspb.TestStatus_NO_RESULT) && o.Annotation == "":
+		return errors.New("override requires a result or annotation")
+	}
+	if _, ok := statuspb.TestStatus_name[o.Result]; !ok {
+		return fmt.Errorf("unknown result %d", o.Result)
+	}
+	return nil
+}
+
+// SetBuildOverride adds the override, replacing any earlier override of its build.
+func SetBuildOverride(overrides *statepb.BuildOverrides, o *statepb.BuildOverride, now time.Time) {
+	o.Created = float64(now.Unix())
+	for i, existing := range overrides.Overrides {
+		if existing.Build == o.Build {
+			overrides.Overrides[i] = o
+			return
+		}
+	}
+	overrides.Overrides = append(overrides.Overrides, o)
+}
+
+// RemoveBuildOverride removes the override of the build, returning false when there is none.
+func RemoveBuildOverride(overrides *statepb.BuildOverrides, build string) bool {
+	for i, o := range overrides.Overrides {
+		if o.Build == build {
+			overrides.Overrides = append(overrides.Overrides[:i], overrides.Overrides[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// applyBuildOverrides forces the results and messages of the cells of each
+// overridden build, returning the number of overridden columns.
+//
+// Cells without a result remain empty.
+func applyBuildOverrides(overrides *statepb.BuildOverrides, cols []InflatedColumn) int {
+	if len(overrides.GetOverrides()) == 0 {
+		return 0
+	}
+	builds := make(map[string]*statepb.BuildOverride, len(overrides.Overrides))
+	for _, o := range overrides.Overrides {
+		builds[o.Build] = o
+	}
+	var n int
+	for _, col := range cols {
+		o, ok := builds[col.Column.Build]
+		if !ok {
+			continue
+		}
+		n++
+		col.Column.Override = o
+		for name, cell := range col.Cells {
+			if cell.Result == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			if o.Result != int32(statuspb.TestStatus_NO_RESULT) {
+				cell.Result = statuspb.TestStatus(o.Result)
+			}
+			if o.Annotation != "" {
+				cell.Message = o.Annotation
+			}
+			col.Cells[name] = cell
+		}
+	}
+	return n
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestValidateBuildOverride(t *testing.T) {
+	cases := []struct {
+		name     string
+		override *statepb.BuildOverride
+		err      bool
+	}{
+		{
+			name:     "result",
+			override: &statepb.BuildOverride{Build: "1", Reason: "bad node", Result: int32(statuspb.TestStatus_CANCEL)},
+		},
+		{
+			name:     "annotation",
+			override: &statepb.BuildOverride{Build: "1", Reason: "bad node", Annotation: "ignore"},
+		},
+		{
+			name:     "missing build",
+			override: &statepb.BuildOverride{Reason: "bad node", Annotation: "ignore"},
+			err:      true,
+		},
+		{
+			name:     "missing reason",
+			override: &statepb.BuildOverride{Build: "1", Annotation: "ignore"},
+			err:      true,
+		},
+		{
+			name:     "overrides nothing",
+			override: &statepb.BuildOverride{Build: "1", Reason: "bad node"},
+			err:      true,
+		},
+		{
+			name:     "unknown result",
+			override: &statepb.BuildOverride{Build: "1", Reason: "bad node", Result: 1000},
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBuildOverride(tc.override)
+			if (err != nil) != tc.err {
+				t.Errorf("ValidateBuildOverride() got err %v, want err %t", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestSetBuildOverride(t *testing.T) {
+	now := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	overrides := &statepb.BuildOverrides{}
+	SetBuildOverride(overrides, &statepb.BuildOverride{Build: "1", Reason: "bad node"}, now)
+	SetBuildOverride(overrides, &statepb.BuildOverride{Build: "2", Reason: "outage"}, now)
+	SetBuildOverride(overrides, &statepb.BuildOverride{Build: "1", Reason: "bad disk"}, now.Add(time.Minute))
+	want := &statepb.BuildOverrides{Overrides: []*statepb.BuildOverride{
+		{Build: "1", Reason: "bad disk", Created: float64(now.Add(time.Minute).Unix())},
+		{Build: "2", Reason: "outage", Created: float64(now.Unix())},
+	}}
+	if diff := cmp.Diff(want, overrides, protocmp.Transform()); diff != "" {
+		t.Errorf("SetBuildOverride() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if !RemoveBuildOverride(overrides, "1") {
+		t.Error("RemoveBuildOverride(1) got false, want true")
+	}
+	if RemoveBuildOverride(overrides, "1") {
+		t.Error("RemoveBuildOverride(1) again got true, want false")
+	}
+	if diff := cmp.Diff(want.Overrides[1:], overrides.Overrides, protocmp.Transform()); diff != "" {
+		t.Errorf("RemoveBuildOverride() got unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyBuildOverrides(t *testing.T) {
+	cancel := &statepb.BuildOverride{Build: "2", Result: int32(statuspb.TestStatus_CANCEL), Reason: "bad node"}
+	annotate := &statepb.BuildOverride{Build: "3", Annotation: "known outage", Reason: "outage"}
+	cols := func() []InflatedColumn {
+		var out []InflatedColumn
+		for _, b := range []string{"3", "2", "1"} {
+			out = append(out, InflatedColumn{
+				Column: &statepb.Column{Build: b},
+				Cells: map[string]Cell{
+					"fail":  {Result: statuspb.TestStatus_FAIL, Message: "boom"},
+					"empty": {},
+				},
+			})
+		}
+		return out
+	}
+	cases := []struct {
+		name      string
+		overrides *statepb.BuildOverrides
+		want      []InflatedColumn
+		wantN     int
+	}{
+		{
+			name: "basically works",
+			want: cols(),
+		},
+		{
+			name:      "override results and messages",
+			overrides: &statepb.BuildOverrides{Overrides: []*statepb.BuildOverride{cancel, annotate}},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "3", Override: annotate},
+					Cells: map[string]Cell{
+						"fail":  {Result: statuspb.TestStatus_FAIL, Message: "known outage"},
+						"empty": {},
+					},
+				},
+				{
+					Column: &statepb.Column{Build: "2", Override: cancel},
+					Cells: map[string]Cell{
+						"fail":  {Result: statuspb.TestStatus_CANCEL, Message: "boom"},
+						"empty": {},
+					},
+				},
+				cols()[2],
+			},
+			wantN: 2,
+		},
+		{
+			name: "ignore missing builds",
+			overrides: &statepb.BuildOverrides{Overrides: []*statepb.BuildOverride{
+				{Build: "9", Annotation: "nope", Reason: "missing"},
+			}},
+			want: cols(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := cols()
+			if n := applyBuildOverrides(tc.overrides, got); n != tc.wantN {
+				t.Errorf("applyBuildOverrides() got %d columns, want %d", n, tc.wantN)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("applyBuildOverrides() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
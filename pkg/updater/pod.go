@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+// PodRule classifies a pod problem into a distinct cell on the pod row.
+type PodRule struct {
+	// Name of the problem, such as OOMKilled.
+	Name   string
+	Result statuspb.TestStatus
+	Icon   string
+	// Match returns a message describing the problem if the pod has it.
+	Match func(*core.Pod) (string, bool)
+}
+
+// PodRules classify common infrastructure problems, checked in order.
+//
+// Pods which match no rule fall back to gcs.PodInfo.Summarize.
+var PodRules = []PodRule{
+	{
+		Name:   "ImagePull",
+		Result: statuspb.TestStatus_TOOL_FAIL,
+		Icon:   "I",
+		Match:  imagePullProblem,
+	},
+	{
+		Name:   "OOMKilled",
+		Result: statuspb.TestStatus_CATEGORIZED_FAIL,
+		Icon:   "M",
+		Match:  oomKilledProblem,
+	},
+	{
+		Name:   "Evicted",
+		Result: statuspb.TestStatus_CATEGORIZED_ABORT,
+		Icon:   "V",
+		Match:  evictedProblem,
+	},
+	{
+		Name:   "Preempted",
+		Result: statuspb.TestStatus_CATEGORIZED_ABORT,
+		Icon:   "P",
+		Match:  preemptedProblem,
+	},
+}
+
+// containerStatuses returns the init container statuses followed by the container statuses.
+func containerStatuses(pod *core.Pod) []core.ContainerStatus {
+	out := make([]core.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	out = append(out, pod.Status.InitContainerStatuses...)
+	return append(out, pod.Status.ContainerStatuses...)
+}
+
+var imagePullReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+func imagePullProblem(pod *core.Pod) (string, bool) {
+	for _, status := range containerStatuses(pod) {
+		if w := status.State.Waiting; w != nil && imagePullReasons[w.Reason] {
+			return fmt.Sprintf("%s could not pull %s: %s", status.Name, status.Image, w.Reason), true
+		}
+	}
+	return "", false
+}
+
+func oomKilledProblem(pod *core.Pod) (string, bool) {
+	const reason = "OOMKilled"
+	for _, status := range containerStatuses(pod) {
+		if t := status.State.Terminated; t != nil && t.Reason == reason {
+			return fmt.Sprintf("%s was OOMKilled", status.Name), true
+		}
+		if t := status.LastTerminationState.Terminated; t != nil && t.Reason == reason {
+			return fmt.Sprintf("%s was OOMKilled and restarted", status.Name), true
+		}
+	}
+	return "", false
+}
+
+func evictedProblem(pod *core.Pod) (string, bool) {
+	if pod.Status.Reason != "Evicted" {
+		return "", false
+	}
+	return fmt.Sprintf("pod evicted: %s", pod.Status.Message), true
+}
+
+var preemptedReasons = map[string]bool{
+	"Preempting":   true,
+	"NodeLost":     true,
+	"NodeShutdown": true,
+	"Shutdown":     true,
+}
+
+func preemptedProblem(pod *core.Pod) (string, bool) {
+	if preemptedReasons[pod.Status.Reason] {
+		return fmt.Sprintf("pod lost its node (%s): %s", pod.Status.Reason, pod.Status.Message), true
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == "DisruptionTarget" && cond.Status == core.ConditionTrue {
+			return fmt.Sprintf("pod lost its node (%s): %s", cond.Reason, cond.Message), true
+		}
+	}
+	return "", false
+}
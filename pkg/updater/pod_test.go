@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+)
+
+func TestPodRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		pod      core.Pod
+		wantRule string
+		wantMsg  string
+	}{
+		{
+			name: "pending pods are not classified",
+			pod: core.Pod{
+				Status: core.PodStatus{Phase: core.PodPending},
+			},
+		},
+		{
+			name: "image pull backoff",
+			pod: core.Pod{
+				Status: core.PodStatus{
+					InitContainerStatuses: []core.ContainerStatus{
+						{
+							Name:  "clonerefs",
+							Image: "gcr.io/clonerefs:v1",
+							State: core.ContainerState{
+								Waiting: &core.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+							},
+						},
+					},
+				},
+			},
+			wantRule: "ImagePull",
+			wantMsg:  "clonerefs could not pull gcr.io/clonerefs:v1: ImagePullBackOff",
+		},
+		{
+			name: "oom killed",
+			pod: core.Pod{
+				Status: core.PodStatus{
+					ContainerStatuses: []core.ContainerStatus{
+						{
+							Name: "test",
+							State: core.ContainerState{
+								Terminated: &core.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+							},
+						},
+					},
+				},
+			},
+			wantRule: "OOMKilled",
+			wantMsg:  "test was OOMKilled",
+		},
+		{
+			name: "previously oom killed",
+			pod: core.Pod{
+				Status: core.PodStatus{
+					ContainerStatuses: []core.ContainerStatus{
+						{
+							Name: "test",
+							LastTerminationState: core.ContainerState{
+								Terminated: &core.ContainerStateTerminated{Reason: "OOMKilled"},
+							},
+						},
+					},
+				},
+			},
+			wantRule: "OOMKilled",
+			wantMsg:  "test was OOMKilled and restarted",
+		},
+		{
+			name: "evicted",
+			pod: core.Pod{
+				Status: core.PodStatus{Reason: "Evicted", Message: "node low on ephemeral-storage"},
+			},
+			wantRule: "Evicted",
+			wantMsg:  "pod evicted: node low on ephemeral-storage",
+		},
+		{
+			name: "node lost",
+			pod: core.Pod{
+				Status: core.PodStatus{Reason: "NodeLost", Message: "node gone"},
+			},
+			wantRule: "Preempted",
+			wantMsg:  "pod lost its node (NodeLost): node gone",
+		},
+		{
+			name: "disruption target",
+			pod: core.Pod{
+				Status: core.PodStatus{
+					Conditions: []core.PodCondition{
+						{
+							Type:    "DisruptionTarget",
+							Status:  core.ConditionTrue,
+							Reason:  "TerminationByKubelet",
+							Message: "preempted",
+						},
+					},
+				},
+			},
+			wantRule: "Preempted",
+			wantMsg:  "pod lost its node (TerminationByKubelet): preempted",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotRule, gotMsg string
+			for _, rule := range PodRules {
+				if msg, ok := rule.Match(&tc.pod); ok {
+					gotRule, gotMsg = rule.Name, msg
+					break
+				}
+			}
+			if gotRule != tc.wantRule || gotMsg != tc.wantMsg {
+				t.Errorf("PodRules got %q: %q, want %q: %q", gotRule, gotMsg, tc.wantRule, tc.wantMsg)
+			}
+		})
+	}
+}
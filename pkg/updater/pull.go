@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/sirupsen/logrus"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// PullGrid returns an ephemeral grid of the presubmit results of a pull request.
+//
+// The pullPath holds the results of each presubmit job in the pr-logs layout:
+// <pullPath>/<job>/<build>/. Rows are named after the job and test, and each
+// column aggregates every run of the pull request's head commit, including
+// retries, so retries which disagree are flaky. At most max of the most
+// recent runs are read.
+func PullGrid(ctx context.Context, log logrus.FieldLogger, client gcs.Downloader, pullPath gcs.Path, max int, buildTimeout time.Duration, concurrency int) (*statepb.Grid, error) {
+	jobs, err := gcs.ListBuilds(ctx, client, pullPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+	var prefixes []string
+	var builds []gcs.Build
+	for _, job := range jobs {
+		jobBuilds, err := gcs.ListBuilds(ctx, client, job.Path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("list %s builds: %w", job.Build(), err)
+		}
+		builds = append(builds, jobBuilds...)
+		prefixes = append(prefixes, job.Path.Bucket()+"/"+job.Path.Object())
+	}
+	gcs.Sort(builds)
+	if max > 0 && len(builds) > max {
+		builds = builds[:max]
+	}
+	log.WithFields(logrus.Fields{
+		"jobs":   len(jobs),
+		"builds": len(builds),
+	}).Debug("Listed pull request builds")
+
+	group := &configpb.TestGroup{
+		Name: pullPath.String(),
+		// Multiple prefixes name rows after their job.
+		GcsPrefix:    strings.Join(prefixes, ","),
+		ColumnHeader: []*configpb.TestGroup_ColumnHeader{{ConfigurationValue: pullHeader}},
+	}
+	if len(builds) == 0 {
+		return &statepb.Grid{}, nil
+	}
+	cols, err := readColumns(ctx, client, group, builds, time.Time{}, len(builds), buildTimeout, concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("read columns: %w", err)
+	}
+	cols = aggregateCommits(cols)
+	SortStarted(group, cols)
+	return constructGrid(log, group, cols), nil
+}
+
+// aggregateCommits merges the columns of each commit, identified by its first header.
+//
+// Columns without a commit are unchanged. Merged columns start with the
+// earliest column, and merging disagreeing cells is flaky.
+func aggregateCommits(cols []InflatedColumn) []InflatedColumn {
+	var out []InflatedColumn
+	commits := map[string]int{}
+	cells := map[int]map[string][]Cell{}
+	for _, col := range cols {
+		var commit string
+		if len(col.Column.Extra) > 0 {
+			commit = col.Column.Extra[0]
+		}
+		if commit == "" || commit == metadata.Missing {
+			out = append(out, col)
+			continue
+		}
+		idx, ok := commits[commit]
+		if !ok {
+			idx = len(out)
+			commits[commit] = idx
+			cells[idx] = map[string][]Cell{}
+			out = append(out, InflatedColumn{
+				Column: &statepb.Column{
+					Build:   commit,
+					Hint:    col.Column.Hint,
+					Started: col.Column.Started,
+					Extra:   col.Column.Extra,
+				},
+			})
+		}
+		agg := out[idx].Column
+		if col.Column.Started < agg.Started {
+			agg.Started = col.Column.Started
+		}
+		if sortorder.NaturalLess(agg.Hint, col.Column.Hint) {
+			agg.Hint = col.Column.Hint
+		}
+		for name, c := range col.Cells {
+			cells[idx][name] = append(cells[idx][name], c)
+		}
+	}
+	for idx, named := range cells {
+		merged := make(map[string]Cell, len(named))
+		for name, cs := range named {
+			merged[name] = MergeCells(true, cs...)
+		}
+		out[idx].Cells = merged
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestAggregateCommits(t *testing.T) {
+	pass := Cell{Result: statuspb.TestStatus_PASS}
+	fail := Cell{Result: statuspb.TestStatus_FAIL}
+	cases := []struct {
+		name string
+		cols []InflatedColumn
+		want []InflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "merge runs of each commit",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "13", Hint: "13", Started: 13, Extra: []string{"bbb"}},
+					Cells:  map[string]Cell{"a.test": pass},
+				},
+				{
+					Column: &statepb.Column{Build: "12", Hint: "12", Started: 12, Extra: []string{"bbb"}},
+					Cells:  map[string]Cell{"a.test": fail},
+				},
+				{
+					Column: &statepb.Column{Build: "11", Hint: "11", Started: 11, Extra: []string{"aaa"}},
+					Cells:  map[string]Cell{"b.test": pass},
+				},
+				{
+					Column: &statepb.Column{Build: "10", Hint: "10", Started: 10, Extra: []string{"aaa"}},
+					Cells:  map[string]Cell{"a.test": pass},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "bbb", Hint: "13", Started: 12, Extra: []string{"bbb"}},
+					Cells:  map[string]Cell{"a.test": MergeCells(true, pass, fail)},
+				},
+				{
+					Column: &statepb.Column{Build: "aaa", Hint: "11", Started: 10, Extra: []string{"aaa"}},
+					Cells:  map[string]Cell{"a.test": pass, "b.test": pass},
+				},
+			},
+		},
+		{
+			name: "keep columns without a commit",
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2", Extra: []string{metadata.Missing}},
+					Cells:  map[string]Cell{"a.test": pass},
+				},
+				{
+					Column: &statepb.Column{Build: "1", Extra: []string{metadata.Missing}},
+					Cells:  map[string]Cell{"a.test": fail},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{Build: "2", Extra: []string{metadata.Missing}},
+					Cells:  map[string]Cell{"a.test": pass},
+				},
+				{
+					Column: &statepb.Column{Build: "1", Extra: []string{metadata.Missing}},
+					Cells:  map[string]Cell{"a.test": fail},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := aggregateCommits(tc.cols)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("aggregateCommits() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPullGrid(t *testing.T) {
+	now := time.Now().Unix()
+	yes := true
+	var no bool
+	run := func(id string, started int64, head string, passed bool, tests ...string) fakeBuild {
+		b := fakeBuild{
+			id: id,
+			started: &fakeObject{
+				Data: jsonData(metadata.Started{Timestamp: started, Pull: "main:base," + "123:" + head}),
+			},
+			finished: &fakeObject{
+				Data: jsonData(metadata.Finished{Timestamp: pint64(started + 1), Passed: &no}),
+			},
+		}
+		if passed {
+			b.finished.Data = jsonData(metadata.Finished{Timestamp: pint64(started + 1), Passed: &yes})
+			b.passed = tests
+		} else {
+			b.failed = tests
+		}
+		return b
+	}
+
+	pullPath := newPathOrDie("gs://bucket/pr-logs/pull/org_repo/123/")
+	client := fakeClient{
+		Lister: fake.Lister{},
+		Opener: fake.Opener{},
+	}
+	jobs := map[string][]fakeBuild{
+		"job-a": {
+			run("13", now+13, "bbb", true, "test"),
+			run("12", now+12, "bbb", false, "test"),
+			run("10", now+10, "aaa", true, "test"),
+		},
+		"job-b": {
+			run("11", now+11, "aaa", true, "other"),
+		},
+	}
+	var jobDirs fake.Iterator
+	for job, builds := range jobs {
+		jobPath := resolveOrDie(&pullPath, job+"/")
+		jobDirs.Objects = append(jobDirs.Objects, storage.ObjectAttrs{Prefix: jobPath.Object()})
+		var buildDirs fake.Iterator
+		for _, b := range addBuilds(&client, *jobPath, builds...) {
+			buildDirs.Objects = append(buildDirs.Objects, storage.ObjectAttrs{Prefix: b.Path.Object()})
+		}
+		client.Lister[*jobPath] = buildDirs
+	}
+	client.Lister[pullPath] = jobDirs
+
+	grid, err := PullGrid(context.Background(), logrus.New(), client, pullPath, 0, time.Minute, 2)
+	if err != nil {
+		t.Fatalf("PullGrid() got unexpected error: %v", err)
+	}
+
+	var builds []string
+	for _, col := range grid.Columns {
+		builds = append(builds, col.Build)
+	}
+	if diff := cmp.Diff([]string{"bbb", "aaa"}, builds); diff != "" {
+		t.Errorf("PullGrid() got unexpected columns (-want +got):\n%s", diff)
+	}
+	results := map[string][]statuspb.TestStatus{}
+	for _, row := range grid.Rows {
+		results[row.Name] = state.Expand(row.Results)
+	}
+	want := map[string][]statuspb.TestStatus{
+		"job-a.test":  {statuspb.TestStatus_FLAKY, statuspb.TestStatus_PASS},
+		"job-b.other": {statuspb.TestStatus_NO_RESULT, statuspb.TestStatus_PASS},
+	}
+	for name, w := range want {
+		if diff := cmp.Diff(w, results[name]); diff != "" {
+			t.Errorf("PullGrid() got unexpected %s results (-want +got):\n%s", name, diff)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package updater
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -31,16 +32,16 @@ import (
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 
-	"github.com/fvbommel/sortorder"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 )
 
 // hintStarted returns the maximum hint and start time
-func hintStarted(cols []InflatedColumn) (string, time.Time) {
+func hintStarted(cols []InflatedColumn, less func(a, b string) bool) (string, time.Time) {
 	var hint string
 	var started float64
 	for i, col := range cols {
-		if newHint := col.Column.Hint; i == 0 || sortorder.NaturalLess(hint, newHint) {
+		if newHint := col.Column.Hint; i == 0 || less(hint, newHint) {
 			hint = newHint
 		}
 
@@ -53,14 +54,16 @@ func hintStarted(cols []InflatedColumn) (string, time.Time) {
 	return hint, when
 }
 
-func gcsColumnReader(client gcs.Client, buildTimeout time.Duration, concurrency int) ColumnReader {
+func gcsColumnReader(client gcs.Client, buildTimeout time.Duration, concurrency, artifactConcurrency int) ColumnReader {
 	return func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []InflatedColumn, stop time.Time) ([]InflatedColumn, error) {
 		tgPaths, err := groupPaths(tg)
 		if err != nil {
 			return nil, fmt.Errorf("group path: %w", err)
 		}
+		tgPaths = append(tgPaths, newMergedSources(tg.MergedSources).paths()...)
 
-		since, newStop := hintStarted(oldCols)
+		less := buildLess(tg)
+		since, newStop := hintStarted(oldCols, less)
 		if newStop.After(stop) {
 			log.WithFields(logrus.Fields{
 				"old columns": len(oldCols),
@@ -71,168 +74,180 @@ func gcsColumnReader(client gcs.Client, buildTimeout time.Duration, concurrency
 			stop = newStop
 		}
 
-		builds, err := listBuilds(ctx, client, since, tgPaths...)
+		builds, err := listBuilds(ctx, client, since, less, tgPaths...)
 		if err != nil {
 			return nil, fmt.Errorf("list builds: %w", err)
 		}
 		log.WithField("total", len(builds)).Debug("Listed builds")
 
 		builds = truncateBuilds(log, builds, oldCols)
+		for i, b := range builds {
+			builds[i] = b.WithSuitesConcurrency(artifactConcurrency).WithFetchCache(gcs.FetchCacheFromContext(ctx))
+		}
+
+		// Sample after truncating, so sampling reads the started.json of at most
+		// the builds this update may read anyway.
+		builds, skipped, err := sampleBuilds(ctx, client, tg.ResultSampling, builds, oldCols)
+		if err != nil {
+			return nil, fmt.Errorf("sample builds: %w", err)
+		}
+		if tg.ResultSampling != nil {
+			log.WithField("sampled", len(builds)).Debug("Sampled builds")
+		}
 
 		const maxCols = 50
-		return readColumns(ctx, client, tg, builds, stop, maxCols, buildTimeout, concurrency)
+		cols, err := readColumns(ctx, client, tg, builds, stop, maxCols, buildTimeout, concurrency)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			col.Column.SkippedBuilds = skipped[col.Column.Hint]
+		}
+		return cols, nil
 	}
 }
 
 // readColumns will list, download and process builds into inflatedColumns.
+//
+// Concurrency workers read builds from newest to oldest, stopping after the
+// first build which started before stopTime. The oldest column counts the
+// builds left unread in its OlderBuilds.
 func readColumns(parent context.Context, client gcs.Downloader, group *configpb.TestGroup, builds []gcs.Build, stopTime time.Time, max int, buildTimeout time.Duration, concurrency int) ([]InflatedColumn, error) {
-	// Spawn build readers
 	if concurrency == 0 {
 		return nil, errors.New("zero readers")
 	}
 
-	// stopWG cannot be part of wg since concurrently calling wg.Add() and wg.Wait() races.
-	var stopWG sync.WaitGroup
-	defer stopWG.Wait()
-	var wg sync.WaitGroup
-	defer wg.Wait()
-	var maxLock sync.Mutex
-
 	log := logrus.WithField("group", group.Name).WithField("prefix", "gs://"+group.GcsPrefix)
 
 	stop := stopTime.Unix() * 1000
 
-	ctx, cancel := context.WithCancel(parent)
-	defer cancel()
 	if lb := len(builds); lb > max {
 		log.WithField("total", lb).WithField("max", max).Debug("Truncating")
 		builds = builds[lb-max:]
 	}
-	maxIdx := len(builds)
-	cols := make([]InflatedColumn, maxIdx)
+	cols := make([]InflatedColumn, len(builds))
 	log.WithField("timeout", buildTimeout).Debug("Updating")
-	ec := make(chan error)
-	old := make(chan int)
-
-	// Send build indices to readers
-	indices := make(chan int)
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer close(indices)
-		for i := range builds {
-			select {
-			case <-ctx.Done():
-				return
-			case <-old:
-				return
-			case indices <- i:
-			}
-		}
-	}()
 
 	var heads []string
 	for _, h := range group.ColumnHeader {
 		heads = append(heads, h.ConfigurationValue)
 	}
 
-	// Concurrently receive indices and read builds
-	wg.Add(concurrency)
+	opts := makeOptions(group)
+
+	// next is the index of the next build to read and maxIdx is one past the
+	// newest build which started before stop. Workers take builds in order
+	// until next reaches maxIdx, which only ever decreases.
+	var lock sync.Mutex
+	next, maxIdx := 0, len(builds)
+	take := func() (int, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		if next >= maxIdx {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+	stopAt := func(idx int) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		if maxIdx <= idx+1 {
+			return false
+		}
+		maxIdx = idx + 1
+		return true
+	}
+
+	g, ctx := errgroup.WithContext(parent)
 	for i := 0; i < concurrency; i++ {
 		nameCfg := makeNameConfig(group)
-		go func() {
-			defer wg.Done()
+		g.Go(func() error {
 			for {
-				var idx int
-				var open bool
-				select {
-				case <-ctx.Done():
-					return
-				case idx, open = <-indices:
+				idx, ok := take()
+				if !ok {
+					return nil
 				}
-
-				if !open {
-					select {
-					case <-ctx.Done():
-					case ec <- nil:
-					}
-					return
+				if err := ctx.Err(); err != nil {
+					return err
 				}
-
 				b := builds[idx]
-
-				// use ctx so we finish reading, even if buildCtx is done
-				inner, innerCancel := context.WithTimeout(ctx, buildTimeout)
-				defer innerCancel()
-				result, err := readResult(inner, client, b)
+				col, keep, err := readColumn(ctx, log, client, b, nameCfg, heads, opts, buildTimeout)
 				if err != nil {
-					innerCancel()
-					select {
-					case <-ctx.Done():
-					case ec <- fmt.Errorf("read %s: %w", b, err):
-					}
-					return
+					return err
 				}
-				id := path.Base(b.Path.Object())
-				col, err := convertResult(log, nameCfg, id, heads, *result, makeOptions(group))
-				if err != nil {
-					innerCancel()
-					select {
-					case <-ctx.Done():
-					case ec <- fmt.Errorf("convert %s: %w", b, err):
-					}
-					return
+				if int64(col.Column.Started) < stop && stopAt(idx) {
+					log.WithFields(logrus.Fields{
+						"idx":     idx,
+						"id":      col.Column.Build,
+						"path":    b.Path,
+						"started": int64(col.Column.Started / 1000),
+						"stop":    stopTime,
+					}).Debug("Stopped")
 				}
-				if int64(col.Column.Started) < stop {
-					// Multiple go-routines may all read an old result.
-					// So we need to use a mutex to read the current max column
-					// and then truncate it to idx if idx is smaller.
-					stopWG.Add(1)
-					go func() {
-						defer stopWG.Done()
-						maxLock.Lock()
-						defer maxLock.Unlock()
-						if maxIdx == len(builds) {
-							// still vending new indices to download, stop this.
-							select {
-							case <-ctx.Done():
-								// Another thread stopped
-							case old <- idx:
-								log.WithFields(logrus.Fields{
-									"idx":     idx,
-									"id":      id,
-									"path":    b.Path,
-									"started": int64(col.Column.Started / 1000),
-									"stop":    stopTime,
-								}).Debug("Stopped")
-							}
-						}
-						if maxIdx > idx+1 {
-							maxIdx = idx + 1 // this is the newest old result
-						}
-					}()
+				if !keep {
+					// Leave a hole, which is removed below.
+					continue
 				}
 				cols[idx] = *col
 			}
-		}()
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := parent.Err(); err != nil {
+		return nil, err
 	}
 
-	for ; concurrency > 0; concurrency-- {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case err := <-ec:
-			if err != nil {
-				return nil, err
+	older := len(builds) - maxIdx
+	cols = cols[0:maxIdx]
+	if len(opts.sources) > 0 {
+		// Remove builds filtered out of their source.
+		kept := cols[:0]
+		for _, col := range cols {
+			if col.Column != nil {
+				kept = append(kept, col)
 			}
 		}
+		cols = kept
 	}
+	if n := len(cols); n > 0 && older > 0 {
+		cols[n-1].Column.OlderBuilds += int32(older)
+	}
+	return cols, nil
+}
 
-	// Wait for maxIdx to be the correct value.
-	cancel()
-	wg.Wait() // Ensure all stopWG.Add() calls are done
-	stopWG.Wait()
-	return cols[0:maxIdx], nil
+// readColumn downloads and converts a build into a column.
+//
+// Returns false when the build's merged source filters out the column.
+func readColumn(parent context.Context, log logrus.FieldLogger, client gcs.Downloader, b gcs.Build, nameCfg nameConfig, heads []string, opts groupOptions, buildTimeout time.Duration) (*InflatedColumn, bool, error) {
+	ctx, cancel := context.WithTimeout(parent, buildTimeout)
+	defer cancel()
+	result, err := readResult(ctx, client, b)
+	if err != nil {
+		return nil, false, fmt.Errorf("read %s: %w", b, err)
+	}
+	if opts.strictMetadata {
+		result.violations = metadataViolations(ctx, client, b)
+	}
+	id := path.Base(b.Path.Object())
+	col, err := convertResult(log, nameCfg, id, heads, *result, opts)
+	if err != nil {
+		return nil, false, fmt.Errorf("convert %s: %w", b, err)
+	}
+	if len(opts.sources) > 0 {
+		src := opts.sources.source(b)
+		switch {
+		case src == nil:
+			col.Column.Source = opts.sourceName
+		case !src.keep(*result):
+			return col, false, nil
+		default:
+			col.Column.Source = src.name
+		}
+	}
+	return col, true, nil
 }
 
 type groupOptions struct {
@@ -241,6 +256,19 @@ type groupOptions struct {
 	addCellID      bool
 	metricKey      string
 	userKey        string
+	cellProperties []*configpb.CellProperty
+	normalize      *configpb.TestNameNormalization
+	foldParameters bool
+	issueLinks     issueLinker
+	headers        headerTransforms
+	columnMetadata []string
+	overallRow     *configpb.TestGroup_SyntheticRow
+	podRow         *configpb.TestGroup_SyntheticRow
+	budgets        durationBudgets
+	sources        mergedSources
+	sourceName     string
+	commitRepo     string
+	strictMetadata bool
 }
 
 func makeOptions(group *configpb.TestGroup) groupOptions {
@@ -250,6 +278,19 @@ func makeOptions(group *configpb.TestGroup) groupOptions {
 		addCellID:      group.BuildOverrideStrftime != "",
 		metricKey:      group.ShortTextMetric,
 		userKey:        group.UserProperty,
+		cellProperties: group.CellProperties,
+		normalize:      group.TestNameNormalization,
+		foldParameters: group.FoldParameterizedTests,
+		issueLinks:     newIssueLinker(group.IssueLinkRules),
+		headers:        newHeaderTransforms(group.ColumnHeader),
+		columnMetadata: group.ColumnMetadata,
+		overallRow:     group.OverallRow,
+		podRow:         group.PodRow,
+		budgets:        newDurationBudgets(group.DurationBudgets),
+		sources:        newMergedSources(group.MergedSources),
+		sourceName:     group.SourceName,
+		commitRepo:     group.CommitRepo,
+		strictMetadata: group.StrictMetadata,
 	}
 }
 
@@ -337,6 +378,20 @@ func ensureJobName(nc *nameConfig) {
 	nc.parts = append([]string{jobName}, nc.parts...)
 }
 
+// malformedJSON returns true when the error describes the content of a json
+// file rather than a failure to read it.
+func malformedJSON(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return true
+	}
+	return false
+}
+
 // readResult will download all GCS artifacts in parallel.
 //
 // Specifically download the following files:
@@ -344,13 +399,11 @@ func ensureJobName(nc *nameConfig) {
 // * finished.json
 // * any junit.xml files under the artifacts directory.
 func readResult(parent context.Context, client gcs.Downloader, build gcs.Build) (*gcsResult, error) {
-	ctx, cancel := context.WithCancel(parent) // Allows aborting after first error
-	defer cancel()
+	g, ctx := errgroup.WithContext(parent) // Aborts after the first error
 	result := gcsResult{
 		job:   build.Job(),
 		build: build.Build(),
 	}
-	ec := make(chan error) // Receives errors from anyone
 
 	var lock sync.Mutex
 	addMalformed := func(s string) {
@@ -359,95 +412,69 @@ func readResult(parent context.Context, client gcs.Downloader, build gcs.Build)
 		result.malformed = append(result.malformed, s)
 	}
 
-	var work int
-
 	// Download podinfo.json
-	work++
-	go func() {
+	g.Go(func() error {
 		pi, err := build.PodInfo(ctx, client)
 		switch {
-		case errors.Is(err, io.EOF):
+		case malformedJSON(err):
 			addMalformed("podinfo.json")
-			err = nil
 		case err != nil:
-			err = fmt.Errorf("podinfo: %w", err)
+			return fmt.Errorf("podinfo: %w", err)
 		case pi != nil:
 			result.podInfo = *pi
 		}
-		select {
-		case <-ctx.Done():
-		case ec <- err:
-		}
-	}()
+		return nil
+	})
 
 	// Download started.json
-	work++
-	go func() {
+	g.Go(func() error {
 		s, err := build.Started(ctx, client)
 		switch {
-		case errors.Is(err, io.EOF):
+		case malformedJSON(err):
 			addMalformed("started.json")
-			err = nil
 		case err != nil:
-			err = fmt.Errorf("started: %w", err)
+			return fmt.Errorf("started: %w", err)
 		default:
 			result.started = *s
 		}
-		select {
-		case <-ctx.Done():
-		case ec <- err:
-		}
-	}()
+		return nil
+	})
 
 	// Download finished.json
-	work++
-	go func() {
+	g.Go(func() error {
 		f, err := build.Finished(ctx, client)
 		switch {
-		case errors.Is(err, io.EOF):
+		case malformedJSON(err):
 			addMalformed("finished.json")
-			err = nil
 		case err != nil:
-			err = fmt.Errorf("finished: %w", err)
+			return fmt.Errorf("finished: %w", err)
 		default:
 			result.finished = *f
 		}
-		select {
-		case <-ctx.Done():
-		case ec <- err:
-		}
-	}()
+		return nil
+	})
 
 	// Download suites
-	work++
-	go func() {
-		var err error
-		result.suites, err = readSuites(ctx, client, build)
+	g.Go(func() error {
+		suites, err := readSuites(ctx, client, build)
 		var gcsError gcs.Error
 		switch {
 		case errors.As(err, &gcsError):
 			s := strings.TrimPrefix(gcsError.Path.String(), build.Path.String())
 			addMalformed(s)
-			err = nil
 		case err != nil:
-			err = fmt.Errorf("suites: %w", err)
+			return fmt.Errorf("suites: %w", err)
+		default:
+			result.suites = suites
 		}
+		return nil
+	})
 
-		select {
-		case <-ctx.Done():
-		case ec <- err:
-		}
-	}()
-
-	for ; work > 0; work-- {
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("timeout: %w", ctx.Err())
-		case err := <-ec:
-			if err != nil {
-				return nil, err
-			}
-		}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := parent.Err(); err != nil {
+		return nil, fmt.Errorf("timeout: %w", err)
 	}
 	sort.Slice(result.malformed, func(i, j int) bool {
 		return result.malformed[i] < result.malformed[j]
@@ -455,71 +482,42 @@ func readResult(parent context.Context, client gcs.Downloader, build gcs.Build)
 	return &result, nil
 }
 
-// readSuites asynchrounously lists and downloads junit.xml files
+// readSuites lists and downloads junit.xml files concurrently.
 func readSuites(parent context.Context, client gcs.Downloader, build gcs.Build) ([]gcs.SuitesMeta, error) {
-	var wg sync.WaitGroup
-	defer wg.Wait()
-	var work int
-	ctx, cancel := context.WithCancel(parent)
-	defer cancel()
-	ec := make(chan error)
+	g, ctx := errgroup.WithContext(parent)
+
 	// List artifacts to the artifacts channel
 	artifacts := make(chan string) // Receives names of arifacts
-	work++
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+	g.Go(func() error {
 		defer close(artifacts) // No more artifacts
-		err := build.Artifacts(ctx, client, artifacts)
-		if err != nil {
-			err = fmt.Errorf("list: %w", err)
+		if err := build.Artifacts(ctx, client, artifacts); err != nil {
+			return fmt.Errorf("list: %w", err)
 		}
-		select {
-		case ec <- err:
-		case <-ctx.Done():
-		}
-	}()
+		return nil
+	})
 
 	// Download each artifact
 	// With parallelism: 60s without: 220s
 	suitesChan := make(chan gcs.SuitesMeta)
-	work++
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+	g.Go(func() error {
 		defer close(suitesChan) // No more rows
-		err := build.Suites(ctx, client, artifacts, suitesChan)
-		if err != nil {
-			err = fmt.Errorf("download: %w", err)
-		}
-
-		select {
-		case ec <- err:
-		case <-ctx.Done():
+		if err := build.Suites(ctx, client, artifacts, suitesChan); err != nil {
+			return fmt.Errorf("download: %w", err)
 		}
-	}()
+		return nil
+	})
 
+	// Add each downloaded artifact to the returned list.
 	var suites []gcs.SuitesMeta
-	for work > 0 {
-		// Add each downloaded artifact to the returned list.
-
-		// Abort if we get an expired context and/or an error.
-		// Otherwise keep going until the channel closes
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("timeout: %w", ctx.Err())
-		case err := <-ec:
-			if err != nil {
-				return nil, err // already wrapped.
-			}
-			work--
-		case suite, more := <-suitesChan:
-			if !more {
-				return suites, nil
-			}
-			suite.Suites.Truncate(1000)
-			suites = append(suites, suite)
-		}
+	for suite := range suitesChan {
+		// Keep whole messages until cells spill them and link their issues.
+		suites = append(suites, suite)
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := parent.Err(); err != nil {
+		return nil, fmt.Errorf("timeout: %w", err)
 	}
 	return suites, nil
 }
@@ -21,9 +21,11 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"net/url"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -191,7 +193,7 @@ func TestHintStarted(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			gotHint, gotWhen := hintStarted(tc.cols)
+			gotHint, gotWhen := hintStarted(tc.cols, buildLess(nil))
 			if tc.wantHint != gotHint {
 				t.Errorf("hintStarted() got hint %q, want %q", gotHint, tc.wantHint)
 			}
@@ -210,6 +212,7 @@ func TestReadColumns(t *testing.T) {
 		name        string
 		ctx         context.Context
 		builds      []fakeBuild
+		sourced     []fakeBuild
 		group       configpb.TestGroup
 		max         int
 		stop        time.Time
@@ -292,6 +295,140 @@ func TestReadColumns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "record metadata violations in strict mode",
+			builds: []fakeBuild{
+				{
+					id: "10",
+					started: &fakeObject{
+						Data: fmt.Sprintf(`{"timestamp": %d, "job-version": "v1"}`, now+10),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix:      "bucket/path/to/build/",
+				StrictMetadata: true,
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "10",
+						Hint:    "10",
+						Started: float64(now+10) * 1000,
+						MetadataViolations: []string{
+							`started.json: "job-version" is deprecated, use repo-commit`,
+						},
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 10 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+			},
+		},
+		{
+			name: "label and filter merged sources",
+			builds: []fakeBuild{
+				{
+					id: "11",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 11}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+						}),
+					},
+				},
+			},
+			sourced: []fakeBuild{
+				{
+					id: "13",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 13}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+							Metadata:  metadata.Metadata{"merged": "true"},
+						}),
+					},
+				},
+				{
+					id: "12",
+					started: &fakeObject{
+						Data: jsonData(metadata.Started{Timestamp: now + 12}),
+					},
+					finished: &fakeObject{
+						Data: jsonData(metadata.Finished{
+							Timestamp: pint64(now + 20),
+							Passed:    &yes,
+							Metadata:  metadata.Metadata{"merged": "false"},
+						}),
+					},
+				},
+			},
+			group: configpb.TestGroup{
+				GcsPrefix:  "bucket/path/to/build/",
+				SourceName: "postsubmit",
+				MergedSources: []*configpb.TestGroup_MergedSource{
+					{
+						Name:            "presubmit",
+						GcsPrefix:       "bucket/path/to/presubmit/",
+						MetadataFilters: map[string]string{"merged": "true"},
+					},
+				},
+			},
+			expected: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "11",
+						Hint:    "11",
+						Started: float64(now+11) * 1000,
+						Source:  "postsubmit",
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 9 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "13",
+						Hint:    "13",
+						Started: float64(now+13) * 1000,
+						Source:  "presubmit",
+					},
+					Cells: map[string]cell{
+						overallRow: {
+							Result: statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{
+								"test-duration-minutes": 7 / 60.0,
+							},
+						},
+						podInfoRow: podInfoMissingCell,
+					},
+				},
+			},
+		},
 		{
 			name: "column headers processed correctly",
 			builds: []fakeBuild{
@@ -618,9 +755,10 @@ func TestReadColumns(t *testing.T) {
 				},
 				{
 					Column: &statepb.Column{
-						Build:   "12",
-						Hint:    "12",
-						Started: float64(now+12) * 1000,
+						Build:       "12",
+						Hint:        "12",
+						Started:     float64(now+12) * 1000,
+						OlderBuilds: 2,
 					},
 					Cells: map[string]cell{
 						overallRow: {
@@ -837,9 +975,10 @@ func TestReadColumns(t *testing.T) {
 				},
 				{
 					Column: &statepb.Column{
-						Build:   "12",
-						Hint:    "12",
-						Started: float64(now+12) * 1000,
+						Build:       "12",
+						Hint:        "12",
+						Started:     float64(now+12) * 1000,
+						OlderBuilds: 2,
 					},
 					Cells: map[string]cell{
 						overallRow: {
@@ -879,6 +1018,10 @@ func TestReadColumns(t *testing.T) {
 			}
 
 			builds := addBuilds(&client, path, tc.builds...)
+			if len(tc.sourced) > 0 {
+				sourcePath := newPathOrDie("gs://" + tc.group.MergedSources[0].GcsPrefix)
+				builds = append(builds, addBuilds(&client, sourcePath, tc.sourced...)...)
+			}
 
 			if tc.concurrency == 0 {
 				tc.concurrency = 1
@@ -1215,6 +1358,23 @@ func TestReadResult(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "hostile files report malformed",
+			data: map[string]fakeObject{
+				"finished.json":      {Data: `{"passed": "yes"}`},
+				"started.json":       {Data: `{"timestamp": 1e400}`},
+				"podinfo.json":       {Data: `{"pod": {`},
+				"junit_super_88.xml": {Data: `<testsuites><testsuite><testcase name="foo"/>`},
+			},
+			expected: &gcsResult{
+				malformed: []string{
+					"finished.json",
+					"junit_super_88.xml",
+					"podinfo.json",
+					"started.json",
+				},
+			},
+		},
 		{
 			name: "missing started.json reports pending",
 			data: map[string]fakeObject{
@@ -1397,6 +1557,7 @@ func newPathOrDie(s string) gcs.Path {
 
 func TestReadSuites(t *testing.T) {
 	path := newPathOrDie("gs://bucket/path/to/build/")
+	long := strings.Repeat("x", 5000)
 	cases := []struct {
 		name       string
 		data       map[string]fakeObject
@@ -1483,6 +1644,32 @@ func TestReadSuites(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keep long messages",
+			data: map[string]fakeObject{
+				"junit.xml": {Data: `<testsuite><testcase name="hi"><failure>` + long + `</failure></testcase></testsuite>`},
+			},
+			expected: []gcs.SuitesMeta{
+				{
+					Suites: junit.Suites{
+						Suites: []junit.Suite{
+							{
+								XMLName: xml.Name{Local: "testsuite"},
+								Results: []junit.Result{
+									{Name: "hi", Failure: &long},
+								},
+							},
+						},
+					},
+					Metadata: map[string]string{
+						"Context":   "",
+						"Thread":    "",
+						"Timestamp": "",
+					},
+					Path: "gs://bucket/path/to/build/junit.xml",
+				},
+			},
+		},
 		{
 			name: "list error returns error",
 			data: map[string]fakeObject{
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"fmt"
+	"regexp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+const defaultRedaction = "[REDACTED]"
+
+type redaction struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+type redactor []redaction
+
+// newRedactor compiles the configured redaction rules.
+func newRedactor(rules []*configpb.Redaction) (redactor, error) {
+	var r redactor
+	for i, rule := range rules {
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("redaction %d: %w", i, err)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = defaultRedaction
+		}
+		r = append(r, redaction{re: re, replacement: replacement})
+	}
+	return r, nil
+}
+
+func (r redactor) redact(s string) string {
+	for _, rule := range r {
+		s = rule.re.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
+
+// redactCell applies the rules to the cell's messages, properties and links.
+func (r redactor) redactCell(c Cell) Cell {
+	c.Message = r.redact(c.Message)
+	c.FullMessage = r.redact(c.FullMessage)
+	c.UserProperty = r.redact(c.UserProperty)
+	if len(c.Properties) > 0 {
+		props := make(map[string]string, len(c.Properties))
+		for k, v := range c.Properties {
+			props[k] = r.redact(v)
+		}
+		c.Properties = props
+	}
+	if len(c.Links) > 0 {
+		links := make([]*statepb.Link, 0, len(c.Links))
+		for _, l := range c.Links {
+			links = append(links, &statepb.Link{
+				Name: r.redact(l.Name),
+				Url:  r.redact(l.Url),
+			})
+		}
+		c.Links = links
+	}
+	if len(c.Parameters) > 0 {
+		params := make([]*statepb.ParameterResult, 0, len(c.Parameters))
+		for _, p := range c.Parameters {
+			params = append(params, &statepb.ParameterResult{
+				Parameters: p.Parameters,
+				Result:     p.Result,
+				Message:    r.redact(p.Message),
+			})
+		}
+		c.Parameters = params
+	}
+	return c
+}
+
+// redactColumns applies the rules to every cell of the columns.
+func (r redactor) redactColumns(cols []InflatedColumn) {
+	if len(r) == 0 {
+		return
+	}
+	for _, col := range cols {
+		for name, cell := range col.Cells {
+			col.Cells[name] = r.redactCell(cell)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+)
+
+func TestNewRedactor(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules []*configpb.Redaction
+		err   bool
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "valid rules",
+			rules: []*configpb.Redaction{
+				{Regex: `token=\S+`},
+				{Regex: `(\w+)\.corp\.example\.com`, Replacement: "${1}.internal"},
+			},
+		},
+		{
+			name: "invalid regex",
+			rules: []*configpb.Redaction{
+				{Regex: `token=(`},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newRedactor(tc.rules)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("newRedactor() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("newRedactor() failed to return an error")
+			}
+		})
+	}
+}
+
+func TestRedactColumns(t *testing.T) {
+	rules := []*configpb.Redaction{
+		{Regex: `token=\S+`},
+		{Regex: `(\w+)\.corp\.example\.com`, Replacement: "${1}.internal"},
+	}
+	cases := []struct {
+		name  string
+		rules []*configpb.Redaction
+		cols  []InflatedColumn
+		want  []InflatedColumn
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "no rules",
+			cols: []InflatedColumn{
+				{Cells: map[string]Cell{"foo": {Message: "token=hunter2"}}},
+			},
+			want: []InflatedColumn{
+				{Cells: map[string]Cell{"foo": {Message: "token=hunter2"}}},
+			},
+		},
+		{
+			name:  "redact messages, properties and links",
+			rules: rules,
+			cols: []InflatedColumn{
+				{
+					Cells: map[string]Cell{
+						"foo": {
+							Result:       statuspb.TestStatus_FAIL,
+							Message:      "login failed with token=hunter2 on db.corp.example.com",
+							UserProperty: "token=abc",
+							Properties:   map[string]string{"host": "ci.corp.example.com", "owner": "node"},
+							Links: []*statepb.Link{
+								{Name: "log", Url: "https://logs.corp.example.com/1?token=xyz"},
+							},
+							Parameters: []*statepb.ParameterResult{
+								{Parameters: "[a]", Message: "token=hunter2"},
+							},
+						},
+						"bar": {
+							Result: statuspb.TestStatus_PASS,
+						},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Cells: map[string]Cell{
+						"foo": {
+							Result:       statuspb.TestStatus_FAIL,
+							Message:      "login failed with [REDACTED] on db.internal",
+							UserProperty: "[REDACTED]",
+							Properties:   map[string]string{"host": "ci.internal", "owner": "node"},
+							Links: []*statepb.Link{
+								{Name: "log", Url: "https://logs.internal/1?[REDACTED]"},
+							},
+							Parameters: []*statepb.ParameterResult{
+								{Parameters: "[a]", Message: "[REDACTED]"},
+							},
+						},
+						"bar": {
+							Result: statuspb.TestStatus_PASS,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := newRedactor(tc.rules)
+			if err != nil {
+				t.Fatalf("newRedactor() got unexpected error: %v", err)
+			}
+			r.redactColumns(tc.cols)
+			if diff := cmp.Diff(tc.want, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("redactColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
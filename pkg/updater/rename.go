@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+type renameRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// renamer maps the old names of renamed tests to their new names.
+type renamer struct {
+	mapping map[string]string
+	rules   []renameRule
+}
+
+// newRenamer compiles the configured rename rules, which the mapping overrides.
+func newRenamer(rules []*configpb.RenameRule, mapping map[string]string) (*renamer, error) {
+	r := renamer{mapping: mapping}
+	for i, rule := range rules {
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("rename rule %d: %w", i, err)
+		}
+		r.rules = append(r.rules, renameRule{re: re, replacement: rule.Replacement})
+	}
+	return &r, nil
+}
+
+// rename returns the new name of the test, if any.
+func (r *renamer) rename(name string) (string, bool) {
+	if to, ok := r.mapping[name]; ok {
+		return to, to != name
+	}
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(name) {
+			continue
+		}
+		to := rule.re.ReplaceAllString(name, rule.replacement)
+		return to, to != name
+	}
+	return "", false
+}
+
+// readRenameMapping returns the JSON object of old to new test names at the path.
+func readRenameMapping(ctx context.Context, opener gcs.Opener, path string) (map[string]string, error) {
+	p, err := gcs.NewPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("bad path: %w", err)
+	}
+	r, err := opener.Open(ctx, *p)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	var mapping map[string]string
+	if err := json.Unmarshal(buf, &mapping); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return mapping, nil
+}
+
+// detectRenames returns the tests which stop in the column just before a
+// test with a similar enough name starts, mapped to that name.
+//
+// Each test pairs with at most one other, preferring the most similar name.
+func detectRenames(cols []InflatedColumn, similarity float64) map[string]string {
+	if similarity <= 0 {
+		return nil
+	}
+	order := make([]int, len(cols))
+	for i := range order {
+		order[i] = i
+	}
+	// Order columns from newest to oldest, regardless of the group's sort.
+	sort.SliceStable(order, func(i, j int) bool {
+		return cols[order[i]].Column.Started > cols[order[j]].Column.Started
+	})
+	newest := map[string]int{}
+	oldest := map[string]int{}
+	for pos, idx := range order {
+		for name := range cols[idx].Cells {
+			if isMetadataRow(name) {
+				continue
+			}
+			if _, ok := newest[name]; !ok {
+				newest[name] = pos
+			}
+			oldest[name] = pos
+		}
+	}
+
+	type candidate struct {
+		from, to string
+		score    float64
+	}
+	var candidates []candidate
+	for from, last := range newest {
+		if last == 0 {
+			continue // Still running.
+		}
+		for to, first := range oldest {
+			if first != last-1 {
+				continue
+			}
+			if score := nameSimilarity(from, to); score >= similarity {
+				candidates = append(candidates, candidate{from, to, score})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].from != candidates[j].from {
+			return candidates[i].from < candidates[j].from
+		}
+		return candidates[i].to < candidates[j].to
+	})
+	renames := map[string]string{}
+	taken := map[string]bool{}
+	for _, c := range candidates {
+		if _, ok := renames[c.from]; ok || taken[c.to] {
+			continue
+		}
+		renames[c.from] = c.to
+		taken[c.to] = true
+	}
+	return renames
+}
+
+// nameSimilarity returns one minus the edit distance between the names
+// relative to the longer one, so identical names score 1.
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// stitchRenames moves the cells of renamed tests to their new names, keeping
+// any cell the new name already has, and returns the number of tests moved.
+//
+// Detected renames only apply to tests without an explicit mapping or rule.
+func stitchRenames(r *renamer, detected map[string]string, cols []InflatedColumn) int {
+	renames := map[string]string{}
+	for _, col := range cols {
+		for name := range col.Cells {
+			if _, ok := renames[name]; ok || isMetadataRow(name) {
+				continue
+			}
+			to, ok := r.rename(name)
+			if !ok {
+				to, ok = detected[name]
+			}
+			if ok {
+				renames[name] = to
+			}
+		}
+	}
+	for _, col := range cols {
+		for from, to := range renames {
+			cell, ok := col.Cells[from]
+			if !ok {
+				continue
+			}
+			delete(col.Cells, from)
+			if _, ok := col.Cells[to]; ok {
+				continue
+			}
+			if cell.ID == from {
+				cell.ID = to
+			}
+			col.Cells[to] = cell
+		}
+	}
+	return len(renames)
+}
+
+// renameTests stitches the history of the group's renamed tests, without the
+// mapping when it cannot be read.
+func renameTests(ctx context.Context, log logrus.FieldLogger, opener gcs.Opener, tg *configpb.TestGroup, cols []InflatedColumn) (int, error) {
+	if len(tg.RenameRules) == 0 && tg.RenameSimilarity <= 0 && tg.RenameMapping == "" {
+		return 0, nil
+	}
+	var mapping map[string]string
+	if tg.RenameMapping != "" {
+		var err error
+		if mapping, err = readRenameMapping(ctx, opener, tg.RenameMapping); err != nil {
+			log.WithError(err).WithField("mapping", tg.RenameMapping).Warning("Failed to read rename mapping")
+		}
+	}
+	r, err := newRenamer(tg.RenameRules, mapping)
+	if err != nil {
+		return 0, err
+	}
+	return stitchRenames(r, detectRenames(cols, float64(tg.RenameSimilarity)), cols), nil
+}
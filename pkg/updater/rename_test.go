@@ -0,0 +1,368 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestRename(t *testing.T) {
+	rules := []*configpb.RenameRule{
+		{Regex: `^TestOld(\w+)$`, Replacement: "TestNew${1}"},
+		{Regex: `^legacy/`, Replacement: "modern/"},
+	}
+	cases := []struct {
+		name    string
+		rules   []*configpb.RenameRule
+		mapping map[string]string
+		test    string
+		want    string
+		renamed bool
+		err     bool
+	}{
+		{
+			name: "basically works",
+			test: "TestOldFoo",
+		},
+		{
+			name:    "first matching rule",
+			rules:   rules,
+			test:    "TestOldFoo",
+			want:    "TestNewFoo",
+			renamed: true,
+		},
+		{
+			name:    "later rule",
+			rules:   rules,
+			test:    "legacy/foo",
+			want:    "modern/foo",
+			renamed: true,
+		},
+		{
+			name:  "no matching rule",
+			rules: rules,
+			test:  "TestFoo",
+		},
+		{
+			name:    "mapping overrides rules",
+			rules:   rules,
+			mapping: map[string]string{"TestOldFoo": "TestFooRenamed"},
+			test:    "TestOldFoo",
+			want:    "TestFooRenamed",
+			renamed: true,
+		},
+		{
+			name:    "mapping to itself keeps the name",
+			rules:   rules,
+			mapping: map[string]string{"TestOldFoo": "TestOldFoo"},
+			test:    "TestOldFoo",
+			want:    "TestOldFoo",
+		},
+		{
+			name:  "invalid regex",
+			rules: []*configpb.RenameRule{{Regex: `(`}},
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := newRenamer(tc.rules, tc.mapping)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("newRenamer() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("newRenamer() failed to return an error")
+			}
+			got, renamed := r.rename(tc.test)
+			if got != tc.want || renamed != tc.renamed {
+				t.Errorf("rename(%q) got %q, %t, want %q, %t", tc.test, got, renamed, tc.want, tc.renamed)
+			}
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "", b: "", want: 1},
+		{a: "same", b: "same", want: 1},
+		{a: "abcd", b: "wxyz", want: 0},
+		{a: "abcd", b: "abce", want: 0.75},
+		{a: "abc", b: "abcdef", want: 0.5},
+	}
+
+	for _, tc := range cases {
+		if got := nameSimilarity(tc.a, tc.b); got != tc.want {
+			t.Errorf("nameSimilarity(%q, %q) got %f, want %f", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func renameColumns(starts ...map[string]bool) []InflatedColumn {
+	var cols []InflatedColumn
+	for i, names := range starts {
+		cells := map[string]Cell{}
+		for name := range names {
+			cells[name] = Cell{Result: statuspb.TestStatus_PASS, ID: name}
+		}
+		cols = append(cols, InflatedColumn{
+			Column: &statepb.Column{Started: float64(len(starts) - i)},
+			Cells:  cells,
+		})
+	}
+	return cols
+}
+
+func TestDetectRenames(t *testing.T) {
+	cases := []struct {
+		name       string
+		cols       []InflatedColumn
+		similarity float64
+		want       map[string]string
+	}{
+		{
+			name: "basically works",
+		},
+		{
+			name: "detection disabled",
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true},
+				map[string]bool{"TestFooBaz": true},
+			),
+		},
+		{
+			name: "detect renames",
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true, "TestOther": true, overallRow: true},
+				map[string]bool{"TestFooBaz": true, "TestOther": true, overallRow: true},
+				map[string]bool{"TestFooBaz": true, "TestOther": true, overallRow: true},
+			),
+			similarity: 0.8,
+			want:       map[string]string{"TestFooBaz": "TestFooBar"},
+		},
+		{
+			name: "ignore dissimilar names",
+			cols: renameColumns(
+				map[string]bool{"TestSomethingElse": true},
+				map[string]bool{"TestFooBaz": true},
+			),
+			similarity: 0.8,
+			want:       map[string]string{},
+		},
+		{
+			name: "require adjacent columns",
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true},
+				map[string]bool{"TestOther": true},
+				map[string]bool{"TestFooBaz": true},
+			),
+			similarity: 0.8,
+			want:       map[string]string{},
+		},
+		{
+			name: "ignore tests which still run",
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true, "TestFooBaz": true},
+				map[string]bool{"TestFooBaz": true},
+			),
+			similarity: 0.8,
+			want:       map[string]string{},
+		},
+		{
+			name: "prefer the most similar name",
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true, "TestFooBazz": true},
+				map[string]bool{"TestFooBaz": true},
+			),
+			similarity: 0.8,
+			want:       map[string]string{"TestFooBaz": "TestFooBazz"},
+		},
+		{
+			name: "regardless of column order",
+			cols: func() []InflatedColumn {
+				cols := renameColumns(
+					map[string]bool{"TestFooBar": true},
+					map[string]bool{"TestFooBaz": true},
+				)
+				return []InflatedColumn{cols[1], cols[0]}
+			}(),
+			similarity: 0.8,
+			want:       map[string]string{"TestFooBaz": "TestFooBar"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := detectRenames(tc.cols, tc.similarity)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("detectRenames() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRenameTests(t *testing.T) {
+	mappingPath := "gs://bucket/renames.json"
+	path, err := gcs.NewPath(mappingPath)
+	if err != nil {
+		t.Fatalf("bad path: %v", err)
+	}
+	cases := []struct {
+		name    string
+		group   *configpb.TestGroup
+		opener  fake.Opener
+		cols    []InflatedColumn
+		want    []InflatedColumn
+		renamed int
+		err     bool
+	}{
+		{
+			name:  "basically works",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name: "stitch rule renames",
+			group: &configpb.TestGroup{
+				RenameRules: []*configpb.RenameRule{{Regex: `^TestOld(\w+)$`, Replacement: "TestNew${1}"}},
+			},
+			cols: renameColumns(
+				map[string]bool{"TestNewFoo": true, overallRow: true},
+				map[string]bool{"TestOldFoo": true, overallRow: true},
+			),
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{Started: 2},
+					Cells: map[string]Cell{
+						"TestNewFoo": {Result: statuspb.TestStatus_PASS, ID: "TestNewFoo"},
+						overallRow:   {Result: statuspb.TestStatus_PASS, ID: overallRow},
+					},
+				},
+				{
+					Column: &statepb.Column{Started: 1},
+					Cells: map[string]Cell{
+						"TestNewFoo": {Result: statuspb.TestStatus_PASS, ID: "TestNewFoo"},
+						overallRow:   {Result: statuspb.TestStatus_PASS, ID: overallRow},
+					},
+				},
+			},
+			renamed: 1,
+		},
+		{
+			name: "keep cells of the new name",
+			group: &configpb.TestGroup{
+				RenameRules: []*configpb.RenameRule{{Regex: `^TestOld(\w+)$`, Replacement: "TestNew${1}"}},
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{Started: 1},
+					Cells: map[string]Cell{
+						"TestNewFoo": {Result: statuspb.TestStatus_FAIL, ID: "TestNewFoo"},
+						"TestOldFoo": {Result: statuspb.TestStatus_PASS, ID: "TestOldFoo"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{Started: 1},
+					Cells: map[string]Cell{
+						"TestNewFoo": {Result: statuspb.TestStatus_FAIL, ID: "TestNewFoo"},
+					},
+				},
+			},
+			renamed: 1,
+		},
+		{
+			name: "stitch mapped and detected renames",
+			group: &configpb.TestGroup{
+				RenameMapping:    mappingPath,
+				RenameSimilarity: 0.8,
+			},
+			opener: fake.Opener{*path: {Data: `{"legacy": "modern"}`}},
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true, "modern": true},
+				map[string]bool{"TestFooBaz": true, "legacy": true},
+			),
+			want: renameColumns(
+				map[string]bool{"TestFooBar": true, "modern": true},
+				map[string]bool{"TestFooBar": true, "modern": true},
+			),
+			renamed: 2,
+		},
+		{
+			name: "continue without a bad mapping",
+			group: &configpb.TestGroup{
+				RenameMapping:    mappingPath,
+				RenameSimilarity: 0.8,
+			},
+			opener: fake.Opener{*path: {Data: `not json`}},
+			cols: renameColumns(
+				map[string]bool{"TestFooBar": true, "modern": true},
+				map[string]bool{"TestFooBaz": true, "legacy": true},
+			),
+			want: renameColumns(
+				map[string]bool{"TestFooBar": true, "modern": true},
+				map[string]bool{"TestFooBar": true, "legacy": true},
+			),
+			renamed: 1,
+		},
+		{
+			name: "invalid rule",
+			group: &configpb.TestGroup{
+				RenameRules: []*configpb.RenameRule{{Regex: `(`}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			renamed, err := renameTests(context.Background(), logrus.WithField("name", tc.name), tc.opener, tc.group, tc.cols)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("renameTests() got unexpected error: %v", err)
+				}
+				return
+			case tc.err:
+				t.Fatal("renameTests() failed to return an error")
+			}
+			if renamed != tc.renamed {
+				t.Errorf("renameTests() got %d renamed, want %d", renamed, tc.renamed)
+			}
+			if diff := cmp.Diff(tc.want, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("renameTests() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"fmt"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// sampleBuilds returns the builds, sorted newest first, which the sampling
+// selects, along with the number of builds skipped before each selected
+// build, keyed by its name.
+//
+// The builds are those listed since the newest old column, oldest last, so
+// every_nth_build counts from that column and each update selects the same
+// builds. max_builds_per_hour then limits the started builds of each hour,
+// counting the old columns, and reads the started.json of each build which
+// every_nth_build keeps. Builds which have not started yet do not count
+// toward any hour.
+//
+// Builds newer than the newest selected build are dropped without counting
+// them, since the next update lists them again.
+func sampleBuilds(ctx context.Context, opener gcs.Opener, sampling *configpb.TestGroup_ResultSampling, builds []gcs.Build, oldCols []InflatedColumn) ([]gcs.Build, map[string]int32, error) {
+	every := int(sampling.GetEveryNthBuild())
+	perHour := int(sampling.GetMaxBuildsPerHour())
+	if every <= 1 && perHour <= 0 {
+		return builds, nil, nil
+	}
+
+	hours := map[int64]int{}
+	for _, col := range oldCols {
+		hours[int64(col.Column.Started/1000)/3600]++
+	}
+
+	var sampled []gcs.Build
+	skipped := map[string]int32{}
+	var n, skips int
+	// Builds are sorted newest first, so count from the oldest.
+	for i := len(builds) - 1; i >= 0; i-- {
+		b := builds[i]
+		n++
+		if every > 1 && n%every != 0 {
+			skips++
+			continue
+		}
+		if perHour > 0 {
+			started, err := b.Started(ctx, opener)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: started: %w", b, err)
+			}
+			if !started.Pending {
+				hour := started.Timestamp / 3600
+				if hours[hour] >= perHour {
+					skips++
+					continue
+				}
+				hours[hour]++
+			}
+		}
+		sampled = append(sampled, b)
+		if skips > 0 {
+			skipped[b.Build()] = int32(skips)
+		}
+		skips = 0
+	}
+
+	// Return the newest first.
+	for i, j := 0, len(sampled)-1; i < j; i, j = i+1, j-1 {
+		sampled[i], sampled[j] = sampled[j], sampled[i]
+	}
+	return sampled, skipped, nil
+}
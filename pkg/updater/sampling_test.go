@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func TestSampleBuilds(t *testing.T) {
+	const hour = 3600
+	cases := []struct {
+		name        string
+		sampling    *configpb.TestGroup_ResultSampling
+		builds      []fakeBuild
+		oldCols     []InflatedColumn
+		want        []string
+		wantSkipped map[string]int32
+	}{
+		{
+			name: "no sampling",
+			builds: []fakeBuild{
+				{id: "2"},
+				{id: "1"},
+			},
+			want: []string{"2", "1"},
+		},
+		{
+			name:     "every nth build",
+			sampling: &configpb.TestGroup_ResultSampling{EveryNthBuild: 3},
+			builds: []fakeBuild{
+				{id: "7"},
+				{id: "6"},
+				{id: "5"},
+				{id: "4"},
+				{id: "3"},
+				{id: "2"},
+				{id: "1"},
+			},
+			want: []string{"6", "3"},
+			wantSkipped: map[string]int32{
+				"6": 2,
+				"3": 2,
+			},
+		},
+		{
+			name:     "builds per hour",
+			sampling: &configpb.TestGroup_ResultSampling{MaxBuildsPerHour: 2},
+			builds: []fakeBuild{
+				{id: "5"},
+				{id: "4", started: jsonStarted(10*hour + hour)},
+				{id: "3", started: jsonStarted(10*hour + 1200)},
+				{id: "2", started: jsonStarted(10*hour + 600)},
+				{id: "1", started: jsonStarted(10 * hour)},
+			},
+			oldCols: []InflatedColumn{
+				{Column: &statepb.Column{Build: "0", Started: 10 * hour * 1000}},
+			},
+			want: []string{"5", "4", "1"},
+			wantSkipped: map[string]int32{
+				"4": 2,
+			},
+		},
+		{
+			name:     "only read the started builds every nth build keeps",
+			sampling: &configpb.TestGroup_ResultSampling{EveryNthBuild: 2, MaxBuildsPerHour: 5},
+			builds: []fakeBuild{
+				{id: "4", started: jsonStarted(10*hour + 1800)},
+				{id: "3", started: &fakeObject{OpenErr: errors.New("should not read")}},
+				{id: "2", started: jsonStarted(10 * hour)},
+				{id: "1", started: &fakeObject{OpenErr: errors.New("should not read")}},
+			},
+			want: []string{"4", "2"},
+			wantSkipped: map[string]int32{
+				"4": 1,
+				"2": 1,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeClient{
+				Lister: fake.Lister{},
+				Opener: fake.Opener{},
+			}
+			path := newPathOrDie("gs://bucket/job/")
+			builds := addBuilds(&client, path, tc.builds...)
+			got, skipped, err := sampleBuilds(context.Background(), client, tc.sampling, builds, tc.oldCols)
+			if err != nil {
+				t.Fatalf("sampleBuilds() got unexpected error: %v", err)
+			}
+			var names []string
+			for _, b := range got {
+				names = append(names, b.Build())
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Errorf("sampleBuilds() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if len(tc.wantSkipped) == 0 && len(skipped) == 0 {
+				return
+			}
+			if diff := cmp.Diff(tc.wantSkipped, skipped); diff != "" {
+				t.Errorf("sampleBuilds() got unexpected skipped diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"regexp"
+	"strings"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// mergedSource labels and filters the builds of an additional result source.
+type mergedSource struct {
+	name    string
+	path    gcs.Path
+	filters map[string]*regexp.Regexp
+}
+
+type mergedSources []mergedSource
+
+// newMergedSources parses the configured merged sources.
+//
+// Invalid sources, which config validation rejects, are ignored.
+func newMergedSources(sources []*configpb.TestGroup_MergedSource) mergedSources {
+	var out mergedSources
+	for _, src := range sources {
+		p, err := prefixPath(strings.TrimSpace(src.GcsPrefix))
+		if err != nil || src.GcsPrefix == "" {
+			continue
+		}
+		ms := mergedSource{name: src.Name, path: *p, filters: map[string]*regexp.Regexp{}}
+		valid := true
+		for key, expr := range src.MetadataFilters {
+			re, err := regexp.Compile("^(?:" + expr + ")$")
+			if err != nil {
+				valid = false
+				break
+			}
+			ms.filters[key] = re
+		}
+		if valid {
+			out = append(out, ms)
+		}
+	}
+	return out
+}
+
+// paths returns the path of each source.
+func (s mergedSources) paths() []gcs.Path {
+	out := make([]gcs.Path, 0, len(s))
+	for _, src := range s {
+		out = append(out, src.path)
+	}
+	return out
+}
+
+// source returns the source containing the build, or nil for the group's own builds.
+func (s mergedSources) source(build gcs.Build) *mergedSource {
+	p := build.Path.String()
+	for i, src := range s {
+		if strings.HasPrefix(p, src.path.String()) {
+			return &s[i]
+		}
+	}
+	return nil
+}
+
+// keep returns true when the build's metadata matches every filter of the source.
+//
+// Finished metadata takes precedence over the deprecated started metadata.
+func (s mergedSource) keep(result gcsResult) bool {
+	finished := result.finished.Metadata.Strings()
+	started := result.started.Metadata.Strings()
+	for key, re := range s.filters {
+		val, ok := finished[key]
+		if !ok {
+			val, ok = started[key]
+		}
+		if !ok || !re.MatchString(val) {
+			return false
+		}
+	}
+	return true
+}
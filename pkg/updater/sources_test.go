@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func TestMergedSources(t *testing.T) {
+	sources := newMergedSources([]*configpb.TestGroup_MergedSource{
+		{Name: "presubmit", GcsPrefix: "bucket/presubmit"},
+		{Name: "branch", GcsPrefix: "bucket/branch/", MetadataFilters: map[string]string{"bad": "("}},
+		{Name: "empty"},
+		{Name: "release", GcsPrefix: "bucket/release"},
+	})
+	if n := len(sources); n != 2 {
+		t.Fatalf("newMergedSources() got %d sources, want 2", n)
+	}
+
+	cases := []struct {
+		name  string
+		build string
+		want  string
+	}{
+		{
+			name:  "own build",
+			build: "gs://bucket/postsubmit/123/",
+		},
+		{
+			name:  "presubmit build",
+			build: "gs://bucket/presubmit/456/",
+			want:  "presubmit",
+		},
+		{
+			name:  "release build",
+			build: "gs://bucket/release/789/",
+			want:  "release",
+		},
+		{
+			name:  "do not match similar prefixes",
+			build: "gs://bucket/presubmit-other/456/",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := gcs.NewPath(tc.build)
+			if err != nil {
+				t.Fatalf("gcs.NewPath(%q): %v", tc.build, err)
+			}
+			var got string
+			if src := sources.source(gcs.Build{Path: *p}); src != nil {
+				got = src.name
+			}
+			if got != tc.want {
+				t.Errorf("source(%s) got %q, want %q", tc.build, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergedSourceKeep(t *testing.T) {
+	src := newMergedSources([]*configpb.TestGroup_MergedSource{
+		{
+			Name:            "presubmit",
+			GcsPrefix:       "bucket/presubmit",
+			MetadataFilters: map[string]string{"merged": "true", "base": "main|master"},
+		},
+	})[0]
+	result := func(started, finished metadata.Metadata) gcsResult {
+		var r gcsResult
+		r.started.Metadata = started
+		r.finished.Metadata = finished
+		return r
+	}
+	cases := []struct {
+		name   string
+		result gcsResult
+		want   bool
+	}{
+		{
+			name: "missing metadata",
+		},
+		{
+			name:   "matching metadata",
+			result: result(nil, metadata.Metadata{"merged": "true", "base": "main"}),
+			want:   true,
+		},
+		{
+			name:   "filters fully match",
+			result: result(nil, metadata.Metadata{"merged": "true", "base": "main-old"}),
+		},
+		{
+			name:   "fall back to started metadata",
+			result: result(metadata.Metadata{"base": "master"}, metadata.Metadata{"merged": "true"}),
+			want:   true,
+		},
+		{
+			name:   "finished metadata takes precedence",
+			result: result(metadata.Metadata{"merged": "true"}, metadata.Metadata{"merged": "false", "base": "main"}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := src.keep(tc.result); got != tc.want {
+				t.Errorf("keep() got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
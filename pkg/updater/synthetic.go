@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"strings"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+// syntheticRowName returns the display name of the synthetic row, or an empty string if it is disabled.
+func syntheticRowName(row *configpb.TestGroup_SyntheticRow, def string) string {
+	switch {
+	case row.GetDisable():
+		return ""
+	case row.GetName() != "":
+		return row.GetName()
+	}
+	return def
+}
+
+// OverallRowName returns the name of the overall row the updater adds to each
+// column of the group, or an empty string if it is disabled.
+func OverallRowName(group *configpb.TestGroup) string {
+	return syntheticRowName(group.GetOverallRow(), overallRow)
+}
+
+// SyntheticRow returns the configuration of the named row if the updater added it to each column.
+//
+// The per-job rows of groups with multiple jobs also match.
+func SyntheticRow(group *configpb.TestGroup, name string) (*configpb.TestGroup_SyntheticRow, bool) {
+	multiJob := strings.Contains(group.GetGcsPrefix(), ",")
+	for _, row := range []struct {
+		cfg *configpb.TestGroup_SyntheticRow
+		def string
+	}{
+		{group.GetOverallRow(), overallRow},
+		{group.GetPodRow(), podInfoRow},
+	} {
+		n := syntheticRowName(row.cfg, row.def)
+		if n == "" {
+			continue
+		}
+		if name == n || multiJob && strings.HasSuffix(name, "."+n) {
+			if row.cfg == nil {
+				return &configpb.TestGroup_SyntheticRow{}, true
+			}
+			return row.cfg, true
+		}
+	}
+	return nil, false
+}
+
+// HealthRows returns the rows which count toward tab health.
+func HealthRows(group *configpb.TestGroup, rows []*statepb.Row) []*statepb.Row {
+	out := rows[:0:0]
+	for _, row := range rows {
+		if cfg, ok := SyntheticRow(group, row.Name); ok && cfg.ExcludeFromHealth {
+			continue
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// positionRows moves synthetic rows to their configured position, preserving the order of the other rows.
+func positionRows(group *configpb.TestGroup, rows []*statepb.Row) {
+	var top, middle, bottom []*statepb.Row
+	for _, row := range rows {
+		cfg, _ := SyntheticRow(group, row.Name)
+		switch cfg.GetPosition() {
+		case configpb.TestGroup_SyntheticRow_TOP:
+			top = append(top, row)
+		case configpb.TestGroup_SyntheticRow_BOTTOM:
+			bottom = append(bottom, row)
+		default:
+			middle = append(middle, row)
+		}
+	}
+	n := copy(rows, top)
+	n += copy(rows[n:], middle)
+	copy(rows[n:], bottom)
+}
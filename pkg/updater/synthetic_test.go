@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
+	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+)
+
+func TestSyntheticRow(t *testing.T) {
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		row   string
+		want  bool
+	}{
+		{
+			name:  "overall by default",
+			group: &configpb.TestGroup{},
+			row:   overallRow,
+			want:  true,
+		},
+		{
+			name:  "pod by default",
+			group: &configpb.TestGroup{},
+			row:   podInfoRow,
+			want:  true,
+		},
+		{
+			name:  "tests are not synthetic",
+			group: &configpb.TestGroup{},
+			row:   "foo.Overall",
+		},
+		{
+			name:  "per-job rows of multiple jobs",
+			group: &configpb.TestGroup{GcsPrefix: "bucket/a,bucket/b"},
+			row:   "a.Overall",
+			want:  true,
+		},
+		{
+			name:  "renamed",
+			group: &configpb.TestGroup{OverallRow: &configpb.TestGroup_SyntheticRow{Name: "Build"}},
+			row:   "Build",
+			want:  true,
+		},
+		{
+			name:  "renamed rows lose their default name",
+			group: &configpb.TestGroup{OverallRow: &configpb.TestGroup_SyntheticRow{Name: "Build"}},
+			row:   overallRow,
+		},
+		{
+			name:  "disabled",
+			group: &configpb.TestGroup{PodRow: &configpb.TestGroup_SyntheticRow{Disable: true}},
+			row:   podInfoRow,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, got := SyntheticRow(tc.group, tc.row); got != tc.want {
+				t.Errorf("SyntheticRow(%q) got %t, want %t", tc.row, got, tc.want)
+			}
+		})
+	}
+}
+
+func rowNames(rows []*statepb.Row) []string {
+	var out []string
+	for _, r := range rows {
+		out = append(out, r.Name)
+	}
+	return out
+}
+
+func TestOverallRowName(t *testing.T) {
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		want  string
+	}{
+		{
+			name:  "default",
+			group: &configpb.TestGroup{},
+			want:  overallRow,
+		},
+		{
+			name:  "renamed",
+			group: &configpb.TestGroup{OverallRow: &configpb.TestGroup_SyntheticRow{Name: "Build"}},
+			want:  "Build",
+		},
+		{
+			name:  "disabled",
+			group: &configpb.TestGroup{OverallRow: &configpb.TestGroup_SyntheticRow{Name: "Build", Disable: true}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := OverallRowName(tc.group); got != tc.want {
+				t.Errorf("OverallRowName() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHealthRows(t *testing.T) {
+	rows := []*statepb.Row{{Name: overallRow}, {Name: podInfoRow}, {Name: "test"}}
+	group := &configpb.TestGroup{
+		OverallRow: &configpb.TestGroup_SyntheticRow{ExcludeFromHealth: true},
+	}
+	want := []string{podInfoRow, "test"}
+	if diff := cmp.Diff(want, rowNames(HealthRows(group, rows))); diff != "" {
+		t.Errorf("HealthRows() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if len(rows) != 3 || rows[0].Name != overallRow {
+		t.Errorf("HealthRows() modified its input: %v", rowNames(rows))
+	}
+}
+
+func TestPositionRows(t *testing.T) {
+	cases := []struct {
+		name  string
+		group *configpb.TestGroup
+		want  []string
+	}{
+		{
+			name:  "sorted by default",
+			group: &configpb.TestGroup{},
+			want:  []string{"a", overallRow, podInfoRow, "z"},
+		},
+		{
+			name: "top and bottom",
+			group: &configpb.TestGroup{
+				OverallRow: &configpb.TestGroup_SyntheticRow{Position: configpb.TestGroup_SyntheticRow_TOP},
+				PodRow:     &configpb.TestGroup_SyntheticRow{Position: configpb.TestGroup_SyntheticRow_BOTTOM},
+			},
+			want: []string{overallRow, "a", "z", podInfoRow},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := []*statepb.Row{{Name: "a"}, {Name: overallRow}, {Name: podInfoRow}, {Name: "z"}}
+			positionRows(tc.group, rows)
+			if diff := cmp.Diff(tc.want, rowNames(rows)); diff != "" {
+				t.Errorf("positionRows() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
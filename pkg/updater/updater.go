@@ -18,8 +18,6 @@ limitations under the License.
 package updater
 
 import (
-	"bytes"
-	"compress/zlib"
 	"context"
 	"errors"
 	"fmt"
@@ -33,20 +31,35 @@ import (
 	"sync"
 	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/fvbommel/sortorder"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/alert"
 	"github.com/GoogleCloudPlatform/testgrid/internal/result"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
 	statuspb "github.com/GoogleCloudPlatform/testgrid/pb/test_status"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
 	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
 )
 
+// clock returns the current time, unless FixTime pins it.
+var clock = time.Now
+
+// FixTime pins the time used to compute grid state, so that identical inputs
+// always produce identical output, such as when comparing a canary.
+//
+// Call it before any update.
+func FixTime(t time.Time) {
+	clock = func() time.Time { return t }
+}
+
 // GroupUpdater will compile the grid state proto for the specified group and upload it.
 //
 // This typically involves downloading the existing state, dropping old columns,
@@ -55,7 +68,10 @@ import (
 type GroupUpdater func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error
 
 // GCS returns a GCS-based GroupUpdater, which knows how to process result data stored in GCS.
-func GCS(groupTimeout, buildTimeout time.Duration, concurrency int, write bool, sortCols ColumnSorter) GroupUpdater {
+//
+// Each group reads up to buildConcurrency builds at once, and each build
+// downloads up to artifactConcurrency junit files at once (or a default when zero).
+func GCS(groupTimeout, buildTimeout time.Duration, buildConcurrency, artifactConcurrency int, write bool, sortCols ColumnSorter, export ColumnExporter) GroupUpdater {
 	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
 		if !tg.UseKubernetesClient {
 			log.Debug("Skipping non-kubernetes client group")
@@ -63,9 +79,28 @@ func GCS(groupTimeout, buildTimeout time.Duration, concurrency int, write bool,
 		}
 		ctx, cancel := context.WithTimeout(parent, groupTimeout)
 		defer cancel()
-		gcsColReader := gcsColumnReader(client, buildTimeout, concurrency)
+		gcsColReader := gcsColumnReader(client, buildTimeout, buildConcurrency, artifactConcurrency)
 		reprocess := 20 * time.Minute // allow 20m for prow to finish uploading artifacts
-		return InflateDropAppend(ctx, log, client, tg, gridPath, write, gcsColReader, sortCols, reprocess)
+		return InflateDropAppend(ctx, log, client, tg, gridPath, write, gcsColReader, sortCols, reprocess, export)
+	}
+}
+
+// ColumnReaders returns a GroupUpdater which reads the columns of groups that set
+// a column_reader with the reader of that name, such as a plugin, and updates
+// every other group with fallback.
+func ColumnReaders(fallback GroupUpdater, readers map[string]ColumnReader, groupTimeout time.Duration, write bool, sortCols ColumnSorter, export ColumnExporter) GroupUpdater {
+	return func(parent context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path) error {
+		if tg.ColumnReader == "" {
+			return fallback(parent, log, client, tg, gridPath)
+		}
+		readCols, ok := readers[tg.ColumnReader]
+		if !ok {
+			return fmt.Errorf("unknown column reader %q", tg.ColumnReader)
+		}
+		ctx, cancel := context.WithTimeout(parent, groupTimeout)
+		defer cancel()
+		reprocess := 20 * time.Minute // match the GCS reader
+		return InflateDropAppend(ctx, log.WithField("reader", tg.ColumnReader), client, tg, gridPath, write, readCols, sortCols, reprocess, export)
 	}
 }
 
@@ -74,7 +109,7 @@ func sortGroups(ctx context.Context, log logrus.FieldLogger, client gcs.Stater,
 	groupedPaths := make(map[gcs.Path]*configpb.TestGroup, len(groups))
 	paths := make([]gcs.Path, 0, len(groups))
 	for _, tg := range groups {
-		tgp, err := testGroupPath(configPath, gridPrefix, tg.Name)
+		tgp, err := TestGroupPath(configPath, gridPrefix, tg.Name)
 		if err != nil {
 			return nil, fmt.Errorf("%s bad group path: %w", tg.Name, err)
 		}
@@ -124,6 +159,17 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 	}
 	log.WithField("groups", len(cfg.TestGroups)).Info("Updating test groups")
 
+	overlapping := overlappingGroups(cfg.TestGroups)
+	cache := &gcs.FetchCache{MaxBytes: maxFetchCacheBytes}
+	defer func() {
+		hits, misses := cache.Stats()
+		log.WithFields(logrus.Fields{
+			"hits":   hits,
+			"misses": misses,
+			"bytes":  cache.Bytes(),
+		}).Debug("Shared artifacts between overlapping groups")
+	}()
+
 	groups := make(chan configpb.TestGroup)
 	var wg sync.WaitGroup
 	defer wg.Wait()
@@ -137,11 +183,17 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 			for tg := range groups {
 				log := log.WithField("group", tg.Name)
 				log.Debug("Starting update")
-				tgp, err := testGroupPath(configPath, gridPrefix, tg.Name)
+				tgp, err := TestGroupPath(configPath, gridPrefix, tg.Name)
 				if err != nil {
 					log.WithError(err).Error("Bad path")
 					continue
 				}
+				if group == "" {
+					if skip, why := skipGroup(ctx, client, &tg, *tgp, clock()); skip {
+						log.WithField("reason", why).Debug("Skipping group")
+						continue
+					}
+				}
 				if write && generations != nil {
 					if err := lockGroup(ctx, client, *tgp, generations[tg.Name]); err != nil {
 						var ok bool
@@ -159,6 +211,10 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 					}
 					log.Debug("Acquired update lock")
 				}
+				ctx := ctx
+				if overlapping[tg.Name] {
+					ctx = gcs.WithFetchCache(ctx, cache)
+				}
 				if err := updateGroup(ctx, log, client, &tg, *tgp); err != nil {
 					log.WithError(err).Error("Error updating group")
 				}
@@ -195,8 +251,78 @@ func Update(parent context.Context, client gcs.ConditionalClient, configPath gcs
 	return nil
 }
 
-// testGroupPath() returns the path to a test_group proto given this proto
-func testGroupPath(g gcs.Path, gridPrefix, groupName string) (*gcs.Path, error) {
+// maxFetchCacheBytes limits the memory of the artifacts overlapping groups
+// share each cycle, evicting the least recently used ones beyond it.
+const maxFetchCacheBytes = 256 << 20
+
+// overlappingGroups returns the groups which read builds under the same path as another group.
+//
+// These groups share the artifacts they read through a fetch cache.
+func overlappingGroups(groups []*configpb.TestGroup) map[string]bool {
+	type groupPath struct {
+		path  string
+		group string
+	}
+	var paths []groupPath
+	for _, tg := range groups {
+		tgPaths, err := groupPaths(tg)
+		if err != nil {
+			continue
+		}
+		tgPaths = append(tgPaths, newMergedSources(tg.MergedSources).paths()...)
+		for _, p := range tgPaths {
+			s := p.String()
+			if !strings.HasSuffix(s, "/") {
+				s += "/"
+			}
+			paths = append(paths, groupPath{s, tg.Name})
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].path < paths[j].path
+	})
+	out := map[string]bool{}
+	for i, p := range paths {
+		// Sorting places the paths under p immediately after it.
+		for _, q := range paths[i+1:] {
+			if !strings.HasPrefix(q.path, p.path) {
+				break
+			}
+			if q.group != p.group {
+				out[p.group] = true
+				out[q.group] = true
+			}
+		}
+	}
+	return out
+}
+
+// skipGroup returns true, and why, if the group should not update this cycle.
+//
+// Paused groups never update, and others only update once per update_interval.
+func skipGroup(ctx context.Context, client gcs.Stater, tg *configpb.TestGroup, path gcs.Path, now time.Time) (bool, string) {
+	if tg.Paused {
+		return true, "paused"
+	}
+	if tg.UpdateInterval == "" {
+		return false, ""
+	}
+	interval, err := time.ParseDuration(tg.UpdateInterval)
+	if err != nil || interval <= 0 {
+		return false, ""
+	}
+	attrs, err := client.Stat(ctx, path)
+	if err != nil {
+		return false, ""
+	}
+	if age := now.Sub(attrs.Updated); age < interval {
+		return true, fmt.Sprintf("updated %s ago, interval is %s", age.Round(time.Second), interval)
+	}
+	return false, ""
+}
+
+// TestGroupPath returns the path to a test_group proto given this proto
+func TestGroupPath(g gcs.Path, gridPrefix, groupName string) (*gcs.Path, error) {
 	name := path.Join(gridPrefix, groupName)
 	u, err := url.Parse(name)
 	if err != nil {
@@ -259,26 +385,35 @@ func groupPaths(tg *configpb.TestGroup) ([]gcs.Path, error) {
 		if prefix == "" {
 			continue
 		}
-		u, err := url.Parse("gs://" + prefix)
+		p, err := prefixPath(prefix)
 		if err != nil {
-			return nil, fmt.Errorf("parse: %w", err)
-		}
-		if u.Path != "" && u.Path[len(u.Path)-1] != '/' {
-			u.Path += "/"
-		}
-
-		var p gcs.Path
-		if err := p.SetURL(u); err != nil {
 			if idx > 0 {
 				return nil, fmt.Errorf("%d: %s: %w", idx, prefix, err)
 			}
 			return nil, err
 		}
-		out = append(out, p)
+		out = append(out, *p)
 	}
 	return out, nil
 }
 
+// prefixPath returns the directory path of a gcs_prefix (some-bucket/some/optional/path).
+func prefixPath(prefix string) (*gcs.Path, error) {
+	u, err := url.Parse("gs://" + prefix)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if u.Path != "" && u.Path[len(u.Path)-1] != '/' {
+		u.Path += "/"
+	}
+
+	var p gcs.Path
+	if err := p.SetURL(u); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 // truncateRunning filters out all columns until the oldest still running column.
 //
 // If there are 20 columns where all are complete except the 3rd and 7th, this will
@@ -290,7 +425,7 @@ func truncateRunning(cols []InflatedColumn) []InflatedColumn {
 		return cols
 	}
 
-	floor := float64(time.Now().Add(-72*time.Hour).UTC().Unix() * 1000)
+	floor := float64(clock().Add(-72*time.Hour).UTC().Unix() * 1000)
 
 	for i := len(cols) - 1; i >= 0; i-- {
 		if cols[i].Column.Started < floor {
@@ -366,7 +501,8 @@ func truncateBuilds(log logrus.FieldLogger, builds []gcs.Build, cols []InflatedC
 	return builds
 }
 
-func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...gcs.Path) ([]gcs.Build, error) {
+// listBuilds returns the builds of each path newer than since, sorted newest first by less.
+func listBuilds(ctx context.Context, client gcs.Lister, since string, less func(a, b string) bool, paths ...gcs.Path) ([]gcs.Build, error) {
 	var out []gcs.Build
 
 	for idx, tgPath := range paths {
@@ -377,7 +513,7 @@ func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...g
 				return nil, fmt.Errorf("resolve since: %w", err)
 			}
 		}
-		builds, err := gcs.ListBuilds(ctx, client, tgPath, offset)
+		builds, err := gcs.ListBuildsFunc(ctx, client, tgPath, offset, less)
 		if err != nil {
 			return nil, fmt.Errorf("%d: %s: %w", idx, tgPath, err)
 		}
@@ -385,7 +521,7 @@ func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...g
 	}
 
 	if len(paths) > 1 {
-		gcs.Sort(out)
+		gcs.SortFunc(out, less)
 	}
 
 	return out, nil
@@ -394,6 +530,9 @@ func listBuilds(ctx context.Context, client gcs.Lister, since string, paths ...g
 // A ColumnReader will find, process and return new columns to insert into the front of grid state.
 type ColumnReader func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, oldCols []InflatedColumn, stop time.Time) ([]InflatedColumn, error)
 
+// A ColumnExporter sends newly completed columns to an external system.
+type ColumnExporter func(ctx context.Context, log logrus.FieldLogger, tg *configpb.TestGroup, cols []InflatedColumn) error
+
 // A ColumnSorter sort InflatedColumns as desired.
 type ColumnSorter func(*configpb.TestGroup, []InflatedColumn)
 
@@ -405,7 +544,7 @@ func SortStarted(_ *configpb.TestGroup, cols []InflatedColumn) {
 }
 
 // InflateDropAppend updates groups by downloading the existing grid, dropping old rows and appending new ones.
-func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, write bool, readCols ColumnReader, sortCols ColumnSorter, reprocess time.Duration) error {
+func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.Client, tg *configpb.TestGroup, gridPath gcs.Path, write bool, readCols ColumnReader, sortCols ColumnSorter, reprocess time.Duration, export ColumnExporter) error {
 	var dur time.Duration
 	if tg.DaysOfResults > 0 {
 		dur = days(float64(tg.DaysOfResults))
@@ -413,17 +552,36 @@ func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.C
 		dur = days(7)
 	}
 
-	stop := time.Now().Add(-dur)
+	stop := clock().Add(-dur)
 
 	var oldCols []InflatedColumn
 
+	// Abort unless the grid does not exist yet, since carrying on would replace
+	// the stored history with only the new columns.
 	old, err := gcs.DownloadGrid(ctx, client, gridPath)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+		old = nil
+	case err != nil:
+		return fmt.Errorf("download existing grid: %w", err)
+	}
+	editsPath, err := ColumnEditsPath(gridPath)
+	if err != nil {
+		return fmt.Errorf("column edits path: %w", err)
+	}
+	edits, _, err := ReadColumnEdits(ctx, client, *editsPath)
 	if err != nil {
-		log.WithField("path", gridPath).WithError(err).Error("Failed to download existing grid")
+		log.WithError(err).Warning("Failed to read column edits, ignoring them")
+		edits = &statepb.ColumnEdits{}
+	}
+	if n := len(edits.Edits); n > 0 {
+		log.WithField("edits", n).Info("Applying column edits")
 	}
 	if old != nil {
-		cols := inflateGrid(old, stop, time.Now().Add(-reprocess))
+		cols := inflateGrid(old, stop, clock().Add(-reprocess))
 		SortStarted(tg, cols) // Our processing requires descending start time.
+		cols = applyColumnEdits(edits, cols)
+		carryOlderBuilds(old, cols, stop)
 		oldCols = truncateRunning(cols)
 	}
 
@@ -431,39 +589,497 @@ func InflateDropAppend(ctx context.Context, log logrus.FieldLogger, client gcs.C
 	if err != nil {
 		return fmt.Errorf("read columns: %w", err)
 	}
+	cols = dropBuilds(cols, deletedBuilds(edits))
+
+	switch {
+	case old.GetArchivePath() != "" && len(cols) == 0:
+		log.Debug("Archived group has no new results")
+		return nil
+	case old.GetArchivePath() != "":
+		log := log.WithField("archive", old.ArchivePath)
+		archived, err := reviveGrid(ctx, client, old)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			log.WithError(err).Warning("Archived grid is missing, reviving without it")
+			oldCols = nil
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("revive archive: %w", err)
+		}
+		log.Info("Reviving archived group")
+		old = archived
+		archivedCols := inflateGrid(archived, stop, clock().Add(-reprocess))
+		SortStarted(tg, archivedCols)
+		archivedCols = applyColumnEdits(edits, archivedCols)
+		carryOlderBuilds(archived, archivedCols, stop)
+		oldCols = truncateRunning(archivedCols)
+	case len(cols) == 0 && shouldArchive(tg, old, clock()):
+		if !write {
+			log.Info("Skipping archival of idle group")
+			return nil
+		}
+		if err := archiveGrid(ctx, client, tg, gridPath, old); err != nil {
+			return fmt.Errorf("archive: %w", err)
+		}
+		log.WithField("days", tg.ArchiveAfterDays).Info("Archived idle group")
+		return nil
+	}
+
+	var exportCols []InflatedColumn
+	if export != nil {
+		exportCols = newlyCompleted(old, cols)
+	}
 
 	overrideBuild(tg, cols)
 	cols = append(cols, oldCols...)
 	cols = groupColumns(tg, cols)
+	overridesPath, err := BuildOverridesPath(gridPath)
+	if err != nil {
+		return fmt.Errorf("build overrides path: %w", err)
+	}
+	overrides, _, err := ReadBuildOverrides(ctx, client, *overridesPath)
+	if err != nil {
+		log.WithError(err).Warning("Failed to read build overrides, ignoring them")
+	} else if n := applyBuildOverrides(overrides, cols); n > 0 {
+		log.WithField("columns", n).Debug("Overrode build results")
+	}
+	if tg.StrictColumns {
+		reportCollisions(log, cols)
+	}
+	if tg.StrictMetadata {
+		reportCompliance(log, cols)
+	}
+
+	redact, err := newRedactor(tg.Redactions)
+	if err != nil {
+		return fmt.Errorf("redactions: %w", err)
+	}
+	// Also redact older columns, which may predate the rule.
+	redact.redactColumns(cols)
+
+	n, err := renameTests(ctx, log, client, tg, cols)
+	if err != nil {
+		return fmt.Errorf("renames: %w", err)
+	}
+	if n > 0 {
+		log.WithField("renamed", n).Info("Stitched renamed tests")
+	}
+
+	if n := limitRows(tg, cols); n > 0 {
+		log.WithFields(logrus.Fields{
+			"overflow": n,
+			"max":      tg.MaxRows,
+		}).Warning("Collapsed tests beyond the row limit")
+	}
+
+	// Spill after redacting, so the message store holds redacted messages.
+	messages := spillMessages(cols)
 
 	sortCols(tg, cols)
 
+	cols, shards, shardUploads, err := archiveColumns(ctx, log, client, tg, old, cols, stop, clock())
+	if err != nil {
+		return fmt.Errorf("archive columns: %w", err)
+	}
+
 	grid := constructGrid(log, tg, cols)
-	buf, err := marshalGrid(grid)
+	grid.ArchiveShards = shards
+	setLastGreen(grid.Columns, grid.Rows, old.GetRows())
+	setFirstSeen(grid.Columns, grid.Rows, old.GetRows(), tg.NewTestDays, clock())
+	setTombstones(grid.Columns, grid.Rows, int(tg.TombstoneColumns))
+	if err := associateIssues(ctx, log, client, tg, gridPath, grid, write); err != nil {
+		log.WithError(err).Warning("Failed to associate issues")
+	}
+	encoded, err := state.Encode(grid, tg.StateVersion)
+	if err != nil {
+		return fmt.Errorf("encode grid: %w", err)
+	}
+	buf, err := marshalGrid(encoded)
 	if err != nil {
 		return fmt.Errorf("marshal grid: %w", err)
 	}
 	log = log.WithField("url", gridPath).WithField("bytes", len(buf))
+	guardErr := checkGuard(tg.WriteGuard, old, grid, stop)
+	if guardErr != nil && !write {
+		log.WithError(guardErr).Warning("Would quarantine grid (dry run)")
+		return nil
+	}
 	if !write {
 		log.Debug("Skipping write")
 	} else {
 		log.Debug("Writing")
+		// Write shards first, so neither the grid nor an accepted quarantine lists missing ones.
+		for _, shard := range shardUploads {
+			if err := client.Upload(ctx, shard.path, shard.buf, gcs.DefaultACL, "no-cache"); err != nil {
+				return fmt.Errorf("upload shard %s: %w", shard.path, err)
+			}
+		}
+		if guardErr != nil {
+			if err := quarantineGrid(ctx, client, gridPath, buf); err != nil {
+				log.WithError(err).Warning("Failed to write quarantined grid")
+			}
+			return fmt.Errorf("%w grid: %v", ErrQuarantined, guardErr)
+		}
+		// Write full messages first, so the grid never links to missing ones.
+		if err := writeMessageStore(ctx, client, gridPath, grid, messages); err != nil {
+			log.WithError(err).Warning("Failed to write message store")
+		}
 		// TODO(fejta): configurable cache value
-		if err := client.Upload(ctx, gridPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+		err := client.Upload(ctx, gridPath, buf, gcs.DefaultACL, "no-cache")
+		writeMirrors(ctx, log, client, tg.MirrorPrefixes, tg.Name, buf)
+		if pub := tg.PublicGrid; pub != nil {
+			if err := publishGrid(ctx, client, pub, tg.Name, grid); err != nil {
+				log.WithError(err).Warning("Failed to publish public grid")
+			}
+		}
+		if err != nil {
 			return fmt.Errorf("upload: %w", err)
 		}
+		if err := clearEdits(ctx, client, *editsPath, edits, stop); err != nil {
+			log.WithError(err).Warning("Failed to clear applied column edits")
+		}
 	}
 	log.WithFields(logrus.Fields{
 		"cols": len(grid.Columns),
 		"rows": len(grid.Rows),
 	}).Info("Wrote grid")
+	if write && len(exportCols) > 0 {
+		if err := export(ctx, log, tg, exportCols); err != nil {
+			// The grid is already written, and a later cycle will not retry.
+			log.WithError(err).WithField("exports", len(exportCols)).Warning("Failed to export columns")
+		}
+	}
 	return nil
 }
 
-// formatStrftime replaces python codes with what go expects.
+const (
+	overflowPrefix = "OVERFLOW"
+	overflowMetric = "overflow-tests"
+)
+
+func isOverflow(name string) bool {
+	return strings.HasPrefix(name, overflowPrefix+" (")
+}
+
+// limitRows collapses tests beyond the group's max number of rows into a single
+// overflow row, returning the number of collapsed tests.
+//
+// Tests are kept in name order. Overflow rows from earlier updates are
+// collapsed into the new overflow row.
+func limitRows(group *configpb.TestGroup, cols []InflatedColumn) int {
+	max := int(group.GetMaxRows())
+	if max <= 0 {
+		return 0
+	}
+	names := map[string]bool{}
+	for _, col := range cols {
+		for name := range col.Cells {
+			if _, ok := SyntheticRow(group, name); ok || isOverflow(name) {
+				continue
+			}
+			names[name] = true
+		}
+	}
+	if len(names) <= max {
+		return 0
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	overflow := map[string]bool{}
+	for _, name := range sorted[max:] {
+		overflow[name] = true
+	}
+	rowName := fmt.Sprintf("%s (%d tests)", overflowPrefix, len(overflow))
+
+	for _, col := range cols {
+		var collapse []string
+		for name := range col.Cells {
+			if overflow[name] || isOverflow(name) {
+				collapse = append(collapse, name)
+			}
+		}
+		if len(collapse) == 0 {
+			continue
+		}
+		sort.Strings(collapse)
+		var tests int
+		cells := make([]Cell, 0, len(collapse))
+		for _, name := range collapse {
+			cell := col.Cells[name]
+			if isOverflow(name) {
+				tests += int(cell.Metrics[overflowMetric])
+			} else {
+				tests++
+			}
+			cells = append(cells, cell)
+			delete(col.Cells, name)
+		}
+		merged := MergeCells(false, cells...)
+		col.Cells[rowName] = Cell{
+			Result:  merged.Result,
+			CellID:  merged.CellID,
+			Icon:    merged.Icon,
+			Message: fmt.Sprintf("%d tests exceed the %d row limit", tests, max),
+			Metrics: map[string]float64{overflowMetric: float64(tests)},
+		}
+	}
+	return len(overflow)
+}
+
+// writeMirrors writes the grid to each mirror, logging any failures.
+//
+// Mirrors are best effort and do not affect the primary write.
+func writeMirrors(ctx context.Context, log logrus.FieldLogger, client gcs.Uploader, prefixes []string, name string, buf []byte) {
+	for _, prefix := range prefixes {
+		log := log.WithField("mirror", prefix)
+		mirrorPath, err := gcs.MirrorPath(prefix, name)
+		if err != nil {
+			log.WithError(err).Warning("Bad mirror prefix")
+			continue
+		}
+		if err := client.Upload(ctx, *mirrorPath, buf, gcs.DefaultACL, "no-cache"); err != nil {
+			log.WithError(err).Warning("Failed to write mirror")
+			continue
+		}
+		log.Debug("Wrote mirror")
+	}
+}
+
+const defaultPublicCacheControl = "public, max-age=300"
+
+// fieldPolicy is how the public copy of a grid treats a field.
+type fieldPolicy int
+
+const (
+	// privateField is left out of the public grid.
+	privateField fieldPolicy = iota
+	// publicField is kept, along with the public fields of its messages.
+	publicField
+	// blankField keeps a list of empty strings, parallel to the results.
+	blankField
+)
+
+// messageName returns the full name of the message type.
+func messageName(m proto.Message) protoreflect.FullName {
+	return proto.MessageReflect(m).Descriptor().FullName()
+}
+
+// publicGridFields classifies each field of the messages a public grid may
+// hold. The public grid leaves out any field which is not classified public,
+// so classify new fields here.
+var publicGridFields = map[protoreflect.FullName]map[protoreflect.Name]fieldPolicy{
+	messageName(&statepb.Grid{}): {
+		"columns":                       publicField,
+		"rows":                          publicField,
+		"last_alert_mail_time":          publicField,
+		"config":                        privateField,
+		"last_time_updated":             publicField,
+		"update_info":                   publicField,
+		"test_metadata":                 privateField,
+		"cluster":                       privateField,
+		"most_recent_cluster_timestamp": publicField,
+		"archive_path":                  privateField,
+		"version":                       privateField,
+		"dictionary":                    privateField,
+		"archive_shards":                privateField,
+	},
+	messageName(&statepb.UpdateInfo{}): {
+		"update_phase_data": publicField,
+	},
+	messageName(&statepb.UpdatePhaseData{}): {
+		"phase_name":    publicField,
+		"phase_seconds": publicField,
+	},
+	messageName(&statepb.Column{}): {
+		"build":               publicField,
+		"name":                publicField,
+		"started":             publicField,
+		"extra":               publicField,
+		"hotlist_ids":         publicField,
+		"hint":                publicField,
+		"broken":              publicField,
+		"metadata":            privateField,
+		"source":              publicField,
+		"date":                publicField,
+		"merged_builds":       publicField,
+		"dropped_cells":       publicField,
+		"metadata_violations": privateField,
+		"skipped_builds":      publicField,
+		"older_builds":        publicField,
+		"override":            publicField,
+	},
+	messageName(&statepb.BuildOverride{}): {
+		"build":      publicField,
+		"result":     publicField,
+		"annotation": publicField,
+		"reason":     privateField,
+		"author":     privateField,
+		"created":    publicField,
+	},
+	messageName(&statepb.Row{}): {
+		"name":            publicField,
+		"id":              publicField,
+		"results":         publicField,
+		"cell_ids":        publicField,
+		"messages":        blankField,
+		"metric":          publicField,
+		"metrics":         publicField,
+		"icons":           publicField,
+		"bug_id":          privateField,
+		"alert_info":      publicField,
+		"user_property":   privateField,
+		"properties":      privateField,
+		"cell_links":      privateField,
+		"cell_parameters": publicField,
+		"links":           privateField,
+		"packed_results":  privateField,
+		"message_refs":    privateField,
+		"icon_refs":       privateField,
+		"cell_id_refs":    privateField,
+		"last_green":      privateField,
+		"first_seen":      publicField,
+		"is_new":          publicField,
+		"tombstone":       publicField,
+	},
+	messageName(&statepb.Metric{}): {
+		"name":    publicField,
+		"indices": publicField,
+		"values":  publicField,
+	},
+	messageName(&statepb.AlertInfo{}): {
+		"fail_count":           publicField,
+		"fail_build_id":        publicField,
+		"fail_time":            publicField,
+		"fail_test_id":         publicField,
+		"pass_build_id":        publicField,
+		"pass_time":            publicField,
+		"failure_message":      privateField,
+		"build_link":           privateField,
+		"build_link_text":      privateField,
+		"build_url_text":       privateField,
+		"latest_fail_build_id": publicField,
+		"latest_fail_test_id":  publicField,
+		"properties":           privateField,
+		"hotlist_ids":          publicField,
+	},
+	messageName(&statepb.CellParameters{}): {
+		"results": publicField,
+	},
+	messageName(&statepb.ParameterResult{}): {
+		"parameters": publicField,
+		"result":     publicField,
+		"message":    privateField,
+	},
+	messageName(&statepb.Tombstone{}): {
+		"build_id":        publicField,
+		"time":            publicField,
+		"missing_columns": publicField,
+	},
+	messageName(&timestamp.Timestamp{}): {
+		"seconds": publicField,
+		"nanos":   publicField,
+	},
+}
+
+// publicGrid returns a copy of the decoded grid with only its public fields,
+// leaving out messages, links and other details which may reveal internal
+// resources.
+func publicGrid(grid *statepb.Grid) *statepb.Grid {
+	pub := proto.Clone(grid).(*statepb.Grid)
+	keepPublicFields(proto.MessageReflect(pub))
+	return pub
+}
+
+// keepPublicFields clears the fields of the message, and of its messages,
+// which publicGridFields does not classify public.
+func keepPublicFields(m protoreflect.Message) {
+	policies := publicGridFields[m.Descriptor().FullName()]
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch policies[fd.Name()] {
+		case publicField:
+			switch {
+			case fd.Message() == nil || fd.IsMap():
+			case fd.IsList():
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					keepPublicFields(list.Get(i).Message())
+				}
+			default:
+				keepPublicFields(v.Message())
+			}
+		case blankField:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, protoreflect.ValueOfString(""))
+			}
+		default:
+			m.Clear(fd)
+		}
+		return true
+	})
+}
+
+// publishGrid writes a world-readable, public copy of the grid.
+func publishGrid(ctx context.Context, client gcs.Uploader, cfg *configpb.PublicGrid, name string, grid *statepb.Grid) error {
+	pubPath, err := gcs.MirrorPath(cfg.Prefix, name)
+	if err != nil {
+		return fmt.Errorf("path: %w", err)
+	}
+	buf, err := marshalGrid(publicGrid(grid))
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	cacheControl := cfg.CacheControl
+	if cacheControl == "" {
+		cacheControl = defaultPublicCacheControl
+	}
+	return client.Upload(ctx, *pubPath, buf, true, cacheControl)
+}
+
+// columnKey identifies the build which produced a column.
+func columnKey(col *statepb.Column) string {
+	if col.Hint != "" {
+		return col.Hint
+	}
+	return col.Build
+}
+
+func isRunning(col InflatedColumn) bool {
+	for _, cell := range col.Cells {
+		if cell.Result == statuspb.TestStatus_RUNNING {
+			return true
+		}
+	}
+	return false
+}
+
+// newlyCompleted returns the completed columns which were absent or running in the old grid.
+func newlyCompleted(old *statepb.Grid, cols []InflatedColumn) []InflatedColumn {
+	completed := map[string]bool{}
+	if old != nil {
+		for _, col := range inflateGrid(old, time.Time{}, time.Unix(math.MaxInt64/2, 0)) {
+			if !isRunning(col) {
+				completed[columnKey(col.Column)] = true
+			}
+		}
+	}
+	var out []InflatedColumn
+	for _, col := range cols {
+		if isRunning(col) || completed[columnKey(col.Column)] {
+			continue
+		}
+		out = append(out, col)
+	}
+	return out
+}
+
+// FormatStrftime replaces python codes with what go expects.
 //
 // aka %Y-%m-%d becomes 2006-01-02
-func formatStrftime(in string) string {
+func FormatStrftime(in string) string {
 	replacements := map[string]string{
 		"%p": "PM",
 		"%Y": "2006",
@@ -488,7 +1104,7 @@ func overrideBuild(tg *configpb.TestGroup, cols []InflatedColumn) {
 	if fmt == "" {
 		return
 	}
-	fmt = formatStrftime(fmt)
+	fmt = FormatStrftime(fmt)
 	for _, col := range cols {
 		started := int64(col.Column.Started)
 		when := time.Unix(started/1000, (started%1000)*int64(time.Millisecond/time.Nanosecond))
@@ -503,6 +1119,7 @@ const columnIDSeparator = "\ue000"
 // Cells are joined together, splitting those with the same name.
 // Started is the smallest value.
 // Extra is the most recent filled value.
+// Groups with strict_columns also record the merged builds and any dropped cells.
 func groupColumns(tg *configpb.TestGroup, cols []InflatedColumn) []InflatedColumn {
 	groups := map[string][]InflatedColumn{}
 	var ids []string
@@ -536,7 +1153,15 @@ func groupColumns(tg *configpb.TestGroup, cols []InflatedColumn) []InflatedColum
 		cells := map[string][]Cell{}
 
 		var count int
+		var merged []string
+		var dropped int32
 		for i, c := range groupedCells {
+			if tg.StrictColumns {
+				merged = appendMerged(merged, c.Column)
+				if i > 0 {
+					dropped += c.Column.DroppedCells
+				}
+			}
 			if i == 0 {
 				col.Column = c.Column
 			} else {
@@ -546,6 +1171,7 @@ func groupColumns(tg *configpb.TestGroup, cols []InflatedColumn) []InflatedColum
 				if !sortorder.NaturalLess(c.Column.Hint, col.Column.Hint) {
 					col.Column.Hint = c.Column.Hint
 				}
+				col.Column.OlderBuilds += c.Column.OlderBuilds
 				for i, val := range c.Column.Extra {
 					if val == "" || val == col.Column.Extra[i] {
 						continue
@@ -570,17 +1196,64 @@ func groupColumns(tg *configpb.TestGroup, cols []InflatedColumn) []InflatedColum
 		for name, duplicateCells := range cells {
 			if tg.IgnoreOldResults {
 				col.Cells[name] = duplicateCells[0]
+				dropped += int32(len(duplicateCells) - 1)
 				continue
 			}
+			if n := len(duplicateCells); n > maxDuplicates {
+				dropped += int32(n - maxDuplicates)
+			}
 			for name, cell := range SplitCells(name, duplicateCells...) {
+				if _, ok := col.Cells[name]; ok {
+					dropped++ // a split name matches another test
+				}
 				col.Cells[name] = cell
 			}
 		}
+		if tg.StrictColumns {
+			col.Column.MergedBuilds = merged
+			col.Column.DroppedCells += dropped
+		}
 		out = append(out, col)
 	}
 	return out
 }
 
+// appendMerged appends the builds merged into the column, or its hint, to those not yet seen.
+func appendMerged(merged []string, col *statepb.Column) []string {
+	builds := col.MergedBuilds
+	if len(builds) == 0 {
+		builds = []string{col.Hint}
+	}
+	for _, b := range builds {
+		var found bool
+		for _, m := range merged {
+			if m == b {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, b)
+		}
+	}
+	return merged
+}
+
+// reportCollisions warns about each column whose merged builds dropped cells.
+func reportCollisions(log logrus.FieldLogger, cols []InflatedColumn) {
+	for _, col := range cols {
+		if col.Column.DroppedCells == 0 {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"build":   col.Column.Build,
+			"name":    col.Column.Name,
+			"builds":  col.Column.MergedBuilds,
+			"dropped": col.Column.DroppedCells,
+		}).Warning("Merged builds dropped colliding cells")
+	}
+}
+
 // days converts days float into a time.Duration, assuming a 24 hour day.
 //
 // A day is not always 24 hours due to things like leap-seconds.
@@ -589,6 +1262,11 @@ func days(d float64) time.Duration {
 	return time.Duration(24*d) * time.Hour // Close enough
 }
 
+// ConstructGrid returns a grid of the columns, such as those from InflateGrid.
+func ConstructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []InflatedColumn) *statepb.Grid {
+	return constructGrid(log, group, cols)
+}
+
 // constructGrid will append all the inflatedColumns into the returned Grid.
 //
 // The returned Grid has correctly compressed row values.
@@ -596,11 +1274,6 @@ func constructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []Inf
 	// Add the columns into a grid message
 	var grid statepb.Grid
 	rows := map[string]*statepb.Row{} // For fast target => row lookup
-	failsOpen := int(group.NumFailuresToAlert)
-	passesClose := int(group.NumPassesToDisableAlert)
-	if failsOpen > 0 && passesClose == 0 {
-		passesClose = 1
-	}
 
 	for _, col := range cols {
 		appendColumn(&grid, rows, col)
@@ -608,10 +1281,12 @@ func constructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []Inf
 
 	dropEmptyRows(log, &grid, rows)
 
-	alertRows(grid.Columns, grid.Rows, failsOpen, passesClose)
+	markBrokenColumns(grid.Columns, grid.Rows, group.GetBrokenColumnThreshold())
+	alert.Set(group, grid.Columns, grid.Rows)
 	sort.SliceStable(grid.Rows, func(i, j int) bool {
 		return sortorder.NaturalLess(grid.Rows[i].Name, grid.Rows[j].Name)
 	})
+	positionRows(group, grid.Rows)
 
 	for _, row := range grid.Rows {
 		del := true
@@ -624,6 +1299,19 @@ func constructGrid(log logrus.FieldLogger, group *configpb.TestGroup, cols []Inf
 		if del {
 			row.UserProperty = nil
 		}
+		del = true
+		for _, cl := range row.CellLinks {
+			if len(cl.Links) > 0 {
+				del = false
+				break
+			}
+		}
+		if del {
+			row.CellLinks = nil
+		}
+		sort.SliceStable(row.Properties, func(i, j int) bool {
+			return row.Properties[i].Name < row.Properties[j].Name
+		})
 		sort.SliceStable(row.Metric, func(i, j int) bool {
 			return sortorder.NaturalLess(row.Metric[i], row.Metric[j])
 		})
@@ -666,19 +1354,7 @@ func dropEmptyRows(log logrus.FieldLogger, grid *statepb.Grid, rows map[string]*
 
 // marhshalGrid serializes a state proto into zlib-compressed bytes.
 func marshalGrid(grid *statepb.Grid) ([]byte, error) {
-	buf, err := proto.Marshal(grid)
-	if err != nil {
-		return nil, fmt.Errorf("marshal: %w", err)
-	}
-	var zbuf bytes.Buffer
-	zw := zlib.NewWriter(&zbuf)
-	if _, err = zw.Write(buf); err != nil {
-		return nil, fmt.Errorf("compress: %w", err)
-	}
-	if err = zw.Close(); err != nil {
-		return nil, fmt.Errorf("close: %w", err)
-	}
-	return zbuf.Bytes(), nil
+	return gcs.MarshalGrid(grid)
 }
 
 // appendMetric adds the value at index to metric.
@@ -705,6 +1381,65 @@ func hasCellID(name string) bool {
 // appendCell adds the rowResult column to the row.
 //
 // Handles the details like missing fields and run-length-encoding the result.
+// appendProperties appends the cell's property values to the row.
+//
+// Must be called before appending the cell's message.
+func appendProperties(row *statepb.Row, props map[string]string) {
+	for name := range props {
+		var found bool
+		for _, prop := range row.Properties {
+			if prop.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			// Backfill earlier filled cells, which lack this property.
+			row.Properties = append(row.Properties, &statepb.PropertyValues{
+				Name:   name,
+				Values: make([]string, len(row.Messages)),
+			})
+		}
+	}
+	for _, prop := range row.Properties {
+		prop.Values = append(prop.Values, props[prop.Name])
+	}
+}
+
+// appendLinks appends the cell's links to the row.
+//
+// Must be called before appending the cell's message.
+func appendLinks(row *statepb.Row, links []*statepb.Link) {
+	if row.CellLinks == nil {
+		if len(links) == 0 {
+			return
+		}
+		// Backfill earlier filled cells, which lack links.
+		row.CellLinks = make([]*statepb.CellLinks, len(row.Messages))
+		for i := range row.CellLinks {
+			row.CellLinks[i] = &statepb.CellLinks{}
+		}
+	}
+	row.CellLinks = append(row.CellLinks, &statepb.CellLinks{Links: links})
+}
+
+// appendParameters appends the cell's folded parameter results to the row.
+//
+// Must be called before appending the cell's message.
+func appendParameters(row *statepb.Row, results []*statepb.ParameterResult) {
+	if row.CellParameters == nil {
+		if len(results) == 0 {
+			return
+		}
+		// Backfill earlier filled cells, which lack parameters.
+		row.CellParameters = make([]*statepb.CellParameters, len(row.Messages))
+		for i := range row.CellParameters {
+			row.CellParameters[i] = &statepb.CellParameters{}
+		}
+	}
+	row.CellParameters = append(row.CellParameters, &statepb.CellParameters{Results: results})
+}
+
 func appendCell(row *statepb.Row, cell Cell, start, count int) {
 	latest := int32(cell.Result)
 	n := len(row.Results)
@@ -750,6 +1485,9 @@ func appendCell(row *statepb.Row, cell Cell, start, count int) {
 		if addCellID {
 			row.CellIds = append(row.CellIds, cell.CellID)
 		}
+		appendProperties(row, cell.Properties)
+		appendLinks(row, cell.Links)
+		appendParameters(row, cell.Parameters)
 		// Javascript client expects no result cells to skip icons/messages
 		row.Messages = append(row.Messages, cell.Message)
 		row.Icons = append(row.Icons, cell.Icon)
@@ -801,124 +1539,204 @@ func appendColumn(grid *statepb.Grid, rows map[string]*statepb.Row, inflated Inf
 	}
 }
 
-// alertRows configures the alert for every row that has one.
-func alertRows(cols []*statepb.Column, rows []*statepb.Row, openFailures, closePasses int) {
-	for _, r := range rows {
-		r.AlertInfo = alertRow(cols, r, openFailures, closePasses)
+// markBrokenColumns flags columns where more than threshold of the results fail.
+//
+// Running cells do not count, and columns require at least two results
+// so a single failing test does not break its column.
+func markBrokenColumns(cols []*statepb.Column, rows []*statepb.Row, threshold float32) {
+	if threshold <= 0 {
+		return
+	}
+	failures := make([]int, len(cols))
+	totals := make([]int, len(cols))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, row := range rows {
+		ch := result.Iter(ctx, row.Results)
+		for i := range cols {
+			res := result.Coalesce(<-ch, result.IgnoreRunning)
+			if res == statuspb.TestStatus_NO_RESULT {
+				continue
+			}
+			totals[i]++
+			if res == statuspb.TestStatus_FAIL {
+				failures[i]++
+			}
+		}
+	}
+	for i, col := range cols {
+		col.Broken = totals[i] > 1 && float32(failures[i]) > threshold*float32(totals[i])
 	}
 }
 
-// alertRow returns an AlertInfo proto if there have been failuresToOpen consecutive failures more recently than passesToClose.
-func alertRow(cols []*statepb.Column, row *statepb.Row, failuresToOpen, passesToClose int) *statepb.AlertInfo {
-	if failuresToOpen == 0 {
-		return nil
+// setLastGreen records the most recent pass of each failing row.
+//
+// Rows without a pass in the columns keep the last green of the old row,
+// whose passing column has since left the grid, and report its build in their alert.
+func setLastGreen(cols []*statepb.Column, rows, oldRows []*statepb.Row) {
+	old := make(map[string]*statepb.LastGreen, len(oldRows))
+	for _, row := range oldRows {
+		if row.LastGreen != nil {
+			old[row.Name] = row.LastGreen
+		}
+	}
+	for _, row := range rows {
+		failing, pass := lastGreen(cols, row)
+		switch {
+		case !failing:
+			row.LastGreen = nil
+		case pass != nil:
+			row.LastGreen = &statepb.LastGreen{
+				BuildId: alert.BuildID(pass),
+				Time:    startedStamp(pass),
+			}
+		default:
+			row.LastGreen = old[row.Name]
+			if ai := row.AlertInfo; ai != nil && ai.PassBuildId == "" && row.LastGreen != nil {
+				ai.PassBuildId = row.LastGreen.BuildId
+			}
+		}
 	}
+}
+
+// lastGreen returns whether the row's latest result fails, and if so the
+// column of its most recent pass, if any.
+func lastGreen(cols []*statepb.Column, row *statepb.Row) (bool, *statepb.Column) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	var failures int
-	var totalFailures int32
-	var passes int
-	var compressedIdx int
 	ch := result.Iter(ctx, row.Results)
-	var firstFail *statepb.Column
-	var latestFail *statepb.Column
-	var latestPass *statepb.Column
-	var failIdx int
-	var latestFailIdx int
-	// find the first number of consecutive passesToClose (no alert)
-	// or else failuresToOpen (alert).
+	var failing bool
 	for _, col := range cols {
-		// TODO(fejta): ignore old running
-		rawRes := <-ch
-		res := result.Coalesce(rawRes, result.IgnoreRunning)
-		if res == statuspb.TestStatus_NO_RESULT {
-			if rawRes == statuspb.TestStatus_RUNNING {
-				compressedIdx++
-			}
+		res := result.Coalesce(<-ch, result.IgnoreRunning)
+		switch {
+		case res == statuspb.TestStatus_NO_RESULT:
 			continue
+		case !failing && res != statuspb.TestStatus_FAIL:
+			return false, nil
+		case res == statuspb.TestStatus_PASS:
+			return true, col
 		}
-		if res == statuspb.TestStatus_PASS {
-			passes++
-			if failures >= failuresToOpen {
-				latestPass = col // most recent pass before outage
-				break
-			}
-			if passes >= passesToClose {
-				return nil // there is no outage
-			}
-			failures = 0
-		}
-		if res == statuspb.TestStatus_FAIL {
-			passes = 0
-			failures++
-			totalFailures++
-			if failures == 1 { // note most recent failure for this outage
-				latestFailIdx = compressedIdx
-				latestFail = col
-			}
-			failIdx = compressedIdx
-			firstFail = col
-		}
-		if res == statuspb.TestStatus_FLAKY {
-			passes = 0
-			if failures >= failuresToOpen {
-				break // cannot definitively say which commit is at fault
-			}
-			failures = 0
+		failing = true
+	}
+	return failing, nil
+}
+
+// setFirstSeen records when each row first had a result, marking the rows
+// first seen within newDays as new tests.
+//
+// Rows keep the first seen time of the old row when it is earlier, since the
+// row's first column may have left the grid.
+func setFirstSeen(cols []*statepb.Column, rows, oldRows []*statepb.Row, newDays int32, now time.Time) {
+	old := make(map[string]*timestamp.Timestamp, len(oldRows))
+	for _, row := range oldRows {
+		if row.FirstSeen != nil {
+			old[row.Name] = row.FirstSeen
 		}
-		compressedIdx++
 	}
-	if failures < failuresToOpen {
-		return nil
+	since := now.Add(-days(float64(newDays)))
+	for _, row := range rows {
+		row.FirstSeen = startedStamp(firstSeen(cols, row))
+		if prev, ok := old[row.Name]; ok && (row.FirstSeen == nil || prev.AsTime().Before(row.FirstSeen.AsTime())) {
+			row.FirstSeen = prev
+		}
+		row.IsNew = newDays > 0 && row.FirstSeen != nil && !row.FirstSeen.AsTime().Before(since)
 	}
-	var id string
-	var latestID string
-	if len(row.CellIds) > 0 { // not all rows have cell ids
-		id = row.CellIds[failIdx]
-		latestID = row.CellIds[latestFailIdx]
+}
+
+// firstSeen returns the earliest column with a result for the row, if any.
+func firstSeen(cols []*statepb.Column, row *statepb.Row) *statepb.Column {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, row.Results)
+	var first *statepb.Column
+	for _, col := range cols {
+		if <-ch != statuspb.TestStatus_NO_RESULT && (first == nil || col.Started < first.Started) {
+			first = col
+		}
 	}
-	msg := row.Messages[latestFailIdx]
-	return alertInfo(totalFailures, msg, id, latestID, firstFail, latestFail, latestPass)
+	return first
+}
+
+// isMetadataRow returns true for the rows the updater adds to every build.
+func isMetadataRow(name string) bool {
+	return name == overallRow || name == podInfoRow
 }
 
-// alertInfo returns an alert proto with the configured fields
-func alertInfo(failures int32, msg, cellID, latestCellID string, fail, latestFail, pass *statepb.Column) *statepb.AlertInfo {
-	return &statepb.AlertInfo{
-		FailCount:         failures,
-		FailBuildId:       buildID(fail),
-		LatestFailBuildId: buildID(latestFail),
-		FailTime:          stamp(fail),
-		FailTestId:        cellID,
-		LatestFailTestId:  latestCellID,
-		FailureMessage:    msg,
-		PassTime:          stamp(pass),
-		PassBuildId:       buildID(pass),
+// testedColumns returns whether each column has a completed result for any
+// test, so missing tests are not blamed on broken harnesses.
+//
+// Broken columns and the overall and pod rows do not count.
+func testedColumns(cols []*statepb.Column, rows []*statepb.Row) []bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tested := make([]bool, len(cols))
+	for _, row := range rows {
+		if isMetadataRow(row.Name) {
+			continue
+		}
+		ch := result.Iter(ctx, row.Results)
+		for i := range cols {
+			if result.Coalesce(<-ch, result.IgnoreRunning) != statuspb.TestStatus_NO_RESULT {
+				tested[i] = true
+			}
+		}
+	}
+	for i, col := range cols {
+		if col.Broken {
+			tested[i] = false
+		}
 	}
+	return tested
 }
 
-// buildID extracts the ID from the first extra row or else the Build field.
-func buildID(col *statepb.Column) string {
-	if col == nil {
-		return ""
+// setTombstones marks the rows missing from at least missing consecutive
+// tested columns with a tombstone of their last result.
+func setTombstones(cols []*statepb.Column, rows []*statepb.Row, missing int) {
+	if missing <= 0 {
+		return
 	}
-	if len(col.Extra) > 0 {
-		return col.Extra[0]
+	tested := testedColumns(cols, rows)
+	for _, row := range rows {
+		if isMetadataRow(row.Name) {
+			continue
+		}
+		row.Tombstone = tombstone(cols, tested, row, missing)
 	}
-	return col.Build
 }
 
-const billion = 1e9
+// tombstone returns the tombstone of a row missing from at least missing
+// consecutive tested columns, or nil.
+func tombstone(cols []*statepb.Column, tested []bool, row *statepb.Row, missing int) *statepb.Tombstone {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := result.Iter(ctx, row.Results)
+	var n int
+	for i, col := range cols {
+		if <-ch != statuspb.TestStatus_NO_RESULT {
+			if n < missing {
+				return nil
+			}
+			return &statepb.Tombstone{
+				BuildId:        alert.BuildID(col),
+				Time:           startedStamp(col),
+				MissingColumns: int32(n),
+			}
+		}
+		if tested[i] {
+			n++
+		}
+	}
+	return nil
+}
 
-// stamp converts seconds into a timestamp proto
-func stamp(col *statepb.Column) *timestamp.Timestamp {
+// startedStamp converts the start of the column, in milliseconds, into a timestamp proto.
+func startedStamp(col *statepb.Column) *timestamp.Timestamp {
 	if col == nil {
 		return nil
 	}
-	seconds := col.Started
-	floor := math.Floor(seconds)
-	remain := seconds - floor
+	ms := int64(col.Started)
 	return &timestamp.Timestamp{
-		Seconds: int64(floor),
-		Nanos:   int32(remain * billion),
+		Seconds: ms / 1000,
+		Nanos:   int32(ms%1000) * 1e6,
 	}
 }
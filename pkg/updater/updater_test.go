@@ -30,11 +30,14 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 	core "k8s.io/api/core/v1"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
+	"github.com/GoogleCloudPlatform/testgrid/internal/alert"
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
 	_ "github.com/GoogleCloudPlatform/testgrid/metadata/junit"
 	configpb "github.com/GoogleCloudPlatform/testgrid/pb/config"
@@ -78,7 +81,7 @@ func TestGCS(t *testing.T) {
 			// either because the context is canceled or things like client are unset)
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel()
-			updater := GCS(0, 0, 0, false, SortStarted)
+			updater := GCS(0, 0, 0, 0, false, SortStarted, nil)
 			defer func() {
 				if r := recover(); r != nil {
 					if !tc.fail {
@@ -99,6 +102,68 @@ func TestGCS(t *testing.T) {
 	}
 }
 
+func TestColumnReaders(t *testing.T) {
+	cases := []struct {
+		name     string
+		group    *configpb.TestGroup
+		fallback bool
+		read     bool
+		err      bool
+	}{
+		{
+			name:     "fall back without a column reader",
+			group:    &configpb.TestGroup{Name: "group"},
+			fallback: true,
+		},
+		{
+			name:  "read with the named reader",
+			group: &configpb.TestGroup{Name: "group", ColumnReader: "plugin", DaysOfResults: 1, NumColumnsRecent: 1},
+			read:  true,
+		},
+		{
+			name:  "reject unknown readers",
+			group: &configpb.TestGroup{Name: "group", ColumnReader: "missing"},
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fellBack, read bool
+			fallback := func(context.Context, logrus.FieldLogger, gcs.Client, *configpb.TestGroup, gcs.Path) error {
+				fellBack = true
+				return nil
+			}
+			readers := map[string]ColumnReader{
+				"plugin": func(context.Context, logrus.FieldLogger, *configpb.TestGroup, []InflatedColumn, time.Time) ([]InflatedColumn, error) {
+					read = true
+					return nil, nil
+				},
+			}
+			client := fake.UploadClient{
+				Client:   fake.Client{Opener: fake.Opener{}},
+				Uploader: fake.Uploader{},
+			}
+			update := ColumnReaders(fallback, readers, time.Minute, false, SortStarted, nil)
+			err := update(context.Background(), logrus.WithField("case", tc.name), client, tc.group, newPathOrDie("gs://bucket/grid/group"))
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("update() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("update() failed to return an error")
+			}
+			if fellBack != tc.fallback {
+				t.Errorf("update() fell back %t, want %t", fellBack, tc.fallback)
+			}
+			if read != tc.read {
+				t.Errorf("update() read %t, want %t", read, tc.read)
+			}
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	defaultTimeout := 5 * time.Minute
 	configPath := newPathOrDie("gs://bucket/path/to/config")
@@ -225,7 +290,7 @@ func TestUpdate(t *testing.T) {
 				client.Lister[buildsPath] = fi
 			}
 
-			groupUpdater := GCS(*tc.groupTimeout, *tc.buildTimeout, tc.buildConcurrency, !tc.skipConfirm, SortStarted)
+			groupUpdater := GCS(*tc.groupTimeout, *tc.buildTimeout, tc.buildConcurrency, 0, !tc.skipConfirm, SortStarted, nil)
 
 			err := Update(
 				ctx,
@@ -256,6 +321,37 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestFixTime(t *testing.T) {
+	defer func() { clock = time.Now }()
+	pinned := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	FixTime(pinned)
+	if got := clock(); !got.Equal(pinned) {
+		t.Fatalf("clock() got %s, want %s", got, pinned)
+	}
+
+	// Running columns within three days of the pinned time hold back the update.
+	started := func(d time.Duration) float64 {
+		return float64(pinned.Add(-d).Unix() * 1000)
+	}
+	cols := []InflatedColumn{
+		{
+			Column: &statepb.Column{Build: "2", Started: started(time.Hour)},
+			Cells:  map[string]Cell{"foo": {Result: statuspb.TestStatus_PASS}},
+		},
+		{
+			Column: &statepb.Column{Build: "1", Started: started(2 * time.Hour)},
+			Cells:  map[string]Cell{"foo": {Result: statuspb.TestStatus_RUNNING}},
+		},
+	}
+	if got := truncateRunning(cols); len(got) != 0 {
+		t.Errorf("truncateRunning() got %d columns, want 0", len(got))
+	}
+	FixTime(pinned.Add(96 * time.Hour))
+	if got := truncateRunning(cols); len(got) != 2 {
+		t.Errorf("truncateRunning() got %d columns long after the pinned time, want 2", len(got))
+	}
+}
+
 func TestTestGroupPath(t *testing.T) {
 	path := newPathOrDie("gs://bucket/config")
 	pNewPathOrDie := func(s string) *gcs.Path {
@@ -298,17 +394,17 @@ func TestTestGroupPath(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual, err := testGroupPath(path, tc.gridPrefix, tc.groupName)
+			actual, err := TestGroupPath(path, tc.gridPrefix, tc.groupName)
 			switch {
 			case err != nil:
 				if tc.expected != nil {
-					t.Errorf("testGroupPath(%v, %v) got unexpected error: %v", path, tc.groupName, err)
+					t.Errorf("TestGroupPath(%v, %v) got unexpected error: %v", path, tc.groupName, err)
 				}
 			case tc.expected == nil:
-				t.Errorf("testGroupPath(%v, %v) failed to receive an error", path, tc.groupName)
+				t.Errorf("TestGroupPath(%v, %v) failed to receive an error", path, tc.groupName)
 			default:
 				if diff := cmp.Diff(actual, tc.expected, cmp.AllowUnexported(gcs.Path{})); diff != "" {
-					t.Errorf("testGroupPath(%v, %v) got unexpected diff (-have, +want):\n%s", path, tc.groupName, diff)
+					t.Errorf("TestGroupPath(%v, %v) got unexpected diff (-have, +want):\n%s", path, tc.groupName, diff)
 				}
 			}
 		})
@@ -360,6 +456,144 @@ func mustGrid(grid *statepb.Grid) []byte {
 	return buf
 }
 
+func TestOverlappingGroups(t *testing.T) {
+	cases := []struct {
+		name   string
+		groups []*configpb.TestGroup
+		want   map[string]bool
+	}{
+		{
+			name: "basically works",
+			want: map[string]bool{},
+		},
+		{
+			name: "distinct prefixes",
+			groups: []*configpb.TestGroup{
+				{Name: "foo", GcsPrefix: "bucket/foo"},
+				{Name: "foobar", GcsPrefix: "bucket/foobar"},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "same prefix",
+			groups: []*configpb.TestGroup{
+				{Name: "foo", GcsPrefix: "bucket/foo"},
+				{Name: "bar", GcsPrefix: "bucket/foo/"},
+				{Name: "other", GcsPrefix: "bucket/other"},
+			},
+			want: map[string]bool{
+				"foo": true,
+				"bar": true,
+			},
+		},
+		{
+			name: "nested prefix",
+			groups: []*configpb.TestGroup{
+				{Name: "all", GcsPrefix: "bucket/logs"},
+				{Name: "foo", GcsPrefix: "bucket/logs/foo"},
+				{Name: "foobar", GcsPrefix: "bucket/logs/foobar"},
+			},
+			want: map[string]bool{
+				"all":    true,
+				"foo":    true,
+				"foobar": true,
+			},
+		},
+		{
+			name: "merged source",
+			groups: []*configpb.TestGroup{
+				{Name: "foo", GcsPrefix: "bucket/foo"},
+				{
+					Name:      "merged",
+					GcsPrefix: "bucket/merged",
+					MergedSources: []*configpb.TestGroup_MergedSource{
+						{Name: "src", GcsPrefix: "bucket/foo"},
+					},
+				},
+			},
+			want: map[string]bool{
+				"foo":    true,
+				"merged": true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := overlappingGroups(tc.groups)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("overlappingGroups() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSkipGroup(t *testing.T) {
+	now := time.Now()
+	path, err := gcs.NewPath("gs://bucket/grid/foo")
+	if err != nil {
+		t.Fatalf("bad path: %v", err)
+	}
+	cases := []struct {
+		name   string
+		group  *configpb.TestGroup
+		stater fakeStater
+		want   bool
+	}{
+		{
+			name:  "basically works",
+			group: &configpb.TestGroup{},
+		},
+		{
+			name:  "skip paused groups",
+			group: &configpb.TestGroup{Paused: true},
+			want:  true,
+		},
+		{
+			name:  "skip recently updated groups",
+			group: &configpb.TestGroup{UpdateInterval: "1h"},
+			stater: fakeStater{
+				*path: {Attrs: storage.ObjectAttrs{Updated: now.Add(-time.Minute)}},
+			},
+			want: true,
+		},
+		{
+			name:  "update groups after the interval",
+			group: &configpb.TestGroup{UpdateInterval: "1h"},
+			stater: fakeStater{
+				*path: {Attrs: storage.ObjectAttrs{Updated: now.Add(-2 * time.Hour)}},
+			},
+		},
+		{
+			name:  "update new groups",
+			group: &configpb.TestGroup{UpdateInterval: "1h"},
+		},
+		{
+			name:  "update groups that fail to stat",
+			group: &configpb.TestGroup{UpdateInterval: "1h"},
+			stater: fakeStater{
+				*path: {Err: errors.New("injected")},
+			},
+		},
+		{
+			name:  "ignore bad intervals",
+			group: &configpb.TestGroup{UpdateInterval: "hourly"},
+			stater: fakeStater{
+				*path: {Attrs: storage.ObjectAttrs{Updated: now}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, why := skipGroup(context.Background(), tc.stater, tc.group, *path, now)
+			if got != tc.want {
+				t.Errorf("skipGroup() got %t (%s), want %t", got, why, tc.want)
+			}
+		})
+	}
+}
+
 func TestSortGroups(t *testing.T) {
 	now := time.Now()
 	times := []time.Time{
@@ -449,7 +683,7 @@ func TestSortGroups(t *testing.T) {
 				} else {
 					stat.Attrs.Updated = *updated
 				}
-				path, err := testGroupPath(*configPath, gridPrefix, name)
+				path, err := TestGroupPath(*configPath, gridPrefix, name)
 				if err != nil {
 					t.Fatalf("bad group path: %v", err)
 				}
@@ -770,9 +1004,19 @@ func TestTruncateBuilds(t *testing.T) {
 }
 
 func TestListBuilds(t *testing.T) {
+	mixed := fakeIterator{
+		Objects: []storage.ObjectAttrs{
+			{Prefix: "job/1/"},
+			{Prefix: "job/10/"},
+			{Prefix: "job/build-10/"},
+			{Prefix: "job/build-8/"},
+			{Prefix: "job/build-9/"},
+		},
+	}
 	cases := []struct {
 		name     string
 		since    string
+		group    *configpb.TestGroup
 		client   fakeLister
 		paths    []gcs.Path
 		expected []gcs.Build
@@ -943,20 +1187,20 @@ func TestListBuilds(t *testing.T) {
 				newPathOrDie("gs://other-prefix/presubmit-job/"): fakeIterator{
 					Objects: []storage.ObjectAttrs{
 						{
-							Name: "job/2",
+							Name: "presubmit-job/2",
 							Metadata: map[string]string{
 								"link": "gs://foo/bar333", // intentionally larger than job 20 and 4
 							},
 						},
 						{
-							Name: "job/20",
+							Name: "presubmit-job/20",
 							Metadata: map[string]string{
 								"link": "gs://foo/bar222",
 							},
 						},
 						{
 
-							Name: "job/4",
+							Name: "presubmit-job/4",
 							Metadata: map[string]string{
 								"link": "gs://foo/bar111",
 							},
@@ -978,6 +1222,45 @@ func TestListBuilds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "list numbered names after the offset",
+			since: "build-9", // drop 1 10 build-8, keep build-10
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): mixed,
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://prefix/job/build-10/"),
+				},
+			},
+		},
+		{
+			name:  "list numbers after names in natural order",
+			since: "build-9", // drop build-8, keep 10 1 build-10
+			group: &configpb.TestGroup{
+				BuildOrder: configpb.TestGroup_BUILD_ORDER_NATURAL,
+			},
+			client: fakeLister{
+				newPathOrDie("gs://prefix/job/"): mixed,
+			},
+			paths: []gcs.Path{
+				newPathOrDie("gs://prefix/job/"),
+			},
+			expected: []gcs.Build{
+				{
+					Path: newPathOrDie("gs://prefix/job/10/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/1/"),
+				},
+				{
+					Path: newPathOrDie("gs://prefix/job/build-10/"),
+				},
+			},
+		},
 	}
 
 	compareBuilds := cmp.Comparer(func(x, y gcs.Build) bool {
@@ -986,7 +1269,7 @@ func TestListBuilds(t *testing.T) {
 	ctx := context.Background()
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual, err := listBuilds(ctx, tc.client, tc.since, tc.paths...)
+			actual, err := listBuilds(ctx, tc.client, tc.since, buildLess(tc.group), tc.paths...)
 			switch {
 			case err != nil:
 				if !tc.err {
@@ -1097,8 +1380,9 @@ func TestInflateDropAppend(t *testing.T) {
 					Rows: []*statepb.Row{
 						setupRow(
 							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
+								Name:      overallRow,
+								Id:        overallRow,
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{
 								Result:  statuspb.TestStatus_RUNNING,
@@ -1120,8 +1404,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: podInfoRow,
-								Id:   podInfoRow,
+								Name:      podInfoRow,
+								Id:        podInfoRow,
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_NO_RESULT},
 							podInfoPassCell,
@@ -1130,8 +1415,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: "flaky",
-								Id:   "flaky",
+								Name:      "flaky",
+								Id:        "flaky",
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_NO_RESULT},
 							cell{Result: statuspb.TestStatus_PASS},
@@ -1144,8 +1430,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: "good1",
-								Id:   "good1",
+								Name:      "good1",
+								Id:        "good1",
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_NO_RESULT},
 							cell{Result: statuspb.TestStatus_PASS},
@@ -1154,8 +1441,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: "good2",
-								Id:   "good2",
+								Name:      "good2",
+								Id:        "good2",
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_NO_RESULT},
 							cell{Result: statuspb.TestStatus_PASS},
@@ -1259,8 +1547,9 @@ func TestInflateDropAppend(t *testing.T) {
 					Rows: []*statepb.Row{
 						setupRow(
 							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
+								Name:      overallRow,
+								Id:        overallRow,
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{
 								Result:  statuspb.TestStatus_PASS,
@@ -1282,8 +1571,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: podInfoRow,
-								Id:   podInfoRow,
+								Name:      podInfoRow,
+								Id:        podInfoRow,
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							podInfoPassCell,
 							podInfoPassCell,
@@ -1292,8 +1582,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: "flaky",
-								Id:   "flaky",
+								Name:      "flaky",
+								Id:        "flaky",
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_PASS},
 							cell{
@@ -1306,8 +1597,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: "good1",
-								Id:   "good1",
+								Name:      "good1",
+								Id:        "good1",
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_PASS},
 							cell{Result: statuspb.TestStatus_PASS},
@@ -1316,8 +1608,9 @@ func TestInflateDropAppend(t *testing.T) {
 						),
 						setupRow(
 							&statepb.Row{
-								Name: "good2",
-								Id:   "good2",
+								Name:      "good2",
+								Id:        "good2",
+								FirstSeen: &timestamp.Timestamp{Seconds: now + 10},
 							},
 							cell{Result: statuspb.TestStatus_PASS},
 							cell{Result: statuspb.TestStatus_PASS},
@@ -1456,8 +1749,9 @@ func TestInflateDropAppend(t *testing.T) {
 					Rows: []*statepb.Row{
 						setupRow(
 							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
+								Name:      overallRow,
+								Id:        overallRow,
+								FirstSeen: &timestamp.Timestamp{Seconds: now - 10, Nanos: 999000000},
 							},
 							cell{
 								Result:  statuspb.TestStatus_RUNNING,
@@ -1582,8 +1876,9 @@ func TestInflateDropAppend(t *testing.T) {
 					Rows: []*statepb.Row{
 						setupRow(
 							&statepb.Row{
-								Name: overallRow,
-								Id:   overallRow,
+								Name:      overallRow,
+								Id:        overallRow,
+								FirstSeen: &timestamp.Timestamp{Seconds: now - 60},
 							},
 							cell{
 								Result:  statuspb.TestStatus_RUNNING,
@@ -1602,6 +1897,25 @@ func TestInflateDropAppend(t *testing.T) {
 				WorldRead:    gcs.DefaultACL,
 			},
 		},
+		{
+			name: "abort when the existing grid fails to download",
+			group: configpb.TestGroup{
+				GcsPrefix: "bucket/path/to/build/",
+			},
+			builds: []fakeBuild{
+				{
+					id:      "10",
+					started: jsonStarted(now + 10),
+					podInfo: podInfoSuccess,
+					finished: jsonFinished(now+11, true, metadata.Metadata{
+						metadata.JobVersion: "build10",
+					}),
+					passed: []string{"good1"},
+				},
+			},
+			current: &fake.Object{OpenErr: errors.New("transient")},
+			err:     true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1643,7 +1957,7 @@ func TestInflateDropAppend(t *testing.T) {
 			}
 			client.Lister[buildsPath] = fi
 
-			colReader := gcsColumnReader(client, *tc.buildTimeout, tc.concurrency)
+			colReader := gcsColumnReader(client, *tc.buildTimeout, tc.concurrency, 0)
 			if tc.colSorter == nil {
 				tc.colSorter = SortStarted
 			}
@@ -1657,12 +1971,16 @@ func TestInflateDropAppend(t *testing.T) {
 				colReader,
 				tc.colSorter,
 				tc.reprocess,
+				nil,
 			)
 			switch {
 			case err != nil:
 				if !tc.err {
 					t.Errorf("updateGroup() got unexpected error: %v", err)
 				}
+				if len(client.Uploader) > 0 {
+					t.Errorf("updateGroup() got error %v, but wrote %d objects", err, len(client.Uploader))
+				}
 			case tc.err:
 				t.Error("updateGroup() failed to receive an exception")
 			default:
@@ -1698,105 +2016,522 @@ func TestInflateDropAppend(t *testing.T) {
 	}
 }
 
-func TestFormatStrftime(t *testing.T) {
+func TestNewlyCompleted(t *testing.T) {
+	col := func(build string, results ...statuspb.TestStatus) InflatedColumn {
+		cells := map[string]Cell{}
+		for i, r := range results {
+			cells[fmt.Sprintf("row-%d", i)] = Cell{Result: r}
+		}
+		return InflatedColumn{
+			Column: &statepb.Column{Build: build, Hint: build},
+			Cells:  cells,
+		}
+	}
+	grid := func(cols ...InflatedColumn) *statepb.Grid {
+		return constructGrid(logrus.New(), &configpb.TestGroup{}, cols)
+	}
+	builds := func(cols []InflatedColumn) []string {
+		var out []string
+		for _, c := range cols {
+			out = append(out, c.Column.Build)
+		}
+		return out
+	}
+	pass := statuspb.TestStatus_PASS
+	running := statuspb.TestStatus_RUNNING
+
 	cases := []struct {
 		name string
-		want string
+		old  *statepb.Grid
+		cols []InflatedColumn
+		want []string
 	}{
 		{
 			name: "basically works",
-			want: "basically works",
-		},
-		{
-			name: "Mon Jan 2 15:04:05",
-			want: "Mon Jan 2 15:04:05",
-		},
-		{
-			name: "python am/pm: %p",
-			want: "python am/pm: PM",
 		},
 		{
-			name: "python year: %Y",
-			want: "python year: 2006",
-		},
-		{
-			name: "python short year: %y",
-			want: "python short year: 06",
-		},
-		{
-			name: "python month: %m",
-			want: "python month: 01",
-		},
-		{
-			name: "python date: %d",
-			want: "python date: 02",
+			name: "new columns without an old grid",
+			cols: []InflatedColumn{col("2", pass), col("1", pass)},
+			want: []string{"2", "1"},
 		},
 		{
-			name: "python 24hr: %H",
-			want: "python 24hr: 15",
+			name: "skip running columns",
+			cols: []InflatedColumn{col("2", pass, running), col("1", pass)},
+			want: []string{"1"},
 		},
 		{
-			name: "python minutes: %M",
-			want: "python minutes: 04",
+			name: "skip columns already complete",
+			old:  grid(col("1", pass)),
+			cols: []InflatedColumn{col("2", pass), col("1", pass)},
+			want: []string{"2"},
 		},
 		{
-			name: "python seconds: %S",
-			want: "python seconds: 05",
+			name: "include columns which were running",
+			old:  grid(col("1", running)),
+			cols: []InflatedColumn{col("2", pass), col("1", pass)},
+			want: []string{"2", "1"},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := formatStrftime(tc.name); got != tc.want {
-				t.Errorf("formatStrftime(%q) got %q want %q", tc.name, got, tc.want)
+			got := builds(newlyCompleted(tc.old, tc.cols))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("newlyCompleted() got unexpected diff (-want +got):\n%s", diff)
 			}
 		})
 	}
-
 }
 
-func TestOverrideBuild(t *testing.T) {
+func TestLimitRows(t *testing.T) {
+	pass := Cell{Result: statuspb.TestStatus_PASS}
+	fail := Cell{Result: statuspb.TestStatus_FAIL, Message: "boom"}
 	cases := []struct {
 		name string
-		tg   *configpb.TestGroup
 		cols []InflatedColumn
+		max  int
 		want []InflatedColumn
+		n    int
 	}{
 		{
 			name: "basically works",
-			tg:   &configpb.TestGroup{},
 		},
 		{
-			name: "empty override does not override",
-			tg:   &configpb.TestGroup{},
+			name: "unlimited",
+			cols: []InflatedColumn{
+				{Cells: map[string]Cell{"a": pass, "b": pass}},
+			},
+			want: []InflatedColumn{
+				{Cells: map[string]Cell{"a": pass, "b": pass}},
+			},
+		},
+		{
+			name: "within limit",
+			max:  2,
 			cols: []InflatedColumn{
+				{Cells: map[string]Cell{overallRow: pass, "a": pass, "b": pass}},
+			},
+			want: []InflatedColumn{
+				{Cells: map[string]Cell{overallRow: pass, "a": pass, "b": pass}},
+			},
+		},
+		{
+			name: "collapse extra tests",
+			max:  1,
+			cols: []InflatedColumn{
+				{Cells: map[string]Cell{overallRow: fail, "a": pass, "b": pass, "c": fail}},
+				{Cells: map[string]Cell{overallRow: pass, "a": pass, "b": pass}},
+				{Cells: map[string]Cell{overallRow: pass, "a": pass}},
+			},
+			want: []InflatedColumn{
 				{
-					Column: &statepb.Column{
-						Build:   "hello",
-						Started: 7,
-					},
 					Cells: map[string]Cell{
-						"keep": {ID: "me"},
+						overallRow: fail,
+						"a":        pass,
+						"OVERFLOW (2 tests)": {
+							Result:  statuspb.TestStatus_FAIL,
+							Icon:    "1/2",
+							Message: "2 tests exceed the 1 row limit",
+							Metrics: map[string]float64{overflowMetric: 2},
+						},
 					},
 				},
 				{
-					Column: &statepb.Column{
-						Build:   "world",
-						Started: 6,
+					Cells: map[string]Cell{
+						overallRow: pass,
+						"a":        pass,
+						"OVERFLOW (2 tests)": {
+							Result:  statuspb.TestStatus_PASS,
+							Message: "1 tests exceed the 1 row limit",
+							Metrics: map[string]float64{overflowMetric: 1},
+						},
 					},
 				},
+				{Cells: map[string]Cell{overallRow: pass, "a": pass}},
 			},
-			want: []InflatedColumn{
+			n: 2,
+		},
+		{
+			name: "collapse earlier overflow rows",
+			max:  1,
+			cols: []InflatedColumn{
+				{Cells: map[string]Cell{"a": pass, "b": pass}},
 				{
-					Column: &statepb.Column{
-						Build:   "hello",
-						Started: 7,
-					},
 					Cells: map[string]Cell{
-						"keep": {ID: "me"},
+						"a": pass,
+						"OVERFLOW (5 tests)": {
+							Result:  statuspb.TestStatus_PASS,
+							Metrics: map[string]float64{overflowMetric: 5},
+						},
 					},
 				},
-				{
+			},
+			want: []InflatedColumn{
+				{
+					Cells: map[string]Cell{
+						"a": pass,
+						"OVERFLOW (1 tests)": {
+							Result:  statuspb.TestStatus_PASS,
+							Message: "1 tests exceed the 1 row limit",
+							Metrics: map[string]float64{overflowMetric: 1},
+						},
+					},
+				},
+				{
+					Cells: map[string]Cell{
+						"a": pass,
+						"OVERFLOW (1 tests)": {
+							Result:  statuspb.TestStatus_PASS,
+							Message: "5 tests exceed the 1 row limit",
+							Metrics: map[string]float64{overflowMetric: 5},
+						},
+					},
+				},
+			},
+			n: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := limitRows(&configpb.TestGroup{MaxRows: int32(tc.max)}, tc.cols)
+			if n != tc.n {
+				t.Errorf("limitRows() got %d, want %d", n, tc.n)
+			}
+			if diff := cmp.Diff(tc.want, tc.cols, protocmp.Transform()); diff != "" {
+				t.Errorf("limitRows() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteMirrors(t *testing.T) {
+	mustPath := func(s string) gcs.Path {
+		p, err := gcs.NewPath(s)
+		if err != nil {
+			t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+		}
+		return *p
+	}
+	buf := []byte("grid")
+	injected := errors.New("injected")
+	cases := []struct {
+		name     string
+		prefixes []string
+		client   fakeUploader
+		want     fakeUploader
+	}{
+		{
+			name:   "basically works",
+			client: fakeUploader{},
+			want:   fakeUploader{},
+		},
+		{
+			name:     "write each mirror",
+			prefixes: []string{"gs://replica/grid", "gs://public/"},
+			client:   fakeUploader{},
+			want: fakeUploader{
+				mustPath("gs://replica/grid/foo"): {Buf: buf, CacheControl: "no-cache", WorldRead: gcs.DefaultACL},
+				mustPath("gs://public/foo"):       {Buf: buf, CacheControl: "no-cache", WorldRead: gcs.DefaultACL},
+			},
+		},
+		{
+			name:     "continue after failures",
+			prefixes: []string{"s3://bad", "gs://broken/grid", "gs://replica/grid"},
+			client: fakeUploader{
+				mustPath("gs://broken/grid/foo"): {Err: injected},
+			},
+			want: fakeUploader{
+				mustPath("gs://broken/grid/foo"):  {Err: injected},
+				mustPath("gs://replica/grid/foo"): {Buf: buf, CacheControl: "no-cache", WorldRead: gcs.DefaultACL},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			writeMirrors(context.Background(), logrus.New(), tc.client, tc.prefixes, "foo", buf)
+			if diff := cmp.Diff(tc.want, tc.client, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("writeMirrors() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPublicGrid(t *testing.T) {
+	grid := &statepb.Grid{
+		Config: &configpb.TestGroup{GcsPrefix: "internal-bucket/logs"},
+		Columns: []*statepb.Column{
+			{Build: "1", Metadata: map[string]string{"node": "internal-pool"}},
+			{
+				Build: "2",
+				Override: &statepb.BuildOverride{
+					Build:      "2",
+					Result:     int32(statuspb.TestStatus_PASS),
+					Annotation: "flake",
+					Reason:     "see http://internal/incident",
+					Author:     "someone@example.com",
+				},
+			},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:         "foo",
+				Results:      []int32{int32(statuspb.TestStatus_FAIL), 2},
+				Messages:     []string{"token=hunter2", "see http://internal/log"},
+				Icons:        []string{"F", "F"},
+				BugId:        []string{"internal-123"},
+				UserProperty: []string{"internal", ""},
+				Properties:   []*statepb.PropertyValues{{Name: "host", Values: []string{"internal-host"}}},
+				CellLinks: []*statepb.CellLinks{
+					{Links: []*statepb.Link{{Name: "log", Url: "gs://internal-bucket/log"}}},
+					{},
+				},
+				CellParameters: []*statepb.CellParameters{
+					{Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: 12, Message: "token=hunter2"}}},
+				},
+				Links:     []*statepb.Link{{Name: "source", Url: "http://internal/source"}},
+				LastGreen: &statepb.LastGreen{BuildId: "0"},
+				AlertInfo: &statepb.AlertInfo{
+					FailCount:      2,
+					FailBuildId:    "1",
+					FailTime:       &timestamp.Timestamp{Seconds: 10},
+					FailureMessage: "token=hunter2",
+					BuildLink:      "http://internal/changes",
+					Properties:     map[string]string{"host": "internal-host"},
+				},
+			},
+		},
+		TestMetadata:  []*statepb.TestMetadata{{TestName: "foo", Owner: "someone@example.com"}},
+		ArchivePath:   "gs://internal-bucket/archive",
+		ArchiveShards: []*statepb.ArchiveShard{{Path: "gs://internal-bucket/archive/1", Columns: 1}},
+	}
+	want := &statepb.Grid{
+		Columns: []*statepb.Column{
+			{Build: "1"},
+			{
+				Build: "2",
+				Override: &statepb.BuildOverride{
+					Build:      "2",
+					Result:     int32(statuspb.TestStatus_PASS),
+					Annotation: "flake",
+				},
+			},
+		},
+		Rows: []*statepb.Row{
+			{
+				Name:     "foo",
+				Results:  []int32{int32(statuspb.TestStatus_FAIL), 2},
+				Messages: []string{"", ""},
+				Icons:    []string{"F", "F"},
+				CellParameters: []*statepb.CellParameters{
+					{Results: []*statepb.ParameterResult{{Parameters: "[1]", Result: 12}}},
+				},
+				AlertInfo: &statepb.AlertInfo{
+					FailCount:   2,
+					FailBuildId: "1",
+					FailTime:    &timestamp.Timestamp{Seconds: 10},
+				},
+			},
+		},
+	}
+	orig := proto.Clone(grid)
+
+	got := publicGrid(grid)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("publicGrid() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(orig, grid, protocmp.Transform()); diff != "" {
+		t.Errorf("publicGrid() modified the original grid (-was +now):\n%s", diff)
+	}
+}
+
+func TestPublicGridFields(t *testing.T) {
+	seen := map[protoreflect.FullName]bool{}
+	var classified func(md protoreflect.MessageDescriptor)
+	classified = func(md protoreflect.MessageDescriptor) {
+		if seen[md.FullName()] {
+			return
+		}
+		seen[md.FullName()] = true
+		policies, ok := publicGridFields[md.FullName()]
+		if !ok {
+			t.Errorf("Public message %s is missing from publicGridFields", md.FullName())
+			return
+		}
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			policy, ok := policies[fd.Name()]
+			if !ok {
+				t.Errorf("Field %s is neither public nor private in publicGridFields", fd.FullName())
+				continue
+			}
+			if policy == publicField && fd.Message() != nil && !fd.IsMap() {
+				classified(fd.Message())
+			}
+		}
+	}
+	classified(proto.MessageReflect(&statepb.Grid{}).Descriptor())
+}
+
+func TestPublishGrid(t *testing.T) {
+	grid := &statepb.Grid{Columns: []*statepb.Column{{Build: "1"}}}
+	buf, err := marshalGrid(grid)
+	if err != nil {
+		t.Fatalf("marshalGrid() got unexpected error: %v", err)
+	}
+	path, err := gcs.NewPath("gs://public/grids/foo")
+	if err != nil {
+		t.Fatalf("bad path: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		cfg  *configpb.PublicGrid
+		want fakeUploader
+		err  bool
+	}{
+		{
+			name: "default cache control",
+			cfg:  &configpb.PublicGrid{Prefix: "gs://public/grids"},
+			want: fakeUploader{
+				*path: {Buf: buf, CacheControl: "public, max-age=300", WorldRead: true},
+			},
+		},
+		{
+			name: "custom cache control",
+			cfg:  &configpb.PublicGrid{Prefix: "gs://public/grids/", CacheControl: "public, max-age=60"},
+			want: fakeUploader{
+				*path: {Buf: buf, CacheControl: "public, max-age=60", WorldRead: true},
+			},
+		},
+		{
+			name: "bad prefix",
+			cfg:  &configpb.PublicGrid{Prefix: "s3://public/grids"},
+			want: fakeUploader{},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeUploader{}
+			err := publishGrid(context.Background(), client, tc.cfg, "foo", grid)
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("publishGrid() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Error("publishGrid() failed to return an error")
+			}
+			if diff := cmp.Diff(tc.want, client); diff != "" {
+				t.Errorf("publishGrid() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatStrftime(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "basically works",
+			want: "basically works",
+		},
+		{
+			name: "Mon Jan 2 15:04:05",
+			want: "Mon Jan 2 15:04:05",
+		},
+		{
+			name: "python am/pm: %p",
+			want: "python am/pm: PM",
+		},
+		{
+			name: "python year: %Y",
+			want: "python year: 2006",
+		},
+		{
+			name: "python short year: %y",
+			want: "python short year: 06",
+		},
+		{
+			name: "python month: %m",
+			want: "python month: 01",
+		},
+		{
+			name: "python date: %d",
+			want: "python date: 02",
+		},
+		{
+			name: "python 24hr: %H",
+			want: "python 24hr: 15",
+		},
+		{
+			name: "python minutes: %M",
+			want: "python minutes: 04",
+		},
+		{
+			name: "python seconds: %S",
+			want: "python seconds: 05",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FormatStrftime(tc.name); got != tc.want {
+				t.Errorf("FormatStrftime(%q) got %q want %q", tc.name, got, tc.want)
+			}
+		})
+	}
+
+}
+
+func TestOverrideBuild(t *testing.T) {
+	cases := []struct {
+		name string
+		tg   *configpb.TestGroup
+		cols []InflatedColumn
+		want []InflatedColumn
+	}{
+		{
+			name: "basically works",
+			tg:   &configpb.TestGroup{},
+		},
+		{
+			name: "empty override does not override",
+			tg:   &configpb.TestGroup{},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:   "world",
+						Started: 6,
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "hello",
+						Started: 7,
+					},
+					Cells: map[string]Cell{
+						"keep": {ID: "me"},
+					},
+				},
+				{
 					Column: &statepb.Column{
 						Build:   "world",
 						Started: 6,
@@ -2176,6 +2911,96 @@ func TestGroupColumns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "strict columns record merged builds and dropped cells",
+			tg: &configpb.TestGroup{
+				IgnoreOldResults: true,
+				StrictColumns:    true,
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:   "same",
+						Name:    "same",
+						Started: 9,
+						Hint:    "3",
+					},
+					Cells: map[string]Cell{
+						"same": {ID: "newest"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build:        "same",
+						Name:         "same",
+						Started:      7,
+						Hint:         "2",
+						MergedBuilds: []string{"2", "1"},
+						DroppedCells: 1,
+					},
+					Cells: map[string]Cell{
+						"same":  {ID: "older"},
+						"other": {ID: "other"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:        "same",
+						Name:         "same",
+						Started:      7,
+						Hint:         "3",
+						MergedBuilds: []string{"3", "2", "1"},
+						DroppedCells: 2,
+					},
+					Cells: map[string]Cell{
+						"same":  {ID: "newest"},
+						"other": {ID: "other"},
+					},
+				},
+			},
+		},
+		{
+			name: "strict columns count split names matching other tests",
+			tg: &configpb.TestGroup{
+				StrictColumns: true,
+			},
+			cols: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build: "same",
+						Name:  "same",
+						Hint:  "2",
+					},
+					Cells: map[string]Cell{
+						"same":     {ID: "newest"},
+						"same [1]": {ID: "really named this"},
+					},
+				},
+				{
+					Column: &statepb.Column{
+						Build: "same",
+						Name:  "same",
+						Hint:  "1",
+					},
+					Cells: map[string]Cell{
+						"same": {ID: "older"},
+					},
+				},
+			},
+			want: []InflatedColumn{
+				{
+					Column: &statepb.Column{
+						Build:        "same",
+						Name:         "same",
+						Hint:         "2",
+						MergedBuilds: []string{"2", "1"},
+						DroppedCells: 1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -2185,6 +3010,12 @@ func TestGroupColumns(t *testing.T) {
 				tg = &configpb.TestGroup{}
 			}
 			got := groupColumns(tg, tc.cols)
+			if tg.StrictColumns && tc.want != nil && tc.want[0].Cells == nil {
+				// Which colliding cell survives is unspecified.
+				for i := range got {
+					got[i].Cells = nil
+				}
+			}
 			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
 				t.Errorf("groupColumns() got unexpected diff (-want +got):\n%s", diff)
 			}
@@ -2447,12 +3278,7 @@ func TestConstructGrid(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			actual := constructGrid(logrus.WithField("name", tc.name), &tc.group, tc.cols)
-			failuresOpen := int(tc.group.NumFailuresToAlert)
-			passesClose := int(tc.group.NumPassesToDisableAlert)
-			if failuresOpen > 0 && passesClose == 0 {
-				passesClose = 1
-			}
-			alertRows(tc.expected.Columns, tc.expected.Rows, failuresOpen, passesClose)
+			alert.Set(&tc.group, tc.expected.Columns, tc.expected.Rows)
 			for _, row := range tc.expected.Rows {
 				sort.SliceStable(row.Metric, func(i, j int) bool {
 					return sortorder.NaturalLess(row.Metric[i], row.Metric[j])
@@ -2821,6 +3647,121 @@ func setupRow(row *statepb.Row, cells ...cell) *statepb.Row {
 	return row
 }
 
+func TestAppendProperties(t *testing.T) {
+	cases := []struct {
+		name  string
+		row   *statepb.Row
+		props map[string]string
+		want  *statepb.Row
+	}{
+		{
+			name: "basically works",
+			row:  &statepb.Row{},
+			want: &statepb.Row{},
+		},
+		{
+			name:  "first property",
+			row:   &statepb.Row{},
+			props: map[string]string{"owner": "node"},
+			want: &statepb.Row{
+				Properties: []*statepb.PropertyValues{
+					{Name: "owner", Values: []string{"node"}},
+				},
+			},
+		},
+		{
+			name: "backfill new property",
+			row: &statepb.Row{
+				Messages: []string{"", ""},
+				Properties: []*statepb.PropertyValues{
+					{Name: "owner", Values: []string{"node", "node"}},
+				},
+			},
+			props: map[string]string{"feature": "alpha"},
+			want: &statepb.Row{
+				Messages: []string{"", ""},
+				Properties: []*statepb.PropertyValues{
+					{Name: "owner", Values: []string{"node", "node", ""}},
+					{Name: "feature", Values: []string{"", "", "alpha"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			appendProperties(tc.row, tc.props)
+			if diff := cmp.Diff(tc.want, tc.row, protocmp.Transform()); diff != "" {
+				t.Errorf("appendProperties() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAppendLinks(t *testing.T) {
+	cases := []struct {
+		name  string
+		row   *statepb.Row
+		links []*statepb.Link
+		want  *statepb.Row
+	}{
+		{
+			name: "basically works",
+			row:  &statepb.Row{},
+			want: &statepb.Row{},
+		},
+		{
+			name: "skip until first link",
+			row: &statepb.Row{
+				Messages: []string{""},
+			},
+			want: &statepb.Row{
+				Messages: []string{""},
+			},
+		},
+		{
+			name: "backfill first link",
+			row: &statepb.Row{
+				Messages: []string{"", ""},
+			},
+			links: []*statepb.Link{{Name: "log.txt", Url: "gs://bucket/log.txt"}},
+			want: &statepb.Row{
+				Messages: []string{"", ""},
+				CellLinks: []*statepb.CellLinks{
+					{},
+					{},
+					{Links: []*statepb.Link{{Name: "log.txt", Url: "gs://bucket/log.txt"}}},
+				},
+			},
+		},
+		{
+			name: "append empty links after the first",
+			row: &statepb.Row{
+				Messages: []string{""},
+				CellLinks: []*statepb.CellLinks{
+					{Links: []*statepb.Link{{Name: "log.txt", Url: "gs://bucket/log.txt"}}},
+				},
+			},
+			want: &statepb.Row{
+				Messages: []string{""},
+				CellLinks: []*statepb.CellLinks{
+					{Links: []*statepb.Link{{Name: "log.txt", Url: "gs://bucket/log.txt"}}},
+					{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			appendLinks(tc.row, tc.links)
+			if diff := cmp.Diff(tc.want, tc.row, protocmp.Transform()); diff != "" {
+				t.Errorf("appendLinks() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestAppendColumn(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -3006,262 +3947,341 @@ func TestAppendColumn(t *testing.T) {
 	}
 }
 
-func TestAlertRow(t *testing.T) {
-	var columns []*statepb.Column
-	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
-		columns = append(columns, &statepb.Column{
-			Build:   id,
-			Started: 100 - float64(i),
-		})
-	}
+func TestMarkBrokenColumns(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	running := int32(statuspb.TestStatus_RUNNING)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
 	cases := []struct {
 		name      string
-		row       statepb.Row
-		failOpen  int
-		passClose int
-		expected  *statepb.AlertInfo
+		rows      []*statepb.Row
+		threshold float32
+		want      []bool
 	}{
 		{
-			name: "never alert by default",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 6,
-				},
+			name: "disabled by default",
+			rows: []*statepb.Row{
+				{Results: []int32{fail, 3}},
+				{Results: []int32{fail, 3}},
 			},
+			want: []bool{false, false, false},
 		},
 		{
-			name: "passes do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 6,
-				},
-			},
-			failOpen:  1,
-			passClose: 3,
+			name: "mark mostly failing columns",
+			rows: []*statepb.Row{
+				{Results: []int32{fail, 1, pass, 2}},
+				{Results: []int32{fail, 2, pass, 1}},
+				{Results: []int32{fail, 1, pass, 1, fail, 1}},
+				{Results: []int32{pass, 3}},
+			},
+			threshold: 0.5,
+			want:      []bool{true, false, false},
 		},
 		{
-			name: "flakes do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 6,
-				},
-			},
-			failOpen: 1,
+			name: "ignore running and empty cells",
+			rows: []*statepb.Row{
+				{Results: []int32{running, 1, empty, 1, fail, 1}},
+				{Results: []int32{fail, 1, fail, 1, pass, 1}},
+				{Results: []int32{fail, 1, empty, 1, pass, 1}},
+			},
+			threshold: 0.5,
+			want:      []bool{true, false, false},
 		},
 		{
-			name: "intermittent failures do not alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 2,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 2,
-				},
+			name: "single results are not broken",
+			rows: []*statepb.Row{
+				{Results: []int32{fail, 1, empty, 1}},
+				{Results: []int32{empty, 2}},
 			},
-			failOpen: 3,
-		},
-		{
-			name: "new failures alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 3,
-					int32(statuspb.TestStatus_PASS), 3,
-				},
-				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
-				CellIds:  []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
-			},
-			failOpen: 3,
-			expected: alertInfo(3, "no", "very wrong", "no", columns[2], columns[0], columns[3]),
+			threshold: 0.5,
+			want:      []bool{false, false},
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cols := make([]*statepb.Column, len(tc.want))
+			for i := range cols {
+				cols[i] = &statepb.Column{Build: fmt.Sprint(i)}
+			}
+			markBrokenColumns(cols, tc.rows, tc.threshold)
+			got := make([]bool, len(cols))
+			for i, col := range cols {
+				got[i] = col.Broken
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("markBrokenColumns() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetLastGreen(t *testing.T) {
+	cols := []*statepb.Column{
+		{Build: "c", Started: 300000},
+		{Build: "b", Started: 200500, Extra: []string{"commit-b"}},
+		{Build: "a", Started: 100000},
+	}
+	carried := &statepb.LastGreen{BuildId: "old", Time: &timestamp.Timestamp{Seconds: 50}}
+	cases := []struct {
+		name      string
+		row       *statepb.Row
+		old       *statepb.Row
+		want      *statepb.LastGreen
+		wantAlert *statepb.AlertInfo
+	}{
 		{
-			name: "rows without cell IDs can alert",
-			row: statepb.Row{
+			name: "passing rows have no last green",
+			row: &statepb.Row{
+				Name: "row",
 				Results: []int32{
-					int32(statuspb.TestStatus_FAIL), 3,
-					int32(statuspb.TestStatus_PASS), 3,
+					int32(statuspb.TestStatus_PASS), 1,
+					int32(statuspb.TestStatus_FAIL), 2,
 				},
-				Messages: []string{"no", "no again", "very wrong", "yes", "hi", "hello"},
 			},
-			failOpen: 3,
-			expected: alertInfo(3, "no", "", "", columns[2], columns[0], columns[3]),
+			old: &statepb.Row{Name: "row", LastGreen: carried},
 		},
 		{
-			name: "too few passes do not close",
-			row: statepb.Row{
+			name: "flaky rows have no last green",
+			row: &statepb.Row{
+				Name: "row",
 				Results: []int32{
+					int32(statuspb.TestStatus_FLAKY), 1,
 					int32(statuspb.TestStatus_PASS), 2,
-					int32(statuspb.TestStatus_FAIL), 4,
 				},
-				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
 			},
-			failOpen:  1,
-			passClose: 3,
-			expected:  alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil),
 		},
 		{
-			name: "flakes do not close",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_FLAKY), 2,
-					int32(statuspb.TestStatus_FAIL), 4,
-				},
-				Messages: []string{"nope", "no", "yay", "very wrong", "hi", "hello"},
-				CellIds:  []string{"wrong", "no", "yep", "very wrong", "hi", "hello"},
-			},
-			failOpen: 1,
-			expected: alertInfo(4, "yay", "hello", "yep", columns[5], columns[2], nil),
-		},
-		{
-			name: "count failures after flaky passes",
-			row: statepb.Row{
+			name: "failing rows find their last pass",
+			row: &statepb.Row{
+				Name: "row",
 				Results: []int32{
 					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_FLAKY), 1,
-					int32(statuspb.TestStatus_FAIL), 1,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 2,
+					int32(statuspb.TestStatus_PASS), 2,
 				},
-				Messages: []string{"nope", "no", "buu", "wrong", "this one", "hi"},
-				CellIds:  []string{"wrong", "no", "buzz", "wrong2", "good job", "hi"},
 			},
-			failOpen:  2,
-			passClose: 2,
-			expected:  alertInfo(4, "this one", "hi", "good job", columns[5], columns[4], nil),
-		},
-		{
-			name: "close alert",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 5,
-				},
+			old: &statepb.Row{Name: "row", LastGreen: carried},
+			want: &statepb.LastGreen{
+				BuildId: "commit-b",
+				Time:    &timestamp.Timestamp{Seconds: 200, Nanos: 500000000},
 			},
-			failOpen: 1,
 		},
 		{
-			name: "track through empty results",
-			row: statepb.Row{
+			name: "ignore running and empty cells",
+			row: &statepb.Row{
+				Name: "row",
 				Results: []int32{
+					int32(statuspb.TestStatus_RUNNING), 1,
 					int32(statuspb.TestStatus_FAIL), 1,
 					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_FAIL), 4,
 				},
-				Messages: []string{"yay" /*no result */, "no", "buu", "wrong", "nono"},
-				CellIds:  []string{"yay-cell" /*no result */, "no", "buzz", "wrong2", "nada"},
 			},
-			failOpen:  5,
-			passClose: 2,
-			expected:  alertInfo(5, "yay", "nada", "yay-cell", columns[5], columns[0], nil),
 		},
 		{
-			name: "track passes through empty results",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_NO_RESULT), 1,
-					int32(statuspb.TestStatus_PASS), 1,
-					int32(statuspb.TestStatus_FAIL), 3,
-				},
+			name: "carry the last green of rows failing throughout",
+			row: &statepb.Row{
+				Name:      "row",
+				Results:   []int32{int32(statuspb.TestStatus_FAIL), 3},
+				AlertInfo: &statepb.AlertInfo{FailBuildId: "a"},
+			},
+			old:  &statepb.Row{Name: "row", LastGreen: carried},
+			want: carried,
+			wantAlert: &statepb.AlertInfo{
+				FailBuildId: "a",
+				PassBuildId: "old",
 			},
-			failOpen:  1,
-			passClose: 2,
 		},
 		{
-			name: "running cells advance compressed index",
-			row: statepb.Row{
-				Results: []int32{
-					int32(statuspb.TestStatus_RUNNING), 1,
-					int32(statuspb.TestStatus_FAIL), 5,
-				},
-				Messages: []string{"running0", "fail1-expected", "fail2", "fail3", "fail4", "fail5"},
-				CellIds:  []string{"wrong", "yep", "no2", "no3", "no4", "no5"},
+			name: "only carry the same row",
+			row: &statepb.Row{
+				Name:    "row",
+				Results: []int32{int32(statuspb.TestStatus_FAIL), 3},
 			},
-			failOpen: 1,
-			expected: alertInfo(5, "fail1-expected", "no5", "yep", columns[5], columns[1], nil),
+			old: &statepb.Row{Name: "other", LastGreen: carried},
 		},
 	}
 
 	for _, tc := range cases {
-		actual := alertRow(columns, &tc.row, tc.failOpen, tc.passClose)
-		if diff := cmp.Diff(tc.expected, actual, protocmp.Transform()); diff != "" {
-			t.Errorf("alertRow() not as expected (-want, +got): %s", diff)
-		}
+		t.Run(tc.name, func(t *testing.T) {
+			var oldRows []*statepb.Row
+			if tc.old != nil {
+				oldRows = append(oldRows, tc.old)
+			}
+			setLastGreen(cols, []*statepb.Row{tc.row}, oldRows)
+			if diff := cmp.Diff(tc.want, tc.row.LastGreen, protocmp.Transform()); diff != "" {
+				t.Errorf("setLastGreen() got unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAlert, tc.row.AlertInfo, protocmp.Transform()); diff != "" {
+				t.Errorf("setLastGreen() got unexpected alert diff (-want +got):\n%s", diff)
+			}
+		})
 	}
 }
 
-func TestBuildID(t *testing.T) {
+func TestSetFirstSeen(t *testing.T) {
+	now := time.Unix(10*86400, 0)
+	cols := []*statepb.Column{
+		{Build: "3", Started: float64(now.Add(-time.Hour).Unix() * 1000)},
+		{Build: "2", Started: float64(now.Add(-48*time.Hour).Unix() * 1000)},
+		{Build: "1", Started: float64(now.Add(-96*time.Hour).Unix() * 1000)},
+	}
+	hourAgo := &timestamp.Timestamp{Seconds: now.Add(-time.Hour).Unix()}
+	twoDaysAgo := &timestamp.Timestamp{Seconds: now.Add(-48 * time.Hour).Unix()}
+	fourDaysAgo := &timestamp.Timestamp{Seconds: now.Add(-96 * time.Hour).Unix()}
+	weekAgo := &timestamp.Timestamp{Seconds: now.Add(-7 * 24 * time.Hour).Unix()}
 	cases := []struct {
-		name     string
-		build    string
-		extra    string
-		expected string
+		name    string
+		results []int32
+		old     *timestamp.Timestamp
+		newDays int32
+		want    *timestamp.Timestamp
+		wantNew bool
 	}{
 		{
-			name: "return empty by default",
+			name:    "first result",
+			results: []int32{int32(statuspb.TestStatus_FAIL), 1, int32(statuspb.TestStatus_PASS), 1, int32(statuspb.TestStatus_NO_RESULT), 1},
+			want:    twoDaysAgo,
+		},
+		{
+			name:    "running counts",
+			results: []int32{int32(statuspb.TestStatus_RUNNING), 1, int32(statuspb.TestStatus_NO_RESULT), 2},
+			newDays: 1,
+			want:    hourAgo,
+			wantNew: true,
+		},
+		{
+			name:    "new within new days",
+			results: []int32{int32(statuspb.TestStatus_FLAKY), 2, int32(statuspb.TestStatus_NO_RESULT), 1},
+			newDays: 3,
+			want:    twoDaysAgo,
+			wantNew: true,
 		},
 		{
-			name:     "favor extra if it exists",
-			build:    "wrong",
-			extra:    "right",
-			expected: "right",
+			name:    "not new beyond new days",
+			results: []int32{int32(statuspb.TestStatus_PASS), 3},
+			newDays: 3,
+			want:    fourDaysAgo,
 		},
 		{
-			name:     "build if no extra",
-			build:    "yes",
-			expected: "yes",
+			name:    "keep earlier old first seen",
+			results: []int32{int32(statuspb.TestStatus_PASS), 1, int32(statuspb.TestStatus_NO_RESULT), 2},
+			old:     weekAgo,
+			newDays: 3,
+			want:    weekAgo,
+		},
+		{
+			name:    "ignore later old first seen",
+			results: []int32{int32(statuspb.TestStatus_PASS), 3},
+			old:     hourAgo,
+			want:    fourDaysAgo,
+		},
+		{
+			name:    "keep old first seen without results",
+			results: []int32{int32(statuspb.TestStatus_NO_RESULT), 3},
+			old:     twoDaysAgo,
+			newDays: 3,
+			want:    twoDaysAgo,
+			wantNew: true,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			col := statepb.Column{
-				Build: tc.build,
-			}
-			if tc.extra != "" {
-				col.Extra = append(col.Extra, tc.extra)
+			row := &statepb.Row{Name: "row", Results: tc.results}
+			oldRows := []*statepb.Row{{Name: "row", FirstSeen: tc.old}}
+			setFirstSeen(cols, []*statepb.Row{row}, oldRows, tc.newDays, now)
+			if diff := cmp.Diff(tc.want, row.FirstSeen, protocmp.Transform()); diff != "" {
+				t.Errorf("setFirstSeen() got unexpected diff (-want +got):\n%s", diff)
 			}
-			if actual := buildID(&col); actual != tc.expected {
-				t.Errorf("%q != expected %q", actual, tc.expected)
+			if row.IsNew != tc.wantNew {
+				t.Errorf("setFirstSeen() got new %t, want %t", row.IsNew, tc.wantNew)
 			}
 		})
 	}
 }
 
-func TestStamp(t *testing.T) {
+func TestSetTombstones(t *testing.T) {
+	pass := int32(statuspb.TestStatus_PASS)
+	fail := int32(statuspb.TestStatus_FAIL)
+	running := int32(statuspb.TestStatus_RUNNING)
+	empty := int32(statuspb.TestStatus_NO_RESULT)
+	cols := []*statepb.Column{
+		{Build: "5", Started: 5000},
+		{Build: "4", Started: 4000},
+		{Build: "3", Started: 3000, Broken: true},
+		{Build: "2", Started: 2000},
+		{Build: "1", Started: 1000, Extra: []string{"commit-1"}},
+	}
 	cases := []struct {
-		name     string
-		col      *statepb.Column
-		expected *timestamp.Timestamp
+		name    string
+		missing int
+		rows    []*statepb.Row
+		want    map[string]*statepb.Tombstone
 	}{
 		{
-			name: "0 returns nil",
+			name: "disabled",
+			rows: []*statepb.Row{
+				{Name: "present", Results: []int32{pass, 5}},
+				{Name: "gone", Results: []int32{empty, 4, pass, 1}},
+			},
 		},
 		{
-			name: "no nanos",
-			col: &statepb.Column{
-				Started: 2,
+			name:    "tombstone missing rows",
+			missing: 2,
+			rows: []*statepb.Row{
+				{Name: "present", Results: []int32{pass, 5}},
+				{Name: "gone", Results: []int32{empty, 4, fail, 1}},
+				{Name: "recent", Results: []int32{empty, 1, pass, 4}},
 			},
-			expected: &timestamp.Timestamp{
-				Seconds: 2,
-				Nanos:   0,
+			want: map[string]*statepb.Tombstone{
+				"gone": {
+					BuildId:        "commit-1",
+					Time:           &timestamp.Timestamp{Seconds: 1},
+					MissingColumns: 3,
+				},
 			},
 		},
 		{
-			name: "has nanos",
-			col: &statepb.Column{
-				Started: 1.1,
+			name:    "broken columns do not count",
+			missing: 3,
+			rows: []*statepb.Row{
+				{Name: "present", Results: []int32{pass, 5}},
+				{Name: "gone", Results: []int32{empty, 3, pass, 2}},
 			},
-			expected: &timestamp.Timestamp{
-				Seconds: 1,
-				Nanos:   1e8,
+		},
+		{
+			name:    "untested columns do not count",
+			missing: 1,
+			rows: []*statepb.Row{
+				{Name: overallRow, Results: []int32{fail, 2, pass, 3}},
+				{Name: podInfoRow, Results: []int32{pass, 5}},
+				{Name: "harness broke", Results: []int32{empty, 2, pass, 3}},
+			},
+		},
+		{
+			name:    "running rows are present",
+			missing: 1,
+			rows: []*statepb.Row{
+				{Name: "present", Results: []int32{pass, 5}},
+				{Name: "running", Results: []int32{running, 1, empty, 4}},
 			},
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if actual := stamp(tc.col); !reflect.DeepEqual(actual, tc.expected) {
-				t.Errorf("stamp %s != expected stamp %s", actual, tc.expected)
+			setTombstones(cols, tc.rows, tc.missing)
+			got := map[string]*statepb.Tombstone{}
+			for _, row := range tc.rows {
+				if row.Tombstone != nil {
+					got[row.Name] = row.Tombstone
+				}
+			}
+			if tc.want == nil {
+				tc.want = map[string]*statepb.Tombstone{}
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("setTombstones() got unexpected diff (-want +got):\n%s", diff)
 			}
 		})
 	}
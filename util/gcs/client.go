@@ -60,6 +60,11 @@ type Copier interface {
 	Copy(ctx context.Context, from, to Path) error
 }
 
+// A Deleter can delete an object.
+type Deleter interface {
+	Delete(ctx context.Context, path Path) error
+}
+
 // A Client can upload, download and stat.
 type Client interface {
 	Uploader
@@ -71,6 +76,7 @@ type Client interface {
 // A ConditionalClient can limit actions to those matching conditions.
 type ConditionalClient interface {
 	Client
+	Deleter
 	// If specifies conditions on the object read from and/or written to.
 	If(read, write *storage.Conditions) ConditionalClient
 }
@@ -127,6 +133,12 @@ func (gc gcsClient) Upload(ctx context.Context, path Path, buf []byte, worldRead
 	return client.Upload(ctx, path, buf, worldReadable, cacheControl)
 }
 
+// Delete removes the object at the given path.
+func (gc gcsClient) Delete(ctx context.Context, path Path) error {
+	client := gc.clientFromPath(path)
+	return client.Delete(ctx, path)
+}
+
 // Stat returns object attributes for a given path.
 func (gc gcsClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
 	client := gc.clientFromPath(path)
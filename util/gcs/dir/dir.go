@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dir stores objects in a local directory, so that code written for
+// GCS runs without a bucket, such as for local demos.
+package dir
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+// tempPrefix starts the name of files which are still being written.
+const tempPrefix = ".upload-"
+
+// Client stores gs://bucket/object at root/bucket/object.
+//
+// Conditions are ignored, so only a single process should write to root.
+type Client struct {
+	root string
+}
+
+var _ gcs.ConditionalClient = &Client{}
+
+// NewClient returns a client which stores objects under the root directory.
+func NewClient(root string) *Client {
+	return &Client{root: root}
+}
+
+// file returns the local path to the object.
+func (c *Client) file(path gcs.Path) (string, error) {
+	if scheme := path.URL().Scheme; scheme != "gs" {
+		return "", fmt.Errorf("%s: must use a gs:// url", path)
+	}
+	if path.Bucket() == "" {
+		return "", fmt.Errorf("%s: missing bucket", path)
+	}
+	return filepath.Join(c.root, path.Bucket(), filepath.FromSlash(path.Object())), nil
+}
+
+func notExist(err error) error {
+	if os.IsNotExist(err) {
+		return storage.ErrObjectNotExist
+	}
+	return err
+}
+
+// If ignores the conditions.
+func (c *Client) If(_, _ *storage.Conditions) gcs.ConditionalClient {
+	return c
+}
+
+// Upload atomically replaces the object, so readers never see a partial write.
+func (c *Client) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, _ string) error {
+	name, err := c.file(path)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, tempPrefix)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// Open opens the object for reading.
+func (c *Client) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	name, err := c.file(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, notExist(err)
+	}
+	return f, nil
+}
+
+// Stat returns the attributes of the object.
+//
+// The generation is the modification time, which changes with each upload.
+func (c *Client) Stat(_ context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	name, err := c.file(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, notExist(err)
+	}
+	if info.IsDir() {
+		return nil, storage.ErrObjectNotExist
+	}
+	return attrs(path.Bucket(), path.Object(), info), nil
+}
+
+func attrs(bucket, name string, info os.FileInfo) *storage.ObjectAttrs {
+	return &storage.ObjectAttrs{
+		Bucket:     bucket,
+		Name:       name,
+		Size:       info.Size(),
+		Updated:    info.ModTime(),
+		Generation: info.ModTime().UnixNano(),
+	}
+}
+
+// Copy copies the object.
+func (c *Client) Copy(ctx context.Context, from, to gcs.Path) error {
+	r, err := c.Open(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return c.Upload(ctx, to, buf, false, "")
+}
+
+// Delete removes the object.
+func (c *Client) Delete(_ context.Context, path gcs.Path) error {
+	name, err := c.file(path)
+	if err != nil {
+		return err
+	}
+	return notExist(os.Remove(name))
+}
+
+// Objects lists the objects under the prefix, in name order.
+//
+// With a delimiter, names which contain it after the prefix are collapsed
+// into a single Prefix entry, like GCS does.
+func (c *Client) Objects(_ context.Context, prefix gcs.Path, delimiter, start string) gcs.Iterator {
+	p := prefix.Object()
+	if p != "" && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	dir, err := c.file(prefix)
+	if err != nil {
+		return &sliceIterator{err: err}
+	}
+	if p == "" {
+		dir = filepath.Join(c.root, prefix.Bucket())
+	}
+	bucket := filepath.Join(c.root, prefix.Bucket())
+
+	var found []*storage.ObjectAttrs
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), tempPrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(bucket, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if name < start {
+			return nil
+		}
+		found = append(found, attrs(prefix.Bucket(), name, info))
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return &sliceIterator{err: err}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Name < found[j].Name
+	})
+
+	var out []*storage.ObjectAttrs
+	seen := map[string]bool{}
+	for _, a := range found {
+		if delimiter != "" {
+			if idx := strings.Index(a.Name[len(p):], delimiter); idx >= 0 {
+				dir := a.Name[:len(p)+idx+len(delimiter)]
+				if !seen[dir] {
+					seen[dir] = true
+					out = append(out, &storage.ObjectAttrs{Prefix: dir})
+				}
+				continue
+			}
+		}
+		out = append(out, a)
+	}
+	return &sliceIterator{attrs: out}
+}
+
+type sliceIterator struct {
+	attrs []*storage.ObjectAttrs
+	err   error
+}
+
+func (si *sliceIterator) Next() (*storage.ObjectAttrs, error) {
+	if si.err != nil {
+		return nil, si.err
+	}
+	if len(si.attrs) == 0 {
+		return nil, iterator.Done
+	}
+	attrs := si.attrs[0]
+	si.attrs = si.attrs[1:]
+	return attrs, nil
+}
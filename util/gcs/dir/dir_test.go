@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dir
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("gcs.NewPath(%q) got err: %v", s, err)
+	}
+	return *p
+}
+
+func tempClient(t *testing.T) *Client {
+	t.Helper()
+	root, err := ioutil.TempDir("", "dir")
+	if err != nil {
+		t.Fatalf("TempDir() got unexpected error: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+	return NewClient(root)
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	client := tempClient(t)
+	hello := mustPath(t, "gs://bucket/path/to/hello")
+
+	if _, err := client.Open(ctx, hello); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Open() of a missing object got %v, want %v", err, storage.ErrObjectNotExist)
+	}
+	if err := client.Upload(ctx, hello, []byte("world"), gcs.DefaultACL, "no-cache"); err != nil {
+		t.Fatalf("Upload() got unexpected error: %v", err)
+	}
+	r, err := client.Open(ctx, hello)
+	if err != nil {
+		t.Fatalf("Open() got unexpected error: %v", err)
+	}
+	buf, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(buf) != "world" {
+		t.Errorf("Open() read %q, %v, want %q", buf, err, "world")
+	}
+	attrs, err := client.Stat(ctx, hello)
+	if err != nil {
+		t.Fatalf("Stat() got unexpected error: %v", err)
+	}
+	if attrs.Name != "path/to/hello" || attrs.Size != 5 || attrs.Generation == 0 {
+		t.Errorf("Stat() got unexpected attrs: %+v", attrs)
+	}
+	if _, err := client.Stat(ctx, mustPath(t, "gs://bucket/path/to")); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Stat() of a directory got %v, want %v", err, storage.ErrObjectNotExist)
+	}
+
+	copied := mustPath(t, "gs://other/copy")
+	if err := client.Copy(ctx, hello, copied); err != nil {
+		t.Fatalf("Copy() got unexpected error: %v", err)
+	}
+	if err := client.Delete(ctx, hello); err != nil {
+		t.Fatalf("Delete() got unexpected error: %v", err)
+	}
+	if _, err := client.Stat(ctx, hello); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Stat() of a deleted object got %v, want %v", err, storage.ErrObjectNotExist)
+	}
+	if _, err := client.Stat(ctx, copied); err != nil {
+		t.Errorf("Stat() of the copy got unexpected error: %v", err)
+	}
+	if err := client.Delete(ctx, hello); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Delete() of a missing object got %v, want %v", err, storage.ErrObjectNotExist)
+	}
+	if err := client.Upload(ctx, mustPath(t, "file:///tmp/foo"), nil, gcs.DefaultACL, ""); err == nil {
+		t.Error("Upload() of a local path failed to return an error")
+	}
+}
+
+func TestObjects(t *testing.T) {
+	ctx := context.Background()
+	client := tempClient(t)
+	for _, name := range []string{
+		"gs://bucket/logs/job/1/started.json",
+		"gs://bucket/logs/job/1/finished.json",
+		"gs://bucket/logs/job/2/started.json",
+		"gs://bucket/logs/job/latest-build.txt",
+		"gs://bucket/logs/other/1/started.json",
+		"gs://bucket/config",
+		"gs://elsewhere/logs/job/3/started.json",
+	} {
+		if err := client.Upload(ctx, mustPath(t, name), []byte(name), gcs.DefaultACL, ""); err != nil {
+			t.Fatalf("Upload(%s) got unexpected error: %v", name, err)
+		}
+	}
+
+	cases := []struct {
+		name      string
+		prefix    string
+		delimiter string
+		start     string
+		want      []string
+	}{
+		{
+			name:   "list everything under the prefix",
+			prefix: "gs://bucket/logs/job/",
+			want: []string{
+				"logs/job/1/finished.json",
+				"logs/job/1/started.json",
+				"logs/job/2/started.json",
+				"logs/job/latest-build.txt",
+			},
+		},
+		{
+			name:      "collapse directories",
+			prefix:    "gs://bucket/logs/job",
+			delimiter: "/",
+			want:      []string{"logs/job/1/", "logs/job/2/", "logs/job/latest-build.txt"},
+		},
+		{
+			name:      "start at an offset",
+			prefix:    "gs://bucket/logs/job/",
+			delimiter: "/",
+			start:     "logs/job/2",
+			want:      []string{"logs/job/2/", "logs/job/latest-build.txt"},
+		},
+		{
+			name:      "list the bucket",
+			prefix:    "gs://bucket/",
+			delimiter: "/",
+			want:      []string{"config", "logs/"},
+		},
+		{
+			name:   "missing prefix",
+			prefix: "gs://bucket/missing/",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			it := client.Objects(ctx, mustPath(t, tc.prefix), tc.delimiter, tc.start)
+			var got []string
+			for {
+				attrs, err := it.Next()
+				if errors.Is(err, iterator.Done) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() got unexpected error: %v", err)
+				}
+				if attrs.Prefix != "" {
+					got = append(got, attrs.Prefix)
+					continue
+				}
+				got = append(got, attrs.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Objects() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
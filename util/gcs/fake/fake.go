@@ -99,6 +99,13 @@ func (cc ConditionalClient) Upload(ctx context.Context, path gcs.Path, buf []byt
 	return nil
 }
 
+func (cc ConditionalClient) Delete(ctx context.Context, path gcs.Path) error {
+	if err := cc.check(ctx, nil, &path); err != nil {
+		return err
+	}
+	return cc.UploadClient.Delete(ctx, path)
+}
+
 func (cc ConditionalClient) If(read, write *storage.Conditions) gcs.ConditionalClient {
 	return ConditionalClient{
 		UploadClient: cc.UploadClient,
@@ -174,6 +181,21 @@ func (fuc Uploader) Upload(ctx context.Context, path gcs.Path, buf []byte, world
 	return nil
 }
 
+func (fuc Uploader) Delete(ctx context.Context, path gcs.Path) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("injected interrupt: %w", err)
+	}
+	u, present := fuc[path]
+	if !present {
+		return storage.ErrObjectNotExist
+	}
+	if err := u.Err; err != nil {
+		return fmt.Errorf("injected delete error: %w", err)
+	}
+	delete(fuc, path)
+	return nil
+}
+
 type Upload struct {
 	Buf          []byte
 	CacheControl string
@@ -233,6 +255,7 @@ type Lister map[gcs.Path]Iterator
 func (fl Lister) Objects(ctx context.Context, path gcs.Path, _, offset string) gcs.Iterator {
 	f := fl[path]
 	f.ctx = ctx
+	f.Offset = offset
 	return &f
 }
 
@@ -258,11 +281,8 @@ func (fi *Iterator) Next() (*storage.ObjectAttrs, error) {
 			break
 		}
 		name, prefix := fi.Objects[fi.Idx].Name, fi.Objects[fi.Idx].Prefix
-		if name != "" && name < fi.Offset {
-			continue
-		}
-		if prefix != "" && prefix < fi.Offset {
-			continue
+		if (name == "" || name >= fi.Offset) && (prefix == "" || prefix >= fi.Offset) {
+			break
 		}
 		fi.Idx++
 	}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// FetchCache shares the metadata and junit results which builds read, so
+// test groups with overlapping prefixes download each file once, and parse
+// each junit file once.
+//
+// Each caller gets its own copy: builds decode the cached contents of metadata
+// and deep copy the cached junit results, so callers may modify them.
+//
+// Builds only use the cache after WithFetchCache. A cache should last a
+// single update cycle, since files such as finished.json appear as builds run.
+type FetchCache struct {
+	// MaxBytes evicts the least recently used files beyond about this many
+	// bytes, unlimited when zero.
+	//
+	// Evicted files, along with those larger than the limit, are read again by
+	// the next build which needs them.
+	MaxBytes int64
+
+	lock    sync.Mutex
+	entries map[string]*list.Element // of *fetchEntry
+	lru     list.List                // most recently used first
+	bytes   int64
+	hits    int
+	misses  int
+}
+
+type fetchEntry struct {
+	key  string
+	done chan struct{}
+	val  interface{}
+	size int64 // counted in bytes once cached
+	err  error
+}
+
+// fetch returns the cached value of the key, or reads it along with its size
+// in bytes.
+//
+// Concurrent fetches of a key wait for the first one. Failed reads are not
+// cached, so those waiting read the key themselves.
+func (c *FetchCache) fetch(ctx context.Context, key string, read func() (interface{}, int64, error)) (interface{}, error) {
+	if c == nil {
+		val, _, err := read()
+		return val, err
+	}
+	c.lock.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.hits++
+		c.lru.MoveToFront(elem)
+		c.lock.Unlock()
+		e := elem.Value.(*fetchEntry)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-e.done:
+		}
+		if e.err != nil {
+			val, _, err := read()
+			return val, err
+		}
+		return e.val, nil
+	}
+	c.misses++
+	if c.entries == nil {
+		c.entries = map[string]*list.Element{}
+	}
+	e := &fetchEntry{key: key, done: make(chan struct{})}
+	elem := c.lru.PushFront(e)
+	c.entries[key] = elem
+	c.lock.Unlock()
+
+	val, size, err := read()
+	c.lock.Lock()
+	e.val, e.err = val, err
+	if c.entries[key] == elem { // unless evicted while reading
+		if err != nil || c.MaxBytes > 0 && size > c.MaxBytes {
+			c.remove(elem)
+		} else {
+			e.size = size
+			c.bytes += size
+			c.evict()
+		}
+	}
+	c.lock.Unlock()
+	close(e.done)
+	return val, err
+}
+
+// remove drops the entry from the cache.
+func (c *FetchCache) remove(elem *list.Element) {
+	e := elem.Value.(*fetchEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, e.key)
+	c.bytes -= e.size
+}
+
+// evict drops the least recently used entries until the cache fits in MaxBytes.
+func (c *FetchCache) evict() {
+	for c.MaxBytes > 0 && c.bytes > c.MaxBytes {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Stats returns the number of reads which the cache served and the number
+// which read storage.
+func (c *FetchCache) Stats() (hits, misses int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.hits, c.misses
+}
+
+// Bytes returns the approximate size of the cached files.
+func (c *FetchCache) Bytes() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.bytes
+}
+
+type fetchCacheKey struct{}
+
+// WithFetchCache returns a context carrying the cache.
+func WithFetchCache(ctx context.Context, c *FetchCache) context.Context {
+	return context.WithValue(ctx, fetchCacheKey{}, c)
+}
+
+// FetchCacheFromContext returns the cache of the context, or nil when it has none.
+func FetchCacheFromContext(ctx context.Context) *FetchCache {
+	c, _ := ctx.Value(fetchCacheKey{}).(*FetchCache)
+	return c
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+)
+
+type countingOpener struct {
+	fakeOpener
+	lock  sync.Mutex
+	opens map[Path]int
+}
+
+func (co *countingOpener) Open(ctx context.Context, path Path) (io.ReadCloser, error) {
+	co.lock.Lock()
+	if co.opens == nil {
+		co.opens = map[Path]int{}
+	}
+	co.opens[path]++
+	co.lock.Unlock()
+	return co.fakeOpener.Open(ctx, path)
+}
+
+func TestFetchCache(t *testing.T) {
+	path := newPathOrDie("gs://bucket/path/")
+	started := resolveOrDie(path, "started.json")
+	finished := resolveOrDie(path, "finished.json")
+	cases := []struct {
+		name       string
+		cache      *FetchCache
+		objects    fakeOpener
+		reads      int
+		wantOpens  map[Path]int
+		wantHits   int
+		wantMisses int
+		wantErr    bool
+	}{
+		{
+			name:  "nil cache always reads",
+			reads: 3,
+			objects: fakeOpener{
+				started:  {data: `{"timestamp": 1}`},
+				finished: {data: `{"timestamp": 2}`},
+			},
+			wantOpens: map[Path]int{
+				started:  3,
+				finished: 3,
+			},
+		},
+		{
+			name:  "reads each object once",
+			cache: &FetchCache{},
+			reads: 3,
+			objects: fakeOpener{
+				started:  {data: `{"timestamp": 1}`},
+				finished: {data: `{"timestamp": 2}`},
+			},
+			wantOpens: map[Path]int{
+				started:  1,
+				finished: 1,
+			},
+			wantHits:   4,
+			wantMisses: 2,
+		},
+		{
+			name:  "caches missing objects",
+			cache: &FetchCache{},
+			reads: 2,
+			wantOpens: map[Path]int{
+				started:  1,
+				finished: 1,
+			},
+			wantHits:   2,
+			wantMisses: 2,
+		},
+		{
+			name:  "does not cache errors",
+			cache: &FetchCache{},
+			reads: 2,
+			objects: fakeOpener{
+				started: {readErr: errors.New("injected read error")},
+			},
+			wantOpens: map[Path]int{
+				started: 2,
+			},
+			wantMisses: 2,
+			wantErr:    true,
+		},
+		{
+			name:  "evicts beyond max bytes",
+			cache: &FetchCache{MaxBytes: 24},
+			reads: 2,
+			objects: fakeOpener{
+				started:  {data: `{"timestamp": 1, "node": "too big to keep"}`},
+				finished: {data: `{"timestamp": 2}`},
+			},
+			wantOpens: map[Path]int{
+				started:  2,
+				finished: 1,
+			},
+			wantHits:   1,
+			wantMisses: 3,
+		},
+		{
+			name:  "evicts the least recently used",
+			cache: &FetchCache{MaxBytes: 40},
+			reads: 2,
+			objects: fakeOpener{
+				started:  {data: `{"timestamp": 1, "node": "node"}`},
+				finished: {data: `{"timestamp": 2}`},
+			},
+			wantOpens: map[Path]int{
+				started:  2,
+				finished: 2,
+			},
+			wantMisses: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			opener := &countingOpener{fakeOpener: tc.objects}
+			if opener.fakeOpener == nil {
+				opener.fakeOpener = fakeOpener{}
+			}
+			b := Build{Path: path}.WithFetchCache(tc.cache)
+			var gotErr bool
+			for i := 0; i < tc.reads; i++ {
+				s, err := b.Started(ctx, opener)
+				if err != nil {
+					gotErr = true
+					continue
+				}
+				s.Timestamp = 100 // callers must not modify the cached value
+				f, err := b.Finished(ctx, opener)
+				if err != nil {
+					gotErr = true
+					continue
+				}
+				if want := opener.fakeOpener[finished].data; want != "" && f.Timestamp == nil {
+					t.Errorf("Finished() got nil timestamp")
+				}
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("got error %t, want %t", gotErr, tc.wantErr)
+			}
+			for p, want := range tc.wantOpens {
+				if got := opener.opens[p]; got != want {
+					t.Errorf("opened %s %d times, want %d", p, got, want)
+				}
+			}
+			if tc.cache == nil {
+				return
+			}
+			hits, misses := tc.cache.Stats()
+			if hits != tc.wantHits || misses != tc.wantMisses {
+				t.Errorf("Stats() got %d hits and %d misses, want %d and %d", hits, misses, tc.wantHits, tc.wantMisses)
+			}
+			if b := tc.cache.Bytes(); b < 0 || tc.cache.MaxBytes > 0 && b > tc.cache.MaxBytes {
+				t.Errorf("Bytes() got %d, want between 0 and %d", b, tc.cache.MaxBytes)
+			}
+			if want, ok := tc.objects[started]; ok && want.readErr == nil {
+				s, err := b.Started(ctx, opener)
+				if err != nil {
+					t.Fatalf("Started() got unexpected error: %v", err)
+				}
+				if s.Timestamp != 1 {
+					t.Errorf("Started() got timestamp %d, want 1", s.Timestamp)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchCacheFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := FetchCacheFromContext(ctx); got != nil {
+		t.Errorf("FetchCacheFromContext() got %v, want nil", got)
+	}
+	cache := &FetchCache{}
+	if got := FetchCacheFromContext(WithFetchCache(ctx, cache)); got != cache {
+		t.Errorf("FetchCacheFromContext() got %p, want %p", got, cache)
+	}
+}
+
+// Run with -race: groups modify what they read from a shared build.
+func TestFetchCacheCopies(t *testing.T) {
+	path := newPathOrDie("gs://bucket/build/")
+	opener := fakeOpener{
+		resolveOrDie(path, "started.json"):  {data: `{"timestamp": 1, "repos": {"org/repo": "main"}, "metadata": {"key": "value"}}`},
+		resolveOrDie(path, "finished.json"): {data: `{"timestamp": 2, "metadata": {"key": "value", "nested": {"key": "value"}}}`},
+		resolveOrDie(path, "podinfo.json"):  {data: `{"pod": {"metadata": {"labels": {"key": "value"}}}}`},
+		resolveOrDie(path, "junit.xml"):     {data: `<testsuite><testcase name="foo"><failure>boom</failure><properties><property name="key" value="value"/></properties></testcase></testsuite>`},
+	}
+	cache := &FetchCache{}
+
+	read := func() error {
+		ctx := context.Background()
+		b := Build{Path: path}.WithFetchCache(cache)
+		started, err := b.Started(ctx, opener)
+		if err != nil {
+			return err
+		}
+		started.Repos["org/repo"] = "modified"
+		started.Metadata["key"] = "modified"
+		finished, err := b.Finished(ctx, opener)
+		if err != nil {
+			return err
+		}
+		finished.Metadata["key"] = "modified"
+		finished.Metadata["nested"].(map[string]interface{})["key"] = "modified"
+		podInfo, err := b.PodInfo(ctx, opener)
+		if err != nil {
+			return err
+		}
+		podInfo.Pod.Labels["key"] = "modified"
+
+		artifacts := make(chan string, 1)
+		artifacts <- "/build/junit.xml"
+		close(artifacts)
+		suites := make(chan SuitesMeta, 1)
+		if err := b.Suites(ctx, opener, artifacts, suites); err != nil {
+			return err
+		}
+		s := <-suites
+		r := &s.Suites.Suites[0].Results[0]
+		*r.Failure = "modified"
+		r.Properties.PropertyList[0].Value = "modified"
+		r.SetProperty("other", "value")
+		return nil
+	}
+
+	// Two groups read the same build concurrently.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = read()
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("read got unexpected error: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	b := Build{Path: path}.WithFetchCache(cache)
+	started, err := b.Started(ctx, opener)
+	if err != nil {
+		t.Fatalf("Started() got unexpected error: %v", err)
+	}
+	if got := started.Repos["org/repo"]; got != "main" {
+		t.Errorf("Started() got repo %q, want main", got)
+	}
+	if got := started.Metadata["key"]; got != "value" {
+		t.Errorf("Started() got metadata %q, want value", got)
+	}
+	finished, err := b.Finished(ctx, opener)
+	if err != nil {
+		t.Fatalf("Finished() got unexpected error: %v", err)
+	}
+	if got := finished.Metadata["nested"].(map[string]interface{})["key"]; got != "value" {
+		t.Errorf("Finished() got nested metadata %q, want value", got)
+	}
+	podInfo, err := b.PodInfo(ctx, opener)
+	if err != nil {
+		t.Fatalf("PodInfo() got unexpected error: %v", err)
+	}
+	if got := podInfo.Pod.Labels["key"]; got != "value" {
+		t.Errorf("PodInfo() got label %q, want value", got)
+	}
+	artifacts := make(chan string, 1)
+	artifacts <- "/build/junit.xml"
+	close(artifacts)
+	suites := make(chan SuitesMeta, 1)
+	if err := b.Suites(ctx, opener, artifacts, suites); err != nil {
+		t.Fatalf("Suites() got unexpected error: %v", err)
+	}
+	r := (<-suites).Suites.Suites[0].Results[0]
+	if *r.Failure != "boom" {
+		t.Errorf("Suites() got failure %q, want boom", *r.Failure)
+	}
+	if want := []junit.Property{{Name: "key", Value: "value"}}; !reflect.DeepEqual(r.Properties.PropertyList, want) {
+		t.Errorf("Suites() got properties %v, want %v", r.Properties.PropertyList, want)
+	}
+	if hits, misses := cache.Stats(); misses != 4 {
+		t.Errorf("Stats() got %d hits and %d misses, want 4 misses", hits, misses)
+	}
+}
@@ -21,21 +21,19 @@ limitations under the License.
 package gcs
 
 import (
-	"compress/zlib"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash/crc32"
-	"io/ioutil"
 	"log"
 	"net/url"
 	"strings"
 
 	statepb "github.com/GoogleCloudPlatform/testgrid/pb/state"
+	"github.com/GoogleCloudPlatform/testgrid/pkg/state"
 
 	"cloud.google.com/go/storage"
-	"github.com/golang/protobuf/proto"
 	"google.golang.org/api/option"
 )
 
@@ -135,6 +133,12 @@ func (g Path) ResolveReference(ref *url.URL) (*Path, error) {
 	return &newP, nil
 }
 
+// MirrorPath returns the path to the named object under a mirror prefix,
+// such as gs://bucket/prefix or /local/dir.
+func MirrorPath(prefix, name string) (*Path, error) {
+	return NewPath(strings.TrimSuffix(prefix, "/") + "/" + name)
+}
+
 // Bucket returns bucket in gs://bucket/obj
 func (g Path) Bucket() string {
 	return g.url.Host
@@ -205,14 +209,10 @@ func DownloadGrid(ctx context.Context, opener Opener, path Path) (*statepb.Grid,
 		return nil, fmt.Errorf("open: %w", err)
 	}
 	defer r.Close()
-	zr, err := zlib.NewReader(r)
-	if err != nil {
-		return nil, fmt.Errorf("open zlib: %w", err)
-	}
-	pbuf, err := ioutil.ReadAll(zr)
-	if err != nil {
-		return nil, fmt.Errorf("decompress: %w", err)
-	}
-	err = proto.Unmarshal(pbuf, &g)
-	return &g, err
+	return state.Read(r)
+}
+
+// MarshalGrid serializes and compresses a grid for DownloadGrid to read.
+func MarshalGrid(grid *statepb.Grid) ([]byte, error) {
+	return state.Marshal(grid)
 }
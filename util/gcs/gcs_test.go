@@ -121,6 +121,52 @@ func Test_ResolveReference(t *testing.T) {
 	}
 }
 
+func TestMirrorPath(t *testing.T) {
+	cases := []struct {
+		name   string
+		prefix string
+		want   string
+		err    bool
+	}{
+		{
+			name:   "bucket prefix",
+			prefix: "gs://mirror/grid",
+			want:   "gs://mirror/grid/foo",
+		},
+		{
+			name:   "trailing slash",
+			prefix: "gs://mirror/grid/",
+			want:   "gs://mirror/grid/foo",
+		},
+		{
+			name:   "local directory",
+			prefix: "/mnt/mirror",
+			want:   "/mnt/mirror/foo",
+		},
+		{
+			name:   "unsupported scheme",
+			prefix: "s3://mirror/grid",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MirrorPath(tc.prefix, "foo")
+			switch {
+			case err != nil:
+				if !tc.err {
+					t.Errorf("MirrorPath() got unexpected error: %v", err)
+				}
+			case tc.err:
+				t.Errorf("MirrorPath() failed to return an error")
+			case got.String() != tc.want:
+				t.Errorf("MirrorPath() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
 // Ensure that a == b => calcCRC(a) == calcCRC(b)
 func Test_calcCRC(t *testing.T) {
 	b1 := []byte("hello")
@@ -106,6 +106,10 @@ func (lc localClient) Upload(ctx context.Context, path Path, buf []byte, _ bool,
 	return ioutil.WriteFile(cleanFilepath(path), buf, 0666)
 }
 
+func (lc localClient) Delete(ctx context.Context, path Path) error {
+	return convertIsNotExistsErr(os.Remove(cleanFilepath(path)))
+}
+
 func (lc localClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
 	info, err := os.Stat(cleanFilepath(path))
 	if err != nil {
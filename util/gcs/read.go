@@ -17,19 +17,23 @@ limitations under the License.
 package gcs
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"path"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
+	"unsafe"
 
 	"cloud.google.com/go/storage"
 	"github.com/fvbommel/sortorder"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/api/iterator"
 	core "k8s.io/api/core/v1"
 
@@ -148,6 +152,20 @@ type Build struct {
 	Path              Path
 	baseName          string
 	suitesConcurrency int // override the max number of concurrent suite downloads
+	cache             *FetchCache
+}
+
+// WithSuitesConcurrency returns a copy of the build which downloads at most n
+// junit files at once, or the default when n is zero.
+func (build Build) WithSuitesConcurrency(n int) Build {
+	build.suitesConcurrency = n
+	return build
+}
+
+// WithFetchCache returns a copy of the build which shares the files it reads through the cache.
+func (build Build) WithFetchCache(c *FetchCache) Build {
+	build.cache = c
+	return build
 }
 
 func (build Build) object() string {
@@ -185,27 +203,48 @@ func readLink(objAttrs *storage.ObjectAttrs) string {
 // hackOffset handles tot's sequential names, which GCS handles poorly
 // AKA asking GCS to return results after 6 will never find 10
 // So we always have to list everything for these types of numbers.
-func hackOffset(offset *string) string {
+//
+// Likewise GCS lists names lexically, so asking for results after build-9
+// will never find build-10. List after the part of the name before its
+// first digit instead, or everything when less orders numbers after the name.
+func hackOffset(offset *string, less func(a, b string) bool) string {
 	if *offset == "" {
 		return ""
 	}
 	offsetBaseName := path.Base(*offset)
 	const first = 1000000000000000000
-	if n, err := strconv.Atoi(offsetBaseName); err == nil && n < first {
-		*offset = path.Join(path.Dir(*offset), "0")
+	if n, err := strconv.Atoi(offsetBaseName); err == nil {
+		if n < first {
+			*offset = path.Join(path.Dir(*offset), "0")
+		}
+		return offsetBaseName
+	}
+	prefix := offsetBaseName
+	if i := strings.IndexAny(prefix, "0123456789"); i >= 0 {
+		prefix = prefix[:i]
 	}
+	if prefix > "0" && less(offsetBaseName, "0") {
+		prefix = "0" // numeric names are newer
+	}
+	*offset = path.Join(path.Dir(*offset), prefix)
 	return offsetBaseName
 }
 
 // ListBuilds returns the array of builds under path, sorted in monotonically decreasing order.
 func ListBuilds(parent context.Context, lister Lister, gcsPath Path, after *Path) ([]Build, error) {
+	return ListBuildsFunc(parent, lister, gcsPath, after, sortorder.NaturalLess)
+}
+
+// ListBuildsFunc returns the array of builds under path newer than after,
+// sorted in monotonically decreasing order of less.
+func ListBuildsFunc(parent context.Context, lister Lister, gcsPath Path, after *Path, less func(a, b string) bool) ([]Build, error) {
 	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 	var offset string
 	if after != nil {
 		offset = after.Object()
 	}
-	offsetBaseName := hackOffset(&offset)
+	offsetBaseName := hackOffset(&offset, less)
 	it := lister.Objects(ctx, gcsPath, "/", offset)
 	var all []Build
 	for {
@@ -258,13 +297,13 @@ func ListBuilds(parent context.Context, lister Lister, gcsPath Path, after *Path
 		})
 	}
 
-	Sort(all)
+	SortFunc(all, less)
 
 	if offsetBaseName != "" {
 		// GCS will return 200 2000 30 for a prefix of 100
 		// testgrid expects this as 2000 200 (dropping 30)
 		for i, b := range all {
-			if sortorder.NaturalLess(b.baseName, offsetBaseName) || b.baseName == offsetBaseName {
+			if less(b.baseName, offsetBaseName) || b.baseName == offsetBaseName {
 				return all[:i], nil // b <= offsetBaseName, so skip this one
 			}
 		}
@@ -310,23 +349,62 @@ func parseSuitesMeta(name string) map[string]string {
 
 // readJSON will decode the json object stored in GCS.
 func readJSON(ctx context.Context, opener Opener, p Path, i interface{}) error {
+	buf, err := readObject(ctx, opener, p)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(buf, i)
+}
+
+// readObject returns the contents of the object stored in GCS.
+func readObject(ctx context.Context, opener Opener, p Path) ([]byte, error) {
 	reader, err := opener.Open(ctx, p)
 	if errors.Is(err, storage.ErrObjectNotExist) {
-		return err
+		return nil, err
 	}
 	if err != nil {
-		return fmt.Errorf("open: %w", err)
+		return nil, fmt.Errorf("open: %w", err)
 	}
 	defer reader.Close()
-	if err = json.NewDecoder(reader).Decode(i); err != nil {
-		return fmt.Errorf("decode: %w", err)
+	buf, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
 	}
 	if err := reader.Close(); err != nil {
-		return fmt.Errorf("close: %w", err)
+		return nil, fmt.Errorf("close: %w", err)
+	}
+	return buf, nil
+}
+
+func decodeJSON(buf []byte, i interface{}) error {
+	if err := json.NewDecoder(bytes.NewReader(buf)).Decode(i); err != nil {
+		return fmt.Errorf("decode: %w", err)
 	}
 	return nil
 }
 
+// readCachedJSON decodes the json object stored in GCS, sharing its contents
+// with other builds through the cache.
+//
+// Only the contents are shared, so each caller decodes values it may modify.
+func (build Build) readCachedJSON(ctx context.Context, opener Opener, p Path, i interface{}) error {
+	val, err := build.cache.fetch(ctx, "json:"+p.String(), func() (interface{}, int64, error) {
+		buf, err := readObject(ctx, opener, p)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return []byte(nil), 0, nil // cache missing objects as nil
+		}
+		return buf, int64(len(buf)), err
+	})
+	if err != nil {
+		return err
+	}
+	buf := val.([]byte)
+	if buf == nil {
+		return storage.ErrObjectNotExist
+	}
+	return decodeJSON(buf, i)
+}
+
 // PodInfo parses the build's pod state.
 func (build Build) PodInfo(ctx context.Context, opener Opener) (*PodInfo, error) {
 	path, err := build.Path.ResolveReference(&url.URL{Path: "podinfo.json"})
@@ -334,7 +412,7 @@ func (build Build) PodInfo(ctx context.Context, opener Opener) (*PodInfo, error)
 		return nil, fmt.Errorf("resolve: %w", err)
 	}
 	var podInfo PodInfo
-	err = readJSON(ctx, opener, *path, &podInfo)
+	err = build.readCachedJSON(ctx, opener, *path, &podInfo)
 	if errors.Is(err, storage.ErrObjectNotExist) {
 		return nil, nil
 	}
@@ -351,7 +429,7 @@ func (build Build) Started(ctx context.Context, opener Opener) (*Started, error)
 		return nil, fmt.Errorf("resolve: %w", err)
 	}
 	var started Started
-	err = readJSON(ctx, opener, *path, &started)
+	err = build.readCachedJSON(ctx, opener, *path, &started)
 	if errors.Is(err, storage.ErrObjectNotExist) {
 		started.Pending = true
 		return &started, nil
@@ -369,7 +447,7 @@ func (build Build) Finished(ctx context.Context, opener Opener) (*Finished, erro
 		return nil, fmt.Errorf("resolve: %w", err)
 	}
 	var finished Finished
-	err = readJSON(ctx, opener, *path, &finished)
+	err = build.readCachedJSON(ctx, opener, *path, &finished)
 	if errors.Is(err, storage.ErrObjectNotExist) {
 		finished.Running = true
 		return &finished, nil
@@ -420,6 +498,28 @@ func readSuites(ctx context.Context, opener Opener, p Path) (*junit.Suites, erro
 	return suitesMeta, nil
 }
 
+// suitesBytes approximates the memory the parsed suites hold.
+func suitesBytes(suites []junit.Suite) int64 {
+	var n int64
+	for _, s := range suites {
+		n += int64(unsafe.Sizeof(s)+uintptr(len(s.Name))) + suitesBytes(s.Suites)
+		for _, r := range s.Results {
+			n += int64(unsafe.Sizeof(r) + uintptr(len(r.Name)+len(r.ClassName)))
+			for _, msg := range []*string{r.Failure, r.Output, r.Error, r.Errored, r.Skipped} {
+				if msg != nil {
+					n += int64(len(*msg))
+				}
+			}
+			if r.Properties != nil {
+				for _, p := range r.Properties.PropertyList {
+					n += int64(unsafe.Sizeof(p) + uintptr(len(p.Name)+len(p.Value)))
+				}
+			}
+		}
+	}
+	return n
+}
+
 // Error wraps an error in an associated Path.
 type Error struct {
 	Path
@@ -440,82 +540,68 @@ func (e Error) Error() string {
 //
 // Note that junit suites are parsed in parallel, so there are no guarantees about suites ordering.
 func (build Build) Suites(parent context.Context, opener Opener, artifacts <-chan string, suites chan<- SuitesMeta) error {
-	var wg sync.WaitGroup
-	var work int
-
-	ec := make(chan error)
-	ctx, cancel := context.WithCancel(parent)
-
-	// semaphore sets a ceiling of size go-routines slots
+	// sem sets a ceiling on the number of concurrent downloads.
 	size := build.suitesConcurrency
 	if size == 0 {
 		size = 5
 	}
-	semaphore := make(chan int, size)
-	defer close(semaphore) // close after all goroutines are done
-	defer wg.Wait()        // ensure all goroutines exit before returning
-	defer cancel()
+	sem := semaphore.NewWeighted(int64(size))
+	g, ctx := errgroup.WithContext(parent)
 
+	var err error
 	for art := range artifacts {
 		meta := parseSuitesMeta(art)
 		if meta == nil {
 			continue // not a junit file ignore it, ignore it
 		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if err = sem.Acquire(ctx, 1); err != nil {
+			break
+		}
 		// concurrently parse each file because there may be a lot of them, and
 		// each takes a non-trivial amount of time waiting for the network.
-		work++
-		wg.Add(1)
-
-		go func(art string, meta map[string]string) {
-			semaphore <- 1 // wait for free slot
-			defer wg.Done()
-			defer func() { <-semaphore }() // free up slot
+		art := art
+		g.Go(func() error {
+			defer sem.Release(1)
 			if art != "" && art[0] != '/' {
 				art = "/" + art
 			}
 			path, err := build.Path.ResolveReference(&url.URL{Path: art})
 			if err != nil {
-				select {
-				case <-ctx.Done():
-				case ec <- fmt.Errorf("resolve %q: %w", art, err):
-				}
-				return
+				return fmt.Errorf("resolve %q: %w", art, err)
 			}
 			out := SuitesMeta{
 				Metadata: meta,
 				Path:     path.String(),
 			}
-			s, err := readSuites(ctx, opener, *path)
-			if err != nil {
-				select {
-				case <-ctx.Done():
-				case ec <- fmt.Errorf("read %w", Error{*path, err}):
+			val, err := build.cache.fetch(ctx, "junit:"+path.String(), func() (interface{}, int64, error) {
+				suites, err := readSuites(ctx, opener, *path)
+				if err != nil {
+					return nil, 0, err
 				}
-				return
+				return suites, suitesBytes(suites.Suites), nil
+			})
+			if err != nil {
+				return fmt.Errorf("read %w", Error{*path, err})
 			}
-			out.Suites = *s
+			// Parsing streams large files without holding them in memory, so
+			// share the parsed suites and give each caller its own copy.
+			out.Suites = val.(*junit.Suites).Clone()
 			select {
 			case <-ctx.Done():
-				return
+				return ctx.Err()
 			case suites <- out:
+				return nil
 			}
-
-			select {
-			case <-ctx.Done():
-			case ec <- nil:
-			}
-		}(art, meta)
+		})
 	}
-
-	for ; work > 0; work-- {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timeout: %w", ctx.Err())
-		case err := <-ec:
-			if err != nil {
-				return err
-			}
-		}
+	if werr := g.Wait(); werr != nil {
+		return werr
+	}
+	if err != nil {
+		return fmt.Errorf("timeout: %w", err)
 	}
 	return nil
 }
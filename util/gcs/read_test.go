@@ -27,10 +27,12 @@ import (
 	"net/url"
 	"reflect"
 	"sort"
+	"strconv"
 	"sync"
 	"testing"
 
 	"cloud.google.com/go/storage"
+	"github.com/fvbommel/sortorder"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/iterator"
 	core "k8s.io/api/core/v1"
@@ -261,9 +263,18 @@ func TestBuildJob(t *testing.T) {
 }
 
 func TestOffsetHack(t *testing.T) {
+	numbersLast := func(a, b string) bool {
+		_, aerr := strconv.Atoi(a)
+		_, berr := strconv.Atoi(b)
+		if (aerr == nil) != (berr == nil) {
+			return berr == nil
+		}
+		return sortorder.NaturalLess(a, b)
+	}
 	cases := []struct {
 		name   string
 		input  string
+		less   func(a, b string) bool
 		output string
 		base   string
 	}{
@@ -285,15 +296,31 @@ func TestOffsetHack(t *testing.T) {
 		{
 			name:   "non-numerical builds work",
 			input:  "logs/ci-benchmark-scheduler/fancy4u",
-			output: "logs/ci-benchmark-scheduler/fancy4u",
+			output: "logs/ci-benchmark-scheduler/fancy",
 			base:   "fancy4u",
 		},
+		{
+			name:   "hack numbered names",
+			input:  "logs/ci-benchmark-scheduler/build-9",
+			output: "logs/ci-benchmark-scheduler/build-",
+			base:   "build-9",
+		},
+		{
+			name:   "list numbers ordered after names",
+			input:  "logs/ci-benchmark-scheduler/build-9",
+			less:   numbersLast,
+			output: "logs/ci-benchmark-scheduler/0",
+			base:   "build-9",
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
+			if tc.less == nil {
+				tc.less = sortorder.NaturalLess
+			}
 			output := tc.input
-			base := hackOffset(&output)
+			base := hackOffset(&output, tc.less)
 			if output != tc.output {
 				t.Errorf("hackOffset(%q) became %q, want %q", tc.input, output, tc.output)
 			}
@@ -82,6 +82,10 @@ func (rgc realGCSClient) Upload(ctx context.Context, path Path, buf []byte, worl
 	return UploadHandle(ctx, rgc.handle(path, rgc.writeCond), buf, worldReadable, cacheControl)
 }
 
+func (rgc realGCSClient) Delete(ctx context.Context, path Path) error {
+	return rgc.handle(path, rgc.writeCond).Delete(ctx)
+}
+
 func (rgc realGCSClient) Stat(ctx context.Context, path Path) (*storage.ObjectAttrs, error) {
 	return rgc.handle(path, rgc.readCond).Attrs(ctx)
 }
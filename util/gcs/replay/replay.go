@@ -0,0 +1,496 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replay records the storage reads of a cycle into a tarball and
+// replays them offline, so production conversion bugs reproduce locally.
+package replay
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+)
+
+const manifestName = "manifest.json"
+
+// object is the recorded state of a path.
+type object struct {
+	Path    string               `json:"path"`
+	Missing bool                 `json:"missing,omitempty"`
+	Attrs   *storage.ObjectAttrs `json:"attrs,omitempty"`
+	// File holds the content in the tarball, when the object was opened.
+	File string `json:"file,omitempty"`
+
+	buf []byte
+}
+
+// listing is a recorded Objects call.
+type listing struct {
+	Prefix    string                 `json:"prefix"`
+	Delimiter string                 `json:"delimiter,omitempty"`
+	Start     string                 `json:"start,omitempty"`
+	Attrs     []*storage.ObjectAttrs `json:"attrs"`
+}
+
+func (l listing) key() string {
+	return listingKey(l.Prefix, l.Delimiter, l.Start)
+}
+
+func listingKey(prefix, delimiter, start string) string {
+	return prefix + "\x00" + delimiter + "\x00" + start
+}
+
+type manifest struct {
+	Recorded time.Time `json:"recorded"`
+	Objects  []*object `json:"objects"`
+	Listings []listing `json:"listings"`
+}
+
+// recording holds everything read through a Recorder and the clients it derives.
+type recording struct {
+	lock     sync.Mutex
+	objects  map[gcs.Path]*object
+	listings map[string]*listing
+}
+
+func (r *recording) object(path gcs.Path) *object {
+	obj, ok := r.objects[path]
+	if !ok {
+		obj = &object{Path: path.String()}
+		r.objects[path] = obj
+	}
+	return obj
+}
+
+func (r *recording) missing(path gcs.Path) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	obj := r.object(path)
+	obj.Missing = true
+	obj.Attrs = nil
+	obj.buf = nil
+}
+
+func (r *recording) opened(path gcs.Path, buf []byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	obj := r.object(path)
+	obj.Missing = false
+	obj.buf = buf
+}
+
+func (r *recording) stated(path gcs.Path, attrs *storage.ObjectAttrs) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	obj := r.object(path)
+	obj.Missing = false
+	obj.Attrs = attrs
+}
+
+func (r *recording) listed(key string, l listing, attrs *storage.ObjectAttrs) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	rec, ok := r.listings[key]
+	if !ok {
+		rec = &l
+		r.listings[key] = rec
+	}
+	if attrs != nil {
+		rec.Attrs = append(rec.Attrs, attrs)
+	}
+}
+
+// Recorder is a client which records every object it reads.
+//
+// Writes pass through unrecorded. Call Save after the cycle to write the tarball.
+type Recorder struct {
+	client gcs.ConditionalClient
+	rec    *recording
+}
+
+// NewRecorder returns a client which records the reads of the client.
+func NewRecorder(client gcs.ConditionalClient) *Recorder {
+	return &Recorder{
+		client: client,
+		rec: &recording{
+			objects:  map[gcs.Path]*object{},
+			listings: map[string]*listing{},
+		},
+	}
+}
+
+var _ gcs.ConditionalClient = &Recorder{}
+
+// If returns a conditional client which shares the recording.
+func (r *Recorder) If(read, write *storage.Conditions) gcs.ConditionalClient {
+	return &Recorder{client: r.client.If(read, write), rec: r.rec}
+}
+
+// Open reads and records the entire object.
+func (r *Recorder) Open(ctx context.Context, path gcs.Path) (io.ReadCloser, error) {
+	rc, err := r.client.Open(ctx, path)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		r.rec.missing(path)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	buf, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	r.rec.opened(path, buf)
+	return ioutil.NopCloser(bytes.NewReader(buf)), nil
+}
+
+// Stat records the attributes of the object.
+func (r *Recorder) Stat(ctx context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	attrs, err := r.client.Stat(ctx, path)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+		r.rec.missing(path)
+	case err == nil:
+		r.rec.stated(path, attrs)
+	}
+	return attrs, err
+}
+
+// Objects records each listed object as the caller iterates.
+func (r *Recorder) Objects(ctx context.Context, prefix gcs.Path, delimiter, start string) gcs.Iterator {
+	l := listing{Prefix: prefix.String(), Delimiter: delimiter, Start: start}
+	key := l.key()
+	r.rec.listed(key, l, nil)
+	return &recordingIterator{
+		it:  r.client.Objects(ctx, prefix, delimiter, start),
+		rec: r.rec,
+		key: key,
+		l:   l,
+	}
+}
+
+type recordingIterator struct {
+	it  gcs.Iterator
+	rec *recording
+	key string
+	l   listing
+}
+
+func (ri *recordingIterator) Next() (*storage.ObjectAttrs, error) {
+	attrs, err := ri.it.Next()
+	if err == nil {
+		ri.rec.listed(ri.key, ri.l, attrs)
+	}
+	return attrs, err
+}
+
+// Upload passes through to the recorded client.
+func (r *Recorder) Upload(ctx context.Context, path gcs.Path, buf []byte, worldReadable bool, cacheControl string) error {
+	return r.client.Upload(ctx, path, buf, worldReadable, cacheControl)
+}
+
+// Copy passes through to the recorded client.
+func (r *Recorder) Copy(ctx context.Context, from, to gcs.Path) error {
+	return r.client.Copy(ctx, from, to)
+}
+
+// Delete passes through to the recorded client.
+func (r *Recorder) Delete(ctx context.Context, path gcs.Path) error {
+	return r.client.Delete(ctx, path)
+}
+
+// Save writes the recording as a gzipped tarball.
+func (r *Recorder) Save(w io.Writer, now time.Time) error {
+	r.rec.lock.Lock()
+	defer r.rec.lock.Unlock()
+
+	m := manifest{Recorded: now}
+	paths := make([]gcs.Path, 0, len(r.rec.objects))
+	for p := range r.rec.objects {
+		paths = append(paths, p)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].String() < paths[j].String()
+	})
+	var files []*object
+	for _, p := range paths {
+		obj := r.rec.objects[p]
+		if obj.buf != nil {
+			obj.File = fmt.Sprintf("objects/%d", len(files))
+			files = append(files, obj)
+		}
+		m.Objects = append(m.Objects, obj)
+	}
+	for _, l := range r.rec.listings {
+		m.Listings = append(m.Listings, *l)
+	}
+	sort.Slice(m.Listings, func(i, j int) bool {
+		return m.Listings[i].key() < m.Listings[j].key()
+	})
+
+	manifestBuf, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	write := func(name string, buf []byte) error {
+		hdr := tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(buf)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(&hdr); err != nil {
+			return fmt.Errorf("%s header: %w", name, err)
+		}
+		if _, err := tw.Write(buf); err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+		return nil
+	}
+	if err := write(manifestName, manifestBuf); err != nil {
+		return err
+	}
+	for _, obj := range files {
+		if err := write(obj.File, obj.buf); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("close tar: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close gzip: %w", err)
+	}
+	return nil
+}
+
+// Replayer is an offline client which serves the reads of a recording.
+//
+// Reads of unrecorded objects fail rather than reach storage. Writes are kept
+// in memory, where later reads see them, and are listed by Uploads.
+type Replayer struct {
+	lock     sync.Mutex
+	objects  map[gcs.Path]*object
+	listings map[string]listing
+	uploads  map[gcs.Path][]byte
+	recorded time.Time
+}
+
+var _ gcs.ConditionalClient = &Replayer{}
+
+// ErrNotRecorded means the recording has no record of the read.
+var ErrNotRecorded = errors.New("not recorded")
+
+// Load reads the tarball written by Recorder.Save.
+func Load(r io.Reader) (*Replayer, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+	files := map[string][]byte{}
+	var m *manifest
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tar: %w", err)
+		}
+		buf, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		if hdr.Name == manifestName {
+			m = &manifest{}
+			if err := json.Unmarshal(buf, m); err != nil {
+				return nil, fmt.Errorf("unmarshal manifest: %w", err)
+			}
+			continue
+		}
+		files[hdr.Name] = buf
+	}
+	if m == nil {
+		return nil, fmt.Errorf("missing %s", manifestName)
+	}
+
+	rep := Replayer{
+		objects:  make(map[gcs.Path]*object, len(m.Objects)),
+		listings: make(map[string]listing, len(m.Listings)),
+		uploads:  map[gcs.Path][]byte{},
+		recorded: m.Recorded,
+	}
+	for _, obj := range m.Objects {
+		p, err := gcs.NewPath(obj.Path)
+		if err != nil {
+			return nil, fmt.Errorf("bad path %q: %w", obj.Path, err)
+		}
+		if obj.File != "" {
+			buf, ok := files[obj.File]
+			if !ok {
+				return nil, fmt.Errorf("%s: missing %s", obj.Path, obj.File)
+			}
+			obj.buf = buf
+		}
+		rep.objects[*p] = obj
+	}
+	for _, l := range m.Listings {
+		rep.listings[l.key()] = l
+	}
+	return &rep, nil
+}
+
+// Recorded returns when the recording was saved.
+func (r *Replayer) Recorded() time.Time {
+	return r.recorded
+}
+
+// Uploads returns the content written to each path during the replay.
+func (r *Replayer) Uploads() map[gcs.Path][]byte {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	out := make(map[gcs.Path][]byte, len(r.uploads))
+	for p, buf := range r.uploads {
+		out[p] = buf
+	}
+	return out
+}
+
+// If ignores conditions.
+func (r *Replayer) If(_, _ *storage.Conditions) gcs.ConditionalClient {
+	return r
+}
+
+// Open returns the recorded content of the path.
+func (r *Replayer) Open(_ context.Context, path gcs.Path) (io.ReadCloser, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if buf, ok := r.uploads[path]; ok {
+		if buf == nil {
+			return nil, storage.ErrObjectNotExist
+		}
+		return ioutil.NopCloser(bytes.NewReader(buf)), nil
+	}
+	obj, ok := r.objects[path]
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("open %s: %w", path, ErrNotRecorded)
+	case obj.Missing:
+		return nil, storage.ErrObjectNotExist
+	case obj.buf == nil:
+		return nil, fmt.Errorf("open %s: content %w", path, ErrNotRecorded)
+	}
+	return ioutil.NopCloser(bytes.NewReader(obj.buf)), nil
+}
+
+// Stat returns the recorded attributes of the path.
+func (r *Replayer) Stat(_ context.Context, path gcs.Path) (*storage.ObjectAttrs, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if buf, ok := r.uploads[path]; ok {
+		if buf == nil {
+			return nil, storage.ErrObjectNotExist
+		}
+		return &storage.ObjectAttrs{Bucket: path.Bucket(), Name: path.Object(), Size: int64(len(buf))}, nil
+	}
+	obj, ok := r.objects[path]
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("stat %s: %w", path, ErrNotRecorded)
+	case obj.Missing:
+		return nil, storage.ErrObjectNotExist
+	case obj.Attrs == nil:
+		return nil, fmt.Errorf("stat %s: attributes %w", path, ErrNotRecorded)
+	}
+	attrs := *obj.Attrs
+	return &attrs, nil
+}
+
+// Objects replays the recorded listing.
+func (r *Replayer) Objects(_ context.Context, prefix gcs.Path, delimiter, start string) gcs.Iterator {
+	l, ok := r.listings[listingKey(prefix.String(), delimiter, start)]
+	if !ok {
+		return &replayIterator{err: fmt.Errorf("list %s: %w", prefix, ErrNotRecorded)}
+	}
+	return &replayIterator{attrs: l.Attrs}
+}
+
+type replayIterator struct {
+	attrs []*storage.ObjectAttrs
+	err   error
+}
+
+func (ri *replayIterator) Next() (*storage.ObjectAttrs, error) {
+	if ri.err != nil {
+		return nil, ri.err
+	}
+	if len(ri.attrs) == 0 {
+		return nil, iterator.Done
+	}
+	attrs := *ri.attrs[0]
+	ri.attrs = ri.attrs[1:]
+	return &attrs, nil
+}
+
+// Upload keeps the content in memory.
+func (r *Replayer) Upload(_ context.Context, path gcs.Path, buf []byte, _ bool, _ string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.uploads[path] = append([]byte{}, buf...)
+	return nil
+}
+
+// Copy copies recorded or uploaded content in memory.
+func (r *Replayer) Copy(ctx context.Context, from, to gcs.Path) error {
+	rc, err := r.Open(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	buf, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	return r.Upload(ctx, to, buf, false, "")
+}
+
+// Delete hides the path from later reads.
+func (r *Replayer) Delete(_ context.Context, path gcs.Path) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.uploads[path] = nil
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The TestGrid Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs"
+	"github.com/GoogleCloudPlatform/testgrid/util/gcs/fake"
+)
+
+func mustPath(t *testing.T, s string) gcs.Path {
+	t.Helper()
+	p, err := gcs.NewPath(s)
+	if err != nil {
+		t.Fatalf("gcs.NewPath(%q): %v", s, err)
+	}
+	return *p
+}
+
+func list(t *testing.T, it gcs.Iterator) ([]string, error) {
+	t.Helper()
+	var names []string
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return names, nil
+		}
+		if err != nil {
+			return names, err
+		}
+		names = append(names, attrs.Name)
+	}
+}
+
+func read(client gcs.Opener, path gcs.Path) (string, error) {
+	rc, err := client.Open(context.Background(), path)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	buf, err := ioutil.ReadAll(rc)
+	return string(buf), err
+}
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	config := mustPath(t, "gs://bucket/config")
+	build := mustPath(t, "gs://bucket/logs/job/1/finished.json")
+	missing := mustPath(t, "gs://bucket/logs/job/2/finished.json")
+	unread := mustPath(t, "gs://bucket/unread")
+	logs := mustPath(t, "gs://bucket/logs/job/")
+	grid := mustPath(t, "gs://bucket/grid/group")
+
+	live := fake.UploadClient{
+		Client: fake.Client{
+			Opener: fake.Opener{
+				config: {Data: "config"},
+				build:  {Data: `{"passed": true}`},
+				unread: {Data: "secret"},
+			},
+			Lister: fake.Lister{
+				logs: fake.Iterator{
+					Objects: []storage.ObjectAttrs{{Name: "logs/job/1/"}, {Name: "logs/job/2/"}},
+				},
+			},
+		},
+		Stater: fake.Stater{
+			config: {Attrs: storage.ObjectAttrs{Name: "config", Generation: 7}},
+		},
+		Uploader: fake.Uploader{},
+	}
+
+	rec := NewRecorder(live)
+	if got, err := read(rec, config); err != nil || got != "config" {
+		t.Fatalf("Recorder.Open() got %q, %v", got, err)
+	}
+	if _, err := read(rec.If(nil, nil), build); err != nil {
+		t.Fatalf("Recorder.If().Open() got unexpected error: %v", err)
+	}
+	if _, err := read(rec, missing); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Fatalf("Recorder.Open() of a missing object got %v, want ErrObjectNotExist", err)
+	}
+	if _, err := rec.Stat(ctx, config); err != nil {
+		t.Fatalf("Recorder.Stat() got unexpected error: %v", err)
+	}
+	names, err := list(t, rec.Objects(ctx, logs, "/", ""))
+	if err != nil {
+		t.Fatalf("Recorder.Objects() got unexpected error: %v", err)
+	}
+	if err := rec.Upload(ctx, grid, []byte("live grid"), false, ""); err != nil {
+		t.Fatalf("Recorder.Upload() got unexpected error: %v", err)
+	}
+	if _, ok := live.Uploader[grid]; !ok {
+		t.Errorf("Recorder.Upload() failed to write through")
+	}
+
+	var buf bytes.Buffer
+	now := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	if err := rec.Save(&buf, now); err != nil {
+		t.Fatalf("Save() got unexpected error: %v", err)
+	}
+	rep, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load() got unexpected error: %v", err)
+	}
+	if got := rep.Recorded(); !got.Equal(now) {
+		t.Errorf("Recorded() got %s, want %s", got, now)
+	}
+
+	if got, err := read(rep, config); err != nil || got != "config" {
+		t.Errorf("Replayer.Open(%s) got %q, %v, want config", config, got, err)
+	}
+	if got, err := read(rep, build); err != nil || got != `{"passed": true}` {
+		t.Errorf("Replayer.Open(%s) got %q, %v", build, got, err)
+	}
+	if _, err := read(rep, missing); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Replayer.Open(%s) got %v, want ErrObjectNotExist", missing, err)
+	}
+	if _, err := read(rep, unread); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Replayer.Open(%s) got %v, want ErrNotRecorded", unread, err)
+	}
+	attrs, err := rep.Stat(ctx, config)
+	if err != nil || attrs.Generation != 7 {
+		t.Errorf("Replayer.Stat(%s) got %v, %v, want generation 7", config, attrs, err)
+	}
+	if _, err := rep.Stat(ctx, build); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Replayer.Stat(%s) got %v, want ErrNotRecorded", build, err)
+	}
+	replayed, err := list(t, rep.Objects(ctx, logs, "/", ""))
+	if err != nil {
+		t.Fatalf("Replayer.Objects() got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(names, replayed); diff != "" {
+		t.Errorf("Replayer.Objects() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if _, err := list(t, rep.Objects(ctx, logs, "", "")); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Replayer.Objects() of an unrecorded listing got %v, want ErrNotRecorded", err)
+	}
+
+	if _, err := read(rep, grid); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Replayer.Open(%s) got %v, want ErrNotRecorded before the upload", grid, err)
+	}
+	if err := rep.Upload(ctx, grid, []byte("replayed grid"), false, ""); err != nil {
+		t.Fatalf("Replayer.Upload() got unexpected error: %v", err)
+	}
+	if got, err := read(rep, grid); err != nil || got != "replayed grid" {
+		t.Errorf("Replayer.Open(%s) got %q, %v after the upload", grid, got, err)
+	}
+	want := map[gcs.Path][]byte{grid: []byte("replayed grid")}
+	if diff := cmp.Diff(want, rep.Uploads(), cmp.AllowUnexported(gcs.Path{})); diff != "" {
+		t.Errorf("Uploads() got unexpected diff (-want +got):\n%s", diff)
+	}
+	if err := rep.Delete(ctx, config); err != nil {
+		t.Fatalf("Replayer.Delete() got unexpected error: %v", err)
+	}
+	if _, err := read(rep, config); !errors.Is(err, storage.ErrObjectNotExist) {
+		t.Errorf("Replayer.Open(%s) got %v, want ErrObjectNotExist after deleting it", config, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := Load(bytes.NewBufferString("not a tarball")); err == nil {
+		t.Error("Load() failed to return an error")
+	}
+}
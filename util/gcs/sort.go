@@ -106,7 +106,12 @@ func Touch(ctx context.Context, client ConditionalClient, path Path, generation
 //   gs://a/5
 //   gs://b/1
 func Sort(builds []Build) {
+	SortFunc(builds, sortorder.NaturalLess)
+}
+
+// SortFunc sorts the builds by monotonically decreasing base name, as ordered by less.
+func SortFunc(builds []Build, less func(a, b string) bool) {
 	sort.SliceStable(builds, func(i, j int) bool { // greater
-		return !sortorder.NaturalLess(builds[i].baseName, builds[j].baseName) && builds[i].baseName != builds[j].baseName
+		return less(builds[j].baseName, builds[i].baseName)
 	})
 }
@@ -121,3 +121,20 @@ func TestSort(t *testing.T) {
 		})
 	}
 }
+
+func TestSortFunc(t *testing.T) {
+	builds := []Build{
+		{baseName: "20210102-934"},
+		{baseName: "20210102-1234"},
+		{baseName: "20210101-2359"},
+	}
+	want := []Build{
+		{baseName: "20210102-934"},
+		{baseName: "20210102-1234"},
+		{baseName: "20210101-2359"},
+	}
+	SortFunc(builds, func(a, b string) bool { return a < b })
+	if diff := cmp.Diff(want, builds, cmp.AllowUnexported(Build{}, Path{})); diff != "" {
+		t.Errorf("SortFunc() got unexpected diff (-want +got):\n%s", diff)
+	}
+}